@@ -47,6 +47,9 @@ type Client interface {
 	LoadSnapshotChunkAsync(context.Context, types.RequestLoadSnapshotChunk) (*ReqRes, error)
 	ApplySnapshotChunkAsync(context.Context, types.RequestApplySnapshotChunk) (*ReqRes, error)
 	PreprocessTxsAsync(context.Context, types.RequestPreprocessTxs) (*ReqRes, error)
+	FinalizeBlockAsync(context.Context, types.RequestFinalizeBlock) (*ReqRes, error)
+	ProcessProposalAsync(context.Context, types.RequestProcessProposal) (*ReqRes, error)
+	RollbackAsync(context.Context, types.RequestRollback) (*ReqRes, error)
 
 	// Synchronous requests
 	FlushSync(context.Context) error
@@ -64,18 +67,24 @@ type Client interface {
 	LoadSnapshotChunkSync(context.Context, types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunkSync(context.Context, types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error)
 	PreprocessTxsSync(context.Context, types.RequestPreprocessTxs) (*types.ResponsePreprocessTxs, error)
+	FinalizeBlockSync(context.Context, types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error)
+	ProcessProposalSync(context.Context, types.RequestProcessProposal) (*types.ResponseProcessProposal, error)
+	RollbackSync(context.Context, types.RequestRollback) (*types.ResponseRollback, error)
 }
 
 //----------------------------------------
 
 // NewClient returns a new ABCI client of the specified transport type.
-// It returns an error if the transport is not "socket" or "grpc"
-func NewClient(addr, transport string, mustConnect bool) (client Client, err error) {
+// It returns an error if the transport is not "socket", "socket-mux" or "grpc".
+// The options only apply to the "grpc" transport.
+func NewClient(addr, transport string, mustConnect bool, options ...GRPCClientOption) (client Client, err error) {
 	switch transport {
 	case "socket":
 		client = NewSocketClient(addr, mustConnect)
+	case "socket-mux":
+		client = newSocketMux(addr, mustConnect).newClient()
 	case "grpc":
-		client = NewGRPCClient(addr, mustConnect)
+		client = NewGRPCClient(addr, mustConnect, options...)
 	default:
 		err = fmt.Errorf("unknown abci transport %s", transport)
 	}
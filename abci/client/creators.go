@@ -22,10 +22,19 @@ func NewLocalCreator(app types.Application) Creator {
 
 // NewRemoteCreator returns a Creator for the given address (e.g.
 // "192.168.0.1") and transport (e.g. "tcp"). Set mustConnect to true if you
-// want the client to connect before reporting success.
-func NewRemoteCreator(addr, transport string, mustConnect bool) Creator {
+// want the client to connect before reporting success. The clients of the
+// "socket-mux" transport share a single connection. The options only apply to
+// the "grpc" transport.
+func NewRemoteCreator(addr, transport string, mustConnect bool, options ...GRPCClientOption) Creator {
+	if transport == "socket-mux" {
+		mux := newSocketMux(addr, mustConnect)
+		return func() (Client, error) {
+			return mux.newClient(), nil
+		}
+	}
+
 	return func() (Client, error) {
-		remoteApp, err := NewClient(addr, transport, mustConnect)
+		remoteApp, err := NewClient(addr, transport, mustConnect, options...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to connect to proxy: %w", err)
 		}
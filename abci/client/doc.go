@@ -1,9 +1,10 @@
 // Package abciclient provides an ABCI implementation in Go.
 //
-// There are 3 clients available:
+// There are 4 clients available:
 //		1. socket (unix or TCP)
-//		2. local (in memory)
-//		3. gRPC
+//		2. socket-mux (unix or TCP)
+//		3. local (in memory)
+//		4. gRPC
 //
 // ## Socket client
 //
@@ -13,6 +14,14 @@
 // sync: the client blocks on 1) enqueuing the Sync request 2) enqueuing the
 // Flush requests 3) waiting for the Flush response
 //
+// ## Socket-mux client
+//
+// same as the socket client, but the clients created by a Creator share a
+// single connection: each request is tagged with the stream of its client and
+// an ID, and the server answers the streams in turns, so a stream with many
+// requests doesn't delay the others. The socket server accepts both socket and
+// socket-mux clients.
+//
 // ## Local client
 //
 // async: global mutex is locked during each call (meaning it's not really async!)
@@ -7,7 +7,10 @@ import (
 	"sync"
 	"time"
 
+	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/keepalive"
 
 	"github.com/tendermint/tendermint/abci/types"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
@@ -20,6 +23,14 @@ type grpcClient struct {
 	service.BaseService
 	mustConnect bool
 
+	callTimeout time.Duration
+	maxRetries  uint
+	keepalive   keepalive.ClientParameters
+	// the options of the calls which change the state of the application, and
+	// of the ones which don't and are retried
+	callOptions      []grpc.CallOption
+	retryCallOptions []grpc.CallOption
+
 	client   types.ABCIApplicationClient
 	conn     *grpc.ClientConn
 	chReqRes chan *ReqRes // dispatches "async" responses to callbacks *in order*, needed by mempool
@@ -32,6 +43,32 @@ type grpcClient struct {
 
 var _ Client = (*grpcClient)(nil)
 
+// GRPCClientOption sets an optional parameter on the gRPC client.
+type GRPCClientOption func(*grpcClient)
+
+// GRPCCallTimeout sets the deadline of each attempt of a call, 0 for none.
+func GRPCCallTimeout(timeout time.Duration) GRPCClientOption {
+	return func(cli *grpcClient) {
+		cli.callTimeout = timeout
+	}
+}
+
+// GRPCMaxRetries sets the number of retries of the calls which don't change
+// the state of the application, when they fail with a transient error.
+func GRPCMaxRetries(retries uint) GRPCClientOption {
+	return func(cli *grpcClient) {
+		cli.maxRetries = retries
+	}
+}
+
+// GRPCKeepalive pings the application every interval when the connection is
+// idle, and closes the connection if a ping isn't answered within timeout.
+func GRPCKeepalive(interval, timeout time.Duration) GRPCClientOption {
+	return func(cli *grpcClient) {
+		cli.keepalive = keepalive.ClientParameters{Time: interval, Timeout: timeout}
+	}
+}
+
 // NewGRPCClient creates a gRPC client, which will connect to addr upon the
 // start. Note Client#Start returns an error if connection is unsuccessful and
 // mustConnect is true.
@@ -42,7 +79,7 @@ var _ Client = (*grpcClient)(nil)
 // which is expensive, but easy - if you want something better, use the socket
 // protocol! maybe one day, if people really want it, we use grpc streams, but
 // hopefully not :D
-func NewGRPCClient(addr string, mustConnect bool) Client {
+func NewGRPCClient(addr string, mustConnect bool, options ...GRPCClientOption) Client {
 	cli := &grpcClient{
 		addr:        addr,
 		mustConnect: mustConnect,
@@ -54,6 +91,19 @@ func NewGRPCClient(addr string, mustConnect bool) Client {
 		// gRPC calls while processing a slow callback at the channel head.
 		chReqRes: make(chan *ReqRes, 64),
 	}
+	for _, option := range options {
+		option(cli)
+	}
+	cli.callOptions = []grpc.CallOption{
+		grpc.WaitForReady(true),
+		grpc_retry.WithMax(1),
+		grpc_retry.WithPerRetryTimeout(cli.callTimeout),
+	}
+	cli.retryCallOptions = []grpc.CallOption{
+		grpc.WaitForReady(true),
+		grpc_retry.WithMax(cli.maxRetries + 1),
+		grpc_retry.WithPerRetryTimeout(cli.callTimeout),
+	}
 	cli.BaseService = *service.NewBaseService(nil, "grpcClient", cli)
 	return cli
 }
@@ -62,6 +112,41 @@ func dialerFunc(ctx context.Context, addr string) (net.Conn, error) {
 	return tmnet.Connect(addr)
 }
 
+// dialOptions returns the options of the connection: the keepalive pings, and
+// the deadline and retries of the calls, with an exponential backoff.
+func (cli *grpcClient) dialOptions() []grpc.DialOption {
+	dialOpts := []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(dialerFunc),
+		grpc.WithUnaryInterceptor(grpc_retry.UnaryClientInterceptor(
+			grpc_retry.WithBackoff(grpc_retry.BackoffExponentialWithJitter(100*time.Millisecond, 0.1)),
+		)),
+	}
+	if cli.keepalive.Time > 0 {
+		// the pings are sent even without calls in flight, to detect a dead
+		// connection before the next call
+		cli.keepalive.PermitWithoutStream = true
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(cli.keepalive))
+	}
+	return dialOpts
+}
+
+// watchConn stops the client once the connection to the application is dead:
+// lost, as when a keepalive ping isn't answered, and failing to be established
+// again. A call failing with a deadline doesn't stop the client, as a slow
+// application isn't an unreachable one.
+func (cli *grpcClient) watchConn(conn *grpc.ClientConn) {
+	state := conn.GetState()
+	for state != connectivity.Shutdown {
+		if state == connectivity.TransientFailure {
+			cli.StopForError(fmt.Errorf("lost the connection to the application at %v", cli.addr))
+			return
+		}
+		conn.WaitForStateChange(context.Background(), state)
+		state = conn.GetState()
+	}
+}
+
 func (cli *grpcClient) OnStart() error {
 	// This processes asynchronous request/response messages and dispatches
 	// them to callbacks.
@@ -95,7 +180,7 @@ func (cli *grpcClient) OnStart() error {
 
 RETRY_LOOP:
 	for {
-		conn, err := grpc.Dial(cli.addr, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
+		conn, err := grpc.Dial(cli.addr, cli.dialOptions()...)
 		if err != nil {
 			if cli.mustConnect {
 				return err
@@ -120,6 +205,7 @@ RETRY_LOOP:
 		}
 
 		cli.client = client
+		go cli.watchConn(conn)
 		return nil
 	}
 }
@@ -167,7 +253,7 @@ func (cli *grpcClient) SetResponseCallback(resCb Callback) {
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) EchoAsync(ctx context.Context, msg string) (*ReqRes, error) {
 	req := types.ToRequestEcho(msg)
-	res, err := cli.client.Echo(ctx, req.GetEcho(), grpc.WaitForReady(true))
+	res, err := cli.client.Echo(ctx, req.GetEcho(), cli.retryCallOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +263,7 @@ func (cli *grpcClient) EchoAsync(ctx context.Context, msg string) (*ReqRes, erro
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) FlushAsync(ctx context.Context) (*ReqRes, error) {
 	req := types.ToRequestFlush()
-	res, err := cli.client.Flush(ctx, req.GetFlush(), grpc.WaitForReady(true))
+	res, err := cli.client.Flush(ctx, req.GetFlush(), cli.retryCallOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +273,7 @@ func (cli *grpcClient) FlushAsync(ctx context.Context) (*ReqRes, error) {
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) InfoAsync(ctx context.Context, params types.RequestInfo) (*ReqRes, error) {
 	req := types.ToRequestInfo(params)
-	res, err := cli.client.Info(ctx, req.GetInfo(), grpc.WaitForReady(true))
+	res, err := cli.client.Info(ctx, req.GetInfo(), cli.retryCallOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -197,7 +283,7 @@ func (cli *grpcClient) InfoAsync(ctx context.Context, params types.RequestInfo)
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) DeliverTxAsync(ctx context.Context, params types.RequestDeliverTx) (*ReqRes, error) {
 	req := types.ToRequestDeliverTx(params)
-	res, err := cli.client.DeliverTx(ctx, req.GetDeliverTx(), grpc.WaitForReady(true))
+	res, err := cli.client.DeliverTx(ctx, req.GetDeliverTx(), cli.callOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -207,7 +293,7 @@ func (cli *grpcClient) DeliverTxAsync(ctx context.Context, params types.RequestD
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) CheckTxAsync(ctx context.Context, params types.RequestCheckTx) (*ReqRes, error) {
 	req := types.ToRequestCheckTx(params)
-	res, err := cli.client.CheckTx(ctx, req.GetCheckTx(), grpc.WaitForReady(true))
+	res, err := cli.client.CheckTx(ctx, req.GetCheckTx(), cli.callOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -217,7 +303,7 @@ func (cli *grpcClient) CheckTxAsync(ctx context.Context, params types.RequestChe
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) QueryAsync(ctx context.Context, params types.RequestQuery) (*ReqRes, error) {
 	req := types.ToRequestQuery(params)
-	res, err := cli.client.Query(ctx, req.GetQuery(), grpc.WaitForReady(true))
+	res, err := cli.client.Query(ctx, req.GetQuery(), cli.retryCallOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -227,7 +313,7 @@ func (cli *grpcClient) QueryAsync(ctx context.Context, params types.RequestQuery
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) CommitAsync(ctx context.Context) (*ReqRes, error) {
 	req := types.ToRequestCommit()
-	res, err := cli.client.Commit(ctx, req.GetCommit(), grpc.WaitForReady(true))
+	res, err := cli.client.Commit(ctx, req.GetCommit(), cli.callOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -237,7 +323,7 @@ func (cli *grpcClient) CommitAsync(ctx context.Context) (*ReqRes, error) {
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) InitChainAsync(ctx context.Context, params types.RequestInitChain) (*ReqRes, error) {
 	req := types.ToRequestInitChain(params)
-	res, err := cli.client.InitChain(ctx, req.GetInitChain(), grpc.WaitForReady(true))
+	res, err := cli.client.InitChain(ctx, req.GetInitChain(), cli.callOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -247,7 +333,7 @@ func (cli *grpcClient) InitChainAsync(ctx context.Context, params types.RequestI
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) BeginBlockAsync(ctx context.Context, params types.RequestBeginBlock) (*ReqRes, error) {
 	req := types.ToRequestBeginBlock(params)
-	res, err := cli.client.BeginBlock(ctx, req.GetBeginBlock(), grpc.WaitForReady(true))
+	res, err := cli.client.BeginBlock(ctx, req.GetBeginBlock(), cli.callOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -257,7 +343,7 @@ func (cli *grpcClient) BeginBlockAsync(ctx context.Context, params types.Request
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) EndBlockAsync(ctx context.Context, params types.RequestEndBlock) (*ReqRes, error) {
 	req := types.ToRequestEndBlock(params)
-	res, err := cli.client.EndBlock(ctx, req.GetEndBlock(), grpc.WaitForReady(true))
+	res, err := cli.client.EndBlock(ctx, req.GetEndBlock(), cli.callOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -267,7 +353,7 @@ func (cli *grpcClient) EndBlockAsync(ctx context.Context, params types.RequestEn
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) ListSnapshotsAsync(ctx context.Context, params types.RequestListSnapshots) (*ReqRes, error) {
 	req := types.ToRequestListSnapshots(params)
-	res, err := cli.client.ListSnapshots(ctx, req.GetListSnapshots(), grpc.WaitForReady(true))
+	res, err := cli.client.ListSnapshots(ctx, req.GetListSnapshots(), cli.retryCallOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -277,7 +363,7 @@ func (cli *grpcClient) ListSnapshotsAsync(ctx context.Context, params types.Requ
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) OfferSnapshotAsync(ctx context.Context, params types.RequestOfferSnapshot) (*ReqRes, error) {
 	req := types.ToRequestOfferSnapshot(params)
-	res, err := cli.client.OfferSnapshot(ctx, req.GetOfferSnapshot(), grpc.WaitForReady(true))
+	res, err := cli.client.OfferSnapshot(ctx, req.GetOfferSnapshot(), cli.callOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -290,7 +376,7 @@ func (cli *grpcClient) LoadSnapshotChunkAsync(
 	params types.RequestLoadSnapshotChunk,
 ) (*ReqRes, error) {
 	req := types.ToRequestLoadSnapshotChunk(params)
-	res, err := cli.client.LoadSnapshotChunk(ctx, req.GetLoadSnapshotChunk(), grpc.WaitForReady(true))
+	res, err := cli.client.LoadSnapshotChunk(ctx, req.GetLoadSnapshotChunk(), cli.retryCallOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -303,7 +389,7 @@ func (cli *grpcClient) ApplySnapshotChunkAsync(
 	params types.RequestApplySnapshotChunk,
 ) (*ReqRes, error) {
 	req := types.ToRequestApplySnapshotChunk(params)
-	res, err := cli.client.ApplySnapshotChunk(ctx, req.GetApplySnapshotChunk(), grpc.WaitForReady(true))
+	res, err := cli.client.ApplySnapshotChunk(ctx, req.GetApplySnapshotChunk(), cli.callOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -316,13 +402,54 @@ func (cli *grpcClient) ApplySnapshotChunkAsync(
 
 func (cli *grpcClient) PreprocessTxsAsync(ctx context.Context, params types.RequestPreprocessTxs) (*ReqRes, error) {
 	req := types.ToRequestPreprocessTxs(params)
-	res, err := cli.client.PreprocessTxs(context.Background(), req.GetPreprocessTxs(), grpc.WaitForReady(true))
+	res, err := cli.client.PreprocessTxs(ctx, req.GetPreprocessTxs(), cli.callOptions...)
 	if err != nil {
-		cli.StopForError(err)
+		return nil, err
 	}
 	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_PreprocessTxs{PreprocessTxs: res}})
 }
 
+func (cli *grpcClient) FinalizeBlockAsync(ctx context.Context, params types.RequestFinalizeBlock) (*ReqRes, error) {
+	req := types.ToRequestFinalizeBlock(params)
+	res, err := cli.client.FinalizeBlock(ctx, req.GetFinalizeBlock(), cli.callOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_FinalizeBlock{FinalizeBlock: res}})
+}
+
+func (cli *grpcClient) ProcessProposalAsync(
+	ctx context.Context,
+	params types.RequestProcessProposal,
+) (*ReqRes, error) {
+	req := types.ToRequestProcessProposal(params)
+	res, err := cli.client.ProcessProposal(ctx, req.GetProcessProposal(), cli.callOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return cli.finishAsyncCall(
+		ctx,
+		req,
+		&types.Response{Value: &types.Response_ProcessProposal{ProcessProposal: res}},
+	)
+}
+
+func (cli *grpcClient) RollbackAsync(
+	ctx context.Context,
+	params types.RequestRollback,
+) (*ReqRes, error) {
+	req := types.ToRequestRollback(params)
+	res, err := cli.client.Rollback(ctx, req.GetRollback(), cli.callOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return cli.finishAsyncCall(
+		ctx,
+		req,
+		&types.Response{Value: &types.Response_Rollback{Rollback: res}},
+	)
+}
+
 // finishAsyncCall creates a ReqRes for an async call, and immediately populates it
 // with the response. We don't complete it until it's been ordered via the channel.
 func (cli *grpcClient) finishAsyncCall(ctx context.Context, req *types.Request, res *types.Response) (*ReqRes, error) {
@@ -525,3 +652,39 @@ func (cli *grpcClient) PreprocessTxsSync(
 	}
 	return reqres.Response.GetPreprocessTxs(), cli.Error()
 }
+
+func (cli *grpcClient) FinalizeBlockSync(
+	ctx context.Context,
+	params types.RequestFinalizeBlock,
+) (*types.ResponseFinalizeBlock, error) {
+
+	reqres, err := cli.FinalizeBlockAsync(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetFinalizeBlock(), cli.Error()
+}
+
+func (cli *grpcClient) ProcessProposalSync(
+	ctx context.Context,
+	params types.RequestProcessProposal,
+) (*types.ResponseProcessProposal, error) {
+
+	reqres, err := cli.ProcessProposalAsync(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetProcessProposal(), cli.Error()
+}
+
+func (cli *grpcClient) RollbackSync(
+	ctx context.Context,
+	params types.RequestRollback,
+) (*types.ResponseRollback, error) {
+
+	reqres, err := cli.RollbackAsync(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetRollback(), cli.Error()
+}
@@ -0,0 +1,89 @@
+package abciclient_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/server"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestGRPCCallTimeout(t *testing.T) {
+	app := &slowInfoApp{}
+	// some port between 20k and 30k
+	addr := fmt.Sprintf("localhost:%d", 20000+rand.Int31()%10000)
+
+	s, err := server.NewServer(addr, "grpc", app)
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+
+	c := abciclient.NewGRPCClient(addr, true,
+		abciclient.GRPCCallTimeout(50*time.Millisecond),
+		abciclient.GRPCMaxRetries(2),
+		abciclient.GRPCKeepalive(time.Second, time.Second))
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+
+	// the calls which don't change the state of the app are retried
+	_, err = c.InfoSync(ctx, types.RequestInfo{})
+	require.Error(t, err)
+	require.EqualValues(t, 3, atomic.LoadInt32(&app.infos))
+
+	// a slow application isn't an unreachable one
+	require.True(t, c.IsRunning())
+	require.NoError(t, c.Error())
+}
+
+func TestGRPCConnectionLost(t *testing.T) {
+	// some port between 20k and 30k
+	addr := fmt.Sprintf("localhost:%d", 20000+rand.Int31()%10000)
+
+	s, err := server.NewServer(addr, "grpc", types.NewBaseApplication())
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+
+	c := abciclient.NewGRPCClient(addr, true,
+		abciclient.GRPCCallTimeout(50*time.Millisecond),
+		abciclient.GRPCKeepalive(100*time.Millisecond, 100*time.Millisecond))
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		if c.IsRunning() {
+			if err := c.Stop(); err != nil {
+				t.Log(err)
+			}
+		}
+	})
+	_, err = c.InfoSync(ctx, types.RequestInfo{})
+	require.NoError(t, err)
+
+	// the client stops once the connection can't be established again
+	require.NoError(t, s.Stop())
+	require.Eventually(t, func() bool { return !c.IsRunning() }, 5*time.Second, 10*time.Millisecond)
+	require.Error(t, c.Error())
+}
+
+type slowInfoApp struct {
+	types.BaseApplication
+	infos int32
+}
+
+func (app *slowInfoApp) Info(req types.RequestInfo) types.ResponseInfo {
+	atomic.AddInt32(&app.infos, 1)
+	time.Sleep(200 * time.Millisecond)
+	return types.ResponseInfo{}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/tendermint/tendermint/abci/types"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/libs/log"
 	tmnet "github.com/tendermint/tendermint/libs/net"
 	"github.com/tendermint/tendermint/libs/service"
 )
@@ -37,6 +38,11 @@ type socketClient struct {
 	mustConnect bool
 	conn        net.Conn
 
+	// mux is the connection shared with the other streams of a socket-mux
+	// client, if any.
+	mux    *socketMux
+	stream uint64
+
 	reqQueue chan *reqResWithContext
 
 	mtx     tmsync.RWMutex
@@ -66,34 +72,60 @@ func NewSocketClient(addr string, mustConnect bool) Client {
 // OnStart implements Service by connecting to the server and spawning reading
 // and writing goroutines.
 func (cli *socketClient) OnStart() error {
-	var (
-		err  error
-		conn net.Conn
-	)
+	if cli.mux != nil {
+		if err := cli.mux.attach(cli); err != nil {
+			return err
+		}
+		go cli.sendRequestsRoutine(func(req *types.Request) error {
+			return cli.mux.writeRequest(cli, req)
+		})
+		return nil
+	}
+
+	conn, err := connect(cli.addr, cli.mustConnect, cli.Logger)
+	if err != nil {
+		return err
+	}
+	cli.conn = conn
 
+	bw := bufio.NewWriter(conn)
+	go cli.sendRequestsRoutine(func(req *types.Request) error {
+		if err := types.WriteMessage(req, bw); err != nil {
+			return fmt.Errorf("write to buffer: %w", err)
+		}
+		if err := bw.Flush(); err != nil {
+			return fmt.Errorf("flush buffer: %w", err)
+		}
+		return nil
+	})
+	go cli.recvResponseRoutine(conn)
+
+	return nil
+}
+
+// connect connects to addr, retrying until it succeeds unless mustConnect is
+// true.
+func connect(addr string, mustConnect bool, logger log.Logger) (net.Conn, error) {
 	for {
-		conn, err = tmnet.Connect(cli.addr)
+		conn, err := tmnet.Connect(addr)
 		if err != nil {
-			if cli.mustConnect {
-				return err
+			if mustConnect {
+				return nil, err
 			}
-			cli.Logger.Error(fmt.Sprintf("abci.socketClient failed to connect to %v.  Retrying after %vs...",
-				cli.addr, dialRetryIntervalSeconds), "err", err)
+			logger.Error(fmt.Sprintf("abci.socketClient failed to connect to %v.  Retrying after %vs...",
+				addr, dialRetryIntervalSeconds), "err", err)
 			time.Sleep(time.Second * dialRetryIntervalSeconds)
 			continue
 		}
-		cli.conn = conn
-
-		go cli.sendRequestsRoutine(conn)
-		go cli.recvResponseRoutine(conn)
-
-		return nil
+		return conn, nil
 	}
 }
 
 // OnStop implements Service by closing connection and flushing all queues.
 func (cli *socketClient) OnStop() {
-	if cli.conn != nil {
+	if cli.mux != nil {
+		cli.mux.detach(cli)
+	} else if cli.conn != nil {
 		cli.conn.Close()
 	}
 
@@ -119,8 +151,7 @@ func (cli *socketClient) SetResponseCallback(resCb Callback) {
 
 //----------------------------------------
 
-func (cli *socketClient) sendRequestsRoutine(conn io.Writer) {
-	bw := bufio.NewWriter(conn)
+func (cli *socketClient) sendRequestsRoutine(write func(*types.Request) error) {
 	for {
 		select {
 		case reqres := <-cli.reqQueue:
@@ -130,12 +161,8 @@ func (cli *socketClient) sendRequestsRoutine(conn io.Writer) {
 			}
 			cli.willSendReq(reqres.R)
 
-			if err := types.WriteMessage(reqres.R.Request, bw); err != nil {
-				cli.stopForError(fmt.Errorf("write to buffer: %w", err))
-				return
-			}
-			if err := bw.Flush(); err != nil {
-				cli.stopForError(fmt.Errorf("flush buffer: %w", err))
+			if err := write(reqres.R.Request); err != nil {
+				cli.stopForError(err)
 				return
 			}
 
@@ -157,21 +184,25 @@ func (cli *socketClient) recvResponseRoutine(conn io.Reader) {
 
 		// cli.Logger.Debug("Received response", "responseType", reflect.TypeOf(res), "response", res)
 
-		switch r := res.Value.(type) {
-		case *types.Response_Exception: // app responded with error
-			// XXX After setting cli.err, release waiters (e.g. reqres.Done())
-			cli.stopForError(errors.New(r.Exception.Error))
+		if err := cli.recvResponse(res); err != nil {
+			cli.stopForError(err)
 			return
-		default:
-			err := cli.didRecvResponse(res)
-			if err != nil {
-				cli.stopForError(err)
-				return
-			}
 		}
 	}
 }
 
+// recvResponse releases the waiters of the request of res, or returns an
+// error if the app responded with an error or res isn't the expected response.
+func (cli *socketClient) recvResponse(res *types.Response) error {
+	switch r := res.Value.(type) {
+	case *types.Response_Exception: // app responded with error
+		// XXX After setting cli.err, release waiters (e.g. reqres.Done())
+		return errors.New(r.Exception.Error)
+	default:
+		return cli.didRecvResponse(res)
+	}
+}
+
 func (cli *socketClient) willSendReq(reqres *ReqRes) {
 	cli.mtx.Lock()
 	defer cli.mtx.Unlock()
@@ -280,6 +311,24 @@ func (cli *socketClient) PreprocessTxsAsync(ctx context.Context, req types.Reque
 	return cli.queueRequestAsync(ctx, types.ToRequestPreprocessTxs(req))
 }
 
+func (cli *socketClient) FinalizeBlockAsync(ctx context.Context, req types.RequestFinalizeBlock) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestFinalizeBlock(req))
+}
+
+func (cli *socketClient) ProcessProposalAsync(
+	ctx context.Context,
+	req types.RequestProcessProposal,
+) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestProcessProposal(req))
+}
+
+func (cli *socketClient) RollbackAsync(
+	ctx context.Context,
+	req types.RequestRollback,
+) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestRollback(req))
+}
+
 //----------------------------------------
 
 func (cli *socketClient) FlushSync(ctx context.Context) error {
@@ -461,6 +510,39 @@ func (cli *socketClient) PreprocessTxsSync(
 	return reqres.Response.GetPreprocessTxs(), nil
 }
 
+func (cli *socketClient) FinalizeBlockSync(
+	ctx context.Context,
+	req types.RequestFinalizeBlock,
+) (*types.ResponseFinalizeBlock, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestFinalizeBlock(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetFinalizeBlock(), nil
+}
+
+func (cli *socketClient) ProcessProposalSync(
+	ctx context.Context,
+	req types.RequestProcessProposal,
+) (*types.ResponseProcessProposal, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestProcessProposal(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetProcessProposal(), nil
+}
+
+func (cli *socketClient) RollbackSync(
+	ctx context.Context,
+	req types.RequestRollback,
+) (*types.ResponseRollback, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestRollback(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetRollback(), nil
+}
+
 //----------------------------------------
 
 // queueRequest enqueues req onto the queue. If the queue is full, it ether
@@ -586,6 +668,12 @@ func resMatchesReq(req *types.Request, res *types.Response) (ok bool) {
 		_, ok = res.Value.(*types.Response_OfferSnapshot)
 	case *types.Request_PreprocessTxs:
 		_, ok = res.Value.(*types.Response_PreprocessTxs)
+	case *types.Request_FinalizeBlock:
+		_, ok = res.Value.(*types.Response_FinalizeBlock)
+	case *types.Request_ProcessProposal:
+		_, ok = res.Value.(*types.Response_ProcessProposal)
+	case *types.Request_Rollback:
+		_, ok = res.Value.(*types.Response_Rollback)
 	}
 	return ok
 }
@@ -0,0 +1,174 @@
+package abciclient
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/tendermint/tendermint/abci/types"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+)
+
+// socketMux is a connection to a socket server shared by the socket clients
+// of its streams. Each request is tagged with its stream and an ID unique to
+// the connection, which the response echoes. The server answers the requests
+// of a stream in order, but the streams take turns, so the responses of the
+// streams are interleaved.
+type socketMux struct {
+	addr        string
+	mustConnect bool
+
+	mtx        tmsync.Mutex
+	conn       net.Conn
+	bw         *bufio.Writer
+	clients    map[*socketClient]struct{}
+	nextStream uint64
+	nextID     uint64
+	// pending are the clients waiting for the response to a request, by
+	// request ID. The responses to a stopped client are ignored.
+	pending map[uint64]*socketClient
+}
+
+func newSocketMux(addr string, mustConnect bool) *socketMux {
+	return &socketMux{
+		addr:        addr,
+		mustConnect: mustConnect,
+		clients:     make(map[*socketClient]struct{}),
+		pending:     make(map[uint64]*socketClient),
+	}
+}
+
+// newClient returns a socket client of a new stream of the connection.
+func (mux *socketMux) newClient() Client {
+	mux.mtx.Lock()
+	defer mux.mtx.Unlock()
+
+	cli := NewSocketClient(mux.addr, mux.mustConnect).(*socketClient)
+	cli.mux = mux
+	cli.stream = mux.nextStream
+	mux.nextStream++
+	return cli
+}
+
+// attach connects to the server if the client is the first one to start, and
+// routes the responses to the requests of cli to it.
+func (mux *socketMux) attach(cli *socketClient) error {
+	mux.mtx.Lock()
+	defer mux.mtx.Unlock()
+
+	if mux.conn == nil {
+		conn, err := connect(mux.addr, mux.mustConnect, cli.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := conn.Write([]byte(types.MuxPreamble)); err != nil {
+			conn.Close()
+			return fmt.Errorf("write preamble: %w", err)
+		}
+		mux.conn = conn
+		mux.bw = bufio.NewWriter(conn)
+
+		go mux.recvResponsesRoutine(conn)
+	}
+
+	mux.clients[cli] = struct{}{}
+	return nil
+}
+
+// detach ignores the responses to the requests of cli, and closes the
+// connection once there are no clients left.
+func (mux *socketMux) detach(cli *socketClient) {
+	mux.mtx.Lock()
+	defer mux.mtx.Unlock()
+
+	if _, ok := mux.clients[cli]; !ok {
+		return
+	}
+	delete(mux.clients, cli)
+	for id, pendingCli := range mux.pending {
+		if pendingCli == cli {
+			mux.pending[id] = nil
+		}
+	}
+
+	if len(mux.clients) == 0 {
+		mux.conn.Close()
+		mux.conn = nil
+		mux.pending = make(map[uint64]*socketClient)
+	}
+}
+
+// writeRequest writes the request of cli to the connection.
+func (mux *socketMux) writeRequest(cli *socketClient, req *types.Request) error {
+	mux.mtx.Lock()
+	defer mux.mtx.Unlock()
+
+	if mux.conn == nil {
+		return errors.New("connection closed")
+	}
+
+	id := mux.nextID
+	mux.nextID++
+	mux.pending[id] = cli
+
+	if err := types.WriteMuxRequest(id, cli.stream, req, mux.bw); err != nil {
+		mux.conn.Close()
+		return fmt.Errorf("write to buffer: %w", err)
+	}
+	if err := mux.bw.Flush(); err != nil {
+		mux.conn.Close()
+		return fmt.Errorf("flush buffer: %w", err)
+	}
+	return nil
+}
+
+// recvResponsesRoutine reads the responses from conn and passes them to the
+// clients of their requests. It stops all the clients if conn fails.
+func (mux *socketMux) recvResponsesRoutine(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	for {
+		var res = &types.Response{}
+		id, err := types.ReadMuxResponse(r, res)
+		if err != nil {
+			mux.stopForError(conn, fmt.Errorf("read message: %w", err))
+			return
+		}
+
+		mux.mtx.Lock()
+		cli, ok := mux.pending[id]
+		delete(mux.pending, id)
+		mux.mtx.Unlock()
+
+		switch {
+		case !ok:
+			mux.stopForError(conn, fmt.Errorf("unexpected %T to unknown request %d", res.Value, id))
+			return
+		case cli == nil:
+			// the client was stopped
+			continue
+		}
+		if err := cli.recvResponse(res); err != nil {
+			cli.stopForError(err)
+		}
+	}
+}
+
+// stopForError stops the clients of conn, if it is still the connection of
+// the mux.
+func (mux *socketMux) stopForError(conn net.Conn, err error) {
+	mux.mtx.Lock()
+	if mux.conn != conn {
+		mux.mtx.Unlock()
+		return
+	}
+	clients := make([]*socketClient, 0, len(mux.clients))
+	for cli := range mux.clients {
+		clients = append(clients, cli)
+	}
+	mux.mtx.Unlock()
+
+	for _, cli := range clients {
+		cli.stopForError(err)
+	}
+}
@@ -0,0 +1,125 @@
+package abciclient_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/server"
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+func TestSocketMuxStreams(t *testing.T) {
+	app := &countingApp{}
+	s, clients := setupMuxClientServer(t, app, 3)
+
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func(i int, c abciclient.Client) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				msg := fmt.Sprintf("%d-%d", i, j)
+				res, err := c.EchoSync(ctx, msg)
+				if assert.NoError(t, err) {
+					assert.Equal(t, msg, res.Message)
+				}
+			}
+			res, err := c.InfoSync(ctx, types.RequestInfo{})
+			if assert.NoError(t, err) {
+				assert.NotNil(t, res)
+			}
+		}(i, c)
+	}
+	wg.Wait()
+	require.EqualValues(t, len(clients), app.infos)
+
+	// all the streams fail with the connection
+	require.NoError(t, s.Stop())
+	for _, c := range clients {
+		_, err := c.EchoSync(ctx, "closed")
+		require.Error(t, err)
+	}
+}
+
+func TestSocketMuxFairness(t *testing.T) {
+	s, clients := setupMuxClientServer(t, slowApp{}, 2)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+	busy, idle := clients[0], clients[1]
+
+	// the busy stream queues requests taking a second in total
+	for i := 0; i < 5; i++ {
+		_, err := busy.BeginBlockAsync(ctx, types.RequestBeginBlock{})
+		require.NoError(t, err)
+	}
+	busyDone := make(chan error, 1)
+	go func() {
+		busyDone <- busy.FlushSync(ctx)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// the idle stream gets its turn before the busy stream is done
+	res, err := idle.EchoSync(ctx, "hello")
+	require.NoError(t, err)
+	require.Equal(t, "hello", res.Message)
+	select {
+	case <-busyDone:
+		require.Fail(t, "the busy stream was done first")
+	default:
+	}
+
+	select {
+	case err := <-busyDone:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "the busy stream isn't done")
+	}
+}
+
+func setupMuxClientServer(t *testing.T, app types.Application, n int) (
+	service.Service, []abciclient.Client) {
+	// some port between 20k and 30k
+	port := 20000 + rand.Int31()%10000
+	addr := fmt.Sprintf("localhost:%d", port)
+
+	s, err := server.NewServer(addr, "socket", app)
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+
+	creator := abciclient.NewRemoteCreator(addr, "socket-mux", true)
+	clients := make([]abciclient.Client, n)
+	for i := range clients {
+		clients[i], err = creator()
+		require.NoError(t, err)
+		require.NoError(t, clients[i].Start())
+		c := clients[i]
+		t.Cleanup(func() {
+			if err := c.Stop(); err != nil {
+				t.Log(err)
+			}
+		})
+	}
+
+	return s, clients
+}
+
+type countingApp struct {
+	types.BaseApplication
+	infos int
+}
+
+func (app *countingApp) Info(req types.RequestInfo) types.ResponseInfo {
+	app.infos++
+	return types.ResponseInfo{}
+}
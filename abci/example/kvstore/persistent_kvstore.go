@@ -175,6 +175,39 @@ func (app *PersistentKVStoreApplication) PreprocessTxs(
 	return types.ResponsePreprocessTxs{Txs: req.Txs}
 }
 
+// Execute the block in one go, like BeginBlock, DeliverTx and EndBlock would
+func (app *PersistentKVStoreApplication) FinalizeBlock(
+	req types.RequestFinalizeBlock) types.ResponseFinalizeBlock {
+	resBeginBlock := app.BeginBlock(types.RequestBeginBlock{
+		Hash:                req.Hash,
+		Header:              req.Header,
+		LastCommitInfo:      req.LastCommitInfo,
+		ByzantineValidators: req.ByzantineValidators,
+	})
+	txResults := make([]*types.ResponseDeliverTx, len(req.Txs))
+	for i, tx := range req.Txs {
+		res := app.DeliverTx(types.RequestDeliverTx{Tx: tx})
+		txResults[i] = &res
+	}
+	resEndBlock := app.EndBlock(types.RequestEndBlock{Height: req.Header.Height})
+	return types.ResponseFinalizeBlock{
+		Events:                append(resBeginBlock.Events, resEndBlock.Events...),
+		TxResults:             txResults,
+		ValidatorUpdates:      resEndBlock.ValidatorUpdates,
+		ConsensusParamUpdates: resEndBlock.ConsensusParamUpdates,
+	}
+}
+
+func (app *PersistentKVStoreApplication) ProcessProposal(
+	req types.RequestProcessProposal) types.ResponseProcessProposal {
+	return types.ResponseProcessProposal{Accept: true}
+}
+
+// Rollback isn't supported, the state of the previous heights isn't kept
+func (app *PersistentKVStoreApplication) Rollback(req types.RequestRollback) types.ResponseRollback {
+	return types.ResponseRollback{}
+}
+
 //---------------------------------------------
 // update validators
 
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+// muxRequest is a request of a socket-mux client, with its ID.
+type muxRequest struct {
+	id  uint64
+	req *types.Request
+}
+
+// muxResponse is the response to the request id of a socket-mux client.
+type muxResponse struct {
+	id  uint64
+	res *types.Response
+}
+
+// muxStreams queues the requests of a socket-mux connection by stream. The
+// requests of a stream are dealt with in order, and the streams with queued
+// requests take turns, so a busy stream doesn't delay the others.
+type muxStreams struct {
+	mtx    sync.Mutex
+	cond   *sync.Cond
+	queues map[uint64][]muxRequest
+	order  []uint64 // the streams, in the order they take turns
+	next   int      // the index in order of the stream taking the next turn
+	closed bool
+}
+
+func newMuxStreams() *muxStreams {
+	streams := &muxStreams{queues: make(map[uint64][]muxRequest)}
+	streams.cond = sync.NewCond(&streams.mtx)
+	return streams
+}
+
+// push queues a request of stream.
+func (ms *muxStreams) push(stream uint64, req muxRequest) {
+	ms.mtx.Lock()
+	defer ms.mtx.Unlock()
+
+	if _, ok := ms.queues[stream]; !ok {
+		ms.order = append(ms.order, stream)
+	}
+	ms.queues[stream] = append(ms.queues[stream], req)
+	ms.cond.Signal()
+}
+
+// pop waits for a queued request and returns the first request of the next
+// stream with queued requests. It returns false once the streams are closed.
+func (ms *muxStreams) pop() (muxRequest, bool) {
+	ms.mtx.Lock()
+	defer ms.mtx.Unlock()
+
+	for !ms.closed {
+		for i := range ms.order {
+			idx := (ms.next + i) % len(ms.order)
+			stream := ms.order[idx]
+			if queue := ms.queues[stream]; len(queue) > 0 {
+				ms.queues[stream] = queue[1:]
+				ms.next = idx + 1
+				return queue[0], true
+			}
+		}
+		ms.cond.Wait()
+	}
+	return muxRequest{}, false
+}
+
+// close wakes up pop, which doesn't return any more requests.
+func (ms *muxStreams) close() {
+	ms.mtx.Lock()
+	defer ms.mtx.Unlock()
+	ms.closed = true
+	ms.cond.Broadcast()
+}
+
+// Read requests from conn and queue them by stream
+func (s *SocketServer) handleMuxRequests(closeConn chan error, bufReader *bufio.Reader, streams *muxStreams) {
+	defer streams.close()
+
+	for {
+		var req = &types.Request{}
+		id, stream, err := types.ReadMuxRequest(bufReader, req)
+		if err != nil {
+			if err == io.EOF {
+				closeConn <- err
+			} else {
+				closeConn <- fmt.Errorf("error reading message: %w", err)
+			}
+			return
+		}
+		streams.push(stream, muxRequest{id: id, req: req})
+	}
+}
+
+// Deal with the queued requests, and push their responses to 'responses'.
+func (s *SocketServer) dispatchMuxRequests(closeConn chan error, streams *muxStreams, responses chan<- muxResponse) {
+	defer close(responses)
+	defer s.recoverAppPanic(closeConn)
+
+	for {
+		req, ok := streams.pop()
+		if !ok {
+			return
+		}
+		s.appMtx.Lock()
+		res := s.handleRequest(req.req)
+		s.appMtx.Unlock()
+		responses <- muxResponse{id: req.id, res: res}
+	}
+}
+
+// Pull responses from 'responses' and write them to conn, flushing the
+// buffered responses once there are no more.
+func (s *SocketServer) handleMuxResponses(closeConn chan error, conn io.Writer, responses <-chan muxResponse) {
+	bw := bufio.NewWriter(conn)
+	for res := range responses {
+		if err := types.WriteMuxResponse(res.id, res.res, bw); err != nil {
+			closeConn <- fmt.Errorf("error writing message: %w", err)
+			return
+		}
+		if len(responses) > 0 {
+			continue
+		}
+		if err := bw.Flush(); err != nil {
+			closeConn <- fmt.Errorf("error flushing write buffer: %w", err)
+			return
+		}
+	}
+}
@@ -120,19 +120,58 @@ func (s *SocketServer) acceptConnectionsRoutine() {
 
 		connID := s.addConn(conn)
 
-		closeConn := make(chan error, 2)              // Push to signal connection closed
-		responses := make(chan *types.Response, 1000) // A channel to buffer responses
+		closeConn := make(chan error, 3) // Push to signal connection closed
 
-		// Read requests from conn and deal with them
-		go s.handleRequests(closeConn, conn, responses)
-		// Pull responses from 'responses' and write them to conn.
-		go s.handleResponses(closeConn, conn, responses)
+		go s.handleConn(closeConn, conn)
 
 		// Wait until signal to close connection
 		go s.waitForClose(closeConn, connID)
 	}
 }
 
+// handleConn serves the requests of a socket-mux client if the connection
+// starts with the mux preamble, or of a socket client otherwise.
+func (s *SocketServer) handleConn(closeConn chan error, conn net.Conn) {
+	bufReader := bufio.NewReader(conn)
+
+	// a socket client never sends the preamble, and its first request is
+	// longer than the preamble if it starts with the same byte
+	preamble, err := bufReader.Peek(1)
+	if err == nil && preamble[0] == types.MuxPreamble[0] {
+		preamble, err = bufReader.Peek(len(types.MuxPreamble))
+	}
+	if err != nil {
+		closeConn <- err
+		return
+	}
+
+	if string(preamble) == types.MuxPreamble {
+		if _, err := bufReader.Discard(len(types.MuxPreamble)); err != nil {
+			closeConn <- err
+			return
+		}
+		s.Logger.Info("Multiplexing the requests of the connection")
+
+		streams := newMuxStreams()
+		responses := make(chan muxResponse, 1000) // A channel to buffer responses
+
+		// Read requests from conn and queue them by stream
+		go s.handleMuxRequests(closeConn, bufReader, streams)
+		// Deal with the queued requests, one stream after the other
+		go s.dispatchMuxRequests(closeConn, streams, responses)
+		// Pull responses from 'responses' and write them to conn.
+		go s.handleMuxResponses(closeConn, conn, responses)
+		return
+	}
+
+	responses := make(chan *types.Response, 1000) // A channel to buffer responses
+
+	// Read requests from conn and deal with them
+	go s.handleRequests(closeConn, bufReader, responses)
+	// Pull responses from 'responses' and write them to conn.
+	go s.handleResponses(closeConn, conn, responses)
+}
+
 func (s *SocketServer) waitForClose(closeConn chan error, connID int) {
 	err := <-closeConn
 	switch {
@@ -151,26 +190,29 @@ func (s *SocketServer) waitForClose(closeConn chan error, connID int) {
 	}
 }
 
+// recoverAppPanic recovers from a panic of the application, which happens
+// with appMtx locked, and closes the connection.
+func (s *SocketServer) recoverAppPanic(closeConn chan error) {
+	// make sure to recover from any app-related panics to allow proper socket cleanup
+	r := recover()
+	if r != nil {
+		const size = 64 << 10
+		buf := make([]byte, size)
+		buf = buf[:runtime.Stack(buf, false)]
+		err := fmt.Errorf("recovered from panic: %v\n%s", r, buf)
+		if !s.isLoggerSet {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		closeConn <- err
+		s.appMtx.Unlock()
+	}
+}
+
 // Read requests from conn and deal with them
-func (s *SocketServer) handleRequests(closeConn chan error, conn io.Reader, responses chan<- *types.Response) {
+func (s *SocketServer) handleRequests(closeConn chan error, bufReader *bufio.Reader, responses chan<- *types.Response) {
 	var count int
-	var bufReader = bufio.NewReader(conn)
-
-	defer func() {
-		// make sure to recover from any app-related panics to allow proper socket cleanup
-		r := recover()
-		if r != nil {
-			const size = 64 << 10
-			buf := make([]byte, size)
-			buf = buf[:runtime.Stack(buf, false)]
-			err := fmt.Errorf("recovered from panic: %v\n%s", r, buf)
-			if !s.isLoggerSet {
-				fmt.Fprintln(os.Stderr, err)
-			}
-			closeConn <- err
-			s.appMtx.Unlock()
-		}
-	}()
+
+	defer s.recoverAppPanic(closeConn)
 
 	for {
 
@@ -186,58 +228,67 @@ func (s *SocketServer) handleRequests(closeConn chan error, conn io.Reader, resp
 		}
 		s.appMtx.Lock()
 		count++
-		s.handleRequest(req, responses)
+		responses <- s.handleRequest(req)
 		s.appMtx.Unlock()
 	}
 }
 
-func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types.Response) {
+func (s *SocketServer) handleRequest(req *types.Request) *types.Response {
 	switch r := req.Value.(type) {
 	case *types.Request_Echo:
-		responses <- types.ToResponseEcho(r.Echo.Message)
+		return types.ToResponseEcho(r.Echo.Message)
 	case *types.Request_Flush:
-		responses <- types.ToResponseFlush()
+		return types.ToResponseFlush()
 	case *types.Request_Info:
 		res := s.app.Info(*r.Info)
-		responses <- types.ToResponseInfo(res)
+		return types.ToResponseInfo(res)
 	case *types.Request_DeliverTx:
 		res := s.app.DeliverTx(*r.DeliverTx)
-		responses <- types.ToResponseDeliverTx(res)
+		return types.ToResponseDeliverTx(res)
 	case *types.Request_CheckTx:
 		res := s.app.CheckTx(*r.CheckTx)
-		responses <- types.ToResponseCheckTx(res)
+		return types.ToResponseCheckTx(res)
 	case *types.Request_Commit:
 		res := s.app.Commit()
-		responses <- types.ToResponseCommit(res)
+		return types.ToResponseCommit(res)
 	case *types.Request_Query:
 		res := s.app.Query(*r.Query)
-		responses <- types.ToResponseQuery(res)
+		return types.ToResponseQuery(res)
 	case *types.Request_InitChain:
 		res := s.app.InitChain(*r.InitChain)
-		responses <- types.ToResponseInitChain(res)
+		return types.ToResponseInitChain(res)
 	case *types.Request_BeginBlock:
 		res := s.app.BeginBlock(*r.BeginBlock)
-		responses <- types.ToResponseBeginBlock(res)
+		return types.ToResponseBeginBlock(res)
 	case *types.Request_EndBlock:
 		res := s.app.EndBlock(*r.EndBlock)
-		responses <- types.ToResponseEndBlock(res)
+		return types.ToResponseEndBlock(res)
 	case *types.Request_ListSnapshots:
 		res := s.app.ListSnapshots(*r.ListSnapshots)
-		responses <- types.ToResponseListSnapshots(res)
+		return types.ToResponseListSnapshots(res)
 	case *types.Request_OfferSnapshot:
 		res := s.app.OfferSnapshot(*r.OfferSnapshot)
-		responses <- types.ToResponseOfferSnapshot(res)
+		return types.ToResponseOfferSnapshot(res)
 	case *types.Request_LoadSnapshotChunk:
 		res := s.app.LoadSnapshotChunk(*r.LoadSnapshotChunk)
-		responses <- types.ToResponseLoadSnapshotChunk(res)
+		return types.ToResponseLoadSnapshotChunk(res)
 	case *types.Request_ApplySnapshotChunk:
 		res := s.app.ApplySnapshotChunk(*r.ApplySnapshotChunk)
-		responses <- types.ToResponseApplySnapshotChunk(res)
+		return types.ToResponseApplySnapshotChunk(res)
 	case *types.Request_PreprocessTxs:
 		res := s.app.PreprocessTxs(*r.PreprocessTxs)
-		responses <- types.ToResponsePreprocessTx(res)
+		return types.ToResponsePreprocessTx(res)
+	case *types.Request_FinalizeBlock:
+		res := s.app.FinalizeBlock(*r.FinalizeBlock)
+		return types.ToResponseFinalizeBlock(res)
+	case *types.Request_ProcessProposal:
+		res := s.app.ProcessProposal(*r.ProcessProposal)
+		return types.ToResponseProcessProposal(res)
+	case *types.Request_Rollback:
+		res := s.app.Rollback(*r.Rollback)
+		return types.ToResponseRollback(res)
 	default:
-		responses <- types.ToResponseException("Unknown request")
+		return types.ToResponseException("Unknown request")
 	}
 }
 
@@ -18,12 +18,15 @@ type Application interface {
 	CheckTx(RequestCheckTx) ResponseCheckTx // Validate a tx for the mempool
 
 	// Consensus Connection
-	InitChain(RequestInitChain) ResponseInitChain             // Initialize blockchain w validators/other info from TendermintCore
-	BeginBlock(RequestBeginBlock) ResponseBeginBlock          // Signals the beginning of a block
-	DeliverTx(RequestDeliverTx) ResponseDeliverTx             // Deliver a tx for full processing
-	EndBlock(RequestEndBlock) ResponseEndBlock                // Signals the end of a block, returns changes to the validator set
-	Commit() ResponseCommit                                   // Commit the state and return the application Merkle root hash
-	PreprocessTxs(RequestPreprocessTxs) ResponsePreprocessTxs // State machine preprocessing of txs
+	InitChain(RequestInitChain) ResponseInitChain                   // Initialize blockchain w validators/other info from TendermintCore
+	BeginBlock(RequestBeginBlock) ResponseBeginBlock                // Signals the beginning of a block
+	DeliverTx(RequestDeliverTx) ResponseDeliverTx                   // Deliver a tx for full processing
+	EndBlock(RequestEndBlock) ResponseEndBlock                      // Signals the end of a block, returns changes to the validator set
+	Commit() ResponseCommit                                         // Commit the state and return the application Merkle root hash
+	PreprocessTxs(RequestPreprocessTxs) ResponsePreprocessTxs       // State machine preprocessing of txs
+	FinalizeBlock(RequestFinalizeBlock) ResponseFinalizeBlock       // Execute all the txs of a block, if Info says so
+	ProcessProposal(RequestProcessProposal) ResponseProcessProposal // Accept or reject a proposed block, if Info says so
+	Rollback(RequestRollback) ResponseRollback                      // Roll the state back to a height, after the node did
 
 	// State Sync Connection
 	ListSnapshots(RequestListSnapshots) ResponseListSnapshots                // List available snapshots
@@ -96,6 +99,18 @@ func (BaseApplication) PreprocessTxs(req RequestPreprocessTxs) ResponsePreproces
 	return ResponsePreprocessTxs{}
 }
 
+func (BaseApplication) FinalizeBlock(req RequestFinalizeBlock) ResponseFinalizeBlock {
+	return ResponseFinalizeBlock{}
+}
+
+func (BaseApplication) ProcessProposal(req RequestProcessProposal) ResponseProcessProposal {
+	return ResponseProcessProposal{Accept: true}
+}
+
+func (BaseApplication) Rollback(req RequestRollback) ResponseRollback {
+	return ResponseRollback{}
+}
+
 //-------------------------------------------------------
 
 // GRPCApplication is a GRPC wrapper for Application
@@ -184,3 +199,21 @@ func (app *GRPCApplication) PreprocessTxs(
 	res := app.app.PreprocessTxs(*req)
 	return &res, nil
 }
+
+func (app *GRPCApplication) FinalizeBlock(
+	ctx context.Context, req *RequestFinalizeBlock) (*ResponseFinalizeBlock, error) {
+	res := app.app.FinalizeBlock(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) ProcessProposal(
+	ctx context.Context, req *RequestProcessProposal) (*ResponseProcessProposal, error) {
+	res := app.app.ProcessProposal(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) Rollback(
+	ctx context.Context, req *RequestRollback) (*ResponseRollback, error) {
+	res := app.app.Rollback(*req)
+	return &res, nil
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"bufio"
+	"encoding/binary"
 	"io"
 
 	"github.com/gogo/protobuf/proto"
@@ -24,6 +26,57 @@ func ReadMessage(r io.Reader, msg proto.Message) error {
 	return err
 }
 
+// MuxPreamble is written by a socket-mux client when it connects, to tell the
+// socket server that the requests of all its streams are multiplexed over the
+// connection. It can't be the start of a varint length-delimited request: the
+// field of the second byte would be longer than the message.
+const MuxPreamble = "ABCIMUX1"
+
+// WriteMuxRequest writes a request multiplexed over a connection: the varint
+// request ID and stream, followed by the varint length-delimited request.
+func WriteMuxRequest(id, stream uint64, req *Request, w io.Writer) error {
+	buf := make([]byte, 2*binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, id)
+	n += binary.PutUvarint(buf[n:], stream)
+	if _, err := w.Write(buf[:n]); err != nil {
+		return err
+	}
+	return WriteMessage(req, w)
+}
+
+// ReadMuxRequest reads a request written by WriteMuxRequest, and returns its
+// ID and stream.
+func ReadMuxRequest(r *bufio.Reader, req *Request) (id, stream uint64, err error) {
+	if id, err = binary.ReadUvarint(r); err != nil {
+		return 0, 0, err
+	}
+	if stream, err = binary.ReadUvarint(r); err != nil {
+		return 0, 0, err
+	}
+	return id, stream, ReadMessage(r, req)
+}
+
+// WriteMuxResponse writes the response to the request id multiplexed over a
+// connection: the varint request ID, followed by the varint length-delimited
+// response.
+func WriteMuxResponse(id uint64, res *Response, w io.Writer) error {
+	buf := make([]byte, binary.MaxVarintLen64)
+	if _, err := w.Write(buf[:binary.PutUvarint(buf, id)]); err != nil {
+		return err
+	}
+	return WriteMessage(res, w)
+}
+
+// ReadMuxResponse reads a response written by WriteMuxResponse, and returns
+// the ID of its request.
+func ReadMuxResponse(r *bufio.Reader, res *Response) (uint64, error) {
+	id, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+	return id, ReadMessage(r, res)
+}
+
 //----------------------------------------
 
 func ToRequestEcho(message string) *Request {
@@ -116,6 +169,24 @@ func ToRequestPreprocessTxs(res RequestPreprocessTxs) *Request {
 	}
 }
 
+func ToRequestFinalizeBlock(req RequestFinalizeBlock) *Request {
+	return &Request{
+		Value: &Request_FinalizeBlock{&req},
+	}
+}
+
+func ToRequestProcessProposal(req RequestProcessProposal) *Request {
+	return &Request{
+		Value: &Request_ProcessProposal{&req},
+	}
+}
+
+func ToRequestRollback(req RequestRollback) *Request {
+	return &Request{
+		Value: &Request_Rollback{&req},
+	}
+}
+
 //----------------------------------------
 
 func ToResponseException(errStr string) *Response {
@@ -212,3 +283,21 @@ func ToResponsePreprocessTx(res ResponsePreprocessTxs) *Response {
 		Value: &Response_PreprocessTxs{&res},
 	}
 }
+
+func ToResponseFinalizeBlock(res ResponseFinalizeBlock) *Response {
+	return &Response{
+		Value: &Response_FinalizeBlock{&res},
+	}
+}
+
+func ToResponseProcessProposal(res ResponseProcessProposal) *Response {
+	return &Response{
+		Value: &Response_ProcessProposal{&res},
+	}
+}
+
+func ToResponseRollback(res ResponseRollback) *Response {
+	return &Response{
+		Value: &Response_Rollback{&res},
+	}
+}
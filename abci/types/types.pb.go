@@ -120,7 +120,7 @@ func (x ResponseOfferSnapshot_Result) String() string {
 }
 
 func (ResponseOfferSnapshot_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{29, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{32, 0}
 }
 
 type ResponseApplySnapshotChunk_Result int32
@@ -157,7 +157,7 @@ func (x ResponseApplySnapshotChunk_Result) String() string {
 }
 
 func (ResponseApplySnapshotChunk_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{31, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{34, 0}
 }
 
 type Request struct {
@@ -177,6 +177,9 @@ type Request struct {
 	//	*Request_LoadSnapshotChunk
 	//	*Request_ApplySnapshotChunk
 	//	*Request_PreprocessTxs
+	//	*Request_FinalizeBlock
+	//	*Request_ProcessProposal
+	//	*Request_Rollback
 	Value isRequest_Value `protobuf_oneof:"value"`
 }
 
@@ -264,6 +267,15 @@ type Request_ApplySnapshotChunk struct {
 type Request_PreprocessTxs struct {
 	PreprocessTxs *RequestPreprocessTxs `protobuf:"bytes,15,opt,name=preprocess_txs,json=preprocessTxs,proto3,oneof" json:"preprocess_txs,omitempty"`
 }
+type Request_FinalizeBlock struct {
+	FinalizeBlock *RequestFinalizeBlock `protobuf:"bytes,16,opt,name=finalize_block,json=finalizeBlock,proto3,oneof" json:"finalize_block,omitempty"`
+}
+type Request_ProcessProposal struct {
+	ProcessProposal *RequestProcessProposal `protobuf:"bytes,17,opt,name=process_proposal,json=processProposal,proto3,oneof" json:"process_proposal,omitempty"`
+}
+type Request_Rollback struct {
+	Rollback *RequestRollback `protobuf:"bytes,18,opt,name=rollback,proto3,oneof" json:"rollback,omitempty"`
+}
 
 func (*Request_Echo) isRequest_Value()               {}
 func (*Request_Flush) isRequest_Value()              {}
@@ -280,6 +292,9 @@ func (*Request_OfferSnapshot) isRequest_Value()      {}
 func (*Request_LoadSnapshotChunk) isRequest_Value()  {}
 func (*Request_ApplySnapshotChunk) isRequest_Value() {}
 func (*Request_PreprocessTxs) isRequest_Value()      {}
+func (*Request_FinalizeBlock) isRequest_Value()      {}
+func (*Request_ProcessProposal) isRequest_Value()    {}
+func (*Request_Rollback) isRequest_Value()           {}
 
 func (m *Request) GetValue() isRequest_Value {
 	if m != nil {
@@ -393,6 +408,27 @@ func (m *Request) GetPreprocessTxs() *RequestPreprocessTxs {
 	return nil
 }
 
+func (m *Request) GetFinalizeBlock() *RequestFinalizeBlock {
+	if x, ok := m.GetValue().(*Request_FinalizeBlock); ok {
+		return x.FinalizeBlock
+	}
+	return nil
+}
+
+func (m *Request) GetProcessProposal() *RequestProcessProposal {
+	if x, ok := m.GetValue().(*Request_ProcessProposal); ok {
+		return x.ProcessProposal
+	}
+	return nil
+}
+
+func (m *Request) GetRollback() *RequestRollback {
+	if x, ok := m.GetValue().(*Request_Rollback); ok {
+		return x.Rollback
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Request) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -411,6 +447,9 @@ func (*Request) XXX_OneofWrappers() []interface{} {
 		(*Request_LoadSnapshotChunk)(nil),
 		(*Request_ApplySnapshotChunk)(nil),
 		(*Request_PreprocessTxs)(nil),
+		(*Request_FinalizeBlock)(nil),
+		(*Request_ProcessProposal)(nil),
+		(*Request_Rollback)(nil),
 	}
 }
 
@@ -1171,7 +1210,9 @@ func (m *RequestApplySnapshotChunk) GetSender() string {
 }
 
 type RequestPreprocessTxs struct {
-	Txs [][]byte `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	Txs      [][]byte `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	MaxBytes int64    `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	MaxGas   int64    `protobuf:"varint,3,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
 }
 
 func (m *RequestPreprocessTxs) Reset()         { *m = RequestPreprocessTxs{} }
@@ -1214,6 +1255,196 @@ func (m *RequestPreprocessTxs) GetTxs() [][]byte {
 	return nil
 }
 
+func (m *RequestPreprocessTxs) GetMaxBytes() int64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+func (m *RequestPreprocessTxs) GetMaxGas() int64 {
+	if m != nil {
+		return m.MaxGas
+	}
+	return 0
+}
+
+// executes all the txs of a block, in place of BeginBlock, DeliverTx and EndBlock
+type RequestFinalizeBlock struct {
+	Hash                []byte         `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Header              types1.Header  `protobuf:"bytes,2,opt,name=header,proto3" json:"header"`
+	LastCommitInfo      LastCommitInfo `protobuf:"bytes,3,opt,name=last_commit_info,json=lastCommitInfo,proto3" json:"last_commit_info"`
+	ByzantineValidators []Evidence     `protobuf:"bytes,4,rep,name=byzantine_validators,json=byzantineValidators,proto3" json:"byzantine_validators"`
+	Txs                 [][]byte       `protobuf:"bytes,5,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (m *RequestFinalizeBlock) Reset()         { *m = RequestFinalizeBlock{} }
+func (m *RequestFinalizeBlock) String() string { return proto.CompactTextString(m) }
+func (*RequestFinalizeBlock) ProtoMessage()    {}
+func (*RequestFinalizeBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{16}
+}
+func (m *RequestFinalizeBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestFinalizeBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestFinalizeBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestFinalizeBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestFinalizeBlock.Merge(m, src)
+}
+func (m *RequestFinalizeBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestFinalizeBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestFinalizeBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestFinalizeBlock proto.InternalMessageInfo
+
+func (m *RequestFinalizeBlock) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *RequestFinalizeBlock) GetHeader() types1.Header {
+	if m != nil {
+		return m.Header
+	}
+	return types1.Header{}
+}
+
+func (m *RequestFinalizeBlock) GetLastCommitInfo() LastCommitInfo {
+	if m != nil {
+		return m.LastCommitInfo
+	}
+	return LastCommitInfo{}
+}
+
+func (m *RequestFinalizeBlock) GetByzantineValidators() []Evidence {
+	if m != nil {
+		return m.ByzantineValidators
+	}
+	return nil
+}
+
+func (m *RequestFinalizeBlock) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+// asks the application whether to accept the proposed block, before prevoting it
+type RequestProcessProposal struct {
+	Header types1.Header `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Txs    [][]byte      `protobuf:"bytes,2,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (m *RequestProcessProposal) Reset()         { *m = RequestProcessProposal{} }
+func (m *RequestProcessProposal) String() string { return proto.CompactTextString(m) }
+func (*RequestProcessProposal) ProtoMessage()    {}
+func (*RequestProcessProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{17}
+}
+func (m *RequestProcessProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestProcessProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestProcessProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestProcessProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestProcessProposal.Merge(m, src)
+}
+func (m *RequestProcessProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestProcessProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestProcessProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestProcessProposal proto.InternalMessageInfo
+
+func (m *RequestProcessProposal) GetHeader() types1.Header {
+	if m != nil {
+		return m.Header
+	}
+	return types1.Header{}
+}
+
+func (m *RequestProcessProposal) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+// informs the application that the node rolled its state back to height: the
+// application must roll its own state back to the same height.
+type RequestRollback struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *RequestRollback) Reset()         { *m = RequestRollback{} }
+func (m *RequestRollback) String() string { return proto.CompactTextString(m) }
+func (*RequestRollback) ProtoMessage()    {}
+func (*RequestRollback) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{18}
+}
+func (m *RequestRollback) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestRollback) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestRollback.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestRollback) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestRollback.Merge(m, src)
+}
+func (m *RequestRollback) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestRollback) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestRollback.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestRollback proto.InternalMessageInfo
+
+func (m *RequestRollback) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 type Response struct {
 	// Types that are valid to be assigned to Value:
 	//	*Response_Exception
@@ -1232,6 +1463,9 @@ type Response struct {
 	//	*Response_LoadSnapshotChunk
 	//	*Response_ApplySnapshotChunk
 	//	*Response_PreprocessTxs
+	//	*Response_FinalizeBlock
+	//	*Response_ProcessProposal
+	//	*Response_Rollback
 	Value isResponse_Value `protobuf_oneof:"value"`
 }
 
@@ -1239,7 +1473,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{16}
+	return fileDescriptor_252557cfdd89a31a, []int{19}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1322,6 +1556,15 @@ type Response_ApplySnapshotChunk struct {
 type Response_PreprocessTxs struct {
 	PreprocessTxs *ResponsePreprocessTxs `protobuf:"bytes,16,opt,name=preprocess_txs,json=preprocessTxs,proto3,oneof" json:"preprocess_txs,omitempty"`
 }
+type Response_FinalizeBlock struct {
+	FinalizeBlock *ResponseFinalizeBlock `protobuf:"bytes,17,opt,name=finalize_block,json=finalizeBlock,proto3,oneof" json:"finalize_block,omitempty"`
+}
+type Response_ProcessProposal struct {
+	ProcessProposal *ResponseProcessProposal `protobuf:"bytes,18,opt,name=process_proposal,json=processProposal,proto3,oneof" json:"process_proposal,omitempty"`
+}
+type Response_Rollback struct {
+	Rollback *ResponseRollback `protobuf:"bytes,19,opt,name=rollback,proto3,oneof" json:"rollback,omitempty"`
+}
 
 func (*Response_Exception) isResponse_Value()          {}
 func (*Response_Echo) isResponse_Value()               {}
@@ -1339,6 +1582,9 @@ func (*Response_OfferSnapshot) isResponse_Value()      {}
 func (*Response_LoadSnapshotChunk) isResponse_Value()  {}
 func (*Response_ApplySnapshotChunk) isResponse_Value() {}
 func (*Response_PreprocessTxs) isResponse_Value()      {}
+func (*Response_FinalizeBlock) isResponse_Value()      {}
+func (*Response_ProcessProposal) isResponse_Value()    {}
+func (*Response_Rollback) isResponse_Value()           {}
 
 func (m *Response) GetValue() isResponse_Value {
 	if m != nil {
@@ -1459,6 +1705,27 @@ func (m *Response) GetPreprocessTxs() *ResponsePreprocessTxs {
 	return nil
 }
 
+func (m *Response) GetFinalizeBlock() *ResponseFinalizeBlock {
+	if x, ok := m.GetValue().(*Response_FinalizeBlock); ok {
+		return x.FinalizeBlock
+	}
+	return nil
+}
+
+func (m *Response) GetProcessProposal() *ResponseProcessProposal {
+	if x, ok := m.GetValue().(*Response_ProcessProposal); ok {
+		return x.ProcessProposal
+	}
+	return nil
+}
+
+func (m *Response) GetRollback() *ResponseRollback {
+	if x, ok := m.GetValue().(*Response_Rollback); ok {
+		return x.Rollback
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Response) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1478,6 +1745,9 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_LoadSnapshotChunk)(nil),
 		(*Response_ApplySnapshotChunk)(nil),
 		(*Response_PreprocessTxs)(nil),
+		(*Response_FinalizeBlock)(nil),
+		(*Response_ProcessProposal)(nil),
+		(*Response_Rollback)(nil),
 	}
 }
 
@@ -1490,7 +1760,7 @@ func (m *ResponseException) Reset()         { *m = ResponseException{} }
 func (m *ResponseException) String() string { return proto.CompactTextString(m) }
 func (*ResponseException) ProtoMessage()    {}
 func (*ResponseException) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{17}
+	return fileDescriptor_252557cfdd89a31a, []int{20}
 }
 func (m *ResponseException) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1534,7 +1804,7 @@ func (m *ResponseEcho) Reset()         { *m = ResponseEcho{} }
 func (m *ResponseEcho) String() string { return proto.CompactTextString(m) }
 func (*ResponseEcho) ProtoMessage()    {}
 func (*ResponseEcho) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{18}
+	return fileDescriptor_252557cfdd89a31a, []int{21}
 }
 func (m *ResponseEcho) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1577,7 +1847,7 @@ func (m *ResponseFlush) Reset()         { *m = ResponseFlush{} }
 func (m *ResponseFlush) String() string { return proto.CompactTextString(m) }
 func (*ResponseFlush) ProtoMessage()    {}
 func (*ResponseFlush) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{19}
+	return fileDescriptor_252557cfdd89a31a, []int{22}
 }
 func (m *ResponseFlush) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1613,13 +1883,17 @@ type ResponseInfo struct {
 	AppVersion       uint64 `protobuf:"varint,3,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
 	LastBlockHeight  int64  `protobuf:"varint,4,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
 	LastBlockAppHash []byte `protobuf:"bytes,5,opt,name=last_block_app_hash,json=lastBlockAppHash,proto3" json:"last_block_app_hash,omitempty"`
+	// the application executes the blocks with FinalizeBlock
+	FinalizeBlock bool `protobuf:"varint,6,opt,name=finalize_block,json=finalizeBlock,proto3" json:"finalize_block,omitempty"`
+	// the application checks the proposed blocks with ProcessProposal
+	ProcessProposal bool `protobuf:"varint,7,opt,name=process_proposal,json=processProposal,proto3" json:"process_proposal,omitempty"`
 }
 
 func (m *ResponseInfo) Reset()         { *m = ResponseInfo{} }
 func (m *ResponseInfo) String() string { return proto.CompactTextString(m) }
 func (*ResponseInfo) ProtoMessage()    {}
 func (*ResponseInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{20}
+	return fileDescriptor_252557cfdd89a31a, []int{23}
 }
 func (m *ResponseInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1683,6 +1957,20 @@ func (m *ResponseInfo) GetLastBlockAppHash() []byte {
 	return nil
 }
 
+func (m *ResponseInfo) GetFinalizeBlock() bool {
+	if m != nil {
+		return m.FinalizeBlock
+	}
+	return false
+}
+
+func (m *ResponseInfo) GetProcessProposal() bool {
+	if m != nil {
+		return m.ProcessProposal
+	}
+	return false
+}
+
 type ResponseInitChain struct {
 	ConsensusParams *types1.ConsensusParams `protobuf:"bytes,1,opt,name=consensus_params,json=consensusParams,proto3" json:"consensus_params,omitempty"`
 	Validators      []ValidatorUpdate       `protobuf:"bytes,2,rep,name=validators,proto3" json:"validators"`
@@ -1693,7 +1981,7 @@ func (m *ResponseInitChain) Reset()         { *m = ResponseInitChain{} }
 func (m *ResponseInitChain) String() string { return proto.CompactTextString(m) }
 func (*ResponseInitChain) ProtoMessage()    {}
 func (*ResponseInitChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{21}
+	return fileDescriptor_252557cfdd89a31a, []int{24}
 }
 func (m *ResponseInitChain) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1760,7 +2048,7 @@ func (m *ResponseQuery) Reset()         { *m = ResponseQuery{} }
 func (m *ResponseQuery) String() string { return proto.CompactTextString(m) }
 func (*ResponseQuery) ProtoMessage()    {}
 func (*ResponseQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{22}
+	return fileDescriptor_252557cfdd89a31a, []int{25}
 }
 func (m *ResponseQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1860,7 +2148,7 @@ func (m *ResponseBeginBlock) Reset()         { *m = ResponseBeginBlock{} }
 func (m *ResponseBeginBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseBeginBlock) ProtoMessage()    {}
 func (*ResponseBeginBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{23}
+	return fileDescriptor_252557cfdd89a31a, []int{26}
 }
 func (m *ResponseBeginBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1916,7 +2204,7 @@ func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
 func (m *ResponseCheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseCheckTx) ProtoMessage()    {}
 func (*ResponseCheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{24}
+	return fileDescriptor_252557cfdd89a31a, []int{27}
 }
 func (m *ResponseCheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2037,7 +2325,7 @@ func (m *ResponseDeliverTx) Reset()         { *m = ResponseDeliverTx{} }
 func (m *ResponseDeliverTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseDeliverTx) ProtoMessage()    {}
 func (*ResponseDeliverTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{25}
+	return fileDescriptor_252557cfdd89a31a, []int{28}
 }
 func (m *ResponseDeliverTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2132,7 +2420,7 @@ func (m *ResponseEndBlock) Reset()         { *m = ResponseEndBlock{} }
 func (m *ResponseEndBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseEndBlock) ProtoMessage()    {}
 func (*ResponseEndBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{26}
+	return fileDescriptor_252557cfdd89a31a, []int{29}
 }
 func (m *ResponseEndBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2192,7 +2480,7 @@ func (m *ResponseCommit) Reset()         { *m = ResponseCommit{} }
 func (m *ResponseCommit) String() string { return proto.CompactTextString(m) }
 func (*ResponseCommit) ProtoMessage()    {}
 func (*ResponseCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{27}
+	return fileDescriptor_252557cfdd89a31a, []int{30}
 }
 func (m *ResponseCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2243,7 +2531,7 @@ func (m *ResponseListSnapshots) Reset()         { *m = ResponseListSnapshots{} }
 func (m *ResponseListSnapshots) String() string { return proto.CompactTextString(m) }
 func (*ResponseListSnapshots) ProtoMessage()    {}
 func (*ResponseListSnapshots) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{28}
+	return fileDescriptor_252557cfdd89a31a, []int{31}
 }
 func (m *ResponseListSnapshots) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2287,7 +2575,7 @@ func (m *ResponseOfferSnapshot) Reset()         { *m = ResponseOfferSnapshot{} }
 func (m *ResponseOfferSnapshot) String() string { return proto.CompactTextString(m) }
 func (*ResponseOfferSnapshot) ProtoMessage()    {}
 func (*ResponseOfferSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{29}
+	return fileDescriptor_252557cfdd89a31a, []int{32}
 }
 func (m *ResponseOfferSnapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2331,7 +2619,7 @@ func (m *ResponseLoadSnapshotChunk) Reset()         { *m = ResponseLoadSnapshotC
 func (m *ResponseLoadSnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseLoadSnapshotChunk) ProtoMessage()    {}
 func (*ResponseLoadSnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30}
+	return fileDescriptor_252557cfdd89a31a, []int{33}
 }
 func (m *ResponseLoadSnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2377,7 +2665,7 @@ func (m *ResponseApplySnapshotChunk) Reset()         { *m = ResponseApplySnapsho
 func (m *ResponseApplySnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseApplySnapshotChunk) ProtoMessage()    {}
 func (*ResponseApplySnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{31}
+	return fileDescriptor_252557cfdd89a31a, []int{34}
 }
 func (m *ResponseApplySnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2436,7 +2724,7 @@ func (m *ResponsePreprocessTxs) Reset()         { *m = ResponsePreprocessTxs{} }
 func (m *ResponsePreprocessTxs) String() string { return proto.CompactTextString(m) }
 func (*ResponsePreprocessTxs) ProtoMessage()    {}
 func (*ResponsePreprocessTxs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{32}
+	return fileDescriptor_252557cfdd89a31a, []int{35}
 }
 func (m *ResponsePreprocessTxs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2479,23 +2767,25 @@ func (m *ResponsePreprocessTxs) GetMessages() *types1.Messages {
 	return nil
 }
 
-type LastCommitInfo struct {
-	Round int32      `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
-	Votes []VoteInfo `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes"`
+type ResponseFinalizeBlock struct {
+	Events                []Event                 `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	TxResults             []*ResponseDeliverTx    `protobuf:"bytes,2,rep,name=tx_results,json=txResults,proto3" json:"tx_results,omitempty"`
+	ValidatorUpdates      []ValidatorUpdate       `protobuf:"bytes,3,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates"`
+	ConsensusParamUpdates *types1.ConsensusParams `protobuf:"bytes,4,opt,name=consensus_param_updates,json=consensusParamUpdates,proto3" json:"consensus_param_updates,omitempty"`
 }
 
-func (m *LastCommitInfo) Reset()         { *m = LastCommitInfo{} }
-func (m *LastCommitInfo) String() string { return proto.CompactTextString(m) }
-func (*LastCommitInfo) ProtoMessage()    {}
-func (*LastCommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{33}
+func (m *ResponseFinalizeBlock) Reset()         { *m = ResponseFinalizeBlock{} }
+func (m *ResponseFinalizeBlock) String() string { return proto.CompactTextString(m) }
+func (*ResponseFinalizeBlock) ProtoMessage()    {}
+func (*ResponseFinalizeBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{36}
 }
-func (m *LastCommitInfo) XXX_Unmarshal(b []byte) error {
+func (m *ResponseFinalizeBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LastCommitInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseFinalizeBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LastCommitInfo.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseFinalizeBlock.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2505,52 +2795,62 @@ func (m *LastCommitInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *LastCommitInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LastCommitInfo.Merge(m, src)
+func (m *ResponseFinalizeBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseFinalizeBlock.Merge(m, src)
 }
-func (m *LastCommitInfo) XXX_Size() int {
+func (m *ResponseFinalizeBlock) XXX_Size() int {
 	return m.Size()
 }
-func (m *LastCommitInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_LastCommitInfo.DiscardUnknown(m)
+func (m *ResponseFinalizeBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseFinalizeBlock.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LastCommitInfo proto.InternalMessageInfo
+var xxx_messageInfo_ResponseFinalizeBlock proto.InternalMessageInfo
 
-func (m *LastCommitInfo) GetRound() int32 {
+func (m *ResponseFinalizeBlock) GetEvents() []Event {
 	if m != nil {
-		return m.Round
+		return m.Events
 	}
-	return 0
+	return nil
 }
 
-func (m *LastCommitInfo) GetVotes() []VoteInfo {
+func (m *ResponseFinalizeBlock) GetTxResults() []*ResponseDeliverTx {
 	if m != nil {
-		return m.Votes
+		return m.TxResults
 	}
 	return nil
 }
 
-// Event allows application developers to attach additional information to
-// ResponseBeginBlock, ResponseEndBlock, ResponseCheckTx and ResponseDeliverTx.
-// Later, transactions may be queried using these events.
-type Event struct {
-	Type       string           `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Attributes []EventAttribute `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty"`
+func (m *ResponseFinalizeBlock) GetValidatorUpdates() []ValidatorUpdate {
+	if m != nil {
+		return m.ValidatorUpdates
+	}
+	return nil
 }
 
-func (m *Event) Reset()         { *m = Event{} }
-func (m *Event) String() string { return proto.CompactTextString(m) }
-func (*Event) ProtoMessage()    {}
-func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{34}
+func (m *ResponseFinalizeBlock) GetConsensusParamUpdates() *types1.ConsensusParams {
+	if m != nil {
+		return m.ConsensusParamUpdates
+	}
+	return nil
 }
-func (m *Event) XXX_Unmarshal(b []byte) error {
+
+type ResponseProcessProposal struct {
+	Accept bool `protobuf:"varint,1,opt,name=accept,proto3" json:"accept,omitempty"`
+}
+
+func (m *ResponseProcessProposal) Reset()         { *m = ResponseProcessProposal{} }
+func (m *ResponseProcessProposal) String() string { return proto.CompactTextString(m) }
+func (*ResponseProcessProposal) ProtoMessage()    {}
+func (*ResponseProcessProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{37}
+}
+func (m *ResponseProcessProposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseProcessProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseProcessProposal.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2560,7 +2860,159 @@ func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Event) XXX_Merge(src proto.Message) {
+func (m *ResponseProcessProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseProcessProposal.Merge(m, src)
+}
+func (m *ResponseProcessProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseProcessProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseProcessProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseProcessProposal proto.InternalMessageInfo
+
+func (m *ResponseProcessProposal) GetAccept() bool {
+	if m != nil {
+		return m.Accept
+	}
+	return false
+}
+
+type ResponseRollback struct {
+	LastBlockHeight  int64  `protobuf:"varint,1,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
+	LastBlockAppHash []byte `protobuf:"bytes,2,opt,name=last_block_app_hash,json=lastBlockAppHash,proto3" json:"last_block_app_hash,omitempty"`
+}
+
+func (m *ResponseRollback) Reset()         { *m = ResponseRollback{} }
+func (m *ResponseRollback) String() string { return proto.CompactTextString(m) }
+func (*ResponseRollback) ProtoMessage()    {}
+func (*ResponseRollback) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{38}
+}
+func (m *ResponseRollback) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseRollback) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseRollback.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseRollback) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseRollback.Merge(m, src)
+}
+func (m *ResponseRollback) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseRollback) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseRollback.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseRollback proto.InternalMessageInfo
+
+func (m *ResponseRollback) GetLastBlockHeight() int64 {
+	if m != nil {
+		return m.LastBlockHeight
+	}
+	return 0
+}
+
+func (m *ResponseRollback) GetLastBlockAppHash() []byte {
+	if m != nil {
+		return m.LastBlockAppHash
+	}
+	return nil
+}
+
+type LastCommitInfo struct {
+	Round int32      `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Votes []VoteInfo `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes"`
+}
+
+func (m *LastCommitInfo) Reset()         { *m = LastCommitInfo{} }
+func (m *LastCommitInfo) String() string { return proto.CompactTextString(m) }
+func (*LastCommitInfo) ProtoMessage()    {}
+func (*LastCommitInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{39}
+}
+func (m *LastCommitInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LastCommitInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LastCommitInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LastCommitInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LastCommitInfo.Merge(m, src)
+}
+func (m *LastCommitInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *LastCommitInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_LastCommitInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LastCommitInfo proto.InternalMessageInfo
+
+func (m *LastCommitInfo) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LastCommitInfo) GetVotes() []VoteInfo {
+	if m != nil {
+		return m.Votes
+	}
+	return nil
+}
+
+// Event allows application developers to attach additional information to
+// ResponseBeginBlock, ResponseEndBlock, ResponseCheckTx and ResponseDeliverTx.
+// Later, transactions may be queried using these events.
+type Event struct {
+	Type       string           `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Attributes []EventAttribute `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{40}
+}
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Event) XXX_Merge(src proto.Message) {
 	xxx_messageInfo_Event.Merge(m, src)
 }
 func (m *Event) XXX_Size() int {
@@ -2597,7 +3049,7 @@ func (m *EventAttribute) Reset()         { *m = EventAttribute{} }
 func (m *EventAttribute) String() string { return proto.CompactTextString(m) }
 func (*EventAttribute) ProtoMessage()    {}
 func (*EventAttribute) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{35}
+	return fileDescriptor_252557cfdd89a31a, []int{41}
 }
 func (m *EventAttribute) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2661,7 +3113,7 @@ func (m *TxResult) Reset()         { *m = TxResult{} }
 func (m *TxResult) String() string { return proto.CompactTextString(m) }
 func (*TxResult) ProtoMessage()    {}
 func (*TxResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{36}
+	return fileDescriptor_252557cfdd89a31a, []int{42}
 }
 func (m *TxResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2729,7 +3181,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{37}
+	return fileDescriptor_252557cfdd89a31a, []int{43}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2782,7 +3234,7 @@ func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
 func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
 func (*ValidatorUpdate) ProtoMessage()    {}
 func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{38}
+	return fileDescriptor_252557cfdd89a31a, []int{44}
 }
 func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2835,7 +3287,7 @@ func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
 func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
 func (*VoteInfo) ProtoMessage()    {}
 func (*VoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{39}
+	return fileDescriptor_252557cfdd89a31a, []int{45}
 }
 func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2896,7 +3348,7 @@ func (m *Evidence) Reset()         { *m = Evidence{} }
 func (m *Evidence) String() string { return proto.CompactTextString(m) }
 func (*Evidence) ProtoMessage()    {}
 func (*Evidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{40}
+	return fileDescriptor_252557cfdd89a31a, []int{46}
 }
 func (m *Evidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2972,7 +3424,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{41}
+	return fileDescriptor_252557cfdd89a31a, []int{47}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3057,6 +3509,9 @@ func init() {
 	proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "tendermint.abci.RequestLoadSnapshotChunk")
 	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "tendermint.abci.RequestApplySnapshotChunk")
 	proto.RegisterType((*RequestPreprocessTxs)(nil), "tendermint.abci.RequestPreprocessTxs")
+	proto.RegisterType((*RequestFinalizeBlock)(nil), "tendermint.abci.RequestFinalizeBlock")
+	proto.RegisterType((*RequestProcessProposal)(nil), "tendermint.abci.RequestProcessProposal")
+	proto.RegisterType((*RequestRollback)(nil), "tendermint.abci.RequestRollback")
 	proto.RegisterType((*Response)(nil), "tendermint.abci.Response")
 	proto.RegisterType((*ResponseException)(nil), "tendermint.abci.ResponseException")
 	proto.RegisterType((*ResponseEcho)(nil), "tendermint.abci.ResponseEcho")
@@ -3074,6 +3529,9 @@ func init() {
 	proto.RegisterType((*ResponseLoadSnapshotChunk)(nil), "tendermint.abci.ResponseLoadSnapshotChunk")
 	proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "tendermint.abci.ResponseApplySnapshotChunk")
 	proto.RegisterType((*ResponsePreprocessTxs)(nil), "tendermint.abci.ResponsePreprocessTxs")
+	proto.RegisterType((*ResponseFinalizeBlock)(nil), "tendermint.abci.ResponseFinalizeBlock")
+	proto.RegisterType((*ResponseProcessProposal)(nil), "tendermint.abci.ResponseProcessProposal")
+	proto.RegisterType((*ResponseRollback)(nil), "tendermint.abci.ResponseRollback")
 	proto.RegisterType((*LastCommitInfo)(nil), "tendermint.abci.LastCommitInfo")
 	proto.RegisterType((*Event)(nil), "tendermint.abci.Event")
 	proto.RegisterType((*EventAttribute)(nil), "tendermint.abci.EventAttribute")
@@ -3088,177 +3546,197 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 2712 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcd, 0x73, 0x23, 0xc5,
-	0x15, 0xd7, 0xa7, 0x25, 0x3d, 0x7d, 0x58, 0xdb, 0xbb, 0x2c, 0x62, 0x58, 0xec, 0x65, 0x28, 0xc8,
-	0xb2, 0x80, 0x1d, 0x4c, 0xb1, 0x81, 0x22, 0x1f, 0x58, 0x42, 0x1b, 0x99, 0x35, 0x96, 0xd3, 0xd6,
-	0x2e, 0x45, 0x12, 0x76, 0x18, 0x69, 0xda, 0xd2, 0xb0, 0xd2, 0xcc, 0x30, 0xd3, 0x32, 0x36, 0xc7,
-	0x54, 0x72, 0xa1, 0x52, 0x15, 0x8e, 0xb9, 0xf0, 0x7f, 0xe4, 0x94, 0x53, 0x0e, 0x1c, 0x72, 0xe0,
-	0x98, 0x43, 0x8a, 0xa4, 0xd8, 0x5b, 0x6e, 0x39, 0xe5, 0x94, 0xaa, 0x54, 0x7f, 0xcc, 0x97, 0xa4,
-	0x91, 0xe4, 0x90, 0x5b, 0x6e, 0xdd, 0x6f, 0xde, 0x7b, 0xea, 0x7e, 0xdd, 0xfd, 0x7b, 0xbf, 0x7e,
-	0x2d, 0x78, 0x9a, 0x12, 0xcb, 0x20, 0xee, 0xc4, 0xb4, 0xe8, 0xae, 0xde, 0x1f, 0x98, 0xbb, 0xf4,
-	0xc2, 0x21, 0xde, 0x8e, 0xe3, 0xda, 0xd4, 0x46, 0x9b, 0xe1, 0xc7, 0x1d, 0xf6, 0x51, 0x79, 0x26,
-	0xa2, 0x3d, 0x70, 0x2f, 0x1c, 0x6a, 0xef, 0x3a, 0xae, 0x6d, 0x9f, 0x0a, 0x7d, 0xe5, 0x46, 0xe4,
-	0x33, 0xf7, 0x13, 0xf5, 0x16, 0xfb, 0x2a, 0x8d, 0x1f, 0x91, 0x0b, 0xff, 0xeb, 0x33, 0x73, 0xb6,
-	0x8e, 0xee, 0xea, 0x13, 0xff, 0xf3, 0xf6, 0xd0, 0xb6, 0x87, 0x63, 0xb2, 0xcb, 0x7b, 0xfd, 0xe9,
-	0xe9, 0x2e, 0x35, 0x27, 0xc4, 0xa3, 0xfa, 0xc4, 0x91, 0x0a, 0xd7, 0x86, 0xf6, 0xd0, 0xe6, 0xcd,
-	0x5d, 0xd6, 0x12, 0x52, 0xf5, 0xcb, 0x22, 0x14, 0x30, 0xf9, 0x64, 0x4a, 0x3c, 0x8a, 0xf6, 0x20,
-	0x47, 0x06, 0x23, 0xbb, 0x91, 0xbe, 0x99, 0xbe, 0x55, 0xde, 0xbb, 0xb1, 0x33, 0x33, 0xb9, 0x1d,
-	0xa9, 0xd7, 0x1e, 0x8c, 0xec, 0x4e, 0x0a, 0x73, 0x5d, 0xf4, 0x3a, 0xe4, 0x4f, 0xc7, 0x53, 0x6f,
-	0xd4, 0xc8, 0x70, 0xa3, 0x67, 0x92, 0x8c, 0xee, 0x32, 0xa5, 0x4e, 0x0a, 0x0b, 0x6d, 0xf6, 0x53,
-	0xa6, 0x75, 0x6a, 0x37, 0xb2, 0xcb, 0x7f, 0xea, 0xc0, 0x3a, 0xe5, 0x3f, 0xc5, 0x74, 0x51, 0x13,
-	0xc0, 0xb4, 0x4c, 0xaa, 0x0d, 0x46, 0xba, 0x69, 0x35, 0x72, 0xdc, 0xf2, 0xd9, 0x64, 0x4b, 0x93,
-	0xb6, 0x98, 0x62, 0x27, 0x85, 0x4b, 0xa6, 0xdf, 0x61, 0xc3, 0xfd, 0x64, 0x4a, 0xdc, 0x8b, 0x46,
-	0x7e, 0xf9, 0x70, 0x7f, 0xc6, 0x94, 0xd8, 0x70, 0xb9, 0x36, 0x6a, 0x43, 0xb9, 0x4f, 0x86, 0xa6,
-	0xa5, 0xf5, 0xc7, 0xf6, 0xe0, 0x51, 0x63, 0x83, 0x1b, 0xab, 0x49, 0xc6, 0x4d, 0xa6, 0xda, 0x64,
-	0x9a, 0x9d, 0x14, 0x86, 0x7e, 0xd0, 0x43, 0x3f, 0x84, 0xe2, 0x60, 0x44, 0x06, 0x8f, 0x34, 0x7a,
-	0xde, 0x28, 0x70, 0x1f, 0xdb, 0x49, 0x3e, 0x5a, 0x4c, 0xaf, 0x77, 0xde, 0x49, 0xe1, 0xc2, 0x40,
-	0x34, 0xd9, 0xfc, 0x0d, 0x32, 0x36, 0xcf, 0x88, 0xcb, 0xec, 0x8b, 0xcb, 0xe7, 0xff, 0x8e, 0xd0,
-	0xe4, 0x1e, 0x4a, 0x86, 0xdf, 0x41, 0x3f, 0x81, 0x12, 0xb1, 0x0c, 0x39, 0x8d, 0x12, 0x77, 0x71,
-	0x33, 0x71, 0x9d, 0x2d, 0xc3, 0x9f, 0x44, 0x91, 0xc8, 0x36, 0x7a, 0x03, 0x36, 0x06, 0xf6, 0x64,
-	0x62, 0xd2, 0x06, 0x70, 0xeb, 0xad, 0xc4, 0x09, 0x70, 0xad, 0x4e, 0x0a, 0x4b, 0x7d, 0x74, 0x04,
-	0xb5, 0xb1, 0xe9, 0x51, 0xcd, 0xb3, 0x74, 0xc7, 0x1b, 0xd9, 0xd4, 0x6b, 0x94, 0xb9, 0x87, 0xe7,
-	0x93, 0x3c, 0x1c, 0x9a, 0x1e, 0x3d, 0xf1, 0x95, 0x3b, 0x29, 0x5c, 0x1d, 0x47, 0x05, 0xcc, 0x9f,
-	0x7d, 0x7a, 0x4a, 0xdc, 0xc0, 0x61, 0xa3, 0xb2, 0xdc, 0x5f, 0x97, 0x69, 0xfb, 0xf6, 0xcc, 0x9f,
-	0x1d, 0x15, 0xa0, 0x5f, 0xc0, 0xd5, 0xb1, 0xad, 0x1b, 0x81, 0x3b, 0x6d, 0x30, 0x9a, 0x5a, 0x8f,
-	0x1a, 0x55, 0xee, 0xf4, 0xc5, 0xc4, 0x41, 0xda, 0xba, 0xe1, 0xbb, 0x68, 0x31, 0x83, 0x4e, 0x0a,
-	0x5f, 0x19, 0xcf, 0x0a, 0xd1, 0x43, 0xb8, 0xa6, 0x3b, 0xce, 0xf8, 0x62, 0xd6, 0x7b, 0x8d, 0x7b,
-	0xbf, 0x9d, 0xe4, 0x7d, 0x9f, 0xd9, 0xcc, 0xba, 0x47, 0xfa, 0x9c, 0x94, 0x05, 0xc3, 0x71, 0x89,
-	0xe3, 0xda, 0x03, 0xe2, 0x79, 0x1a, 0x3d, 0xf7, 0x1a, 0x9b, 0xcb, 0x83, 0x71, 0x1c, 0x68, 0xf7,
-	0xce, 0x79, 0x70, 0x9d, 0xa8, 0xa0, 0x59, 0x80, 0xfc, 0x99, 0x3e, 0x9e, 0x12, 0xf5, 0x7b, 0x50,
-	0x8e, 0x1c, 0x7b, 0xd4, 0x80, 0xc2, 0x84, 0x78, 0x9e, 0x3e, 0x24, 0x1c, 0x25, 0x4a, 0xd8, 0xef,
-	0xaa, 0x35, 0xa8, 0x44, 0x8f, 0xba, 0xfa, 0x45, 0x3a, 0xb0, 0x64, 0xa7, 0x98, 0x59, 0x9e, 0x11,
-	0xd7, 0x33, 0x6d, 0xcb, 0xb7, 0x94, 0x5d, 0xf4, 0x1c, 0x54, 0xf9, 0x7e, 0xd4, 0xfc, 0xef, 0x0c,
-	0x4a, 0x72, 0xb8, 0xc2, 0x85, 0x0f, 0xa4, 0xd2, 0x36, 0x94, 0x9d, 0x3d, 0x27, 0x50, 0xc9, 0x72,
-	0x15, 0x70, 0xf6, 0x1c, 0x5f, 0xe1, 0x59, 0xa8, 0xb0, 0xf9, 0x05, 0x1a, 0x39, 0xfe, 0x23, 0x65,
-	0x26, 0x93, 0x2a, 0xea, 0x9f, 0x33, 0x50, 0x9f, 0x85, 0x07, 0xf4, 0x06, 0xe4, 0x18, 0x52, 0x4a,
-	0xd0, 0x53, 0x76, 0x04, 0x8c, 0xee, 0xf8, 0x30, 0xba, 0xd3, 0xf3, 0x61, 0xb4, 0x59, 0xfc, 0xea,
-	0x9b, 0xed, 0xd4, 0x17, 0x7f, 0xdb, 0x4e, 0x63, 0x6e, 0x81, 0x9e, 0x62, 0xa7, 0x59, 0x37, 0x2d,
-	0xcd, 0x34, 0xf8, 0x90, 0x4b, 0xec, 0xa8, 0xea, 0xa6, 0x75, 0x60, 0xa0, 0x43, 0xa8, 0x0f, 0x6c,
-	0xcb, 0x23, 0x96, 0x37, 0xf5, 0x34, 0x01, 0xd3, 0x12, 0xea, 0x62, 0x07, 0x56, 0x80, 0x7f, 0xcb,
-	0xd7, 0x3c, 0xe6, 0x8a, 0x78, 0x73, 0x10, 0x17, 0xa0, 0xbb, 0x00, 0x67, 0xfa, 0xd8, 0x34, 0x74,
-	0x6a, 0xbb, 0x5e, 0x23, 0x77, 0x33, 0xbb, 0xf0, 0xd4, 0x3e, 0xf0, 0x55, 0xee, 0x3b, 0x86, 0x4e,
-	0x49, 0x33, 0xc7, 0x86, 0x8b, 0x23, 0x96, 0xe8, 0x05, 0xd8, 0xd4, 0x1d, 0x47, 0xf3, 0xa8, 0x4e,
-	0x89, 0xd6, 0xbf, 0xa0, 0xc4, 0xe3, 0x30, 0x58, 0xc1, 0x55, 0xdd, 0x71, 0x4e, 0x98, 0xb4, 0xc9,
-	0x84, 0xe8, 0x79, 0xa8, 0x31, 0xc4, 0x34, 0xf5, 0xb1, 0x36, 0x22, 0xe6, 0x70, 0x44, 0x39, 0xe0,
-	0x65, 0x71, 0x55, 0x4a, 0x3b, 0x5c, 0xa8, 0x1a, 0xc1, 0x8a, 0x73, 0xb4, 0x44, 0x08, 0x72, 0x86,
-	0x4e, 0x75, 0x1e, 0xc9, 0x0a, 0xe6, 0x6d, 0x26, 0x73, 0x74, 0x3a, 0x92, 0xf1, 0xe1, 0x6d, 0x74,
-	0x1d, 0x36, 0xa4, 0xdb, 0x2c, 0x77, 0x2b, 0x7b, 0xe8, 0x1a, 0xe4, 0x1d, 0xd7, 0x3e, 0x23, 0x7c,
-	0xe9, 0x8a, 0x58, 0x74, 0xd4, 0x5f, 0x67, 0xe0, 0xca, 0x1c, 0xae, 0x32, 0xbf, 0x23, 0xdd, 0x1b,
-	0xf9, 0xbf, 0xc5, 0xda, 0xe8, 0x0e, 0xf3, 0xab, 0x1b, 0xc4, 0x95, 0xb9, 0xa8, 0x31, 0x1f, 0xea,
-	0x0e, 0xff, 0x2e, 0x43, 0x23, 0xb5, 0x51, 0x17, 0xea, 0x63, 0xdd, 0xa3, 0x9a, 0xc0, 0x29, 0x2d,
-	0x92, 0x97, 0xe6, 0xd1, 0xf9, 0x50, 0xf7, 0x91, 0x8d, 0x6d, 0x6a, 0xe9, 0xa8, 0x36, 0x8e, 0x49,
-	0x11, 0x86, 0x6b, 0xfd, 0x8b, 0xcf, 0x74, 0x8b, 0x9a, 0x16, 0xd1, 0xe6, 0x56, 0xee, 0xa9, 0x39,
-	0xa7, 0xed, 0x33, 0xd3, 0x20, 0xd6, 0xc0, 0x5f, 0xb2, 0xab, 0x81, 0x71, 0xb0, 0xa4, 0x9e, 0x8a,
-	0xa1, 0x16, 0xcf, 0x0c, 0xa8, 0x06, 0x19, 0x7a, 0x2e, 0x03, 0x90, 0xa1, 0xe7, 0xe8, 0xfb, 0x90,
-	0x63, 0x93, 0xe4, 0x93, 0xaf, 0x2d, 0x48, 0xa9, 0xd2, 0xae, 0x77, 0xe1, 0x10, 0xcc, 0x35, 0x55,
-	0x35, 0x38, 0x0e, 0x41, 0xb6, 0x98, 0xf5, 0xaa, 0xbe, 0x08, 0x9b, 0x33, 0xe9, 0x20, 0xb2, 0x7e,
-	0xe9, 0xe8, 0xfa, 0xa9, 0x9b, 0x50, 0x8d, 0x61, 0xbf, 0x7a, 0x1d, 0xae, 0x2d, 0x82, 0x72, 0x75,
-	0x14, 0xc8, 0x63, 0x90, 0x8c, 0x5e, 0x87, 0x62, 0x80, 0xe5, 0xe2, 0x38, 0xce, 0xc7, 0xca, 0x57,
-	0xc6, 0x81, 0x2a, 0x3b, 0x87, 0x6c, 0x5b, 0xf3, 0xfd, 0x90, 0xe1, 0x03, 0x2f, 0xe8, 0x8e, 0xd3,
-	0xd1, 0xbd, 0x91, 0xfa, 0x11, 0x34, 0x92, 0x70, 0x7a, 0x66, 0x1a, 0xb9, 0x60, 0x1b, 0x5e, 0x87,
-	0x8d, 0x53, 0xdb, 0x9d, 0xe8, 0x94, 0x3b, 0xab, 0x62, 0xd9, 0x63, 0xdb, 0x53, 0x60, 0x76, 0x96,
-	0x8b, 0x45, 0x47, 0xd5, 0xe0, 0xa9, 0x44, 0xac, 0x66, 0x26, 0xa6, 0x65, 0x10, 0x11, 0xcf, 0x2a,
-	0x16, 0x9d, 0xd0, 0x91, 0x18, 0xac, 0xe8, 0xb0, 0x9f, 0xf5, 0xf8, 0x5c, 0xb9, 0xff, 0x12, 0x96,
-	0x3d, 0xf5, 0x56, 0x10, 0xac, 0x18, 0x64, 0xa3, 0x3a, 0x64, 0x19, 0xcc, 0xa7, 0x6f, 0x66, 0x6f,
-	0x55, 0x30, 0x6b, 0xaa, 0xff, 0x2c, 0x42, 0x11, 0x13, 0xcf, 0x61, 0xe8, 0x81, 0x9a, 0x50, 0x22,
-	0xe7, 0x03, 0xe2, 0x50, 0x1f, 0x70, 0x17, 0xf3, 0x15, 0xa1, 0xdd, 0xf6, 0x35, 0x19, 0x59, 0x08,
-	0xcc, 0xd0, 0x6b, 0x92, 0x0f, 0x26, 0x53, 0x3b, 0x69, 0x1e, 0x25, 0x84, 0x77, 0x7c, 0x42, 0x98,
-	0x4d, 0xe4, 0x07, 0xc2, 0x6a, 0x86, 0x11, 0xbe, 0x26, 0x19, 0x61, 0x6e, 0xc5, 0x8f, 0xc5, 0x28,
-	0x61, 0x2b, 0x46, 0x09, 0xf3, 0x2b, 0xa6, 0x99, 0xc0, 0x09, 0xef, 0xf8, 0x9c, 0x70, 0x63, 0xc5,
-	0x88, 0x67, 0x48, 0xe1, 0xdd, 0x38, 0x29, 0x14, 0x84, 0xee, 0xb9, 0x44, 0xeb, 0x44, 0x56, 0xf8,
-	0xa3, 0x08, 0x2b, 0x2c, 0x26, 0x52, 0x32, 0xe1, 0x64, 0x01, 0x2d, 0x6c, 0xc5, 0x68, 0x61, 0x69,
-	0x45, 0x0c, 0x12, 0x78, 0xe1, 0xdb, 0x51, 0x5e, 0x08, 0x89, 0xd4, 0x52, 0xae, 0xf7, 0x22, 0x62,
-	0xf8, 0x66, 0x40, 0x0c, 0xcb, 0x89, 0xcc, 0x56, 0xce, 0x61, 0x96, 0x19, 0x76, 0xe7, 0x98, 0xa1,
-	0x60, 0x72, 0x2f, 0x24, 0xba, 0x58, 0x41, 0x0d, 0xbb, 0x73, 0xd4, 0xb0, 0xba, 0xc2, 0xe1, 0x0a,
-	0x6e, 0xf8, 0xcb, 0xc5, 0xdc, 0x30, 0x99, 0xbd, 0xc9, 0x61, 0xae, 0x47, 0x0e, 0xb5, 0x04, 0x72,
-	0x28, 0x28, 0xdc, 0x4b, 0x89, 0xee, 0xd7, 0x66, 0x87, 0xdd, 0x39, 0x76, 0x58, 0x5f, 0x11, 0x8f,
-	0x75, 0xe9, 0xe1, 0x8b, 0x2c, 0x39, 0xcf, 0x80, 0x08, 0x03, 0x38, 0xe2, 0xba, 0xb6, 0x2b, 0x89,
-	0x9e, 0xe8, 0xa8, 0xb7, 0x18, 0x5d, 0x08, 0x01, 0x63, 0x09, 0x95, 0xe4, 0x89, 0x24, 0x02, 0x12,
-	0xea, 0x1f, 0xd2, 0xa1, 0x2d, 0xcf, 0xb0, 0x51, 0xaa, 0x51, 0x92, 0x54, 0x23, 0x42, 0x30, 0x33,
-	0x71, 0x82, 0xb9, 0x0d, 0x65, 0x96, 0x20, 0x66, 0xb8, 0xa3, 0xee, 0x04, 0xdc, 0xf1, 0x36, 0x5c,
-	0xe1, 0x0c, 0x40, 0xd0, 0x50, 0x99, 0x15, 0x72, 0x3c, 0xb9, 0x6d, 0xb2, 0x0f, 0x62, 0xb7, 0x8b,
-	0xf4, 0xf0, 0x0a, 0x5c, 0x8d, 0xe8, 0x06, 0x89, 0x47, 0x10, 0xa9, 0x7a, 0xa0, 0xbd, 0x2f, 0x33,
-	0xd0, 0x9f, 0xd2, 0x61, 0x84, 0x42, 0xd2, 0xb9, 0x88, 0x1f, 0xa6, 0xff, 0x47, 0xfc, 0x30, 0xf3,
-	0x5f, 0xf3, 0xc3, 0x68, 0x22, 0xcd, 0xc6, 0x13, 0xe9, 0xbf, 0xd2, 0xe1, 0x9a, 0x04, 0x6c, 0x6f,
-	0x60, 0x1b, 0x44, 0xa6, 0x36, 0xde, 0x66, 0x39, 0x69, 0x6c, 0x0f, 0x65, 0x02, 0x63, 0x4d, 0xa6,
-	0x15, 0xa0, 0x7a, 0x49, 0x82, 0x76, 0x90, 0x15, 0xf3, 0x3c, 0xc2, 0x32, 0x2b, 0xd6, 0x21, 0xfb,
-	0x88, 0x08, 0x0c, 0xae, 0x60, 0xd6, 0x64, 0x7a, 0x7c, 0x93, 0x71, 0x64, 0xad, 0x60, 0xd1, 0x41,
-	0x6f, 0x40, 0x89, 0x57, 0x54, 0x34, 0xdb, 0xf1, 0x24, 0x5c, 0x3e, 0x1d, 0x9d, 0xab, 0x28, 0x9c,
-	0xec, 0x1c, 0x33, 0x9d, 0xae, 0xe3, 0xe1, 0xa2, 0x23, 0x5b, 0x91, 0x84, 0x5f, 0x8a, 0xf1, 0xce,
-	0x1b, 0x50, 0x62, 0xa3, 0xf7, 0x1c, 0x7d, 0x40, 0x38, 0xf6, 0x95, 0x70, 0x28, 0x50, 0x1f, 0x02,
-	0x9a, 0x47, 0x70, 0xd4, 0x81, 0x0d, 0x72, 0x46, 0x2c, 0x2a, 0x12, 0x70, 0x79, 0xef, 0xfa, 0x02,
-	0x52, 0x47, 0x2c, 0xda, 0x6c, 0xb0, 0x20, 0xff, 0xe3, 0x9b, 0xed, 0xba, 0xd0, 0x7e, 0xd9, 0x9e,
-	0x98, 0x94, 0x4c, 0x1c, 0x7a, 0x81, 0xa5, 0xbd, 0xfa, 0xd7, 0x0c, 0x63, 0x58, 0x31, 0x74, 0x5f,
-	0x18, 0x5b, 0x7f, 0xcb, 0x67, 0x22, 0xec, 0x7a, 0xbd, 0x78, 0x6f, 0x01, 0x0c, 0x75, 0x4f, 0xfb,
-	0x54, 0xb7, 0x28, 0x31, 0x64, 0xd0, 0x23, 0x12, 0xa4, 0x40, 0x91, 0xf5, 0xa6, 0x1e, 0x31, 0x24,
-	0xd1, 0x0f, 0xfa, 0x91, 0x79, 0x16, 0xbe, 0xdb, 0x3c, 0xe3, 0x51, 0x2e, 0xce, 0x44, 0x39, 0xc2,
-	0x7e, 0x4a, 0x51, 0xf6, 0xc3, 0xc6, 0xe6, 0xb8, 0xa6, 0xed, 0x9a, 0xf4, 0x82, 0x2f, 0x4d, 0x16,
-	0x07, 0x7d, 0x76, 0x6f, 0x9c, 0x90, 0x89, 0x63, 0xdb, 0x63, 0x4d, 0xc0, 0x4d, 0x99, 0x9b, 0x56,
-	0xa4, 0xb0, 0xcd, 0x51, 0xe7, 0x37, 0x99, 0xf0, 0xfc, 0x85, 0x2c, 0xf7, 0xff, 0x2e, 0xc0, 0xea,
-	0x6f, 0xf9, 0xdd, 0x37, 0x9e, 0xbf, 0xd1, 0x09, 0x5c, 0x09, 0x8e, 0xbf, 0x36, 0xe5, 0xb0, 0xe0,
-	0x6f, 0xe8, 0x75, 0xf1, 0xa3, 0x7e, 0x16, 0x17, 0x7b, 0xe8, 0x03, 0x78, 0x72, 0x06, 0xdb, 0x02,
-	0xd7, 0x99, 0x75, 0x21, 0xee, 0x89, 0x38, 0xc4, 0xf9, 0xae, 0xc3, 0x60, 0x65, 0xbf, 0xe3, 0xa9,
-	0x3b, 0x60, 0xd7, 0xa9, 0x28, 0x1d, 0x59, 0xb8, 0xfc, 0xcf, 0x41, 0xd5, 0x25, 0x94, 0x5d, 0xf1,
-	0x63, 0x17, 0xd6, 0x8a, 0x10, 0xca, 0x6b, 0xf0, 0x31, 0x3c, 0xb1, 0x90, 0x96, 0xa0, 0x1f, 0x40,
-	0x29, 0x64, 0x34, 0xe9, 0x84, 0xbb, 0x5f, 0x70, 0x9f, 0x09, 0x75, 0xd5, 0x3f, 0xa6, 0x43, 0x97,
-	0xf1, 0x1b, 0x52, 0x1b, 0x36, 0x5c, 0xe2, 0x4d, 0xc7, 0xe2, 0xce, 0x52, 0xdb, 0x7b, 0x65, 0x3d,
-	0x42, 0xc3, 0xa4, 0xd3, 0x31, 0xc5, 0xd2, 0x58, 0x7d, 0x08, 0x1b, 0x42, 0x82, 0xca, 0x50, 0xb8,
-	0x7f, 0x74, 0xef, 0xa8, 0xfb, 0xfe, 0x51, 0x3d, 0x85, 0x00, 0x36, 0xf6, 0x5b, 0xad, 0xf6, 0x71,
-	0xaf, 0x9e, 0x46, 0x25, 0xc8, 0xef, 0x37, 0xbb, 0xb8, 0x57, 0xcf, 0x30, 0x31, 0x6e, 0xbf, 0xdb,
-	0x6e, 0xf5, 0xea, 0x59, 0x74, 0x05, 0xaa, 0xa2, 0xad, 0xdd, 0xed, 0xe2, 0xf7, 0xf6, 0x7b, 0xf5,
-	0x5c, 0x44, 0x74, 0xd2, 0x3e, 0x7a, 0xa7, 0x8d, 0xeb, 0x79, 0xf5, 0x55, 0x76, 0x29, 0x4a, 0xa0,
-	0x40, 0xe1, 0xf5, 0x27, 0x1d, 0xb9, 0xfe, 0xa8, 0xbf, 0xcf, 0x80, 0x92, 0xcc, 0x6b, 0xd0, 0xbb,
-	0x33, 0x13, 0xdf, 0xbb, 0x04, 0x29, 0x9a, 0x99, 0x3d, 0x7a, 0x1e, 0x6a, 0x2e, 0x39, 0x25, 0x74,
-	0x30, 0x12, 0x3c, 0x4b, 0xa4, 0xcc, 0x2a, 0xae, 0x4a, 0x29, 0x37, 0xf2, 0x84, 0xda, 0xc7, 0x64,
-	0x40, 0x35, 0x81, 0x45, 0x62, 0xd3, 0x95, 0x98, 0x1a, 0x93, 0x9e, 0x08, 0xa1, 0xfa, 0xd1, 0xa5,
-	0x62, 0x59, 0x82, 0x3c, 0x6e, 0xf7, 0xf0, 0x07, 0xf5, 0x2c, 0x42, 0x50, 0xe3, 0x4d, 0xed, 0xe4,
-	0x68, 0xff, 0xf8, 0xa4, 0xd3, 0x65, 0xb1, 0xbc, 0x0a, 0x9b, 0x7e, 0x2c, 0x7d, 0x61, 0x5e, 0xd5,
-	0xc3, 0xdd, 0xb0, 0xe2, 0x0a, 0x88, 0xee, 0x40, 0x51, 0x92, 0x28, 0xff, 0xb0, 0x29, 0xf3, 0x87,
-	0xed, 0x3d, 0xa9, 0x81, 0x03, 0x5d, 0xf5, 0x43, 0xa8, 0xc5, 0x2b, 0x1b, 0x6c, 0x95, 0x5c, 0x7b,
-	0x6a, 0x19, 0x3c, 0xde, 0x79, 0x2c, 0x3a, 0xe8, 0x75, 0xc8, 0x9f, 0xd9, 0xe2, 0x24, 0x2f, 0xde,
-	0xce, 0x0f, 0x6c, 0x4a, 0x22, 0x95, 0x11, 0xa1, 0xad, 0x7e, 0x06, 0x79, 0x7e, 0x30, 0xd9, 0x21,
-	0xe3, 0x35, 0x0a, 0xc9, 0xdb, 0x58, 0x1b, 0x7d, 0x08, 0xa0, 0x53, 0xea, 0x9a, 0xfd, 0x69, 0xe8,
-	0x78, 0x7b, 0xf1, 0xc1, 0xde, 0xf7, 0xf5, 0x9a, 0x37, 0xe4, 0x09, 0xbf, 0x16, 0x9a, 0x46, 0x4e,
-	0x79, 0xc4, 0xa1, 0x7a, 0x04, 0xb5, 0xb8, 0xad, 0xcf, 0x34, 0xc4, 0x18, 0xe2, 0x4c, 0x43, 0x10,
-	0x47, 0xc9, 0x34, 0x02, 0x9e, 0x92, 0x15, 0xf5, 0x28, 0xde, 0x51, 0x3f, 0x4f, 0x43, 0xb1, 0x77,
-	0x2e, 0x97, 0x3c, 0xa1, 0x14, 0x12, 0x9a, 0x66, 0xa2, 0x17, 0x7f, 0x51, 0x5b, 0xc9, 0x06, 0x15,
-	0x9b, 0xb7, 0x83, 0x4d, 0x9d, 0x5b, 0xf7, 0xd6, 0xe6, 0x97, 0xae, 0xe4, 0x41, 0x7e, 0x0b, 0x4a,
-	0x01, 0x2c, 0x33, 0x02, 0xac, 0x1b, 0x86, 0x4b, 0x3c, 0x4f, 0x1e, 0x2d, 0xbf, 0xcb, 0x2b, 0x6b,
-	0xf6, 0xa7, 0xb2, 0xb4, 0x90, 0xc5, 0xa2, 0xa3, 0x1a, 0xb0, 0x39, 0x83, 0xe9, 0xe8, 0x2d, 0x28,
-	0x38, 0xd3, 0xbe, 0xe6, 0x87, 0x67, 0xe6, 0x65, 0xc6, 0xa7, 0x56, 0xd3, 0xfe, 0xd8, 0x1c, 0xdc,
-	0x23, 0x17, 0xfe, 0x60, 0x9c, 0x69, 0xff, 0x9e, 0x88, 0xa2, 0xf8, 0x95, 0x4c, 0xf4, 0x57, 0xce,
-	0xa0, 0xe8, 0x6f, 0x0a, 0xf4, 0x63, 0x28, 0x05, 0xe9, 0x22, 0x28, 0xb8, 0x26, 0xe6, 0x19, 0xe9,
-	0x3e, 0x34, 0x61, 0x3c, 0xdd, 0x33, 0x87, 0x16, 0x31, 0xb4, 0x90, 0x82, 0xf3, 0x5f, 0x2b, 0xe2,
-	0x4d, 0xf1, 0xe1, 0xd0, 0xe7, 0xdf, 0xea, 0xbf, 0xd3, 0x50, 0xf4, 0x0b, 0x6b, 0xe8, 0xd5, 0xc8,
-	0xbe, 0xab, 0x2d, 0x28, 0x2e, 0xf8, 0x8a, 0x61, 0x71, 0x2c, 0x3e, 0xd6, 0xcc, 0xe5, 0xc7, 0x9a,
-	0x54, 0xe5, 0xf4, 0xeb, 0xcd, 0xb9, 0x4b, 0xd7, 0x9b, 0x5f, 0x06, 0x44, 0x6d, 0xaa, 0x8f, 0xb5,
-	0x33, 0x9b, 0x9a, 0xd6, 0x50, 0x13, 0xc1, 0x16, 0x74, 0xa3, 0xce, 0xbf, 0x3c, 0xe0, 0x1f, 0x8e,
-	0x79, 0xdc, 0x7f, 0x95, 0x86, 0x62, 0x90, 0x37, 0x2e, 0x5b, 0xeb, 0xba, 0x0e, 0x1b, 0x12, 0x1a,
-	0x45, 0xb1, 0x4b, 0xf6, 0x82, 0xb2, 0x6b, 0x2e, 0x52, 0x76, 0x55, 0x18, 0xe6, 0x50, 0x9d, 0x27,
-	0x4f, 0x71, 0x0b, 0x0a, 0xfa, 0xb7, 0xdf, 0x84, 0x72, 0xa4, 0xec, 0xc8, 0x4e, 0xde, 0x51, 0xfb,
-	0xfd, 0x7a, 0x4a, 0x29, 0x7c, 0xfe, 0xe5, 0xcd, 0xec, 0x11, 0xf9, 0x94, 0xed, 0x59, 0xdc, 0x6e,
-	0x75, 0xda, 0xad, 0x7b, 0xf5, 0xb4, 0x52, 0xfe, 0xfc, 0xcb, 0x9b, 0x05, 0x4c, 0x78, 0x61, 0xe3,
-	0x76, 0x07, 0x2a, 0xd1, 0x55, 0x89, 0xa3, 0x2b, 0x82, 0xda, 0x3b, 0xf7, 0x8f, 0x0f, 0x0f, 0x5a,
-	0xfb, 0xbd, 0xb6, 0xf6, 0xa0, 0xdb, 0x6b, 0xd7, 0xd3, 0xe8, 0x49, 0xb8, 0x7a, 0x78, 0xf0, 0xd3,
-	0x4e, 0x4f, 0x6b, 0x1d, 0x1e, 0xb4, 0x8f, 0x7a, 0xda, 0x7e, 0xaf, 0xb7, 0xdf, 0xba, 0x57, 0xcf,
-	0xec, 0xfd, 0x0e, 0x60, 0x73, 0xbf, 0xd9, 0x3a, 0x60, 0x99, 0xc1, 0x1c, 0xe8, 0xfc, 0x8a, 0xda,
-	0x82, 0x1c, 0xbf, 0x84, 0x2e, 0x7d, 0xe4, 0x54, 0x96, 0x97, 0xbc, 0xd0, 0x5d, 0xc8, 0xf3, 0xfb,
-	0x29, 0x5a, 0xfe, 0xea, 0xa9, 0xac, 0xa8, 0x81, 0xb1, 0xc1, 0xf0, 0xe3, 0xb1, 0xf4, 0x19, 0x54,
-	0x59, 0x5e, 0x12, 0x43, 0x18, 0x4a, 0x21, 0xbf, 0x5d, 0xfd, 0x2c, 0xa8, 0xac, 0x01, 0x36, 0xe8,
-	0x10, 0x0a, 0xfe, 0x95, 0x64, 0xd5, 0x43, 0xa5, 0xb2, 0xb2, 0x66, 0xc5, 0xc2, 0x25, 0xae, 0x8e,
-	0xcb, 0x5f, 0x5d, 0x95, 0x15, 0x05, 0x38, 0x74, 0x00, 0x1b, 0x92, 0xb3, 0xad, 0x78, 0x7c, 0x54,
-	0x56, 0xd5, 0xa0, 0x58, 0xd0, 0xc2, 0x4b, 0xf9, 0xea, 0xb7, 0x64, 0x65, 0x8d, 0xda, 0x22, 0xba,
-	0x0f, 0x10, 0xb9, 0x28, 0xae, 0xf1, 0x48, 0xac, 0xac, 0x53, 0x33, 0x44, 0x5d, 0x28, 0x06, 0xbc,
-	0x7d, 0xe5, 0x93, 0xad, 0xb2, 0xba, 0x78, 0x87, 0x1e, 0x42, 0x35, 0xce, 0x57, 0xd7, 0x7b, 0x88,
-	0x55, 0xd6, 0xac, 0xca, 0x31, 0xff, 0x71, 0xf2, 0xba, 0xde, 0xc3, 0xac, 0xb2, 0x66, 0x91, 0x0e,
-	0x7d, 0x0c, 0x57, 0xe6, 0xc9, 0xe5, 0xfa, 0xef, 0xb4, 0xca, 0x25, 0xca, 0x76, 0x68, 0x02, 0x68,
-	0x01, 0x29, 0xbd, 0xc4, 0xb3, 0xad, 0x72, 0x99, 0x2a, 0x1e, 0x0b, 0x5d, 0x9c, 0xe9, 0xad, 0xf7,
-	0x8c, 0xab, 0xac, 0x59, 0xcf, 0x6b, 0xb6, 0xbf, 0xfa, 0x76, 0x2b, 0xfd, 0xf5, 0xb7, 0x5b, 0xe9,
-	0xbf, 0x7f, 0xbb, 0x95, 0xfe, 0xe2, 0xf1, 0x56, 0xea, 0xeb, 0xc7, 0x5b, 0xa9, 0xbf, 0x3c, 0xde,
-	0x4a, 0xfd, 0xfc, 0xa5, 0xa1, 0x49, 0x47, 0xd3, 0xfe, 0xce, 0xc0, 0x9e, 0xec, 0x46, 0xff, 0x6f,
-	0xb2, 0xe8, 0x3f, 0x30, 0xfd, 0x0d, 0x9e, 0xb4, 0x5e, 0xfb, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x4a, 0xe7, 0x9d, 0x11, 0x23, 0x23, 0x00, 0x00,
+	// 3025 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcf, 0x73, 0x23, 0xc5,
+	0xf5, 0xd7, 0x4f, 0x4b, 0x7a, 0xfa, 0xe9, 0x5e, 0xef, 0xae, 0x76, 0x58, 0xec, 0x65, 0x28, 0x60,
+	0x77, 0x01, 0xfb, 0x8b, 0x29, 0xf6, 0x0b, 0x45, 0x02, 0xd8, 0x42, 0x8b, 0xcc, 0x1a, 0xdb, 0x69,
+	0x6b, 0x97, 0x22, 0x09, 0x3b, 0x8c, 0xa4, 0xb6, 0x34, 0xac, 0x34, 0x33, 0xcc, 0x8c, 0x8c, 0xcd,
+	0x31, 0x3f, 0x2e, 0x5b, 0x39, 0x70, 0x4c, 0x0e, 0x5c, 0xf3, 0x67, 0xe4, 0x94, 0x03, 0x87, 0x1c,
+	0x38, 0xe4, 0x90, 0x4a, 0xa5, 0x48, 0x0a, 0x6e, 0xf9, 0x07, 0x72, 0x4a, 0x55, 0xaa, 0x7f, 0x8d,
+	0x66, 0xa4, 0x19, 0x49, 0x0e, 0xe4, 0xc4, 0xad, 0xfb, 0xcd, 0x7b, 0xaf, 0xbb, 0x5f, 0x77, 0xbf,
+	0xf7, 0x3e, 0x6f, 0x1a, 0x9e, 0xf0, 0x88, 0xd9, 0x23, 0xce, 0xc8, 0x30, 0xbd, 0x2d, 0xbd, 0xd3,
+	0x35, 0xb6, 0xbc, 0x73, 0x9b, 0xb8, 0x9b, 0xb6, 0x63, 0x79, 0x16, 0xaa, 0x4e, 0x3e, 0x6e, 0xd2,
+	0x8f, 0xca, 0x93, 0x01, 0xee, 0xae, 0x73, 0x6e, 0x7b, 0xd6, 0x96, 0xed, 0x58, 0xd6, 0x09, 0xe7,
+	0x57, 0xae, 0x07, 0x3e, 0x33, 0x3d, 0x41, 0x6d, 0xca, 0xf5, 0x59, 0xe1, 0x47, 0xe4, 0x5c, 0x7e,
+	0x7d, 0x72, 0x46, 0xd6, 0xd6, 0x1d, 0x7d, 0x24, 0x3f, 0x6f, 0xf4, 0x2d, 0xab, 0x3f, 0x24, 0x5b,
+	0xac, 0xd7, 0x19, 0x9f, 0x6c, 0x79, 0xc6, 0x88, 0xb8, 0x9e, 0x3e, 0xb2, 0x05, 0xc3, 0x5a, 0xdf,
+	0xea, 0x5b, 0xac, 0xb9, 0x45, 0x5b, 0x9c, 0xaa, 0xfe, 0x12, 0x20, 0x87, 0xc9, 0x27, 0x63, 0xe2,
+	0x7a, 0x68, 0x1b, 0x32, 0xa4, 0x3b, 0xb0, 0xea, 0xc9, 0x1b, 0xc9, 0x9b, 0xc5, 0xed, 0xeb, 0x9b,
+	0x53, 0x8b, 0xdb, 0x14, 0x7c, 0xcd, 0xee, 0xc0, 0x6a, 0x25, 0x30, 0xe3, 0x45, 0xaf, 0x40, 0xf6,
+	0x64, 0x38, 0x76, 0x07, 0xf5, 0x14, 0x13, 0x7a, 0x32, 0x4e, 0xe8, 0x2e, 0x65, 0x6a, 0x25, 0x30,
+	0xe7, 0xa6, 0x43, 0x19, 0xe6, 0x89, 0x55, 0x4f, 0xcf, 0x1f, 0x6a, 0xcf, 0x3c, 0x61, 0x43, 0x51,
+	0x5e, 0xb4, 0x0b, 0x60, 0x98, 0x86, 0xa7, 0x75, 0x07, 0xba, 0x61, 0xd6, 0x33, 0x4c, 0xf2, 0xa9,
+	0x78, 0x49, 0xc3, 0x6b, 0x50, 0xc6, 0x56, 0x02, 0x17, 0x0c, 0xd9, 0xa1, 0xd3, 0xfd, 0x64, 0x4c,
+	0x9c, 0xf3, 0x7a, 0x76, 0xfe, 0x74, 0x7f, 0x42, 0x99, 0xe8, 0x74, 0x19, 0x37, 0x6a, 0x42, 0xb1,
+	0x43, 0xfa, 0x86, 0xa9, 0x75, 0x86, 0x56, 0xf7, 0x51, 0x7d, 0x85, 0x09, 0xab, 0x71, 0xc2, 0xbb,
+	0x94, 0x75, 0x97, 0x72, 0xb6, 0x12, 0x18, 0x3a, 0x7e, 0x0f, 0xfd, 0x08, 0xf2, 0xdd, 0x01, 0xe9,
+	0x3e, 0xd2, 0xbc, 0xb3, 0x7a, 0x8e, 0xe9, 0xd8, 0x88, 0xd3, 0xd1, 0xa0, 0x7c, 0xed, 0xb3, 0x56,
+	0x02, 0xe7, 0xba, 0xbc, 0x49, 0xd7, 0xdf, 0x23, 0x43, 0xe3, 0x94, 0x38, 0x54, 0x3e, 0x3f, 0x7f,
+	0xfd, 0x6f, 0x73, 0x4e, 0xa6, 0xa1, 0xd0, 0x93, 0x1d, 0xf4, 0x26, 0x14, 0x88, 0xd9, 0x13, 0xcb,
+	0x28, 0x30, 0x15, 0x37, 0x62, 0xf7, 0xd9, 0xec, 0xc9, 0x45, 0xe4, 0x89, 0x68, 0xa3, 0x57, 0x61,
+	0xa5, 0x6b, 0x8d, 0x46, 0x86, 0x57, 0x07, 0x26, 0xbd, 0x1e, 0xbb, 0x00, 0xc6, 0xd5, 0x4a, 0x60,
+	0xc1, 0x8f, 0x0e, 0xa0, 0x32, 0x34, 0x5c, 0x4f, 0x73, 0x4d, 0xdd, 0x76, 0x07, 0x96, 0xe7, 0xd6,
+	0x8b, 0x4c, 0xc3, 0x33, 0x71, 0x1a, 0xf6, 0x0d, 0xd7, 0x3b, 0x96, 0xcc, 0xad, 0x04, 0x2e, 0x0f,
+	0x83, 0x04, 0xaa, 0xcf, 0x3a, 0x39, 0x21, 0x8e, 0xaf, 0xb0, 0x5e, 0x9a, 0xaf, 0xef, 0x90, 0x72,
+	0x4b, 0x79, 0xaa, 0xcf, 0x0a, 0x12, 0xd0, 0xcf, 0xe0, 0xd2, 0xd0, 0xd2, 0x7b, 0xbe, 0x3a, 0xad,
+	0x3b, 0x18, 0x9b, 0x8f, 0xea, 0x65, 0xa6, 0xf4, 0x56, 0xec, 0x24, 0x2d, 0xbd, 0x27, 0x55, 0x34,
+	0xa8, 0x40, 0x2b, 0x81, 0x57, 0x87, 0xd3, 0x44, 0xf4, 0x10, 0xd6, 0x74, 0xdb, 0x1e, 0x9e, 0x4f,
+	0x6b, 0xaf, 0x30, 0xed, 0xb7, 0xe3, 0xb4, 0xef, 0x50, 0x99, 0x69, 0xf5, 0x48, 0x9f, 0xa1, 0x52,
+	0x63, 0xd8, 0x0e, 0xb1, 0x1d, 0xab, 0x4b, 0x5c, 0x57, 0xf3, 0xce, 0xdc, 0x7a, 0x75, 0xbe, 0x31,
+	0x8e, 0x7c, 0xee, 0xf6, 0x19, 0x33, 0xae, 0x1d, 0x24, 0x50, 0x7d, 0x27, 0x86, 0xa9, 0x0f, 0x8d,
+	0xcf, 0x88, 0x38, 0x2c, 0xb5, 0xf9, 0xfa, 0xee, 0x0a, 0x6e, 0x79, 0x62, 0xca, 0x27, 0x41, 0x02,
+	0x6a, 0x43, 0x4d, 0x4e, 0xce, 0x76, 0x2c, 0xdb, 0x72, 0xf5, 0x61, 0x7d, 0x95, 0x69, 0x7c, 0x2e,
+	0x7e, 0x86, 0x8c, 0xff, 0x48, 0xb0, 0xb7, 0x12, 0xb8, 0x6a, 0x87, 0x49, 0xe8, 0x0d, 0xc8, 0x3b,
+	0xd6, 0x70, 0xd8, 0xd1, 0xbb, 0x8f, 0xea, 0x68, 0xfe, 0x61, 0xc6, 0x82, 0x8f, 0x1e, 0x66, 0x29,
+	0xb3, 0x9b, 0x83, 0xec, 0xa9, 0x3e, 0x1c, 0x13, 0xf5, 0x39, 0x28, 0x06, 0x9c, 0x1b, 0xaa, 0x43,
+	0x6e, 0x44, 0x5c, 0x57, 0xef, 0x13, 0xe6, 0x0b, 0x0b, 0x58, 0x76, 0xd5, 0x0a, 0x94, 0x82, 0x0e,
+	0x4d, 0xfd, 0x3c, 0x09, 0xc5, 0x80, 0xaf, 0xa2, 0x92, 0xa7, 0xc4, 0x71, 0x0d, 0xcb, 0x94, 0x92,
+	0xa2, 0x8b, 0x9e, 0x86, 0x32, 0x33, 0xa4, 0x26, 0xbf, 0x53, 0x87, 0x99, 0xc1, 0x25, 0x46, 0x7c,
+	0x20, 0x98, 0x36, 0xa0, 0x68, 0x6f, 0xdb, 0x3e, 0x4b, 0x9a, 0xb1, 0x80, 0xbd, 0x6d, 0x4b, 0x86,
+	0xa7, 0xa0, 0x44, 0x57, 0xe5, 0x73, 0x64, 0xd8, 0x20, 0x45, 0x4a, 0x13, 0x2c, 0xea, 0x9f, 0x52,
+	0x50, 0x9b, 0x76, 0x82, 0xe8, 0x55, 0xc8, 0xd0, 0x78, 0x20, 0x5c, 0xbb, 0xb2, 0xc9, 0x83, 0xc5,
+	0xa6, 0x0c, 0x16, 0x9b, 0x6d, 0x19, 0x2c, 0x76, 0xf3, 0x5f, 0x7e, 0xbd, 0x91, 0xf8, 0xfc, 0xef,
+	0x1b, 0x49, 0xcc, 0x24, 0xd0, 0x35, 0xea, 0xb3, 0x74, 0xc3, 0xd4, 0x8c, 0x1e, 0x9b, 0x72, 0x81,
+	0x3a, 0x24, 0xdd, 0x30, 0xf7, 0x7a, 0x68, 0x1f, 0x6a, 0x5d, 0xcb, 0x74, 0x89, 0xe9, 0x8e, 0x5d,
+	0x8d, 0x07, 0xa3, 0x7a, 0x7a, 0xd6, 0x2d, 0xf1, 0x10, 0xd7, 0x90, 0x9c, 0x47, 0x8c, 0x11, 0x57,
+	0xbb, 0x61, 0x02, 0xba, 0x0b, 0x70, 0xaa, 0x0f, 0x8d, 0x9e, 0xee, 0x59, 0x8e, 0x5b, 0xcf, 0xdc,
+	0x48, 0x47, 0x6e, 0xe7, 0x03, 0xc9, 0x72, 0xdf, 0xee, 0xe9, 0x1e, 0xd9, 0xcd, 0xd0, 0xe9, 0xe2,
+	0x80, 0x24, 0x7a, 0x16, 0xaa, 0xba, 0x6d, 0x6b, 0xae, 0xa7, 0x7b, 0x44, 0xeb, 0x9c, 0x7b, 0xc4,
+	0x65, 0xce, 0xbe, 0x84, 0xcb, 0xba, 0x6d, 0x1f, 0x53, 0xea, 0x2e, 0x25, 0xa2, 0x67, 0xa0, 0x42,
+	0xe3, 0x82, 0xa1, 0x0f, 0xb5, 0x01, 0x31, 0xfa, 0x03, 0x8f, 0xb9, 0xf5, 0x34, 0x2e, 0x0b, 0x6a,
+	0x8b, 0x11, 0xd5, 0x1e, 0x94, 0x82, 0x31, 0x01, 0x21, 0xc8, 0xf4, 0x74, 0x4f, 0x67, 0x96, 0x2c,
+	0x61, 0xd6, 0xa6, 0x34, 0x5b, 0xf7, 0x06, 0xc2, 0x3e, 0xac, 0x8d, 0xae, 0xc0, 0x8a, 0x50, 0x9b,
+	0x66, 0x6a, 0x45, 0x0f, 0xad, 0x41, 0xd6, 0x76, 0xac, 0x53, 0xc2, 0xb6, 0x2e, 0x8f, 0x79, 0x47,
+	0xfd, 0x55, 0x0a, 0x56, 0x67, 0xa2, 0x07, 0xd5, 0x3b, 0xd0, 0xdd, 0x81, 0x1c, 0x8b, 0xb6, 0xd1,
+	0x1d, 0xaa, 0x57, 0xef, 0x11, 0x47, 0x44, 0xdc, 0xfa, 0xac, 0xa9, 0x5b, 0xec, 0xbb, 0x30, 0x8d,
+	0xe0, 0x46, 0x87, 0x50, 0x1b, 0xea, 0xae, 0xa7, 0x71, 0x6f, 0xac, 0x05, 0xa2, 0xef, 0x6c, 0x0c,
+	0xda, 0xd7, 0xa5, 0xff, 0xa6, 0x87, 0x5a, 0x28, 0xaa, 0x0c, 0x43, 0x54, 0x84, 0x61, 0xad, 0x73,
+	0xfe, 0x99, 0x6e, 0x7a, 0x86, 0x49, 0xb4, 0x99, 0x9d, 0xbb, 0x36, 0xa3, 0xb4, 0x79, 0x6a, 0xf4,
+	0x88, 0xd9, 0x95, 0x5b, 0x76, 0xc9, 0x17, 0xf6, 0xb7, 0xd4, 0x55, 0x31, 0x54, 0xc2, 0xf1, 0x0f,
+	0x55, 0x20, 0xe5, 0x9d, 0x09, 0x03, 0xa4, 0xbc, 0x33, 0xf4, 0x7f, 0x90, 0xa1, 0x8b, 0x64, 0x8b,
+	0xaf, 0x44, 0x24, 0x0e, 0x42, 0xae, 0x7d, 0x6e, 0x13, 0xcc, 0x38, 0x55, 0x15, 0x6a, 0xd3, 0x31,
+	0x71, 0x5a, 0xab, 0x7a, 0x0b, 0xaa, 0x53, 0x41, 0x2f, 0xb0, 0x7f, 0xc9, 0xe0, 0xfe, 0xa9, 0x55,
+	0x28, 0x87, 0x22, 0x9c, 0x7a, 0x05, 0xd6, 0xa2, 0x02, 0x96, 0x3a, 0x80, 0xb5, 0xa8, 0xc0, 0x83,
+	0x5e, 0x81, 0xbc, 0x1f, 0xb1, 0xf8, 0x75, 0x9c, 0xb5, 0x95, 0x64, 0xc6, 0x3e, 0x2b, 0xbd, 0x87,
+	0xf4, 0x58, 0xb3, 0xf3, 0x90, 0x62, 0x13, 0xcf, 0xe9, 0xb6, 0xdd, 0xd2, 0xdd, 0x81, 0xfa, 0x11,
+	0xd4, 0xe3, 0xa2, 0xd1, 0xd4, 0x32, 0x32, 0xfe, 0x31, 0xbc, 0x02, 0x2b, 0x27, 0x96, 0x33, 0xd2,
+	0x3d, 0xa6, 0xac, 0x8c, 0x45, 0x8f, 0x1e, 0x4f, 0x1e, 0x99, 0xd2, 0x8c, 0xcc, 0x3b, 0xaa, 0x06,
+	0xd7, 0x62, 0x23, 0x12, 0x15, 0x31, 0xcc, 0x1e, 0xe1, 0xf6, 0x2c, 0x63, 0xde, 0x99, 0x28, 0xe2,
+	0x93, 0xe5, 0x1d, 0x3a, 0xac, 0xcb, 0xd6, 0xca, 0xf4, 0x17, 0xb0, 0xe8, 0xa9, 0x0f, 0x61, 0x2d,
+	0x2a, 0x30, 0xa1, 0x1a, 0xa4, 0x69, 0x30, 0x4b, 0xde, 0x48, 0xdf, 0x2c, 0x61, 0xda, 0x44, 0x4f,
+	0x40, 0x61, 0xa4, 0x9f, 0x89, 0x8b, 0x9d, 0x62, 0x5b, 0x93, 0x1f, 0xe9, 0x67, 0xfc, 0x4e, 0x5f,
+	0x85, 0x1c, 0xfd, 0xd8, 0xd7, 0x5d, 0x79, 0xeb, 0x46, 0xfa, 0xd9, 0x3b, 0xba, 0xab, 0xfe, 0x2e,
+	0x05, 0x6b, 0x51, 0x91, 0xea, 0x07, 0x77, 0xc5, 0xa4, 0x45, 0xb3, 0xbe, 0x45, 0xd5, 0x0e, 0x5c,
+	0x89, 0x0e, 0xb9, 0x01, 0x43, 0x24, 0x2f, 0x64, 0x08, 0x31, 0x46, 0x6a, 0x32, 0xc6, 0xe4, 0x82,
+	0xc9, 0x40, 0x1c, 0x7b, 0xc1, 0xfe, 0x0a, 0x90, 0xc7, 0xc4, 0xb5, 0x69, 0x78, 0x40, 0xbb, 0x50,
+	0x20, 0x67, 0x5d, 0x62, 0x7b, 0x32, 0xa2, 0x46, 0xa7, 0xdd, 0x9c, 0xbb, 0x29, 0x39, 0x69, 0xce,
+	0xeb, 0x8b, 0xa1, 0x97, 0x05, 0xac, 0x89, 0x47, 0x28, 0x42, 0x3c, 0x88, 0x6b, 0xee, 0x48, 0x5c,
+	0x93, 0x8e, 0x4d, 0x73, 0xb9, 0xd4, 0x14, 0xb0, 0x79, 0x59, 0x00, 0x9b, 0xcc, 0x82, 0xc1, 0x42,
+	0xc8, 0xa6, 0x11, 0x42, 0x36, 0xd9, 0x05, 0xcb, 0x8c, 0x81, 0x36, 0x77, 0x24, 0xb4, 0x59, 0x59,
+	0x30, 0xe3, 0x29, 0x6c, 0x73, 0x37, 0x8c, 0x6d, 0x38, 0x2e, 0x79, 0x3a, 0x56, 0x3a, 0x16, 0xdc,
+	0xfc, 0x38, 0x00, 0x6e, 0xf2, 0xb1, 0xc9, 0x18, 0x57, 0x12, 0x81, 0x6e, 0x1a, 0x21, 0x74, 0x53,
+	0x58, 0x60, 0x83, 0x18, 0x78, 0xf3, 0x56, 0x10, 0xde, 0x40, 0x2c, 0x42, 0x12, 0xfb, 0x1d, 0x85,
+	0x6f, 0x5e, 0xf3, 0xf1, 0x4d, 0x31, 0x16, 0xa0, 0x89, 0x35, 0x4c, 0x03, 0x9c, 0xc3, 0x19, 0x80,
+	0xc3, 0x01, 0xc9, 0xb3, 0xb1, 0x2a, 0x16, 0x20, 0x9c, 0xc3, 0x19, 0x84, 0x53, 0x5e, 0xa0, 0x70,
+	0x01, 0xc4, 0xf9, 0x79, 0x34, 0xc4, 0x89, 0x07, 0x21, 0x62, 0x9a, 0xcb, 0x61, 0x1c, 0x2d, 0x06,
+	0xe3, 0x70, 0x24, 0xf2, 0x7c, 0xac, 0xfa, 0xa5, 0x41, 0xce, 0xe1, 0x0c, 0xc8, 0xa9, 0x2d, 0xb0,
+	0xc7, 0x02, 0x94, 0x73, 0x38, 0x83, 0x72, 0x56, 0x17, 0x28, 0x5c, 0x00, 0x73, 0xee, 0x47, 0xc0,
+	0x1c, 0x0e, 0x4c, 0x6e, 0xce, 0x99, 0xe3, 0x42, 0x9c, 0xf3, 0x66, 0x00, 0xe7, 0x5c, 0x5a, 0x70,
+	0xaa, 0xe7, 0x03, 0x9d, 0x5b, 0xb0, 0x2a, 0x19, 0x7d, 0x6f, 0x49, 0x43, 0x35, 0x71, 0x1c, 0xcb,
+	0x11, 0x90, 0x85, 0x77, 0xd4, 0x9b, 0x50, 0xf2, 0x59, 0xe7, 0x83, 0x22, 0x96, 0x12, 0x05, 0xbc,
+	0xa1, 0xfa, 0x38, 0x05, 0xa5, 0xa0, 0xa3, 0x0b, 0x25, 0xcd, 0x05, 0x91, 0x34, 0x07, 0xa0, 0x52,
+	0x2a, 0x0c, 0x95, 0x36, 0xa0, 0x48, 0x53, 0x9d, 0x29, 0x14, 0xa4, 0xdb, 0x3e, 0x0a, 0xba, 0x0d,
+	0xab, 0x2c, 0xd0, 0x72, 0x40, 0x25, 0xa2, 0x48, 0x86, 0x45, 0x91, 0x2a, 0xfd, 0xc0, 0x77, 0x87,
+	0x91, 0xd1, 0x8b, 0x70, 0x29, 0xc0, 0xeb, 0xa7, 0x50, 0x1c, 0x12, 0xd4, 0x7c, 0xee, 0x1d, 0x9e,
+	0x4b, 0x51, 0x54, 0x30, 0x75, 0x24, 0x56, 0x58, 0x9e, 0x3e, 0xb5, 0xd1, 0xb7, 0x22, 0x36, 0x3a,
+	0xc7, 0x18, 0xa7, 0x37, 0x4f, 0xfd, 0x63, 0x12, 0x56, 0x67, 0x5c, 0x77, 0x24, 0x76, 0x4a, 0x7e,
+	0x4f, 0xd8, 0x29, 0xf5, 0x5f, 0x63, 0xa7, 0x60, 0x92, 0x99, 0x0e, 0x27, 0x99, 0xff, 0x4a, 0x42,
+	0x39, 0x14, 0x41, 0xe8, 0xa6, 0x76, 0xad, 0x1e, 0x11, 0x69, 0x1f, 0x6b, 0xd3, 0xc8, 0x3f, 0xb4,
+	0xfa, 0x22, 0xb9, 0xa3, 0x4d, 0xca, 0xe5, 0x07, 0xc4, 0x82, 0x88, 0x77, 0x7e, 0xc6, 0x98, 0x65,
+	0x7b, 0xc6, 0x3b, 0x54, 0xf6, 0x11, 0xe1, 0xe1, 0xab, 0x84, 0x69, 0x13, 0xad, 0x89, 0x63, 0xcb,
+	0x4c, 0x5b, 0xc2, 0xbc, 0x83, 0x5e, 0x85, 0x02, 0xab, 0xa9, 0x6a, 0x96, 0xed, 0x8a, 0x48, 0xf3,
+	0x44, 0x70, 0xad, 0xbc, 0x74, 0xba, 0x79, 0x44, 0x79, 0x0e, 0x6d, 0x17, 0xe7, 0x6d, 0xd1, 0x0a,
+	0xa4, 0x1c, 0x85, 0x10, 0x26, 0xbb, 0x0e, 0x05, 0x3a, 0x7b, 0xd7, 0xd6, 0xbb, 0x84, 0x85, 0x8d,
+	0x02, 0x9e, 0x10, 0xd4, 0x87, 0x80, 0x66, 0x83, 0x1f, 0x6a, 0xc1, 0x0a, 0x39, 0x25, 0xa6, 0xc7,
+	0x93, 0xd3, 0xe2, 0xf6, 0x95, 0x88, 0x6c, 0x8c, 0x98, 0xde, 0x6e, 0x9d, 0x1a, 0xf9, 0x9f, 0x5f,
+	0x6f, 0xd4, 0x38, 0xf7, 0x0b, 0xd6, 0xc8, 0xf0, 0xc8, 0xc8, 0xf6, 0xce, 0xb1, 0x90, 0x57, 0xff,
+	0x96, 0x82, 0xaa, 0x1c, 0x40, 0xc2, 0x9e, 0x28, 0xdb, 0xca, 0x4b, 0x94, 0x0a, 0x20, 0xcf, 0xe5,
+	0xec, 0xbd, 0x0e, 0xd0, 0xd7, 0x5d, 0xed, 0x53, 0xdd, 0xf4, 0x48, 0x4f, 0x18, 0x3d, 0x40, 0x41,
+	0x0a, 0xe4, 0x69, 0x6f, 0xec, 0x92, 0x9e, 0x00, 0xc1, 0x7e, 0x3f, 0xb0, 0xce, 0xdc, 0x77, 0x5b,
+	0x67, 0xd8, 0xca, 0xf9, 0x29, 0x2b, 0x07, 0x90, 0x41, 0x21, 0x88, 0x0c, 0xe8, 0xdc, 0x6c, 0xc7,
+	0xb0, 0x1c, 0xc3, 0x3b, 0x67, 0x5b, 0x93, 0xc6, 0x7e, 0x9f, 0xd6, 0x54, 0x46, 0x64, 0x64, 0x5b,
+	0xd6, 0x50, 0xe3, 0x0e, 0xac, 0xc8, 0x44, 0x4b, 0x82, 0xd8, 0x64, 0x7e, 0xec, 0xd7, 0x29, 0x58,
+	0x9d, 0x49, 0x1b, 0x7e, 0x78, 0x06, 0x56, 0x7f, 0xc3, 0xea, 0x42, 0xe1, 0xd4, 0x07, 0x1d, 0xc3,
+	0xaa, 0x7f, 0xfd, 0xb5, 0x31, 0x73, 0x0b, 0xf2, 0x40, 0x2f, 0xeb, 0x3f, 0x6a, 0xa7, 0x61, 0xb2,
+	0x8b, 0x3e, 0x80, 0xab, 0x53, 0xbe, 0xcd, 0x57, 0x9d, 0x5a, 0xd6, 0xc5, 0x5d, 0x0e, 0xbb, 0x38,
+	0xa9, 0x7a, 0x62, 0xac, 0xf4, 0x77, 0xbc, 0x75, 0x7b, 0x50, 0x91, 0xd6, 0xe0, 0x99, 0x5c, 0xe4,
+	0xf6, 0x3f, 0x0d, 0x65, 0x87, 0x78, 0xb4, 0xfc, 0x15, 0x2a, 0xe6, 0x94, 0x38, 0x51, 0x94, 0x88,
+	0x8e, 0xe0, 0x72, 0x64, 0x46, 0x87, 0xfe, 0x1f, 0x0a, 0x93, 0x64, 0x30, 0x19, 0x03, 0xda, 0x24,
+	0x3b, 0x9e, 0xf0, 0xaa, 0x7f, 0x48, 0xc2, 0xe5, 0xc8, 0x9c, 0x0e, 0x35, 0x61, 0xc5, 0x21, 0xee,
+	0x78, 0xc8, 0x51, 0x53, 0x65, 0xfb, 0xc5, 0xe5, 0x72, 0x41, 0x4a, 0x1d, 0x0f, 0x3d, 0x2c, 0x84,
+	0xd5, 0x87, 0xb0, 0xc2, 0x29, 0xa8, 0x08, 0xb9, 0xfb, 0x07, 0xf7, 0x0e, 0x0e, 0xdf, 0x3f, 0xa8,
+	0x25, 0x10, 0xc0, 0xca, 0x4e, 0xa3, 0xd1, 0x3c, 0x6a, 0xd7, 0x92, 0xa8, 0x00, 0xd9, 0x9d, 0xdd,
+	0x43, 0xdc, 0xae, 0xa5, 0x28, 0x19, 0x37, 0xdf, 0x6d, 0x36, 0xda, 0xb5, 0x34, 0x5a, 0x85, 0x32,
+	0x6f, 0x6b, 0x77, 0x0f, 0xf1, 0x7b, 0x3b, 0xed, 0x5a, 0x26, 0x40, 0x3a, 0x6e, 0x1e, 0xbc, 0xdd,
+	0xc4, 0xb5, 0xac, 0xfa, 0x12, 0x5c, 0x93, 0xf3, 0x98, 0xad, 0x49, 0xf8, 0xa5, 0x81, 0x64, 0xa0,
+	0x34, 0xa0, 0xfe, 0x36, 0x05, 0x4a, 0x7c, 0x4a, 0x88, 0xde, 0x9d, 0x5a, 0xf8, 0xf6, 0x05, 0xf2,
+	0xc9, 0xa9, 0xd5, 0xd3, 0x20, 0xef, 0x90, 0x13, 0xe2, 0x75, 0x07, 0x3c, 0x45, 0xe5, 0x21, 0xb3,
+	0x8c, 0xcb, 0x82, 0xca, 0x84, 0x5c, 0xce, 0xf6, 0x31, 0xe9, 0x7a, 0x1a, 0xf7, 0x45, 0xfc, 0xd0,
+	0x15, 0x70, 0x99, 0x53, 0x8f, 0x39, 0x51, 0xfd, 0xe8, 0x42, 0xb6, 0x2c, 0x40, 0x16, 0x37, 0xdb,
+	0xf8, 0x83, 0x5a, 0x1a, 0x21, 0xa8, 0xb0, 0xa6, 0x76, 0x7c, 0xb0, 0x73, 0x74, 0xdc, 0x3a, 0xa4,
+	0xb6, 0xbc, 0x04, 0x55, 0x69, 0x4b, 0x49, 0xcc, 0xaa, 0x3a, 0x5c, 0x8e, 0xcc, 0x68, 0x23, 0xca,
+	0x23, 0x77, 0x20, 0x2f, 0xd2, 0x32, 0x79, 0xd9, 0x94, 0xd9, 0xcb, 0xf6, 0x9e, 0xe0, 0xc0, 0x3e,
+	0xaf, 0xfa, 0xe7, 0x14, 0x5c, 0x8e, 0x4c, 0x72, 0xbf, 0xbf, 0x40, 0x87, 0x76, 0x00, 0xbc, 0x33,
+	0x8d, 0xef, 0x81, 0xcc, 0x52, 0x96, 0x80, 0x78, 0xb8, 0xe0, 0x9d, 0x71, 0x03, 0xbb, 0xd1, 0xfe,
+	0x2a, 0xfd, 0xbf, 0xf3, 0x57, 0x99, 0xef, 0xe6, 0xaf, 0xd4, 0x97, 0xe0, 0x6a, 0x4c, 0x9e, 0x4f,
+	0x03, 0x9e, 0xde, 0xa5, 0x29, 0x38, 0x3b, 0xd0, 0x79, 0x2c, 0x7a, 0xea, 0x08, 0x6a, 0xd3, 0xb9,
+	0x7c, 0x74, 0xc2, 0x9b, 0xbc, 0x50, 0xc2, 0x9b, 0x8a, 0x4e, 0x78, 0xd5, 0x0f, 0xa1, 0x12, 0xae,
+	0x45, 0xd1, 0xeb, 0xe9, 0x58, 0x63, 0xb3, 0xc7, 0x06, 0xc8, 0x62, 0xde, 0xa1, 0x7f, 0x4e, 0x4f,
+	0x2d, 0xee, 0xc2, 0xa3, 0xfd, 0xd8, 0x03, 0xcb, 0x23, 0x81, 0x5a, 0x16, 0xe7, 0x56, 0x3f, 0x83,
+	0x2c, 0x3b, 0x1e, 0xd4, 0xbb, 0xb2, 0xc2, 0xad, 0x80, 0x00, 0xb4, 0x8d, 0x3e, 0x04, 0xd0, 0x3d,
+	0xcf, 0x31, 0x3a, 0xe3, 0x89, 0xe2, 0x8d, 0xe8, 0xe3, 0xb5, 0x23, 0xf9, 0x76, 0xaf, 0x8b, 0x73,
+	0xb6, 0x36, 0x11, 0x0d, 0x9c, 0xb5, 0x80, 0x42, 0xf5, 0x00, 0x2a, 0x61, 0x59, 0x99, 0x62, 0xf2,
+	0x39, 0x84, 0x53, 0x4c, 0x8e, 0x41, 0x78, 0x67, 0x92, 0xa0, 0xa6, 0x79, 0x91, 0x9e, 0x75, 0xd4,
+	0xc7, 0x49, 0xc8, 0xb7, 0xc5, 0x51, 0x8c, 0x2b, 0x5f, 0x4d, 0x44, 0x53, 0xc1, 0x6a, 0x28, 0x2f,
+	0x38, 0xa7, 0xfd, 0x32, 0xf6, 0x5b, 0xbe, 0x37, 0xcb, 0x2c, 0x5b, 0xe9, 0x90, 0x35, 0x36, 0xe1,
+	0xc1, 0x5f, 0x87, 0x82, 0x7f, 0xbe, 0x29, 0x96, 0xd2, 0x7b, 0x3d, 0x87, 0xb8, 0xae, 0xf0, 0xa9,
+	0xb2, 0x4b, 0xa7, 0x63, 0x5b, 0x9f, 0x8a, 0x7a, 0x6b, 0x1a, 0xf3, 0x8e, 0xda, 0x83, 0xea, 0xd4,
+	0xe5, 0x40, 0xaf, 0x43, 0xce, 0x1e, 0x77, 0x34, 0x69, 0x9e, 0xa9, 0x9f, 0xf2, 0x32, 0xa7, 0x1e,
+	0x77, 0x86, 0x46, 0xf7, 0x1e, 0x39, 0x97, 0x93, 0xb1, 0xc7, 0x9d, 0x7b, 0xdc, 0x8a, 0x7c, 0x94,
+	0x54, 0x70, 0x94, 0x53, 0xc8, 0xcb, 0x43, 0x81, 0xde, 0x80, 0x82, 0x7f, 0xef, 0xfc, 0xbf, 0x50,
+	0xb1, 0x17, 0x56, 0xa8, 0x9f, 0x88, 0xd0, 0x1b, 0xe0, 0x1a, 0x7d, 0x93, 0xf4, 0xb4, 0xc9, 0xe1,
+	0x66, 0xa3, 0xe5, 0x71, 0x95, 0x7f, 0xd8, 0x97, 0x27, 0x5b, 0xfd, 0x77, 0x12, 0xf2, 0xb2, 0x14,
+	0x8a, 0x5e, 0x0a, 0x9c, 0xbb, 0x4a, 0x44, 0x41, 0x4e, 0x32, 0x4e, 0xfe, 0x18, 0x84, 0xe7, 0x9a,
+	0xba, 0xf8, 0x5c, 0xe3, 0x7e, 0xfd, 0xc8, 0x9f, 0x70, 0x99, 0x0b, 0xff, 0x84, 0x7b, 0x01, 0x90,
+	0x67, 0x79, 0xfa, 0x50, 0x3b, 0xb5, 0x3c, 0xc3, 0xec, 0x6b, 0xdc, 0xd8, 0x3c, 0xcf, 0xac, 0xb1,
+	0x2f, 0x0f, 0xd8, 0x87, 0x23, 0x66, 0xf7, 0x5f, 0x24, 0x21, 0xef, 0x27, 0x0c, 0x17, 0xfd, 0x01,
+	0x70, 0x05, 0x56, 0x44, 0x4c, 0xe4, 0x7f, 0x00, 0x44, 0xcf, 0x2f, 0x94, 0x67, 0x02, 0x85, 0x72,
+	0x85, 0x06, 0x1b, 0x4f, 0x67, 0x59, 0x13, 0x07, 0xd4, 0x7e, 0xff, 0xf6, 0x6b, 0x50, 0x0c, 0xfc,
+	0x8b, 0xa1, 0x37, 0xef, 0xa0, 0xf9, 0x7e, 0x2d, 0xa1, 0xe4, 0x1e, 0x7f, 0x71, 0x23, 0x7d, 0x40,
+	0x3e, 0xa5, 0x67, 0x16, 0x37, 0x1b, 0xad, 0x66, 0xe3, 0x5e, 0x2d, 0xa9, 0x14, 0x1f, 0x7f, 0x71,
+	0x23, 0x87, 0x09, 0x2b, 0x06, 0xde, 0x6e, 0x41, 0x29, 0xb8, 0x2b, 0xe1, 0xb0, 0x8a, 0xa0, 0xf2,
+	0xf6, 0xfd, 0xa3, 0xfd, 0xbd, 0xc6, 0x4e, 0xbb, 0xa9, 0x3d, 0x38, 0x6c, 0x37, 0x6b, 0x49, 0x74,
+	0x15, 0x2e, 0xed, 0xef, 0xbd, 0xd3, 0x6a, 0x6b, 0x8d, 0xfd, 0xbd, 0xe6, 0x41, 0x5b, 0xdb, 0x69,
+	0xb7, 0x77, 0x1a, 0xf7, 0x6a, 0xa9, 0xed, 0xdf, 0x97, 0xa0, 0xba, 0xb3, 0xdb, 0xd8, 0xa3, 0x29,
+	0x81, 0xd1, 0xd5, 0x59, 0xb5, 0xa3, 0x01, 0x19, 0x56, 0xcf, 0x98, 0xfb, 0xbe, 0x45, 0x99, 0x5f,
+	0x26, 0x46, 0x77, 0x21, 0xcb, 0x4a, 0x1d, 0x68, 0xfe, 0x83, 0x17, 0x65, 0x41, 0xdd, 0x98, 0x4e,
+	0x86, 0x5d, 0x8f, 0xb9, 0x2f, 0x60, 0x94, 0xf9, 0x65, 0x64, 0x84, 0xa1, 0x30, 0x01, 0x36, 0x8b,
+	0x5f, 0x84, 0x28, 0x4b, 0x38, 0x1b, 0xb4, 0x0f, 0x39, 0x89, 0x45, 0x17, 0xbd, 0x51, 0x51, 0x16,
+	0xd6, 0x79, 0xa9, 0xb9, 0x78, 0xcd, 0x60, 0xfe, 0x83, 0x1b, 0x65, 0x41, 0xd1, 0x1a, 0xed, 0xc1,
+	0x8a, 0x48, 0xd6, 0x17, 0xbc, 0x3b, 0x51, 0x16, 0xd5, 0x6d, 0xa9, 0xd1, 0x26, 0xd5, 0x98, 0xc5,
+	0xcf, 0x88, 0x94, 0x25, 0xea, 0xf1, 0xe8, 0x3e, 0x40, 0xa0, 0x42, 0xb0, 0xc4, 0xfb, 0x20, 0x65,
+	0x99, 0x3a, 0x3b, 0x3a, 0x84, 0xbc, 0x0f, 0xd8, 0x16, 0xbe, 0xd6, 0x51, 0x16, 0x17, 0xbc, 0xd1,
+	0x43, 0x28, 0x87, 0x81, 0xca, 0x72, 0x6f, 0x70, 0x94, 0x25, 0x2b, 0xd9, 0x54, 0x7f, 0x18, 0xb5,
+	0x2c, 0xf7, 0x26, 0x47, 0x59, 0xb2, 0xb0, 0x8d, 0x3e, 0x86, 0xd5, 0x59, 0x54, 0xb1, 0xfc, 0x13,
+	0x1d, 0xe5, 0x02, 0xa5, 0x6e, 0x34, 0x02, 0x14, 0x81, 0x46, 0x2e, 0xf0, 0x62, 0x47, 0xb9, 0x48,
+	0xe5, 0x9b, 0x9a, 0x2e, 0x9c, 0xe2, 0x2f, 0xf7, 0x82, 0x47, 0x59, 0xb2, 0x06, 0x4e, 0xf5, 0x87,
+	0xd3, 0xfb, 0xe5, 0x5e, 0xf4, 0x28, 0x4b, 0x96, 0xc4, 0x51, 0x0f, 0xaa, 0xd3, 0x89, 0xee, 0xb2,
+	0x2f, 0x7c, 0x94, 0xa5, 0x6b, 0xe4, 0xf4, 0x46, 0xf8, 0xb9, 0xf1, 0xc2, 0x27, 0x3f, 0xca, 0xe2,
+	0x62, 0xf9, 0x6e, 0xf3, 0xcb, 0x6f, 0xd6, 0x93, 0x5f, 0x7d, 0xb3, 0x9e, 0xfc, 0xc7, 0x37, 0xeb,
+	0xc9, 0xcf, 0xbf, 0x5d, 0x4f, 0x7c, 0xf5, 0xed, 0x7a, 0xe2, 0x2f, 0xdf, 0xae, 0x27, 0x7e, 0xfa,
+	0x7c, 0xdf, 0xf0, 0x06, 0xe3, 0xce, 0x66, 0xd7, 0x1a, 0x6d, 0x05, 0x5f, 0x60, 0x46, 0xbd, 0x0a,
+	0xed, 0xac, 0xb0, 0x58, 0xfe, 0xf2, 0x7f, 0x06, 0x00, 0xef, 0x0b, 0xde, 0xda, 0x35, 0x2a, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3288,6 +3766,9 @@ type ABCIApplicationClient interface {
 	LoadSnapshotChunk(ctx context.Context, in *RequestLoadSnapshotChunk, opts ...grpc.CallOption) (*ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(ctx context.Context, in *RequestApplySnapshotChunk, opts ...grpc.CallOption) (*ResponseApplySnapshotChunk, error)
 	PreprocessTxs(ctx context.Context, in *RequestPreprocessTxs, opts ...grpc.CallOption) (*ResponsePreprocessTxs, error)
+	FinalizeBlock(ctx context.Context, in *RequestFinalizeBlock, opts ...grpc.CallOption) (*ResponseFinalizeBlock, error)
+	ProcessProposal(ctx context.Context, in *RequestProcessProposal, opts ...grpc.CallOption) (*ResponseProcessProposal, error)
+	Rollback(ctx context.Context, in *RequestRollback, opts ...grpc.CallOption) (*ResponseRollback, error)
 }
 
 type aBCIApplicationClient struct {
@@ -3433,6 +3914,33 @@ func (c *aBCIApplicationClient) PreprocessTxs(ctx context.Context, in *RequestPr
 	return out, nil
 }
 
+func (c *aBCIApplicationClient) FinalizeBlock(ctx context.Context, in *RequestFinalizeBlock, opts ...grpc.CallOption) (*ResponseFinalizeBlock, error) {
+	out := new(ResponseFinalizeBlock)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/FinalizeBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) ProcessProposal(ctx context.Context, in *RequestProcessProposal, opts ...grpc.CallOption) (*ResponseProcessProposal, error) {
+	out := new(ResponseProcessProposal)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/ProcessProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) Rollback(ctx context.Context, in *RequestRollback, opts ...grpc.CallOption) (*ResponseRollback, error) {
+	out := new(ResponseRollback)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/Rollback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ABCIApplicationServer is the server API for ABCIApplication service.
 type ABCIApplicationServer interface {
 	Echo(context.Context, *RequestEcho) (*ResponseEcho, error)
@@ -3450,6 +3958,9 @@ type ABCIApplicationServer interface {
 	LoadSnapshotChunk(context.Context, *RequestLoadSnapshotChunk) (*ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(context.Context, *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error)
 	PreprocessTxs(context.Context, *RequestPreprocessTxs) (*ResponsePreprocessTxs, error)
+	FinalizeBlock(context.Context, *RequestFinalizeBlock) (*ResponseFinalizeBlock, error)
+	ProcessProposal(context.Context, *RequestProcessProposal) (*ResponseProcessProposal, error)
+	Rollback(context.Context, *RequestRollback) (*ResponseRollback, error)
 }
 
 // UnimplementedABCIApplicationServer can be embedded to have forward compatible implementations.
@@ -3501,6 +4012,15 @@ func (*UnimplementedABCIApplicationServer) ApplySnapshotChunk(ctx context.Contex
 func (*UnimplementedABCIApplicationServer) PreprocessTxs(ctx context.Context, req *RequestPreprocessTxs) (*ResponsePreprocessTxs, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PreprocessTxs not implemented")
 }
+func (*UnimplementedABCIApplicationServer) FinalizeBlock(ctx context.Context, req *RequestFinalizeBlock) (*ResponseFinalizeBlock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizeBlock not implemented")
+}
+func (*UnimplementedABCIApplicationServer) ProcessProposal(ctx context.Context, req *RequestProcessProposal) (*ResponseProcessProposal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessProposal not implemented")
+}
+func (*UnimplementedABCIApplicationServer) Rollback(ctx context.Context, req *RequestRollback) (*ResponseRollback, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rollback not implemented")
+}
 
 func RegisterABCIApplicationServer(s *grpc.Server, srv ABCIApplicationServer) {
 	s.RegisterService(&_ABCIApplication_serviceDesc, srv)
@@ -3776,6 +4296,60 @@ func _ABCIApplication_PreprocessTxs_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ABCIApplication_FinalizeBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestFinalizeBlock)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).FinalizeBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/FinalizeBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).FinalizeBlock(ctx, req.(*RequestFinalizeBlock))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_ProcessProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestProcessProposal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).ProcessProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/ProcessProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).ProcessProposal(ctx, req.(*RequestProcessProposal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestRollback)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).Rollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/Rollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).Rollback(ctx, req.(*RequestRollback))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tendermint.abci.ABCIApplication",
 	HandlerType: (*ABCIApplicationServer)(nil),
@@ -3840,6 +4414,18 @@ var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PreprocessTxs",
 			Handler:    _ABCIApplication_PreprocessTxs_Handler,
 		},
+		{
+			MethodName: "FinalizeBlock",
+			Handler:    _ABCIApplication_FinalizeBlock_Handler,
+		},
+		{
+			MethodName: "ProcessProposal",
+			Handler:    _ABCIApplication_ProcessProposal_Handler,
+		},
+		{
+			MethodName: "Rollback",
+			Handler:    _ABCIApplication_Rollback_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tendermint/abci/types.proto",
@@ -4192,6 +4778,75 @@ func (m *Request_PreprocessTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Request_FinalizeBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_FinalizeBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.FinalizeBlock != nil {
+		{
+			size, err := m.FinalizeBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Request_ProcessProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_ProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ProcessProposal != nil {
+		{
+			size, err := m.ProcessProposal.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Request_Rollback) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_Rollback) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Rollback != nil {
+		{
+			size, err := m.Rollback.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	return len(dAtA) - i, nil
+}
 func (m *RequestEcho) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4357,12 +5012,12 @@ func (m *RequestInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	n17, err17 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err17 != nil {
-		return 0, err17
+	n18, err18 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err18 != nil {
+		return 0, err18
 	}
-	i -= n17
-	i = encodeVarintTypes(dAtA, i, uint64(n17))
+	i -= n18
+	i = encodeVarintTypes(dAtA, i, uint64(n18))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -4765,6 +5420,16 @@ func (m *RequestPreprocessTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MaxGas != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxGas))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MaxBytes != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxBytes))
+		i--
+		dAtA[i] = 0x10
+	}
 	if len(m.Txs) > 0 {
 		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Txs[iNdEx])
@@ -4777,6 +5442,149 @@ func (m *RequestPreprocessTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RequestFinalizeBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestFinalizeBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestFinalizeBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.ByzantineValidators) > 0 {
+		for iNdEx := len(m.ByzantineValidators) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ByzantineValidators[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	{
+		size, err := m.LastCommitInfo.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestProcessProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestProcessProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestRollback) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestRollback) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestRollback) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Response) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5147,6 +5955,75 @@ func (m *Response_PreprocessTxs) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Response_FinalizeBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_FinalizeBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.FinalizeBlock != nil {
+		{
+			size, err := m.FinalizeBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_ProcessProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_ProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ProcessProposal != nil {
+		{
+			size, err := m.ProcessProposal.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_Rollback) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_Rollback) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Rollback != nil {
+		{
+			size, err := m.Rollback.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *ResponseException) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5250,6 +6127,26 @@ func (m *ResponseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ProcessProposal {
+		i--
+		if m.ProcessProposal {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.FinalizeBlock {
+		i--
+		if m.FinalizeBlock {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.LastBlockAppHash) > 0 {
 		i -= len(m.LastBlockAppHash)
 		copy(dAtA[i:], m.LastBlockAppHash)
@@ -5864,20 +6761,20 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 		}
 	}
 	if len(m.RefetchChunks) > 0 {
-		dAtA41 := make([]byte, len(m.RefetchChunks)*10)
-		var j40 int
+		dAtA45 := make([]byte, len(m.RefetchChunks)*10)
+		var j44 int
 		for _, num := range m.RefetchChunks {
 			for num >= 1<<7 {
-				dAtA41[j40] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA45[j44] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j40++
+				j44++
 			}
-			dAtA41[j40] = uint8(num)
-			j40++
+			dAtA45[j44] = uint8(num)
+			j44++
 		}
-		i -= j40
-		copy(dAtA[i:], dAtA41[:j40])
-		i = encodeVarintTypes(dAtA, i, uint64(j40))
+		i -= j44
+		copy(dAtA[i:], dAtA45[:j44])
+		i = encodeVarintTypes(dAtA, i, uint64(j44))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -5933,7 +6830,7 @@ func (m *ResponsePreprocessTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *LastCommitInfo) Marshal() (dAtA []byte, err error) {
+func (m *ResponseFinalizeBlock) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5943,20 +6840,32 @@ func (m *LastCommitInfo) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LastCommitInfo) MarshalTo(dAtA []byte) (int, error) {
+func (m *ResponseFinalizeBlock) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *LastCommitInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ResponseFinalizeBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Votes) > 0 {
-		for iNdEx := len(m.Votes) - 1; iNdEx >= 0; iNdEx-- {
+	if m.ConsensusParamUpdates != nil {
+		{
+			size, err := m.ConsensusParamUpdates.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ValidatorUpdates) > 0 {
+		for iNdEx := len(m.ValidatorUpdates) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Votes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.ValidatorUpdates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -5964,25 +6873,158 @@ func (m *LastCommitInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintTypes(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0x12
+			dAtA[i] = 0x1a
 		}
 	}
-	if m.Round != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
-}
-
-func (m *Event) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if len(m.TxResults) > 0 {
+		for iNdEx := len(m.TxResults) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TxResults[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	return dAtA[:n], nil
+	if len(m.Events) > 0 {
+		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Events[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseProcessProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseProcessProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Accept {
+		i--
+		if m.Accept {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseRollback) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseRollback) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseRollback) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LastBlockAppHash) > 0 {
+		i -= len(m.LastBlockAppHash)
+		copy(dAtA[i:], m.LastBlockAppHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.LastBlockAppHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.LastBlockHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.LastBlockHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LastCommitInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LastCommitInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LastCommitInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Votes) > 0 {
+		for iNdEx := len(m.Votes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Votes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Event) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
 
 func (m *Event) MarshalTo(dAtA []byte) (int, error) {
@@ -6257,12 +7299,12 @@ func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n46, err46 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err46 != nil {
-		return 0, err46
+	n51, err51 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err51 != nil {
+		return 0, err51
 	}
-	i -= n46
-	i = encodeVarintTypes(dAtA, i, uint64(n46))
+	i -= n51
+	i = encodeVarintTypes(dAtA, i, uint64(n51))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -6543,6 +7585,42 @@ func (m *Request_PreprocessTxs) Size() (n int) {
 	}
 	return n
 }
+func (m *Request_FinalizeBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FinalizeBlock != nil {
+		l = m.FinalizeBlock.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_ProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ProcessProposal != nil {
+		l = m.ProcessProposal.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_Rollback) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Rollback != nil {
+		l = m.Rollback.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *RequestEcho) Size() (n int) {
 	if m == nil {
 		return 0
@@ -6792,6 +7870,70 @@ func (m *RequestPreprocessTxs) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	if m.MaxBytes != 0 {
+		n += 1 + sovTypes(uint64(m.MaxBytes))
+	}
+	if m.MaxGas != 0 {
+		n += 1 + sovTypes(uint64(m.MaxGas))
+	}
+	return n
+}
+
+func (m *RequestFinalizeBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.Header.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = m.LastCommitInfo.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.ByzantineValidators) > 0 {
+		for _, e := range m.ByzantineValidators {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RequestProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RequestRollback) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
 	return n
 }
 
@@ -6999,6 +8141,42 @@ func (m *Response_PreprocessTxs) Size() (n int) {
 	}
 	return n
 }
+func (m *Response_FinalizeBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FinalizeBlock != nil {
+		l = m.FinalizeBlock.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_ProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ProcessProposal != nil {
+		l = m.ProcessProposal.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_Rollback) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Rollback != nil {
+		l = m.Rollback.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *ResponseException) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7058,6 +8236,12 @@ func (m *ResponseInfo) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.FinalizeBlock {
+		n += 2
+	}
+	if m.ProcessProposal {
+		n += 2
+	}
 	return n
 }
 
@@ -7357,25 +8541,84 @@ func (m *ResponsePreprocessTxs) Size() (n int) {
 	return n
 }
 
-func (m *LastCommitInfo) Size() (n int) {
+func (m *ResponseFinalizeBlock) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Round != 0 {
-		n += 1 + sovTypes(uint64(m.Round))
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
 	}
-	if len(m.Votes) > 0 {
-		for _, e := range m.Votes {
+	if len(m.TxResults) > 0 {
+		for _, e := range m.TxResults {
 			l = e.Size()
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
-	return n
-}
-
-func (m *Event) Size() (n int) {
+	if len(m.ValidatorUpdates) > 0 {
+		for _, e := range m.ValidatorUpdates {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.ConsensusParamUpdates != nil {
+		l = m.ConsensusParamUpdates.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ResponseProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Accept {
+		n += 2
+	}
+	return n
+}
+
+func (m *ResponseRollback) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LastBlockHeight != 0 {
+		n += 1 + sovTypes(uint64(m.LastBlockHeight))
+	}
+	l = len(m.LastBlockAppHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *LastCommitInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	if len(m.Votes) > 0 {
+		for _, e := range m.Votes {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Event) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -8087,6 +9330,111 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Request_PreprocessTxs{v}
 			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinalizeBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestFinalizeBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_FinalizeBlock{v}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessProposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestProcessProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_ProcessProposal{v}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rollback", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestRollback{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_Rollback{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -9748,6 +11096,44 @@ func (m *RequestPreprocessTxs) Unmarshal(dAtA []byte) error {
 			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
 			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
+			}
+			m.MaxBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGas", wireType)
+			}
+			m.MaxGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxGas |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -9769,7 +11155,7 @@ func (m *RequestPreprocessTxs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *RequestFinalizeBlock) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9792,17 +11178,17 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestFinalizeBlock: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestFinalizeBlock: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -9812,30 +11198,29 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseException{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
-			m.Value = &Response_Exception{v}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9862,15 +11247,13 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseEcho{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Echo{v}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCommitInfo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9897,15 +11280,13 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseFlush{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.LastCommitInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Flush{v}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ByzantineValidators", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9932,17 +11313,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseInfo{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.ByzantineValidators = append(m.ByzantineValidators, Evidence{})
+			if err := m.ByzantineValidators[len(m.ByzantineValidators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Info{v}
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -9952,39 +11332,86 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseInitChain{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Value = &Response_InitChain{v}
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestProcessProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestProcessProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestProcessProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
 				b := dAtA[iNdEx]
 				iNdEx++
 				msglen |= int(b&0x7F) << shift
@@ -10002,17 +11429,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseQuery{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Query{v}
 			iNdEx = postIndex
-		case 7:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10022,30 +11447,146 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseBeginBlock{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Value = &Response_BeginBlock{v}
-			iNdEx = postIndex
-		case 8:
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestRollback) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestRollback: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestRollback: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Response) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckTx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10072,15 +11613,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseCheckTx{}
+			v := &ResponseException{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_CheckTx{v}
+			m.Value = &Response_Exception{v}
 			iNdEx = postIndex
-		case 9:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10107,15 +11648,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseDeliverTx{}
+			v := &ResponseEcho{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_DeliverTx{v}
+			m.Value = &Response_Echo{v}
 			iNdEx = postIndex
-		case 10:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10142,15 +11683,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseEndBlock{}
+			v := &ResponseFlush{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_EndBlock{v}
+			m.Value = &Response_Flush{v}
 			iNdEx = postIndex
-		case 11:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10177,15 +11718,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseCommit{}
+			v := &ResponseInfo{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Commit{v}
+			m.Value = &Response_Info{v}
 			iNdEx = postIndex
-		case 12:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ListSnapshots", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10212,15 +11753,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseListSnapshots{}
+			v := &ResponseInitChain{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_ListSnapshots{v}
+			m.Value = &Response_InitChain{v}
 			iNdEx = postIndex
-		case 13:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OfferSnapshot", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10247,15 +11788,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseOfferSnapshot{}
+			v := &ResponseQuery{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_OfferSnapshot{v}
+			m.Value = &Response_Query{v}
 			iNdEx = postIndex
-		case 14:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LoadSnapshotChunk", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10282,15 +11823,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseLoadSnapshotChunk{}
+			v := &ResponseBeginBlock{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_LoadSnapshotChunk{v}
+			m.Value = &Response_BeginBlock{v}
 			iNdEx = postIndex
-		case 15:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ApplySnapshotChunk", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckTx", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10317,15 +11858,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseApplySnapshotChunk{}
+			v := &ResponseCheckTx{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_ApplySnapshotChunk{v}
+			m.Value = &Response_CheckTx{v}
 			iNdEx = postIndex
-		case 16:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PreprocessTxs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10352,11 +11893,525 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponsePreprocessTxs{}
+			v := &ResponseDeliverTx{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_DeliverTx{v}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseEndBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_EndBlock{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseCommit{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_Commit{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListSnapshots", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseListSnapshots{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_ListSnapshots{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OfferSnapshot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseOfferSnapshot{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_OfferSnapshot{v}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadSnapshotChunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseLoadSnapshotChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_LoadSnapshotChunk{v}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplySnapshotChunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseApplySnapshotChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_ApplySnapshotChunk{v}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreprocessTxs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponsePreprocessTxs{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_PreprocessTxs{v}
+			m.Value = &Response_PreprocessTxs{v}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinalizeBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseFinalizeBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_FinalizeBlock{v}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessProposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseProcessProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_ProcessProposal{v}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rollback", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseRollback{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_Rollback{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseException) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseException: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseException: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseEcho) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseEcho: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseEcho: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10379,7 +12434,7 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseException) Unmarshal(dAtA []byte) error {
+func (m *ResponseFlush) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10402,15 +12457,65 @@ func (m *ResponseException) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseException: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseFlush: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseException: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseFlush: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10438,8 +12543,152 @@ func (m *ResponseException) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Error = string(dAtA[iNdEx:postIndex])
+			m.Data = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppVersion", wireType)
+			}
+			m.AppVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockHeight", wireType)
+			}
+			m.LastBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastBlockHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockAppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastBlockAppHash = append(m.LastBlockAppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.LastBlockAppHash == nil {
+				m.LastBlockAppHash = []byte{}
+			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinalizeBlock", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FinalizeBlock = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessProposal", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ProcessProposal = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -10461,7 +12710,7 @@ func (m *ResponseException) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseEcho) Unmarshal(dAtA []byte) error {
+func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10484,17 +12733,53 @@ func (m *ResponseEcho) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseEcho: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseInitChain: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseEcho: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseInitChain: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParams", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConsensusParams == nil {
+				m.ConsensusParams = &types1.ConsensusParams{}
+			}
+			if err := m.ConsensusParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10504,74 +12789,60 @@ func (m *ResponseEcho) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
+			m.Validators = append(m.Validators, ValidatorUpdate{})
+			if err := m.Validators[len(m.Validators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseFlush) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			if iNdEx >= l {
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseFlush: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseFlush: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -10593,7 +12864,7 @@ func (m *ResponseFlush) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
+func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10616,15 +12887,34 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseQuery: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseQuery: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10652,11 +12942,11 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = string(dAtA[iNdEx:postIndex])
+			m.Log = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10684,13 +12974,13 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Version = string(dAtA[iNdEx:postIndex])
+			m.Info = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppVersion", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.AppVersion = 0
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10700,16 +12990,16 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.AppVersion |= uint64(b&0x7F) << shift
+				m.Index |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockHeight", wireType)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			m.LastBlockHeight = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10719,14 +13009,29 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastBlockHeight |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockAppHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -10753,10 +13058,97 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LastBlockAppHash = append(m.LastBlockAppHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.LastBlockAppHash == nil {
-				m.LastBlockAppHash = []byte{}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProofOps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ProofOps == nil {
+				m.ProofOps = &crypto.ProofOps{}
+			}
+			if err := m.ProofOps.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
 			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10779,7 +13171,7 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
+func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10802,51 +13194,15 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseInitChain: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseBeginBlock: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseInitChain: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseBeginBlock: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParams", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ConsensusParams == nil {
-				m.ConsensusParams = &types1.ConsensusParams{}
-			}
-			if err := m.ConsensusParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10873,45 +13229,11 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Validators = append(m.Validators, ValidatorUpdate{})
-			if err := m.Validators[len(m.Validators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Events = append(m.Events, Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.AppHash == nil {
-				m.AppHash = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -10933,7 +13255,7 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
+func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10956,10 +13278,10 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseQuery: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseCheckTx: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseCheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -10981,6 +13303,40 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
@@ -11047,9 +13403,9 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
 			}
-			m.Index = 0
+			m.GasWanted = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11059,16 +13415,16 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= int64(b&0x7F) << shift
+				m.GasWanted |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
 			}
-			var byteLen int
+			m.GasUsed = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11078,31 +13434,16 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.GasUsed |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
-			}
-			iNdEx = postIndex
 		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11112,31 +13453,31 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.Events = append(m.Events, Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProofOps", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11146,50 +13487,27 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ProofOps == nil {
-				m.ProofOps = &crypto.ProofOps{}
-			}
-			if err := m.ProofOps.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
-			}
-			m.Height = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11217,63 +13535,32 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Codespace = string(dAtA[iNdEx:postIndex])
+			m.Sender = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseBeginBlock: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseBeginBlock: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MempoolError", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11283,25 +13570,23 @@ func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Events = append(m.Events, Event{})
-			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.MempoolError = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11324,7 +13609,7 @@ func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
+func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11347,10 +13632,10 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseCheckTx: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseDeliverTx: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseCheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseDeliverTx: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11574,11 +13859,61 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			}
 			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 9:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseEndBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseEndBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorUpdates", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11588,29 +13923,31 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Sender = string(dAtA[iNdEx:postIndex])
+			m.ValidatorUpdates = append(m.ValidatorUpdates, ValidatorUpdate{})
+			if err := m.ValidatorUpdates[len(m.ValidatorUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParamUpdates", wireType)
 			}
-			m.Priority = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11620,16 +13957,33 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Priority |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 11:
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConsensusParamUpdates == nil {
+				m.ConsensusParamUpdates = &types1.ConsensusParams{}
+			}
+			if err := m.ConsensusParamUpdates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MempoolError", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11639,24 +13993,129 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseCommit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseCommit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MempoolError = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetainHeight", wireType)
+			}
+			m.RetainHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetainHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11678,7 +14137,7 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
+func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11701,36 +14160,17 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseDeliverTx: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseListSnapshots: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseDeliverTx: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseListSnapshots: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
-			}
-			m.Code = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Code |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshots", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11740,114 +14180,81 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			m.Snapshots = append(m.Snapshots, &Snapshot{})
+			if err := m.Snapshots[len(m.Snapshots)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Log = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Info = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
-			}
-			m.GasWanted = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.GasWanted |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-		case 6:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseOfferSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseOfferSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
-			m.GasUsed = 0
+			m.Result = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11857,50 +14264,66 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GasUsed |= int64(b&0x7F) << shift
+				m.Result |= ResponseOfferSnapshot_Result(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if postIndex > l {
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Events = append(m.Events, Event{})
-			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 8:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11910,23 +14333,25 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Codespace = string(dAtA[iNdEx:postIndex])
+			m.Chunk = append(m.Chunk[:0], dAtA[iNdEx:postIndex]...)
+			if m.Chunk == nil {
+				m.Chunk = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11949,7 +14374,7 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
+func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11972,17 +14397,17 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseEndBlock: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseApplySnapshotChunk: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseEndBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseApplySnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorUpdates", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
-			var msglen int
+			m.Result = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11992,67 +14417,92 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Result |= ResponseApplySnapshotChunk_Result(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ValidatorUpdates = append(m.ValidatorUpdates, ValidatorUpdate{})
-			if err := m.ValidatorUpdates[len(m.ValidatorUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParamUpdates", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.RefetchChunks = append(m.RefetchChunks, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthTypes
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
 				}
+				elementCount = count
+				if elementCount != 0 && len(m.RefetchChunks) == 0 {
+					m.RefetchChunks = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RefetchChunks = append(m.RefetchChunks, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefetchChunks", wireType)
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ConsensusParamUpdates == nil {
-				m.ConsensusParamUpdates = &types1.ConsensusParams{}
-			}
-			if err := m.ConsensusParamUpdates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectSenders", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12062,25 +14512,23 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Events = append(m.Events, Event{})
-			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.RejectSenders = append(m.RejectSenders, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12103,7 +14551,7 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
+func (m *ResponsePreprocessTxs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12126,15 +14574,15 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseCommit: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponsePreprocessTxs: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseCommit: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponsePreprocessTxs: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -12161,16 +14609,14 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RetainHeight", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
 			}
-			m.RetainHeight = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12180,11 +14626,28 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RetainHeight |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Messages == nil {
+				m.Messages = &types1.Messages{}
+			}
+			if err := m.Messages.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -12206,7 +14669,7 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
+func (m *ResponseFinalizeBlock) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12229,15 +14692,15 @@ func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseListSnapshots: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseFinalizeBlock: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseListSnapshots: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseFinalizeBlock: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Snapshots", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12264,66 +14727,50 @@ func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Snapshots = append(m.Snapshots, &Snapshot{})
-			if err := m.Snapshots[len(m.Snapshots)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Events = append(m.Events, Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxResults", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseOfferSnapshot: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseOfferSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			m.TxResults = append(m.TxResults, &ResponseDeliverTx{})
+			if err := m.TxResults[len(m.TxResults)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Result = 0
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorUpdates", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12333,66 +14780,31 @@ func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Result |= ResponseOfferSnapshot_Result(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.ValidatorUpdates = append(m.ValidatorUpdates, ValidatorUpdate{})
+			if err := m.ValidatorUpdates[len(m.ValidatorUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParamUpdates", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12402,24 +14814,26 @@ func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Chunk = append(m.Chunk[:0], dAtA[iNdEx:postIndex]...)
-			if m.Chunk == nil {
-				m.Chunk = []byte{}
+			if m.ConsensusParamUpdates == nil {
+				m.ConsensusParamUpdates = &types1.ConsensusParams{}
+			}
+			if err := m.ConsensusParamUpdates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -12443,7 +14857,7 @@ func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
+func (m *ResponseProcessProposal) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12466,112 +14880,17 @@ func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseApplySnapshotChunk: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseProcessProposal: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseApplySnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseProcessProposal: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
-			}
-			m.Result = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Result |= ResponseApplySnapshotChunk_Result(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType == 0 {
-				var v uint32
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowTypes
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint32(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.RefetchChunks = append(m.RefetchChunks, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowTypes
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthTypes
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthTypes
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.RefetchChunks) == 0 {
-					m.RefetchChunks = make([]uint32, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint32
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowTypes
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint32(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.RefetchChunks = append(m.RefetchChunks, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field RefetchChunks", wireType)
-			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RejectSenders", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Accept", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12581,24 +14900,12 @@ func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.RejectSenders = append(m.RejectSenders, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
+			m.Accept = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -12620,7 +14927,7 @@ func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponsePreprocessTxs) Unmarshal(dAtA []byte) error {
+func (m *ResponseRollback) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12643,17 +14950,17 @@ func (m *ResponsePreprocessTxs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponsePreprocessTxs: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseRollback: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponsePreprocessTxs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseRollback: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockHeight", wireType)
 			}
-			var byteLen int
+			m.LastBlockHeight = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12663,29 +14970,16 @@ func (m *ResponsePreprocessTxs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.LastBlockHeight |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
-			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockAppHash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12695,26 +14989,24 @@ func (m *ResponsePreprocessTxs) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Messages == nil {
-				m.Messages = &types1.Messages{}
-			}
-			if err := m.Messages.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.LastBlockAppHash = append(m.LastBlockAppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.LastBlockAppHash == nil {
+				m.LastBlockAppHash = []byte{}
 			}
 			iNdEx = postIndex
 		default:
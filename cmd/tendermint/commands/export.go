@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/internal/chaindata"
+	"github.com/tendermint/tendermint/types"
+)
+
+// MakeExportCommand returns the command exporting the blocks and the state of
+// a stopped node to an archive.
+func MakeExportCommand() *cobra.Command {
+	var (
+		height int64
+		output string
+	)
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the blocks and the state up to a height to an archive",
+		Long: `
+	export writes the blocks of a stopped node up to a height, with their
+	validator sets and consensus params and the state after the last block, to
+	an archive which bootstraps a new node with tendermint import. The chain of
+	blocks is verified as it is exported. The application state isn't part of
+	the archive: the application of the new node must be at the same height, or
+	replays the blocks.
+	`,
+		Example: `
+	tendermint export --output chain.archive
+	tendermint export --height 1000 --output chain.archive
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output == "" {
+				return errors.New("the output file is required")
+			}
+			blockStore, stateStore, closeDBs, err := openStores(true)
+			if err != nil {
+				return err
+			}
+			defer closeDBs()
+
+			if height == 0 {
+				state, err := stateStore.Load()
+				if err != nil {
+					return err
+				}
+				height = state.LastBlockHeight
+			}
+
+			f, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			from, err := chaindata.Export(f, stateStore, blockStore, height)
+			if err != nil {
+				f.Close()
+				os.Remove(output)
+				return fmt.Errorf("export failed: %w", err)
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "exported blocks %d to %d to %s\n", from, height, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&height, "height", 0, "the height of the last block to export, 0 for the latest")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "the archive file to write")
+	addDBFlags(cmd)
+	return cmd
+}
+
+// MakeImportCommand returns the command bootstrapping the stores of a new node
+// from an archive written by tendermint export.
+func MakeImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [archive]",
+		Short: "Bootstrap a new node from an archive of blocks and state",
+		Long: `
+	import verifies an archive written by tendermint export against the genesis
+	of the node, and then saves its blocks and state to the stores of the node,
+	which must be empty. The node then continues the chain from the last block
+	of the archive.
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+			if err != nil {
+				return err
+			}
+
+			// the archive is verified before anything is saved to the stores
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			_, _, err = chaindata.Verify(f, genDoc)
+			f.Close()
+			if err != nil {
+				return fmt.Errorf("invalid archive: %w", err)
+			}
+
+			blockStore, stateStore, closeDBs, err := openStores(false)
+			if err != nil {
+				return err
+			}
+			defer closeDBs()
+
+			f, err = os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			from, to, err := chaindata.Import(f, stateStore, blockStore, genDoc)
+			if err != nil {
+				return fmt.Errorf("import failed: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "imported blocks %d to %d\n", from, to)
+			if from != genDoc.InitialHeight {
+				fmt.Fprintf(cmd.OutOrStdout(), "the archive starts after the initial height: the validators of "+
+					"block %d weren't verified against the genesis, make sure the archive comes from a trusted node\n", from)
+			}
+			return nil
+		},
+	}
+
+	addDBFlags(cmd)
+	return cmd
+}
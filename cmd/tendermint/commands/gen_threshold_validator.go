@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	threshold int
+	shares    int
+)
+
+// GenThresholdValidatorCmd allows the generation of a threshold key for a
+// validator, split into shares for the remote signers which sign jointly.
+var GenThresholdValidatorCmd = &cobra.Command{
+	Use:   "gen-threshold-validator",
+	Short: "Generate new threshold validator key shares",
+	RunE:  genThresholdValidator,
+}
+
+func init() {
+	GenThresholdValidatorCmd.Flags().IntVar(&threshold, "threshold", 2,
+		"Number of key shares needed to sign")
+	GenThresholdValidatorCmd.Flags().IntVar(&shares, "shares", 3,
+		"Number of key shares to generate")
+	GenThresholdValidatorCmd.Flags().StringVar(&keyType, "key", "",
+		"Key type to generate the threshold key with, instead of ed25519. Options: ed25519, bls")
+}
+
+func genThresholdValidator(cmd *cobra.Command, args []string) error {
+	thresholdKeyType := keyType
+	if thresholdKeyType == "" {
+		thresholdKeyType = types.ABCIPubKeyTypeEd25519
+	}
+	keys, err := privval.GenThresholdPVKeys(thresholdKeyType, threshold, shares)
+	if err != nil {
+		return err
+	}
+
+	jsbz, err := tmjson.Marshal(keys)
+	if err != nil {
+		return fmt.Errorf("validator -> json: %w", err)
+	}
+
+	fmt.Printf(`%v
+`, string(jsbz))
+
+	return nil
+}
@@ -7,7 +7,6 @@ import (
 
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/privval"
-	"github.com/tendermint/tendermint/types"
 )
 
 // GenValidatorCmd allows the generation of a keypair for a
@@ -19,12 +18,12 @@ var GenValidatorCmd = &cobra.Command{
 }
 
 func init() {
-	GenValidatorCmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeEd25519,
-		"Key type to generate privval file with. Options: ed25519, secp256k1")
+	GenValidatorCmd.Flags().StringVar(&keyType, "key", "",
+		"Key type to generate privval file with, instead of the priv-validator key-type of the config. Options: ed25519, secp256k1, bls")
 }
 
 func genValidator(cmd *cobra.Command, args []string) error {
-	pv, err := privval.GenFilePV("", "", keyType)
+	pv, err := privval.GenFilePV("", "", privValidatorKeyType(config))
 	if err != nil {
 		return err
 	}
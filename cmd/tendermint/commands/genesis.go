@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// MakeGenesisCommand constructs the command to check the genesis file.
+func MakeGenesisCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "genesis",
+		Short: "Validate and hash the genesis file",
+		Long: `Validate and hash the genesis file, config/genesis.json by default.
+
+The canonical hash of the genesis doc doesn't depend on the formatting of the
+file: it is the SHA-256 hash of its JSON encoding with sorted keys and without
+whitespace, once the defaults are filled in. Nodes log it at startup, and
+refuse to start if it doesn't match expected-genesis-hash, when set.`,
+	}
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "validate [file]",
+			Short: "Check the genesis file",
+			Args:  cobra.MaximumNArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				genDoc, err := loadGenesisDoc(args)
+				if err != nil {
+					return err
+				}
+				hash, err := genDoc.Hash()
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "genesis of %s with %d validators is valid, hash %X\n",
+					genDoc.ChainID, len(genDoc.Validators), hash)
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "hash [file]",
+			Short: "Print the canonical hash of the genesis file",
+			Args:  cobra.MaximumNArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				genDoc, err := loadGenesisDoc(args)
+				if err != nil {
+					return err
+				}
+				hash, err := genDoc.Hash()
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%X\n", hash)
+				return nil
+			},
+		},
+	)
+	return cmd
+}
+
+// loadGenesisDoc loads and validates the genesis file given as argument, or
+// else the one of the config.
+func loadGenesisDoc(args []string) (*types.GenesisDoc, error) {
+	file := config.GenesisFile()
+	if len(args) > 0 {
+		file = args[0]
+	}
+	return types.GenesisDocFromFile(file)
+}
@@ -30,8 +30,20 @@ var (
 )
 
 func init() {
-	InitFilesCmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeEd25519,
-		"Key type to generate privval file with. Options: ed25519, secp256k1")
+	InitFilesCmd.Flags().StringVar(&keyType, "key", "",
+		"Key type to generate privval file with, instead of the priv-validator key-type of the config. Options: ed25519, secp256k1, bls")
+}
+
+// privValidatorKeyType returns the key type of the --key flag if set, or else
+// the one of the config.
+func privValidatorKeyType(config *cfg.Config) string {
+	if keyType != "" {
+		return keyType
+	}
+	if config.PrivValidator.KeyType != "" {
+		return config.PrivValidator.KeyType
+	}
+	return types.ABCIPubKeyTypeEd25519
 }
 
 func initFiles(cmd *cobra.Command, args []string) error {
@@ -61,7 +73,7 @@ func initFilesWithConfig(config *cfg.Config) error {
 			logger.Info("Found private validator", "keyFile", privValKeyFile,
 				"stateFile", privValStateFile)
 		} else {
-			pv, err = privval.GenFilePV(privValKeyFile, privValStateFile, keyType)
+			pv, err = privval.GenFilePV(privValKeyFile, privValStateFile, privValidatorKeyType(config))
 			if err != nil {
 				return err
 			}
@@ -92,9 +104,9 @@ func initFilesWithConfig(config *cfg.Config) error {
 			GenesisTime:     tmtime.Now(),
 			ConsensusParams: types.DefaultConsensusParams(),
 		}
-		if keyType == "secp256k1" {
+		if keyType := privValidatorKeyType(config); keyType != types.ABCIPubKeyTypeEd25519 {
 			genDoc.ConsensusParams.Validator = types.ValidatorParams{
-				PubKeyTypes: []string{types.ABCIPubKeyTypeSecp256k1},
+				PubKeyTypes: []string{keyType},
 			}
 		}
 
@@ -0,0 +1,243 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/google/orderedcode"
+	"github.com/spf13/cobra"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	dbm "github.com/tendermint/tm-db"
+
+	cfg "github.com/tendermint/tendermint/config"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/store"
+)
+
+// inspectDBs are the databases reported by inspect-db usage.
+var inspectDBs = []string{"blockstore", "state", "tx_index", "evidence", "light", "peerstore"}
+
+// keyPrefixes names the prefixes of the keys of the databases, which are
+// unique across all of them.
+var keyPrefixes = map[int64]string{
+	0:  "block metas",
+	1:  "block parts",
+	2:  "block commits",
+	3:  "seen commit",
+	4:  "block hash index",
+	5:  "validators",
+	6:  "consensus params",
+	7:  "ABCI responses",
+	8:  "state",
+	9:  "committed evidence",
+	10: "pending evidence",
+	11: "light blocks",
+	12: "light store size",
+	13: "block archive height",
+	14: "schema version",
+}
+
+// MakeInspectDBCommand returns the command inspecting the block store and
+// state store databases offline, without starting the node.
+func MakeInspectDBCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect-db",
+		Short: "Verify, measure and repair the databases of a stopped node",
+		Long: `
+	inspect-db is an offline tooling to verify the integrity of the block store
+	and state store, report the disk usage of the databases and repair the
+	recoverable inconsistencies of the block store. The node must be stopped.
+	verify and usage open the goleveldb databases read-only.
+	`,
+	}
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the integrity of the block store and state store",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blockStore, stateStore, closeDBs, err := openStores(true)
+			if err != nil {
+				return err
+			}
+			defer closeDBs()
+
+			return verifyStores(cmd.OutOrStdout(), blockStore, stateStore)
+		},
+	}
+
+	usageCmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Report the size of the keys and values of the databases, by kind of data",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "DATABASE\tDATA\tKEYS\tBYTES")
+			for _, id := range inspectDBs {
+				if _, err := os.Stat(filepath.Join(config.DBDir(), id+".db")); errors.Is(err, os.ErrNotExist) {
+					continue
+				}
+				db, err := openDB(id, true)
+				if err != nil {
+					return fmt.Errorf("opening database %q: %w", id, err)
+				}
+				err = printDBUsage(w, id, db)
+				db.Close()
+				if err != nil {
+					return err
+				}
+			}
+			return w.Flush()
+		},
+	}
+
+	repairCmd := &cobra.Command{
+		Use:   "repair",
+		Short: "Repair the recoverable inconsistencies of the block store",
+		Long: `
+	repair rebuilds the block hash index and the missing commits from the blocks,
+	and deletes the orphaned data left outside of the blocks of the block store.
+	The inconsistencies which can't be repaired are reported.
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blockStore, stateStore, closeDBs, err := openStores(false)
+			if err != nil {
+				return err
+			}
+			defer closeDBs()
+
+			repaired, err := blockStore.Repair()
+			if err != nil {
+				return err
+			}
+			for _, inconsistency := range repaired {
+				fmt.Fprintf(cmd.OutOrStdout(), "repaired: %v\n", inconsistency)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "repaired %d inconsistencies\n", len(repaired))
+
+			return verifyStores(cmd.OutOrStdout(), blockStore, stateStore)
+		},
+	}
+
+	for _, subcmd := range []*cobra.Command{verifyCmd, usageCmd, repairCmd} {
+		addDBFlags(subcmd)
+		cmd.AddCommand(subcmd)
+	}
+	return cmd
+}
+
+// openDB opens the database id of the node, read-only if possible.
+func openDB(id string, readOnly bool) (dbm.DB, error) {
+	if readOnly && dbm.BackendType(config.DBBackend) == dbm.GoLevelDBBackend {
+		return dbm.NewGoLevelDBWithOpts(id, config.DBDir(), &opt.Options{ReadOnly: true, ErrorIfMissing: true})
+	}
+	return cfg.DefaultDBProvider(&cfg.DBContext{ID: id, Config: config})
+}
+
+// openStores opens the block store and the state store, and returns a function
+// closing their databases.
+func openStores(readOnly bool) (*store.BlockStore, sm.Store, func(), error) {
+	blockStoreDB, err := openDB("blockstore", readOnly)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("opening block store: %w", err)
+	}
+	stateDB, err := openDB("state", readOnly)
+	if err != nil {
+		blockStoreDB.Close()
+		return nil, nil, nil, fmt.Errorf("opening state store: %w", err)
+	}
+
+	archive, err := store.ArchiveFromConfig(config.BaseConfig)
+	if err != nil {
+		blockStoreDB.Close()
+		stateDB.Close()
+		return nil, nil, nil, fmt.Errorf("opening block archive: %w", err)
+	}
+	blockStore := store.NewBlockStore(blockStoreDB, store.BlockStoreWithArchive(archive))
+	closeDBs := func() {
+		blockStoreDB.Close()
+		stateDB.Close()
+	}
+	return blockStore, sm.NewStore(stateDB), closeDBs, nil
+}
+
+// verifyStores prints the inconsistencies of the stores to w, and returns an
+// error if there are any.
+func verifyStores(w io.Writer, blockStore *store.BlockStore, stateStore sm.Store) error {
+	fmt.Fprintf(w, "block store: base %d, height %d\n", blockStore.Base(), blockStore.Height())
+
+	inconsistencies, err := blockStore.Verify()
+	if err != nil {
+		return fmt.Errorf("verifying block store: %w", err)
+	}
+	repairable := 0
+	for _, inconsistency := range inconsistencies {
+		if inconsistency.Repairable() {
+			repairable++
+			fmt.Fprintf(w, "block store: %v (repairable)\n", inconsistency)
+		} else {
+			fmt.Fprintf(w, "block store: %v\n", inconsistency)
+		}
+	}
+
+	stateInconsistencies, err := sm.VerifyStore(stateStore, blockStore)
+	if err != nil {
+		return fmt.Errorf("verifying state store: %w", err)
+	}
+	for _, inconsistency := range stateInconsistencies {
+		fmt.Fprintf(w, "state store: %v\n", inconsistency)
+	}
+
+	total := len(inconsistencies) + len(stateInconsistencies)
+	if total > 0 {
+		return fmt.Errorf("found %d inconsistencies, %d of them repairable with inspect-db repair", total, repairable)
+	}
+	fmt.Fprintln(w, "no inconsistencies found")
+	return nil
+}
+
+// printDBUsage prints the number of keys and the size of the keys and values
+// of db to w, by key prefix. The size is the size before compression.
+func printDBUsage(w io.Writer, id string, db dbm.DB) error {
+	type usage struct {
+		keys  int64
+		bytes int64
+	}
+	usages := make(map[string]*usage)
+
+	iter, err := db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		name := "other"
+		var prefix int64
+		if _, err := orderedcode.Parse(string(iter.Key()), &prefix); err == nil {
+			if prefixName, ok := keyPrefixes[prefix]; ok {
+				name = prefixName
+			}
+		}
+		if usages[name] == nil {
+			usages[name] = &usage{}
+		}
+		usages[name].keys++
+		usages[name].bytes += int64(len(iter.Key()) + len(iter.Value()))
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(usages))
+	for name := range usages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", id, name, usages[name].keys, usages[name].bytes)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/privval"
+)
+
+// KeyNewPassphraseEnv is the environment variable holding the new passphrase
+// of the validator key, for the encrypt and rotate subcommands.
+const KeyNewPassphraseEnv = "TM_PRIV_VALIDATOR_KEY_NEW_PASSPHRASE"
+
+// MakeKeyCommand constructs the command to manage the encryption of the
+// validator key file, and to import and export the keys.
+func MakeKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "key",
+		Short: "Manage the encryption of the validator key file, and import or export the keys",
+		Long: fmt.Sprintf(`Manage the passphrase-based encryption of the validator key file, and
+import or export the validator and node keys.
+
+The passphrase of an encrypted key file is read, at startup and by these
+commands, from the %s environment variable, the %s
+systemd credential or else a prompt. The new passphrase of the encrypt and
+rotate commands is read from the %s environment
+variable or else a prompt.`, privval.KeyPassphraseEnv, privval.KeyPassphraseCredential, KeyNewPassphraseEnv),
+	}
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "encrypt",
+			Short: "Encrypt the validator key file with a passphrase",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				encrypted, err := isKeyFileEncrypted()
+				if err != nil {
+					return err
+				}
+				if encrypted {
+					return errors.New("the validator key file is already encrypted, use rotate to change the passphrase")
+				}
+				return saveKeyFile(newKeyPassphrase)
+			},
+		},
+		&cobra.Command{
+			Use:   "decrypt",
+			Short: "Decrypt the validator key file",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				encrypted, err := isKeyFileEncrypted()
+				if err != nil {
+					return err
+				}
+				if !encrypted {
+					return errors.New("the validator key file is not encrypted")
+				}
+				return saveKeyFile(func() ([]byte, error) { return nil, nil })
+			},
+		},
+		&cobra.Command{
+			Use:   "rotate",
+			Short: "Change the passphrase of the validator key file",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				encrypted, err := isKeyFileEncrypted()
+				if err != nil {
+					return err
+				}
+				if !encrypted {
+					return errors.New("the validator key file is not encrypted, use encrypt to set a passphrase")
+				}
+				return saveKeyFile(newKeyPassphrase)
+			},
+		},
+		makeKeyExportCommand(),
+		makeKeyImportCommand(),
+	)
+	return cmd
+}
+
+func isKeyFileEncrypted() (bool, error) {
+	bz, err := ioutil.ReadFile(config.PrivValidator.KeyFile())
+	if err != nil {
+		return false, err
+	}
+	return privval.IsEncryptedKey(bz), nil
+}
+
+// saveKeyFile loads the validator key file, and saves it again encrypted with
+// the passphrase, or in plaintext if it is nil.
+func saveKeyFile(passphrase func() ([]byte, error)) error {
+	keyFile := config.PrivValidator.KeyFile()
+	pv, err := privval.LoadFilePVEmptyState(keyFile, "")
+	if err != nil {
+		return err
+	}
+	newPassphrase, err := passphrase()
+	if err != nil {
+		return err
+	}
+	pv.Key.SetPassphrase(newPassphrase)
+	pv.Key.Save()
+	if newPassphrase == nil {
+		logger.Info("Decrypted the validator key file", "path", keyFile)
+	} else {
+		logger.Info("Encrypted the validator key file", "path", keyFile)
+	}
+	return nil
+}
+
+func newKeyPassphrase() ([]byte, error) {
+	if passphrase, ok := os.LookupEnv(KeyNewPassphraseEnv); ok {
+		if passphrase == "" {
+			return nil, errors.New("empty passphrase")
+		}
+		return []byte(passphrase), nil
+	}
+	passphrase, err := privval.PromptPassphrase("New passphrase of the validator key: ")
+	if err != nil {
+		return nil, err
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.New("empty passphrase")
+	}
+	confirmation, err := privval.PromptPassphrase("Repeat the new passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(passphrase, confirmation) {
+		return nil, errors.New("the passphrases don't match")
+	}
+	return passphrase, nil
+}
@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/types"
+)
+
+// KeyMnemonicPassphraseEnv is the environment variable holding the BIP-39
+// passphrase of the mnemonic a key is imported from, if any.
+const KeyMnemonicPassphraseEnv = "TM_KEY_MNEMONIC_PASSPHRASE"
+
+// The formats of the exported and imported keys.
+const (
+	keyFormatJSON     = "json"
+	keyFormatPEM      = "pem"
+	keyFormatMnemonic = "mnemonic"
+	keyFormatSeed     = "seed"
+)
+
+func makeKeyExportCommand() *cobra.Command {
+	var (
+		nodeKey bool
+		format  string
+	)
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Print the validator key, or the node key, in plaintext",
+		Long: `
+	export prints the validator key, or the node key with --node, decrypted if
+	the validator key file is encrypted: in the JSON of the key files, or in
+	PEM, the ed25519 keys in PKCS #8 as read by openssl.
+	`,
+		Example: `
+	tendermint key export --format=pem > priv_validator_key.pem
+	tendermint key export --node
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				privKey crypto.PrivKey
+				key     interface{}
+			)
+			if nodeKey {
+				nk, err := types.LoadNodeKey(config.NodeKeyFile())
+				if err != nil {
+					return err
+				}
+				privKey, key = nk.PrivKey, nk
+			} else {
+				pv, err := privval.LoadFilePVEmptyState(config.PrivValidator.KeyFile(), "")
+				if err != nil {
+					return err
+				}
+				privKey, key = pv.Key.PrivKey, pv.Key
+			}
+
+			var (
+				bz  []byte
+				err error
+			)
+			switch format {
+			case keyFormatJSON:
+				bz, err = tmjson.MarshalIndent(key, "", "  ")
+				bz = append(bz, '\n')
+			case keyFormatPEM:
+				bz, err = privval.MarshalPrivKeyPEM(privKey)
+			case keyFormatMnemonic:
+				return errors.New("the keys can't be exported as mnemonics, only derived from them")
+			default:
+				return fmt.Errorf("unknown format %q", format)
+			}
+			if err != nil {
+				return err
+			}
+			_, err = cmd.OutOrStdout().Write(bz)
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(&nodeKey, "node", false, "export the node key instead of the validator key")
+	cmd.Flags().StringVar(&format, "format", keyFormatJSON, "the format of the key: json or pem")
+	return cmd
+}
+
+func makeKeyImportCommand() *cobra.Command {
+	var (
+		nodeKey bool
+		format  string
+		keyType string
+		force   bool
+	)
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Replace the validator key, or the node key, with an imported key",
+		Long: fmt.Sprintf(`
+	import reads a key from the file, or else the standard input, and saves it
+	as the validator key, or the node key with --node. The key is read in the
+	format of the key files, in PEM, or derived of the type of --key-type from a
+	BIP-39 mnemonic, with the passphrase of the %s
+	environment variable if set, or from a seed, the same seed always deriving
+	the same key, e.g. for the keys of a test network.
+
+	The key file of the node isn't replaced unless --force is set. The imported
+	validator key is saved in plaintext, to be encrypted with tendermint key
+	encrypt, and the last sign state of the validator is kept: a validator key
+	which signed elsewhere must be imported with the last sign state of the
+	node which used it, not to double sign.
+	`, KeyMnemonicPassphraseEnv),
+		Example: `
+	tendermint key import --format=pem priv_validator_key.pem
+	tendermint key import --format=mnemonic --key-type=ed25519 < mnemonic.txt
+	echo -n validator01 | tendermint key import --format=seed --force
+	`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				bz  []byte
+				err error
+			)
+			if len(args) == 1 {
+				bz, err = ioutil.ReadFile(args[0])
+			} else {
+				bz, err = ioutil.ReadAll(io.LimitReader(cmd.InOrStdin(), 1<<20))
+			}
+			if err != nil {
+				return err
+			}
+			privKey, err := parseKey(format, keyType, bz)
+			if err != nil {
+				return err
+			}
+
+			if nodeKey {
+				if _, ok := privKey.(ed25519.PrivKey); !ok {
+					return fmt.Errorf("the node key must be an ed25519 key, not %s", privKey.Type())
+				}
+				keyFile := config.NodeKeyFile()
+				if tmos.FileExists(keyFile) && !force {
+					return fmt.Errorf("the node key file %s exists, set --force to replace it", keyFile)
+				}
+				nk := types.NodeKey{ID: types.NodeIDFromPubKey(privKey.PubKey()), PrivKey: privKey}
+				if err := nk.SaveAs(keyFile); err != nil {
+					return err
+				}
+				logger.Info("Imported the node key", "path", keyFile, "id", nk.ID)
+				return nil
+			}
+
+			keyFile, stateFile := config.PrivValidator.KeyFile(), config.PrivValidator.StateFile()
+			if tmos.FileExists(keyFile) && !force {
+				return fmt.Errorf("the validator key file %s exists, set --force to replace it", keyFile)
+			}
+			pv := privval.NewFilePV(privKey, keyFile, stateFile)
+			pv.Key.Save()
+			if !tmos.FileExists(stateFile) {
+				pv.LastSignState.Save()
+			}
+			logger.Info("Imported the validator key", "path", keyFile, "address", pv.Key.Address)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&nodeKey, "node", false, "import the node key instead of the validator key")
+	cmd.Flags().StringVar(&format, "format", keyFormatJSON, "the format of the key: json, pem, mnemonic or seed")
+	cmd.Flags().StringVar(&keyType, "key-type", types.ABCIPubKeyTypeEd25519,
+		"the type of the key derived from a mnemonic or a seed")
+	cmd.Flags().BoolVar(&force, "force", false, "replace the existing key file")
+	return cmd
+}
+
+// parseKey returns the key read from bz in the format.
+func parseKey(format, keyType string, bz []byte) (crypto.PrivKey, error) {
+	switch format {
+	case keyFormatJSON:
+		// both the validator and the node key files hold the key in priv_key
+		var key struct {
+			PrivKey crypto.PrivKey `json:"priv_key"`
+		}
+		if err := tmjson.Unmarshal(bz, &key); err != nil {
+			return nil, err
+		}
+		if key.PrivKey == nil {
+			return nil, errors.New("no priv_key found")
+		}
+		return key.PrivKey, nil
+	case keyFormatPEM:
+		return privval.UnmarshalPrivKeyPEM(bz)
+	case keyFormatMnemonic:
+		return privval.PrivKeyFromMnemonic(keyType, string(bz), os.Getenv(KeyMnemonicPassphraseEnv))
+	case keyFormatSeed:
+		seed := strings.TrimRight(string(bz), "\r\n")
+		if seed == "" {
+			return nil, errors.New("empty seed")
+		}
+		return privval.GenPrivKeyFromSeed(keyType, []byte(seed))
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
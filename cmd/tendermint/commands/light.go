@@ -13,14 +13,19 @@ import (
 	"github.com/spf13/cobra"
 	dbm "github.com/tendermint/tm-db"
 
+	tmcfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/das"
 	lproxy "github.com/tendermint/tendermint/light/proxy"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
 	dbs "github.com/tendermint/tendermint/light/store/db"
+	"github.com/tendermint/tendermint/node"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	"github.com/tendermint/tendermint/types"
 )
 
 // LightCmd represents the base command when called without any subcommands
@@ -33,11 +38,25 @@ All calls that can be tracked back to a block header by a proof
 will be verified before passing them back to the caller. Other than
 that, it will present the same interface as a full Tendermint node.
 
+Results of /abci_query, /tx and /block_results carry a "verification" field
+with the height and hash of the trusted header they were verified against.
+Proofs are always requested from the primary; responses that cannot be
+verified are returned as errors rather than passed through.
+
 Furthermore to the chainID, a fresh instance of a light client will
 need a primary RPC address, a trusted hash and height and witness RPC addresses
 (if not using sequential verification). To restart the node, thereafter
 only the chainID is required.
 
+With --das-samples, the light client also runs data availability sampling:
+it doesn't download the blocks, but verifies that their data is available by
+sampling random shares of each new block from the peers given by --das-peers,
+over the p2p share channel, against the data availability header committed to
+in the verified header. The data availability headers are requested from the
+primary and the witnesses. The confidence reached for each height, or the
+failure of the heights which couldn't be sampled and are retried, is reported
+by /das_status.
+
 When /abci_query is called, the Merkle key path format is:
 
 	/{store name}/{key}
@@ -68,6 +87,16 @@ var (
 	logLevel  string
 	logFormat string
 
+	dbBackend       string
+	pruningSize     uint16
+	pruningInterval int64
+
+	dasSamples       int
+	dasInterval      time.Duration
+	dasLaddr         string
+	dasPeers         string
+	dasCheckEncoding bool
+
 	primaryKey   = []byte("primary")
 	witnessesKey = []byte("witnesses")
 )
@@ -98,6 +127,31 @@ func init() {
 	LightCmd.Flags().BoolVar(&sequential, "sequential", false,
 		"sequential verification. Verify all headers sequentially as opposed to using skipping verification",
 	)
+	LightCmd.Flags().StringVar(&dbBackend, "db-backend", string(dbm.GoLevelDBBackend),
+		"database backend of the trusted store: goleveldb | cleveldb | boltdb | rocksdb | badgerdb | pebble. "+
+			"cleveldb, boltdb, rocksdb and badgerdb require building with the corresponding build tag",
+	)
+	LightCmd.Flags().Uint16Var(&pruningSize, "pruning-size", 1000,
+		"number of most recent light blocks to keep in the trusted store. 0 disables pruning",
+	)
+	LightCmd.Flags().Int64Var(&pruningInterval, "pruning-interval", 0,
+		"also keep the light blocks at heights that are a multiple of this interval. 0 keeps none",
+	)
+	LightCmd.Flags().IntVar(&dasSamples, "das-samples", 0,
+		"number of shares sampled to verify the availability of the data of each new block. 0 disables sampling",
+	)
+	LightCmd.Flags().DurationVar(&dasInterval, "das-interval", time.Second,
+		"interval at which the new blocks are sampled",
+	)
+	LightCmd.Flags().StringVar(&dasLaddr, "das-laddr", "tcp://0.0.0.0:26656",
+		"p2p address listened on by data availability sampling",
+	)
+	LightCmd.Flags().StringVar(&dasPeers, "das-peers", "",
+		"peers to sample the shares from, comma-separated ID@host:port",
+	)
+	LightCmd.Flags().BoolVar(&dasCheckEncoding, "das-check-encoding", false,
+		"download the whole extended data square of each new block to check its erasure coding, as partial nodes do",
+	)
 }
 
 func runProxy(cmd *cobra.Command, args []string) error {
@@ -114,7 +168,7 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		witnessesAddrs = strings.Split(witnessAddrsJoined, ",")
 	}
 
-	lightDB, err := dbm.NewGoLevelDB("light-client-db", dir)
+	lightDB, err := tmcfg.NewDB("light-client-db", dbm.BackendType(dbBackend), dir)
 	if err != nil {
 		return fmt.Errorf("can't create a db: %w", err)
 	}
@@ -143,7 +197,11 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("can't parse trust level: %w", err)
 	}
 
-	options := []light.Option{light.Logger(logger)}
+	options := []light.Option{
+		light.Logger(logger),
+		light.PruningSize(pruningSize),
+		light.PruningInterval(pruningInterval),
+	}
 
 	if sequential {
 		options = append(options, light.SequentialVerification())
@@ -186,8 +244,58 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	var dasNode *node.DASNode
+	if dasSamples > 0 {
+		dasNode, err = makeDASNode(c, logger)
+		if err != nil {
+			return err
+		}
+		clients := make([]das.Client, 0, len(witnessesAddrs)+1)
+		for _, addr := range append([]string{primaryAddr}, witnessesAddrs...) {
+			client, err := rpchttp.NewWithTimeout(addr, cfg.WriteTimeout)
+			if err != nil {
+				return fmt.Errorf("failed to create http client for %s: %w", addr, err)
+			}
+			clients = append(clients, client)
+		}
+		opts := []das.Option{
+			das.SamplesPerHeight(dasSamples),
+			das.SampleInterval(dasInterval),
+		}
+		if dasCheckEncoding {
+			opts = append(opts, das.CheckEncoding())
+		}
+		p.Sampler = das.NewSampler(c, clients, dasNode.Shares(), logger.With("module", "das"), opts...)
+		dasNode.SetBadEncodingProofHandler(p.Sampler.HandleBadEncodingProof)
+		logger.Info("Starting data availability sampling...", "samples", dasSamples)
+		if err := dasNode.Start(); err != nil {
+			return err
+		}
+		if err := p.Sampler.Start(); err != nil {
+			return err
+		}
+
+		// the data of the chain can't be trusted after a bad encoding proof
+		go func() {
+			<-p.Sampler.Halted()
+			logger.Error("Halting on a bad encoding proof", "height", p.Sampler.BadEncodingProof().Height)
+			if err := dasNode.Stop(); err != nil {
+				logger.Error("failed to stop the data availability sampling node", "err", err)
+			}
+			os.Exit(1)
+		}()
+	}
+
 	// Stop upon receiving SIGTERM or CTRL-C.
 	tmos.TrapSignal(logger, func() {
+		if p.Sampler != nil {
+			if err := p.Sampler.Stop(); err != nil {
+				logger.Error("failed to stop the sampler", "err", err)
+			}
+			if err := dasNode.Stop(); err != nil {
+				logger.Error("failed to stop the data availability sampling node", "err", err)
+			}
+		}
 		p.Listener.Close()
 	})
 
@@ -200,6 +308,28 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// makeDASNode returns the p2p node fetching the shares sampled, keeping its
+// node key and peer store in the light client directory.
+func makeDASNode(c *light.Client, logger log.Logger) (*node.DASNode, error) {
+	lb, err := c.TrustedLightBlock(0)
+	if err != nil {
+		return nil, err
+	}
+
+	config.SetRoot(dir)
+	config.P2P.ListenAddress = dasLaddr
+	config.P2P.PersistentPeers = dasPeers
+	if err := tmos.EnsureDir(filepath.Dir(config.NodeKeyFile()), 0700); err != nil {
+		return nil, err
+	}
+	nodeKey, err := types.LoadOrGenNodeKey(config.NodeKeyFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
+	}
+
+	return node.NewDASNode(config, nodeKey, chainID, lb.Version.Block, logger.With("module", "das"))
+}
+
 func checkForExistingProviders(db dbm.DB) (string, []string, error) {
 	primaryBytes, err := db.Get(primaryKey)
 	if err != nil {
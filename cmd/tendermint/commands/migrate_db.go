@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/migrate"
+)
+
+// MakeMigrateDBCommand returns the command migrating the block store and state
+// store databases of a stopped node to the latest version of their schema.
+func MakeMigrateDBCommand() *cobra.Command {
+	var check bool
+	cmd := &cobra.Command{
+		Use:   "migrate-db",
+		Short: "Migrate the block store and state store to the latest version of their schema",
+		Long: `
+	migrate-db migrates the block store and state store databases of a stopped
+	node written with an older key layout to the layout of this version of
+	Tendermint. The node refuses to start until its databases are migrated. An
+	interrupted migration resumes from the last completed step. Back up the data
+	directory before migrating.
+	`,
+		Example: `
+	tendermint migrate-db --check
+	tendermint migrate-db
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if check {
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+				fmt.Fprintln(w, "DATABASE\tVERSION\tLATEST")
+				for _, schema := range migrate.Schemas {
+					db, err := openDB(schema.ID, true)
+					if err != nil {
+						return fmt.Errorf("opening database %q: %w", schema.ID, err)
+					}
+					version, err := schema.Version(db)
+					db.Close()
+					if err != nil {
+						return err
+					}
+					fmt.Fprintf(w, "%s\t%d\t%d\n", schema.ID, version, schema.Latest())
+				}
+				return w.Flush()
+			}
+
+			for _, schema := range migrate.Schemas {
+				db, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: schema.ID, Config: config})
+				if err != nil {
+					return fmt.Errorf("opening database %q: %w", schema.ID, err)
+				}
+				from, err := schema.Migrate(cmd.Context(), db)
+				db.Close()
+				if err != nil {
+					return err
+				}
+				if from == schema.Latest() {
+					logger.Info("database is up to date", "db", schema.ID, "version", from)
+				} else {
+					logger.Info("migrated database", "db", schema.ID, "from", from, "to", schema.Latest())
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "only print the schema versions of the databases")
+	addDBFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	"github.com/tendermint/tendermint/types"
+)
+
+// MakeParamsCommand constructs the command to check the updates of the
+// consensus params.
+func MakeParamsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params",
+		Short: "Check the updates of the consensus params",
+	}
+
+	var (
+		node            string
+		height          int64
+		validators      int
+		blockTime       time.Duration
+		unbondingPeriod time.Duration
+		outputJSON      bool
+	)
+	simulateCmd := &cobra.Command{
+		Use:   "simulate [update-file]",
+		Short: "Validate an update of the consensus params, and report its conflicts",
+		Long: `Validate an update of the consensus params before it is proposed on chain,
+and report the changes and the conflicts of the updated params: with each
+other, with the network, e.g. blocks too large to be gossiped within
+timeout-propose at the p2p recv-rate of the config, and with the current
+params, e.g. key types of validators no longer allowed.
+
+The update is given in the JSON encoding of the consensus params of the genesis
+file, the sections omitted being left unchanged. The current params are those of
+the genesis file, or of a node with --node. The command fails if the updated
+params are invalid, the nodes halting on such an update, or conflict.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var update tmproto.ConsensusParams
+			if err := tmjson.Unmarshal(bz, &update); err != nil {
+				return fmt.Errorf("invalid update %s: %w", args[0], err)
+			}
+
+			params, numValidators, err := loadConsensusParams(cmd.Context(), node, height)
+			if err != nil {
+				return err
+			}
+			if validators > 0 {
+				numValidators = validators
+			}
+			sim, err := params.SimulateUpdate(&update, types.ParamsEnvironment{
+				Validators:        numValidators,
+				BlockTime:         blockTime,
+				UnbondingPeriod:   unbondingPeriod,
+				TimeoutPropose:    config.Consensus.TimeoutPropose,
+				RecvRate:          config.P2P.RecvRate,
+				MempoolMaxTxBytes: config.Mempool.MaxTxBytes,
+			})
+			if err != nil {
+				return err
+			}
+
+			if outputJSON {
+				bz, err := tmjson.MarshalIndent(sim, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			} else {
+				printParamsSimulation(cmd.OutOrStdout(), sim)
+			}
+			if len(sim.Conflicts) > 0 {
+				return fmt.Errorf("the update conflicts with %d params", len(sim.Conflicts))
+			}
+			return nil
+		},
+	}
+	simulateCmd.Flags().StringVar(&node, "node", "",
+		"RPC address of a node to load the current params from, instead of the genesis file")
+	simulateCmd.Flags().Int64Var(&height, "height", 0,
+		"height of the params to load from the node (latest if 0)")
+	simulateCmd.Flags().IntVar(&validators, "validators", 0,
+		"number of validators (those of the genesis file or the node if 0)")
+	simulateCmd.Flags().DurationVar(&blockTime, "block-time", 0,
+		"expected time between blocks, to check the evidence max age in blocks")
+	simulateCmd.Flags().DurationVar(&unbondingPeriod, "unbonding-period", 0,
+		"unbonding period of the application, to check the evidence max age")
+	simulateCmd.Flags().BoolVar(&outputJSON, "json", false, "print the result in JSON")
+
+	cmd.AddCommand(simulateCmd)
+	return cmd
+}
+
+// loadConsensusParams loads the consensus params, and the number of
+// validators, of the node at the height if set, or else of the genesis file.
+func loadConsensusParams(ctx context.Context, node string, height int64) (types.ConsensusParams, int, error) {
+	if node == "" {
+		genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+		if err != nil {
+			return types.ConsensusParams{}, 0, err
+		}
+		return *genDoc.ConsensusParams, len(genDoc.Validators), nil
+	}
+
+	client, err := rpchttp.New(node)
+	if err != nil {
+		return types.ConsensusParams{}, 0, err
+	}
+	var h *int64
+	if height > 0 {
+		h = &height
+	}
+	res, err := client.ConsensusParams(ctx, h)
+	if err != nil {
+		return types.ConsensusParams{}, 0, fmt.Errorf("loading the consensus params of %s: %w", node, err)
+	}
+	vals, err := client.Validators(ctx, &res.BlockHeight, nil, nil)
+	if err != nil {
+		return types.ConsensusParams{}, 0, fmt.Errorf("loading the validators of %s: %w", node, err)
+	}
+	return res.ConsensusParams, vals.Total, nil
+}
+
+// printParamsSimulation prints the changes and conflicts of the update.
+func printParamsSimulation(w io.Writer, sim *types.ParamsSimulation) {
+	fmt.Fprintf(w, "%d changes:\n", len(sim.Changes))
+	for _, c := range sim.Changes {
+		fmt.Fprintf(w, "  %s: %s -> %s\n", c.Param, c.Old, c.New)
+	}
+	fmt.Fprintf(w, "%d conflicts:\n", len(sim.Conflicts))
+	for _, c := range sim.Conflicts {
+		fmt.Fprintf(w, "  %s\n", c)
+	}
+}
@@ -26,8 +26,9 @@ const (
 
 // ReIndexEventCmd allows re-index the event by given block height interval
 var ReIndexEventCmd = &cobra.Command{
-	Use:   "reindex-event",
-	Short: "reindex events to the event store backends",
+	Use:     "reindex-event",
+	Aliases: []string{"reindex"},
+	Short:   "reindex events to the event store backends",
 	Long: `
 	reindex-event is an offline tooling to re-index block and tx events to the eventsinks,
 	you can run this command when the event store backend dropped/disconnected or you want to replace the backend.
@@ -40,6 +41,7 @@ var ReIndexEventCmd = &cobra.Command{
 	tendermint reindex-event --start-height 2
 	tendermint reindex-event --end-height 10
 	tendermint reindex-event --start-height 2 --end-height 10
+	tendermint reindex --start-height 2 --end-height 10
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
 		bs, ss, err := loadStateAndBlockStore(config)
@@ -89,6 +91,7 @@ func loadEventSinks(cfg *tmcfg.Config) ([]indexer.EventSink, error) {
 		sinks[sl] = true
 	}
 
+	filter := indexer.NewEventFilter(cfg.TxIndex.IndexEvents, cfg.TxIndex.ExcludeEvents)
 	eventSinks := []indexer.EventSink{}
 
 	for k := range sinks {
@@ -100,7 +103,7 @@ func loadEventSinks(cfg *tmcfg.Config) ([]indexer.EventSink, error) {
 			if err != nil {
 				return nil, err
 			}
-			eventSinks = append(eventSinks, kv.NewEventSink(store))
+			eventSinks = append(eventSinks, indexer.NewFilteredEventSink(kv.NewEventSink(store), filter))
 		case string(indexer.PSQL):
 			conn := cfg.TxIndex.PsqlConn
 			if conn == "" {
@@ -110,7 +113,7 @@ func loadEventSinks(cfg *tmcfg.Config) ([]indexer.EventSink, error) {
 			if err != nil {
 				return nil, err
 			}
-			eventSinks = append(eventSinks, es)
+			eventSinks = append(eventSinks, indexer.NewFilteredEventSink(es, filter))
 		default:
 			return nil, errors.New("unsupported event sink type")
 		}
@@ -132,14 +135,18 @@ func loadStateAndBlockStore(cfg *tmcfg.Config) (*store.BlockStore, state.Store,
 	dbType := dbm.BackendType(cfg.DBBackend)
 
 	// Get BlockStore
-	blockStoreDB, err := dbm.NewDB("blockstore", dbType, cfg.DBDir())
+	blockStoreDB, err := tmcfg.NewDB("blockstore", dbType, cfg.DBDir())
 	if err != nil {
 		return nil, nil, err
 	}
-	blockStore := store.NewBlockStore(blockStoreDB)
+	archive, err := store.ArchiveFromConfig(cfg.BaseConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	blockStore := store.NewBlockStore(blockStoreDB, store.BlockStoreWithArchive(archive))
 
 	// Get StateStore
-	stateDB, err := dbm.NewDB("state", dbType, cfg.DBDir())
+	stateDB, err := tmcfg.NewDB("state", dbType, cfg.DBDir())
 	if err != nil {
 		return nil, nil, err
 	}
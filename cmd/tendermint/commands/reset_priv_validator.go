@@ -8,7 +8,6 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/privval"
-	"github.com/tendermint/tendermint/types"
 )
 
 // ResetAllCmd removes the database of this Tendermint core
@@ -23,8 +22,8 @@ var keepAddrBook bool
 
 func init() {
 	ResetAllCmd.Flags().BoolVar(&keepAddrBook, "keep-addr-book", false, "keep the address book intact")
-	ResetPrivValidatorCmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeEd25519,
-		"Key type to generate privval file with. Options: ed25519, secp256k1")
+	ResetPrivValidatorCmd.Flags().StringVar(&keyType, "key", "",
+		"Key type to generate privval file with, instead of the priv-validator key-type of the config. Options: ed25519, secp256k1, bls")
 }
 
 // ResetPrivValidatorCmd resets the private validator files.
@@ -77,7 +76,7 @@ func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) err
 		logger.Info("Reset private validator file to genesis state", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
 	} else {
-		pv, err := privval.GenFilePV(privValKeyFile, privValStateFile, keyType)
+		pv, err := privval.GenFilePV(privValKeyFile, privValStateFile, privValidatorKeyType(config))
 		if err != nil {
 			return err
 		}
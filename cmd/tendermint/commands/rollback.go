@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/proxy"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/store"
+)
+
+// MakeRollbackCommand returns the command rolling the state and the blocks of
+// a stopped node back by a number of heights.
+func MakeRollbackCommand() *cobra.Command {
+	var (
+		heights           int64
+		height            int64
+		withApp           bool
+		rebuildBlockIndex bool
+	)
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Roll the state and the blocks of a stopped node back by a number of heights",
+		Long: `
+	rollback rolls the state of a stopped node back by --heights heights, or to
+	--height, e.g. to recover from a non-deterministic application which computed
+	a wrong app hash. The blocks after the target height are removed, except the
+	next one, which the node executes again on restart.
+
+	The application must be rolled back to the same height: with --app, the
+	Rollback method of the application at proxy-app is called, and the height and
+	the app hash it rolled back to are checked against the state. Otherwise, the
+	application must be rolled back by the operator.
+
+	With --rebuild-block-index, the block hash index is rebuilt, and the data left
+	outside of the blocks of the block store is removed, as by inspect-db repair.
+
+	A rollback which was interrupted can be resumed with --height.
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blockStore, stateStore, closeDBs, err := openStores(false)
+			if err != nil {
+				return err
+			}
+			defer closeDBs()
+
+			state, err := stateStore.Load()
+			if err != nil {
+				return err
+			}
+			if state.IsEmpty() {
+				return errors.New("no state found, the node has no block to roll back")
+			}
+			target := height
+			if target == 0 {
+				if heights <= 0 {
+					return fmt.Errorf("the number of heights to roll back must be positive, got %d", heights)
+				}
+				target = state.LastBlockHeight - heights
+			}
+
+			w := cmd.OutOrStdout()
+			if target < state.LastBlockHeight {
+				state, err = sm.Rollback(blockStore, stateStore, target)
+				if err != nil {
+					return fmt.Errorf("rolling back the state: %w", err)
+				}
+			} else if target > state.LastBlockHeight {
+				return fmt.Errorf("can't roll back to height %d, the last height is %d", target, state.LastBlockHeight)
+			}
+			fmt.Fprintf(w, "rolled back the state to height %d and app hash %X\n", state.LastBlockHeight, state.AppHash)
+
+			removed, err := blockStore.RollbackBlocks(target + 1)
+			if err != nil {
+				return fmt.Errorf("removing the blocks after height %d: %w", target+1, err)
+			}
+			fmt.Fprintf(w, "removed %d blocks, the block store height is %d\n", removed, blockStore.Height())
+
+			if rebuildBlockIndex {
+				if err := repairBlockStore(w, blockStore); err != nil {
+					return err
+				}
+			}
+
+			if !withApp {
+				fmt.Fprintf(w, "the application must be rolled back to height %d before restarting the node\n", target)
+				return nil
+			}
+			return rollbackApp(cmd.Context(), w, state)
+		},
+	}
+	cmd.Flags().Int64Var(&heights, "heights", 1, "number of heights to roll back")
+	cmd.Flags().Int64Var(&height, "height", 0, "height to roll back to, in place of --heights")
+	cmd.Flags().BoolVar(&withApp, "app", false,
+		"call the Rollback method of the application at proxy-app, and check its new height and app hash")
+	cmd.Flags().BoolVar(&rebuildBlockIndex, "rebuild-block-index", false,
+		"rebuild the block hash index and remove the orphaned data of the block store")
+	addDBFlags(cmd)
+	return cmd
+}
+
+// repairBlockStore repairs the inconsistencies of the block store left by the
+// rollback, e.g. the hash index of the removed blocks.
+func repairBlockStore(w io.Writer, blockStore *store.BlockStore) error {
+	repaired, err := blockStore.Repair()
+	if err != nil {
+		return fmt.Errorf("rebuilding the block index: %w", err)
+	}
+	for _, inconsistency := range repaired {
+		fmt.Fprintf(w, "repaired: %v\n", inconsistency)
+	}
+	fmt.Fprintf(w, "rebuilt the block index, repaired %d inconsistencies\n", len(repaired))
+	return nil
+}
+
+// rollbackApp asks the application to roll back to the height of the state,
+// and checks that it did.
+func rollbackApp(ctx context.Context, w io.Writer, state sm.State) error {
+	clientCreator, closer := proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir(),
+		proxy.GRPCClientOptions(config.BaseConfig)...)
+	defer closer.Close()
+	client, err := clientCreator()
+	if err != nil {
+		return fmt.Errorf("connecting to the application: %w", err)
+	}
+	if err := client.Start(); err != nil {
+		return fmt.Errorf("connecting to the application: %w", err)
+	}
+	defer client.Stop() //nolint:errcheck // ignore for the command
+
+	res, err := client.RollbackSync(ctx, abci.RequestRollback{Height: state.LastBlockHeight})
+	if err != nil {
+		return fmt.Errorf("rolling back the application: %w", err)
+	}
+	switch {
+	case res.LastBlockHeight == 0 && len(res.LastBlockAppHash) == 0:
+		return fmt.Errorf("the application doesn't support Rollback, it must be rolled back to height %d "+
+			"before restarting the node", state.LastBlockHeight)
+	case res.LastBlockHeight != state.LastBlockHeight:
+		return fmt.Errorf("the application rolled back to height %d instead of %d",
+			res.LastBlockHeight, state.LastBlockHeight)
+	case !bytes.Equal(res.LastBlockAppHash, state.AppHash):
+		return fmt.Errorf("the application rolled back to app hash %X instead of %X",
+			res.LastBlockAppHash, state.AppHash)
+	}
+	fmt.Fprintf(w, "rolled back the application to height %d and app hash %X\n",
+		res.LastBlockHeight, res.LastBlockAppHash)
+	return nil
+}
@@ -41,6 +41,15 @@ func ParseConfig() (*cfg.Config, error) {
 	return conf, nil
 }
 
+// ReloadConfig reads the config file again, with the flags and the environment
+// variables bound at startup, and parses the config as ParseConfig does.
+func ReloadConfig() (*cfg.Config, error) {
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return ParseConfig()
+}
+
 // RootCmd is the root command for Tendermint core.
 var RootCmd = &cobra.Command{
 	Use:   "tendermint",
@@ -66,7 +66,7 @@ func AddNodeFlags(cmd *cobra.Command) {
 		config.ProxyApp,
 		"proxy app address, or one of: 'kvstore',"+
 			" 'persistent_kvstore', 'e2e' or 'noop' for local testing.")
-	cmd.Flags().String("abci", config.ABCI, "specify abci transport (socket | grpc)")
+	cmd.Flags().String("abci", config.ABCI, "specify abci transport (socket | socket-mux | grpc)")
 
 	// rpc flags
 	cmd.Flags().String("rpc.laddr", config.RPC.ListenAddress, "RPC listen address. Port required")
@@ -107,7 +107,7 @@ func addDBFlags(cmd *cobra.Command) {
 	cmd.Flags().String(
 		"db-backend",
 		config.DBBackend,
-		"database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb")
+		"database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb | pebble")
 	cmd.Flags().String(
 		"db-dir",
 		config.DBPath,
@@ -131,6 +131,13 @@ func NewRunNodeCmd(nodeProvider cfg.ServiceProvider) *cobra.Command {
 				return fmt.Errorf("failed to create node: %w", err)
 			}
 
+			// Reload the config as it was loaded at startup.
+			if l, ok := n.(interface {
+				SetConfigLoader(func() (*cfg.Config, error))
+			}); ok {
+				l.SetConfigLoader(ReloadConfig)
+			}
+
 			if err := n.Start(); err != nil {
 				return fmt.Errorf("failed to start node: %w", err)
 			}
@@ -146,6 +153,24 @@ func NewRunNodeCmd(nodeProvider cfg.ServiceProvider) *cobra.Command {
 				}
 			})
 
+			// Reload the config file upon receiving SIGHUP.
+			if r, ok := n.(interface{ ReloadConfig() error }); ok {
+				tmos.TrapReloadSignal(logger, func() {
+					if err := r.ReloadConfig(); err != nil {
+						logger.Error("unable to reload the config", "error", err)
+					}
+				})
+			}
+
+			// Save a debug bundle upon receiving SIGUSR1.
+			if d, ok := n.(interface{ SaveDebugBundle() (string, error) }); ok {
+				tmos.TrapDebugSignal(logger, func() {
+					if _, err := d.SaveDebugBundle(); err != nil {
+						logger.Error("unable to save a debug bundle", "error", err)
+					}
+				})
+			}
+
 			// Run forever.
 			select {}
 		},
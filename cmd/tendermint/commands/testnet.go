@@ -74,8 +74,8 @@ func init() {
 		"P2P Port")
 	TestnetFilesCmd.Flags().BoolVar(&randomMonikers, "random-monikers", false,
 		"randomize the moniker for each generated node")
-	TestnetFilesCmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeEd25519,
-		"Key type to generate privval file with. Options: ed25519, secp256k1")
+	TestnetFilesCmd.Flags().StringVar(&keyType, "key", "",
+		"Key type to generate privval file with, instead of the priv-validator key-type of the config. Options: ed25519, secp256k1, bls")
 }
 
 // TestnetFilesCmd allows initialisation of files for a Tendermint testnet.
@@ -194,9 +194,9 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 		Validators:      genVals,
 		ConsensusParams: types.DefaultConsensusParams(),
 	}
-	if keyType == "secp256k1" {
+	if keyType := privValidatorKeyType(config); keyType != types.ABCIPubKeyTypeEd25519 {
 		genDoc.ConsensusParams.Validator = types.ValidatorParams{
-			PubKeyTypes: []string{types.ABCIPubKeyTypeSecp256k1},
+			PubKeyTypes: []string{keyType},
 		}
 	}
 
@@ -7,14 +7,20 @@ import (
 	cmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 	"github.com/tendermint/tendermint/cmd/tendermint/commands/debug"
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/crypto/tmhash/simd"
 	"github.com/tendermint/tendermint/libs/cli"
 	"github.com/tendermint/tendermint/node"
 )
 
 func main() {
+	// hash the blocks with the SHA extensions or AVX2 instructions of the CPU
+	tmhash.SetHasher(simd.Hasher{})
+
 	rootCmd := cmd.RootCmd
 	rootCmd.AddCommand(
 		cmd.GenValidatorCmd,
+		cmd.GenThresholdValidatorCmd,
 		cmd.ReIndexEventCmd,
 		cmd.InitFilesCmd,
 		cmd.ProbeUpnpCmd,
@@ -29,7 +35,15 @@ func main() {
 		cmd.GenNodeKeyCmd,
 		cmd.VersionCmd,
 		cmd.InspectCmd,
+		cmd.MakeGenesisCommand(),
+		cmd.MakeKeyCommand(),
 		cmd.MakeKeyMigrateCommand(),
+		cmd.MakeInspectDBCommand(),
+		cmd.MakeRollbackCommand(),
+		cmd.MakeExportCommand(),
+		cmd.MakeImportCommand(),
+		cmd.MakeMigrateDBCommand(),
+		cmd.MakeParamsCommand(),
 		debug.DebugCmd,
 		cli.NewCompletionCmd(rootCmd, true),
 	)
@@ -6,13 +6,20 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	tmos "github.com/tendermint/tendermint/libs/os"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -34,6 +41,9 @@ const (
 
 	MempoolV0 = "v0"
 	MempoolV1 = "v1"
+
+	ABCIResponseValidationStrict  = "strict"
+	ABCIResponseValidationLenient = "lenient"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -130,9 +140,84 @@ func (cfg *Config) SetRoot(root string) *Config {
 	cfg.Mempool.RootDir = root
 	cfg.Consensus.RootDir = root
 	cfg.PrivValidator.RootDir = root
+	cfg.BlockSync.RootDir = root
+	cfg.StateSync.RootDir = root
 	return cfg
 }
 
+// Diff returns the keys of the settings which differ between cfg and other,
+// e.g. "log-level" or "p2p.laddr". The home directories are not compared.
+func (cfg *Config) Diff(other *Config) []string {
+	return diffSection("", reflect.ValueOf(*cfg), reflect.ValueOf(*other))
+}
+
+func diffSection(prefix string, a, b reflect.Value) []string {
+	var keys []string
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		tag := strings.Split(field.Tag.Get("mapstructure"), ",")
+		fa, fb := a.Field(i), b.Field(i)
+		switch {
+		case len(tag) > 1 && tag[1] == "squash":
+			keys = append(keys, diffSection(prefix, fa, fb)...)
+		case tag[0] == "" || tag[0] == "home":
+		case fa.Kind() == reflect.Ptr && fa.Elem().Kind() == reflect.Struct:
+			if fa.IsNil() || fb.IsNil() {
+				if fa.IsNil() != fb.IsNil() {
+					keys = append(keys, prefix+tag[0])
+				}
+				continue
+			}
+			keys = append(keys, diffSection(prefix+tag[0]+".", fa.Elem(), fb.Elem())...)
+		case !reflect.DeepEqual(fa.Interface(), fb.Interface()):
+			keys = append(keys, prefix+tag[0])
+		}
+	}
+	return keys
+}
+
+// Redacted returns a copy of the config without the passwords of psql-conn
+// and snapshot-urls, and the values of the OpenTelemetry headers, e.g. to
+// share it.
+func (cfg *Config) Redacted() *Config {
+	redacted := *cfg
+	if cfg.TxIndex != nil {
+		txIndex := *cfg.TxIndex
+		txIndex.PsqlConn = redactPasswords(txIndex.PsqlConn)
+		redacted.TxIndex = &txIndex
+	}
+	if cfg.StateSync != nil {
+		stateSync := *cfg.StateSync
+		stateSync.SnapshotURLs = make([]string, len(cfg.StateSync.SnapshotURLs))
+		for i, u := range cfg.StateSync.SnapshotURLs {
+			stateSync.SnapshotURLs[i] = redactPasswords(u)
+		}
+		redacted.StateSync = &stateSync
+	}
+	if cfg.Instrumentation != nil && cfg.Instrumentation.OTel != nil {
+		instrumentation, otel := *cfg.Instrumentation, *cfg.Instrumentation.OTel
+		otel.Headers = make(map[string]string, len(cfg.Instrumentation.OTel.Headers))
+		for key := range cfg.Instrumentation.OTel.Headers {
+			otel.Headers[key] = "xxxxx"
+		}
+		instrumentation.OTel = &otel
+		redacted.Instrumentation = &instrumentation
+	}
+	return &redacted
+}
+
+var (
+	urlPassword   = regexp.MustCompile(`(://[^/@:]*:)[^/@]*@`)
+	paramPassword = regexp.MustCompile(`(?i)(password=)[^&\s]*`)
+)
+
+// redactPasswords hides the passwords of a URL, or of a key=value connection
+// string.
+func redactPasswords(s string) string {
+	s = urlPassword.ReplaceAllString(s, "${1}xxxxx@")
+	return paramPassword.ReplaceAllString(s, "${1}xxxxx")
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *Config) ValidateBasic() error {
@@ -148,6 +233,9 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Mempool.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [mempool] section: %w", err)
 	}
+	if err := cfg.PrivValidator.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [priv-validator] section: %w", err)
+	}
 	if err := cfg.StateSync.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [statesync] section: %w", err)
 	}
@@ -157,6 +245,9 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [consensus] section: %w", err)
 	}
+	if err := cfg.TxIndex.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [tx-index] section: %w", err)
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
@@ -194,7 +285,7 @@ type BaseConfig struct { //nolint: maligned
 	//   - No priv_validator_key.json, priv_validator_state.json
 	Mode string `mapstructure:"mode"`
 
-	// Database backend: goleveldb | cleveldb | boltdb | rocksdb
+	// Database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb | pebble
 	// * goleveldb (github.com/syndtr/goleveldb - most popular implementation)
 	//   - pure go
 	//   - stable
@@ -213,6 +304,10 @@ type BaseConfig struct { //nolint: maligned
 	// * badgerdb (uses github.com/dgraph-io/badger)
 	//   - EXPERIMENTAL
 	//   - use badgerdb build tag (go build -tags badgerdb)
+	// * pebble (uses github.com/cockroachdb/pebble)
+	//   - EXPERIMENTAL
+	//   - pure go
+	//   - doesn't stall the writes while compacting, unlike goleveldb
 	DBBackend string `mapstructure:"db-backend"`
 
 	// Database directory
@@ -227,16 +322,74 @@ type BaseConfig struct { //nolint: maligned
 	// Path to the JSON file containing the initial validator set and other meta data
 	Genesis string `mapstructure:"genesis-file"`
 
+	// Expected canonical hash of the genesis doc, in hex. The node refuses to
+	// start with another genesis. See "tendermint genesis hash".
+	ExpectedGenesisHash string `mapstructure:"expected-genesis-hash"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node-key-file"`
 
-	// Mechanism to connect to the ABCI application: socket | grpc
+	// Mechanism to connect to the ABCI application: socket | socket-mux | grpc
 	ABCI string `mapstructure:"abci"`
 
+	// Deadline of each attempt of a call to the ABCI application over gRPC.
+	// It must be longer than the slowest call of the application, like a
+	// Commit. 0 disables the deadline.
+	ABCIGRPCCallTimeout time.Duration `mapstructure:"abci-grpc-call-timeout"`
+
+	// The number of times a call to the ABCI application over gRPC is retried
+	// when it fails with a transient error. Only the calls which don't change
+	// the state of the application, like Info and Query, are retried.
+	ABCIGRPCMaxRetries uint `mapstructure:"abci-grpc-max-retries"`
+
+	// The interval of the keepalive pings of the gRPC connection to the ABCI
+	// application when it is idle, and how long to wait for their reply before
+	// closing the connection.
+	ABCIGRPCKeepaliveTime    time.Duration `mapstructure:"abci-grpc-keepalive-time"`
+	ABCIGRPCKeepaliveTimeout time.Duration `mapstructure:"abci-grpc-keepalive-timeout"`
+
+	// How to deal with the responses of the ABCI application which violate the
+	// protocol, like a negative gas or an invalid validator update: strict |
+	// lenient. Strict rejects the response, which halts the node, and lenient
+	// logs it and sanitizes the response. All the validators should use the
+	// same mode, as a sanitized response may change the state.
+	ABCIResponseValidation string `mapstructure:"abci-response-validation"`
+
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter-peers"` // false
 
+	// The number of most recent blocks whose results (the ABCI responses) are
+	// kept in the state store, regardless of the blocks the application asks
+	// to retain. 0 keeps the results of the blocks retained by the application.
+	RetainResultsBlocks int64 `mapstructure:"retain-results-blocks"`
+
+	// Save the state, prune the stores and publish the events of each block
+	// in the background, while the consensus moves to the next height. The
+	// events of a block may then be published after the first consensus
+	// events of the next height.
+	PipelineCommit bool `mapstructure:"pipeline-commit"`
+
+	// The file the report of an app hash mismatch is written to, when the
+	// application computes another app hash, or last results hash, than the
+	// one of a block committed by the network.
+	MismatchReport string `mapstructure:"mismatch-report-file"`
+
+	// The number of most recent blocks kept in the block store database when
+	// the older ones are moved to the block archive, to shrink the database.
+	// The archived blocks are still served, from the archive. 0 disables the
+	// archiving.
+	ArchiveRetainBlocks int64 `mapstructure:"archive-retain-blocks"`
+
+	// Directory of the block archive
+	ArchivePath string `mapstructure:"archive-dir"`
+
+	// URL of the S3 bucket of the block archive, instead of archive-dir
+	ArchiveS3URL string `mapstructure:"archive-s3-url"`
+
+	// Region of the S3 bucket of the block archive
+	ArchiveS3Region string `mapstructure:"archive-s3-region"`
+
 	Other map[string]interface{} `mapstructure:",remain"`
 }
 
@@ -254,6 +407,16 @@ func DefaultBaseConfig() BaseConfig {
 		FilterPeers: false,
 		DBBackend:   "goleveldb",
 		DBPath:      "data",
+		ArchivePath: filepath.Join(defaultDataDir, "archive"),
+
+		MismatchReport: filepath.Join(defaultDataDir, "mismatch_report.json"),
+
+		ArchiveS3Region: "us-east-1",
+
+		ABCIGRPCMaxRetries:       3,
+		ABCIGRPCKeepaliveTime:    10 * time.Second,
+		ABCIGRPCKeepaliveTimeout: 20 * time.Second,
+		ABCIResponseValidation:   ABCIResponseValidationStrict,
 	}
 }
 
@@ -276,6 +439,11 @@ func (cfg BaseConfig) GenesisFile() string {
 	return rootify(cfg.Genesis, cfg.RootDir)
 }
 
+// ConfigFile returns the full path to the config.toml file
+func (cfg BaseConfig) ConfigFile() string {
+	return rootify(defaultConfigFilePath, cfg.RootDir)
+}
+
 // NodeKeyFile returns the full path to the node_key.json file
 func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
@@ -321,6 +489,16 @@ func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
 }
 
+// MismatchReportFile returns the full path to the app hash mismatch report
+func (cfg BaseConfig) MismatchReportFile() string {
+	return rootify(cfg.MismatchReport, cfg.RootDir)
+}
+
+// ArchiveDir returns the full path to the block archive directory
+func (cfg BaseConfig) ArchiveDir() string {
+	return rootify(cfg.ArchivePath, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg BaseConfig) ValidateBasic() error {
@@ -330,6 +508,41 @@ func (cfg BaseConfig) ValidateBasic() error {
 		return errors.New("unknown log format (must be 'plain', 'text' or 'json')")
 	}
 
+	if cfg.ExpectedGenesisHash != "" {
+		if hash, err := hex.DecodeString(cfg.ExpectedGenesisHash); err != nil || len(hash) != tmhash.Size {
+			return fmt.Errorf("expected-genesis-hash must be a %d bytes hex hash", tmhash.Size)
+		}
+	}
+	if cfg.RetainResultsBlocks < 0 {
+		return errors.New("retain-results-blocks can't be negative")
+	}
+	if cfg.ArchiveRetainBlocks < 0 {
+		return errors.New("archive-retain-blocks can't be negative")
+	}
+	if cfg.ArchiveS3URL != "" {
+		if u, err := url.Parse(cfg.ArchiveS3URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return errors.New("archive-s3-url must be an http or https URL")
+		}
+		if cfg.ArchiveS3Region == "" {
+			return errors.New("archive-s3-region can't be empty with archive-s3-url")
+		}
+	}
+	if cfg.ABCIGRPCCallTimeout < 0 {
+		return errors.New("abci-grpc-call-timeout can't be negative")
+	}
+	if cfg.ABCIGRPCKeepaliveTime < 0 {
+		return errors.New("abci-grpc-keepalive-time can't be negative")
+	}
+	if cfg.ABCIGRPCKeepaliveTimeout < 0 {
+		return errors.New("abci-grpc-keepalive-timeout can't be negative")
+	}
+	switch cfg.ABCIResponseValidation {
+	case ABCIResponseValidationStrict, ABCIResponseValidationLenient:
+	default:
+		return fmt.Errorf("unknown abci-response-validation: %v (must be %q or %q)",
+			cfg.ABCIResponseValidation, ABCIResponseValidationStrict, ABCIResponseValidationLenient)
+	}
+
 	switch cfg.Mode {
 	case ModeFull, ModeValidator, ModeSeed:
 	case "":
@@ -377,10 +590,19 @@ type PrivValidatorConfig struct {
 	// Path to the JSON file containing the last sign state of a validator
 	State string `mapstructure:"state-file"`
 
+	// Type of the key generated for the validator, by init and the other
+	// commands creating the key file: ed25519, secp256k1 or bls
+	KeyType string `mapstructure:"key-type"`
+
 	// TCP or UNIX socket address for Tendermint to listen on for
 	// connections from an external PrivValidator process
 	ListenAddr string `mapstructure:"laddr"`
 
+	// TCP or UNIX socket addresses for Tendermint to listen on for
+	// connections from backup external PrivValidator processes, in order of
+	// priority. Signing fails over to them when the ones before don't respond.
+	FailoverListenAddrs []string `mapstructure:"failover-laddrs"`
+
 	// Client certificate generated while creating needed files for secure connection.
 	// If a remote validator address is provided but no certificate, the connection will be insecure
 	ClientCertificate string `mapstructure:"client-certificate-file"`
@@ -390,17 +612,68 @@ type PrivValidatorConfig struct {
 
 	// Path Root Certificate Authority used to sign both client and server certificates
 	RootCA string `mapstructure:"root-ca-file"`
+
+	// TCP or UNIX socket addresses for Tendermint to listen on for
+	// connections from the external PrivValidator processes holding the
+	// shares of a threshold key, or grpc:// addresses of the gRPC servers of
+	// such processes to dial. Takes precedence over ListenAddr.
+	ThresholdListenAddrs []string `mapstructure:"threshold-laddrs"`
+
+	// Number of the external PrivValidator processes needed to sign
+	Threshold int `mapstructure:"threshold"`
+
+	// Time to wait for each round of a threshold signing session, after which
+	// the processes which haven't responded are left out of the next attempt
+	ThresholdTimeout time.Duration `mapstructure:"threshold-timeout"`
+
+	// Number of threshold signing sessions attempted before signing fails
+	ThresholdRetries int `mapstructure:"threshold-retries"`
 }
 
 // DefaultBaseConfig returns a default private validator configuration
 // for a Tendermint node.
 func DefaultPrivValidatorConfig() *PrivValidatorConfig {
 	return &PrivValidatorConfig{
-		Key:   defaultPrivValKeyPath,
-		State: defaultPrivValStatePath,
+		Key:              defaultPrivValKeyPath,
+		State:            defaultPrivValStatePath,
+		KeyType:          "ed25519",
+		ThresholdTimeout: 1 * time.Second,
+		ThresholdRetries: 3,
 	}
 }
 
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *PrivValidatorConfig) ValidateBasic() error {
+	switch cfg.KeyType {
+	case "", "ed25519", "secp256k1", "bls":
+	default:
+		return fmt.Errorf("unsupported key-type %q", cfg.KeyType)
+	}
+	if len(cfg.FailoverListenAddrs) > 0 {
+		if cfg.ListenAddr == "" {
+			return errors.New("failover-laddrs requires laddr")
+		}
+		if strings.HasPrefix(cfg.ListenAddr, "grpc://") {
+			return errors.New("failover-laddrs isn't supported with a grpc laddr")
+		}
+	}
+	if len(cfg.ThresholdListenAddrs) == 0 {
+		return nil
+	}
+	if cfg.Threshold < 1 || cfg.Threshold > len(cfg.ThresholdListenAddrs) {
+		return fmt.Errorf("threshold must be between 1 and the number of threshold-laddrs (%d)",
+			len(cfg.ThresholdListenAddrs))
+	}
+	if cfg.ThresholdTimeout <= 0 {
+		return errors.New("threshold-timeout must be positive")
+	}
+	if cfg.ThresholdRetries < 1 {
+		return errors.New("threshold-retries must be at least 1")
+	}
+	return nil
+}
+
 // ClientKeyFile returns the full path to the priv_validator_key.json file
 func (cfg *PrivValidatorConfig) ClientKeyFile() string {
 	return rootify(cfg.ClientKey, cfg.RootDir)
@@ -449,6 +722,11 @@ type RPCConfig struct {
 	// TCP or UNIX socket address for the RPC server to listen on
 	ListenAddress string `mapstructure:"laddr"`
 
+	// Permissions of the UNIX sockets of laddr and grpc-laddr, in octal. The
+	// clients must be allowed to write to a socket to connect to it. Empty
+	// leaves the permissions to the umask.
+	UnixSocketMode string `mapstructure:"unix-socket-mode"`
+
 	// A list of origins a cross-domain request can be executed from.
 	// If the special '*' value is present in the list, all origins will be allowed.
 	// An origin may contain a wildcard (*) to replace 0 or more characters (i.e.: http://*.domain.com).
@@ -496,6 +774,19 @@ type RPCConfig struct {
 	// to the estimated maximum number of broadcast_tx_commit calls per block.
 	MaxSubscriptionsPerClient int `mapstructure:"max-subscriptions-per-client"`
 
+	// Number of events kept to resume the /subscribe subscriptions of the
+	// clients which reconnect, see the last_event_id parameter. 0 disables
+	// resumption.
+	EventHistorySize int `mapstructure:"event-history-size"`
+
+	// Number of events buffered by a /subscribe subscription for its client.
+	SubscriptionBufferSize int `mapstructure:"subscription-buffer-size"`
+
+	// What happens to the events of a /subscribe subscription once its buffer
+	// is full: "terminate" cancels the subscription, "drop-oldest" and
+	// "drop-newest" drop the oldest or newest events.
+	SubscriptionOverflow string `mapstructure:"subscription-overflow"`
+
 	// How long to wait for a tx to be committed during /broadcast_tx_commit
 	// WARNING: Using a value larger than 10s will result in increasing the
 	// global HTTP write timeout, which applies to all connections and endpoints.
@@ -508,6 +799,44 @@ type RPCConfig struct {
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max-header-bytes"`
 
+	// Maximum rate of the requests of all clients, in requests per second.
+	// 0 disables the limit.
+	RateLimit float64 `mapstructure:"rate-limit"`
+
+	// Number of requests of all clients which can be served at once, above
+	// the rate limit.
+	RateLimitBurst int `mapstructure:"rate-limit-burst"`
+
+	// Maximum rate of the requests of each client IP, in requests per second.
+	// 0 disables the limit.
+	RateLimitPerIP float64 `mapstructure:"rate-limit-per-ip"`
+
+	// Number of requests of each client IP which can be served at once, above
+	// the rate limit.
+	RateLimitPerIPBurst int `mapstructure:"rate-limit-per-ip-burst"`
+
+	// The number of requests a request to each endpoint counts as against the
+	// rate limits. Endpoints not listed count as one request.
+	RateLimitWeights map[string]float64 `mapstructure:"rate-limit-weights"`
+
+	// Maximum size, in bytes, of the cache of responses which cannot change
+	// anymore: /block, /block_results, /commit and /validators at committed
+	// heights. 0 disables the cache.
+	ResponseCacheBytes int64 `mapstructure:"response-cache-bytes"`
+
+	// Maximum time since the last block before /health reports the node as
+	// unhealthy, once it caught up. 0 disables the check.
+	HealthMaxBlockInterval time.Duration `mapstructure:"health-max-block-interval"`
+
+	// Number of peers below which /health reports the node as degraded.
+	HealthTargetPeers int `mapstructure:"health-target-peers"`
+
+	// Number of connections to the application over which the queries of
+	// /abci_query at past heights are served, for an application able to
+	// serve them concurrently. 0 serves them over the query connection, along
+	// with the queries of the latest state.
+	HistoricalQueryConnections int `mapstructure:"historical-query-connections"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Might be either absolute path or path related to Tendermint's config directory.
 	//
@@ -526,6 +855,22 @@ type RPCConfig struct {
 	// Otherwise, HTTP server is run.
 	TLSKeyFile string `mapstructure:"tls-key-file"`
 
+	// The path to a file containing the certificates of the certificate
+	// authorities which sign the certificates of the clients, PEM encoded.
+	// Might be either absolute path or path related to Tendermint's config
+	// directory.
+	//
+	// If set along with tls-cert-file and tls-key-file, the clients must
+	// authenticate with a certificate signed by one of them (mutual TLS).
+	TLSClientCAFile string `mapstructure:"tls-client-ca-file"`
+
+	// A list of "<common name>=<method>,<method>..." entries, restricting the
+	// clients which authenticate with a certificate of the given common name
+	// to the given methods. The "*" common name restricts the clients whose
+	// common name is not listed. The clients not restricted can call all the
+	// methods. Requires tls-client-ca-file.
+	TLSClientAllowedMethods []string `mapstructure:"tls-client-allowed-methods"`
+
 	// pprof listen address (https://golang.org/pkg/net/http/pprof)
 	PprofListenAddress string `mapstructure:"pprof-laddr"`
 }
@@ -534,6 +879,7 @@ type RPCConfig struct {
 func DefaultRPCConfig() *RPCConfig {
 	return &RPCConfig{
 		ListenAddress:          "tcp://127.0.0.1:26657",
+		UnixSocketMode:         "0660",
 		CORSAllowedOrigins:     []string{},
 		CORSAllowedMethods:     []string{http.MethodHead, http.MethodGet, http.MethodPost},
 		CORSAllowedHeaders:     []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time"},
@@ -545,13 +891,30 @@ func DefaultRPCConfig() *RPCConfig {
 
 		MaxSubscriptionClients:    100,
 		MaxSubscriptionsPerClient: 5,
+		EventHistorySize:          1000,
+		SubscriptionBufferSize:    100,
+		SubscriptionOverflow:      tmpubsub.OverflowTerminate.String(),
 		TimeoutBroadcastTxCommit:  10 * time.Second,
 
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		RateLimitBurst:      100,
+		RateLimitPerIPBurst: 20,
+		RateLimitWeights: map[string]float64{
+			"tx_search":           10,
+			"block_search":        10,
+			"broadcast_tx_commit": 5,
+		},
+
+		HealthMaxBlockInterval: time.Minute,
+		HealthTargetPeers:      1,
+
 		TLSCertFile: "",
 		TLSKeyFile:  "",
+
+		TLSClientCAFile:         "",
+		TLSClientAllowedMethods: []string{},
 	}
 }
 
@@ -567,6 +930,9 @@ func TestRPCConfig() *RPCConfig {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *RPCConfig) ValidateBasic() error {
+	if _, err := cfg.UnixSocketFileMode(); err != nil {
+		return fmt.Errorf("unix-socket-mode: %w", err)
+	}
 	if cfg.GRPCMaxOpenConnections < 0 {
 		return errors.New("grpc-max-open-connections can't be negative")
 	}
@@ -579,6 +945,15 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxSubscriptionsPerClient < 0 {
 		return errors.New("max-subscriptions-per-client can't be negative")
 	}
+	if cfg.EventHistorySize < 0 {
+		return errors.New("event-history-size can't be negative")
+	}
+	if cfg.SubscriptionBufferSize <= 0 {
+		return errors.New("subscription-buffer-size must be positive")
+	}
+	if _, err := tmpubsub.ParseOverflow(cfg.SubscriptionOverflow); err != nil {
+		return fmt.Errorf("subscription-overflow: %w", err)
+	}
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return errors.New("timeout-broadcast-tx-commit can't be negative")
 	}
@@ -588,9 +963,63 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max-header-bytes can't be negative")
 	}
+	if cfg.RateLimit < 0 {
+		return errors.New("rate-limit can't be negative")
+	}
+	if cfg.RateLimitBurst < 0 {
+		return errors.New("rate-limit-burst can't be negative")
+	}
+	if cfg.RateLimitPerIP < 0 {
+		return errors.New("rate-limit-per-ip can't be negative")
+	}
+	if cfg.RateLimitPerIPBurst < 0 {
+		return errors.New("rate-limit-per-ip-burst can't be negative")
+	}
+	for endpoint, weight := range cfg.RateLimitWeights {
+		if weight < 0 {
+			return fmt.Errorf("rate-limit-weights: weight of %s can't be negative", endpoint)
+		}
+	}
+	if cfg.ResponseCacheBytes < 0 {
+		return errors.New("response-cache-bytes can't be negative")
+	}
+	if cfg.HealthMaxBlockInterval < 0 {
+		return errors.New("health-max-block-interval can't be negative")
+	}
+	if cfg.HistoricalQueryConnections < 0 {
+		return errors.New("historical-query-connections can't be negative")
+	}
+	if cfg.HealthTargetPeers < 0 {
+		return errors.New("health-target-peers can't be negative")
+	}
+	if cfg.TLSClientCAFile != "" && !cfg.IsTLSEnabled() {
+		return errors.New("tls-client-ca-file requires tls-cert-file and tls-key-file")
+	}
+	if len(cfg.TLSClientAllowedMethods) > 0 && cfg.TLSClientCAFile == "" {
+		return errors.New("tls-client-allowed-methods requires tls-client-ca-file")
+	}
+	if _, err := cfg.ClientAllowedMethods(); err != nil {
+		return fmt.Errorf("tls-client-allowed-methods: %w", err)
+	}
 	return nil
 }
 
+// UnixSocketFileMode parses UnixSocketMode. It returns 0, leaving the
+// permissions to the umask, if it is empty.
+func (cfg *RPCConfig) UnixSocketFileMode() (os.FileMode, error) {
+	if cfg.UnixSocketMode == "" {
+		return 0, nil
+	}
+	mode, err := strconv.ParseUint(cfg.UnixSocketMode, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an octal mode", cfg.UnixSocketMode)
+	}
+	if mode > 0777 {
+		return 0, fmt.Errorf("%q is not a permission mode", cfg.UnixSocketMode)
+	}
+	return os.FileMode(mode), nil
+}
+
 // IsCorsEnabled returns true if cross-origin resource sharing is enabled.
 func (cfg *RPCConfig) IsCorsEnabled() bool {
 	return len(cfg.CORSAllowedOrigins) != 0
@@ -616,6 +1045,45 @@ func (cfg RPCConfig) IsTLSEnabled() bool {
 	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
 }
 
+func (cfg RPCConfig) ClientCAFile() string {
+	path := cfg.TLSClientCAFile
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
+}
+
+// IsClientAuthEnabled returns true if the clients must authenticate with a
+// certificate over TLS.
+func (cfg RPCConfig) IsClientAuthEnabled() bool {
+	return cfg.IsTLSEnabled() && cfg.TLSClientCAFile != ""
+}
+
+// ClientAllowedMethods parses TLSClientAllowedMethods into the methods each
+// common name is restricted to.
+func (cfg RPCConfig) ClientAllowedMethods() (map[string][]string, error) {
+	allowed := make(map[string][]string, len(cfg.TLSClientAllowedMethods))
+	for _, entry := range cfg.TLSClientAllowedMethods {
+		// the methods can't contain '=', unlike the common names
+		i := strings.LastIndex(entry, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("entry %q is not of the form <common name>=<method>,<method>...", entry)
+		}
+		commonName := entry[:i]
+		if _, ok := allowed[commonName]; ok {
+			return nil, fmt.Errorf("common name %q is listed twice", commonName)
+		}
+		methods := []string{}
+		for _, method := range strings.Split(entry[i+1:], ",") {
+			if method = strings.TrimSpace(method); method != "" {
+				methods = append(methods, method)
+			}
+		}
+		allowed[commonName] = methods
+	}
+	return allowed, nil
+}
+
 //-----------------------------------------------------------------------------
 // P2PConfig
 
@@ -692,6 +1160,24 @@ type P2PConfig struct { //nolint: maligned
 	// Rate at which packets can be received, in bytes/second
 	RecvRate int64 `mapstructure:"recv-rate"`
 
+	// Number of bytes sent over a peer connection after which the sending
+	// key is rotated. 0 disables byte-based rekeying.
+	SecretConnRekeyBytes uint64 `mapstructure:"secret-conn-rekey-bytes"`
+
+	// Time after which the sending key of a peer connection is rotated, on
+	// the next message sent over it. 0 disables time-based rekeying.
+	//
+	// The keys are rotated through a one-way derivation: a leaked key exposes
+	// the traffic sent under it and the later keys, but not the earlier ones.
+	SecretConnRekeyInterval time.Duration `mapstructure:"secret-conn-rekey-interval"`
+
+	// Number of erasure-coded shares served to each peer per second on the
+	// share channel. 0 disables serving the shares.
+	ShareServeRate int `mapstructure:"share-serve-rate"`
+
+	// Number of shares a peer can be served at once before being rate limited.
+	ShareServeBurst int `mapstructure:"share-serve-burst"`
+
 	// Set true to enable the peer-exchange reactor
 	PexReactor bool `mapstructure:"pex"`
 
@@ -719,6 +1205,19 @@ type P2PConfig struct { //nolint: maligned
 	// layer uses. Options are: "fifo", "priority" and "wdrr",
 	// with the default being "priority".
 	QueueType string `mapstructure:"queue-type"`
+
+	// Path to the audit log of the peer connections, to which the connection
+	// attempts of the node and the disconnections of its peers are appended,
+	// one JSON object per line. Empty disables the audit log.
+	AuditLog string `mapstructure:"audit-log-file"`
+
+	// Size in bytes of the audit log file over which it is rotated. 0 disables
+	// the rotation.
+	AuditLogMaxSize int64 `mapstructure:"audit-log-max-size"`
+
+	// Total size in bytes of the audit log files over which the oldest are
+	// removed. 0 keeps all the files.
+	AuditLogMaxTotalSize int64 `mapstructure:"audit-log-max-total-size"`
 }
 
 // DefaultP2PConfig returns a default configuration for the peer-to-peer layer
@@ -750,6 +1249,11 @@ func DefaultP2PConfig() *P2PConfig {
 		TestDialFail:            false,
 		QueueType:               "priority",
 		UseLegacy:               false,
+		ShareServeRate:          1024,
+		ShareServeBurst:         4096,
+		AuditLog:                "",
+		AuditLogMaxSize:         10 * 1024 * 1024,   // 10MB
+		AuditLogMaxTotalSize:    1024 * 1024 * 1024, // 1GB
 	}
 }
 
@@ -767,6 +1271,15 @@ func (cfg *P2PConfig) AddrBookFile() string {
 	return rootify(cfg.AddrBook, cfg.RootDir)
 }
 
+// AuditLogFile returns the full path to the audit log of the peer connections,
+// or an empty string if it is disabled.
+func (cfg *P2PConfig) AuditLogFile() string {
+	if cfg.AuditLog == "" {
+		return ""
+	}
+	return rootify(cfg.AuditLog, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
@@ -791,6 +1304,21 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv-rate can't be negative")
 	}
+	if cfg.SecretConnRekeyInterval < 0 {
+		return errors.New("secret-conn-rekey-interval can't be negative")
+	}
+	if cfg.ShareServeRate < 0 {
+		return errors.New("share-serve-rate can't be negative")
+	}
+	if cfg.ShareServeBurst < 0 {
+		return errors.New("share-serve-burst can't be negative")
+	}
+	if cfg.AuditLogMaxSize < 0 {
+		return errors.New("audit-log-max-size can't be negative")
+	}
+	if cfg.AuditLogMaxTotalSize < 0 {
+		return errors.New("audit-log-max-total-size can't be negative")
+	}
 	return nil
 }
 
@@ -829,6 +1357,18 @@ type MempoolConfig struct {
 	// XXX: Unused due to https://github.com/tendermint/tendermint/issues/5796
 	MaxBatchBytes int `mapstructure:"max-batch-bytes"`
 
+	// TxHashesBatchSize is the maximum number of tx keys advertised to a peer
+	// in a message, the peer requesting the txs it hasn't seen of them.
+	TxHashesBatchSize int `mapstructure:"tx-hashes-batch-size"`
+
+	// MaxInFlightTxs is the maximum number of txs requested of a peer and not
+	// received yet.
+	MaxInFlightTxs int `mapstructure:"max-in-flight-txs"`
+
+	// TxRequestTimeout is how long a requested tx is waited for, before it is
+	// requested of another peer which advertised it.
+	TxRequestTimeout time.Duration `mapstructure:"tx-request-timeout"`
+
 	// TTLDuration, if non-zero, defines the maximum amount of time a transaction
 	// can exist for in the mempool.
 	//
@@ -844,6 +1384,27 @@ type MempoolConfig struct {
 	// has existed in the mempool at least TTLNumBlocks number of blocks or if
 	// it's insertion time into the mempool is beyond TTLDuration.
 	TTLNumBlocks int64 `mapstructure:"ttl-num-blocks"`
+
+	// ABCIConnections is the number of connections to the application over
+	// which the txs are checked, for an application able to check txs
+	// concurrently. Txs are rechecked over a single connection, to keep their
+	// order. It brings no concurrency to a builtin application.
+	ABCIConnections int `mapstructure:"abci-connections"`
+
+	// SeenTxsFile, if set, is the file the txs of the cache are persisted to
+	// when the node stops, in a bloom filter of their keys, for the restarted
+	// node not to check and gossip again the txs its peers send it back.
+	SeenTxsFile string `mapstructure:"seen-txs-file"`
+
+	// SeenTxsFalsePositiveRate is the rate of the txs never seen that the
+	// filter of the seen txs mistakes for seen ones, and the restarted node
+	// rejects until the filter is discarded.
+	SeenTxsFalsePositiveRate float64 `mapstructure:"seen-txs-false-positive-rate"`
+
+	// SeenTxsMaxAge is how long the filter of the seen txs is kept after it is
+	// persisted, the txs the node saw earlier being expected to have been
+	// committed or dropped by its peers.
+	SeenTxsMaxAge time.Duration `mapstructure:"seen-txs-max-age"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool.
@@ -854,12 +1415,20 @@ func DefaultMempoolConfig() *MempoolConfig {
 		Broadcast: true,
 		// Each signature verification takes .5ms, Size reduced until we implement
 		// ABCI Recheck
-		Size:         5000,
-		MaxTxsBytes:  1024 * 1024 * 1024, // 1GB
-		CacheSize:    10000,
-		MaxTxBytes:   1024 * 1024, // 1MB
-		TTLDuration:  0 * time.Second,
-		TTLNumBlocks: 0,
+		Size:            5000,
+		MaxTxsBytes:     1024 * 1024 * 1024, // 1GB
+		CacheSize:       10000,
+		MaxTxBytes:      1024 * 1024, // 1MB
+		TTLDuration:     0 * time.Second,
+		TTLNumBlocks:    0,
+		ABCIConnections: 1,
+
+		TxHashesBatchSize: 512,
+		MaxInFlightTxs:    1024,
+		TxRequestTimeout:  10 * time.Second,
+
+		SeenTxsFalsePositiveRate: 0.0001,
+		SeenTxsMaxAge:            time.Hour,
 	}
 }
 
@@ -870,6 +1439,11 @@ func TestMempoolConfig() *MempoolConfig {
 	return cfg
 }
 
+// SeenTxsFilePath returns the full path to the file of the seen txs.
+func (cfg *MempoolConfig) SeenTxsFilePath() string {
+	return rootify(cfg.SeenTxsFile, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
@@ -885,12 +1459,30 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxTxBytes < 0 {
 		return errors.New("max-tx-bytes can't be negative")
 	}
+	if cfg.TxHashesBatchSize < 1 {
+		return errors.New("tx-hashes-batch-size must be at least 1")
+	}
+	if cfg.MaxInFlightTxs < 1 {
+		return errors.New("max-in-flight-txs must be at least 1")
+	}
+	if cfg.TxRequestTimeout <= 0 {
+		return errors.New("tx-request-timeout must be positive")
+	}
 	if cfg.TTLDuration < 0 {
 		return errors.New("ttl-duration can't be negative")
 	}
 	if cfg.TTLNumBlocks < 0 {
 		return errors.New("ttl-num-blocks can't be negative")
 	}
+	if cfg.ABCIConnections < 1 {
+		return errors.New("abci-connections must be at least 1")
+	}
+	if cfg.SeenTxsFalsePositiveRate <= 0 || cfg.SeenTxsFalsePositiveRate >= 1 {
+		return errors.New("seen-txs-false-positive-rate must be between 0 and 1")
+	}
+	if cfg.SeenTxsMaxAge < 0 {
+		return errors.New("seen-txs-max-age can't be negative")
+	}
 
 	return nil
 }
@@ -900,6 +1492,8 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 
 // StateSyncConfig defines the configuration for the Tendermint state sync service
 type StateSyncConfig struct {
+	RootDir string `mapstructure:"home"`
+
 	// State sync rapidly bootstraps a new node by discovering, fetching, and restoring a
 	// state machine snapshot from peers instead of fetching and replaying historical
 	// blocks. Requires some peers in the network to take and serve state machine
@@ -940,6 +1534,48 @@ type StateSyncConfig struct {
 
 	// The number of concurrent chunk and block fetchers to run (default: 4).
 	Fetchers int32 `mapstructure:"fetchers"`
+
+	// Base URLs of HTTP(S) snapshot sources, e.g. a CDN or an S3-style object
+	// store bucket, used in addition to peers. Each source serves a
+	// snapshots.json manifest listing its snapshots along with the SHA-256
+	// hash of every chunk, and the chunks themselves at
+	// <url>/<height>/<format>/<index>.
+	SnapshotURLs []string `mapstructure:"snapshot-urls"`
+
+	// Lowest height down to which headers and commits are backfilled after
+	// state sync. Blocks are always backfilled far enough back to verify
+	// evidence that is still valid; a lower retain height keeps more history
+	// for RPC queries. 0 backfills only what evidence verification requires.
+	BackfillRetainHeight int64 `mapstructure:"backfill-retain-height"`
+
+	// Number of blocks between refreshes of the snapshots this node serves to
+	// peers. At each refresh the node lists the application's snapshots and
+	// caches the chunks of the most recent one on disk. It should match the
+	// interval at which the application takes snapshots. 0 disables the cache
+	// and serves snapshots directly from the application.
+	SnapshotInterval int64 `mapstructure:"snapshot-interval"`
+
+	// Directory of the snapshot chunk cache, relative to the home directory.
+	SnapshotCachePath string `mapstructure:"snapshot-cache-path"`
+
+	// Maximum size in bytes of the snapshot chunk cache. The least recently
+	// used chunks are evicted once it is full.
+	SnapshotCacheSize int64 `mapstructure:"snapshot-cache-size"`
+}
+
+// ProgressDir returns the directory in which the progress of a state sync is
+// persisted so that it can be resumed after a restart, or an empty string if
+// the node has no home directory.
+func (cfg *StateSyncConfig) ProgressDir() string {
+	if cfg.RootDir == "" {
+		return ""
+	}
+	return filepath.Join(cfg.RootDir, defaultDataDir, "statesync")
+}
+
+// SnapshotCacheDir returns the full path to the snapshot chunk cache.
+func (cfg *StateSyncConfig) SnapshotCacheDir() string {
+	return rootify(cfg.SnapshotCachePath, cfg.RootDir)
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -958,6 +1594,8 @@ func DefaultStateSyncConfig() *StateSyncConfig {
 		DiscoveryTime:       15 * time.Second,
 		ChunkRequestTimeout: 15 * time.Second,
 		Fetchers:            4,
+		SnapshotCachePath:   filepath.Join(defaultDataDir, "snapshot-cache"),
+		SnapshotCacheSize:   1 << 30, // 1GB
 	}
 }
 
@@ -968,6 +1606,14 @@ func TestStateSyncConfig() *StateSyncConfig {
 
 // ValidateBasic performs basic validation.
 func (cfg *StateSyncConfig) ValidateBasic() error {
+	if cfg.SnapshotInterval < 0 {
+		return errors.New("snapshot-interval can't be negative")
+	}
+
+	if cfg.SnapshotInterval > 0 && cfg.SnapshotCacheSize <= 0 {
+		return errors.New("snapshot-cache-size must be positive when snapshot-interval is set")
+	}
+
 	if !cfg.Enable {
 		return nil
 	}
@@ -1015,6 +1661,16 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 		return errors.New("fetchers is required")
 	}
 
+	if cfg.BackfillRetainHeight < 0 {
+		return errors.New("backfill-retain-height can't be negative")
+	}
+
+	for _, url := range cfg.SnapshotURLs {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			return fmt.Errorf("invalid snapshot-urls entry %q: must be an http or https URL", url)
+		}
+	}
+
 	return nil
 }
 
@@ -1025,8 +1681,14 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 // allows them to catchup quickly by downloading blocks in parallel
 // and verifying their commits.
 type BlockSyncConfig struct {
+	RootDir string `mapstructure:"home"`
+
 	Enable  bool   `mapstructure:"enable"`
 	Version string `mapstructure:"version"`
+
+	// Path to a directory or a .tar/.tar.gz file of exported blocks that are
+	// imported, verifying commits as usual, before syncing from peers.
+	ArchivePath string `mapstructure:"archive-path"`
 }
 
 // DefaultBlockSyncConfig returns a default configuration for the block sync service
@@ -1042,6 +1704,15 @@ func TestBlockSyncConfig() *BlockSyncConfig {
 	return DefaultBlockSyncConfig()
 }
 
+// ArchiveFile returns the full path to the block archive, or an empty string
+// if none is configured.
+func (cfg *BlockSyncConfig) ArchiveFile() string {
+	if cfg.ArchivePath == "" {
+		return ""
+	}
+	return rootify(cfg.ArchivePath, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation.
 func (cfg *BlockSyncConfig) ValidateBasic() error {
 	switch cfg.Version {
@@ -1093,7 +1764,20 @@ type ConsensusConfig struct {
 	PeerGossipSleepDuration     time.Duration `mapstructure:"peer-gossip-sleep-duration"`
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer-query-maj23-sleep-duration"`
 
+	// Gossip the proposal blocks of up to 128 parts to the peers supporting it
+	// as erasure-coded parts, so that the proposer sends each part or parity
+	// part once, to a single peer.
+	ErasureCodedParts bool `mapstructure:"erasure-coded-parts"`
+
+	// Gossip the proposal blocks to the peers supporting it as compact blocks,
+	// the keys of their txs, which the peers look up in their mempool.
+	CompactBlocks bool `mapstructure:"compact-blocks"`
+
 	DoubleSignCheckHeight int64 `mapstructure:"double-sign-check-height"`
+
+	// Check the invariants of the state machine after each transition and
+	// halt on violation, for testnets.
+	ParanoidMode bool `mapstructure:"paranoid-mode"`
 }
 
 // DefaultConsensusConfig returns a default configuration for the consensus service
@@ -1112,7 +1796,10 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		CreateEmptyBlocksInterval:   0 * time.Second,
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
+		ErasureCodedParts:           false,
+		CompactBlocks:               false,
 		DoubleSignCheckHeight:       int64(0),
+		ParanoidMode:                false,
 	}
 }
 
@@ -1130,6 +1817,7 @@ func TestConsensusConfig() *ConsensusConfig {
 	cfg.PeerGossipSleepDuration = 5 * time.Millisecond
 	cfg.PeerQueryMaj23SleepDuration = 250 * time.Millisecond
 	cfg.DoubleSignCheckHeight = int64(0)
+	cfg.ParanoidMode = true
 	return cfg
 }
 
@@ -1242,6 +1930,16 @@ type TxIndexConfig struct {
 	// The PostgreSQL connection configuration, the connection format:
 	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 	PsqlConn string `mapstructure:"psql-conn"`
+
+	// The events to index, as patterns of event types or composite keys
+	// ("type.key"), where "*" matches any sequence of characters. An event type
+	// matches all the attributes of the events of that type.
+	// If empty, all the events are indexed.
+	IndexEvents []string `mapstructure:"index-events"`
+
+	// The events not to index, among those selected by IndexEvents, as patterns
+	// of the same form.
+	ExcludeEvents []string `mapstructure:"exclude-events"`
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
@@ -1256,6 +1954,22 @@ func TestTxIndexConfig() *TxIndexConfig {
 	return DefaultTxIndexConfig()
 }
 
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *TxIndexConfig) ValidateBasic() error {
+	for _, pattern := range cfg.IndexEvents {
+		if strings.TrimSpace(pattern) == "" {
+			return errors.New("index-events can't contain empty patterns")
+		}
+	}
+	for _, pattern := range cfg.ExcludeEvents {
+		if strings.TrimSpace(pattern) == "" {
+			return errors.New("exclude-events can't contain empty patterns")
+		}
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 // InstrumentationConfig
 
@@ -1277,6 +1991,16 @@ type InstrumentationConfig struct {
 
 	// Instrumentation namespace.
 	Namespace string `mapstructure:"namespace"`
+
+	// Maximum number of peers with their own peer_id label in the p2p
+	// metrics, as each one adds series for every channel. The first peers
+	// keep their label until the node restarts, the others are reported
+	// together with peer_id = "other".
+	// 0 - unlimited.
+	MaxPeerMetrics int `mapstructure:"max-peer-metrics"`
+
+	// Export to an OpenTelemetry collector.
+	OTel *OTelConfig `mapstructure:"otel"`
 }
 
 // DefaultInstrumentationConfig returns a default configuration for metrics
@@ -1287,6 +2011,8 @@ func DefaultInstrumentationConfig() *InstrumentationConfig {
 		PrometheusListenAddr: ":26660",
 		MaxOpenConnections:   3,
 		Namespace:            "tendermint",
+		MaxPeerMetrics:       100,
+		OTel:                 DefaultOTelConfig(),
 	}
 }
 
@@ -1302,6 +2028,75 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max-open-connections can't be negative")
 	}
+	if cfg.MaxPeerMetrics < 0 {
+		return errors.New("max-peer-metrics can't be negative")
+	}
+	if cfg.OTel != nil {
+		if err := cfg.OTel.ValidateBasic(); err != nil {
+			return fmt.Errorf("error in [instrumentation.otel] section: %w", err)
+		}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+// OTelConfig
+
+// OTelConfig defines the export of the metrics and traces of the node to an
+// OpenTelemetry collector, with OTLP over gRPC.
+type OTelConfig struct {
+	// Address of the OTLP gRPC receiver of the collector, e.g.
+	// "localhost:4317". Nothing is exported if empty.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Connect to the collector without TLS.
+	Insecure bool `mapstructure:"insecure"`
+
+	// Headers sent with the exports, e.g. to authenticate.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// Export the metrics, the same as served to Prometheus, whether or not
+	// the Prometheus server is on.
+	Metrics bool `mapstructure:"metrics"`
+
+	// Interval between the exports of the metrics.
+	MetricsInterval time.Duration `mapstructure:"metrics-interval"`
+
+	// Export the traces of block execution, ABCI calls and consensus gossip.
+	Traces bool `mapstructure:"traces"`
+
+	// Fraction of the traces exported, from 0 to 1.
+	TraceSampleRate float64 `mapstructure:"trace-sample-rate"`
+}
+
+// DefaultOTelConfig returns a default configuration for the export to an
+// OpenTelemetry collector, which is off.
+func DefaultOTelConfig() *OTelConfig {
+	return &OTelConfig{
+		Endpoint:        "",
+		Insecure:        false,
+		Metrics:         true,
+		MetricsInterval: 15 * time.Second,
+		Traces:          true,
+		TraceSampleRate: 1,
+	}
+}
+
+// Enabled returns true if metrics or traces are exported. It is safe to call
+// on a nil config.
+func (cfg *OTelConfig) Enabled() bool {
+	return cfg != nil && cfg.Endpoint != "" && (cfg.Metrics || cfg.Traces)
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *OTelConfig) ValidateBasic() error {
+	if cfg.MetricsInterval <= 0 {
+		return errors.New("metrics-interval must be positive")
+	}
+	if cfg.TraceSampleRate < 0 || cfg.TraceSampleRate > 1 {
+		return errors.New("trace-sample-rate must be between 0 and 1")
+	}
 	return nil
 }
 
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fmt"
+
 	dbm "github.com/tendermint/tm-db"
 
+	"github.com/tendermint/tendermint/internal/libs/pebbledb"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 )
@@ -23,5 +26,18 @@ type DBProvider func(*DBContext) (dbm.DB, error)
 // specified in the Config.
 func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
 	dbType := dbm.BackendType(ctx.Config.DBBackend)
-	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+	return NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+}
+
+// NewDB creates a new database of type backend with the given name: either
+// one of the backends of tm-db, or "pebble".
+func NewDB(name string, backend dbm.BackendType, dir string) (dbm.DB, error) {
+	if backend == pebbledb.BackendType {
+		db, err := pebbledb.NewDB(name, dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize database: %w", err)
+		}
+		return db, nil
+	}
+	return dbm.NewDB(name, backend, dir)
 }
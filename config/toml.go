@@ -3,6 +3,7 @@ package config
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -57,6 +58,11 @@ func WriteConfigFile(rootDir string, config *Config) {
 	mustWriteFile(configFilePath, buffer.Bytes(), 0644)
 }
 
+// WriteConfig renders config using the template to w.
+func WriteConfig(w io.Writer, config *Config) error {
+	return configTemplate.Execute(w, config)
+}
+
 func writeDefaultConfigFileIfNone(rootDir string) {
 	configFilePath := filepath.Join(rootDir, defaultConfigFilePath)
 	if !tmos.FileExists(configFilePath) {
@@ -97,7 +103,7 @@ moniker = "{{ .BaseConfig.Moniker }}"
 #   - No priv_validator_key.json, priv_validator_state.json
 mode = "{{ .BaseConfig.Mode }}"
 
-# Database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb
+# Database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb | pebble
 # * goleveldb (github.com/syndtr/goleveldb - most popular implementation)
 #   - pure go
 #   - stable
@@ -116,6 +122,10 @@ mode = "{{ .BaseConfig.Mode }}"
 # * badgerdb (uses github.com/dgraph-io/badger)
 #   - EXPERIMENTAL
 #   - use badgerdb build tag (go build -tags badgerdb)
+# * pebble (uses github.com/cockroachdb/pebble)
+#   - EXPERIMENTAL
+#   - pure go
+#   - doesn't stall the writes while compacting, unlike goleveldb
 db-backend = "{{ .BaseConfig.DBBackend }}"
 
 # Database directory
@@ -132,16 +142,88 @@ log-format = "{{ .BaseConfig.LogFormat }}"
 # Path to the JSON file containing the initial validator set and other meta data
 genesis-file = "{{ js .BaseConfig.Genesis }}"
 
+# Expected canonical hash of the genesis doc, in hex, as printed by
+# "tendermint genesis hash". If set, the node refuses to start with another
+# genesis.
+expected-genesis-hash = "{{ .BaseConfig.ExpectedGenesisHash }}"
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node-key-file = "{{ js .BaseConfig.NodeKey }}"
 
-# Mechanism to connect to the ABCI application: socket | grpc
+# Mechanism to connect to the ABCI application: socket | socket-mux | grpc
 abci = "{{ .BaseConfig.ABCI }}"
 
+# Deadline of each attempt of a call to the ABCI application over gRPC.
+# It must be longer than the slowest call of the application, like a
+# Commit. 0 disables the deadline.
+abci-grpc-call-timeout = "{{ .BaseConfig.ABCIGRPCCallTimeout }}"
+
+# The number of times a call to the ABCI application over gRPC is retried
+# when it fails with a transient error. Only the calls which don't change
+# the state of the application, like Info and Query, are retried.
+abci-grpc-max-retries = {{ .BaseConfig.ABCIGRPCMaxRetries }}
+
+# The interval of the keepalive pings of the gRPC connection to the ABCI
+# application when it is idle, and how long to wait for their reply before
+# closing the connection.
+abci-grpc-keepalive-time = "{{ .BaseConfig.ABCIGRPCKeepaliveTime }}"
+abci-grpc-keepalive-timeout = "{{ .BaseConfig.ABCIGRPCKeepaliveTimeout }}"
+
+# How to deal with the responses of the ABCI application which violate the
+# protocol, like a negative gas or an invalid validator update: strict |
+# lenient. Strict rejects the response, which halts the node, and lenient
+# logs it and sanitizes the response. All the validators should use the
+# same mode, as a sanitized response may change the state.
+abci-response-validation = "{{ .BaseConfig.ABCIResponseValidation }}"
+
 # If true, query the ABCI app on connecting to a new peer
 # so the app can decide if we should keep the connection or not
 filter-peers = {{ .BaseConfig.FilterPeers }}
 
+# The number of most recent blocks whose results (the ABCI responses served by
+# the block_results endpoint) are kept, regardless of the blocks the application
+# asks to retain. This allows pruning the results, which are often much larger
+# than the blocks, while serving the blocks, or keeping the results of more
+# blocks than the blocks themselves.
+# 0 keeps the results of the blocks retained by the application.
+retain-results-blocks = {{ .BaseConfig.RetainResultsBlocks }}
+
+# Save the state, prune the stores and publish the events of each block in the
+# background, one block after the other, while the consensus moves to the next
+# height, instead of before. This cuts the time between the blocks of the nodes
+# whose disks are slow. The events of a block may then be published after the
+# first consensus events of the next height, e.g. NewRound.
+pipeline-commit = {{ .BaseConfig.PipelineCommit }}
+
+# The file the report of an app hash mismatch is written to, when the
+# application computes another app hash, or last results hash, than the one of
+# a block committed by the network. The report has the txs and the ABCI
+# responses of the block which led to the mismatch, and is also served by
+# /mismatch_report.
+mismatch-report-file = "{{ js .BaseConfig.MismatchReport }}"
+
+# The number of most recent blocks kept in the block store database, when the
+# older blocks are moved to the block archive in archive-dir. This shrinks the
+# database of the archival nodes, whose old blocks are rarely accessed: the
+# archived blocks are still served, and loaded from the archive on access.
+# The archive can also be an S3 compatible object store, see archive-s3-url.
+# 0 disables the archiving.
+archive-retain-blocks = {{ .BaseConfig.ArchiveRetainBlocks }}
+
+# Directory of the block archive
+archive-dir = "{{ js .BaseConfig.ArchivePath }}"
+
+# URL of the bucket of the block archive in an S3 compatible object store,
+# instead of archive-dir, path-style with an optional prefix, e.g.
+# "https://s3.us-east-1.amazonaws.com/bucket/prefix". GCS is used with
+# "https://storage.googleapis.com/bucket" and HMAC keys. The credentials are
+# read from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+# environment variables.
+archive-s3-url = "{{ .BaseConfig.ArchiveS3URL }}"
+
+# Region of the bucket of archive-s3-url ("auto" for GCS)
+archive-s3-region = "{{ .BaseConfig.ArchiveS3Region }}"
+
 
 #######################################################
 ###       Priv Validator Configuration              ###
@@ -154,11 +236,20 @@ key-file = "{{ js .PrivValidator.Key }}"
 # Path to the JSON file containing the last sign state of a validator
 state-file = "{{ js .PrivValidator.State }}"
 
+# Type of the key generated for the validator, by init and the other commands
+# creating the key file: ed25519, secp256k1 or bls
+key-type = "{{ .PrivValidator.KeyType }}"
+
 # TCP or UNIX socket address for Tendermint to listen on for
 # connections from an external PrivValidator process
 # when the listenAddr is prefixed with grpc instead of tcp it will use the gRPC Client
 laddr = "{{ .PrivValidator.ListenAddr }}"
 
+# TCP or UNIX socket addresses for Tendermint to listen on for connections
+# from backup external PrivValidator processes, in order of priority. Signing
+# fails over to them when the ones before don't respond, e.g. on a timeout.
+failover-laddrs = [{{ range $i, $e := .PrivValidator.FailoverListenAddrs }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+
 # Path to the client certificate generated while creating needed files for secure connection.
 # If a remote validator address is provided but no certificate, the connection will be insecure
 client-certificate-file = "{{ js .PrivValidator.ClientCertificate }}"
@@ -169,6 +260,22 @@ validator-client-key-file = "{{ js .PrivValidator.ClientKey }}"
 # Path to the Root Certificate Authority used to sign both client and server certificates
 certificate-authority = "{{ js .PrivValidator.RootCA }}"
 
+# TCP or UNIX socket addresses for Tendermint to listen on for connections
+# from the external PrivValidator processes holding the shares of a threshold
+# key, which sign jointly, or grpc:// addresses of the gRPC servers of such
+# processes to dial. Takes precedence over laddr.
+threshold-laddrs = [{{ range $i, $e := .PrivValidator.ThresholdListenAddrs }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+
+# Number of the external PrivValidator processes needed to sign
+threshold = {{ .PrivValidator.Threshold }}
+
+# Time to wait for each round of a threshold signing session, after which the
+# processes which haven't responded are left out of the next attempt
+threshold-timeout = "{{ .PrivValidator.ThresholdTimeout }}"
+
+# Number of threshold signing sessions attempted before signing fails
+threshold-retries = {{ .PrivValidator.ThresholdRetries }}
+
 
 #######################################################################
 ###                 Advanced Configuration Options                  ###
@@ -182,6 +289,12 @@ certificate-authority = "{{ js .PrivValidator.RootCA }}"
 # TCP or UNIX socket address for the RPC server to listen on
 laddr = "{{ .RPC.ListenAddress }}"
 
+# Permissions of the UNIX sockets of laddr and grpc-laddr, in octal, e.g.
+# laddr = "unix:///var/run/tendermint.sock". The clients must be allowed to
+# write to a socket to connect to it: "0660" lets the group of the node connect.
+# Empty leaves the permissions to the umask.
+unix-socket-mode = "{{ .RPC.UnixSocketMode }}"
+
 # A list of origins a cross-domain request can be executed from
 # Default value '[]' disables cors support
 # Use '["*"]' to allow any origin
@@ -209,6 +322,8 @@ grpc-laddr = "{{ .RPC.GRPCListenAddress }}"
 grpc-max-open-connections = {{ .RPC.GRPCMaxOpenConnections }}
 
 # Activate unsafe RPC commands like /dial-seeds and /unsafe-flush-mempool
+# /unsafe-drain, /unsafe-set-log-level and /unsafe-reload-config are only
+# allowed from localhost
 unsafe = {{ .RPC.Unsafe }}
 
 # Maximum number of simultaneous connections (including WebSocket).
@@ -230,6 +345,21 @@ max-subscription-clients = {{ .RPC.MaxSubscriptionClients }}
 # the estimated # maximum number of broadcast_tx_commit calls per block.
 max-subscriptions-per-client = {{ .RPC.MaxSubscriptionsPerClient }}
 
+# Number of events kept to resume the /subscribe subscriptions of the clients
+# which reconnect, by passing the ID of the last event they received as
+# last_event_id. Set to 0 to disable resumption.
+event-history-size = {{ .RPC.EventHistorySize }}
+
+# Number of events buffered by a /subscribe subscription for its client.
+subscription-buffer-size = {{ .RPC.SubscriptionBufferSize }}
+
+# What happens to the events of a /subscribe subscription once its buffer is
+# full, when the client is too slow:
+#   1) "terminate" - the subscription is canceled (default)
+#   2) "drop-oldest" - the oldest buffered events are dropped
+#   3) "drop-newest" - the new events are dropped
+subscription-overflow = "{{ .RPC.SubscriptionOverflow }}"
+
 # How long to wait for a tx to be committed during /broadcast_tx_commit.
 # WARNING: Using a value larger than 10s will result in increasing the
 # global HTTP write timeout, which applies to all connections and endpoints.
@@ -242,6 +372,42 @@ max-body-bytes = {{ .RPC.MaxBodyBytes }}
 # Maximum size of request header, in bytes
 max-header-bytes = {{ .RPC.MaxHeaderBytes }}
 
+# Maximum rate of the requests of all clients, in requests per second.
+# Throttled requests get the status 429 Too Many Requests.
+# Set to 0 to disable the limit.
+rate-limit = {{ .RPC.RateLimit }}
+
+# Number of requests of all clients which can be served at once, above the
+# rate limit.
+rate-limit-burst = {{ .RPC.RateLimitBurst }}
+
+# Maximum rate of the requests of each client IP, in requests per second.
+# Set to 0 to disable the limit.
+rate-limit-per-ip = {{ .RPC.RateLimitPerIP }}
+
+# Number of requests of each client IP which can be served at once, above the
+# rate limit.
+rate-limit-per-ip-burst = {{ .RPC.RateLimitPerIPBurst }}
+
+# Maximum size, in bytes, of the cache of responses which cannot change
+# anymore: /block, /block_results, /commit and /validators at committed
+# heights. Set to 0 to disable the cache.
+response-cache-bytes = {{ .RPC.ResponseCacheBytes }}
+
+# Maximum time since the last block before /health reports the node as
+# unhealthy, once it caught up. Raise it, or set it to 0 to disable the check,
+# if blocks are not produced at a steady pace (e.g. create-empty-blocks = false).
+health-max-block-interval = "{{ .RPC.HealthMaxBlockInterval }}"
+
+# Number of peers below which /health reports the node as degraded.
+health-target-peers = {{ .RPC.HealthTargetPeers }}
+
+# Number of connections to the application over which the queries of
+# /abci_query at past heights are served, for an application able to serve them
+# concurrently. 0 serves them over the query connection, along with the queries
+# of the latest state.
+historical-query-connections = {{ .RPC.HistoricalQueryConnections }}
+
 # The path to a file containing certificate that is used to create the HTTPS server.
 # Might be either absolute path or path related to Tendermint's config directory.
 # If the certificate is signed by a certificate authority,
@@ -257,9 +423,28 @@ tls-cert-file = "{{ .RPC.TLSCertFile }}"
 # Otherwise, HTTP server is run.
 tls-key-file = "{{ .RPC.TLSKeyFile }}"
 
+# The path to a file containing the certificates of the certificate authorities
+# which sign the certificates of the clients, PEM encoded.
+# Might be either absolute path or path related to Tendermint's config directory.
+# If set along with tls-cert-file and tls-key-file, the clients must
+# authenticate with a certificate signed by one of them (mutual TLS).
+tls-client-ca-file = "{{ .RPC.TLSClientCAFile }}"
+
+# A list of "<common name>=<method>,<method>..." entries, restricting the
+# clients which authenticate with a certificate of the given common name to the
+# given methods, e.g. ["relayer=broadcast_tx_sync,broadcast_tx_async"]. The "*"
+# common name restricts the clients whose common name is not listed. The clients
+# not restricted can call all the methods. Requires tls-client-ca-file.
+tls-client-allowed-methods = [{{ range .RPC.TLSClientAllowedMethods }}{{ printf "%q, " . }}{{end}}]
+
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
 pprof-laddr = "{{ .RPC.PprofListenAddress }}"
 
+# The number of requests a request to each endpoint counts as against the
+# rate limits. Endpoints not listed count as one request.
+[rpc.rate-limit-weights]
+{{ range $endpoint, $weight := .RPC.RateLimitWeights }}{{ $endpoint }} = {{ $weight }}
+{{ end }}
 #######################################################
 ###           P2P Configuration Options             ###
 #######################################################
@@ -355,6 +540,25 @@ send-rate = {{ .P2P.SendRate }}
 # ref: https:#github.com/tendermint/tendermint/issues/5670
 recv-rate = {{ .P2P.RecvRate }}
 
+# Rotate the key used to encrypt outgoing traffic on a peer connection after
+# this many bytes have been sent under it. 0 disables byte-based rekeying.
+# Only the connections to the peers understanding rekey frames are rekeyed.
+secret-conn-rekey-bytes = {{ .P2P.SecretConnRekeyBytes }}
+
+# Rotate the key used to encrypt outgoing traffic on a peer connection after
+# it has been in use for this long, on the next message sent over it. 0
+# disables time-based rekeying. The keys are derived one from another, so a
+# leaked key exposes the traffic sent under the later keys, but not the
+# earlier ones.
+secret-conn-rekey-interval = "{{ .P2P.SecretConnRekeyInterval }}"
+
+# Number of erasure-coded shares served to each peer per second, for data
+# availability sampling and partial nodes. 0 disables serving the shares.
+share-serve-rate = {{ .P2P.ShareServeRate }}
+
+# Number of shares a peer can be served at once before being rate limited.
+share-serve-burst = {{ .P2P.ShareServeBurst }}
+
 # Set true to enable the peer-exchange reactor
 pex = {{ .P2P.PexReactor }}
 
@@ -369,6 +573,20 @@ allow-duplicate-ip = {{ .P2P.AllowDuplicateIP }}
 handshake-timeout = "{{ .P2P.HandshakeTimeout }}"
 dial-timeout = "{{ .P2P.DialTimeout }}"
 
+# Path to the audit log of the peer connections, to which the connection
+# attempts of the node (inbound and outbound, with their node ID, address and
+# handshake result) and the disconnections of its peers (with their reason) are
+# appended, one JSON object per line. Empty disables the audit log.
+audit-log-file = "{{ .P2P.AuditLog }}"
+
+# Size in bytes of the audit log file over which it is rotated. 0 disables the
+# rotation.
+audit-log-max-size = {{ .P2P.AuditLogMaxSize }}
+
+# Total size in bytes of the audit log files over which the oldest are removed.
+# 0 keeps all the files.
+audit-log-max-total-size = {{ .P2P.AuditLogMaxTotalSize }}
+
 #######################################################
 ###          Mempool Configuration Option          ###
 #######################################################
@@ -407,6 +625,17 @@ max-tx-bytes = {{ .Mempool.MaxTxBytes }}
 # XXX: Unused due to https://github.com/tendermint/tendermint/issues/5796
 max-batch-bytes = {{ .Mempool.MaxBatchBytes }}
 
+# Maximum number of tx keys advertised to a peer in a message. The txs are
+# gossiped as their keys, the peers requesting the txs they haven't seen.
+tx-hashes-batch-size = {{ .Mempool.TxHashesBatchSize }}
+
+# Maximum number of txs requested of a peer and not received yet.
+max-in-flight-txs = {{ .Mempool.MaxInFlightTxs }}
+
+# How long a requested tx is waited for, before it is requested of another
+# peer which advertised it.
+tx-request-timeout = "{{ .Mempool.TxRequestTimeout }}"
+
 # ttl-duration, if non-zero, defines the maximum amount of time a transaction
 # can exist for in the mempool.
 #
@@ -423,6 +652,25 @@ ttl-duration = "{{ .Mempool.TTLDuration }}"
 # it's insertion time into the mempool is beyond ttl-duration.
 ttl-num-blocks = {{ .Mempool.TTLNumBlocks }}
 
+# Number of connections to the application over which txs are checked, for an
+# application able to check txs concurrently. Txs are rechecked over a single
+# connection, to keep their order. It brings no concurrency to a builtin application.
+abci-connections = {{ .Mempool.ABCIConnections }}
+
+# If set, the file the txs of the cache are persisted to when the node stops,
+# in a bloom filter of their keys, for the restarted node not to check and
+# gossip again the txs its peers send it back. Relative to the home directory.
+seen-txs-file = "{{ js .Mempool.SeenTxsFile }}"
+
+# Rate of the txs never seen that the filter mistakes for seen ones, and the
+# restarted node rejects until the filter is discarded. The lower the rate, the
+# larger the file: about 2.4KB per 1000 txs of the cache at 0.0001.
+seen-txs-false-positive-rate = {{ .Mempool.SeenTxsFalsePositiveRate }}
+
+# How long the filter is kept after it is persisted, the txs the node saw
+# earlier being expected to have been committed or dropped by its peers.
+seen-txs-max-age = "{{ .Mempool.SeenTxsMaxAge }}"
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -467,6 +715,32 @@ chunk-request-timeout = "{{ .StateSync.ChunkRequestTimeout }}"
 # The number of concurrent chunk and block fetchers to run (default: 4).
 fetchers = "{{ .StateSync.Fetchers }}"
 
+# Comma-separated list of base URLs of HTTP(S) snapshot sources, such as a CDN
+# or an S3-style object store bucket, used in addition to peers. Each source
+# must serve a snapshots.json manifest listing its snapshots along with the
+# SHA-256 hash of every chunk, and the chunks at <url>/<height>/<format>/<index>.
+snapshot-urls = "{{ StringsJoin .StateSync.SnapshotURLs "," }}"
+
+# Lowest height down to which headers and commits are backfilled after state
+# sync. Blocks are always backfilled far enough back to verify evidence that is
+# still valid; a lower retain height keeps more history for RPC queries.
+# 0 backfills only what evidence verification requires.
+backfill-retain-height = {{ .StateSync.BackfillRetainHeight }}
+
+# Number of blocks between refreshes of the snapshots this node serves to peers.
+# At each refresh the node lists the application's snapshots and caches the
+# chunks of the most recent one on disk. It should match the interval at which
+# the application takes snapshots. 0 disables the cache and serves snapshots
+# directly from the application.
+snapshot-interval = {{ .StateSync.SnapshotInterval }}
+
+# Directory of the snapshot chunk cache, relative to the home directory.
+snapshot-cache-path = "{{ js .StateSync.SnapshotCachePath }}"
+
+# Maximum size in bytes of the snapshot chunk cache. The least recently used
+# chunks are evicted once it is full.
+snapshot-cache-size = {{ .StateSync.SnapshotCacheSize }}
+
 #######################################################
 ###       Block Sync Configuration Connections       ###
 #######################################################
@@ -482,6 +756,12 @@ enable = {{ .BlockSync.Enable }}
 #   2) "v2" - DEPRECATED, please use v0
 version = "{{ .BlockSync.Version }}"
 
+# Path to a directory or a .tar/.tar.gz file of exported blocks to import
+# before syncing from peers. Block commits are verified as usual. Each file
+# holds length-delimited protobuf encoded blocks in ascending height order;
+# files in a directory are read in lexical order.
+archive-path = "{{ js .BlockSync.ArchivePath }}"
+
 #######################################################
 ###         Consensus Configuration Options         ###
 #######################################################
@@ -523,6 +803,24 @@ create-empty-blocks-interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
 peer-gossip-sleep-duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer-query-maj23-sleep-duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 
+# Gossip the proposal blocks of up to 128 parts to the peers supporting it as
+# erasure-coded parts: the proposer sends each part, or Reed-Solomon parity
+# part, to a single peer, and the peers rebuild the block from any half of
+# them, cutting the egress bandwidth of the proposer.
+erasure-coded-parts = {{ .Consensus.ErasureCodedParts }}
+
+# Gossip the proposal blocks to the peers supporting it as compact blocks: the
+# block without its txs and the hashes of its txs. The peers reconstruct the
+# block from the txs of their mempool, and request the txs they miss, saving
+# most of the bandwidth spent on txs already gossipped by the mempool.
+compact-blocks = {{ .Consensus.CompactBlocks }}
+
+# Check the invariants of the consensus state machine after each transition
+# (monotonic height/round/step and locked round, valid POLs, consistent vote
+# sets) and halt with a dump of the round state on violation. This slows the
+# consensus down and is meant for testnets.
+paranoid-mode = {{ .Consensus.ParanoidMode }}
+
 #######################################################
 ###   Transaction Indexer Configuration Options     ###
 #######################################################
@@ -545,6 +843,16 @@ indexer = [{{ range $i, $e := .TxIndex.Indexer }}{{if $i}}, {{end}}{{ printf "%q
 #   postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 psql-conn = "{{ .TxIndex.PsqlConn }}"
 
+# The events to index, as patterns of event types (e.g. "transfer") or of
+# composite keys (e.g. "transfer.sender"), where "*" matches any sequence of
+# characters (e.g. "*.amount"). An event type matches all its attributes.
+# If empty, all the attributes the application marks for indexing are indexed.
+index-events = [{{ range $i, $e := .TxIndex.IndexEvents }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+
+# The events not to index, among those selected by index-events, as patterns of
+# the same form. "tx.height", "tx.hash" and "block.height" are always indexed.
+exclude-events = [{{ range $i, $e := .TxIndex.ExcludeEvents }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
@@ -566,7 +874,42 @@ max-open-connections = {{ .Instrumentation.MaxOpenConnections }}
 
 # Instrumentation namespace
 namespace = "{{ .Instrumentation.Namespace }}"
-`
+
+# Maximum number of peers with their own peer_id label in the p2p metrics, as
+# each one adds series for every channel. The first peers keep their label
+# until the node restarts, the others are reported together with
+# peer_id = "other".
+# 0 - unlimited.
+max-peer-metrics = {{ .Instrumentation.MaxPeerMetrics }}
+
+# Export of the metrics and traces to an OpenTelemetry collector, with OTLP
+# over gRPC.
+[instrumentation.otel]
+
+# Address of the OTLP gRPC receiver of the collector, e.g. "localhost:4317".
+# Nothing is exported if empty.
+endpoint = "{{ .Instrumentation.OTel.Endpoint }}"
+
+# Connect to the collector without TLS.
+insecure = {{ .Instrumentation.OTel.Insecure }}
+
+# Export the metrics, the same as served to Prometheus, whether or not the
+# Prometheus server is on.
+metrics = {{ .Instrumentation.OTel.Metrics }}
+
+# Interval between the exports of the metrics.
+metrics-interval = "{{ .Instrumentation.OTel.MetricsInterval }}"
+
+# Export the traces of block execution, ABCI calls and consensus gossip.
+traces = {{ .Instrumentation.OTel.Traces }}
+
+# Fraction of the traces exported, from 0 to 1.
+trace-sample-rate = {{ .Instrumentation.OTel.TraceSampleRate }}
+
+# Headers sent with the exports, e.g. to authenticate.
+[instrumentation.otel.headers]
+{{ range $key, $value := .Instrumentation.OTel.Headers }}{{ $key }} = {{ printf "%q" $value }}
+{{ end }}`
 
 /****** these are for test settings ***********/
 
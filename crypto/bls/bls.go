@@ -0,0 +1,287 @@
+// Package bls implements BLS signatures over the BN256 pairing-friendly curve
+// of golang.org/x/crypto/bn256: a private key is a scalar, its public key a
+// point of G2 and a signature the point of G1 the message hashes to, times the
+// private key. As the signatures of a message by the shares of a key combine
+// into the signature by the key, they suit threshold signing.
+//
+// NOTE: the BN256 curve provides about 100 bits of security, rather than the
+// 128 bits of BLS12-381, which no dependency of this module implements.
+package bls
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/bn256"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+const (
+	PrivKeyName = "tendermint/PrivKeyBLS"
+	PubKeyName  = "tendermint/PubKeyBLS"
+	// PrivKeySize is the size, in bytes, of private keys: a scalar.
+	PrivKeySize = 32
+	// PubKeySize is the size, in bytes, of public keys: a point of G2.
+	PubKeySize = 128
+	// SignatureSize is the size, in bytes, of signatures: a point of G1.
+	SignatureSize = 64
+
+	KeyType = "bls"
+
+	hashDomain = "tendermint/bls/hash-to-g1"
+)
+
+var (
+	// fieldOrder is the order of the field of the coordinates of G1.
+	fieldOrder, _ = new(big.Int).SetString(
+		"65000549695646603732796438742359905742825358107623003571877145026864184071783", 10)
+	// curveB is the constant of the equation y² = x³ + b of G1.
+	curveB = big.NewInt(3)
+)
+
+func init() {
+	tmjson.RegisterType(PubKey{}, PubKeyName)
+	tmjson.RegisterType(PrivKey{}, PrivKeyName)
+}
+
+//-------------------------------------
+
+var _ crypto.PrivKey = PrivKey{}
+
+// PrivKey implements crypto.PrivKey.
+type PrivKey []byte
+
+// Bytes returns the privkey byte format.
+func (privKey PrivKey) Bytes() []byte {
+	return []byte(privKey)
+}
+
+// Sign returns the signature of msg: the point msg hashes to, times the
+// private key.
+func (privKey PrivKey) Sign(msg []byte) ([]byte, error) {
+	if len(privKey) != PrivKeySize {
+		return nil, fmt.Errorf("invalid private key size %d", len(privKey))
+	}
+	k := new(big.Int).SetBytes(privKey)
+	return new(bn256.G1).ScalarMult(HashToG1(msg), k).Marshal(), nil
+}
+
+// PubKey returns the public key of the private key, the generator of G2 times
+// the private key.
+func (privKey PrivKey) PubKey() crypto.PubKey {
+	k := new(big.Int).SetBytes(privKey)
+	return PubKey(new(bn256.G2).ScalarBaseMult(k).Marshal())
+}
+
+// Equals - you probably don't need to use this.
+// Runs in constant time based on length of the keys.
+func (privKey PrivKey) Equals(other crypto.PrivKey) bool {
+	if otherBLS, ok := other.(PrivKey); ok {
+		return subtle.ConstantTimeCompare(privKey[:], otherBLS[:]) == 1
+	}
+	return false
+}
+
+func (privKey PrivKey) Type() string {
+	return KeyType
+}
+
+// GenPrivKey generates a new BLS private key.
+// It uses OS randomness in conjunction with the current global random seed
+// in tendermint/libs/common to generate the private key.
+func GenPrivKey() PrivKey {
+	return genPrivKey(crypto.CReader())
+}
+
+// genPrivKey generates a new BLS private key using the provided reader.
+func genPrivKey(rand io.Reader) PrivKey {
+	seed := make([]byte, 64)
+	if _, err := io.ReadFull(rand, seed); err != nil {
+		panic(err)
+	}
+	return privKeyFromSeed(seed)
+}
+
+// GenPrivKeyFromSecret hashes the secret with SHA2, and uses that output to
+// create the private key.
+// NOTE: secret should be the output of a KDF like bcrypt,
+// if it's derived from user input.
+func GenPrivKeyFromSecret(secret []byte) PrivKey {
+	seed := sha512.Sum512(secret)
+	return privKeyFromSeed(seed[:])
+}
+
+// privKeyFromSeed reduces the seed to a non-zero scalar.
+func privKeyFromSeed(seed []byte) PrivKey {
+	k := new(big.Int).SetBytes(seed)
+	k.Mod(k, new(big.Int).Sub(bn256.Order, big.NewInt(1)))
+	k.Add(k, big.NewInt(1))
+	return PrivKey(k.FillBytes(make([]byte, PrivKeySize)))
+}
+
+//-------------------------------------
+
+var _ crypto.PubKey = PubKey{}
+
+// PubKey implements crypto.PubKey for BLS signatures.
+type PubKey []byte
+
+// Address is the SHA256-20 of the raw pubkey bytes.
+func (pubKey PubKey) Address() crypto.Address {
+	if len(pubKey) != PubKeySize {
+		panic("pubkey is incorrect size")
+	}
+	return crypto.Address(tmhash.SumTruncated(pubKey))
+}
+
+// Bytes returns the PubKey byte format.
+func (pubKey PubKey) Bytes() []byte {
+	return []byte(pubKey)
+}
+
+// VerifySignature checks that e(sig, g2) = e(H(msg), pubKey).
+func (pubKey PubKey) VerifySignature(msg []byte, sig []byte) bool {
+	if len(sig) != SignatureSize {
+		return false
+	}
+	point, ok := new(bn256.G2).Unmarshal(pubKey)
+	if !ok || isZero(pubKey) {
+		return false
+	}
+	sigPoint, ok := new(bn256.G1).Unmarshal(sig)
+	if !ok {
+		return false
+	}
+	return VerifyPoints(point, HashToG1(msg), sigPoint)
+}
+
+func (pubKey PubKey) String() string {
+	return fmt.Sprintf("PubKeyBLS{%X}", []byte(pubKey))
+}
+
+func (pubKey PubKey) Type() string {
+	return KeyType
+}
+
+func (pubKey PubKey) Equals(other crypto.PubKey) bool {
+	if otherBLS, ok := other.(PubKey); ok {
+		return bytes.Equal(pubKey[:], otherBLS[:])
+	}
+	return false
+}
+
+//-------------------------------------
+
+// HashToG1 hashes msg to a point of G1: the first of the hashes of msg with a
+// counter which is the x coordinate of a point. As G1 has a cofactor of 1, the
+// point is in the group.
+func HashToG1(msg []byte) *bn256.G1 {
+	msgHash := sha512.Sum512(msg)
+	var (
+		counter [4]byte
+		encoded [2 * 32]byte
+	)
+	for i := uint32(0); ; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		h := sha512.New()
+		h.Write([]byte(hashDomain))
+		h.Write(msgHash[:])
+		h.Write(counter[:])
+
+		x := new(big.Int).SetBytes(h.Sum(nil))
+		x.Mod(x, fieldOrder)
+		y2 := new(big.Int).Exp(x, big.NewInt(3), fieldOrder)
+		y2.Add(y2, curveB)
+		y2.Mod(y2, fieldOrder)
+		y := new(big.Int).ModSqrt(y2, fieldOrder)
+		if y == nil {
+			continue
+		}
+
+		x.FillBytes(encoded[:32])
+		y.FillBytes(encoded[32:])
+		if point, ok := new(bn256.G1).Unmarshal(encoded[:]); ok {
+			return point
+		}
+	}
+}
+
+// AggregateSignatures returns the aggregate of the signatures, their sum,
+// which VerifyAggregateSignature checks against the keys and messages they
+// sign at once.
+func AggregateSignatures(sigs ...[]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("no signatures to aggregate")
+	}
+	var aggregate *bn256.G1
+	for i, sig := range sigs {
+		if len(sig) != SignatureSize {
+			return nil, fmt.Errorf("invalid size %d of signature %d", len(sig), i)
+		}
+		point, ok := new(bn256.G1).Unmarshal(sig)
+		if !ok {
+			return nil, fmt.Errorf("invalid signature %d", i)
+		}
+		if aggregate == nil {
+			aggregate = point
+		} else {
+			aggregate.Add(aggregate, point)
+		}
+	}
+	return aggregate.Marshal(), nil
+}
+
+// VerifyAggregateSignature checks that sig is the aggregate of the signatures
+// of msgs[i] by pubKeys[i], that is e(sig, g2) = e(H(msgs[0]), pubKeys[0]) *
+// ... * e(H(msgs[n-1]), pubKeys[n-1]). As the messages may differ, it takes a
+// pairing per signature, but a single signature.
+func VerifyAggregateSignature(pubKeys []PubKey, msgs [][]byte, sig []byte) bool {
+	if len(pubKeys) == 0 || len(pubKeys) != len(msgs) || len(sig) != SignatureSize {
+		return false
+	}
+	sigPoint, ok := new(bn256.G1).Unmarshal(sig)
+	if !ok {
+		return false
+	}
+	var product *bn256.GT
+	for i, pubKey := range pubKeys {
+		point, ok := new(bn256.G2).Unmarshal(pubKey)
+		if !ok || isZero(pubKey) {
+			return false
+		}
+		pair := bn256.Pair(HashToG1(msgs[i]), point)
+		if product == nil {
+			product = pair
+		} else {
+			// the group operation of GT, noted additively
+			product.Add(product, pair)
+		}
+	}
+	g2 := new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+	return bytes.Equal(bn256.Pair(sigPoint, g2).Marshal(), product.Marshal())
+}
+
+// VerifyPoints checks that sig is the signature of the point msg by pubKey,
+// that is e(sig, g2) = e(msg, pubKey).
+func VerifyPoints(pubKey *bn256.G2, msg, sig *bn256.G1) bool {
+	g2 := new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+	return bytes.Equal(bn256.Pair(sig, g2).Marshal(), bn256.Pair(msg, pubKey).Marshal())
+}
+
+func isZero(bz []byte) bool {
+	for _, b := range bz {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
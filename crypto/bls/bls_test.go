@@ -0,0 +1,75 @@
+package bls_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls"
+)
+
+func TestSignAndValidateBLS(t *testing.T) {
+	privKey := bls.GenPrivKey()
+	pubKey := privKey.PubKey()
+	require.Len(t, pubKey.Bytes(), bls.PubKeySize)
+
+	msg := crypto.CRandBytes(128)
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, bls.SignatureSize)
+
+	// Test the signature
+	assert.True(t, pubKey.VerifySignature(msg, sig))
+	assert.False(t, pubKey.VerifySignature([]byte("other"), sig))
+	assert.False(t, bls.GenPrivKey().PubKey().VerifySignature(msg, sig))
+
+	// Mutate the signature, just one bit.
+	sig[7] ^= byte(0x01)
+	assert.False(t, pubKey.VerifySignature(msg, sig))
+}
+
+func TestGenPrivKeyFromSecret(t *testing.T) {
+	secret := []byte("secret")
+	assert.True(t, bls.GenPrivKeyFromSecret(secret).Equals(bls.GenPrivKeyFromSecret(secret)))
+	assert.False(t, bls.GenPrivKeyFromSecret(secret).Equals(bls.GenPrivKeyFromSecret([]byte("other"))))
+}
+
+func TestAggregateSignatures(t *testing.T) {
+	var (
+		pubKeys []bls.PubKey
+		msgs    [][]byte
+		sigs    [][]byte
+	)
+	for i := 0; i < 4; i++ {
+		privKey := bls.GenPrivKey()
+		msg := crypto.CRandBytes(32)
+		sig, err := privKey.Sign(msg)
+		require.NoError(t, err)
+		pubKeys = append(pubKeys, privKey.PubKey().(bls.PubKey))
+		msgs = append(msgs, msg)
+		sigs = append(sigs, sig)
+	}
+
+	aggregate, err := bls.AggregateSignatures(sigs...)
+	require.NoError(t, err)
+	require.Len(t, aggregate, bls.SignatureSize)
+	assert.True(t, bls.VerifyAggregateSignature(pubKeys, msgs, aggregate))
+
+	// the aggregate of some of the signatures with the others
+	partial, err := bls.AggregateSignatures(sigs[:2]...)
+	require.NoError(t, err)
+	combined, err := bls.AggregateSignatures(partial, sigs[2], sigs[3])
+	require.NoError(t, err)
+	assert.Equal(t, aggregate, combined)
+
+	// a signature left out, or a message of another key
+	assert.False(t, bls.VerifyAggregateSignature(pubKeys[:3], msgs[:3], aggregate))
+	assert.False(t, bls.VerifyAggregateSignature(pubKeys, [][]byte{msgs[1], msgs[0], msgs[2], msgs[3]}, aggregate))
+
+	_, err = bls.AggregateSignatures()
+	assert.Error(t, err)
+	_, err = bls.AggregateSignatures([]byte("invalid"))
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/crypto/sr25519"
@@ -15,6 +16,7 @@ func init() {
 	json.RegisterType((*cryptoproto.PublicKey)(nil), "tendermint.crypto.PublicKey")
 	json.RegisterType((*cryptoproto.PublicKey_Ed25519)(nil), "tendermint.crypto.PublicKey_Ed25519")
 	json.RegisterType((*cryptoproto.PublicKey_Secp256K1)(nil), "tendermint.crypto.PublicKey_Secp256K1")
+	json.RegisterType((*cryptoproto.PublicKey_Bls)(nil), "tendermint.crypto.PublicKey_Bls")
 }
 
 // PubKeyToProto takes crypto.PubKey and transforms it to a protobuf Pubkey
@@ -39,6 +41,12 @@ func PubKeyToProto(k crypto.PubKey) (cryptoproto.PublicKey, error) {
 				Sr25519: k,
 			},
 		}
+	case bls.PubKey:
+		kp = cryptoproto.PublicKey{
+			Sum: &cryptoproto.PublicKey_Bls{
+				Bls: k,
+			},
+		}
 	default:
 		return kp, fmt.Errorf("toproto: key type %v is not supported", k)
 	}
@@ -72,6 +80,14 @@ func PubKeyFromProto(k cryptoproto.PublicKey) (crypto.PubKey, error) {
 		pk := make(sr25519.PubKey, sr25519.PubKeySize)
 		copy(pk, k.Sr25519)
 		return pk, nil
+	case *cryptoproto.PublicKey_Bls:
+		if len(k.Bls) != bls.PubKeySize {
+			return nil, fmt.Errorf("invalid size for PubKeyBLS. Got %d, expected %d",
+				len(k.Bls), bls.PubKeySize)
+		}
+		pk := make(bls.PubKey, bls.PubKeySize)
+		copy(pk, k.Bls)
+		return pk, nil
 	default:
 		return nil, fmt.Errorf("fromproto: key type %v is not supported", k)
 	}
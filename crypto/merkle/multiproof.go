@@ -0,0 +1,183 @@
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// MultiProof represents a batched Merkle proof of several leaves of a tree.
+// The inner nodes shared by the paths of the leaves to the root are only
+// included once, and the ones computable from the leaves not at all, so that
+// it is smaller than the proofs of the leaves taken one by one.
+type MultiProof struct {
+	Total      int64    `json:"total"`       // Total number of items.
+	Indices    []int64  `json:"indices"`     // Indices of the items to prove, in increasing order.
+	LeafHashes [][]byte `json:"leaf_hashes"` // Hashes of the item values, in the order of the indices.
+	// Aunts are the roots of the subtrees holding none of the items to prove,
+	// in the order of a depth-first, left to right, traversal of the tree.
+	Aunts [][]byte `json:"aunts"`
+}
+
+// MultiProofFromByteSlices computes the multiproof of the items at the given
+// indices.
+func MultiProofFromByteSlices(items [][]byte, indices []int64) (rootHash []byte, proof *MultiProof, err error) {
+	total := int64(len(items))
+	if err := validateIndices(indices, total); err != nil {
+		return nil, nil, err
+	}
+
+	proof = &MultiProof{
+		Total:      total,
+		Indices:    indices,
+		LeafHashes: make([][]byte, len(indices)),
+	}
+	for i, index := range indices {
+		proof.LeafHashes[i] = leafHash(items[index])
+	}
+	rootHash = proof.collectAunts(items, 0, indices)
+	return rootHash, proof, nil
+}
+
+// collectAunts appends the aunts of the subtree of the items, starting at the
+// offset of the tree, holding the indices, and returns its root.
+func (mp *MultiProof) collectAunts(items [][]byte, offset int64, indices []int64) []byte {
+	if len(indices) == 0 {
+		root := HashFromByteSlices(items)
+		mp.Aunts = append(mp.Aunts, root)
+		return root
+	}
+	if len(items) == 1 {
+		return leafHash(items[0])
+	}
+	k := getSplitPoint(int64(len(items)))
+	split := sort.Search(len(indices), func(i int) bool { return indices[i] >= offset+k })
+	left := mp.collectAunts(items[:k], offset, indices[:split])
+	right := mp.collectAunts(items[k:], offset+k, indices[split:])
+	return innerHash(left, right)
+}
+
+// Verify that the MultiProof proves the leaves against the root hash, the
+// leaves being the items at the indices of the proof.
+func (mp *MultiProof) Verify(rootHash []byte, leaves [][]byte) error {
+	if err := mp.ValidateBasic(); err != nil {
+		return err
+	}
+	if len(leaves) != len(mp.Indices) {
+		return fmt.Errorf("expected %d leaves, got %d", len(mp.Indices), len(leaves))
+	}
+	for i, leaf := range leaves {
+		if leafHash := leafHash(leaf); !bytes.Equal(mp.LeafHashes[i], leafHash) {
+			return fmt.Errorf("invalid leaf hash #%d: wanted %X got %X", i, leafHash, mp.LeafHashes[i])
+		}
+	}
+	computedHash, err := mp.ComputeRootHash()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(computedHash, rootHash) {
+		return fmt.Errorf("invalid root hash: wanted %X got %X", rootHash, computedHash)
+	}
+	return nil
+}
+
+// ComputeRootHash computes the root hash from the leaf hashes and the aunts.
+// It does not verify the result.
+func (mp *MultiProof) ComputeRootHash() ([]byte, error) {
+	if err := validateIndices(mp.Indices, mp.Total); err != nil {
+		return nil, err
+	}
+	if len(mp.LeafHashes) != len(mp.Indices) {
+		return nil, fmt.Errorf("expected %d leaf hashes, got %d", len(mp.Indices), len(mp.LeafHashes))
+	}
+	aunts := mp.Aunts
+	root, err := computeHashFromMultiAunts(mp.Total, 0, mp.Indices, mp.LeafHashes, &aunts)
+	if err != nil {
+		return nil, err
+	}
+	if len(aunts) != 0 {
+		return nil, fmt.Errorf("%d aunts left over", len(aunts))
+	}
+	return root, nil
+}
+
+// ValidateBasic performs basic validation.
+// NOTE: it expects the leaf hashes and the aunts to be of size tmhash.Size.
+func (mp *MultiProof) ValidateBasic() error {
+	if mp.Total < 0 {
+		return errors.New("negative Total")
+	}
+	if err := validateIndices(mp.Indices, mp.Total); err != nil {
+		return err
+	}
+	if len(mp.LeafHashes) != len(mp.Indices) {
+		return fmt.Errorf("expected %d leaf hashes, got %d", len(mp.Indices), len(mp.LeafHashes))
+	}
+	for i, leafHash := range mp.LeafHashes {
+		if len(leafHash) != tmhash.Size {
+			return fmt.Errorf("expected LeafHashes#%d size to be %d, got %d", i, tmhash.Size, len(leafHash))
+		}
+	}
+	if max := int64(len(mp.Indices)) * MaxAunts; int64(len(mp.Aunts)) > max {
+		return fmt.Errorf("expected no more than %d aunts, got %d", max, len(mp.Aunts))
+	}
+	for i, auntHash := range mp.Aunts {
+		if len(auntHash) != tmhash.Size {
+			return fmt.Errorf("expected Aunts#%d size to be %d, got %d", i, tmhash.Size, len(auntHash))
+		}
+	}
+	return nil
+}
+
+// computeHashFromMultiAunts computes the root of the subtree of total items,
+// starting at the offset of the tree, from the leaf hashes of the indices it
+// holds, consuming the aunts of the subtrees holding none.
+func computeHashFromMultiAunts(
+	total, offset int64,
+	indices []int64,
+	leafHashes [][]byte,
+	aunts *[][]byte,
+) ([]byte, error) {
+	if len(indices) == 0 {
+		if len(*aunts) == 0 {
+			return nil, errors.New("missing aunts")
+		}
+		aunt := (*aunts)[0]
+		*aunts = (*aunts)[1:]
+		return aunt, nil
+	}
+	if total == 1 {
+		return leafHashes[0], nil
+	}
+	k := getSplitPoint(total)
+	split := sort.Search(len(indices), func(i int) bool { return indices[i] >= offset+k })
+	left, err := computeHashFromMultiAunts(k, offset, indices[:split], leafHashes[:split], aunts)
+	if err != nil {
+		return nil, err
+	}
+	right, err := computeHashFromMultiAunts(total-k, offset+k, indices[split:], leafHashes[split:], aunts)
+	if err != nil {
+		return nil, err
+	}
+	return innerHash(left, right), nil
+}
+
+// validateIndices checks that the indices are within total, and in strictly
+// increasing order.
+func validateIndices(indices []int64, total int64) error {
+	if len(indices) == 0 {
+		return errors.New("no indices to prove")
+	}
+	for i, index := range indices {
+		if index < 0 || index >= total {
+			return fmt.Errorf("index %d out of the %d items", index, total)
+		}
+		if i > 0 && index <= indices[i-1] {
+			return fmt.Errorf("indices must be in increasing order, got %d after %d", index, indices[i-1])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package merkle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiProof(t *testing.T) {
+	items := make([][]byte, 100)
+	for i := range items {
+		items[i] = []byte(fmt.Sprintf("item %d", i))
+	}
+	_, proofs := ProofsFromByteSlices(items)
+
+	testCases := []struct {
+		total   int
+		indices []int64
+	}{
+		{1, []int64{0}},
+		{2, []int64{1}},
+		{2, []int64{0, 1}},
+		{7, []int64{0, 3, 6}},
+		{100, []int64{42}},
+		{100, []int64{0, 1, 2, 3}},
+		{100, []int64{5, 50, 63, 64, 99}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("%d/%v", tc.total, tc.indices), func(t *testing.T) {
+			items := items[:tc.total]
+			root, proof, err := MultiProofFromByteSlices(items, tc.indices)
+			require.NoError(t, err)
+			assert.Equal(t, HashFromByteSlices(items), root)
+
+			leaves := make([][]byte, len(tc.indices))
+			for i, index := range tc.indices {
+				leaves[i] = items[index]
+			}
+			require.NoError(t, proof.Verify(root, leaves))
+
+			// the leaves must be the ones proven
+			leaves[0] = []byte("another item")
+			assert.Error(t, proof.Verify(root, leaves))
+			assert.Error(t, proof.Verify(root, leaves[1:]))
+
+			// and the aunts the ones of the tree
+			if len(proof.Aunts) > 0 {
+				proof.Aunts = proof.Aunts[1:]
+				assert.Error(t, proof.Verify(root, leaves))
+			}
+		})
+	}
+
+	// the inner nodes are shared
+	indices := []int64{0, 1, 2, 3}
+	_, proof, err := MultiProofFromByteSlices(items, indices)
+	require.NoError(t, err)
+	aunts := 0
+	for _, index := range indices {
+		aunts += len(proofs[index].Aunts)
+	}
+	assert.Less(t, len(proof.Aunts), aunts)
+
+	_, _, err = MultiProofFromByteSlices(items, []int64{3, 1})
+	assert.Error(t, err)
+	_, _, err = MultiProofFromByteSlices(items, []int64{100})
+	assert.Error(t, err)
+	_, _, err = MultiProofFromByteSlices(items, nil)
+	assert.Error(t, err)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/crypto/tmhash/simd"
 	ctest "github.com/tendermint/tendermint/internal/libs/test"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 )
@@ -136,6 +137,32 @@ func BenchmarkHashAlternatives(b *testing.B) {
 	})
 }
 
+func BenchmarkHashers(b *testing.B) {
+	// the shares of a big block
+	items := make([][]byte, 128*128)
+	for i := range items {
+		items[i] = tmrand.Bytes(256)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		hasher tmhash.Hasher
+	}{
+		{"std", nil},
+		{"simd", simd.Hasher{}},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			if tc.hasher != nil {
+				tmhash.SetHasher(tc.hasher)
+				defer tmhash.SetHasher(nil)
+			}
+			for i := 0; i < b.N; i++ {
+				_ = HashFromByteSlices(items)
+			}
+		})
+	}
+}
+
 func Test_getSplitPoint(t *testing.T) {
 	tests := []struct {
 		length int64
@@ -0,0 +1,70 @@
+package secp256k1
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier implements batch verification for secp256k1.
+//
+// ECDSA signatures can't be verified together, so the signatures of the batch
+// are verified one by one, spread over the CPUs.
+type BatchVerifier struct {
+	entries []batchEntry
+}
+
+type batchEntry struct {
+	key       PubKey
+	msg       []byte
+	signature []byte
+}
+
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{}
+}
+
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	pk, ok := key.(PubKey)
+	if !ok {
+		return fmt.Errorf("pubkey is not secp256k1")
+	}
+	if l := len(pk); l != PubKeySize {
+		return fmt.Errorf("pubkey size is incorrect; expected: %d, got %d", PubKeySize, l)
+	}
+
+	b.entries = append(b.entries, batchEntry{key: pk, msg: msg, signature: signature})
+	return nil
+}
+
+func (b *BatchVerifier) Verify() (bool, []bool) {
+	valid := make([]bool, len(b.entries))
+
+	workers := runtime.NumCPU()
+	if workers > len(b.entries) {
+		workers = len(b.entries)
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(b.entries); i += workers {
+				e := b.entries[i]
+				valid[i] = e.key.VerifySignature(e.msg, e.signature)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for _, ok := range valid {
+		if !ok {
+			return false, valid
+		}
+	}
+	return len(valid) > 0, valid
+}
@@ -2,6 +2,7 @@ package secp256k1_test
 
 import (
 	"encoding/hex"
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -112,3 +113,34 @@ func TestGenPrivKeySecp256k1(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchVerify(t *testing.T) {
+	v := secp256k1.NewBatchVerifier()
+	vFail := secp256k1.NewBatchVerifier()
+	for i := 0; i < 10; i++ {
+		priv := secp256k1.GenPrivKey()
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+
+		require.NoError(t, v.Add(priv.PubKey(), msg, sig))
+		if i == 7 {
+			msg = []byte("another message")
+		}
+		require.NoError(t, vFail.Add(priv.PubKey(), msg, sig))
+	}
+
+	ok, valid := v.Verify()
+	require.True(t, ok, "failed batch verification")
+	for i, ok := range valid {
+		require.Truef(t, ok, "sig[%d] should be marked valid", i)
+	}
+
+	ok, valid = vFail.Verify()
+	require.False(t, ok, "succeeded batch verification (invalid batch)")
+	for i, ok := range valid {
+		require.Equalf(t, i != 7, ok, "sig[%d] marked wrongly", i)
+	}
+
+	assert.Error(t, v.Add(secp256k1.PubKey{}, []byte("message"), nil))
+}
@@ -0,0 +1,108 @@
+package threshold
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/bn256"
+
+	"github.com/tendermint/tendermint/crypto/bls"
+)
+
+func dealBLS(threshold, n int) ([]KeyShare, error) {
+	// the secret is the constant term of a random polynomial of degree
+	// threshold-1, and the shares are its values at 1 to n
+	coeffs := make([]*big.Int, threshold)
+	for i := range coeffs {
+		c, err := rand.Int(rand.Reader, bn256.Order)
+		if err != nil {
+			return nil, err
+		}
+		coeffs[i] = c
+	}
+	if coeffs[0].Sign() == 0 {
+		coeffs[0].SetInt64(1)
+	}
+	pubKey := bls.PubKey(new(bn256.G2).ScalarBaseMult(coeffs[0]).Marshal())
+
+	shares := make([]KeyShare, n)
+	pubShares := make([][]byte, n)
+	for i := range shares {
+		x := big.NewInt(int64(i + 1))
+		y := new(big.Int)
+		for j := len(coeffs) - 1; j >= 0; j-- {
+			y.Mul(y, x)
+			y.Add(y, coeffs[j])
+			y.Mod(y, bn256.Order)
+		}
+		pubShares[i] = new(bn256.G2).ScalarBaseMult(y).Marshal()
+		shares[i] = KeyShare{
+			Index:     uint32(i + 1),
+			Secret:    y.FillBytes(make([]byte, bls.PrivKeySize)),
+			PubKey:    pubKey,
+			PubShares: pubShares,
+			Threshold: threshold,
+		}
+	}
+	return shares, nil
+}
+
+// verifyPartialBLS checks that the partial signature is the BLS signature of
+// msg by the public share.
+func verifyPartialBLS(pubShare []byte, msg []byte, partial []byte) error {
+	if !bls.PubKey(pubShare).VerifySignature(msg, partial) {
+		return errors.New("partial signature doesn't verify")
+	}
+	return nil
+}
+
+// aggregateBLS interpolates the partial signatures at 0: the signature is the
+// sum of the partial signatures times their Lagrange coefficients.
+func aggregateBLS(pubKey bls.PubKey, msg []byte, partials map[uint32][]byte) ([]byte, error) {
+	if len(partials) == 0 {
+		return nil, errors.New("no partial signatures")
+	}
+
+	var sig *bn256.G1
+	for index, partial := range partials {
+		if index == 0 {
+			return nil, errors.New("partial signature of share 0")
+		}
+		point, ok := new(bn256.G1).Unmarshal(partial)
+		if !ok {
+			return nil, fmt.Errorf("invalid partial signature of share %d", index)
+		}
+		term := new(bn256.G1).ScalarMult(point, lagrangeBLS(index, partials))
+		if sig == nil {
+			sig = term
+		} else {
+			sig.Add(sig, term)
+		}
+	}
+
+	sigBytes := sig.Marshal()
+	if !pubKey.VerifySignature(msg, sigBytes) {
+		return nil, errors.New("aggregated signature doesn't verify")
+	}
+	return sigBytes, nil
+}
+
+// lagrangeBLS returns the Lagrange coefficient at 0 of the share index among
+// the shares of the partial signatures.
+func lagrangeBLS(index uint32, partials map[uint32][]byte) *big.Int {
+	num, den := big.NewInt(1), big.NewInt(1)
+	x := big.NewInt(int64(index))
+	for other := range partials {
+		if other == index {
+			continue
+		}
+		xj := big.NewInt(int64(other))
+		num.Mul(num, xj)
+		num.Mod(num, bn256.Order)
+		den.Mul(den, new(big.Int).Sub(xj, x))
+		den.Mod(den, bn256.Order)
+	}
+	return num.Mul(num, den.ModInverse(den, bn256.Order)).Mod(num, bn256.Order)
+}
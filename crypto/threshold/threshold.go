@@ -0,0 +1,490 @@
+// Package threshold implements threshold signatures: any t of the n holders of
+// a share of a key jointly produce a signature which verifies as a plain
+// signature by the key. Two schemes are supported, by the type of the key:
+//
+// Ed25519 keys sign after the two-round FROST protocol. In the first round,
+// every signer of a session commits to a pair of fresh nonces. In the second
+// round, given the message and the commitments of all the signers of the
+// session, every signer returns a partial signature, which the coordinator
+// aggregates.
+//
+// BLS keys sign in a single round, without commitments: the partial
+// signatures are the BLS signatures of the message by the shares, which the
+// coordinator interpolates.
+//
+// The coordinator checks every partial signature against the public share of
+// its signer, so that the signers returning invalid ones can be left out.
+package threshold
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/oasisprotocol/curve25519-voi/curve"
+	"github.com/oasisprotocol/curve25519-voi/curve/scalar"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+const (
+	// ScalarSize is the size, in bytes, of a secret share and of a partial
+	// signature.
+	ScalarSize = scalar.ScalarSize
+
+	// PointSize is the size, in bytes, of a nonce commitment.
+	PointSize = curve.CompressedPointSize
+
+	bindingDomain = "tendermint/threshold/binding"
+)
+
+// KeyShare is the share of a threshold key held by a signer.
+type KeyShare struct {
+	// Index identifies the share, from 1 to the number of shares.
+	Index uint32 `json:"index"`
+	// Secret is the share of the secret scalar of the key.
+	Secret []byte `json:"secret"`
+	// PubKey is the key, which the aggregated signatures verify against:
+	// an ed25519.PubKey or a bls.PubKey.
+	PubKey crypto.PubKey `json:"pub_key"`
+	// PubShares are the public shares of all the shares, by index - 1, which
+	// the partial signatures verify against.
+	PubShares [][]byte `json:"pub_shares"`
+	// Threshold is the number of shares needed to sign.
+	Threshold int `json:"threshold"`
+}
+
+// Public returns the key share without its secret.
+func (ks KeyShare) Public() KeyShare {
+	ks.Secret = nil
+	return ks
+}
+
+// PubShare returns the public share of the given index, or nil if there is no
+// such share.
+func (ks KeyShare) PubShare(index uint32) []byte {
+	if index == 0 || int(index) > len(ks.PubShares) {
+		return nil
+	}
+	return ks.PubShares[index-1]
+}
+
+// Commitment is the commitment of the signer of a share to its nonces.
+type Commitment struct {
+	Index   uint32
+	Hiding  []byte
+	Binding []byte
+}
+
+// Nonces are the secret nonces of a signer in a session. They must be used
+// for a single partial signature.
+type Nonces struct {
+	index   uint32
+	hiding  *scalar.Scalar
+	binding *scalar.Scalar
+}
+
+// Deal generates a new key of the given type, ed25519.KeyType or bls.KeyType,
+// split into n shares of which threshold are needed to sign.
+func Deal(keyType string, threshold, n int) ([]KeyShare, error) {
+	if threshold < 1 || threshold > n {
+		return nil, fmt.Errorf("invalid threshold %d of %d shares", threshold, n)
+	}
+	switch keyType {
+	case ed25519.KeyType:
+		return dealEd25519(threshold, n)
+	case bls.KeyType:
+		return dealBLS(threshold, n)
+	default:
+		return nil, fmt.Errorf("threshold signing doesn't support %s keys", keyType)
+	}
+}
+
+// Interactive returns true if signing with the key takes a commitment round,
+// as Ed25519 keys do.
+func Interactive(pubKey crypto.PubKey) bool {
+	_, ok := pubKey.(ed25519.PubKey)
+	return ok
+}
+
+// VerifyPartial checks the partial signature of msg by the share of the given
+// index against its public share, in a session with the given commitments,
+// which BLS keys don't take.
+func VerifyPartial(
+	pubKey crypto.PubKey,
+	pubShare []byte,
+	msg []byte,
+	commitments []Commitment,
+	index uint32,
+	partial []byte,
+) error {
+	switch pubKey := pubKey.(type) {
+	case ed25519.PubKey:
+		return verifyPartialEd25519(pubKey, pubShare, msg, commitments, index, partial)
+	case bls.PubKey:
+		return verifyPartialBLS(pubShare, msg, partial)
+	default:
+		return fmt.Errorf("threshold signing doesn't support %T keys", pubKey)
+	}
+}
+
+// Aggregate returns the signature of msg by pubKey made of the partial
+// signatures, by share index, of a session with the given commitments, which
+// BLS keys don't take. It fails if the signature doesn't verify.
+func Aggregate(
+	pubKey crypto.PubKey,
+	msg []byte,
+	commitments []Commitment,
+	partials map[uint32][]byte,
+) ([]byte, error) {
+	switch pubKey := pubKey.(type) {
+	case ed25519.PubKey:
+		return aggregateEd25519(pubKey, msg, commitments, partials)
+	case bls.PubKey:
+		return aggregateBLS(pubKey, msg, partials)
+	default:
+		return nil, fmt.Errorf("threshold signing doesn't support %T keys", pubKey)
+	}
+}
+
+func dealEd25519(threshold, n int) ([]KeyShare, error) {
+	// the secret is the constant term of a random polynomial of degree
+	// threshold-1, and the shares are its values at 1 to n
+	coeffs := make([]*scalar.Scalar, threshold)
+	for i := range coeffs {
+		s, err := randomScalar(nil)
+		if err != nil {
+			return nil, err
+		}
+		coeffs[i] = s
+	}
+	pubKey := ed25519.PubKey(basepointMul(coeffs[0])[:])
+
+	shares := make([]KeyShare, n)
+	pubShares := make([][]byte, n)
+	for i := range shares {
+		x := scalar.NewFromUint64(uint64(i + 1))
+		y := scalar.New()
+		for j := len(coeffs) - 1; j >= 0; j-- {
+			y.Mul(y, x)
+			y.Add(y, coeffs[j])
+		}
+		secret := make([]byte, ScalarSize)
+		if err := y.ToBytes(secret); err != nil {
+			return nil, err
+		}
+		pubShares[i] = basepointMul(y)[:]
+		shares[i] = KeyShare{
+			Index:     uint32(i + 1),
+			Secret:    secret,
+			PubKey:    pubKey,
+			PubShares: pubShares,
+			Threshold: threshold,
+		}
+	}
+	return shares, nil
+}
+
+// Commit returns fresh nonces for a session, and the commitment to them. Only
+// Ed25519 keys take commitments.
+func (ks KeyShare) Commit() (*Nonces, Commitment, error) {
+	if _, ok := ks.PubKey.(ed25519.PubKey); !ok {
+		return nil, Commitment{}, fmt.Errorf("%T keys take no commitments", ks.PubKey)
+	}
+	// the nonces are hedged with the secret, against a weak source of
+	// randomness
+	hiding, err := randomScalar(ks.Secret)
+	if err != nil {
+		return nil, Commitment{}, err
+	}
+	binding, err := randomScalar(ks.Secret)
+	if err != nil {
+		return nil, Commitment{}, err
+	}
+	nonces := &Nonces{index: ks.Index, hiding: hiding, binding: binding}
+	return nonces, Commitment{
+		Index:   ks.Index,
+		Hiding:  basepointMul(hiding)[:],
+		Binding: basepointMul(binding)[:],
+	}, nil
+}
+
+// Sign returns the partial signature of msg with the nonces committed to, in
+// a session with the given commitments. BLS keys take neither nonces nor
+// commitments, and return the same partial signature of msg every time.
+func (ks KeyShare) Sign(nonces *Nonces, msg []byte, commitments []Commitment) ([]byte, error) {
+	switch pubKey := ks.PubKey.(type) {
+	case ed25519.PubKey:
+		return ks.signEd25519(pubKey, nonces, msg, commitments)
+	case bls.PubKey:
+		if nonces != nil || len(commitments) > 0 {
+			return nil, errors.New("BLS keys take neither nonces nor commitments")
+		}
+		return bls.PrivKey(ks.Secret).Sign(msg)
+	default:
+		return nil, fmt.Errorf("threshold signing doesn't support %T keys", ks.PubKey)
+	}
+}
+
+func (ks KeyShare) signEd25519(
+	pubKey ed25519.PubKey,
+	nonces *Nonces,
+	msg []byte,
+	commitments []Commitment,
+) ([]byte, error) {
+	if nonces == nil || nonces.index != ks.Index {
+		return nil, errors.New("nonces of another share")
+	}
+	secret, err := scalar.NewFromCanonicalBytes(ks.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret share: %w", err)
+	}
+	s, err := newSession(pubKey, msg, commitments, ks.Threshold)
+	if err != nil {
+		return nil, err
+	}
+	own, ok := s.commitments[ks.Index]
+	if !ok {
+		return nil, fmt.Errorf("no commitment of share %d", ks.Index)
+	}
+	if !equalPoint(own.hiding, basepointMul(nonces.hiding)) ||
+		!equalPoint(own.binding, basepointMul(nonces.binding)) {
+		return nil, errors.New("commitment doesn't match the nonces")
+	}
+
+	// z = hiding + binding * rho + lambda * secret * c
+	z := scalar.New().Mul(nonces.binding, s.bindingFactors[ks.Index])
+	z.Add(z, nonces.hiding)
+	lc := scalar.New().Mul(s.lagrange(ks.Index), secret)
+	lc.Mul(lc, s.challenge)
+	z.Add(z, lc)
+
+	partial := make([]byte, ScalarSize)
+	if err := z.ToBytes(partial); err != nil {
+		return nil, err
+	}
+	return partial, nil
+}
+
+// verifyPartialEd25519 checks that z·B = D + ρ·E + λ·c·Y, with z the partial
+// signature, D and E the commitments of the signer, ρ its binding factor, λ
+// its Lagrange coefficient, c the challenge and Y its public share.
+func verifyPartialEd25519(
+	pubKey ed25519.PubKey,
+	pubShare []byte,
+	msg []byte,
+	commitments []Commitment,
+	index uint32,
+	partial []byte,
+) error {
+	s, err := newSession(pubKey, msg, commitments, 0)
+	if err != nil {
+		return err
+	}
+	c, ok := s.commitments[index]
+	if !ok {
+		return fmt.Errorf("partial signature of share %d without a commitment", index)
+	}
+	y, err := decodePoint(pubShare)
+	if err != nil {
+		return fmt.Errorf("invalid public share %d: %w", index, err)
+	}
+	z, err := scalar.NewFromCanonicalBytes(partial)
+	if err != nil {
+		return fmt.Errorf("invalid partial signature of share %d: %w", index, err)
+	}
+
+	expected := curve.NewEdwardsPoint().Mul(c.binding, s.bindingFactors[index])
+	expected.Add(expected, c.hiding)
+	lc := scalar.New().Mul(s.lagrange(index), s.challenge)
+	expected.Add(expected, curve.NewEdwardsPoint().Mul(y, lc))
+	if !equalPoint(expected, basepointMul(z)) {
+		return fmt.Errorf("partial signature of share %d doesn't verify", index)
+	}
+	return nil
+}
+
+func aggregateEd25519(
+	pubKey ed25519.PubKey,
+	msg []byte,
+	commitments []Commitment,
+	partials map[uint32][]byte,
+) ([]byte, error) {
+	s, err := newSession(pubKey, msg, commitments, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(partials) != len(s.commitments) {
+		return nil, fmt.Errorf("got %d partial signatures for %d commitments", len(partials), len(s.commitments))
+	}
+
+	z := scalar.New()
+	for index, partial := range partials {
+		if _, ok := s.commitments[index]; !ok {
+			return nil, fmt.Errorf("partial signature of share %d without a commitment", index)
+		}
+		zi, err := scalar.NewFromCanonicalBytes(partial)
+		if err != nil {
+			return nil, fmt.Errorf("invalid partial signature of share %d: %w", index, err)
+		}
+		z.Add(z, zi)
+	}
+
+	sig := make([]byte, ed25519.SignatureSize)
+	copy(sig, s.commitment[:])
+	if err := z.ToBytes(sig[PointSize:]); err != nil {
+		return nil, err
+	}
+	if !pubKey.VerifySignature(msg, sig) {
+		return nil, errors.New("aggregated signature doesn't verify")
+	}
+	return sig, nil
+}
+
+type point struct {
+	hiding, binding *curve.EdwardsPoint
+}
+
+// session holds the values derived from the message and the commitments of
+// a session, which the signers and the coordinator agree on.
+type session struct {
+	indices        []uint32
+	commitments    map[uint32]point
+	bindingFactors map[uint32]*scalar.Scalar
+	// the group commitment R
+	commitment *curve.CompressedEdwardsY
+	// the Ed25519 challenge H(R || A || msg)
+	challenge *scalar.Scalar
+}
+
+func newSession(pubKey ed25519.PubKey, msg []byte, commitments []Commitment, threshold int) (*session, error) {
+	if len(pubKey) != ed25519.PubKeySize {
+		return nil, errors.New("invalid public key")
+	}
+	if len(commitments) == 0 || len(commitments) < threshold {
+		return nil, fmt.Errorf("got %d commitments, need %d", len(commitments), threshold)
+	}
+
+	s := &session{
+		commitments:    make(map[uint32]point, len(commitments)),
+		bindingFactors: make(map[uint32]*scalar.Scalar, len(commitments)),
+	}
+	sorted := make([]Commitment, len(commitments))
+	copy(sorted, commitments)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Index < sorted[j].Index })
+
+	// the binding factors commit to the message and all the commitments
+	encoded := sha512.New()
+	for _, c := range sorted {
+		if c.Index == 0 {
+			return nil, errors.New("commitment of share 0")
+		}
+		if _, ok := s.commitments[c.Index]; ok {
+			return nil, fmt.Errorf("duplicate commitment of share %d", c.Index)
+		}
+		hiding, err := decodePoint(c.Hiding)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hiding commitment of share %d: %w", c.Index, err)
+		}
+		binding, err := decodePoint(c.Binding)
+		if err != nil {
+			return nil, fmt.Errorf("invalid binding commitment of share %d: %w", c.Index, err)
+		}
+		s.indices = append(s.indices, c.Index)
+		s.commitments[c.Index] = point{hiding: hiding, binding: binding}
+
+		var index [4]byte
+		binary.BigEndian.PutUint32(index[:], c.Index)
+		encoded.Write(index[:])
+		encoded.Write(c.Hiding)
+		encoded.Write(c.Binding)
+	}
+	msgHash := sha512.Sum512(msg)
+	commitmentsHash := encoded.Sum(nil)
+
+	r := curve.NewEdwardsPoint().Identity()
+	for _, index := range s.indices {
+		var indexBytes [4]byte
+		binary.BigEndian.PutUint32(indexBytes[:], index)
+		rho, err := hashToScalar([]byte(bindingDomain), pubKey, msgHash[:], commitmentsHash, indexBytes[:])
+		if err != nil {
+			return nil, err
+		}
+		s.bindingFactors[index] = rho
+
+		c := s.commitments[index]
+		term := curve.NewEdwardsPoint().Mul(c.binding, rho)
+		term.Add(term, c.hiding)
+		r.Add(r, term)
+	}
+	s.commitment = curve.NewCompressedEdwardsY().SetEdwardsPoint(r)
+
+	challenge, err := hashToScalar(s.commitment[:], pubKey, msg)
+	if err != nil {
+		return nil, err
+	}
+	s.challenge = challenge
+	return s, nil
+}
+
+// lagrange returns the Lagrange coefficient at 0 of the share index among the
+// shares of the session.
+func (s *session) lagrange(index uint32) *scalar.Scalar {
+	num, den := scalar.One(), scalar.One()
+	x := scalar.NewFromUint64(uint64(index))
+	for _, other := range s.indices {
+		if other == index {
+			continue
+		}
+		xj := scalar.NewFromUint64(uint64(other))
+		num.Mul(num, xj)
+		den.Mul(den, scalar.New().Sub(xj, x))
+	}
+	return num.Mul(num, scalar.New().Invert(den))
+}
+
+func basepointMul(s *scalar.Scalar) *curve.CompressedEdwardsY {
+	p := curve.NewEdwardsPoint().MulBasepoint(curve.ED25519_BASEPOINT_TABLE, s)
+	return curve.NewCompressedEdwardsY().SetEdwardsPoint(p)
+}
+
+func decodePoint(bz []byte) (*curve.EdwardsPoint, error) {
+	compressed, err := curve.NewCompressedEdwardsYFromBytes(bz)
+	if err != nil {
+		return nil, err
+	}
+	p, err := curve.NewEdwardsPoint().SetCompressedY(compressed)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsSmallOrder() {
+		return nil, errors.New("small order point")
+	}
+	return p, nil
+}
+
+func equalPoint(p *curve.EdwardsPoint, compressed *curve.CompressedEdwardsY) bool {
+	return curve.NewCompressedEdwardsY().SetEdwardsPoint(p).Equal(compressed) == 1
+}
+
+func hashToScalar(parts ...[]byte) (*scalar.Scalar, error) {
+	h := sha512.New()
+	for _, part := range parts {
+		h.Write(part)
+	}
+	return scalar.NewFromBytesModOrderWide(h.Sum(nil))
+}
+
+// randomScalar returns a random scalar, hedged with the given secret.
+func randomScalar(secret []byte) (*scalar.Scalar, error) {
+	var random [32]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		return nil, err
+	}
+	return hashToScalar(random[:], secret)
+}
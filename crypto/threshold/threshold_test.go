@@ -0,0 +1,99 @@
+package threshold
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/bls"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func sign(t *testing.T, shares []KeyShare, msg []byte) ([]Commitment, map[uint32][]byte) {
+	if _, ok := shares[0].PubKey.(bls.PubKey); ok {
+		partials := make(map[uint32][]byte, len(shares))
+		for _, share := range shares {
+			partial, err := share.Sign(nil, msg, nil)
+			require.NoError(t, err)
+			partials[share.Index] = partial
+		}
+		return nil, partials
+	}
+
+	nonces := make([]*Nonces, len(shares))
+	commitments := make([]Commitment, len(shares))
+	for i, share := range shares {
+		var err error
+		nonces[i], commitments[i], err = share.Commit()
+		require.NoError(t, err)
+	}
+	partials := make(map[uint32][]byte, len(shares))
+	for i, share := range shares {
+		partial, err := share.Sign(nonces[i], msg, commitments)
+		require.NoError(t, err)
+		partials[share.Index] = partial
+	}
+	return commitments, partials
+}
+
+func TestThresholdSignature(t *testing.T) {
+	for _, keyType := range []string{ed25519.KeyType, bls.KeyType} {
+		t.Run(keyType, func(t *testing.T) {
+			shares, err := Deal(keyType, 3, 5)
+			require.NoError(t, err)
+			pubKey := shares[0].PubKey
+			msg := []byte("sign me")
+
+			// any 3 of the 5 shares sign, in any order
+			for _, signers := range [][]KeyShare{
+				{shares[0], shares[1], shares[2]},
+				{shares[4], shares[1], shares[3]},
+				shares,
+			} {
+				commitments, partials := sign(t, signers, msg)
+				for index, partial := range partials {
+					require.NoError(t, VerifyPartial(pubKey, shares[0].PubShare(index), msg,
+						commitments, index, partial))
+				}
+				sig, err := Aggregate(pubKey, msg, commitments, partials)
+				require.NoError(t, err)
+				require.True(t, pubKey.VerifySignature(msg, sig))
+			}
+
+			// 2 shares can't sign
+			commitments, partials := sign(t, shares[:2], msg)
+			_, err = Aggregate(pubKey, msg, commitments, partials)
+			require.Error(t, err)
+
+			// nor can shares sign different messages, which their public
+			// shares tell
+			commitments, partials = sign(t, shares[:3], msg)
+			_, forged := sign(t, shares[:3], []byte("other"))
+			partials[1] = forged[1]
+			require.Error(t, VerifyPartial(pubKey, shares[0].PubShare(1), msg, commitments, 1, partials[1]))
+			require.NoError(t, VerifyPartial(pubKey, shares[0].PubShare(2), msg, commitments, 2, partials[2]))
+			_, err = Aggregate(pubKey, msg, commitments, partials)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestThresholdSignatureEd25519Session(t *testing.T) {
+	shares, err := Deal(ed25519.KeyType, 3, 5)
+	require.NoError(t, err)
+	msg := []byte("sign me")
+
+	// a session needs the commitments of threshold shares
+	nonces, commitment, err := shares[0].Commit()
+	require.NoError(t, err)
+	_, other, err := shares[1].Commit()
+	require.NoError(t, err)
+	_, err = shares[0].Sign(nonces, msg, []Commitment{commitment, other})
+	require.Error(t, err)
+
+	// BLS keys take no commitments
+	blsShares, err := Deal(bls.KeyType, 2, 3)
+	require.NoError(t, err)
+	_, _, err = blsShares[0].Commit()
+	require.Error(t, err)
+}
@@ -10,17 +10,47 @@ const (
 	BlockSize = sha256.BlockSize
 )
 
+// Hasher computes the SHA256 hashes, of the merkle trees and the txs among
+// others. It is crypto/sha256 by default, and can be replaced by an
+// accelerated implementation with SetHasher.
+type Hasher interface {
+	New() hash.Hash
+	Sum256(bz []byte) [Size]byte
+}
+
+type stdHasher struct{}
+
+func (stdHasher) New() hash.Hash              { return sha256.New() }
+func (stdHasher) Sum256(bz []byte) [Size]byte { return sha256.Sum256(bz) }
+
+var hasher Hasher = stdHasher{}
+
+// SetHasher replaces the implementation of the SHA256 hashes, nil restoring
+// crypto/sha256. It must be called before any hashing, as it is not safe for
+// concurrent use.
+func SetHasher(h Hasher) {
+	if h == nil {
+		h = stdHasher{}
+	}
+	hasher = h
+}
+
 // New returns a new hash.Hash.
 func New() hash.Hash {
-	return sha256.New()
+	return hasher.New()
 }
 
 // Sum returns the SHA256 of the bz.
 func Sum(bz []byte) []byte {
-	h := sha256.Sum256(bz)
+	h := hasher.Sum256(bz)
 	return h[:]
 }
 
+// Sum256 returns the SHA256 of the bz, as an array.
+func Sum256(bz []byte) [Size]byte {
+	return hasher.Sum256(bz)
+}
+
 //-------------------------------------------------------------
 
 const (
@@ -54,12 +84,12 @@ func (h sha256trunc) BlockSize() int {
 // NewTruncated returns a new hash.Hash.
 func NewTruncated() hash.Hash {
 	return sha256trunc{
-		sha256: sha256.New(),
+		sha256: hasher.New(),
 	}
 }
 
 // SumTruncated returns the first 20 bytes of SHA256 of the bz.
 func SumTruncated(bz []byte) []byte {
-	hash := sha256.Sum256(bz)
+	hash := hasher.Sum256(bz)
 	return hash[:TruncatedSize]
 }
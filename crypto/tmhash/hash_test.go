@@ -2,6 +2,7 @@ package tmhash_test
 
 import (
 	"crypto/sha256"
+	"hash"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -46,3 +47,29 @@ func TestHashTruncated(t *testing.T) {
 	assert.Equal(t, bz, bz2)
 	assert.Equal(t, bz, bz3)
 }
+
+type countingHasher struct {
+	sums int
+}
+
+func (h *countingHasher) New() hash.Hash { return sha256.New() }
+
+func (h *countingHasher) Sum256(bz []byte) [tmhash.Size]byte {
+	h.sums++
+	return sha256.Sum256(bz)
+}
+
+func TestSetHasher(t *testing.T) {
+	h := &countingHasher{}
+	tmhash.SetHasher(h)
+	defer tmhash.SetHasher(nil)
+
+	want := sha256.Sum256([]byte("abc"))
+	assert.Equal(t, want[:], tmhash.Sum([]byte("abc")))
+	assert.Equal(t, want[:tmhash.TruncatedSize], tmhash.SumTruncated([]byte("abc")))
+	assert.Equal(t, 2, h.sums)
+
+	tmhash.SetHasher(nil)
+	assert.Equal(t, want[:], tmhash.Sum([]byte("abc")))
+	assert.Equal(t, 2, h.sums)
+}
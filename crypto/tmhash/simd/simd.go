@@ -0,0 +1,26 @@
+// Package simd implements a tmhash.Hasher accelerated with the SHA extensions
+// (SHA-NI), AVX512 or AVX2 instructions of the CPU, see
+// github.com/minio/sha256-simd. It falls back to crypto/sha256 when the CPU
+// has none of them.
+package simd
+
+import (
+	"hash"
+
+	sha256 "github.com/minio/sha256-simd"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+var _ tmhash.Hasher = Hasher{}
+
+// Hasher is the accelerated tmhash.Hasher.
+type Hasher struct{}
+
+func (Hasher) New() hash.Hash {
+	return sha256.New()
+}
+
+func (Hasher) Sum256(bz []byte) [tmhash.Size]byte {
+	return sha256.Sum256(bz)
+}
@@ -0,0 +1,44 @@
+package simd_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto/tmhash/simd"
+)
+
+func TestHasher(t *testing.T) {
+	for _, size := range []int{0, 3, 64, 1000, 1 << 20} {
+		bz := make([]byte, size)
+		for i := range bz {
+			bz[i] = byte(i)
+		}
+
+		want := sha256.Sum256(bz)
+		assert.Equal(t, want, simd.Hasher{}.Sum256(bz))
+
+		h := simd.Hasher{}.New()
+		_, _ = h.Write(bz)
+		assert.Equal(t, want[:], h.Sum(nil))
+	}
+}
+
+func BenchmarkSum256(b *testing.B) {
+	for _, size := range []int{32, 1024, 64 * 1024} {
+		bz := make([]byte, size)
+		b.Run(fmt.Sprintf("std/%d", size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				sha256.Sum256(bz)
+			}
+		})
+		b.Run(fmt.Sprintf("simd/%d", size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				simd.Hasher{}.Sum256(bz)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package blocksync
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tendermint/tendermint/internal/libs/protoio"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// MaxArchiveBlockSize is the largest encoded block accepted from an archive.
+const MaxArchiveBlockSize = types.MaxBlockSizeBytes + 1024
+
+// An archive is a set of files holding varint length-delimited
+// tendermint.types.Block protobuf messages, in ascending height order. The
+// files are either stored in a directory, in which case they are read in
+// lexical order of their names, or in a tar file (optionally gzip compressed),
+// in which case they are read in the order they appear in the archive.
+
+// ArchiveReader reads blocks from an archive.
+type ArchiveReader struct {
+	// directory archives
+	files []string
+
+	// tar archives
+	tr *tar.Reader
+
+	closers []io.Closer
+	current protoio.Reader
+}
+
+// OpenArchive opens the archive at path, which must be a directory, a .tar
+// file or a .tar.gz/.tgz file. The caller must close the returned reader.
+func OpenArchive(path string) (*ArchiveReader, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		files := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			if entry.Type().IsRegular() {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+		sort.Strings(files)
+		return &ArchiveReader{files: files}, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	ar := &ArchiveReader{closers: []io.Closer{f}}
+
+	var r io.Reader = bufio.NewReader(f)
+	switch {
+	case strings.HasSuffix(path, ".tar.gz"), strings.HasSuffix(path, ".tgz"):
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("reading gzip archive %q: %w", path, err)
+		}
+		ar.closers = append(ar.closers, gz)
+		r = gz
+	case strings.HasSuffix(path, ".tar"):
+	default:
+		f.Close()
+		return nil, fmt.Errorf("unsupported block archive %q: expected a directory, .tar or .tar.gz file", path)
+	}
+	ar.tr = tar.NewReader(r)
+	return ar, nil
+}
+
+// Next returns the next block in the archive, or io.EOF once all blocks have
+// been read.
+func (ar *ArchiveReader) Next() (*types.Block, error) {
+	for {
+		if ar.current == nil {
+			if err := ar.nextFile(); err != nil {
+				return nil, err
+			}
+		}
+
+		var pb tmproto.Block
+		_, err := ar.current.ReadMsg(&pb)
+		if errors.Is(err, io.EOF) {
+			ar.current = nil
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading block from archive: %w", err)
+		}
+		return types.BlockFromProto(&pb)
+	}
+}
+
+// nextFile advances to the next file of the archive.
+func (ar *ArchiveReader) nextFile() error {
+	if ar.tr != nil {
+		for {
+			hdr, err := ar.tr.Next()
+			if err != nil {
+				return err
+			}
+			if hdr.Typeflag == tar.TypeReg {
+				ar.current = protoio.NewDelimitedReader(bufio.NewReader(ar.tr), MaxArchiveBlockSize)
+				return nil
+			}
+		}
+	}
+
+	if len(ar.files) == 0 {
+		return io.EOF
+	}
+	f, err := os.Open(ar.files[0])
+	if err != nil {
+		return err
+	}
+	ar.files = ar.files[1:]
+	ar.closers = append(ar.closers, f)
+	ar.current = protoio.NewDelimitedReader(bufio.NewReader(f), MaxArchiveBlockSize)
+	return nil
+}
+
+// Close closes all files opened by the reader.
+func (ar *ArchiveReader) Close() error {
+	var err error
+	for i := len(ar.closers) - 1; i >= 0; i-- {
+		if cerr := ar.closers[i].Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	ar.closers = nil
+	return err
+}
+
+// ArchiveWriter writes blocks to a single archive file.
+type ArchiveWriter struct {
+	w protoio.Writer
+}
+
+// NewArchiveWriter returns a writer appending blocks to w.
+func NewArchiveWriter(w io.Writer) *ArchiveWriter {
+	return &ArchiveWriter{w: protoio.NewDelimitedWriter(w)}
+}
+
+// WriteBlock appends block to the archive. Blocks must be written in
+// ascending height order.
+func (aw *ArchiveWriter) WriteBlock(block *types.Block) error {
+	pb, err := block.ToProto()
+	if err != nil {
+		return err
+	}
+	_, err = aw.w.WriteMsg(pb)
+	return err
+}
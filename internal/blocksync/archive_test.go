@@ -0,0 +1,115 @@
+package blocksync
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/types"
+)
+
+func makeArchiveBlocks(from, to int64) []*types.Block {
+	blocks := make([]*types.Block, 0, to-from+1)
+	for h := from; h <= to; h++ {
+		lastCommit := types.NewCommit(h-1, 0, types.BlockID{}, nil)
+		if h > 1 {
+			lastCommit = types.NewCommit(h-1, 0, factory.MakeBlockID(),
+				[]types.CommitSig{types.NewCommitSigAbsent()})
+		}
+		block := types.MakeBlock(h, []types.Tx{types.Tx("tx")}, nil, nil, nil, lastCommit)
+		block.ProposerAddress = tmrand.Bytes(crypto.AddressSize)
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func encodeArchiveBlocks(t *testing.T, blocks []*types.Block) []byte {
+	var buf bytes.Buffer
+	w := NewArchiveWriter(&buf)
+	for _, block := range blocks {
+		require.NoError(t, w.WriteBlock(block))
+	}
+	return buf.Bytes()
+}
+
+func readArchiveHeights(t *testing.T, path string) []int64 {
+	ar, err := OpenArchive(path)
+	require.NoError(t, err)
+	defer ar.Close()
+
+	var heights []int64
+	for {
+		block, err := ar.Next()
+		if err == io.EOF {
+			return heights
+		}
+		require.NoError(t, err)
+		heights = append(heights, block.Height)
+	}
+}
+
+func TestArchiveDirectory(t *testing.T) {
+	dir := t.TempDir()
+	// Files are read in lexical order, regardless of creation order.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b"), encodeArchiveBlocks(t, makeArchiveBlocks(4, 5)), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), encodeArchiveBlocks(t, makeArchiveBlocks(1, 3)), 0600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "c"), 0700))
+
+	require.Equal(t, []int64{1, 2, 3, 4, 5}, readArchiveHeights(t, dir))
+}
+
+func TestArchiveTar(t *testing.T) {
+	writeTar := func(w io.Writer) {
+		tw := tar.NewWriter(w)
+		for i, blocks := range [][]*types.Block{makeArchiveBlocks(1, 2), makeArchiveBlocks(3, 4)} {
+			data := encodeArchiveBlocks(t, blocks)
+			require.NoError(t, tw.WriteHeader(&tar.Header{
+				Name:     string(rune('a' + i)),
+				Mode:     0600,
+				Size:     int64(len(data)),
+				Typeflag: tar.TypeReg,
+			}))
+			_, err := tw.Write(data)
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+	}
+
+	dir := t.TempDir()
+
+	plain := filepath.Join(dir, "blocks.tar")
+	f, err := os.Create(plain)
+	require.NoError(t, err)
+	writeTar(f)
+	require.NoError(t, f.Close())
+	require.Equal(t, []int64{1, 2, 3, 4}, readArchiveHeights(t, plain))
+
+	compressed := filepath.Join(dir, "blocks.tar.gz")
+	f, err = os.Create(compressed)
+	require.NoError(t, err)
+	gz := gzip.NewWriter(f)
+	writeTar(gz)
+	require.NoError(t, gz.Close())
+	require.NoError(t, f.Close())
+	require.Equal(t, []int64{1, 2, 3, 4}, readArchiveHeights(t, compressed))
+}
+
+func TestArchiveUnsupported(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocks.zip")
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+
+	_, err := OpenArchive(path)
+	require.Error(t, err)
+
+	_, err = OpenArchive(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
@@ -24,6 +24,12 @@ eg, L = latency = 0.1s
 	B/S = CB/P/BS = 12.8 blocks/s
 
 	12.8 * 0.1 = 1.28 blocks on conn
+
+Each peer is given a window of requests that may be in flight at once. The
+window is sized to windowGain times the peer's bandwidth-delay product, i.e.
+the measured block throughput multiplied by the lowest observed request
+latency, so that fast peers on high latency links are kept busy while slow
+peers aren't flooded with requests they can't serve in time.
 */
 
 const (
@@ -31,7 +37,17 @@ const (
 	maxTotalRequesters        = 600
 	maxPeerErrBuffer          = 1000
 	maxPendingRequests        = maxTotalRequesters
-	maxPendingRequestsPerPeer = 20
+	minPendingRequestsPerPeer = 1
+	maxPendingRequestsPerPeer = 100
+
+	// Number of requests a peer may have in flight before we have any
+	// throughput measurements for it.
+	initialPendingRequestsPerPeer = 10
+
+	// Multiple of the estimated bandwidth-delay product used as a peer's
+	// window. A gain above 1 lets the window grow while the peer is still
+	// limited by the window rather than by its bandwidth.
+	windowGain = 2
 
 	// Minimum recv rate to ensure we're receiving blocks from a peer fast
 	// enough. If a peer is not sending us data at at least that rate, we
@@ -281,7 +297,7 @@ func (pool *BlockPool) AddBlock(peerID types.NodeID, block *types.Block, blockSi
 		atomic.AddInt32(&pool.numPending, -1)
 		peer := pool.peers[peerID]
 		if peer != nil {
-			peer.decrPending(blockSize)
+			peer.decrPending(blockSize, requester.latency())
 		}
 	} else {
 		err := errors.New("requester is different or block already exists")
@@ -368,27 +384,34 @@ func (pool *BlockPool) updateMaxPeerHeight() {
 	pool.maxPeerHeight = max
 }
 
-// Pick an available peer with the given height available.
-// If no peers are available, returns nil.
+// Pick an available peer with the given height available. Among the
+// candidates, the peer with the most unused room in its request window is
+// chosen, which spreads requests across peers in proportion to their
+// throughput. If no peers are available, returns nil.
 func (pool *BlockPool) pickIncrAvailablePeer(height int64) *bpPeer {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
+	var best *bpPeer
 	for _, peer := range pool.peers {
 		if peer.didTimeout {
 			pool.removePeer(peer.id)
 			continue
 		}
-		if peer.numPending >= maxPendingRequestsPerPeer {
+		if peer.numPending >= peer.window {
 			continue
 		}
 		if height < peer.base || height > peer.height {
 			continue
 		}
-		peer.incrPending()
-		return peer
+		if best == nil || peer.window-peer.numPending > best.window-best.numPending {
+			best = peer
+		}
 	}
-	return nil
+	if best != nil {
+		best.incrPending()
+	}
+	return best
 }
 
 func (pool *BlockPool) makeNextRequester() {
@@ -467,12 +490,17 @@ func (pool *BlockPool) getLastSyncRate() float64 {
 type bpPeer struct {
 	didTimeout  bool
 	numPending  int32
+	window      int32 // max number of requests in flight
 	height      int64
 	base        int64
 	pool        *BlockPool
 	id          types.NodeID
 	recvMonitor *flow.Monitor
 
+	// used to size the window, see updateWindow
+	minLatency   time.Duration
+	avgBlockSize float64
+
 	timeout *time.Timer
 
 	logger log.Logger
@@ -485,6 +513,7 @@ func newBPPeer(pool *BlockPool, peerID types.NodeID, base int64, height int64) *
 		base:       base,
 		height:     height,
 		numPending: 0,
+		window:     initialPendingRequestsPerPeer,
 		logger:     log.NewNopLogger(),
 	}
 	return peer
@@ -516,16 +545,53 @@ func (peer *bpPeer) incrPending() {
 	peer.numPending++
 }
 
-func (peer *bpPeer) decrPending(recvSize int) {
+func (peer *bpPeer) decrPending(recvSize int, latency time.Duration) {
 	peer.numPending--
+	peer.recvMonitor.Update(recvSize)
+	peer.updateWindow(recvSize, latency)
 	if peer.numPending == 0 {
 		peer.timeout.Stop()
 	} else {
-		peer.recvMonitor.Update(recvSize)
 		peer.resetTimeout()
 	}
 }
 
+// updateWindow records a block of recvSize bytes received latency after it
+// was requested, and resizes the peer's window to windowGain times the
+// estimated number of blocks that fit on the connection.
+func (peer *bpPeer) updateWindow(recvSize int, latency time.Duration) {
+	if latency > 0 && (peer.minLatency == 0 || latency < peer.minLatency) {
+		peer.minLatency = latency
+	}
+	if peer.avgBlockSize == 0 {
+		peer.avgBlockSize = float64(recvSize)
+	} else {
+		peer.avgBlockSize = 0.9*peer.avgBlockSize + 0.1*float64(recvSize)
+	}
+
+	rate := peer.recvMonitor.Status().CurRate
+	if rate == 0 || peer.avgBlockSize == 0 || peer.minLatency == 0 {
+		return
+	}
+	peer.window = windowSize(rate, peer.avgBlockSize, peer.minLatency)
+}
+
+// windowSize returns the number of requests to keep in flight to a peer
+// delivering rate bytes per second in blocks of blockSize bytes, with a round
+// trip latency of latency.
+func windowSize(rate int64, blockSize float64, latency time.Duration) int32 {
+	blocksOnConn := float64(rate) / blockSize * latency.Seconds()
+	window := math.Ceil(windowGain * blocksOnConn)
+	switch {
+	case window < minPendingRequestsPerPeer:
+		return minPendingRequestsPerPeer
+	case window > maxPendingRequestsPerPeer:
+		return maxPendingRequestsPerPeer
+	default:
+		return int32(window)
+	}
+}
+
 func (peer *bpPeer) onTimeout() {
 	peer.pool.mtx.Lock()
 	defer peer.pool.mtx.Unlock()
@@ -545,9 +611,10 @@ type bpRequester struct {
 	gotBlockCh chan struct{}
 	redoCh     chan types.NodeID // redo may send multitime, add peerId to identify repeat
 
-	mtx    tmsync.Mutex
-	peerID types.NodeID
-	block  *types.Block
+	mtx         tmsync.Mutex
+	peerID      types.NodeID
+	block       *types.Block
+	requestedAt time.Time
 }
 
 func newBPRequester(pool *BlockPool, height int64) *bpRequester {
@@ -598,6 +665,14 @@ func (bpr *bpRequester) getPeerID() types.NodeID {
 	return bpr.peerID
 }
 
+// latency returns the time elapsed since the block was requested from the
+// current peer.
+func (bpr *bpRequester) latency() time.Duration {
+	bpr.mtx.Lock()
+	defer bpr.mtx.Unlock()
+	return time.Since(bpr.requestedAt)
+}
+
 // This is called from the requestRoutine, upon redo().
 func (bpr *bpRequester) reset() {
 	bpr.mtx.Lock()
@@ -642,6 +717,7 @@ OUTER_LOOP:
 		}
 		bpr.mtx.Lock()
 		bpr.peerID = peer.id
+		bpr.requestedAt = time.Now()
 		bpr.mtx.Unlock()
 
 		// Send request and wait.
@@ -240,3 +240,52 @@ func TestBlockPoolRemovePeer(t *testing.T) {
 
 	assert.EqualValues(t, 0, pool.MaxPeerHeight())
 }
+
+func TestBlockPoolWindowSize(t *testing.T) {
+	testCases := []struct {
+		name      string
+		rate      int64
+		blockSize float64
+		latency   time.Duration
+		expected  int32
+	}{
+		{"one block on conn", 10240, 1024, 100 * time.Millisecond, 2},
+		{"high latency link", 1024000, 1024, 500 * time.Millisecond, maxPendingRequestsPerPeer},
+		{"slow peer", 1024, 10240, 50 * time.Millisecond, minPendingRequestsPerPeer},
+		{"partial block rounds up", 15360, 1024, 100 * time.Millisecond, 3},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, windowSize(tc.rate, tc.blockSize, tc.latency))
+		})
+	}
+}
+
+func TestBlockPoolPicksPeerWithMostRoom(t *testing.T) {
+	pool := NewBlockPool(1, make(chan BlockRequest), make(chan peerError))
+	pool.SetLogger(log.TestingLogger())
+
+	pool.SetPeerRange("slow", 1, 100)
+	pool.SetPeerRange("fast", 1, 100)
+	pool.peers["slow"].window = 2
+	pool.peers["fast"].window = 8
+
+	// The fast peer has the larger window, so it should receive requests
+	// until its remaining room drops to that of the slow peer.
+	picked := map[types.NodeID]int{}
+	for i := 0; i < 10; i++ {
+		peer := pool.pickIncrAvailablePeer(int64(i + 1))
+		require.NotNil(t, peer)
+		picked[peer.id]++
+	}
+	assert.Equal(t, 8, picked["fast"])
+	assert.Equal(t, 2, picked["slow"])
+
+	// Both windows are full.
+	assert.Nil(t, pool.pickIncrAvailablePeer(11))
+
+	for _, peer := range pool.peers {
+		peer.timeout.Stop()
+	}
+}
@@ -1,7 +1,9 @@
 package v0
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"runtime/debug"
 	"sync"
 	"time"
@@ -110,6 +112,10 @@ type Reactor struct {
 	metrics *consensus.Metrics
 
 	syncStartTime time.Time
+
+	// archivePath, if set, points to a block archive that is imported before
+	// syncing from peers.
+	archivePath string
 }
 
 // NewReactor returns new reactor instance.
@@ -122,6 +128,7 @@ func NewReactor(
 	blockSyncCh *p2p.Channel,
 	peerUpdates *p2p.PeerUpdates,
 	blockSync bool,
+	archivePath string,
 	metrics *consensus.Metrics,
 ) (*Reactor, error) {
 	if state.LastBlockHeight != store.Height() {
@@ -151,6 +158,7 @@ func NewReactor(
 		closeCh:              make(chan struct{}),
 		metrics:              metrics,
 		syncStartTime:        time.Time{},
+		archivePath:          archivePath,
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "BlockSync", r)
@@ -163,15 +171,18 @@ func NewReactor(
 // OnStop to ensure the outbound p2p Channels are closed.
 //
 // If blockSync is enabled, we also start the pool and the pool processing
-// goroutine. If the pool fails to start, an error is returned.
+// goroutine. If the pool fails to start, an error is returned. Blocks from the
+// configured archive, if any, are imported before the pool is started.
 func (r *Reactor) OnStart() error {
 	if r.blockSync.IsSet() {
+		imported := r.importArchive()
+
 		if err := r.pool.Start(); err != nil {
 			return err
 		}
 
 		r.poolWG.Add(1)
-		go r.poolRoutine(false)
+		go r.poolRoutine(imported > 0)
 	}
 
 	go r.processBlockSyncCh()
@@ -377,6 +388,7 @@ func (r *Reactor) SwitchToBlockSync(state sm.State) error {
 	r.blockSync.Set()
 	r.initialState = state
 	r.pool.height = state.LastBlockHeight + 1
+	r.importArchive()
 
 	if err := r.pool.Start(); err != nil {
 		return err
@@ -390,6 +402,100 @@ func (r *Reactor) SwitchToBlockSync(state sm.State) error {
 	return nil
 }
 
+// importArchive applies the blocks of the configured archive on top of the
+// initial state, verifying each block with the commit of its successor, and
+// returns the number of blocks applied. The last block of the archive can't be
+// verified and is left to be fetched from peers, as is everything after the
+// first block that fails to verify. On return, the initial state and the pool
+// height reflect the imported blocks.
+func (r *Reactor) importArchive() uint64 {
+	if r.archivePath == "" {
+		return 0
+	}
+
+	state := r.initialState
+	imported, err := r.applyArchive(&state)
+	if err != nil {
+		r.Logger.Error("failed to import block archive; falling back to peers",
+			"path", r.archivePath, "height", state.LastBlockHeight, "err", err)
+	} else {
+		r.Logger.Info("imported block archive",
+			"path", r.archivePath, "height", state.LastBlockHeight, "blocks", imported)
+	}
+
+	r.initialState = state
+	r.pool.height = state.LastBlockHeight + 1
+	return imported
+}
+
+func (r *Reactor) applyArchive(state *sm.State) (uint64, error) {
+	archive, err := blocksync.OpenArchive(r.archivePath)
+	if err != nil {
+		return 0, err
+	}
+	defer archive.Close()
+
+	var (
+		imported uint64
+		first    *types.Block
+	)
+	for {
+		select {
+		case <-r.Quit():
+			return imported, errors.New("reactor stopped")
+		default:
+		}
+
+		second, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return imported, nil
+		}
+		if err != nil {
+			return imported, err
+		}
+
+		expected := state.LastBlockHeight + 1
+		if state.LastBlockHeight == 0 {
+			expected = state.InitialHeight
+		}
+		if first == nil {
+			switch {
+			case second.Height < expected:
+				// we already have this block
+				continue
+			case second.Height > expected:
+				return imported, fmt.Errorf("archive is missing block %d, next block is %d", expected, second.Height)
+			}
+			first = second
+			continue
+		}
+		if second.Height != first.Height+1 {
+			return imported, fmt.Errorf("archive is missing block %d, next block is %d", first.Height+1, second.Height)
+		}
+
+		var (
+			firstParts = first.MakePartSetForParams(state.ConsensusParams.Block)
+			firstID    = types.BlockID{Hash: first.Hash(), PartSetHeader: firstParts.Header()}
+		)
+		err = state.Validators.VerifyCommitLight(state.ChainID, firstID, first.Height, second.LastCommit)
+		if err != nil {
+			return imported, fmt.Errorf("invalid last commit for block %d: %w", first.Height, err)
+		}
+
+		r.store.SaveBlock(first, firstParts, second.LastCommit)
+
+		newState, err := r.blockExec.ApplyBlock(*state, firstID, first)
+		if err != nil {
+			panic(fmt.Sprintf("failed to process committed block (%d:%X): %v", first.Height, first.Hash(), err))
+		}
+		*state = newState
+
+		r.metrics.RecordConsMetrics(first)
+		imported++
+		first = second
+	}
+}
+
 func (r *Reactor) requestRoutine() {
 	statusUpdateTicker := time.NewTicker(statusUpdateIntervalSeconds * time.Second)
 	defer statusUpdateTicker.Stop()
@@ -531,7 +637,7 @@ FOR_LOOP:
 			}
 
 			var (
-				firstParts         = first.MakePartSet(types.BlockPartSizeBytes)
+				firstParts         = first.MakePartSetForParams(state.ConsensusParams.Block)
 				firstPartSetHeader = firstParts.Header()
 				firstID            = types.BlockID{Hash: first.Hash(), PartSetHeader: firstPartSetHeader}
 			)
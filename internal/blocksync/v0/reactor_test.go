@@ -1,7 +1,9 @@
 package v0
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/blocksync"
 	"github.com/tendermint/tendermint/internal/consensus"
 	"github.com/tendermint/tendermint/internal/mempool/mock"
 	"github.com/tendermint/tendermint/internal/p2p"
@@ -165,6 +168,7 @@ func (rts *reactorTestSuite) addNode(t *testing.T,
 		rts.blockSyncChannels[nodeID],
 		rts.peerUpdates[nodeID],
 		rts.blockSync,
+		"",
 		consensus.NopMetrics())
 	require.NoError(t, err)
 
@@ -356,3 +360,71 @@ func TestReactor_BadBlockStopsPeer(t *testing.T) {
 		len(rts.reactors[newNode.NodeID].pool.peers),
 	)
 }
+
+func TestReactor_ImportArchive(t *testing.T) {
+	cfg := config.ResetTestRoot("block_sync_reactor_test")
+	defer os.RemoveAll(cfg.RootDir)
+
+	genDoc, privVals := factory.RandGenesisDoc(cfg, 1, false, 30)
+	maxBlockHeight := int64(20)
+
+	rts := setup(t, genDoc, privVals[0], []int64{maxBlockHeight}, 0)
+	source := rts.reactors[rts.nodes[0]].store
+	require.Equal(t, maxBlockHeight, source.Height())
+
+	// Export the blocks into two files, which must be read in order.
+	archiveDir := t.TempDir()
+	for i, heights := range [][2]int64{{1, 10}, {11, maxBlockHeight}} {
+		f, err := os.Create(filepath.Join(archiveDir, fmt.Sprintf("blocks-%d", i)))
+		require.NoError(t, err)
+		w := blocksync.NewArchiveWriter(f)
+		for h := heights[0]; h <= heights[1]; h++ {
+			require.NoError(t, w.WriteBlock(source.LoadBlock(h)))
+		}
+		require.NoError(t, f.Close())
+	}
+
+	app := proxy.NewAppConns(abciclient.NewLocalCreator(&abci.BaseApplication{}))
+	require.NoError(t, app.Start())
+	t.Cleanup(func() { require.NoError(t, app.Stop()) })
+
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	require.NoError(t, stateStore.Save(state))
+
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		app.Consensus(),
+		mock.Mempool{},
+		sm.EmptyEvidencePool{},
+		blockStore,
+	)
+
+	reactor, err := NewReactor(
+		log.TestingLogger(),
+		state,
+		blockExec,
+		blockStore,
+		nil,
+		nil,
+		nil,
+		true,
+		archiveDir,
+		consensus.NopMetrics())
+	require.NoError(t, err)
+
+	// The last block can't be verified without its successor, so it is left to
+	// be fetched from peers.
+	require.EqualValues(t, maxBlockHeight-1, reactor.importArchive())
+	require.Equal(t, maxBlockHeight-1, blockStore.Height())
+	require.Equal(t, maxBlockHeight-1, reactor.initialState.LastBlockHeight)
+	require.Equal(t, maxBlockHeight, reactor.pool.height)
+	require.Equal(t, source.LoadBlock(maxBlockHeight-1).Hash(), blockStore.LoadBlock(maxBlockHeight-1).Hash())
+
+	// Importing again is a no-op since all blocks are already stored.
+	require.Zero(t, reactor.importArchive())
+	require.Equal(t, maxBlockHeight-1, blockStore.Height())
+}
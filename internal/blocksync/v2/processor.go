@@ -159,7 +159,7 @@ func (state *pcState) handle(event Event) (Event, error) {
 
 		var (
 			first, second = firstItem.block, secondItem.block
-			firstParts    = first.MakePartSet(types.BlockPartSizeBytes)
+			firstParts    = first.MakePartSetForParams(tmstate.ConsensusParams.Block)
 			firstID       = types.BlockID{Hash: first.Hash(), PartSetHeader: firstParts.Header()}
 		)
 
@@ -0,0 +1,348 @@
+// Package chaindata exports the blocks of a node up to a height, with their
+// validator sets and consensus params and the state after the last block, to a
+// portable archive, and imports the archive into the stores of a new node.
+//
+// An archive is a gzip compressed stream of delimited protobuf messages: the
+// state after the last block and the commit of the last block, followed by the
+// block, the validator set and the consensus params of each height, from the
+// first block to the last one.
+//
+// The chain of blocks is verified both when it is exported and imported: each
+// block must link to the previous one, be committed by its validators, and
+// match its validator set and consensus params, and the state must match the
+// last block. The first validator set is verified against the genesis when the
+// archive starts at the initial height.
+package chaindata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/internal/libs/protoio"
+	sm "github.com/tendermint/tendermint/internal/state"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+)
+
+// maxMsgSize is the maximum size of a message of an archive.
+const maxMsgSize = 2 * types.MaxBlockSizeBytes
+
+// Export writes the archive of the blocks of blockStore up to height, from the
+// first block of the store, to w. It returns the height of the first block.
+func Export(w io.Writer, stateStore sm.Store, blockStore sm.BlockStore, height int64) (int64, error) {
+	state, err := exportState(stateStore, blockStore, height)
+	if err != nil {
+		return 0, err
+	}
+
+	var commit *types.Commit
+	if height < blockStore.Height() {
+		commit = blockStore.LoadBlockCommit(height)
+	} else if seenCommit := blockStore.LoadSeenCommit(); seenCommit != nil && seenCommit.Height == height {
+		commit = seenCommit
+	}
+	if commit == nil {
+		return 0, fmt.Errorf("no commit for height %d", height)
+	}
+
+	// the heights of the signed headers saved by the state sync have no block
+	from := blockStore.Base()
+	for from <= height && blockStore.LoadBlock(from) == nil {
+		from++
+	}
+	if from > height {
+		return 0, fmt.Errorf("no block up to height %d", height)
+	}
+
+	pbState, err := state.ToProto()
+	if err != nil {
+		return 0, err
+	}
+	gw := gzip.NewWriter(w)
+	pw := protoio.NewDelimitedWriter(gw)
+	if _, err := pw.WriteMsg(pbState); err != nil {
+		return 0, err
+	}
+	if _, err := pw.WriteMsg(commit.ToProto()); err != nil {
+		return 0, err
+	}
+
+	v := &verifier{state: state, commit: commit}
+	for h := from; h <= height; h++ {
+		block := blockStore.LoadBlock(h)
+		if block == nil {
+			return 0, fmt.Errorf("missing block at height %d", h)
+		}
+		vals, err := stateStore.LoadValidators(h)
+		if err != nil {
+			return 0, err
+		}
+		params, err := stateStore.LoadConsensusParams(h)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := v.verifyBlock(block, vals, params); err != nil {
+			return 0, err
+		}
+
+		pbBlock, err := block.ToProto()
+		if err != nil {
+			return 0, err
+		}
+		pbVals, err := vals.ToProto()
+		if err != nil {
+			return 0, err
+		}
+		pbParams := params.ToProto()
+		for _, msg := range []proto.Message{pbBlock, pbVals, &pbParams} {
+			if _, err := pw.WriteMsg(msg); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := v.verifyEnd(); err != nil {
+		return 0, err
+	}
+
+	return from, gw.Close()
+}
+
+// exportState returns the state after the block at height, which is rebuilt
+// from the stores for the heights before the latest state, like the state
+// sync does from the light blocks.
+func exportState(stateStore sm.Store, blockStore sm.BlockStore, height int64) (sm.State, error) {
+	state, err := stateStore.Load()
+	if err != nil {
+		return sm.State{}, err
+	}
+	if state.IsEmpty() {
+		return sm.State{}, errors.New("no state")
+	}
+	if height <= 0 || height > state.LastBlockHeight {
+		return sm.State{}, fmt.Errorf("height %d must be between 1 and the last block height %d",
+			height, state.LastBlockHeight)
+	}
+	if height == state.LastBlockHeight {
+		return state, nil
+	}
+
+	lastMeta := blockStore.LoadBlockMeta(height)
+	currentMeta := blockStore.LoadBlockMeta(height + 1)
+	if lastMeta == nil || currentMeta == nil {
+		return sm.State{}, fmt.Errorf("missing blocks at heights %d and %d", height, height+1)
+	}
+
+	state.Version = sm.Version{
+		Consensus: currentMeta.Header.Version,
+		Software:  version.TMVersion,
+	}
+	state.LastBlockHeight = height
+	state.LastBlockTime = lastMeta.Header.Time
+	state.LastBlockID = lastMeta.BlockID
+	state.AppHash = currentMeta.Header.AppHash
+	state.LastResultsHash = currentMeta.Header.LastResultsHash
+
+	if state.LastValidators, err = stateStore.LoadValidators(height); err != nil {
+		return sm.State{}, err
+	}
+	if state.Validators, err = stateStore.LoadValidators(height + 1); err != nil {
+		return sm.State{}, err
+	}
+	if state.NextValidators, err = stateStore.LoadValidators(height + 2); err != nil {
+		return sm.State{}, err
+	}
+	state.LastHeightValidatorsChanged = height + 2
+
+	if state.ConsensusParams, err = stateStore.LoadConsensusParams(height + 1); err != nil {
+		return sm.State{}, err
+	}
+	state.LastHeightConsensusParamsChanged = height + 1
+
+	return state, nil
+}
+
+// Verify reads and verifies the archive from r against genDoc, and returns the
+// heights of its first and last blocks.
+func Verify(r io.Reader, genDoc *types.GenesisDoc) (int64, int64, error) {
+	return read(r, genDoc, nil, nil)
+}
+
+// Import reads the archive from r, verifies it against genDoc and saves its
+// blocks, validator sets, consensus params and state to the stores, which must
+// be empty. It returns the heights of the first and last blocks imported. The
+// stores must be discarded if the import fails.
+func Import(
+	r io.Reader,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	genDoc *types.GenesisDoc,
+) (int64, int64, error) {
+	state, err := stateStore.Load()
+	if err != nil {
+		return 0, 0, err
+	}
+	if !state.IsEmpty() || blockStore.Height() != 0 {
+		return 0, 0, errors.New("the stores must be empty")
+	}
+
+	var (
+		// a block is saved with the commit of the next block
+		block   *types.Block
+		partSet *types.PartSet
+
+		// the validator sets and consensus params are saved by ranges of
+		// heights over which they don't change
+		vals       *types.ValidatorSet
+		valsFrom   int64
+		params     types.ConsensusParams
+		paramsFrom int64
+	)
+	next := func(
+		nextBlock *types.Block,
+		nextPartSet *types.PartSet,
+		nextVals *types.ValidatorSet,
+		nextParams types.ConsensusParams,
+	) error {
+		if block != nil {
+			blockStore.SaveBlock(block, partSet, nextBlock.LastCommit)
+
+			if !bytes.Equal(vals.Hash(), nextVals.Hash()) {
+				if err := stateStore.SaveValidatorSets(valsFrom, block.Height, vals); err != nil {
+					return err
+				}
+				valsFrom = nextBlock.Height
+			}
+			if !bytes.Equal(params.HashConsensusParams(), nextParams.HashConsensusParams()) {
+				if err := stateStore.SaveConsensusParams(paramsFrom, block.Height, params); err != nil {
+					return err
+				}
+				paramsFrom = nextBlock.Height
+			}
+		} else {
+			valsFrom, paramsFrom = nextBlock.Height, nextBlock.Height
+		}
+		block, partSet, vals, params = nextBlock, nextPartSet, nextVals, nextParams
+		return nil
+	}
+
+	end := func(state sm.State, commit *types.Commit) error {
+		blockStore.SaveBlock(block, partSet, commit)
+		if err := stateStore.SaveValidatorSets(valsFrom, block.Height, vals); err != nil {
+			return err
+		}
+		if err := stateStore.SaveConsensusParams(paramsFrom, block.Height, params); err != nil {
+			return err
+		}
+		return stateStore.Bootstrap(state)
+	}
+
+	return read(r, genDoc, next, end)
+}
+
+// read reads and verifies the archive from r, calling next, if any, with each
+// block and end, if any, with the state and the last commit once all the
+// blocks are verified.
+func read(
+	r io.Reader,
+	genDoc *types.GenesisDoc,
+	next func(*types.Block, *types.PartSet, *types.ValidatorSet, types.ConsensusParams) error,
+	end func(sm.State, *types.Commit) error,
+) (int64, int64, error) {
+	genesisState, err := sm.MakeGenesisState(genDoc)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, 0, fmt.Errorf("reading archive: %w", err)
+	}
+	pr := protoio.NewDelimitedReader(gr, maxMsgSize)
+
+	pbState := new(tmstate.State)
+	if _, err := pr.ReadMsg(pbState); err != nil {
+		return 0, 0, fmt.Errorf("reading state: %w", err)
+	}
+	state, err := sm.StateFromProto(pbState)
+	if err != nil {
+		return 0, 0, err
+	}
+	if state.ChainID != genDoc.ChainID || state.InitialHeight != genesisState.InitialHeight {
+		return 0, 0, fmt.Errorf("the archive of chain %s with initial height %d doesn't match the genesis "+
+			"of chain %s with initial height %d",
+			state.ChainID, state.InitialHeight, genDoc.ChainID, genesisState.InitialHeight)
+	}
+
+	pbCommit := new(tmproto.Commit)
+	if _, err := pr.ReadMsg(pbCommit); err != nil {
+		return 0, 0, fmt.Errorf("reading commit: %w", err)
+	}
+	commit, err := types.CommitFromProto(pbCommit)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	v := &verifier{state: *state, commit: commit}
+	// the validators may also be set by the application in InitChain
+	if len(genDoc.Validators) > 0 {
+		v.genesisVals = genesisState.Validators
+	}
+
+	var from int64
+	for {
+		pbBlock := new(tmproto.Block)
+		if _, err := pr.ReadMsg(pbBlock); err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, 0, fmt.Errorf("reading block: %w", err)
+		}
+		pbVals := new(tmproto.ValidatorSet)
+		if _, err := pr.ReadMsg(pbVals); err != nil {
+			return 0, 0, fmt.Errorf("reading validators: %w", err)
+		}
+		pbParams := new(tmproto.ConsensusParams)
+		if _, err := pr.ReadMsg(pbParams); err != nil {
+			return 0, 0, fmt.Errorf("reading consensus params: %w", err)
+		}
+
+		block, err := types.BlockFromProto(pbBlock)
+		if err != nil {
+			return 0, 0, err
+		}
+		vals, err := types.ValidatorSetFromProto(pbVals)
+		if err != nil {
+			return 0, 0, err
+		}
+		params := types.ConsensusParamsFromProto(*pbParams)
+
+		partSet, err := v.verifyBlock(block, vals, params)
+		if err != nil {
+			return 0, 0, err
+		}
+		if from == 0 {
+			from = block.Height
+		}
+		if next != nil {
+			if err := next(block, partSet, vals, params); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+	if err := v.verifyEnd(); err != nil {
+		return 0, 0, err
+	}
+
+	if end != nil {
+		if err := end(*state, commit); err != nil {
+			return 0, 0, err
+		}
+	}
+	return from, state.LastBlockHeight, nil
+}
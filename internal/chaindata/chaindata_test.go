@@ -0,0 +1,137 @@
+package chaindata_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/chaindata"
+	mmock "github.com/tendermint/tendermint/internal/mempool/mock"
+	"github.com/tendermint/tendermint/internal/proxy"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// makeChain executes and commits n blocks, and returns the genesis and the
+// stores of the chain.
+func makeChain(t *testing.T, n int64) (*types.GenesisDoc, sm.Store, *store.BlockStore) {
+	t.Helper()
+
+	genDoc, privVals := factory.RandGenesisDoc(config.TestConfig(), 2, false, 10)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	require.NoError(t, stateStore.Save(state))
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	proxyApp := proxy.NewAppConns(abciclient.NewLocalCreator(kvstore.NewApplication()))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() { require.NoError(t, proxyApp.Stop()) })
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore)
+
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for height := int64(1); height <= n; height++ {
+		txs := []types.Tx{types.Tx("key=value")}
+		block, partSet := state.MakeBlock(height, txs, nil, nil, nil, lastCommit, state.Validators.GetProposer().Address)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+
+		voteSet := types.NewVoteSet(state.ChainID, height, 0, tmproto.PrecommitType, state.Validators)
+		lastCommit, err = factory.MakeCommit(blockID, height, 0, voteSet, privVals, block.Time.Add(1))
+		require.NoError(t, err)
+
+		state, err = blockExec.ApplyBlock(state, blockID, block)
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, partSet, lastCommit)
+	}
+	return genDoc, stateStore, blockStore
+}
+
+func TestExportImport(t *testing.T) {
+	genDoc, stateStore, blockStore := makeChain(t, 5)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+
+	for _, height := range []int64{5, 3} {
+		archive := new(bytes.Buffer)
+		from, err := chaindata.Export(archive, stateStore, blockStore, height)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, from)
+
+		from, to, err := chaindata.Verify(bytes.NewReader(archive.Bytes()), genDoc)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, from)
+		require.Equal(t, height, to)
+
+		importedStateStore := sm.NewStore(dbm.NewMemDB())
+		importedBlockStore := store.NewBlockStore(dbm.NewMemDB())
+		from, to, err = chaindata.Import(bytes.NewReader(archive.Bytes()), importedStateStore, importedBlockStore, genDoc)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, from)
+		require.Equal(t, height, to)
+
+		importedState, err := importedStateStore.Load()
+		require.NoError(t, err)
+		require.Equal(t, height, importedState.LastBlockHeight)
+		require.Equal(t, blockStore.LoadBlockMeta(height).BlockID, importedState.LastBlockID)
+		if height == state.LastBlockHeight {
+			require.Equal(t, state, importedState)
+		} else {
+			require.Equal(t, []byte(blockStore.LoadBlockMeta(height+1).Header.AppHash), importedState.AppHash)
+		}
+
+		require.EqualValues(t, 1, importedBlockStore.Base())
+		require.Equal(t, height, importedBlockStore.Height())
+		for h := int64(1); h <= height; h++ {
+			require.Equal(t, blockStore.LoadBlock(h).Hash(), importedBlockStore.LoadBlock(h).Hash())
+			vals, err := importedStateStore.LoadValidators(h)
+			require.NoError(t, err)
+			require.Equal(t, importedBlockStore.LoadBlockMeta(h).Header.ValidatorsHash, tmbytes.HexBytes(vals.Hash()))
+			params, err := importedStateStore.LoadConsensusParams(h)
+			require.NoError(t, err)
+			require.Equal(t, importedBlockStore.LoadBlockMeta(h).Header.ConsensusHash, tmbytes.HexBytes(params.HashConsensusParams()))
+		}
+		require.Equal(t, blockStore.LoadBlockCommit(height-1).Hash(), importedBlockStore.LoadBlockCommit(height-1).Hash())
+		require.Equal(t, height, importedBlockStore.LoadSeenCommit().Height)
+
+		// the stores must be empty
+		_, _, err = chaindata.Import(bytes.NewReader(archive.Bytes()), importedStateStore, importedBlockStore, genDoc)
+		require.Error(t, err)
+	}
+
+	_, err = chaindata.Export(new(bytes.Buffer), stateStore, blockStore, 6)
+	require.Error(t, err)
+}
+
+func TestVerifyInvalidArchive(t *testing.T) {
+	genDoc, stateStore, blockStore := makeChain(t, 3)
+	archive := new(bytes.Buffer)
+	_, err := chaindata.Export(archive, stateStore, blockStore, 3)
+	require.NoError(t, err)
+
+	// another chain
+	otherGenDoc := *genDoc
+	otherGenDoc.ChainID = "other-chain"
+	_, _, err = chaindata.Verify(bytes.NewReader(archive.Bytes()), &otherGenDoc)
+	require.Error(t, err)
+
+	// other validators
+	otherValsGenDoc, _ := factory.RandGenesisDoc(config.TestConfig(), 2, false, 10)
+	otherValsGenDoc.ChainID = genDoc.ChainID
+	_, _, err = chaindata.Verify(bytes.NewReader(archive.Bytes()), otherValsGenDoc)
+	require.Error(t, err)
+
+	// truncated
+	_, _, err = chaindata.Verify(bytes.NewReader(archive.Bytes()[:archive.Len()/2]), genDoc)
+	require.Error(t, err)
+}
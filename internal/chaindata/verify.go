@@ -0,0 +1,100 @@
+package chaindata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// verifier verifies the chain of blocks of an archive, one block at a time,
+// and then the state and the commit of the last block.
+type verifier struct {
+	state  sm.State
+	commit *types.Commit
+
+	// the validators of the initial height, if known
+	genesisVals *types.ValidatorSet
+
+	last     *types.Block
+	lastID   types.BlockID
+	lastVals *types.ValidatorSet
+}
+
+// verifyBlock verifies that block follows the previous block, and matches vals
+// and params, the validators and consensus params of its height. It returns
+// the part set of the block.
+func (v *verifier) verifyBlock(
+	block *types.Block,
+	vals *types.ValidatorSet,
+	params types.ConsensusParams,
+) (*types.PartSet, error) {
+	if err := block.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid block at height %d: %w", block.Height, err)
+	}
+	if block.ChainID != v.state.ChainID {
+		return nil, fmt.Errorf("block at height %d is from chain %s", block.Height, block.ChainID)
+	}
+
+	if v.last == nil {
+		if block.Height == v.state.InitialHeight && v.genesisVals != nil &&
+			!bytes.Equal(vals.Hash(), v.genesisVals.Hash()) {
+			return nil, errors.New("the validators of the initial height don't match the genesis validators")
+		}
+	} else {
+		if block.Height != v.last.Height+1 {
+			return nil, fmt.Errorf("block at height %d follows height %d", block.Height, v.last.Height)
+		}
+		if !block.LastBlockID.Equals(v.lastID) {
+			return nil, fmt.Errorf("block at height %d doesn't link to the previous block", block.Height)
+		}
+		if !bytes.Equal(v.last.NextValidatorsHash, vals.Hash()) {
+			return nil, fmt.Errorf("validators at height %d don't match the next validators of the previous block",
+				block.Height)
+		}
+		if err := v.lastVals.VerifyCommitLight(v.state.ChainID, v.lastID, v.last.Height, block.LastCommit); err != nil {
+			return nil, fmt.Errorf("invalid commit for height %d: %w", v.last.Height, err)
+		}
+	}
+
+	if !bytes.Equal(block.ValidatorsHash, vals.Hash()) {
+		return nil, fmt.Errorf("validators at height %d don't match the block", block.Height)
+	}
+	if !bytes.Equal(block.ConsensusHash, params.HashConsensusParams()) {
+		return nil, fmt.Errorf("consensus params at height %d don't match the block", block.Height)
+	}
+
+	partSet := block.MakePartSetForParams(params.Block)
+	v.last = block
+	v.lastID = types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+	v.lastVals = vals
+	return partSet, nil
+}
+
+// verifyEnd verifies the commit of the last block, and that the state follows
+// it.
+func (v *verifier) verifyEnd() error {
+	if v.last == nil {
+		return errors.New("no blocks")
+	}
+	if err := v.lastVals.VerifyCommitLight(v.state.ChainID, v.lastID, v.last.Height, v.commit); err != nil {
+		return fmt.Errorf("invalid commit for height %d: %w", v.last.Height, err)
+	}
+
+	switch {
+	case v.state.LastBlockHeight != v.last.Height:
+		return fmt.Errorf("the state is at height %d, but the last block at height %d",
+			v.state.LastBlockHeight, v.last.Height)
+	case !v.state.LastBlockID.Equals(v.lastID):
+		return errors.New("the last block ID of the state doesn't match the last block")
+	case !v.state.LastBlockTime.Equal(v.last.Time):
+		return errors.New("the last block time of the state doesn't match the last block")
+	case !bytes.Equal(v.state.LastValidators.Hash(), v.lastVals.Hash()):
+		return errors.New("the last validators of the state don't match the last block")
+	case !bytes.Equal(v.state.Validators.Hash(), v.last.NextValidatorsHash):
+		return errors.New("the validators of the state don't match the last block")
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package consensus
+
+import (
+	"bytes"
+	"fmt"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/internal/mempool"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/types"
+)
+
+// CompactBlocksFeature is advertised in the NodeInfo of the nodes gossiping the
+// proposal blocks as compact blocks, see ReactorCompactBlocks. They are only
+// sent to the peers advertising it.
+const CompactBlocksFeature = "compact-blocks"
+
+// compactBlock holds the compact block of the proposal block of the current
+// height: the block without its txs, and the keys of its txs. The nodes having
+// the proposal block gossip it as a compact block to the peers supporting it,
+// which reconstruct the block from the txs of their mempool, and request the
+// txs they miss from the sender of the compact block.
+type compactBlock struct {
+	mtx tmsync.Mutex
+
+	height int64
+	hash   tmbytes.HexBytes
+	block  *types.Block
+	keys   [][]byte
+
+	// txs, missing and from are only set for the compact blocks received, and
+	// rebuilt once they are reconstructed.
+	txs     types.Txs
+	missing int
+	from    types.NodeID
+	rebuilt *types.Block
+	done    bool
+}
+
+// reset starts holding the compact block of the given block, unless it
+// already is, and returns whether it did.
+func (cb *compactBlock) reset(height int64, hash tmbytes.HexBytes, block *types.Block, keys [][]byte) bool {
+	if cb.height == height && bytes.Equal(cb.hash, hash) {
+		return false
+	}
+	cb.height = height
+	cb.hash = hash
+	cb.block = block
+	cb.keys = keys
+	cb.txs = nil
+	cb.missing = 0
+	cb.from = ""
+	cb.rebuilt = nil
+	cb.done = false
+	return true
+}
+
+// originate returns the compact block message of the complete proposal block
+// for the given round.
+func (cb *compactBlock) originate(round int32, block *types.Block) *CompactBlockMessage {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	if hash := block.Hash(); cb.height != block.Height || !bytes.Equal(cb.hash, hash) {
+		keys := make([][]byte, len(block.Txs))
+		for i, tx := range block.Txs {
+			key := mempool.TxKey(tx)
+			keys[i] = key[:]
+		}
+		data := block.Data
+		data.Txs = nil
+		cb.reset(block.Height, hash, &types.Block{
+			Header:     block.Header,
+			Data:       data,
+			LastCommit: block.LastCommit,
+		}, keys)
+		// the proposal block is already complete
+		cb.done = true
+	}
+
+	return &CompactBlockMessage{
+		Height: cb.height,
+		Round:  round,
+		Block:  cb.block,
+		TxKeys: cb.keys,
+	}
+}
+
+// add adds the compact block of msg, received from peerID, unless we already
+// have it, and looks its txs up in mp. It returns the number of txs found, and
+// the indexes of the missing ones.
+func (cb *compactBlock) add(msg *CompactBlockMessage, peerID types.NodeID, mp mempool.Mempool) (int, []uint32) {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	if msg.Height < cb.height || !cb.reset(msg.Height, msg.Block.Hash(), msg.Block, msg.TxKeys) {
+		return 0, nil
+	}
+
+	cb.from = peerID
+	cb.txs = make(types.Txs, len(cb.keys))
+	var missing []uint32
+	for i, key := range cb.keys {
+		var txKey [mempool.TxKeySize]byte
+		copy(txKey[:], key)
+		if tx, ok := mp.GetTxByKey(txKey); ok {
+			cb.txs[i] = tx.WithoutBlobs()
+		} else {
+			missing = append(missing, uint32(i))
+		}
+	}
+	cb.missing = len(missing)
+	if cb.missing == 0 {
+		cb.reconstruct()
+	}
+	return len(cb.keys) - cb.missing, missing
+}
+
+// addTxs adds the requested txs of msg, and returns whether it added any.
+func (cb *compactBlock) addTxs(msg *CompactBlockTxsMessage) (bool, error) {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	if cb.height != msg.Height || !bytes.Equal(cb.hash, msg.BlockHash) || cb.missing == 0 {
+		return false, nil
+	}
+
+	added := false
+	for i, index := range msg.Indexes {
+		if int(index) >= len(cb.keys) {
+			return added, fmt.Errorf("tx %d of a compact block of %d txs", index, len(cb.keys))
+		}
+		if cb.txs[index] != nil {
+			continue
+		}
+		if key := mempool.TxKey(msg.Txs[i]); !bytes.Equal(key[:], cb.keys[index]) {
+			return added, fmt.Errorf("tx %d doesn't match its key %X", index, cb.keys[index])
+		}
+		cb.txs[index] = msg.Txs[i]
+		cb.missing--
+		added = true
+	}
+	if added && cb.missing == 0 {
+		cb.reconstruct()
+	}
+	return added, nil
+}
+
+// reconstruct reconstructs the block once we have all its txs.
+func (cb *compactBlock) reconstruct() {
+	data := cb.block.Data
+	data.Txs = cb.txs
+	cb.rebuilt = &types.Block{
+		Header:     cb.block.Header,
+		Data:       data,
+		LastCommit: cb.block.LastCommit,
+	}
+}
+
+// take returns the parts of the reconstructed block with the given block ID,
+// the one of the proposal, split as the block params of its height say, and
+// the peer which sent us its compact block, once. It returns an error if they
+// don't match the part set header of the proposal.
+func (cb *compactBlock) take(
+	height int64,
+	blockID types.BlockID,
+	params types.BlockParams,
+) (*types.PartSet, types.NodeID, error) {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	if cb.height != height || !bytes.Equal(cb.hash, blockID.Hash) || cb.rebuilt == nil || cb.done {
+		return nil, "", nil
+	}
+	cb.done = true
+	parts := cb.rebuilt.MakePartSetForParams(params)
+	if !parts.HasHeader(blockID.PartSetHeader) {
+		return nil, "", fmt.Errorf("reconstructed block parts %v instead of %v", parts.Header(), blockID.PartSetHeader)
+	}
+	return parts, cb.from, nil
+}
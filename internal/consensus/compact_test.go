@@ -0,0 +1,85 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/internal/mempool"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestCompactBlock(t *testing.T) {
+	config := configSetup(t)
+
+	cs, _ := randState(config, 1)
+	peerCS, _ := randState(config, 1)
+	txs := make(types.Txs, 4)
+	for i := range txs {
+		txs[i] = types.Tx(fmt.Sprintf("key%d=value", i))
+		require.NoError(t, assertMempool(cs.txNotifier).CheckTx(context.Background(), txs[i], nil, mempool.TxInfo{}))
+	}
+	// the peer only has the first txs in its mempool
+	for _, tx := range txs[:2] {
+		require.NoError(t, assertMempool(peerCS.txNotifier).CheckTx(context.Background(), tx, nil, mempool.TxInfo{}))
+	}
+
+	block, blockParts := cs.createProposalBlock()
+	require.Len(t, block.Txs, len(txs))
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: blockParts.Header()}
+	params := cs.state.ConsensusParams.Block
+
+	// the compact block is sent without the txs of the block
+	origin := &compactBlock{}
+	msgProto, err := MsgToProto(origin.originate(0, block))
+	require.NoError(t, err)
+	msgI, err := MsgFromProto(msgProto)
+	require.NoError(t, err)
+	msg := msgI.(*CompactBlockMessage)
+	require.Empty(t, msg.Block.Txs)
+	require.Len(t, msg.TxKeys, len(txs))
+	require.Equal(t, blockID.Hash, msg.Block.Hash())
+
+	// the peer requests the txs missing from its mempool
+	peer := &compactBlock{}
+	found, missing := peer.add(msg, "peer", assertMempool(peerCS.txNotifier))
+	require.Equal(t, 2, found)
+	require.Equal(t, []uint32{2, 3}, missing)
+	parts, _, err := peer.take(block.Height, blockID, params)
+	require.NoError(t, err)
+	require.Nil(t, parts)
+
+	// and rejects the txs which don't match their keys
+	txsMsg := &CompactBlockTxsMessage{
+		Height:    block.Height,
+		BlockHash: blockID.Hash,
+		Indexes:   []uint32{2, 3},
+		Txs:       types.Txs{txs[3], txs[2]},
+	}
+	_, err = peer.addTxs(txsMsg)
+	require.Error(t, err)
+
+	txsMsg.Txs = types.Txs{txs[2], txs[3]}
+	added, err := peer.addTxs(txsMsg)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	// it passes on the reconstructed block once, if it is the proposal block
+	otherID := types.BlockID{Hash: tmrand.Bytes(tmhash.Size), PartSetHeader: blockID.PartSetHeader}
+	parts, _, err = peer.take(block.Height, otherID, params)
+	require.NoError(t, err)
+	require.Nil(t, parts)
+
+	parts, peerID, err := peer.take(block.Height, blockID, params)
+	require.NoError(t, err)
+	require.Equal(t, types.NodeID("peer"), peerID)
+	require.True(t, parts.HasHeader(blockID.PartSetHeader))
+
+	parts, _, err = peer.take(block.Height, blockID, params)
+	require.NoError(t, err)
+	require.Nil(t, parts)
+}
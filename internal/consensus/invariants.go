@@ -0,0 +1,179 @@
+package consensus
+
+import (
+	"bytes"
+	"fmt"
+
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// invariantChecker checks the invariants of the consensus state machine in
+// paranoid mode. It remembers the last round state it checked, to verify the
+// transitions from it.
+type invariantChecker struct {
+	checked     bool
+	height      int64
+	round       int32
+	step        cstypes.RoundStepType
+	lockedRound int32
+	lockedHash  tmbytes.HexBytes
+}
+
+// checkInvariants halts the state machine, with a dump of the round state, if
+// the last transition violated one of its invariants. It's a no-op outside of
+// paranoid mode. It must be called with cs.mtx held.
+func (cs *State) checkInvariants() {
+	if cs.invariants == nil {
+		return
+	}
+	if err := cs.invariants.check(&cs.RoundState); err != nil {
+		dump, jsonErr := tmjson.MarshalIndent(cs.RoundState, "", "  ")
+		if jsonErr != nil {
+			dump = []byte(jsonErr.Error())
+		}
+		cs.Logger.Error("consensus invariant violated", "err", err, "round_state", string(dump))
+		panic(fmt.Sprintf("consensus invariant violated: %v", err))
+	}
+}
+
+// check returns an error if rs, or the transition to it from the last round
+// state checked, violates an invariant.
+func (ic *invariantChecker) check(rs *cstypes.RoundState) error {
+	if err := checkRoundState(rs); err != nil {
+		return err
+	}
+	if err := ic.checkTransition(rs); err != nil {
+		return err
+	}
+
+	ic.checked = true
+	ic.height, ic.round, ic.step = rs.Height, rs.Round, rs.Step
+	ic.lockedRound = rs.LockedRound
+	ic.lockedHash = rs.LockedBlock.Hash()
+	return nil
+}
+
+// checkTransition checks that the height, round and step never go back, and
+// that we only unlock on a POL for another block, or nil, after our lock.
+func (ic *invariantChecker) checkTransition(rs *cstypes.RoundState) error {
+	if !ic.checked {
+		return nil
+	}
+	if rs.Height < ic.height ||
+		rs.Height == ic.height && (rs.Round < ic.round || rs.Round == ic.round && rs.Step < ic.step) {
+		return fmt.Errorf("went back from %d/%d/%v to %d/%d/%v",
+			ic.height, ic.round, ic.step, rs.Height, rs.Round, rs.Step)
+	}
+	if rs.Height != ic.height || ic.lockedRound == -1 {
+		return nil
+	}
+
+	switch {
+	case rs.LockedRound == -1:
+		for round := ic.lockedRound + 1; round <= rs.Round; round++ {
+			blockID, ok := rs.Votes.Prevotes(round).TwoThirdsMajority()
+			if ok && !bytes.Equal(blockID.Hash, ic.lockedHash) {
+				return nil
+			}
+		}
+		return fmt.Errorf("unlocked %v locked at round %d without a POL", ic.lockedHash, ic.lockedRound)
+
+	case rs.LockedRound < ic.lockedRound:
+		return fmt.Errorf("locked round went back from %d to %d", ic.lockedRound, rs.LockedRound)
+	}
+	return nil
+}
+
+// checkRoundState checks the consistency of rs: the locked and valid blocks
+// have a POL, the proposal is for the current round and the votes are those
+// of the validators.
+func checkRoundState(rs *cstypes.RoundState) error {
+	if err := checkPOL("locked", rs.LockedRound, rs.LockedBlock, rs.LockedBlockParts, rs); err != nil {
+		return err
+	}
+	if err := checkPOL("valid", rs.ValidRound, rs.ValidBlock, rs.ValidBlockParts, rs); err != nil {
+		return err
+	}
+
+	if p := rs.Proposal; p != nil {
+		if p.Height != rs.Height || p.Round != rs.Round {
+			return fmt.Errorf("proposal for %d/%d at %d/%d", p.Height, p.Round, rs.Height, rs.Round)
+		}
+		if p.POLRound < -1 || p.POLRound >= p.Round {
+			return fmt.Errorf("proposal with POL round %d at round %d", p.POLRound, p.Round)
+		}
+	}
+
+	if rs.LastCommit != nil {
+		if height := rs.LastCommit.GetHeight(); height != rs.Height-1 {
+			return fmt.Errorf("last commit for height %d at height %d", height, rs.Height)
+		}
+		if !rs.LastCommit.HasTwoThirdsMajority() {
+			return fmt.Errorf("last commit without +2/3 precommits: %v", rs.LastCommit)
+		}
+	}
+
+	if height := rs.Votes.Height(); height != rs.Height {
+		return fmt.Errorf("votes for height %d at height %d", height, rs.Height)
+	}
+	for round := int32(0); round <= rs.Votes.Round()+1; round++ {
+		if err := checkVoteSet(rs.Votes.Prevotes(round), rs.Validators); err != nil {
+			return err
+		}
+		if err := checkVoteSet(rs.Votes.Precommits(round), rs.Validators); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPOL checks that the round of the locked or valid block is in [-1,
+// rs.Round], that we have the block iff the round isn't -1, and that +2/3
+// prevoted for the block in that round.
+func checkPOL(name string, round int32, block *types.Block, parts *types.PartSet, rs *cstypes.RoundState) error {
+	if round < -1 || round > rs.Round {
+		return fmt.Errorf("%s round %d at round %d", name, round, rs.Round)
+	}
+	if (round == -1) != (block == nil) || (block == nil) != (parts == nil) {
+		return fmt.Errorf("%s round %d with block %v and parts %v", name, round, block.Hash(), parts.Header())
+	}
+	if round == -1 {
+		return nil
+	}
+	blockID, ok := rs.Votes.Prevotes(round).TwoThirdsMajority()
+	if !ok || !block.HashesTo(blockID.Hash) {
+		return fmt.Errorf("%s block %v without a POL at round %d", name, block.Hash(), round)
+	}
+	return nil
+}
+
+// checkVoteSet checks that the votes of voteSet are for its height, round and
+// type, and from the validators at their index.
+func checkVoteSet(voteSet *types.VoteSet, vals *types.ValidatorSet) error {
+	if voteSet == nil {
+		return nil
+	}
+	msgType := tmproto.SignedMsgType(voteSet.Type())
+	votes := voteSet.BitArray()
+	for i := 0; i < votes.Size(); i++ {
+		if !votes.GetIndex(i) {
+			continue
+		}
+		vote := voteSet.GetByIndex(int32(i))
+		if vote == nil {
+			return fmt.Errorf("no %v at index %d of round %d", msgType, i, voteSet.GetRound())
+		}
+		if vote.Height != voteSet.GetHeight() || vote.Round != voteSet.GetRound() ||
+			vote.Type != msgType || vote.ValidatorIndex != int32(i) {
+			return fmt.Errorf("%v at index %d of the %v of %d/%d", vote, i, msgType, voteSet.GetHeight(), voteSet.GetRound())
+		}
+		if address, _ := vals.GetByIndex(int32(i)); !bytes.Equal(address, vote.ValidatorAddress) {
+			return fmt.Errorf("%v from validator %X at index %d", vote, address, i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestInvariantChecker(t *testing.T) {
+	config := configSetup(t)
+
+	cs, vss := randState(config, 4)
+	block, blockParts := cs.createProposalBlock()
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: blockParts.Header()}
+
+	ic := &invariantChecker{}
+	rs := cs.RoundState
+	require.NoError(t, ic.check(&rs))
+
+	locked := rs
+	locked.LockedRound = 0
+	require.Error(t, ic.check(&locked), "locked round without a locked block")
+
+	locked.LockedBlock = block
+	locked.LockedBlockParts = blockParts
+	require.Error(t, ic.check(&locked), "locked block without a POL")
+
+	for _, vote := range signVotes(config, tmproto.PrevoteType, blockID.Hash, blockID.PartSetHeader, vss[1:]...) {
+		_, err := cs.Votes.AddVote(vote, "")
+		require.NoError(t, err)
+	}
+	require.NoError(t, ic.check(&locked))
+
+	unlocked := locked
+	unlocked.Round = 1
+	unlocked.LockedRound = -1
+	unlocked.LockedBlock = nil
+	unlocked.LockedBlockParts = nil
+	require.Error(t, ic.check(&unlocked), "unlocked without a POL")
+
+	cs.Votes.SetRound(1)
+	incrementRound(vss[1:]...)
+	for _, vote := range signVotes(config, tmproto.PrevoteType, nil, types.PartSetHeader{}, vss[1:]...) {
+		_, err := cs.Votes.AddVote(vote, "")
+		require.NoError(t, err)
+	}
+	require.NoError(t, ic.check(&unlocked))
+
+	back := unlocked
+	back.Round = 0
+	require.Error(t, ic.check(&back), "round went back")
+}
@@ -61,6 +61,27 @@ type Metrics struct {
 
 	// Number of blockparts transmitted by peer.
 	BlockParts metrics.Counter
+	// Number of block parts first received from a peer.
+	BlockPartsFirstSeen metrics.Counter
+	// Number of parity parts transmitted by peer.
+	ParityParts metrics.Counter
+	// Number of proposal blocks rebuilt from their parity parts.
+	RebuiltBlocks metrics.Counter
+	// Number of txs of the compact blocks received found in the mempool.
+	CompactBlockTxsFound metrics.Counter
+	// Number of txs of the compact blocks received missing from the mempool.
+	CompactBlockTxsMissing metrics.Counter
+	// Number of proposal blocks reconstructed from their compact block.
+	ReconstructedBlocks metrics.Counter
+	// Time between the timestamp of a proposal and its first receipt from a
+	// peer.
+	ProposalReceiveDelay metrics.Histogram
+	// Time between the timestamp of a proposal and the receipt of the last
+	// part of its block.
+	ProposalCompleteDelay metrics.Histogram
+	// Time between the receipt of the first and the last part of a proposal
+	// block.
+	BlockPartsCompletionTime metrics.Histogram
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -187,6 +208,60 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "block_parts",
 			Help:      "Number of blockparts transmitted by peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		BlockPartsFirstSeen: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_parts_first_seen",
+			Help:      "Number of block parts first received from a peer.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		ParityParts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "parity_parts",
+			Help:      "Number of parity parts transmitted by peer.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		RebuiltBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rebuilt_blocks",
+			Help:      "Number of proposal blocks rebuilt from their parity parts.",
+		}, labels).With(labelsAndValues...),
+		CompactBlockTxsFound: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compact_block_txs_found",
+			Help:      "Number of txs of the compact blocks received found in the mempool.",
+		}, labels).With(labelsAndValues...),
+		CompactBlockTxsMissing: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compact_block_txs_missing",
+			Help:      "Number of txs of the compact blocks received missing from the mempool.",
+		}, labels).With(labelsAndValues...),
+		ReconstructedBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reconstructed_blocks",
+			Help:      "Number of proposal blocks reconstructed from their compact block.",
+		}, labels).With(labelsAndValues...),
+		ProposalReceiveDelay: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "proposal_receive_delay_seconds",
+			Help:      "Time between the timestamp of a proposal and its first receipt from a peer.",
+		}, labels).With(labelsAndValues...),
+		ProposalCompleteDelay: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "proposal_complete_delay_seconds",
+			Help:      "Time between the timestamp of a proposal and the receipt of the last part of its block.",
+		}, labels).With(labelsAndValues...),
+		BlockPartsCompletionTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_parts_completion_seconds",
+			Help:      "Time between the receipt of the first and the last part of a proposal block.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -217,6 +292,16 @@ func NopMetrics() *Metrics {
 		BlockSyncing:    discard.NewGauge(),
 		StateSyncing:    discard.NewGauge(),
 		BlockParts:      discard.NewCounter(),
+
+		BlockPartsFirstSeen:      discard.NewCounter(),
+		ParityParts:              discard.NewCounter(),
+		RebuiltBlocks:            discard.NewCounter(),
+		CompactBlockTxsFound:     discard.NewCounter(),
+		CompactBlockTxsMissing:   discard.NewCounter(),
+		ReconstructedBlocks:      discard.NewCounter(),
+		ProposalReceiveDelay:     discard.NewHistogram(),
+		ProposalCompleteDelay:    discard.NewHistogram(),
+		BlockPartsCompletionTime: discard.NewHistogram(),
 	}
 }
 
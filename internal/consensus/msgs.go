@@ -5,7 +5,9 @@ import (
 	"fmt"
 
 	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/libs/bits"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
@@ -30,6 +32,10 @@ func init() {
 	tmjson.RegisterType(&HasVoteMessage{}, "tendermint/HasVote")
 	tmjson.RegisterType(&VoteSetMaj23Message{}, "tendermint/VoteSetMaj23")
 	tmjson.RegisterType(&VoteSetBitsMessage{}, "tendermint/VoteSetBits")
+	tmjson.RegisterType(&ParityPartMessage{}, "tendermint/ParityPart")
+	tmjson.RegisterType(&CompactBlockMessage{}, "tendermint/CompactBlock")
+	tmjson.RegisterType(&CompactBlockTxsRequestMessage{}, "tendermint/CompactBlockTxsRequest")
+	tmjson.RegisterType(&CompactBlockTxsMessage{}, "tendermint/CompactBlockTxs")
 }
 
 // NewRoundStepMessage is sent for every step taken in the ConsensusState.
@@ -201,6 +207,167 @@ func (m *BlockPartMessage) String() string {
 	return fmt.Sprintf("[BlockPart H:%v R:%v P:%v]", m.Height, m.Round, m.Part)
 }
 
+// ParityPartMessage is sent when gossipping an erasure-coded parity piece of the
+// proposed block. Its part proves its inclusion in the parity parts of the
+// block with the given part set header, not in the block parts.
+type ParityPartMessage struct {
+	Height        int64
+	Round         int32
+	PartSetHeader types.PartSetHeader
+	BlockSize     int64
+	Part          *types.Part
+}
+
+// ValidateBasic performs basic validation.
+func (m *ParityPartMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if err := m.PartSetHeader.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong PartSetHeader: %v", err)
+	}
+	if m.PartSetHeader.Total == 0 || m.PartSetHeader.Total > maxCodedBlockParts {
+		return fmt.Errorf("parity parts for %d block parts, max: %d", m.PartSetHeader.Total, maxCodedBlockParts)
+	}
+	// the parts of the block are of a size between the default and the max
+	// part size, depending on the block params
+	if m.BlockSize <= int64(m.PartSetHeader.Total-1)*int64(types.BlockPartSizeBytes) ||
+		m.BlockSize > int64(m.PartSetHeader.Total)*int64(types.MaxBlockPartSizeBytes) {
+		return fmt.Errorf("block size %d doesn't match %d block parts", m.BlockSize, m.PartSetHeader.Total)
+	}
+	if m.Part.Index >= m.PartSetHeader.Total {
+		return fmt.Errorf("parity part index %d, max: %d", m.Part.Index, m.PartSetHeader.Total-1)
+	}
+	if err := m.Part.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong Part: %v", err)
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *ParityPartMessage) String() string {
+	return fmt.Sprintf("[ParityPart H:%v R:%v PSH:%v P:%v]", m.Height, m.Round, m.PartSetHeader, m.Part)
+}
+
+// CompactBlockMessage is sent when gossipping the proposed block by the keys of
+// its txs, in place of its parts. Its block has no txs.
+type CompactBlockMessage struct {
+	Height int64
+	Round  int32
+	Block  *types.Block
+	TxKeys [][]byte
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if m.Block == nil {
+		return errors.New("nil Block")
+	}
+	if m.Block.Height != m.Height {
+		return fmt.Errorf("block of height %d, expected %d", m.Block.Height, m.Height)
+	}
+	if err := m.Block.Header.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong Header: %v", err)
+	}
+	if m.Block.LastCommit == nil {
+		return errors.New("nil LastCommit")
+	}
+	if err := m.Block.LastCommit.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong LastCommit: %v", err)
+	}
+	if len(m.Block.Txs) > 0 {
+		return fmt.Errorf("compact block with %d txs", len(m.Block.Txs))
+	}
+	for i, key := range m.TxKeys {
+		if len(key) != mempool.TxKeySize {
+			return fmt.Errorf("tx key %d of %d bytes, expected %d", i, len(key), mempool.TxKeySize)
+		}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockMessage) String() string {
+	return fmt.Sprintf("[CompactBlock H:%v R:%v B:%v T:%d]", m.Height, m.Round, m.Block, len(m.TxKeys))
+}
+
+// CompactBlockTxsRequestMessage is sent to request the txs of a compact block
+// missing from the mempool, by their index in the block.
+type CompactBlockTxsRequestMessage struct {
+	Height    int64
+	Round     int32
+	BlockHash tmbytes.HexBytes
+	Indexes   []uint32
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockTxsRequestMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if len(m.BlockHash) == 0 {
+		return errors.New("empty BlockHash")
+	}
+	if err := types.ValidateHash(m.BlockHash); err != nil {
+		return fmt.Errorf("wrong BlockHash: %v", err)
+	}
+	if len(m.Indexes) == 0 {
+		return errors.New("no tx requested")
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockTxsRequestMessage) String() string {
+	return fmt.Sprintf("[CompactBlockTxsRequest H:%v R:%v B:%v I:%v]", m.Height, m.Round, m.BlockHash, m.Indexes)
+}
+
+// CompactBlockTxsMessage is sent in response to a CompactBlockTxsRequestMessage.
+type CompactBlockTxsMessage struct {
+	Height    int64
+	Round     int32
+	BlockHash tmbytes.HexBytes
+	Indexes   []uint32
+	Txs       types.Txs
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockTxsMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if len(m.BlockHash) == 0 {
+		return errors.New("empty BlockHash")
+	}
+	if err := types.ValidateHash(m.BlockHash); err != nil {
+		return fmt.Errorf("wrong BlockHash: %v", err)
+	}
+	if len(m.Indexes) != len(m.Txs) {
+		return fmt.Errorf("%d txs for %d indexes", len(m.Txs), len(m.Indexes))
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockTxsMessage) String() string {
+	return fmt.Sprintf("[CompactBlockTxs H:%v R:%v B:%v I:%v]", m.Height, m.Round, m.BlockHash, m.Indexes)
+}
+
 // VoteMessage is sent when voting for a proposal (or lack thereof).
 type VoteMessage struct {
 	Vote *types.Vote
@@ -383,6 +550,64 @@ func MsgToProto(msg Message) (*tmcons.Message, error) {
 				},
 			},
 		}
+	case *ParityPartMessage:
+		part, err := msg.Part.ToProto()
+		if err != nil {
+			return nil, fmt.Errorf("msg to proto error: %w", err)
+		}
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_ParityPart{
+				ParityPart: &tmcons.ParityPart{
+					Height:        msg.Height,
+					Round:         msg.Round,
+					PartSetHeader: msg.PartSetHeader.ToProto(),
+					BlockSize:     msg.BlockSize,
+					Part:          *part,
+				},
+			},
+		}
+	case *CompactBlockMessage:
+		block, err := msg.Block.ToProto()
+		if err != nil {
+			return nil, fmt.Errorf("msg to proto error: %w", err)
+		}
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlock{
+				CompactBlock: &tmcons.CompactBlock{
+					Height: msg.Height,
+					Round:  msg.Round,
+					Block:  block,
+					TxKeys: msg.TxKeys,
+				},
+			},
+		}
+	case *CompactBlockTxsRequestMessage:
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlockTxsRequest{
+				CompactBlockTxsRequest: &tmcons.CompactBlockTxsRequest{
+					Height:    msg.Height,
+					Round:     msg.Round,
+					BlockHash: msg.BlockHash,
+					Indexes:   msg.Indexes,
+				},
+			},
+		}
+	case *CompactBlockTxsMessage:
+		txs := make([][]byte, len(msg.Txs))
+		for i, tx := range msg.Txs {
+			txs[i] = tx
+		}
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlockTxs{
+				CompactBlockTxs: &tmcons.CompactBlockTxs{
+					Height:    msg.Height,
+					Round:     msg.Round,
+					BlockHash: msg.BlockHash,
+					Indexes:   msg.Indexes,
+					Txs:       txs,
+				},
+			},
+		}
 	case *VoteMessage:
 		vote := msg.Vote.ToProto()
 		pb = tmcons.Message{
@@ -513,6 +738,52 @@ func MsgFromProto(msg *tmcons.Message) (Message, error) {
 			Round:  msg.BlockPart.Round,
 			Part:   parts,
 		}
+	case *tmcons.Message_ParityPart:
+		psh, err := types.PartSetHeaderFromProto(&msg.ParityPart.PartSetHeader)
+		if err != nil {
+			return nil, fmt.Errorf("parts header to proto error: %w", err)
+		}
+		part, err := types.PartFromProto(&msg.ParityPart.Part)
+		if err != nil {
+			return nil, fmt.Errorf("paritypart msg to proto error: %w", err)
+		}
+		pb = &ParityPartMessage{
+			Height:        msg.ParityPart.Height,
+			Round:         msg.ParityPart.Round,
+			PartSetHeader: *psh,
+			BlockSize:     msg.ParityPart.BlockSize,
+			Part:          part,
+		}
+	case *tmcons.Message_CompactBlock:
+		block, err := compactBlockFromProto(msg.CompactBlock.Block)
+		if err != nil {
+			return nil, fmt.Errorf("compactblock msg to proto error: %w", err)
+		}
+		pb = &CompactBlockMessage{
+			Height: msg.CompactBlock.Height,
+			Round:  msg.CompactBlock.Round,
+			Block:  block,
+			TxKeys: msg.CompactBlock.TxKeys,
+		}
+	case *tmcons.Message_CompactBlockTxsRequest:
+		pb = &CompactBlockTxsRequestMessage{
+			Height:    msg.CompactBlockTxsRequest.Height,
+			Round:     msg.CompactBlockTxsRequest.Round,
+			BlockHash: msg.CompactBlockTxsRequest.BlockHash,
+			Indexes:   msg.CompactBlockTxsRequest.Indexes,
+		}
+	case *tmcons.Message_CompactBlockTxs:
+		txs := make(types.Txs, len(msg.CompactBlockTxs.Txs))
+		for i, tx := range msg.CompactBlockTxs.Txs {
+			txs[i] = tx
+		}
+		pb = &CompactBlockTxsMessage{
+			Height:    msg.CompactBlockTxs.Height,
+			Round:     msg.CompactBlockTxs.Round,
+			BlockHash: msg.CompactBlockTxs.BlockHash,
+			Indexes:   msg.CompactBlockTxs.Indexes,
+			Txs:       txs,
+		}
 	case *tmcons.Message_Vote:
 		vote, err := types.VoteFromProto(msg.Vote.Vote)
 		if err != nil {
@@ -569,6 +840,29 @@ func MsgFromProto(msg *tmcons.Message) (Message, error) {
 	return pb, nil
 }
 
+// compactBlockFromProto converts the block of a compact block, which
+// types.BlockFromProto rejects as its txs don't match its data hash.
+func compactBlockFromProto(bp *tmproto.Block) (*types.Block, error) {
+	if bp == nil {
+		return nil, errors.New("nil block")
+	}
+
+	header, err := types.HeaderFromProto(&bp.Header)
+	if err != nil {
+		return nil, err
+	}
+	data, err := types.DataFromProto(&bp.Data)
+	if err != nil {
+		return nil, err
+	}
+	lastCommit, err := types.CommitFromProto(bp.LastCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Block{Header: header, Data: data, LastCommit: lastCommit}, nil
+}
+
 // WALToProto takes a WAL message and return a proto walMessage and error.
 func WALToProto(msg WALMessage) (*tmcons.WALMessage, error) {
 	var pb tmcons.WALMessage
@@ -0,0 +1,325 @@
+package consensus
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/libs/bits"
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// maxCodedBlockParts is the maximum number of parts of the blocks gossiped
+	// with parity parts, as the Reed-Solomon code over GF(2^8) has at most 256
+	// shares.
+	maxCodedBlockParts = 128
+
+	// ParityPartsFeature is advertised in the NodeInfo of the nodes gossiping
+	// the proposal blocks with parity parts, see ReactorParityParts. They are
+	// only sent to the peers advertising it.
+	ParityPartsFeature = "parity-parts"
+)
+
+// encodeParityParts returns the parity parts of the complete part set parts:
+// the Reed-Solomon parity shares of its parts, padded to the size of the first
+// one, with their proofs of inclusion in the merkle tree of the parity parts.
+// Any parts.Total() of the parts and parity parts rebuild the block.
+func encodeParityParts(parts *types.PartSet) ([]*types.Part, error) {
+	shares := make([][]byte, parts.Total())
+	for i := range shares {
+		shares[i] = parts.GetPart(i).Bytes
+	}
+
+	parity, err := consts.DefaultCodec().Encode(padShares(shares))
+	if err != nil {
+		return nil, err
+	}
+
+	_, proofs := merkle.ProofsFromByteSlices(parity)
+	parityParts := make([]*types.Part, len(parity))
+	for i, bz := range parity {
+		parityParts[i] = &types.Part{Index: uint32(i), Bytes: bz, Proof: *proofs[i]}
+	}
+	return parityParts, nil
+}
+
+// decodeParityParts rebuilds the parts of partSize bytes of the block of
+// blockSize bytes with the given part set header from its shares: its parts
+// followed by its parity parts, nil if missing.
+func decodeParityParts(
+	header types.PartSetHeader,
+	blockSize int64,
+	partSize int,
+	shares [][]byte,
+) (*types.PartSet, error) {
+	data, err := consts.DefaultCodec().Decode(padShares(shares))
+	if err != nil {
+		return nil, err
+	}
+
+	bz := bytes.Join(data, nil)
+	if int64(len(bz)) < blockSize {
+		return nil, fmt.Errorf("rebuilt %d bytes of a block of %d bytes", len(bz), blockSize)
+	}
+	parts := types.NewPartSetFromData(bz[:blockSize], uint32(partSize))
+	if !parts.HasHeader(header) {
+		return nil, fmt.Errorf("rebuilt block parts %v instead of %v", parts.Header(), header)
+	}
+	return parts, nil
+}
+
+// padShares returns the shares padded with zeros to the size of the largest
+// one, as the codec requires, leaving the missing ones nil.
+func padShares(shares [][]byte) [][]byte {
+	size := 0
+	for _, share := range shares {
+		if len(share) > size {
+			size = len(share)
+		}
+	}
+
+	padded := make([][]byte, len(shares))
+	for i, share := range shares {
+		if share == nil {
+			continue
+		}
+		padded[i] = make([]byte, size)
+		copy(padded[i], share)
+	}
+	return padded
+}
+
+// parityParts holds the parity parts of the proposal block of the current
+// height. The originators of the block, which have it before any of its parity
+// parts, compute them and dispatch the parts and parity parts of the block
+// across the peers supporting them, each to a single peer per round. The other
+// nodes relay the parity parts they receive and rebuild the block from any
+// header.Total of its parts and parity parts.
+type parityParts struct {
+	mtx tmsync.Mutex
+
+	height    int64
+	header    types.PartSetHeader
+	blockSize int64
+	partSize  int
+	root      []byte
+	parts     []*types.Part
+	count     int
+	from      types.NodeID
+	rebuilt   bool
+
+	originator    bool
+	dispatchRound int32
+	dispatched    *bits.BitArray
+}
+
+// reset starts collecting the parity parts of the block with the given part
+// set header, unless they already are.
+func (pp *parityParts) reset(height int64, header types.PartSetHeader) {
+	if pp.height == height && pp.header.Equals(header) {
+		return
+	}
+	pp.height = height
+	pp.header = header
+	pp.blockSize = 0
+	pp.partSize = 0
+	pp.root = nil
+	pp.parts = make([]*types.Part, header.Total)
+	pp.count = 0
+	pp.from = ""
+	pp.rebuilt = false
+	pp.originator = false
+	pp.dispatchRound = -1
+	pp.dispatched = nil
+}
+
+// originate computes the parity parts of the complete part set parts, unless
+// we received some of them, and returns whether we originate the block.
+func (pp *parityParts) originate(height int64, parts *types.PartSet) (bool, error) {
+	pp.mtx.Lock()
+	defer pp.mtx.Unlock()
+
+	pp.reset(height, parts.Header())
+	if pp.originator || pp.count > 0 || pp.rebuilt {
+		return pp.originator, nil
+	}
+
+	parityParts, err := encodeParityParts(parts)
+	if err != nil {
+		return false, err
+	}
+	pp.parts = parityParts
+	pp.count = len(parityParts)
+	pp.root = parityParts[0].Proof.ComputeRootHash()
+	pp.blockSize = parts.ByteSize()
+	pp.originator = true
+	return true, nil
+}
+
+// add adds the parity part of msg, received from peerID, and returns whether
+// it was added. The first parity part received for a block pins the root of
+// its parity parts, the size of the block, and the size of its parts, which
+// the block params may grow with the size of the block.
+func (pp *parityParts) add(msg *ParityPartMessage, peerID types.NodeID) (bool, error) {
+	pp.mtx.Lock()
+	defer pp.mtx.Unlock()
+
+	if msg.Height < pp.height {
+		return false, nil
+	}
+	pp.reset(msg.Height, msg.PartSetHeader)
+	if pp.originator || pp.rebuilt || pp.parts[msg.Part.Index] != nil {
+		return false, nil
+	}
+
+	part := msg.Part
+	if part.Proof.Total != int64(pp.header.Total) || part.Proof.Index != int64(part.Index) {
+		return false, fmt.Errorf("parity part %d with a proof for %d/%d", part.Index, part.Proof.Index, part.Proof.Total)
+	}
+	if !isParityPartSize(msg.BlockSize, pp.header.Total, len(part.Bytes)) {
+		return false, fmt.Errorf("parity part of %d bytes for %d block parts of a block of %d bytes",
+			len(part.Bytes), pp.header.Total, msg.BlockSize)
+	}
+
+	if pp.root == nil {
+		pp.root = part.Proof.ComputeRootHash()
+		pp.blockSize = msg.BlockSize
+		pp.partSize = len(part.Bytes)
+	}
+	// The parity parts of another root or size don't help us rebuild the block
+	// with the parity parts we have, but their sender may be honest.
+	if msg.BlockSize != pp.blockSize || len(part.Bytes) != pp.partSize ||
+		part.Proof.Verify(pp.root, part.Bytes) != nil {
+		return false, nil
+	}
+
+	pp.parts[part.Index] = part
+	pp.count++
+	pp.from = peerID
+	return true, nil
+}
+
+// rebuild rebuilds the block from its parts, extra (a part received but
+// possibly not added to them yet) and its parity parts, once we have enough of
+// them. It returns nil if it can't, and the last peer which sent us a parity
+// part. The parity parts are dropped if they don't rebuild the block.
+func (pp *parityParts) rebuild(height int64, parts *types.PartSet, extra *types.Part) (*types.PartSet, types.NodeID, error) {
+	pp.mtx.Lock()
+	defer pp.mtx.Unlock()
+
+	if pp.height != height || !parts.HasHeader(pp.header) || pp.originator || pp.rebuilt || pp.count == 0 {
+		return nil, "", nil
+	}
+
+	total := int(pp.header.Total)
+	shares := make([][]byte, 2*total)
+	count := pp.count
+	for i := 0; i < total; i++ {
+		if part := parts.GetPart(i); part != nil {
+			shares[i] = part.Bytes
+			count++
+		}
+		if part := pp.parts[i]; part != nil {
+			shares[total+i] = part.Bytes
+		}
+	}
+	if extra != nil && int(extra.Index) < total && shares[extra.Index] == nil &&
+		extra.Proof.Verify(pp.header.Hash, extra.Bytes) == nil {
+		shares[extra.Index] = extra.Bytes
+		count++
+	}
+	if count < total {
+		return nil, "", nil
+	}
+
+	rebuilt, err := decodeParityParts(pp.header, pp.blockSize, pp.partSize, shares)
+	if err != nil {
+		pp.parts = make([]*types.Part, total)
+		pp.count = 0
+		pp.root = nil
+		return nil, "", err
+	}
+	pp.rebuilt = true
+	return rebuilt, pp.from, nil
+}
+
+// pick picks the next part, or parity part, of the block of the complete or
+// partial part set parts to send to a peer having the given parts and parity
+// parts, in the given round. The originators dispatch each part and parity
+// part once per round, and no more than the parts needed to rebuild the block
+// to any peer. The other nodes pick a parity part they received.
+func (pp *parityParts) pick(
+	round int32,
+	parts *types.PartSet,
+	peerParts, peerParityParts *bits.BitArray,
+) (*types.Part, *ParityPartMessage) {
+	pp.mtx.Lock()
+	defer pp.mtx.Unlock()
+
+	if !parts.HasHeader(pp.header) {
+		return nil, nil
+	}
+
+	total := int(pp.header.Total)
+	if !pp.originator {
+		candidates := bits.NewBitArray(total)
+		for i, part := range pp.parts {
+			candidates.SetIndex(i, part != nil && !peerParityParts.GetIndex(i))
+		}
+		if index, ok := candidates.PickRandom(); ok {
+			return nil, pp.parityPartMessage(round, index)
+		}
+		return nil, nil
+	}
+
+	if pp.dispatchRound != round {
+		pp.dispatchRound = round
+		pp.dispatched = bits.NewBitArray(2 * total)
+	}
+	known := 0
+	candidates := bits.NewBitArray(2 * total)
+	for i := 0; i < 2*total; i++ {
+		has := i < total && peerParts.GetIndex(i) || i >= total && peerParityParts.GetIndex(i-total)
+		if has {
+			known++
+		}
+		candidates.SetIndex(i, !has && !pp.dispatched.GetIndex(i))
+	}
+	if known >= total {
+		return nil, nil
+	}
+	index, ok := candidates.PickRandom()
+	if !ok {
+		return nil, nil
+	}
+	pp.dispatched.SetIndex(index, true)
+	if index < total {
+		return parts.GetPart(index), nil
+	}
+	return nil, pp.parityPartMessage(round, index-total)
+}
+
+func (pp *parityParts) parityPartMessage(round int32, index int) *ParityPartMessage {
+	return &ParityPartMessage{
+		Height:        pp.height,
+		Round:         round,
+		PartSetHeader: pp.header,
+		BlockSize:     pp.blockSize,
+		Part:          pp.parts[index],
+	}
+}
+
+// isParityPartSize returns whether the parity parts of a block of blockSize
+// bytes split in total parts may be of size bytes: the size of its first part.
+func isParityPartSize(blockSize int64, total uint32, size int) bool {
+	if size <= 0 || size > int(types.MaxBlockPartSizeBytes) {
+		return false
+	}
+	if total == 1 {
+		return int64(size) == blockSize
+	}
+	return (blockSize+int64(size)-1)/int64(size) == int64(total)
+}
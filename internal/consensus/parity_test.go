@@ -0,0 +1,141 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/bits"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestParityPartsEncodeDecode(t *testing.T) {
+	// the blocks of the default part size, and of a larger one
+	for _, partSize := range []uint32{types.BlockPartSizeBytes, 4 * types.BlockPartSizeBytes} {
+		for _, size := range []int{100, int(partSize), 3*int(partSize) + 1000} {
+			parts := types.NewPartSetFromData(tmrand.Bytes(size), partSize)
+			total := int(parts.Total())
+
+			parityParts, err := encodeParityParts(parts)
+			require.NoError(t, err)
+			require.Len(t, parityParts, total)
+
+			// rebuild from the last part and the parity parts but the first one
+			shares := make([][]byte, 2*total)
+			shares[total-1] = parts.GetPart(total - 1).Bytes
+			for i := 1; i < total; i++ {
+				shares[total+i] = parityParts[i].Bytes
+			}
+			rebuilt, err := decodeParityParts(parts.Header(), parts.ByteSize(), int(partSize), shares)
+			require.NoError(t, err)
+			require.True(t, rebuilt.HasHeader(parts.Header()))
+
+			// rebuild from the parity parts only
+			shares = make([][]byte, 2*total)
+			for i := 0; i < total; i++ {
+				shares[total+i] = parityParts[i].Bytes
+			}
+			rebuilt, err = decodeParityParts(parts.Header(), parts.ByteSize(), int(partSize), shares)
+			require.NoError(t, err)
+			require.True(t, rebuilt.HasHeader(parts.Header()))
+
+			// a corrupted parity part doesn't rebuild the block
+			shares[total] = tmrand.Bytes(len(shares[total]))
+			_, err = decodeParityParts(parts.Header(), parts.ByteSize(), int(partSize), shares)
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestParityPartsGossip(t *testing.T) {
+	parts := types.NewPartSetFromData(tmrand.Bytes(3*int(types.BlockPartSizeBytes)+1000), types.BlockPartSizeBytes)
+	total := int(parts.Total())
+
+	origin := &parityParts{}
+	originator, err := origin.originate(1, parts)
+	require.NoError(t, err)
+	require.True(t, originator)
+
+	// the originator dispatches each part and parity part once per round, and
+	// no more than total of them to a peer
+	peerParts := make([]*bits.BitArray, 3)
+	peerParityParts := make([]*bits.BitArray, 3)
+	for i := range peerParts {
+		peerParts[i] = bits.NewBitArray(total)
+		peerParityParts[i] = bits.NewBitArray(total)
+		for {
+			part, parityPart := origin.pick(0, parts, peerParts[i], peerParityParts[i])
+			if part != nil {
+				peerParts[i].SetIndex(int(part.Index), true)
+			} else if parityPart != nil {
+				peerParityParts[i].SetIndex(int(parityPart.Part.Index), true)
+			} else {
+				break
+			}
+		}
+	}
+	require.Equal(t, total, countTrue(peerParts[0])+countTrue(peerParityParts[0]))
+	require.Equal(t, total, countTrue(peerParts[1])+countTrue(peerParityParts[1]))
+	require.True(t, peerParts[2].IsEmpty() && peerParityParts[2].IsEmpty())
+	part, parityPart := origin.pick(1, parts, peerParts[2], peerParityParts[2])
+	require.True(t, part != nil || parityPart != nil)
+
+	// a peer rebuilds the block from its parts and parity parts, dropping the
+	// parity parts of another block
+	partial := types.NewPartSetFromHeader(parts.Header())
+	_, err = partial.AddPart(parts.GetPart(0))
+	require.NoError(t, err)
+	other := &parityParts{}
+	_, err = other.originate(1, types.NewPartSetFromData(tmrand.Bytes(int(parts.ByteSize())), types.BlockPartSizeBytes))
+	require.NoError(t, err)
+
+	relay := &parityParts{}
+	for i := 0; i < total-1; i++ {
+		msg := other.parityPartMessage(0, i)
+		msg.PartSetHeader = parts.Header()
+		added, err := relay.add(msg, "peer")
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	_, _, err = relay.rebuild(1, partial, nil)
+	require.Error(t, err)
+
+	for i := 0; i < total-1; i++ {
+		msg := origin.parityPartMessage(0, i)
+		corrupted := *msg.Part
+		corrupted.Bytes = tmrand.Bytes(len(corrupted.Bytes))
+		added, err := relay.add(&ParityPartMessage{
+			Height:        msg.Height,
+			PartSetHeader: msg.PartSetHeader,
+			BlockSize:     msg.BlockSize,
+			Part:          &corrupted,
+		}, "peer")
+		require.NoError(t, err)
+		require.False(t, added)
+
+		added, err = relay.add(msg, "peer")
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	rebuilt, peerID, err := relay.rebuild(1, partial, nil)
+	require.NoError(t, err)
+	require.Equal(t, types.NodeID("peer"), peerID)
+	require.True(t, rebuilt.HasHeader(parts.Header()))
+
+	// and relays the parity parts it received
+	part, parityPart = relay.pick(0, partial, partial.BitArray(), nil)
+	require.Nil(t, part)
+	require.NotNil(t, parityPart)
+	require.Less(t, int(parityPart.Part.Index), total-1)
+}
+
+func countTrue(bA *bits.BitArray) int {
+	count := 0
+	for i := 0; i < bA.Size(); i++ {
+		if bA.GetIndex(i) {
+			count++
+		}
+	}
+	return count
+}
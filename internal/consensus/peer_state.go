@@ -39,6 +39,12 @@ type PeerState struct {
 	peerID types.NodeID
 	logger log.Logger
 
+	// parityParts is set if the peer advertises the gossip of parity parts.
+	parityParts bool
+
+	// compactBlocks is set if the peer advertises the gossip of compact blocks.
+	compactBlocks bool
+
 	// NOTE: Modify below using setters, never directly.
 	mtx     tmsync.RWMutex
 	running bool
@@ -161,6 +167,41 @@ func (ps *PeerState) SetHasProposalBlockPart(height int64, round int32, index in
 	ps.PRS.ProposalBlockParts.SetIndex(index, true)
 }
 
+// SetHasProposalParityPart sets the given parity part index of the proposal
+// block with the given part set header as known for the peer.
+func (ps *PeerState) SetHasProposalParityPart(height int64, round int32, header types.PartSetHeader, index int) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.PRS.Height != height || ps.PRS.Round != round || !ps.PRS.ProposalBlockPartSetHeader.Equals(header) {
+		return
+	}
+
+	if ps.PRS.ProposalParityParts == nil {
+		ps.PRS.ProposalParityParts = bits.NewBitArray(int(header.Total))
+	}
+	ps.PRS.ProposalParityParts.SetIndex(index, true)
+}
+
+// SetHasProposalCompactBlock records that the compact block of the proposal
+// block was sent to, or received from, the peer. In the latter case, the peer
+// has all the parts of the block.
+func (ps *PeerState) SetHasProposalCompactBlock(height int64, round int32, received bool) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.PRS.Height != height || ps.PRS.Round != round {
+		return
+	}
+
+	ps.PRS.ProposalCompactBlock = true
+	if received && ps.PRS.ProposalBlockParts != nil {
+		for i := 0; i < ps.PRS.ProposalBlockParts.Size(); i++ {
+			ps.PRS.ProposalBlockParts.SetIndex(i, true)
+		}
+	}
+}
+
 // PickVoteToSend picks a vote to send to the peer. It will return true if a
 // vote was picked.
 //
@@ -192,11 +233,19 @@ func (ps *PeerState) PickVoteToSend(votes types.VoteSetReader) (*types.Vote, boo
 		return nil, false // not something worth sending
 	}
 
-	if index, ok := votes.BitArray().Sub(psVotes).PickRandom(); ok {
-		return votes.GetByIndex(int32(index)), true
+	// the votes of an aggregated commit without a signature of their own
+	// can't be sent
+	candidates := votes.BitArray().Sub(psVotes)
+	for {
+		index, ok := candidates.PickRandom()
+		if !ok {
+			return nil, false
+		}
+		if vote := votes.GetByIndex(int32(index)); len(vote.Signature) != 0 {
+			return vote, true
+		}
+		candidates.SetIndex(index, false)
 	}
-
-	return nil, false
 }
 
 func (ps *PeerState) getVoteBitArray(height int64, round int32, votesType tmproto.SignedMsgType) *bits.BitArray {
@@ -406,6 +455,8 @@ func (ps *PeerState) ApplyNewRoundStepMessage(msg *NewRoundStepMessage) {
 		ps.PRS.Proposal = false
 		ps.PRS.ProposalBlockPartSetHeader = types.PartSetHeader{}
 		ps.PRS.ProposalBlockParts = nil
+		ps.PRS.ProposalParityParts = nil
+		ps.PRS.ProposalCompactBlock = false
 		ps.PRS.ProposalPOLRound = -1
 		ps.PRS.ProposalPOL = nil
 
@@ -451,6 +502,10 @@ func (ps *PeerState) ApplyNewValidBlockMessage(msg *NewValidBlockMessage) {
 		return
 	}
 
+	if !ps.PRS.ProposalBlockPartSetHeader.Equals(msg.BlockPartSetHeader) {
+		ps.PRS.ProposalParityParts = nil
+		ps.PRS.ProposalCompactBlock = false
+	}
 	ps.PRS.ProposalBlockPartSetHeader = msg.BlockPartSetHeader
 	ps.PRS.ProposalBlockParts = msg.BlockParts
 }
@@ -0,0 +1,85 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// blockPropagation records when the proposal and the parts of the proposal
+// block of the current height were first received, and from which peers.
+type blockPropagation struct {
+	height int64
+	header types.PartSetHeader
+	data   types.BlockPropagation
+}
+
+// reset starts recording the propagation of the block with the given part
+// set header, unless it is already recorded.
+func (bp *blockPropagation) reset(height int64, header types.PartSetHeader) {
+	if bp.height == height && bp.header.Equals(header) {
+		return
+	}
+	bp.height = height
+	bp.header = header
+	bp.data = types.BlockPropagation{Parts: make([]types.FirstSeen, header.Total)}
+}
+
+// snapshot returns a copy of the propagation data.
+func (bp *blockPropagation) snapshot() *types.BlockPropagation {
+	data := bp.data
+	data.Parts = make([]types.FirstSeen, len(bp.data.Parts))
+	copy(data.Parts, bp.data.Parts)
+	return &data
+}
+
+// recordProposal records the receipt of cs.Proposal.
+func (cs *State) recordProposal(peerID types.NodeID, now time.Time) {
+	if cs.replayMode {
+		return
+	}
+	cs.propagation.reset(cs.Height, cs.Proposal.BlockID.PartSetHeader)
+	if cs.propagation.data.Proposal != nil {
+		return
+	}
+	cs.propagation.data.Proposal = &types.FirstSeen{Time: now, PeerID: peerID}
+	cs.propagation.data.ProposalRound = cs.Proposal.Round
+	if peerID != "" {
+		cs.metrics.ProposalReceiveDelay.Observe(now.Sub(cs.Proposal.Timestamp).Seconds())
+	}
+}
+
+// recordBlockPart records the receipt of a part of cs.ProposalBlockParts and
+// whether it completed the block.
+func (cs *State) recordBlockPart(index uint32, peerID types.NodeID, now time.Time) {
+	if cs.replayMode {
+		return
+	}
+	cs.propagation.reset(cs.Height, cs.ProposalBlockParts.Header())
+	parts := cs.propagation.data.Parts
+	if int(index) >= len(parts) || !parts[index].Time.IsZero() {
+		return
+	}
+	parts[index] = types.FirstSeen{Time: now, PeerID: peerID}
+	if peerID != "" {
+		cs.metrics.BlockPartsFirstSeen.With("peer_id", string(peerID)).Add(1)
+	}
+
+	if !cs.ProposalBlockParts.IsComplete() {
+		return
+	}
+	cs.propagation.data.Complete = now
+	if peerID == "" {
+		return
+	}
+	first := now
+	for _, part := range parts {
+		if part.Time.Before(first) {
+			first = part.Time
+		}
+	}
+	cs.metrics.BlockPartsCompletionTime.Observe(now.Sub(first).Seconds())
+	if proposal := cs.Proposal; proposal != nil && proposal.BlockID.PartSetHeader.Equals(cs.propagation.header) {
+		cs.metrics.ProposalCompleteDelay.Observe(now.Sub(proposal.Timestamp).Seconds())
+	}
+}
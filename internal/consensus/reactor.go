@@ -1,23 +1,34 @@
 package consensus
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"runtime/debug"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/p2p"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/libs/bits"
 	tmevents "github.com/tendermint/tendermint/libs/events"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
+	tmstrings "github.com/tendermint/tendermint/libs/strings"
 	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
 
+// tracer traces the gossip of the proposals, block parts and votes.
+var tracer = otel.Tracer("github.com/tendermint/tendermint/internal/consensus")
+
 var (
 	_ service.Service = (*Reactor)(nil)
 	_ p2p.Wrapper     = (*tmcons.Message)(nil)
@@ -132,6 +143,16 @@ type Reactor struct {
 	peers    map[types.NodeID]*PeerState
 	waitSync bool
 
+	// parityParts enables the gossip of the proposal blocks as erasure-coded
+	// parts to the peers supporting it.
+	parityParts bool
+	parity      parityParts
+
+	// mempool, if set, enables the gossip of the proposal blocks as compact
+	// blocks to the peers supporting it, reconstructed from its txs.
+	mempool mempool.Mempool
+	compact compactBlock
+
 	stateCh       *p2p.Channel
 	dataCh        *p2p.Channel
 	voteCh        *p2p.Channel
@@ -278,6 +299,19 @@ func ReactorMetrics(metrics *Metrics) ReactorOption {
 	return func(r *Reactor) { r.Metrics = metrics }
 }
 
+// ReactorParityParts enables, or disables, the gossip of the proposal blocks as
+// erasure-coded parts to the peers supporting it, as an option function.
+func ReactorParityParts(enabled bool) ReactorOption {
+	return func(r *Reactor) { r.parityParts = enabled }
+}
+
+// ReactorCompactBlocks enables the gossip of the proposal blocks as compact
+// blocks to the peers supporting it, reconstructing the compact blocks received
+// from the txs of mp, as an option function.
+func ReactorCompactBlocks(mp mempool.Mempool) ReactorOption {
+	return func(r *Reactor) { r.mempool = mp }
+}
+
 // SwitchToConsensus switches from block-sync mode to consensus mode. It resets
 // the state, turns off block-sync, and starts the consensus state-machine.
 func (r *Reactor) SwitchToConsensus(state sm.State, skipWAL bool) {
@@ -501,6 +535,7 @@ func (r *Reactor) gossipDataForCatchup(rs *cstypes.RoundState, prs *cstypes.Peer
 		}
 
 		logger.Debug("sending block part for catchup", "round", prs.Round, "index", index)
+		span := startGossipSpan("gossip.BlockPart", ps.peerID, prs.Height, prs.Round)
 		r.dataCh.Out <- p2p.Envelope{
 			To: ps.peerID,
 			Message: &tmcons.BlockPart{
@@ -509,6 +544,7 @@ func (r *Reactor) gossipDataForCatchup(rs *cstypes.RoundState, prs *cstypes.Peer
 				Part:   *partProto,
 			},
 		}
+		span.End()
 
 		return
 	}
@@ -539,8 +575,18 @@ OUTER_LOOP:
 		rs := r.state.GetRoundState()
 		prs := ps.GetRoundState()
 
+		// Send the proposal Block as a compact block, or its parts or parity
+		// parts, to a peer supporting them?
+		sent, skipBlockParts := r.gossipCompactBlock(rs, prs, ps)
+		if !sent && !skipBlockParts {
+			sent, skipBlockParts = r.gossipParityParts(rs, prs, ps)
+		}
+		if sent {
+			continue OUTER_LOOP
+		}
+
 		// Send proposal Block parts?
-		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
+		if !skipBlockParts && rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
 			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
 				part := rs.ProposalBlockParts.GetPart(index)
 				partProto, err := part.ToProto()
@@ -550,6 +596,7 @@ OUTER_LOOP:
 				}
 
 				logger.Debug("sending block part", "height", prs.Height, "round", prs.Round)
+				span := startGossipSpan("gossip.BlockPart", ps.peerID, rs.Height, rs.Round)
 				r.dataCh.Out <- p2p.Envelope{
 					To: ps.peerID,
 					Message: &tmcons.BlockPart{
@@ -558,6 +605,7 @@ OUTER_LOOP:
 						Part:   *partProto,
 					},
 				}
+				span.End()
 
 				ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
 				continue OUTER_LOOP
@@ -611,12 +659,14 @@ OUTER_LOOP:
 				propProto := rs.Proposal.ToProto()
 
 				logger.Debug("sending proposal", "height", prs.Height, "round", prs.Round)
+				span := startGossipSpan("gossip.Proposal", ps.peerID, rs.Proposal.Height, rs.Proposal.Round)
 				r.dataCh.Out <- p2p.Envelope{
 					To: ps.peerID,
 					Message: &tmcons.Proposal{
 						Proposal: *propProto,
 					},
 				}
+				span.End()
 
 				// NOTE: A peer might have received a different proposal message, so
 				// this Proposal msg will be rejected!
@@ -651,17 +701,209 @@ OUTER_LOOP:
 	}
 }
 
+// gossipParityParts sends the next part, or parity part, of the proposal block
+// to a peer supporting parity parts, and returns whether it did. It also
+// returns whether to skip the gossip of block parts to the peer, left to the
+// other peers by the originators of the block until the peer leaves the
+// propose step without it.
+func (r *Reactor) gossipParityParts(
+	rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState,
+	ps *PeerState,
+) (sent bool, skipBlockParts bool) {
+	if !r.parityParts || !ps.parityParts || rs.Height != prs.Height ||
+		!rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) ||
+		rs.ProposalBlockParts.Total() > maxCodedBlockParts {
+		return false, false
+	}
+
+	originator := false
+	if rs.ProposalBlockParts.IsComplete() {
+		var err error
+		if originator, err = r.parity.originate(rs.Height, rs.ProposalBlockParts); err != nil {
+			r.Logger.Error("failed to compute the parity parts", "height", rs.Height, "err", err)
+			return false, false
+		}
+	} else {
+		r.rebuildProposalBlock(rs, nil)
+	}
+
+	part, parityPart := r.parity.pick(rs.Round, rs.ProposalBlockParts, prs.ProposalBlockParts, prs.ProposalParityParts)
+	switch {
+	case part != nil:
+		partProto, err := part.ToProto()
+		if err != nil {
+			r.Logger.Error("failed to convert block part to proto", "err", err)
+			return false, false
+		}
+
+		span := startGossipSpan("gossip.BlockPart", ps.peerID, rs.Height, rs.Round)
+		r.dataCh.Out <- p2p.Envelope{
+			To: ps.peerID,
+			Message: &tmcons.BlockPart{
+				Height: rs.Height,
+				Round:  rs.Round,
+				Part:   *partProto,
+			},
+		}
+		span.End()
+
+		ps.SetHasProposalBlockPart(prs.Height, prs.Round, int(part.Index))
+		return true, true
+
+	case parityPart != nil:
+		partProto, err := parityPart.Part.ToProto()
+		if err != nil {
+			r.Logger.Error("failed to convert parity part to proto", "err", err)
+			return false, false
+		}
+
+		span := startGossipSpan("gossip.ParityPart", ps.peerID, rs.Height, rs.Round)
+		r.dataCh.Out <- p2p.Envelope{
+			To: ps.peerID,
+			Message: &tmcons.ParityPart{
+				Height:        parityPart.Height,
+				Round:         parityPart.Round,
+				PartSetHeader: parityPart.PartSetHeader.ToProto(),
+				BlockSize:     parityPart.BlockSize,
+				Part:          *partProto,
+			},
+		}
+		span.End()
+
+		ps.SetHasProposalParityPart(prs.Height, prs.Round, parityPart.PartSetHeader, int(parityPart.Part.Index))
+		return true, true
+	}
+
+	return false, originator && prs.Round == rs.Round && prs.Step <= cstypes.RoundStepPropose
+}
+
+// rebuildProposalBlock rebuilds the proposal block from its parts, extra and
+// its parity parts, once we have enough of them, and passes the parts it lacks
+// to the consensus state.
+func (r *Reactor) rebuildProposalBlock(rs *cstypes.RoundState, extra *types.Part) {
+	if rs.ProposalBlockParts == nil || rs.ProposalBlockParts.IsComplete() {
+		return
+	}
+
+	parts, peerID, err := r.parity.rebuild(rs.Height, rs.ProposalBlockParts, extra)
+	if err != nil {
+		r.Logger.Error("failed to rebuild the proposal block from its parity parts", "height", rs.Height, "err", err)
+		return
+	}
+	if parts == nil {
+		return
+	}
+
+	r.Logger.Debug("rebuilt the proposal block from its parity parts", "height", rs.Height, "round", rs.Round)
+	r.Metrics.RebuiltBlocks.Add(1)
+	r.queueMissingBlockParts(rs, parts, peerID)
+}
+
+// gossipCompactBlock sends the compact block of the proposal block to a peer
+// supporting compact blocks, and returns whether it did. It also returns
+// whether to skip the gossip of block parts to the peer, which has the propose
+// step to reconstruct the block.
+func (r *Reactor) gossipCompactBlock(
+	rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState,
+	ps *PeerState,
+) (sent bool, skipBlockParts bool) {
+	if r.mempool == nil || !ps.compactBlocks || rs.Height != prs.Height ||
+		!rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
+		return false, false
+	}
+
+	if !rs.ProposalBlockParts.IsComplete() || rs.ProposalBlock == nil {
+		r.reconstructProposalBlock(rs)
+		return false, false
+	}
+
+	if !prs.ProposalCompactBlock && !prs.ProposalBlockParts.IsFull() {
+		msg := r.compact.originate(rs.Round, rs.ProposalBlock)
+		blockProto, err := msg.Block.ToProto()
+		if err != nil {
+			r.Logger.Error("failed to convert compact block to proto", "err", err)
+			return false, false
+		}
+
+		span := startGossipSpan("gossip.CompactBlock", ps.peerID, rs.Height, rs.Round)
+		r.dataCh.Out <- p2p.Envelope{
+			To: ps.peerID,
+			Message: &tmcons.CompactBlock{
+				Height: msg.Height,
+				Round:  msg.Round,
+				Block:  blockProto,
+				TxKeys: msg.TxKeys,
+			},
+		}
+		span.End()
+
+		ps.SetHasProposalCompactBlock(prs.Height, prs.Round, false)
+		return true, true
+	}
+
+	return false, prs.ProposalCompactBlock && prs.Round == rs.Round && prs.Step <= cstypes.RoundStepPropose
+}
+
+// reconstructProposalBlock passes the parts of the proposal block it lacks,
+// once reconstructed from its compact block, to the consensus state.
+func (r *Reactor) reconstructProposalBlock(rs *cstypes.RoundState) {
+	if rs.Proposal == nil || !rs.ProposalBlockParts.HasHeader(rs.Proposal.BlockID.PartSetHeader) ||
+		rs.ProposalBlockParts.IsComplete() {
+		return
+	}
+
+	parts, peerID, err := r.compact.take(rs.Height, rs.Proposal.BlockID, r.state.GetBlockParams())
+	if err != nil {
+		r.Logger.Error("failed to reconstruct the proposal block from its compact block", "height", rs.Height, "err", err)
+		return
+	}
+	if parts == nil {
+		return
+	}
+
+	r.Logger.Debug("reconstructed the proposal block from its compact block", "height", rs.Height, "round", rs.Round)
+	r.Metrics.ReconstructedBlocks.Add(1)
+	r.queueMissingBlockParts(rs, parts, peerID)
+}
+
+// queueMissingBlockParts passes the parts of the complete part set parts which
+// the proposal block lacks to the consensus state, as received from peerID.
+func (r *Reactor) queueMissingBlockParts(rs *cstypes.RoundState, parts *types.PartSet, peerID types.NodeID) {
+	have := rs.ProposalBlockParts.BitArray()
+	for i := 0; i < int(parts.Total()); i++ {
+		if have.GetIndex(i) {
+			continue
+		}
+		r.queueMsg(msgInfo{&BlockPartMessage{Height: rs.Height, Round: rs.Round, Part: parts.GetPart(i)}, peerID})
+	}
+}
+
+// queueMsg passes a peer message to the consensus state. It gives up once the
+// reactor is stopping, as the stopped state no longer reads its queue while
+// the peers, e.g. catching us up, may still fill it.
+func (r *Reactor) queueMsg(mi msgInfo) {
+	select {
+	case r.state.peerMsgQueue <- mi:
+	case <-r.closeCh:
+	}
+}
+
 // pickSendVote picks a vote and sends it to the peer. It will return true if
 // there is a vote to send and false otherwise.
 func (r *Reactor) pickSendVote(ps *PeerState, votes types.VoteSetReader) bool {
 	if vote, ok := ps.PickVoteToSend(votes); ok {
 		r.Logger.Debug("sending vote message", "ps", ps, "vote", vote)
+		span := startGossipSpan("gossip.Vote", ps.peerID, vote.Height, vote.Round)
+		span.SetAttributes(attribute.String("type", vote.Type.String()))
 		r.voteCh.Out <- p2p.Envelope{
 			To: ps.peerID,
 			Message: &tmcons.Vote{
 				Vote: vote.ToProto(),
 			},
 		}
+		span.End()
 
 		ps.SetHasVote(vote)
 		return true
@@ -670,6 +912,17 @@ func (r *Reactor) pickSendVote(ps *PeerState, votes types.VoteSetReader) bool {
 	return false
 }
 
+// startGossipSpan starts the span of the sending of a consensus message to a
+// peer, which lasts until the message is queued.
+func startGossipSpan(name string, peerID types.NodeID, height int64, round int32) trace.Span {
+	_, span := tracer.Start(context.Background(), name, trace.WithAttributes(
+		attribute.String("peer_id", string(peerID)),
+		attribute.Int64("height", height),
+		attribute.Int64("round", int64(round)),
+	))
+	return span
+}
+
 func (r *Reactor) gossipVotesForHeight(rs *cstypes.RoundState, prs *cstypes.PeerRoundState, ps *PeerState) bool {
 	logger := r.Logger.With("height", prs.Height).With("peer", ps.peerID)
 
@@ -953,6 +1206,8 @@ func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
 		ps, ok = r.peers[peerUpdate.NodeID]
 		if !ok {
 			ps = NewPeerState(r.Logger, peerUpdate.NodeID)
+			ps.parityParts = tmstrings.StringInSlice(ParityPartsFeature, peerUpdate.Features)
+			ps.compactBlocks = tmstrings.StringInSlice(CompactBlocksFeature, peerUpdate.Features)
 			r.peers[peerUpdate.NodeID] = ps
 		}
 
@@ -1105,7 +1360,7 @@ func (r *Reactor) handleDataMessage(envelope p2p.Envelope, msgI Message) error {
 		pMsg := msgI.(*ProposalMessage)
 
 		ps.SetHasProposal(pMsg.Proposal)
-		r.state.peerMsgQueue <- msgInfo{pMsg, envelope.From}
+		r.queueMsg(msgInfo{pMsg, envelope.From})
 
 	case *tmcons.ProposalPOL:
 		ps.ApplyProposalPOLMessage(msgI.(*ProposalPOLMessage))
@@ -1115,7 +1370,107 @@ func (r *Reactor) handleDataMessage(envelope p2p.Envelope, msgI Message) error {
 
 		ps.SetHasProposalBlockPart(bpMsg.Height, bpMsg.Round, int(bpMsg.Part.Index))
 		r.Metrics.BlockParts.With("peer_id", string(envelope.From)).Add(1)
-		r.state.peerMsgQueue <- msgInfo{bpMsg, envelope.From}
+		r.queueMsg(msgInfo{bpMsg, envelope.From})
+		if r.parityParts {
+			if rs := r.state.GetRoundState(); rs.Height == bpMsg.Height {
+				r.rebuildProposalBlock(rs, bpMsg.Part)
+			}
+		}
+
+	case *tmcons.ParityPart:
+		ppMsg := msgI.(*ParityPartMessage)
+
+		ps.SetHasProposalParityPart(ppMsg.Height, ppMsg.Round, ppMsg.PartSetHeader, int(ppMsg.Part.Index))
+		r.Metrics.ParityParts.With("peer_id", string(envelope.From)).Add(1)
+		if !r.parityParts {
+			return nil
+		}
+
+		// Only keep the parity parts of the proposal block, or of a block of the
+		// current height until we have its proposal.
+		rs := r.state.GetRoundState()
+		if ppMsg.Height != rs.Height ||
+			rs.ProposalBlockParts != nil && !rs.ProposalBlockParts.HasHeader(ppMsg.PartSetHeader) {
+			return nil
+		}
+		added, err := r.parity.add(ppMsg, envelope.From)
+		if err != nil {
+			return err
+		}
+		if added {
+			r.rebuildProposalBlock(rs, nil)
+		}
+
+	case *tmcons.CompactBlock:
+		cbMsg := msgI.(*CompactBlockMessage)
+
+		ps.SetHasProposalCompactBlock(cbMsg.Height, cbMsg.Round, true)
+		if r.mempool == nil {
+			return nil
+		}
+
+		// Only keep the compact block of the proposal block, or of a block of the
+		// current height until we have its proposal.
+		rs := r.state.GetRoundState()
+		if cbMsg.Height != rs.Height || rs.ProposalBlockParts != nil && rs.ProposalBlockParts.IsComplete() ||
+			rs.Proposal != nil && !bytes.Equal(rs.Proposal.BlockID.Hash, cbMsg.Block.Hash()) {
+			return nil
+		}
+		found, missing := r.compact.add(cbMsg, envelope.From, r.mempool)
+		r.Metrics.CompactBlockTxsFound.Add(float64(found))
+		r.Metrics.CompactBlockTxsMissing.Add(float64(len(missing)))
+		if len(missing) > 0 {
+			r.dataCh.Out <- p2p.Envelope{
+				To: envelope.From,
+				Message: &tmcons.CompactBlockTxsRequest{
+					Height:    cbMsg.Height,
+					Round:     cbMsg.Round,
+					BlockHash: cbMsg.Block.Hash(),
+					Indexes:   missing,
+				},
+			}
+		}
+		r.reconstructProposalBlock(rs)
+
+	case *tmcons.CompactBlockTxsRequest:
+		reqMsg := msgI.(*CompactBlockTxsRequestMessage)
+
+		rs := r.state.GetRoundState()
+		block := rs.ProposalBlock
+		if r.mempool == nil || reqMsg.Height != rs.Height || block == nil || !bytes.Equal(block.Hash(), reqMsg.BlockHash) {
+			return nil
+		}
+		txs := make([][]byte, len(reqMsg.Indexes))
+		for i, index := range reqMsg.Indexes {
+			if int(index) >= len(block.Txs) {
+				return fmt.Errorf("request of tx %d of a block of %d txs", index, len(block.Txs))
+			}
+			txs[i] = block.Txs[index]
+		}
+		r.dataCh.Out <- p2p.Envelope{
+			To: envelope.From,
+			Message: &tmcons.CompactBlockTxs{
+				Height:    reqMsg.Height,
+				Round:     reqMsg.Round,
+				BlockHash: reqMsg.BlockHash,
+				Indexes:   reqMsg.Indexes,
+				Txs:       txs,
+			},
+		}
+
+	case *tmcons.CompactBlockTxs:
+		txsMsg := msgI.(*CompactBlockTxsMessage)
+
+		if r.mempool == nil {
+			return nil
+		}
+		added, err := r.compact.addTxs(txsMsg)
+		if err != nil {
+			return err
+		}
+		if added {
+			r.reconstructProposalBlock(r.state.GetRoundState())
+		}
 
 	default:
 		return fmt.Errorf("received unknown message on DataChannel: %T", msg)
@@ -1154,7 +1509,7 @@ func (r *Reactor) handleVoteMessage(envelope p2p.Envelope, msgI Message) error {
 		ps.EnsureVoteBitArrays(height-1, lastCommitSize)
 		ps.SetHasVote(vMsg.Vote)
 
-		r.state.peerMsgQueue <- msgInfo{vMsg, envelope.From}
+		r.queueMsg(msgInfo{vMsg, envelope.From})
 
 	default:
 		return fmt.Errorf("received unknown message on VoteChannel: %T", msg)
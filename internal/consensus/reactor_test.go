@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/fortytw2/leaktest"
+	"github.com/go-kit/kit/metrics/generic"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	dbm "github.com/tendermint/tm-db"
@@ -56,17 +57,40 @@ func chDesc(chID p2p.ChannelID) p2p.ChannelDescriptor {
 	}
 }
 
-func setup(t *testing.T, numNodes int, states []*State, size int) *reactorTestSuite {
+func setup(t *testing.T, numNodes int, states []*State, size int, options ...ReactorOption) *reactorTestSuite {
+	t.Helper()
+
+	return setupWithFeatures(t, numNodes, states, size, func(int) []string { return nil }, options...)
+}
+
+// setupWithFeatures is setup with the i-th node advertising features(i) in its
+// NodeInfo. The reactors are created in the order of the nodes, the options of
+// the i-th call being those of the i-th node.
+func setupWithFeatures(
+	t *testing.T,
+	numNodes int,
+	states []*State,
+	size int,
+	features func(i int) []string,
+	options ...ReactorOption,
+) *reactorTestSuite {
 	t.Helper()
 
 	rts := &reactorTestSuite{
-		network:       p2ptest.MakeNetwork(t, p2ptest.NetworkOptions{NumNodes: numNodes}),
+		network:       p2ptest.MakeNetwork(t, p2ptest.NetworkOptions{}),
 		states:        make(map[types.NodeID]*State),
 		reactors:      make(map[types.NodeID]*Reactor, numNodes),
 		subs:          make(map[types.NodeID]types.Subscription, numNodes),
 		blocksyncSubs: make(map[types.NodeID]types.Subscription, numNodes),
 	}
 
+	nodeIDs := make([]types.NodeID, numNodes)
+	for i := range nodeIDs {
+		node := rts.network.MakeNode(t, p2ptest.NodeOptions{Features: features(i)})
+		rts.network.Nodes[node.NodeID] = node
+		nodeIDs[i] = node.NodeID
+	}
+
 	rts.stateChannels = rts.network.MakeChannelsNoCleanup(t, chDesc(StateChannel), new(tmcons.Message), size)
 	rts.dataChannels = rts.network.MakeChannelsNoCleanup(t, chDesc(DataChannel), new(tmcons.Message), size)
 	rts.voteChannels = rts.network.MakeChannelsNoCleanup(t, chDesc(VoteChannel), new(tmcons.Message), size)
@@ -74,8 +98,8 @@ func setup(t *testing.T, numNodes int, states []*State, size int) *reactorTestSu
 
 	_, cancel := context.WithCancel(context.Background())
 
-	i := 0
-	for nodeID, node := range rts.network.Nodes {
+	for i, nodeID := range nodeIDs {
+		node := rts.network.Nodes[nodeID]
 		state := states[i]
 
 		reactor := NewReactor(
@@ -87,6 +111,7 @@ func setup(t *testing.T, numNodes int, states []*State, size int) *reactorTestSu
 			rts.voteSetBitsChannels[nodeID],
 			node.MakePeerUpdates(t),
 			true,
+			options...,
 		)
 
 		reactor.SetEventBus(state.eventBus)
@@ -109,8 +134,6 @@ func setup(t *testing.T, numNodes int, states []*State, size int) *reactorTestSu
 
 		require.NoError(t, reactor.Start())
 		require.True(t, reactor.IsRunning())
-
-		i++
 	}
 
 	require.Len(t, rts.reactors, numNodes)
@@ -315,6 +338,144 @@ func TestReactorBasic(t *testing.T) {
 	wg.Wait()
 }
 
+func TestReactorParityParts(t *testing.T) {
+	testCases := []struct {
+		name    string
+		enabled int // number of nodes gossiping the parity parts
+	}{
+		{"all nodes", 4},
+		{"mixed nodes", 2},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := configSetup(t)
+
+			n := 4
+			states, cleanup := randConsensusState(t,
+				cfg, n, "consensus_reactor_test",
+				newMockTickerFunc(true), newKVStore)
+			t.Cleanup(cleanup)
+
+			metrics := NopMetrics()
+			rebuiltBlocks := generic.NewCounter("rebuilt_blocks")
+			metrics.RebuiltBlocks = rebuiltBlocks
+
+			// enable the parity parts on the first tc.enabled nodes only, the
+			// others being unaware of them
+			var created int
+			parityParts := func(r *Reactor) {
+				ReactorParityParts(created < tc.enabled)(r)
+				created++
+			}
+			features := func(i int) []string {
+				if i < tc.enabled {
+					return []string{ParityPartsFeature}
+				}
+				return nil
+			}
+
+			rts := setupWithFeatures(t, n, states, 100, features, ReactorMetrics(metrics), parityParts)
+
+			for _, reactor := range rts.reactors {
+				state := reactor.state.GetState()
+				reactor.SwitchToConsensus(state, false)
+			}
+
+			// wait till everyone makes the first three blocks
+			var wg sync.WaitGroup
+			for _, sub := range rts.subs {
+				wg.Add(1)
+
+				go func(s types.Subscription) {
+					defer wg.Done()
+					for i := 0; i < 3; i++ {
+						<-s.Out()
+					}
+				}(sub)
+			}
+
+			wg.Wait()
+
+			if tc.enabled == n {
+				require.Greater(t, rebuiltBlocks.Value(), 0.0, "no block was rebuilt from its parity parts")
+			}
+		})
+	}
+}
+
+func TestReactorCompactBlocks(t *testing.T) {
+	testCases := []struct {
+		name    string
+		enabled int // number of nodes gossiping the compact blocks
+	}{
+		{"all nodes", 4},
+		{"mixed nodes", 2},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := configSetup(t)
+
+			n := 4
+			states, cleanup := randConsensusState(t,
+				cfg, n, "consensus_reactor_test",
+				newMockTickerFunc(true), newKVStore)
+			t.Cleanup(cleanup)
+
+			metrics := NopMetrics()
+			reconstructedBlocks := generic.NewCounter("reconstructed_blocks")
+			metrics.ReconstructedBlocks = reconstructedBlocks
+
+			// enable the compact blocks on the first tc.enabled nodes only, the
+			// others being unaware of them
+			var created int
+			compactBlocks := func(r *Reactor) {
+				if created < tc.enabled {
+					ReactorCompactBlocks(assertMempool(r.state.txNotifier))(r)
+				}
+				created++
+			}
+			features := func(i int) []string {
+				if i < tc.enabled {
+					return []string{CompactBlocksFeature}
+				}
+				return nil
+			}
+
+			rts := setupWithFeatures(t, n, states, 100, features, ReactorMetrics(metrics), compactBlocks)
+
+			for _, reactor := range rts.reactors {
+				require.NoError(t, assertMempool(reactor.state.txNotifier).CheckTx(
+					context.Background(), types.Tx("key=value"), nil, mempool.TxInfo{}))
+				state := reactor.state.GetState()
+				reactor.SwitchToConsensus(state, false)
+			}
+
+			// wait till everyone makes the first three blocks
+			var wg sync.WaitGroup
+			for _, sub := range rts.subs {
+				wg.Add(1)
+
+				go func(s types.Subscription) {
+					defer wg.Done()
+					for i := 0; i < 3; i++ {
+						<-s.Out()
+					}
+				}(sub)
+			}
+
+			wg.Wait()
+
+			if tc.enabled == n {
+				require.Greater(t, reconstructedBlocks.Value(), 0.0, "no block was reconstructed from its compact block")
+			}
+		})
+	}
+}
+
 func TestReactorWithEvidence(t *testing.T) {
 	cfg := configSetup(t)
 
@@ -206,7 +206,14 @@ type Handshaker struct {
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
 
-	nBlocks int // number of blocks applied to the state
+	// loads the app_state of the genesis doc, if it was left out of genDoc
+	appStateLoader func() ([]byte, error)
+
+	nBlocks       int  // number of blocks applied to the state
+	finalizeBlock bool // the app executes the blocks with FinalizeBlock
+
+	// the reports of the app hash mismatches are written to this file, if set
+	mismatchReportFile string
 }
 
 func NewHandshaker(stateStore sm.Store, state sm.State,
@@ -233,11 +240,29 @@ func (h *Handshaker) SetEventBus(eventBus types.BlockEventPublisher) {
 	h.eventBus = eventBus
 }
 
+// SetAppStateLoader sets the function loading the app_state of the genesis
+// doc for InitChain, if the genesis doc was loaded without it.
+func (h *Handshaker) SetAppStateLoader(loader func() ([]byte, error)) {
+	h.appStateLoader = loader
+}
+
+// SetMismatchReportFile sets the file the report of an app hash mismatch
+// found while replaying the blocks is written to.
+func (h *Handshaker) SetMismatchReportFile(path string) {
+	h.mismatchReportFile = path
+}
+
 // NBlocks returns the number of blocks applied to the state.
 func (h *Handshaker) NBlocks() int {
 	return h.nBlocks
 }
 
+// FinalizeBlock returns whether the app said in Info that it executes the
+// blocks with FinalizeBlock.
+func (h *Handshaker) FinalizeBlock() bool {
+	return h.finalizeBlock
+}
+
 // TODO: retry the handshake/replay if it fails ?
 func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 
@@ -258,7 +283,9 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 		"hash", appHash,
 		"software-version", res.Version,
 		"protocol-version", res.AppVersion,
+		"finalize-block", res.FinalizeBlock,
 	)
+	h.finalizeBlock = res.FinalizeBlock
 
 	// Only set the version if there is no existing state.
 	if h.initialState.LastBlockHeight == 0 {
@@ -268,7 +295,7 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 	// Replay blocks up to the latest in the blockstore.
 	_, err = h.ReplayBlocks(h.initialState, appHash, blockHeight, proxyApp)
 	if err != nil {
-		return fmt.Errorf("error on replay: %v", err)
+		return fmt.Errorf("error on replay: %w", err)
 	}
 
 	h.logger.Info("Completed ABCI Handshake - Tendermint and App are synced",
@@ -309,13 +336,20 @@ func (h *Handshaker) ReplayBlocks(
 		validatorSet := types.NewValidatorSet(validators)
 		nextVals := types.TM2PB.ValidatorUpdates(validatorSet)
 		pbParams := h.genDoc.ConsensusParams.ToProto()
+		appState := h.genDoc.AppState
+		if len(appState) == 0 && h.appStateLoader != nil {
+			var err error
+			if appState, err = h.appStateLoader(); err != nil {
+				return nil, fmt.Errorf("failed to load the genesis app_state: %w", err)
+			}
+		}
 		req := abci.RequestInitChain{
 			Time:            h.genDoc.GenesisTime,
 			ChainId:         h.genDoc.ChainID,
 			InitialHeight:   h.genDoc.InitialHeight,
 			ConsensusParams: &pbParams,
 			Validators:      nextVals,
-			AppStateBytes:   h.genDoc.AppState,
+			AppStateBytes:   appState,
 		}
 		res, err := proxyApp.Consensus().InitChainSync(context.Background(), req)
 		if err != nil {
@@ -356,11 +390,26 @@ func (h *Handshaker) ReplayBlocks(
 		}
 	}
 
+	// With the pipelined commits, the node may have crashed before saving the
+	// state of the last block the app committed, while the next block was
+	// already stored: catch the state up with the app first, using the mock app.
+	if storeBlockHeight == stateBlockHeight+2 && appBlockHeight == stateBlockHeight+1 {
+		abciResponses, err := h.stateStore.LoadABCIResponses(appBlockHeight)
+		if err != nil {
+			return nil, err
+		}
+		mockApp := newMockProxyApp(appHash, abciResponses)
+		h.logger.Info("Replay the block of the unsaved state using mock app", "height", appBlockHeight)
+		if state, err = h.replayBlock(state, appBlockHeight, mockApp); err != nil {
+			return nil, err
+		}
+		stateBlockHeight = state.LastBlockHeight
+	}
+
 	// First handle edge cases and constraints on the storeBlockHeight and storeBlockBase.
 	switch {
 	case storeBlockHeight == 0:
-		assertAppHashEqualsOneFromState(appHash, state)
-		return appHash, nil
+		return appHash, h.checkStateAppHash(appHash, state)
 
 	case appBlockHeight == 0 && state.InitialHeight < storeBlockBase:
 		// the app has no state, and the block store is truncated above the initial height
@@ -395,8 +444,7 @@ func (h *Handshaker) ReplayBlocks(
 
 		} else if appBlockHeight == storeBlockHeight {
 			// We're good!
-			assertAppHashEqualsOneFromState(appHash, state)
-			return appHash, nil
+			return appHash, h.checkStateAppHash(appHash, state)
 		}
 
 	} else if storeBlockHeight == stateBlockHeight+1 {
@@ -466,23 +514,26 @@ func (h *Handshaker) replayBlocks(
 		block := h.store.LoadBlock(i)
 		// Extra check to ensure the app was not changed in a way it shouldn't have.
 		if len(appHash) > 0 {
-			assertAppHashEqualsOneFromBlock(appHash, block)
+			if err := h.checkAppHash(block.Height, block.AppHash, appHash); err != nil {
+				return nil, err
+			}
 		}
 
 		if i == finalBlock && !mutateState {
 			// We emit events for the index services at the final block due to the sync issue when
 			// the node shutdown during the block committing status.
 			blockExec := sm.NewBlockExecutor(
-				h.stateStore, h.logger, proxyApp.Consensus(), emptyMempool{}, sm.EmptyEvidencePool{}, h.store)
+				h.stateStore, h.logger, proxyApp.Consensus(), emptyMempool{}, sm.EmptyEvidencePool{}, h.store,
+				sm.BlockExecutorWithFinalizeBlock(h.finalizeBlock))
 			blockExec.SetEventBus(h.eventBus)
-			appHash, err = sm.ExecCommitBlock(
-				blockExec, proxyApp.Consensus(), block, h.logger, h.stateStore, h.genDoc.InitialHeight, state)
+			appHash, err = sm.ExecCommitBlock(blockExec, proxyApp.Consensus(), block, h.logger, h.stateStore,
+				h.genDoc.InitialHeight, state, h.finalizeBlock)
 			if err != nil {
 				return nil, err
 			}
 		} else {
-			appHash, err = sm.ExecCommitBlock(
-				nil, proxyApp.Consensus(), block, h.logger, h.stateStore, h.genDoc.InitialHeight, state)
+			appHash, err = sm.ExecCommitBlock(nil, proxyApp.Consensus(), block, h.logger, h.stateStore,
+				h.genDoc.InitialHeight, state, h.finalizeBlock)
 			if err != nil {
 				return nil, err
 			}
@@ -500,8 +551,7 @@ func (h *Handshaker) replayBlocks(
 		appHash = state.AppHash
 	}
 
-	assertAppHashEqualsOneFromState(appHash, state)
-	return appHash, nil
+	return appHash, h.checkStateAppHash(appHash, state)
 }
 
 // ApplyBlock on the proxyApp with the last block.
@@ -511,7 +561,9 @@ func (h *Handshaker) replayBlock(state sm.State, height int64, proxyApp proxy.Ap
 
 	// Use stubs for both mempool and evidence pool since no transactions nor
 	// evidence are needed here - block already exists.
-	blockExec := sm.NewBlockExecutor(h.stateStore, h.logger, proxyApp, emptyMempool{}, sm.EmptyEvidencePool{}, h.store)
+	blockExec := sm.NewBlockExecutor(h.stateStore, h.logger, proxyApp, emptyMempool{}, sm.EmptyEvidencePool{}, h.store,
+		sm.BlockExecutorWithFinalizeBlock(h.finalizeBlock),
+		sm.BlockExecutorWithMismatchReportFile(h.mismatchReportFile))
 	blockExec.SetEventBus(h.eventBus)
 
 	var err error
@@ -525,24 +577,28 @@ func (h *Handshaker) replayBlock(state sm.State, height int64, proxyApp proxy.Ap
 	return state, nil
 }
 
-func assertAppHashEqualsOneFromBlock(appHash []byte, block *types.Block) {
-	if !bytes.Equal(appHash, block.AppHash) {
-		panic(fmt.Sprintf(`block.AppHash does not match AppHash after replay. Got %X, expected %X.
-
-Block: %v
-`,
-			appHash, block.AppHash, block))
+// checkAppHash returns an ErrAppHashMismatch, after writing its report, if the
+// app hash computed by the app doesn't match the one of the block of the
+// height.
+func (h *Handshaker) checkAppHash(height int64, expected, got []byte) error {
+	if bytes.Equal(expected, got) {
+		return nil
+	}
+	mismatch := sm.ErrAppHashMismatch{Field: "AppHash", Height: height, Expected: expected, Got: got}
+	if h.mismatchReportFile != "" {
+		report := sm.NewMismatchReport(mismatch, h.stateStore, h.store)
+		if err := report.SaveAs(h.mismatchReportFile); err != nil {
+			h.logger.Error("failed to save the app hash mismatch report", "err", err)
+		}
 	}
+	return mismatch
 }
 
-func assertAppHashEqualsOneFromState(appHash []byte, state sm.State) {
-	if !bytes.Equal(appHash, state.AppHash) {
-		panic(fmt.Sprintf(`state.AppHash does not match AppHash after replay. Got
-%X, expected %X.
-
-State: %v
-
-Did you reset Tendermint without resetting your application's data?`,
-			appHash, state.AppHash, state))
+// checkStateAppHash checks the app hash computed by the app against the one
+// of the state, held by the next block.
+func (h *Handshaker) checkStateAppHash(appHash []byte, state sm.State) error {
+	if err := h.checkAppHash(state.LastBlockHeight+1, state.AppHash, appHash); err != nil {
+		return fmt.Errorf("%w. Did you reset Tendermint without resetting your application's data?", err)
 	}
+	return nil
 }
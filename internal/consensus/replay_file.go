@@ -289,14 +289,18 @@ func (pb *playback) replayConsoleLoop() int {
 func newConsensusStateForReplay(cfg config.BaseConfig, csConfig *config.ConsensusConfig) *State {
 	dbType := dbm.BackendType(cfg.DBBackend)
 	// Get BlockStore
-	blockStoreDB, err := dbm.NewDB("blockstore", dbType, cfg.DBDir())
+	blockStoreDB, err := config.NewDB("blockstore", dbType, cfg.DBDir())
 	if err != nil {
 		tmos.Exit(err.Error())
 	}
-	blockStore := store.NewBlockStore(blockStoreDB)
+	archive, err := store.ArchiveFromConfig(cfg)
+	if err != nil {
+		tmos.Exit(err.Error())
+	}
+	blockStore := store.NewBlockStore(blockStoreDB, store.BlockStoreWithArchive(archive))
 
 	// Get State
-	stateDB, err := dbm.NewDB("state", dbType, cfg.DBDir())
+	stateDB, err := config.NewDB("state", dbType, cfg.DBDir())
 	if err != nil {
 		tmos.Exit(err.Error())
 	}
@@ -311,7 +315,8 @@ func newConsensusStateForReplay(cfg config.BaseConfig, csConfig *config.Consensu
 	}
 
 	// Create proxyAppConn connection (consensus, mempool, query)
-	clientCreator, _ := proxy.DefaultClientCreator(cfg.ProxyApp, cfg.ABCI, cfg.DBDir())
+	clientCreator, _ := proxy.DefaultClientCreator(cfg.ProxyApp, cfg.ABCI, cfg.DBDir(),
+		proxy.GRPCClientOptions(cfg)...)
 	proxyApp := proxy.NewAppConns(clientCreator)
 	err = proxyApp.Start()
 	if err != nil {
@@ -331,7 +336,8 @@ func newConsensusStateForReplay(cfg config.BaseConfig, csConfig *config.Consensu
 	}
 
 	mempool, evpool := emptyMempool{}, sm.EmptyEvidencePool{}
-	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(), mempool, evpool, blockStore)
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(), mempool, evpool, blockStore,
+		sm.BlockExecutorWithFinalizeBlock(handshaker.FinalizeBlock()))
 
 	consensusState := NewState(csConfig, state.Copy(), blockExec,
 		blockStore, mempool, evpool)
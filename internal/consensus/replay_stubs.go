@@ -21,6 +21,9 @@ var _ mempool.Mempool = emptyMempool{}
 func (emptyMempool) Lock()     {}
 func (emptyMempool) Unlock()   {}
 func (emptyMempool) Size() int { return 0 }
+func (emptyMempool) GetTxByKey(_ [mempool.TxKeySize]byte) (types.Tx, bool) {
+	return nil, false
+}
 func (emptyMempool) CheckTx(_ context.Context, _ types.Tx, _ func(*abci.Response), _ mempool.TxInfo) error {
 	return nil
 }
@@ -40,6 +43,8 @@ func (emptyMempool) FlushAppConn() error           { return nil }
 func (emptyMempool) TxsAvailable() <-chan struct{} { return make(chan struct{}) }
 func (emptyMempool) EnableTxsAvailable()           {}
 func (emptyMempool) SizeBytes() int64              { return 0 }
+func (emptyMempool) SetLimits(int, int64)          {}
+func (emptyMempool) Limits() (int, int64)          { return 0, 0 }
 
 func (emptyMempool) TxsFront() *clist.CElement    { return nil }
 func (emptyMempool) TxsWaitChan() <-chan struct{} { return nil }
@@ -3,6 +3,7 @@ package consensus
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -933,7 +934,7 @@ func buildTMStateFromChain(
 	return state
 }
 
-func TestHandshakePanicsIfAppReturnsWrongAppHash(t *testing.T) {
+func TestHandshakeFailsIfAppReturnsWrongAppHash(t *testing.T) {
 	// 1. Initialize tendermint and commit 3 blocks with the following app hashes:
 	//		- 0x01
 	//		- 0x02
@@ -953,7 +954,7 @@ func TestHandshakePanicsIfAppReturnsWrongAppHash(t *testing.T) {
 	blocks := sf.MakeBlocks(3, &state, privVal)
 	store.chain = blocks
 
-	// 2. Tendermint must panic if app returns wrong hash for the first block
+	// 2. Tendermint must fail if app returns wrong hash for the first block
 	//		- RANDOM HASH
 	//		- 0x02
 	//		- 0x03
@@ -969,15 +970,13 @@ func TestHandshakePanicsIfAppReturnsWrongAppHash(t *testing.T) {
 			}
 		})
 
-		assert.Panics(t, func() {
-			h := NewHandshaker(stateStore, state, store, genDoc)
-			if err = h.Handshake(proxyApp); err != nil {
-				t.Log(err)
-			}
-		})
+		h := NewHandshaker(stateStore, state, store, genDoc)
+		err = h.Handshake(proxyApp)
+		var mismatch sm.ErrAppHashMismatch
+		require.True(t, errors.As(err, &mismatch), err)
 	}
 
-	// 3. Tendermint must panic if app returns wrong hash for the last block
+	// 3. Tendermint must fail if app returns wrong hash for the last block
 	//		- 0x01
 	//		- 0x02
 	//		- RANDOM HASH
@@ -993,12 +992,19 @@ func TestHandshakePanicsIfAppReturnsWrongAppHash(t *testing.T) {
 			}
 		})
 
-		assert.Panics(t, func() {
-			h := NewHandshaker(stateStore, state, store, genDoc)
-			if err = h.Handshake(proxyApp); err != nil {
-				t.Log(err)
-			}
-		})
+		reportFile := filepath.Join(cfg.RootDir, "mismatch_report.json")
+		h := NewHandshaker(stateStore, state, store, genDoc)
+		h.SetMismatchReportFile(reportFile)
+		err = h.Handshake(proxyApp)
+		var mismatch sm.ErrAppHashMismatch
+		require.True(t, errors.As(err, &mismatch), err)
+
+		// the report of the mismatch is written to the file
+		report, err := sm.LoadMismatchReport(reportFile)
+		require.NoError(t, err)
+		assert.Equal(t, mismatch.Height, report.Height)
+		assert.EqualValues(t, mismatch.Expected, report.Expected)
+		assert.EqualValues(t, mismatch.Got, report.Got)
 	}
 }
 
@@ -0,0 +1,455 @@
+package consensus
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// simNetwork runs the consensus state machines of validators on a simulated
+// network, in virtual time, without reactors: the timeouts of the nodes and
+// the deliveries of their messages are events of a queue, processed one at a
+// time in the order of their virtual time. The latency, drops and partitions
+// of the network are drawn from a seeded source, so that a run is replayed by
+// its seed, e.g. to debug a liveness or safety failure found by a test.
+//
+// The nodes broadcast their proposals, block parts and votes as they make
+// them, and gossip every gossipInterval the messages of the height of each
+// peer it is missing, as the reactor does, for the messages dropped or held
+// by a partition to eventually be delivered. The messages are encoded and
+// decoded as on the wire.
+//
+// The content of the blocks and votes isn't reproduced, their timestamps
+// being those of the wall clock, but the events and their order are.
+type simNetwork struct {
+	t     *testing.T
+	nodes []*simNode
+	rng   *rand.Rand
+
+	started bool
+	now     time.Duration
+	seq     int
+	events  simEvents
+	trace   []string
+
+	minLatency, maxLatency time.Duration
+	dropRate               float64
+	gossipInterval         time.Duration
+}
+
+type simNode struct {
+	index int
+	peer  types.NodeID
+	cs    *State
+
+	partition int                 // the nodes of different partitions can't communicate
+	log       map[int64][]Message // the messages the node has, by height
+	logged    map[string]bool     // the keys of the messages of the log
+	timeout   *simEvent           // the pending timeout
+	lastTi    timeoutInfo         // the last timeout scheduled
+}
+
+// simEvent is a timeout of a node, the delivery of a message to it, or an
+// action on the network, at a virtual time.
+type simEvent struct {
+	at   time.Duration
+	seq  int
+	node int
+
+	timeout   *timeoutInfo
+	msg       Message
+	from      int
+	action    func()
+	cancelled bool
+}
+
+type simEvents []*simEvent
+
+func (e simEvents) Len() int { return len(e) }
+func (e simEvents) Less(i, j int) bool {
+	if e[i].at != e[j].at {
+		return e[i].at < e[j].at
+	}
+	return e[i].seq < e[j].seq
+}
+func (e simEvents) Swap(i, j int)       { e[i], e[j] = e[j], e[i] }
+func (e *simEvents) Push(x interface{}) { *e = append(*e, x.(*simEvent)) }
+func (e *simEvents) Pop() interface{} {
+	old := *e
+	ev := old[len(old)-1]
+	*e = old[:len(old)-1]
+	return ev
+}
+
+// simTicker schedules the timeouts of a node as events of the network.
+type simTicker struct {
+	net  *simNetwork
+	node int
+}
+
+func (*simTicker) Start() error                     { return nil }
+func (*simTicker) Stop() error                      { return nil }
+func (*simTicker) Chan() <-chan timeoutInfo         { return nil }
+func (*simTicker) SetLogger(log.Logger)             {}
+func (t *simTicker) ScheduleTimeout(ti timeoutInfo) { t.net.scheduleTimeout(t.node, ti) }
+
+// newSimNetwork returns a network of n validators of equal power, of the
+// seed, with a latency of 10 to 50ms and no drops.
+func newSimNetwork(t *testing.T, n int, seed int64) *simNetwork {
+	cfg := configSetup(t)
+	states, cleanup := randConsensusState(t, cfg, n, "consensus_simulation_test",
+		newMockTickerFunc(false), newKVStore)
+	t.Cleanup(cleanup)
+
+	net := &simNetwork{
+		t:              t,
+		rng:            rand.New(rand.NewSource(seed)),
+		minLatency:     10 * time.Millisecond,
+		maxLatency:     50 * time.Millisecond,
+		gossipInterval: 20 * time.Millisecond,
+	}
+	for i, cs := range states {
+		cs := cs
+		cs.SetTimeoutTicker(&simTicker{net: net, node: i})
+		require.NoError(t, cs.evsw.Start())
+		t.Cleanup(func() { _ = cs.evsw.Stop() })
+		net.nodes = append(net.nodes, &simNode{
+			index:  i,
+			peer:   types.NodeID(fmt.Sprintf("%040x", i+1)),
+			cs:     cs,
+			log:    make(map[int64][]Message),
+			logged: make(map[string]bool),
+		})
+	}
+	return net
+}
+
+// at schedules the action on the network, e.g. a partition, at the virtual
+// time.
+func (net *simNetwork) at(at time.Duration, action func()) {
+	net.push(&simEvent{at: at, node: -1, action: action})
+}
+
+// partition splits the network in the partitions of the nodes, the nodes not
+// listed being in a partition of their own.
+func (net *simNetwork) partition(partitions ...[]int) {
+	for i, node := range net.nodes {
+		node.partition = len(partitions) + i
+	}
+	for p, nodes := range partitions {
+		for _, i := range nodes {
+			net.nodes[i].partition = p
+		}
+	}
+}
+
+// heal removes the partitions of the network.
+func (net *simNetwork) heal() {
+	for _, node := range net.nodes {
+		node.partition = 0
+	}
+}
+
+// height returns the height of the last block committed by the node.
+func (net *simNetwork) height(i int) int64 {
+	return net.nodes[i].cs.blockStore.Height()
+}
+
+// minHeight returns the height of the last block committed by all the
+// nodes.
+func (net *simNetwork) minHeight() int64 {
+	min := net.height(0)
+	for i := range net.nodes {
+		if h := net.height(i); h < min {
+			min = h
+		}
+	}
+	return min
+}
+
+// run starts the nodes, if they aren't yet, and processes the events until
+// done returns true, failing the test if it doesn't by the virtual deadline.
+// It checks that the nodes never commit different blocks.
+func (net *simNetwork) run(deadline time.Duration, done func() bool) {
+	if !net.started {
+		net.started = true
+		for i, node := range net.nodes {
+			node.cs.scheduleRound0(node.cs.GetRoundState())
+			net.drain(i)
+		}
+		net.scheduleGossip()
+	}
+
+	for !done() {
+		require.NotZero(net.t, net.events.Len(), "no more events")
+		ev := heap.Pop(&net.events).(*simEvent)
+		if ev.cancelled {
+			continue
+		}
+		require.LessOrEqual(net.t, ev.at, deadline,
+			"not done by %v: the nodes are at heights %v", deadline, net.heights())
+		net.now = ev.at
+		net.process(ev)
+	}
+	net.checkSafety()
+}
+
+func (net *simNetwork) heights() []int64 {
+	heights := make([]int64, len(net.nodes))
+	for i := range net.nodes {
+		heights[i] = net.height(i)
+	}
+	return heights
+}
+
+// checkSafety fails the test if two nodes committed different blocks at a
+// height.
+func (net *simNetwork) checkSafety() {
+	for i := 1; i < len(net.nodes); i++ {
+		for h := int64(1); h <= net.height(0) && h <= net.height(i); h++ {
+			a := net.nodes[0].cs.blockStore.LoadBlockMeta(h)
+			b := net.nodes[i].cs.blockStore.LoadBlockMeta(h)
+			require.Equal(net.t, a.BlockID, b.BlockID, "nodes 0 and %d committed different blocks at height %d", i, h)
+		}
+	}
+}
+
+func (net *simNetwork) push(ev *simEvent) {
+	net.seq++
+	ev.seq = net.seq
+	heap.Push(&net.events, ev)
+}
+
+func (net *simNetwork) process(ev *simEvent) {
+	switch {
+	case ev.action != nil:
+		ev.action()
+
+	case ev.timeout != nil:
+		node := net.nodes[ev.node]
+		node.timeout = nil
+		net.trace = append(net.trace, fmt.Sprintf("%v %d timeout %v/%v/%v",
+			net.now, ev.node, ev.timeout.Height, ev.timeout.Round, ev.timeout.Step))
+		node.cs.handleTimeout(*ev.timeout, *node.cs.GetRoundState())
+		net.drain(ev.node)
+
+	default:
+		node := net.nodes[ev.node]
+		net.trace = append(net.trace, fmt.Sprintf("%v %d<-%d %s", net.now, ev.node, ev.from, simMsgKey(ev.msg)))
+		node.cs.handleMsg(msgInfo{Msg: ev.msg, PeerID: net.nodes[ev.from].peer})
+		if net.has(node, ev.msg) {
+			net.record(node, ev.msg)
+		}
+		net.drain(ev.node)
+	}
+}
+
+// drain processes the messages the node sent itself, its proposals, block
+// parts and votes, and broadcasts them.
+func (net *simNetwork) drain(i int) {
+	node := net.nodes[i]
+	for {
+		select {
+		case mi := <-node.cs.internalMsgQueue:
+			node.cs.handleMsg(mi)
+			net.record(node, mi.Msg)
+			for j := range net.nodes {
+				if j != i {
+					net.send(i, j, mi.Msg)
+				}
+			}
+		case <-node.cs.statsMsgQueue:
+		default:
+			return
+		}
+	}
+}
+
+// send sends the message to the node j, unless dropped or held by a
+// partition, after a latency.
+func (net *simNetwork) send(i, j int, msg Message) {
+	if net.nodes[i].partition != net.nodes[j].partition {
+		return
+	}
+	if net.dropRate > 0 && net.rng.Float64() < net.dropRate {
+		return
+	}
+	latency := net.minLatency
+	if net.maxLatency > net.minLatency {
+		latency += time.Duration(net.rng.Int63n(int64(net.maxLatency - net.minLatency)))
+	}
+
+	pb, err := MsgToProto(msg)
+	require.NoError(net.t, err)
+	decoded, err := MsgFromProto(pb)
+	require.NoError(net.t, err)
+	net.push(&simEvent{at: net.now + latency, node: j, from: i, msg: decoded})
+}
+
+// scheduleGossip sends each node the messages of its height its peers have
+// and it is missing, every gossip interval.
+func (net *simNetwork) scheduleGossip() {
+	net.at(net.now+net.gossipInterval, func() {
+		for _, to := range net.nodes {
+			height := to.cs.GetRoundState().Height
+			for _, from := range net.nodes {
+				if from == to {
+					continue
+				}
+				for _, msg := range from.log[height] {
+					if !net.has(to, msg) {
+						net.send(from.index, to.index, msg)
+					}
+				}
+			}
+		}
+		net.scheduleGossip()
+	})
+}
+
+// record adds the message to the log of the node, for it to gossip.
+func (net *simNetwork) record(node *simNode, msg Message) {
+	key := simMsgKey(msg)
+	if node.logged[key] {
+		return
+	}
+	node.logged[key] = true
+	height := simMsgHeight(msg)
+	node.log[height] = append(node.log[height], msg)
+}
+
+// has returns whether the node has the message, or no longer needs it, as
+// the reactor tracks it for its peers.
+func (net *simNetwork) has(node *simNode, msg Message) bool {
+	rs := node.cs.GetRoundState()
+	if simMsgHeight(msg) != rs.Height {
+		return simMsgHeight(msg) < rs.Height
+	}
+	switch msg := msg.(type) {
+	case *ProposalMessage:
+		return msg.Proposal.Round < rs.Round || (msg.Proposal.Round == rs.Round && rs.Proposal != nil)
+	case *BlockPartMessage:
+		parts := rs.ProposalBlockParts
+		return parts != nil && (parts.IsComplete() ||
+			(msg.Part.Index < parts.Total() && parts.GetPart(int(msg.Part.Index)) != nil))
+	case *VoteMessage:
+		var votes *types.VoteSet
+		if msg.Vote.Type == tmproto.PrevoteType {
+			votes = rs.Votes.Prevotes(msg.Vote.Round)
+		} else {
+			votes = rs.Votes.Precommits(msg.Vote.Round)
+		}
+		return votes != nil && votes.GetByIndex(msg.Vote.ValidatorIndex) != nil
+	default:
+		return true
+	}
+}
+
+// scheduleTimeout schedules the timeout as the TimeoutTicker does, replacing
+// the pending timeout of the node unless for an earlier step. The timeouts
+// of the new heights are those of the config, their durations being computed
+// from the wall clock.
+func (net *simNetwork) scheduleTimeout(i int, ti timeoutInfo) {
+	node := net.nodes[i]
+	last := node.lastTi
+	if ti.Height < last.Height ||
+		(ti.Height == last.Height && (ti.Round < last.Round ||
+			(ti.Round == last.Round && last.Step > 0 && ti.Step <= last.Step))) {
+		return
+	}
+	if ti.Step == cstypes.RoundStepNewHeight {
+		if ti.Duration > 0 {
+			ti.Duration = node.cs.config.TimeoutCommit
+		} else {
+			ti.Duration = 0
+		}
+	}
+	node.lastTi = ti
+	if node.timeout != nil {
+		node.timeout.cancelled = true
+	}
+	node.timeout = &simEvent{at: net.now + ti.Duration, node: i, timeout: &ti}
+	net.push(node.timeout)
+}
+
+func simMsgHeight(msg Message) int64 {
+	switch msg := msg.(type) {
+	case *ProposalMessage:
+		return msg.Proposal.Height
+	case *BlockPartMessage:
+		return msg.Height
+	case *VoteMessage:
+		return msg.Vote.Height
+	default:
+		return 0
+	}
+}
+
+// simMsgKey identifies the message in the logs and the trace.
+func simMsgKey(msg Message) string {
+	switch msg := msg.(type) {
+	case *ProposalMessage:
+		return fmt.Sprintf("proposal/%d/%d", msg.Proposal.Height, msg.Proposal.Round)
+	case *BlockPartMessage:
+		return fmt.Sprintf("part/%d/%d/%d", msg.Height, msg.Round, msg.Part.Index)
+	case *VoteMessage:
+		return fmt.Sprintf("vote/%d/%d/%d/%d",
+			msg.Vote.Height, msg.Vote.Round, msg.Vote.Type, msg.Vote.ValidatorIndex)
+	default:
+		return fmt.Sprintf("%T", msg)
+	}
+}
+
+func TestSimNetwork(t *testing.T) {
+	run := func(seed int64) []string {
+		net := newSimNetwork(t, 4, seed)
+		net.run(10*time.Second, func() bool { return net.minHeight() >= 5 })
+		return net.trace
+	}
+
+	// a run is replayed by its seed
+	trace := run(1)
+	require.Equal(t, trace, run(1))
+	require.NotEqual(t, trace, run(2))
+}
+
+func TestSimNetworkPartition(t *testing.T) {
+	net := newSimNetwork(t, 4, 1)
+	net.run(10*time.Second, func() bool { return net.minHeight() >= 2 })
+
+	// no partition has +2/3 of the voting power
+	start := net.now
+	heights := net.heights()
+	net.partition([]int{0, 1}, []int{2, 3})
+	net.run(start+5*time.Second, func() bool { return net.now >= start+4*time.Second })
+	for i, height := range net.heights() {
+		require.LessOrEqual(t, height, heights[i]+1, "node %d committed without a majority", i)
+	}
+
+	// the network recovers once healed, catching up the messages it missed
+	net.heal()
+	net.run(start+20*time.Second, func() bool { return net.minHeight() >= heights[0]+3 })
+}
+
+func TestSimNetworkDrops(t *testing.T) {
+	net := newSimNetwork(t, 4, 1)
+	net.dropRate = 0.3
+	net.minLatency, net.maxLatency = 50*time.Millisecond, 500*time.Millisecond
+	net.run(time.Minute, func() bool { return net.minHeight() >= 5 })
+
+	// a validator down, the others keep committing
+	net.partition([]int{0, 1, 2})
+	height := net.minHeight()
+	net.run(net.now+time.Minute, func() bool {
+		return net.height(0) >= height+3 && net.height(1) >= height+3 && net.height(2) >= height+3
+	})
+}
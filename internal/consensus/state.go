@@ -128,6 +128,12 @@ type State struct {
 	// for tests where we want to limit the number of transitions the state makes
 	nSteps int
 
+	// first receipts of the proposal and block parts of the current height
+	propagation blockPropagation
+
+	// checks the invariants of the state machine, in paranoid mode only
+	invariants *invariantChecker
+
 	// some functions can be overwritten for testing
 	decideProposal func(height int64, round int32)
 	doPrevote      func(height int64, round int32)
@@ -183,6 +189,10 @@ func NewState(
 	cs.doPrevote = cs.defaultDoPrevote
 	cs.setProposal = cs.defaultSetProposal
 
+	if cfg.ParanoidMode {
+		cs.invariants = &invariantChecker{}
+	}
+
 	// We have no votes, so reconstruct LastCommit from SeenCommit.
 	if state.LastBlockHeight > 0 {
 		cs.reconstructLastCommit(state)
@@ -230,6 +240,13 @@ func (cs *State) GetState() sm.State {
 	return cs.state.Copy()
 }
 
+// GetBlockParams returns the block params of the current height.
+func (cs *State) GetBlockParams() types.BlockParams {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	return cs.state.ConsensusParams.Block
+}
+
 // GetLastHeight returns the last height committed.
 // If there were no blocks, returns 0.
 func (cs *State) GetLastHeight() int64 {
@@ -260,6 +277,18 @@ func (cs *State) GetRoundStateSimpleJSON() ([]byte, error) {
 	return tmjson.Marshal(cs.RoundState.RoundStateSimple())
 }
 
+// GetRoundInfo returns the height, round and step of the round in progress,
+// and the address of its proposer.
+func (cs *State) GetRoundInfo() (int64, int32, string, types.Address) {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	var proposer types.Address
+	if cs.Validators != nil && cs.Validators.Size() > 0 {
+		proposer = cs.Validators.GetProposer().Address
+	}
+	return cs.Height, cs.Round, cs.Step.String(), proposer
+}
+
 // GetValidators returns a copy of the current validators.
 func (cs *State) GetValidators() (int64, []*types.Validator) {
 	cs.mtx.RLock()
@@ -851,6 +880,7 @@ func (cs *State) receiveRoutine(maxSteps int) {
 func (cs *State) handleMsg(mi msgInfo) {
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
+	defer cs.checkInvariants()
 
 	var (
 		added bool
@@ -863,7 +893,11 @@ func (cs *State) handleMsg(mi msgInfo) {
 	case *ProposalMessage:
 		// will not cause transition.
 		// once proposal is set, we can receive block parts
+		hadProposal := cs.Proposal != nil
 		err = cs.setProposal(msg.Proposal)
+		if err == nil && !hadProposal && cs.Proposal != nil {
+			cs.recordProposal(peerID, tmtime.Now())
+		}
 
 	case *BlockPartMessage:
 		// if the proposal is complete, we'll enterPrevote or tryFinalizeCommit
@@ -934,6 +968,7 @@ func (cs *State) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 	// the timeout will now cause a state transition
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
+	defer cs.checkInvariants()
 
 	switch ti.Step {
 	case cstypes.RoundStepNewHeight:
@@ -975,6 +1010,7 @@ func (cs *State) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 func (cs *State) handleTxsAvailable() {
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
+	defer cs.checkInvariants()
 
 	// We only need to do this for round 0.
 	if cs.Round != 0 {
@@ -1319,6 +1355,28 @@ func (cs *State) defaultDoPrevote(height int64, round int32) {
 		return
 	}
 
+	// The proposal block must be split as the block params say, for the nodes
+	// syncing it to derive the same block ID from it.
+	partSize := cs.state.ConsensusParams.Block.PartSize(int(cs.ProposalBlockParts.ByteSize()))
+	if !cs.ProposalBlockParts.HasPartSize(partSize) {
+		logger.Error("prevote step: ProposalBlock is not split in parts of the block params", "part_size", partSize)
+		cs.signAddVote(tmproto.PrevoteType, nil, types.PartSetHeader{})
+		return
+	}
+
+	// The application may reject a valid block, prevote nil then.
+	accept, err := cs.blockExec.ProcessProposal(cs.ProposalBlock)
+	if err != nil {
+		logger.Error("prevote step: failed to process ProposalBlock", "err", err)
+		cs.signAddVote(tmproto.PrevoteType, nil, types.PartSetHeader{})
+		return
+	}
+	if !accept {
+		logger.Error("prevote step: ProposalBlock rejected by the application")
+		cs.signAddVote(tmproto.PrevoteType, nil, types.PartSetHeader{})
+		return
+	}
+
 	// Prevote cs.ProposalBlock
 	// NOTE: the proposal signature is validated when it is received,
 	// and the proposal block parts are validated as they are received (against the merkle hash in the proposal)
@@ -1633,6 +1691,7 @@ func (cs *State) finalizeCommit(height int64) {
 	}
 
 	if err := cs.blockExec.ValidateBlock(cs.state, block); err != nil {
+		cs.blockExec.ReportMismatch(err)
 		panic(fmt.Errorf("+2/3 committed an invalid block: %w", err))
 	}
 
@@ -1887,6 +1946,9 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID types.NodeID
 	if err != nil {
 		return added, err
 	}
+	if added {
+		cs.recordBlockPart(part.Index, peerID, tmtime.Now())
+	}
 	if cs.ProposalBlockParts.ByteSize() > cs.state.ConsensusParams.Block.MaxBytes {
 		return added, fmt.Errorf("total size of proposal block parts exceeds maximum block bytes (%d > %d)",
 			cs.ProposalBlockParts.ByteSize(), cs.state.ConsensusParams.Block.MaxBytes,
@@ -1914,7 +1976,11 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID types.NodeID
 		// NOTE: it's possible to receive complete proposal blocks for future rounds without having the proposal
 		cs.Logger.Info("received complete proposal block", "height", cs.ProposalBlock.Height, "hash", cs.ProposalBlock.Hash())
 
-		if err := cs.eventBus.PublishEventCompleteProposal(cs.CompleteProposalEvent()); err != nil {
+		event := cs.CompleteProposalEvent()
+		if cs.propagation.height == height && cs.propagation.header.Equals(cs.ProposalBlockParts.Header()) {
+			event.Propagation = cs.propagation.snapshot()
+		}
+		if err := cs.eventBus.PublishEventCompleteProposal(event); err != nil {
 			cs.Logger.Error("failed publishing event complete proposal", "err", err)
 		}
 
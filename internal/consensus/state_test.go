@@ -249,6 +249,53 @@ func TestStateBadProposal(t *testing.T) {
 	signAddVotes(config, cs1, tmproto.PrecommitType, propBlock.Hash(), propBlock.MakePartSet(partSize).Header(), vs2)
 }
 
+func TestStateBlockPropagation(t *testing.T) {
+	config := configSetup(t)
+
+	cs1, vss := randState(config, 2)
+	height, round := cs1.Height, cs1.Round
+	vs2 := vss[1]
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+
+	propBlock, _ := cs1.createProposalBlock()
+
+	// make the second validator the proposer by incrementing round
+	round++
+	incrementRound(vss[1:]...)
+
+	propBlockParts := propBlock.MakePartSet(types.BlockPartSizeBytes)
+	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+	proposal := types.NewProposal(vs2.Height, round, -1, blockID)
+	p := proposal.ToProto()
+	require.NoError(t, vs2.SignProposal(context.Background(), config.ChainID(), p))
+	proposal.Signature = p.Signature
+
+	start := time.Now()
+	require.NoError(t, cs1.SetProposalAndBlock(proposal, propBlock, propBlockParts, "some peer"))
+	startTestRound(cs1, height, round)
+
+	select {
+	case msg := <-proposalCh:
+		event := msg.Data().(types.EventDataCompleteProposal)
+		require.Equal(t, blockID, event.BlockID)
+		propagation := event.Propagation
+		require.NotNil(t, propagation)
+		require.NotNil(t, propagation.Proposal)
+		assert.Equal(t, types.NodeID("some peer"), propagation.Proposal.PeerID)
+		assert.Equal(t, round, propagation.ProposalRound)
+		assert.False(t, propagation.Proposal.Time.Before(start))
+		require.Len(t, propagation.Parts, int(propBlockParts.Total()))
+		for _, part := range propagation.Parts {
+			assert.Equal(t, types.NodeID("some peer"), part.PeerID)
+			assert.False(t, part.Time.Before(propagation.Proposal.Time))
+			assert.False(t, propagation.Complete.Before(part.Time))
+		}
+	case <-time.After(ensureTimeout):
+		t.Fatal("timed out waiting for the complete proposal")
+	}
+}
+
 func TestStateOversizedBlock(t *testing.T) {
 	config := configSetup(t)
 
@@ -24,6 +24,10 @@ type PeerRoundState struct {
 	Proposal                   bool                `json:"proposal"`
 	ProposalBlockPartSetHeader types.PartSetHeader `json:"proposal_block_part_set_header"`
 	ProposalBlockParts         *bits.BitArray      `json:"proposal_block_parts"`
+	// nil until a parity part of the proposal block is sent or received.
+	ProposalParityParts *bits.BitArray `json:"proposal_parity_parts"`
+	// True if the compact block of the proposal block was sent or received.
+	ProposalCompactBlock bool `json:"proposal_compact_block"`
 	// Proposal's POL round. -1 if none.
 	ProposalPOLRound int32 `json:"proposal_pol_round"`
 
@@ -62,6 +66,7 @@ func (prs PeerRoundState) Copy() PeerRoundState {
 		Hash:  hashCopy,
 	}
 	prs.ProposalBlockParts = prs.ProposalBlockParts.Copy()
+	prs.ProposalParityParts = prs.ProposalParityParts.Copy()
 	prs.ProposalPOL = prs.ProposalPOL.Copy()
 	prs.Prevotes = prs.Prevotes.Copy()
 	prs.Precommits = prs.Precommits.Copy()
@@ -63,7 +63,11 @@ func NewFromConfig(logger log.Logger, cfg *config.Config) (*Inspector, error) {
 	if err != nil {
 		return nil, err
 	}
-	bs := store.NewBlockStore(bsDB)
+	archive, err := store.ArchiveFromConfig(cfg.BaseConfig)
+	if err != nil {
+		return nil, err
+	}
+	bs := store.NewBlockStore(bsDB, store.BlockStoreWithArchive(archive))
 	sDB, err := config.DefaultDBProvider(&config.DBContext{ID: "state", Config: cfg})
 	if err != nil {
 		return nil, err
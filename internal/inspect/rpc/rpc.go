@@ -44,11 +44,15 @@ func Routes(cfg config.RPCConfig, s state.Store, bs state.BlockStore, es []index
 		"block":            server.NewRPCFunc(env.Block, "height", true),
 		"block_by_hash":    server.NewRPCFunc(env.BlockByHash, "hash", true),
 		"block_results":    server.NewRPCFunc(env.BlockResults, "height", true),
+		"namespaced_data":  server.NewRPCFunc(env.NamespacedData, "height,namespace_id", true),
 		"commit":           server.NewRPCFunc(env.Commit, "height", true),
 		"validators":       server.NewRPCFunc(env.Validators, "height,page,per_page", true),
 		"tx":               server.NewRPCFunc(env.Tx, "hash,prove", true),
-		"tx_search":        server.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by", false),
-		"block_search":     server.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by", false),
+		"tx_search":        server.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by,cursor,limit,count_total", false),
+		"block_search":     server.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by,cursor,limit,count_total", false),
+
+		"data_availability_header": server.NewRPCFunc(env.DataAvailabilityHeader, "height", true),
+		"share":                    server.NewRPCFunc(env.Share, "height,row,col", true),
 	}
 }
 
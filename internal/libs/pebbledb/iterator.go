@@ -0,0 +1,93 @@
+package pebbledb
+
+import (
+	"github.com/cockroachdb/pebble"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// pebbleDBIterator iterates over the keys within the bounds of the source
+// iterator, which are the start and the end of its domain.
+type pebbleDBIterator struct {
+	source    *pebble.Iterator
+	start     []byte
+	end       []byte
+	isReverse bool
+	isInvalid bool
+}
+
+var _ dbm.Iterator = (*pebbleDBIterator)(nil)
+
+func newPebbleDBIterator(source *pebble.Iterator, start, end []byte, isReverse bool) *pebbleDBIterator {
+	if isReverse {
+		source.Last()
+	} else {
+		source.First()
+	}
+	return &pebbleDBIterator{
+		source:    source,
+		start:     start,
+		end:       end,
+		isReverse: isReverse,
+	}
+}
+
+// Domain implements Iterator.
+func (itr *pebbleDBIterator) Domain() ([]byte, []byte) {
+	return itr.start, itr.end
+}
+
+// Valid implements Iterator.
+func (itr *pebbleDBIterator) Valid() bool {
+	// Once invalid, forever invalid.
+	if itr.isInvalid {
+		return false
+	}
+
+	// If source errors or is invalid, invalid.
+	if itr.source.Error() != nil || !itr.source.Valid() {
+		itr.isInvalid = true
+		return false
+	}
+
+	return true
+}
+
+// Key implements Iterator.
+func (itr *pebbleDBIterator) Key() []byte {
+	// the key of the source is only valid until it moves
+	itr.assertIsValid()
+	return cp(itr.source.Key())
+}
+
+// Value implements Iterator.
+func (itr *pebbleDBIterator) Value() []byte {
+	// the value of the source is only valid until it moves
+	itr.assertIsValid()
+	return cp(itr.source.Value())
+}
+
+// Next implements Iterator.
+func (itr *pebbleDBIterator) Next() {
+	itr.assertIsValid()
+	if itr.isReverse {
+		itr.source.Prev()
+	} else {
+		itr.source.Next()
+	}
+}
+
+// Error implements Iterator.
+func (itr *pebbleDBIterator) Error() error {
+	return itr.source.Error()
+}
+
+// Close implements Iterator.
+func (itr *pebbleDBIterator) Close() error {
+	return itr.source.Close()
+}
+
+func (itr *pebbleDBIterator) assertIsValid() {
+	if !itr.Valid() {
+		panic("iterator is invalid")
+	}
+}
@@ -0,0 +1,178 @@
+// Package pebbledb implements a tm-db database backed by Pebble
+// (github.com/cockroachdb/pebble), a key-value store inspired by RocksDB which
+// doesn't stall the writes while it compacts, unlike goleveldb.
+package pebbledb
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cockroachdb/pebble"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// BackendType is the type of the Pebble backend, in the db-backend option of
+// the config.
+const BackendType dbm.BackendType = "pebble"
+
+var (
+	errBatchClosed = errors.New("batch has been written or closed")
+	errKeyEmpty    = errors.New("key cannot be empty")
+	errValueNil    = errors.New("value cannot be nil")
+)
+
+// PebbleDB is a database backed by Pebble.
+type PebbleDB struct {
+	db *pebble.DB
+}
+
+var _ dbm.DB = (*PebbleDB)(nil)
+
+// NewDB opens or creates the database name in dir.
+func NewDB(name string, dir string) (*PebbleDB, error) {
+	db, err := pebble.Open(filepath.Join(dir, name+".db"), &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return &PebbleDB{db: db}, nil
+}
+
+// Get implements DB.
+func (db *PebbleDB) Get(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, errKeyEmpty
+	}
+	res, closer, err := db.db.Get(key)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer closer.Close()
+
+	// the value is only valid until the closer is closed
+	return cp(res), nil
+}
+
+// Has implements DB.
+func (db *PebbleDB) Has(key []byte) (bool, error) {
+	bytes, err := db.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return bytes != nil, nil
+}
+
+// Set implements DB.
+func (db *PebbleDB) Set(key []byte, value []byte) error {
+	return db.set(key, value, pebble.NoSync)
+}
+
+// SetSync implements DB.
+func (db *PebbleDB) SetSync(key []byte, value []byte) error {
+	return db.set(key, value, pebble.Sync)
+}
+
+func (db *PebbleDB) set(key []byte, value []byte, opts *pebble.WriteOptions) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if value == nil {
+		return errValueNil
+	}
+	return db.db.Set(key, value, opts)
+}
+
+// Delete implements DB.
+func (db *PebbleDB) Delete(key []byte) error {
+	return db.delete(key, pebble.NoSync)
+}
+
+// DeleteSync implements DB.
+func (db *PebbleDB) DeleteSync(key []byte) error {
+	return db.delete(key, pebble.Sync)
+}
+
+func (db *PebbleDB) delete(key []byte, opts *pebble.WriteOptions) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	return db.db.Delete(key, opts)
+}
+
+// DB returns the underlying Pebble database.
+func (db *PebbleDB) DB() *pebble.DB {
+	return db.db
+}
+
+// Compact compacts all the keys of the database, to reclaim the space of the
+// deleted ones.
+func (db *PebbleDB) Compact() error {
+	it := db.db.NewIter(nil)
+	if !it.First() {
+		return it.Close()
+	}
+	start := cp(it.Key())
+	it.Last()
+	// the end is exclusive
+	end := append(cp(it.Key()), 0)
+	if err := it.Close(); err != nil {
+		return err
+	}
+	return db.db.Compact(start, end)
+}
+
+// Close implements DB.
+func (db *PebbleDB) Close() error {
+	return db.db.Close()
+}
+
+// Print implements DB.
+func (db *PebbleDB) Print() error {
+	fmt.Printf("%v\n", db.db.Metrics())
+
+	it := db.db.NewIter(nil)
+	defer it.Close()
+	for it.First(); it.Valid(); it.Next() {
+		fmt.Printf("[%X]:\t[%X]\n", it.Key(), it.Value())
+	}
+	return it.Error()
+}
+
+// Stats implements DB.
+func (db *PebbleDB) Stats() map[string]string {
+	return map[string]string{
+		"pebble.metrics": db.db.Metrics().String(),
+	}
+}
+
+// NewBatch implements DB.
+func (db *PebbleDB) NewBatch() dbm.Batch {
+	return newPebbleDBBatch(db)
+}
+
+// Iterator implements DB.
+func (db *PebbleDB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+	it := db.db.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
+	return newPebbleDBIterator(it, start, end, false), nil
+}
+
+// ReverseIterator implements DB.
+func (db *PebbleDB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+	it := db.db.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
+	return newPebbleDBIterator(it, start, end, true), nil
+}
+
+func cp(bz []byte) []byte {
+	ret := make([]byte, len(bz))
+	copy(ret, bz)
+	return ret
+}
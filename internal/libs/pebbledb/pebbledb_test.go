@@ -0,0 +1,143 @@
+package pebbledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func newTestDB(t *testing.T) *PebbleDB {
+	t.Helper()
+	db, err := NewDB("testdb", t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+	return db
+}
+
+func TestPebbleDBGetSetDelete(t *testing.T) {
+	db := newTestDB(t)
+
+	// A nonexistent key should return nil.
+	value, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+	ok, err := db.Has([]byte("a"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, db.Set([]byte("a"), []byte{0x01}))
+	require.NoError(t, db.SetSync([]byte("b"), []byte{0x02}))
+	value, err = db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01}, value)
+	ok, err = db.Has([]byte("b"))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Deleting a non-existent value is fine.
+	require.NoError(t, db.Delete([]byte("x")))
+	require.NoError(t, db.Delete([]byte("a")))
+	require.NoError(t, db.DeleteSync([]byte("b")))
+	value, err = db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// Empty keys and nil values are errors, but empty values are fine.
+	_, err = db.Get(nil)
+	require.Equal(t, errKeyEmpty, err)
+	require.Equal(t, errKeyEmpty, db.Set([]byte{}, []byte{0x01}))
+	require.Equal(t, errKeyEmpty, db.Delete(nil))
+	require.Equal(t, errValueNil, db.Set([]byte("x"), nil))
+	require.NoError(t, db.Set([]byte("x"), []byte{}))
+	value, err = db.Get([]byte("x"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{}, value)
+}
+
+func TestPebbleDBIterator(t *testing.T) {
+	db := newTestDB(t)
+	for _, k := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, db.Set([]byte(k), []byte(k)))
+	}
+
+	keys := func(it dbm.Iterator, err error) []string {
+		require.NoError(t, err)
+		defer it.Close()
+		var keys []string
+		for ; it.Valid(); it.Next() {
+			require.Equal(t, it.Key(), it.Value())
+			keys = append(keys, string(it.Key()))
+		}
+		require.NoError(t, it.Error())
+		return keys
+	}
+
+	require.Equal(t, []string{"a", "b", "c", "d"}, keys(db.Iterator(nil, nil)))
+	require.Equal(t, []string{"b", "c"}, keys(db.Iterator([]byte("b"), []byte("d"))))
+	require.Equal(t, []string{"c", "d"}, keys(db.Iterator([]byte("bb"), nil)))
+	require.Equal(t, []string{"d", "c", "b", "a"}, keys(db.ReverseIterator(nil, nil)))
+	require.Equal(t, []string{"c", "b"}, keys(db.ReverseIterator([]byte("b"), []byte("d"))))
+	require.Equal(t, []string{"b", "a"}, keys(db.ReverseIterator(nil, []byte("bb"))))
+	require.Empty(t, keys(db.Iterator([]byte("e"), nil)))
+
+	_, err := db.Iterator([]byte{}, nil)
+	require.Equal(t, errKeyEmpty, err)
+	_, err = db.ReverseIterator(nil, []byte{})
+	require.Equal(t, errKeyEmpty, err)
+
+	it, err := db.Iterator([]byte("a"), []byte("b"))
+	require.NoError(t, err)
+	start, end := it.Domain()
+	require.Equal(t, []byte("a"), start)
+	require.Equal(t, []byte("b"), end)
+	it.Next()
+	require.False(t, it.Valid())
+	require.Panics(t, func() { it.Key() })
+	require.NoError(t, it.Close())
+}
+
+func TestPebbleDBBatch(t *testing.T) {
+	db := newTestDB(t)
+	require.NoError(t, db.Set([]byte("a"), []byte{1}))
+
+	batch := db.NewBatch()
+	require.NoError(t, batch.Set([]byte("b"), []byte{2}))
+	require.NoError(t, batch.Delete([]byte("a")))
+	require.Equal(t, errKeyEmpty, batch.Set(nil, []byte{2}))
+	require.Equal(t, errValueNil, batch.Set([]byte("c"), nil))
+
+	// nothing is written before the batch
+	value, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, value)
+
+	require.NoError(t, batch.WriteSync())
+	value, err = db.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+	value, err = db.Get([]byte("b"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, value)
+
+	// a written batch can't be used anymore, but can be closed
+	require.Equal(t, errBatchClosed, batch.Set([]byte("c"), []byte{3}))
+	require.Equal(t, errBatchClosed, batch.Write())
+	require.NoError(t, batch.Close())
+	require.NoError(t, batch.Close())
+}
+
+func TestPebbleDBCompact(t *testing.T) {
+	db := newTestDB(t)
+	require.NoError(t, db.Compact())
+
+	for _, k := range []string{"a", "b", "c"} {
+		require.NoError(t, db.Set([]byte(k), []byte(k)))
+	}
+	require.NoError(t, db.Delete([]byte("b")))
+	require.NoError(t, db.Compact())
+
+	value, err := db.Get([]byte("c"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("c"), value)
+}
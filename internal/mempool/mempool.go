@@ -2,6 +2,7 @@ package mempool
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 
@@ -90,8 +91,21 @@ type Mempool interface {
 	// Size returns the number of transactions in the mempool.
 	Size() int
 
+	// GetTxByKey returns the transaction with the given key, as returned by
+	// TxKey, if it is in the mempool.
+	GetTxByKey(key [TxKeySize]byte) (types.Tx, bool)
+
 	// SizeBytes returns the total size of all txs in the mempool.
 	SizeBytes() int64
+
+	// SetLimits changes the maximum number of txs in the mempool, and their
+	// maximum total size in bytes. The txs already in the mempool are kept
+	// if the limits are lowered.
+	SetLimits(size int, maxTxsBytes int64)
+
+	// Limits returns the maximum number of txs in the mempool, and their
+	// maximum total size in bytes.
+	Limits() (size int, maxTxsBytes int64)
 }
 
 // PreCheckFunc is an optional filter executed before CheckTx and rejects
@@ -118,6 +132,19 @@ func PreCheckMaxBytes(maxBytes int64) PreCheckFunc {
 	}
 }
 
+// PreCheckBlobTx checks that the blob txs carry their blobs, and commit to
+// them.
+func PreCheckBlobTx(tx types.Tx) error {
+	btx, ok := types.UnmarshalBlobTx(tx)
+	if !ok {
+		return nil
+	}
+	if len(btx.Blobs) == 0 {
+		return errors.New("blob tx without blobs")
+	}
+	return btx.ValidateBasic()
+}
+
 // PostCheckMaxGas checks that the wanted gas is smaller or equal to the passed
 // maxGas. Returns nil if maxGas is -1.
 func PostCheckMaxGas(maxGas int64) PostCheckFunc {
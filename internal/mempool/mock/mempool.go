@@ -17,6 +17,9 @@ var _ mempool.Mempool = Mempool{}
 func (Mempool) Lock()     {}
 func (Mempool) Unlock()   {}
 func (Mempool) Size() int { return 0 }
+func (Mempool) GetTxByKey(_ [mempool.TxKeySize]byte) (types.Tx, bool) {
+	return nil, false
+}
 func (Mempool) CheckTx(_ context.Context, _ types.Tx, _ func(*abci.Response), _ mempool.TxInfo) error {
 	return nil
 }
@@ -36,6 +39,8 @@ func (Mempool) FlushAppConn() error           { return nil }
 func (Mempool) TxsAvailable() <-chan struct{} { return make(chan struct{}) }
 func (Mempool) EnableTxsAvailable()           {}
 func (Mempool) SizeBytes() int64              { return 0 }
+func (Mempool) SetLimits(int, int64)          {}
+func (Mempool) Limits() (int, int64)          { return 0, 0 }
 
 func (Mempool) TxsFront() *clist.CElement    { return nil }
 func (Mempool) TxsWaitChan() <-chan struct{} { return nil }
@@ -0,0 +1,196 @@
+package mempool
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/types"
+)
+
+var _ TxCache = (*SeenTxCache)(nil)
+
+// SeenTxCache is a LRUTxCache persisted across restarts: the keys of its txs
+// are saved when the node stops in a bloom filter, a few bytes per tx, and
+// the txs of the filter restored when the node starts are taken as already
+// seen, for the node not to check and gossip again the backlog of txs its
+// peers send it back.
+//
+// The filter mistakes a few txs never seen for seen ones, at its false
+// positive rate, and is discarded once older than its max age, the txs seen
+// before it was saved being expected to have been committed or dropped by
+// then.
+type SeenTxCache struct {
+	*LRUTxCache
+
+	file   string
+	fpRate float64
+	maxAge time.Duration
+
+	mtx           tmsync.Mutex
+	restored      *bloomFilter
+	restoredUntil time.Time
+}
+
+// NewSeenTxCache returns a cache of cacheSize txs, persisted to file in a
+// bloom filter of the false positive rate fpRate, kept for maxAge.
+func NewSeenTxCache(cacheSize int, file string, fpRate float64, maxAge time.Duration) *SeenTxCache {
+	return &SeenTxCache{
+		LRUTxCache: NewLRUTxCache(cacheSize),
+		file:       file,
+		fpRate:     fpRate,
+		maxAge:     maxAge,
+	}
+}
+
+// seenTxsFile is the JSON encoding of the file of the seen txs.
+type seenTxsFile struct {
+	SavedAt time.Time `json:"saved_at"`
+	Hashes  uint32    `json:"hashes"`
+	Bits    []byte    `json:"bits"`
+}
+
+// Load restores the filter of the seen txs from the file, if it exists and
+// isn't older than the max age.
+func (c *SeenTxCache) Load() error {
+	bz, err := ioutil.ReadFile(c.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var f seenTxsFile
+	if err := tmjson.Unmarshal(bz, &f); err != nil {
+		return fmt.Errorf("invalid seen txs file %s: %w", c.file, err)
+	}
+	if len(f.Bits) == 0 || f.Hashes == 0 {
+		return fmt.Errorf("invalid seen txs file %s: empty filter", c.file)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.restored, c.restoredUntil = nil, f.SavedAt.Add(c.maxAge)
+	if time.Now().Before(c.restoredUntil) {
+		c.restored = &bloomFilter{bits: f.Bits, hashes: f.Hashes}
+	}
+	return nil
+}
+
+// Save persists the keys of the txs of the cache to the file.
+func (c *SeenTxCache) Save() error {
+	c.LRUTxCache.mtx.Lock()
+	filter := newBloomFilter(c.size, c.fpRate)
+	for e := c.list.Front(); e != nil; e = e.Next() {
+		filter.add(e.Value.([TxKeySize]byte))
+	}
+	c.LRUTxCache.mtx.Unlock()
+
+	bz, err := tmjson.Marshal(seenTxsFile{
+		SavedAt: time.Now(),
+		Hashes:  filter.hashes,
+		Bits:    filter.bits,
+	})
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(c.file, bz, 0600)
+}
+
+// Push adds the tx to the cache, and returns true if it was neither in the
+// cache nor seen before the node restarted.
+func (c *SeenTxCache) Push(tx types.Tx) bool {
+	if !c.LRUTxCache.Push(tx) {
+		return false
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.restored == nil {
+		return true
+	}
+	if !time.Now().Before(c.restoredUntil) {
+		c.restored = nil
+		return true
+	}
+	return !c.restored.has(TxKey(tx))
+}
+
+// Has returns true if the tx of the key is in the cache, or was seen before
+// the node restarted.
+func (c *SeenTxCache) Has(key [TxKeySize]byte) bool {
+	if c.LRUTxCache.Has(key) {
+		return true
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.restored != nil && time.Now().Before(c.restoredUntil) && c.restored.has(key)
+}
+
+// Reset empties the cache, and forgets the txs seen before the node
+// restarted.
+func (c *SeenTxCache) Reset() {
+	c.LRUTxCache.Reset()
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.restored = nil
+}
+
+// bloomFilter is a bloom filter of tx keys. The keys being hashes, the
+// indexes of their bits are derived from the keys themselves, by double
+// hashing.
+type bloomFilter struct {
+	bits   []byte
+	hashes uint32
+}
+
+// newBloomFilter returns a filter sized for n keys at the false positive
+// rate fpRate.
+func newBloomFilter(n int, fpRate float64) *bloomFilter {
+	if n < 1 {
+		n = 1
+	}
+	m := math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/float64(n)*math.Ln2))
+	return &bloomFilter{
+		bits:   make([]byte, (int(m)+7)/8),
+		hashes: uint32(k),
+	}
+}
+
+func (f *bloomFilter) add(key [TxKeySize]byte) {
+	f.forEachBit(key, func(i uint64) bool {
+		f.bits[i/8] |= 1 << (i % 8)
+		return true
+	})
+}
+
+func (f *bloomFilter) has(key [TxKeySize]byte) bool {
+	return f.forEachBit(key, func(i uint64) bool {
+		return f.bits[i/8]&(1<<(i%8)) != 0
+	})
+}
+
+// forEachBit calls fn with the indexes of the bits of the key, as long as it
+// returns true, and returns whether it always did.
+func (f *bloomFilter) forEachBit(key [TxKeySize]byte, fn func(uint64) bool) bool {
+	var (
+		m  = uint64(len(f.bits)) * 8
+		h1 = binary.LittleEndian.Uint64(key[0:8])
+		h2 = binary.LittleEndian.Uint64(key[8:16]) | 1
+	)
+	for i := uint64(0); i < uint64(f.hashes); i++ {
+		if !fn((h1 + i*h2) % m) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package mempool
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestSeenTxCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "seen_txs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "seen_txs.json")
+
+	// nothing to restore
+	cache := NewSeenTxCache(1000, file, 0.0001, time.Hour)
+	require.NoError(t, cache.Load())
+
+	txs := make([]types.Tx, 1000)
+	for i := range txs {
+		txs[i] = tmrand.Bytes(64)
+		require.True(t, cache.Push(txs[i]))
+	}
+	cache.Remove(txs[0])
+	require.NoError(t, cache.Save())
+
+	// the txs of the cache are seen once restarted, but the removed one
+	restarted := NewSeenTxCache(1000, file, 0.0001, time.Hour)
+	require.NoError(t, restarted.Load())
+	require.True(t, restarted.Has(TxKey(txs[1])))
+	require.True(t, restarted.Push(txs[0]))
+	for _, tx := range txs[1:] {
+		require.False(t, restarted.Push(tx))
+	}
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if !restarted.Push(tmrand.Bytes(64)) {
+			falsePositives++
+		}
+	}
+	require.Less(t, falsePositives, 10)
+
+	// until flushed
+	restarted.Reset()
+	require.True(t, restarted.Push(txs[1]))
+
+	// the filter is discarded once older than its max age
+	expired := NewSeenTxCache(1000, file, 0.0001, time.Nanosecond)
+	require.NoError(t, expired.Load())
+	require.True(t, expired.Push(txs[1]))
+
+	require.NoError(t, ioutil.WriteFile(file, []byte("{}"), 0600))
+	require.Error(t, NewSeenTxCache(1000, file, 0.0001, time.Hour).Load())
+}
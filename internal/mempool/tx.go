@@ -1,17 +1,37 @@
 package mempool
 
 import (
-	"crypto/sha256"
+	"errors"
+	"fmt"
 
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/types"
 )
 
 // TxKeySize defines the size of the transaction's key used for indexing.
-const TxKeySize = sha256.Size
+const TxKeySize = tmhash.Size
 
-// TxKey is the fixed length array key used as an index.
+// TxKey is the fixed length array key used as an index. The blob txs are
+// keyed as they are included in the blocks, without their blobs, so that they
+// are removed once committed.
 func TxKey(tx types.Tx) [TxKeySize]byte {
-	return sha256.Sum256(tx)
+	return tmhash.Sum256(tx.WithoutBlobs())
+}
+
+// TxKeysFromHashes returns the keys of the txs advertised or requested by a
+// peer, or an error if there are none or one of them is malformed.
+func TxKeysFromHashes(hashes [][]byte) ([][TxKeySize]byte, error) {
+	if len(hashes) == 0 {
+		return nil, errors.New("empty tx hashes received from peer")
+	}
+	keys := make([][TxKeySize]byte, len(hashes))
+	for i, hash := range hashes {
+		if len(hash) != TxKeySize {
+			return nil, fmt.Errorf("invalid tx hash size %d, expected %d", len(hash), TxKeySize)
+		}
+		copy(keys[i][:], hash)
+	}
+	return keys, nil
 }
 
 // TxHashFromBytes returns the hash of a transaction from raw bytes.
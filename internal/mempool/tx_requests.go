@@ -0,0 +1,168 @@
+package mempool
+
+import (
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// TxRequests tracks the txs advertised by the peers, and requested of them:
+// each tx is requested of a single peer at a time, and each peer has at most
+// maxInFlight txs requested of it and not received yet. A tx which isn't
+// received in time, or whose peer disconnects, is requested of another peer
+// which advertised it.
+type TxRequests struct {
+	mtx         tmsync.Mutex
+	maxInFlight int
+	maxTxs      int
+	timeout     time.Duration
+
+	requests map[[TxKeySize]byte]*txRequest
+	inFlight map[types.NodeID]int
+}
+
+// txRequest is a tx advertised by the peers, requested of peer since
+// requestedAt, or of none of them yet if peer is empty.
+type txRequest struct {
+	peer        types.NodeID
+	requestedAt time.Time
+
+	// sources are the other peers which advertised the tx, and haven't been
+	// requested it yet.
+	sources []types.NodeID
+}
+
+// NewTxRequests returns the requests of at most maxInFlight txs per peer,
+// each waited for timeout. At most maxTxs txs are tracked, the mempool being
+// unable to hold more.
+func NewTxRequests(maxInFlight, maxTxs int, timeout time.Duration) *TxRequests {
+	return &TxRequests{
+		maxInFlight: maxInFlight,
+		maxTxs:      maxTxs,
+		timeout:     timeout,
+		requests:    make(map[[TxKeySize]byte]*txRequest),
+		inFlight:    make(map[types.NodeID]int),
+	}
+}
+
+// Advertised records that the peer has the tx of the key, and returns true if
+// the tx must be requested of it: the tx isn't requested of another peer and
+// the peer has room for another request.
+func (r *TxRequests) Advertised(peer types.NodeID, key [TxKeySize]byte) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	req, ok := r.requests[key]
+	if !ok {
+		if len(r.requests) >= r.maxTxs {
+			return false
+		}
+		req = &txRequest{}
+		r.requests[key] = req
+	}
+	if req.peer == peer {
+		return false
+	}
+	if req.peer == "" && r.inFlight[peer] < r.maxInFlight {
+		req.removeSource(peer)
+		r.request(req, peer, time.Now())
+		return true
+	}
+	for _, source := range req.sources {
+		if source == peer {
+			return false
+		}
+	}
+	req.sources = append(req.sources, peer)
+	return false
+}
+
+// Received records that the tx of the key was received, from any peer.
+func (r *TxRequests) Received(key [TxKeySize]byte) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	req, ok := r.requests[key]
+	if !ok {
+		return
+	}
+	r.release(req)
+	delete(r.requests, key)
+}
+
+// RemovePeer forgets the txs advertised by the peer, the txs requested of it
+// being requested of other peers on the next call to Retry.
+func (r *TxRequests) RemovePeer(peer types.NodeID) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	for key, req := range r.requests {
+		if req.peer == peer {
+			req.peer = ""
+		}
+		req.removeSource(peer)
+		if req.peer == "" && len(req.sources) == 0 {
+			delete(r.requests, key)
+		}
+	}
+	delete(r.inFlight, peer)
+}
+
+// Retry requests the txs which weren't received in time of the other peers
+// which advertised them, as well as the txs whose peers had no room, and
+// returns the keys of the txs to request of each peer. The txs which no other
+// peer advertised are forgotten.
+func (r *TxRequests) Retry(now time.Time) map[types.NodeID][][TxKeySize]byte {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	retries := make(map[types.NodeID][][TxKeySize]byte)
+	for key, req := range r.requests {
+		if req.peer != "" {
+			if now.Sub(req.requestedAt) < r.timeout {
+				continue
+			}
+			r.release(req)
+		}
+		for _, source := range req.sources {
+			if r.inFlight[source] < r.maxInFlight {
+				req.removeSource(source)
+				r.request(req, source, now)
+				retries[source] = append(retries[source], key)
+				break
+			}
+		}
+		if req.peer == "" && len(req.sources) == 0 {
+			delete(r.requests, key)
+		}
+	}
+	return retries
+}
+
+// request requests the tx of the peer. The mutex must be held.
+func (r *TxRequests) request(req *txRequest, peer types.NodeID, now time.Time) {
+	req.peer, req.requestedAt = peer, now
+	r.inFlight[peer]++
+}
+
+// release frees the room of the request of its peer. The mutex must be held.
+func (r *TxRequests) release(req *txRequest) {
+	if req.peer == "" {
+		return
+	}
+	r.inFlight[req.peer]--
+	if r.inFlight[req.peer] <= 0 {
+		delete(r.inFlight, req.peer)
+	}
+	req.peer = ""
+}
+
+func (req *txRequest) removeSource(peer types.NodeID) {
+	for i, source := range req.sources {
+		if source == peer {
+			req.sources = append(req.sources[:i], req.sources[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxRequests(t *testing.T) {
+	const (
+		peerA = types.NodeID("aa")
+		peerB = types.NodeID("bb")
+	)
+	keys := make([][TxKeySize]byte, 3)
+	for i := range keys {
+		keys[i][0] = byte(i)
+	}
+
+	requests := NewTxRequests(2, 10, time.Second)
+
+	// a tx is requested of the first peer which advertises it, the others are
+	// kept as sources
+	require.True(t, requests.Advertised(peerA, keys[0]))
+	require.False(t, requests.Advertised(peerA, keys[0]))
+	require.False(t, requests.Advertised(peerB, keys[0]))
+
+	// a peer has at most 2 txs in flight
+	require.True(t, requests.Advertised(peerA, keys[1]))
+	require.False(t, requests.Advertised(peerA, keys[2]))
+
+	// the room freed by a received tx is taken on retry
+	requests.Received(keys[1])
+	require.Equal(t, map[types.NodeID][][TxKeySize]byte{
+		peerA: {keys[2]},
+	}, requests.Retry(time.Now()))
+
+	// a tx not received in time is requested of another source, or forgotten
+	retries := requests.Retry(time.Now().Add(2 * time.Second))
+	require.Equal(t, map[types.NodeID][][TxKeySize]byte{
+		peerB: {keys[0]},
+	}, retries)
+	require.Len(t, requests.requests, 1)
+	require.Equal(t, 1, requests.inFlight[peerB])
+	require.NotContains(t, requests.inFlight, peerA)
+
+	// the txs requested of a removed peer are forgotten without other source
+	requests.RemovePeer(peerB)
+	require.Empty(t, requests.requests)
+	require.Empty(t, requests.inFlight)
+}
+
+func TestTxRequestsRemovePeer(t *testing.T) {
+	const (
+		peerA = types.NodeID("aa")
+		peerB = types.NodeID("bb")
+	)
+	var key [TxKeySize]byte
+
+	requests := NewTxRequests(1, 10, time.Minute)
+	require.True(t, requests.Advertised(peerA, key))
+	require.False(t, requests.Advertised(peerB, key))
+
+	// the tx is requested of the other source without waiting for the timeout
+	requests.RemovePeer(peerA)
+	require.Equal(t, map[types.NodeID][][TxKeySize]byte{
+		peerB: {key},
+	}, requests.Retry(time.Now()))
+}
+
+func TestTxRequestsMaxTxs(t *testing.T) {
+	requests := NewTxRequests(1, 1, time.Minute)
+
+	var key, other [TxKeySize]byte
+	other[0] = 1
+	require.True(t, requests.Advertised("aa", key))
+	require.False(t, requests.Advertised("bb", other))
+	require.Empty(t, requests.Retry(time.Now()))
+}
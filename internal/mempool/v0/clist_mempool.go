@@ -25,8 +25,10 @@ import (
 // be efficiently accessed by multiple concurrent readers.
 type CListMempool struct {
 	// Atomic integers
-	height   int64 // the last block Update()'d to
-	txsBytes int64 // total size of mempool, in bytes
+	height      int64 // the last block Update()'d to
+	txsBytes    int64 // total size of mempool, in bytes
+	maxTxs      int64 // see SetLimits
+	maxTxsBytes int64
 
 	// notify listeners (ie. consensus) when txs are available
 	notifiedTxsAvailable bool
@@ -80,6 +82,8 @@ func NewCListMempool(
 		proxyAppConn:  proxyAppConn,
 		txs:           clist.New(),
 		height:        height,
+		maxTxs:        int64(cfg.Size),
+		maxTxsBytes:   cfg.MaxTxsBytes,
 		recheckCursor: nil,
 		recheckEnd:    nil,
 		logger:        log.NewNopLogger(),
@@ -130,6 +134,12 @@ func WithMetrics(metrics *mempool.Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
 }
 
+// WithCache sets the cache of the txs already seen, instead of the one of
+// cache-size txs.
+func WithCache(cache mempool.TxCache) CListMempoolOption {
+	return func(mem *CListMempool) { mem.cache = cache }
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) Lock() {
 	mem.updateMtx.Lock()
@@ -145,11 +155,31 @@ func (mem *CListMempool) Size() int {
 	return mem.txs.Len()
 }
 
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) GetTxByKey(key [mempool.TxKeySize]byte) (types.Tx, bool) {
+	e, ok := mem.txsMap.Load(key)
+	if !ok {
+		return nil, false
+	}
+	return e.(*clist.CElement).Value.(*mempoolTx).tx, true
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) SizeBytes() int64 {
 	return atomic.LoadInt64(&mem.txsBytes)
 }
 
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) SetLimits(size int, maxTxsBytes int64) {
+	atomic.StoreInt64(&mem.maxTxs, int64(size))
+	atomic.StoreInt64(&mem.maxTxsBytes, maxTxsBytes)
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) Limits() (int, int64) {
+	return int(atomic.LoadInt64(&mem.maxTxs)), atomic.LoadInt64(&mem.maxTxsBytes)
+}
+
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) FlushAppConn() error {
 	return mem.proxyAppConn.FlushSync(context.Background())
@@ -358,16 +388,18 @@ func (mem *CListMempool) RemoveTxByKey(txKey [mempool.TxKeySize]byte, removeFrom
 
 func (mem *CListMempool) isFull(txSize int) error {
 	var (
-		memSize  = mem.Size()
-		txsBytes = mem.SizeBytes()
+		memSize     = mem.Size()
+		txsBytes    = mem.SizeBytes()
+		maxTxs      = int(atomic.LoadInt64(&mem.maxTxs))
+		maxTxsBytes = atomic.LoadInt64(&mem.maxTxsBytes)
 	)
 
-	if memSize >= mem.config.Size || int64(txSize)+txsBytes > mem.config.MaxTxsBytes {
+	if memSize >= maxTxs || int64(txSize)+txsBytes > maxTxsBytes {
 		return types.ErrMempoolIsFull{
 			NumTxs:      memSize,
-			MaxTxs:      mem.config.Size,
+			MaxTxs:      maxTxs,
 			TxsBytes:    txsBytes,
-			MaxTxsBytes: mem.config.MaxTxsBytes,
+			MaxTxsBytes: maxTxsBytes,
 		}
 	}
 
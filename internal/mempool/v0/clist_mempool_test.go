@@ -551,6 +551,29 @@ func TestMempoolTxsBytes(t *testing.T) {
 
 }
 
+func TestMempoolSetLimits(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := abciclient.NewLocalCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	err := mp.CheckTx(context.Background(), []byte{0x01}, nil, mempool.TxInfo{})
+	require.NoError(t, err)
+
+	// the txs in the mempool are kept when the limits are lowered
+	mp.SetLimits(1, 10)
+	assert.Equal(t, 1, mp.Size())
+	err = mp.CheckTx(context.Background(), []byte{0x02}, nil, mempool.TxInfo{})
+	if assert.Error(t, err) {
+		assert.IsType(t, types.ErrMempoolIsFull{}, err)
+	}
+
+	mp.SetLimits(2, 10)
+	err = mp.CheckTx(context.Background(), []byte{0x02}, nil, mempool.TxInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, mp.Size())
+}
+
 // This will non-deterministically catch some concurrency failures like
 // https://github.com/tendermint/tendermint/issues/3509
 // TODO: all of the tests should probably also run using the remote proxy app
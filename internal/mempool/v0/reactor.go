@@ -34,12 +34,16 @@ type PeerManager interface {
 // Reactor implements a service that contains mempool of txs that are broadcasted
 // amongst peers. It maintains a map from peer ID to counter, to prevent gossiping
 // txs to the peers you received it from.
+//
+// The txs are advertised to the peers as batches of their keys, the peers
+// requesting the txs they haven't seen, each of a single peer at a time.
 type Reactor struct {
 	service.BaseService
 
-	cfg     *config.MempoolConfig
-	mempool *CListMempool
-	ids     *mempool.MempoolIDs
+	cfg      *config.MempoolConfig
+	mempool  *CListMempool
+	ids      *mempool.MempoolIDs
+	requests *mempool.TxRequests
 
 	// XXX: Currently, this is the only way to get information about a peer. Ideally,
 	// we rely on message-oriented communication to get necessary peer data.
@@ -73,6 +77,7 @@ func NewReactor(
 		peerMgr:      peerMgr,
 		mempool:      mp,
 		ids:          mempool.NewMempoolIDs(),
+		requests:     mempool.NewTxRequests(cfg.MaxInFlightTxs, cfg.Size, cfg.TxRequestTimeout),
 		mempoolCh:    mempoolCh,
 		peerUpdates:  peerUpdates,
 		closeCh:      make(chan struct{}),
@@ -97,6 +102,26 @@ func GetChannelShims(cfg *config.MempoolConfig) map[p2p.ChannelID]*p2p.ChannelDe
 			Txs: &protomem.Txs{Txs: [][]byte{largestTx}},
 		},
 	}
+	recvCapacity := batchMsg.Size()
+
+	// a peer advertises at most tx-hashes-batch-size keys at once, and is
+	// requested at most max-in-flight-txs txs
+	maxHashes := cfg.TxHashesBatchSize
+	if cfg.MaxInFlightTxs > maxHashes {
+		maxHashes = cfg.MaxInFlightTxs
+	}
+	hashes := make([][]byte, maxHashes)
+	for i := range hashes {
+		hashes[i] = make([]byte, mempool.TxKeySize)
+	}
+	hashesMsg := protomem.Message{
+		Sum: &protomem.Message_TxHashes{
+			TxHashes: &protomem.TxHashes{Hashes: hashes},
+		},
+	}
+	if hashesMsg.Size() > recvCapacity {
+		recvCapacity = hashesMsg.Size()
+	}
 
 	return map[p2p.ChannelID]*p2p.ChannelDescriptorShim{
 		mempool.MempoolChannel: {
@@ -104,7 +129,7 @@ func GetChannelShims(cfg *config.MempoolConfig) map[p2p.ChannelID]*p2p.ChannelDe
 			Descriptor: &p2p.ChannelDescriptor{
 				ID:                  byte(mempool.MempoolChannel),
 				Priority:            5,
-				RecvMessageCapacity: batchMsg.Size(),
+				RecvMessageCapacity: recvCapacity,
 				RecvBufferCapacity:  128,
 				MaxSendBytes:        5000,
 			},
@@ -123,6 +148,7 @@ func (r *Reactor) OnStart() error {
 
 	go r.processMempoolCh()
 	go r.processPeerUpdates()
+	go r.processTxRequests()
 
 	return nil
 }
@@ -151,9 +177,11 @@ func (r *Reactor) OnStop() {
 }
 
 // handleMempoolMessage handles envelopes sent from peers on the MempoolChannel.
-// For every tx in the message, we execute CheckTx. It returns an error if an
-// empty set of txs are sent in an envelope or if we receive an unexpected
-// message type.
+// For every tx in the message, we execute CheckTx. For every key advertised,
+// we request the tx if we haven't seen it, and for every key requested, we
+// send the tx back if it is in the mempool. It returns an error if an empty
+// set of txs or keys are sent in an envelope, if a key is malformed, or if we
+// receive an unexpected message type.
 func (r *Reactor) handleMempoolMessage(envelope p2p.Envelope) error {
 	logger := r.Logger.With("peer", envelope.From)
 
@@ -170,11 +198,61 @@ func (r *Reactor) handleMempoolMessage(envelope p2p.Envelope) error {
 		}
 
 		for _, tx := range protoTxs {
+			r.requests.Received(mempool.TxKey(tx))
 			if err := r.mempool.CheckTx(context.Background(), types.Tx(tx), nil, txInfo); err != nil {
 				logger.Error("checktx failed for tx", "tx", fmt.Sprintf("%X", mempool.TxHashFromBytes(tx)), "err", err)
 			}
 		}
 
+	case *protomem.TxHashes:
+		keys, err := mempool.TxKeysFromHashes(msg.GetHashes())
+		if err != nil {
+			return err
+		}
+
+		peerMempoolID := r.ids.GetForPeer(envelope.From)
+		var want [][]byte
+		for _, key := range keys {
+			// the peer has the tx, which isn't to be advertised back to it
+			if e, ok := r.mempool.txsMap.Load(key); ok {
+				e.(*clist.CElement).Value.(*mempoolTx).senders.Store(peerMempoolID, true)
+				continue
+			}
+			if r.mempool.cache.Has(key) {
+				continue
+			}
+			if r.requests.Advertised(envelope.From, key) {
+				key := key
+				want = append(want, key[:])
+			}
+		}
+		if len(want) > 0 {
+			r.mempoolCh.Out <- p2p.Envelope{
+				To:      envelope.From,
+				Message: &protomem.WantTxs{Hashes: want},
+			}
+		}
+
+	case *protomem.WantTxs:
+		keys, err := mempool.TxKeysFromHashes(msg.GetHashes())
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			tx, ok := r.mempool.GetTxByKey(key)
+			if !ok {
+				// removed since it was advertised
+				continue
+			}
+			r.mempoolCh.Out <- p2p.Envelope{
+				To: envelope.From,
+				Message: &protomem.Txs{
+					Txs: [][]byte{tx},
+				},
+			}
+		}
+
 	default:
 		return fmt.Errorf("received unknown message: %T", msg)
 	}
@@ -275,6 +353,7 @@ func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
 
 	case p2p.PeerStatusDown:
 		r.ids.Reclaim(peerUpdate.NodeID)
+		r.requests.RemovePeer(peerUpdate.NodeID)
 
 		// Check if we've started a tx broadcasting goroutine for this peer.
 		// If we have, we signal to terminate the goroutine via the channel's closure.
@@ -305,10 +384,51 @@ func (r *Reactor) processPeerUpdates() {
 	}
 }
 
+// processTxRequests requests the txs which weren't received in time, or whose
+// peers disconnected, of the other peers which advertised them.
+func (r *Reactor) processTxRequests() {
+	ticker := time.NewTicker(r.cfg.TxRequestTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			for peerID, keys := range r.requests.Retry(now) {
+				want := make([][]byte, len(keys))
+				for i := range keys {
+					want[i] = keys[i][:]
+				}
+				r.mempoolCh.Out <- p2p.Envelope{
+					To:      peerID,
+					Message: &protomem.WantTxs{Hashes: want},
+				}
+			}
+
+		case <-r.closeCh:
+			return
+		}
+	}
+}
+
 func (r *Reactor) broadcastTxRoutine(peerID types.NodeID, closer *tmsync.Closer) {
 	peerMempoolID := r.ids.GetForPeer(peerID)
 	var next *clist.CElement
 
+	// the keys of the txs to advertise to the peer, sent once the batch is full
+	// or no other tx is available
+	batch := make([][]byte, 0, r.cfg.TxHashesBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		r.mempoolCh.Out <- p2p.Envelope{
+			To:      peerID,
+			Message: &protomem.TxHashes{Hashes: batch},
+		}
+		r.Logger.Debug("advertised txs to peer", "num_txs", len(batch), "peer", peerID)
+		batch = make([][]byte, 0, r.cfg.TxHashesBatchSize)
+	}
+
 	// remove the peer ID from the map of routines and mark the waitgroup as done
 	defer func() {
 		r.mtx.Lock()
@@ -335,6 +455,8 @@ func (r *Reactor) broadcastTxRoutine(peerID types.NodeID, closer *tmsync.Closer)
 		// collected (removed). That is, .NextWait() returned nil. Go ahead and
 		// start from the beginning.
 		if next == nil {
+			flush()
+
 			select {
 			case <-r.mempool.TxsWaitChan(): // wait until a tx is available
 				if next = r.mempool.TxsFront(); next == nil {
@@ -359,28 +481,37 @@ func (r *Reactor) broadcastTxRoutine(peerID types.NodeID, closer *tmsync.Closer)
 			height := r.peerMgr.GetHeight(peerID)
 			if height > 0 && height < memTx.Height()-1 {
 				// allow for a lag of one block
+				flush()
 				time.Sleep(mempool.PeerCatchupSleepIntervalMS * time.Millisecond)
 				continue
 			}
 		}
 
-		// NOTE: Transaction batching was disabled due to:
-		// https://github.com/tendermint/tendermint/issues/5796
-
 		if _, ok := memTx.senders.Load(peerMempoolID); !ok {
-			// Send the mempool tx to the corresponding peer. Note, the peer may be
-			// behind and thus would not be able to process the mempool tx correctly.
-			r.mempoolCh.Out <- p2p.Envelope{
-				To: peerID,
-				Message: &protomem.Txs{
-					Txs: [][]byte{memTx.tx},
-				},
+			// Advertise the mempool tx to the corresponding peer, which requests
+			// it if it hasn't seen it. Note, the peer may be behind and thus would
+			// not be able to process the mempool tx correctly.
+			key := mempool.TxKey(memTx.tx)
+			if batch = append(batch, key[:]); len(batch) >= r.cfg.TxHashesBatchSize {
+				flush()
 			}
-			r.Logger.Debug(
-				"gossiped tx to peer",
-				"tx", fmt.Sprintf("%X", mempool.TxHashFromBytes(memTx.tx)),
-				"peer", peerID,
-			)
+		}
+
+		select {
+		case <-next.NextWaitChan():
+			// see the start of the for loop for nil check
+			next = next.Next()
+			continue
+
+		case <-closer.Done():
+			return
+
+		case <-r.closeCh:
+			return
+
+		default:
+			// no other tx is available yet: advertise the batch before waiting
+			flush()
 		}
 
 		select {
@@ -43,6 +43,11 @@ type TxMempool struct {
 	// sizeBytes defines the total size of the mempool (sum of all tx bytes)
 	sizeBytes int64
 
+	// maxTxs and maxTxsBytes define the limits of the mempool, initially
+	// those of the config, see SetLimits
+	maxTxs      int64
+	maxTxsBytes int64
+
 	// cache defines a fixed-size cache of already seen transactions as this
 	// reduces pressure on the proxyApp.
 	cache mempool.TxCache
@@ -103,6 +108,8 @@ func NewTxMempool(
 		config:        cfg,
 		proxyAppConn:  proxyAppConn,
 		height:        height,
+		maxTxs:        int64(cfg.Size),
+		maxTxsBytes:   cfg.MaxTxsBytes,
 		cache:         mempool.NopTxCache{},
 		metrics:       mempool.NopMetrics(),
 		txStore:       NewTxStore(),
@@ -148,6 +155,12 @@ func WithMetrics(metrics *mempool.Metrics) TxMempoolOption {
 	return func(txmp *TxMempool) { txmp.metrics = metrics }
 }
 
+// WithCache sets the cache of the transactions already seen, instead of the
+// one of cache-size transactions.
+func WithCache(cache mempool.TxCache) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.cache = cache }
+}
+
 // Lock obtains a write-lock on the mempool. A caller must be sure to explicitly
 // release the lock when finished.
 func (txmp *TxMempool) Lock() {
@@ -165,12 +178,35 @@ func (txmp *TxMempool) Size() int {
 	return txmp.txStore.Size()
 }
 
+// GetTxByKey returns the valid transaction with the given key, if it is in the
+// mempool. It is thread-safe.
+func (txmp *TxMempool) GetTxByKey(key [mempool.TxKeySize]byte) (types.Tx, bool) {
+	wtx := txmp.txStore.GetTxByHash(key)
+	if wtx == nil {
+		return nil, false
+	}
+	return wtx.tx, true
+}
+
 // SizeBytes return the total sum in bytes of all the valid transactions in the
 // mempool. It is thread-safe.
 func (txmp *TxMempool) SizeBytes() int64 {
 	return atomic.LoadInt64(&txmp.sizeBytes)
 }
 
+// SetLimits changes the maximum number of transactions in the mempool and
+// their maximum total size. It is thread-safe.
+func (txmp *TxMempool) SetLimits(size int, maxTxsBytes int64) {
+	atomic.StoreInt64(&txmp.maxTxs, int64(size))
+	atomic.StoreInt64(&txmp.maxTxsBytes, maxTxsBytes)
+}
+
+// Limits returns the maximum number of transactions in the mempool and their
+// maximum total size. It is thread-safe.
+func (txmp *TxMempool) Limits() (int, int64) {
+	return int(atomic.LoadInt64(&txmp.maxTxs)), atomic.LoadInt64(&txmp.maxTxsBytes)
+}
+
 // FlushAppConn executes FlushSync on the mempool's proxyAppConn.
 //
 // NOTE: The caller must obtain a write-lock via Lock() prior to execution.
@@ -554,7 +590,7 @@ func (txmp *TxMempool) initTxCallback(wtx *WrappedTx, res *abci.Response, txInfo
 			priority,
 			int64(wtx.Size()),
 			txmp.SizeBytes(),
-			txmp.config.MaxTxsBytes,
+			atomic.LoadInt64(&txmp.maxTxsBytes),
 		)
 		if len(evictTxs) == 0 {
 			// No room for the new incoming transaction so we just remove it from
@@ -722,16 +758,18 @@ func (txmp *TxMempool) updateReCheckTxs() {
 // and the transaction can be inserted into the mempool.
 func (txmp *TxMempool) canAddTx(wtx *WrappedTx) error {
 	var (
-		numTxs    = txmp.Size()
-		sizeBytes = txmp.SizeBytes()
+		numTxs      = txmp.Size()
+		sizeBytes   = txmp.SizeBytes()
+		maxTxs      = int(atomic.LoadInt64(&txmp.maxTxs))
+		maxTxsBytes = atomic.LoadInt64(&txmp.maxTxsBytes)
 	)
 
-	if numTxs >= txmp.config.Size || int64(wtx.Size())+sizeBytes > txmp.config.MaxTxsBytes {
+	if numTxs >= maxTxs || int64(wtx.Size())+sizeBytes > maxTxsBytes {
 		return types.ErrMempoolIsFull{
 			NumTxs:      numTxs,
-			MaxTxs:      txmp.config.Size,
+			MaxTxs:      maxTxs,
 			TxsBytes:    sizeBytes,
-			MaxTxsBytes: txmp.config.MaxTxsBytes,
+			MaxTxsBytes: maxTxsBytes,
 		}
 	}
 
@@ -0,0 +1,166 @@
+// Package migrate versions the key layout of the block store and state store
+// databases, detects the databases written with an older layout and migrates
+// them to the layout of this version of Tendermint.
+//
+// The version of the schema of a database is saved under a key of its own.
+// The databases written before the versioning have no version, which is
+// detected from their keys. A node refuses to start on a database with an
+// older version, which must be migrated with tendermint migrate-db first.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/orderedcode"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	// prefixSchemaVersion is the key prefix of the schema version, unique
+	// across the databases.
+	prefixSchemaVersion = int64(14)
+)
+
+// Migration migrates a database from a version of its schema to the next.
+type Migration struct {
+	// Description describes the change of the key layout.
+	Description string
+	// Migrate rewrites the keys of the database. It must be safe to run again
+	// on a database where it was interrupted.
+	Migrate func(ctx context.Context, db dbm.DB) error
+}
+
+// Schema is the versioned key layout of a database.
+type Schema struct {
+	// ID is the ID of the database, as in config.DBContext.
+	ID string
+	// Migrations migrate the database from the version of their index to the
+	// next one. The latest version is the number of migrations.
+	Migrations []Migration
+	// Detect returns the version of a non-empty database without a version,
+	// written before the versioning.
+	Detect func(db dbm.DB) (uint64, error)
+}
+
+// Latest returns the latest version of the schema.
+func (s Schema) Latest() uint64 {
+	return uint64(len(s.Migrations))
+}
+
+// Version returns the version of the schema of db, detected from its keys if
+// it has no version. An empty database is at the latest version.
+func (s Schema) Version(db dbm.DB) (uint64, error) {
+	bz, err := db.Get(schemaVersionKey())
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) > 0 {
+		version, err := strconv.ParseUint(string(bz), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to extract schema version from %s: %w", bz, err)
+		}
+		return version, nil
+	}
+
+	empty, err := isEmpty(db)
+	if err != nil {
+		return 0, err
+	}
+	if empty {
+		return s.Latest(), nil
+	}
+	return s.Detect(db)
+}
+
+// Check returns an ErrStaleSchema if db must be migrated, or an error if it
+// was written by a newer version of Tendermint. It saves the version of a
+// database at the latest version without one.
+func (s Schema) Check(db dbm.DB) error {
+	bz, err := db.Get(schemaVersionKey())
+	if err != nil {
+		return err
+	}
+	version, err := s.Version(db)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case version < s.Latest():
+		return ErrStaleSchema{ID: s.ID, Version: version, Latest: s.Latest()}
+	case version > s.Latest():
+		return fmt.Errorf("the %s database is at schema version %d, which is newer than the version %d "+
+			"of this version of Tendermint", s.ID, version, s.Latest())
+	case len(bz) == 0:
+		return setVersion(db, version)
+	}
+	return nil
+}
+
+// Migrate runs the migrations of db from its version to the latest one, and
+// returns the version it was migrated from. The version is saved after each
+// migration, so an interrupted migration resumes from the last one.
+func (s Schema) Migrate(ctx context.Context, db dbm.DB) (uint64, error) {
+	from, err := s.Version(db)
+	if err != nil {
+		return 0, err
+	}
+	if from > s.Latest() {
+		return from, fmt.Errorf("the %s database is at schema version %d, which is newer than the version %d "+
+			"of this version of Tendermint", s.ID, from, s.Latest())
+	}
+
+	for version := from; version < s.Latest(); version++ {
+		if err := ctx.Err(); err != nil {
+			return from, err
+		}
+		if err := s.Migrations[version].Migrate(ctx, db); err != nil {
+			return from, fmt.Errorf("migrating the %s database to schema version %d: %w", s.ID, version+1, err)
+		}
+		if err := setVersion(db, version+1); err != nil {
+			return from, err
+		}
+	}
+
+	// the version of a database without one is saved even if it is up to date
+	if err := setVersion(db, s.Latest()); err != nil {
+		return from, err
+	}
+	return from, nil
+}
+
+// ErrStaleSchema is returned when a database must be migrated to the latest
+// version of its schema.
+type ErrStaleSchema struct {
+	ID      string
+	Version uint64
+	Latest  uint64
+}
+
+func (e ErrStaleSchema) Error() string {
+	return fmt.Sprintf("the %s database is at schema version %d, but this version of Tendermint requires "+
+		"version %d: run tendermint migrate-db", e.ID, e.Version, e.Latest)
+}
+
+func setVersion(db dbm.DB, version uint64) error {
+	return db.SetSync(schemaVersionKey(), []byte(strconv.FormatUint(version, 10)))
+}
+
+func isEmpty(db dbm.DB) (bool, error) {
+	iter, err := db.Iterator(nil, nil)
+	if err != nil {
+		return false, err
+	}
+	defer iter.Close()
+	return !iter.Valid(), iter.Error()
+}
+
+func schemaVersionKey() []byte {
+	key, err := orderedcode.Append(nil, prefixSchemaVersion)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
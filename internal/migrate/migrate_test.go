@@ -0,0 +1,90 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestCheckEmpty(t *testing.T) {
+	db := dbm.NewMemDB()
+	require.NoError(t, BlockStore.Check(db))
+
+	// the version of an empty database is saved
+	bz, err := db.Get(schemaVersionKey())
+	require.NoError(t, err)
+	require.Equal(t, "1", string(bz))
+}
+
+func TestCheckLegacy(t *testing.T) {
+	db := dbm.NewMemDB()
+	require.NoError(t, db.Set([]byte("H:1"), []byte("meta")))
+	require.NoError(t, db.Set([]byte("P:1:0"), []byte("part")))
+
+	err := BlockStore.Check(db)
+	var staleErr ErrStaleSchema
+	require.True(t, errors.As(err, &staleErr), err)
+	require.Equal(t, ErrStaleSchema{ID: "blockstore", Version: 0, Latest: 1}, staleErr)
+
+	from, err := BlockStore.Migrate(context.Background(), db)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, from)
+	require.NoError(t, BlockStore.Check(db))
+
+	version, err := BlockStore.Version(db)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, version)
+	has, err := db.Has([]byte("H:1"))
+	require.NoError(t, err)
+	require.False(t, has)
+}
+
+func TestCheckUnversioned(t *testing.T) {
+	// a database written with the latest layout before the versioning
+	db := dbm.NewMemDB()
+	require.NoError(t, db.Set([]byte{0x80, 0x01}, []byte("value")))
+
+	require.NoError(t, State.Check(db))
+	version, err := State.Version(db)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, version)
+}
+
+func TestCheckNewer(t *testing.T) {
+	db := dbm.NewMemDB()
+	require.NoError(t, setVersion(db, State.Latest()+1))
+	require.Error(t, State.Check(db))
+
+	_, err := State.Migrate(context.Background(), db)
+	require.Error(t, err)
+}
+
+func TestMigrateResume(t *testing.T) {
+	var runs []int
+	schema := Schema{
+		ID: "test",
+		Migrations: []Migration{
+			{Migrate: func(context.Context, dbm.DB) error { runs = append(runs, 0); return nil }},
+			{Migrate: func(context.Context, dbm.DB) error { runs = append(runs, 1); return errors.New("interrupted") }},
+		},
+		Detect: func(dbm.DB) (uint64, error) { return 0, nil },
+	}
+	db := dbm.NewMemDB()
+	require.NoError(t, db.Set([]byte("key"), []byte("value")))
+
+	_, err := schema.Migrate(context.Background(), db)
+	require.Error(t, err)
+	version, err := schema.Version(db)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, version)
+
+	schema.Migrations[1].Migrate = func(context.Context, dbm.DB) error { runs = append(runs, 1); return nil }
+	from, err := schema.Migrate(context.Background(), db)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, from)
+	require.Equal(t, []int{0, 1, 1}, runs)
+	require.NoError(t, schema.Check(db))
+}
@@ -0,0 +1,58 @@
+package migrate
+
+import (
+	"context"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/scripts/keymigrate"
+)
+
+// orderedKeys migrates the legacy string keys to the keys encoded with
+// orderedcode, from version 0 to version 1.
+var orderedKeys = Migration{
+	Description: "encode the keys with orderedcode",
+	Migrate: func(ctx context.Context, db dbm.DB) error {
+		return keymigrate.Migrate(ctx, db)
+	},
+}
+
+// BlockStore is the schema of the block store database.
+var BlockStore = Schema{
+	ID:         "blockstore",
+	Migrations: []Migration{orderedKeys},
+	Detect:     detectLegacyKeys("H:", "P:", "C:", "SC:", "BH:"),
+}
+
+// State is the schema of the state store database.
+var State = Schema{
+	ID:         "state",
+	Migrations: []Migration{orderedKeys},
+	Detect:     detectLegacyKeys("validatorsKey:", "consensusParamsKey:", "abciResponsesKey:", "stateKey"),
+}
+
+// Schemas are the versioned schemas of the databases.
+var Schemas = []Schema{BlockStore, State}
+
+// detectLegacyKeys returns a Detect function returning version 0 if the
+// database has keys with one of the legacy prefixes, or version 1 otherwise.
+func detectLegacyKeys(prefixes ...string) func(dbm.DB) (uint64, error) {
+	return func(db dbm.DB) (uint64, error) {
+		for _, prefix := range prefixes {
+			iter, err := dbm.IteratePrefix(db, []byte(prefix))
+			if err != nil {
+				return 0, err
+			}
+			legacy := iter.Valid()
+			err = iter.Error()
+			iter.Close()
+			if err != nil {
+				return 0, err
+			}
+			if legacy {
+				return 0, nil
+			}
+		}
+		return 1, nil
+	}
+}
@@ -0,0 +1,128 @@
+package p2p
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/internal/libs/autofile"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+// The directions of the connections in the audit log.
+const (
+	AuditInbound  = "inbound"
+	AuditOutbound = "outbound"
+)
+
+// The events of the audit log: the outcome of a connection attempt, or the
+// disconnection of a connected peer.
+const (
+	AuditConnected       = "connected"
+	AuditRateLimited     = "rate_limited"
+	AuditFiltered        = "filtered"
+	AuditDialFailed      = "dial_failed"
+	AuditHandshakeFailed = "handshake_failed"
+	AuditRejected        = "rejected"
+	AuditDisconnected    = "disconnected"
+)
+
+// AuditEntry is a line of the audit log.
+type AuditEntry struct {
+	Time      time.Time    `json:"time"`
+	Event     string       `json:"event"`
+	Direction string       `json:"direction"`
+	NodeID    types.NodeID `json:"node_id,omitempty"`
+	Address   string       `json:"address"`
+	// Error is the reason of a failed attempt or of a disconnection.
+	Error string `json:"error,omitempty"`
+	// Duration is how long a disconnected peer was connected for.
+	Duration string `json:"duration,omitempty"`
+}
+
+// AuditLog is an append-only log of the connection attempts of a Router and of
+// the disconnections of its peers, with an AuditEntry in JSON per line. The
+// head file is rotated when it grows over a size limit, and the oldest files
+// are removed when the files grow over a total size limit.
+//
+// A nil AuditLog discards the entries.
+type AuditLog struct {
+	logger log.Logger
+
+	mtx    sync.Mutex
+	group  *autofile.Group
+	closed bool
+}
+
+// NewAuditLog opens the audit log at path, appending to it if it exists. The
+// head file is rotated over headSizeLimit bytes, and the oldest files are
+// removed over totalSizeLimit bytes, 0 disabling either.
+func NewAuditLog(logger log.Logger, path string, headSizeLimit, totalSizeLimit int64) (*AuditLog, error) {
+	group, err := autofile.OpenGroup(path,
+		autofile.GroupHeadSizeLimit(headSizeLimit),
+		autofile.GroupTotalSizeLimit(totalSizeLimit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	group.SetLogger(logger)
+	return &AuditLog{logger: logger, group: group}, nil
+}
+
+// Log appends an entry to the audit log, timestamped now. The entry is synced
+// to disk before returning, and a failure to write it is logged.
+func (a *AuditLog) Log(entry AuditEntry) {
+	if a == nil {
+		return
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		a.logger.Error("failed to encode the audit log entry", "err", err)
+		return
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if a.closed {
+		return
+	}
+	if err := a.group.WriteLine(string(line)); err != nil {
+		a.logger.Error("failed to write the audit log entry", "err", err)
+		return
+	}
+	if err := a.group.FlushAndSync(); err != nil {
+		a.logger.Error("failed to sync the audit log", "err", err)
+	}
+}
+
+// start starts rotating the files of the audit log.
+func (a *AuditLog) start() error {
+	if a == nil {
+		return nil
+	}
+	return a.group.Start()
+}
+
+// close stops rotating the files of the audit log and closes it. The entries
+// logged after are discarded.
+func (a *AuditLog) close() {
+	if a == nil {
+		return
+	}
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if a.closed {
+		return
+	}
+	a.closed = true
+	if a.group.IsRunning() {
+		if err := a.group.Stop(); err != nil {
+			a.logger.Error("failed to stop the audit log", "err", err)
+		}
+	}
+	a.group.Close()
+}
@@ -0,0 +1,97 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := NewAuditLog(log.TestingLogger(), path, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, auditLog.start())
+
+	auditLog.Log(AuditEntry{
+		Event:     AuditDisconnected,
+		Direction: AuditOutbound,
+		NodeID:    "00000000000000000000000000000000000000aa",
+		Address:   "tcp://00000000000000000000000000000000000000aa@127.0.0.1:26656",
+		Error:     "evicted",
+		Duration:  time.Minute.String(),
+	})
+	auditLog.close()
+	// the entries logged once closed are discarded
+	auditLog.Log(AuditEntry{Event: AuditConnected, Direction: AuditInbound})
+
+	entries := readAuditLog(t, path)
+	require.Len(t, entries, 1)
+	require.Equal(t, AuditDisconnected, entries[0].Event)
+	require.Equal(t, AuditOutbound, entries[0].Direction)
+	require.Equal(t, "evicted", entries[0].Error)
+	require.Equal(t, "1m0s", entries[0].Duration)
+	require.False(t, entries[0].Time.IsZero())
+
+	// a nil audit log discards the entries
+	var nilLog *AuditLog
+	require.NoError(t, nilLog.start())
+	nilLog.Log(AuditEntry{Event: AuditConnected})
+	nilLog.close()
+}
+
+func TestAuditLogFilteredConnection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := log.TestingLogger()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := NewAuditLog(logger, path, 0, 0)
+	require.NoError(t, err)
+	defer auditLog.close()
+
+	router := &Router{
+		logger:      logger,
+		connTracker: newConnTracker(1, time.Second),
+		options: RouterOptions{
+			FilterPeerByIP: func(ctx context.Context, ip net.IP, port uint16) error {
+				return errors.New("banned")
+			},
+			AuditLog: auditLog,
+		},
+	}
+	router.openConnection(ctx, &MemoryConnection{logger: logger, closer: sync.NewCloser()})
+
+	entries := readAuditLog(t, path)
+	require.Len(t, entries, 1)
+	require.Equal(t, AuditFiltered, entries[0].Event)
+	require.Equal(t, AuditInbound, entries[0].Direction)
+	require.Equal(t, "banned", entries[0].Error)
+}
+
+func readAuditLog(t *testing.T, path string) []AuditEntry {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
@@ -111,6 +111,9 @@ type MConnection struct {
 	pongTimer     *time.Timer
 	pongTimeoutCh chan bool // true - timeout, false - peer sent pong
 
+	pingSent      time.Time // time the last ping was sent, zero once answered
+	roundTripTime int64     // of the last answered ping, atomic
+
 	chStatsTimer *time.Ticker // update channel stats periodically
 
 	created time.Time // time of creation
@@ -134,6 +137,14 @@ type MConnConfig struct {
 
 	// Maximum wait time for pongs
 	PongTimeout time.Duration `mapstructure:"pong_timeout"`
+
+	// Rekey the underlying secret connection after this many bytes have been
+	// sent under a single key. 0 disables byte-based rekeying.
+	RekeyBytes uint64 `mapstructure:"rekey_bytes"`
+
+	// Rekey the underlying secret connection after a key has been in use for
+	// this long, on the next write. 0 disables time-based rekeying.
+	RekeyInterval time.Duration `mapstructure:"rekey_interval"`
 }
 
 // DefaultMConnConfig returns the default config.
@@ -445,6 +456,7 @@ FOR_LOOP:
 				break SELECTION
 			}
 			c.sendMonitor.Update(_n)
+			c.pingSent = time.Now()
 			c.Logger.Debug("Starting pong timer", "dur", c.config.PongTimeout)
 			c.pongTimer = time.AfterFunc(c.config.PongTimeout, func() {
 				select {
@@ -459,6 +471,10 @@ FOR_LOOP:
 				err = errors.New("pong timeout")
 			} else {
 				c.stopPongTimer()
+				if !c.pingSent.IsZero() {
+					atomic.StoreInt64(&c.roundTripTime, int64(time.Since(c.pingSent)))
+					c.pingSent = time.Time{}
+				}
 			}
 		case <-c.pong:
 			c.Logger.Debug("Send Pong")
@@ -687,6 +703,8 @@ type ConnectionStatus struct {
 	SendMonitor flow.Status
 	RecvMonitor flow.Status
 	Channels    []ChannelStatus
+	// RoundTripTime is measured with the last ping answered, 0 if none was.
+	RoundTripTime time.Duration
 }
 
 type ChannelStatus struct {
@@ -702,6 +720,7 @@ func (c *MConnection) Status() ConnectionStatus {
 	status.Duration = time.Since(c.created)
 	status.SendMonitor = c.sendMonitor.Status()
 	status.RecvMonitor = c.recvMonitor.Status()
+	status.RoundTripTime = time.Duration(atomic.LoadInt64(&c.roundTripTime))
 	status.Channels = make([]ChannelStatus, len(c.channels))
 	for i, channel := range c.channels {
 		status.Channels[i] = ChannelStatus{
@@ -353,6 +353,8 @@ func TestMConnectionPingPongs(t *testing.T) {
 	case <-time.After(2 * pongTimerExpired):
 		assert.True(t, mconn.IsRunning())
 	}
+	// the pongs give the round-trip time
+	assert.Greater(t, int64(mconn.Status().RoundTripTime), int64(0))
 }
 
 func TestMConnectionStopsAndReturnsError(t *testing.T) {
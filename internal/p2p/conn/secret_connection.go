@@ -43,12 +43,19 @@ const (
 	labelEphemeralUpperPublicKey = "EPHEMERAL_UPPER_PUBLIC_KEY"
 	labelDHSecret                = "DH_SECRET"
 	labelSecretConnectionMac     = "SECRET_CONNECTION_MAC"
+
+	// rekeyFrameMarker is written in place of the chunk length to signal that
+	// the sender switches to the next key in its chain after this frame. It
+	// can never collide with a real chunk length as those are bounded by
+	// dataMaxSize.
+	rekeyFrameMarker = math.MaxUint32
 )
 
 var (
 	ErrSmallOrderRemotePubKey = errors.New("detected low order point from remote peer")
 
 	secretConnKeyAndChallengeGen = []byte("TENDERMINT_SECRET_CONNECTION_KEY_AND_CHALLENGE_GEN")
+	secretConnRekeyGen           = []byte("TENDERMINT_SECRET_CONNECTION_REKEY_GEN")
 )
 
 // SecretConnection implements net.Conn.
@@ -60,12 +67,18 @@ var (
 // the remote peer's pubkey against known information, like a nodeID.
 // Otherwise they are vulnerable to MITM.
 // (TODO(ismail): see also https://github.com/tendermint/tendermint/issues/3010)
+//
+// Each direction of the connection can be rekeyed independently: the sender
+// emits a rekey frame under its current key and then replaces that key with
+// one derived from it through HKDF, resetting the nonce. Since the derivation
+// is one-way and the old key is erased, compromising the current key does not
+// expose traffic sent under earlier keys. There is no new Diffie-Hellman
+// exchange though, so it does not protect the traffic sent under later keys
+// either: all of them are derived from the current one. Rekey frames are always
+// accepted, but only sent once SetRekeyPolicy has been called.
 type SecretConnection struct {
 
 	// immutable
-	recvAead cipher.AEAD
-	sendAead cipher.AEAD
-
 	remPubKey crypto.PubKey
 	conn      io.ReadWriteCloser
 
@@ -79,9 +92,19 @@ type SecretConnection struct {
 	recvMtx    tmsync.Mutex
 	recvBuffer []byte
 	recvNonce  *[aeadNonceSize]byte
-
-	sendMtx   tmsync.Mutex
-	sendNonce *[aeadNonceSize]byte
+	recvSecret *[aeadKeySize]byte
+	recvAead   cipher.AEAD
+	recvRekeys uint64
+
+	sendMtx       tmsync.Mutex
+	sendNonce     *[aeadNonceSize]byte
+	sendSecret    *[aeadKeySize]byte
+	sendAead      cipher.AEAD
+	sendRekeys    uint64
+	sentBytes     uint64
+	lastRekey     time.Time
+	rekeyBytes    uint64
+	rekeyInterval time.Duration
 }
 
 // MakeSecretConnection performs handshake and returns a new authenticated
@@ -148,8 +171,11 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*
 		recvBuffer: nil,
 		recvNonce:  new([aeadNonceSize]byte),
 		sendNonce:  new([aeadNonceSize]byte),
+		recvSecret: recvSecret,
+		sendSecret: sendSecret,
 		recvAead:   recvAead,
 		sendAead:   sendAead,
+		lastRekey:  time.Now(),
 	}
 
 	// Sign the challenge bytes for authentication.
@@ -184,6 +210,87 @@ func (sc *SecretConnection) RemotePubKey() crypto.PubKey {
 	return sc.remPubKey
 }
 
+// SetRekeyPolicy enables rekeying of the sending direction once either
+// maxBytes of plaintext have been sent or maxAge has elapsed under the current
+// key. A zero value disables the respective threshold. The thresholds are only
+// checked on Write, so the key of an idle direction is rotated on its next
+// write, not when maxAge elapses. The remote peer must understand rekey frames,
+// so this should only be enabled once the peer is known to support them.
+func (sc *SecretConnection) SetRekeyPolicy(maxBytes uint64, maxAge time.Duration) {
+	sc.sendMtx.Lock()
+	defer sc.sendMtx.Unlock()
+
+	sc.rekeyBytes = maxBytes
+	sc.rekeyInterval = maxAge
+}
+
+// Rekeys returns the number of times the sending and the receiving keys have
+// been rotated.
+func (sc *SecretConnection) Rekeys() (sent, received uint64) {
+	sc.sendMtx.Lock()
+	sent = sc.sendRekeys
+	sc.sendMtx.Unlock()
+
+	sc.recvMtx.Lock()
+	received = sc.recvRekeys
+	sc.recvMtx.Unlock()
+	return sent, received
+}
+
+// shouldRekey reports whether the send key has exceeded the rekey policy.
+// Callers must hold sendMtx.
+func (sc *SecretConnection) shouldRekey() bool {
+	if sc.rekeyBytes > 0 && sc.sentBytes >= sc.rekeyBytes {
+		return true
+	}
+	return sc.rekeyInterval > 0 && time.Since(sc.lastRekey) >= sc.rekeyInterval
+}
+
+// rekeySend writes a rekey frame under the current send key and then
+// switches to the next key. Callers must hold sendMtx.
+func (sc *SecretConnection) rekeySend() error {
+	var sealedFrame = pool.Get(aeadSizeOverhead + totalFrameSize)
+	var frame = pool.Get(totalFrameSize)
+	defer func() {
+		pool.Put(sealedFrame)
+		pool.Put(frame)
+	}()
+	for i := range frame {
+		frame[i] = 0
+	}
+	binary.LittleEndian.PutUint32(frame, rekeyFrameMarker)
+
+	sc.sendAead.Seal(sealedFrame[:0], sc.sendNonce[:], frame, nil)
+	incrNonce(sc.sendNonce)
+	if _, err := sc.conn.Write(sealedFrame); err != nil {
+		return err
+	}
+
+	secret, aead, err := nextSecret(sc.sendSecret)
+	if err != nil {
+		return err
+	}
+	sc.sendSecret, sc.sendAead = secret, aead
+	sc.sendNonce = new([aeadNonceSize]byte)
+	sc.sendRekeys++
+	sc.sentBytes = 0
+	sc.lastRekey = time.Now()
+	return nil
+}
+
+// rekeyRecv switches to the next receive key after a rekey frame has been
+// read. Callers must hold recvMtx.
+func (sc *SecretConnection) rekeyRecv() error {
+	secret, aead, err := nextSecret(sc.recvSecret)
+	if err != nil {
+		return err
+	}
+	sc.recvSecret, sc.recvAead = secret, aead
+	sc.recvNonce = new([aeadNonceSize]byte)
+	sc.recvRekeys++
+	return nil
+}
+
 // Writes encrypted frames of `totalFrameSize + aeadSizeOverhead`.
 // CONTRACT: data smaller than dataMaxSize is written atomically.
 func (sc *SecretConnection) Write(data []byte) (n int, err error) {
@@ -191,6 +298,11 @@ func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 	defer sc.sendMtx.Unlock()
 
 	for 0 < len(data) {
+		if sc.shouldRekey() {
+			if err := sc.rekeySend(); err != nil {
+				return n, err
+			}
+		}
 		if err := func() error {
 			var sealedFrame = pool.Get(aeadSizeOverhead + totalFrameSize)
 			var frame = pool.Get(totalFrameSize)
@@ -220,6 +332,7 @@ func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 				return err
 			}
 			n += len(chunk)
+			sc.sentBytes += uint64(len(chunk))
 			return nil
 		}(); err != nil {
 			return n, err
@@ -240,38 +353,47 @@ func (sc *SecretConnection) Read(data []byte) (n int, err error) {
 		return
 	}
 
-	// read off the conn
 	var sealedFrame = pool.Get(aeadSizeOverhead + totalFrameSize)
 	defer pool.Put(sealedFrame)
-	_, err = io.ReadFull(sc.conn, sealedFrame)
-	if err != nil {
-		return
-	}
-
-	// decrypt the frame.
-	// reads and updates the sc.recvNonce
 	var frame = pool.Get(totalFrameSize)
 	defer pool.Put(frame)
-	_, err = sc.recvAead.Open(frame[:0], sc.recvNonce[:], sealedFrame, nil)
-	if err != nil {
-		return n, fmt.Errorf("failed to decrypt SecretConnection: %w", err)
-	}
-	incrNonce(sc.recvNonce)
-	// end decryption
-
-	// copy checkLength worth into data,
-	// set recvBuffer to the rest.
-	var chunkLength = binary.LittleEndian.Uint32(frame) // read the first four bytes
-	if chunkLength > dataMaxSize {
-		return 0, errors.New("chunkLength is greater than dataMaxSize")
-	}
-	var chunk = frame[dataLenSize : dataLenSize+chunkLength]
-	n = copy(data, chunk)
-	if n < len(chunk) {
-		sc.recvBuffer = make([]byte, len(chunk)-n)
-		copy(sc.recvBuffer, chunk[n:])
+
+	for {
+		// read off the conn
+		_, err = io.ReadFull(sc.conn, sealedFrame)
+		if err != nil {
+			return
+		}
+
+		// decrypt the frame.
+		// reads and updates the sc.recvNonce
+		_, err = sc.recvAead.Open(frame[:0], sc.recvNonce[:], sealedFrame, nil)
+		if err != nil {
+			return n, fmt.Errorf("failed to decrypt SecretConnection: %w", err)
+		}
+		incrNonce(sc.recvNonce)
+		// end decryption
+
+		// copy checkLength worth into data,
+		// set recvBuffer to the rest.
+		var chunkLength = binary.LittleEndian.Uint32(frame) // read the first four bytes
+		if chunkLength == rekeyFrameMarker {
+			if err = sc.rekeyRecv(); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if chunkLength > dataMaxSize {
+			return 0, errors.New("chunkLength is greater than dataMaxSize")
+		}
+		var chunk = frame[dataLenSize : dataLenSize+chunkLength]
+		n = copy(data, chunk)
+		if n < len(chunk) {
+			sc.recvBuffer = make([]byte, len(chunk)-n)
+			copy(sc.recvBuffer, chunk[n:])
+		}
+		return n, err
 	}
-	return n, err
 }
 
 // Implements net.Conn
@@ -365,6 +487,26 @@ func deriveSecrets(
 	return
 }
 
+// nextSecret derives the key following secret in a rekey chain, along with
+// its AEAD. The derivation is one-way, so earlier keys can't be recovered from
+// later ones.
+func nextSecret(secret *[aeadKeySize]byte) (*[aeadKeySize]byte, cipher.AEAD, error) {
+	hkdf := hkdf.New(sha256.New, secret[:], nil, secretConnRekeyGen)
+	next := new([aeadKeySize]byte)
+	if _, err := io.ReadFull(hkdf, next[:]); err != nil {
+		return nil, nil, err
+	}
+	aead, err := chacha20poly1305.New(next[:])
+	if err != nil {
+		return nil, nil, errors.New("invalid rekeyed SecretConnection Key")
+	}
+	// The old key is no longer needed; don't keep it around in memory.
+	for i := range secret {
+		secret[i] = 0
+	}
+	return next, aead, nil
+}
+
 // computeDHSecret computes a Diffie-Hellman shared secret key
 // from our own local private key and the other's public key.
 func computeDHSecret(remPubKey, locPrivKey *[32]byte) (*[32]byte, error) {
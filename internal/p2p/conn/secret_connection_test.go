@@ -469,3 +469,40 @@ func BenchmarkReadSecretConnection(b *testing.B) {
 	}
 	b.StopTimer()
 }
+
+func TestSecretConnectionRekey(t *testing.T) {
+	fooSecConn, barSecConn := makeSecretConnPair(t)
+	fooSecConn.SetRekeyPolicy(4*dataMaxSize, 0)
+	barSecConn.SetRekeyPolicy(0, 0)
+
+	fooWriteText := tmrand.Str(dataMaxSize)
+	n := 20
+	wg := new(sync.WaitGroup)
+	wg.Add(2)
+	go writeLots(t, wg, fooSecConn, fooWriteText, n)
+	go writeLots(t, wg, barSecConn, fooWriteText, n)
+
+	readData := func(conn *SecretConnection) string {
+		var out strings.Builder
+		buf := make([]byte, dataMaxSize)
+		for out.Len() < n*dataMaxSize {
+			read, err := conn.Read(buf)
+			require.NoError(t, err)
+			out.Write(buf[:read])
+		}
+		return out.String()
+	}
+	require.Equal(t, strings.Repeat(fooWriteText, n), readData(barSecConn))
+	require.Equal(t, strings.Repeat(fooWriteText, n), readData(fooSecConn))
+	wg.Wait()
+
+	fooSent, fooRecv := fooSecConn.Rekeys()
+	barSent, barRecv := barSecConn.Rekeys()
+	assert.EqualValues(t, n/4-1, fooSent)
+	assert.EqualValues(t, fooSent, barRecv)
+	assert.Zero(t, barSent)
+	assert.Zero(t, fooRecv)
+
+	require.NoError(t, fooSecConn.Close())
+	require.NoError(t, barSecConn.Close())
+}
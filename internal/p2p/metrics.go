@@ -1,10 +1,14 @@
 package p2p
 
 import (
+	"sync"
+
 	"github.com/go-kit/kit/metrics"
 	"github.com/go-kit/kit/metrics/discard"
 	"github.com/go-kit/kit/metrics/prometheus"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	"github.com/tendermint/tendermint/types"
 )
 
 const (
@@ -23,6 +27,13 @@ type Metrics struct {
 	PeerSendBytesTotal metrics.Counter
 	// Pending bytes to be sent to a given peer.
 	PeerPendingSendBytes metrics.Gauge
+	// Number of messages received from a given peer, by channel.
+	PeerReceiveMessagesTotal metrics.Counter
+	// Number of messages sent to a given peer, by channel.
+	PeerSendMessagesTotal metrics.Counter
+	// Round-trip time to a given peer, in seconds, measured with the pings of
+	// the connection.
+	PeerRoundTripTime metrics.Gauge
 
 	// RouterPeerQueueRecv defines the time taken to read off of a peer's queue
 	// before sending on the connection.
@@ -43,6 +54,8 @@ type Metrics struct {
 	// PeerQueueMsgSize defines the average size of messages sent over a peer's
 	// queue for a specific flow (i.e. Channel).
 	PeerQueueMsgSize metrics.Gauge
+
+	peerLabels peerLabels
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -84,6 +97,27 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help:      "Number of pending bytes to be sent to a given peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
 
+		PeerReceiveMessagesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_receive_messages_total",
+			Help:      "Number of messages received from a given peer, by channel.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+
+		PeerSendMessagesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_send_messages_total",
+			Help:      "Number of messages sent to a given peer, by channel.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+
+		PeerRoundTripTime: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_round_trip_time_seconds",
+			Help:      "Round-trip time to a given peer, measured with the pings of the connection.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+
 		RouterPeerQueueRecv: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -124,14 +158,59 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 // NopMetrics returns no-op Metrics.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Peers:                  discard.NewGauge(),
-		PeerReceiveBytesTotal:  discard.NewCounter(),
-		PeerSendBytesTotal:     discard.NewCounter(),
-		PeerPendingSendBytes:   discard.NewGauge(),
-		RouterPeerQueueRecv:    discard.NewHistogram(),
-		RouterPeerQueueSend:    discard.NewHistogram(),
-		RouterChannelQueueSend: discard.NewHistogram(),
-		PeerQueueDroppedMsgs:   discard.NewCounter(),
-		PeerQueueMsgSize:       discard.NewGauge(),
+		Peers:                    discard.NewGauge(),
+		PeerReceiveBytesTotal:    discard.NewCounter(),
+		PeerSendBytesTotal:       discard.NewCounter(),
+		PeerPendingSendBytes:     discard.NewGauge(),
+		PeerReceiveMessagesTotal: discard.NewCounter(),
+		PeerSendMessagesTotal:    discard.NewCounter(),
+		PeerRoundTripTime:        discard.NewGauge(),
+		RouterPeerQueueRecv:      discard.NewHistogram(),
+		RouterPeerQueueSend:      discard.NewHistogram(),
+		RouterChannelQueueSend:   discard.NewHistogram(),
+		PeerQueueDroppedMsgs:     discard.NewCounter(),
+		PeerQueueMsgSize:         discard.NewGauge(),
+	}
+}
+
+// OtherPeersLabel is the peer_id label value of the peers beyond the limit set
+// with LimitPeerLabels.
+const OtherPeersLabel = "other"
+
+// LimitPeerLabels limits the number of peers with their own peer_id label
+// value to max, 0 meaning no limit, as each value adds series for every
+// channel. The first max peers keep their label until the node restarts,
+// the others share OtherPeersLabel. It must be called before the metrics are
+// used.
+func (m *Metrics) LimitPeerLabels(max int) *Metrics {
+	m.peerLabels.max = max
+	return m
+}
+
+// peerLabels assigns the peer_id label values.
+type peerLabels struct {
+	mtx     sync.Mutex
+	max     int
+	labeled map[types.NodeID]struct{}
+}
+
+// peerLabel returns the peer_id label value of a peer.
+func (m *Metrics) peerLabel(peerID types.NodeID) string {
+	l := &m.peerLabels
+	if l.max <= 0 {
+		return string(peerID)
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if _, ok := l.labeled[peerID]; ok {
+		return string(peerID)
+	}
+	if len(l.labeled) >= l.max {
+		return OtherPeersLabel
+	}
+	if l.labeled == nil {
+		l.labeled = make(map[types.NodeID]struct{})
 	}
+	l.labeled[peerID] = struct{}{}
+	return string(peerID)
 }
@@ -0,0 +1,27 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestMetricsPeerLabel(t *testing.T) {
+	a, b, c := types.NodeID("aa"), types.NodeID("bb"), types.NodeID("cc")
+
+	// unlimited by default
+	m := NopMetrics()
+	for _, peerID := range []types.NodeID{a, b, c} {
+		assert.Equal(t, string(peerID), m.peerLabel(peerID))
+	}
+
+	// the first peers keep their label
+	m = NopMetrics().LimitPeerLabels(2)
+	assert.Equal(t, "aa", m.peerLabel(a))
+	assert.Equal(t, "bb", m.peerLabel(b))
+	assert.Equal(t, OtherPeersLabel, m.peerLabel(c))
+	assert.Equal(t, "aa", m.peerLabel(a))
+	assert.Equal(t, OtherPeersLabel, m.peerLabel(c))
+}
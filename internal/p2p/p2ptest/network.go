@@ -38,6 +38,7 @@ type NetworkOptions struct {
 type NodeOptions struct {
 	MaxPeers     uint16
 	MaxConnected uint16
+	Features     []string
 }
 
 func (opts *NetworkOptions) setDefaults() {
@@ -96,8 +97,9 @@ func (n *Network) Start(t *testing.T) {
 			select {
 			case peerUpdate := <-sourceSub.Updates():
 				require.Equal(t, p2p.PeerUpdate{
-					NodeID: targetNode.NodeID,
-					Status: p2p.PeerStatusUp,
+					NodeID:   targetNode.NodeID,
+					Status:   p2p.PeerStatusUp,
+					Features: targetNode.NodeInfo.Features,
 				}, peerUpdate)
 			case <-time.After(3 * time.Second):
 				require.Fail(t, "timed out waiting for peer", "%v dialing %v",
@@ -107,8 +109,9 @@ func (n *Network) Start(t *testing.T) {
 			select {
 			case peerUpdate := <-targetSub.Updates():
 				require.Equal(t, p2p.PeerUpdate{
-					NodeID: sourceNode.NodeID,
-					Status: p2p.PeerStatusUp,
+					NodeID:   sourceNode.NodeID,
+					Status:   p2p.PeerStatusUp,
+					Features: sourceNode.NodeInfo.Features,
 				}, peerUpdate)
 			case <-time.After(3 * time.Second):
 				require.Fail(t, "timed out waiting for peer", "%v accepting %v",
@@ -233,6 +236,7 @@ func (n *Network) MakeNode(t *testing.T, opts NodeOptions) *Node {
 		NodeID:     nodeID,
 		ListenAddr: "0.0.0.0:0", // FIXME: We have to fake this for now.
 		Moniker:    string(nodeID),
+		Features:   opts.Features,
 	}
 
 	transport := n.memoryNetwork.CreateTransport(nodeID)
@@ -175,6 +175,12 @@ func (p *peer) processMessages() {
 			p.onError(fmt.Errorf("unknown channel %v", chID))
 			return
 		}
+		labels := []string{
+			"peer_id", p.metrics.peerLabel(p.ID()),
+			"chID", fmt.Sprintf("%#x", chID),
+		}
+		p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msg)))
+		p.metrics.PeerReceiveMessagesTotal.With(labels...).Add(1)
 		reactor.Receive(byte(chID), p, msg)
 	}
 }
@@ -259,10 +265,11 @@ func (p *peer) Send(chID byte, msgBytes []byte) bool {
 	}
 	if res {
 		labels := []string{
-			"peer_id", string(p.ID()),
+			"peer_id", p.metrics.peerLabel(p.ID()),
 			"chID", fmt.Sprintf("%#x", chID),
 		}
 		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+		p.metrics.PeerSendMessagesTotal.With(labels...).Add(1)
 	}
 	return res
 }
@@ -284,10 +291,11 @@ func (p *peer) TrySend(chID byte, msgBytes []byte) bool {
 	}
 	if res {
 		labels := []string{
-			"peer_id", string(p.ID()),
+			"peer_id", p.metrics.peerLabel(p.ID()),
 			"chID", fmt.Sprintf("%#x", chID),
 		}
 		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+		p.metrics.PeerSendMessagesTotal.With(labels...).Add(1)
 	}
 	return res
 }
@@ -363,7 +371,13 @@ func (p *peer) metricsReporter() {
 				sendQueueSize += float64(chStatus.SendQueueSize)
 			}
 
-			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(sendQueueSize)
+			// the peers without their own label share the gauges
+			if label := p.metrics.peerLabel(p.ID()); label != OtherPeersLabel {
+				p.metrics.PeerPendingSendBytes.With("peer_id", label).Set(sendQueueSize)
+				if status.RoundTripTime > 0 {
+					p.metrics.PeerRoundTripTime.With("peer_id", label).Set(status.RoundTripTime.Seconds())
+				}
+			}
 		case <-p.Quit():
 			return
 		}
@@ -0,0 +1,186 @@
+package p2p
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// The peer store is split into buckets to make eclipse attacks harder, in the
+// same way as the address book of bitcoind. The peers we have successfully
+// dialed are "tried", and spread over the tried buckets by their address
+// group. The others are "new", and spread over the new buckets by both their
+// address group and the address group of the peer we learned them from, so
+// that a source can only ever fill a few buckets, whatever the number of
+// addresses it sends us. The buckets are keyed by a secret random key, so that
+// an attacker can't pick addresses landing in given buckets.
+
+// peerBucket identifies a bucket of the peer store.
+type peerBucket struct {
+	tried bool
+	index uint64
+}
+
+// addressGroup returns the group of the address, i.e. the /16 subnet of IPv4
+// addresses and the /32 subnet of IPv6 ones, all the local addresses and all
+// the unroutable ones being grouped together. Hostnames are grouped by name,
+// as we can't tell which IPs they resolve to.
+func addressGroup(address NodeAddress) string {
+	ip := net.ParseIP(address.Hostname)
+	if ip == nil {
+		return address.Hostname
+	}
+
+	na := &types.NetAddress{ID: address.NodeID, IP: ip}
+	switch {
+	case na.Local():
+		return "local"
+	case !na.Routable():
+		return "unroutable"
+	case ip.To4() != nil:
+		return ip.Mask(net.CIDRMask(16, 32)).String()
+	default:
+		return ip.Mask(net.CIDRMask(32, 128)).String()
+	}
+}
+
+// bucketingEnabled returns whether the peer store is split into buckets.
+func (o *PeerManagerOptions) bucketingEnabled() bool {
+	return o.BucketSize > 0
+}
+
+// bucket returns the bucket of the peer.
+func (m *PeerManager) bucket(peer *peerInfo) peerBucket {
+	group := peer.group()
+	if peer.tried() {
+		return peerBucket{
+			tried: true,
+			index: m.hashGroups("tried", group) % uint64(m.options.TriedBuckets),
+		}
+	}
+	return peerBucket{
+		index: m.hashGroups("new", peer.SourceGroup, group) % uint64(m.options.NewBuckets),
+	}
+}
+
+// hashGroups hashes the groups with the bucket key of the peer store.
+func (m *PeerManager) hashGroups(groups ...string) uint64 {
+	h := sha256.New()
+	_, _ = h.Write(m.store.bucketKey)
+	for _, group := range groups {
+		_, _ = h.Write([]byte(group))
+		_, _ = h.Write([]byte{0})
+	}
+	return binary.BigEndian.Uint64(h.Sum(nil))
+}
+
+// sourceGroup returns the address group of a source peer, or its ID if we
+// don't know its addresses.
+func (m *PeerManager) sourceGroup(source types.NodeID) string {
+	if peer, ok := m.store.Get(source); ok && len(peer.AddressInfo) > 0 {
+		return peer.group()
+	}
+	return string(source)
+}
+
+// makeRoom makes room for the peer in its bucket, if it is full, by evicting
+// the lowest-scored peer of the bucket we're neither connected nor dialing.
+// Evicted tried peers are moved back to the new buckets, and evicted new peers
+// deleted. It returns false if there is no room to make. The caller must hold
+// the mutex lock.
+func (m *PeerManager) makeRoom(peer *peerInfo) (bool, error) {
+	if !m.options.bucketingEnabled() {
+		return true, nil
+	}
+
+	bucket := m.bucket(peer)
+	var candidates []*peerInfo
+	size := 0
+	for _, p := range m.store.Ranked() {
+		if p.ID == peer.ID || m.bucket(p) != bucket {
+			continue
+		}
+		size++
+		switch {
+		case p.Persistent, p.Anchor:
+		case m.dialing[p.ID], m.connected[p.ID]:
+		default:
+			candidates = append(candidates, p)
+		}
+	}
+	if size < int(m.options.BucketSize) {
+		return true, nil
+	}
+	if len(candidates) == 0 {
+		return false, nil
+	}
+
+	// evict the lowest-scored peer, the one failing the most to dial first
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if si, sj := candidates[i].Score(), candidates[j].Score(); si != sj {
+			return si < sj
+		}
+		return candidates[i].dialFailures() > candidates[j].dialFailures()
+	})
+	victim := candidates[0].Copy()
+
+	if !bucket.tried {
+		return true, m.store.Delete(victim.ID)
+	}
+	for _, addressInfo := range victim.AddressInfo {
+		addressInfo.LastDialSuccess = time.Time{}
+	}
+	room, err := m.makeRoom(&victim)
+	if err != nil {
+		return false, err
+	}
+	if !room {
+		return true, m.store.Delete(victim.ID)
+	}
+	return true, m.store.Set(victim)
+}
+
+// tried returns whether the peer was ever successfully dialed.
+func (p *peerInfo) tried() bool {
+	for _, addressInfo := range p.AddressInfo {
+		if !addressInfo.LastDialSuccess.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// group returns the address group of the peer, the one of its last
+// successfully dialed address, or else of its lowest address.
+func (p *peerInfo) group() string {
+	var latest *peerAddressInfo
+	for _, addressInfo := range p.AddressInfo {
+		switch {
+		case latest == nil:
+			latest = addressInfo
+		case addressInfo.LastDialSuccess.After(latest.LastDialSuccess):
+			latest = addressInfo
+		case addressInfo.LastDialSuccess.Equal(latest.LastDialSuccess) &&
+			addressInfo.Address.String() < latest.Address.String():
+			latest = addressInfo
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return addressGroup(latest.Address)
+}
+
+// dialFailures returns the number of failed dials of the peer addresses since
+// their last successful dial.
+func (p *peerInfo) dialFailures() uint32 {
+	failures := uint32(0)
+	for _, addressInfo := range p.AddressInfo {
+		failures += addressInfo.DialFailures
+	}
+	return failures
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/google/orderedcode"
 	dbm "github.com/tendermint/tm-db"
 
+	"github.com/tendermint/tendermint/crypto"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
 	p2pproto "github.com/tendermint/tendermint/proto/tendermint/p2p"
 	"github.com/tendermint/tendermint/types"
@@ -49,6 +50,10 @@ const (
 type PeerUpdate struct {
 	NodeID types.NodeID
 	Status PeerStatus
+
+	// Features are the optional protocol features advertised by the peer in
+	// its handshake. They are only set for PeerStatusUp.
+	Features []string
 }
 
 // PeerUpdates is a peer update subscription with notifications about peer
@@ -154,6 +159,26 @@ type PeerManagerOptions struct {
 	// consider private and never gossip.
 	PrivatePeers map[types.NodeID]struct{}
 
+	// NewBuckets and TriedBuckets are the number of buckets the peer store is
+	// split into, for the peers we have never and have successfully dialed
+	// respectively, each holding at most BucketSize peers. The new peers are
+	// bucketed by both their address group and the one of the peer we learned
+	// them from, so that a single source can't fill the peer store and eclipse
+	// us. 0 disables bucketing.
+	NewBuckets   uint16
+	TriedBuckets uint16
+	BucketSize   uint16
+
+	// MaxOutboundPerGroup is the maximum number of outbound connections to
+	// peers of a same address group, i.e. /16 IPv4 or /32 IPv6 subnet.
+	// Persistent peers and anchors are exempt. 0 means no limit.
+	MaxOutboundPerGroup uint16
+
+	// Anchors is the number of outbound peers kept as anchors, which are
+	// dialed first on restart to recover the connections we had. 0 disables
+	// anchors.
+	Anchors uint16
+
 	// persistentPeers provides fast PersistentPeers lookups. It is built
 	// by optimize().
 	persistentPeers map[types.NodeID]bool
@@ -195,6 +220,10 @@ func (o *PeerManagerOptions) Validate() error {
 		}
 	}
 
+	if o.BucketSize > 0 && (o.NewBuckets == 0 || o.TriedBuckets == 0) {
+		return errors.New("can't set BucketSize without NewBuckets and TriedBuckets")
+	}
+
 	if o.MaxRetryTimePersistent > 0 {
 		if o.MinRetryTime == 0 {
 			return errors.New("can't set MaxRetryTimePersistent without MinRetryTime")
@@ -288,6 +317,7 @@ type PeerManager struct {
 	ready         map[types.NodeID]bool         // ready peers (Ready → Disconnected)
 	evict         map[types.NodeID]bool         // peers scheduled for eviction (Connected → EvictNext)
 	evicting      map[types.NodeID]bool         // peers being evicted (EvictNext → Disconnected)
+	outbound      map[types.NodeID]string       // address groups of outbound peers (DialNext → DialFailed/Disconnected)
 }
 
 // NewPeerManager creates a new peer manager.
@@ -321,6 +351,7 @@ func NewPeerManager(selfID types.NodeID, peerDB dbm.DB, options PeerManagerOptio
 		ready:         map[types.NodeID]bool{},
 		evict:         map[types.NodeID]bool{},
 		evicting:      map[types.NodeID]bool{},
+		outbound:      map[types.NodeID]string{},
 		subscriptions: map[*PeerUpdates]*PeerUpdates{},
 	}
 	if err = peerManager.configurePeers(); err != nil {
@@ -401,6 +432,41 @@ func (m *PeerManager) prunePeers() error {
 // exists, the address is added to it if it isn't already present. This will push
 // low scoring peers out of the address book if it exceeds the maximum size.
 func (m *PeerManager) Add(address NodeAddress) (bool, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.add(address, "", time.Time{})
+}
+
+// AddFrom adds a peer address learned from the source peer, e.g. over PEX, like
+// Add. If bucketing is enabled, a new peer is only added if there is room
+// for it in its bucket, keyed by the address groups of both the peer and the
+// source.
+func (m *PeerManager) AddFrom(address NodeAddress, source types.NodeID) (bool, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.add(address, m.sourceGroup(source), time.Time{})
+}
+
+// Restore adds a peer address from another address book, e.g. the legacy
+// one, like AddFrom. The source is the address of the peer it was learned
+// from, if any, and lastDialSuccess the last time it was successfully dialed,
+// if ever, for the peer to be bucketed with the tried ones.
+func (m *PeerManager) Restore(address NodeAddress, source *NodeAddress, lastDialSuccess time.Time) (bool, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	sourceGroup := ""
+	if source != nil {
+		sourceGroup = addressGroup(*source)
+	}
+	return m.add(address, sourceGroup, lastDialSuccess)
+}
+
+// add adds a peer address for Add, AddFrom and Restore. The caller must hold
+// the mutex lock.
+func (m *PeerManager) add(address NodeAddress, sourceGroup string, lastDialSuccess time.Time) (bool, error) {
 	if err := address.Validate(); err != nil {
 		return false, err
 	}
@@ -408,21 +474,25 @@ func (m *PeerManager) Add(address NodeAddress) (bool, error) {
 		return false, fmt.Errorf("can't add self (%v) to peer store", m.selfID)
 	}
 
-	m.mtx.Lock()
-	defer m.mtx.Unlock()
-
-	peer, ok := m.store.Get(address.NodeID)
-	if !ok {
+	peer, known := m.store.Get(address.NodeID)
+	if !known {
 		peer = m.newPeerInfo(address.NodeID)
+		peer.SourceGroup = sourceGroup
 	}
-	_, ok = peer.AddressInfo[address]
+	_, ok := peer.AddressInfo[address]
 	// if we already have the peer address, there's no need to continue
 	if ok {
 		return false, nil
 	}
 
-	// else add the new address
-	peer.AddressInfo[address] = &peerAddressInfo{Address: address}
+	// else add the new address, if there is room in the bucket of a new peer
+	peer.AddressInfo[address] = &peerAddressInfo{Address: address, LastDialSuccess: lastDialSuccess}
+	if !known && !peer.Persistent {
+		room, err := m.makeRoom(&peer)
+		if err != nil || !room {
+			return false, err
+		}
+	}
 	if err := m.store.Set(peer); err != nil {
 		return false, err
 	}
@@ -477,7 +547,20 @@ func (m *PeerManager) TryDialNext() (NodeAddress, error) {
 		return NodeAddress{}, nil
 	}
 
-	for _, peer := range m.store.Ranked() {
+	// While we have free connection slots, we dial the anchors first, to
+	// recover the connections we had before restarting.
+	peers := m.store.Ranked()
+	full := m.options.MaxConnected > 0 && len(m.connected) >= int(m.options.MaxConnected)
+	if m.options.Anchors > 0 && !full {
+		peers = anchorsFirst(peers)
+	}
+
+	groups := map[string]int{}
+	for _, group := range m.outbound {
+		groups[group]++
+	}
+
+	for _, peer := range peers {
 		if m.dialing[peer.ID] || m.connected[peer.ID] {
 			continue
 		}
@@ -487,6 +570,15 @@ func (m *PeerManager) TryDialNext() (NodeAddress, error) {
 				continue
 			}
 
+			// We don't make too many outbound connections to a same group,
+			// for an attacker to need addresses in many groups to eclipse us.
+			exempt := peer.Persistent || peer.Anchor
+			group := addressGroup(addressInfo.Address)
+			if m.options.MaxOutboundPerGroup > 0 && !exempt &&
+				groups[group] >= int(m.options.MaxOutboundPerGroup) {
+				continue
+			}
+
 			// We now have an eligible address to dial. If we're full but have
 			// upgrade capacity (as checked above), we find a lower-scored peer
 			// we can replace and mark it as upgrading so noone else claims it.
@@ -503,12 +595,31 @@ func (m *PeerManager) TryDialNext() (NodeAddress, error) {
 			}
 
 			m.dialing[peer.ID] = true
+			if !exempt {
+				m.outbound[peer.ID] = group
+			}
 			return addressInfo.Address, nil
 		}
 	}
 	return NodeAddress{}, nil
 }
 
+// anchorsFirst returns the ranked peers, the anchors first.
+func anchorsFirst(ranked []*peerInfo) []*peerInfo {
+	peers := make([]*peerInfo, 0, len(ranked))
+	for _, peer := range ranked {
+		if peer.Anchor {
+			peers = append(peers, peer)
+		}
+	}
+	for _, peer := range ranked {
+		if !peer.Anchor {
+			peers = append(peers, peer)
+		}
+	}
+	return peers
+}
+
 // DialFailed reports a failed dial attempt. This will make the peer available
 // for dialing again when appropriate (possibly after a retry timeout).
 //
@@ -518,6 +629,7 @@ func (m *PeerManager) DialFailed(address NodeAddress) error {
 	defer m.mtx.Unlock()
 
 	delete(m.dialing, address.NodeID)
+	delete(m.outbound, address.NodeID)
 	for from, to := range m.upgrading {
 		if to == address.NodeID {
 			delete(m.upgrading, from) // Unmark failed upgrade attempt.
@@ -534,6 +646,7 @@ func (m *PeerManager) DialFailed(address NodeAddress) error {
 	}
 	addressInfo.LastDialFailure = time.Now().UTC()
 	addressInfo.DialFailures++
+	peer.Anchor = false // anchors we can't reach aren't dialed first anymore
 	if err := m.store.Set(peer); err != nil {
 		return err
 	}
@@ -567,6 +680,16 @@ func (m *PeerManager) Dialed(address NodeAddress) error {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
+	if err := m.dialed(address); err != nil {
+		delete(m.outbound, address.NodeID)
+		return err
+	}
+	return nil
+}
+
+// dialed marks a peer as successfully dialed for Dialed. The caller must hold
+// the mutex lock.
+func (m *PeerManager) dialed(address NodeAddress) error {
 	delete(m.dialing, address.NodeID)
 
 	var upgradeFromPeer types.NodeID
@@ -596,12 +719,23 @@ func (m *PeerManager) Dialed(address NodeAddress) error {
 		return fmt.Errorf("peer %q was removed while dialing", address.NodeID)
 	}
 	now := time.Now().UTC()
+	tried := peer.tried()
 	peer.LastConnected = now
 	if addressInfo, ok := peer.AddressInfo[address]; ok {
 		addressInfo.DialFailures = 0
 		addressInfo.LastDialSuccess = now
 		// If not found, assume address has been removed.
 	}
+	if !tried && peer.tried() && !peer.Persistent {
+		// The peer is moved to the tried buckets. If its bucket is full of
+		// peers we're connected to, it just overflows until we aren't.
+		if _, err := m.makeRoom(&peer); err != nil {
+			return err
+		}
+	}
+	if m.options.Anchors > 0 && !peer.Anchor && !peer.Persistent && m.anchors() < int(m.options.Anchors) {
+		peer.Anchor = true
+	}
 	if err := m.store.Set(peer); err != nil {
 		return err
 	}
@@ -687,16 +821,18 @@ func (m *PeerManager) Accepted(peerID types.NodeID) error {
 // Ready marks a peer as ready, broadcasting status updates to subscribers. The
 // peer must already be marked as connected. This is separate from Dialed() and
 // Accepted() to allow the router to set up its internal queues before reactors
-// start sending messages.
-func (m *PeerManager) Ready(peerID types.NodeID) {
+// start sending messages. The features advertised by the peer are passed on to
+// the subscribers.
+func (m *PeerManager) Ready(peerID types.NodeID, features []string) {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
 	if m.connected[peerID] {
 		m.ready[peerID] = true
 		m.broadcast(PeerUpdate{
-			NodeID: peerID,
-			Status: PeerStatusUp,
+			NodeID:   peerID,
+			Status:   PeerStatusUp,
+			Features: features,
 		})
 	}
 }
@@ -766,6 +902,7 @@ func (m *PeerManager) Disconnected(peerID types.NodeID) {
 	delete(m.evict, peerID)
 	delete(m.evicting, peerID)
 	delete(m.ready, peerID)
+	delete(m.outbound, peerID)
 
 	if ready {
 		m.broadcast(PeerUpdate{
@@ -793,9 +930,26 @@ func (m *PeerManager) Errored(peerID types.NodeID, err error) {
 		m.evict[peerID] = true
 	}
 
+	// misbehaving peers aren't dialed first on restart
+	if peer, ok := m.store.Get(peerID); ok && peer.Anchor {
+		peer.Anchor = false
+		_ = m.store.Set(peer)
+	}
+
 	m.evictWaker.Wake()
 }
 
+// anchors returns the number of anchors. The caller must hold the mutex lock.
+func (m *PeerManager) anchors() int {
+	anchors := 0
+	for _, peer := range m.store.Ranked() {
+		if peer.Anchor {
+			anchors++
+		}
+	}
+	return anchors
+}
+
 // Advertise returns a list of peer addresses to advertise to a peer.
 //
 // FIXME: This is fairly naïve and only returns the addresses of the
@@ -938,6 +1092,23 @@ func (m *PeerManager) Addresses(peerID types.NodeID) []NodeAddress {
 	return addresses
 }
 
+// SetMaxConnected changes the maximum number of connected peers. Raising it
+// dials new peers, lowering it evicts the lowest-ranked connected peers.
+func (m *PeerManager) SetMaxConnected(maxConnected uint16) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	options := m.options
+	options.MaxConnected = maxConnected
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	m.options.MaxConnected = maxConnected
+	m.dialWaker.Wake()
+	m.evictWaker.Wake()
+	return nil
+}
+
 // Peers returns all known peers, primarily for testing. The order is arbitrary.
 func (m *PeerManager) Peers() []types.NodeID {
 	m.mtx.Lock()
@@ -1067,9 +1238,10 @@ func (m *PeerManager) SetHeight(peerID types.NodeID, height int64) error {
 // from disk on initialization, and any changes are written back to disk
 // (without fsync, since we can afford to lose recent writes).
 type peerStore struct {
-	db     dbm.DB
-	peers  map[types.NodeID]*peerInfo
-	ranked []*peerInfo // cache for Ranked(), nil invalidates cache
+	db        dbm.DB
+	peers     map[types.NodeID]*peerInfo
+	ranked    []*peerInfo // cache for Ranked(), nil invalidates cache
+	bucketKey []byte      // secret key of the buckets
 }
 
 // newPeerStore creates a new peer store, loading all persisted peers from the
@@ -1082,9 +1254,29 @@ func newPeerStore(db dbm.DB) (*peerStore, error) {
 	if err := store.loadPeers(); err != nil {
 		return nil, err
 	}
+	if err := store.loadBucketKey(); err != nil {
+		return nil, err
+	}
 	return store, nil
 }
 
+// loadBucketKey loads the secret key of the buckets from the database,
+// generating it on first use.
+func (s *peerStore) loadBucketKey() error {
+	key, err := s.db.Get(keyBucketKey())
+	if err != nil {
+		return err
+	}
+	if len(key) == 0 {
+		key = crypto.CRandBytes(32)
+		if err := s.db.Set(keyBucketKey(), key); err != nil {
+			return err
+		}
+	}
+	s.bucketKey = key
+	return nil
+}
+
 // loadPeers loads all peers from the database into memory.
 func (s *peerStore) loadPeers() error {
 	peers := map[types.NodeID]*peerInfo{}
@@ -1217,6 +1409,8 @@ type peerInfo struct {
 	ID            types.NodeID
 	AddressInfo   map[NodeAddress]*peerAddressInfo
 	LastConnected time.Time
+	SourceGroup   string // address group of the peer we learned it from
+	Anchor        bool
 
 	// These fields are ephemeral, i.e. not persisted to the database.
 	Persistent bool
@@ -1232,6 +1426,8 @@ func peerInfoFromProto(msg *p2pproto.PeerInfo) (*peerInfo, error) {
 	p := &peerInfo{
 		ID:          types.NodeID(msg.ID),
 		AddressInfo: map[NodeAddress]*peerAddressInfo{},
+		SourceGroup: msg.SourceGroup,
+		Anchor:      msg.Anchor,
 	}
 	if msg.LastConnected != nil {
 		p.LastConnected = *msg.LastConnected
@@ -1254,6 +1450,8 @@ func (p *peerInfo) ToProto() *p2pproto.PeerInfo {
 	msg := &p2pproto.PeerInfo{
 		ID:            string(p.ID),
 		LastConnected: &p.LastConnected,
+		SourceGroup:   p.SourceGroup,
+		Anchor:        p.Anchor,
 	}
 	for _, addressInfo := range p.AddressInfo {
 		msg.AddressInfo = append(msg.AddressInfo, addressInfo.ToProto())
@@ -1363,7 +1561,8 @@ func (a *peerAddressInfo) Validate() error {
 
 // Database key prefixes.
 const (
-	prefixPeerInfo int64 = 1
+	prefixPeerInfo  int64 = 1
+	prefixBucketKey int64 = 2
 )
 
 // keyPeerInfo generates a peerInfo database key.
@@ -1387,3 +1586,12 @@ func keyPeerInfoRange() ([]byte, []byte) {
 	}
 	return start, end
 }
+
+// keyBucketKey generates the database key of the secret key of the buckets.
+func keyBucketKey() []byte {
+	key, err := orderedcode.Append(nil, prefixBucketKey)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
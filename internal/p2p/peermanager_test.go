@@ -54,6 +54,16 @@ func TestPeerManagerOptions_Validate(t *testing.T) {
 			MaxConnectedUpgrade: 2,
 		}, false},
 
+		// Buckets
+		"BucketSize with buckets": {p2p.PeerManagerOptions{
+			NewBuckets:   2,
+			TriedBuckets: 2,
+			BucketSize:   2,
+		}, true},
+		"BucketSize without buckets": {p2p.PeerManagerOptions{
+			BucketSize: 2,
+		}, false},
+
 		// MaxPeers
 		"MaxPeers without MaxConnected": {p2p.PeerManagerOptions{
 			MaxPeers: 3,
@@ -272,6 +282,118 @@ func TestPeerManager_Add(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestPeerManager_AddFrom_Buckets(t *testing.T) {
+	sourceID := types.NodeID(strings.Repeat("s", 40))
+	aID := types.NodeID(strings.Repeat("a", 40))
+	bID := types.NodeID(strings.Repeat("b", 40))
+	cID := types.NodeID(strings.Repeat("c", 40))
+	dID := types.NodeID(strings.Repeat("d", 40))
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		PersistentPeers: []types.NodeID{dID},
+		NewBuckets:      1,
+		TriedBuckets:    1,
+		BucketSize:      2,
+	})
+	require.NoError(t, err)
+
+	// The source can fill its bucket.
+	for _, id := range []types.NodeID{aID, bID} {
+		added, err := peerManager.AddFrom(p2p.NodeAddress{Protocol: "memory", NodeID: id}, sourceID)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	// Once full, another peer of the bucket makes room for the next one.
+	added, err := peerManager.AddFrom(p2p.NodeAddress{Protocol: "memory", NodeID: cID}, sourceID)
+	require.NoError(t, err)
+	require.True(t, added)
+	require.Len(t, peerManager.Peers(), 2)
+	require.Contains(t, peerManager.Peers(), cID)
+
+	// Persistent peers aren't bucketed.
+	added, err = peerManager.AddFrom(p2p.NodeAddress{Protocol: "memory", NodeID: dID}, sourceID)
+	require.NoError(t, err)
+	require.True(t, added)
+	require.Len(t, peerManager.Peers(), 3)
+}
+
+func TestPeerManager_TryDialNext_MaxOutboundPerGroup(t *testing.T) {
+	a := p2p.NodeAddress{Protocol: "tcp", NodeID: types.NodeID(strings.Repeat("a", 40)), Hostname: "1.2.3.4"}
+	b := p2p.NodeAddress{Protocol: "tcp", NodeID: types.NodeID(strings.Repeat("b", 40)), Hostname: "1.2.5.6"}
+	c := p2p.NodeAddress{Protocol: "tcp", NodeID: types.NodeID(strings.Repeat("c", 40)), Hostname: "5.6.7.8"}
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		MaxOutboundPerGroup: 1,
+		PeerScores:          map[types.NodeID]p2p.PeerScore{a.NodeID: 3, b.NodeID: 2, c.NodeID: 1},
+	})
+	require.NoError(t, err)
+	for _, address := range []p2p.NodeAddress{a, b, c} {
+		added, err := peerManager.Add(address)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	// b is in the same /16 subnet as a, so c is dialed instead.
+	address, err := peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, a, address)
+	address, err = peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, c, address)
+	address, err = peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Zero(t, address)
+
+	// Once a fails, b can be dialed.
+	require.NoError(t, peerManager.DialFailed(a))
+	address, err = peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, b, address)
+}
+
+func TestPeerManager_Anchors(t *testing.T) {
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+	db := dbm.NewMemDB()
+
+	peerManager, err := p2p.NewPeerManager(selfID, db, p2p.PeerManagerOptions{Anchors: 1})
+	require.NoError(t, err)
+	added, err := peerManager.Add(a)
+	require.NoError(t, err)
+	require.True(t, added)
+	address, err := peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, a, address)
+	require.NoError(t, peerManager.Dialed(a))
+	peerManager.Close()
+
+	// On restart, the anchor is dialed first, although b scores better.
+	peerManager, err = p2p.NewPeerManager(selfID, db, p2p.PeerManagerOptions{
+		Anchors:    1,
+		PeerScores: map[types.NodeID]p2p.PeerScore{b.NodeID: 1},
+	})
+	require.NoError(t, err)
+	added, err = peerManager.Add(b)
+	require.NoError(t, err)
+	require.True(t, added)
+	address, err = peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, a, address)
+
+	// An anchor failing to dial isn't one anymore.
+	require.NoError(t, peerManager.DialFailed(a))
+	peerManager.Close()
+	peerManager, err = p2p.NewPeerManager(selfID, db, p2p.PeerManagerOptions{
+		Anchors:    1,
+		PeerScores: map[types.NodeID]p2p.PeerScore{b.NodeID: 1},
+	})
+	require.NoError(t, err)
+	address, err = peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, b, address)
+}
+
 func TestPeerManager_DialNext(t *testing.T) {
 	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
 
@@ -1270,7 +1392,7 @@ func TestPeerManager_Ready(t *testing.T) {
 	require.Equal(t, p2p.PeerStatusDown, peerManager.Status(a.NodeID))
 
 	// Marking a as ready should transition it to PeerStatusUp and send an update.
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, nil)
 	require.Equal(t, p2p.PeerStatusUp, peerManager.Status(a.NodeID))
 	require.Equal(t, p2p.PeerUpdate{
 		NodeID: a.NodeID,
@@ -1282,7 +1404,7 @@ func TestPeerManager_Ready(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, added)
 	require.Equal(t, p2p.PeerStatusDown, peerManager.Status(b.NodeID))
-	peerManager.Ready(b.NodeID)
+	peerManager.Ready(b.NodeID, nil)
 	require.Equal(t, p2p.PeerStatusDown, peerManager.Status(b.NodeID))
 	require.Empty(t, sub.Updates())
 }
@@ -1298,7 +1420,7 @@ func TestPeerManager_EvictNext(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, added)
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, nil)
 
 	// Since there are no peers to evict, EvictNext should block until timeout.
 	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
@@ -1331,7 +1453,7 @@ func TestPeerManager_EvictNext_WakeOnError(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, added)
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, nil)
 
 	// Spawn a goroutine to error a peer after a delay.
 	go func() {
@@ -1363,7 +1485,7 @@ func TestPeerManager_EvictNext_WakeOnUpgradeDialed(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, added)
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, nil)
 
 	// Spawn a goroutine to upgrade to b with a delay.
 	go func() {
@@ -1401,7 +1523,7 @@ func TestPeerManager_EvictNext_WakeOnUpgradeAccepted(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, added)
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, nil)
 
 	// Spawn a goroutine to upgrade b with a delay.
 	go func() {
@@ -1416,6 +1538,39 @@ func TestPeerManager_EvictNext_WakeOnUpgradeAccepted(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, a.NodeID, evict)
 }
+func TestPeerManager_SetMaxConnected(t *testing.T) {
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		PersistentPeers: []types.NodeID{a.NodeID},
+		MaxConnected:    2,
+		MaxPeers:        10,
+	})
+	require.NoError(t, err)
+
+	for _, addr := range []p2p.NodeAddress{a, b} {
+		added, err := peerManager.Add(addr)
+		require.NoError(t, err)
+		require.True(t, added)
+		require.NoError(t, peerManager.Accepted(addr.NodeID))
+		peerManager.Ready(addr.NodeID, nil)
+	}
+	evict, err := peerManager.TryEvictNext()
+	require.NoError(t, err)
+	require.Zero(t, evict)
+
+	// lowering the limit evicts the lowest-ranked peer
+	require.NoError(t, peerManager.SetMaxConnected(1))
+	evict, err = peerManager.TryEvictNext()
+	require.NoError(t, err)
+	require.Equal(t, b.NodeID, evict)
+
+	// the limit must be valid
+	require.Error(t, peerManager.SetMaxConnected(0))
+	require.Error(t, peerManager.SetMaxConnected(20))
+}
+
 func TestPeerManager_TryEvictNext(t *testing.T) {
 	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
 
@@ -1433,7 +1588,7 @@ func TestPeerManager_TryEvictNext(t *testing.T) {
 
 	// Connecting to a won't evict anything either.
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, nil)
 
 	// But if a errors it should be evicted.
 	peerManager.Errored(a.NodeID, errors.New("foo"))
@@ -1478,7 +1633,7 @@ func TestPeerManager_Disconnected(t *testing.T) {
 	_, err = peerManager.Add(a)
 	require.NoError(t, err)
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, nil)
 	require.Equal(t, p2p.PeerStatusUp, peerManager.Status(a.NodeID))
 	require.NotEmpty(t, sub.Updates())
 	require.Equal(t, p2p.PeerUpdate{
@@ -1530,7 +1685,7 @@ func TestPeerManager_Errored(t *testing.T) {
 	require.Zero(t, evict)
 
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, nil)
 	evict, err = peerManager.TryEvictNext()
 	require.NoError(t, err)
 	require.Zero(t, evict)
@@ -1561,7 +1716,7 @@ func TestPeerManager_Subscribe(t *testing.T) {
 	require.NoError(t, peerManager.Accepted(a.NodeID))
 	require.Empty(t, sub.Updates())
 
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, nil)
 	require.NotEmpty(t, sub.Updates())
 	require.Equal(t, p2p.PeerUpdate{NodeID: a.NodeID, Status: p2p.PeerStatusUp}, <-sub.Updates())
 
@@ -1578,9 +1733,10 @@ func TestPeerManager_Subscribe(t *testing.T) {
 	require.NoError(t, peerManager.Dialed(a))
 	require.Empty(t, sub.Updates())
 
-	peerManager.Ready(a.NodeID)
+	features := []string{"rekey"}
+	peerManager.Ready(a.NodeID, features)
 	require.NotEmpty(t, sub.Updates())
-	require.Equal(t, p2p.PeerUpdate{NodeID: a.NodeID, Status: p2p.PeerStatusUp}, <-sub.Updates())
+	require.Equal(t, p2p.PeerUpdate{NodeID: a.NodeID, Status: p2p.PeerStatusUp, Features: features}, <-sub.Updates())
 
 	peerManager.Errored(a.NodeID, errors.New("foo"))
 	require.Empty(t, sub.Updates())
@@ -1618,7 +1774,7 @@ func TestPeerManager_Subscribe_Close(t *testing.T) {
 	require.NoError(t, peerManager.Accepted(a.NodeID))
 	require.Empty(t, sub.Updates())
 
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, nil)
 	require.NotEmpty(t, sub.Updates())
 	require.Equal(t, p2p.PeerUpdate{NodeID: a.NodeID, Status: p2p.PeerStatusUp}, <-sub.Updates())
 
@@ -1648,7 +1804,7 @@ func TestPeerManager_Subscribe_Broadcast(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, added)
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, nil)
 
 	expectUp := p2p.PeerUpdate{NodeID: a.NodeID, Status: p2p.PeerStatusUp}
 	require.NotEmpty(t, s1)
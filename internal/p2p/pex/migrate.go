@@ -0,0 +1,64 @@
+package pex
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/tendermint/tendermint/internal/p2p"
+)
+
+// MigrateAddrBook adds the peers of the legacy JSON address book at filePath
+// to the peer manager, the ones of the old buckets as successfully dialed, and
+// renames the file with a ".migrated" suffix so that it is only migrated once.
+// It returns the number of peers added, and does nothing if there is no
+// address book.
+func MigrateAddrBook(filePath string, peerManager *p2p.PeerManager) (int, error) {
+	bz, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	aJSON := &addrBookJSON{}
+	if err := json.Unmarshal(bz, aJSON); err != nil {
+		return 0, fmt.Errorf("error reading address book %s: %w", filePath, err)
+	}
+
+	added := 0
+	for _, ka := range aJSON.Addrs {
+		if ka.Addr == nil {
+			continue
+		}
+		var source *p2p.NodeAddress
+		if ka.Src != nil && ka.Src.ID != ka.Addr.ID {
+			src := nodeAddress(ka.Src)
+			source = &src
+		}
+		var lastDialSuccess time.Time
+		if ka.isOld() {
+			lastDialSuccess = ka.LastSuccess
+		}
+
+		// invalid addresses are dropped
+		ok, err := peerManager.Restore(nodeAddress(ka.Addr), source, lastDialSuccess)
+		if err == nil && ok {
+			added++
+		}
+	}
+
+	return added, os.Rename(filePath, filePath+".migrated")
+}
+
+// nodeAddress converts a legacy address into a node address.
+func nodeAddress(na *p2p.NetAddress) p2p.NodeAddress {
+	return p2p.NodeAddress{
+		NodeID:   na.ID,
+		Protocol: p2p.MConnProtocol,
+		Hostname: na.IP.String(),
+		Port:     na.Port,
+	}
+}
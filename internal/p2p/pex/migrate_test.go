@@ -0,0 +1,48 @@
+package pex
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestMigrateAddrBook(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "addrbook.json")
+	book := NewAddrBook(fname, true).(*addrBook)
+	book.SetLogger(log.TestingLogger())
+
+	randAddrs := randNetAddressPairs(t, 10)
+	for _, addr := range randAddrs {
+		require.NoError(t, book.AddAddress(addr.addr, addr.src))
+	}
+	book.MarkGood(randAddrs[0].addr.ID)
+	book.saveToFile(fname)
+
+	selfID := types.NodeID("00112233445566778899aabbccddeeff00112233")
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+
+	added, err := MigrateAddrBook(fname, peerManager)
+	require.NoError(t, err)
+	assert.Equal(t, len(randAddrs), added)
+	for _, addr := range randAddrs {
+		assert.Equal(t, []p2p.NodeAddress{nodeAddress(addr.addr)}, peerManager.Addresses(addr.addr.ID))
+	}
+
+	// the address book is only migrated once
+	_, err = os.Stat(fname)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(fname + ".migrated")
+	require.NoError(t, err)
+	added, err = MigrateAddrBook(fname, peerManager)
+	require.NoError(t, err)
+	assert.Zero(t, added)
+}
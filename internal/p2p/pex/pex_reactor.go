@@ -100,6 +100,8 @@ type Reactor struct {
 
 	// seed/crawled mode fields
 	crawlPeerInfos map[types.NodeID]crawlPeerInfo
+
+	eventBus types.PeerEventPublisher
 }
 
 func (r *Reactor) minReceiveRequestInterval() time.Duration {
@@ -140,11 +142,18 @@ func NewReactor(b AddrBook, config *ReactorConfig) *Reactor {
 		requestsSent:         cmap.NewCMap(),
 		lastReceivedRequests: cmap.NewCMap(),
 		crawlPeerInfos:       make(map[types.NodeID]crawlPeerInfo),
+		eventBus:             types.NopEventBus{},
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("PEX", r)
 	return r
 }
 
+// SetEventBus sets the event bus the reactor publishes the bans of the peers
+// on.
+func (r *Reactor) SetEventBus(eventBus types.PeerEventPublisher) {
+	r.eventBus = eventBus
+}
+
 // OnStart implements BaseService
 func (r *Reactor) OnStart() error {
 	err := r.book.Start()
@@ -283,7 +292,7 @@ func (r *Reactor) Receive(chID byte, src Peer, msgBytes []byte) {
 			// Check we're not receiving requests too frequently.
 			if err := r.receiveRequest(src); err != nil {
 				r.Switch.StopPeerForError(src, err)
-				r.book.MarkBad(src.SocketAddr(), defaultBanTime)
+				r.markBad(src.SocketAddr(), err)
 				return
 			}
 			r.SendAddrs(src, r.book.GetSelection())
@@ -294,14 +303,14 @@ func (r *Reactor) Receive(chID byte, src Peer, msgBytes []byte) {
 		addrs, err := NetAddressesFromProto(msg.Addresses)
 		if err != nil {
 			r.Switch.StopPeerForError(src, err)
-			r.book.MarkBad(src.SocketAddr(), defaultBanTime)
+			r.markBad(src.SocketAddr(), err)
 			return
 		}
 		err = r.ReceiveAddrs(addrs, src)
 		if err != nil {
 			r.Switch.StopPeerForError(src, err)
 			if err == ErrUnsolicitedList {
-				r.book.MarkBad(src.SocketAddr(), defaultBanTime)
+				r.markBad(src.SocketAddr(), err)
 			}
 			return
 		}
@@ -554,7 +563,7 @@ func (r *Reactor) dialAttemptsInfo(addr *p2p.NetAddress) (attempts int, lastDial
 func (r *Reactor) dialPeer(addr *p2p.NetAddress) error {
 	attempts, lastDialed := r.dialAttemptsInfo(addr)
 	if !r.Switch.IsPeerPersistent(addr) && attempts > maxAttemptsToDial {
-		r.book.MarkBad(addr, defaultBanTime)
+		r.markBad(addr, errMaxAttemptsToDial{})
 		return errMaxAttemptsToDial{}
 	}
 
@@ -576,7 +585,7 @@ func (r *Reactor) dialPeer(addr *p2p.NetAddress) error {
 			return err
 		}
 
-		markAddrInBookBasedOnErr(addr, r.book, err)
+		r.markAddrInBookBasedOnErr(addr, err)
 		switch err.(type) {
 		case p2p.ErrSwitchAuthenticationFailure:
 			// NOTE: addr is removed from addrbook in markAddrInBookBasedOnErr
@@ -764,13 +773,29 @@ func (r *Reactor) attemptDisconnects() {
 	}
 }
 
-func markAddrInBookBasedOnErr(addr *p2p.NetAddress, book AddrBook, err error) {
+func (r *Reactor) markAddrInBookBasedOnErr(addr *p2p.NetAddress, err error) {
 	// TODO: detect more "bad peer" scenarios
 	switch err.(type) {
 	case p2p.ErrSwitchAuthenticationFailure:
-		book.MarkBad(addr, defaultBanTime)
+		r.markBad(addr, err)
 	default:
-		book.MarkAttempt(addr)
+		r.book.MarkAttempt(addr)
+	}
+}
+
+// markBad bans the address for defaultBanTime, for the reason, and publishes
+// the ban.
+func (r *Reactor) markBad(addr *p2p.NetAddress, reason error) {
+	r.book.MarkBad(addr, defaultBanTime)
+
+	peerEvent := types.EventDataPeer{
+		NodeID:   addr.ID,
+		Address:  addr.String(),
+		Reason:   reason.Error(),
+		Duration: defaultBanTime,
+	}
+	if err := r.eventBus.PublishEventPeerBanned(peerEvent); err != nil {
+		r.Logger.Error("failed to publish the peer banned event", "peer", addr, "err", err)
 	}
 }
 
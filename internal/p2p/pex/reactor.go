@@ -242,7 +242,7 @@ func (r *ReactorV2) handlePexMessage(envelope p2p.Envelope) error {
 			if err != nil {
 				continue
 			}
-			added, err := r.peerManager.Add(peerAddress)
+			added, err := r.peerManager.AddFrom(peerAddress, envelope.From)
 			if err != nil {
 				logger.Error("failed to add PEX address", "address", peerAddress, "err", err)
 			}
@@ -294,7 +294,7 @@ func (r *ReactorV2) handlePexMessage(envelope p2p.Envelope) error {
 			if err != nil {
 				continue
 			}
-			added, err := r.peerManager.Add(peerAddress)
+			added, err := r.peerManager.AddFrom(peerAddress, envelope.From)
 			if err != nil {
 				logger.Error("failed to add V2 PEX address", "address", peerAddress, "err", err)
 			}
@@ -169,6 +169,11 @@ type RouterOptions struct {
 	// are used to dial peers. This defaults to the value of
 	// runtime.NumCPU.
 	NumConcurrentDials func() int
+
+	// AuditLog, if given, is appended the connection attempts of the router
+	// and the disconnections of its peers. It is closed by the router when it
+	// stops.
+	AuditLog *AuditLog
 }
 
 const (
@@ -255,12 +260,16 @@ type Router struct {
 	transports         []Transport
 	connTracker        connectionTracker
 	protocolTransports map[Protocol]Transport
+	eventBus           types.PeerEventPublisher
+	nodeInfoOther      func() types.NodeInfoOther
 	stopCh             chan struct{} // signals Router shutdown
 
 	peerMtx    sync.RWMutex
 	peerQueues map[types.NodeID]queue // outbound messages per peer for all channels
 	// the channels that the peer queue has open
 	peerChannels map[types.NodeID]channelIDs
+	// the errors the peers are evicted for, reported on their disconnection
+	peerErrors   map[types.NodeID]error
 	queueFactory func(int) queue
 
 	// FIXME: We don't strictly need to use a mutex for this if we seal the
@@ -302,11 +311,13 @@ func NewRouter(
 		protocolTransports: map[Protocol]Transport{},
 		peerManager:        peerManager,
 		options:            options,
+		eventBus:           types.NopEventBus{},
 		stopCh:             make(chan struct{}),
 		channelQueues:      map[ChannelID]queue{},
 		channelMessages:    map[ChannelID]proto.Message{},
 		peerQueues:         map[types.NodeID]queue{},
 		peerChannels:       make(map[types.NodeID]channelIDs),
+		peerErrors:         map[types.NodeID]error{},
 	}
 
 	router.BaseService = service.NewBaseService(logger, "router", router)
@@ -509,6 +520,14 @@ func (r *Router) routeChannel(
 
 			r.logger.Error("peer error, evicting", "peer", peerError.NodeID, "err", peerError.Err)
 
+			r.peerMtx.Lock()
+			if _, ok := r.peerQueues[peerError.NodeID]; ok {
+				if _, ok := r.peerErrors[peerError.NodeID]; !ok {
+					r.peerErrors[peerError.NodeID] = peerError.Err
+				}
+			}
+			r.peerMtx.Unlock()
+
 			r.peerManager.Errored(peerError.NodeID, peerError.Err)
 
 		case <-r.stopCh:
@@ -577,6 +596,12 @@ func (r *Router) acceptPeers(transport Transport) {
 
 		incomingIP := conn.RemoteEndpoint().IP
 		if err := r.connTracker.AddConn(incomingIP); err != nil {
+			r.options.AuditLog.Log(AuditEntry{
+				Event:     AuditRateLimited,
+				Direction: AuditInbound,
+				Address:   conn.RemoteEndpoint().String(),
+				Error:     err.Error(),
+			})
 			closeErr := conn.Close()
 			r.logger.Debug("rate limiting incoming peer",
 				"err", err,
@@ -602,6 +627,12 @@ func (r *Router) openConnection(ctx context.Context, conn Connection) {
 
 	if err := r.filterPeersIP(ctx, incomingIP, re.Port); err != nil {
 		r.logger.Debug("peer filtered by IP", "ip", incomingIP.String(), "err", err)
+		r.options.AuditLog.Log(AuditEntry{
+			Event:     AuditFiltered,
+			Direction: AuditInbound,
+			Address:   re.String(),
+			Error:     err.Error(),
+		})
 		return
 	}
 
@@ -626,20 +657,41 @@ func (r *Router) openConnection(ctx context.Context, conn Connection) {
 		return
 	case err != nil:
 		r.logger.Error("peer handshake failed", "endpoint", conn, "err", err)
+		r.options.AuditLog.Log(AuditEntry{
+			Event:     AuditHandshakeFailed,
+			Direction: AuditInbound,
+			NodeID:    peerInfo.NodeID,
+			Address:   re.String(),
+			Error:     err.Error(),
+		})
 		return
 	}
 	if err := r.filterPeersID(ctx, peerInfo.NodeID); err != nil {
 		r.logger.Debug("peer filtered by node ID", "node", peerInfo.NodeID, "err", err)
+		r.options.AuditLog.Log(AuditEntry{
+			Event:     AuditFiltered,
+			Direction: AuditInbound,
+			NodeID:    peerInfo.NodeID,
+			Address:   re.String(),
+			Error:     err.Error(),
+		})
 		return
 	}
 
 	if err := r.runWithPeerMutex(func() error { return r.peerManager.Accepted(peerInfo.NodeID) }); err != nil {
 		r.logger.Error("failed to accept connection",
 			"op", "incoming/accepted", "peer", peerInfo.NodeID, "err", err)
+		r.options.AuditLog.Log(AuditEntry{
+			Event:     AuditRejected,
+			Direction: AuditInbound,
+			NodeID:    peerInfo.NodeID,
+			Address:   re.String(),
+			Error:     err.Error(),
+		})
 		return
 	}
 
-	r.routePeer(peerInfo.NodeID, conn, toChannelIDs(peerInfo.Channels))
+	r.routePeer(peerInfo.NodeID, conn, AuditInbound, toChannelIDs(peerInfo.Channels), peerInfo.Features)
 }
 
 // dialPeers maintains outbound connections to peers by dialing them.
@@ -708,6 +760,13 @@ func (r *Router) connectPeer(ctx context.Context, address NodeAddress) {
 		return
 	case err != nil:
 		r.logger.Error("failed to dial peer", "peer", address, "err", err)
+		r.options.AuditLog.Log(AuditEntry{
+			Event:     AuditDialFailed,
+			Direction: AuditOutbound,
+			NodeID:    address.NodeID,
+			Address:   address.String(),
+			Error:     err.Error(),
+		})
 		if err = r.peerManager.DialFailed(address); err != nil {
 			r.logger.Error("failed to report dial failure", "peer", address, "err", err)
 		}
@@ -721,6 +780,13 @@ func (r *Router) connectPeer(ctx context.Context, address NodeAddress) {
 		return
 	case err != nil:
 		r.logger.Error("failed to handshake with peer", "peer", address, "err", err)
+		r.options.AuditLog.Log(AuditEntry{
+			Event:     AuditHandshakeFailed,
+			Direction: AuditOutbound,
+			NodeID:    address.NodeID,
+			Address:   address.String(),
+			Error:     err.Error(),
+		})
 		if err = r.peerManager.DialFailed(address); err != nil {
 			r.logger.Error("failed to report dial failure", "peer", address, "err", err)
 		}
@@ -731,12 +797,19 @@ func (r *Router) connectPeer(ctx context.Context, address NodeAddress) {
 	if err := r.runWithPeerMutex(func() error { return r.peerManager.Dialed(address) }); err != nil {
 		r.logger.Error("failed to dial peer",
 			"op", "outgoing/dialing", "peer", address.NodeID, "err", err)
+		r.options.AuditLog.Log(AuditEntry{
+			Event:     AuditRejected,
+			Direction: AuditOutbound,
+			NodeID:    address.NodeID,
+			Address:   address.String(),
+			Error:     err.Error(),
+		})
 		conn.Close()
 		return
 	}
 
 	// routePeer (also) calls connection close
-	go r.routePeer(address.NodeID, conn, toChannelIDs(peerInfo.Channels))
+	go r.routePeer(address.NodeID, conn, AuditOutbound, toChannelIDs(peerInfo.Channels), peerInfo.Features)
 }
 
 func (r *Router) getOrMakeQueue(peerID types.NodeID, channels channelIDs) queue {
@@ -817,7 +890,7 @@ func (r *Router) handshakePeer(
 		defer cancel()
 	}
 
-	peerInfo, peerKey, err := conn.Handshake(ctx, r.nodeInfo, r.privKey)
+	peerInfo, peerKey, err := conn.Handshake(ctx, r.NodeInfo(), r.privKey)
 	if err != nil {
 		return peerInfo, peerKey, err
 	}
@@ -851,21 +924,67 @@ func (r *Router) runWithPeerMutex(fn func() error) error {
 // routePeer routes inbound and outbound messages between a peer and the reactor
 // channels. It will close the given connection and send queue when done, or if
 // they are closed elsewhere it will cause this method to shut down and return.
-func (r *Router) routePeer(peerID types.NodeID, conn Connection, channels channelIDs) {
+// The direction of the connection is reported to the audit log.
+func (r *Router) routePeer(
+	peerID types.NodeID,
+	conn Connection,
+	direction string,
+	channels channelIDs,
+	features []string,
+) {
 	r.metrics.Peers.Add(1)
-	r.peerManager.Ready(peerID)
+	r.peerManager.Ready(peerID, features)
+
+	connected := time.Now()
+	peerEvent := types.EventDataPeer{
+		NodeID:  peerID,
+		Address: conn.RemoteEndpoint().NodeAddress(peerID).String(),
+	}
+	if err := r.eventBus.PublishEventPeerConnected(peerEvent); err != nil {
+		r.logger.Error("failed to publish the peer connected event", "peer", peerID, "err", err)
+	}
+	r.options.AuditLog.Log(AuditEntry{
+		Event:     AuditConnected,
+		Direction: direction,
+		NodeID:    peerID,
+		Address:   peerEvent.Address,
+	})
 
+	var err error
 	sendQueue := r.getOrMakeQueue(peerID, channels)
 	defer func() {
 		r.peerMtx.Lock()
 		delete(r.peerQueues, peerID)
 		delete(r.peerChannels, peerID)
+		peerErr, evicted := r.peerErrors[peerID]
+		delete(r.peerErrors, peerID)
 		r.peerMtx.Unlock()
 
 		sendQueue.close()
 
 		r.peerManager.Disconnected(peerID)
 		r.metrics.Peers.Add(-1)
+
+		// the peer is disconnected for the error it is evicted for, rather
+		// than the one of its closed connection
+		switch {
+		case evicted:
+			peerEvent.Reason = peerErr.Error()
+		case err != nil && err != io.EOF:
+			peerEvent.Reason = err.Error()
+		}
+		peerEvent.Duration = time.Since(connected)
+		if err := r.eventBus.PublishEventPeerDisconnected(peerEvent); err != nil {
+			r.logger.Error("failed to publish the peer disconnected event", "peer", peerID, "err", err)
+		}
+		r.options.AuditLog.Log(AuditEntry{
+			Event:     AuditDisconnected,
+			Direction: direction,
+			NodeID:    peerID,
+			Address:   peerEvent.Address,
+			Error:     peerEvent.Reason,
+			Duration:  peerEvent.Duration.String(),
+		})
 	}()
 
 	r.logger.Info("peer connected", "peer", peerID, "endpoint", conn)
@@ -880,7 +999,11 @@ func (r *Router) routePeer(peerID types.NodeID, conn Connection, channels channe
 		errCh <- r.sendPeer(peerID, conn, sendQueue)
 	}()
 
-	err := <-errCh
+	done := make(chan struct{})
+	defer close(done)
+	go r.reportPeerMetrics(peerID, conn, done)
+
+	err = <-errCh
 	_ = conn.Close()
 	sendQueue.close()
 
@@ -899,6 +1022,25 @@ func (r *Router) routePeer(peerID types.NodeID, conn Connection, channels channe
 	}
 }
 
+// reportPeerMetrics periodically sets the round-trip time to a peer, measured
+// by its connection, until done is closed.
+func (r *Router) reportPeerMetrics(peerID types.NodeID, conn Connection, done <-chan struct{}) {
+	ticker := time.NewTicker(metricsTickerDuration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// the peers without their own label share the gauge
+			label := r.metrics.peerLabel(peerID)
+			if rtt := conn.Status().RoundTripTime; rtt > 0 && label != OtherPeersLabel {
+				r.metrics.PeerRoundTripTime.With("peer_id", label).Set(rtt.Seconds())
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
 // receivePeer receives inbound messages from a peer, deserializes them and
 // passes them on to the appropriate channel.
 func (r *Router) receivePeer(peerID types.NodeID, conn Connection) error {
@@ -936,9 +1078,9 @@ func (r *Router) receivePeer(peerID types.NodeID, conn Connection) error {
 
 		select {
 		case queue.enqueue() <- Envelope{From: peerID, Message: msg}:
-			r.metrics.PeerReceiveBytesTotal.With(
-				"chID", fmt.Sprint(chID),
-				"peer_id", string(peerID)).Add(float64(proto.Size(msg)))
+			labels := []string{"chID", fmt.Sprint(chID), "peer_id", r.metrics.peerLabel(peerID)}
+			r.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(proto.Size(msg)))
+			r.metrics.PeerReceiveMessagesTotal.With(labels...).Add(1)
 			r.metrics.RouterChannelQueueSend.Observe(time.Since(start).Seconds())
 			r.logger.Debug("received message", "peer", peerID, "message", msg)
 
@@ -974,6 +1116,9 @@ func (r *Router) sendPeer(peerID types.NodeID, conn Connection, peerQueue queue)
 			if err != nil {
 				return err
 			}
+			labels := []string{"chID", fmt.Sprint(envelope.channelID), "peer_id", r.metrics.peerLabel(peerID)}
+			r.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(bz)))
+			r.metrics.PeerSendMessagesTotal.With(labels...).Add(1)
 
 			r.logger.Debug("sent message", "peer", envelope.To, "message", envelope.Message)
 
@@ -1016,9 +1161,28 @@ func (r *Router) evictPeers() {
 	}
 }
 
-// NodeInfo returns a copy of the current NodeInfo. Used for testing.
+// SetNodeInfoOther sets the function returning the data of the node info
+// which changes over time, e.g. the earliest block height of the node as it
+// prunes its blocks, sent to each peer at the handshake. It must be called
+// before the router starts.
+func (r *Router) SetNodeInfoOther(nodeInfoOther func() types.NodeInfoOther) {
+	r.nodeInfoOther = nodeInfoOther
+}
+
+// SetEventBus sets the event bus the router publishes the connections and
+// disconnections of the peers on. It must be called before the router starts.
+func (r *Router) SetEventBus(eventBus types.PeerEventPublisher) {
+	r.eventBus = eventBus
+}
+
+// NodeInfo returns a copy of the current NodeInfo, as sent to the peers at
+// the handshake.
 func (r *Router) NodeInfo() types.NodeInfo {
-	return r.nodeInfo.Copy()
+	nodeInfo := r.nodeInfo.Copy()
+	if r.nodeInfoOther != nil {
+		nodeInfo.Other = r.nodeInfoOther()
+	}
+	return nodeInfo
 }
 
 // OnStart implements service.Service.
@@ -1032,6 +1196,10 @@ func (r *Router) OnStart() error {
 		"net_addr", netAddr,
 	)
 
+	if err := r.options.AuditLog.start(); err != nil {
+		return fmt.Errorf("starting the audit log: %w", err)
+	}
+
 	go r.dialPeers()
 	go r.evictPeers()
 
@@ -1077,6 +1245,8 @@ func (r *Router) OnStop() {
 	for _, q := range queues {
 		<-q.closed()
 	}
+
+	r.options.AuditLog.close()
 }
 
 // stopCtx returns a new context that is canceled when the router stops.
@@ -413,6 +413,73 @@ func TestRouter_AcceptPeers(t *testing.T) {
 	}
 }
 
+func TestRouter_PeerEvents(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	connectedSub, err := eventBus.Subscribe(ctx, "test", types.EventQueryPeerConnected)
+	require.NoError(t, err)
+	disconnectedSub, err := eventBus.Subscribe(ctx, "test", types.EventQueryPeerDisconnected)
+	require.NoError(t, err)
+
+	// Set up a mock transport accepting a peer which closes its connection.
+	mockConnection := &mocks.Connection{}
+	mockConnection.On("String").Maybe().Return("mock")
+	mockConnection.On("Handshake", mock.Anything, selfInfo, selfKey).
+		Return(peerInfo, peerKey.PubKey(), nil)
+	mockConnection.On("Close").Return(nil)
+	mockConnection.On("RemoteEndpoint").Return(p2p.Endpoint{})
+	mockConnection.On("ReceiveMessage").Return(chID, nil, io.EOF)
+
+	mockTransport := &mocks.Transport{}
+	mockTransport.On("String").Maybe().Return("mock")
+	mockTransport.On("Protocols").Return([]p2p.Protocol{"mock"})
+	mockTransport.On("Close").Return(nil)
+	mockTransport.On("Accept").Once().Return(mockConnection, nil)
+	mockTransport.On("Accept").Once().Return(nil, io.EOF)
+
+	// Set up and start the router.
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+	defer peerManager.Close()
+
+	router, err := p2p.NewRouter(
+		log.TestingLogger(),
+		p2p.NopMetrics(),
+		selfInfo,
+		selfKey,
+		peerManager,
+		[]p2p.Transport{mockTransport},
+		p2p.RouterOptions{},
+	)
+	require.NoError(t, err)
+	router.SetEventBus(eventBus)
+	require.NoError(t, router.Start())
+
+	for _, sub := range []types.Subscription{connectedSub, disconnectedSub} {
+		select {
+		case msg := <-sub.Out():
+			peer := msg.Data().(types.EventDataPeer)
+			require.Equal(t, peerID, peer.NodeID)
+			// the peer closing its connection is not a failure
+			require.Empty(t, peer.Reason)
+		case <-time.After(time.Second):
+			require.Fail(t, "no peer event after 1 sec.")
+		}
+	}
+
+	require.NoError(t, router.Stop())
+	mockTransport.AssertExpectations(t)
+}
+
 func TestRouter_AcceptPeers_Error(t *testing.T) {
 	t.Cleanup(leaktest.Check(t))
 
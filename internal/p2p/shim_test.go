@@ -110,6 +110,10 @@ func TestReactorShim_GetChannels(t *testing.T) {
 
 func TestReactorShim_AddPeer(t *testing.T) {
 	peerA, peerIDA := simplePeer(t, "aa")
+	peerA.On("NodeInfo").Return(types.NodeInfo{
+		NodeID:   peerIDA,
+		Features: []string{"rekey"},
+	})
 	rts := setup(t, []p2p.Peer{peerA})
 
 	var wg sync.WaitGroup
@@ -126,6 +130,7 @@ func TestReactorShim_AddPeer(t *testing.T) {
 
 	require.Equal(t, peerIDA, peerUpdate.NodeID)
 	require.Equal(t, p2p.PeerStatusUp, peerUpdate.Status)
+	require.Equal(t, []string{"rekey"}, peerUpdate.Features)
 }
 
 func TestReactorShim_RemovePeer(t *testing.T) {
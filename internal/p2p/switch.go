@@ -45,6 +45,8 @@ func MConnConfig(cfg *config.P2PConfig) conn.MConnConfig {
 	mConfig.SendRate = cfg.SendRate
 	mConfig.RecvRate = cfg.RecvRate
 	mConfig.MaxPacketMsgPayloadSize = cfg.MaxPacketMsgPayloadSize
+	mConfig.RekeyBytes = cfg.SecretConnRekeyBytes
+	mConfig.RekeyInterval = cfg.SecretConnRekeyInterval
 	return mConfig
 }
 
@@ -117,7 +119,8 @@ type Switch struct {
 	connFilters   []ConnFilterFunc
 	conns         ConnSet
 
-	metrics *Metrics
+	metrics  *Metrics
+	eventBus types.PeerEventPublisher
 }
 
 // NetAddress returns the first address the switch is listening on,
@@ -157,6 +160,7 @@ func NewSwitch(
 		unconditionalPeerIDs: make(map[types.NodeID]struct{}),
 		filterTimeout:        defaultFilterTimeout,
 		conns:                NewConnSet(),
+		eventBus:             types.NopEventBus{},
 	}
 
 	// Ensure PRNG is reseeded.
@@ -252,6 +256,12 @@ func (sw *Switch) NodeInfo() types.NodeInfo {
 	return sw.nodeInfo
 }
 
+// SetEventBus sets the event bus the switch publishes the connections and
+// disconnections of the peers on. It must be called before the switch starts.
+func (sw *Switch) SetEventBus(eventBus types.PeerEventPublisher) {
+	sw.eventBus = eventBus
+}
+
 // SetNodeKey sets the switch's private key for authenticated encryption.
 // NOTE: Not goroutine safe.
 func (sw *Switch) SetNodeKey(nodeKey types.NodeKey) {
@@ -418,6 +428,18 @@ func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 	// https://github.com/tendermint/tendermint/issues/3338
 	if sw.peers.Remove(peer) {
 		sw.metrics.Peers.Add(float64(-1))
+
+		peerEvent := types.EventDataPeer{
+			NodeID:   peer.ID(),
+			Address:  peer.SocketAddr().String(),
+			Duration: peer.Status().Duration,
+		}
+		if reason != nil {
+			peerEvent.Reason = fmt.Sprintf("%v", reason)
+		}
+		if err := sw.eventBus.PublishEventPeerDisconnected(peerEvent); err != nil {
+			sw.Logger.Error("failed to publish the peer disconnected event", "peer", peer, "err", err)
+		}
 	}
 
 	sw.conns.RemoveAddr(peer.RemoteAddr())
@@ -1044,6 +1066,11 @@ func (sw *Switch) addPeer(p Peer) error {
 
 	sw.Logger.Info("Added peer", "peer", p)
 
+	peerEvent := types.EventDataPeer{NodeID: p.ID(), Address: p.SocketAddr().String()}
+	if err := sw.eventBus.PublishEventPeerConnected(peerEvent); err != nil {
+		sw.Logger.Error("failed to publish the peer connected event", "peer", p, "err", err)
+	}
+
 	return nil
 }
 
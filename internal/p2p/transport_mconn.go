@@ -23,6 +23,11 @@ import (
 const (
 	MConnProtocol Protocol = "mconn"
 	TCPProtocol   Protocol = "tcp"
+
+	// RekeyFeature is advertised in the NodeInfo of the nodes understanding
+	// the rekey frames of the secret connection. The connections to the peers
+	// not advertising it are never rekeyed.
+	RekeyFeature = "secret-conn-rekey"
 )
 
 // MConnTransportOptions sets options for MConnTransport.
@@ -334,6 +339,10 @@ func (c *mConnConnection) handshake(
 	if err != nil {
 		return nil, types.NodeInfo{}, nil, err
 	}
+	// only rekey the connection if the peer understands the rekey frames
+	if peerInfo.HasFeature(RekeyFeature) {
+		secretConn.SetRekeyPolicy(c.mConnConfig.RekeyBytes, c.mConnConfig.RekeyInterval)
+	}
 
 	mconn := conn.NewMConnectionWithConfig(
 		secretConn,
@@ -3,15 +3,20 @@ package p2p_test
 import (
 	"io"
 	"net"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/fortytw2/leaktest"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/internal/libs/protoio"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/p2p/conn"
 	"github.com/tendermint/tendermint/libs/log"
+	p2pproto "github.com/tendermint/tendermint/proto/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
 )
 
 // Transports are mainly tested by common tests in transport_test.go, we
@@ -216,3 +221,76 @@ func TestMConnTransport_Listen(t *testing.T) {
 		})
 	}
 }
+
+// The connections are only rekeyed if the peer advertises it understands the
+// rekey frames, which the peers of earlier versions don't.
+func TestMConnTransport_Rekey(t *testing.T) {
+	testcases := []struct {
+		name     string
+		features []string
+		rekeyed  bool
+	}{
+		{"legacy peer", nil, false},
+		{"rekeying peer", []string{p2p.RekeyFeature}, true},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mConnConfig := conn.DefaultMConnConfig()
+			mConnConfig.RekeyBytes = 1024
+			transport := p2p.NewMConnTransport(
+				log.TestingLogger(),
+				mConnConfig,
+				[]*p2p.ChannelDescriptor{{ID: byte(chID), Priority: 1}},
+				p2p.MConnTransportOptions{},
+			)
+			require.NoError(t, transport.Listen(p2p.Endpoint{Protocol: p2p.MConnProtocol, IP: net.IPv4(127, 0, 0, 1)}))
+			t.Cleanup(func() { _ = transport.Close() })
+			endpoint := transport.Endpoints()[0]
+
+			// The transport accepts the peer and sends it a few KB.
+			go func() {
+				peerConn, err := transport.Accept()
+				if err != nil {
+					return
+				}
+				defer peerConn.Close()
+				key := ed25519.GenPrivKey()
+				info := types.NodeInfo{NodeID: types.NodeIDFromPubKey(key.PubKey())}
+				if _, _, err := peerConn.Handshake(ctx, info, key); err != nil {
+					return
+				}
+				for i := 0; i < 8; i++ {
+					if _, err := peerConn.SendMessage(chID, make([]byte, 1024)); err != nil {
+						return
+					}
+				}
+				time.Sleep(time.Second)
+			}()
+
+			// The peer handshakes by hand, advertising the features of the
+			// test case, and reads the raw traffic.
+			tcpConn, err := net.Dial("tcp", net.JoinHostPort(endpoint.IP.String(), strconv.Itoa(int(endpoint.Port))))
+			require.NoError(t, err)
+			defer tcpConn.Close()
+			key := ed25519.GenPrivKey()
+			secretConn, err := conn.MakeSecretConnection(tcpConn, key)
+			require.NoError(t, err)
+			info := types.NodeInfo{NodeID: types.NodeIDFromPubKey(key.PubKey()), Features: tc.features}
+			_, err = protoio.NewDelimitedWriter(secretConn).WriteMsg(info.ToProto())
+			require.NoError(t, err)
+			var peerInfo p2pproto.NodeInfo
+			_, err = protoio.NewDelimitedReader(secretConn, types.MaxNodeInfoSize()).ReadMsg(&peerInfo)
+			require.NoError(t, err)
+
+			_, err = io.ReadFull(secretConn, make([]byte, 4*1024))
+			require.NoError(t, err)
+			_, received := secretConn.Rekeys()
+			if tc.rekeyed {
+				require.NotZero(t, received)
+			} else {
+				require.Zero(t, received)
+			}
+		})
+	}
+}
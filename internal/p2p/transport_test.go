@@ -258,6 +258,7 @@ func TestConnection_Handshake(t *testing.T) {
 				TxIndex:    "txindex",
 				RPCAddress: "rpc.domain.com",
 			},
+			Features: []string{p2p.RekeyFeature},
 		}
 		bKey := ed25519.GenPrivKey()
 		bInfo := types.NodeInfo{NodeID: types.NodeIDFromPubKey(bKey.PubKey())}
@@ -3,10 +3,17 @@ package proxy
 import (
 	"context"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/types"
 )
 
+// tracer traces the ABCI calls of the consensus connection.
+var tracer = otel.Tracer("github.com/tendermint/tendermint/internal/proxy")
+
 //go:generate ../../scripts/mockery_generate.sh AppConnConsensus|AppConnMempool|AppConnQuery|AppConnSnapshot
 
 //----------------------------------------------------------------------------------------
@@ -24,6 +31,8 @@ type AppConnConsensus interface {
 	CommitSync(context.Context) (*types.ResponseCommit, error)
 
 	PreprocessTxsSync(context.Context, types.RequestPreprocessTxs) (*types.ResponsePreprocessTxs, error)
+	FinalizeBlockSync(context.Context, types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error)
+	ProcessProposalSync(context.Context, types.RequestProcessProposal) (*types.ResponseProcessProposal, error)
 }
 
 type AppConnMempool interface {
@@ -79,14 +88,20 @@ func (app *appConnConsensus) InitChainSync(
 	ctx context.Context,
 	req types.RequestInitChain,
 ) (*types.ResponseInitChain, error) {
-	return app.appConn.InitChainSync(ctx, req)
+	ctx, span := tracer.Start(ctx, "abci.InitChain")
+	res, err := app.appConn.InitChainSync(ctx, req)
+	endSpan(span, err)
+	return res, err
 }
 
 func (app *appConnConsensus) BeginBlockSync(
 	ctx context.Context,
 	req types.RequestBeginBlock,
 ) (*types.ResponseBeginBlock, error) {
-	return app.appConn.BeginBlockSync(ctx, req)
+	ctx, span := tracer.Start(ctx, "abci.BeginBlock")
+	res, err := app.appConn.BeginBlockSync(ctx, req)
+	endSpan(span, err)
+	return res, err
 }
 
 func (app *appConnConsensus) DeliverTxAsync(
@@ -100,18 +115,56 @@ func (app *appConnConsensus) EndBlockSync(
 	ctx context.Context,
 	req types.RequestEndBlock,
 ) (*types.ResponseEndBlock, error) {
-	return app.appConn.EndBlockSync(ctx, req)
+	ctx, span := tracer.Start(ctx, "abci.EndBlock")
+	res, err := app.appConn.EndBlockSync(ctx, req)
+	endSpan(span, err)
+	return res, err
 }
 
 func (app *appConnConsensus) CommitSync(ctx context.Context) (*types.ResponseCommit, error) {
-	return app.appConn.CommitSync(ctx)
+	ctx, span := tracer.Start(ctx, "abci.Commit")
+	res, err := app.appConn.CommitSync(ctx)
+	endSpan(span, err)
+	return res, err
+}
+
+// endSpan ends the span of an ABCI call, which failed if err is not nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
 }
 
 func (app *appConnConsensus) PreprocessTxsSync(
 	ctx context.Context,
 	req types.RequestPreprocessTxs,
 ) (*types.ResponsePreprocessTxs, error) {
-	return app.appConn.PreprocessTxsSync(ctx, req)
+	ctx, span := tracer.Start(ctx, "abci.PreprocessTxs")
+	res, err := app.appConn.PreprocessTxsSync(ctx, req)
+	endSpan(span, err)
+	return res, err
+}
+
+func (app *appConnConsensus) FinalizeBlockSync(
+	ctx context.Context,
+	req types.RequestFinalizeBlock,
+) (*types.ResponseFinalizeBlock, error) {
+	ctx, span := tracer.Start(ctx, "abci.FinalizeBlock")
+	res, err := app.appConn.FinalizeBlockSync(ctx, req)
+	endSpan(span, err)
+	return res, err
+}
+
+func (app *appConnConsensus) ProcessProposalSync(
+	ctx context.Context,
+	req types.RequestProcessProposal,
+) (*types.ResponseProcessProposal, error) {
+	ctx, span := tracer.Start(ctx, "abci.ProcessProposal")
+	res, err := app.appConn.ProcessProposalSync(ctx, req)
+	endSpan(span, err)
+	return res, err
 }
 
 //------------------------------------------------
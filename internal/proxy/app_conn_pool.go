@@ -0,0 +1,185 @@
+package proxy
+
+import (
+	"context"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/types"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+)
+
+// pooledAppConnMempool is a mempool connection backed by a pool of clients,
+// for an application checking txs concurrently. New txs are checked over the
+// clients in turn.
+//
+// The mempool expects the responses to the rechecks in the order of the
+// requests, and the responses to the new txs after the ones to the rechecks.
+// Hence the rechecks go over the first client, and so do the new txs while
+// rechecks are pending. Only the async rechecks are pending, from their request
+// to their response: a sync recheck is over when CheckTxSync returns.
+type pooledAppConnMempool struct {
+	appConns []abciclient.Client
+
+	mtx tmsync.Mutex
+	// the index of the client for the next new tx
+	next int
+	// the number of async rechecks without a response yet
+	pendingRechecks int
+}
+
+// NewPooledAppConnMempool returns a mempool connection backed by appConns,
+// which must not be empty.
+func NewPooledAppConnMempool(appConns []abciclient.Client) AppConnMempool {
+	if len(appConns) == 1 {
+		return NewAppConnMempool(appConns[0])
+	}
+	return &pooledAppConnMempool{
+		appConns: appConns,
+	}
+}
+
+func (app *pooledAppConnMempool) SetResponseCallback(cb abciclient.Callback) {
+	for _, appConn := range app.appConns {
+		appConn.SetResponseCallback(cb)
+	}
+}
+
+func (app *pooledAppConnMempool) Error() error {
+	for _, appConn := range app.appConns {
+		if err := appConn.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FlushAsync flushes all the clients, and returns the request to the first
+// one.
+func (app *pooledAppConnMempool) FlushAsync(ctx context.Context) (*abciclient.ReqRes, error) {
+	for _, appConn := range app.appConns[1:] {
+		if _, err := appConn.FlushAsync(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return app.appConns[0].FlushAsync(ctx)
+}
+
+func (app *pooledAppConnMempool) FlushSync(ctx context.Context) error {
+	for _, appConn := range app.appConns {
+		if err := appConn.FlushSync(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (app *pooledAppConnMempool) CheckTxAsync(
+	ctx context.Context,
+	req types.RequestCheckTx,
+) (*abciclient.ReqRes, error) {
+	if req.Type != types.CheckTxType_Recheck {
+		return app.pick().CheckTxAsync(ctx, req)
+	}
+
+	// the recheck is pending before it's sent, for the new txs checked
+	// after it to go over the same client
+	app.mtx.Lock()
+	app.pendingRechecks++
+	app.mtx.Unlock()
+
+	reqRes, err := app.appConns[0].CheckTxAsync(ctx, req)
+	if err != nil {
+		app.donePendingRecheck()
+		return nil, err
+	}
+	// each recheck is done once, when its response is received, whichever
+	// callbacks the client calls
+	go func() {
+		reqRes.Wait()
+		app.donePendingRecheck()
+	}()
+	return reqRes, nil
+}
+
+func (app *pooledAppConnMempool) donePendingRecheck() {
+	app.mtx.Lock()
+	app.pendingRechecks--
+	app.mtx.Unlock()
+}
+
+func (app *pooledAppConnMempool) CheckTxSync(
+	ctx context.Context,
+	req types.RequestCheckTx,
+) (*types.ResponseCheckTx, error) {
+	if req.Type == types.CheckTxType_Recheck {
+		return app.appConns[0].CheckTxSync(ctx, req)
+	}
+	return app.pick().CheckTxSync(ctx, req)
+}
+
+// pick returns the client to check a new tx over.
+func (app *pooledAppConnMempool) pick() abciclient.Client {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	if app.pendingRechecks > 0 {
+		return app.appConns[0]
+	}
+	appConn := app.appConns[app.next]
+	app.next = (app.next + 1) % len(app.appConns)
+	return appConn
+}
+
+// routedAppConnQuery is a query connection routing the queries at past
+// heights to a pool of clients, for an application serving them
+// concurrently, and the others to the query client. The queries of the pool
+// go over its clients in turn.
+type routedAppConnQuery struct {
+	AppConnQuery
+
+	historical []abciclient.Client
+
+	mtx  tmsync.Mutex
+	next int
+}
+
+// NewRoutedAppConnQuery returns a query connection over appConn, routing the
+// queries at past heights to historical if not empty.
+func NewRoutedAppConnQuery(appConn abciclient.Client, historical []abciclient.Client) AppConnQuery {
+	if len(historical) == 0 {
+		return NewAppConnQuery(appConn)
+	}
+	return &routedAppConnQuery{
+		AppConnQuery: NewAppConnQuery(appConn),
+		historical:   historical,
+	}
+}
+
+func (app *routedAppConnQuery) Error() error {
+	if err := app.AppConnQuery.Error(); err != nil {
+		return err
+	}
+	for _, appConn := range app.historical {
+		if err := appConn.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// QuerySync routes the queries at a height, 0 being the latest one, to the
+// pool.
+func (app *routedAppConnQuery) QuerySync(
+	ctx context.Context,
+	req types.RequestQuery,
+) (*types.ResponseQuery, error) {
+	if req.Height == 0 {
+		return app.AppConnQuery.QuerySync(ctx, req)
+	}
+
+	app.mtx.Lock()
+	appConn := app.historical[app.next]
+	app.next = (app.next + 1) % len(app.historical)
+	app.mtx.Unlock()
+	return appConn.QuerySync(ctx, req)
+}
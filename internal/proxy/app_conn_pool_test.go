@@ -0,0 +1,189 @@
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+// deferredClient holds the async CheckTx requests, until respond completes
+// them. Like the local client, it calls the response callback for the sync
+// requests too.
+type deferredClient struct {
+	abciclient.Client
+
+	cb      abciclient.Callback
+	pending []*abciclient.ReqRes
+	checked int
+}
+
+func (c *deferredClient) SetResponseCallback(cb abciclient.Callback) {
+	c.cb = cb
+}
+
+func (c *deferredClient) CheckTxAsync(ctx context.Context, req types.RequestCheckTx) (*abciclient.ReqRes, error) {
+	c.checked++
+	reqRes := abciclient.NewReqRes(types.ToRequestCheckTx(req))
+	c.pending = append(c.pending, reqRes)
+	return reqRes, nil
+}
+
+func (c *deferredClient) CheckTxSync(ctx context.Context, req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	c.checked++
+	c.cb(types.ToRequestCheckTx(req), types.ToResponseCheckTx(types.ResponseCheckTx{}))
+	return &types.ResponseCheckTx{}, nil
+}
+
+func (c *deferredClient) respond() {
+	for _, reqRes := range c.pending {
+		reqRes.Response = types.ToResponseCheckTx(types.ResponseCheckTx{})
+		reqRes.Done()
+		reqRes.SetDone()
+		c.cb(reqRes.Request, reqRes.Response)
+	}
+	c.pending = nil
+}
+
+func newDeferredPool(n int) ([]*deferredClient, *pooledAppConnMempool) {
+	clients := make([]*deferredClient, n)
+	appConns := make([]abciclient.Client, n)
+	for i := range clients {
+		clients[i] = &deferredClient{}
+		appConns[i] = clients[i]
+	}
+	conn := NewPooledAppConnMempool(appConns).(*pooledAppConnMempool)
+	conn.SetResponseCallback(func(req *types.Request, res *types.Response) {})
+	return clients, conn
+}
+
+func requireNoPendingRechecks(t *testing.T, conn *pooledAppConnMempool) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		conn.mtx.Lock()
+		defer conn.mtx.Unlock()
+		return conn.pendingRechecks == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestPooledAppConnMempool(t *testing.T) {
+	ctx := context.Background()
+	clients, conn := newDeferredPool(3)
+
+	responses := 0
+	conn.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		responses++
+	})
+
+	// new txs go over the clients in turn
+	for i := 0; i < 6; i++ {
+		_, err := conn.CheckTxAsync(ctx, types.RequestCheckTx{Tx: []byte("tx")})
+		require.NoError(t, err)
+	}
+	for _, c := range clients {
+		require.Equal(t, 2, c.checked)
+		c.respond()
+	}
+
+	// rechecks, and new txs while rechecks are pending, go over the first client
+	for i := 0; i < 2; i++ {
+		_, err := conn.CheckTxAsync(ctx, types.RequestCheckTx{Tx: []byte("tx"), Type: types.CheckTxType_Recheck})
+		require.NoError(t, err)
+	}
+	_, err := conn.CheckTxAsync(ctx, types.RequestCheckTx{Tx: []byte("tx")})
+	require.NoError(t, err)
+	require.Equal(t, 5, clients[0].checked)
+	clients[0].respond()
+	require.Equal(t, 9, responses)
+	requireNoPendingRechecks(t, conn)
+
+	// once the rechecks are done, the new txs are spread again
+	for i := 0; i < 3; i++ {
+		_, err := conn.CheckTxAsync(ctx, types.RequestCheckTx{Tx: []byte("tx")})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 6, clients[0].checked)
+	require.Equal(t, 3, clients[1].checked)
+	require.Equal(t, 3, clients[2].checked)
+}
+
+func TestPooledAppConnMempoolSyncRecheck(t *testing.T) {
+	ctx := context.Background()
+	clients, conn := newDeferredPool(2)
+
+	// the response callback of a sync recheck doesn't complete an async one
+	_, err := conn.CheckTxAsync(ctx, types.RequestCheckTx{Tx: []byte("tx"), Type: types.CheckTxType_Recheck})
+	require.NoError(t, err)
+	_, err = conn.CheckTxSync(ctx, types.RequestCheckTx{Tx: []byte("tx"), Type: types.CheckTxType_Recheck})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err := conn.CheckTxAsync(ctx, types.RequestCheckTx{Tx: []byte("tx")})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 4, clients[0].checked)
+	require.Zero(t, clients[1].checked)
+
+	clients[0].respond()
+	requireNoPendingRechecks(t, conn)
+
+	// and no sync recheck leaves the count below zero
+	for i := 0; i < 3; i++ {
+		_, err := conn.CheckTxSync(ctx, types.RequestCheckTx{Tx: []byte("tx"), Type: types.CheckTxType_Recheck})
+		require.NoError(t, err)
+	}
+	conn.mtx.Lock()
+	require.Zero(t, conn.pendingRechecks)
+	conn.mtx.Unlock()
+
+	for i := 0; i < 2; i++ {
+		_, err := conn.CheckTxAsync(ctx, types.RequestCheckTx{Tx: []byte("tx")})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 8, clients[0].checked)
+	require.Equal(t, 1, clients[1].checked)
+}
+
+// queryClient counts the queries it serves.
+type queryClient struct {
+	abciclient.Client
+
+	queried int
+}
+
+func (c *queryClient) QuerySync(ctx context.Context, req types.RequestQuery) (*types.ResponseQuery, error) {
+	c.queried++
+	return &types.ResponseQuery{Height: req.Height}, nil
+}
+
+func TestRoutedAppConnQuery(t *testing.T) {
+	ctx := context.Background()
+	latest, historical := &queryClient{}, []*queryClient{{}, {}}
+	conn := NewRoutedAppConnQuery(latest, []abciclient.Client{historical[0], historical[1]})
+
+	// the queries of the latest state go over the query client
+	for i := 0; i < 3; i++ {
+		_, err := conn.QuerySync(ctx, types.RequestQuery{Path: "/key"})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, latest.queried)
+
+	// and those at past heights over the pool, in turn
+	for i := 0; i < 4; i++ {
+		res, err := conn.QuerySync(ctx, types.RequestQuery{Path: "/key", Height: 5})
+		require.NoError(t, err)
+		require.EqualValues(t, 5, res.Height)
+	}
+	require.Equal(t, 3, latest.queried)
+	require.Equal(t, 2, historical[0].queried)
+	require.Equal(t, 2, historical[1].queried)
+
+	// without a pool, all the queries go over the query client
+	conn = NewRoutedAppConnQuery(latest, nil)
+	_, err := conn.QuerySync(ctx, types.RequestQuery{Path: "/key", Height: 5})
+	require.NoError(t, err)
+	require.Equal(t, 4, latest.queried)
+}
@@ -6,6 +6,7 @@ import (
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
 	e2e "github.com/tendermint/tendermint/test/e2e/app"
 )
 
@@ -14,8 +15,12 @@ import (
 // 'persistent_kvstore', 'e2e', or 'noop', otherwise - a remote client.
 //
 // The Closer is a noop except for persistent_kvstore applications,
-// which will clean up the store.
-func DefaultClientCreator(addr, transport, dbDir string) (abciclient.Creator, io.Closer) {
+// which will clean up the store. The options apply to a remote client over
+// gRPC.
+func DefaultClientCreator(
+	addr, transport, dbDir string,
+	options ...abciclient.GRPCClientOption,
+) (abciclient.Creator, io.Closer) {
 	switch addr {
 	case "kvstore":
 		return abciclient.NewLocalCreator(kvstore.NewApplication()), noopCloser{}
@@ -32,7 +37,17 @@ func DefaultClientCreator(addr, transport, dbDir string) (abciclient.Creator, io
 		return abciclient.NewLocalCreator(types.NewBaseApplication()), noopCloser{}
 	default:
 		mustConnect := false // loop retrying
-		return abciclient.NewRemoteCreator(addr, transport, mustConnect), noopCloser{}
+		return abciclient.NewRemoteCreator(addr, transport, mustConnect, options...), noopCloser{}
+	}
+}
+
+// GRPCClientOptions returns the options of the gRPC client of the ABCI
+// application set in cfg.
+func GRPCClientOptions(cfg config.BaseConfig) []abciclient.GRPCClientOption {
+	return []abciclient.GRPCClientOption{
+		abciclient.GRPCCallTimeout(cfg.ABCIGRPCCallTimeout),
+		abciclient.GRPCMaxRetries(cfg.ABCIGRPCMaxRetries),
+		abciclient.GRPCKeepalive(cfg.ABCIGRPCKeepaliveTime, cfg.ABCIGRPCKeepaliveTimeout),
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"syscall"
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/config"
 	tmlog "github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 )
@@ -33,8 +34,38 @@ type AppConns interface {
 }
 
 // NewAppConns calls NewMultiAppConn.
-func NewAppConns(clientCreator abciclient.Creator) AppConns {
-	return NewMultiAppConn(clientCreator)
+func NewAppConns(clientCreator abciclient.Creator, options ...AppConnsOption) AppConns {
+	return NewMultiAppConn(clientCreator, options...)
+}
+
+// AppConnsOption sets an optional parameter on the AppConns.
+type AppConnsOption func(*multiAppConn)
+
+// AppConnsWithResponseValidation sets how the responses of the consensus
+// connection which violate the protocol are dealt with:
+// config.ABCIResponseValidationStrict (the default) or
+// config.ABCIResponseValidationLenient.
+func AppConnsWithResponseValidation(mode string) AppConnsOption {
+	return func(app *multiAppConn) {
+		app.lenientValidation = mode == config.ABCIResponseValidationLenient
+	}
+}
+
+// AppConnsWithMempoolConnections sets the number of connections of the pool
+// backing the mempool connection. Defaults to 1.
+func AppConnsWithMempoolConnections(n int) AppConnsOption {
+	return func(app *multiAppConn) {
+		app.mempoolConns = n
+	}
+}
+
+// AppConnsWithHistoricalQueryConnections sets the number of connections of
+// the pool the queries at past heights go over, instead of the query
+// connection. Defaults to 0.
+func AppConnsWithHistoricalQueryConnections(n int) AppConnsOption {
+	return func(app *multiAppConn) {
+		app.historicalQueryConns = n
+	}
 }
 
 // multiAppConn implements AppConns.
@@ -54,14 +85,26 @@ type multiAppConn struct {
 	mempoolConnClient   abciclient.Client
 	queryConnClient     abciclient.Client
 	snapshotConnClient  abciclient.Client
+	// the clients of the mempool connection pool besides mempoolConnClient
+	mempoolPoolClients []abciclient.Client
+	// the clients of the pool of the queries at past heights
+	historicalQueryClients []abciclient.Client
 
 	clientCreator abciclient.Creator
+
+	lenientValidation    bool
+	mempoolConns         int
+	historicalQueryConns int
 }
 
 // NewMultiAppConn makes all necessary abci connections to the application.
-func NewMultiAppConn(clientCreator abciclient.Creator) AppConns {
+func NewMultiAppConn(clientCreator abciclient.Creator, options ...AppConnsOption) AppConns {
 	multiAppConn := &multiAppConn{
 		clientCreator: clientCreator,
+		mempoolConns:  1,
+	}
+	for _, option := range options {
+		option(multiAppConn)
 	}
 	multiAppConn.BaseService = *service.NewBaseService(nil, "multiAppConn", multiAppConn)
 	return multiAppConn
@@ -89,7 +132,15 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.queryConnClient = c
-	app.queryConn = NewAppConnQuery(c)
+	for i := 0; i < app.historicalQueryConns; i++ {
+		c, err = app.abciClientFor(fmt.Sprintf("%s-historical-%d", connQuery, i))
+		if err != nil {
+			app.stopAllClients()
+			return err
+		}
+		app.historicalQueryClients = append(app.historicalQueryClients, c)
+	}
+	app.queryConn = NewRoutedAppConnQuery(app.queryConnClient, app.historicalQueryClients)
 
 	c, err = app.abciClientFor(connSnapshot)
 	if err != nil {
@@ -105,7 +156,16 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.mempoolConnClient = c
-	app.mempoolConn = NewAppConnMempool(c)
+	for i := 1; i < app.mempoolConns; i++ {
+		c, err = app.abciClientFor(fmt.Sprintf("%s-%d", connMempool, i))
+		if err != nil {
+			app.stopAllClients()
+			return err
+		}
+		app.mempoolPoolClients = append(app.mempoolPoolClients, c)
+	}
+	app.mempoolConn = NewPooledAppConnMempool(
+		append([]abciclient.Client{app.mempoolConnClient}, app.mempoolPoolClients...))
 
 	c, err = app.abciClientFor(connConsensus)
 	if err != nil {
@@ -113,10 +173,17 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.consensusConnClient = c
-	app.consensusConn = NewAppConnConsensus(c)
+	app.consensusConn = NewValidatingAppConnConsensus(NewAppConnConsensus(c), !app.lenientValidation,
+		app.Logger.With("module", "abci-validation"))
 
 	// Kill Tendermint if the ABCI application crashes.
 	go app.killTMOnClientError()
+	for _, c := range app.mempoolPoolClients {
+		go app.killTMOnPoolClientError(connMempool, c)
+	}
+	for _, c := range app.historicalQueryClients {
+		go app.killTMOnPoolClientError(connQuery, c)
+	}
 
 	return nil
 }
@@ -155,6 +222,20 @@ func (app *multiAppConn) killTMOnClientError() {
 	}
 }
 
+// killTMOnPoolClientError kills Tendermint if c, a client of the pool of the
+// conn connection, terminates with an error.
+func (app *multiAppConn) killTMOnPoolClientError(conn string, c abciclient.Client) {
+	<-c.Quit()
+	if err := c.Error(); err != nil {
+		app.Logger.Error(
+			fmt.Sprintf("%s connection terminated. Did the application crash? Please restart tendermint", conn),
+			"err", err)
+		if killErr := kill(); killErr != nil {
+			app.Logger.Error("Failed to kill this process - please do so manually", "err", killErr)
+		}
+	}
+}
+
 func (app *multiAppConn) stopAllClients() {
 	if app.consensusConnClient != nil {
 		if err := app.consensusConnClient.Stop(); err != nil {
@@ -166,11 +247,21 @@ func (app *multiAppConn) stopAllClients() {
 			app.Logger.Error("error while stopping mempool client", "error", err)
 		}
 	}
+	for _, c := range app.mempoolPoolClients {
+		if err := c.Stop(); err != nil {
+			app.Logger.Error("error while stopping mempool client", "error", err)
+		}
+	}
 	if app.queryConnClient != nil {
 		if err := app.queryConnClient.Stop(); err != nil {
 			app.Logger.Error("error while stopping query client", "error", err)
 		}
 	}
+	for _, c := range app.historicalQueryClients {
+		if err := c.Stop(); err != nil {
+			app.Logger.Error("error while stopping query client", "error", err)
+		}
+	}
 	if app.snapshotConnClient != nil {
 		if err := app.snapshotConnClient.Stop(); err != nil {
 			app.Logger.Error("error while stopping snapshot client", "error", err)
@@ -0,0 +1,220 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/encoding"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// MaxAppHashBytes is the maximum size of the app hash returned by Commit.
+const MaxAppHashBytes = 64
+
+// ErrInvalidResponse is returned in strict mode for a response of the
+// application which violates the ABCI protocol.
+type ErrInvalidResponse struct {
+	Method string
+	Err    error
+}
+
+func (e ErrInvalidResponse) Error() string {
+	return fmt.Sprintf("invalid %s response from the application: %v", e.Method, e.Err)
+}
+
+func (e ErrInvalidResponse) Unwrap() error {
+	return e.Err
+}
+
+// validatingAppConnConsensus checks the responses of the consensus connection
+// for protocol violations. In strict mode, a call returning an invalid
+// response fails, which halts the node. In lenient mode, the violations are
+// logged, and the response is sanitized where it can be.
+type validatingAppConnConsensus struct {
+	AppConnConsensus
+
+	strict bool
+	logger log.Logger
+
+	mtx tmsync.Mutex
+	// the first invalid DeliverTx response of the block in strict mode, which
+	// fails EndBlock
+	deliverTxErr error
+}
+
+// NewValidatingAppConnConsensus returns a consensus connection checking the
+// responses of appConn, in strict mode or in lenient mode otherwise.
+func NewValidatingAppConnConsensus(appConn AppConnConsensus, strict bool, logger log.Logger) AppConnConsensus {
+	return &validatingAppConnConsensus{
+		AppConnConsensus: appConn,
+		strict:           strict,
+		logger:           logger,
+	}
+}
+
+// check returns the violations of a response in strict mode, and logs them
+// otherwise.
+func (app *validatingAppConnConsensus) check(method string, violations []error) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	if app.strict {
+		return ErrInvalidResponse{Method: method, Err: violations[0]}
+	}
+	for _, err := range violations {
+		app.logger.Error("sanitized an invalid response from the application", "method", method, "err", err)
+	}
+	return nil
+}
+
+func (app *validatingAppConnConsensus) SetResponseCallback(cb abciclient.Callback) {
+	app.AppConnConsensus.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		if r, ok := res.Value.(*types.Response_DeliverTx); ok {
+			if err := app.check("DeliverTx", sanitizeDeliverTx(r.DeliverTx)); err != nil {
+				app.mtx.Lock()
+				if app.deliverTxErr == nil {
+					app.deliverTxErr = err
+				}
+				app.mtx.Unlock()
+			}
+		}
+		cb(req, res)
+	})
+}
+
+func (app *validatingAppConnConsensus) InitChainSync(
+	ctx context.Context,
+	req types.RequestInitChain,
+) (*types.ResponseInitChain, error) {
+	res, err := app.AppConnConsensus.InitChainSync(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var violations []error
+	res.Validators, violations = sanitizeValidatorUpdates(res.Validators)
+	if err := app.check("InitChain", violations); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (app *validatingAppConnConsensus) BeginBlockSync(
+	ctx context.Context,
+	req types.RequestBeginBlock,
+) (*types.ResponseBeginBlock, error) {
+	app.mtx.Lock()
+	app.deliverTxErr = nil
+	app.mtx.Unlock()
+	return app.AppConnConsensus.BeginBlockSync(ctx, req)
+}
+
+func (app *validatingAppConnConsensus) EndBlockSync(
+	ctx context.Context,
+	req types.RequestEndBlock,
+) (*types.ResponseEndBlock, error) {
+	res, err := app.AppConnConsensus.EndBlockSync(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// the DeliverTx responses have all been received by now
+	app.mtx.Lock()
+	err = app.deliverTxErr
+	app.mtx.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []error
+	res.ValidatorUpdates, violations = sanitizeValidatorUpdates(res.ValidatorUpdates)
+	if err := app.check("EndBlock", violations); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (app *validatingAppConnConsensus) FinalizeBlockSync(
+	ctx context.Context,
+	req types.RequestFinalizeBlock,
+) (*types.ResponseFinalizeBlock, error) {
+	res, err := app.AppConnConsensus.FinalizeBlockSync(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var violations []error
+	for _, txRes := range res.TxResults {
+		if txRes != nil {
+			violations = append(violations, sanitizeDeliverTx(txRes)...)
+		}
+	}
+	var valViolations []error
+	res.ValidatorUpdates, valViolations = sanitizeValidatorUpdates(res.ValidatorUpdates)
+	if err := app.check("FinalizeBlock", append(violations, valViolations...)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (app *validatingAppConnConsensus) CommitSync(ctx context.Context) (*types.ResponseCommit, error) {
+	res, err := app.AppConnConsensus.CommitSync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// an app hash can't be sanitized, as it must match the one of the app
+	if len(res.Data) > MaxAppHashBytes {
+		violation := fmt.Errorf("app hash of %d bytes is longer than %d bytes", len(res.Data), MaxAppHashBytes)
+		if err := app.check("Commit", []error{violation}); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// sanitizeDeliverTx zeroes the negative gas of res, and returns the
+// violations.
+func sanitizeDeliverTx(res *types.ResponseDeliverTx) []error {
+	var violations []error
+	if res.GasWanted < 0 {
+		violations = append(violations, fmt.Errorf("negative gas wanted %d", res.GasWanted))
+		res.GasWanted = 0
+	}
+	if res.GasUsed < 0 {
+		violations = append(violations, fmt.Errorf("negative gas used %d", res.GasUsed))
+		res.GasUsed = 0
+	}
+	return violations
+}
+
+// sanitizeValidatorUpdates returns the valid validator updates, without the
+// ones with a negative power or an invalid pubkey, nor the repeated updates
+// of a pubkey, and the violations.
+func sanitizeValidatorUpdates(updates []types.ValidatorUpdate) ([]types.ValidatorUpdate, []error) {
+	var violations []error
+	valid := make([]types.ValidatorUpdate, 0, len(updates))
+	seen := make(map[string]bool, len(updates))
+	for _, update := range updates {
+		pk, err := encoding.PubKeyFromProto(update.PubKey)
+		switch {
+		case update.Power < 0:
+			err = fmt.Errorf("negative power %d", update.Power)
+		case err != nil:
+			err = fmt.Errorf("invalid pubkey: %w", err)
+		case seen[string(pk.Bytes())]:
+			err = errors.New("duplicate pubkey")
+		}
+		if err != nil {
+			violations = append(violations, fmt.Errorf("validator update %v: %w", update, err))
+			continue
+		}
+		seen[string(pk.Bytes())] = true
+		valid = append(valid, update)
+	}
+	if len(violations) == 0 {
+		return updates, nil
+	}
+	return valid, violations
+}
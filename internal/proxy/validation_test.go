@@ -0,0 +1,74 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+type invalidResponsesApp struct {
+	types.BaseApplication
+	updates []types.ValidatorUpdate
+}
+
+func (app *invalidResponsesApp) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx {
+	return types.ResponseDeliverTx{GasWanted: -1, GasUsed: 1}
+}
+
+func (app *invalidResponsesApp) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
+	return types.ResponseEndBlock{ValidatorUpdates: app.updates}
+}
+
+func (app *invalidResponsesApp) Commit() types.ResponseCommit {
+	return types.ResponseCommit{Data: make([]byte, MaxAppHashBytes+1)}
+}
+
+func TestValidatingAppConnConsensus(t *testing.T) {
+	ctx := context.Background()
+	pk := ed25519.GenPrivKey().PubKey()
+	valid := types.UpdateValidator(pk.Bytes(), 10, "")
+	app := &invalidResponsesApp{updates: []types.ValidatorUpdate{
+		valid,
+		types.UpdateValidator(pk.Bytes(), 20, ""),
+		types.UpdateValidator(ed25519.GenPrivKey().PubKey().Bytes(), -1, ""),
+	}}
+
+	for _, strict := range []bool{true, false} {
+		client, err := abciclient.NewLocalCreator(app)()
+		require.NoError(t, err)
+		conn := NewValidatingAppConnConsensus(NewAppConnConsensus(client), strict, log.TestingLogger())
+
+		var deliverTx *types.ResponseDeliverTx
+		conn.SetResponseCallback(func(req *types.Request, res *types.Response) {
+			deliverTx = res.GetDeliverTx()
+		})
+		_, err = conn.BeginBlockSync(ctx, types.RequestBeginBlock{})
+		require.NoError(t, err)
+		_, err = conn.DeliverTxAsync(ctx, types.RequestDeliverTx{Tx: []byte("tx")})
+		require.NoError(t, err)
+		require.NotNil(t, deliverTx)
+		require.Zero(t, deliverTx.GasWanted)
+		require.EqualValues(t, 1, deliverTx.GasUsed)
+
+		res, err := conn.EndBlockSync(ctx, types.RequestEndBlock{})
+		_, commitErr := conn.CommitSync(ctx)
+		if strict {
+			var invalid ErrInvalidResponse
+			require.True(t, errors.As(err, &invalid))
+			require.Equal(t, "DeliverTx", invalid.Method)
+			require.True(t, errors.As(commitErr, &invalid))
+			require.Equal(t, "Commit", invalid.Method)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, []types.ValidatorUpdate{valid}, res.ValidatorUpdates)
+		require.NoError(t, commitErr)
+	}
+}
@@ -1,22 +1,36 @@
 package core
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/internal/proxy"
-	"github.com/tendermint/tendermint/libs/bytes"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
 // ABCIQuery queries the application for some information.
+//
+// A query at a past height fails if the height is pruned, and its proof, if
+// of op types the node knows, is verified against the app hash of the height.
 // More: https://docs.tendermint.com/master/rpc/#/ABCI/abci_query
 func (env *Environment) ABCIQuery(
 	ctx *rpctypes.Context,
 	path string,
-	data bytes.HexBytes,
+	data tmbytes.HexBytes,
 	height int64,
 	prove bool,
 ) (*coretypes.ResultABCIQuery, error) {
+	if height != 0 {
+		if _, err := env.getHeight(env.BlockStore.Height(), &height); err != nil {
+			return nil, err
+		}
+	}
+
 	resQuery, err := env.ProxyAppQuery.QuerySync(ctx.Context(), abci.RequestQuery{
 		Path:   path,
 		Data:   data,
@@ -27,9 +41,75 @@ func (env *Environment) ABCIQuery(
 		return nil, err
 	}
 
+	if height != 0 && prove && resQuery.IsOK() && resQuery.ProofOps != nil {
+		if resQuery.Height != height {
+			return nil, fmt.Errorf("response height %d does not match requested height %d", resQuery.Height, height)
+		}
+		if err := env.verifyQueryProof(resQuery); err != nil {
+			return nil, err
+		}
+	}
+
 	return &coretypes.ResultABCIQuery{Response: *resQuery}, nil
 }
 
+// verifyQueryProof verifies the proof of the query response against the app
+// hash of its height, if the proof runtime knows its op types. The key path
+// of the proof is that of the keys of its ops, the first one being the key of
+// the response.
+func (env *Environment) verifyQueryProof(res *abci.ResponseQuery) error {
+	if env.ProofRuntime == nil {
+		return nil
+	}
+	ops, err := env.ProofRuntime.DecodeProof(res.ProofOps)
+	if err != nil {
+		return nil
+	}
+
+	var keyPath merkle.KeyPath
+	for i := len(ops) - 1; i >= 0; i-- {
+		if key := ops[i].GetKey(); len(key) != 0 {
+			keyPath = keyPath.AppendKey(key, merkle.KeyEncodingHex)
+		}
+	}
+	if len(keyPath) == 0 {
+		return errors.New("proof of no key")
+	}
+	if key := ops[0].GetKey(); len(key) != 0 && !bytes.Equal(key, res.Key) {
+		return fmt.Errorf("proof of key %X, not of the key %X of the response", key, res.Key)
+	}
+	var args [][]byte
+	if res.Value != nil {
+		args = [][]byte{res.Value}
+	}
+
+	appHash, err := env.appHashAt(res.Height)
+	if err != nil {
+		return err
+	}
+	if err := ops.Verify(appHash, keyPath.String(), args); err != nil {
+		return fmt.Errorf("invalid proof against the app hash %X of height %d: %w", appHash, res.Height, err)
+	}
+	return nil
+}
+
+// appHashAt returns the app hash of the state of the application at the
+// height, that of the header of the next block, or of the state of the node
+// if the height is the last one.
+func (env *Environment) appHashAt(height int64) ([]byte, error) {
+	if meta := env.BlockStore.LoadBlockMeta(height + 1); meta != nil {
+		return meta.Header.AppHash, nil
+	}
+	state, err := env.StateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	if state.LastBlockHeight != height {
+		return nil, fmt.Errorf("%w: no app hash of height %d yet", coretypes.ErrHeightNotAvailable, height)
+	}
+	return state.AppHash, nil
+}
+
 // ABCIInfo gets some info about the application.
 // More: https://docs.tendermint.com/master/rpc/#/ABCI/abci_info
 func (env *Environment) ABCIInfo(ctx *rpctypes.Context) (*coretypes.ResultABCIInfo, error) {
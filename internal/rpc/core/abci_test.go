@@ -0,0 +1,88 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/internal/proxy"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// queryApp answers the queries with the value of the key, and its proof.
+type queryApp struct {
+	proxy.AppConnQuery
+
+	key, value []byte
+	proof      *tmcrypto.ProofOps
+}
+
+func (app queryApp) QuerySync(ctx context.Context, req abci.RequestQuery) (*abci.ResponseQuery, error) {
+	return &abci.ResponseQuery{Key: app.key, Value: app.value, ProofOps: app.proof, Height: req.Height}, nil
+}
+
+// valueProof returns the proof of the value of the key, and the root it is
+// proven against.
+func valueProof(key, value []byte) ([]byte, *tmcrypto.ProofOps) {
+	var kv bytes.Buffer
+	for _, bz := range [][]byte{key, tmhash.Sum(value)} {
+		var buf [binary.MaxVarintLen64]byte
+		n := binary.PutUvarint(buf[:], uint64(len(bz)))
+		kv.Write(buf[:n])
+		kv.Write(bz)
+	}
+	root, proofs := merkle.ProofsFromByteSlices([][]byte{kv.Bytes()})
+	op := merkle.NewValueOp(key, proofs[0]).ProofOp()
+	return root, &tmcrypto.ProofOps{Ops: []tmcrypto.ProofOp{op}}
+}
+
+func TestABCIQueryHistorical(t *testing.T) {
+	key, value := []byte("key"), []byte("value")
+	appHash, proof := valueProof(key, value)
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(3))
+	blockStore.On("Height").Return(int64(10))
+	blockStore.On("LoadBlockMeta", int64(6)).Return(&types.BlockMeta{
+		Header: types.Header{Height: 6, AppHash: appHash},
+	})
+	blockStore.On("LoadBlockMeta", int64(7)).Return(&types.BlockMeta{
+		Header: types.Header{Height: 7, AppHash: []byte("other app hash")},
+	})
+	env := &Environment{
+		ProxyAppQuery: queryApp{key: key, value: value, proof: proof},
+		BlockStore:    blockStore,
+		ProofRuntime:  merkle.DefaultProofRuntime(),
+	}
+
+	// the proof is verified against the app hash of the header of the next
+	// block
+	res, err := env.ABCIQuery(&rpctypes.Context{}, "/key", key, 5, true)
+	require.NoError(t, err)
+	assert.Equal(t, value, res.Response.Value)
+
+	_, err = env.ABCIQuery(&rpctypes.Context{}, "/key", key, 6, true)
+	require.Error(t, err)
+
+	// a proof of another value
+	env.ProxyAppQuery = queryApp{key: key, value: []byte("other value"), proof: proof}
+	_, err = env.ABCIQuery(&rpctypes.Context{}, "/key", key, 5, true)
+	require.Error(t, err)
+
+	// the pruned heights and those not committed yet
+	_, err = env.ABCIQuery(&rpctypes.Context{}, "/key", key, 2, true)
+	require.ErrorIs(t, err, coretypes.ErrHeightNotAvailable)
+	_, err = env.ABCIQuery(&rpctypes.Context{}, "/key", key, 11, true)
+	require.ErrorIs(t, err, coretypes.ErrHeightExceedsChainHead)
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"math"
 	"sort"
 
 	"github.com/tendermint/tendermint/internal/state/indexer"
@@ -54,6 +55,73 @@ func (env *Environment) BlockchainInfo(
 		BlockMetas: blockMetas}, nil
 }
 
+// Blocks gets the blocks for minHeight <= height <= maxHeight, so that a
+// range of blocks can be fetched in a single round trip.
+//
+// If maxHeight does not yet exist, blocks up to the current height will be
+// returned. If minHeight does not exist (due to pruning), earliest existing
+// height will be used.
+//
+// At most 100 blocks will be returned, and no block is added once the blocks
+// returned amount to 16MB. At least one block is returned though. Blocks are
+// returned in ascending order (lowest first), the next range starts above the
+// last height returned. Transactions are left out unless includeTxs is true.
+//
+// More: https://docs.tendermint.com/master/rpc/#/Info/blocks
+func (env *Environment) Blocks(
+	ctx *rpctypes.Context,
+	minHeight, maxHeight int64,
+	includeTxs bool,
+) (*coretypes.ResultBlocks, error) {
+	const (
+		limit    int64 = 100
+		maxBytes       = 16 << 20
+	)
+
+	var err error
+	minHeight, maxHeight, err = filterMinMax(
+		env.BlockStore.Base(),
+		env.BlockStore.Height(),
+		minHeight,
+		maxHeight,
+		math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+	maxHeight = tmmath.MinInt64(maxHeight, minHeight+limit-1)
+	env.Logger.Debug("Blocks", "maxHeight", maxHeight, "minHeight", minHeight)
+
+	blocks := make([]*coretypes.ResultBlock, 0, maxHeight-minHeight+1)
+	size := 0
+	for height := minHeight; height <= maxHeight && (size < maxBytes || len(blocks) == 0); height++ {
+		blockMeta := env.BlockStore.LoadBlockMeta(height)
+		block := env.BlockStore.LoadBlock(height)
+		if blockMeta == nil || block == nil {
+			continue
+		}
+		res := newResultBlock(blockMeta.BlockID, block)
+		if !includeTxs {
+			block = &types.Block{
+				Header: block.Header,
+				Data: types.Data{
+					IntermediateStateRoots: block.IntermediateStateRoots,
+					Evidence:               block.Evidence,
+					Messages:               block.Messages,
+				},
+				LastCommit: block.LastCommit,
+			}
+		}
+		res.Block = block
+		size += block.Size()
+		blocks = append(blocks, res)
+	}
+
+	return &coretypes.ResultBlocks{
+		LastHeight: env.BlockStore.Height(),
+		Blocks:     blocks,
+	}, nil
+}
+
 // error if either min or max are negative or min > max
 // if 0, use blockstore base for min, latest block height for max
 // enforce limit.
@@ -97,13 +165,22 @@ func (env *Environment) Block(ctx *rpctypes.Context, heightPtr *int64) (*coretyp
 		return nil, err
 	}
 
+	key := cacheKey("block", height)
+	if res, ok := env.responseCache.get(key); ok {
+		return res.(*coretypes.ResultBlock), nil
+	}
+
 	blockMeta := env.BlockStore.LoadBlockMeta(height)
 	if blockMeta == nil {
 		return &coretypes.ResultBlock{BlockID: types.BlockID{}, Block: nil}, nil
 	}
 
 	block := env.BlockStore.LoadBlock(height)
-	return &coretypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, nil
+	res := newResultBlock(blockMeta.BlockID, block)
+	if block != nil {
+		env.responseCache.add(key, height, env.BlockStore.Base(), res)
+	}
+	return res, nil
 }
 
 // BlockByHash gets block by hash.
@@ -119,7 +196,17 @@ func (env *Environment) BlockByHash(ctx *rpctypes.Context, hash bytes.HexBytes)
 	}
 	// If block is not nil, then blockMeta can't be nil.
 	blockMeta := env.BlockStore.LoadBlockMeta(block.Height)
-	return &coretypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, nil
+	return newResultBlock(blockMeta.BlockID, block), nil
+}
+
+// newResultBlock returns the block, with the width of its original data
+// square.
+func newResultBlock(blockID types.BlockID, block *types.Block) *coretypes.ResultBlock {
+	res := &coretypes.ResultBlock{BlockID: blockID, Block: block}
+	if block != nil {
+		res.SquareSize = block.Data.SquareSize()
+	}
+	return res
 }
 
 // Commit gets block commit at a given height.
@@ -131,6 +218,11 @@ func (env *Environment) Commit(ctx *rpctypes.Context, heightPtr *int64) (*corety
 		return nil, err
 	}
 
+	key := cacheKey("commit", height)
+	if res, ok := env.responseCache.get(key); ok {
+		return res.(*coretypes.ResultCommit), nil
+	}
+
 	blockMeta := env.BlockStore.LoadBlockMeta(height)
 	if blockMeta == nil {
 		return nil, nil
@@ -153,7 +245,9 @@ func (env *Environment) Commit(ctx *rpctypes.Context, heightPtr *int64) (*corety
 	if commit == nil {
 		return nil, nil
 	}
-	return coretypes.NewResultCommit(&header, commit, true), nil
+	res := coretypes.NewResultCommit(&header, commit, true)
+	env.responseCache.add(key, height, env.BlockStore.Base(), res)
+	return res, nil
 }
 
 // BlockResults gets ABCIResults at a given height.
@@ -169,6 +263,11 @@ func (env *Environment) BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*
 		return nil, err
 	}
 
+	key := cacheKey("block_results", height)
+	if res, ok := env.responseCache.get(key); ok {
+		return res.(*coretypes.ResultBlockResults), nil
+	}
+
 	results, err := env.StateStore.LoadABCIResponses(height)
 	if err != nil {
 		return nil, err
@@ -179,7 +278,7 @@ func (env *Environment) BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*
 		totalGasUsed += tx.GetGasUsed()
 	}
 
-	return &coretypes.ResultBlockResults{
+	res := &coretypes.ResultBlockResults{
 		Height:                height,
 		TxsResults:            results.DeliverTxs,
 		TotalGasUsed:          totalGasUsed,
@@ -187,27 +286,54 @@ func (env *Environment) BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*
 		EndBlockEvents:        results.EndBlock.Events,
 		ValidatorUpdates:      results.EndBlock.ValidatorUpdates,
 		ConsensusParamUpdates: results.EndBlock.ConsensusParamUpdates,
-	}, nil
+	}
+	env.responseCache.add(key, height, env.BlockStore.Base(), res)
+	return res, nil
 }
 
 // BlockSearch searches for a paginated set of blocks matching BeginBlock and
-// EndBlock event search criteria.
+// EndBlock event search criteria. Like TxSearch, it supports both page and
+// cursor based pagination.
 func (env *Environment) BlockSearch(
 	ctx *rpctypes.Context,
 	query string,
 	pagePtr, perPagePtr *int,
 	orderBy string,
+	cursor string,
+	limitPtr *int,
+	countTotal bool,
 ) (*coretypes.ResultBlockSearch, error) {
 
 	if !indexer.KVSinkEnabled(env.EventSinks) {
 		return nil, fmt.Errorf("block searching is disabled due to no kvEventSink")
 	}
 
+	useCursor, err := searchWithCursor(pagePtr, cursor, limitPtr)
+	if err != nil {
+		return nil, err
+	}
+
 	q, err := tmquery.New(query)
 	if err != nil {
 		return nil, err
 	}
 
+	var cur *searchCursor
+	if cursor != "" {
+		c, err := decodeSearchCursor(cursor, orderBy)
+		if err != nil {
+			return nil, err
+		}
+		cur = &c
+
+		// without a total count, the results preceding the cursor are not needed
+		if !countTotal {
+			if q, err = cur.narrow(query, types.BlockHeightKey); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	var kvsink indexer.EventSink
 	for _, sink := range env.EventSinks {
 		if sink.Type() == indexer.KV {
@@ -234,15 +360,36 @@ func (env *Environment) BlockSearch(
 
 	// paginate results
 	totalCount := len(results)
-	perPage := env.validatePerPage(perPagePtr)
+	var (
+		skipCount, pageSize int
+		nextCursor          string
+	)
+	if useCursor {
+		if cur != nil {
+			results = results[sort.Search(len(results), func(i int) bool {
+				return cur.follows(results[i], 0)
+			}):]
+		}
+		if !countTotal {
+			totalCount = -1
+		}
 
-	page, err := validatePage(pagePtr, perPage, totalCount)
-	if err != nil {
-		return nil, err
-	}
+		limit := env.validatePerPage(limitPtr)
+		pageSize = tmmath.MinInt(limit, len(results))
+		if len(results) > limit {
+			nextCursor = searchCursor{desc: orderBy != "asc", height: results[pageSize-1]}.String()
+		}
+	} else {
+		perPage := env.validatePerPage(perPagePtr)
 
-	skipCount := validateSkipCount(page, perPage)
-	pageSize := tmmath.MinInt(perPage, totalCount-skipCount)
+		page, err := validatePage(pagePtr, perPage, totalCount)
+		if err != nil {
+			return nil, err
+		}
+
+		skipCount = validateSkipCount(page, perPage)
+		pageSize = tmmath.MinInt(perPage, totalCount-skipCount)
+	}
 
 	apiResults := make([]*coretypes.ResultBlock, 0, pageSize)
 	for i := skipCount; i < skipCount+pageSize; i++ {
@@ -250,13 +397,10 @@ func (env *Environment) BlockSearch(
 		if block != nil {
 			blockMeta := env.BlockStore.LoadBlockMeta(block.Height)
 			if blockMeta != nil {
-				apiResults = append(apiResults, &coretypes.ResultBlock{
-					Block:   block,
-					BlockID: blockMeta.BlockID,
-				})
+				apiResults = append(apiResults, newResultBlock(blockMeta.BlockID, block))
 			}
 		}
 	}
 
-	return &coretypes.ResultBlockSearch{Blocks: apiResults, TotalCount: totalCount}, nil
+	return &coretypes.ResultBlockSearch{Blocks: apiResults, TotalCount: totalCount, NextCursor: nextCursor}, nil
 }
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+// responseCache is an LRU cache of RPC responses which cannot change anymore,
+// such as the block at a committed height. Each response is kept with the
+// height it belongs to, so that it is dropped once that height is pruned.
+//
+// A nil responseCache is valid and caches nothing.
+type responseCache struct {
+	mtx      sync.Mutex
+	maxBytes int64
+	bytes    int64
+	base     int64 // lowest height that has not been pruned
+	entries  map[string]*list.Element
+	lru      *list.List // front is most recently used
+}
+
+type cacheEntry struct {
+	key    string
+	height int64
+	size   int64
+	value  interface{}
+}
+
+// newResponseCache returns a cache holding at most maxBytes of responses, as
+// measured by their JSON encoding. It returns nil if maxBytes is 0.
+func newResponseCache(maxBytes int64) *responseCache {
+	if maxBytes <= 0 {
+		return nil
+	}
+	return &responseCache{
+		maxBytes: maxBytes,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// cacheKey returns the key of the response of method for the given params.
+func cacheKey(method string, params ...interface{}) string {
+	key := method
+	for _, p := range params {
+		switch v := p.(type) {
+		case *int:
+			if v == nil {
+				key += "/-"
+			} else {
+				key += fmt.Sprintf("/%d", *v)
+			}
+		default:
+			key += fmt.Sprintf("/%v", v)
+		}
+	}
+	return key
+}
+
+// get returns the response cached under key, if any.
+//
+// NOTE: the response is shared, callers must not modify it.
+func (c *responseCache) get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).value, true
+}
+
+// add caches the response of the given height under key. base is the current
+// base height of the node: responses below it are evicted.
+func (c *responseCache) add(key string, height, base int64, value interface{}) {
+	if c == nil {
+		return
+	}
+
+	// measure the response before taking the lock, the encoding of a large
+	// block takes a while
+	bz, err := tmjson.Marshal(value)
+	if err != nil {
+		return
+	}
+	size := int64(len(bz))
+	if size > c.maxBytes {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if base > c.base {
+		c.base = base
+		for elem := c.lru.Front(); elem != nil; {
+			next := elem.Next()
+			if elem.Value.(*cacheEntry).height < base {
+				c.remove(elem)
+			}
+			elem = next
+		}
+	}
+	if height < c.base {
+		return
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	for c.bytes+size > c.maxBytes {
+		c.remove(c.lru.Back())
+	}
+
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, height: height, size: size, value: value})
+	c.bytes += size
+}
+
+func (c *responseCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+)
+
+func TestResponseCache(t *testing.T) {
+	res := func(height int64) *coretypes.ResultValidators {
+		return &coretypes.ResultValidators{BlockHeight: height}
+	}
+	bz, err := tmjson.Marshal(res(1))
+	require.NoError(t, err)
+	size := int64(len(bz))
+
+	// disabled
+	c := newResponseCache(0)
+	c.add("a", 1, 1, res(1))
+	_, ok := c.get("a")
+	assert.False(t, ok)
+
+	// room for three responses, all of the same size below height 10
+	c = newResponseCache(3 * size)
+	for h := int64(1); h <= 3; h++ {
+		c.add(cacheKey("validators", h), h, 1, res(h))
+	}
+	got, ok := c.get(cacheKey("validators", 1))
+	require.True(t, ok)
+	assert.Equal(t, res(1), got)
+
+	// the least recently used response is evicted
+	c.add(cacheKey("validators", 4), 4, 1, res(4))
+	_, ok = c.get(cacheKey("validators", 2))
+	assert.False(t, ok)
+	for _, h := range []int64{1, 3, 4} {
+		_, ok = c.get(cacheKey("validators", h))
+		assert.True(t, ok, h)
+	}
+
+	// pruned heights are evicted, and not cached anymore
+	c.add(cacheKey("validators", 5), 5, 4, res(5))
+	for h, want := range map[int64]bool{1: false, 3: false, 4: true, 5: true} {
+		_, ok = c.get(cacheKey("validators", h))
+		assert.Equal(t, want, ok, h)
+	}
+	c.add(cacheKey("validators", 2), 2, 4, res(2))
+	_, ok = c.get(cacheKey("validators", 2))
+	assert.False(t, ok)
+
+	// responses larger than the cache are not cached
+	c = newResponseCache(size - 1)
+	c.add("a", 1, 1, res(1))
+	_, ok = c.get("a")
+	assert.False(t, ok)
+}
+
+func TestCacheKey(t *testing.T) {
+	page := 2
+	assert.Equal(t, "validators/10/2/-", cacheKey("validators", int64(10), &page, (*int)(nil)))
+	assert.Equal(t, "block/10", cacheKey("block", int64(10)))
+}
@@ -1,7 +1,10 @@
 package core
 
 import (
+	"bytes"
 	"errors"
+	"math"
+	"reflect"
 
 	"github.com/tendermint/tendermint/internal/consensus"
 	tmmath "github.com/tendermint/tendermint/libs/math"
@@ -28,6 +31,11 @@ func (env *Environment) Validators(
 		return nil, err
 	}
 
+	key := cacheKey("validators", height, pagePtr, perPagePtr)
+	if res, ok := env.responseCache.get(key); ok {
+		return res.(*coretypes.ResultValidators), nil
+	}
+
 	validators, err := env.StateStore.LoadValidators(height)
 	if err != nil {
 		return nil, err
@@ -44,11 +52,131 @@ func (env *Environment) Validators(
 
 	v := validators.Validators[skipCount : skipCount+tmmath.MinInt(perPage, totalCount-skipCount)]
 
-	return &coretypes.ResultValidators{
+	res := &coretypes.ResultValidators{
 		BlockHeight: height,
 		Validators:  v,
 		Count:       len(v),
-		Total:       totalCount}, nil
+		Total:       totalCount}
+	env.responseCache.add(key, height, env.BlockStore.Base(), res)
+	return res, nil
+}
+
+// ValidatorSets gets the validator sets of the heights fromHeight <= height
+// <= toHeight, so that the historical sets can be downloaded in bulk. The
+// heights are paginated, and the consecutive heights of a page sharing their
+// set are returned as a single range.
+//
+// If toHeight is 0, or does not yet exist, the sets up to the latest one are
+// returned. If fromHeight is 0, or does not exist (due to pruning), the
+// earliest existing height is used.
+//
+// More: https://docs.tendermint.com/master/rpc/#/Info/validator_sets
+func (env *Environment) ValidatorSets(
+	ctx *rpctypes.Context,
+	fromHeight, toHeight int64,
+	pagePtr, perPagePtr *int,
+) (*coretypes.ResultValidatorSets, error) {
+	fromHeight, toHeight, total, err := env.heightsPage(fromHeight, toHeight, pagePtr, perPagePtr)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sets     []coretypes.ValidatorSetRange
+		lastHash []byte
+	)
+	for height := fromHeight; height <= toHeight; height++ {
+		validators, err := env.StateStore.LoadValidators(height)
+		if err != nil {
+			return nil, err
+		}
+		hash := validators.Hash()
+		if n := len(sets); n > 0 && bytes.Equal(hash, lastHash) {
+			sets[n-1].ToHeight = height
+			continue
+		}
+		lastHash = hash
+		sets = append(sets, coretypes.ValidatorSetRange{
+			FromHeight: height,
+			ToHeight:   height,
+			Validators: validators.Validators,
+		})
+	}
+
+	return &coretypes.ResultValidatorSets{
+		ValidatorSets: sets,
+		Count:         int(toHeight - fromHeight + 1),
+		Total:         total,
+	}, nil
+}
+
+// ConsensusParamsHistory gets the consensus params of the heights fromHeight
+// <= height <= toHeight, paginated like ValidatorSets: the consecutive heights
+// of a page sharing their params are returned as a single range.
+//
+// More: https://docs.tendermint.com/master/rpc/#/Info/consensus_params_history
+func (env *Environment) ConsensusParamsHistory(
+	ctx *rpctypes.Context,
+	fromHeight, toHeight int64,
+	pagePtr, perPagePtr *int,
+) (*coretypes.ResultConsensusParamsHistory, error) {
+	fromHeight, toHeight, total, err := env.heightsPage(fromHeight, toHeight, pagePtr, perPagePtr)
+	if err != nil {
+		return nil, err
+	}
+
+	var params []coretypes.ConsensusParamsRange
+	for height := fromHeight; height <= toHeight; height++ {
+		consensusParams, err := env.StateStore.LoadConsensusParams(height)
+		if err != nil {
+			return nil, err
+		}
+		if n := len(params); n > 0 && reflect.DeepEqual(params[n-1].ConsensusParams, consensusParams) {
+			params[n-1].ToHeight = height
+			continue
+		}
+		params = append(params, coretypes.ConsensusParamsRange{
+			FromHeight:      height,
+			ToHeight:        height,
+			ConsensusParams: consensusParams,
+		})
+	}
+
+	return &coretypes.ResultConsensusParamsHistory{
+		ConsensusParams: params,
+		Count:           int(toHeight - fromHeight + 1),
+		Total:           total,
+	}, nil
+}
+
+// heightsPage returns the heights of the page of the heights fromHeight <=
+// height <= toHeight, bounded by the heights the state is known at, along with
+// the total number of heights.
+func (env *Environment) heightsPage(
+	fromHeight, toHeight int64,
+	pagePtr, perPagePtr *int,
+) (int64, int64, int, error) {
+	fromHeight, toHeight, err := filterMinMax(
+		env.BlockStore.Base(),
+		env.latestUncommittedHeight(),
+		fromHeight,
+		toHeight,
+		math.MaxInt64)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	total := int(toHeight - fromHeight + 1)
+	perPage := env.validatePerPage(perPagePtr)
+	page, err := validatePage(pagePtr, perPage, total)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	skipCount := validateSkipCount(page, perPage)
+
+	fromHeight += int64(skipCount)
+	toHeight = tmmath.MinInt64(toHeight, fromHeight+int64(perPage)-1)
+	return fromHeight, toHeight, total, nil
 }
 
 // DumpConsensusState dumps consensus state.
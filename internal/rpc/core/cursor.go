@@ -0,0 +1,101 @@
+package core
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+)
+
+// searchCursor is the position of the last result returned by a search. It is
+// handed to clients as an opaque token, which they pass back to get the
+// results following it. Unlike a page number, a cursor keeps pointing at the
+// same result while new blocks are indexed.
+type searchCursor struct {
+	desc   bool
+	height int64
+	index  uint32 // always 0 for block searches
+}
+
+// String encodes the cursor as an opaque token.
+func (c searchCursor) String() string {
+	order := "asc"
+	if c.desc {
+		order = "desc"
+	}
+	raw := fmt.Sprintf("%s/%d/%d", order, c.height, c.index)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeSearchCursor decodes a token created by searchCursor.String and
+// checks it was created for the given order.
+func decodeSearchCursor(token, orderBy string) (searchCursor, error) {
+	errBadCursor := fmt.Errorf("malformed cursor %q: %w", token, coretypes.ErrInvalidRequest)
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return searchCursor{}, errBadCursor
+	}
+	parts := strings.Split(string(raw), "/")
+	if len(parts) != 3 {
+		return searchCursor{}, errBadCursor
+	}
+
+	var c searchCursor
+	switch parts[0] {
+	case "asc":
+	case "desc":
+		c.desc = true
+	default:
+		return searchCursor{}, errBadCursor
+	}
+	if c.desc != (orderBy != "asc") {
+		return searchCursor{}, fmt.Errorf("cursor was created for order_by %q: %w", parts[0], coretypes.ErrInvalidRequest)
+	}
+
+	if c.height, err = strconv.ParseInt(parts[1], 10, 64); err != nil || c.height <= 0 {
+		return searchCursor{}, errBadCursor
+	}
+	index, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil {
+		return searchCursor{}, errBadCursor
+	}
+	c.index = uint32(index)
+
+	return c, nil
+}
+
+// follows reports whether the result at the given height and index comes
+// after the cursor in the order of the cursor.
+func (c searchCursor) follows(height int64, index uint32) bool {
+	if c.desc {
+		return height < c.height || (height == c.height && index < c.index)
+	}
+	return height > c.height || (height == c.height && index > c.index)
+}
+
+// narrow adds a condition on the height key to the query q, so that the
+// indexer does not need to return the results preceding the cursor. q is
+// parenthesized for the condition to apply to all its OR clauses.
+func (c searchCursor) narrow(q, heightKey string) (*tmquery.Query, error) {
+	op := ">="
+	if c.desc {
+		op = "<="
+	}
+	return tmquery.New(fmt.Sprintf("(%s) AND %s %s %d", q, heightKey, op, c.height))
+}
+
+// searchWithCursor reports whether a search uses cursor pagination, checking
+// that it is not mixed with page based pagination.
+func searchWithCursor(pagePtr *int, cursor string, limitPtr *int) (bool, error) {
+	if cursor == "" && limitPtr == nil {
+		return false, nil
+	}
+	if pagePtr != nil {
+		return false, fmt.Errorf("page cannot be used together with cursor or limit: %w", coretypes.ErrInvalidRequest)
+	}
+	return true, nil
+}
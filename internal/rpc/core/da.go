@@ -0,0 +1,235 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/da"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// NamespacedData gets the shares of the namespace namespaceID in the block at
+// the given height, along with the NMT proofs of their inclusion, or of their
+// absence, against the row roots of the data availability header of the
+// block. If no height is provided, it will fetch the shares of the latest
+// block.
+//
+// The proofs can be checked with da.VerifyNamespaceProofs, so that a client
+// only downloads the data of its namespace, and not the whole block.
+// More: https://docs.tendermint.com/master/rpc/#/Info/namespaced_data
+func (env *Environment) NamespacedData(
+	ctx *rpctypes.Context,
+	heightPtr *int64,
+	namespaceID bytes.HexBytes,
+) (*coretypes.ResultNamespacedData, error) {
+	if len(namespaceID) != consts.NamespaceSize {
+		return nil, fmt.Errorf("namespace_id must be %d bytes, got %d: %w",
+			consts.NamespaceSize, len(namespaceID), coretypes.ErrInvalidRequest)
+	}
+	height, eds, err := env.loadExtendedDataSquare(heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	proofs, err := da.ProveNamespace(eds, namespace.ID(namespaceID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &coretypes.ResultNamespacedData{
+		Height:                 height,
+		NamespaceID:            namespaceID,
+		DataAvailabilityHeader: da.NewDataAvailabilityHeader(eds),
+		Proofs:                 proofs,
+	}, nil
+}
+
+// Blob gets the blob of the namespace namespaceID whose share commitment is
+// commitment in the block at the given height, along with the NMT proofs of
+// the shares of the namespace, among which the blob is laid out, against the
+// row roots of the data availability header of the block. If no height is
+// provided, it will fetch the blob of the latest block.
+// More: https://docs.tendermint.com/master/rpc/#/Info/blob
+func (env *Environment) Blob(
+	ctx *rpctypes.Context,
+	heightPtr *int64,
+	namespaceID bytes.HexBytes,
+	commitment bytes.HexBytes,
+) (*coretypes.ResultBlob, error) {
+	if len(namespaceID) != consts.NamespaceSize {
+		return nil, fmt.Errorf("namespace must be %d bytes, got %d: %w",
+			consts.NamespaceSize, len(namespaceID), coretypes.ErrInvalidRequest)
+	}
+	height, block, err := env.loadBlock(heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	blob, ok := block.Data.FindBlob(namespaceID, commitment)
+	if !ok {
+		return nil, fmt.Errorf("no blob of namespace %X with the share commitment %X at height %d",
+			namespaceID, commitment, height)
+	}
+	eds, err := extendedDataSquare(&block.Data)
+	if err != nil {
+		return nil, err
+	}
+	proofs, err := da.ProveNamespace(eds, namespace.ID(namespaceID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &coretypes.ResultBlob{
+		Height:                 height,
+		NamespaceID:            namespaceID,
+		Commitment:             commitment,
+		Data:                   blob.Data,
+		DataAvailabilityHeader: da.NewDataAvailabilityHeader(eds),
+		Proofs:                 proofs,
+	}, nil
+}
+
+// DataAvailabilityHeader gets the data availability header of the block at
+// the given height, whose hash is the DataHash of the block header. If no
+// height is provided, it will fetch the one of the latest block.
+// More: https://docs.tendermint.com/master/rpc/#/Info/data_availability_header
+func (env *Environment) DataAvailabilityHeader(
+	ctx *rpctypes.Context,
+	heightPtr *int64,
+) (*coretypes.ResultDataAvailabilityHeader, error) {
+	height, eds, err := env.loadExtendedDataSquare(heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coretypes.ResultDataAvailabilityHeader{
+		Height:                 height,
+		DataAvailabilityHeader: da.NewDataAvailabilityHeader(eds),
+	}, nil
+}
+
+// Share gets the share at the coordinates (row, col) of the extended data
+// square of the block at the given height, along with the proofs of its
+// inclusion against the row and column roots of the data availability header
+// of the block. If no height is provided, it will fetch the share of the
+// latest block.
+//
+// It is what light clients sample to verify the availability of the data of a
+// block without downloading it, see da.ShareProof.
+// More: https://docs.tendermint.com/master/rpc/#/Info/share
+func (env *Environment) Share(
+	ctx *rpctypes.Context,
+	heightPtr *int64,
+	row, col uint64,
+) (*coretypes.ResultShare, error) {
+	height, eds, err := env.loadExtendedDataSquare(heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	if width := uint64(eds.Width()); row >= width || col >= width {
+		return nil, fmt.Errorf("coordinates (%d, %d) out of the %dx%d square: %w",
+			row, col, width, width, coretypes.ErrInvalidRequest)
+	}
+	proof, err := da.ProveShare(eds, row, col)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coretypes.ResultShare{
+		Height: height,
+		Proof:  proof,
+	}, nil
+}
+
+// ProveTxInclusion gets the proof of the inclusion of the tx of the given
+// hash in the data root of its block, the DataHash of the block header:
+// through the shares the tx is laid out in, to the roots of their rows, to the
+// data root. Unlike the proof of /tx over the flat tx list, it can be verified
+// by a client only following the data roots of the blocks, e.g. a rollup
+// bridge, see da.TxInclusionProof. If no height is provided, the tx is looked
+// up in the tx index.
+// More: https://docs.tendermint.com/master/rpc/#/Info/prove_tx_inclusion
+func (env *Environment) ProveTxInclusion(
+	ctx *rpctypes.Context,
+	heightPtr *int64,
+	txHash bytes.HexBytes,
+) (*coretypes.ResultTxInclusionProof, error) {
+	if heightPtr == nil {
+		res, err := env.Tx(ctx, txHash, false)
+		if err != nil {
+			return nil, err
+		}
+		heightPtr = &res.Height
+	}
+	height, block, err := env.loadBlock(heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	if block.Version.Block < types.DataAvailabilityHeaderBlockVersion {
+		return nil, fmt.Errorf("the data hash of block protocol version %d at height %d is not a data root",
+			block.Version.Block, height)
+	}
+	index := block.Data.Txs.IndexByHash(txHash)
+	if index < 0 {
+		return nil, fmt.Errorf("%w: tx (%X) at height %d", coretypes.ErrTxNotFound, txHash, height)
+	}
+	eds, err := extendedDataSquare(&block.Data)
+	if err != nil {
+		return nil, err
+	}
+	start, end, offset := block.Data.Txs.ShareRange(index)
+	proof, err := da.ProveTxInclusion(eds, start, end, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coretypes.ResultTxInclusionProof{
+		Height:   height,
+		Hash:     txHash,
+		Tx:       block.Data.Txs[index],
+		DataRoot: block.DataHash,
+		Proof:    proof,
+	}, nil
+}
+
+// loadExtendedDataSquare returns the extended data square of the block at the
+// given height, or of the latest block if no height is provided.
+func (env *Environment) loadExtendedDataSquare(heightPtr *int64) (int64, *rsmt2d.ExtendedDataSquare, error) {
+	height, block, err := env.loadBlock(heightPtr)
+	if err != nil {
+		return 0, nil, err
+	}
+	eds, err := extendedDataSquare(&block.Data)
+	if err != nil {
+		return 0, nil, err
+	}
+	return height, eds, nil
+}
+
+// loadBlock returns the block at the given height, or the latest block if no
+// height is provided.
+func (env *Environment) loadBlock(heightPtr *int64) (int64, *types.Block, error) {
+	height, err := env.getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	block := env.BlockStore.LoadBlock(height)
+	if block == nil {
+		return 0, nil, fmt.Errorf("block at height %d not found", height)
+	}
+	return height, block, nil
+}
+
+// extendedDataSquare returns the extended data square of the block data, the
+// one from which the DataHash of the block header is computed.
+func extendedDataSquare(data *types.Data) (*rsmt2d.ExtendedDataSquare, error) {
+	shares, _ := data.ComputeShares()
+	squareSize := uint64(math.Sqrt(float64(len(shares))))
+	return da.ExtendShares(squareSize, shares.RawShares())
+}
@@ -1,6 +1,13 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
@@ -10,3 +17,146 @@ func (env *Environment) UnsafeFlushMempool(ctx *rpctypes.Context) (*coretypes.Re
 	env.Mempool.Flush()
 	return &coretypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeDrain puts the RPC server in drain mode before a restart: /health
+// fails so that load balancers stop sending requests to the node, and new
+// subscriptions are refused. The node waits for the requests in flight when it
+// stops. Only allowed from localhost.
+func (env *Environment) UnsafeDrain(ctx *rpctypes.Context) (*coretypes.ResultUnsafeDrain, error) {
+	if err := checkLocalhost(ctx); err != nil {
+		return nil, err
+	}
+	if env.Drainer == nil {
+		return nil, errors.New("the RPC server can't be drained")
+	}
+	env.Drainer.Drain()
+	env.Logger.Info("draining RPC server")
+	return &coretypes.ResultUnsafeDrain{}, nil
+}
+
+// UnsafeDebugBundle saves a tar.gz file of debugging data for a support
+// ticket on the node host: the goroutine, heap and block profiles, the
+// consensus state, the end of the consensus WAL, the config without passwords
+// and the net info. Only allowed from localhost.
+func (env *Environment) UnsafeDebugBundle(ctx *rpctypes.Context) (*coretypes.ResultUnsafeDebugBundle, error) {
+	if err := checkLocalhost(ctx); err != nil {
+		return nil, err
+	}
+	if env.DebugBundler == nil {
+		return nil, errors.New("the node can't save debug bundles")
+	}
+	file, err := env.DebugBundler()
+	if err != nil {
+		return nil, fmt.Errorf("failed to save debug bundle: %w", err)
+	}
+	return &coretypes.ResultUnsafeDebugBundle{File: file}, nil
+}
+
+// UnsafeSetLogLevel changes the log level of the node until it is restarted.
+// Only allowed from localhost.
+func (env *Environment) UnsafeSetLogLevel(
+	ctx *rpctypes.Context,
+	level string,
+) (*coretypes.ResultUnsafeSetLogLevel, error) {
+	if err := checkLocalhost(ctx); err != nil {
+		return nil, err
+	}
+	if err := log.SetLevel(env.Logger, level); err != nil {
+		return nil, fmt.Errorf("%w: %v", coretypes.ErrInvalidRequest, err)
+	}
+	env.Logger.Info("changed log level", "level", level)
+	return &coretypes.ResultUnsafeSetLogLevel{LogLevel: level}, nil
+}
+
+// UnsafeReloadConfig reloads the config of the node, as ReloadConfig does.
+// Only allowed from localhost.
+func (env *Environment) UnsafeReloadConfig(ctx *rpctypes.Context) (*coretypes.ResultUnsafeReloadConfig, error) {
+	if err := checkLocalhost(ctx); err != nil {
+		return nil, err
+	}
+	return env.ReloadConfig()
+}
+
+// ReloadConfig loads the config of the node again with ConfigLoader, and
+// applies the settings which can change while the node runs: the log level,
+// the size limits of the mempool and the broadcast_tx_commit timeout, which
+// can't be raised above the value the node started with, and those
+// ConfigReloader applies. Other settings require a restart.
+func (env *Environment) ReloadConfig() (*coretypes.ResultUnsafeReloadConfig, error) {
+	if env.ConfigLoader == nil {
+		return nil, errors.New("the config can't be reloaded")
+	}
+	// the loader and the reloader aren't expected to be safe for concurrent
+	// use, and the settings are applied as a whole
+	env.reloadMtx.Lock()
+	defer env.reloadMtx.Unlock()
+
+	cfg, err := env.ConfigLoader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("error in config: %w", err)
+	}
+
+	timeout := cfg.RPC.TimeoutBroadcastTxCommit
+	if max := env.Config.TimeoutBroadcastTxCommit; max > 0 && (timeout == 0 || timeout > max) {
+		return nil, fmt.Errorf("timeout-broadcast-tx-commit can't be raised above %v without a restart", max)
+	}
+	var applied, requiresRestart []string
+	if env.ConfigReloader != nil {
+		applied, requiresRestart, err = env.ConfigReloader(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reload config: %w", err)
+		}
+	}
+	if err := log.SetLevel(env.Logger, cfg.LogLevel); err != nil {
+		return nil, err
+	}
+	env.Mempool.SetLimits(cfg.Mempool.Size, cfg.Mempool.MaxTxsBytes)
+	atomic.StoreInt64(&env.timeoutBroadcastTxCommit, int64(timeout))
+
+	env.Logger.Info("reloaded config",
+		"log-level", cfg.LogLevel,
+		"mempool-size", cfg.Mempool.Size,
+		"mempool-max-txs-bytes", cfg.Mempool.MaxTxsBytes,
+		"timeout-broadcast-tx-commit", timeout,
+		"applied", applied,
+		"requires-restart", requiresRestart)
+
+	return &coretypes.ResultUnsafeReloadConfig{
+		LogLevel:                 cfg.LogLevel,
+		MempoolSize:              cfg.Mempool.Size,
+		MempoolMaxTxsBytes:       cfg.Mempool.MaxTxsBytes,
+		TimeoutBroadcastTxCommit: timeout,
+		Applied:                  applied,
+		RequiresRestart:          requiresRestart,
+	}, nil
+}
+
+// broadcastTxCommitTimeout returns how long /broadcast_tx_commit waits for a
+// tx to be committed, 0 meaning as long as the request lasts.
+func (env *Environment) broadcastTxCommitTimeout() time.Duration {
+	if timeout := atomic.LoadInt64(&env.timeoutBroadcastTxCommit); timeout > 0 {
+		return time.Duration(timeout)
+	}
+	return env.Config.TimeoutBroadcastTxCommit
+}
+
+// checkLocalhost returns an error unless the request comes from the host of
+// the node. Requests without a remote address, made in process or over a unix
+// socket, are allowed.
+func checkLocalhost(ctx *rpctypes.Context) error {
+	addr := ctx.RemoteAddr()
+	if addr == "" || addr == "@" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("%w: only allowed from localhost", coretypes.ErrInvalidRequest)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/mempool/mock"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+type limitsMempool struct {
+	mock.Mempool
+
+	size        int
+	maxTxsBytes int64
+}
+
+func (mp *limitsMempool) SetLimits(size int, maxTxsBytes int64) {
+	mp.size, mp.maxTxsBytes = size, maxTxsBytes
+}
+
+func TestUnsafeReloadConfig(t *testing.T) {
+	cfg := config.ResetTestRoot("rpc_reload_config")
+	t.Cleanup(func() { os.RemoveAll(cfg.RootDir) })
+
+	mp := &limitsMempool{}
+	env := &Environment{
+		Logger:  log.MustNewDefaultLogger(log.LogFormatJSON, log.LogLevelInfo, false),
+		Mempool: mp,
+		Config:  *cfg.RPC,
+	}
+	_, err := env.ReloadConfig()
+	assert.Error(t, err)
+
+	loaded := 0
+	env.ConfigLoader = func() (*config.Config, error) {
+		loaded++
+		return cfg, nil
+	}
+	assert.Equal(t, cfg.RPC.TimeoutBroadcastTxCommit, env.broadcastTxCommitTimeout())
+
+	cfg.LogLevel = log.LogLevelDebug
+	cfg.Mempool.Size = 42
+	cfg.RPC.TimeoutBroadcastTxCommit = time.Second
+
+	res, err := env.UnsafeReloadConfig(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, log.LogLevelDebug, res.LogLevel)
+	assert.Equal(t, 42, mp.size)
+	assert.Equal(t, cfg.Mempool.MaxTxsBytes, mp.maxTxsBytes)
+	assert.Equal(t, time.Second, env.broadcastTxCommitTimeout())
+
+	// the timeout can't be raised
+	cfg.RPC.TimeoutBroadcastTxCommit = time.Hour
+	_, err = env.ReloadConfig()
+	assert.Error(t, err)
+	assert.Equal(t, time.Second, env.broadcastTxCommitTimeout())
+
+	// only from localhost
+	_, err = env.UnsafeReloadConfig(&rpctypes.Context{HTTPReq: &http.Request{RemoteAddr: "10.0.0.1:1234"}})
+	assert.Error(t, err)
+	assert.Equal(t, 2, loaded)
+}
+
+func TestCheckLocalhost(t *testing.T) {
+	testCases := []struct {
+		remoteAddr string
+		ok         bool
+	}{
+		{"", true},
+		{"127.0.0.1:26657", true},
+		{"[::1]:26657", true},
+		{"10.0.0.1:26657", false},
+		{"example.com:26657", false},
+	}
+	for _, tc := range testCases {
+		ctx := &rpctypes.Context{HTTPReq: &http.Request{RemoteAddr: tc.remoteAddr}}
+		err := checkLocalhost(ctx)
+		assert.Equal(t, tc.ok, err == nil, tc.remoteAddr)
+	}
+}
+
+func TestUnsafeDrain(t *testing.T) {
+	env := &Environment{Logger: log.TestingLogger()}
+	_, err := env.UnsafeDrain(&rpctypes.Context{})
+	assert.Error(t, err)
+
+	env.Drainer = rpcserver.NewDrainer()
+	_, err = env.Health(&rpctypes.Context{}, "")
+	require.NoError(t, err)
+
+	_, err = env.UnsafeDrain(&rpctypes.Context{})
+	require.NoError(t, err)
+
+	_, err = env.Health(&rpctypes.Context{}, "")
+	assert.ErrorIs(t, err, coretypes.ErrDraining)
+	_, err = env.Subscribe(&rpctypes.Context{}, "tm.event = 'NewBlock'", "")
+	assert.ErrorIs(t, err, coretypes.ErrDraining)
+}
+
+func TestUnsafeDebugBundle(t *testing.T) {
+	env := &Environment{Logger: log.TestingLogger()}
+	_, err := env.UnsafeDebugBundle(&rpctypes.Context{})
+	assert.Error(t, err)
+
+	env.DebugBundler = func() (string, error) { return "/tmp/bundle.tar.gz", nil }
+	res, err := env.UnsafeDebugBundle(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/bundle.tar.gz", res.File)
+
+	_, err = env.UnsafeDebugBundle(&rpctypes.Context{HTTPReq: &http.Request{RemoteAddr: "10.0.0.1:1234"}})
+	assert.ErrorIs(t, err, coretypes.ErrInvalidRequest)
+}
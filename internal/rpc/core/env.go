@@ -3,10 +3,12 @@ package core
 import (
 	"encoding/base64"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/internal/consensus"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/p2p"
@@ -17,6 +19,7 @@ import (
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -31,7 +34,7 @@ const (
 
 	// genesisChunkSize is the maximum size, in bytes, of each
 	// chunk in the genesis structure for the chunked API
-	genesisChunkSize = 16 * 1024 * 1024 // 16
+	genesisChunkSize = sm.GenesisChunkSize
 )
 
 //----------------------------------------------
@@ -43,6 +46,11 @@ type consensusState interface {
 	GetLastHeight() int64
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
+	GetRoundInfo() (height int64, round int32, step string, proposer types.Address)
+}
+
+type blockExecutor interface {
+	MismatchReport() *sm.MismatchReport
 }
 
 type transport interface {
@@ -69,6 +77,15 @@ type peerManager interface {
 	Addresses(types.NodeID) []p2p.NodeAddress
 }
 
+type indexerService interface {
+	Lag() int
+}
+
+type genesisStore interface {
+	NumChunks() (int, error)
+	LoadChunk(int) ([]byte, error)
+}
+
 //----------------------------------------------
 // Environment contains objects and interfaces used by the RPC. It is expected
 // to be setup once during startup.
@@ -82,6 +99,7 @@ type Environment struct {
 	BlockStore       sm.BlockStore
 	EvidencePool     sm.EvidencePool
 	ConsensusState   consensusState
+	BlockExecutor    blockExecutor
 	ConsensusReactor consensusReactor
 	P2PPeers         peers
 
@@ -93,8 +111,11 @@ type Environment struct {
 
 	// objects
 	PubKey            crypto.PubKey
+	PrivValidator     types.PrivValidator
 	GenDoc            *types.GenesisDoc // cache the genesis structure
+	GenesisStore      genesisStore      // serves the genesis chunks, if set
 	EventSinks        []indexer.EventSink
+	IndexerService    indexerService
 	EventBus          *types.EventBus // thread safe
 	Mempool           mempool.Mempool
 	BlockSyncReactor  consensus.BlockSyncReactor
@@ -104,8 +125,45 @@ type Environment struct {
 
 	Config config.RPCConfig
 
-	// cache of chunked genesis data.
-	genChunks []string
+	// ConsensusConfig provides the consensus timeouts reported by /status,
+	// if set.
+	ConsensusConfig *config.ConsensusConfig
+
+	// ProofRuntime decodes the proof ops of the queries at past heights, to
+	// verify them against the app hash of the height, if set. The proofs of
+	// op types it doesn't know are returned unverified.
+	ProofRuntime *merkle.ProofRuntime
+
+	// ConfigLoader loads the config of the node again, for
+	// /unsafe_reload_config.
+	ConfigLoader func() (*config.Config, error)
+
+	// ConfigReloader applies the reloaded config to the rest of the node, if
+	// set, and returns the changed settings it applied and those which
+	// require a restart.
+	ConfigReloader func(*config.Config) (applied, requiresRestart []string, err error)
+
+	// DebugBundler saves a bundle of debugging data, and returns the path of
+	// the file.
+	DebugBundler func() (string, error)
+
+	// Drainer is put in drain mode by /unsafe_drain.
+	Drainer *rpcserver.Drainer
+
+	// serializes the reloads of the config
+	reloadMtx sync.Mutex
+
+	// overrides Config.TimeoutBroadcastTxCommit once the config is reloaded,
+	// atomic
+	timeoutBroadcastTxCommit int64
+
+	// cache of chunked genesis data, or number of chunks of the
+	// GenesisStore.
+	genChunks    []string
+	numGenChunks int
+
+	// cache of the responses which cannot change anymore
+	responseCache *responseCache
 }
 
 //----------------------------------------------
@@ -151,7 +209,17 @@ func (env *Environment) validatePerPage(perPagePtr *int) int {
 // InitGenesisChunks configures the environment and should be called on service
 // startup.
 func (env *Environment) InitGenesisChunks() error {
-	if env.genChunks != nil {
+	if env.genChunks != nil || env.numGenChunks > 0 {
+		return nil
+	}
+
+	// the genesis doc, which can be large, isn't held in memory
+	if env.GenesisStore != nil {
+		n, err := env.GenesisStore.NumChunks()
+		if err != nil {
+			return err
+		}
+		env.numGenChunks = n
 		return nil
 	}
 
@@ -177,6 +245,14 @@ func (env *Environment) InitGenesisChunks() error {
 	return nil
 }
 
+// InitResponseCache sets up the cache of immutable responses, using the
+// memory budget of the RPC config. It should be called on service startup.
+func (env *Environment) InitResponseCache() {
+	if env.responseCache == nil {
+		env.responseCache = newResponseCache(env.Config.ResponseCacheBytes)
+	}
+}
+
 func validateSkipCount(page, perPage int) int {
 	skipCount := (page - 1) * perPage
 	if skipCount < 0 {
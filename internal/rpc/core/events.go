@@ -11,20 +11,21 @@ import (
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
-const (
-	// Buffer on the Tendermint (server) side to allow some slowness in clients.
-	subBufferSize = 100
-)
-
 // Subscribe for events via WebSocket.
+//
+// Each event carries an ID. After a reconnection, passing the ID of the last
+// event received as last_event_id first sends the events which were missed,
+// as long as they are still buffered by the node.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/subscribe
-func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*coretypes.ResultSubscribe, error) {
+func (env *Environment) Subscribe(
+	ctx *rpctypes.Context,
+	query string,
+	lastEventID string,
+) (*coretypes.ResultSubscribe, error) {
 	addr := ctx.RemoteAddr()
 
-	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
-		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
-	} else if env.EventBus.NumClientSubscriptions(addr) >= env.Config.MaxSubscriptionsPerClient {
-		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+	if err := env.checkSubscriptionLimits(addr); err != nil {
+		return nil, err
 	}
 
 	env.Logger.Info("Subscribe to query", "remote", addr, "query", query)
@@ -33,11 +34,27 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*coretyp
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse query: %w", err)
 	}
+	overflow, err := tmpubsub.ParseOverflow(env.Config.SubscriptionOverflow)
+	if err != nil {
+		return nil, err
+	}
 
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
 
-	sub, err := env.EventBus.Subscribe(subCtx, addr, q, subBufferSize)
+	// The buffer on the Tendermint (server) side allows some slowness in
+	// clients, and leaves room for the missed events when resuming.
+	args := tmpubsub.SubscribeArgs{
+		ClientID:    addr,
+		Query:       q,
+		Capacity:    env.Config.SubscriptionBufferSize,
+		Overflow:    overflow,
+		LastEventID: lastEventID,
+	}
+	if lastEventID != "" {
+		args.Capacity += env.Config.EventHistorySize
+	}
+	sub, err := env.EventBus.SubscribeWithArgs(subCtx, args)
 	if err != nil {
 		return nil, err
 	}
@@ -49,12 +66,18 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*coretyp
 			select {
 			case msg := <-sub.Out():
 				var (
-					resultEvent = &coretypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}
-					resp        = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
+					resultEvent = &coretypes.ResultEvent{
+						Query:   query,
+						EventID: msg.EventID(),
+						Data:    msg.Data(),
+						Events:  msg.Events(),
+					}
+					resp = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
 				)
 				writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-				defer cancel()
-				if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
+				err := ctx.WSConn.WriteRPCResponse(writeCtx, resp)
+				cancel()
+				if err != nil {
 					env.Logger.Info("Can't write response (slow client)",
 						"to", addr, "subscriptionID", subscriptionID, "err", err)
 				}
@@ -83,6 +106,19 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*coretyp
 	return &coretypes.ResultSubscribe{}, nil
 }
 
+// checkSubscriptionLimits returns an error if the client can't subscribe,
+// the node being drained or the subscription limits reached.
+func (env *Environment) checkSubscriptionLimits(addr string) error {
+	if env.Drainer.Draining() {
+		return fmt.Errorf("%w: no new subscriptions", coretypes.ErrDraining)
+	} else if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
+		return fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+	} else if env.EventBus.NumClientSubscriptions(addr) >= env.Config.MaxSubscriptionsPerClient {
+		return fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+	}
+	return nil
+}
+
 // Unsubscribe from events via WebSocket.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/unsubscribe
 func (env *Environment) Unsubscribe(ctx *rpctypes.Context, query string) (*coretypes.ResultUnsubscribe, error) {
@@ -1,13 +1,176 @@
 package core
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
-// Health gets node health. Returns empty result (200 OK) on success, no
-// response - in case of an error.
+const (
+	// HealthProbeLiveness fails once the node is unhealthy, i.e. a restart
+	// may be needed.
+	HealthProbeLiveness = "liveness"
+	// HealthProbeReadiness fails as long as the node can't serve up to date
+	// data: while it catches up, is drained or is unhealthy.
+	HealthProbeReadiness = "readiness"
+
+	// mempoolSaturation is the fill ratio of the mempool above which it is
+	// degraded.
+	mempoolSaturation = 0.9
+)
+
+// Health gets node health: the state of each subsystem (consensus, mempool,
+// p2p, privval, indexer), the overall state (healthy, degraded or unhealthy)
+// and whether the node is live and ready.
+//
+// The probe parameter makes /health fail (500) for the liveness and readiness
+// probes of kubernetes: "liveness" fails once the node is unhealthy,
+// "readiness" fails as long as the node is not ready. Without a probe, /health
+// only fails with ErrDraining once the RPC server is drained, so that load
+// balancers stop sending requests to the node.
 // More: https://docs.tendermint.com/master/rpc/#/Info/health
-func (env *Environment) Health(ctx *rpctypes.Context) (*coretypes.ResultHealth, error) {
-	return &coretypes.ResultHealth{}, nil
+func (env *Environment) Health(ctx *rpctypes.Context, probe string) (*coretypes.ResultHealth, error) {
+	switch probe {
+	case "", HealthProbeLiveness, HealthProbeReadiness:
+	default:
+		return nil, fmt.Errorf("%w: unknown probe %q, expected %q or %q",
+			coretypes.ErrInvalidRequest, probe, HealthProbeLiveness, HealthProbeReadiness)
+	}
+
+	draining := env.Drainer.Draining()
+	result := env.health(draining)
+	switch {
+	case probe == HealthProbeLiveness && !result.Live:
+		return nil, fmt.Errorf("%w: %s", coretypes.ErrUnhealthy, failedChecks(result))
+	case probe != HealthProbeLiveness && draining:
+		return nil, coretypes.ErrDraining
+	case probe == HealthProbeReadiness && !result.Live:
+		return nil, fmt.Errorf("%w: %s", coretypes.ErrUnhealthy, failedChecks(result))
+	case probe == HealthProbeReadiness && !result.Ready:
+		return nil, fmt.Errorf("%w: %s", coretypes.ErrNotReady, failedChecks(result))
+	}
+	return result, nil
+}
+
+func (env *Environment) health(draining bool) *coretypes.ResultHealth {
+	result := &coretypes.ResultHealth{Status: coretypes.HealthStatusHealthy}
+	add := func(name string, status coretypes.HealthStatus, format string, args ...interface{}) {
+		result.Checks = append(result.Checks, coretypes.HealthCheck{
+			Name:    name,
+			Status:  status,
+			Message: fmt.Sprintf(format, args...),
+		})
+		if status == coretypes.HealthStatusUnhealthy ||
+			(status == coretypes.HealthStatusDegraded && result.Status == coretypes.HealthStatusHealthy) {
+			result.Status = status
+		}
+	}
+
+	catchingUp := false
+	if env.ConsensusReactor != nil && env.BlockStore != nil {
+		height := env.BlockStore.Height()
+		var mismatch *sm.MismatchReport
+		if env.BlockExecutor != nil {
+			mismatch = env.BlockExecutor.MismatchReport()
+		}
+		switch {
+		case mismatch != nil:
+			add("consensus", coretypes.HealthStatusUnhealthy, "halted by an app hash mismatch at height %d, see /mismatch_report",
+				mismatch.Height)
+		case env.ConsensusReactor.WaitSync():
+			catchingUp = true
+			add("consensus", coretypes.HealthStatusDegraded, "catching up at height %d", height)
+		case height == 0:
+			add("consensus", coretypes.HealthStatusHealthy, "waiting for the first block")
+		default:
+			maxInterval := env.Config.HealthMaxBlockInterval
+			meta := env.BlockStore.LoadBlockMeta(height)
+			if meta != nil && maxInterval > 0 {
+				if since := time.Since(meta.Header.Time); since > maxInterval {
+					add("consensus", coretypes.HealthStatusUnhealthy, "no block for %v since height %d",
+						since.Truncate(time.Second), height)
+					break
+				}
+			}
+			add("consensus", coretypes.HealthStatusHealthy, "height %d", height)
+		}
+	}
+
+	if env.Mempool != nil {
+		maxTxs, maxTxsBytes := env.Mempool.Limits()
+		size, sizeBytes := env.Mempool.Size(), env.Mempool.SizeBytes()
+		var fill float64
+		if maxTxs > 0 {
+			fill = float64(size) / float64(maxTxs)
+		}
+		if maxTxsBytes > 0 && float64(sizeBytes)/float64(maxTxsBytes) > fill {
+			fill = float64(sizeBytes) / float64(maxTxsBytes)
+		}
+		status := coretypes.HealthStatusHealthy
+		if fill >= mempoolSaturation {
+			status = coretypes.HealthStatusDegraded
+		}
+		add("mempool", status, "%d txs (%d bytes), %.0f%% full", size, sizeBytes, fill*100)
+	}
+
+	if peers, ok := env.numPeers(); ok {
+		status := coretypes.HealthStatusHealthy
+		if peers < env.Config.HealthTargetPeers {
+			status = coretypes.HealthStatusDegraded
+		}
+		add("p2p", status, "%d peers, target %d", peers, env.Config.HealthTargetPeers)
+	}
+
+	if env.PrivValidator != nil {
+		if conn, ok := env.PrivValidator.(interface{ IsConnected() bool }); ok && !conn.IsConnected() {
+			add("privval", coretypes.HealthStatusDegraded, "remote signer not connected")
+		} else {
+			add("privval", coretypes.HealthStatusHealthy, "")
+		}
+	}
+
+	if env.IndexerService != nil && indexer.IndexingEnabled(env.EventSinks) {
+		lag := env.IndexerService.Lag()
+		switch {
+		case lag >= indexer.QueueSize:
+			add("indexer", coretypes.HealthStatusDegraded, "%d blocks behind, dropping blocks", lag)
+		case lag > indexer.QueueSize/2:
+			add("indexer", coretypes.HealthStatusDegraded, "%d blocks behind", lag)
+		default:
+			add("indexer", coretypes.HealthStatusHealthy, "%d blocks behind", lag)
+		}
+	}
+
+	result.Live = result.Status != coretypes.HealthStatusUnhealthy
+	result.Ready = result.Live && !catchingUp && !draining
+	return result
+}
+
+// numPeers returns the number of connected peers, if the peer management
+// system supports it.
+func (env *Environment) numPeers() (int, bool) {
+	switch {
+	case env.P2PPeers != nil:
+		return env.P2PPeers.Peers().Size(), true
+	case env.PeerManager != nil:
+		return len(env.PeerManager.Peers()), true
+	default:
+		return 0, false
+	}
+}
+
+// failedChecks describes the checks which are not healthy.
+func failedChecks(result *coretypes.ResultHealth) string {
+	failed := make([]string, 0, len(result.Checks))
+	for _, check := range result.Checks {
+		if check.Status != coretypes.HealthStatusHealthy {
+			failed = append(failed, fmt.Sprintf("%s %s (%s)", check.Name, check.Status, check.Message))
+		}
+	}
+	return strings.Join(failed, ", ")
 }
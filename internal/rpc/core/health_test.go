@@ -0,0 +1,153 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/consensus"
+	"github.com/tendermint/tendermint/internal/mempool/mock"
+	"github.com/tendermint/tendermint/internal/p2p"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+type healthConsensusReactor struct {
+	waitSync bool
+}
+
+func (r healthConsensusReactor) WaitSync() bool { return r.waitSync }
+
+func (healthConsensusReactor) GetPeerState(types.NodeID) (*consensus.PeerState, bool) {
+	return nil, false
+}
+
+type healthMempool struct {
+	mock.Mempool
+
+	size int
+}
+
+func (mp healthMempool) Size() int            { return mp.size }
+func (mp healthMempool) Limits() (int, int64) { return 100, 0 }
+
+type healthPeerManager struct {
+	peers int
+}
+
+func (pm healthPeerManager) Peers() []types.NodeID {
+	return make([]types.NodeID, pm.peers)
+}
+
+func (healthPeerManager) Addresses(types.NodeID) []p2p.NodeAddress { return nil }
+
+type healthBlockExecutor struct {
+	report *sm.MismatchReport
+}
+
+func (e healthBlockExecutor) MismatchReport() *sm.MismatchReport { return e.report }
+
+func TestHealth(t *testing.T) {
+	testCases := []struct {
+		name         string
+		waitSync     bool
+		blockAge     time.Duration
+		mempoolSize  int
+		peers        int
+		draining     bool
+		status       coretypes.HealthStatus
+		liveErr      error
+		readinessErr error
+	}{
+		{"healthy", false, time.Second, 0, 2, false, coretypes.HealthStatusHealthy, nil, nil},
+		{"mempool saturated", false, time.Second, 95, 2, false, coretypes.HealthStatusDegraded, nil, nil},
+		{"no peers", false, time.Second, 0, 0, false, coretypes.HealthStatusDegraded, nil, nil},
+		{"catching up", true, time.Hour, 0, 2, false,
+			coretypes.HealthStatusDegraded, nil, coretypes.ErrNotReady},
+		{"consensus stalled", false, time.Hour, 0, 2, false,
+			coretypes.HealthStatusUnhealthy, coretypes.ErrUnhealthy, coretypes.ErrUnhealthy},
+		{"draining", false, time.Second, 0, 2, true,
+			coretypes.HealthStatusHealthy, nil, coretypes.ErrDraining},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			blockStore := &mocks.BlockStore{}
+			blockStore.On("Height").Return(int64(10))
+			blockStore.On("LoadBlockMeta", int64(10)).Return(&types.BlockMeta{
+				Header: types.Header{Height: 10, Time: time.Now().Add(-tc.blockAge)},
+			})
+			env := &Environment{
+				BlockStore:       blockStore,
+				ConsensusReactor: healthConsensusReactor{waitSync: tc.waitSync},
+				Mempool:          healthMempool{size: tc.mempoolSize},
+				PeerManager:      healthPeerManager{peers: tc.peers},
+				Config:           *config.DefaultRPCConfig(),
+				Drainer:          rpcserver.NewDrainer(),
+			}
+			if tc.draining {
+				env.Drainer.Drain()
+			}
+
+			if !tc.draining {
+				result, err := env.Health(&rpctypes.Context{}, "")
+				require.NoError(t, err)
+				assert.Equal(t, tc.status, result.Status)
+				assert.Equal(t, tc.liveErr == nil, result.Live)
+				assert.Equal(t, tc.readinessErr == nil, result.Ready)
+				assert.Len(t, result.Checks, 3)
+			}
+
+			_, err := env.Health(&rpctypes.Context{}, HealthProbeLiveness)
+			if tc.liveErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.liveErr)
+			}
+			_, err = env.Health(&rpctypes.Context{}, HealthProbeReadiness)
+			if tc.readinessErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.readinessErr)
+			}
+		})
+	}
+
+	env := &Environment{}
+	_, err := env.Health(&rpctypes.Context{}, "startup")
+	assert.ErrorIs(t, err, coretypes.ErrInvalidRequest)
+}
+
+func TestHealthMismatch(t *testing.T) {
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(int64(10))
+	blockExec := &healthBlockExecutor{}
+	env := &Environment{
+		BlockStore:       blockStore,
+		BlockExecutor:    blockExec,
+		ConsensusReactor: healthConsensusReactor{},
+		Drainer:          rpcserver.NewDrainer(),
+	}
+
+	report, err := env.MismatchReport(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.False(t, report.Mismatch)
+
+	// the node is unhealthy once the consensus is halted by a mismatch
+	blockExec.report = &sm.MismatchReport{Field: "AppHash", Height: 11, Expected: []byte{1}, Got: []byte{2}}
+	report, err = env.MismatchReport(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.True(t, report.Mismatch)
+	assert.EqualValues(t, 11, report.Height)
+	assert.EqualValues(t, []byte{1}, report.Expected)
+
+	_, err = env.Health(&rpctypes.Context{}, HealthProbeLiveness)
+	assert.ErrorIs(t, err, coretypes.ErrUnhealthy)
+}
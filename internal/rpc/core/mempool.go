@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -9,6 +10,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/state/indexer"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
@@ -72,6 +74,10 @@ func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*
 	}
 
 	r := (<-resCh).GetCheckTx()
+	if r.Code != abci.CodeTypeOK {
+		// the tx won't be committed, no need to wait for it
+		return nil, coretypes.AppError{Code: r.Code, Codespace: r.Codespace, Log: r.Log}
+	}
 
 	if !indexer.KVSinkEnabled(env.EventSinks) {
 		return &coretypes.ResultBroadcastTxCommit{
@@ -81,6 +87,13 @@ func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*
 			errors.New("cannot wait for commit because kvEventSync is not enabled")
 	}
 
+	waitCtx := ctx.Context()
+	if timeout := env.broadcastTxCommitTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(waitCtx, timeout)
+		defer cancel()
+	}
+
 	startAt := time.Now()
 	timer := time.NewTimer(0)
 	defer timer.Stop()
@@ -89,15 +102,15 @@ func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*
 	for {
 		count++
 		select {
-		case <-ctx.Context().Done():
+		case <-waitCtx.Done():
 			env.Logger.Error("Error on broadcastTxCommit",
 				"duration", time.Since(startAt),
 				"err", err)
 			return &coretypes.ResultBroadcastTxCommit{
 					CheckTx: *r,
 					Hash:    tx.Hash(),
-				}, fmt.Errorf("timeout waiting for commit of tx %s (%s)",
-					tx.Hash(), time.Since(startAt))
+				}, fmt.Errorf("%w waiting for commit of tx %s (%s)",
+					coretypes.ErrTimedOut, tx.Hash(), time.Since(startAt))
 		case <-timer.C:
 			txres, err := env.Tx(ctx, tx.Hash(), false)
 			if err != nil {
@@ -117,6 +130,99 @@ func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*
 	}
 }
 
+// BroadcastTxSubscribe returns with the response from CheckTx, and the ID of
+// a subscription delivering the DeliverTx result via WebSocket once the tx is
+// committed, as a Tx event. Unlike BroadcastTxCommit, the request is not held
+// while the tx waits to be committed. The subscription ends with the event,
+// or can be canceled with unsubscribe. It is not created if CheckTx fails.
+// More: https://docs.tendermint.com/master/rpc/#/Websocket/broadcast_tx_subscribe
+func (env *Environment) BroadcastTxSubscribe(
+	ctx *rpctypes.Context,
+	tx types.Tx,
+) (*coretypes.ResultBroadcastTxSubscribe, error) {
+	addr := ctx.RemoteAddr()
+	if err := env.checkSubscriptionLimits(addr); err != nil {
+		return nil, err
+	}
+
+	// subscribe before submitting the tx, so that its commit can't be missed
+	q := types.EventQueryTxFor(tx)
+	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
+	defer cancel()
+	sub, err := env.EventBus.SubscribeWithArgs(subCtx, tmpubsub.SubscribeArgs{
+		ClientID: addr,
+		Query:    q,
+		Capacity: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	unsubscribe := func() {
+		err := env.EventBus.Unsubscribe(context.Background(), tmpubsub.UnsubscribeArgs{
+			Subscriber: addr,
+			ID:         sub.ID(),
+		})
+		if err != nil && !errors.Is(err, tmpubsub.ErrSubscriptionNotFound) {
+			env.Logger.Error("failed to unsubscribe", "remote", addr, "query", q, "err", err)
+		}
+	}
+
+	resCh := make(chan *abci.Response, 1)
+	err = env.Mempool.CheckTx(
+		ctx.Context(),
+		tx,
+		func(res *abci.Response) { resCh <- res },
+		mempool.TxInfo{},
+	)
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	r := (<-resCh).GetCheckTx()
+	result := &coretypes.ResultBroadcastTxSubscribe{CheckTx: *r, Hash: tx.Hash()}
+	if r.Code != abci.CodeTypeOK {
+		// the tx won't be committed, no need to wait for it
+		unsubscribe()
+		return result, nil
+	}
+	result.SubscriptionID = sub.ID()
+
+	// Capture the current ID, since it can change in the future.
+	requestID := ctx.JSONReq.ID
+	go func() {
+		select {
+		case msg := <-sub.Out():
+			resp := rpctypes.NewRPCSuccessResponse(requestID, &coretypes.ResultEvent{
+				SubscriptionID: sub.ID(),
+				Query:          q.String(),
+				EventID:        msg.EventID(),
+				Data:           msg.Data(),
+				Events:         msg.Events(),
+			})
+			writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			err := ctx.WSConn.WriteRPCResponse(writeCtx, resp)
+			cancel()
+			if err != nil {
+				env.Logger.Info("Can't write response (slow client)",
+					"to", addr, "subscriptionID", sub.ID(), "err", err)
+			}
+			unsubscribe()
+		case <-sub.Canceled():
+			if err := sub.Err(); err != nil && err != tmpubsub.ErrUnsubscribed {
+				resp := rpctypes.RPCServerError(requestID,
+					fmt.Errorf("subscription was canceled (reason: %s)", err))
+				if ok := ctx.WSConn.TryWriteRPCResponse(resp); !ok {
+					env.Logger.Info("Can't write response (slow client)",
+						"to", addr, "subscriptionID", sub.ID(), "err", err)
+				}
+			}
+		}
+	}()
+
+	return result, nil
+}
+
 // UnconfirmedTxs gets unconfirmed transactions (maximum ?limit entries)
 // including their number.
 // More: https://docs.tendermint.com/master/rpc/#/Info/unconfirmed_txs
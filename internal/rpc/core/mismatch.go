@@ -0,0 +1,45 @@
+package core
+
+import (
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// MismatchReport gets the report of the last app hash mismatch of a committed
+// block since the node started, which halted the consensus: the app hash, or
+// the last results hash, of the block, the one the application computed, and
+// the txs and the ABCI responses of the block executed before. The report is
+// also written to the mismatch-report-file of the config, including when the
+// mismatch is found while replaying the blocks at startup.
+// More: https://docs.tendermint.com/master/rpc/#/Info/mismatch_report
+func (env *Environment) MismatchReport(ctx *rpctypes.Context) (*coretypes.ResultMismatchReport, error) {
+	if env.BlockExecutor == nil {
+		return &coretypes.ResultMismatchReport{}, nil
+	}
+	report := env.BlockExecutor.MismatchReport()
+	if report == nil {
+		return &coretypes.ResultMismatchReport{}, nil
+	}
+
+	res := &coretypes.ResultMismatchReport{
+		Mismatch: true,
+		Time:     report.Time,
+		Field:    report.Field,
+		Height:   report.Height,
+		Expected: report.Expected,
+		Got:      report.Got,
+		TxHashes: report.TxHashes,
+	}
+	if responses := report.ABCIResponses; responses != nil {
+		res.TxsResults = responses.DeliverTxs
+		if responses.BeginBlock != nil {
+			res.BeginBlockEvents = responses.BeginBlock.Events
+		}
+		if responses.EndBlock != nil {
+			res.EndBlockEvents = responses.EndBlock.Events
+			res.ValidatorUpdates = responses.EndBlock.ValidatorUpdates
+			res.ConsensusParamUpdates = responses.EndBlock.ConsensusParamUpdates
+		}
+	}
+	return res, nil
+}
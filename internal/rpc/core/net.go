@@ -1,13 +1,16 @@
 package core
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/tendermint/tendermint/internal/p2p"
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 // NetInfo returns network info.
@@ -117,32 +120,60 @@ func (env *Environment) UnsafeDialPeers(
 // Genesis returns genesis file.
 // More: https://docs.tendermint.com/master/rpc/#/Info/genesis
 func (env *Environment) Genesis(ctx *rpctypes.Context) (*coretypes.ResultGenesis, error) {
-	if len(env.genChunks) > 1 {
+	if len(env.genChunks) > 1 || env.numGenChunks > 1 {
 		return nil, errors.New("genesis response is large, please use the genesis_chunked API instead")
 	}
 
+	if env.GenesisStore != nil {
+		// GenDoc doesn't hold the app_state
+		bz, err := env.GenesisStore.LoadChunk(0)
+		if err != nil {
+			return nil, err
+		}
+		genDoc := &types.GenesisDoc{}
+		if err := tmjson.Unmarshal(bz, genDoc); err != nil {
+			return nil, err
+		}
+		return &coretypes.ResultGenesis{Genesis: genDoc}, nil
+	}
+
 	return &coretypes.ResultGenesis{Genesis: env.GenDoc}, nil
 }
 
 func (env *Environment) GenesisChunked(ctx *rpctypes.Context, chunk uint) (*coretypes.ResultGenesisChunk, error) {
-	if env.genChunks == nil {
-		return nil, fmt.Errorf("service configuration error, genesis chunks are not initialized")
+	total := env.numGenChunks
+	if env.GenesisStore == nil {
+		if env.genChunks == nil {
+			return nil, fmt.Errorf("service configuration error, genesis chunks are not initialized")
+		}
+		total = len(env.genChunks)
 	}
 
-	if len(env.genChunks) == 0 {
+	if total == 0 {
 		return nil, fmt.Errorf("service configuration error, there are no chunks")
 	}
 
 	id := int(chunk)
 
-	if id > len(env.genChunks)-1 {
-		return nil, fmt.Errorf("there are %d chunks, %d is invalid", len(env.genChunks)-1, id)
+	if id > total-1 {
+		return nil, fmt.Errorf("there are %d chunks, %d is invalid", total-1, id)
+	}
+
+	var data string
+	if env.GenesisStore != nil {
+		bz, err := env.GenesisStore.LoadChunk(id)
+		if err != nil {
+			return nil, err
+		}
+		data = base64.StdEncoding.EncodeToString(bz)
+	} else {
+		data = env.genChunks[id]
 	}
 
 	return &coretypes.ResultGenesisChunk{
-		TotalChunks: len(env.genChunks),
+		TotalChunks: total,
 		ChunkNumber: id,
-		Data:        env.genChunks[id],
+		Data:        data,
 	}, nil
 }
 
@@ -1,15 +1,21 @@
 package core
 
 import (
+	"encoding/base64"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/p2p"
+	sm "github.com/tendermint/tendermint/internal/state"
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestUnsafeDialSeeds(t *testing.T) {
@@ -87,3 +93,32 @@ func TestUnsafeDialPeers(t *testing.T) {
 		}
 	}
 }
+
+func TestGenesisFromStore(t *testing.T) {
+	genDoc, err := types.NewGenesisBuilder("test-chain", time.Now()).AppState([]byte(`{"a":1}`)).Build()
+	require.NoError(t, err)
+	hash, err := genDoc.Hash()
+	require.NoError(t, err)
+	store := sm.NewGenesisStore(dbm.NewMemDB())
+	require.NoError(t, store.Save(genDoc, hash))
+
+	withoutAppState := *genDoc
+	withoutAppState.AppState = nil
+	env := &Environment{GenDoc: &withoutAppState, GenesisStore: store}
+	require.NoError(t, env.InitGenesisChunks())
+
+	// app_state is read from the store
+	res, err := env.Genesis(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":1}`, string(res.Genesis.AppState))
+
+	chunk, err := env.GenesisChunked(&rpctypes.Context{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, chunk.TotalChunks)
+	expected, err := tmjson.Marshal(genDoc)
+	require.NoError(t, err)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(expected), chunk.Data)
+
+	_, err = env.GenesisChunked(&rpctypes.Context{}, 1)
+	assert.Error(t, err)
+}
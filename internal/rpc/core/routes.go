@@ -12,31 +12,48 @@ type RoutesMap map[string]*rpc.RPCFunc
 func (env *Environment) GetRoutes() RoutesMap {
 	return RoutesMap{
 		// subscribe/unsubscribe are reserved for websocket events.
-		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query"),
+		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query,last_event_id"),
 		"unsubscribe":     rpc.NewWSRPCFunc(env.Unsubscribe, "query"),
 		"unsubscribe_all": rpc.NewWSRPCFunc(env.UnsubscribeAll, ""),
 
+		// broadcast_tx_subscribe delivers the commit of the tx as an event.
+		"broadcast_tx_subscribe": rpc.NewWSRPCFunc(env.BroadcastTxSubscribe, "tx"),
+
 		// info API
-		"health":               rpc.NewRPCFunc(env.Health, "", false),
+		"health":               rpc.NewRPCFunc(env.Health, "probe", false),
 		"status":               rpc.NewRPCFunc(env.Status, "", false),
 		"net_info":             rpc.NewRPCFunc(env.NetInfo, "", false),
 		"blockchain":           rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight", true),
+		"blocks":               rpc.NewRPCFunc(env.Blocks, "min_height,max_height,include_txs", true),
 		"genesis":              rpc.NewRPCFunc(env.Genesis, "", true),
 		"genesis_chunked":      rpc.NewRPCFunc(env.GenesisChunked, "chunk", true),
 		"block":                rpc.NewRPCFunc(env.Block, "height", true),
 		"block_by_hash":        rpc.NewRPCFunc(env.BlockByHash, "hash", true),
 		"block_results":        rpc.NewRPCFunc(env.BlockResults, "height", true),
+		"namespaced_data":      rpc.NewRPCFunc(env.NamespacedData, "height,namespace_id", true),
 		"commit":               rpc.NewRPCFunc(env.Commit, "height", true),
 		"check_tx":             rpc.NewRPCFunc(env.CheckTx, "tx", true),
 		"tx":                   rpc.NewRPCFunc(env.Tx, "hash,prove", true),
-		"tx_search":            rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by", false),
-		"block_search":         rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by", false),
+		"txs":                  rpc.NewRPCFunc(env.Txs, "hashes,prove", true),
+		"tx_search":            rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by,cursor,limit,count_total", false),
+		"block_search":         rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by,cursor,limit,count_total", false),
 		"validators":           rpc.NewRPCFunc(env.Validators, "height,page,per_page", true),
 		"dump_consensus_state": rpc.NewRPCFunc(env.DumpConsensusState, "", false),
 		"consensus_state":      rpc.NewRPCFunc(env.GetConsensusState, "", false),
 		"consensus_params":     rpc.NewRPCFunc(env.ConsensusParams, "height", true),
 		"unconfirmed_txs":      rpc.NewRPCFunc(env.UnconfirmedTxs, "limit", false),
 		"num_unconfirmed_txs":  rpc.NewRPCFunc(env.NumUnconfirmedTxs, "", false),
+		"mismatch_report":      rpc.NewRPCFunc(env.MismatchReport, "", false),
+
+		// historical state API
+		"validator_sets":           rpc.NewRPCFunc(env.ValidatorSets, "from_height,to_height,page,per_page", true),
+		"consensus_params_history": rpc.NewRPCFunc(env.ConsensusParamsHistory, "from_height,to_height,page,per_page", true),
+
+		// data availability API
+		"data_availability_header": rpc.NewRPCFunc(env.DataAvailabilityHeader, "height", true),
+		"share":                    rpc.NewRPCFunc(env.Share, "height,row,col", true),
+		"blob":                     rpc.NewRPCFunc(env.Blob, "height,namespace,commitment", true),
+		"prove_tx_inclusion":       rpc.NewRPCFunc(env.ProveTxInclusion, "height,tx_hash", true),
 
 		// tx broadcast API
 		"broadcast_tx_commit": rpc.NewRPCFunc(env.BroadcastTxCommit, "tx", false),
@@ -58,4 +75,8 @@ func (env *Environment) AddUnsafe(routes RoutesMap) {
 	routes["dial_seeds"] = rpc.NewRPCFunc(env.UnsafeDialSeeds, "seeds", false)
 	routes["dial_peers"] = rpc.NewRPCFunc(env.UnsafeDialPeers, "peers,persistent,unconditional,private", false)
 	routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(env.UnsafeFlushMempool, "", false)
+	routes["unsafe_set_log_level"] = rpc.NewRPCFunc(env.UnsafeSetLogLevel, "level", false)
+	routes["unsafe_reload_config"] = rpc.NewRPCFunc(env.UnsafeReloadConfig, "", false)
+	routes["unsafe_drain"] = rpc.NewRPCFunc(env.UnsafeDrain, "", false)
+	routes["unsafe_debug_bundle"] = rpc.NewRPCFunc(env.UnsafeDebugBundle, "", false)
 }
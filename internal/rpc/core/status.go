@@ -11,7 +11,8 @@ import (
 )
 
 // Status returns Tendermint status including node info, pubkey, latest block
-// hash, app hash, block height, current max peer block height, and time.
+// hash, app hash, block height, current max peer block height, and time, and
+// the round in progress, its proposer and timeouts.
 // More: https://docs.tendermint.com/master/rpc/#/Info/status
 func (env *Environment) Status(ctx *rpctypes.Context) (*coretypes.ResultStatus, error) {
 	var (
@@ -58,8 +59,9 @@ func (env *Environment) Status(ctx *rpctypes.Context) (*coretypes.ResultStatus,
 			VotingPower: votingPower,
 		}
 	}
+	nodeInfo := env.P2PTransport.NodeInfo()
 	result := &coretypes.ResultStatus{
-		NodeInfo: env.P2PTransport.NodeInfo(),
+		NodeInfo: nodeInfo,
 		SyncInfo: coretypes.SyncInfo{
 			LatestBlockHash:     latestBlockHash,
 			LatestAppHash:       latestAppHash,
@@ -69,12 +71,14 @@ func (env *Environment) Status(ctx *rpctypes.Context) (*coretypes.ResultStatus,
 			EarliestAppHash:     earliestAppHash,
 			EarliestBlockHeight: earliestBlockHeight,
 			EarliestBlockTime:   time.Unix(0, earliestBlockTimeNano),
+			ServesSnapshots:     nodeInfo.Other.ServesSnapshots,
 			MaxPeerBlockHeight:  env.BlockSyncReactor.GetMaxPeerBlockHeight(),
 			CatchingUp:          env.ConsensusReactor.WaitSync(),
 			TotalSyncedTime:     env.BlockSyncReactor.GetTotalSyncedTime(),
 			RemainingTime:       env.BlockSyncReactor.GetRemainingSyncTime(),
 		},
 		ValidatorInfo: validatorInfo,
+		ConsensusInfo: env.consensusInfo(latestHeight, latestBlockTimeNano),
 	}
 
 	if env.StateSyncMetricer != nil {
@@ -83,6 +87,7 @@ func (env *Environment) Status(ctx *rpctypes.Context) (*coretypes.ResultStatus,
 		result.SyncInfo.SnapshotHeight = env.StateSyncMetricer.SnapshotHeight()
 		result.SyncInfo.SnapshotChunksCount = env.StateSyncMetricer.SnapshotChunksCount()
 		result.SyncInfo.SnapshotChunksTotal = env.StateSyncMetricer.SnapshotChunksTotal()
+		result.SyncInfo.SnapshotRemainingTime = env.StateSyncMetricer.SnapshotRemainingTime()
 		result.SyncInfo.BackFilledBlocks = env.StateSyncMetricer.BackFilledBlocks()
 		result.SyncInfo.BackFillBlocksTotal = env.StateSyncMetricer.BackFillBlocksTotal()
 	}
@@ -90,6 +95,27 @@ func (env *Environment) Status(ctx *rpctypes.Context) (*coretypes.ResultStatus,
 	return result, nil
 }
 
+// consensusInfo returns the round in progress, its proposer and timeouts, and
+// the time since the latest block.
+func (env *Environment) consensusInfo(latestHeight, latestBlockTimeNano int64) coretypes.ConsensusInfo {
+	var info coretypes.ConsensusInfo
+	var proposer types.Address
+	info.Height, info.Round, info.Step, proposer = env.ConsensusState.GetRoundInfo()
+	info.ProposerAddress = tmbytes.HexBytes(proposer)
+
+	if cfg := env.ConsensusConfig; cfg != nil {
+		info.TimeoutPropose = cfg.Propose(info.Round)
+		info.TimeoutPrevote = cfg.Prevote(info.Round)
+		info.TimeoutPrecommit = cfg.Precommit(info.Round)
+		info.TimeoutCommit = cfg.TimeoutCommit
+		info.SkipTimeoutCommit = cfg.SkipTimeoutCommit
+	}
+	if latestHeight != 0 && latestBlockTimeNano != 0 {
+		info.TimeSinceLastBlock = time.Since(time.Unix(0, latestBlockTimeNano))
+	}
+	return info
+}
+
 func (env *Environment) validatorAtHeight(h int64) *types.Validator {
 	valsWithH, err := env.StateStore.LoadValidators(h)
 	if err != nil {
@@ -33,7 +33,7 @@ func (env *Environment) Tx(ctx *rpctypes.Context, hash bytes.HexBytes, prove boo
 		if sink.Type() == indexer.KV {
 			r, err := sink.GetTxByHash(hash)
 			if r == nil {
-				return nil, fmt.Errorf("tx (%X) not found, err: %w", hash, err)
+				return nil, fmt.Errorf("%w: tx (%X), err: %v", coretypes.ErrTxNotFound, hash, err)
 			}
 
 			height := r.Height
@@ -59,8 +59,87 @@ func (env *Environment) Tx(ctx *rpctypes.Context, hash bytes.HexBytes, prove boo
 	return nil, fmt.Errorf("transaction querying is disabled on this node due to the KV event sink being disabled")
 }
 
+// Txs allows you to query the results of several transactions by their
+// hashes, at most 100 at once. If prove is true, the transactions of each
+// block are proven together by a merkle multiproof, which is smaller than
+// their proofs taken one by one as they share their inner nodes.
+// More: https://docs.tendermint.com/master/rpc/#/Info/txs
+func (env *Environment) Txs(ctx *rpctypes.Context, hashes []bytes.HexBytes, prove bool) (*coretypes.ResultTxs, error) {
+	if !indexer.KVSinkEnabled(env.EventSinks) {
+		return nil, errors.New("transaction querying is disabled due to no kvEventSink")
+	}
+	if len(hashes) == 0 || len(hashes) > maxPerPage {
+		return nil, fmt.Errorf("%w: expected 1 to %d hashes, got %d",
+			coretypes.ErrInvalidRequest, maxPerPage, len(hashes))
+	}
+
+	var sink indexer.EventSink
+	for _, s := range env.EventSinks {
+		if s.Type() == indexer.KV {
+			sink = s
+			break
+		}
+	}
+
+	txs := make([]*coretypes.ResultTx, len(hashes))
+	indices := make(map[int64][]int64)
+	for i, hash := range hashes {
+		r, err := sink.GetTxByHash(hash)
+		if r == nil {
+			return nil, fmt.Errorf("%w: tx (%X), err: %v", coretypes.ErrTxNotFound, hash, err)
+		}
+		txs[i] = &coretypes.ResultTx{
+			Hash:     hash,
+			Height:   r.Height,
+			Index:    r.Index,
+			TxResult: r.Result,
+			Tx:       r.Tx,
+		}
+		indices[r.Height] = append(indices[r.Height], int64(r.Index))
+	}
+
+	res := &coretypes.ResultTxs{Txs: txs}
+	if !prove {
+		return res, nil
+	}
+
+	heights := make([]int64, 0, len(indices))
+	for height := range indices {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	for _, height := range heights {
+		block := env.BlockStore.LoadBlock(height)
+		if block == nil {
+			return nil, fmt.Errorf("block at height %d not found", height)
+		}
+		proof, err := block.Data.Txs.MultiProof(sortedUnique(indices[height]))
+		if err != nil {
+			return nil, err
+		}
+		res.Proofs = append(res.Proofs, coretypes.BlockTxsProof{Height: height, Proof: proof})
+	}
+	return res, nil
+}
+
+// sortedUnique sorts the indices in increasing order, without duplicates.
+func sortedUnique(indices []int64) []int64 {
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	unique := indices[:0]
+	for i, index := range indices {
+		if i == 0 || index != indices[i-1] {
+			unique = append(unique, index)
+		}
+	}
+	return unique
+}
+
 // TxSearch allows you to query for multiple transactions results. It returns a
 // list of transactions (maximum ?per_page entries) and the total count.
+//
+// Instead of pages, results can be fetched with ?limit and ?cursor, passing
+// the next_cursor of a response as the cursor of the next request. The total
+// count is then only computed if ?count_total is set, and is -1 otherwise.
 // More: https://docs.tendermint.com/master/rpc/#/Info/tx_search
 func (env *Environment) TxSearch(
 	ctx *rpctypes.Context,
@@ -68,17 +147,41 @@ func (env *Environment) TxSearch(
 	prove bool,
 	pagePtr, perPagePtr *int,
 	orderBy string,
+	cursor string,
+	limitPtr *int,
+	countTotal bool,
 ) (*coretypes.ResultTxSearch, error) {
 
 	if !indexer.KVSinkEnabled(env.EventSinks) {
 		return nil, fmt.Errorf("transaction searching is disabled due to no kvEventSink")
 	}
 
+	useCursor, err := searchWithCursor(pagePtr, cursor, limitPtr)
+	if err != nil {
+		return nil, err
+	}
+
 	q, err := tmquery.New(query)
 	if err != nil {
 		return nil, err
 	}
 
+	var cur *searchCursor
+	if cursor != "" {
+		c, err := decodeSearchCursor(cursor, orderBy)
+		if err != nil {
+			return nil, err
+		}
+		cur = &c
+
+		// without a total count, the results preceding the cursor are not needed
+		if !countTotal {
+			if q, err = cur.narrow(query, types.TxHeightKey); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	for _, sink := range env.EventSinks {
 		if sink.Type() == indexer.KV {
 			results, err := sink.SearchTxEvents(ctx.Context(), q)
@@ -108,15 +211,37 @@ func (env *Environment) TxSearch(
 
 			// paginate results
 			totalCount := len(results)
-			perPage := env.validatePerPage(perPagePtr)
+			var (
+				skipCount, pageSize int
+				nextCursor          string
+			)
+			if useCursor {
+				if cur != nil {
+					results = results[sort.Search(len(results), func(i int) bool {
+						return cur.follows(results[i].Height, results[i].Index)
+					}):]
+				}
+				if !countTotal {
+					totalCount = -1
+				}
 
-			page, err := validatePage(pagePtr, perPage, totalCount)
-			if err != nil {
-				return nil, err
-			}
+				limit := env.validatePerPage(limitPtr)
+				pageSize = tmmath.MinInt(limit, len(results))
+				if len(results) > limit {
+					last := results[pageSize-1]
+					nextCursor = searchCursor{desc: orderBy != "asc", height: last.Height, index: last.Index}.String()
+				}
+			} else {
+				perPage := env.validatePerPage(perPagePtr)
 
-			skipCount := validateSkipCount(page, perPage)
-			pageSize := tmmath.MinInt(perPage, totalCount-skipCount)
+				page, err := validatePage(pagePtr, perPage, totalCount)
+				if err != nil {
+					return nil, err
+				}
+
+				skipCount = validateSkipCount(page, perPage)
+				pageSize = tmmath.MinInt(perPage, totalCount-skipCount)
+			}
 
 			apiResults := make([]*coretypes.ResultTx, 0, pageSize)
 			for i := skipCount; i < skipCount+pageSize; i++ {
@@ -138,7 +263,7 @@ func (env *Environment) TxSearch(
 				})
 			}
 
-			return &coretypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
+			return &coretypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount, NextCursor: nextCursor}, nil
 		}
 	}
 
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink/kv"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxSearchCursor(t *testing.T) {
+	sink := kv.NewEventSink(dbm.NewMemDB())
+	env := &Environment{EventSinks: []indexer.EventSink{sink}}
+
+	index := func(height int64, txIndex uint32) {
+		err := sink.IndexTxEvents([]*abci.TxResult{{
+			Height: height,
+			Index:  txIndex,
+			Tx:     types.Tx{byte(height), byte(txIndex)},
+			Result: abci.ResponseDeliverTx{Events: []abci.Event{{
+				Type:       "account",
+				Attributes: []abci.EventAttribute{{Key: "owner", Value: "Ivan", Index: true}},
+			}}},
+		}})
+		require.NoError(t, err)
+	}
+	for h := int64(1); h <= 3; h++ {
+		index(h, 0)
+		index(h, 1)
+	}
+
+	type position struct {
+		height int64
+		index  uint32
+	}
+	search := func(orderBy, cursor string, limit int, countTotal bool) ([]position, *coretypes.ResultTxSearch) {
+		res, err := env.TxSearch(&rpctypes.Context{}, "account.owner = 'Ivan'", false, nil, nil,
+			orderBy, cursor, &limit, countTotal)
+		require.NoError(t, err)
+		got := make([]position, len(res.Txs))
+		for i, tx := range res.Txs {
+			got[i] = position{tx.Height, tx.Index}
+		}
+		return got, res
+	}
+
+	got, res := search("asc", "", 4, false)
+	assert.Equal(t, []position{{1, 0}, {1, 1}, {2, 0}, {2, 1}}, got)
+	assert.Equal(t, -1, res.TotalCount)
+	require.NotEmpty(t, res.NextCursor)
+
+	// results indexed after the first page do not shift the next one
+	index(4, 0)
+	got, res = search("asc", res.NextCursor, 4, true)
+	assert.Equal(t, []position{{3, 0}, {3, 1}, {4, 0}}, got)
+	assert.Equal(t, 7, res.TotalCount)
+	assert.Empty(t, res.NextCursor)
+
+	limit3 := 3
+	got, res = search("desc", "", 2, false)
+	assert.Equal(t, []position{{4, 0}, {3, 1}}, got)
+	got, _ = search("desc", res.NextCursor, 3, false)
+	assert.Equal(t, []position{{3, 0}, {2, 1}, {2, 0}}, got)
+
+	// the cursor narrows all the clauses of a query with OR
+	cur, err := decodeSearchCursor(res.NextCursor, "desc")
+	require.NoError(t, err)
+	q, err := cur.narrow("account.owner = 'Igor' OR account.owner = 'Ivan'", types.TxHeightKey)
+	require.NoError(t, err)
+	conjunctions, err := q.Disjunction()
+	require.NoError(t, err)
+	require.Len(t, conjunctions, 2)
+	for _, c := range conjunctions {
+		require.Len(t, c.Conditions, 2)
+		assert.Equal(t, types.TxHeightKey, c.Conditions[1].CompositeKey)
+	}
+	orRes, err := env.TxSearch(&rpctypes.Context{}, "account.owner = 'Igor' OR account.owner = 'Ivan'",
+		false, nil, nil, "desc", res.NextCursor, &limit3, false)
+	require.NoError(t, err)
+	require.Len(t, orRes.Txs, 3)
+	assert.EqualValues(t, 3, orRes.Txs[0].Height)
+
+	// a cursor cannot be used with another order or with pages
+	_, err = env.TxSearch(&rpctypes.Context{}, "account.owner = 'Ivan'", false, nil, nil,
+		"asc", res.NextCursor, nil, false)
+	assert.ErrorIs(t, err, coretypes.ErrInvalidRequest)
+	page := 1
+	_, err = env.TxSearch(&rpctypes.Context{}, "account.owner = 'Ivan'", false, &page, nil,
+		"desc", res.NextCursor, nil, false)
+	assert.ErrorIs(t, err, coretypes.ErrInvalidRequest)
+	_, err = env.TxSearch(&rpctypes.Context{}, "account.owner = 'Ivan'", false, nil, nil,
+		"desc", "garbage", nil, false)
+	assert.ErrorIs(t, err, coretypes.ErrInvalidRequest)
+}
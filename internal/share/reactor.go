@@ -0,0 +1,775 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime/debug"
+	"time"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/tendermint/tendermint/config"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/pkg/da"
+	shproto "github.com/tendermint/tendermint/proto/tendermint/share"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	_ service.Service = (*Reactor)(nil)
+
+	// ChannelShims contains a map of ChannelDescriptorShim objects, where each
+	// object wraps a reference to a legacy p2p ChannelDescriptor and the corresponding
+	// p2p proto.Message the new p2p Channel is responsible for handling.
+	//
+	//
+	// TODO: Remove once p2p refactor is complete.
+	// ref: https://github.com/tendermint/tendermint/issues/5670
+	ChannelShims = map[p2p.ChannelID]*p2p.ChannelDescriptorShim{
+		ShareChannel: {
+			MsgType: new(shproto.Message),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(ShareChannel),
+				Priority:            4,
+				SendQueueCapacity:   16,
+				RecvMessageCapacity: maxMsgSize,
+				RecvBufferCapacity:  32,
+				MaxSendBytes:        10000,
+			},
+		},
+		FraudChannel: {
+			MsgType: new(shproto.Message),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(FraudChannel),
+				Priority:            6,
+				SendQueueCapacity:   8,
+				RecvMessageCapacity: maxMsgSize,
+				RecvBufferCapacity:  8,
+				MaxSendBytes:        10000,
+			},
+		},
+	}
+
+	// ErrSharesNotAvailable is returned by FetchShares when the peer doesn't
+	// have the block of the requested shares.
+	ErrSharesNotAvailable = errors.New("peer doesn't have the shares")
+
+	// ErrUnknownHeight is returned by a BadEncodingProofHandler which can't
+	// verify a bad encoding proof, not knowing the block of its height. The
+	// proof is dropped, without punishing its sender.
+	ErrUnknownHeight = errors.New("unknown height")
+)
+
+const (
+	// ShareChannel serves the shares of the extended data squares of the
+	// blocks.
+	ShareChannel = p2p.ChannelID(0x70)
+
+	// FraudChannel gossips the bad encoding proofs of the blocks, see
+	// da.BadEncodingProof.
+	FraudChannel = p2p.ChannelID(0x71)
+
+	maxMsgSize = 4194304 // 4MB
+
+	// MaxRequestShares is the maximum number of shares a request can ask for,
+	// counting each share of the rows and columns requested. A share served
+	// with its proofs is about 1KB in the largest squares.
+	MaxRequestShares = 2048
+
+	// edsCacheSize is the number of extended data squares kept, the requests
+	// of the DAS clients focusing on the latest heights.
+	edsCacheSize = 4
+)
+
+// BlockStore is the store of the blocks whose shares are served.
+type BlockStore interface {
+	LoadBlock(height int64) *types.Block
+}
+
+// BadEncodingProofHandler verifies a bad encoding proof received from a peer,
+// returning an error if it is invalid, and acts upon it if it is valid, such
+// as halting a light client.
+type BadEncodingProofHandler func(proof *da.BadEncodingProof) error
+
+// Reactor serves the shares of the extended data squares of the blocks in its
+// store to the peers, by row, column or coordinates, so that DAS clients and
+// partial nodes don't need to download whole blocks. The shares served to each
+// peer are rate limited.
+//
+// It also fetches the shares from the peers, see FetchShares, and gossips the
+// bad encoding proofs on the FraudChannel, relaying the ones verified by its
+// BadEncodingProofHandler.
+type Reactor struct {
+	service.BaseService
+
+	store BlockStore
+	// serveRate and serveBurst are the number of shares served to a peer per
+	// second, and at once. Nothing is served if serveRate is zero.
+	serveRate  float64
+	serveBurst float64
+
+	shareCh *p2p.Channel
+	// shareOutBridgeCh defines a channel that acts as a bridge between sending
+	// Envelope messages that the reactor will consume in processShareCh and
+	// the requests sent by FetchShares. We do this instead of directly sending
+	// on shareCh.Out to avoid race conditions in the case where FetchShares
+	// sends to shareCh.Out while processShareCh closes it.
+	shareOutBridgeCh chan p2p.Envelope
+	// peerErrorCh bridges the errors of the peers sending invalid shares to
+	// FetchShares, for the same reason.
+	peerErrorCh chan p2p.PeerError
+
+	fraudCh *p2p.Channel
+	// fraudOutBridgeCh bridges the proofs broadcast by
+	// BroadcastBadEncodingProof to processFraudCh, for the same reason.
+	fraudOutBridgeCh chan *da.BadEncodingProof
+	// seenProofs are the axes of the valid proofs gossiped. It is only
+	// accessed by processFraudCh.
+	seenProofs map[proofKey]struct{}
+
+	peerUpdates *p2p.PeerUpdates
+	closeCh     chan struct{}
+
+	// edsCache is only accessed by processShareCh.
+	edsCache map[int64]*rsmt2d.ExtendedDataSquare
+	edsOrder []int64
+
+	mtx          tmsync.Mutex
+	proofHandler BadEncodingProofHandler
+	peers        map[types.NodeID]struct{}
+	limiters     map[types.NodeID]*limiter
+	nextID       uint64
+	requests     map[uint64]*request
+}
+
+// request is a request sent by FetchShares waiting for its response.
+type request struct {
+	peer       types.NodeID
+	responseCh chan *shproto.SharesResponse
+}
+
+// proofKey identifies the axis of a bad encoding proof.
+type proofKey struct {
+	height int64
+	isRow  bool
+	index  uint64
+}
+
+// NewReactor returns a reference to a new share reactor, which implements the
+// service.Service interface. It accepts a p2p Channel dedicated for handling
+// envelopes with share messages, and one for the bad encoding proofs. The
+// proofs are verified against the blocks of the store until another
+// BadEncodingProofHandler is set.
+func NewReactor(
+	cfg *config.P2PConfig,
+	logger log.Logger,
+	store BlockStore,
+	shareCh *p2p.Channel,
+	fraudCh *p2p.Channel,
+	peerUpdates *p2p.PeerUpdates,
+) *Reactor {
+	r := &Reactor{
+		store:            store,
+		serveRate:        float64(cfg.ShareServeRate),
+		serveBurst:       float64(cfg.ShareServeBurst),
+		shareCh:          shareCh,
+		shareOutBridgeCh: make(chan p2p.Envelope),
+		peerErrorCh:      make(chan p2p.PeerError),
+		fraudCh:          fraudCh,
+		fraudOutBridgeCh: make(chan *da.BadEncodingProof),
+		seenProofs:       make(map[proofKey]struct{}),
+		peerUpdates:      peerUpdates,
+		closeCh:          make(chan struct{}),
+		peers:            make(map[types.NodeID]struct{}),
+		limiters:         make(map[types.NodeID]*limiter),
+		edsCache:         make(map[int64]*rsmt2d.ExtendedDataSquare),
+		requests:         make(map[uint64]*request),
+	}
+
+	r.proofHandler = r.verifyBadEncodingProof
+	r.BaseService = *service.NewBaseService(logger, "Share", r)
+	return r
+}
+
+// SetBadEncodingProofHandler sets the handler of the bad encoding proofs
+// received from the peers.
+func (r *Reactor) SetBadEncodingProofHandler(handler BadEncodingProofHandler) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.proofHandler = handler
+}
+
+// OnStart starts separate go routines for each p2p Channel and listens for
+// envelopes on each. In addition, it also listens for peer updates and handles
+// messages on that p2p channel accordingly. The caller must be sure to execute
+// OnStop to ensure the outbound p2p Channels are closed. No error is returned.
+func (r *Reactor) OnStart() error {
+	go r.processShareCh()
+	go r.processFraudCh()
+	go r.processPeerUpdates()
+
+	return nil
+}
+
+// OnStop stops the reactor by signaling to all spawned goroutines to exit and
+// blocking until they all exit.
+func (r *Reactor) OnStop() {
+	// Close closeCh to signal to all spawned goroutines to gracefully exit. All
+	// p2p Channels should execute Close().
+	close(r.closeCh)
+
+	// Wait for all p2p Channels to be closed before returning. This ensures we
+	// can easily reason about synchronization of all p2p Channels and ensure no
+	// panics will occur.
+	<-r.shareCh.Done()
+	<-r.fraudCh.Done()
+	<-r.peerUpdates.Done()
+}
+
+// Peers returns the connected peers, which the shares can be fetched from.
+func (r *Reactor) Peers() []types.NodeID {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	peers := make([]types.NodeID, 0, len(r.peers))
+	for peer := range r.peers {
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+// FetchShares requests the shares of req from the peer and returns them once
+// verified against dah, the data availability header of the requested height.
+// The ID of req is assigned by FetchShares. It returns ErrSharesNotAvailable if
+// the peer doesn't have the block, and reports the peer if the shares are
+// invalid.
+func (r *Reactor) FetchShares(
+	ctx context.Context,
+	peer types.NodeID,
+	dah *da.DataAvailabilityHeader,
+	req *shproto.SharesRequest,
+) (*shproto.SharesResponse, error) {
+	responseCh := make(chan *shproto.SharesResponse, 1)
+
+	r.mtx.Lock()
+	r.nextID++
+	req.Id = r.nextID
+	r.requests[req.Id] = &request{peer: peer, responseCh: responseCh}
+	r.mtx.Unlock()
+
+	defer func() {
+		r.mtx.Lock()
+		delete(r.requests, req.Id)
+		r.mtx.Unlock()
+	}()
+
+	select {
+	case r.shareOutBridgeCh <- p2p.Envelope{To: peer, Message: req}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-r.closeCh:
+		return nil, errors.New("reactor stopped")
+	}
+
+	var res *shproto.SharesResponse
+	select {
+	case res = <-responseCh:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-r.closeCh:
+		return nil, errors.New("reactor stopped")
+	}
+
+	if len(res.Rows) == 0 && len(res.Cols) == 0 && len(res.Shares) == 0 {
+		return nil, fmt.Errorf("%w at height %d", ErrSharesNotAvailable, req.Height)
+	}
+	if err := verifyResponse(dah, req, res); err != nil {
+		err = fmt.Errorf("invalid shares from peer %v: %w", peer, err)
+		select {
+		case r.peerErrorCh <- p2p.PeerError{NodeID: peer, Err: err}:
+		case <-r.closeCh:
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// BroadcastBadEncodingProof gossips the bad encoding proof, generated by us, to
+// the peers.
+func (r *Reactor) BroadcastBadEncodingProof(proof *da.BadEncodingProof) {
+	select {
+	case r.fraudOutBridgeCh <- proof:
+	case <-r.closeCh:
+	}
+}
+
+// verifyResponse checks that res holds all the shares of req, and that they
+// are the ones committed to by dah.
+func verifyResponse(dah *da.DataAvailabilityHeader, req *shproto.SharesRequest, res *shproto.SharesResponse) error {
+	if res.Height != req.Height {
+		return fmt.Errorf("expected height %d, got %d", req.Height, res.Height)
+	}
+	if len(res.Rows) != len(req.Rows) || len(res.Cols) != len(req.Cols) ||
+		len(res.Shares) != len(req.Coordinates) {
+		return errors.New("shares missing from the response")
+	}
+
+	for i, row := range res.Rows {
+		if row.Index != req.Rows[i] {
+			return fmt.Errorf("expected row %d, got %d", req.Rows[i], row.Index)
+		}
+		if err := da.VerifyRow(dah, row.Index, row.Shares); err != nil {
+			return err
+		}
+	}
+	for i, col := range res.Cols {
+		if col.Index != req.Cols[i] {
+			return fmt.Errorf("expected column %d, got %d", req.Cols[i], col.Index)
+		}
+		if err := da.VerifyCol(dah, col.Index, col.Shares); err != nil {
+			return err
+		}
+	}
+	for i, share := range res.Shares {
+		coord := req.Coordinates[i]
+		if share.Row != coord.Row || share.Col != coord.Col {
+			return fmt.Errorf("expected share (%d, %d), got (%d, %d)", coord.Row, coord.Col, share.Row, share.Col)
+		}
+		proof := da.ShareProof{
+			Row:      share.Row,
+			Col:      share.Col,
+			Share:    share.Data,
+			RowNodes: share.RowNodes,
+			ColNodes: share.ColNodes,
+		}
+		if err := proof.Verify(dah); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleShareMessage handles envelopes sent from peers on the ShareChannel.
+// It returns an error only if the Envelope.Message is unknown for this channel
+// or if the request is invalid. This should never be called outside of
+// handleMessage.
+func (r *Reactor) handleShareMessage(envelope p2p.Envelope) error {
+	logger := r.Logger.With("peer", envelope.From)
+
+	switch msg := envelope.Message.(type) {
+	case *shproto.SharesRequest:
+		if r.serveRate <= 0 {
+			logger.Debug("not serving shares", "height", msg.Height)
+			return nil
+		}
+		if !r.allow(envelope.From) {
+			logger.Debug("dropping rate limited shares request", "height", msg.Height)
+			return nil
+		}
+
+		res, err := r.respond(msg)
+		if err != nil {
+			return err
+		}
+		r.charge(envelope.From, responseShares(res))
+		r.shareCh.Out <- p2p.Envelope{
+			To:      envelope.From,
+			Message: res,
+		}
+
+	case *shproto.SharesResponse:
+		r.mtx.Lock()
+		req, ok := r.requests[msg.Id]
+		if ok && req.peer == envelope.From {
+			delete(r.requests, msg.Id)
+		}
+		r.mtx.Unlock()
+
+		if !ok || req.peer != envelope.From {
+			// the request may have timed out
+			logger.Debug("received unsolicited shares response", "id", msg.Id, "height", msg.Height)
+			return nil
+		}
+		req.responseCh <- msg
+
+	default:
+		return fmt.Errorf("received unknown message: %T", msg)
+	}
+
+	return nil
+}
+
+// handleFraudMessage handles envelopes sent from peers on the FraudChannel.
+// It returns an error only if the Envelope.Message is unknown for this channel
+// or if the proof is invalid. This should never be called outside of
+// handleMessage.
+func (r *Reactor) handleFraudMessage(envelope p2p.Envelope) error {
+	logger := r.Logger.With("peer", envelope.From)
+
+	switch msg := envelope.Message.(type) {
+	case *shproto.BadEncodingProof:
+		proof := badEncodingProofFromProto(msg)
+		if _, ok := r.seenProofs[proofKey{proof.Height, proof.IsRow, proof.Index}]; ok {
+			return nil
+		}
+
+		r.mtx.Lock()
+		handler := r.proofHandler
+		r.mtx.Unlock()
+
+		if err := handler(proof); err != nil {
+			if errors.Is(err, ErrUnknownHeight) {
+				logger.Debug("dropping bad encoding proof of an unknown height", "height", proof.Height)
+				return nil
+			}
+			return fmt.Errorf("invalid bad encoding proof: %w", err)
+		}
+		logger.Error("received a valid bad encoding proof", "height", proof.Height,
+			"is_row", proof.IsRow, "index", proof.Index)
+		r.gossipBadEncodingProof(proof)
+
+	default:
+		return fmt.Errorf("received unknown message: %T", msg)
+	}
+
+	return nil
+}
+
+// gossipBadEncodingProof broadcasts the valid bad encoding proof to the peers,
+// once.
+func (r *Reactor) gossipBadEncodingProof(proof *da.BadEncodingProof) {
+	key := proofKey{proof.Height, proof.IsRow, proof.Index}
+	if _, ok := r.seenProofs[key]; ok {
+		return
+	}
+	r.seenProofs[key] = struct{}{}
+
+	r.fraudCh.Out <- p2p.Envelope{
+		Broadcast: true,
+		Message:   badEncodingProofToProto(proof),
+	}
+}
+
+// verifyBadEncodingProof is the default BadEncodingProofHandler, verifying the
+// proofs against the blocks of the store. As the blocks of the store are
+// erasure coded, they don't have valid proofs.
+func (r *Reactor) verifyBadEncodingProof(proof *da.BadEncodingProof) error {
+	if r.store == nil {
+		return ErrUnknownHeight
+	}
+	block := r.store.LoadBlock(proof.Height)
+	if block == nil {
+		return fmt.Errorf("%w %d", ErrUnknownHeight, proof.Height)
+	}
+	eds, err := extendBlockData(block)
+	if err != nil {
+		return err
+	}
+	dah := da.NewDataAvailabilityHeader(eds)
+	return proof.Verify(&dah)
+}
+
+// respond returns the response to the request of a peer, with no shares if
+// the block isn't in the store.
+func (r *Reactor) respond(req *shproto.SharesRequest) (*shproto.SharesResponse, error) {
+	res := &shproto.SharesResponse{
+		Id:     req.Id,
+		Height: req.Height,
+		Rows:   []shproto.Axis{},
+		Cols:   []shproto.Axis{},
+		Shares: []shproto.Share{},
+	}
+
+	eds, err := r.extendedDataSquare(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	if eds == nil {
+		return res, nil
+	}
+
+	width := uint64(eds.Width())
+	if count := width*uint64(len(req.Rows)+len(req.Cols)) + uint64(len(req.Coordinates)); count > MaxRequestShares {
+		return nil, fmt.Errorf("requested %d shares, the maximum is %d", count, MaxRequestShares)
+	}
+
+	for _, index := range req.Rows {
+		if index >= width {
+			return nil, fmt.Errorf("row %d out of the %d rows", index, width)
+		}
+		res.Rows = append(res.Rows, shproto.Axis{Index: index, Shares: eds.Row(uint(index))})
+	}
+	for _, index := range req.Cols {
+		if index >= width {
+			return nil, fmt.Errorf("column %d out of the %d columns", index, width)
+		}
+		res.Cols = append(res.Cols, shproto.Axis{Index: index, Shares: eds.Col(uint(index))})
+	}
+	for _, coord := range req.Coordinates {
+		proof, err := da.ProveShare(eds, coord.Row, coord.Col)
+		if err != nil {
+			return nil, err
+		}
+		res.Shares = append(res.Shares, shproto.Share{
+			Row:      proof.Row,
+			Col:      proof.Col,
+			Data:     proof.Share,
+			RowNodes: proof.RowNodes,
+			ColNodes: proof.ColNodes,
+		})
+	}
+	return res, nil
+}
+
+// extendedDataSquare returns the extended data square of the block at height,
+// or nil if the block isn't in the store.
+func (r *Reactor) extendedDataSquare(height int64) (*rsmt2d.ExtendedDataSquare, error) {
+	if eds, ok := r.edsCache[height]; ok {
+		return eds, nil
+	}
+
+	block := r.store.LoadBlock(height)
+	if block == nil {
+		return nil, nil
+	}
+	eds, err := extendBlockData(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.edsOrder) == edsCacheSize {
+		delete(r.edsCache, r.edsOrder[0])
+		r.edsOrder = r.edsOrder[1:]
+	}
+	r.edsCache[height] = eds
+	r.edsOrder = append(r.edsOrder, height)
+	return eds, nil
+}
+
+// extendBlockData returns the extended data square of the data of the block.
+func extendBlockData(block *types.Block) (*rsmt2d.ExtendedDataSquare, error) {
+	shares, _ := block.Data.ComputeShares()
+	squareSize := uint64(math.Sqrt(float64(len(shares))))
+	eds, err := da.ExtendShares(squareSize, shares.RawShares())
+	if err != nil {
+		return nil, fmt.Errorf("failed to extend the data of height %d: %w", block.Height, err)
+	}
+	return eds, nil
+}
+
+// handleMessage handles an Envelope sent from a peer on a specific p2p Channel.
+// It will handle errors and any possible panics gracefully. A caller can handle
+// any error returned by sending a PeerError on the respective channel.
+func (r *Reactor) handleMessage(chID p2p.ChannelID, envelope p2p.Envelope) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("panic in processing message: %v", e)
+			r.Logger.Error(
+				"recovering from processing message panic",
+				"err", err,
+				"stack", string(debug.Stack()),
+			)
+		}
+	}()
+
+	r.Logger.Debug("received message", "message", envelope.Message, "peer", envelope.From)
+
+	switch chID {
+	case ShareChannel:
+		err = r.handleShareMessage(envelope)
+
+	case FraudChannel:
+		err = r.handleFraudMessage(envelope)
+
+	default:
+		err = fmt.Errorf("unknown channel ID (%d) for envelope (%v)", chID, envelope)
+	}
+
+	return err
+}
+
+// processShareCh initiates a blocking process where we listen for and handle
+// envelopes on the ShareChannel, shareOutBridgeCh and peerErrorCh. Any error
+// encountered during message execution will result in a PeerError being sent
+// on the ShareChannel. When the reactor is stopped, we will catch the signal
+// and close the p2p Channel gracefully.
+func (r *Reactor) processShareCh() {
+	defer r.shareCh.Close()
+
+	for {
+		select {
+		case envelope := <-r.shareCh.In:
+			if err := r.handleMessage(r.shareCh.ID, envelope); err != nil {
+				r.Logger.Error("failed to process message", "ch_id", r.shareCh.ID, "envelope", envelope, "err", err)
+				r.shareCh.Error <- p2p.PeerError{
+					NodeID: envelope.From,
+					Err:    err,
+				}
+			}
+
+		case envelope := <-r.shareOutBridgeCh:
+			r.shareCh.Out <- envelope
+
+		case peerErr := <-r.peerErrorCh:
+			r.shareCh.Error <- peerErr
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on share channel; closing...")
+			return
+		}
+	}
+}
+
+// processFraudCh initiates a blocking process where we listen for and handle
+// envelopes on the FraudChannel and the proofs on fraudOutBridgeCh. Any error
+// encountered during message execution will result in a PeerError being sent
+// on the FraudChannel. When the reactor is stopped, we will catch the signal
+// and close the p2p Channel gracefully.
+func (r *Reactor) processFraudCh() {
+	defer r.fraudCh.Close()
+
+	for {
+		select {
+		case envelope := <-r.fraudCh.In:
+			if err := r.handleMessage(r.fraudCh.ID, envelope); err != nil {
+				r.Logger.Error("failed to process message", "ch_id", r.fraudCh.ID, "envelope", envelope, "err", err)
+				r.fraudCh.Error <- p2p.PeerError{
+					NodeID: envelope.From,
+					Err:    err,
+				}
+			}
+
+		case proof := <-r.fraudOutBridgeCh:
+			r.gossipBadEncodingProof(proof)
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on fraud channel; closing...")
+			return
+		}
+	}
+}
+
+// processPeerUpdate processes a PeerUpdate, tracking the connected peers and
+// dropping the rate limit of the down peers.
+func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
+	r.Logger.Debug("received peer update", "peer", peerUpdate.NodeID, "status", peerUpdate.Status)
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	switch peerUpdate.Status {
+	case p2p.PeerStatusUp:
+		r.peers[peerUpdate.NodeID] = struct{}{}
+
+	case p2p.PeerStatusDown:
+		delete(r.peers, peerUpdate.NodeID)
+		delete(r.limiters, peerUpdate.NodeID)
+	}
+}
+
+// processPeerUpdates initiates a blocking process where we listen for and handle
+// PeerUpdate messages. When the reactor is stopped, we will catch the signal and
+// close the p2p PeerUpdatesCh gracefully.
+func (r *Reactor) processPeerUpdates() {
+	defer r.peerUpdates.Close()
+
+	for {
+		select {
+		case peerUpdate := <-r.peerUpdates.Updates():
+			r.processPeerUpdate(peerUpdate)
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on peer updates channel; closing...")
+			return
+		}
+	}
+}
+
+// allow returns whether a request of the peer can be served under its rate
+// limit.
+func (r *Reactor) allow(peer types.NodeID) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	lim, ok := r.limiters[peer]
+	if !ok {
+		lim = &limiter{tokens: r.serveBurst, last: time.Now()}
+		r.limiters[peer] = lim
+	}
+	return lim.allow(time.Now(), r.serveRate, r.serveBurst)
+}
+
+// charge charges the shares served to the peer to its rate limit.
+func (r *Reactor) charge(peer types.NodeID, shares int) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if lim, ok := r.limiters[peer]; ok {
+		lim.tokens -= float64(shares)
+	}
+}
+
+// responseShares returns the number of shares of the response.
+func responseShares(res *shproto.SharesResponse) int {
+	n := len(res.Shares)
+	for _, axis := range res.Rows {
+		n += len(axis.Shares)
+	}
+	for _, axis := range res.Cols {
+		n += len(axis.Shares)
+	}
+	return n
+}
+
+// limiter is a token bucket limiting the shares served to a peer. The shares
+// of a response are only charged once served, so that a peer may go into
+// debt, and is then served again once its tokens are positive.
+type limiter struct {
+	tokens float64
+	last   time.Time
+}
+
+// allow refills the bucket at rate tokens per second, up to burst, and returns
+// whether a request can be served.
+func (l *limiter) allow(now time.Time, rate, burst float64) bool {
+	l.tokens = math.Min(burst, l.tokens+rate*now.Sub(l.last).Seconds())
+	l.last = now
+	return l.tokens > 0
+}
+
+func badEncodingProofToProto(proof *da.BadEncodingProof) *shproto.BadEncodingProof {
+	pb := &shproto.BadEncodingProof{
+		Height: proof.Height,
+		IsRow:  proof.IsRow,
+		Index:  proof.Index,
+		Shares: make([]shproto.ProvenShare, len(proof.Shares)),
+	}
+	for i, share := range proof.Shares {
+		if share != nil {
+			pb.Shares[i] = shproto.ProvenShare{Data: share.Share, Nodes: share.Nodes}
+		}
+	}
+	return pb
+}
+
+// badEncodingProofFromProto returns the proof of pb, whose empty shares are
+// the missing ones.
+func badEncodingProofFromProto(pb *shproto.BadEncodingProof) *da.BadEncodingProof {
+	proof := &da.BadEncodingProof{
+		Height: pb.Height,
+		IsRow:  pb.IsRow,
+		Index:  pb.Index,
+		Shares: make([]*da.ProvenShare, len(pb.Shares)),
+	}
+	for i, share := range pb.Shares {
+		if len(share.Data) > 0 {
+			proof.Shares[i] = &da.ProvenShare{Share: share.Data, Nodes: share.Nodes}
+		}
+	}
+	return proof
+}
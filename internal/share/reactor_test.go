@@ -0,0 +1,222 @@
+package share_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fortytw2/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/p2p/p2ptest"
+	"github.com/tendermint/tendermint/internal/share"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/da"
+	shproto "github.com/tendermint/tendermint/proto/tendermint/share"
+	"github.com/tendermint/tendermint/types"
+)
+
+type blockStore map[int64]*types.Block
+
+func (s blockStore) LoadBlock(height int64) *types.Block {
+	return s[height]
+}
+
+type reactorTestSuite struct {
+	network  *p2ptest.Network
+	reactors map[types.NodeID]*share.Reactor
+	// server serves the shares of the blocks, client fetches them.
+	server, client types.NodeID
+}
+
+func setup(t *testing.T, cfg *config.P2PConfig, store blockStore) *reactorTestSuite {
+	t.Helper()
+
+	rts := &reactorTestSuite{
+		network:  p2ptest.MakeNetwork(t, p2ptest.NetworkOptions{NumNodes: 2}),
+		reactors: make(map[types.NodeID]*share.Reactor, 2),
+	}
+
+	chDesc := p2p.ChannelDescriptor{ID: byte(share.ShareChannel)}
+	channels := rts.network.MakeChannelsNoCleanup(t, chDesc, new(shproto.Message), 16)
+	fraudChDesc := p2p.ChannelDescriptor{ID: byte(share.FraudChannel)}
+	fraudChannels := rts.network.MakeChannelsNoCleanup(t, fraudChDesc, new(shproto.Message), 16)
+
+	for nodeID := range rts.network.Nodes {
+		peerUpdates := rts.network.Nodes[nodeID].MakePeerUpdatesNoRequireEmpty(t)
+		nodeStore := blockStore{}
+		if rts.server == "" {
+			rts.server = nodeID
+			nodeStore = store
+		} else {
+			rts.client = nodeID
+		}
+
+		rts.reactors[nodeID] = share.NewReactor(cfg, log.TestingLogger().With("node", nodeID),
+			nodeStore, channels[nodeID], fraudChannels[nodeID], peerUpdates)
+		require.NoError(t, rts.reactors[nodeID].Start())
+	}
+
+	t.Cleanup(func() {
+		for _, r := range rts.reactors {
+			if r.IsRunning() {
+				require.NoError(t, r.Stop())
+			}
+		}
+		leaktest.Check(t)
+	})
+
+	rts.network.Start(t)
+	return rts
+}
+
+func (rts *reactorTestSuite) fetch(
+	t *testing.T,
+	dah *da.DataAvailabilityHeader,
+	req *shproto.SharesRequest,
+) (*shproto.SharesResponse, error) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return rts.reactors[rts.client].FetchShares(ctx, rts.server, dah, req)
+}
+
+func makeBlock(t *testing.T, height int64) (*types.Block, *da.DataAvailabilityHeader) {
+	t.Helper()
+
+	txs := make([]types.Tx, 20)
+	for i := range txs {
+		txs[i] = make(types.Tx, 300)
+		txs[i][0] = byte(i)
+	}
+	block := types.MakeBlock(height, txs, nil, nil, nil, nil)
+
+	shares, _ := block.Data.ComputeShares()
+	squareSize := uint64(1)
+	for squareSize*squareSize < uint64(len(shares)) {
+		squareSize *= 2
+	}
+	eds, err := da.ExtendShares(squareSize, shares.RawShares())
+	require.NoError(t, err)
+	dah := da.NewDataAvailabilityHeader(eds)
+	require.Equal(t, []byte(block.Data.Hash()), dah.Hash())
+	return block, &dah
+}
+
+func TestReactorFetchShares(t *testing.T) {
+	block, dah := makeBlock(t, 3)
+	rts := setup(t, config.DefaultP2PConfig(), blockStore{3: block})
+	width := uint64(len(dah.RowsRoots))
+
+	req := &shproto.SharesRequest{
+		Height:      3,
+		Rows:        []uint64{0, width - 1},
+		Cols:        []uint64{1},
+		Coordinates: []shproto.Coordinate{{Row: 0, Col: 0}, {Row: width - 1, Col: width / 2}},
+	}
+	res, err := rts.fetch(t, dah, req)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2)
+	require.Len(t, res.Cols, 1)
+	require.Len(t, res.Shares, 2)
+	assert.EqualValues(t, width-1, res.Rows[1].Index)
+	assert.Len(t, res.Cols[0].Shares, int(width))
+	assert.EqualValues(t, width/2, res.Shares[1].Col)
+
+	// the shares not committed to by the data availability header are rejected
+	other := &da.DataAvailabilityHeader{
+		RowsRoots:   append([][]byte{dah.RowsRoots[1]}, dah.RowsRoots[1:]...),
+		ColumnRoots: dah.ColumnRoots,
+	}
+	_, err = rts.fetch(t, other, &shproto.SharesRequest{Height: 3, Rows: []uint64{0}})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, share.ErrSharesNotAvailable)
+}
+
+func TestReactorBadEncodingProof(t *testing.T) {
+	block, dah := makeBlock(t, 3)
+	rts := setup(t, config.DefaultP2PConfig(), blockStore{3: block})
+
+	// the square of the block, tampered with and committed to by a byzantine
+	// block producer
+	shares, _ := block.Data.ComputeShares()
+	eds, err := da.ExtendShares(uint64(len(dah.RowsRoots))/2, shares.RawShares())
+	require.NoError(t, err)
+	var square [][]byte
+	for i := uint(0); i < eds.Width(); i++ {
+		square = append(square, eds.Row(i)...)
+	}
+	square[len(square)-1] = bytes.Repeat([]byte{0xFF}, consts.ShareSize)
+	tamperedDAH, err := da.SquareDataAvailabilityHeader(square)
+	require.NoError(t, err)
+	proof, err := da.ProveBadEncoding(3, &tamperedDAH, square)
+	require.NoError(t, err)
+	require.NotNil(t, proof)
+
+	// the client verifies the proofs against the tampered header, as the
+	// light clients of the byzantine chain do
+	received := make(chan *da.BadEncodingProof, 1)
+	rts.reactors[rts.client].SetBadEncodingProofHandler(func(proof *da.BadEncodingProof) error {
+		if err := proof.Verify(&tamperedDAH); err != nil {
+			return err
+		}
+		received <- proof
+		return nil
+	})
+	require.Eventually(t, func() bool {
+		return len(rts.reactors[rts.server].Peers()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	rts.reactors[rts.server].BroadcastBadEncodingProof(proof)
+	select {
+	case got := <-received:
+		assert.Equal(t, proof, got)
+	case <-time.After(2 * time.Second):
+		t.Fatal("bad encoding proof not received")
+	}
+}
+
+func TestReactorPeers(t *testing.T) {
+	rts := setup(t, config.DefaultP2PConfig(), blockStore{})
+
+	require.Eventually(t, func() bool {
+		peers := rts.reactors[rts.client].Peers()
+		return len(peers) == 1 && peers[0] == rts.server
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, rts.reactors[rts.server].Stop())
+	rts.network.Remove(t, rts.server)
+	require.Eventually(t, func() bool {
+		return len(rts.reactors[rts.client].Peers()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestReactorFetchSharesNotAvailable(t *testing.T) {
+	block, dah := makeBlock(t, 3)
+	rts := setup(t, config.DefaultP2PConfig(), blockStore{3: block})
+
+	_, err := rts.fetch(t, dah, &shproto.SharesRequest{Height: 5, Rows: []uint64{0}})
+	require.ErrorIs(t, err, share.ErrSharesNotAvailable)
+}
+
+func TestReactorRateLimit(t *testing.T) {
+	block, dah := makeBlock(t, 3)
+	cfg := config.DefaultP2PConfig()
+	cfg.ShareServeRate = 1
+	cfg.ShareServeBurst = 1
+	rts := setup(t, cfg, blockStore{3: block})
+
+	// the first request is served, leaving the peer in debt of a row
+	_, err := rts.fetch(t, dah, &shproto.SharesRequest{Height: 3, Rows: []uint64{0}})
+	require.NoError(t, err)
+
+	// the next one is dropped until the debt is paid
+	_, err = rts.fetch(t, dah, &shproto.SharesRequest{Height: 3, Rows: []uint64{1}})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -48,6 +48,16 @@ type (
 	ErrNoABCIResponsesForHeight struct {
 		Height int64
 	}
+
+	// ErrAppHashMismatch is returned when the app hash, or the last results
+	// hash, of a block doesn't match the one computed by the node executing
+	// the blocks below it.
+	ErrAppHashMismatch struct {
+		Field    string // AppHash or LastResultsHash
+		Height   int64  // height of the block whose header holds the hash
+		Expected []byte // hash of the block, or of the state of the node
+		Got      []byte // hash computed by the node
+	}
 )
 
 func (e ErrUnknownBlock) Error() string {
@@ -99,3 +109,7 @@ func (e ErrNoConsensusParamsForHeight) Error() string {
 func (e ErrNoABCIResponsesForHeight) Error() string {
 	return fmt.Sprintf("could not find results for height #%d", e.Height)
 }
+
+func (e ErrAppHashMismatch) Error() string {
+	return fmt.Sprintf("%s mismatch at height %d: expected %X, got %X", e.Field, e.Height, e.Expected, e.Got)
+}
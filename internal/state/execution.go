@@ -4,8 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/internal/libs/fail"
@@ -16,6 +22,9 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
+// tracer traces the execution of the blocks.
+var tracer = otel.Tracer("github.com/tendermint/tendermint/internal/state")
+
 //-----------------------------------------------------------------------------
 // BlockExecutor handles block execution and state updates.
 // It exposes ApplyBlock(), which validates & executes the block, updates state w/ ABCI responses,
@@ -43,8 +52,39 @@ type BlockExecutor struct {
 	logger  log.Logger
 	metrics *Metrics
 
+	// the number of most recent blocks whose ABCI responses are kept, or 0 to
+	// keep them as long as the blocks, atomic
+	retainResultsBlocks int64
+
+	// prunes in the background, if set
+	pruner *Pruner
+
+	// execute the blocks with FinalizeBlock, instead of BeginBlock, DeliverTx
+	// and EndBlock
+	finalizeBlock bool
+
+	// ask the application whether to accept the proposed blocks
+	processProposal bool
+
 	// cache the verification results over a single height
 	cache map[string]struct{}
+
+	// save the states, prune and publish the events of the blocks in the
+	// background, one block after the other
+	pipelineCommit bool
+
+	// finalized is closed once the state of the last block applied is saved,
+	// with finalizeErr set if it failed, when the commits are pipelined
+	finalizeMtx sync.Mutex
+	finalized   chan struct{}
+	finalizeErr error
+
+	// the reports of the app hash mismatches are written to this file, if set
+	mismatchReportFile string
+
+	// the report of the last app hash mismatch of a committed block
+	mismatchMtx    sync.Mutex
+	mismatchReport *MismatchReport
 }
 
 type BlockExecutorOption func(executor *BlockExecutor)
@@ -55,6 +95,66 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithRetainResultsBlocks keeps the ABCI responses of the given
+// number of most recent blocks, instead of pruning them with the blocks.
+func BlockExecutorWithRetainResultsBlocks(blocks int64) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.retainResultsBlocks = blocks
+	}
+}
+
+// SetRetainResultsBlocks changes the number of most recent blocks whose ABCI
+// responses are kept, 0 keeping them as long as the blocks. It applies from
+// the next block.
+func (blockExec *BlockExecutor) SetRetainResultsBlocks(blocks int64) {
+	atomic.StoreInt64(&blockExec.retainResultsBlocks, blocks)
+}
+
+// BlockExecutorWithPruner prunes the heights the application doesn't retain
+// with pruner, in the background, instead of in ApplyBlock.
+func BlockExecutorWithPruner(pruner *Pruner) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.pruner = pruner
+	}
+}
+
+// BlockExecutorWithFinalizeBlock executes the blocks with a single
+// FinalizeBlock call if enabled, which the application says it supports in its
+// Info response. The state is still committed with Commit.
+func BlockExecutorWithFinalizeBlock(enabled bool) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.finalizeBlock = enabled
+	}
+}
+
+// BlockExecutorWithProcessProposal asks the application whether to accept the
+// proposed blocks with ProcessProposal if enabled, which the application says
+// it supports in its Info response.
+func BlockExecutorWithProcessProposal(enabled bool) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.processProposal = enabled
+	}
+}
+
+// BlockExecutorWithPipelineCommit saves the state, prunes and publishes the
+// events of each block in the background if enabled, ApplyBlock returning
+// once the application committed the block. The state of a block is saved
+// before the next block is committed, so that the state store is at most one
+// block behind the application.
+func BlockExecutorWithPipelineCommit(enabled bool) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.pipelineCommit = enabled
+	}
+}
+
+// BlockExecutorWithMismatchReportFile writes the reports of the app hash
+// mismatches of the committed blocks to the file.
+func BlockExecutorWithMismatchReportFile(path string) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.mismatchReportFile = path
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -98,7 +198,9 @@ func (blockExec *BlockExecutor) SetEventBus(eventBus types.BlockEventPublisher)
 // CreateProposalBlock calls state.MakeBlock with evidence from the evpool
 // and txs from the mempool. The max bytes must be big enough to fit the commit.
 // Up to 1/10th of the block space is allcoated for maximum sized evidence.
-// The rest is given to txs, up to the max gas.
+// The rest is given to txs, up to the max gas, which the app prepares into the
+// txs of the block, see preprocessTxs. If the app's txs don't fit, no block is
+// proposed.
 func (blockExec *BlockExecutor) CreateProposalBlock(
 	height int64,
 	state State, commit *types.Commit,
@@ -113,13 +215,66 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	// Fetch a limited amount of valid txs
 	maxDataBytes := types.MaxDataBytes(maxBytes, evSize, state.Validators.Size())
 
-	// TODO(ismail): reaping the mempool has to happen in relation to a max
-	// allowed square size instead of (only) Gas / bytes
-	// maybe the mempool actually should track things separately
-	// meaning that CheckTx should already do the mapping:
-	// Tx -> Txs, Message
-	// https://github.com/tendermint/tendermint/issues/77
-	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
+	// The txs reaped must fit, once preprocessed by the app, in the original
+	// data square of the max square size: the smallest square fitting them is
+	// used. If they don't, fewer are reaped, in proportion of the shares
+	// exceeding the square.
+	maxSquareSize := state.ConsensusParams.Block.SquareSizeUpperBound()
+	maxShares := int(maxSquareSize * maxSquareSize)
+	var (
+		processedTxs types.Txs
+		messages     types.Messages
+	)
+	for {
+		txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
+		var err error
+		processedTxs, messages, err = blockExec.preprocessTxs(txs, maxDataBytes, maxGas)
+		if err != nil {
+			blockExec.logger.Error("app prepared an invalid proposal", "height", height, "err", err)
+			return nil, nil
+		}
+
+		data := types.Data{
+			Txs:      processedTxs,
+			Evidence: types.EvidenceData{Evidence: evidence},
+			Messages: messages,
+		}
+		shares := data.ShareCount()
+		if shares <= maxShares {
+			break
+		}
+		if len(txs) == 0 {
+			blockExec.logger.Error("app prepared an invalid proposal", "height", height,
+				"err", fmt.Errorf("%d shares don't fit in the max square size %d", shares, maxSquareSize))
+			return nil, nil
+		}
+		maxDataBytes = types.ComputeProtoSizeForTxs(txs) * int64(maxShares) / int64(shares)
+	}
+
+	// From the aggregated commit block version on, the signatures of the last
+	// commit are aggregated into one if the validators all have BLS keys.
+	if state.Version.Consensus.Block >= types.AggregatedCommitBlockVersion &&
+		commit.Size() > 0 && types.CanAggregateCommit(state.LastValidators) {
+		aggregated, err := commit.Aggregate()
+		if err != nil {
+			blockExec.logger.Error("failed to aggregate the last commit", "height", height, "err", err)
+		} else {
+			commit = aggregated
+		}
+	}
+
+	return state.MakeBlock(height, processedTxs, evidence, nil, messages.MessagesList, commit, proposerAddr)
+}
+
+// preprocessTxs has the app prepare the txs reaped into the txs and the
+// messages of the block, within the max bytes and gas: the app may reorder,
+// replace, or add to them, but the txs it returns must fit in the max bytes.
+// The blobs of the blob txs are laid out in the messages, and the blob txs
+// included without them.
+func (blockExec *BlockExecutor) preprocessTxs(
+	txs types.Txs,
+	maxDataBytes, maxGas int64,
+) (types.Txs, types.Messages, error) {
 	l := len(txs)
 	bzs := make([][]byte, l)
 	for i := 0; i < l; i++ {
@@ -129,11 +284,12 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	// TODO(ismail):
 	//  1. get those intermediate state roots & messages either from the
 	//     mempool or from the abci-app
-	//  1.1 at this point we should now the square / block size:
-	//      https://github.com/celestiaorg/celestia-specs/blob/53e5f350838f1e0785ad670704bf91dac2f4f5a3/specs/block_proposer.md#deciding-on-a-block-size
-	//      Here, we instead assume a fixed (max) square size instead.
-	//  2. feed them into MakeBlock below:
-	processedBlockTxs, err := blockExec.proxyApp.PreprocessTxsSync(context.TODO(), abci.RequestPreprocessTxs{Txs: bzs})
+	//  2. feed them into MakeBlock
+	processedBlockTxs, err := blockExec.proxyApp.PreprocessTxsSync(context.TODO(), abci.RequestPreprocessTxs{
+		Txs:      bzs,
+		MaxBytes: maxDataBytes,
+		MaxGas:   maxGas,
+	})
 	if err != nil {
 		// The App MUST ensure that only valid (and hence 'processable')
 		// Tx enter the mempool. Hence, at this point, we can't have any non-processable
@@ -149,17 +305,18 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 
 	pbmessages := processedBlockTxs.GetMessages()
 
-	lp := len(ppt)
-	processedTxs := make(types.Txs, lp)
-	if lp > 0 {
-		for i := 0; i < l; i++ {
-			processedTxs[i] = ppt[i]
-		}
+	processedTxs := make(types.Txs, len(ppt))
+	for i, tx := range ppt {
+		processedTxs[i] = tx
+	}
+	if size := types.ComputeProtoSizeForTxs(processedTxs); size > maxDataBytes {
+		return nil, types.Messages{}, fmt.Errorf("txs of %d bytes exceed the max data bytes %d", size, maxDataBytes)
 	}
 
 	messages := types.MessagesFromProto(pbmessages)
-
-	return state.MakeBlock(height, processedTxs, evidence, nil, messages.MessagesList, commit, proposerAddr)
+	processedTxs, blobs := processedTxs.SplitBlobs()
+	messages.MessagesList = append(messages.MessagesList, blobs...)
+	return processedTxs, messages, nil
 }
 
 // ValidateBlock validates the given block against the given state.
@@ -186,9 +343,70 @@ func (blockExec *BlockExecutor) ValidateBlock(state State, block *types.Block) e
 	return nil
 }
 
+// ProcessProposal returns whether the application accepts the proposed block,
+// which must be valid. The blocks whose blob txs commit to blobs missing from
+// their messages are rejected, the others are accepted unless the application
+// checks them, see BlockExecutorWithProcessProposal.
+func (blockExec *BlockExecutor) ProcessProposal(block *types.Block) (bool, error) {
+	if err := block.Data.VerifyBlobCommitments(); err != nil {
+		blockExec.logger.Info("rejecting proposal with invalid blob commitments", "height", block.Height, "err", err)
+		return false, nil
+	}
+	if !blockExec.processProposal {
+		return true, nil
+	}
+
+	txs := make([][]byte, len(block.Txs))
+	for i, tx := range block.Txs {
+		txs[i] = tx
+	}
+	res, err := blockExec.proxyApp.ProcessProposalSync(context.TODO(), abci.RequestProcessProposal{
+		Header: *block.Header.ToProto(),
+		Txs:    txs,
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.Accept, nil
+}
+
+// ReportMismatch reports the error of the validation of a committed block if
+// it is an ErrAppHashMismatch, and returns whether it did: the report is
+// logged, written to the mismatch report file and returned by MismatchReport.
+func (blockExec *BlockExecutor) ReportMismatch(err error) bool {
+	var mismatch ErrAppHashMismatch
+	if !errors.As(err, &mismatch) {
+		return false
+	}
+
+	report := NewMismatchReport(mismatch, blockExec.store, blockExec.blockStore)
+	blockExec.logger.Error("app hash mismatch, the application diverged from the network",
+		"field", report.Field, "height", report.Height, "expected", report.Expected, "got", report.Got,
+		"txs", len(report.TxHashes), "report", blockExec.mismatchReportFile)
+	if blockExec.mismatchReportFile != "" {
+		if err := report.SaveAs(blockExec.mismatchReportFile); err != nil {
+			blockExec.logger.Error("failed to save the app hash mismatch report", "err", err)
+		}
+	}
+
+	blockExec.mismatchMtx.Lock()
+	defer blockExec.mismatchMtx.Unlock()
+	blockExec.mismatchReport = report
+	return true
+}
+
+// MismatchReport returns the report of the last app hash mismatch of a
+// committed block, or nil if there was none.
+func (blockExec *BlockExecutor) MismatchReport() *MismatchReport {
+	blockExec.mismatchMtx.Lock()
+	defer blockExec.mismatchMtx.Unlock()
+	return blockExec.mismatchReport
+}
+
 // ApplyBlock validates the block against the state, executes it against the app,
 // fires the relevant events, commits the app, and saves the new state and responses.
-// It returns the new state.
+// It returns the new state. With the pipelined commits, the state is saved and
+// the events fired in the background, see Wait.
 // It's the only function that needs to be called
 // from outside this package to process and commit an entire block.
 // It takes a blockID to avoid recomputing the parts hash.
@@ -198,12 +416,19 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	// validate the block if we haven't already
 	if err := blockExec.ValidateBlock(state, block); err != nil {
+		blockExec.ReportMismatch(err)
 		return state, ErrInvalidBlock(err)
 	}
 
+	ctx, span := tracer.Start(context.Background(), "ApplyBlock", trace.WithAttributes(
+		attribute.Int64("height", block.Height),
+		attribute.Int("num_txs", len(block.Txs)),
+	))
+	defer span.End()
+
 	startTime := time.Now().UnixNano()
 	abciResponses, err := execBlockOnProxyApp(
-		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
+		ctx, blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight, blockExec.finalizeBlock,
 	)
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
@@ -241,8 +466,14 @@ func (blockExec *BlockExecutor) ApplyBlock(
 		return state, fmt.Errorf("commit failed for application: %v", err)
 	}
 
+	// The state of the previous block must be saved before the app commits
+	// this one, for the node to recover if it crashes.
+	if err := blockExec.Wait(); err != nil {
+		return state, err
+	}
+
 	// Lock mempool, commit app state, update mempoool.
-	appHash, retainHeight, err := blockExec.Commit(state, block, abciResponses.DeliverTxs)
+	appHash, retainHeight, err := blockExec.commit(ctx, state, block, abciResponses.DeliverTxs)
 	if err != nil {
 		return state, fmt.Errorf("commit failed for application: %v", err)
 	}
@@ -250,36 +481,84 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	// Update evpool with the latest state.
 	blockExec.evpool.Update(state, block.Evidence.Evidence)
 
-	fail.Fail() // XXX
+	// reset the verification cache
+	blockExec.cache = make(map[string]struct{})
 
-	// Update the app hash and save the state.
+	// Update the app hash.
 	state.AppHash = appHash
-	if err := blockExec.store.Save(state); err != nil {
-		return state, err
+
+	// Prune old heights, if requested by ABCI app, and old ABCI responses, if
+	// retained separately from the blocks.
+	resultsRetainHeight := retainHeight
+	if retainResultsBlocks := atomic.LoadInt64(&blockExec.retainResultsBlocks); retainResultsBlocks > 0 {
+		resultsRetainHeight = block.Height - retainResultsBlocks + 1
 	}
 
-	fail.Fail() // XXX
+	finalize := func(state State) error {
+		fail.Fail() // XXX
 
-	// Prune old heights, if requested by ABCI app.
-	if retainHeight > 0 {
-		pruned, err := blockExec.pruneBlocks(retainHeight)
-		if err != nil {
-			blockExec.logger.Error("failed to prune blocks", "retain_height", retainHeight, "err", err)
+		// Save the state.
+		if err := blockExec.store.Save(state); err != nil {
+			return err
+		}
+
+		fail.Fail() // XXX
+
+		if blockExec.pruner != nil {
+			blockExec.pruner.SetRetainHeights(retainHeight, resultsRetainHeight)
 		} else {
-			blockExec.logger.Debug("pruned blocks", "pruned", pruned, "retain_height", retainHeight)
+			blockExec.prune(retainHeight, resultsRetainHeight)
 		}
+
+		// Events are fired after everything else.
+		// NOTE: if we crash between Commit and Save, events wont be fired during replay
+		fireEvents(blockExec.logger, blockExec.eventBus, block, blockID, abciResponses, validatorUpdates)
+		return nil
 	}
 
-	// reset the verification cache
-	blockExec.cache = make(map[string]struct{})
+	if !blockExec.pipelineCommit {
+		if err := finalize(state); err != nil {
+			return state, err
+		}
+		return state, nil
+	}
 
-	// Events are fired after everything else.
-	// NOTE: if we crash between Commit and Save, events wont be fired during replay
-	fireEvents(blockExec.logger, blockExec.eventBus, block, blockID, abciResponses, validatorUpdates)
+	finalized := make(chan struct{})
+	blockExec.finalizeMtx.Lock()
+	blockExec.finalized = finalized
+	blockExec.finalizeMtx.Unlock()
+	go func(state State) {
+		err := finalize(state)
+		if err != nil {
+			blockExec.logger.Error("failed to save the state", "height", state.LastBlockHeight, "err", err)
+		}
+		blockExec.finalizeMtx.Lock()
+		blockExec.finalizeErr = err
+		blockExec.finalizeMtx.Unlock()
+		close(finalized)
+	}(state)
 
 	return state, nil
 }
 
+// Wait waits for the state of the last block applied to be saved and its
+// events fired, when the commits are pipelined, and returns the error saving
+// the state, if any.
+func (blockExec *BlockExecutor) Wait() error {
+	blockExec.finalizeMtx.Lock()
+	finalized := blockExec.finalized
+	blockExec.finalizeMtx.Unlock()
+	if finalized == nil {
+		return nil
+	}
+
+	<-finalized
+
+	blockExec.finalizeMtx.Lock()
+	defer blockExec.finalizeMtx.Unlock()
+	return blockExec.finalizeErr
+}
+
 // Commit locks the mempool, runs the ABCI Commit message, and updates the
 // mempool.
 // It returns the result of calling abci.Commit (the AppHash) and the height to retain (if any).
@@ -290,6 +569,15 @@ func (blockExec *BlockExecutor) Commit(
 	state State,
 	block *types.Block,
 	deliverTxResponses []*abci.ResponseDeliverTx,
+) ([]byte, int64, error) {
+	return blockExec.commit(context.Background(), state, block, deliverTxResponses)
+}
+
+func (blockExec *BlockExecutor) commit(
+	ctx context.Context,
+	state State,
+	block *types.Block,
+	deliverTxResponses []*abci.ResponseDeliverTx,
 ) ([]byte, int64, error) {
 	blockExec.mempool.Lock()
 	defer blockExec.mempool.Unlock()
@@ -303,7 +591,7 @@ func (blockExec *BlockExecutor) Commit(
 	}
 
 	// Commit block, get hash back
-	res, err := blockExec.proxyApp.CommitSync(context.Background())
+	res, err := blockExec.proxyApp.CommitSync(ctx)
 	if err != nil {
 		blockExec.logger.Error("client error during proxyAppConn.CommitSync", "err", err)
 		return nil, 0, err
@@ -335,11 +623,13 @@ func (blockExec *BlockExecutor) Commit(
 // Executes block's transactions on proxyAppConn.
 // Returns a list of transaction results and updates to the validator set
 func execBlockOnProxyApp(
+	ctx context.Context,
 	logger log.Logger,
 	proxyAppConn proxy.AppConnConsensus,
 	block *types.Block,
 	store Store,
 	initialHeight int64,
+	finalizeBlock bool,
 ) (*tmstate.ABCIResponses, error) {
 	var validTxs, invalidTxs = 0, 0
 
@@ -375,8 +665,6 @@ func execBlockOnProxyApp(
 		byzVals = append(byzVals, evidence.ABCI()...)
 	}
 
-	ctx := context.Background()
-
 	// Begin block
 	var err error
 	pbh := block.Header.ToProto()
@@ -384,6 +672,15 @@ func execBlockOnProxyApp(
 		return nil, errors.New("nil header")
 	}
 
+	if finalizeBlock {
+		return finalizeBlockOnProxyApp(ctx, logger, proxyAppConn, block, abci.RequestFinalizeBlock{
+			Hash:                block.Hash(),
+			Header:              *pbh,
+			LastCommitInfo:      commitInfo,
+			ByzantineValidators: byzVals,
+		})
+	}
+
 	abciResponses.BeginBlock, err = proxyAppConn.BeginBlockSync(
 		ctx,
 		abci.RequestBeginBlock{
@@ -416,6 +713,54 @@ func execBlockOnProxyApp(
 	return abciResponses, nil
 }
 
+// finalizeBlockOnProxyApp executes the block with a single FinalizeBlock call,
+// and returns its response as the responses to BeginBlock, DeliverTx and
+// EndBlock would be.
+func finalizeBlockOnProxyApp(
+	ctx context.Context,
+	logger log.Logger,
+	proxyAppConn proxy.AppConnConsensus,
+	block *types.Block,
+	req abci.RequestFinalizeBlock,
+) (*tmstate.ABCIResponses, error) {
+	req.Txs = make([][]byte, len(block.Txs))
+	for i, tx := range block.Txs {
+		req.Txs[i] = tx
+	}
+
+	res, err := proxyAppConn.FinalizeBlockSync(ctx, req)
+	if err != nil {
+		logger.Error("error in proxyAppConn.FinalizeBlock", "err", err)
+		return nil, err
+	}
+	if len(res.TxResults) != len(block.Txs) {
+		return nil, fmt.Errorf("expected %d tx results from FinalizeBlock, got %d", len(block.Txs), len(res.TxResults))
+	}
+
+	var validTxs, invalidTxs = 0, 0
+	for _, txRes := range res.TxResults {
+		if txRes == nil {
+			return nil, errors.New("nil tx result from FinalizeBlock")
+		}
+		if txRes.Code == abci.CodeTypeOK {
+			validTxs++
+		} else {
+			logger.Debug("invalid tx", "code", txRes.Code, "log", txRes.Log)
+			invalidTxs++
+		}
+	}
+
+	logger.Info("executed block", "height", block.Height, "num_valid_txs", validTxs, "num_invalid_txs", invalidTxs)
+	return &tmstate.ABCIResponses{
+		DeliverTxs: res.TxResults,
+		BeginBlock: &abci.ResponseBeginBlock{Events: res.Events},
+		EndBlock: &abci.ResponseEndBlock{
+			ValidatorUpdates:      res.ValidatorUpdates,
+			ConsensusParamUpdates: res.ConsensusParamUpdates,
+		},
+	}, nil
+}
+
 func getBeginBlockValidatorInfo(block *types.Block, store Store,
 	initialHeight int64) abci.LastCommitInfo {
 	voteInfos := make([]abci.VoteInfo, block.LastCommit.Size())
@@ -619,8 +964,15 @@ func ExecCommitBlock(
 	store Store,
 	initialHeight int64,
 	s State,
+	finalizeBlock bool,
 ) ([]byte, error) {
-	abciResponses, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight)
+	ctx, span := tracer.Start(context.Background(), "ExecCommitBlock", trace.WithAttributes(
+		attribute.Int64("height", block.Height),
+		attribute.Int("num_txs", len(block.Txs)),
+	))
+	defer span.End()
+
+	abciResponses, err := execBlockOnProxyApp(ctx, logger, appConnConsensus, block, store, initialHeight, finalizeBlock)
 	if err != nil {
 		logger.Error("failed executing block on proxy app", "height", block.Height, "err", err)
 		return nil, err
@@ -640,12 +992,12 @@ func ExecCommitBlock(
 			return nil, err
 		}
 
-		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(types.BlockPartSizeBytes).Header()}
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSetForParams(s.ConsensusParams.Block).Header()}
 		fireEvents(be.logger, be.eventBus, block, blockID, abciResponses, validatorUpdates)
 	}
 
 	// Commit block, get hash back
-	res, err := appConnConsensus.CommitSync(context.Background())
+	res, err := appConnConsensus.CommitSync(ctx)
 	if err != nil {
 		logger.Error("client error during proxyAppConn.CommitSync", "err", res)
 		return nil, err
@@ -655,6 +1007,25 @@ func ExecCommitBlock(
 	return res.Data, nil
 }
 
+// prune prunes the blocks, states and ABCI responses below the retain heights,
+// synchronously, when the executor has no Pruner.
+func (blockExec *BlockExecutor) prune(retainHeight, resultsRetainHeight int64) {
+	if retainHeight > 0 {
+		pruned, err := blockExec.pruneBlocks(retainHeight)
+		if err != nil {
+			blockExec.logger.Error("failed to prune blocks", "retain_height", retainHeight, "err", err)
+		} else {
+			blockExec.logger.Debug("pruned blocks", "pruned", pruned, "retain_height", retainHeight)
+		}
+	}
+
+	if resultsRetainHeight > 1 {
+		if err := blockExec.store.PruneABCIResponses(resultsRetainHeight); err != nil {
+			blockExec.logger.Error("failed to prune block results", "retain_height", resultsRetainHeight, "err", err)
+		}
+	}
+}
+
 func (blockExec *BlockExecutor) pruneBlocks(retainHeight int64) (uint64, error) {
 	base := blockExec.blockStore.Base()
 	if retainHeight <= base {
@@ -2,6 +2,8 @@ package state_test
 
 import (
 	"context"
+	"errors"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -9,6 +11,9 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	dbm "github.com/tendermint/tm-db"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -25,6 +30,7 @@ import (
 	testfactory "github.com/tendermint/tendermint/internal/test/factory"
 	"github.com/tendermint/tendermint/libs/log"
 	tmtime "github.com/tendermint/tendermint/libs/time"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 	"github.com/tendermint/tendermint/version"
 )
@@ -58,6 +64,266 @@ func TestApplyBlock(t *testing.T) {
 	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
 }
 
+func TestApplyBlockAppHashMismatch(t *testing.T) {
+	app := &testApp{}
+	cc := abciclient.NewLocalCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	reportFile := filepath.Join(t.TempDir(), "mismatch_report.json")
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore,
+		sm.BlockExecutorWithMismatchReportFile(reportFile))
+
+	block := sf.MakeBlock(state, 1, new(types.Commit))
+	block.AppHash = []byte("diverged")
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	_, err = blockExec.ApplyBlock(state, blockID, block)
+	var mismatch sm.ErrAppHashMismatch
+	require.True(t, errors.As(err, &mismatch), err)
+	assert.Equal(t, "AppHash", mismatch.Field)
+
+	// the mismatch is reported, and written to the file
+	report := blockExec.MismatchReport()
+	require.NotNil(t, report)
+	assert.EqualValues(t, 1, report.Height)
+	assert.EqualValues(t, block.AppHash, report.Expected)
+	assert.EqualValues(t, state.AppHash, report.Got)
+
+	saved, err := sm.LoadMismatchReport(reportFile)
+	require.NoError(t, err)
+	assert.Equal(t, report.Height, saved.Height)
+	assert.Equal(t, report.Expected, saved.Expected)
+}
+
+func TestApplyBlockTraces(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defaultProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(defaultProvider)
+
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore)
+
+	block := sf.MakeBlock(state, 1, new(types.Commit))
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	_, err := blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	root, ok := spans["ApplyBlock"]
+	require.True(t, ok)
+	assert.False(t, root.Parent().IsValid())
+	for _, name := range []string{"abci.BeginBlock", "abci.EndBlock", "abci.Commit"} {
+		span, ok := spans[name]
+		require.True(t, ok, name)
+		assert.Equal(t, root.SpanContext().SpanID(), span.Parent().SpanID(), name)
+		assert.Equal(t, root.SpanContext().TraceID(), span.SpanContext().TraceID(), name)
+	}
+}
+
+func TestApplyBlockRetainResultsBlocks(t *testing.T) {
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore, sm.BlockExecutorWithRetainResultsBlocks(2))
+
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for height := int64(1); height <= 5; height++ {
+		var err error
+		state, _, lastCommit, err = makeAndCommitGoodBlock(
+			state, height, lastCommit, state.Validators.GetProposer().Address, blockExec, privVals, nil)
+		require.NoError(t, err, "height %d", height)
+	}
+
+	// only the results of the last 2 blocks are kept
+	for height := int64(1); height <= 5; height++ {
+		_, err := stateStore.LoadABCIResponses(height)
+		if height < 4 {
+			require.Error(t, err, height)
+		} else {
+			require.NoError(t, err, height)
+		}
+	}
+}
+
+// TestApplyBlockPipelineCommit ensures the states of the blocks are saved,
+// and their events published, in order in the background.
+func TestApplyBlockPipelineCommit(t *testing.T) {
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore, sm.BlockExecutorWithPipelineCommit(true))
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	defer eventBus.Stop() //nolint:errcheck // ignore for tests
+	blockExec.SetEventBus(eventBus)
+
+	sub, err := eventBus.Subscribe(context.Background(), "TestApplyBlockPipelineCommit", types.EventQueryNewBlock, 5)
+	require.NoError(t, err)
+
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for height := int64(1); height <= 5; height++ {
+		state, _, lastCommit, err = makeAndCommitGoodBlock(
+			state, height, lastCommit, state.Validators.GetProposer().Address, blockExec, privVals, nil)
+		require.NoError(t, err, "height %d", height)
+	}
+
+	require.NoError(t, blockExec.Wait())
+	saved, err := stateStore.Load()
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, saved.LastBlockHeight)
+	assert.Equal(t, state.AppHash, saved.AppHash)
+
+	for height := int64(1); height <= 5; height++ {
+		select {
+		case msg := <-sub.Out():
+			assert.Equal(t, height, msg.Data().(types.EventDataNewBlock).Block.Height)
+		case <-time.After(time.Second):
+			t.Fatalf("no NewBlock event for height %d", height)
+		}
+	}
+}
+
+// TestApplyBlockFinalizeBlock ensures the block is executed with a single
+// FinalizeBlock call, and its response is saved like the responses to
+// BeginBlock, DeliverTx and EndBlock.
+func TestApplyBlockFinalizeBlock(t *testing.T) {
+	app := &finalizeBlockApp{}
+	cc := abciclient.NewLocalCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore, sm.BlockExecutorWithFinalizeBlock(true))
+
+	block := sf.MakeBlock(state, 1, new(types.Commit))
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	state, err := blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
+
+	require.Len(t, app.txs, len(block.Txs))
+	for i, tx := range block.Txs {
+		assert.EqualValues(t, tx, app.txs[i])
+	}
+
+	abciResponses, err := stateStore.LoadABCIResponses(1)
+	require.NoError(t, err)
+	require.Len(t, abciResponses.DeliverTxs, len(block.Txs))
+	assert.Equal(t, uint32(1), abciResponses.DeliverTxs[0].Code)
+	assert.Equal(t, "finalize", abciResponses.BeginBlock.Events[0].Type)
+
+	// every tx has a result
+	app.dropResult = true
+	block = sf.MakeBlock(state, 2, new(types.Commit))
+	blockID = types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	_, err = blockExec.ApplyBlock(state, blockID, block)
+	require.Error(t, err)
+}
+
+type finalizeBlockApp struct {
+	abci.BaseApplication
+
+	txs        [][]byte
+	dropResult bool
+}
+
+func (app *finalizeBlockApp) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	panic("BeginBlock called in FinalizeBlock mode")
+}
+
+func (app *finalizeBlockApp) FinalizeBlock(req abci.RequestFinalizeBlock) abci.ResponseFinalizeBlock {
+	app.txs = req.Txs
+	txResults := make([]*abci.ResponseDeliverTx, len(req.Txs))
+	for i := range txResults {
+		txResults[i] = &abci.ResponseDeliverTx{Code: uint32(i + 1)}
+	}
+	if app.dropResult {
+		txResults = txResults[1:]
+	}
+	return abci.ResponseFinalizeBlock{
+		Events:    []abci.Event{{Type: "finalize"}},
+		TxResults: txResults,
+		ConsensusParamUpdates: &tmproto.ConsensusParams{
+			Version: &tmproto.VersionParams{AppVersion: 1}},
+	}
+}
+
+type processProposalApp struct {
+	abci.BaseApplication
+
+	calls  int
+	reject bool
+}
+
+func (app *processProposalApp) ProcessProposal(req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+	app.calls++
+	return abci.ResponseProcessProposal{Accept: !app.reject}
+}
+
+// TestProcessProposal ensures the app is only asked whether to accept the
+// proposed blocks if it checks them.
+func TestProcessProposal(t *testing.T) {
+	app := &processProposalApp{reject: true}
+	cc := abciclient.NewLocalCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	block := sf.MakeBlock(state, 1, new(types.Commit))
+
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore)
+	accept, err := blockExec.ProcessProposal(block)
+	require.NoError(t, err)
+	assert.True(t, accept)
+	assert.Zero(t, app.calls)
+
+	blockExec = sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore, sm.BlockExecutorWithProcessProposal(true))
+	accept, err = blockExec.ProcessProposal(block)
+	require.NoError(t, err)
+	assert.False(t, accept)
+	assert.Equal(t, 1, app.calls)
+}
+
 // TestBeginBlockValidators ensures we send absent validators list.
 func TestBeginBlockValidators(t *testing.T) {
 	app := &testApp{}
@@ -111,7 +377,7 @@ func TestBeginBlockValidators(t *testing.T) {
 			state.Validators.GetProposer().Address,
 		)
 
-		_, err = sm.ExecCommitBlock(nil, proxyApp.Consensus(), block, log.TestingLogger(), stateStore, 1, state)
+		_, err = sm.ExecCommitBlock(nil, proxyApp.Consensus(), block, log.TestingLogger(), stateStore, 1, state, false)
 		require.Nil(t, err, tc.desc)
 
 		// -> app receives a list of validators with a bool indicating if they signed
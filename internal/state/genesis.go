@@ -0,0 +1,222 @@
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/orderedcode"
+	dbm "github.com/tendermint/tm-db"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/types"
+)
+
+// GenesisChunkSize is the size of the chunks of the genesis doc, as served
+// by /genesis_chunked.
+const GenesisChunkSize = 16 * 1024 * 1024 // 16 MiB
+
+const (
+	// prefixes are unique across all tm db's
+	prefixGenesis      = int64(15)
+	prefixGenesisChunk = int64(16)
+)
+
+var (
+	// genesisDocKey holds the genesis doc without its app_state, and
+	// genesisHashKey its canonical hash. They are written last, once the
+	// chunks are saved.
+	genesisDocKey  []byte
+	genesisHashKey []byte
+)
+
+func init() {
+	var err error
+	if genesisDocKey, err = orderedcode.Append(nil, prefixGenesis, "doc"); err != nil {
+		panic(err)
+	}
+	if genesisHashKey, err = orderedcode.Append(nil, prefixGenesis, "hash"); err != nil {
+		panic(err)
+	}
+}
+
+func genesisChunkKey(chunk int) []byte {
+	return encodeKey(prefixGenesisChunk, int64(chunk))
+}
+
+// GenesisStore keeps the genesis doc in the state DB once it is loaded, so
+// that the genesis file, which can be several GB large, isn't parsed on every
+// start. It holds the genesis doc without its app_state, which is only needed
+// at genesis, and the JSON encoding of the whole genesis doc in chunks of
+// GenesisChunkSize bytes.
+type GenesisStore struct {
+	db dbm.DB
+}
+
+// NewGenesisStore returns the genesis store of the state DB.
+func NewGenesisStore(db dbm.DB) *GenesisStore {
+	return &GenesisStore{db: db}
+}
+
+// Load returns the saved genesis doc, without its app_state, and its
+// canonical hash. It returns nil if no genesis doc was saved.
+func (s *GenesisStore) Load() (*types.GenesisDoc, []byte, error) {
+	bz, err := s.db.Get(genesisDocKey)
+	if err != nil || len(bz) == 0 {
+		return nil, nil, err
+	}
+	genDoc := &types.GenesisDoc{}
+	if err := tmjson.Unmarshal(bz, genDoc); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode the saved genesis doc: %w", err)
+	}
+	hash, err := s.db.Get(genesisHashKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return genDoc, hash, nil
+}
+
+// Save saves the genesis doc, which must be complete (see
+// types.GenesisDoc.ValidateAndComplete), with its canonical hash.
+func (s *GenesisStore) Save(genDoc *types.GenesisDoc, hash []byte) error {
+	doc, err := genesisDocWithoutAppState(genDoc)
+	if err != nil {
+		return err
+	}
+
+	// The JSON encoding of the genesis doc ends with app_state: it is the
+	// encoding of the genesis doc without it, followed by app_state. The
+	// chunks are written as they are filled, not to hold a copy of app_state.
+	w := &genesisChunkWriter{db: s.db}
+	if len(genDoc.AppState) == 0 {
+		w.Write(doc)
+	} else {
+		w.Write(doc[:len(doc)-1])
+		w.Write([]byte(`,"app_state":`))
+		w.Write(genDoc.AppState)
+		w.Write([]byte("}"))
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to save the genesis chunks: %w", err)
+	}
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(genesisHashKey, hash); err != nil {
+		return err
+	}
+	if err := batch.Set(genesisDocKey, doc); err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
+
+// NumChunks returns the number of chunks of the saved genesis doc.
+func (s *GenesisStore) NumChunks() (int, error) {
+	for i := 0; ; i++ {
+		ok, err := s.db.Has(genesisChunkKey(i))
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return i, nil
+		}
+	}
+}
+
+// LoadChunk returns a chunk of the JSON encoding of the saved genesis doc.
+func (s *GenesisStore) LoadChunk(chunk int) ([]byte, error) {
+	bz, err := s.db.Get(genesisChunkKey(chunk))
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, fmt.Errorf("no genesis chunk %d", chunk)
+	}
+	return bz, nil
+}
+
+// LoadAppState returns the app_state of the saved genesis doc, read from
+// its chunks.
+func (s *GenesisStore) LoadAppState() (json.RawMessage, error) {
+	bz, err := s.db.Get(genesisDocKey)
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, errors.New("no genesis doc saved")
+	}
+	var full bytes.Buffer
+	for i := 0; ; i++ {
+		chunk, err := s.db.Get(genesisChunkKey(i))
+		if err != nil {
+			return nil, err
+		}
+		if chunk == nil {
+			break
+		}
+		full.Write(chunk)
+	}
+
+	prefix := len(bz) - 1 + len(`,"app_state":`)
+	if full.Len() <= prefix {
+		return nil, nil
+	}
+	return full.Bytes()[prefix : full.Len()-1], nil
+}
+
+// genesisDocWithoutAppState returns the JSON encoding of the genesis doc,
+// without app_state.
+func genesisDocWithoutAppState(genDoc *types.GenesisDoc) ([]byte, error) {
+	doc := *genDoc
+	doc.AppState = nil
+	return tmjson.Marshal(doc)
+}
+
+// genesisChunkWriter writes data in chunks of GenesisChunkSize bytes to the
+// DB. Errors are returned by Close.
+type genesisChunkWriter struct {
+	db    dbm.DB
+	buf   []byte
+	chunk int
+	err   error
+}
+
+func (w *genesisChunkWriter) Write(p []byte) {
+	for len(p) > 0 && w.err == nil {
+		n := GenesisChunkSize - len(w.buf)
+		if n > len(p) {
+			n = len(p)
+		}
+		w.buf = append(w.buf, p[:n]...)
+		p = p[n:]
+		if len(w.buf) == GenesisChunkSize {
+			w.flush()
+		}
+	}
+}
+
+func (w *genesisChunkWriter) flush() {
+	// the DB may keep the slice
+	if w.err = w.db.Set(genesisChunkKey(w.chunk), w.buf); w.err == nil {
+		w.chunk++
+		w.buf = nil
+	}
+}
+
+// Close writes the last chunk, and deletes the chunks of a genesis doc saved
+// before.
+func (w *genesisChunkWriter) Close() error {
+	if len(w.buf) > 0 && w.err == nil {
+		w.flush()
+	}
+	for i := w.chunk; w.err == nil; i++ {
+		var ok bool
+		if ok, w.err = w.db.Has(genesisChunkKey(i)); !ok {
+			break
+		}
+		w.err = w.db.Delete(genesisChunkKey(i))
+	}
+	return w.err
+}
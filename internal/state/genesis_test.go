@@ -0,0 +1,71 @@
+package state_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	sm "github.com/tendermint/tendermint/internal/state"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestGenesisStore(t *testing.T) {
+	store := sm.NewGenesisStore(dbm.NewMemDB())
+
+	genDoc, hash, err := store.Load()
+	require.NoError(t, err)
+	assert.Nil(t, genDoc)
+	assert.Nil(t, hash)
+
+	// an app_state spanning several chunks
+	appState := []byte(`{"data":"` + strings.Repeat("a", sm.GenesisChunkSize+1024) + `"}`)
+	large, err := types.NewGenesisBuilder("test-chain", time.Now()).AppState(appState).Build()
+	require.NoError(t, err)
+	hash, err = large.Hash()
+	require.NoError(t, err)
+	require.NoError(t, store.Save(large, hash))
+
+	loaded, loadedHash, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, hash, loadedHash)
+	assert.Empty(t, loaded.AppState)
+	assert.Equal(t, large.ChainID, loaded.ChainID)
+	assert.Equal(t, large.ConsensusParams, loaded.ConsensusParams)
+
+	loadedAppState, err := store.LoadAppState()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(large.AppState), []byte(loadedAppState))
+
+	// the chunks make up the JSON encoding of the whole genesis doc
+	n, err := store.NumChunks()
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	var chunks bytes.Buffer
+	for i := 0; i < n; i++ {
+		chunk, err := store.LoadChunk(i)
+		require.NoError(t, err)
+		chunks.Write(chunk)
+	}
+	expected, err := tmjson.Marshal(large)
+	require.NoError(t, err)
+	assert.Equal(t, expected, chunks.Bytes())
+	_, err = store.LoadChunk(n)
+	assert.Error(t, err)
+
+	// saving a smaller genesis doc deletes the chunks left over
+	small, err := types.NewGenesisBuilder("test-chain", time.Now()).Build()
+	require.NoError(t, err)
+	require.NoError(t, store.Save(small, hash))
+	n, err = store.NumChunks()
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	loadedAppState, err = store.LoadAppState()
+	require.NoError(t, err)
+	assert.Empty(t, loadedAppState)
+}
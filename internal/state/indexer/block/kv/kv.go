@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/google/orderedcode"
@@ -91,11 +90,69 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 	default:
 	}
 
-	conditions, err := q.Conditions()
+	conjunctions, err := q.Disjunction()
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse query conditions: %w", err)
 	}
 
+	filteredHeights := make(map[string][]byte)
+	for _, conj := range conjunctions {
+		heights, err := idx.matchConditions(ctx, conj.Conditions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range conj.Negated {
+			if len(heights) == 0 {
+				break
+			}
+			negated, err := idx.matchConditions(ctx, []query.Condition{c})
+			if err != nil {
+				return nil, err
+			}
+			for k := range negated {
+				delete(heights, k)
+			}
+		}
+
+		for k, h := range heights {
+			filteredHeights[k] = h
+		}
+	}
+
+	// fetch matching heights
+	results = make([]int64, 0, len(filteredHeights))
+heights:
+	for _, hBz := range filteredHeights {
+		h := int64FromBytes(hBz)
+
+		ok, err := idx.Has(h)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			results = append(results, h)
+		}
+
+		select {
+		case <-ctx.Done():
+			break heights
+
+		default:
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+
+	return results, nil
+}
+
+// matchConditions returns the heights of the blocks matching all the
+// conditions, or of all the blocks if there are none.
+func (idx *BlockerIndexer) matchConditions(
+	ctx context.Context,
+	conditions []query.Condition,
+) (map[string][]byte, error) {
 	// If there is an exact height query, return the result immediately
 	// (if it exists).
 	height, ok := lookForHeight(conditions)
@@ -106,10 +163,16 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 		}
 
 		if ok {
-			return []int64{height}, nil
+			heightBz := int64ToBytes(height)
+			return map[string][]byte{string(heightBz): heightBz}, nil
 		}
 
-		return results, nil
+		return map[string][]byte{}, nil
+	}
+
+	// all the blocks are indexed by height
+	if len(conditions) == 0 {
+		conditions = []query.Condition{{CompositeKey: types.BlockHeightKey, Op: query.OpExists}}
 	}
 
 	var heightsInitialized bool
@@ -184,31 +247,7 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 		}
 	}
 
-	// fetch matching heights
-	results = make([]int64, 0, len(filteredHeights))
-heights:
-	for _, hBz := range filteredHeights {
-		h := int64FromBytes(hBz)
-
-		ok, err := idx.Has(h)
-		if err != nil {
-			return nil, err
-		}
-		if ok {
-			results = append(results, h)
-		}
-
-		select {
-		case <-ctx.Done():
-			break heights
-
-		default:
-		}
-	}
-
-	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
-
-	return results, nil
+	return filteredHeights, nil
 }
 
 // matchRange returns all matching block heights that match a given QueryRange
@@ -232,10 +271,18 @@ func (idx *BlockerIndexer) matchRange(
 	}
 
 	tmpHeights := make(map[string][]byte)
-	lowerBound := qr.LowerBoundValue()
-	upperBound := qr.UpperBoundValue()
 
-	it, err := dbm.IteratePrefix(idx.store, startKey)
+	// the heights are ordered in the keys, so only the range is scanned
+	var it dbm.Iterator
+	start, end, err := heightKeyRange(qr)
+	if err != nil {
+		return nil, err
+	}
+	if end != nil {
+		it, err = idx.store.Iterator(start, end)
+	} else {
+		it, err = dbm.IteratePrefix(idx.store, startKey)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create prefix iterator: %w", err)
 	}
@@ -258,24 +305,8 @@ iter:
 			continue
 		}
 
-		if _, ok := qr.AnyBound().(int64); ok {
-			v, err := strconv.ParseInt(eventValue, 10, 64)
-			if err != nil {
-				continue iter
-			}
-
-			include := true
-			if lowerBound != nil && v < lowerBound.(int64) {
-				include = false
-			}
-
-			if upperBound != nil && v > upperBound.(int64) {
-				include = false
-			}
-
-			if include {
-				tmpHeights[string(it.Value())] = it.Value()
-			}
+		if qr.Matches(eventValue) {
+			tmpHeights[string(it.Value())] = it.Value()
 		}
 
 		select {
@@ -129,6 +129,22 @@ func TestBlockIndexer(t *testing.T) {
 			q:       query.MustParse("begin_event.proposer CONTAINS 'FCAA001'"),
 			results: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
 		},
+		"block.height >= 3 AND block.height < 6": {
+			q:       query.MustParse("block.height >= 3 AND block.height < 6"),
+			results: []int64{3, 4, 5},
+		},
+		"end_event.foo = 2 OR end_event.foo = 6": {
+			q:       query.MustParse("end_event.foo = 2 OR end_event.foo = 6"),
+			results: []int64{2, 6},
+		},
+		"end_event.foo <= 8 AND NOT block.height = 4": {
+			q:       query.MustParse("end_event.foo <= 8 AND NOT block.height = 4"),
+			results: []int64{2, 6, 8},
+		},
+		"end_event.foo > 3.5 AND end_event.foo < 8.5": {
+			q:       query.MustParse("end_event.foo > 3.5 AND end_event.foo < 8.5"),
+			results: []int64{4, 6, 8},
+		},
 	}
 
 	for name, tc := range testCases {
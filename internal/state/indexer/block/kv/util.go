@@ -3,9 +3,11 @@ package kv
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 	"strconv"
 
 	"github.com/google/orderedcode"
+	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
 	"github.com/tendermint/tendermint/types"
 )
@@ -39,6 +41,39 @@ func heightKey(height int64) ([]byte, error) {
 	)
 }
 
+// heightKeyRange returns the range of the height keys of the blocks within qr,
+// or nil if qr is not a range of block heights with an integer upper bound.
+func heightKeyRange(qr indexer.QueryRange) (start, end []byte, err error) {
+	if qr.Key != types.BlockHeightKey {
+		return nil, nil, nil
+	}
+	if _, ok := qr.UpperBound.(int64); !ok {
+		return nil, nil, nil
+	}
+	upper := qr.UpperBoundValue().(int64)
+	if upper == math.MaxInt64 {
+		return nil, nil, nil
+	}
+
+	switch qr.LowerBound.(type) {
+	case int64:
+		start, err = heightKey(qr.LowerBoundValue().(int64))
+	case nil:
+		start, err = orderedcode.Append(nil, types.BlockHeightKey)
+	default:
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	end, err = heightKey(upper + 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return start, end, nil
+}
+
 func eventKey(compositeKey, typ, eventValue string, height int64) ([]byte, error) {
 	return orderedcode.Append(
 		nil,
@@ -42,12 +42,12 @@ must ensure the following:
 3. The block and transaction event schemas have been created in the PostgreSQL database.
 
 Tendermint provides the block and transaction event schemas in the following
-path: state/indexer/sink/psql/schema.sql
+path: internal/state/indexer/sink/psql/schema.sql
 
 To create the schema in a PostgreSQL database, perform the schema query
 manually or invoke schema creation via the CLI:
 
-	$ psql <flags> -f state/indexer/sink/psql/schema.sql
+	$ psql <flags> -f internal/state/indexer/sink/psql/schema.sql
 
 The "psql" indexing sink prohibits queries via RPC. When using a PostgreSQL sink,
 queries can and should be made directly against the database using SQL.
@@ -0,0 +1,123 @@
+package indexer
+
+import (
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// EventFilter selects the event attributes to index, with patterns of event
+// types or of composite keys ("type.key"), where "*" matches any sequence of
+// characters. An event type matches all the attributes of its events.
+type EventFilter struct {
+	include []string
+	exclude []string
+}
+
+// NewEventFilter returns a filter of the attributes matching a pattern of
+// include, or all of them if include is empty, and none of exclude.
+func NewEventFilter(include, exclude []string) *EventFilter {
+	return &EventFilter{include: include, exclude: exclude}
+}
+
+// Empty returns whether the filter selects all the attributes.
+func (f *EventFilter) Empty() bool {
+	return len(f.include) == 0 && len(f.exclude) == 0
+}
+
+// Indexed returns whether the attribute key of the events of type eventType is
+// selected by the filter.
+func (f *EventFilter) Indexed(eventType, key string) bool {
+	compositeKey := eventType + "." + key
+	matchAny := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if matchPattern(pattern, eventType) || matchPattern(pattern, compositeKey) {
+				return true
+			}
+		}
+		return false
+	}
+	return (len(f.include) == 0 || matchAny(f.include)) && !matchAny(f.exclude)
+}
+
+// filterEvents returns a copy of events where the attributes not selected by
+// the filter are not indexed. The events are shared with the other
+// subscribers of the event bus, and are not modified.
+func (f *EventFilter) filterEvents(events []abci.Event) []abci.Event {
+	if len(events) == 0 {
+		return events
+	}
+
+	filtered := make([]abci.Event, len(events))
+	for i, event := range events {
+		filtered[i] = abci.Event{
+			Type:       event.Type,
+			Attributes: make([]abci.EventAttribute, len(event.Attributes)),
+		}
+		for j, attr := range event.Attributes {
+			attr.Index = attr.Index && f.Indexed(event.Type, attr.Key)
+			filtered[i].Attributes[j] = attr
+		}
+	}
+	return filtered
+}
+
+// matchPattern returns whether s matches pattern, where "*" matches any
+// sequence of characters.
+func matchPattern(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+
+	// the first part is a prefix, the last a suffix, and the others are
+	// matched from left to right in between
+	first, last := parts[0], parts[len(parts)-1]
+	if len(s) < len(first)+len(last) || !strings.HasPrefix(s, first) || !strings.HasSuffix(s, last) {
+		return false
+	}
+	s = s[len(first) : len(s)-len(last)]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return true
+}
+
+// filteredEventSink is an EventSink indexing only the attributes selected by
+// a filter.
+type filteredEventSink struct {
+	EventSink
+	filter *EventFilter
+}
+
+// NewFilteredEventSink returns an EventSink indexing in sink only the
+// attributes selected by filter. The searches are left to sink.
+func NewFilteredEventSink(sink EventSink, filter *EventFilter) EventSink {
+	if filter.Empty() {
+		return sink
+	}
+	return &filteredEventSink{EventSink: sink, filter: filter}
+}
+
+// IndexBlockEvents implements EventSink.
+func (es *filteredEventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
+	h.ResultBeginBlock.Events = es.filter.filterEvents(h.ResultBeginBlock.Events)
+	h.ResultEndBlock.Events = es.filter.filterEvents(h.ResultEndBlock.Events)
+	return es.EventSink.IndexBlockEvents(h)
+}
+
+// IndexTxEvents implements EventSink.
+func (es *filteredEventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	filtered := make([]*abci.TxResult, len(txrs))
+	for i, txr := range txrs {
+		txr := *txr
+		txr.Result.Events = es.filter.filterEvents(txr.Result.Events)
+		filtered[i] = &txr
+	}
+	return es.EventSink.IndexTxEvents(filtered)
+}
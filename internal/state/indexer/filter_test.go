@@ -0,0 +1,94 @@
+package indexer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	indexer "github.com/tendermint/tendermint/internal/state/indexer"
+	kv "github.com/tendermint/tendermint/internal/state/indexer/sink/kv"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestEventFilterIndexed(t *testing.T) {
+	testCases := []struct {
+		include, exclude []string
+		eventType, key   string
+		indexed          bool
+	}{
+		{nil, nil, "transfer", "sender", true},
+		{[]string{"transfer"}, nil, "transfer", "sender", true},
+		{[]string{"transfer"}, nil, "message", "sender", false},
+		{[]string{"transfer.sender"}, nil, "transfer", "sender", true},
+		{[]string{"transfer.sender"}, nil, "transfer", "recipient", false},
+		{[]string{"*.sender"}, nil, "message", "sender", true},
+		{[]string{"trans*"}, nil, "transfer", "sender", true},
+		{[]string{"t*r.s*r"}, nil, "transfer", "sender", true},
+		{[]string{"t*r.s*r"}, nil, "transfer", "amount", false},
+		{[]string{"*"}, nil, "transfer", "sender", true},
+		{nil, []string{"transfer"}, "transfer", "sender", false},
+		{nil, []string{"transfer"}, "message", "sender", true},
+		{nil, []string{"*.memo"}, "transfer", "memo", false},
+		{[]string{"transfer"}, []string{"transfer.memo"}, "transfer", "memo", false},
+		{[]string{"transfer"}, []string{"transfer.memo"}, "transfer", "sender", true},
+	}
+
+	for _, tc := range testCases {
+		filter := indexer.NewEventFilter(tc.include, tc.exclude)
+		assert.Equal(t, tc.indexed, filter.Indexed(tc.eventType, tc.key),
+			"include %v, exclude %v: %s.%s", tc.include, tc.exclude, tc.eventType, tc.key)
+	}
+}
+
+func TestFilteredEventSink(t *testing.T) {
+	filter := indexer.NewEventFilter(nil, []string{"transfer.memo", "end_event"})
+	sink := indexer.NewFilteredEventSink(kv.NewEventSink(dbm.NewMemDB()), filter)
+
+	txResult := &abci.TxResult{
+		Height: 1,
+		Tx:     types.Tx("HELLO WORLD"),
+		Result: abci.ResponseDeliverTx{
+			Events: []abci.Event{{
+				Type: "transfer",
+				Attributes: []abci.EventAttribute{
+					{Key: "sender", Value: "Ivan", Index: true},
+					{Key: "memo", Value: "hi", Index: true},
+				},
+			}},
+		},
+	}
+	require.NoError(t, sink.IndexTxEvents([]*abci.TxResult{txResult}))
+
+	require.NoError(t, sink.IndexBlockEvents(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 1},
+		ResultBeginBlock: abci.ResponseBeginBlock{
+			Events: []abci.Event{{Type: "begin_event", Attributes: []abci.EventAttribute{{Key: "foo", Value: "1", Index: true}}}},
+		},
+		ResultEndBlock: abci.ResponseEndBlock{
+			Events: []abci.Event{{Type: "end_event", Attributes: []abci.EventAttribute{{Key: "foo", Value: "1", Index: true}}}},
+		},
+	}))
+
+	// the events themselves are left as they are
+	assert.True(t, txResult.Result.Events[0].Attributes[1].Index)
+
+	ctx := context.Background()
+	txs, err := sink.SearchTxEvents(ctx, query.MustParse("transfer.sender = 'Ivan'"))
+	require.NoError(t, err)
+	assert.Len(t, txs, 1)
+	txs, err = sink.SearchTxEvents(ctx, query.MustParse("transfer.memo = 'hi'"))
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+
+	heights, err := sink.SearchBlockEvents(ctx, query.MustParse("begin_event.foo = 1"))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, heights)
+	heights, err = sink.SearchBlockEvents(ctx, query.MustParse("end_event.foo = 1"))
+	require.NoError(t, err)
+	assert.Empty(t, heights)
+}
@@ -2,7 +2,10 @@ package indexer
 
 import (
 	"context"
+	"sync"
 
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/types"
 )
@@ -11,15 +14,32 @@ import (
 
 const (
 	subscriber = "IndexerService"
+
+	// QueueSize is the number of blocks which can be waiting to be indexed
+	// by a sink, before the next ones are dropped for that sink.
+	QueueSize = 100
 )
 
 // Service connects event bus, transaction and block indexers together in
 // order to index transactions and blocks coming from the event bus.
+//
+// Each sink indexes the blocks from its own queue, so that a slow or failing
+// sink neither delays the others nor blocks the processing of the blocks.
 type Service struct {
 	service.BaseService
 
 	eventSinks []EventSink
 	eventBus   *types.EventBus
+
+	queues []chan blockEvents
+	quit   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// blockEvents are the events of a block to be indexed by a sink.
+type blockEvents struct {
+	header types.EventDataNewBlockHeader
+	txs    []*abci.TxResult
 }
 
 // NewIndexerService returns a new service instance.
@@ -49,11 +69,30 @@ func (is *Service) OnStart() error {
 		return err
 	}
 
+	is.quit = make(chan struct{})
+	is.queues = make([]chan blockEvents, len(is.eventSinks))
+	for i, sink := range is.eventSinks {
+		is.queues[i] = make(chan blockEvents, QueueSize)
+		is.wg.Add(1)
+		go is.indexBlocks(sink, is.queues[i])
+	}
+
+	is.wg.Add(1)
 	go func() {
+		defer is.wg.Done()
+		// the blocks already queued are still indexed
+		defer func() {
+			for _, queue := range is.queues {
+				close(queue)
+			}
+		}()
+
 		for {
 			select {
 			case <-blockHeadersSub.Canceled():
 				return
+			case <-is.quit:
+				return
 			case msg := <-blockHeadersSub.Out():
 
 				eventDataHeader := msg.Data().(types.EventDataNewBlockHeader)
@@ -61,7 +100,12 @@ func (is *Service) OnStart() error {
 				batch := NewBatch(eventDataHeader.NumTxs)
 
 				for i := int64(0); i < eventDataHeader.NumTxs; i++ {
-					msg2 := <-txsSub.Out()
+					var msg2 pubsub.Message
+					select {
+					case msg2 = <-txsSub.Out():
+					case <-is.quit:
+						return
+					}
 					txResult := msg2.Data().(types.EventDataTx).TxResult
 
 					if err = batch.Add(&txResult); err != nil {
@@ -78,20 +122,12 @@ func (is *Service) OnStart() error {
 					continue
 				}
 
-				for _, sink := range is.eventSinks {
-					if err := sink.IndexBlockEvents(eventDataHeader); err != nil {
-						is.Logger.Error("failed to index block", "height", height, "err", err)
-					} else {
-						is.Logger.Debug("indexed block", "height", height, "sink", sink.Type())
-					}
-
-					if len(batch.Ops) > 0 {
-						err := sink.IndexTxEvents(batch.Ops)
-						if err != nil {
-							is.Logger.Error("failed to index block txs", "height", height, "err", err)
-						} else {
-							is.Logger.Debug("indexed txs", "height", height, "sink", sink.Type())
-						}
+				for i, queue := range is.queues {
+					select {
+					case queue <- blockEvents{header: eventDataHeader, txs: batch.Ops}:
+					default:
+						is.Logger.Error("sink is falling behind, dropping block (use reindex-event to index it)",
+							"height", height, "sink", is.eventSinks[i].Type())
 					}
 				}
 			}
@@ -100,12 +136,37 @@ func (is *Service) OnStart() error {
 	return nil
 }
 
+// indexBlocks indexes the blocks of queue in sink, until the queue is closed.
+func (is *Service) indexBlocks(sink EventSink, queue <-chan blockEvents) {
+	defer is.wg.Done()
+
+	for b := range queue {
+		height := b.header.Header.Height
+		if err := sink.IndexBlockEvents(b.header); err != nil {
+			is.Logger.Error("failed to index block", "height", height, "sink", sink.Type(), "err", err)
+		} else {
+			is.Logger.Debug("indexed block", "height", height, "sink", sink.Type())
+		}
+
+		if len(b.txs) > 0 {
+			err := sink.IndexTxEvents(b.txs)
+			if err != nil {
+				is.Logger.Error("failed to index block txs", "height", height, "sink", sink.Type(), "err", err)
+			} else {
+				is.Logger.Debug("indexed txs", "height", height, "sink", sink.Type())
+			}
+		}
+	}
+}
+
 // OnStop implements service.Service by unsubscribing from all transactions and
 // close the eventsink.
 func (is *Service) OnStop() {
 	if is.eventBus.IsRunning() {
 		_ = is.eventBus.UnsubscribeAll(context.Background(), subscriber)
 	}
+	close(is.quit)
+	is.wg.Wait()
 
 	for _, sink := range is.eventSinks {
 		if err := sink.Stop(); err != nil {
@@ -114,6 +175,18 @@ func (is *Service) OnStop() {
 	}
 }
 
+// Lag returns the number of blocks waiting to be indexed by the slowest sink.
+// Once a sink is QueueSize blocks behind, the next blocks are dropped for it.
+func (is *Service) Lag() int {
+	lag := 0
+	for _, queue := range is.queues {
+		if len(queue) > lag {
+			lag = len(queue)
+		}
+	}
+	return lag
+}
+
 // KVSinkEnabled returns the given eventSinks is containing KVEventSink.
 func KVSinkEnabled(sinks []EventSink) bool {
 	for _, sink := range sinks {
@@ -12,11 +12,13 @@ import (
 	dockertest "github.com/ory/dockertest"
 	"github.com/ory/dockertest/docker"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	indexer "github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/state/indexer/mocks"
 	kv "github.com/tendermint/tendermint/internal/state/indexer/sink/kv"
 	psql "github.com/tendermint/tendermint/internal/state/indexer/sink/psql"
 	tmlog "github.com/tendermint/tendermint/libs/log"
@@ -112,6 +114,48 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	assert.Nil(t, teardown(t, pool))
 }
 
+func TestIndexerServiceIsolatesSinks(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(tmlog.TestingLogger())
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// the slow sink is stuck until unblocked
+	unblock := make(chan struct{})
+	slowSink := &mocks.EventSink{}
+	slowSink.On("Type").Return(indexer.PSQL)
+	slowSink.On("IndexBlockEvents", mock.Anything).Run(func(mock.Arguments) { <-unblock }).Return(nil)
+	slowSink.On("Stop").Return(nil)
+
+	store := dbm.NewMemDB()
+	eventSinks := []indexer.EventSink{slowSink, kv.NewEventSink(store)}
+
+	service := indexer.NewIndexerService(eventSinks, eventBus)
+	service.SetLogger(tmlog.TestingLogger())
+	require.NoError(t, service.Start())
+
+	for h := int64(1); h <= 3; h++ {
+		require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: h},
+		}))
+	}
+
+	// the kv sink indexes the blocks without waiting for the slow sink
+	require.Eventually(t, func() bool {
+		ok, err := eventSinks[1].HasBlock(3)
+		return err == nil && ok
+	}, time.Second, 10*time.Millisecond)
+
+	// the blocks queued for the slow sink are indexed before stopping
+	close(unblock)
+	require.NoError(t, service.Stop())
+	slowSink.AssertNumberOfCalls(t, "IndexBlockEvents", 3)
+}
+
 func readSchema() ([]*schema.Migration, error) {
 	filename := "./sink/psql/schema.sql"
 	contents, err := ioutil.ReadFile(filename)
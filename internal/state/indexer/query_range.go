@@ -1,6 +1,7 @@
 package indexer
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/tendermint/tendermint/libs/pubsub/query"
@@ -13,8 +14,8 @@ type QueryRanges map[string]QueryRange
 
 // QueryRange defines a range within a query condition.
 type QueryRange struct {
-	LowerBound        interface{} // int || time.Time
-	UpperBound        interface{} // int || time.Time
+	LowerBound        interface{} // int64 || float64 || time.Time
+	UpperBound        interface{} // int64 || float64 || time.Time
 	Key               string
 	IncludeLowerBound bool
 	IncludeUpperBound bool
@@ -75,6 +76,74 @@ func (qr QueryRange) UpperBoundValue() interface{} {
 	}
 }
 
+// Matches returns whether the event attribute value is within the range. The
+// bounds can be integers or floating point numbers, and values which are not
+// numbers are never within the range.
+func (qr QueryRange) Matches(value string) bool {
+	if qr.LowerBound != nil {
+		cmp, ok := compareToBound(value, qr.LowerBound)
+		if !ok || cmp < 0 || (cmp == 0 && !qr.IncludeLowerBound) {
+			return false
+		}
+	}
+	if qr.UpperBound != nil {
+		cmp, ok := compareToBound(value, qr.UpperBound)
+		if !ok || cmp > 0 || (cmp == 0 && !qr.IncludeUpperBound) {
+			return false
+		}
+	}
+	return true
+}
+
+// compareToBound compares the numeric value to bound, returning false if
+// either is not a number.
+func compareToBound(value string, bound interface{}) (int, bool) {
+	switch b := bound.(type) {
+	case int64:
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return compareInt64(v, b), true
+		}
+		// compare as floats, e.g. 2.5 to 2
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, false
+		}
+		return compareFloat64(v, float64(b)), true
+
+	case float64:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, false
+		}
+		return compareFloat64(v, b), true
+
+	default: // time.Time values are not indexed
+		return 0, false
+	}
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // LookForRanges returns a mapping of QueryRanges and the matching indexes in
 // the provided query conditions.
 func LookForRanges(conditions []query.Condition) (ranges QueryRanges, indexes []int) {
@@ -26,7 +26,7 @@ const (
 
 // EventSink is an indexer backend providing the tx/block index services.  This
 // implementation stores records in a PostgreSQL database using the schema
-// defined in state/indexer/sink/psql/schema.sql.
+// defined in internal/state/indexer/sink/psql/schema.sql.
 type EventSink struct {
 	store   *sql.DB
 	chainID string
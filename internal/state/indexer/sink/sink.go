@@ -29,6 +29,7 @@ func EventSinksFromConfig(cfg *config.Config, dbProvider config.DBProvider, chai
 		}
 		sinks[sl] = struct{}{}
 	}
+	filter := indexer.NewEventFilter(cfg.TxIndex.IndexEvents, cfg.TxIndex.ExcludeEvents)
 	eventSinks := []indexer.EventSink{}
 	for k := range sinks {
 		switch indexer.EventSinkType(k) {
@@ -43,7 +44,7 @@ func EventSinksFromConfig(cfg *config.Config, dbProvider config.DBProvider, chai
 				return nil, err
 			}
 
-			eventSinks = append(eventSinks, kv.NewEventSink(store))
+			eventSinks = append(eventSinks, indexer.NewFilteredEventSink(kv.NewEventSink(store), filter))
 
 		case indexer.PSQL:
 			conn := cfg.TxIndex.PsqlConn
@@ -55,7 +56,7 @@ func EventSinksFromConfig(cfg *config.Config, dbProvider config.DBProvider, chai
 			if err != nil {
 				return nil, err
 			}
-			eventSinks = append(eventSinks, es)
+			eventSinks = append(eventSinks, indexer.NewFilteredEventSink(es, filter))
 		default:
 			return nil, errors.New("unsupported event sink type")
 		}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/gogo/protobuf/proto"
@@ -127,12 +126,14 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 
 // Search performs a search using the given query.
 //
-// It breaks the query into conditions (like "tx.height > 5"). For each
-// condition, it queries the DB index. One special use cases here: (1) if
+// The query is broken into conjunctions of conditions (like "tx.height > 5"),
+// the txs matching any of them are returned. For each condition of a
+// conjunction, it queries the DB index. One special use cases here: (1) if
 // "tx.hash" is found, it returns tx result for it (2) for range queries it is
 // better for the client to provide both lower and upper bounds, so we are not
-// performing a full scan. Results from querying indexes are then intersected
-// and returned to the caller, in no particular order.
+// performing a full scan. Results from querying indexes are then intersected,
+// the txs matching the negated conditions are removed, and the results are
+// returned to the caller, in no particular order.
 //
 // Search will exit early and return any result fetched so far,
 // when a message is received on the context chan.
@@ -144,15 +145,60 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	default:
 	}
 
-	var hashesInitialized bool
-	filteredHashes := make(map[string][]byte)
-
-	// get a list of conditions (like "tx.height > 5")
-	conditions, err := q.Conditions()
+	// get the conjunctions of conditions (like "tx.height > 5")
+	conjunctions, err := q.Disjunction()
 	if err != nil {
 		return nil, fmt.Errorf("error during parsing conditions from query: %w", err)
 	}
 
+	filteredHashes := make(map[string][]byte)
+	for _, conj := range conjunctions {
+		hashes, err := txi.matchConditions(ctx, conj.Conditions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range conj.Negated {
+			if len(hashes) == 0 {
+				break
+			}
+			negated, err := txi.matchConditions(ctx, []query.Condition{c})
+			if err != nil {
+				return nil, err
+			}
+			for k := range negated {
+				delete(hashes, k)
+			}
+		}
+
+		for k, h := range hashes {
+			filteredHashes[k] = h
+		}
+	}
+
+	results := make([]*abci.TxResult, 0, len(filteredHashes))
+hashes:
+	for _, h := range filteredHashes {
+		res, err := txi.Get(h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
+		}
+		results = append(results, res)
+
+		// Potentially exit early.
+		select {
+		case <-ctx.Done():
+			break hashes
+		default:
+		}
+	}
+
+	return results, nil
+}
+
+// matchConditions returns the hashes of the txs matching all the conditions,
+// or of all the txs if there are none.
+func (txi *TxIndex) matchConditions(ctx context.Context, conditions []query.Condition) (map[string][]byte, error) {
 	// if there is a hash condition, return the result immediately
 	hash, ok, err := lookForHash(conditions)
 	if err != nil {
@@ -161,14 +207,22 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		res, err := txi.Get(hash)
 		switch {
 		case err != nil:
-			return []*abci.TxResult{}, fmt.Errorf("error while retrieving the result: %w", err)
+			return nil, fmt.Errorf("error while retrieving the result: %w", err)
 		case res == nil:
-			return []*abci.TxResult{}, nil
+			return map[string][]byte{}, nil
 		default:
-			return []*abci.TxResult{res}, nil
+			return map[string][]byte{string(hash): hash}, nil
 		}
 	}
 
+	// all the txs are indexed by height
+	if len(conditions) == 0 {
+		conditions = []query.Condition{{CompositeKey: types.TxHeightKey, Op: query.OpExists}}
+	}
+
+	var hashesInitialized bool
+	filteredHashes := make(map[string][]byte)
+
 	// conditions to skip because they're handled before "everything else"
 	skipIndexes := make([]int, 0)
 
@@ -218,24 +272,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		}
 	}
 
-	results := make([]*abci.TxResult, 0, len(filteredHashes))
-hashes:
-	for _, h := range filteredHashes {
-		res, err := txi.Get(h)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
-		}
-		results = append(results, res)
-
-		// Potentially exit early.
-		select {
-		case <-ctx.Done():
-			break hashes
-		default:
-		}
-	}
-
-	return results, nil
+	return filteredHashes, nil
 }
 
 func lookForHash(conditions []query.Condition) (hash []byte, ok bool, err error) {
@@ -407,8 +444,6 @@ func (txi *TxIndex) matchRange(
 	}
 
 	tmpHashes := make(map[string][]byte)
-	lowerBound := qr.LowerBoundValue()
-	upperBound := qr.UpperBoundValue()
 
 	it, err := dbm.IteratePrefix(txi.store, startKey)
 	if err != nil {
@@ -422,31 +457,8 @@ iter:
 		if err != nil {
 			continue
 		}
-		if _, ok := qr.AnyBound().(int64); ok {
-			v, err := strconv.ParseInt(value, 10, 64)
-			if err != nil {
-				continue iter
-			}
-
-			include := true
-			if lowerBound != nil && v < lowerBound.(int64) {
-				include = false
-			}
-
-			if upperBound != nil && v > upperBound.(int64) {
-				include = false
-			}
-
-			if include {
-				tmpHashes[string(it.Value())] = it.Value()
-			}
-
-			// XXX: passing time in a ABCI Events is not yet implemented
-			// case time.Time:
-			// 	v := strconv.ParseInt(extractValueFromKey(it.Key()), 10, 64)
-			// 	if v == r.upperBound {
-			// 		break
-			// 	}
+		if qr.Matches(value) {
+			tmpHashes[string(it.Value())] = it.Value()
 		}
 
 		// Potentially exit early.
@@ -125,6 +125,19 @@ func TestTxSearch(t *testing.T) {
 		{"account.number = 1 AND tx.height = 3", 0},
 		// search using height only
 		{"tx.height = 1", 1},
+		// search using OR
+		{"account.owner = 'Vlad' OR account.number = 1", 1},
+		{"account.owner = 'Vlad' OR account.number = 2", 0},
+		// search using NOT
+		{"account.number = 1 AND NOT account.owner = 'Vlad'", 1},
+		{"account.number = 1 AND NOT account.owner = 'Ivan'", 0},
+		{"NOT account.owner = 'Vlad'", 1},
+		// search using parentheses
+		{"(account.owner = 'Vlad' OR account.owner = 'Ivan') AND account.number = 1", 1},
+		{"account.number = 1 AND NOT (account.owner = 'Vlad' OR account.owner = 'Ivan')", 0},
+		// search by range with a decimal bound
+		{"account.number >= 1 AND account.number < 1.5", 1},
+		{"account.number > 1.5", 0},
 	}
 
 	ctx := context.Background()
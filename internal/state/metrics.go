@@ -17,6 +17,22 @@ const (
 type Metrics struct {
 	// Time between BeginBlock and EndBlock.
 	BlockProcessingTime metrics.Histogram
+
+	// The height from which the application retains the blocks.
+	BlocksRetainHeight metrics.Gauge
+
+	// The lowest height of the block store, which the pruning brings up to
+	// the retain height.
+	BlockStoreBase metrics.Gauge
+
+	// The height below which the blocks have been moved to the block archive.
+	BlockArchiveHeight metrics.Gauge
+
+	// Time to prune a batch of blocks and states.
+	PruningTime metrics.Histogram
+
+	// Time to compact the databases after pruning.
+	CompactionTime metrics.Histogram
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -35,6 +51,38 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help:      "Time between BeginBlock and EndBlock in ms.",
 			Buckets:   stdprometheus.LinearBuckets(1, 10, 10),
 		}, labels).With(labelsAndValues...),
+		BlocksRetainHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "blocks_retain_height",
+			Help:      "The height from which the application retains the blocks.",
+		}, labels).With(labelsAndValues...),
+		BlockStoreBase: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_store_base",
+			Help:      "The lowest height of the block store, which the pruning brings up to the retain height.",
+		}, labels).With(labelsAndValues...),
+		BlockArchiveHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_archive_height",
+			Help:      "The height below which the blocks have been moved to the block archive.",
+		}, labels).With(labelsAndValues...),
+		PruningTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruning_time",
+			Help:      "Time to prune a batch of blocks and states in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.01, 2, 10),
+		}, labels).With(labelsAndValues...),
+		CompactionTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compaction_time",
+			Help:      "Time to compact the databases after pruning in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 2, 10),
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -42,5 +90,10 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 func NopMetrics() *Metrics {
 	return &Metrics{
 		BlockProcessingTime: discard.NewHistogram(),
+		BlocksRetainHeight:  discard.NewGauge(),
+		BlockStoreBase:      discard.NewGauge(),
+		BlockArchiveHeight:  discard.NewGauge(),
+		PruningTime:         discard.NewHistogram(),
+		CompactionTime:      discard.NewHistogram(),
 	}
 }
@@ -0,0 +1,79 @@
+package state
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+)
+
+// MismatchReport is the diagnostic of an ErrAppHashMismatch, for the operators
+// to find out which side diverged: the hash of the block Height results from
+// the execution of the block Height-1, whose txs and ABCI responses are
+// reported.
+type MismatchReport struct {
+	Time     time.Time        `json:"time"`
+	Field    string           `json:"field"`
+	Height   int64            `json:"height"`
+	Expected tmbytes.HexBytes `json:"expected"`
+	Got      tmbytes.HexBytes `json:"got"`
+
+	// TxHashes are the hashes of the txs of the block Height-1, if it is
+	// still in the block store.
+	TxHashes []tmbytes.HexBytes `json:"tx_hashes"`
+
+	// ABCIResponses are the responses of the app to the block Height-1, if
+	// they are still in the state store.
+	ABCIResponses *tmstate.ABCIResponses `json:"abci_responses"`
+}
+
+// NewMismatchReport returns the report of the mismatch, loading the txs and
+// the ABCI responses of the block Height-1 from the stores.
+func NewMismatchReport(mismatch ErrAppHashMismatch, stateStore Store, blockStore BlockStore) *MismatchReport {
+	report := &MismatchReport{
+		Time:     time.Now(),
+		Field:    mismatch.Field,
+		Height:   mismatch.Height,
+		Expected: mismatch.Expected,
+		Got:      mismatch.Got,
+	}
+	if block := blockStore.LoadBlock(mismatch.Height - 1); block != nil {
+		report.TxHashes = make([]tmbytes.HexBytes, len(block.Txs))
+		for i, tx := range block.Txs {
+			report.TxHashes[i] = tx.Hash()
+		}
+	}
+	if responses, err := stateStore.LoadABCIResponses(mismatch.Height - 1); err == nil {
+		report.ABCIResponses = responses
+	}
+	return report
+}
+
+// SaveAs writes the report as JSON to the file, replacing the previous one.
+func (r *MismatchReport) SaveAs(path string) error {
+	bz, err := tmjson.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := tempfile.WriteFileAtomic(path, bz, 0644); err != nil {
+		return fmt.Errorf("writing the app hash mismatch report to %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadMismatchReport reads the report written to the file.
+func LoadMismatchReport(path string) (*MismatchReport, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	report := new(MismatchReport)
+	if err := tmjson.Unmarshal(bz, report); err != nil {
+		return nil, fmt.Errorf("reading the app hash mismatch report of %s: %w", path, err)
+	}
+	return report, nil
+}
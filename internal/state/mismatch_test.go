@@ -0,0 +1,53 @@
+package state_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestMismatchReport(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	responses := &tmstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{{Code: 1, Log: "diverged"}},
+		BeginBlock: &abci.ResponseBeginBlock{},
+		EndBlock:   &abci.ResponseEndBlock{},
+	}
+	require.NoError(t, stateStore.SaveABCIResponses(4, responses))
+
+	tx := types.Tx("tx")
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlock", int64(4)).Return(&types.Block{Data: types.Data{Txs: types.Txs{tx}}})
+
+	// the report has the txs and the ABCI responses of the previous block
+	mismatch := sm.ErrAppHashMismatch{Field: "LastResultsHash", Height: 5, Expected: []byte{1}, Got: []byte{2}}
+	report := sm.NewMismatchReport(mismatch, stateStore, blockStore)
+	assert.Equal(t, "LastResultsHash", report.Field)
+	assert.EqualValues(t, 5, report.Height)
+	assert.Equal(t, []tmbytes.HexBytes{tx.Hash()}, report.TxHashes)
+	require.NotNil(t, report.ABCIResponses)
+	assert.Equal(t, "diverged", report.ABCIResponses.DeliverTxs[0].Log)
+
+	path := filepath.Join(t.TempDir(), "mismatch_report.json")
+	require.NoError(t, report.SaveAs(path))
+	saved, err := sm.LoadMismatchReport(path)
+	require.NoError(t, err)
+	assert.Equal(t, report.Height, saved.Height)
+	assert.Equal(t, report.Expected, saved.Expected)
+	assert.Equal(t, report.Got, saved.Got)
+	assert.Equal(t, report.TxHashes, saved.TxHashes)
+	assert.Equal(t, "diverged", saved.ABCIResponses.DeliverTxs[0].Log)
+
+	_, err = sm.LoadMismatchReport(filepath.Join(t.TempDir(), "none.json"))
+	assert.Error(t, err)
+}
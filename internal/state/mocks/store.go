@@ -15,6 +15,27 @@ type Store struct {
 	mock.Mock
 }
 
+// ABCIResponsesBase provides a mock function with given fields:
+func (_m *Store) ABCIResponsesBase() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Bootstrap provides a mock function with given fields: _a0
 func (_m *Store) Bootstrap(_a0 state.State) error {
 	ret := _m.Called(_a0)
@@ -94,6 +115,48 @@ func (_m *Store) LoadConsensusParams(_a0 int64) (types.ConsensusParams, error) {
 	return r0, r1
 }
 
+// LoadLastHeightConsensusParamsChanged provides a mock function with given fields: _a0
+func (_m *Store) LoadLastHeightConsensusParamsChanged(_a0 int64) (int64, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadLastHeightValidatorsChanged provides a mock function with given fields: _a0
+func (_m *Store) LoadLastHeightValidatorsChanged(_a0 int64) (int64, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadValidators provides a mock function with given fields: _a0
 func (_m *Store) LoadValidators(_a0 int64) (*types.ValidatorSet, error) {
 	ret := _m.Called(_a0)
@@ -117,6 +180,20 @@ func (_m *Store) LoadValidators(_a0 int64) (*types.ValidatorSet, error) {
 	return r0, r1
 }
 
+// PruneABCIResponses provides a mock function with given fields: _a0
+func (_m *Store) PruneABCIResponses(_a0 int64) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PruneStates provides a mock function with given fields: _a0
 func (_m *Store) PruneStates(_a0 int64) error {
 	ret := _m.Called(_a0)
@@ -159,6 +236,20 @@ func (_m *Store) SaveABCIResponses(_a0 int64, _a1 *tendermintstate.ABCIResponses
 	return r0
 }
 
+// SaveConsensusParams provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Store) SaveConsensusParams(_a0 int64, _a1 int64, _a2 types.ConsensusParams) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, types.ConsensusParams) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveValidatorSets provides a mock function with given fields: _a0, _a1, _a2
 func (_m *Store) SaveValidatorSets(_a0 int64, _a1 int64, _a2 *types.ValidatorSet) error {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -0,0 +1,304 @@
+package state
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+const (
+	// pruneBatchHeights is the number of heights pruned at once, so that the
+	// pruning never holds the databases for long.
+	pruneBatchHeights = 100
+
+	// compactionHeights is the number of heights to prune before the
+	// databases are compacted, to reclaim the space of the deleted keys.
+	compactionHeights = 10000
+)
+
+// Pruner prunes the blocks, the states and the ABCI responses below the
+// retain heights set by the BlockExecutor, in the background and in bounded
+// batches, so that the pruning doesn't delay the commit of the blocks. It
+// compacts the databases once enough heights have been pruned. With an
+// archive, it also moves the old blocks to the archive the same way.
+type Pruner struct {
+	service.BaseService
+
+	stateStore Store
+	blockStore BlockStore
+	dbs        []dbm.DB // compacted after pruning
+	metrics    *Metrics
+
+	archiver            BlockArchiver
+	archiveRetainBlocks int64 // atomic
+
+	mtx                 sync.Mutex
+	blocksRetainHeight  int64
+	resultsRetainHeight int64
+
+	// the ABCI responses are pruned up to resultsBase (exclusive)
+	resultsBase int64
+	// heights pruned since the last compaction
+	uncompacted int64
+
+	signal chan struct{}
+	quit   chan struct{}
+	done   chan struct{}
+}
+
+type PrunerOption func(*Pruner)
+
+// BlockArchiver is a block store which moves its old blocks to an archive.
+type BlockArchiver interface {
+	// ArchiveHeight returns the height below which the blocks are archived.
+	ArchiveHeight() int64
+	// ArchiveBlocks archives the blocks below height.
+	ArchiveBlocks(height int64) (uint64, error)
+}
+
+// PrunerWithMetrics sets the metrics.
+func PrunerWithMetrics(metrics *Metrics) PrunerOption {
+	return func(p *Pruner) { p.metrics = metrics }
+}
+
+// PrunerWithCompaction compacts dbs once enough heights have been pruned. Only
+// the goleveldb and pebble databases can be compacted, the others are left as
+// they are.
+func PrunerWithCompaction(dbs ...dbm.DB) PrunerOption {
+	return func(p *Pruner) { p.dbs = dbs }
+}
+
+// PrunerWithArchive moves the blocks older than the latest retainBlocks blocks
+// to the archive of archiver.
+func PrunerWithArchive(archiver BlockArchiver, retainBlocks int64) PrunerOption {
+	return func(p *Pruner) {
+		p.archiver = archiver
+		p.archiveRetainBlocks = retainBlocks
+	}
+}
+
+// NewPruner returns a Pruner of the blocks of blockStore and of the states and
+// ABCI responses of stateStore.
+func NewPruner(stateStore Store, blockStore BlockStore, logger log.Logger, options ...PrunerOption) *Pruner {
+	p := &Pruner{
+		stateStore: stateStore,
+		blockStore: blockStore,
+		metrics:    NopMetrics(),
+		signal:     make(chan struct{}, 1),
+	}
+	p.BaseService = *service.NewBaseService(logger, "Pruner", p)
+
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// SetRetainHeights sets the heights from which to retain the blocks and the
+// states, and the ABCI responses. It doesn't wait for the pruning, and the
+// heights lower than the previous ones are ignored.
+func (p *Pruner) SetRetainHeights(blocksRetainHeight, resultsRetainHeight int64) {
+	p.mtx.Lock()
+	if blocksRetainHeight > p.blocksRetainHeight {
+		p.blocksRetainHeight = blocksRetainHeight
+		p.metrics.BlocksRetainHeight.Set(float64(blocksRetainHeight))
+	}
+	if resultsRetainHeight > p.resultsRetainHeight {
+		p.resultsRetainHeight = resultsRetainHeight
+	}
+	p.mtx.Unlock()
+
+	select {
+	case p.signal <- struct{}{}:
+	default:
+	}
+}
+
+// SetArchiveRetainBlocks changes the number of most recent blocks kept out of
+// the archive, 0 pausing the archiving. It returns false if the Pruner has no
+// archive.
+func (p *Pruner) SetArchiveRetainBlocks(retainBlocks int64) bool {
+	if p.archiver == nil {
+		return false
+	}
+	atomic.StoreInt64(&p.archiveRetainBlocks, retainBlocks)
+	return true
+}
+
+// OnStart implements service.Service.
+func (p *Pruner) OnStart() error {
+	p.quit = make(chan struct{})
+	p.done = make(chan struct{})
+	go p.pruneRoutine()
+	return nil
+}
+
+// OnStop implements service.Service, waiting for the batch being pruned.
+func (p *Pruner) OnStop() {
+	close(p.quit)
+	<-p.done
+}
+
+func (p *Pruner) pruneRoutine() {
+	defer close(p.done)
+
+	for {
+		select {
+		case <-p.quit:
+			return
+		case <-p.signal:
+		}
+
+		p.mtx.Lock()
+		blocksRetainHeight, resultsRetainHeight := p.blocksRetainHeight, p.resultsRetainHeight
+		p.mtx.Unlock()
+
+		// one batch at a time, until the retain heights are reached
+		for {
+			select {
+			case <-p.quit:
+				return
+			default:
+			}
+
+			pruned, err := p.pruneBlocks(blocksRetainHeight)
+			if err != nil {
+				p.Logger.Error("failed to prune blocks", "retain_height", blocksRetainHeight, "err", err)
+				break
+			}
+			prunedResults, err := p.pruneResults(resultsRetainHeight)
+			if err != nil {
+				p.Logger.Error("failed to prune block results", "retain_height", resultsRetainHeight, "err", err)
+				break
+			}
+			archived, err := p.archiveBlocks()
+			if err != nil {
+				p.Logger.Error("failed to archive blocks", "err", err)
+				break
+			}
+			if pruned == 0 && prunedResults == 0 && archived == 0 {
+				break
+			}
+		}
+
+		if p.uncompacted >= compactionHeights {
+			p.compact()
+		}
+	}
+}
+
+// pruneBlocks prunes the next batch of blocks and states below retainHeight,
+// and returns the number of heights pruned.
+func (p *Pruner) pruneBlocks(retainHeight int64) (int64, error) {
+	base := p.blockStore.Base()
+	if retainHeight <= base {
+		return 0, nil
+	}
+	if retainHeight > base+pruneBatchHeights {
+		retainHeight = base + pruneBatchHeights
+	}
+
+	start := time.Now()
+	if _, err := p.blockStore.PruneBlocks(retainHeight); err != nil {
+		return 0, fmt.Errorf("failed to prune block store: %w", err)
+	}
+	if err := p.stateStore.PruneStates(retainHeight); err != nil {
+		return 0, fmt.Errorf("failed to prune state store: %w", err)
+	}
+	p.metrics.PruningTime.Observe(time.Since(start).Seconds())
+	p.metrics.BlockStoreBase.Set(float64(retainHeight))
+
+	p.uncompacted += retainHeight - base
+	p.Logger.Debug("pruned blocks", "base", retainHeight, "pruned", retainHeight-base)
+	return retainHeight - base, nil
+}
+
+// pruneResults prunes the next batch of ABCI responses below retainHeight,
+// and returns the number of heights pruned.
+func (p *Pruner) pruneResults(retainHeight int64) (int64, error) {
+	if retainHeight <= p.resultsBase || retainHeight <= 1 {
+		return 0, nil
+	}
+	// the heights pruned before a restart aren't known: look for the lowest
+	// height of the ABCI responses stored
+	if p.resultsBase == 0 {
+		base, err := p.stateStore.ABCIResponsesBase()
+		if err != nil || base == 0 {
+			return 0, err
+		}
+		p.resultsBase = base
+	}
+	base := p.resultsBase
+	if retainHeight <= base {
+		return 0, nil
+	}
+	if retainHeight > base+pruneBatchHeights {
+		retainHeight = base + pruneBatchHeights
+	}
+
+	if err := p.stateStore.PruneABCIResponses(retainHeight); err != nil {
+		return 0, err
+	}
+	p.resultsBase = retainHeight
+	p.uncompacted += retainHeight - base
+	p.Logger.Debug("pruned block results", "base", retainHeight, "pruned", retainHeight-base)
+	return retainHeight - base, nil
+}
+
+// archiveBlocks moves the next batch of blocks older than the latest
+// archiveRetainBlocks blocks to the archive, and returns the number of heights
+// archived.
+func (p *Pruner) archiveBlocks() (int64, error) {
+	retainBlocks := atomic.LoadInt64(&p.archiveRetainBlocks)
+	if p.archiver == nil || retainBlocks <= 0 {
+		return 0, nil
+	}
+	archiveHeight := p.blockStore.Height() - retainBlocks + 1
+	base := p.archiver.ArchiveHeight()
+	if blockStoreBase := p.blockStore.Base(); base < blockStoreBase {
+		base = blockStoreBase
+	}
+	if archiveHeight <= base {
+		return 0, nil
+	}
+	if archiveHeight > base+pruneBatchHeights {
+		archiveHeight = base + pruneBatchHeights
+	}
+
+	if _, err := p.archiver.ArchiveBlocks(archiveHeight); err != nil {
+		return 0, err
+	}
+	p.metrics.BlockArchiveHeight.Set(float64(archiveHeight))
+
+	p.uncompacted += archiveHeight - base
+	p.Logger.Debug("archived blocks", "archive_height", archiveHeight, "archived", archiveHeight-base)
+	return archiveHeight - base, nil
+}
+
+// compact compacts the databases which support it.
+func (p *Pruner) compact() {
+	start := time.Now()
+	for _, db := range p.dbs {
+		var err error
+		switch db := db.(type) {
+		case *dbm.GoLevelDB:
+			err = db.DB().CompactRange(util.Range{})
+		case interface{ Compact() error }:
+			err = db.Compact()
+		}
+		if err != nil {
+			p.Logger.Error("failed to compact database", "err", err)
+			return
+		}
+	}
+	p.metrics.CompactionTime.Observe(time.Since(start).Seconds())
+	p.Logger.Info("compacted databases", "pruned_heights", p.uncompacted, "duration", time.Since(start))
+	p.uncompacted = 0
+}
@@ -0,0 +1,99 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	mmock "github.com/tendermint/tendermint/internal/mempool/mock"
+	sm "github.com/tendermint/tendermint/internal/state"
+	sf "github.com/tendermint/tendermint/internal/state/test/factory"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestPruner(t *testing.T) {
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStoreDB := dbm.NewMemDB()
+	blockStore := store.NewBlockStore(blockStoreDB)
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore)
+
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for height := int64(1); height <= 5; height++ {
+		var err error
+		block := sf.MakeBlock(state, height, lastCommit)
+		state, _, lastCommit, err = makeAndCommitGoodBlock(
+			state, height, lastCommit, state.Validators.GetProposer().Address, blockExec, privVals, nil)
+		require.NoError(t, err, "height %d", height)
+		blockStore.SaveBlock(block, block.MakePartSet(testPartSize), lastCommit)
+	}
+
+	pruner := sm.NewPruner(stateStore, blockStore, log.TestingLogger(),
+		sm.PrunerWithCompaction(blockStoreDB, stateDB))
+	require.NoError(t, pruner.Start())
+	defer pruner.Stop() //nolint:errcheck // ignore for tests
+
+	pruner.SetRetainHeights(3, 4)
+	require.Eventually(t, func() bool {
+		_, err := stateStore.LoadABCIResponses(3)
+		return blockStore.Base() == 3 && err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	_, err := stateStore.LoadABCIResponses(4)
+	require.NoError(t, err)
+	_, err = stateStore.LoadValidators(3)
+	require.NoError(t, err)
+
+	// lower retain heights are ignored
+	pruner.SetRetainHeights(1, 1)
+	time.Sleep(50 * time.Millisecond)
+	require.EqualValues(t, 3, blockStore.Base())
+	_, err = stateStore.LoadABCIResponses(4)
+	require.NoError(t, err)
+}
+
+func TestPrunerArchive(t *testing.T) {
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB(),
+		store.BlockStoreWithArchive(store.NewFileArchive(t.TempDir())))
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore)
+
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for height := int64(1); height <= 5; height++ {
+		var err error
+		block := sf.MakeBlock(state, height, lastCommit)
+		state, _, lastCommit, err = makeAndCommitGoodBlock(
+			state, height, lastCommit, state.Validators.GetProposer().Address, blockExec, privVals, nil)
+		require.NoError(t, err, "height %d", height)
+		blockStore.SaveBlock(block, block.MakePartSet(testPartSize), lastCommit)
+	}
+	block := blockStore.LoadBlock(2)
+
+	pruner := sm.NewPruner(stateStore, blockStore, log.TestingLogger(),
+		sm.PrunerWithArchive(blockStore, 2))
+	require.NoError(t, pruner.Start())
+	defer pruner.Stop() //nolint:errcheck // ignore for tests
+
+	// the latest 2 blocks are kept, and nothing is pruned
+	pruner.SetRetainHeights(0, 0)
+	require.Eventually(t, func() bool {
+		return blockStore.ArchiveHeight() == 4
+	}, time.Second, 10*time.Millisecond)
+	require.EqualValues(t, 1, blockStore.Base())
+	require.Equal(t, block, blockStore.LoadBlock(2))
+}
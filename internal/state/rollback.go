@@ -0,0 +1,93 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/version"
+)
+
+// Rollback overwrites the current state with the state after the block at
+// height, rebuilt from the block and state stores, and returns it. The blocks
+// after height are left in the block store: the one at height+1 is executed
+// again on restart, and the ones after it must be removed by the caller.
+// Rollback doesn't affect the application state, which must be rolled back to
+// height, or to height+1 at most.
+func Rollback(bs BlockStore, ss Store, height int64) (State, error) {
+	current, err := ss.Load()
+	if err != nil {
+		return State{}, err
+	}
+	if current.IsEmpty() {
+		return State{}, errors.New("no state found")
+	}
+	if height >= current.LastBlockHeight {
+		return State{}, fmt.Errorf("can't roll back to height %d, the last height is %d",
+			height, current.LastBlockHeight)
+	}
+	if height < current.InitialHeight {
+		return State{}, fmt.Errorf("can't roll back to height %d, below the initial height %d",
+			height, current.InitialHeight)
+	}
+
+	// the hashes of the results of the block at height are in the next one
+	rollbackBlock := bs.LoadBlockMeta(height)
+	nextBlock := bs.LoadBlockMeta(height + 1)
+	if rollbackBlock == nil || nextBlock == nil {
+		return State{}, fmt.Errorf("the blocks at heights %d and %d must be in the block store (base %d)",
+			height, height+1, bs.Base())
+	}
+
+	lastValidators, err := ss.LoadValidators(height)
+	if err != nil {
+		return State{}, err
+	}
+	validators, err := ss.LoadValidators(height + 1)
+	if err != nil {
+		return State{}, err
+	}
+	nextValidators, err := ss.LoadValidators(height + 2)
+	if err != nil {
+		return State{}, err
+	}
+	valsChangeHeight, err := ss.LoadLastHeightValidatorsChanged(height + 2)
+	if err != nil {
+		return State{}, err
+	}
+	params, err := ss.LoadConsensusParams(height + 1)
+	if err != nil {
+		return State{}, err
+	}
+	paramsChangeHeight, err := ss.LoadLastHeightConsensusParamsChanged(height + 1)
+	if err != nil {
+		return State{}, err
+	}
+
+	state := State{
+		Version: Version{
+			Consensus: nextBlock.Header.Version,
+			Software:  version.TMVersion,
+		},
+		ChainID:       current.ChainID,
+		InitialHeight: current.InitialHeight,
+
+		LastBlockHeight: height,
+		LastBlockID:     rollbackBlock.BlockID,
+		LastBlockTime:   rollbackBlock.Header.Time,
+
+		NextValidators:              nextValidators,
+		Validators:                  validators,
+		LastValidators:              lastValidators,
+		LastHeightValidatorsChanged: valsChangeHeight,
+
+		ConsensusParams:                  params,
+		LastHeightConsensusParamsChanged: paramsChangeHeight,
+
+		LastResultsHash: nextBlock.Header.LastResultsHash,
+		AppHash:         nextBlock.Header.AppHash,
+	}
+	if err := ss.Save(state); err != nil {
+		return State{}, err
+	}
+	return state, nil
+}
@@ -0,0 +1,78 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	mmock "github.com/tendermint/tendermint/internal/mempool/mock"
+	"github.com/tendermint/tendermint/internal/proxy"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestRollback(t *testing.T) {
+	app := &testApp{}
+	cc := abciclient.NewLocalCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore)
+
+	// the app updates the consensus params at every height
+	states := make(map[int64]sm.State)
+	lastCommit := new(types.Commit)
+	for height := int64(1); height <= 5; height++ {
+		block, parts := state.MakeBlock(height, factory.MakeTenTxs(height), nil, nil, nil, lastCommit,
+			state.Validators.GetProposer().Address)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		state, err = blockExec.ApplyBlock(state, blockID, block)
+		require.NoError(t, err)
+		lastCommit, err = makeValidCommit(height, blockID, state.LastValidators, privVals)
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, parts, lastCommit)
+		states[height] = state
+	}
+
+	_, err = sm.Rollback(blockStore, stateStore, 5)
+	require.Error(t, err)
+	_, err = sm.Rollback(blockStore, stateStore, 0)
+	require.Error(t, err)
+
+	rolledBack, err := sm.Rollback(blockStore, stateStore, 2)
+	require.NoError(t, err)
+	loaded, err := stateStore.Load()
+	require.NoError(t, err)
+	require.EqualValues(t, 2, loaded.LastBlockHeight)
+
+	for _, s := range []sm.State{rolledBack, loaded} {
+		expected := states[2]
+		assert.Equal(t, expected.Version, s.Version)
+		assert.Equal(t, expected.LastBlockID, s.LastBlockID)
+		assert.True(t, expected.LastBlockTime.Equal(s.LastBlockTime))
+		assert.Equal(t, expected.NextValidators.Hash(), s.NextValidators.Hash())
+		assert.Equal(t, expected.Validators.Hash(), s.Validators.Hash())
+		assert.Equal(t, expected.LastValidators.Hash(), s.LastValidators.Hash())
+		assert.Equal(t, expected.LastHeightValidatorsChanged, s.LastHeightValidatorsChanged)
+		assert.Equal(t, expected.ConsensusParams.HashConsensusParams(), s.ConsensusParams.HashConsensusParams())
+		assert.Equal(t, expected.LastHeightConsensusParamsChanged, s.LastHeightConsensusParamsChanged)
+		assert.Equal(t, expected.LastResultsHash, s.LastResultsHash)
+		assert.Equal(t, expected.AppHash, s.AppHash)
+	}
+
+	// the rolled back state can't be rolled back to a later height
+	_, err = sm.Rollback(blockStore, stateStore, 3)
+	require.Error(t, err)
+}
@@ -281,7 +281,7 @@ func (state State) MakeBlock(
 		proposerAddress,
 	)
 
-	return block, block.MakePartSet(types.BlockPartSizeBytes)
+	return block, block.MakePartSetForParams(state.ConsensusParams.Block)
 }
 
 // MedianTime computes a median time for a given Commit (based on Timestamp field of votes messages) and the
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/orderedcode"
@@ -83,16 +84,26 @@ type Store interface {
 	LoadABCIResponses(int64) (*tmstate.ABCIResponses, error)
 	// LoadConsensusParams loads the consensus params for a given height
 	LoadConsensusParams(int64) (types.ConsensusParams, error)
+	// LoadLastHeightValidatorsChanged loads the last height the validator set changed as of a given height
+	LoadLastHeightValidatorsChanged(int64) (int64, error)
+	// LoadLastHeightConsensusParamsChanged loads the last height the consensus params changed as of a given height
+	LoadLastHeightConsensusParamsChanged(int64) (int64, error)
 	// Save overwrites the previous state with the updated one
 	Save(State) error
 	// SaveABCIResponses saves ABCIResponses for a given height
 	SaveABCIResponses(int64, *tmstate.ABCIResponses) error
 	// SaveValidatorSet saves the validator set at a given height
 	SaveValidatorSets(int64, int64, *types.ValidatorSet) error
+	// SaveConsensusParams saves the consensus params at a range of heights
+	SaveConsensusParams(int64, int64, types.ConsensusParams) error
 	// Bootstrap is used for bootstrapping state when not starting from a initial height.
 	Bootstrap(State) error
 	// PruneStates takes the height from which to prune up to (exclusive)
 	PruneStates(int64) error
+	// PruneABCIResponses takes the height from which to prune the ABCI responses up to (exclusive)
+	PruneABCIResponses(int64) error
+	// ABCIResponsesBase returns the lowest height of the ABCI responses stored, or 0 if there are none
+	ABCIResponsesBase() (int64, error)
 }
 
 // dbStore wraps a db (github.com/tendermint/tm-db)
@@ -216,6 +227,7 @@ func (store dbStore) Bootstrap(state State) error {
 // PruneStates deletes states up to the height specified (exclusive). It is not
 // guaranteed to delete all states, since the last checkpointed state and states being pointed to by
 // e.g. `LastHeightChanged` must remain. The state at retain height must also exist.
+// The ABCI responses are pruned separately, see PruneABCIResponses.
 // Pruning is done in descending order.
 func (store dbStore) PruneStates(retainHeight int64) error {
 	if retainHeight <= 0 {
@@ -235,11 +247,38 @@ func (store dbStore) PruneStates(retainHeight int64) error {
 		return err
 	}
 
-	if err := store.pruneABCIResponses(retainHeight); err != nil {
-		return err
+	return nil
+}
+
+// PruneABCIResponses deletes the ABCI responses up to the height specified
+// (exclusive). They are pruned separately from the states, as they can be
+// retained for a different number of blocks.
+func (store dbStore) PruneABCIResponses(retainHeight int64) error {
+	if retainHeight <= 0 {
+		return fmt.Errorf("height %v must be greater than 0", retainHeight)
 	}
 
-	return nil
+	return store.pruneABCIResponses(retainHeight)
+}
+
+// ABCIResponsesBase returns the lowest height of the ABCI responses stored, or
+// 0 if there are none. It seeks the first key of the ABCI responses, without
+// going over the pruned heights.
+func (store dbStore) ABCIResponsesBase() (int64, error) {
+	iter, err := store.db.Iterator(abciResponsesKey(1), abciResponsesKey(math.MaxInt64))
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+
+	if !iter.Valid() {
+		return 0, iter.Error()
+	}
+	var prefix, height int64
+	if _, err := orderedcode.Parse(string(iter.Key()), &prefix, &height); err != nil {
+		return 0, fmt.Errorf("failed to decode ABCI responses key %X: %w", iter.Key(), err)
+	}
+	return height, nil
 }
 
 // pruneValidatorSets calls a reverse iterator from base height to retain height (exclusive), deleting
@@ -481,6 +520,24 @@ func (store dbStore) SaveValidatorSets(lowerHeight, upperHeight int64, vals *typ
 
 //-----------------------------------------------------------------------------
 
+// SaveConsensusParams is used to save the consensus params over multiple
+// heights, like SaveValidatorSets. It is exposed so that an import of the
+// blocks of a chain can populate the store with their consensus params.
+func (store dbStore) SaveConsensusParams(lowerHeight, upperHeight int64, params types.ConsensusParams) error {
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
+	for height := lowerHeight; height <= upperHeight; height++ {
+		if err := store.saveConsensusParamsInfo(height, lowerHeight, params, batch); err != nil {
+			return err
+		}
+	}
+
+	return batch.WriteSync()
+}
+
+//-----------------------------------------------------------------------------
+
 // LoadValidators loads the ValidatorSet for a given height.
 // Returns ErrNoValSetForHeight if the validator set can't be found for this height.
 func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
@@ -524,6 +581,16 @@ func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
 	return vip, nil
 }
 
+// LoadLastHeightValidatorsChanged loads the last height at which the validator
+// set changed, as of the given height.
+func (store dbStore) LoadLastHeightValidatorsChanged(height int64) (int64, error) {
+	valInfo, err := loadValidatorsInfo(store.db, height)
+	if err != nil {
+		return 0, ErrNoValSetForHeight{height}
+	}
+	return valInfo.LastHeightChanged, nil
+}
+
 func lastStoredHeightFor(height, lastHeightChanged int64) int64 {
 	checkpointHeight := height - height%valSetCheckpointInterval
 	return tmmath.MaxInt64(checkpointHeight, lastHeightChanged)
@@ -620,6 +687,16 @@ func (store dbStore) LoadConsensusParams(height int64) (types.ConsensusParams, e
 	return types.ConsensusParamsFromProto(paramsInfo.ConsensusParams), nil
 }
 
+// LoadLastHeightConsensusParamsChanged loads the last height at which the
+// consensus params changed, as of the given height.
+func (store dbStore) LoadLastHeightConsensusParamsChanged(height int64) (int64, error) {
+	paramsInfo, err := store.loadConsensusParamsInfo(height)
+	if err != nil {
+		return 0, fmt.Errorf("could not find consensus params for height #%d: %w", height, err)
+	}
+	return paramsInfo.LastHeightChanged, nil
+}
+
 func (store dbStore) loadConsensusParamsInfo(height int64) (*tmstate.ConsensusParamsInfo, error) {
 	buf, err := store.db.Get(consensusParamsKey(height))
 	if err != nil {
@@ -276,14 +276,47 @@ func TestPruneStates(t *testing.T) {
 					require.Equal(t, emptyParams, params, h)
 				}
 
+				// the ABCI responses are pruned separately
 				abci, err := stateStore.LoadABCIResponses(h)
-				require.Error(t, err, h)
-				require.Nil(t, abci, h)
+				require.NoError(t, err, h)
+				require.NotNil(t, abci, h)
 			}
 		})
 	}
 }
 
+func TestPruneABCIResponses(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	base, err := stateStore.ABCIResponsesBase()
+	require.NoError(t, err)
+	require.EqualValues(t, 0, base)
+
+	for h := int64(1); h <= 1010; h++ {
+		err = stateStore.SaveABCIResponses(h, &tmstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{{Data: []byte{1}}},
+		})
+		require.NoError(t, err)
+	}
+
+	require.Error(t, stateStore.PruneABCIResponses(0))
+	require.NoError(t, stateStore.PruneABCIResponses(1005))
+
+	base, err = stateStore.ABCIResponsesBase()
+	require.NoError(t, err)
+	require.EqualValues(t, 1005, base)
+
+	for h := int64(1); h <= 1010; h++ {
+		abci, err := stateStore.LoadABCIResponses(h)
+		if h < 1005 {
+			require.Error(t, err, h)
+			require.Nil(t, abci, h)
+		} else {
+			require.NoError(t, err, h)
+			require.NotNil(t, abci, h)
+		}
+	}
+}
+
 func TestABCIResponsesResultsHash(t *testing.T) {
 	responses := &tmstate.ABCIResponses{
 		BeginBlock: &abci.ResponseBeginBlock{},
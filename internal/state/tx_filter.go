@@ -6,13 +6,20 @@ import (
 )
 
 // TxPreCheck returns a function to filter transactions before processing.
-// The function limits the size of a transaction to the block's maximum data size.
+// The function limits the size of a transaction to the block's maximum data size,
+// and checks the share commitments of the blob txs.
 func TxPreCheck(state State) mempool.PreCheckFunc {
 	maxDataBytes := types.MaxDataBytesNoEvidence(
 		state.ConsensusParams.Block.MaxBytes,
 		state.Validators.Size(),
 	)
-	return mempool.PreCheckMaxBytes(maxDataBytes)
+	preCheckMaxBytes := mempool.PreCheckMaxBytes(maxDataBytes)
+	return func(tx types.Tx) error {
+		if err := preCheckMaxBytes(tx); err != nil {
+			return err
+		}
+		return mempool.PreCheckBlobTx(tx)
+	}
 }
 
 // TxPostCheck returns a function to filter transactions after processing.
@@ -51,10 +51,12 @@ func validateBlock(state State, block *types.Block) error {
 
 	// Validate app info
 	if !bytes.Equal(block.AppHash, state.AppHash) {
-		return fmt.Errorf("wrong Block.Header.AppHash.  Expected %X, got %v",
-			state.AppHash,
-			block.AppHash,
-		)
+		return ErrAppHashMismatch{
+			Field:    "AppHash",
+			Height:   block.Height,
+			Expected: block.AppHash,
+			Got:      state.AppHash,
+		}
 	}
 	hashCP := state.ConsensusParams.HashConsensusParams()
 	if !bytes.Equal(block.ConsensusHash, hashCP) {
@@ -64,10 +66,12 @@ func validateBlock(state State, block *types.Block) error {
 		)
 	}
 	if !bytes.Equal(block.LastResultsHash, state.LastResultsHash) {
-		return fmt.Errorf("wrong Block.Header.LastResultsHash.  Expected %X, got %v",
-			state.LastResultsHash,
-			block.LastResultsHash,
-		)
+		return ErrAppHashMismatch{
+			Field:    "LastResultsHash",
+			Height:   block.Height,
+			Expected: block.LastResultsHash,
+			Got:      state.LastResultsHash,
+		}
 	}
 	if !bytes.Equal(block.ValidatorsHash, state.Validators.Hash()) {
 		return fmt.Errorf("wrong Block.Header.ValidatorsHash.  Expected %X, got %v",
@@ -136,6 +140,11 @@ func validateBlock(state State, block *types.Block) error {
 			block.Height, state.InitialHeight)
 	}
 
+	// Check the data fits in the max square size.
+	if max, got := state.ConsensusParams.Block.SquareSizeUpperBound(), block.Data.SquareSize(); got > max {
+		return fmt.Errorf("block data square size %d exceeds the max square size %d", got, max)
+	}
+
 	// Check evidence doesn't exceed the limit amount of bytes.
 	if max, got := state.ConsensusParams.Evidence.MaxBytes, block.Evidence.ByteSize(); got > max {
 		return types.NewErrEvidenceOverflow(max, got)
@@ -0,0 +1,66 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// VerifyStore checks that the state of stateStore is consistent with the
+// blocks of blockStore: that the last block of the state is the latest block,
+// or the one before if the latest block wasn't executed, and that the
+// validators and the consensus parameters of the blocks are saved and match
+// their headers. It returns the inconsistencies found.
+func VerifyStore(stateStore Store, blockStore BlockStore) ([]error, error) {
+	state, err := stateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	base, height := blockStore.Base(), blockStore.Height()
+	if state.IsEmpty() {
+		if height > 0 {
+			return []error{errors.New("no state, but the block store has blocks")}, nil
+		}
+		return nil, nil
+	}
+
+	var inconsistencies []error
+	if state.LastBlockHeight != height && state.LastBlockHeight != height-1 {
+		inconsistencies = append(inconsistencies, fmt.Errorf(
+			"the state is at height %d, but the block store is at height %d", state.LastBlockHeight, height))
+	}
+
+	for h := base; h > 0 && h <= state.LastBlockHeight+1; h++ {
+		vals, err := stateStore.LoadValidators(h)
+		if err != nil {
+			inconsistencies = append(inconsistencies, fmt.Errorf("height %d: %w", h, err))
+		}
+
+		blockMeta := blockStore.LoadBlockMeta(h)
+		if blockMeta == nil {
+			continue
+		}
+		if vals != nil && !bytes.Equal(vals.Hash(), blockMeta.Header.ValidatorsHash) {
+			inconsistencies = append(inconsistencies, fmt.Errorf(
+				"height %d: validators hash %X doesn't match the header validators hash %X",
+				h, vals.Hash(), blockMeta.Header.ValidatorsHash))
+		}
+
+		// the consensus parameters of the base aren't saved after a state sync
+		if h == base {
+			continue
+		}
+		params, err := stateStore.LoadConsensusParams(h)
+		if err != nil {
+			inconsistencies = append(inconsistencies, fmt.Errorf("height %d: %w", h, err))
+			continue
+		}
+		if !bytes.Equal(params.HashConsensusParams(), blockMeta.Header.ConsensusHash) {
+			inconsistencies = append(inconsistencies, fmt.Errorf(
+				"height %d: consensus params hash %X doesn't match the header consensus hash %X",
+				h, params.HashConsensusParams(), blockMeta.Header.ConsensusHash))
+		}
+	}
+
+	return inconsistencies, nil
+}
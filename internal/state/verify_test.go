@@ -0,0 +1,50 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	mmock "github.com/tendermint/tendermint/internal/mempool/mock"
+	sm "github.com/tendermint/tendermint/internal/state"
+	sf "github.com/tendermint/tendermint/internal/state/test/factory"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestVerifyStore(t *testing.T) {
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore)
+
+	inconsistencies, err := sm.VerifyStore(stateStore, blockStore)
+	require.NoError(t, err)
+	require.Empty(t, inconsistencies)
+
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for height := int64(1); height <= 5; height++ {
+		var err error
+		block := sf.MakeBlock(state, height, lastCommit)
+		blockStore.SaveBlock(block, block.MakePartSet(testPartSize), lastCommit)
+		state, _, lastCommit, err = makeAndCommitGoodBlock(
+			state, height, lastCommit, state.Validators.GetProposer().Address, blockExec, privVals, nil)
+		require.NoError(t, err, "height %d", height)
+	}
+
+	inconsistencies, err = sm.VerifyStore(stateStore, blockStore)
+	require.NoError(t, err)
+	require.Empty(t, inconsistencies)
+
+	// the state is ahead of an empty block store
+	inconsistencies, err = sm.VerifyStore(stateStore, store.NewBlockStore(dbm.NewMemDB()))
+	require.NoError(t, err)
+	require.Len(t, inconsistencies, 1)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/libs/bits"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -37,6 +38,7 @@ type chunkQueue struct {
 	chunkAllocated map[uint32]bool            // chunks that have been allocated via Allocate()
 	chunkReturned  map[uint32]bool            // chunks returned via Next()
 	waiters        map[uint32][]chan<- uint32 // signals WaitFor() waiters about chunk arrival
+	keepDir        bool                       // don't remove dir on Close(), see openChunkQueue()
 }
 
 // newChunkQueue creates a new chunk queue for a snapshot, using a temp dir for storage.
@@ -61,6 +63,42 @@ func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
 	}, nil
 }
 
+// openChunkQueue creates a chunk queue for a snapshot, storing chunks in dir. The applied chunks
+// already in dir are added to the queue, as if they had been fetched from a peer. Unlike
+// newChunkQueue, dir is not removed when the queue is closed, so that the chunks can be reused
+// when resuming an interrupted sync.
+func openChunkQueue(snapshot *snapshot, dir string, applied *bits.BitArray) (*chunkQueue, error) {
+	if snapshot.Chunks == 0 {
+		return nil, errors.New("snapshot has no chunks")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create dir for state sync chunks: %w", err)
+	}
+
+	q := &chunkQueue{
+		snapshot:       snapshot,
+		dir:            dir,
+		chunkFiles:     make(map[uint32]string, snapshot.Chunks),
+		chunkSenders:   make(map[uint32]types.NodeID, snapshot.Chunks),
+		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
+		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
+		waiters:        make(map[uint32][]chan<- uint32),
+		keepDir:        true,
+	}
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		if !applied.GetIndex(int(i)) {
+			continue
+		}
+		path := filepath.Join(dir, strconv.FormatUint(uint64(i), 10))
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		q.chunkFiles[i] = path
+		q.chunkAllocated[i] = true
+	}
+	return q, nil
+}
+
 // Add adds a chunk to the queue. It ignores chunks that already exist, returning false.
 func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 	if chunk == nil || chunk.Chunk == nil {
@@ -148,6 +186,9 @@ func (q *chunkQueue) Close() error {
 	q.waiters = nil
 	q.snapshot = nil
 
+	if q.keepDir {
+		return nil
+	}
 	if err := os.RemoveAll(q.dir); err != nil {
 		return fmt.Errorf("failed to clean up state sync tempdir %v: %w", q.dir, err)
 	}
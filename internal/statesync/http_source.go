@@ -0,0 +1,158 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// httpSnapshotManifest is the name of the file listing the snapshots
+	// available from an HTTP source.
+	httpSnapshotManifest = "snapshots.json"
+
+	// httpRequestTimeout is the timeout for a single request to an HTTP
+	// source.
+	httpRequestTimeout = 1 * time.Minute
+)
+
+// httpSnapshot is the description of a snapshot in the manifest of an HTTP
+// source. ChunkHashes holds the SHA-256 hash of each chunk, which is checked
+// before a chunk is handed to the application.
+type httpSnapshot struct {
+	Height      uint64             `json:"height"`
+	Format      uint32             `json:"format"`
+	Chunks      uint32             `json:"chunks"`
+	Hash        tmbytes.HexBytes   `json:"hash"`
+	Metadata    []byte             `json:"metadata"`
+	ChunkHashes []tmbytes.HexBytes `json:"chunk_hashes"`
+}
+
+// httpManifest is the manifest of an HTTP source.
+type httpManifest struct {
+	Snapshots []httpSnapshot `json:"snapshots"`
+}
+
+// httpSource fetches snapshots from a static file layout served over
+// HTTP(S), such as a CDN or an S3-style object store bucket:
+//
+//	<url>/snapshots.json                 the manifest, see httpManifest
+//	<url>/<height>/<format>/<index>      the contents of each chunk
+//
+// Sources take part in the snapshot pool like peers do, under an ID derived
+// from their URL, so the application can reject them as it would a peer.
+type httpSource struct {
+	id     types.NodeID
+	url    string
+	client *http.Client
+
+	mtx         tmsync.Mutex
+	chunkHashes map[snapshotKey][]tmbytes.HexBytes
+}
+
+// newHTTPSource creates a snapshot source for the given base URL.
+func newHTTPSource(url string) *httpSource {
+	url = strings.TrimSuffix(url, "/")
+	return &httpSource{
+		id:          types.NodeID("http-source:" + url),
+		url:         url,
+		client:      &http.Client{Timeout: httpRequestTimeout},
+		chunkHashes: make(map[snapshotKey][]tmbytes.HexBytes),
+	}
+}
+
+// ListSnapshots fetches and validates the manifest of the source.
+func (h *httpSource) ListSnapshots(ctx context.Context) ([]*snapshot, error) {
+	body, err := h.get(ctx, h.url+"/"+httpSnapshotManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest httpManifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid snapshot manifest from %s: %w", h.url, err)
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	snapshots := make([]*snapshot, 0, len(manifest.Snapshots))
+	for _, s := range manifest.Snapshots {
+		if s.Chunks == 0 {
+			return nil, fmt.Errorf("snapshot %d/%d from %s has no chunks", s.Height, s.Format, h.url)
+		}
+		if len(s.ChunkHashes) != int(s.Chunks) {
+			return nil, fmt.Errorf("snapshot %d/%d from %s has %d chunks but %d chunk hashes",
+				s.Height, s.Format, h.url, s.Chunks, len(s.ChunkHashes))
+		}
+		snap := &snapshot{
+			Height:   s.Height,
+			Format:   s.Format,
+			Chunks:   s.Chunks,
+			Hash:     s.Hash,
+			Metadata: s.Metadata,
+		}
+		h.chunkHashes[snap.Key()] = s.ChunkHashes
+		snapshots = append(snapshots, snap)
+	}
+	return snapshots, nil
+}
+
+// FetchChunk downloads a chunk of a snapshot previously returned by
+// ListSnapshots, and checks it against the hash listed in the manifest.
+func (h *httpSource) FetchChunk(ctx context.Context, snapshot *snapshot, index uint32) (*chunk, error) {
+	h.mtx.Lock()
+	hashes, ok := h.chunkHashes[snapshot.Key()]
+	h.mtx.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("snapshot %d/%d is not provided by %s", snapshot.Height, snapshot.Format, h.url)
+	}
+	if index >= uint32(len(hashes)) {
+		return nil, fmt.Errorf("chunk %d out of range for snapshot %d/%d", index, snapshot.Height, snapshot.Format)
+	}
+
+	body, err := h.get(ctx, fmt.Sprintf("%s/%d/%d/%d", h.url, snapshot.Height, snapshot.Format, index))
+	if err != nil {
+		return nil, err
+	}
+	if hash := sha256.Sum256(body); !bytes.Equal(hash[:], hashes[index]) {
+		return nil, fmt.Errorf("chunk %d of snapshot %d/%d from %s has hash %X, expected %X",
+			index, snapshot.Height, snapshot.Format, h.url, hash, hashes[index])
+	}
+
+	return &chunk{
+		Height: snapshot.Height,
+		Format: snapshot.Format,
+		Index:  index,
+		Chunk:  body,
+		Sender: h.id,
+	}, nil
+}
+
+func (h *httpSource) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", url, resp.Status)
+	}
+	return ioutil.ReadAll(io.LimitReader(resp.Body, int64(chunkMsgSize)))
+}
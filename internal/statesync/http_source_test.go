@@ -0,0 +1,140 @@
+package statesync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/types"
+)
+
+// serveSnapshot serves a single snapshot in the layout expected by
+// httpSource. The contents of chunk corrupt differ from the ones listed in the
+// manifest.
+func serveSnapshot(t *testing.T, chunks [][]byte, corrupt int) *httptest.Server {
+	hashes := make([]tmbytes.HexBytes, len(chunks))
+	for i, c := range chunks {
+		hash := sha256.Sum256(c)
+		hashes[i] = hash[:]
+	}
+	manifest, err := json.Marshal(httpManifest{Snapshots: []httpSnapshot{{
+		Height:      3,
+		Format:      1,
+		Chunks:      uint32(len(chunks)),
+		Hash:        []byte{1, 2, 3},
+		Metadata:    []byte("metadata"),
+		ChunkHashes: hashes,
+	}}})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/snapshots.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(manifest)
+	})
+	for i, c := range chunks {
+		c := c
+		if i == corrupt {
+			c = []byte("corrupt")
+		}
+		mux.HandleFunc("/3/1/"+string(rune('0'+i)), func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(c)
+		})
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHTTPSource(t *testing.T) {
+	chunks := [][]byte{{3, 1, 0}, {3, 1, 1}, {3, 1, 2}}
+	srv := serveSnapshot(t, chunks, 2)
+	source := newHTTPSource(srv.URL + "/")
+
+	snapshots, err := source.ListSnapshots(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*snapshot{{
+		Height:   3,
+		Format:   1,
+		Chunks:   3,
+		Hash:     []byte{1, 2, 3},
+		Metadata: []byte("metadata"),
+	}}, snapshots)
+
+	c, err := source.FetchChunk(ctx, snapshots[0], 1)
+	require.NoError(t, err)
+	require.Equal(t, &chunk{Height: 3, Format: 1, Index: 1, Chunk: chunks[1], Sender: source.id}, c)
+
+	// The served chunk doesn't match the manifest.
+	_, err = source.FetchChunk(ctx, snapshots[0], 2)
+	require.Error(t, err)
+
+	// Out of range.
+	_, err = source.FetchChunk(ctx, snapshots[0], 3)
+	require.Error(t, err)
+
+	// Unknown snapshot.
+	_, err = source.FetchChunk(ctx, &snapshot{Height: 4, Format: 1, Chunks: 1}, 0)
+	require.Error(t, err)
+}
+
+func TestHTTPSource_InvalidManifest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"snapshots":[{"height":1,"format":1,"chunks":2,"chunk_hashes":["AA"]}]}`))
+	}))
+	defer srv.Close()
+
+	_, err := newHTTPSource(srv.URL).ListSnapshots(ctx)
+	require.Error(t, err)
+
+	missing := httptest.NewServer(http.NotFoundHandler())
+	defer missing.Close()
+
+	_, err = newHTTPSource(missing.URL).ListSnapshots(ctx)
+	require.Error(t, err)
+}
+
+func TestSyncer_ChunksFromSource(t *testing.T) {
+	chunks := [][]byte{{3, 1, 0}, {3, 1, 1}}
+	srv := serveSnapshot(t, chunks, -1)
+	source := newHTTPSource(srv.URL)
+
+	rts := setup(t, nil, nil, nil, 2)
+	require.NoError(t, rts.syncer.AddSource(ctx, source))
+
+	snapshot := rts.syncer.snapshots.Best()
+	require.NotNil(t, snapshot)
+	require.Equal(t, []types.NodeID{source.id}, rts.syncer.snapshots.GetPeers(snapshot))
+
+	queue, err := newChunkQueue(snapshot, t.TempDir())
+	require.NoError(t, err)
+	defer queue.Close()
+	rts.syncer.mtx.Lock()
+	rts.syncer.chunks = queue
+	rts.syncer.mtx.Unlock()
+
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for i := range chunks {
+		index := uint32(i)
+		rts.syncer.requestChunk(fetchCtx, snapshot, index)
+		select {
+		case <-queue.WaitFor(index):
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for chunk %d", index)
+		}
+	}
+
+	for i := range chunks {
+		c, err := queue.Next()
+		require.NoError(t, err)
+		require.Equal(t, chunks[i], c.Chunk)
+		require.Equal(t, source.id, c.Sender)
+	}
+}
@@ -14,13 +14,22 @@ const (
 
 // Metrics contains metrics exposed by this package.
 type Metrics struct {
-	TotalSnapshots      metrics.Counter
-	ChunkProcessAvgTime metrics.Gauge
-	SnapshotHeight      metrics.Gauge
-	SnapshotChunk       metrics.Counter
-	SnapshotChunkTotal  metrics.Gauge
-	BackFilledBlocks    metrics.Counter
-	BackFillBlocksTotal metrics.Gauge
+	TotalSnapshots        metrics.Counter
+	ChunkProcessAvgTime   metrics.Gauge
+	SnapshotHeight        metrics.Gauge
+	SnapshotChunk         metrics.Counter
+	SnapshotChunkTotal    metrics.Gauge
+	SnapshotRemainingTime metrics.Gauge
+	BackFilledBlocks      metrics.Counter
+	BackFillBlocksTotal   metrics.Gauge
+
+	SnapshotRequestsServed metrics.Counter
+	ChunksServed           metrics.Counter
+	ChunkBytesServed       metrics.Counter
+	ChunkCacheHits         metrics.Counter
+	ChunkCacheMisses       metrics.Counter
+	ChunkCacheSize         metrics.Gauge
+	ServedSnapshotHeight   metrics.Gauge
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -62,6 +71,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "snapshot_chunks_total",
 			Help:      "The total number of chunks in the current snapshot.",
 		}, labels).With(labelsAndValues...),
+		SnapshotRemainingTime: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshot_remaining_time",
+			Help:      "The estimated time in seconds left to restore the current snapshot.",
+		}, labels).With(labelsAndValues...),
 		BackFilledBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -74,18 +89,69 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "backfilled_blocks_total",
 			Help:      "The total number of blocks that need to be back-filled.",
 		}, labels).With(labelsAndValues...),
+		SnapshotRequestsServed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshot_requests_served",
+			Help:      "The number of snapshot requests from peers that have been answered.",
+		}, labels).With(labelsAndValues...),
+		ChunksServed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunks_served",
+			Help:      "The number of snapshot chunks served to peers.",
+		}, labels).With(labelsAndValues...),
+		ChunkBytesServed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunk_bytes_served",
+			Help:      "The number of bytes of snapshot chunks served to peers.",
+		}, labels).With(labelsAndValues...),
+		ChunkCacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunk_cache_hits",
+			Help:      "The number of served chunks found in the snapshot chunk cache.",
+		}, labels).With(labelsAndValues...),
+		ChunkCacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunk_cache_misses",
+			Help:      "The number of served chunks loaded from the application.",
+		}, labels).With(labelsAndValues...),
+		ChunkCacheSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunk_cache_size",
+			Help:      "The size in bytes of the snapshot chunk cache.",
+		}, labels).With(labelsAndValues...),
+		ServedSnapshotHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "served_snapshot_height",
+			Help:      "The height of the most recent snapshot served to peers.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
 // NopMetrics returns no-op Metrics.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		TotalSnapshots:      discard.NewCounter(),
-		ChunkProcessAvgTime: discard.NewGauge(),
-		SnapshotHeight:      discard.NewGauge(),
-		SnapshotChunk:       discard.NewCounter(),
-		SnapshotChunkTotal:  discard.NewGauge(),
-		BackFilledBlocks:    discard.NewCounter(),
-		BackFillBlocksTotal: discard.NewGauge(),
+		TotalSnapshots:        discard.NewCounter(),
+		ChunkProcessAvgTime:   discard.NewGauge(),
+		SnapshotHeight:        discard.NewGauge(),
+		SnapshotChunk:         discard.NewCounter(),
+		SnapshotChunkTotal:    discard.NewGauge(),
+		SnapshotRemainingTime: discard.NewGauge(),
+		BackFilledBlocks:      discard.NewCounter(),
+		BackFillBlocksTotal:   discard.NewGauge(),
+
+		SnapshotRequestsServed: discard.NewCounter(),
+		ChunksServed:           discard.NewCounter(),
+		ChunkBytesServed:       discard.NewCounter(),
+		ChunkCacheHits:         discard.NewCounter(),
+		ChunkCacheMisses:       discard.NewCounter(),
+		ChunkCacheSize:         discard.NewGauge(),
+		ServedSnapshotHeight:   discard.NewGauge(),
 	}
 }
@@ -97,6 +97,20 @@ func (_m *Metricer) SnapshotHeight() int64 {
 	return r0
 }
 
+// SnapshotRemainingTime provides a mock function with given fields:
+func (_m *Metricer) SnapshotRemainingTime() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
 // TotalSnapshots provides a mock function with given fields:
 func (_m *Metricer) TotalSnapshots() int64 {
 	ret := _m.Called()
@@ -0,0 +1,85 @@
+package statesync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	"github.com/tendermint/tendermint/libs/bits"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+)
+
+const (
+	// progressFile is the name of the file holding the sync progress within
+	// the progress directory.
+	progressFile = "progress.json"
+
+	// progressChunksDir is the name of the directory holding the applied
+	// chunks within the progress directory.
+	progressChunksDir = "chunks"
+)
+
+// syncProgress is the persisted progress of a state sync: the snapshot that
+// was accepted by the application and the chunks it has applied. The applied
+// chunks are kept on disk, so that a node restarting mid-sync can offer the
+// same snapshot again and replay them without refetching them from peers.
+type syncProgress struct {
+	Height   uint64           `json:"height"`
+	Format   uint32           `json:"format"`
+	Chunks   uint32           `json:"chunks"`
+	Hash     tmbytes.HexBytes `json:"hash"`
+	Metadata []byte           `json:"metadata"`
+	Applied  *bits.BitArray   `json:"applied"`
+}
+
+// Snapshot returns the snapshot the progress refers to.
+func (p *syncProgress) Snapshot() *snapshot {
+	return &snapshot{
+		Height:   p.Height,
+		Format:   p.Format,
+		Chunks:   p.Chunks,
+		Hash:     p.Hash,
+		Metadata: p.Metadata,
+	}
+}
+
+// loadProgress loads the sync progress persisted in dir. It returns nil if
+// there is none.
+func loadProgress(dir string) (*syncProgress, error) {
+	bz, err := ioutil.ReadFile(filepath.Join(dir, progressFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var progress syncProgress
+	if err := json.Unmarshal(bz, &progress); err != nil {
+		return nil, fmt.Errorf("invalid state sync progress: %w", err)
+	}
+	if progress.Applied == nil || progress.Applied.Size() != int(progress.Chunks) {
+		return nil, errors.New("invalid state sync progress: applied chunks do not match snapshot")
+	}
+	return &progress, nil
+}
+
+// saveProgress atomically persists the sync progress in dir.
+func saveProgress(dir string, progress *syncProgress) error {
+	bz, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(filepath.Join(dir, progressFile), bz, 0600)
+}
+
+// clearProgress removes the sync progress and the applied chunks from dir.
+func clearProgress(dir string) error {
+	return os.RemoveAll(dir)
+}
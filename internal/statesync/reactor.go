@@ -133,6 +133,7 @@ type Metricer interface {
 	SnapshotHeight() int64
 	SnapshotChunksCount() int64
 	SnapshotChunksTotal() int64
+	SnapshotRemainingTime() time.Duration
 	BackFilledBlocks() int64
 	BackFillBlocksTotal() int64
 }
@@ -171,6 +172,10 @@ type Reactor struct {
 	providers     map[types.NodeID]*BlockProvider
 	stateProvider StateProvider
 
+	// server is only set when the node maintains its own cache of snapshots
+	// to serve, see StateSyncConfig.SnapshotInterval.
+	server *snapshotServer
+
 	metrics            *Metrics
 	backfillBlockTotal int64
 	backfilledBlocks   int64
@@ -226,6 +231,22 @@ func NewReactor(
 // The caller must be sure to execute OnStop to ensure the outbound p2p Channels are
 // closed. No error is returned.
 func (r *Reactor) OnStart() error {
+	if r.cfg.SnapshotInterval > 0 {
+		cache, err := newChunkCache(r.cfg.SnapshotCacheDir(), r.cfg.SnapshotCacheSize)
+		if err != nil {
+			return err
+		}
+		r.server = newSnapshotServer(
+			r.Logger.With("module", "snapshot-server"),
+			r.conn,
+			r.blockStore,
+			r.cfg.SnapshotInterval,
+			cache,
+			r.metrics,
+		)
+		go r.server.Run(r.closeCh)
+	}
+
 	go r.processSnapshotCh()
 
 	go r.processChunkCh()
@@ -304,6 +325,12 @@ func (r *Reactor) Sync(ctx context.Context) (sm.State, error) {
 		r.mtx.Unlock()
 	}()
 
+	for _, url := range r.cfg.SnapshotURLs {
+		if err := r.syncer.AddSource(ctx, newHTTPSource(url)); err != nil {
+			r.Logger.Error("failed to discover snapshots from source", "url", url, "err", err)
+		}
+	}
+
 	requestSnapshotsHook := func() {
 		// request snapshots from all currently connected peers
 		msg := p2p.Envelope{
@@ -346,15 +373,7 @@ func (r *Reactor) Sync(ctx context.Context) (sm.State, error) {
 // and time that is less or equal to the stopHeight and stopTime. The
 // trustedBlockID should be of the header at startHeight.
 func (r *Reactor) Backfill(ctx context.Context, state sm.State) error {
-	params := state.ConsensusParams.Evidence
-	stopHeight := state.LastBlockHeight - params.MaxAgeNumBlocks
-	stopTime := state.LastBlockTime.Add(-params.MaxAgeDuration)
-	// ensure that stop height doesn't go below the initial height
-	if stopHeight < state.InitialHeight {
-		stopHeight = state.InitialHeight
-		// this essentially makes stop time a void criteria for termination
-		stopTime = state.LastBlockTime
-	}
+	stopHeight, stopTime := r.backfillTarget(state)
 	return r.backfill(
 		ctx,
 		state.ChainID,
@@ -366,6 +385,25 @@ func (r *Reactor) Backfill(ctx context.Context, state sm.State) error {
 	)
 }
 
+// backfillTarget returns the height and time down to which blocks are
+// backfilled: far enough back to verify any evidence that is still valid, or
+// down to the configured retain height if that is lower.
+func (r *Reactor) backfillTarget(state sm.State) (int64, time.Time) {
+	params := state.ConsensusParams.Evidence
+	stopHeight := state.LastBlockHeight - params.MaxAgeNumBlocks
+	stopTime := state.LastBlockTime.Add(-params.MaxAgeDuration)
+	if retain := r.cfg.BackfillRetainHeight; retain > 0 && retain < stopHeight {
+		stopHeight = retain
+	}
+	// ensure that stop height doesn't go below the initial height
+	if stopHeight < state.InitialHeight {
+		stopHeight = state.InitialHeight
+		// this essentially makes stop time a void criteria for termination
+		stopTime = state.LastBlockTime
+	}
+	return stopHeight, stopTime
+}
+
 func (r *Reactor) backfill(
 	ctx context.Context,
 	chainID string,
@@ -547,11 +585,18 @@ func (r *Reactor) handleSnapshotMessage(envelope p2p.Envelope) error {
 
 	switch msg := envelope.Message.(type) {
 	case *ssproto.SnapshotsRequest:
-		snapshots, err := r.recentSnapshots(recentSnapshots)
-		if err != nil {
-			logger.Error("failed to fetch snapshots", "err", err)
-			return nil
+		var snapshots []*snapshot
+		if r.server != nil {
+			snapshots = r.server.Snapshots()
+		} else {
+			var err error
+			snapshots, err = r.recentSnapshots(recentSnapshots)
+			if err != nil {
+				logger.Error("failed to fetch snapshots", "err", err)
+				return nil
+			}
 		}
+		r.metrics.SnapshotRequestsServed.Add(1)
 
 		for _, snapshot := range snapshots {
 			logger.Info(
@@ -621,11 +666,7 @@ func (r *Reactor) handleChunkMessage(envelope p2p.Envelope) error {
 			"chunk", msg.Index,
 			"peer", envelope.From,
 		)
-		resp, err := r.conn.LoadSnapshotChunkSync(context.Background(), abci.RequestLoadSnapshotChunk{
-			Height: msg.Height,
-			Format: msg.Format,
-			Chunk:  msg.Index,
-		})
+		body, err := r.loadChunk(msg.Height, msg.Format, msg.Index)
 		if err != nil {
 			r.Logger.Error(
 				"failed to load chunk",
@@ -651,10 +692,14 @@ func (r *Reactor) handleChunkMessage(envelope p2p.Envelope) error {
 				Height:  msg.Height,
 				Format:  msg.Format,
 				Index:   msg.Index,
-				Chunk:   resp.Chunk,
-				Missing: resp.Chunk == nil,
+				Chunk:   body,
+				Missing: body == nil,
 			},
 		}
+		if body != nil {
+			r.metrics.ChunksServed.Add(1)
+			r.metrics.ChunkBytesServed.Add(float64(len(body)))
+		}
 
 	case *ssproto.ChunkResponse:
 		r.mtx.RLock()
@@ -698,6 +743,23 @@ func (r *Reactor) handleChunkMessage(envelope p2p.Envelope) error {
 	return nil
 }
 
+// loadChunk loads a snapshot chunk to serve to a peer, through the snapshot
+// server's cache if it is enabled.
+func (r *Reactor) loadChunk(height uint64, format, index uint32) ([]byte, error) {
+	if r.server != nil {
+		return r.server.LoadChunk(height, format, index)
+	}
+	resp, err := r.conn.LoadSnapshotChunkSync(context.Background(), abci.RequestLoadSnapshotChunk{
+		Height: height,
+		Format: format,
+		Chunk:  index,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Chunk, nil
+}
+
 func (r *Reactor) handleLightBlockMessage(envelope p2p.Envelope) error {
 	switch msg := envelope.Message.(type) {
 	case *ssproto.LightBlockRequest:
@@ -931,9 +993,25 @@ func (r *Reactor) processPeerUpdates() {
 	}
 }
 
+// ServesSnapshots returns whether the node has snapshots to serve to its
+// peers.
+func (r *Reactor) ServesSnapshots() bool {
+	if r.server != nil {
+		return len(r.server.Snapshots()) > 0
+	}
+	snapshots, err := r.recentSnapshots(1)
+	return err == nil && len(snapshots) > 0
+}
+
 // recentSnapshots fetches the n most recent snapshots from the app
 func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
-	resp, err := r.conn.ListSnapshotsSync(context.Background(), abci.RequestListSnapshots{})
+	return recentAppSnapshots(r.conn, n)
+}
+
+// recentAppSnapshots fetches the n most recent snapshots from the app, most
+// recent first.
+func recentAppSnapshots(conn proxy.AppConnSnapshot, n uint32) ([]*snapshot, error) {
+	resp, err := conn.ListSnapshotsSync(context.Background(), abci.RequestListSnapshots{})
 	if err != nil {
 		return nil, err
 	}
@@ -1115,6 +1193,19 @@ func (r *Reactor) SnapshotChunksTotal() int64 {
 	return 0
 }
 
+// SnapshotRemainingTime estimates the time left to restore the snapshot being
+// synced, based on the average time taken per chunk so far.
+func (r *Reactor) SnapshotRemainingTime() time.Duration {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	if r.syncer != nil && r.syncer.chunks != nil {
+		remaining := int64(r.syncer.chunks.Size()) - int64(r.syncer.chunks.numChunksReturned())
+		return time.Duration(r.syncer.avgChunkTime * remaining)
+	}
+	return 0
+}
+
 func (r *Reactor) BackFilledBlocks() int64 {
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
@@ -18,6 +18,7 @@ import (
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/proxy"
 	proxymocks "github.com/tendermint/tendermint/internal/proxy/mocks"
+	sm "github.com/tendermint/tendermint/internal/state"
 	smmocks "github.com/tendermint/tendermint/internal/state/mocks"
 	"github.com/tendermint/tendermint/internal/statesync/mocks"
 	"github.com/tendermint/tendermint/internal/store"
@@ -554,6 +555,41 @@ func TestReactor_StateProviderP2P(t *testing.T) {
 	require.True(t, added)
 }
 
+func TestReactor_BackfillTarget(t *testing.T) {
+	rts := setup(t, nil, nil, nil, 2)
+
+	now := time.Now()
+	state := sm.State{
+		InitialHeight:   1,
+		LastBlockHeight: 1000,
+		LastBlockTime:   now,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+	state.ConsensusParams.Evidence.MaxAgeNumBlocks = 100
+	state.ConsensusParams.Evidence.MaxAgeDuration = time.Hour
+
+	// by default only evidence that is still valid is covered
+	stopHeight, stopTime := rts.reactor.backfillTarget(state)
+	require.EqualValues(t, 900, stopHeight)
+	require.Equal(t, now.Add(-time.Hour), stopTime)
+
+	// a lower retain height backfills further
+	rts.reactor.cfg.BackfillRetainHeight = 500
+	stopHeight, _ = rts.reactor.backfillTarget(state)
+	require.EqualValues(t, 500, stopHeight)
+
+	// a higher retain height can't prevent evidence verification
+	rts.reactor.cfg.BackfillRetainHeight = 950
+	stopHeight, _ = rts.reactor.backfillTarget(state)
+	require.EqualValues(t, 900, stopHeight)
+
+	// backfill never goes below the initial height
+	state.InitialHeight = 950
+	stopHeight, stopTime = rts.reactor.backfillTarget(state)
+	require.EqualValues(t, 950, stopHeight)
+	require.Equal(t, now, stopTime)
+}
+
 func TestReactor_Backfill(t *testing.T) {
 	// test backfill algorithm with varying failure rates [0, 10]
 	failureRates := []int{0, 2, 9}
@@ -0,0 +1,140 @@
+package statesync
+
+import (
+	"container/list"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+)
+
+// chunkCacheKey identifies a cached chunk.
+type chunkCacheKey struct {
+	height uint64
+	format uint32
+	index  uint32
+}
+
+// chunkCacheEntry is the value of an element of the cache's LRU list.
+type chunkCacheEntry struct {
+	key  chunkCacheKey
+	size int64
+}
+
+// chunkCache is an on-disk cache of snapshot chunks, bounded by the total size
+// of the chunks it holds. When the bound is exceeded the least recently used
+// chunks are evicted.
+type chunkCache struct {
+	mtx      tmsync.Mutex
+	dir      string
+	maxBytes int64
+	size     int64
+	lru      *list.List // front is most recently used
+	entries  map[chunkCacheKey]*list.Element
+}
+
+// newChunkCache creates a chunk cache in dir, holding at most maxBytes of
+// chunks. Any chunks left in dir by a previous run are removed, since the
+// application may have pruned their snapshots in the meantime.
+func newChunkCache(dir string, maxBytes int64) (*chunkCache, error) {
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("unable to clear snapshot chunk cache: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create snapshot chunk cache: %w", err)
+	}
+	return &chunkCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  make(map[chunkCacheKey]*list.Element),
+	}, nil
+}
+
+// Get returns a cached chunk, or false if the chunk is not in the cache.
+func (c *chunkCache) Get(height uint64, format, index uint32) ([]byte, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := chunkCacheKey{height, format, index}
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	body, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		c.remove(elem)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return body, true
+}
+
+// Has returns whether a chunk is in the cache, without marking it as used.
+func (c *chunkCache) Has(height uint64, format, index uint32) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	_, ok := c.entries[chunkCacheKey{height, format, index}]
+	return ok
+}
+
+// Put adds a chunk to the cache, evicting the least recently used chunks as
+// needed. Chunks larger than the cache itself are not cached.
+func (c *chunkCache) Put(height uint64, format, index uint32, body []byte) error {
+	size := int64(len(body))
+	if size > c.maxBytes {
+		return nil
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := chunkCacheKey{height, format, index}
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	for c.size+size > c.maxBytes {
+		c.remove(c.lru.Back())
+	}
+
+	if err := ioutil.WriteFile(c.path(key), body, 0600); err != nil {
+		return fmt.Errorf("unable to cache chunk %d of snapshot %d/%d: %w", index, height, format, err)
+	}
+	c.entries[key] = c.lru.PushFront(&chunkCacheEntry{key: key, size: size})
+	c.size += size
+	return nil
+}
+
+// RemoveSnapshot removes all cached chunks of a snapshot.
+func (c *chunkCache) RemoveSnapshot(height uint64, format uint32) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for key, elem := range c.entries {
+		if key.height == height && key.format == format {
+			c.remove(elem)
+		}
+	}
+}
+
+// Size returns the total size of the cached chunks.
+func (c *chunkCache) Size() int64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.size
+}
+
+// remove drops an element from the cache. The caller must hold the lock.
+func (c *chunkCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*chunkCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+	_ = os.Remove(c.path(entry.key))
+}
+
+func (c *chunkCache) path(key chunkCacheKey) string {
+	return filepath.Join(c.dir, fmt.Sprintf("%d-%d-%d", key.height, key.format, key.index))
+}
@@ -0,0 +1,39 @@
+package statesync
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkCache(t *testing.T) {
+	cache, err := newChunkCache(filepath.Join(t.TempDir(), "cache"), 10)
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Put(1, 1, 0, []byte{1, 1, 1, 1}))
+	require.NoError(t, cache.Put(1, 1, 1, []byte{2, 2, 2, 2}))
+	require.EqualValues(t, 8, cache.Size())
+
+	// touching chunk 0 makes chunk 1 the least recently used
+	body, ok := cache.Get(1, 1, 0)
+	require.True(t, ok)
+	require.Equal(t, []byte{1, 1, 1, 1}, body)
+
+	require.NoError(t, cache.Put(2, 1, 0, []byte{3, 3, 3, 3}))
+	require.EqualValues(t, 8, cache.Size())
+	require.True(t, cache.Has(1, 1, 0))
+	require.False(t, cache.Has(1, 1, 1))
+	require.True(t, cache.Has(2, 1, 0))
+
+	// chunks larger than the cache are not cached
+	require.NoError(t, cache.Put(3, 1, 0, make([]byte, 11)))
+	require.False(t, cache.Has(3, 1, 0))
+
+	cache.RemoveSnapshot(1, 1)
+	require.False(t, cache.Has(1, 1, 0))
+	require.EqualValues(t, 4, cache.Size())
+
+	_, ok = cache.Get(1, 1, 0)
+	require.False(t, ok)
+}
@@ -0,0 +1,186 @@
+package statesync
+
+import (
+	"context"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/internal/proxy"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// snapshotPollInterval is how often the snapshot server checks whether the
+// chain has crossed a snapshot interval.
+const snapshotPollInterval = time.Second
+
+// snapshotServer maintains the set of snapshots the node advertises to peers
+// and serves their chunks from an on-disk cache, so that serving peers does
+// not repeatedly hit the application.
+//
+// ABCI has no call to make the application take a snapshot; applications take
+// snapshots on their own schedule, usually during Commit. Every interval
+// blocks the server lists the application's snapshots, caches the chunks of
+// the most recent one and drops cached chunks of snapshots the application has
+// pruned. Chunks of older snapshots are cached as peers request them.
+type snapshotServer struct {
+	logger     log.Logger
+	conn       proxy.AppConnSnapshot
+	blockStore *store.BlockStore
+	interval   int64
+	cache      *chunkCache
+	metrics    *Metrics
+
+	mtx        tmsync.RWMutex
+	snapshots  []*snapshot
+	lastHeight int64 // height of the last refresh
+}
+
+func newSnapshotServer(
+	logger log.Logger,
+	conn proxy.AppConnSnapshot,
+	blockStore *store.BlockStore,
+	interval int64,
+	cache *chunkCache,
+	metrics *Metrics,
+) *snapshotServer {
+	return &snapshotServer{
+		logger:     logger,
+		conn:       conn,
+		blockStore: blockStore,
+		interval:   interval,
+		cache:      cache,
+		metrics:    metrics,
+	}
+}
+
+// Snapshots returns the snapshots to advertise, most recent first.
+func (s *snapshotServer) Snapshots() []*snapshot {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.snapshots
+}
+
+// LoadChunk returns a chunk from the cache, loading it from the application
+// and caching it on a miss. A nil chunk means the application does not have it.
+func (s *snapshotServer) LoadChunk(height uint64, format, index uint32) ([]byte, error) {
+	if body, ok := s.cache.Get(height, format, index); ok {
+		s.metrics.ChunkCacheHits.Add(1)
+		return body, nil
+	}
+	s.metrics.ChunkCacheMisses.Add(1)
+
+	resp, err := s.conn.LoadSnapshotChunkSync(context.Background(), abci.RequestLoadSnapshotChunk{
+		Height: height,
+		Format: format,
+		Chunk:  index,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Chunk != nil && s.advertised(height, format) {
+		if err := s.cache.Put(height, format, index, resp.Chunk); err != nil {
+			s.logger.Error("failed to cache chunk", "height", height, "format", format, "chunk", index, "err", err)
+		}
+		s.metrics.ChunkCacheSize.Set(float64(s.cache.Size()))
+	}
+	return resp.Chunk, nil
+}
+
+// advertised returns whether the snapshot is currently advertised to peers.
+func (s *snapshotServer) advertised(height uint64, format uint32) bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	for _, snapshot := range s.snapshots {
+		if snapshot.Height == height && snapshot.Format == format {
+			return true
+		}
+	}
+	return false
+}
+
+// Run refreshes the advertised snapshots each time the block height crosses a
+// multiple of the interval, until closeCh is closed.
+func (s *snapshotServer) Run(closeCh <-chan struct{}) {
+	ticker := time.NewTicker(snapshotPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if height := s.blockStore.Height(); s.due(height) {
+			if err := s.refresh(height); err != nil {
+				s.logger.Error("failed to refresh snapshots", "height", height, "err", err)
+			}
+		}
+
+		select {
+		case <-closeCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// due returns whether the snapshots should be refreshed at height.
+func (s *snapshotServer) due(height int64) bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	if height <= 0 {
+		return false
+	}
+	return s.lastHeight == 0 || height/s.interval > s.lastHeight/s.interval
+}
+
+// refresh lists the application's snapshots, caches the chunks of the most
+// recent one and evicts the chunks of snapshots that are no longer available.
+func (s *snapshotServer) refresh(height int64) error {
+	snapshots, err := recentAppSnapshots(s.conn, recentSnapshots)
+	if err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	old := s.snapshots
+	s.snapshots = snapshots
+	s.lastHeight = height
+	s.mtx.Unlock()
+
+	current := make(map[snapshotKey]bool, len(snapshots))
+	for _, snapshot := range snapshots {
+		current[snapshot.Key()] = true
+	}
+	for _, snapshot := range old {
+		if !current[snapshot.Key()] {
+			s.cache.RemoveSnapshot(snapshot.Height, snapshot.Format)
+		}
+	}
+
+	if len(snapshots) > 0 {
+		latest := snapshots[0]
+		s.metrics.ServedSnapshotHeight.Set(float64(latest.Height))
+		for index := uint32(0); index < latest.Chunks; index++ {
+			if s.cache.Has(latest.Height, latest.Format, index) {
+				continue
+			}
+			resp, err := s.conn.LoadSnapshotChunkSync(context.Background(), abci.RequestLoadSnapshotChunk{
+				Height: latest.Height,
+				Format: latest.Format,
+				Chunk:  index,
+			})
+			if err != nil {
+				return err
+			}
+			if resp.Chunk == nil {
+				break
+			}
+			if err := s.cache.Put(latest.Height, latest.Format, index, resp.Chunk); err != nil {
+				return err
+			}
+		}
+		s.logger.Info("cached snapshot for serving", "height", latest.Height, "format", latest.Format,
+			"chunks", latest.Chunks)
+	}
+	s.metrics.ChunkCacheSize.Set(float64(s.cache.Size()))
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package statesync
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	proxymocks "github.com/tendermint/tendermint/internal/proxy/mocks"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestSnapshotServer(t *testing.T) {
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshotsSync", mock.Anything, abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: []*abci.Snapshot{
+			{Height: 10, Format: 1, Chunks: 2, Hash: []byte{1}},
+			{Height: 20, Format: 1, Chunks: 2, Hash: []byte{2}},
+		},
+	}, nil).Once()
+	for _, c := range []chunkCacheKey{{20, 1, 0}, {20, 1, 1}, {10, 1, 1}} {
+		conn.On("LoadSnapshotChunkSync", mock.Anything, abci.RequestLoadSnapshotChunk{
+			Height: c.height, Format: c.format, Chunk: c.index,
+		}).Return(&abci.ResponseLoadSnapshotChunk{Chunk: []byte{byte(c.height), byte(c.index)}}, nil).Once()
+	}
+
+	cache, err := newChunkCache(filepath.Join(t.TempDir(), "cache"), 1024)
+	require.NoError(t, err)
+	server := newSnapshotServer(log.TestingLogger(), conn, store.NewBlockStore(dbm.NewMemDB()),
+		10, cache, NopMetrics())
+
+	require.False(t, server.due(0))
+	require.True(t, server.due(25))
+	require.NoError(t, server.refresh(25))
+	require.False(t, server.due(29))
+	require.True(t, server.due(30))
+
+	// the most recent snapshot is advertised first and cached eagerly
+	snapshots := server.Snapshots()
+	require.Len(t, snapshots, 2)
+	require.EqualValues(t, 20, snapshots[0].Height)
+	require.True(t, cache.Has(20, 1, 0))
+	require.True(t, cache.Has(20, 1, 1))
+	require.False(t, cache.Has(10, 1, 0))
+
+	// older snapshots are loaded from the app once, then served from the cache
+	for i := 0; i < 2; i++ {
+		body, err := server.LoadChunk(10, 1, 1)
+		require.NoError(t, err)
+		require.Equal(t, []byte{10, 1}, body)
+	}
+	body, err := server.LoadChunk(20, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, []byte{20, 0}, body)
+
+	// snapshots pruned by the app are evicted from the cache
+	conn.On("ListSnapshotsSync", mock.Anything, abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: []*abci.Snapshot{
+			{Height: 20, Format: 1, Chunks: 2, Hash: []byte{2}},
+		},
+	}, nil).Once()
+	require.NoError(t, server.refresh(30))
+	require.Len(t, server.Snapshots(), 1)
+	require.False(t, cache.Has(10, 1, 1))
+	require.True(t, cache.Has(20, 1, 1))
+
+	conn.AssertExpectations(t)
+}
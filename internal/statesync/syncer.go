@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -13,6 +14,7 @@ import (
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/proxy"
 	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/libs/bits"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/light"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
@@ -71,6 +73,15 @@ type syncer struct {
 	lastSyncedSnapshotHeight int64
 	processingSnapshot       *snapshot
 	closeCh                  <-chan struct{}
+
+	// sources provide snapshots from outside the p2p network, keyed by the
+	// ID they use in the snapshot pool.
+	sources map[types.NodeID]*httpSource
+
+	// progressDir is where the sync progress is persisted, if not empty.
+	// applied tracks the chunks of the current snapshot applied so far.
+	progressDir string
+	applied     *bits.BitArray
 }
 
 // newSyncer creates a new syncer.
@@ -99,6 +110,8 @@ func newSyncer(
 		retryTimeout:  cfg.ChunkRequestTimeout,
 		metrics:       metrics,
 		closeCh:       closeCh,
+		sources:       make(map[types.NodeID]*httpSource),
+		progressDir:   cfg.ProgressDir(),
 	}
 }
 
@@ -155,6 +168,23 @@ func (s *syncer) AddPeer(peerID types.NodeID) {
 	}
 }
 
+// AddSource registers a snapshot source outside of the p2p network and adds
+// the snapshots it provides to the pool. It must be called before syncing.
+func (s *syncer) AddSource(ctx context.Context, source *httpSource) error {
+	s.sources[source.id] = source
+
+	snapshots, err := source.ListSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		if _, err := s.AddSnapshot(source.id, snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // RemovePeer removes a peer from the pool.
 func (s *syncer) RemovePeer(peerID types.NodeID) {
 	s.logger.Debug("Removing peer from sync", "peer", peerID)
@@ -184,13 +214,39 @@ func (s *syncer) SyncAny(
 	var (
 		snapshot *snapshot
 		chunks   *chunkQueue
+		applied  *bits.BitArray
+		resume   *syncProgress
 		err      error
 	)
+
+	// A previous sync may have been interrupted, in which case we resume it if its snapshot
+	// is still available.
+	if s.progressDir != "" {
+		resume, err = loadProgress(s.progressDir)
+		if err != nil {
+			s.logger.Error("Failed to load state sync progress, starting over", "err", err)
+			s.resetProgress()
+		}
+	}
+
 	for {
 		// If not nil, we're going to retry restoration of the same snapshot.
 		if snapshot == nil {
 			snapshot = s.snapshots.Best()
 			chunks = nil
+			applied = nil
+			if resume != nil && snapshot != nil {
+				if resumed := s.snapshots.Get(resume.Snapshot().Key()); resumed != nil {
+					s.logger.Info("Resuming interrupted state sync", "height", resume.Height,
+						"format", resume.Format, "hash", resume.Hash)
+					snapshot = resumed
+					applied = resume.Applied
+				} else {
+					s.logger.Info("Snapshot of interrupted state sync is no longer available, starting over",
+						"height", resume.Height, "format", resume.Format, "hash", resume.Hash)
+				}
+				resume = nil
+			}
 		}
 		if snapshot == nil {
 			if discoveryTime == 0 {
@@ -201,11 +257,20 @@ func (s *syncer) SyncAny(
 			continue
 		}
 		if chunks == nil {
-			chunks, err = newChunkQueue(snapshot, s.tempDir)
+			if s.progressDir != "" {
+				if applied == nil {
+					s.resetProgress()
+					applied = bits.NewBitArray(int(snapshot.Chunks))
+				}
+				chunks, err = openChunkQueue(snapshot, filepath.Join(s.progressDir, progressChunksDir), applied)
+			} else {
+				chunks, err = newChunkQueue(snapshot, s.tempDir)
+			}
 			if err != nil {
 				return sm.State{}, nil, fmt.Errorf("failed to create chunk queue: %w", err)
 			}
 			defer chunks.Close() // in case we forget to close it elsewhere
+			s.applied = applied
 		}
 
 		s.processingSnapshot = snapshot
@@ -216,9 +281,11 @@ func (s *syncer) SyncAny(
 		case err == nil:
 			s.metrics.SnapshotHeight.Set(float64(snapshot.Height))
 			s.lastSyncedSnapshotHeight = int64(snapshot.Height)
+			s.resetProgress()
 			return newState, commit, nil
 
 		case errors.Is(err, errAbort):
+			s.resetProgress()
 			return sm.State{}, nil, err
 
 		case errors.Is(err, errRetrySnapshot):
@@ -258,12 +325,42 @@ func (s *syncer) SyncAny(
 		if err != nil {
 			s.logger.Error("Failed to clean up chunk queue", "err", err)
 		}
+		s.resetProgress()
 		snapshot = nil
 		chunks = nil
 		s.processingSnapshot = nil
 	}
 }
 
+// persistProgress persists the progress of restoring snapshot, if enabled, so that the sync can
+// be resumed after a restart.
+func (s *syncer) persistProgress(snapshot *snapshot) {
+	if s.progressDir == "" || s.applied == nil {
+		return
+	}
+	err := saveProgress(s.progressDir, &syncProgress{
+		Height:   snapshot.Height,
+		Format:   snapshot.Format,
+		Chunks:   snapshot.Chunks,
+		Hash:     snapshot.Hash,
+		Metadata: snapshot.Metadata,
+		Applied:  s.applied,
+	})
+	if err != nil {
+		s.logger.Error("Failed to persist state sync progress", "err", err)
+	}
+}
+
+// resetProgress removes any persisted progress, along with the chunks kept for resuming.
+func (s *syncer) resetProgress() {
+	if s.progressDir == "" {
+		return
+	}
+	if err := clearProgress(s.progressDir); err != nil {
+		s.logger.Error("Failed to remove state sync progress", "err", err)
+	}
+}
+
 // Sync executes a sync for a specific snapshot, returning the latest state and block commit which
 // the caller must use to bootstrap the node.
 func (s *syncer) Sync(ctx context.Context, snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.Commit, error) {
@@ -306,6 +403,7 @@ func (s *syncer) Sync(ctx context.Context, snapshot *snapshot, chunks *chunkQueu
 	if err != nil {
 		return sm.State{}, nil, err
 	}
+	s.persistProgress(snapshot)
 
 	// Spawn chunk fetchers. They will terminate when the chunk queue is closed or context canceled.
 	fetchCtx, cancel := context.WithCancel(ctx)
@@ -432,6 +530,9 @@ func (s *syncer) applyChunks(ctx context.Context, chunks *chunkQueue, start time
 			if err != nil {
 				return fmt.Errorf("failed to discard chunk %v: %w", index, err)
 			}
+			if s.applied != nil {
+				s.applied.SetIndex(int(index), false)
+			}
 		}
 
 		// Reject any senders as requested by the app
@@ -451,6 +552,12 @@ func (s *syncer) applyChunks(ctx context.Context, chunks *chunkQueue, start time
 			s.metrics.SnapshotChunk.Add(1)
 			s.avgChunkTime = time.Since(start).Nanoseconds() / int64(chunks.numChunksReturned())
 			s.metrics.ChunkProcessAvgTime.Set(float64(s.avgChunkTime))
+			remaining := int64(chunks.Size()) - int64(chunks.numChunksReturned())
+			s.metrics.SnapshotRemainingTime.Set(time.Duration(s.avgChunkTime * remaining).Seconds())
+			if s.applied != nil {
+				s.applied.SetIndex(int(chunk.Index), true)
+				s.persistProgress(chunks.snapshot)
+			}
 		case abci.ResponseApplySnapshotChunk_ABORT:
 			return errAbort
 		case abci.ResponseApplySnapshotChunk_RETRY:
@@ -500,7 +607,7 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 		ticker := time.NewTicker(s.retryTimeout)
 		defer ticker.Stop()
 
-		s.requestChunk(snapshot, index)
+		s.requestChunk(ctx, snapshot, index)
 
 		select {
 		case <-chunks.WaitFor(index):
@@ -519,8 +626,8 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 	}
 }
 
-// requestChunk requests a chunk from a peer.
-func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
+// requestChunk requests a chunk from a peer or a snapshot source.
+func (s *syncer) requestChunk(ctx context.Context, snapshot *snapshot, chunk uint32) {
 	peer := s.snapshots.GetPeer(snapshot)
 	if peer == "" {
 		s.logger.Error("No valid peers found for snapshot", "height", snapshot.Height,
@@ -528,6 +635,11 @@ func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 		return
 	}
 
+	if source, ok := s.sources[peer]; ok {
+		go s.fetchSourceChunk(ctx, source, snapshot, chunk)
+		return
+	}
+
 	s.logger.Debug(
 		"Requesting snapshot chunk",
 		"height", snapshot.Height,
@@ -551,6 +663,27 @@ func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 	}
 }
 
+// fetchSourceChunk downloads a chunk from a snapshot source and adds it to the
+// chunk queue. Failed downloads are retried by fetchChunks after the chunk
+// request timeout, possibly from another peer or source.
+func (s *syncer) fetchSourceChunk(ctx context.Context, source *httpSource, snapshot *snapshot, index uint32) {
+	s.logger.Debug("Fetching snapshot chunk from source", "height", snapshot.Height,
+		"format", snapshot.Format, "chunk", index, "source", source.url)
+
+	chunk, err := source.FetchChunk(ctx, snapshot, index)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("Failed to fetch snapshot chunk from source", "height", snapshot.Height,
+				"format", snapshot.Format, "chunk", index, "source", source.url, "err", err)
+		}
+		return
+	}
+	if _, err := s.AddChunk(chunk); err != nil {
+		s.logger.Error("Failed to add snapshot chunk from source", "height", snapshot.Height,
+			"format", snapshot.Format, "chunk", index, "source", source.url, "err", err)
+	}
+}
+
 // verifyApp verifies the sync, checking the app hash and last block height. It returns the
 // app version, which should be returned as part of the initial state.
 func (s *syncer) verifyApp(snapshot *snapshot) (uint64, error) {
@@ -1,8 +1,12 @@
 package statesync
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -17,6 +21,7 @@ import (
 	proxymocks "github.com/tendermint/tendermint/internal/proxy/mocks"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/statesync/mocks"
+	"github.com/tendermint/tendermint/libs/bits"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
 	"github.com/tendermint/tendermint/types"
 	"github.com/tendermint/tendermint/version"
@@ -211,6 +216,75 @@ func TestSyncer_SyncAny(t *testing.T) {
 	connQuery.AssertExpectations(t)
 }
 
+func TestSyncer_SyncAny_resume(t *testing.T) {
+	state := sm.State{ChainID: "chain", LastBlockHeight: 1, AppHash: []byte("app_hash")}
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(1)).Return(state.AppHash, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(1)).Return(commit, nil)
+	stateProvider.On("State", mock.Anything, uint64(1)).Return(state, nil)
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	connQuery := &proxymocks.AppConnQuery{}
+
+	rts := setup(t, connSnapshot, connQuery, stateProvider, 2)
+	rts.syncer.progressDir = filepath.Join(t.TempDir(), "statesync")
+
+	// A previous sync of the snapshot at height 1 was interrupted after applying chunks 0 and 1.
+	s := &snapshot{Height: 1, Format: 1, Chunks: 3, Hash: []byte{1, 2, 3}}
+	applied := bits.NewBitArray(3)
+	applied.SetIndex(0, true)
+	applied.SetIndex(1, true)
+	require.NoError(t, saveProgress(rts.syncer.progressDir, &syncProgress{
+		Height: s.Height, Format: s.Format, Chunks: s.Chunks, Hash: s.Hash, Applied: applied,
+	}))
+	chunkDir := filepath.Join(rts.syncer.progressDir, progressChunksDir)
+	require.NoError(t, os.MkdirAll(chunkDir, 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(chunkDir, "0"), []byte{1, 1, 0}, 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(chunkDir, "1"), []byte{1, 1, 1}, 0600))
+
+	// The interrupted snapshot is resumed, even though a better one is available.
+	peerID := types.NodeID("aa")
+	_, err := rts.syncer.AddSnapshot(peerID, s)
+	require.NoError(t, err)
+	_, err = rts.syncer.AddSnapshot(peerID, &snapshot{Height: 2, Format: 1, Chunks: 3, Hash: []byte{1}})
+	require.NoError(t, err)
+
+	connSnapshot.On("OfferSnapshotSync", ctx, abci.RequestOfferSnapshot{
+		Snapshot: &abci.Snapshot{Height: 1, Format: 1, Chunks: 3, Hash: []byte{1, 2, 3}},
+		AppHash:  []byte("app_hash"),
+	}).Once().Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+	for i := uint32(0); i < 3; i++ {
+		i := i
+		connSnapshot.On("ApplySnapshotChunkSync", ctx, mock.MatchedBy(func(req abci.RequestApplySnapshotChunk) bool {
+			return req.Index == i && bytes.Equal(req.Chunk, []byte{1, 1, byte(i)})
+		})).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	}
+	connQuery.On("InfoSync", ctx, proxy.RequestInfo).Return(&abci.ResponseInfo{
+		LastBlockHeight:  1,
+		LastBlockAppHash: []byte("app_hash"),
+	}, nil)
+
+	// Only the chunk that was not applied is fetched from peers.
+	go func() {
+		e := <-rts.chunkOutCh
+		msg, ok := e.Message.(*ssproto.ChunkRequest)
+		assert.True(t, ok)
+		assert.EqualValues(t, 2, msg.Index)
+		_, err := rts.syncer.AddChunk(&chunk{Height: 1, Format: 1, Index: 2, Chunk: []byte{1, 1, 2}, Sender: peerID})
+		assert.NoError(t, err)
+	}()
+
+	_, _, err = rts.syncer.SyncAny(ctx, 0, func() {})
+	require.NoError(t, err)
+
+	// The progress is removed once the sync completes.
+	_, err = os.Stat(rts.syncer.progressDir)
+	require.True(t, os.IsNotExist(err))
+
+	connSnapshot.AssertExpectations(t)
+}
+
 func TestSyncer_SyncAny_noSnapshots(t *testing.T) {
 	stateProvider := &mocks.StateProvider{}
 	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
@@ -0,0 +1,295 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gogo/protobuf/proto"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/libs/protoio"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// archiveShardHeights is the number of heights stored in each directory of a
+// FileArchive, to keep the directories small.
+const archiveShardHeights = 10000
+
+// Archive is an object store, where the BlockStore moves the parts and the
+// commits of its old blocks. Any object store (a directory, S3, GCS...) can
+// back the BlockStore, by implementing Archive.
+type Archive interface {
+	// Get returns the object with the given name, or nil if there is none.
+	Get(name string) ([]byte, error)
+	// Put stores the object with the given name, replacing any previous one.
+	Put(name string, data []byte) error
+}
+
+// FileArchive is an Archive storing each object in a file of a directory.
+type FileArchive struct {
+	dir string
+}
+
+var _ Archive = (*FileArchive)(nil)
+
+// NewFileArchive returns an Archive storing the objects in dir, which is
+// created with the first object.
+func NewFileArchive(dir string) *FileArchive {
+	return &FileArchive{dir: dir}
+}
+
+// Get implements Archive.
+func (a *FileArchive) Get(name string) ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(a.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Put implements Archive. The object is written to a temporary file first, so
+// that a crash never leaves a partial object behind.
+func (a *FileArchive) Put(name string, data []byte) error {
+	path := filepath.Join(a.dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) //nolint:errcheck // the file is renamed on success
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// archivedBlock is the content of the archive object of a height: the parts of
+// the block and its commit.
+type archivedBlock struct {
+	height int64
+	parts  []*types.Part
+	commit *types.Commit
+}
+
+// archiveObjectName returns the name of the archive object of height.
+func archiveObjectName(height int64) string {
+	return fmt.Sprintf("%d/%d.block", height/archiveShardHeights, height)
+}
+
+// encodeArchivedBlock encodes the commit and then the parts, as delimited
+// protobuf messages.
+func encodeArchivedBlock(commit *tmproto.Commit, parts []*tmproto.Part) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w := protoio.NewDelimitedWriter(buf)
+	if _, err := w.WriteMsg(commit); err != nil {
+		return nil, err
+	}
+	for _, part := range parts {
+		if _, err := w.WriteMsg(part); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeArchivedBlock(height int64, data []byte) (*archivedBlock, error) {
+	r := protoio.NewDelimitedReader(bytes.NewReader(data), len(data))
+
+	pbc := new(tmproto.Commit)
+	if _, err := r.ReadMsg(pbc); err != nil {
+		return nil, fmt.Errorf("reading commit: %w", err)
+	}
+	commit, err := types.CommitFromProto(pbc)
+	if err != nil {
+		return nil, fmt.Errorf("error from proto commit: %w", err)
+	}
+
+	var parts []*types.Part
+	for {
+		pbpart := new(tmproto.Part)
+		if _, err := r.ReadMsg(pbpart); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("reading part %d: %w", len(parts), err)
+		}
+		part, err := types.PartFromProto(pbpart)
+		if err != nil {
+			return nil, fmt.Errorf("error from proto part: %w", err)
+		}
+		parts = append(parts, part)
+	}
+
+	return &archivedBlock{height: height, parts: parts, commit: commit}, nil
+}
+
+// ArchiveHeight returns the height below which the parts and the commits of
+// the blocks have been moved to the archive, or 0 if none have been.
+func (bs *BlockStore) ArchiveHeight() int64 {
+	bz, err := bs.db.Get(archiveHeightKey())
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) == 0 {
+		return 0
+	}
+	height, err := strconv.ParseInt(string(bz), 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("failed to extract archive height from %s: %v", bz, err))
+	}
+	return height
+}
+
+// ArchiveBlocks moves the parts and the commits of the blocks up to (but not
+// including) a height to the archive, and returns the number of blocks moved.
+// The block metas are kept, so that the archived blocks are still in the store
+// and are loaded from the archive transparently.
+func (bs *BlockStore) ArchiveBlocks(height int64) (uint64, error) {
+	if bs.archive == nil {
+		return 0, errors.New("block store has no archive")
+	}
+	if height <= 0 {
+		return 0, fmt.Errorf("height must be greater than 0")
+	}
+	if height > bs.Height() {
+		return 0, fmt.Errorf("height must be equal to or less than the latest height %d", bs.Height())
+	}
+
+	start := bs.ArchiveHeight()
+	if base := bs.Base(); start < base {
+		start = base
+	}
+	if height <= start {
+		return 0, nil
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	var archived uint64
+	for h := start; h < height; h++ {
+		ok, err := bs.archiveBlock(h, batch)
+		if err != nil {
+			return archived, fmt.Errorf("failed to archive block %d: %w", h, err)
+		}
+		if ok {
+			archived++
+		}
+	}
+
+	// the local copies are only deleted once all the objects are archived
+	if err := batch.Set(archiveHeightKey(), []byte(strconv.FormatInt(height, 10))); err != nil {
+		return archived, err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return archived, err
+	}
+	return archived, nil
+}
+
+// archiveBlock puts the archive object of height, and adds the deletion of its
+// local parts and commit to batch. Heights without a complete block, like the
+// ones of the signed headers saved by the state sync, are skipped.
+func (bs *BlockStore) archiveBlock(height int64, batch dbm.Batch) (bool, error) {
+	blockMeta := bs.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return false, nil
+	}
+
+	bz, err := bs.db.Get(blockCommitKey(height))
+	if err != nil {
+		return false, err
+	}
+	if len(bz) == 0 {
+		return false, nil
+	}
+	pbc := new(tmproto.Commit)
+	if err := proto.Unmarshal(bz, pbc); err != nil {
+		return false, fmt.Errorf("unmarshal to tmproto.Commit: %w", err)
+	}
+
+	total := int(blockMeta.BlockID.PartSetHeader.Total)
+	parts := make([]*tmproto.Part, 0, total)
+	for i := 0; i < total; i++ {
+		bz, err := bs.db.Get(blockPartKey(height, i))
+		if err != nil {
+			return false, err
+		}
+		if len(bz) == 0 {
+			return false, nil
+		}
+		pbpart := new(tmproto.Part)
+		if err := proto.Unmarshal(bz, pbpart); err != nil {
+			return false, fmt.Errorf("unmarshal to tmproto.Part: %w", err)
+		}
+		parts = append(parts, pbpart)
+	}
+
+	data, err := encodeArchivedBlock(pbc, parts)
+	if err != nil {
+		return false, err
+	}
+	if err := bs.archive.Put(archiveObjectName(height), data); err != nil {
+		return false, err
+	}
+
+	for i := 0; i < total; i++ {
+		if err := batch.Delete(blockPartKey(height, i)); err != nil {
+			return false, err
+		}
+	}
+	if err := batch.Delete(blockCommitKey(height)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// loadArchivedBlock returns the archived parts and commit of height, or nil if
+// the height isn't archived. The last object loaded is cached, since a block
+// is loaded one part at a time.
+func (bs *BlockStore) loadArchivedBlock(height int64) *archivedBlock {
+	if bs.archive == nil || height >= bs.ArchiveHeight() {
+		return nil
+	}
+	// the pruned heights may still be in the archive
+	if bs.LoadBlockMeta(height) == nil {
+		return nil
+	}
+
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	if bs.lastArchived != nil && bs.lastArchived.height == height {
+		return bs.lastArchived
+	}
+
+	data, err := bs.archive.Get(archiveObjectName(height))
+	if err != nil {
+		panic(fmt.Errorf("failed to load archived block %d: %w", height, err))
+	}
+	if data == nil {
+		return nil
+	}
+	block, err := decodeArchivedBlock(height, data)
+	if err != nil {
+		panic(fmt.Errorf("failed to decode archived block %d: %w", height, err))
+	}
+	bs.lastArchived = block
+	return block
+}
@@ -0,0 +1,228 @@
+package store
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+)
+
+const (
+	s3Service = "s3"
+	// s3Timeout bounds a request to the object store, so that an unreachable
+	// store doesn't block the block store forever.
+	s3Timeout = time.Minute
+)
+
+// S3Archive is an Archive storing each object in a bucket of an S3 compatible
+// object store, such as AWS S3, GCS (with HMAC keys) or MinIO. The requests
+// are signed with AWS Signature Version 4.
+type S3Archive struct {
+	bucket *url.URL
+	region string
+
+	accessKey    string
+	secretKey    string
+	sessionToken string
+
+	client *http.Client
+	now    func() time.Time
+}
+
+var _ Archive = (*S3Archive)(nil)
+
+// NewS3Archive returns an Archive storing the objects under bucketURL, the
+// path-style URL of a bucket with an optional prefix, such as
+// https://s3.us-east-1.amazonaws.com/bucket/prefix. The session token is
+// only needed with temporary credentials.
+func NewS3Archive(bucketURL, region, accessKey, secretKey, sessionToken string) (*S3Archive, error) {
+	bucket, err := url.Parse(bucketURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bucket URL: %w", err)
+	}
+	if (bucket.Scheme != "http" && bucket.Scheme != "https") || bucket.Host == "" {
+		return nil, fmt.Errorf("bucket URL %q must be an http or https URL", bucketURL)
+	}
+	if strings.Trim(bucket.Path, "/") == "" {
+		return nil, fmt.Errorf("bucket URL %q has no bucket", bucketURL)
+	}
+	if region == "" {
+		return nil, fmt.Errorf("no region for bucket URL %q", bucketURL)
+	}
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("no credentials for bucket URL %q", bucketURL)
+	}
+
+	return &S3Archive{
+		bucket:       bucket,
+		region:       region,
+		accessKey:    accessKey,
+		secretKey:    secretKey,
+		sessionToken: sessionToken,
+		client:       &http.Client{Timeout: s3Timeout},
+		now:          time.Now,
+	}, nil
+}
+
+// Get implements Archive.
+func (a *S3Archive) Get(name string) ([]byte, error) {
+	res, err := a.do(http.MethodGet, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return ioutil.ReadAll(res.Body)
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, s3Error(res)
+	}
+}
+
+// Put implements Archive. An object is only visible in the bucket once
+// completely stored.
+func (a *S3Archive) Put(name string, data []byte) error {
+	res, err := a.do(http.MethodPut, name, data)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return s3Error(res)
+	}
+	return nil
+}
+
+// do sends a signed request for the object with the given name.
+func (a *S3Archive) do(method, name string, body []byte) (*http.Response, error) {
+	u := *a.bucket
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + name
+	u.RawPath = s3EscapePath(u.Path)
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	a.sign(req, body)
+	return a.client.Do(req)
+}
+
+// sign signs the request with AWS Signature Version 4, covering the host and
+// all the headers of the request, and the payload.
+func (a *S3Archive) sign(req *http.Request, payload []byte) {
+	now := a.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := strings.Join([]string{now.Format("20060102"), a.region, s3Service, "aws4_request"}, "/")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if a.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", a.sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+a.secretKey), now.Format("20060102"))
+	key = hmacSHA256(key, a.region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		a.accessKey, scope, signedHeaders, signature))
+}
+
+// s3EscapePath escapes the path as required by the signature, escaping every
+// byte but the unreserved characters and the slashes.
+func s3EscapePath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '.' || c == '_' || c == '~' || c == '/' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// s3Error returns the error of an unexpected response, with the start of its
+// body, which describes the error.
+func s3Error(res *http.Response) error {
+	body, _ := ioutil.ReadAll(&io.LimitedReader{R: res.Body, N: 512})
+	return fmt.Errorf("object store responded %s: %s", res.Status, bytes.TrimSpace(body))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data)) //nolint:errcheck // never fails
+	return h.Sum(nil)
+}
+
+// ArchiveFromConfig returns the Archive of the config: the bucket of
+// archive-s3-url if set, with the credentials of the AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables, or else
+// the directory archive-dir.
+func ArchiveFromConfig(cfg config.BaseConfig) (Archive, error) {
+	if cfg.ArchiveS3URL == "" {
+		return NewFileArchive(cfg.ArchiveDir()), nil
+	}
+	archive, err := NewS3Archive(cfg.ArchiveS3URL, cfg.ArchiveS3Region,
+		os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"), os.Getenv("AWS_SESSION_TOKEN"))
+	if err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/config"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/test/factory"
+	tmtime "github.com/tendermint/tendermint/libs/time"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestArchiveBlocks(t *testing.T) {
+	cfg := config.ResetTestRoot("block_store_archive_test")
+	defer os.RemoveAll(cfg.RootDir)
+	state, err := sm.MakeGenesisStateFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+
+	_, err = NewBlockStore(dbm.NewMemDB()).ArchiveBlocks(1)
+	require.Error(t, err, "a block store without archive can't archive blocks")
+
+	archive := NewFileArchive(t.TempDir())
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db, BlockStoreWithArchive(archive))
+
+	blocks := make(map[int64]*types.Block)
+	for h := int64(1); h <= 20; h++ {
+		block := factory.MakeBlock(state, h, new(types.Commit))
+		partSet := block.MakePartSet(2)
+		bs.SaveBlock(block, partSet, makeTestCommit(h, tmtime.Now()))
+		blocks[h] = bs.LoadBlock(h)
+	}
+	commits := make(map[int64]*types.Commit)
+	for h := int64(1); h < 20; h++ {
+		commits[h] = bs.LoadBlockCommit(h)
+		require.NotNil(t, commits[h])
+	}
+
+	_, err = bs.ArchiveBlocks(0)
+	require.Error(t, err)
+	_, err = bs.ArchiveBlocks(21)
+	require.Error(t, err)
+
+	archived, err := bs.ArchiveBlocks(10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 9, archived)
+	assert.EqualValues(t, 10, bs.ArchiveHeight())
+
+	// the store is unchanged, but the archived blocks aren't in the DB anymore
+	assert.EqualValues(t, 1, bs.Base())
+	assert.EqualValues(t, 20, bs.Height())
+	bz, err := db.Get(blockPartKey(5, 0))
+	require.NoError(t, err)
+	require.Nil(t, bz)
+	bz, err = db.Get(blockCommitKey(5))
+	require.NoError(t, err)
+	require.Nil(t, bz)
+
+	for h := int64(1); h <= 20; h++ {
+		require.Equal(t, blocks[h], bs.LoadBlock(h), "height %d", h)
+		require.Equal(t, blocks[h], bs.LoadBlockByHash(blocks[h].Hash()), "height %d", h)
+		require.NotNil(t, bs.LoadBlockPart(h, 0))
+		if h < 20 {
+			require.Equal(t, commits[h], bs.LoadBlockCommit(h), "height %d", h)
+		}
+	}
+	require.Nil(t, bs.LoadBlockPart(5, 1000))
+
+	// archiving again only archives the new heights
+	archived, err = bs.ArchiveBlocks(10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, archived)
+	archived, err = bs.ArchiveBlocks(15)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, archived)
+
+	// the archived blocks can be reloaded by a new block store
+	bs = NewBlockStore(db, BlockStoreWithArchive(NewFileArchive(archive.dir)))
+	require.Equal(t, blocks[12], bs.LoadBlock(12))
+
+	// the pruned blocks aren't loaded from the archive
+	_, err = bs.PruneBlocks(8)
+	require.NoError(t, err)
+	require.Nil(t, bs.LoadBlock(5))
+	require.Nil(t, bs.LoadBlockCommit(5))
+	require.Nil(t, bs.LoadBlockPart(5, 0))
+	require.Equal(t, blocks[8], bs.LoadBlock(8))
+}
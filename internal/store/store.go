@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/orderedcode"
@@ -27,17 +28,38 @@ the Commit data outside the Block. (TODO)
 
 The store can be assumed to contain all contiguous blocks between base and height (inclusive).
 
+With an Archive, the block parts and commits of the old blocks can be moved to
+the archive with ArchiveBlocks, and are then loaded from it transparently. The
+block metas always stay in the DB.
+
 // NOTE: BlockStore methods will panic if they encounter errors
 // deserializing loaded data, indicating probable corruption on disk.
 */
 type BlockStore struct {
-	db dbm.DB
+	db      dbm.DB
+	archive Archive // the parts and commits of old blocks, if any
+
+	mtx          sync.Mutex
+	lastArchived *archivedBlock // the last block loaded from the archive
+}
+
+// BlockStoreOption sets an optional parameter on the BlockStore.
+type BlockStoreOption func(*BlockStore)
+
+// BlockStoreWithArchive sets the archive where ArchiveBlocks moves the old
+// blocks, and from which they are loaded afterwards.
+func BlockStoreWithArchive(archive Archive) BlockStoreOption {
+	return func(bs *BlockStore) { bs.archive = archive }
 }
 
 // NewBlockStore returns a new BlockStore with the given DB,
 // initialized to the last height that was committed to the DB.
-func NewBlockStore(db dbm.DB) *BlockStore {
-	return &BlockStore{db}
+func NewBlockStore(db dbm.DB, options ...BlockStoreOption) *BlockStore {
+	bs := &BlockStore{db: db}
+	for _, option := range options {
+		option(bs)
+	}
+	return bs
 }
 
 // Base returns the first known contiguous block height, or 0 for empty block stores.
@@ -192,6 +214,9 @@ func (bs *BlockStore) LoadBlockPart(height int64, index int) *types.Part {
 		panic(err)
 	}
 	if len(bz) == 0 {
+		if archived := bs.loadArchivedBlock(height); archived != nil && index < len(archived.parts) {
+			return archived.parts[index]
+		}
 		return nil
 	}
 
@@ -245,6 +270,9 @@ func (bs *BlockStore) LoadBlockCommit(height int64) *types.Commit {
 		panic(err)
 	}
 	if len(bz) == 0 {
+		if archived := bs.loadArchivedBlock(height); archived != nil {
+			return archived.commit
+		}
 		return nil
 	}
 	err = proto.Unmarshal(bz, pbc)
@@ -293,28 +321,6 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 		return 0, fmt.Errorf("height must be equal to or less than the latest height %d", bs.Height())
 	}
 
-	// when removing the block meta, use the hash to remove the hash key at the same time
-	removeBlockHash := func(key, value []byte, batch dbm.Batch) error {
-		// unmarshal block meta
-		var pbbm = new(tmproto.BlockMeta)
-		err := proto.Unmarshal(value, pbbm)
-		if err != nil {
-			return fmt.Errorf("unmarshal to tmproto.BlockMeta: %w", err)
-		}
-
-		blockMeta, err := types.BlockMetaFromProto(pbbm)
-		if err != nil {
-			return fmt.Errorf("error from proto blockMeta: %w", err)
-		}
-
-		// delete the hash key corresponding to the block meta's hash
-		if err := batch.Delete(blockHashKey(blockMeta.BlockID.Hash)); err != nil {
-			return fmt.Errorf("failed to delete hash key: %X: %w", blockHashKey(blockMeta.BlockID.Hash), err)
-		}
-
-		return nil
-	}
-
 	// remove block meta first as this is used to indicate whether the block exists.
 	// For this reason, we also use ony block meta as a measure of the amount of blocks pruned
 	pruned, err := bs.pruneRange(blockMetaKey(0), blockMetaKey(height), removeBlockHash)
@@ -333,6 +339,72 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 	return pruned, nil
 }
 
+// RollbackBlocks removes the blocks after a height, which becomes the height
+// of the store, and saves the commit of the block at height as the seen
+// commit. It returns the number of blocks removed. The archived blocks can't
+// be removed.
+func (bs *BlockStore) RollbackBlocks(height int64) (uint64, error) {
+	latest := bs.Height()
+	if height < bs.Base() || height <= 0 {
+		return 0, fmt.Errorf("height %d must be greater than or equal to the base %d", height, bs.Base())
+	}
+	if archiveHeight := bs.ArchiveHeight(); height+1 < archiveHeight {
+		return 0, fmt.Errorf("can't remove the blocks archived below height %d", archiveHeight)
+	}
+	if height >= latest {
+		return 0, nil
+	}
+
+	// the commit of the block at height is the last commit of the next block,
+	// which is removed
+	commit := bs.LoadBlockCommit(height)
+	if commit == nil {
+		return 0, fmt.Errorf("no commit found for height %d", height)
+	}
+	if err := bs.SaveSeenCommit(height, commit); err != nil {
+		return 0, err
+	}
+
+	// remove block meta first as this is used to indicate whether the block exists.
+	removed, err := bs.pruneRange(blockMetaKey(height+1), blockMetaKey(latest+1), removeBlockHash)
+	if err != nil {
+		return removed, err
+	}
+
+	if _, err := bs.pruneRange(blockPartKey(height+1, 0), blockPartKey(latest+1, 0), nil); err != nil {
+		return removed, err
+	}
+
+	if _, err := bs.pruneRange(blockCommitKey(height+1), blockCommitKey(latest+1), nil); err != nil {
+		return removed, err
+	}
+
+	return removed, nil
+}
+
+// removeBlockHash is the hook removing the hash key of a block meta, at the
+// same time as the block meta.
+func removeBlockHash(key, value []byte, batch dbm.Batch) error {
+	// unmarshal block meta
+	var pbbm = new(tmproto.BlockMeta)
+	err := proto.Unmarshal(value, pbbm)
+	if err != nil {
+		return fmt.Errorf("unmarshal to tmproto.BlockMeta: %w", err)
+	}
+
+	blockMeta, err := types.BlockMetaFromProto(pbbm)
+	if err != nil {
+		return fmt.Errorf("error from proto blockMeta: %w", err)
+	}
+
+	// delete the hash key corresponding to the block meta's hash
+	if err := batch.Delete(blockHashKey(blockMeta.BlockID.Hash)); err != nil {
+		return fmt.Errorf("failed to delete hash key: %X: %w", blockHashKey(blockMeta.BlockID.Hash), err)
+	}
+
+	return nil
+}
+
 // pruneRange is a generic function for deleting a range of values based on the lowest
 // height up to but excluding retainHeight. For each key/value pair, an optional hook can be
 // executed before the deletion itself is made. pruneRange will use batch delete to delete
@@ -562,6 +634,7 @@ const (
 	prefixBlockCommit = int64(2)
 	prefixSeenCommit  = int64(3)
 	prefixBlockHash   = int64(4)
+	prefixArchive     = int64(13)
 )
 
 func blockMetaKey(height int64) []byte {
@@ -619,6 +692,14 @@ func blockHashKey(hash []byte) []byte {
 	return key
 }
 
+func archiveHeightKey() []byte {
+	key, err := orderedcode.Append(nil, prefixArchive)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
 //-----------------------------------------------------------------------------
 
 // mustEncode proto encodes a proto.message and panics if fails
@@ -419,6 +419,44 @@ func TestPruneBlocks(t *testing.T) {
 	assert.Nil(t, bs.LoadBlock(1501))
 }
 
+func TestRollbackBlocks(t *testing.T) {
+	bs, _ := freshBlockStore()
+
+	blocks := make(map[int64]*types.Block)
+	for h := int64(1); h <= 10; h++ {
+		block := factory.MakeBlock(state, h, makeTestCommit(h-1, tmtime.Now()))
+		bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(h, tmtime.Now()))
+		blocks[h] = block
+	}
+
+	_, err := bs.RollbackBlocks(0)
+	require.Error(t, err)
+
+	// rolling back to the current height does nothing
+	removed, err := bs.RollbackBlocks(10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, removed)
+
+	removed, err = bs.RollbackBlocks(7)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, removed)
+	assert.EqualValues(t, 1, bs.Base())
+	assert.EqualValues(t, 7, bs.Height())
+
+	for h := int64(8); h <= 10; h++ {
+		require.Nil(t, bs.LoadBlock(h))
+		require.Nil(t, bs.LoadBlockMeta(h))
+		require.Nil(t, bs.LoadBlockPart(h, 0))
+		require.Nil(t, bs.LoadBlockByHash(blocks[h].Hash()))
+		require.Nil(t, bs.LoadBlockCommit(h))
+	}
+	require.Equal(t, blocks[7].Hash(), bs.LoadBlock(7).Hash())
+
+	// the commit of the last block, which was in the next block, is the seen one
+	require.Equal(t, blocks[8].LastCommit.Hash(), bs.LoadBlockCommit(7).Hash())
+	require.Equal(t, blocks[8].LastCommit.Hash(), bs.LoadSeenCommit().Hash())
+}
+
 func TestLoadBlockMeta(t *testing.T) {
 	bs, db := freshBlockStore()
 	height := int64(10)
@@ -0,0 +1,262 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/google/orderedcode"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// Inconsistency is an inconsistency of the block store found by Verify.
+type Inconsistency struct {
+	// Height of the inconsistent block, or 0 if it isn't about one block.
+	Height      int64
+	Description string
+
+	// repair adds the fix of the inconsistency to a batch, if it can be fixed
+	repair func(batch dbm.Batch) error
+}
+
+// Repairable returns true if Repair can fix the inconsistency.
+func (i Inconsistency) Repairable() bool {
+	return i.repair != nil
+}
+
+func (i Inconsistency) String() string {
+	if i.Height == 0 {
+		return i.Description
+	}
+	return fmt.Sprintf("height %d: %s", i.Height, i.Description)
+}
+
+// Verify checks the integrity of the block store: that all the heights between
+// the base and the height have a block meta, a block matching it and a commit,
+// that each block links to the previous one, that the hash index points to the
+// blocks, and that no data is left outside of the blocks of the store. It
+// returns the inconsistencies found, which are corruptions when the blocks
+// can't be loaded.
+func (bs *BlockStore) Verify() ([]Inconsistency, error) {
+	base, height := bs.Base(), bs.Height()
+	if height == 0 {
+		return nil, nil
+	}
+
+	var inconsistencies []Inconsistency
+	for h := base; h <= height; h++ {
+		found, err := bs.verifyHeight(h, base, height)
+		if err != nil {
+			inconsistencies = append(inconsistencies, Inconsistency{Height: h, Description: err.Error()})
+			continue
+		}
+		inconsistencies = append(inconsistencies, found...)
+	}
+
+	orphans, err := bs.verifyOrphans(base, height)
+	if err != nil {
+		return nil, err
+	}
+	return append(inconsistencies, orphans...), nil
+}
+
+// Repair fixes the repairable inconsistencies found by Verify, and returns
+// them.
+func (bs *BlockStore) Repair() ([]Inconsistency, error) {
+	inconsistencies, err := bs.Verify()
+	if err != nil {
+		return nil, err
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	var repaired []Inconsistency
+	for _, inconsistency := range inconsistencies {
+		if !inconsistency.Repairable() {
+			continue
+		}
+		if err := inconsistency.repair(batch); err != nil {
+			return nil, fmt.Errorf("failed to repair %v: %w", inconsistency, err)
+		}
+		repaired = append(repaired, inconsistency)
+	}
+
+	if err := batch.WriteSync(); err != nil {
+		return nil, err
+	}
+	return repaired, nil
+}
+
+// verifyHeight verifies the block at height h, between base and height, and
+// returns an error if it is corrupted.
+func (bs *BlockStore) verifyHeight(h, base, height int64) (inconsistencies []Inconsistency, err error) {
+	// the loading panics when the data is corrupted
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("corrupted block: %v", r)
+		}
+	}()
+
+	add := func(description string, repair func(batch dbm.Batch) error) {
+		inconsistencies = append(inconsistencies, Inconsistency{Height: h, Description: description, repair: repair})
+	}
+
+	blockMeta := bs.LoadBlockMeta(h)
+	if blockMeta == nil {
+		add("missing block meta", nil)
+		return inconsistencies, nil
+	}
+	hash := blockMeta.BlockID.Hash
+
+	if !bytes.Equal(blockMeta.Header.Hash(), hash) {
+		add(fmt.Sprintf("header hash %X doesn't match block hash %X", blockMeta.Header.Hash(), hash), nil)
+	}
+	if h > base {
+		prevMeta := bs.LoadBlockMeta(h - 1)
+		if prevMeta != nil && !prevMeta.BlockID.Equals(blockMeta.Header.LastBlockID) {
+			add(fmt.Sprintf("last block ID %v doesn't match the block ID %v of height %d",
+				blockMeta.Header.LastBlockID, prevMeta.BlockID, h-1), nil)
+		}
+	}
+
+	// the signed headers saved by the state sync have no block, and aren't
+	// indexed by hash
+	if blockMeta.NumTxs != -1 {
+		bz, err := bs.db.Get(blockHashKey(hash))
+		if err != nil {
+			return nil, err
+		}
+		if string(bz) != strconv.FormatInt(h, 10) {
+			add(fmt.Sprintf("hash index of %X doesn't point to the block", hash), func(batch dbm.Batch) error {
+				return batch.Set(blockHashKey(hash), []byte(strconv.FormatInt(h, 10)))
+			})
+		}
+
+		block := bs.LoadBlock(h)
+		switch {
+		case block == nil:
+			add("missing block parts", nil)
+		case !bytes.Equal(block.Hash(), hash):
+			add(fmt.Sprintf("block hash %X doesn't match block meta hash %X", block.Hash(), hash), nil)
+		}
+	}
+
+	if h == height {
+		return inconsistencies, nil
+	}
+	commit := bs.LoadBlockCommit(h)
+	switch {
+	case commit == nil:
+		// the commit is the last commit of the next block
+		var repair func(batch dbm.Batch) error
+		if next := bs.LoadBlock(h + 1); next != nil && next.LastCommit != nil {
+			repair = func(batch dbm.Batch) error {
+				return batch.Set(blockCommitKey(h), mustEncode(next.LastCommit.ToProto()))
+			}
+		}
+		add("missing commit", repair)
+	case !commit.BlockID.Equals(blockMeta.BlockID):
+		add(fmt.Sprintf("commit for block %v doesn't match block %v", commit.BlockID, blockMeta.BlockID), nil)
+	}
+
+	return inconsistencies, nil
+}
+
+// verifyOrphans finds the block parts, commits and hash index entries which
+// don't belong to any block of the store, e.g. left behind by an interrupted
+// pruning.
+func (bs *BlockStore) verifyOrphans(base, height int64) ([]Inconsistency, error) {
+	var inconsistencies []Inconsistency
+
+	orphanRanges := []struct {
+		description string
+		start, end  []byte
+	}{
+		{"block parts below the base", blockPartKey(0, 0), blockPartKey(base, 0)},
+		{"block parts above the height", blockPartKey(height+1, 0), blockPartKey(1<<63-1, 0)},
+		// the commit of the height before the initial height is kept
+		{"commits below the base", blockCommitKey(0), blockCommitKey(base - 1)},
+		{"commits above the height", blockCommitKey(height + 1), blockCommitKey(1<<63 - 1)},
+	}
+	for _, r := range orphanRanges {
+		keys, err := bs.keys(r.start, r.end, func([]byte, []byte) bool { return true })
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) > 0 {
+			inconsistencies = append(inconsistencies, Inconsistency{
+				Description: fmt.Sprintf("%d orphaned %s", len(keys), r.description),
+				repair:      deleteKeys(keys),
+			})
+		}
+	}
+
+	// the hash index entries of heights without block or with another block
+	keys, err := bs.keys(blockHashKey(nil), prefixEnd(prefixBlockHash), func(key, value []byte) bool {
+		var (
+			prefix int64
+			hash   string
+		)
+		if _, err := orderedcode.Parse(string(key), &prefix, &hash); err != nil {
+			return true
+		}
+		h, err := strconv.ParseInt(string(value), 10, 64)
+		if err != nil || h < base || h > height {
+			return true
+		}
+		blockMeta := bs.LoadBlockMeta(h)
+		return blockMeta == nil || !bytes.Equal(blockMeta.BlockID.Hash, []byte(hash))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) > 0 {
+		inconsistencies = append(inconsistencies, Inconsistency{
+			Description: fmt.Sprintf("%d orphaned hash index entries", len(keys)),
+			repair:      deleteKeys(keys),
+		})
+	}
+
+	return inconsistencies, nil
+}
+
+// keys returns the keys between start and end matched by match.
+func (bs *BlockStore) keys(start, end []byte, match func(key, value []byte) bool) ([][]byte, error) {
+	if bytes.Compare(start, end) >= 0 {
+		return nil, nil
+	}
+	iter, err := bs.db.Iterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		if match(iter.Key(), iter.Value()) {
+			keys = append(keys, iter.Key())
+		}
+	}
+	return keys, iter.Error()
+}
+
+func deleteKeys(keys [][]byte) func(batch dbm.Batch) error {
+	return func(batch dbm.Batch) error {
+		for _, key := range keys {
+			if err := batch.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// prefixEnd returns the first key after the keys of prefix.
+func prefixEnd(prefix int64) []byte {
+	key, err := orderedcode.Append(nil, prefix+1)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
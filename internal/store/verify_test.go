@@ -0,0 +1,78 @@
+package store
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/config"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/test/factory"
+	tmtime "github.com/tendermint/tendermint/libs/time"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestVerifyAndRepair(t *testing.T) {
+	cfg := config.ResetTestRoot("block_store_verify_test")
+	defer os.RemoveAll(cfg.RootDir)
+	state, err := sm.MakeGenesisStateFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db)
+	inconsistencies, err := bs.Verify()
+	require.NoError(t, err)
+	require.Empty(t, inconsistencies)
+
+	lastCommit := new(types.Commit)
+	for h := int64(1); h <= 10; h++ {
+		state.LastBlockID = lastCommit.BlockID
+		block := factory.MakeBlock(state, h, lastCommit)
+		partSet := block.MakePartSet(2)
+		bs.SaveBlock(block, partSet, makeTestCommit(h, tmtime.Now()))
+		lastCommit = makeTestCommit(h, tmtime.Now())
+		lastCommit.BlockID = types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+	}
+	_, err = bs.PruneBlocks(3)
+	require.NoError(t, err)
+
+	inconsistencies, err = bs.Verify()
+	require.NoError(t, err)
+	require.Empty(t, inconsistencies)
+
+	// repairable: a missing hash index entry, a missing commit and orphans
+	block := bs.LoadBlock(5)
+	require.NoError(t, db.Delete(blockHashKey(block.Hash())))
+	require.NoError(t, db.Delete(blockCommitKey(6)))
+	require.NoError(t, db.Set(blockPartKey(1, 0), []byte{1}))
+	require.NoError(t, db.Set(blockHashKey([]byte("hash")), []byte("1")))
+	// unrepairable: missing parts
+	require.NoError(t, db.Delete(blockPartKey(8, 0)))
+
+	inconsistencies, err = bs.Verify()
+	require.NoError(t, err)
+	require.Len(t, inconsistencies, 5)
+	repairable := 0
+	for _, inconsistency := range inconsistencies {
+		if inconsistency.Repairable() {
+			repairable++
+		} else {
+			require.EqualValues(t, 8, inconsistency.Height)
+		}
+	}
+	require.Equal(t, 4, repairable)
+
+	repaired, err := bs.Repair()
+	require.NoError(t, err)
+	require.Len(t, repaired, 4)
+
+	inconsistencies, err = bs.Verify()
+	require.NoError(t, err)
+	require.Len(t, inconsistencies, 1)
+	require.Equal(t, "height 8: missing block parts", inconsistencies[0].String())
+
+	require.Equal(t, block, bs.LoadBlockByHash(block.Hash()))
+	require.Equal(t, bs.LoadBlock(7).LastCommit.Hash(), bs.LoadBlockCommit(6).Hash())
+}
@@ -0,0 +1,160 @@
+// Package telemetry exports the metrics and traces of the node to an
+// OpenTelemetry collector.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+// shutdownTimeout is the time given to the exporter to flush the pending
+// spans on stop.
+const shutdownTimeout = 5 * time.Second
+
+// Exporter pushes the metrics gathered from a Prometheus registry and the
+// spans of the global tracer provider to an OpenTelemetry collector, with
+// OTLP over gRPC.
+type Exporter struct {
+	service.BaseService
+
+	cfg      *config.OTelConfig
+	gatherer prometheus.Gatherer
+	attrs    []attribute.KeyValue
+
+	tracerProvider *sdktrace.TracerProvider
+	conn           *grpc.ClientConn
+	client         collectormetrics.MetricsServiceClient
+	start          time.Time
+	quit           chan struct{}
+	done           chan struct{}
+}
+
+// NewExporter returns an exporter of the metrics of gatherer and of the
+// traces of the node. The attributes describe the node, e.g. its ID and
+// chain ID, and are attached to all the metrics and spans.
+func NewExporter(
+	cfg *config.OTelConfig,
+	gatherer prometheus.Gatherer,
+	logger log.Logger,
+	attrs ...attribute.KeyValue,
+) *Exporter {
+	e := &Exporter{
+		cfg:      cfg,
+		gatherer: gatherer,
+		attrs:    append([]attribute.KeyValue{attribute.String("service.name", "tendermint")}, attrs...),
+	}
+	e.BaseService = *service.NewBaseService(logger, "OTelExporter", e)
+	return e
+}
+
+// OnStart implements service.Service. It sets the global tracer provider if
+// traces are exported.
+func (e *Exporter) OnStart() error {
+	if e.cfg.Traces {
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(e.cfg.Endpoint),
+			otlptracegrpc.WithHeaders(e.cfg.Headers),
+		}
+		if e.cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		} else {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
+		}
+		exporter, err := otlptracegrpc.New(context.Background(), opts...)
+		if err != nil {
+			return fmt.Errorf("failed to create trace exporter: %w", err)
+		}
+		e.tracerProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(resource.NewSchemaless(e.attrs...)),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(e.cfg.TraceSampleRate))),
+		)
+		otel.SetTracerProvider(e.tracerProvider)
+	}
+
+	if e.cfg.Metrics && e.gatherer != nil {
+		creds := grpc.WithInsecure()
+		if !e.cfg.Insecure {
+			creds = grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, ""))
+		}
+		conn, err := grpc.Dial(e.cfg.Endpoint, creds)
+		if err != nil {
+			return fmt.Errorf("failed to dial %s: %w", e.cfg.Endpoint, err)
+		}
+		e.conn = conn
+		e.client = collectormetrics.NewMetricsServiceClient(conn)
+		e.start = time.Now()
+		e.quit = make(chan struct{})
+		e.done = make(chan struct{})
+		go e.metricsRoutine()
+	}
+	return nil
+}
+
+// OnStop implements service.Service. It flushes the pending spans.
+func (e *Exporter) OnStop() {
+	if e.tracerProvider != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := e.tracerProvider.Shutdown(ctx); err != nil {
+			e.Logger.Error("failed to shut down the tracer provider", "err", err)
+		}
+	}
+	if e.conn != nil {
+		close(e.quit)
+		<-e.done
+		if err := e.conn.Close(); err != nil {
+			e.Logger.Error("failed to close the connection to the collector", "err", err)
+		}
+	}
+}
+
+func (e *Exporter) metricsRoutine() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.cfg.MetricsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.exportMetrics(); err != nil {
+				e.Logger.Error("failed to export metrics", "err", err)
+			}
+		case <-e.quit:
+			return
+		}
+	}
+}
+
+func (e *Exporter) exportMetrics() error {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("failed to gather metrics: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.MetricsInterval)
+	defer cancel()
+	for key, value := range e.cfg.Headers {
+		ctx = metadata.AppendToOutgoingContext(ctx, key, value)
+	}
+	_, err = e.client.Export(ctx, &collectormetrics.ExportMetricsServiceRequest{
+		ResourceMetrics: toResourceMetrics(families, e.attrs, e.start, time.Now()),
+	})
+	return err
+}
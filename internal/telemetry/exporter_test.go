@@ -0,0 +1,77 @@
+package telemetry
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+type testCollector struct {
+	collectormetrics.UnimplementedMetricsServiceServer
+
+	requests chan *collectormetrics.ExportMetricsServiceRequest
+	headers  chan metadata.MD
+}
+
+func (c *testCollector) Export(
+	ctx context.Context,
+	req *collectormetrics.ExportMetricsServiceRequest,
+) (*collectormetrics.ExportMetricsServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	select {
+	case c.requests <- req:
+		c.headers <- md
+	default:
+	}
+	return &collectormetrics.ExportMetricsServiceResponse{}, nil
+}
+
+func TestExporterMetrics(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	collector := &testCollector{
+		requests: make(chan *collectormetrics.ExportMetricsServiceRequest, 1),
+		headers:  make(chan metadata.MD, 1),
+	}
+	server := grpc.NewServer()
+	collectormetrics.RegisterMetricsServiceServer(server, collector)
+	go server.Serve(listener) //nolint:errcheck // ignore for tests
+	defer server.Stop()
+
+	registry := prometheus.NewRegistry()
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "height"})
+	registry.MustRegister(gauge)
+	gauge.Set(7)
+
+	cfg := config.DefaultOTelConfig()
+	cfg.Endpoint = listener.Addr().String()
+	cfg.Insecure = true
+	cfg.Traces = false
+	cfg.MetricsInterval = 10 * time.Millisecond
+	cfg.Headers = map[string]string{"authorization": "Bearer token"}
+
+	exporter := NewExporter(cfg, registry, log.TestingLogger())
+	require.NoError(t, exporter.Start())
+	defer exporter.Stop() //nolint:errcheck // ignore for tests
+
+	select {
+	case req := <-collector.requests:
+		metrics := req.ResourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics
+		require.Len(t, metrics, 1)
+		require.Equal(t, "height", metrics[0].Name)
+		require.Equal(t, 7.0, metrics[0].GetGauge().DataPoints[0].GetAsDouble())
+		require.Equal(t, []string{"Bearer token"}, (<-collector.headers).Get("authorization"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("no metrics exported")
+	}
+}
@@ -0,0 +1,167 @@
+package telemetry
+
+import (
+	"math"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/attribute"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+
+	"github.com/tendermint/tendermint/version"
+)
+
+// instrumentationLibrary is the name under which the metrics are exported.
+const instrumentationLibrary = "github.com/tendermint/tendermint"
+
+// toResourceMetrics converts the metric families gathered from Prometheus to
+// OTLP. Counters are exported as cumulative sums started at start.
+func toResourceMetrics(
+	families []*dto.MetricFamily,
+	attrs []attribute.KeyValue,
+	start, now time.Time,
+) []*metricspb.ResourceMetrics {
+	metrics := make([]*metricspb.Metric, 0, len(families))
+	for _, family := range families {
+		if m := toMetric(family, uint64(start.UnixNano()), uint64(now.UnixNano())); m != nil {
+			metrics = append(metrics, m)
+		}
+	}
+
+	resource := &resourcepb.Resource{Attributes: make([]*commonpb.KeyValue, 0, len(attrs))}
+	for _, attr := range attrs {
+		resource.Attributes = append(resource.Attributes, stringKeyValue(string(attr.Key), attr.Value.Emit()))
+	}
+
+	return []*metricspb.ResourceMetrics{{
+		Resource: resource,
+		InstrumentationLibraryMetrics: []*metricspb.InstrumentationLibraryMetrics{{
+			InstrumentationLibrary: &commonpb.InstrumentationLibrary{
+				Name:    instrumentationLibrary,
+				Version: version.TMVersion,
+			},
+			Metrics: metrics,
+		}},
+	}}
+}
+
+// toMetric converts a metric family to OTLP. It returns nil for the unknown
+// metric types.
+func toMetric(family *dto.MetricFamily, start, now uint64) *metricspb.Metric {
+	m := &metricspb.Metric{
+		Name:        family.GetName(),
+		Description: family.GetHelp(),
+	}
+
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		sum := &metricspb.Sum{
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			IsMonotonic:            true,
+		}
+		for _, metric := range family.GetMetric() {
+			sum.DataPoints = append(sum.DataPoints, &metricspb.NumberDataPoint{
+				Attributes:        toAttributes(metric.GetLabel()),
+				StartTimeUnixNano: start,
+				TimeUnixNano:      now,
+				Value:             &metricspb.NumberDataPoint_AsDouble{AsDouble: metric.GetCounter().GetValue()},
+			})
+		}
+		m.Data = &metricspb.Metric_Sum{Sum: sum}
+
+	case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+		gauge := &metricspb.Gauge{}
+		for _, metric := range family.GetMetric() {
+			value := metric.GetGauge().GetValue()
+			if family.GetType() == dto.MetricType_UNTYPED {
+				value = metric.GetUntyped().GetValue()
+			}
+			gauge.DataPoints = append(gauge.DataPoints, &metricspb.NumberDataPoint{
+				Attributes:   toAttributes(metric.GetLabel()),
+				TimeUnixNano: now,
+				Value:        &metricspb.NumberDataPoint_AsDouble{AsDouble: value},
+			})
+		}
+		m.Data = &metricspb.Metric_Gauge{Gauge: gauge}
+
+	case dto.MetricType_HISTOGRAM:
+		histogram := &metricspb.Histogram{
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		}
+		for _, metric := range family.GetMetric() {
+			histogram.DataPoints = append(histogram.DataPoints,
+				toHistogramDataPoint(metric.GetHistogram(), toAttributes(metric.GetLabel()), start, now))
+		}
+		m.Data = &metricspb.Metric_Histogram{Histogram: histogram}
+
+	case dto.MetricType_SUMMARY:
+		summary := &metricspb.Summary{}
+		for _, metric := range family.GetMetric() {
+			s := metric.GetSummary()
+			point := &metricspb.SummaryDataPoint{
+				Attributes:        toAttributes(metric.GetLabel()),
+				StartTimeUnixNano: start,
+				TimeUnixNano:      now,
+				Count:             s.GetSampleCount(),
+				Sum:               s.GetSampleSum(),
+			}
+			for _, q := range s.GetQuantile() {
+				point.QuantileValues = append(point.QuantileValues, &metricspb.SummaryDataPoint_ValueAtQuantile{
+					Quantile: q.GetQuantile(),
+					Value:    q.GetValue(),
+				})
+			}
+			summary.DataPoints = append(summary.DataPoints, point)
+		}
+		m.Data = &metricspb.Metric_Summary{Summary: summary}
+
+	default:
+		return nil
+	}
+	return m
+}
+
+// toHistogramDataPoint converts a Prometheus histogram, whose buckets are
+// cumulative, to an OTLP data point, whose buckets are not and end with an
+// implicit +Inf bucket.
+func toHistogramDataPoint(
+	h *dto.Histogram,
+	attrs []*commonpb.KeyValue,
+	start, now uint64,
+) *metricspb.HistogramDataPoint {
+	point := &metricspb.HistogramDataPoint{
+		Attributes:        attrs,
+		StartTimeUnixNano: start,
+		TimeUnixNano:      now,
+		Count:             h.GetSampleCount(),
+		Sum:               h.GetSampleSum(),
+	}
+	var prev uint64
+	for _, bucket := range h.GetBucket() {
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			break
+		}
+		point.ExplicitBounds = append(point.ExplicitBounds, bucket.GetUpperBound())
+		point.BucketCounts = append(point.BucketCounts, bucket.GetCumulativeCount()-prev)
+		prev = bucket.GetCumulativeCount()
+	}
+	point.BucketCounts = append(point.BucketCounts, h.GetSampleCount()-prev)
+	return point
+}
+
+func toAttributes(labels []*dto.LabelPair) []*commonpb.KeyValue {
+	attrs := make([]*commonpb.KeyValue, 0, len(labels))
+	for _, label := range labels {
+		attrs = append(attrs, stringKeyValue(label.GetName(), label.GetValue()))
+	}
+	return attrs
+}
+
+func stringKeyValue(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
@@ -0,0 +1,76 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+func TestToResourceMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tendermint",
+		Name:      "txs_total",
+		Help:      "Number of transactions.",
+	}, []string{"chain_id"})
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "tendermint",
+		Name:      "height",
+		Help:      "Height of the chain.",
+	})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "tendermint",
+		Name:      "block_interval_seconds",
+		Help:      "Time between blocks.",
+		Buckets:   []float64{1, 2},
+	})
+	registry.MustRegister(counter, gauge, histogram)
+
+	counter.WithLabelValues("test-chain").Add(3)
+	gauge.Set(42)
+	for _, v := range []float64{0.5, 1.5, 1.5, 5} {
+		histogram.Observe(v)
+	}
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	start := time.Unix(100, 0)
+	now := time.Unix(200, 0)
+	resourceMetrics := toResourceMetrics(families, []attribute.KeyValue{attribute.String("service.name", "tendermint")}, start, now)
+	require.Len(t, resourceMetrics, 1)
+	require.Equal(t, "service.name", resourceMetrics[0].Resource.Attributes[0].Key)
+	require.Equal(t, "tendermint", resourceMetrics[0].Resource.Attributes[0].Value.GetStringValue())
+
+	metrics := make(map[string]*metricspb.Metric)
+	for _, m := range resourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+	require.Len(t, metrics, 3)
+
+	sum := metrics["tendermint_txs_total"].GetSum()
+	require.NotNil(t, sum)
+	require.True(t, sum.IsMonotonic)
+	require.Equal(t, metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, sum.AggregationTemporality)
+	require.Len(t, sum.DataPoints, 1)
+	require.Equal(t, 3.0, sum.DataPoints[0].GetAsDouble())
+	require.Equal(t, uint64(start.UnixNano()), sum.DataPoints[0].StartTimeUnixNano)
+	require.Equal(t, "chain_id", sum.DataPoints[0].Attributes[0].Key)
+	require.Equal(t, "test-chain", sum.DataPoints[0].Attributes[0].Value.GetStringValue())
+
+	g := metrics["tendermint_height"].GetGauge()
+	require.NotNil(t, g)
+	require.Equal(t, 42.0, g.DataPoints[0].GetAsDouble())
+	require.Equal(t, uint64(now.UnixNano()), g.DataPoints[0].TimeUnixNano)
+
+	h := metrics["tendermint_block_interval_seconds"].GetHistogram()
+	require.NotNil(t, h)
+	require.Len(t, h.DataPoints, 1)
+	require.Equal(t, uint64(4), h.DataPoints[0].Count)
+	require.Equal(t, 8.5, h.DataPoints[0].Sum)
+	require.Equal(t, []float64{1, 2}, h.DataPoints[0].ExplicitBounds)
+	require.Equal(t, []uint64{1, 2, 1}, h.DataPoints[0].BucketCounts)
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -15,7 +16,11 @@ var _ Logger = (*defaultLogger)(nil)
 type defaultLogger struct {
 	zerolog.Logger
 
-	trace bool
+	// shared by the loggers derived with With, so that SetLevel and
+	// SetFormat apply to all of them
+	level  *int32
+	writer *syncWriter
+	trace  bool
 }
 
 // NewDefaultLogger returns a default logger that can be used within Tendermint
@@ -27,10 +32,34 @@ type defaultLogger struct {
 // that in a generic interface, all logging methods accept a series of key/value
 // pair tuples, where the key must be a string.
 func NewDefaultLogger(format, level string, trace bool) (Logger, error) {
-	var logWriter io.Writer
+	formatWriter, err := newFormatWriter(format)
+	if err != nil {
+		return nil, err
+	}
+
+	logLevel, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse log level (%s): %w", level, err)
+	}
+
+	// make the writer thread-safe
+	logWriter := newSyncWriter(formatWriter)
+
+	lvl := int32(logLevel)
+	return defaultLogger{
+		Logger: zerolog.New(logWriter).With().Timestamp().Logger(),
+		level:  &lvl,
+		writer: logWriter,
+		trace:  trace,
+	}, nil
+}
+
+// newFormatWriter returns the writer of the logs to stderr in the given
+// format.
+func newFormatWriter(format string) (io.Writer, error) {
 	switch strings.ToLower(format) {
 	case LogFormatPlain, LogFormatText:
-		logWriter = zerolog.ConsoleWriter{
+		return zerolog.ConsoleWriter{
 			Out:        os.Stderr,
 			NoColor:    true,
 			TimeFormat: time.RFC3339,
@@ -40,27 +69,14 @@ func NewDefaultLogger(format, level string, trace bool) (Logger, error) {
 				}
 				return "????"
 			},
-		}
+		}, nil
 
 	case LogFormatJSON:
-		logWriter = os.Stderr
+		return os.Stderr, nil
 
 	default:
 		return nil, fmt.Errorf("unsupported log format: %s", format)
 	}
-
-	logLevel, err := zerolog.ParseLevel(level)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse log level (%s): %w", level, err)
-	}
-
-	// make the writer thread-safe
-	logWriter = newSyncWriter(logWriter)
-
-	return defaultLogger{
-		Logger: zerolog.New(logWriter).Level(logLevel).With().Timestamp().Logger(),
-		trace:  trace,
-	}, nil
 }
 
 // MustNewDefaultLogger delegates a call NewDefaultLogger where it panics on
@@ -75,10 +91,16 @@ func MustNewDefaultLogger(format, level string, trace bool) Logger {
 }
 
 func (l defaultLogger) Info(msg string, keyVals ...interface{}) {
+	if !l.enabled(zerolog.InfoLevel) {
+		return
+	}
 	l.Logger.Info().Fields(getLogFields(keyVals...)).Msg(msg)
 }
 
 func (l defaultLogger) Error(msg string, keyVals ...interface{}) {
+	if !l.enabled(zerolog.ErrorLevel) {
+		return
+	}
 	e := l.Logger.Error()
 	if l.trace {
 		e = e.Stack()
@@ -88,16 +110,53 @@ func (l defaultLogger) Error(msg string, keyVals ...interface{}) {
 }
 
 func (l defaultLogger) Debug(msg string, keyVals ...interface{}) {
+	if !l.enabled(zerolog.DebugLevel) {
+		return
+	}
 	l.Logger.Debug().Fields(getLogFields(keyVals...)).Msg(msg)
 }
 
 func (l defaultLogger) With(keyVals ...interface{}) Logger {
 	return defaultLogger{
 		Logger: l.Logger.With().Fields(getLogFields(keyVals...)).Logger(),
+		level:  l.level,
+		writer: l.writer,
 		trace:  l.trace,
 	}
 }
 
+// SetLevel changes the log level of the logger, and of all the loggers it was
+// derived from or which were derived from it.
+func (l defaultLogger) SetLevel(level string) error {
+	logLevel, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("failed to parse log level (%s): %w", level, err)
+	}
+	atomic.StoreInt32(l.level, int32(logLevel))
+	return nil
+}
+
+// SetFormat changes the log format of the logger, and of all the loggers it
+// was derived from or which were derived from it.
+func (l defaultLogger) SetFormat(format string) error {
+	formatWriter, err := newFormatWriter(format)
+	if err != nil {
+		return err
+	}
+	if l.writer == nil {
+		// the nop logger writes nothing
+		return nil
+	}
+	l.writer.Lock()
+	defer l.writer.Unlock()
+	l.writer.Writer = formatWriter
+	return nil
+}
+
+func (l defaultLogger) enabled(level zerolog.Level) bool {
+	return level >= zerolog.Level(atomic.LoadInt32(l.level))
+}
+
 func getLogFields(keyVals ...interface{}) map[string]interface{} {
 	if len(keyVals)%2 != 0 {
 		return nil
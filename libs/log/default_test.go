@@ -46,3 +46,22 @@ func TestNewDefaultLogger(t *testing.T) {
 		})
 	}
 }
+
+func TestSetLevel(t *testing.T) {
+	logger, err := log.NewDefaultLogger(log.LogFormatJSON, log.LogLevelInfo, false)
+	require.NoError(t, err)
+
+	require.NoError(t, log.SetLevel(logger, log.LogLevelDebug))
+	require.NoError(t, log.SetLevel(logger.With("module", "test"), log.LogLevelError))
+	require.Error(t, log.SetLevel(logger, "foo"))
+}
+
+func TestSetFormat(t *testing.T) {
+	logger, err := log.NewDefaultLogger(log.LogFormatJSON, log.LogLevelInfo, false)
+	require.NoError(t, err)
+
+	require.NoError(t, log.SetFormat(logger, log.LogFormatPlain))
+	require.NoError(t, log.SetFormat(logger.With("module", "test"), log.LogFormatJSON))
+	require.Error(t, log.SetFormat(logger, "foo"))
+	require.NoError(t, log.SetFormat(log.NewNopLogger(), log.LogFormatJSON))
+}
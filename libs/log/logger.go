@@ -1,6 +1,7 @@
 package log
 
 import (
+	"fmt"
 	"io"
 	"sync"
 )
@@ -37,6 +38,36 @@ type Logger interface {
 	With(keyVals ...interface{}) Logger
 }
 
+// LevelSetter is implemented by the loggers whose level can be changed while
+// they are in use.
+type LevelSetter interface {
+	SetLevel(level string) error
+}
+
+// SetLevel changes the level of logger, if it supports it.
+func SetLevel(logger Logger, level string) error {
+	ls, ok := logger.(LevelSetter)
+	if !ok {
+		return fmt.Errorf("logger %T does not support changing its level", logger)
+	}
+	return ls.SetLevel(level)
+}
+
+// FormatSetter is implemented by the loggers whose format can be changed
+// while they are in use.
+type FormatSetter interface {
+	SetFormat(format string) error
+}
+
+// SetFormat changes the format of logger, if it supports it.
+func SetFormat(logger Logger, format string) error {
+	fs, ok := logger.(FormatSetter)
+	if !ok {
+		return fmt.Errorf("logger %T does not support changing its format", logger)
+	}
+	return fs.SetFormat(format)
+}
+
 // syncWriter wraps an io.Writer that can be used in a Logger that is safe for
 // concurrent use by multiple goroutines.
 type syncWriter struct {
@@ -44,7 +75,7 @@ type syncWriter struct {
 	io.Writer
 }
 
-func newSyncWriter(w io.Writer) io.Writer {
+func newSyncWriter(w io.Writer) *syncWriter {
 	return &syncWriter{Writer: w}
 }
 
@@ -5,8 +5,10 @@ import (
 )
 
 func NewNopLogger() Logger {
+	level := int32(zerolog.Disabled)
 	return defaultLogger{
 		Logger: zerolog.Nop(),
+		level:  &level,
 		trace:  false,
 	}
 }
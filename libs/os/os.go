@@ -29,6 +29,19 @@ func TrapSignal(logger logger, cb func()) {
 	}()
 }
 
+// TrapReloadSignal executes the reload function each time SIGHUP is caught.
+func TrapReloadSignal(logger logger, cb func()) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+
+	go func() {
+		for sig := range c {
+			logger.Info(fmt.Sprintf("captured %v, reloading...", sig))
+			cb()
+		}
+	}()
+}
+
 func Exit(s string) {
 	fmt.Printf(s + "\n")
 	os.Exit(1)
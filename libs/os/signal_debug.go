@@ -0,0 +1,24 @@
+//go:build !windows
+// +build !windows
+
+package os
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// TrapDebugSignal executes the debug function each time SIGUSR1 is caught.
+func TrapDebugSignal(logger logger, cb func()) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGUSR1)
+
+	go func() {
+		for sig := range c {
+			logger.Info(fmt.Sprintf("captured %v, collecting debugging data...", sig))
+			cb()
+		}
+	}()
+}
@@ -0,0 +1,4 @@
+package os
+
+// TrapDebugSignal does nothing, as there is no SIGUSR1 on Windows.
+func TrapDebugSignal(logger logger, cb func()) {}
@@ -0,0 +1,75 @@
+package pubsub
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "pubsub"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of subscriptions.
+	Subscriptions metrics.Gauge
+	// Number of messages dropped because a subscription was full, by
+	// overflow policy.
+	DroppedMessages metrics.Counter
+	// Number of subscriptions canceled because they were full.
+	TerminatedSubscriptions metrics.Counter
+	// Number of messages queued in a buffered subscription when one more is
+	// sent to it.
+	SubscriptionLag metrics.Histogram
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		Subscriptions: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "subscriptions",
+			Help:      "Number of subscriptions.",
+		}, labels).With(labelsAndValues...),
+		DroppedMessages: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "dropped_messages",
+			Help:      "Number of messages dropped because a subscription was full.",
+		}, append(labels, "overflow")).With(labelsAndValues...),
+		TerminatedSubscriptions: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "terminated_subscriptions",
+			Help:      "Number of subscriptions canceled because they were full.",
+		}, labels).With(labelsAndValues...),
+		SubscriptionLag: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "subscription_lag",
+			Help:      "Number of messages queued in a subscription when one more is sent to it.",
+			Buckets:   []float64{0, 1, 4, 16, 64, 256, 1024},
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Subscriptions:           discard.NewGauge(),
+		DroppedMessages:         discard.NewCounter(),
+		TerminatedSubscriptions: discard.NewCounter(),
+		SubscriptionLag:         discard.NewHistogram(),
+	}
+}
@@ -38,6 +38,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/tendermint/tendermint/abci/types"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
@@ -62,6 +66,10 @@ var (
 	// ErrAlreadySubscribed is returned when a client tries to subscribe twice or
 	// more using the same query.
 	ErrAlreadySubscribed = errors.New("already subscribed")
+
+	// ErrEventNotFound is returned when a client tries to resume a
+	// subscription after an event which is not in the history anymore.
+	ErrEventNotFound = errors.New("event not found in history")
 )
 
 // Query defines an interface for a query to be used for subscribing. A query
@@ -75,6 +83,58 @@ type Query interface {
 	String() string
 }
 
+// Overflow is what happens to the messages sent to a subscription once its Out
+// channel is full.
+type Overflow int
+
+const (
+	// OverflowTerminate cancels the subscription with ErrOutOfCapacity.
+	OverflowTerminate Overflow = iota
+	// OverflowDropOldest drops the oldest message of the channel to make room.
+	OverflowDropOldest
+	// OverflowDropNewest drops the message sent.
+	OverflowDropNewest
+)
+
+// ParseOverflow returns the overflow policy of the given name, "terminate",
+// "drop-oldest" or "drop-newest".
+func ParseOverflow(name string) (Overflow, error) {
+	for overflow := OverflowTerminate; overflow <= OverflowDropNewest; overflow++ {
+		if overflow.String() == name {
+			return overflow, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown overflow policy %q", name)
+}
+
+func (o Overflow) String() string {
+	switch o {
+	case OverflowTerminate:
+		return "terminate"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowDropNewest:
+		return "drop-newest"
+	default:
+		return fmt.Sprintf("Overflow(%d)", int(o))
+	}
+}
+
+// SubscribeArgs are the parameters of a subscription.
+type SubscribeArgs struct {
+	ClientID string
+	Query    Query
+	// Capacity of the Subscription#Out channel. An unbuffered subscription
+	// blocks the server until it receives the messages, use with caution.
+	Capacity int
+	// Overflow is the policy applied once the Subscription#Out channel is
+	// full, ignored by unbuffered subscriptions.
+	Overflow Overflow
+	// LastEventID, if set, resumes the subscription after the message with
+	// this ID, see SubscribeFrom.
+	LastEventID string
+}
+
 type UnsubscribeArgs struct {
 	ID         string
 	Subscriber string
@@ -104,6 +164,10 @@ type cmd struct {
 	// publish
 	msg    interface{}
 	events []types.Event
+
+	// resumed subscribe
+	lastEventID string
+	errc        chan error
 }
 
 // Server allows clients to subscribe/unsubscribe for messages, publishing
@@ -114,6 +178,13 @@ type Server struct {
 	cmds    chan cmd
 	cmdsCap int
 
+	// number of published messages kept to resume subscriptions, and the
+	// prefix of the IDs of the messages published by this server
+	historySize int
+	epoch       string
+
+	metrics *Metrics
+
 	// check if we have subscription before
 	// subscribing or unsubscribing
 	mtx tmsync.RWMutex
@@ -133,6 +204,8 @@ type Option func(*Server)
 func NewServer(options ...Option) *Server {
 	s := &Server{
 		subscriptions: make(map[string]map[string]string),
+		epoch:         strconv.FormatInt(time.Now().UnixNano(), 36),
+		metrics:       NopMetrics(),
 	}
 	s.BaseService = *service.NewBaseService(nil, "PubSub", s)
 
@@ -158,6 +231,22 @@ func BufferCapacity(cap int) Option {
 	}
 }
 
+// EventHistory makes the server keep the last size published messages, so
+// that clients can resume a subscription after one of them with
+// SubscribeFrom. Disabled by default.
+func EventHistory(size int) Option {
+	return func(s *Server) {
+		if size > 0 {
+			s.historySize = size
+		}
+	}
+}
+
+// WithMetrics makes the server record the given metrics of its subscriptions.
+func WithMetrics(metrics *Metrics) Option {
+	return func(s *Server) { s.metrics = metrics }
+}
+
 // BufferCapacity returns capacity of the internal server's queue.
 func (s *Server) BufferCapacity() int {
 	return s.cmdsCap
@@ -184,17 +273,56 @@ func (s *Server) Subscribe(
 		outCap = outCapacity[0]
 	}
 
-	return s.subscribe(ctx, clientID, query, outCap)
+	return s.SubscribeWithArgs(ctx, SubscribeArgs{ClientID: clientID, Query: query, Capacity: outCap})
+}
+
+// SubscribeFrom does the same as Subscribe, except the messages of the history
+// published after the one with the ID lastEventID and matching the query are
+// sent first to the subscription. ErrEventNotFound is returned if some of
+// these messages are not in the history anymore.
+//
+// The capacity of the Subscription#Out channel must leave room for the
+// messages replayed, or the subscription is canceled with ErrOutOfCapacity.
+func (s *Server) SubscribeFrom(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	lastEventID string,
+	outCapacity ...int) (*Subscription, error) {
+	outCap := 1
+	if len(outCapacity) > 0 {
+		if outCapacity[0] <= 0 {
+			panic("Negative or zero capacity. Use SubscribeUnbuffered if you want an unbuffered channel")
+		}
+		outCap = outCapacity[0]
+	}
+
+	return s.SubscribeWithArgs(ctx, SubscribeArgs{
+		ClientID:    clientID,
+		Query:       query,
+		Capacity:    outCap,
+		LastEventID: lastEventID,
+	})
 }
 
 // SubscribeUnbuffered does the same as Subscribe, except it returns a
 // subscription with unbuffered channel. Use with caution as it can freeze the
 // server.
 func (s *Server) SubscribeUnbuffered(ctx context.Context, clientID string, query Query) (*Subscription, error) {
-	return s.subscribe(ctx, clientID, query, 0)
+	return s.SubscribeWithArgs(ctx, SubscribeArgs{ClientID: clientID, Query: query})
 }
 
-func (s *Server) subscribe(ctx context.Context, clientID string, query Query, outCapacity int) (*Subscription, error) {
+// SubscribeWithArgs creates a subscription with the given parameters, see
+// Subscribe and SubscribeFrom.
+func (s *Server) SubscribeWithArgs(ctx context.Context, args SubscribeArgs) (*Subscription, error) {
+	if args.Query == nil {
+		return nil, errors.New("missing query")
+	}
+	if args.Capacity < 0 {
+		return nil, fmt.Errorf("negative capacity %d", args.Capacity)
+	}
+	clientID, query, lastEventID := args.ClientID, args.Query, args.LastEventID
+
 	s.mtx.RLock()
 	clientSubscriptions, ok := s.subscriptions[clientID]
 	if ok {
@@ -205,9 +333,27 @@ func (s *Server) subscribe(ctx context.Context, clientID string, query Query, ou
 		return nil, ErrAlreadySubscribed
 	}
 
-	subscription := NewSubscription(outCapacity)
+	subscription := NewSubscription(args.Capacity)
+	subscription.overflow = args.Overflow
+	c := cmd{op: sub, clientID: clientID, query: query, subscription: subscription, lastEventID: lastEventID}
+	if lastEventID != "" {
+		c.errc = make(chan error, 1)
+	}
 	select {
-	case s.cmds <- cmd{op: sub, clientID: clientID, query: query, subscription: subscription}:
+	case s.cmds <- c:
+		if c.errc != nil {
+			select {
+			case err := <-c.errc:
+				if err != nil {
+					return nil, err
+				}
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-s.Quit():
+				return nil, nil
+			}
+		}
+
 		s.mtx.Lock()
 		if _, ok = s.subscriptions[clientID]; !ok {
 			s.subscriptions[clientID] = make(map[string]string)
@@ -355,6 +501,22 @@ type state struct {
 	subscriptions map[string]map[string]*Subscription
 	// query string -> queryPlusRefCount
 	queries map[string]*queryPlusRefCount
+
+	// the last published messages, oldest first
+	history     []published
+	historySize int
+	epoch       string
+	// sequence number of the last published message
+	seq uint64
+
+	metrics *Metrics
+}
+
+// published is a message of the history.
+type published struct {
+	seq    uint64
+	msg    interface{}
+	events []types.Event
 }
 
 // queryPlusRefCount holds a pointer to a query and reference counter. When
@@ -369,6 +531,9 @@ func (s *Server) OnStart() error {
 	go s.loop(state{
 		subscriptions: make(map[string]map[string]*Subscription),
 		queries:       make(map[string]*queryPlusRefCount),
+		historySize:   s.historySize,
+		epoch:         s.epoch,
+		metrics:       s.metrics,
 	})
 	return nil
 }
@@ -392,7 +557,16 @@ loop:
 			state.removeAll(nil)
 			break loop
 		case sub:
-			state.add(cmd.clientID, cmd.query, cmd.subscription)
+			if cmd.lastEventID == "" {
+				state.add(cmd.clientID, cmd.query, cmd.subscription)
+				continue
+			}
+			missed, err := state.since(cmd.lastEventID)
+			if err == nil {
+				state.add(cmd.clientID, cmd.query, cmd.subscription)
+				err = state.replay(cmd.clientID, cmd.query, cmd.subscription, missed)
+			}
+			cmd.errc <- err
 		case pub:
 			if err := state.send(cmd.msg, cmd.events); err != nil {
 				s.Logger.Error("Error querying for events", "err", err)
@@ -423,6 +597,7 @@ func (state *state) add(clientID string, q Query, subscription *Subscription) {
 	}
 	// increment reference counter
 	state.queries[qStr].refCount++
+	state.metrics.Subscriptions.Add(1)
 }
 
 func (state *state) remove(clientID string, qStr, id string, reason error) {
@@ -437,6 +612,7 @@ func (state *state) remove(clientID string, qStr, id string, reason error) {
 	}
 
 	subscription.cancel(reason)
+	state.metrics.Subscriptions.Add(-1)
 
 	// remove client from query map.
 	// if query has no other clients subscribed, remove it.
@@ -486,7 +662,58 @@ func (state *state) removeAll(reason error) {
 	}
 }
 
+func (state *state) eventID(seq uint64) string {
+	return fmt.Sprintf("%s-%d", state.epoch, seq)
+}
+
+// since returns the messages of the history published after the one with the
+// given ID.
+func (state *state) since(lastEventID string) ([]published, error) {
+	epoch, seqStr, ok := cut(lastEventID, "-")
+	if !ok || epoch != state.epoch {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotFound, lastEventID)
+	}
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if err != nil || seq > state.seq {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotFound, lastEventID)
+	}
+
+	// the history holds the messages from oldest to state.seq
+	oldest := state.seq + 1 - uint64(len(state.history))
+	if seq+1 < oldest {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotFound, lastEventID)
+	}
+	return state.history[seq+1-oldest:], nil
+}
+
+// replay sends the given messages matching q to the new subscription.
+func (state *state) replay(clientID string, q Query, subscription *Subscription, messages []published) error {
+	for _, p := range messages {
+		match, err := q.Matches(p.events)
+		if err != nil {
+			return fmt.Errorf("failed to match against query %s: %w", q.String(), err)
+		}
+		if !match {
+			continue
+		}
+		msg := newMessage(subscription.id, state.eventID(p.seq), p.msg, p.events)
+		if !state.deliver(clientID, q.String(), subscription, msg) {
+			return nil
+		}
+	}
+	return nil
+}
+
 func (state *state) send(msg interface{}, events []types.Event) error {
+	state.seq++
+	if state.historySize > 0 {
+		state.history = append(state.history, published{seq: state.seq, msg: msg, events: events})
+		if len(state.history) > state.historySize {
+			state.history = state.history[1:]
+		}
+	}
+	eventID := state.eventID(state.seq)
+
 	for qStr, clientSubscriptions := range state.subscriptions {
 		if sub, ok := clientSubscriptions[qStr]; ok && sub.id == qStr {
 			continue
@@ -505,23 +732,59 @@ func (state *state) send(msg interface{}, events []types.Event) error {
 
 		if match {
 			for clientID, subscription := range clientSubscriptions {
-				if cap(subscription.out) == 0 {
-					// block on unbuffered channel
-					select {
-					case subscription.out <- NewMessage(subscription.id, msg, events):
-					case <-subscription.canceled:
-					}
-				} else {
-					// don't block on buffered channels
-					select {
-					case subscription.out <- NewMessage(subscription.id, msg, events):
-					default:
-						state.remove(clientID, qStr, subscription.id, ErrOutOfCapacity)
-					}
-				}
+				state.deliver(clientID, qStr, subscription, newMessage(subscription.id, eventID, msg, events))
 			}
 		}
 	}
 
 	return nil
 }
+
+// deliver sends the message to the subscription, blocking if it is unbuffered.
+// Buffered subscriptions never block, their overflow policy applying once
+// full. It returns false if the subscription was canceled.
+func (state *state) deliver(clientID, qStr string, subscription *Subscription, msg Message) bool {
+	if cap(subscription.out) == 0 {
+		select {
+		case subscription.out <- msg:
+		case <-subscription.canceled:
+		}
+		return true
+	}
+
+	state.metrics.SubscriptionLag.Observe(float64(len(subscription.out)))
+	select {
+	case subscription.out <- msg:
+		return true
+	default:
+	}
+
+	switch subscription.overflow {
+	case OverflowDropOldest:
+		select {
+		case <-subscription.out:
+		default:
+		}
+		// the server is the only sender, so there is room now
+		select {
+		case subscription.out <- msg:
+		default:
+		}
+	case OverflowDropNewest:
+	default:
+		state.remove(clientID, qStr, subscription.id, ErrOutOfCapacity)
+		state.metrics.TerminatedSubscriptions.Add(1)
+		return false
+	}
+	atomic.AddUint64(&subscription.dropped, 1)
+	state.metrics.DroppedMessages.With("overflow", subscription.overflow.String()).Add(1)
+	return true
+}
+
+// cut slices s around the first instance of sep.
+func cut(s, sep string) (before, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -150,6 +150,66 @@ func TestSlowClientIsRemovedWithErrOutOfCapacity(t *testing.T) {
 	assertCanceled(t, subscription, pubsub.ErrOutOfCapacity)
 }
 
+func TestSubscribeWithOverflow(t *testing.T) {
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	dropOldest, err := s.SubscribeWithArgs(ctx, pubsub.SubscribeArgs{
+		ClientID: "drop-oldest-client",
+		Query:    query.Empty{},
+		Capacity: 2,
+		Overflow: pubsub.OverflowDropOldest,
+	})
+	require.NoError(t, err)
+	dropNewest, err := s.SubscribeWithArgs(ctx, pubsub.SubscribeArgs{
+		ClientID: "drop-newest-client",
+		Query:    query.Empty{},
+		Capacity: 2,
+		Overflow: pubsub.OverflowDropNewest,
+	})
+	require.NoError(t, err)
+
+	for _, msg := range []string{"Nightcrawler", "Colossus", "Banshee", "Sunfire"} {
+		require.NoError(t, s.Publish(ctx, msg))
+	}
+	require.Eventually(t, func() bool {
+		return dropOldest.Dropped() == 2 && dropNewest.Dropped() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// the full subscriptions are kept
+	assertReceive(t, "Banshee", dropOldest.Out())
+	assertReceive(t, "Sunfire", dropOldest.Out())
+	assertReceive(t, "Nightcrawler", dropNewest.Out())
+	assertReceive(t, "Colossus", dropNewest.Out())
+	assert.Nil(t, dropOldest.Err())
+	assert.Nil(t, dropNewest.Err())
+	assert.Equal(t, 2, s.NumClients())
+
+	_, err = s.SubscribeWithArgs(ctx, pubsub.SubscribeArgs{ClientID: clientID, Query: query.Empty{}, Capacity: -1})
+	assert.Error(t, err)
+}
+
+func TestParseOverflow(t *testing.T) {
+	for _, overflow := range []pubsub.Overflow{
+		pubsub.OverflowTerminate,
+		pubsub.OverflowDropOldest,
+		pubsub.OverflowDropNewest,
+	} {
+		parsed, err := pubsub.ParseOverflow(overflow.String())
+		require.NoError(t, err)
+		assert.Equal(t, overflow, parsed)
+	}
+	_, err := pubsub.ParseOverflow("block")
+	assert.Error(t, err)
+}
+
 func TestDifferentClients(t *testing.T) {
 	s := pubsub.NewServer()
 	s.SetLogger(log.TestingLogger())
@@ -446,6 +506,49 @@ func TestBufferCapacity(t *testing.T) {
 	}
 }
 
+func TestSubscribeFrom(t *testing.T) {
+	s := pubsub.NewServer(pubsub.EventHistory(3))
+	s.SetLogger(log.TestingLogger())
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	subscription, err := s.Subscribe(ctx, clientID, query.Empty{}, 10)
+	require.NoError(t, err)
+
+	q := query.MustParse("tm.events.type='NewBlock'")
+	newBlock := []abci.Event{{Type: "tm", Attributes: []abci.EventAttribute{{Key: "events.type", Value: "NewBlock"}}}}
+	require.NoError(t, s.PublishWithEvents(ctx, "Rogue", newBlock))
+	require.NoError(t, s.Publish(ctx, "Gambit"))
+	require.NoError(t, s.PublishWithEvents(ctx, "Beast", newBlock))
+	require.NoError(t, s.PublishWithEvents(ctx, "Storm", newBlock))
+
+	var ids []string
+	for i := 0; i < 4; i++ {
+		msg := <-subscription.Out()
+		ids = append(ids, msg.EventID())
+	}
+
+	// the messages after Gambit which match the query are replayed
+	resumed, err := s.SubscribeFrom(ctx, "resumed-client", q, ids[1], 10)
+	require.NoError(t, err)
+	assertReceive(t, "Beast", resumed.Out())
+	assertReceive(t, "Storm", resumed.Out())
+	require.NoError(t, s.PublishWithEvents(ctx, "Cyclops", newBlock))
+	assertReceive(t, "Cyclops", resumed.Out())
+
+	// Gambit, which follows Rogue, is not in the history anymore
+	_, err = s.SubscribeFrom(ctx, "late-client", q, ids[0], 10)
+	assert.ErrorIs(t, err, pubsub.ErrEventNotFound)
+	_, err = s.SubscribeFrom(ctx, "late-client", q, "other-1", 10)
+	assert.ErrorIs(t, err, pubsub.ErrEventNotFound)
+	assert.Equal(t, 2, s.NumClients())
+}
+
 func Benchmark10Clients(b *testing.B)   { benchmarkNClients(10, b) }
 func Benchmark100Clients(b *testing.B)  { benchmarkNClients(100, b) }
 func Benchmark1000Clients(b *testing.B) { benchmarkNClients(1000, b) }
@@ -0,0 +1,388 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxConjunctions bounds the size of the disjunctive normal form of a query,
+// which grows exponentially with the nesting of ANDs and ORs.
+const maxConjunctions = 128
+
+// exprOp is the operator of an expression node.
+type exprOp uint8
+
+const (
+	exprCondition exprOp = iota
+	exprAnd
+	exprOr
+	exprNot
+)
+
+// expr is a node of the expression tree of a query: either a condition (the
+// leaves), or the AND, OR or NOT of sub-expressions.
+type expr struct {
+	op   exprOp
+	args []*expr
+	cond Condition
+}
+
+// Conjunction is a list of conditions which must all match, and of negated
+// conditions of which none must match.
+type Conjunction struct {
+	Conditions []Condition
+	Negated    []Condition
+}
+
+// token of the grammar of the queries:
+//
+//	or        <- and ("OR" and)*
+//	and       <- unary ("AND" unary)*
+//	unary     <- "NOT" unary / "(" or ")" / condition
+//
+// where the conditions are what's left between the keywords and the
+// parentheses, see parseCondition.
+type token struct {
+	text string
+	cond bool // otherwise a keyword or a parenthesis
+}
+
+// tokenize splits s into the keywords, the parentheses and the conditions in
+// between them. The keywords must be separated from the conditions by spaces
+// or parentheses, and the quoted values are never split.
+func tokenize(s string) ([]token, error) {
+	var (
+		tokens    []token
+		condStart = -1
+	)
+	endCond := func(end int) {
+		if condStart >= 0 {
+			tokens = append(tokens, token{text: strings.TrimSpace(s[condStart:end]), cond: true})
+			condStart = -1
+		}
+	}
+
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == '(' || c == ')':
+			endCond(i)
+			tokens = append(tokens, token{text: s[i : i+1]})
+			i++
+
+		case unicode.IsSpace(rune(c)):
+			i++
+
+		default:
+			// a word, up to a space or a parenthesis, with its quoted values
+			start := i
+			for i < len(s) && s[i] != '(' && s[i] != ')' && !unicode.IsSpace(rune(s[i])) {
+				if s[i] == '\'' {
+					end := strings.IndexByte(s[i+1:], '\'')
+					if end < 0 {
+						return nil, fmt.Errorf("unterminated value at position %d", i)
+					}
+					i += end + 1
+				}
+				i++
+			}
+
+			switch word := s[start:i]; word {
+			case "AND", "OR", "NOT":
+				endCond(start)
+				tokens = append(tokens, token{text: word})
+			default:
+				if condStart < 0 {
+					condStart = start
+				}
+			}
+		}
+	}
+	endCond(len(s))
+	return tokens, nil
+}
+
+// exprParser is a recursive descent parser of the outer grammar.
+type exprParser struct {
+	tokens []token
+	pos    int
+}
+
+// parseExpr parses s into an expression tree.
+func parseExpr(s string) (*expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return e, nil
+}
+
+func (p *exprParser) peek(keyword string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].cond && p.tokens[p.pos].text == keyword
+}
+
+func (p *exprParser) or() (*expr, error) {
+	return p.binary(exprOr, "OR", p.and)
+}
+
+func (p *exprParser) and() (*expr, error) {
+	return p.binary(exprAnd, "AND", p.unary)
+}
+
+func (p *exprParser) binary(op exprOp, keyword string, operand func() (*expr, error)) (*expr, error) {
+	e, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	args := []*expr{e}
+	for p.peek(keyword) {
+		p.pos++
+		e, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, e)
+	}
+	if len(args) == 1 {
+		return args[0], nil
+	}
+	return &expr{op: op, args: args}, nil
+}
+
+func (p *exprParser) unary() (*expr, error) {
+	if p.pos == len(p.tokens) {
+		return nil, errors.New("unexpected end of query")
+	}
+
+	switch tok := p.tokens[p.pos]; {
+	case tok.cond:
+		p.pos++
+		cond, err := parseCondition(tok.text)
+		if err != nil {
+			return nil, err
+		}
+		return &expr{op: exprCondition, cond: cond}, nil
+
+	case tok.text == "NOT":
+		p.pos++
+		e, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &expr{op: exprNot, args: []*expr{e}}, nil
+
+	case tok.text == "(":
+		p.pos++
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peek(")") {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		p.pos++
+		return e, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected %q", tok.text)
+	}
+}
+
+var (
+	numberRegex = regexp.MustCompile(`^(0|[1-9][0-9]*(\.[0-9]*)?)$`)
+	dateRegex   = regexp.MustCompile(`^[12][0-9]{3}-[01][0-9]-[0-3][0-9]$`)
+	timeRegex   = regexp.MustCompile(
+		`^[12][0-9]{3}-[01][0-9]-[0-3][0-9]T[0-9]{2}:[0-9]{2}:[0-9]{2}([-+][0-9]{2}:[0-9]{2}|Z)$`)
+)
+
+// parseCondition parses a single condition:
+//
+//	condition <- tag ' '* (("<=" / ">=" / "<" / ">") ' '* (number / time / date)
+//	                      / "=" ' '* (number / time / date / value)
+//	                      / "CONTAINS" ' '* value
+//	                      / "EXISTS")
+//	tag       <- (![ \t\n\r\\()"'=><] .)+
+//	value     <- '\'' (!["'] .)* '\''
+//	number    <- '0' / [1-9] [0-9]* ('.' [0-9]*)?
+//	time      <- "TIME " RFC 3339 time, with a timezone
+//	date      <- "DATE " yyyy-mm-dd
+func parseCondition(s string) (Condition, error) {
+	end := strings.IndexAny(s, " \t\n\r\\()\"'=><")
+	if end < 0 {
+		end = len(s)
+	}
+	if end == 0 {
+		return Condition{}, fmt.Errorf("missing tag in condition %q", s)
+	}
+	cond := Condition{CompositeKey: s[:end]}
+
+	rest := strings.TrimLeft(s[end:], " ")
+	for _, op := range []struct {
+		text string
+		op   Operator
+	}{
+		{"<=", OpLessEqual},
+		{">=", OpGreaterEqual},
+		{"<", OpLess},
+		{">", OpGreater},
+		{"=", OpEqual},
+		{"CONTAINS", OpContains},
+	} {
+		if strings.HasPrefix(rest, op.text) {
+			cond.Op = op.op
+			operand, err := parseOperand(strings.TrimLeft(rest[len(op.text):], " "), op.op)
+			if err != nil {
+				return Condition{}, fmt.Errorf("invalid condition %q: %w", s, err)
+			}
+			cond.Operand = operand
+			return cond, nil
+		}
+	}
+	if rest == "EXISTS" {
+		cond.Op = OpExists
+		return cond, nil
+	}
+	return Condition{}, fmt.Errorf("missing operator in condition %q", s)
+}
+
+// parseOperand parses the operand of a condition with the operator op: a
+// quoted string for = and CONTAINS, or a number, a time or a date for the
+// other operators and =.
+func parseOperand(s string, op Operator) (interface{}, error) {
+	if strings.HasPrefix(s, "'") {
+		if op != OpEqual && op != OpContains {
+			return nil, errors.New("strings can only be compared with = or CONTAINS")
+		}
+		if len(s) < 2 || !strings.HasSuffix(s, "'") || strings.ContainsAny(s[1:len(s)-1], `"'`) {
+			return nil, fmt.Errorf("invalid string %s", s)
+		}
+		return s[1 : len(s)-1], nil
+	}
+	if op == OpContains {
+		return nil, errors.New("CONTAINS expects a string")
+	}
+
+	switch {
+	case strings.HasPrefix(s, "TIME "):
+		if !timeRegex.MatchString(s[5:]) {
+			return nil, fmt.Errorf("invalid time %s", s[5:])
+		}
+		return time.Parse(TimeLayout, s[5:])
+
+	case strings.HasPrefix(s, "DATE "):
+		if !dateRegex.MatchString(s[5:]) {
+			return nil, fmt.Errorf("invalid date %s", s[5:])
+		}
+		return time.Parse(DateLayout, s[5:])
+
+	case !numberRegex.MatchString(s):
+		return nil, fmt.Errorf("invalid operand %s", s)
+
+	case strings.Contains(s, "."):
+		return strconv.ParseFloat(s, 64)
+
+	default:
+		return strconv.ParseInt(s, 10, 64)
+	}
+}
+
+// matches returns whether the expression matches the flattened events.
+func (e *expr) matches(events map[string][]string) (bool, error) {
+	switch e.op {
+	case exprCondition:
+		return matchCondition(e.cond, events)
+
+	case exprNot:
+		match, err := e.args[0].matches(events)
+		return !match, err
+
+	default:
+		// AND matches unless an argument doesn't, OR doesn't unless one does
+		for _, arg := range e.args {
+			match, err := arg.matches(events)
+			if err != nil {
+				return false, err
+			}
+			if match != (e.op == exprAnd) {
+				return match, nil
+			}
+		}
+		return e.op == exprAnd, nil
+	}
+}
+
+// disjunction returns the expression in disjunctive normal form, with the
+// negations pushed down to the conditions.
+func (e *expr) disjunction(negated bool) ([]Conjunction, error) {
+	switch {
+	case e.op == exprCondition && negated:
+		return []Conjunction{{Negated: []Condition{e.cond}}}, nil
+
+	case e.op == exprCondition:
+		return []Conjunction{{Conditions: []Condition{e.cond}}}, nil
+
+	case e.op == exprNot:
+		return e.args[0].disjunction(!negated)
+
+	// OR, or NOT AND: the union of the disjunctions of the arguments
+	case (e.op == exprOr) != negated:
+		var result []Conjunction
+		for _, arg := range e.args {
+			d, err := arg.disjunction(negated)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, d...)
+			if len(result) > maxConjunctions {
+				return nil, errTooComplex
+			}
+		}
+		return result, nil
+
+	// AND, or NOT OR: the product of the disjunctions of the arguments
+	default:
+		result := []Conjunction{{}}
+		for _, arg := range e.args {
+			d, err := arg.disjunction(negated)
+			if err != nil {
+				return nil, err
+			}
+			if len(result)*len(d) > maxConjunctions {
+				return nil, errTooComplex
+			}
+			product := make([]Conjunction, 0, len(result)*len(d))
+			for _, c1 := range result {
+				for _, c2 := range d {
+					product = append(product, Conjunction{
+						Conditions: concat(c1.Conditions, c2.Conditions),
+						Negated:    concat(c1.Negated, c2.Negated),
+					})
+				}
+			}
+			result = product
+		}
+		return result, nil
+	}
+}
+
+// concat returns a new slice with the conditions of a and b.
+func concat(a, b []Condition) []Condition {
+	if len(a)+len(b) == 0 {
+		return nil
+	}
+	return append(append(make([]Condition, 0, len(a)+len(b)), a...), b...)
+}
+
+var errTooComplex = fmt.Errorf("query is too complex: more than %d alternatives once expanded", maxConjunctions)
@@ -84,6 +84,19 @@ func TestParser(t *testing.T) {
 
 		{"hash='136E18F7E4C348B780CF873A0BF43922E5BAFA63'", true},
 		{"hash=136E18F7E4C348B780CF873A0BF43922E5BAFA63", false},
+
+		{"tm.events.type='NewBlock' OR tm.events.type='Tx'", true},
+		{"NOT tm.events.type='NewBlock'", true},
+		{"tm.events.type='Tx' AND NOT (tx.gas > 7 OR tx.gas < 2)", true},
+		{"(account.balance=100)", true},
+		{"account.name='(Igor AND Ivan)'", true},
+		{"tm.events.type='NewBlock' OR", false},
+		{"NOT", false},
+		{"(account.balance=100", false},
+		{"account.balance=100)", false},
+		{"()", false},
+		{"account.balance=100 OR AND account.balance=200", false},
+		{"account.name='Igor", false},
 	}
 
 	for _, c := range cases {
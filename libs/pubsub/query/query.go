@@ -2,13 +2,19 @@
 //
 //		abci.invoice.number=22 AND abci.invoice.owner=Ivan
 //
-// See query.peg for the grammar, which is a https://en.wikipedia.org/wiki/Parsing_expression_grammar.
+// See expr.go for the grammar, which is a https://en.wikipedia.org/wiki/Parsing_expression_grammar.
 // More: https://github.com/PhilippeSigaud/Pegged/wiki/PEG-Basics
 //
 // It has a support for numbers (integer and floating point), dates and times.
+//
+// Conditions can be combined with AND, OR and NOT, from the highest to the
+// lowest precedence NOT, AND, OR, and grouped with parentheses:
+//
+//		tm.event='Tx' AND (transfer.sender='Ivan' OR NOT transfer.amount<=100)
 package query
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -23,10 +29,10 @@ var (
 	numRegex = regexp.MustCompile(`([0-9\.]+)`)
 )
 
-// Query holds the query string and the query parser.
+// Query holds the query string and its parsed expression.
 type Query struct {
-	str    string
-	parser *QueryParser
+	str  string
+	expr *expr
 }
 
 // Condition represents a single condition within a query and consists of composite key
@@ -40,12 +46,11 @@ type Condition struct {
 // New parses the given string and returns a query or error if the string is
 // invalid.
 func New(s string) (*Query, error) {
-	p := &QueryParser{Buffer: fmt.Sprintf(`"%s"`, s)}
-	p.Init()
-	if err := p.Parse(); err != nil {
+	e, err := parseExpr(s)
+	if err != nil {
 		return nil, err
 	}
-	return &Query{str: s, parser: p}, nil
+	return &Query{str: s, expr: e}, nil
 }
 
 // MustParse turns the given string into a query or panics; for tests or others
@@ -91,106 +96,24 @@ const (
 	TimeLayout = time.RFC3339
 )
 
-// Conditions returns a list of conditions. It returns an error if there is any
-// error with the provided grammar in the Query.
+// Conditions returns a list of conditions, which must all match. It returns
+// an error if the query has OR or NOT clauses, see Disjunction.
 func (q *Query) Conditions() ([]Condition, error) {
-	var (
-		eventAttr string
-		op        Operator
-	)
-
-	conditions := make([]Condition, 0)
-	buffer, begin, end := q.parser.Buffer, 0, 0
-
-	// tokens must be in the following order: tag ("tx.gas") -> operator ("=") -> operand ("7")
-	for token := range q.parser.Tokens() {
-		switch token.pegRule {
-		case rulePegText:
-			begin, end = int(token.begin), int(token.end)
-
-		case ruletag:
-			eventAttr = buffer[begin:end]
-
-		case rulele:
-			op = OpLessEqual
-
-		case rulege:
-			op = OpGreaterEqual
-
-		case rulel:
-			op = OpLess
-
-		case ruleg:
-			op = OpGreater
-
-		case ruleequal:
-			op = OpEqual
-
-		case rulecontains:
-			op = OpContains
-
-		case ruleexists:
-			op = OpExists
-			conditions = append(conditions, Condition{eventAttr, op, nil})
-
-		case rulevalue:
-			// strip single quotes from value (i.e. "'NewBlock'" -> "NewBlock")
-			valueWithoutSingleQuotes := buffer[begin+1 : end-1]
-			conditions = append(conditions, Condition{eventAttr, op, valueWithoutSingleQuotes})
-
-		case rulenumber:
-			number := buffer[begin:end]
-			if strings.ContainsAny(number, ".") { // if it looks like a floating-point number
-				value, err := strconv.ParseFloat(number, 64)
-				if err != nil {
-					err = fmt.Errorf(
-						"got %v while trying to parse %s as float64 (should never happen if the grammar is correct)",
-						err, number,
-					)
-					return nil, err
-				}
-
-				conditions = append(conditions, Condition{eventAttr, op, value})
-			} else {
-				value, err := strconv.ParseInt(number, 10, 64)
-				if err != nil {
-					err = fmt.Errorf(
-						"got %v while trying to parse %s as int64 (should never happen if the grammar is correct)",
-						err, number,
-					)
-					return nil, err
-				}
-
-				conditions = append(conditions, Condition{eventAttr, op, value})
-			}
-
-		case ruletime:
-			value, err := time.Parse(TimeLayout, buffer[begin:end])
-			if err != nil {
-				err = fmt.Errorf(
-					"got %v while trying to parse %s as time.Time / RFC3339 (should never happen if the grammar is correct)",
-					err, buffer[begin:end],
-				)
-				return nil, err
-			}
-
-			conditions = append(conditions, Condition{eventAttr, op, value})
-
-		case ruledate:
-			value, err := time.Parse("2006-01-02", buffer[begin:end])
-			if err != nil {
-				err = fmt.Errorf(
-					"got %v while trying to parse %s as time.Time / '2006-01-02' (should never happen if the grammar is correct)",
-					err, buffer[begin:end],
-				)
-				return nil, err
-			}
-
-			conditions = append(conditions, Condition{eventAttr, op, value})
-		}
+	conjunctions, err := q.Disjunction()
+	if err != nil {
+		return nil, err
 	}
+	if len(conjunctions) != 1 || len(conjunctions[0].Negated) > 0 {
+		return nil, errors.New("query has OR or NOT clauses")
+	}
+	return conjunctions[0].Conditions, nil
+}
 
-	return conditions, nil
+// Disjunction returns the query in disjunctive normal form: it matches when
+// any of the returned conjunctions does. It returns an error if the query
+// expands to too many conjunctions.
+func (q *Query) Disjunction() ([]Conjunction, error) {
+	return q.expr.disjunction(false)
 }
 
 // Matches returns true if the query matches against any event in the given set
@@ -205,162 +128,30 @@ func (q *Query) Matches(rawEvents []types.Event) (bool, error) {
 		return false, nil
 	}
 
-	events := flattenEvents(rawEvents)
-
-	var (
-		eventAttr string
-		op        Operator
-	)
-
-	buffer, begin, end := q.parser.Buffer, 0, 0
-
-	// tokens must be in the following order:
-
-	// tag ("tx.gas") -> operator ("=") -> operand ("7")
-	for token := range q.parser.Tokens() {
-		switch token.pegRule {
-		case rulePegText:
-			begin, end = int(token.begin), int(token.end)
-
-		case ruletag:
-			eventAttr = buffer[begin:end]
-
-		case rulele:
-			op = OpLessEqual
-
-		case rulege:
-			op = OpGreaterEqual
-
-		case rulel:
-			op = OpLess
-
-		case ruleg:
-			op = OpGreater
-
-		case ruleequal:
-			op = OpEqual
-
-		case rulecontains:
-			op = OpContains
-		case ruleexists:
-			op = OpExists
-			if strings.Contains(eventAttr, ".") {
-				// Searching for a full "type.attribute" event.
-				_, ok := events[eventAttr]
-				if !ok {
-					return false, nil
-				}
-			} else {
-				foundEvent := false
-
-			loop:
-				for compositeKey := range events {
-					if strings.Index(compositeKey, eventAttr) == 0 {
-						foundEvent = true
-						break loop
-					}
-				}
-				if !foundEvent {
-					return false, nil
-				}
-			}
-
-		case rulevalue:
-			// strip single quotes from value (i.e. "'NewBlock'" -> "NewBlock")
-			valueWithoutSingleQuotes := buffer[begin+1 : end-1]
-
-			// see if the triplet (event attribute, operator, operand) matches any event
-			// "tx.gas", "=", "7", { "tx.gas": 7, "tx.ID": "4AE393495334" }
-			match, err := match(eventAttr, op, reflect.ValueOf(valueWithoutSingleQuotes), events)
-			if err != nil {
-				return false, err
-			}
-
-			if !match {
-				return false, nil
-			}
-
-		case rulenumber:
-			number := buffer[begin:end]
-			if strings.ContainsAny(number, ".") { // if it looks like a floating-point number
-				value, err := strconv.ParseFloat(number, 64)
-				if err != nil {
-					err = fmt.Errorf(
-						"got %v while trying to parse %s as float64 (should never happen if the grammar is correct)",
-						err, number,
-					)
-					return false, err
-				}
-
-				match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-				if err != nil {
-					return false, err
-				}
-
-				if !match {
-					return false, nil
-				}
-			} else {
-				value, err := strconv.ParseInt(number, 10, 64)
-				if err != nil {
-					err = fmt.Errorf(
-						"got %v while trying to parse %s as int64 (should never happen if the grammar is correct)",
-						err, number,
-					)
-					return false, err
-				}
-
-				match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-				if err != nil {
-					return false, err
-				}
-
-				if !match {
-					return false, nil
-				}
-			}
-
-		case ruletime:
-			value, err := time.Parse(TimeLayout, buffer[begin:end])
-			if err != nil {
-				err = fmt.Errorf(
-					"got %v while trying to parse %s as time.Time / RFC3339 (should never happen if the grammar is correct)",
-					err, buffer[begin:end],
-				)
-				return false, err
-			}
-
-			match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-			if err != nil {
-				return false, err
-			}
-
-			if !match {
-				return false, nil
-			}
+	return q.expr.matches(flattenEvents(rawEvents))
+}
 
-		case ruledate:
-			value, err := time.Parse("2006-01-02", buffer[begin:end])
-			if err != nil {
-				err = fmt.Errorf(
-					"got %v while trying to parse %s as time.Time / '2006-01-02' (should never happen if the grammar is correct)",
-					err, buffer[begin:end],
-				)
-				return false, err
-			}
+// matchCondition returns true if the condition matches any value of the
+// events.
+func matchCondition(c Condition, events map[string][]string) (bool, error) {
+	if c.Op != OpExists {
+		// see if the triplet (event attribute, operator, operand) matches any event
+		// "tx.gas", "=", "7", { "tx.gas": 7, "tx.ID": "4AE393495334" }
+		return match(c.CompositeKey, c.Op, reflect.ValueOf(c.Operand), events)
+	}
 
-			match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-			if err != nil {
-				return false, err
-			}
+	if strings.Contains(c.CompositeKey, ".") {
+		// Searching for a full "type.attribute" event.
+		_, ok := events[c.CompositeKey]
+		return ok, nil
+	}
 
-			if !match {
-				return false, nil
-			}
+	for compositeKey := range events {
+		if strings.Index(compositeKey, c.CompositeKey) == 0 {
+			return true, nil
 		}
 	}
-
-	return true, nil
+	return false, nil
 }
 
 // match returns true if the given triplet (attribute, operator, operand) matches
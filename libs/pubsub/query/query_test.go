@@ -173,6 +173,31 @@ func TestMatches(t *testing.T) {
 			false,
 			false,
 		},
+		{"peaches.kg < 4 OR apples.kg > 2", map[string][]string{"peaches.kg": {"5"}, "apples.kg": {"3"}}, false, true, false},
+		{"peaches.kg < 4 OR apples.kg > 3", map[string][]string{"peaches.kg": {"5"}, "apples.kg": {"3"}}, false, false, false},
+		{"NOT peaches.kg < 4", map[string][]string{"peaches.kg": {"5"}}, false, true, false},
+		{"NOT slash.reason EXISTS", map[string][]string{"peaches.kg": {"5"}}, false, true, false},
+		{
+			"tm.event='Tx' AND NOT (transfer.sender='Ivan' OR transfer.amount > 100)",
+			map[string][]string{"tm.event": {"Tx"}, "transfer.sender": {"Igor"}, "transfer.amount": {"50"}},
+			false,
+			true,
+			false,
+		},
+		{
+			"tm.event='Tx' AND NOT (transfer.sender='Ivan' OR transfer.amount > 100)",
+			map[string][]string{"tm.event": {"Tx"}, "transfer.sender": {"Ivan"}, "transfer.amount": {"50"}},
+			false,
+			false,
+			false,
+		},
+		{
+			"tm.event='Tx' AND transfer.sender='Ivan' OR transfer.amount > 100",
+			map[string][]string{"tm.event": {"NewBlock"}, "transfer.amount": {"150"}},
+			false,
+			true,
+			false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -245,3 +270,72 @@ func TestConditions(t *testing.T) {
 		require.Equal(t, tc.conditions, c)
 	}
 }
+
+func TestDisjunction(t *testing.T) {
+	var (
+		typeTx   = query.Condition{CompositeKey: "tm.event", Op: query.OpEqual, Operand: "Tx"}
+		gasHigh  = query.Condition{CompositeKey: "tx.gas", Op: query.OpGreater, Operand: int64(7)}
+		gasLow   = query.Condition{CompositeKey: "tx.gas", Op: query.OpLess, Operand: int64(2)}
+		byIvan   = query.Condition{CompositeKey: "transfer.sender", Op: query.OpEqual, Operand: "Ivan"}
+		slashing = query.Condition{CompositeKey: "slashing", Op: query.OpExists}
+	)
+
+	testCases := []struct {
+		s            string
+		conjunctions []query.Conjunction
+	}{
+		{
+			"tm.event='Tx' AND tx.gas > 7",
+			[]query.Conjunction{{Conditions: []query.Condition{typeTx, gasHigh}}},
+		},
+		{
+			"tx.gas > 7 OR tx.gas < 2",
+			[]query.Conjunction{{Conditions: []query.Condition{gasHigh}}, {Conditions: []query.Condition{gasLow}}},
+		},
+		{
+			"tm.event='Tx' AND (tx.gas > 7 OR tx.gas < 2)",
+			[]query.Conjunction{
+				{Conditions: []query.Condition{typeTx, gasHigh}},
+				{Conditions: []query.Condition{typeTx, gasLow}},
+			},
+		},
+		{
+			"tm.event='Tx' AND NOT (tx.gas > 7 OR tx.gas < 2)",
+			[]query.Conjunction{{Conditions: []query.Condition{typeTx}, Negated: []query.Condition{gasHigh, gasLow}}},
+		},
+		{
+			"NOT (transfer.sender='Ivan' AND slashing EXISTS)",
+			[]query.Conjunction{{Negated: []query.Condition{byIvan}}, {Negated: []query.Condition{slashing}}},
+		},
+		{
+			"NOT NOT slashing EXISTS",
+			[]query.Conjunction{{Conditions: []query.Condition{slashing}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		q, err := query.New(tc.s)
+		require.NoError(t, err)
+
+		d, err := q.Disjunction()
+		require.NoError(t, err)
+		require.Equal(t, tc.conjunctions, d, tc.s)
+	}
+
+	// only the queries without OR and NOT have a list of conditions
+	_, err := query.MustParse("tx.gas > 7 OR tx.gas < 2").Conditions()
+	require.Error(t, err)
+
+	// the expansion of the query is bounded
+	var b strings.Builder
+	for i := 0; i < 8; i++ {
+		if i > 0 {
+			b.WriteString(" AND ")
+		}
+		fmt.Fprintf(&b, "(a.b=%d OR c.d=%d)", i, i)
+	}
+	q, err := query.New(b.String())
+	require.NoError(t, err)
+	_, err = q.Disjunction()
+	require.Error(t, err)
+}
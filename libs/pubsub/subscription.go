@@ -3,6 +3,7 @@ package pubsub
 import (
 	"errors"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 	"github.com/tendermint/tendermint/abci/types"
@@ -24,8 +25,10 @@ var (
 // 2) channel which is closed if a client is too slow or choose to unsubscribe
 // 3) err indicating the reason for (2)
 type Subscription struct {
-	id  string
-	out chan Message
+	id       string
+	out      chan Message
+	overflow Overflow
+	dropped  uint64 // atomic
 
 	canceled chan struct{}
 	mtx      tmsync.RWMutex
@@ -50,6 +53,12 @@ func (s *Subscription) Out() <-chan Message {
 
 func (s *Subscription) ID() string { return s.id }
 
+// Dropped returns the number of messages dropped because the Out channel was
+// full, with the OverflowDropOldest and OverflowDropNewest policies.
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
 // Canceled returns a channel that's closed when the subscription is
 // terminated and supposed to be used in a select statement.
 func (s *Subscription) Canceled() <-chan struct{} {
@@ -88,9 +97,10 @@ func (s *Subscription) cancel(err error) {
 
 // Message glues data and events together.
 type Message struct {
-	subID  string
-	data   interface{}
-	events []types.Event
+	subID   string
+	eventID string
+	data    interface{}
+	events  []types.Event
 }
 
 func NewMessage(subID string, data interface{}, events []types.Event) Message {
@@ -101,10 +111,20 @@ func NewMessage(subID string, data interface{}, events []types.Event) Message {
 	}
 }
 
+func newMessage(subID, eventID string, data interface{}, events []types.Event) Message {
+	msg := NewMessage(subID, data, events)
+	msg.eventID = eventID
+	return msg
+}
+
 // SubscriptionID returns the unique identifier for the subscription
 // that produced this message.
 func (msg Message) SubscriptionID() string { return msg.subID }
 
+// EventID returns the identifier of the published message, which can be
+// passed to Server#SubscribeFrom to resume a subscription after it.
+func (msg Message) EventID() string { return msg.eventID }
+
 // Data returns an original data published.
 func (msg Message) Data() interface{} { return msg.data }
 
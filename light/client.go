@@ -25,6 +25,12 @@ const (
 
 	defaultPruningSize = 1000
 
+	// evidenceSubmissionAttempts is the number of times evidence is sent to a
+	// provider before giving up on it. Retries are spaced by
+	// evidenceRetryBackoff, doubling after each attempt.
+	evidenceSubmissionAttempts = 3
+	evidenceRetryBackoff       = 500 * time.Millisecond
+
 	// For verifySkipping, we need an algorithm to find what height to check
 	// next to see if it has sufficient validator set overlap. The most
 	// intuitive method is to take the halfway point i.e. if you trusted block
@@ -90,6 +96,25 @@ func PruningSize(h uint16) Option {
 	return func(c *Client) { c.pruningSize = h }
 }
 
+// PruningInterval option makes the light client keep, beyond the PruningSize
+// most recent light blocks, every light block at a height that is a multiple
+// of k. This retains a sparse history of trusted headers, which grows by one
+// light block every k heights. Default: 0, which keeps no such light blocks.
+func PruningInterval(k int64) Option {
+	return func(c *Client) { c.pruningInterval = k }
+}
+
+// EvidenceCallback is called once the light client has submitted evidence of
+// an attack to its providers, with one receipt per provider.
+type EvidenceCallback func(ev *types.LightClientAttackEvidence, receipts []EvidenceReceipt)
+
+// OnEvidence option sets a callback, which lets the embedding application know
+// that evidence was filed and to which providers. The callback is called
+// synchronously by the detector and must not call back into the client.
+func OnEvidence(cb EvidenceCallback) Option {
+	return func(c *Client) { c.evidenceCallback = cb }
+}
+
 // Logger option can be used to set a logger for the client.
 func Logger(l log.Logger) Option {
 	return func(c *Client) { c.logger = l }
@@ -147,6 +172,11 @@ type Client struct {
 
 	// See PruningSize option
 	pruningSize uint16
+	// See PruningInterval option
+	pruningInterval int64
+
+	// See OnEvidence option
+	evidenceCallback EvidenceCallback
 
 	logger log.Logger
 }
@@ -216,6 +246,10 @@ func NewClient(
 		return nil, err
 	}
 
+	if c.pruningInterval < 0 {
+		return nil, fmt.Errorf("pruning interval must be non-negative, got %d", c.pruningInterval)
+	}
+
 	// Use the trusted hash and height to fetch the first weakly-trusted block
 	// from the primary provider. Assert that all the witnesses have the same block
 	if err := c.initializeWithTrustOptions(ctx, trustOptions); err != nil {
@@ -263,6 +297,10 @@ func NewClientFromTrustedStore(
 		return nil, err
 	}
 
+	if c.pruningInterval < 0 {
+		return nil, fmt.Errorf("pruning interval must be non-negative, got %d", c.pruningInterval)
+	}
+
 	// Check that the trusted store has at least one block and
 	if err := c.restoreTrustedLightBlock(); err != nil {
 		return nil, err
@@ -486,6 +524,40 @@ func (c *Client) VerifyHeader(ctx context.Context, newHeader *types.Header, now
 	return c.verifyLightBlock(ctx, l, now)
 }
 
+// VerifyHeaderBatch verifies a contiguous range of headers, given in ascending
+// order of height. Rather than verifying each header on its own, it checks
+// that every header is linked to the next one by its hash, and then verifies
+// only the highest header as VerifyHeader does: skipping from the closest
+// trusted light block where possible and cross-checking the result with all
+// witnesses concurrently. As each header commits to the hash of the one before
+// it, this verifies the whole range with a single verification.
+//
+// Only the highest header is saved to the trusted store.
+func (c *Client) VerifyHeaderBatch(ctx context.Context, headers []*types.Header, now time.Time) error {
+	if len(headers) == 0 {
+		return errors.New("empty header batch")
+	}
+	for i, header := range headers {
+		if header == nil {
+			return fmt.Errorf("nil header at index %d", i)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := headers[i-1]
+		if header.Height != prev.Height+1 {
+			return fmt.Errorf("headers are not contiguous: height %d follows height %d",
+				header.Height, prev.Height)
+		}
+		if !bytes.Equal(header.LastBlockID.Hash, prev.Hash()) {
+			return fmt.Errorf("header at height %d does not link to the previous header: expected last block hash %X, got %X",
+				header.Height, prev.Hash(), header.LastBlockID.Hash)
+		}
+	}
+
+	return c.VerifyHeader(ctx, headers[len(headers)-1], now)
+}
+
 func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.LightBlock, now time.Time) error {
 	c.logger.Info("verify light block", "height", newLightBlock.Height, "hash", newLightBlock.Hash())
 
@@ -860,7 +932,7 @@ func (c *Client) updateTrustedLightBlock(l *types.LightBlock) error {
 	}
 
 	if c.pruningSize > 0 {
-		if err := c.trustedStore.Prune(c.pruningSize); err != nil {
+		if err := c.trustedStore.PruneWithInterval(c.pruningSize, c.pruningInterval); err != nil {
 			return fmt.Errorf("prune: %w", err)
 		}
 	}
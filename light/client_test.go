@@ -552,6 +552,41 @@ func TestClient_Update(t *testing.T) {
 	mockFullNode.AssertExpectations(t)
 }
 
+func TestClient_VerifyHeaderBatch(t *testing.T) {
+	mockFullNode := &provider_mocks.Provider{}
+	mockFullNode.On("LightBlock", mock.Anything, int64(1)).Return(l1, nil)
+	mockFullNode.On("LightBlock", mock.Anything, int64(3)).Return(l3, nil)
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		mockFullNode,
+		[]provider.Provider{mockFullNode},
+		dbs.New(dbm.NewMemDB()),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	now := bTime.Add(2 * time.Hour)
+
+	// gaps and broken hash links are rejected before anything is fetched
+	require.Error(t, c.VerifyHeaderBatch(ctx, nil, now))
+	require.Error(t, c.VerifyHeaderBatch(ctx, []*types.Header{h1.Header, h3.Header}, now))
+	unlinked := *h3.Header
+	unlinked.LastBlockID = types.BlockID{Hash: hash("other")}
+	require.Error(t, c.VerifyHeaderBatch(ctx, []*types.Header{h2.Header, &unlinked}, now))
+
+	// only the highest header is verified and stored
+	require.NoError(t, c.VerifyHeaderBatch(ctx, []*types.Header{h1.Header, h2.Header, h3.Header}, now))
+	l, err := c.TrustedLightBlock(3)
+	require.NoError(t, err)
+	assert.EqualValues(t, h3.Hash(), l.Hash())
+	_, err = c.TrustedLightBlock(2)
+	assert.Error(t, err)
+
+	mockFullNode.AssertExpectations(t)
+}
+
 func TestClient_Concurrency(t *testing.T) {
 	mockFullNode := &provider_mocks.Provider{}
 	mockFullNode.On("LightBlock", mock.Anything, int64(2)).Return(l2, nil)
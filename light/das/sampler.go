@@ -0,0 +1,535 @@
+package das
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	mrand "math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/internal/share"
+	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/pkg/da"
+	shproto "github.com/tendermint/tendermint/proto/tendermint/share"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	defaultSamplesPerHeight = 16
+	defaultSampleInterval   = time.Second
+
+	// fetchTimeout is the time a peer has to serve a share sampled.
+	fetchTimeout = 10 * time.Second
+
+	// statusHistory is the number of most recent heights whose status is kept.
+	statusHistory = 1000
+)
+
+// ErrHeightNotSampled is returned when the status of a height that was not
+// sampled, or whose status was dropped, is requested.
+var ErrHeightNotSampled = errors.New("height not sampled")
+
+// Client is a source of the data availability headers of the blocks sampled,
+// such as the RPC client of a full node. It doesn't need to be trusted, the
+// headers being verified against the data hash of the verified headers.
+type Client interface {
+	DataAvailabilityHeader(ctx context.Context, height *int64) (*coretypes.ResultDataAvailabilityHeader, error)
+}
+
+// ShareFetcher fetches the shares sampled from the connected peers, such as
+// the share reactor of a node.
+type ShareFetcher interface {
+	// Peers returns the peers connected.
+	Peers() []types.NodeID
+	// FetchShares requests the shares of req from the peer, and returns them
+	// once verified against dah.
+	FetchShares(ctx context.Context, peer types.NodeID, dah *da.DataAvailabilityHeader,
+		req *shproto.SharesRequest) (*shproto.SharesResponse, error)
+	// BroadcastBadEncodingProof gossips the bad encoding proof to the peers.
+	BroadcastBadEncodingProof(proof *da.BadEncodingProof)
+}
+
+// LightClient is the functionality needed by Sampler from the light client,
+// to get the verified headers of the blocks to sample.
+type LightClient interface {
+	Update(ctx context.Context, now time.Time) (*types.LightBlock, error)
+	LastTrustedHeight() (int64, error)
+	VerifyLightBlockAtHeight(ctx context.Context, height int64, now time.Time) (*types.LightBlock, error)
+}
+
+// Sampler verifies the availability of the data of the blocks verified by the
+// light client, without downloading it: for each height, it samples random
+// shares of the extended data square from the connected peers and verifies
+// them against the row and column roots of the data availability header
+// committed to in the header.
+//
+// The heights which can't be sampled are reported as failed and are retried at
+// each interval, until sampled or dropped from the status history.
+//
+// If the data of a block can't be recovered, at least a quarter of the shares
+// is missing, so that each sample independently catches the withholding with
+// a probability of at least 1/4. See Confidence.
+//
+// The data must also be erasure coded for the shares sampled to make it
+// recoverable, which partial nodes check, see CheckEncoding, gossiping a bad
+// encoding proof otherwise. The Sampler halts on the valid proofs, see
+// HandleBadEncodingProof.
+type Sampler struct {
+	service.BaseService
+
+	lc      LightClient
+	clients []Client
+	shares  ShareFetcher
+
+	samplesPerHeight int
+	sampleInterval   time.Duration
+	checkEncoding    bool
+
+	// rand is used by the sampling routine and HandleBadEncodingProof.
+	randMtx sync.Mutex
+	rand    *mrand.Rand
+	cancel  context.CancelFunc
+
+	mtx      sync.RWMutex
+	statuses map[int64]coretypes.DASHeightStatus
+	// lastSampled is the height up to which all the heights were sampled
+	// successfully, since the first one sampled.
+	lastSampled int64
+	// badEncoding is the bad encoding proof the Sampler halted on, closing
+	// halted.
+	badEncoding *da.BadEncodingProof
+	halted      chan struct{}
+}
+
+// Option sets a parameter of the Sampler.
+type Option func(*Sampler)
+
+// SamplesPerHeight sets the number of shares sampled for each height. The
+// default is 16.
+func SamplesPerHeight(n int) Option {
+	return func(s *Sampler) {
+		s.samplesPerHeight = n
+	}
+}
+
+// SampleInterval sets the interval at which the light client is updated to
+// sample the new heights. The default is 1s.
+func SampleInterval(d time.Duration) Option {
+	return func(s *Sampler) {
+		s.sampleInterval = d
+	}
+}
+
+// CheckEncoding makes the Sampler download the whole extended data square of
+// each height, as partial nodes do, to check that it is erasure coded. If it
+// isn't, the Sampler gossips the bad encoding proof and halts.
+func CheckEncoding() Option {
+	return func(s *Sampler) {
+		s.checkEncoding = true
+	}
+}
+
+// NewSampler returns a Sampler of the blocks verified by lc, getting their
+// data availability headers from clients and fetching their shares from
+// shares.
+func NewSampler(lc LightClient, clients []Client, shares ShareFetcher, logger log.Logger,
+	opts ...Option) *Sampler {
+	s := &Sampler{
+		lc:               lc,
+		clients:          clients,
+		shares:           shares,
+		samplesPerHeight: defaultSamplesPerHeight,
+		sampleInterval:   defaultSampleInterval,
+		rand:             tmrand.NewRand(),
+		statuses:         make(map[int64]coretypes.DASHeightStatus),
+		halted:           make(chan struct{}),
+	}
+	s.BaseService = *service.NewBaseService(logger, "Sampler", s)
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+// OnStart implements service.Service by starting the routine sampling the new
+// heights.
+func (s *Sampler) OnStart() error {
+	if len(s.clients) == 0 {
+		return errors.New("no client to get the data availability headers from")
+	}
+	if s.samplesPerHeight <= 0 {
+		return fmt.Errorf("samples per height must be positive, got %d", s.samplesPerHeight)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mtx.Lock()
+	s.cancel = cancel
+	s.mtx.Unlock()
+	go s.sampleRoutine(ctx)
+	return nil
+}
+
+// OnStop implements service.Service.
+func (s *Sampler) OnStop() {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// Halted returns a channel closed when the Sampler halts on a bad encoding
+// proof.
+func (s *Sampler) Halted() <-chan struct{} {
+	return s.halted
+}
+
+// BadEncodingProof returns the bad encoding proof the Sampler halted on, or
+// nil.
+func (s *Sampler) BadEncodingProof() *da.BadEncodingProof {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.badEncoding
+}
+
+// HandleBadEncodingProof verifies a bad encoding proof received from a peer
+// against the data availability header of the verified header of its height,
+// and halts the Sampler if the proof is valid. It is a
+// share.BadEncodingProofHandler, returning share.ErrUnknownHeight if the
+// header can't be verified.
+func (s *Sampler) HandleBadEncodingProof(proof *da.BadEncodingProof) error {
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	lb, err := s.lc.VerifyLightBlockAtHeight(ctx, proof.Height, time.Now())
+	if err != nil {
+		return fmt.Errorf("%w %d: %v", share.ErrUnknownHeight, proof.Height, err)
+	}
+	dah, err := s.dataAvailabilityHeader(ctx, lb.Header)
+	if err != nil {
+		return fmt.Errorf("%w %d: %v", share.ErrUnknownHeight, proof.Height, err)
+	}
+	if err := proof.Verify(dah); err != nil {
+		return err
+	}
+	s.halt(proof)
+	return nil
+}
+
+// halt stops sampling on the valid bad encoding proof.
+func (s *Sampler) halt(proof *da.BadEncodingProof) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.badEncoding != nil {
+		return
+	}
+	s.Logger.Error("halting on a bad encoding proof: the data of the block is not erasure coded",
+		"height", proof.Height, "is_row", proof.IsRow, "index", proof.Index)
+	s.badEncoding = proof
+	close(s.halted)
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// Status returns the status of the sampling of the given height, or of all the
+// recent heights sampled if height is nil.
+func (s *Sampler) Status(height *int64) (*coretypes.ResultDASStatus, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	res := &coretypes.ResultDASStatus{
+		SamplesPerHeight: s.samplesPerHeight,
+		Heights:          []coretypes.DASHeightStatus{},
+		BadEncodingProof: s.badEncoding,
+	}
+	if height != nil {
+		status, ok := s.statuses[*height]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrHeightNotSampled, *height)
+		}
+		res.Heights = append(res.Heights, status)
+		return res, nil
+	}
+
+	for _, status := range s.statuses {
+		res.Heights = append(res.Heights, status)
+	}
+	sort.Slice(res.Heights, func(i, j int) bool {
+		return res.Heights[i].Height < res.Heights[j].Height
+	})
+	return res, nil
+}
+
+func (s *Sampler) sampleRoutine(ctx context.Context) {
+	ticker := time.NewTicker(s.sampleInterval)
+	defer ticker.Stop()
+
+	for {
+		s.sampleNewHeights(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sampleNewHeights updates the light client and samples the heights verified
+// since the last one sampled, retrying the ones which failed. When starting,
+// only the latest height is sampled.
+func (s *Sampler) sampleNewHeights(ctx context.Context) {
+	if _, err := s.lc.Update(ctx, time.Now()); err != nil {
+		s.Logger.Error("failed to update the light client", "err", err)
+		return
+	}
+	latest, err := s.lc.LastTrustedHeight()
+	if err != nil || latest <= 0 {
+		return
+	}
+
+	s.mtx.Lock()
+	switch {
+	case s.lastSampled == 0:
+		s.lastSampled = latest - 1
+	case s.lastSampled < latest-statusHistory:
+		// the heights failing for the whole status history are given up
+		s.lastSampled = latest - statusHistory
+	}
+	from := s.lastSampled + 1
+	s.mtx.Unlock()
+
+	for height := from; height <= latest; height++ {
+		if s.sampled(height) {
+			continue
+		}
+
+		var status coretypes.DASHeightStatus
+		lb, err := s.lc.VerifyLightBlockAtHeight(ctx, height, time.Now())
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			status = coretypes.DASHeightStatus{
+				Height: height,
+				Error:  fmt.Sprintf("failed to verify the header: %v", err),
+			}
+		} else {
+			status = s.sampleHeight(ctx, lb.Header)
+			if ctx.Err() != nil {
+				return
+			}
+		}
+
+		if status.Error != "" {
+			// retried at the next interval
+			s.Logger.Error("failed to sample the data", "height", height, "err", status.Error)
+		} else {
+			s.Logger.Debug("sampled data", "height", height, "samples", status.Samples,
+				"confidence", status.Confidence)
+		}
+		s.setStatus(status)
+	}
+}
+
+// sampled returns whether the height was sampled successfully.
+func (s *Sampler) sampled(height int64) bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	status, ok := s.statuses[height]
+	return ok && !status.Failed
+}
+
+// sampleHeight samples the shares of the block of the verified header and
+// returns the outcome.
+func (s *Sampler) sampleHeight(ctx context.Context, header *types.Header) coretypes.DASHeightStatus {
+	status := coretypes.DASHeightStatus{Height: header.Height}
+
+	dah, err := s.dataAvailabilityHeader(ctx, header)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	width := uint64(len(dah.RowsRoots))
+	status.SquareSize = width / 2
+
+	for _, coords := range s.coordinates(width) {
+		if err := s.sampleShare(ctx, header.Height, dah, coords[0], coords[1]); err != nil {
+			status.Error = err.Error()
+			return status
+		}
+		status.Samples++
+	}
+	status.Confidence = Confidence(status.SquareSize, status.Samples)
+
+	if s.checkEncoding {
+		proof, err := s.proveBadEncoding(ctx, header.Height, dah)
+		if err != nil {
+			status.Error = err.Error()
+			return status
+		}
+		if proof != nil {
+			s.shares.BroadcastBadEncodingProof(proof)
+			s.halt(proof)
+			status.Error = "the data is not erasure coded"
+		}
+	}
+	return status
+}
+
+// proveBadEncoding downloads the rows of the extended data square of the
+// height, verified against dah, and returns the bad encoding proof of the
+// square, or nil if it is erasure coded.
+func (s *Sampler) proveBadEncoding(ctx context.Context, height int64,
+	dah *da.DataAvailabilityHeader) (*da.BadEncodingProof, error) {
+	width := uint64(len(dah.RowsRoots))
+	batch := share.MaxRequestShares / width
+	square := make([][]byte, 0, width*width)
+	for first := uint64(0); first < width; first += batch {
+		req := &shproto.SharesRequest{Height: height}
+		for row := first; row < first+batch && row < width; row++ {
+			req.Rows = append(req.Rows, row)
+		}
+		res, err := s.fetch(ctx, dah, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch rows %d to %d: %w", first, req.Rows[len(req.Rows)-1], err)
+		}
+		for _, row := range res.Rows {
+			square = append(square, row.Shares...)
+		}
+	}
+	return da.ProveBadEncoding(height, dah, square)
+}
+
+// dataAvailabilityHeader returns the data availability header whose hash is
+// the data hash of header, from the first client returning it.
+func (s *Sampler) dataAvailabilityHeader(ctx context.Context,
+	header *types.Header) (*da.DataAvailabilityHeader, error) {
+	var err error
+	for _, i := range s.perm(len(s.clients)) {
+		var res *coretypes.ResultDataAvailabilityHeader
+		res, err = s.clients[i].DataAvailabilityHeader(ctx, &header.Height)
+		if err != nil {
+			continue
+		}
+		dah := &res.DataAvailabilityHeader
+		if err = dah.ValidateBasic(); err != nil {
+			continue
+		}
+		if !bytes.Equal(dah.Hash(), header.DataHash) {
+			err = fmt.Errorf("data availability header %X does not match with data hash %X",
+				dah.Hash(), header.DataHash)
+			continue
+		}
+		return dah, nil
+	}
+	return nil, fmt.Errorf("failed to get the data availability header: %w", err)
+}
+
+// sampleShare fetches the share at the coordinates (row, col), from the first
+// peer serving it with a valid proof.
+func (s *Sampler) sampleShare(ctx context.Context, height int64, dah *da.DataAvailabilityHeader,
+	row, col uint64) error {
+	req := &shproto.SharesRequest{
+		Height:      height,
+		Coordinates: []shproto.Coordinate{{Row: row, Col: col}},
+	}
+	if _, err := s.fetch(ctx, dah, req); err != nil {
+		return fmt.Errorf("failed to sample share (%d, %d): %w", row, col, err)
+	}
+	return nil
+}
+
+// fetch fetches the shares of req from the first peer serving them, verified
+// against dah.
+func (s *Sampler) fetch(ctx context.Context, dah *da.DataAvailabilityHeader,
+	req *shproto.SharesRequest) (*shproto.SharesResponse, error) {
+	peers := s.shares.Peers()
+	if len(peers) == 0 {
+		return nil, errors.New("no peer connected")
+	}
+
+	var err error
+	for _, i := range s.perm(len(peers)) {
+		fetchCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+		var res *shproto.SharesResponse
+		res, err = s.shares.FetchShares(fetchCtx, peers[i], dah, req)
+		cancel()
+		if err == nil {
+			return res, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	return nil, err
+}
+
+// coordinates returns distinct random coordinates of the square of the given
+// width, as many as the samples per height, or all of them if there are not
+// as many.
+func (s *Sampler) coordinates(width uint64) [][2]uint64 {
+	n := s.samplesPerHeight
+	if uint64(n) > width*width {
+		n = int(width * width)
+	}
+	coords := make([][2]uint64, 0, n)
+	for _, i := range s.perm(int(width * width))[:n] {
+		coords = append(coords, [2]uint64{uint64(i) / width, uint64(i) % width})
+	}
+	return coords
+}
+
+// perm returns a random permutation of [0, n).
+func (s *Sampler) perm(n int) []int {
+	s.randMtx.Lock()
+	defer s.randMtx.Unlock()
+	return s.rand.Perm(n)
+}
+
+// setStatus records the outcome of an attempt to sample a height, and moves
+// lastSampled past the heights sampled successfully.
+func (s *Sampler) setStatus(status coretypes.DASHeightStatus) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	status.Failed = status.Error != ""
+	status.Attempts = s.statuses[status.Height].Attempts + 1
+	s.statuses[status.Height] = status
+	for height := range s.statuses {
+		if height <= status.Height-statusHistory {
+			delete(s.statuses, height)
+		}
+	}
+	for {
+		next, ok := s.statuses[s.lastSampled+1]
+		if !ok || next.Failed {
+			break
+		}
+		s.lastSampled++
+	}
+}
+
+// Confidence returns the probability that the data of a square of the given
+// size is available, after verifying the given number of samples: for the
+// data to be unrecoverable, at least (k+1)^2 of the (2k)^2 shares of the
+// extended square must be withheld, so that each sample misses the withholding
+// with a probability of at most 1-(k+1)^2/(2k)^2.
+func Confidence(squareSize uint64, samples int) float64 {
+	if squareSize == 0 {
+		return 0
+	}
+	k := float64(squareSize)
+	miss := 1 - (k+1)*(k+1)/(4*k*k)
+	return 1 - math.Pow(miss, float64(samples))
+}
@@ -0,0 +1,350 @@
+package das
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/da"
+	shproto "github.com/tendermint/tendermint/proto/tendermint/share"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
+)
+
+// testClient serves the shares of an extended data square, except the
+// withheld ones, as the data availability header client and as a peer.
+type testClient struct {
+	dah      da.DataAvailabilityHeader
+	square   [][]byte
+	proofs   map[[2]uint64]da.ShareProof
+	withheld map[[2]uint64]bool
+	tampered bool
+	// unavailable heights are withheld entirely
+	unavailable map[int64]bool
+	// broadcast are the bad encoding proofs broadcast to the peer
+	broadcast []*da.BadEncodingProof
+}
+
+func newTestClient(t *testing.T, squareSize uint64) *testClient {
+	shares := make([][]byte, squareSize*squareSize)
+	for i := range shares {
+		nID := []byte{0, 0, 0, 0, 0, 0, 1, byte(i)}
+		shares[i] = append(nID, bytes.Repeat([]byte{byte(i)}, consts.MsgShareSize)...)
+	}
+	eds, err := da.ExtendShares(squareSize, shares)
+	require.NoError(t, err)
+
+	c := &testClient{
+		dah:         da.NewDataAvailabilityHeader(eds),
+		proofs:      make(map[[2]uint64]da.ShareProof),
+		withheld:    make(map[[2]uint64]bool),
+		unavailable: make(map[int64]bool),
+	}
+	for i := uint(0); i < eds.Width(); i++ {
+		c.square = append(c.square, eds.Row(i)...)
+	}
+	for row := uint64(0); row < 2*squareSize; row++ {
+		for col := uint64(0); col < 2*squareSize; col++ {
+			proof, err := da.ProveShare(eds, row, col)
+			require.NoError(t, err)
+			c.proofs[[2]uint64{row, col}] = proof
+		}
+	}
+	return c
+}
+
+func (c *testClient) DataAvailabilityHeader(
+	ctx context.Context,
+	height *int64,
+) (*coretypes.ResultDataAvailabilityHeader, error) {
+	return &coretypes.ResultDataAvailabilityHeader{Height: *height, DataAvailabilityHeader: c.dah}, nil
+}
+
+// fetch serves the shares of req, verified against dah as the share reactor
+// does.
+func (c *testClient) fetch(dah *da.DataAvailabilityHeader,
+	req *shproto.SharesRequest) (*shproto.SharesResponse, error) {
+	res := &shproto.SharesResponse{Id: req.Id, Height: req.Height}
+	width := uint64(len(c.dah.RowsRoots))
+	for _, index := range req.Rows {
+		if c.unavailable[req.Height] {
+			return nil, errors.New("withheld")
+		}
+		shares := c.square[index*width : (index+1)*width]
+		if err := da.VerifyRow(dah, index, shares); err != nil {
+			return nil, err
+		}
+		res.Rows = append(res.Rows, shproto.Axis{Index: index, Shares: shares})
+	}
+	for _, coord := range req.Coordinates {
+		if c.unavailable[req.Height] || c.withheld[[2]uint64{coord.Row, coord.Col}] {
+			return nil, errors.New("withheld")
+		}
+		proof := c.proofs[[2]uint64{coord.Row, coord.Col}]
+		if c.tampered {
+			proof.Share = bytes.Repeat([]byte{0xFF}, consts.ShareSize)
+		}
+		if err := proof.Verify(dah); err != nil {
+			return nil, err
+		}
+		res.Shares = append(res.Shares, shproto.Share{
+			Row:      proof.Row,
+			Col:      proof.Col,
+			Data:     proof.Share,
+			RowNodes: proof.RowNodes,
+			ColNodes: proof.ColNodes,
+		})
+	}
+	return res, nil
+}
+
+// tamperSquare tampers with the last parity share of the square, and commits
+// to it as a byzantine block producer would.
+func (c *testClient) tamperSquare(t *testing.T) {
+	c.square[len(c.square)-1] = bytes.Repeat([]byte{0xFF}, consts.ShareSize)
+	dah, err := da.SquareDataAvailabilityHeader(c.square)
+	require.NoError(t, err)
+	c.dah = dah
+}
+
+// testPeers is a ShareFetcher of the shares served by each peer.
+type testPeers map[types.NodeID]*testClient
+
+func (p testPeers) Peers() []types.NodeID {
+	peers := make([]types.NodeID, 0, len(p))
+	for peer := range p {
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+func (p testPeers) FetchShares(
+	ctx context.Context,
+	peer types.NodeID,
+	dah *da.DataAvailabilityHeader,
+	req *shproto.SharesRequest,
+) (*shproto.SharesResponse, error) {
+	return p[peer].fetch(dah, req)
+}
+
+func (p testPeers) BroadcastBadEncodingProof(proof *da.BadEncodingProof) {
+	for _, c := range p {
+		c.broadcast = append(c.broadcast, proof)
+	}
+}
+
+// testLightClient verifies the headers of the blocks up to latest, whose data
+// is the square of client.
+type testLightClient struct {
+	latest int64
+	client *testClient
+}
+
+func (lc *testLightClient) Update(ctx context.Context, now time.Time) (*types.LightBlock, error) {
+	return nil, nil
+}
+
+func (lc *testLightClient) LastTrustedHeight() (int64, error) {
+	return lc.latest, nil
+}
+
+func (lc *testLightClient) VerifyLightBlockAtHeight(
+	ctx context.Context,
+	height int64,
+	now time.Time,
+) (*types.LightBlock, error) {
+	header := &types.Header{Height: height, DataHash: lc.client.dah.Hash()}
+	return &types.LightBlock{SignedHeader: &types.SignedHeader{Header: header}}, nil
+}
+
+func TestSamplerSampleHeight(t *testing.T) {
+	const squareSize = 4
+	ctx := context.Background()
+
+	testCases := []struct {
+		name      string
+		samples   int
+		peers     func(*testClient) testPeers
+		available bool
+	}{
+		{"available", 8, func(c *testClient) testPeers {
+			return testPeers{"a": c}
+		}, true},
+		{"more samples than shares", 100, func(c *testClient) testPeers {
+			return testPeers{"a": c}
+		}, true},
+		{"share withheld", 4 * squareSize * squareSize, func(c *testClient) testPeers {
+			c.withheld[[2]uint64{5, 2}] = true
+			return testPeers{"a": c}
+		}, false},
+		{"share withheld by a peer only", 4 * squareSize * squareSize, func(c *testClient) testPeers {
+			withholding := *c
+			withholding.withheld = map[[2]uint64]bool{{5, 2}: true}
+			return testPeers{"a": &withholding, "b": c}
+		}, true},
+		{"invalid shares", 8, func(c *testClient) testPeers {
+			c.tampered = true
+			return testPeers{"a": c}
+		}, false},
+		{"no peer", 8, func(c *testClient) testPeers {
+			return testPeers{}
+		}, false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestClient(t, squareSize)
+			header := &types.Header{Height: 3, DataHash: c.dah.Hash()}
+
+			s := NewSampler(nil, []Client{c}, tc.peers(c), log.TestingLogger(), SamplesPerHeight(tc.samples))
+			status := s.sampleHeight(ctx, header)
+			assert.EqualValues(t, 3, status.Height)
+			assert.EqualValues(t, squareSize, status.SquareSize)
+			if tc.available {
+				assert.Empty(t, status.Error)
+				assert.Equal(t, tmin(tc.samples, 4*squareSize*squareSize), status.Samples)
+				assert.Equal(t, Confidence(squareSize, status.Samples), status.Confidence)
+			} else {
+				assert.NotEmpty(t, status.Error)
+				assert.Zero(t, status.Confidence)
+			}
+		})
+	}
+
+	// the data availability header must match the data hash
+	c := newTestClient(t, squareSize)
+	s := NewSampler(nil, []Client{c}, testPeers{"a": c}, log.TestingLogger())
+	status := s.sampleHeight(ctx, &types.Header{Height: 3, DataHash: make([]byte, 32)})
+	assert.Contains(t, status.Error, "does not match")
+	assert.Zero(t, status.Samples)
+}
+
+func TestSamplerRetriesFailedHeights(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, 2)
+	lc := &testLightClient{latest: 3, client: c}
+	s := NewSampler(lc, []Client{c}, testPeers{"a": c}, log.TestingLogger())
+
+	status := func(height int64) coretypes.DASHeightStatus {
+		res, err := s.Status(&height)
+		require.NoError(t, err)
+		return res.Heights[0]
+	}
+
+	// only the latest height is sampled when starting
+	s.sampleNewHeights(ctx)
+	res, err := s.Status(nil)
+	require.NoError(t, err)
+	require.Len(t, res.Heights, 1)
+	assert.False(t, status(3).Failed)
+
+	// the failed heights are reported, and don't hold back the next ones
+	lc.latest = 6
+	c.unavailable[4] = true
+	s.sampleNewHeights(ctx)
+	assert.True(t, status(4).Failed)
+	assert.NotEmpty(t, status(4).Error)
+	assert.False(t, status(5).Failed)
+	assert.False(t, status(6).Failed)
+	assert.EqualValues(t, 3, s.lastSampled)
+
+	s.sampleNewHeights(ctx)
+	assert.Equal(t, 2, status(4).Attempts)
+	assert.Equal(t, 1, status(5).Attempts)
+
+	// the failed heights are retried until they succeed
+	c.unavailable[4] = false
+	s.sampleNewHeights(ctx)
+	assert.False(t, status(4).Failed)
+	assert.Empty(t, status(4).Error)
+	assert.Equal(t, 3, status(4).Attempts)
+	assert.Equal(t, 1, status(6).Attempts)
+	assert.EqualValues(t, 6, s.lastSampled)
+}
+
+func TestSamplerStatus(t *testing.T) {
+	s := NewSampler(nil, nil, nil, log.TestingLogger())
+	for height := int64(1); height <= statusHistory+2; height++ {
+		s.setStatus(coretypes.DASHeightStatus{Height: height, Samples: 1})
+	}
+
+	res, err := s.Status(nil)
+	require.NoError(t, err)
+	require.Len(t, res.Heights, statusHistory)
+	assert.EqualValues(t, 3, res.Heights[0].Height)
+	assert.EqualValues(t, statusHistory+2, res.Heights[statusHistory-1].Height)
+	assert.Equal(t, defaultSamplesPerHeight, res.SamplesPerHeight)
+
+	height := int64(10)
+	res, err = s.Status(&height)
+	require.NoError(t, err)
+	require.Len(t, res.Heights, 1)
+	assert.EqualValues(t, 10, res.Heights[0].Height)
+
+	height = 2
+	_, err = s.Status(&height)
+	require.ErrorIs(t, err, ErrHeightNotSampled)
+}
+
+func TestSamplerCheckEncoding(t *testing.T) {
+	ctx := context.Background()
+
+	// the whole square of an erasure coded block is downloaded and checked
+	c := newTestClient(t, 4)
+	s := NewSampler(nil, []Client{c}, testPeers{"a": c}, log.TestingLogger(), CheckEncoding())
+	status := s.sampleHeight(ctx, &types.Header{Height: 3, DataHash: c.dah.Hash()})
+	assert.Empty(t, status.Error)
+	assert.Empty(t, c.broadcast)
+	assert.Nil(t, s.BadEncodingProof())
+
+	// the square of a byzantine block producer isn't erasure coded
+	tampered := newTestClient(t, 4)
+	tampered.tamperSquare(t)
+	s = NewSampler(nil, []Client{tampered}, testPeers{"a": tampered}, log.TestingLogger(), CheckEncoding())
+	proof, err := s.proveBadEncoding(ctx, 3, &tampered.dah)
+	require.NoError(t, err)
+	require.NotNil(t, proof)
+	require.NoError(t, proof.Verify(&tampered.dah))
+
+	// which is proven to the light clients of the chain, halting them
+	lc := &testLightClient{latest: 3, client: tampered}
+	s = NewSampler(lc, []Client{tampered}, testPeers{}, log.TestingLogger())
+	require.NoError(t, s.HandleBadEncodingProof(proof))
+	select {
+	case <-s.Halted():
+	default:
+		t.Fatal("sampler not halted")
+	}
+	res, err := s.Status(nil)
+	require.NoError(t, err)
+	assert.Equal(t, proof, res.BadEncodingProof)
+
+	// but not to the ones of an honest chain
+	lc = &testLightClient{latest: 3, client: c}
+	s = NewSampler(lc, []Client{c}, testPeers{}, log.TestingLogger())
+	require.Error(t, s.HandleBadEncodingProof(proof))
+	assert.Nil(t, s.BadEncodingProof())
+}
+
+func TestConfidence(t *testing.T) {
+	assert.Zero(t, Confidence(4, 0))
+	assert.Zero(t, Confidence(0, 10))
+	// each sample misses with a probability of at most 1-25/64 in a 4x4 square
+	assert.InDelta(t, 1-(39.0/64)*(39.0/64), Confidence(4, 2), 1e-9)
+	assert.Greater(t, Confidence(128, 16), 0.99)
+	assert.Greater(t, Confidence(4, 16), Confidence(4, 15))
+}
+
+func tmin(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/tendermint/tendermint/light/provider"
@@ -21,7 +22,7 @@ import (
 // witness providers that the light client is connected to. If a conflicting header
 // is returned it verifies and examines the conflicting header against the verified
 // trace that was produced from the primary. If successful, it produces two sets of evidence
-// and sends them to the opposite provider before halting.
+// and sends them to the primary and all witnesses before halting.
 //
 // If there are no conflictinge headers, the light client deems the verified target header
 // trusted and saves it to the trusted store.
@@ -202,11 +203,86 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 	errc <- nil
 }
 
-// sendEvidence sends evidence to a provider on a best effort basis.
-func (c *Client) sendEvidence(ctx context.Context, ev *types.LightClientAttackEvidence, receiver provider.Provider) {
-	err := receiver.ReportEvidence(ctx, ev)
-	if err != nil {
-		c.logger.Error("failed to report evidence to provider", "ev", ev, "provider", receiver)
+// EvidenceReceipt records the submission of evidence to a single provider.
+type EvidenceReceipt struct {
+	// Provider is the description of the provider.
+	Provider string
+	// Attempts is the number of times the evidence was sent to the provider.
+	Attempts int
+	// Time is when the last attempt completed.
+	Time time.Time
+	// Err is the error of the last attempt, nil if the provider accepted the
+	// evidence.
+	Err error
+}
+
+// Accepted returns whether the provider accepted the evidence.
+func (r EvidenceReceipt) Accepted() bool {
+	return r.Err == nil
+}
+
+// sendEvidence submits evidence to the primary and all witnesses, since it is
+// unknown which of them are honest. Each provider is tried up to
+// evidenceSubmissionAttempts times. Once all submissions are done, the
+// receipts are passed to the evidence callback, if any.
+//
+// CONTRACT: the provider mutex is held.
+func (c *Client) sendEvidence(ctx context.Context, ev *types.LightClientAttackEvidence) []EvidenceReceipt {
+	receivers := append([]provider.Provider{c.primary}, c.witnesses...)
+	receipts := make([]EvidenceReceipt, len(receivers))
+	for i, receiver := range receivers {
+		receipts[i].Provider = fmt.Sprint(receiver)
+	}
+
+	var wg sync.WaitGroup
+	for i, receiver := range receivers {
+		wg.Add(1)
+		go func(receipt *EvidenceReceipt, receiver provider.Provider) {
+			defer wg.Done()
+			c.submitEvidence(ctx, ev, receiver, receipt)
+		}(&receipts[i], receiver)
+	}
+	wg.Wait()
+
+	accepted := 0
+	for _, receipt := range receipts {
+		if receipt.Accepted() {
+			accepted++
+		} else {
+			c.logger.Error("failed to report evidence to provider", "ev", ev, "provider", receipt.Provider,
+				"attempts", receipt.Attempts, "err", receipt.Err)
+		}
+	}
+	c.logger.Info("reported evidence", "ev", ev, "accepted", accepted, "providers", len(receipts))
+
+	if c.evidenceCallback != nil {
+		c.evidenceCallback(ev, receipts)
+	}
+	return receipts
+}
+
+// submitEvidence reports evidence to a single provider, retrying with an
+// exponential backoff until it is accepted, the attempts are exhausted or the
+// context is done. The outcome is recorded in receipt.
+func (c *Client) submitEvidence(
+	ctx context.Context,
+	ev *types.LightClientAttackEvidence,
+	receiver provider.Provider,
+	receipt *EvidenceReceipt,
+) {
+	for attempt := 1; attempt <= evidenceSubmissionAttempts; attempt++ {
+		receipt.Attempts = attempt
+		receipt.Err = receiver.ReportEvidence(ctx, ev)
+		receipt.Time = time.Now()
+		if receipt.Err == nil || attempt == evidenceSubmissionAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(evidenceRetryBackoff << (attempt - 1)):
+		}
 	}
 }
 
@@ -238,7 +314,7 @@ func (c *Client) handleConflictingHeaders(
 	evidenceAgainstPrimary := newLightClientAttackEvidence(primaryBlock, trustedBlock, commonBlock)
 	c.logger.Error("ATTEMPTED ATTACK DETECTED. Sending evidence againt primary by witness", "ev", evidenceAgainstPrimary,
 		"primary", c.primary, "witness", supportingWitness)
-	c.sendEvidence(ctx, evidenceAgainstPrimary, supportingWitness)
+	c.sendEvidence(ctx, evidenceAgainstPrimary)
 
 	if primaryBlock.Commit.Round != witnessTrace[len(witnessTrace)-1].Commit.Round {
 		c.logger.Info("The light client has detected, and prevented, an attempted amnesia attack." +
@@ -266,7 +342,7 @@ func (c *Client) handleConflictingHeaders(
 	evidenceAgainstWitness := newLightClientAttackEvidence(witnessBlock, trustedBlock, commonBlock)
 	c.logger.Error("Sending evidence against witness by primary", "ev", evidenceAgainstWitness,
 		"primary", c.primary, "witness", supportingWitness)
-	c.sendEvidence(ctx, evidenceAgainstWitness, c.primary)
+	c.sendEvidence(ctx, evidenceAgainstWitness)
 	// We return the error and don't process anymore witnesses
 	return ErrLightClientAttack
 }
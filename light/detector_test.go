@@ -2,6 +2,7 @@ package light_test
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 	"time"
 
@@ -77,6 +78,13 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		return bytes.Equal(evidence.Hash(), evAgainstWitness.Hash())
 	})).Return(nil)
 
+	// evidence is sent to all providers: the primary fails to accept the
+	// evidence against itself once and the submission is retried
+	mockPrimary.On("ReportEvidence", mock.Anything, mock.Anything).Return(errors.New("unavailable")).Once()
+	mockPrimary.On("ReportEvidence", mock.Anything, mock.Anything).Return(nil)
+	mockWitness.On("ReportEvidence", mock.Anything, mock.Anything).Return(nil)
+
+	var receipts [][]light.EvidenceReceipt
 	c, err := light.NewClient(
 		ctx,
 		chainID,
@@ -89,6 +97,9 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		[]provider.Provider{mockWitness},
 		dbs.New(dbm.NewMemDB()),
 		light.Logger(log.TestingLogger()),
+		light.OnEvidence(func(_ *types.LightClientAttackEvidence, r []light.EvidenceReceipt) {
+			receipts = append(receipts, r)
+		}),
 	)
 	require.NoError(t, err)
 
@@ -98,6 +109,16 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		assert.Equal(t, light.ErrLightClientAttack, err)
 	}
 
+	// Check the receipts of both evidence submissions.
+	require.Len(t, receipts, 2)
+	for _, r := range receipts {
+		require.Len(t, r, 2)
+		assert.True(t, r[0].Accepted())
+		assert.True(t, r[1].Accepted())
+	}
+	assert.Equal(t, 2, receipts[0][0].Attempts)
+	assert.Equal(t, 1, receipts[0][1].Attempts)
+
 	mockWitness.AssertExpectations(t)
 	mockPrimary.AssertExpectations(t)
 }
@@ -187,6 +208,9 @@ func TestLightClientAttackEvidence_Equivocation(t *testing.T) {
 				}
 				return bytes.Equal(evidence.Hash(), evAgainstWitness.Hash())
 			})).Return(nil)
+			// evidence is sent to all providers
+			mockPrimary.On("ReportEvidence", mock.Anything, mock.Anything).Return(nil)
+			mockWitness.On("ReportEvidence", mock.Anything, mock.Anything).Return(nil)
 
 			c, err := light.NewClient(
 				ctx,
@@ -282,6 +306,8 @@ func TestLightClientAttackEvidence_ForwardLunatic(t *testing.T) {
 		}
 		return bytes.Equal(evidence.Hash(), evAgainstPrimary.Hash())
 	})).Return(nil).Twice()
+	// evidence is sent to all providers, including the primary
+	mockPrimary.On("ReportEvidence", mock.Anything, mock.Anything).Return(nil)
 
 	// In order to perform the attack, the primary needs at least one accomplice as a witness to also
 	// send the forged block
@@ -0,0 +1,182 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	tmnet "github.com/tendermint/tendermint/libs/net"
+	"github.com/tendermint/tendermint/light/provider"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	coregrpc "github.com/tendermint/tendermint/rpc/grpc"
+	"github.com/tendermint/tendermint/types"
+)
+
+var defaultOptions = Options{
+	Connections: 1,
+	Timeout:     5 * time.Second,
+}
+
+// grpcProvider obtains light blocks from the LightAPI gRPC service of a node.
+type grpcProvider struct {
+	chainID string
+	remote  string
+	timeout time.Duration
+
+	// requests are spread over the pool of connections in a round robin
+	// fashion
+	conns   []*grpc.ClientConn
+	clients []coregrpc.LightAPIClient
+	next    uint32
+}
+
+type Options struct {
+	// The number of connections opened to the remote. Requests are spread
+	// over them, which helps when many requests are made concurrently.
+	// Defaults to 1.
+	Connections int
+	// The deadline of each request, unless the context passed in has an
+	// earlier one. 0 means no timeout.
+	Timeout time.Duration
+}
+
+// New creates a gRPC provider connecting to the given remote address,
+// e.g. "tcp://127.0.0.1:9090". The 5s timeout is used for all requests.
+func New(chainID, remote string) (provider.Provider, error) {
+	return NewWithOptions(chainID, remote, defaultOptions)
+}
+
+// NewWithOptions is an extension to creating a new gRPC provider that allows
+// setting the number of connections and the request timeout.
+func NewWithOptions(chainID, remote string, options Options) (provider.Provider, error) {
+	if options.Connections <= 0 {
+		options.Connections = 1
+	}
+
+	p := &grpcProvider{
+		chainID: chainID,
+		remote:  remote,
+		timeout: options.Timeout,
+	}
+	for i := 0; i < options.Connections; i++ {
+		conn, err := grpc.Dial(remote, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
+		if err != nil {
+			_ = p.Close()
+			return nil, err
+		}
+		p.conns = append(p.conns, conn)
+		p.clients = append(p.clients, coregrpc.NewLightAPIClient(conn))
+	}
+	return p, nil
+}
+
+func (p *grpcProvider) String() string {
+	return fmt.Sprintf("grpc{%s}", p.remote)
+}
+
+// LightBlock fetches a LightBlock at the given height and checks the
+// chainID matches.
+func (p *grpcProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	if height < 0 {
+		return nil, provider.ErrBadLightBlock{Reason: errors.New("expected height >= 0")}
+	}
+
+	reqCtx, cancel := p.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := p.client().LightBlock(reqCtx, &ssproto.LightBlockRequest{Height: uint64(height)})
+	if err != nil {
+		return nil, p.parseError(ctx, err)
+	}
+	if resp.LightBlock == nil {
+		return nil, provider.ErrBadLightBlock{Reason: errors.New("returned light block is nil unexpectedly")}
+	}
+
+	lb, err := types.LightBlockFromProto(resp.LightBlock)
+	if err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	if height != 0 && lb.Height != height {
+		return nil, provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("height %d responded doesn't match height %d requested", lb.Height, height),
+		}
+	}
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	return lb, nil
+}
+
+// ReportEvidence calls the ReportEvidence method of the LightAPI.
+func (p *grpcProvider) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	pb, err := types.EvidenceToProto(ev)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, cancel := p.withTimeout(ctx)
+	defer cancel()
+
+	if _, err := p.client().ReportEvidence(reqCtx, pb); err != nil {
+		return p.parseError(ctx, err)
+	}
+	return nil
+}
+
+// Close closes all connections of the provider.
+func (p *grpcProvider) Close() error {
+	var err error
+	for _, conn := range p.conns {
+		if cerr := conn.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// client returns the client of the next connection of the pool.
+func (p *grpcProvider) client() coregrpc.LightAPIClient {
+	i := atomic.AddUint32(&p.next, 1)
+	return p.clients[int(i)%len(p.clients)]
+}
+
+func (p *grpcProvider) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.timeout)
+}
+
+// parseError converts a gRPC error into the corresponding provider error.
+// Errors caused by ctx, the context of the caller, are returned as is.
+func (p *grpcProvider) parseError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	switch status.Code(err) {
+	case codes.OutOfRange:
+		return provider.ErrHeightTooHigh
+	case codes.NotFound:
+		return provider.ErrLightBlockNotFound
+	case codes.DeadlineExceeded:
+		return provider.ErrNoResponse
+	case codes.Unavailable:
+		return provider.ErrConnectionClosed
+	case codes.InvalidArgument:
+		return provider.ErrBadLightBlock{Reason: err}
+	default:
+		return provider.ErrUnreliableProvider{Reason: err.Error()}
+	}
+}
+
+func dialerFunc(ctx context.Context, addr string) (net.Conn, error) {
+	return tmnet.Connect(addr)
+}
@@ -0,0 +1,78 @@
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/light/provider"
+	lightgrpc "github.com/tendermint/tendermint/light/provider/grpc"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	rpctest "github.com/tendermint/tendermint/rpc/test"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestNewProvider(t *testing.T) {
+	p, err := lightgrpc.New("chain-test", "tcp://192.168.0.1:9090")
+	require.NoError(t, err)
+	require.Equal(t, "grpc{tcp://192.168.0.1:9090}", fmt.Sprintf("%s", p))
+}
+
+func TestProvider(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := rpctest.CreateConfig(t.Name())
+
+	// start a tendermint node in the background to test against
+	app := kvstore.NewApplication()
+	_, closer, err := rpctest.StartTendermint(ctx, cfg, app)
+	require.NoError(t, err)
+	defer func() { _ = closer(ctx) }()
+
+	genDoc, err := types.GenesisDocFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	chainID := genDoc.ChainID
+
+	c, err := rpchttp.New(cfg.RPC.ListenAddress)
+	require.NoError(t, err)
+
+	p, err := lightgrpc.NewWithOptions(chainID, cfg.RPC.GRPCListenAddress, lightgrpc.Options{
+		Connections: 2,
+		Timeout:     5 * time.Second,
+	})
+	require.NoError(t, err)
+
+	// let it produce some blocks
+	err = rpcclient.WaitForHeight(c, 10, nil)
+	require.NoError(t, err)
+
+	// let's get the highest block
+	lb, err := p.LightBlock(ctx, 0)
+	require.NoError(t, err)
+	assert.NoError(t, lb.ValidateBasic(chainID))
+
+	// historical queries are spread over both connections
+	lower := lb.Height - 3
+	for i := 0; i < 2; i++ {
+		lb, err = p.LightBlock(ctx, lower)
+		require.NoError(t, err)
+		assert.Equal(t, lower, lb.Height)
+	}
+
+	// fetching future heights should return the appropriate error
+	_, err = p.LightBlock(ctx, lb.Height+1000000)
+	assert.Equal(t, provider.ErrHeightTooHigh, err)
+
+	// the deadline of the caller takes precedence
+	expired, cancelExpired := context.WithTimeout(ctx, time.Nanosecond)
+	defer cancelExpired()
+	time.Sleep(time.Millisecond)
+	_, err = p.LightBlock(expired, lower)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
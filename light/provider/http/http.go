@@ -300,11 +300,14 @@ func (p *http) noBlock(e error) error {
 func (p *http) parseRPCError(e *rpctypes.RPCError) error {
 	switch {
 	// 1) check if the error indicates that the peer doesn't have the block
-	case strings.Contains(e.Data, coretypes.ErrHeightNotAvailable.Error()):
+	// (older nodes don't set the codes, only the messages)
+	case e.Code == rpctypes.CodeHeightNotAvailable,
+		strings.Contains(e.Data, coretypes.ErrHeightNotAvailable.Error()):
 		return p.noBlock(provider.ErrLightBlockNotFound)
 
 	// 2) check if the height requested is too high
-	case strings.Contains(e.Data, coretypes.ErrHeightExceedsChainHead.Error()):
+	case e.Code == rpctypes.CodeHeightExceedsChainHead,
+		strings.Contains(e.Data, coretypes.ErrHeightExceedsChainHead.Error()):
 		return p.noBlock(provider.ErrHeightTooHigh)
 
 	// 3) check if the provider closed the connection
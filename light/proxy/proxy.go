@@ -9,6 +9,7 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/das"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
@@ -19,6 +20,7 @@ type Proxy struct {
 	Addr     string // TCP address to listen on, ":http" if empty
 	Config   *rpcserver.Config
 	Client   *lrpc.Client
+	Sampler  *das.Sampler // serves /das_status if set
 	Logger   log.Logger
 	Listener net.Listener
 }
@@ -89,6 +91,9 @@ func (p *Proxy) listen() (net.Listener, *http.ServeMux, error) {
 
 	// 1) Register regular routes.
 	r := RPCRoutes(p.Client)
+	if p.Sampler != nil {
+		r["das_status"] = rpcserver.NewRPCFunc(makeDASStatusFunc(p.Sampler), "height", false)
+	}
 	rpcserver.RegisterRPCFuncs(mux, r, p.Logger)
 
 	// 2) Allow websocket connections.
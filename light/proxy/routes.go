@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/light/das"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	"github.com/tendermint/tendermint/rpc/coretypes"
@@ -22,11 +23,13 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"status":               rpcserver.NewRPCFunc(makeStatusFunc(c), "", false),
 		"net_info":             rpcserver.NewRPCFunc(makeNetInfoFunc(c), "", false),
 		"blockchain":           rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight", true),
+		"blocks":               rpcserver.NewRPCFunc(makeBlocksFunc(c), "min_height,max_height,include_txs", true),
 		"genesis":              rpcserver.NewRPCFunc(makeGenesisFunc(c), "", true),
 		"genesis_chunked":      rpcserver.NewRPCFunc(makeGenesisChunkedFunc(c), "", true),
 		"block":                rpcserver.NewRPCFunc(makeBlockFunc(c), "height", true),
 		"block_by_hash":        rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash", true),
 		"block_results":        rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height", true),
+		"namespaced_data":      rpcserver.NewRPCFunc(makeNamespacedDataFunc(c), "height,namespace_id", true),
 		"commit":               rpcserver.NewRPCFunc(makeCommitFunc(c), "height", true),
 		"tx":                   rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove", true),
 		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by", false),
@@ -38,6 +41,10 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit", false),
 		"num_unconfirmed_txs":  rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), "", false),
 
+		// data availability API
+		"data_availability_header": rpcserver.NewRPCFunc(makeDataAvailabilityHeaderFunc(c), "height", true),
+		"share":                    rpcserver.NewRPCFunc(makeShareFunc(c), "height,row,col", true),
+
 		// tx broadcast API
 		"broadcast_tx_commit": rpcserver.NewRPCFunc(makeBroadcastTxCommitFunc(c), "tx", false),
 		"broadcast_tx_sync":   rpcserver.NewRPCFunc(makeBroadcastTxSyncFunc(c), "tx", false),
@@ -85,6 +92,15 @@ func makeBlockchainInfoFunc(c *lrpc.Client) rpcBlockchainInfoFunc {
 	}
 }
 
+type rpcBlocksFunc func(ctx *rpctypes.Context, minHeight, maxHeight int64,
+	includeTxs bool) (*coretypes.ResultBlocks, error)
+
+func makeBlocksFunc(c *lrpc.Client) rpcBlocksFunc {
+	return func(ctx *rpctypes.Context, minHeight, maxHeight int64, includeTxs bool) (*coretypes.ResultBlocks, error) {
+		return c.Blocks(ctx.Context(), minHeight, maxHeight, includeTxs)
+	}
+}
+
 type rpcGenesisFunc func(ctx *rpctypes.Context) (*coretypes.ResultGenesis, error)
 
 func makeGenesisFunc(c *lrpc.Client) rpcGenesisFunc {
@@ -125,6 +141,40 @@ func makeBlockResultsFunc(c *lrpc.Client) rpcBlockResultsFunc {
 	}
 }
 
+type rpcNamespacedDataFunc func(ctx *rpctypes.Context, height *int64,
+	namespaceID bytes.HexBytes) (*coretypes.ResultNamespacedData, error)
+
+func makeNamespacedDataFunc(c *lrpc.Client) rpcNamespacedDataFunc {
+	return func(ctx *rpctypes.Context, height *int64, namespaceID bytes.HexBytes) (*coretypes.ResultNamespacedData, error) {
+		return c.NamespacedData(ctx.Context(), height, namespaceID)
+	}
+}
+
+type rpcDataAvailabilityHeaderFunc func(ctx *rpctypes.Context,
+	height *int64) (*coretypes.ResultDataAvailabilityHeader, error)
+
+func makeDataAvailabilityHeaderFunc(c *lrpc.Client) rpcDataAvailabilityHeaderFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*coretypes.ResultDataAvailabilityHeader, error) {
+		return c.DataAvailabilityHeader(ctx.Context(), height)
+	}
+}
+
+type rpcShareFunc func(ctx *rpctypes.Context, height *int64, row, col uint64) (*coretypes.ResultShare, error)
+
+func makeShareFunc(c *lrpc.Client) rpcShareFunc {
+	return func(ctx *rpctypes.Context, height *int64, row, col uint64) (*coretypes.ResultShare, error) {
+		return c.Share(ctx.Context(), height, row, col)
+	}
+}
+
+type rpcDASStatusFunc func(ctx *rpctypes.Context, height *int64) (*coretypes.ResultDASStatus, error)
+
+func makeDASStatusFunc(s *das.Sampler) rpcDASStatusFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*coretypes.ResultDASStatus, error) {
+		return s.Status(height)
+	}
+}
+
 type rpcCommitFunc func(ctx *rpctypes.Context, height *int64) (*coretypes.ResultCommit, error)
 
 func makeCommitFunc(c *lrpc.Client) rpcCommitFunc {
@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"time"
 
+	"github.com/celestiaorg/nmt/namespace"
 	"github.com/gogo/protobuf/proto"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -15,6 +17,7 @@ import (
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	service "github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/pkg/da"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -152,6 +155,9 @@ func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data tmb
 	if resp.Height <= 0 {
 		return nil, coretypes.ErrZeroOrNegativeHeight
 	}
+	if opts.Height != 0 && resp.Height != opts.Height {
+		return nil, fmt.Errorf("response height %d does not match requested height %d", resp.Height, opts.Height)
+	}
 
 	// Update the light client if we're behind.
 	// NOTE: AppHash for height H is in header H+1.
@@ -185,7 +191,7 @@ func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data tmb
 		}
 	}
 
-	return &coretypes.ResultABCIQuery{Response: resp}, nil
+	return &coretypes.ResultABCIQuery{Response: resp, Verification: verification(l)}, nil
 }
 
 func (c *Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTxCommit, error) {
@@ -257,6 +263,10 @@ func (c *Client) Health(ctx context.Context) (*coretypes.ResultHealth, error) {
 	return c.next.Health(ctx)
 }
 
+func (c *Client) MismatchReport(ctx context.Context) (*coretypes.ResultMismatchReport, error) {
+	return c.next.MismatchReport(ctx)
+}
+
 // BlockchainInfo calls rpcclient#BlockchainInfo and then verifies every header
 // returned.
 func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*coretypes.ResultBlockchainInfo, error) { //nolint:lll
@@ -298,6 +308,63 @@ func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 	return res, nil
 }
 
+// Blocks calls rpcclient#Blocks and then verifies every block returned.
+func (c *Client) Blocks(
+	ctx context.Context,
+	minHeight, maxHeight int64,
+	includeTxs bool,
+) (*coretypes.ResultBlocks, error) {
+	res, err := c.next.Blocks(ctx, minHeight, maxHeight, includeTxs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	for i, b := range res.Blocks {
+		if b == nil || b.Block == nil {
+			return nil, fmt.Errorf("nil block %d", i)
+		}
+		if err := b.BlockID.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("invalid block ID %d: %w", i, err)
+		}
+		// without its txs, the data of a block does not match its header
+		if includeTxs {
+			err = b.Block.ValidateBasic()
+		} else {
+			err = b.Block.Header.ValidateBasic()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid block %d: %w", i, err)
+		}
+		if bmH, bH := b.BlockID.Hash, b.Block.Hash(); !bytes.Equal(bmH, bH) {
+			return nil, fmt.Errorf("blockID %X does not match with block %X",
+				bmH, bH)
+		}
+	}
+
+	// Update the light client if we're behind.
+	if len(res.Blocks) > 0 {
+		lastHeight := res.Blocks[len(res.Blocks)-1].Block.Height
+		if _, err := c.updateLightClientIfNeededTo(ctx, &lastHeight); err != nil {
+			return nil, err
+		}
+	}
+
+	// Verify each of the blocks.
+	for _, b := range res.Blocks {
+		h, err := c.lc.TrustedLightBlock(b.Block.Height)
+		if err != nil {
+			return nil, fmt.Errorf("trusted header %d: %w", b.Block.Height, err)
+		}
+		if bH, tH := b.Block.Hash(), h.Hash(); !bytes.Equal(bH, tH) {
+			return nil, fmt.Errorf("block header %X does not match with trusted header %X",
+				bH, tH)
+		}
+	}
+
+	return res, nil
+}
+
 func (c *Client) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
 	return c.next.Genesis(ctx)
 }
@@ -324,6 +391,10 @@ func (c *Client) Block(ctx context.Context, height *int64) (*coretypes.ResultBlo
 		return nil, fmt.Errorf("blockID %X does not match with block %X",
 			bmH, bH)
 	}
+	if s := res.Block.Data.SquareSize(); res.SquareSize != s {
+		return nil, fmt.Errorf("square size %d does not match with block data of square size %d",
+			res.SquareSize, s)
+	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, &res.Block.Height)
@@ -358,6 +429,10 @@ func (c *Client) BlockByHash(ctx context.Context, hash tmbytes.HexBytes) (*coret
 		return nil, fmt.Errorf("blockID %X does not match with block %X",
 			bmH, bH)
 	}
+	if s := res.Block.Data.SquareSize(); res.SquareSize != s {
+		return nil, fmt.Errorf("square size %d does not match with block data of square size %d",
+			res.SquareSize, s)
+	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, &res.Block.Height)
@@ -399,6 +474,9 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*coretypes.Re
 	if res.Height <= 0 {
 		return nil, coretypes.ErrZeroOrNegativeHeight
 	}
+	if res.Height != h {
+		return nil, fmt.Errorf("block results height %d does not match requested height %d", res.Height, h)
+	}
 
 	// Update the light client if we're behind.
 	nextHeight := h + 1
@@ -435,6 +513,243 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*coretypes.Re
 			rH, trustedBlock.LastResultsHash)
 	}
 
+	res.Verification = verification(trustedBlock)
+	return res, nil
+}
+
+// NamespacedData calls rpcclient#NamespacedData and then verifies the shares
+// returned against the data availability header of the trusted header.
+func (c *Client) NamespacedData(
+	ctx context.Context,
+	height *int64,
+	namespaceID tmbytes.HexBytes,
+) (*coretypes.ResultNamespacedData, error) {
+	res, err := c.next.NamespacedData(ctx, height, namespaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, coretypes.ErrZeroOrNegativeHeight
+	}
+	if height != nil && res.Height != *height {
+		return nil, fmt.Errorf("namespaced data height %d does not match requested height %d", res.Height, *height)
+	}
+	if !bytes.Equal(res.NamespaceID, namespaceID) {
+		return nil, fmt.Errorf("namespace %X does not match requested namespace %X", res.NamespaceID, namespaceID)
+	}
+	dah := &res.DataAvailabilityHeader
+	if err := dah.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid data availability header: %w", err)
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the shares against the data hash of the trusted header.
+	if !bytes.Equal(dah.Hash(), l.DataHash) {
+		return nil, fmt.Errorf("data availability header %X does not match with trusted data hash %X",
+			dah.Hash(), l.DataHash)
+	}
+	if err := da.VerifyNamespaceProofs(dah, namespace.ID(namespaceID), res.Proofs); err != nil {
+		return nil, err
+	}
+
+	res.Verification = verification(l)
+	return res, nil
+}
+
+// Blob calls rpcclient#Blob and then verifies the shares of the namespace
+// returned against the data availability header of the trusted header, and
+// that the blob is laid out among them, with the requested share commitment.
+func (c *Client) Blob(
+	ctx context.Context,
+	height *int64,
+	namespaceID, commitment tmbytes.HexBytes,
+) (*coretypes.ResultBlob, error) {
+	res, err := c.next.Blob(ctx, height, namespaceID, commitment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, coretypes.ErrZeroOrNegativeHeight
+	}
+	if height != nil && res.Height != *height {
+		return nil, fmt.Errorf("blob height %d does not match requested height %d", res.Height, *height)
+	}
+	if !bytes.Equal(res.NamespaceID, namespaceID) {
+		return nil, fmt.Errorf("namespace %X does not match requested namespace %X", res.NamespaceID, namespaceID)
+	}
+	if !bytes.Equal(res.Commitment, commitment) {
+		return nil, fmt.Errorf("share commitment %X does not match requested share commitment %X",
+			res.Commitment, commitment)
+	}
+	blobCommitment, err := types.ShareCommitment(types.Message{NamespaceID: namespace.ID(namespaceID), Data: res.Data})
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob: %w", err)
+	}
+	if !bytes.Equal(blobCommitment, commitment) {
+		return nil, fmt.Errorf("blob share commitment %X does not match requested share commitment %X",
+			blobCommitment, commitment)
+	}
+	dah := &res.DataAvailabilityHeader
+	if err := dah.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid data availability header: %w", err)
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the shares against the data hash of the trusted header.
+	if !bytes.Equal(dah.Hash(), l.DataHash) {
+		return nil, fmt.Errorf("data availability header %X does not match with trusted data hash %X",
+			dah.Hash(), l.DataHash)
+	}
+	if err := da.VerifyNamespaceProofs(dah, namespace.ID(namespaceID), res.Proofs); err != nil {
+		return nil, err
+	}
+
+	// Verify the blob is laid out among the shares.
+	var shares [][]byte
+	for _, proof := range res.Proofs {
+		shares = append(shares, proof.Shares...)
+	}
+	msgs, err := types.MessagesFromShares(shares)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse the messages of the namespace: %w", err)
+	}
+	found := false
+	for _, msg := range msgs {
+		if bytes.Equal(msg.Data, res.Data) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("blob with share commitment %X not found in the namespace %X", commitment, namespaceID)
+	}
+
+	res.Verification = verification(l)
+	return res, nil
+}
+
+// ProveTxInclusion calls rpcclient#ProveTxInclusion and then verifies the
+// proof of the inclusion of the tx against the data hash of the trusted header.
+func (c *Client) ProveTxInclusion(
+	ctx context.Context,
+	height *int64,
+	txHash tmbytes.HexBytes,
+) (*coretypes.ResultTxInclusionProof, error) {
+	res, err := c.next.ProveTxInclusion(ctx, height, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, coretypes.ErrZeroOrNegativeHeight
+	}
+	if height != nil && res.Height != *height {
+		return nil, fmt.Errorf("tx height %d does not match requested height %d", res.Height, *height)
+	}
+	if !bytes.Equal(res.Tx.Hash(), txHash) {
+		return nil, fmt.Errorf("tx hash %X does not match requested hash %X", res.Tx.Hash(), txHash)
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the tx against the data hash of the trusted header.
+	if err := res.Proof.Verify(l.DataHash, res.Tx); err != nil {
+		return nil, fmt.Errorf("invalid proof of the inclusion of tx %X: %w", txHash, err)
+	}
+
+	res.Verification = verification(l)
+	return res, nil
+}
+
+// DataAvailabilityHeader calls rpcclient#DataAvailabilityHeader and then
+// verifies the result against the data hash of the trusted header.
+func (c *Client) DataAvailabilityHeader(
+	ctx context.Context,
+	height *int64,
+) (*coretypes.ResultDataAvailabilityHeader, error) {
+	res, err := c.next.DataAvailabilityHeader(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, coretypes.ErrZeroOrNegativeHeight
+	}
+	if height != nil && res.Height != *height {
+		return nil, fmt.Errorf("data availability header height %d does not match requested height %d",
+			res.Height, *height)
+	}
+	dah := &res.DataAvailabilityHeader
+	if err := dah.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid data availability header: %w", err)
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the data availability header against the trusted header.
+	if !bytes.Equal(dah.Hash(), l.DataHash) {
+		return nil, fmt.Errorf("data availability header %X does not match with trusted data hash %X",
+			dah.Hash(), l.DataHash)
+	}
+
+	res.Verification = verification(l)
+	return res, nil
+}
+
+// Share calls rpcclient#Share and then verifies the share returned against the
+// data availability header of the trusted header.
+func (c *Client) Share(ctx context.Context, height *int64, row, col uint64) (*coretypes.ResultShare, error) {
+	res, err := c.next.Share(ctx, height, row, col)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, coretypes.ErrZeroOrNegativeHeight
+	}
+	if height != nil && res.Height != *height {
+		return nil, fmt.Errorf("share height %d does not match requested height %d", res.Height, *height)
+	}
+	if res.Proof.Row != row || res.Proof.Col != col {
+		return nil, fmt.Errorf("share (%d, %d) does not match requested share (%d, %d)",
+			res.Proof.Row, res.Proof.Col, row, col)
+	}
+
+	// Verify the share against the verified data availability header.
+	dah, err := c.DataAvailabilityHeader(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+	if err := res.Proof.Verify(&dah.DataAvailabilityHeader); err != nil {
+		return nil, err
+	}
+
+	res.Verification = dah.Verification
 	return res, nil
 }
 
@@ -452,18 +767,29 @@ func (c *Client) Commit(ctx context.Context, height *int64) (*coretypes.ResultCo
 	}, nil
 }
 
-// Tx calls rpcclient#Tx method and then verifies the proof if such was
-// requested.
+// Tx calls rpcclient#Tx method and then verifies the transaction against
+// the trusted header at its height. The proof is always requested, regardless
+// of prove. The deterministic fields of the result (code, data and gas) are
+// verified against the block results; events and logs cannot be verified.
 func (c *Client) Tx(ctx context.Context, hash tmbytes.HexBytes, prove bool) (*coretypes.ResultTx, error) {
-	res, err := c.next.Tx(ctx, hash, prove)
-	if err != nil || !prove {
-		return res, err
+	res, err := c.next.Tx(ctx, hash, true)
+	if err != nil {
+		return nil, err
 	}
 
 	// Validate res.
 	if res.Height <= 0 {
 		return nil, coretypes.ErrZeroOrNegativeHeight
 	}
+	if !bytes.Equal(res.Hash, hash) {
+		return nil, fmt.Errorf("tx hash %X does not match requested hash %X", res.Hash, hash)
+	}
+	if !bytes.Equal(res.Tx.Hash(), hash) || !bytes.Equal(res.Proof.Data, res.Tx) {
+		return nil, errors.New("tx does not match its hash or proof")
+	}
+	if res.Proof.Proof.Index != int64(res.Index) {
+		return nil, fmt.Errorf("proof index %d does not match tx index %d", res.Proof.Proof.Index, res.Index)
+	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
@@ -472,7 +798,109 @@ func (c *Client) Tx(ctx context.Context, hash tmbytes.HexBytes, prove bool) (*co
 	}
 
 	// Validate the proof.
-	return res, res.Proof.Validate(l.DataHash)
+	if err := res.Proof.Validate(l.DataHash); err != nil {
+		return nil, err
+	}
+
+	// Validate the result against the verified block results.
+	results, err := c.BlockResults(ctx, &res.Height)
+	if err != nil {
+		return nil, fmt.Errorf("can't verify tx result: %w", err)
+	}
+	if int(res.Index) >= len(results.TxsResults) {
+		return nil, fmt.Errorf("tx index %d is out of range of %d block results", res.Index, len(results.TxsResults))
+	}
+	trusted := types.NewResults(results.TxsResults[res.Index : res.Index+1])
+	if rH, tH := types.NewResults([]*abci.ResponseDeliverTx{&res.TxResult}).Hash(), trusted.Hash(); !bytes.Equal(rH, tH) {
+		return nil, fmt.Errorf("tx result %X does not match with trusted tx result %X", rH, tH)
+	}
+
+	if !prove {
+		res.Proof = types.TxProof{}
+	}
+	res.Verification = verification(l)
+	return res, nil
+}
+
+// Txs calls rpcclient#Txs, always asking for the proofs of the txs, and
+// verifies the multiproofs of each block and the tx results.
+func (c *Client) Txs(ctx context.Context, hashes []tmbytes.HexBytes, prove bool) (*coretypes.ResultTxs, error) {
+	res, err := c.next.Txs(ctx, hashes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if len(res.Txs) != len(hashes) {
+		return nil, fmt.Errorf("expected %d txs, got %d", len(hashes), len(res.Txs))
+	}
+
+	// Validate the proofs against the trusted headers.
+	proofs := make(map[int64]types.TxMultiProof, len(res.Proofs))
+	verifications := make(map[int64]*coretypes.ResultVerification, len(res.Proofs))
+	for _, proof := range res.Proofs {
+		if _, ok := proofs[proof.Height]; ok {
+			return nil, fmt.Errorf("duplicate proof for height %d", proof.Height)
+		}
+		if proof.Height <= 0 {
+			return nil, coretypes.ErrZeroOrNegativeHeight
+		}
+		l, err := c.updateLightClientIfNeededTo(ctx, &proof.Height)
+		if err != nil {
+			return nil, err
+		}
+		if err := proof.Proof.Validate(l.DataHash); err != nil {
+			return nil, fmt.Errorf("invalid proof for height %d: %w", proof.Height, err)
+		}
+		proofs[proof.Height] = proof.Proof
+		verifications[proof.Height] = verification(l)
+	}
+
+	results := make(map[int64][]*abci.ResponseDeliverTx, len(proofs))
+	for i, tx := range res.Txs {
+		if !bytes.Equal(tx.Hash, hashes[i]) {
+			return nil, fmt.Errorf("tx hash %X does not match requested hash %X", tx.Hash, hashes[i])
+		}
+		if !bytes.Equal(tx.Tx.Hash(), tx.Hash) {
+			return nil, fmt.Errorf("tx does not match its hash %X", tx.Hash)
+		}
+
+		// Check the tx is proven at its index.
+		proof, ok := proofs[tx.Height]
+		if !ok {
+			return nil, fmt.Errorf("missing proof for tx %X at height %d", tx.Hash, tx.Height)
+		}
+		indices := proof.Proof.Indices
+		j := sort.Search(len(indices), func(j int) bool { return indices[j] >= int64(tx.Index) })
+		if j == len(indices) || indices[j] != int64(tx.Index) || !bytes.Equal(proof.Data[j], tx.Tx) {
+			return nil, fmt.Errorf("tx %X is not proven at index %d", tx.Hash, tx.Index)
+		}
+
+		// Validate the result against the verified block results.
+		if _, ok := results[tx.Height]; !ok {
+			blockResults, err := c.BlockResults(ctx, &tx.Height)
+			if err != nil {
+				return nil, fmt.Errorf("can't verify tx result: %w", err)
+			}
+			results[tx.Height] = blockResults.TxsResults
+		}
+		if int(tx.Index) >= len(results[tx.Height]) {
+			return nil, fmt.Errorf("tx index %d is out of range of %d block results",
+				tx.Index, len(results[tx.Height]))
+		}
+		trusted := types.NewResults(results[tx.Height][tx.Index : tx.Index+1])
+		rH, tH := types.NewResults([]*abci.ResponseDeliverTx{&tx.TxResult}).Hash(), trusted.Hash()
+		if !bytes.Equal(rH, tH) {
+			return nil, fmt.Errorf("tx result %X does not match with trusted tx result %X", rH, tH)
+		}
+
+		tx.Verification = verifications[tx.Height]
+	}
+
+	if !prove {
+		res.Proofs = nil
+	}
+	return res, nil
 }
 
 func (c *Client) TxSearch(
@@ -525,6 +953,124 @@ func (c *Client) Validators(
 		Total:       totalCount}, nil
 }
 
+// ValidatorSets calls rpcclient#ValidatorSets and then verifies each set
+// returned against the validators hash of the trusted headers of the first and
+// the last heights of its range.
+func (c *Client) ValidatorSets(
+	ctx context.Context,
+	fromHeight, toHeight int64,
+	pagePtr, perPagePtr *int,
+) (*coretypes.ResultValidatorSets, error) {
+	res, err := c.next.ValidatorSets(ctx, fromHeight, toHeight, pagePtr, perPagePtr)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make([][2]int64, len(res.ValidatorSets))
+	for i, set := range res.ValidatorSets {
+		ranges[i] = [2]int64{set.FromHeight, set.ToHeight}
+	}
+	if err := validateHeightRanges(ranges, res.Count); err != nil {
+		return nil, err
+	}
+
+	var l *types.LightBlock
+	for _, set := range res.ValidatorSets {
+		if len(set.Validators) == 0 {
+			return nil, fmt.Errorf("empty validator set at heights [%d, %d]", set.FromHeight, set.ToHeight)
+		}
+		for i, val := range set.Validators {
+			if err := val.ValidateBasic(); err != nil {
+				return nil, fmt.Errorf("invalid validator #%d at heights [%d, %d]: %w", i, set.FromHeight, set.ToHeight, err)
+			}
+		}
+		hash := (&types.ValidatorSet{Validators: set.Validators}).Hash()
+		for _, height := range []int64{set.FromHeight, set.ToHeight} {
+			l, err = c.updateLightClientIfNeededTo(ctx, &height)
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(hash, l.ValidatorsHash) {
+				return nil, fmt.Errorf("validators hash %X does not match trusted validators hash %X at height %d",
+					hash, l.ValidatorsHash, height)
+			}
+		}
+	}
+
+	if l != nil {
+		res.Verification = verification(l)
+	}
+	return res, nil
+}
+
+// ConsensusParamsHistory calls rpcclient#ConsensusParamsHistory and then
+// verifies the params returned against the consensus hash of the trusted
+// headers of the first and the last heights of their range.
+func (c *Client) ConsensusParamsHistory(
+	ctx context.Context,
+	fromHeight, toHeight int64,
+	pagePtr, perPagePtr *int,
+) (*coretypes.ResultConsensusParamsHistory, error) {
+	res, err := c.next.ConsensusParamsHistory(ctx, fromHeight, toHeight, pagePtr, perPagePtr)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make([][2]int64, len(res.ConsensusParams))
+	for i, params := range res.ConsensusParams {
+		ranges[i] = [2]int64{params.FromHeight, params.ToHeight}
+	}
+	if err := validateHeightRanges(ranges, res.Count); err != nil {
+		return nil, err
+	}
+
+	var l *types.LightBlock
+	for _, params := range res.ConsensusParams {
+		if err := params.ConsensusParams.ValidateConsensusParams(); err != nil {
+			return nil, err
+		}
+		hash := params.ConsensusParams.HashConsensusParams()
+		for _, height := range []int64{params.FromHeight, params.ToHeight} {
+			l, err = c.updateLightClientIfNeededTo(ctx, &height)
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(hash, l.ConsensusHash) {
+				return nil, fmt.Errorf("params hash %X does not match trusted hash %X at height %d",
+					hash, l.ConsensusHash, height)
+			}
+		}
+	}
+
+	if l != nil {
+		res.Verification = verification(l)
+	}
+	return res, nil
+}
+
+// validateHeightRanges checks that the ranges of heights are consecutive, and
+// amount to count heights.
+func validateHeightRanges(ranges [][2]int64, count int) error {
+	heights := int64(0)
+	for i, r := range ranges {
+		if r[0] <= 0 {
+			return coretypes.ErrZeroOrNegativeHeight
+		}
+		if r[1] < r[0] {
+			return fmt.Errorf("invalid range of heights [%d, %d]", r[0], r[1])
+		}
+		if i > 0 && r[0] != ranges[i-1][1]+1 {
+			return fmt.Errorf("range of heights [%d, %d] does not follow [%d, %d]",
+				r[0], r[1], ranges[i-1][0], ranges[i-1][1])
+		}
+		heights += r[1] - r[0] + 1
+	}
+	if heights != int64(count) {
+		return fmt.Errorf("%d heights in the ranges, but a count of %d", heights, count)
+	}
+	return nil
+}
+
 func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	return c.next.BroadcastEvidence(ctx, ev)
 }
@@ -558,6 +1104,16 @@ func (c *Client) updateLightClientIfNeededTo(ctx context.Context, height *int64)
 	return l, nil
 }
 
+// verification returns the verification status of a result verified against
+// the trusted light block l.
+func verification(l *types.LightBlock) *coretypes.ResultVerification {
+	return &coretypes.ResultVerification{
+		Verified:      true,
+		TrustedHeight: l.Height,
+		TrustedHash:   l.Hash(),
+	}
+}
+
 func (c *Client) RegisterOpDecoder(typ string, dec merkle.OpDecoder) {
 	c.prt.RegisterOpDecoder(typ, dec)
 }
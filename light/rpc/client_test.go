@@ -0,0 +1,161 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	lcmock "github.com/tendermint/tendermint/light/rpc/mocks"
+	rpcmock "github.com/tendermint/tendermint/rpc/client/mocks"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTx(t *testing.T) {
+	const height = int64(5)
+	txs := types.Txs{types.Tx("foo"), types.Tx("bar")}
+	txsResults := []*abci.ResponseDeliverTx{{Code: 0, Data: []byte("foo")}, {Code: 1, Data: []byte("bar")}}
+
+	bbeBytes, err := proto.Marshal(&abci.ResponseBeginBlock{})
+	require.NoError(t, err)
+	ebeBytes, err := proto.Marshal(&abci.ResponseEndBlock{})
+	require.NoError(t, err)
+	resultsHash := merkle.HashFromByteSlices([][]byte{bbeBytes, types.NewResults(txsResults).Hash(), ebeBytes})
+
+	lb := &types.LightBlock{SignedHeader: &types.SignedHeader{
+		Header: &types.Header{Height: height, DataHash: txs.Hash()},
+	}}
+	nextLB := &types.LightBlock{SignedHeader: &types.SignedHeader{
+		Header: &types.Header{Height: height + 1, LastResultsHash: resultsHash},
+	}}
+
+	makeResult := func() *coretypes.ResultTx {
+		return &coretypes.ResultTx{
+			Hash:     txs[1].Hash(),
+			Height:   height,
+			Index:    1,
+			TxResult: *txsResults[1],
+			Tx:       txs[1],
+			Proof:    txs.Proof(1),
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(*coretypes.ResultTx)
+		errMsg string
+	}{
+		{"valid", func(*coretypes.ResultTx) {}, ""},
+		{"wrong tx", func(r *coretypes.ResultTx) { r.Tx = txs[0] }, "does not match"},
+		{"wrong index", func(r *coretypes.ResultTx) { r.Index = 0 }, "proof index"},
+		{"wrong data hash", func(r *coretypes.ResultTx) { r.Proof.RootHash = []byte("wrong") }, "data hash"},
+		{"wrong result", func(r *coretypes.ResultTx) { r.TxResult.Code = 0 }, "trusted tx result"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			res := makeResult()
+			tc.modify(res)
+
+			next := &rpcmock.Client{}
+			next.On("Tx", mock.Anything, res.Hash, true).Return(res, nil)
+			h := height
+			next.On("BlockResults", mock.Anything, &h).Return(&coretypes.ResultBlockResults{
+				Height:     height,
+				TxsResults: txsResults,
+			}, nil)
+
+			lc := &lcmock.LightClient{}
+			lc.On("VerifyLightBlockAtHeight", mock.Anything, height, mock.Anything).Return(lb, nil)
+			lc.On("VerifyLightBlockAtHeight", mock.Anything, height+1, mock.Anything).Return(nextLB, nil)
+
+			c := NewClient(next, lc)
+			got, err := c.Tx(context.Background(), res.Hash, false)
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, got.Verification)
+			assert.True(t, got.Verification.Verified)
+			assert.Equal(t, height, got.Verification.TrustedHeight)
+			assert.Empty(t, got.Proof.RootHash, "proof should be stripped when not requested")
+		})
+	}
+}
+
+func TestValidatorSets(t *testing.T) {
+	vals, _ := factory.RandValidatorSet(2, 10)
+	otherVals, _ := factory.RandValidatorSet(2, 10)
+	lb := func(height int64, vals *types.ValidatorSet) *types.LightBlock {
+		return &types.LightBlock{SignedHeader: &types.SignedHeader{
+			Header: &types.Header{Height: height, ValidatorsHash: vals.Hash()},
+		}}
+	}
+
+	makeResult := func() *coretypes.ResultValidatorSets {
+		return &coretypes.ResultValidatorSets{
+			ValidatorSets: []coretypes.ValidatorSetRange{
+				{FromHeight: 1, ToHeight: 2, Validators: vals.Validators},
+				{FromHeight: 3, ToHeight: 3, Validators: otherVals.Validators},
+			},
+			Count: 3,
+			Total: 3,
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(*coretypes.ResultValidatorSets)
+		errMsg string
+	}{
+		{"valid", func(*coretypes.ResultValidatorSets) {}, ""},
+		{"wrong set", func(r *coretypes.ResultValidatorSets) {
+			r.ValidatorSets[1].Validators = vals.Validators
+		}, "trusted validators hash"},
+		{"wrong range", func(r *coretypes.ResultValidatorSets) {
+			r.ValidatorSets[0].ToHeight = 1
+			r.ValidatorSets[1].FromHeight = 2
+		}, "trusted validators hash"},
+		{"gap", func(r *coretypes.ResultValidatorSets) {
+			r.ValidatorSets[0].ToHeight = 1
+		}, "does not follow"},
+		{"wrong count", func(r *coretypes.ResultValidatorSets) { r.Count = 2 }, "count"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			res := makeResult()
+			tc.modify(res)
+
+			next := &rpcmock.Client{}
+			next.On("ValidatorSets", mock.Anything, int64(1), int64(3), (*int)(nil), (*int)(nil)).Return(res, nil)
+
+			lc := &lcmock.LightClient{}
+			lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(1), mock.Anything).Return(lb(1, vals), nil)
+			lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(2), mock.Anything).Return(lb(2, vals), nil)
+			lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(3), mock.Anything).Return(lb(3, otherVals), nil)
+
+			c := NewClient(next, lc)
+			got, err := c.ValidatorSets(context.Background(), 1, 3, nil, nil)
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, got.Verification)
+			assert.Equal(t, int64(3), got.Verification.TrustedHeight)
+		})
+	}
+}
@@ -220,6 +220,19 @@ func (s *dbs) LightBlockBefore(height int64) (*types.LightBlock, error) {
 //
 // Safe for concurrent use by multiple goroutines.
 func (s *dbs) Prune(size uint16) error {
+	return s.PruneWithInterval(size, 0)
+}
+
+// PruneWithInterval prunes header & validator set pairs until there are only
+// size pairs left, plus the older pairs at heights that are a multiple of
+// interval.
+//
+// Safe for concurrent use by multiple goroutines.
+func (s *dbs) PruneWithInterval(size uint16, interval int64) error {
+	if interval < 0 {
+		panic("negative interval")
+	}
+
 	// 1) Check how many we need to prune.
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
@@ -234,18 +247,22 @@ func (s *dbs) Prune(size uint16) error {
 	defer b.Close()
 
 	// 2) use an iterator to batch together all the blocks that need to be deleted
-	if err := s.batchDelete(b, numToPrune); err != nil {
+	pruned, err := s.batchDelete(b, numToPrune, interval)
+	if err != nil {
 		return err
 	}
 
 	// 3) // update size
-	s.size = size
-	if err := b.Set(s.sizeKey(), marshalSize(size)); err != nil {
+	if err := b.Set(s.sizeKey(), marshalSize(sSize-pruned)); err != nil {
 		return fmt.Errorf("failed to persist size: %w", err)
 	}
 
 	// 4) write batch deletion to disk
-	return b.WriteSync()
+	if err := b.WriteSync(); err != nil {
+		return err
+	}
+	s.size = sSize - pruned
+	return nil
 }
 
 // Size returns the number of header & validator set pairs.
@@ -257,25 +274,35 @@ func (s *dbs) Size() uint16 {
 	return s.size
 }
 
-func (s *dbs) batchDelete(batch dbm.Batch, numToPrune uint16) error {
+// batchDelete adds to batch the deletion of the oldest numToPrune light
+// blocks, skipping those at heights that are a multiple of interval unless
+// interval is 0. It returns the number of deleted light blocks.
+func (s *dbs) batchDelete(batch dbm.Batch, numToPrune uint16, interval int64) (uint16, error) {
 	itr, err := s.db.Iterator(
 		s.lbKey(1),
 		append(s.lbKey(1<<63-1), byte(0x00)),
 	)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer itr.Close()
 
-	for itr.Valid() && numToPrune > 0 {
-		if err = batch.Delete(itr.Key()); err != nil {
-			return err
+	pruned := uint16(0)
+	for ; itr.Valid() && numToPrune > 0; numToPrune-- {
+		height, err := s.decodeLbKey(itr.Key())
+		if err != nil {
+			return 0, err
+		}
+		if interval == 0 || height%interval != 0 {
+			if err = batch.Delete(itr.Key()); err != nil {
+				return 0, err
+			}
+			pruned++
 		}
 		itr.Next()
-		numToPrune--
 	}
 
-	return itr.Error()
+	return pruned, itr.Error()
 }
 
 func (s *dbs) sizeKey() []byte {
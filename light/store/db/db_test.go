@@ -13,6 +13,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/internal/test/factory"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/light/store"
 	"github.com/tendermint/tendermint/types"
 	"github.com/tendermint/tendermint/version"
 )
@@ -130,6 +131,39 @@ func Test_Prune(t *testing.T) {
 	assert.EqualValues(t, 7, dbStore.Size())
 }
 
+func Test_PruneWithInterval(t *testing.T) {
+	dbStore := New(dbm.NewMemDB())
+
+	for i := 1; i <= 20; i++ {
+		err := dbStore.SaveLightBlock(randLightBlock(int64(i)))
+		require.NoError(t, err)
+	}
+
+	// keeps 16..20 as well as 5, 10 and 15
+	err := dbStore.PruneWithInterval(5, 5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 8, dbStore.Size())
+
+	for _, height := range []int64{5, 10, 15, 16, 17, 18, 19, 20} {
+		_, err := dbStore.LightBlock(height)
+		assert.NoError(t, err, "height %d", height)
+	}
+	_, err = dbStore.LightBlock(4)
+	assert.Equal(t, store.ErrLightBlockNotFound, err)
+
+	height, err := dbStore.FirstLightBlockHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, height)
+
+	// the size is persisted
+	assert.EqualValues(t, 8, New(dbStore.(*dbs).db).Size())
+
+	// an interval of 0 prunes everything
+	err = dbStore.PruneWithInterval(0, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, dbStore.Size())
+}
+
 func Test_Concurrency(t *testing.T) {
 	dbStore := New(dbm.NewMemDB())
 
@@ -43,6 +43,13 @@ type Store interface {
 	// defined size (number of header & validator set pairs).
 	Prune(size uint16) error
 
+	// PruneWithInterval is like Prune, except that the light blocks at heights
+	// that are a multiple of interval are kept along with the size most recent
+	// ones. This keeps a sparse history of trusted headers at a bounded cost.
+	//
+	// interval must be >= 0. An interval of 0 behaves like Prune.
+	PruneWithInterval(size uint16, interval int64) error
+
 	// Size returns a number of currently existing header & validator set pairs.
 	Size() uint16
 }
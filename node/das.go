@@ -0,0 +1,159 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/share"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/light/das"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+)
+
+// DASNode is the p2p node of a light client running data availability
+// sampling: it only connects to the peers of the chain over the share channel,
+// to fetch the shares sampled, and serves none. See das.Sampler.
+type DASNode struct {
+	service.BaseService
+
+	config  *config.Config
+	nodeKey types.NodeKey
+
+	transport    *p2p.MConnTransport
+	peerManager  *p2p.PeerManager
+	router       *p2p.Router
+	shareReactor *share.Reactor
+}
+
+// NewDASNode returns a DASNode of the chain, connecting to the peers of the
+// p2p config, which must run the given block protocol version. Only the new
+// p2p stack is supported.
+func NewDASNode(
+	cfg *config.Config,
+	nodeKey types.NodeKey,
+	chainID string,
+	blockVersion uint64,
+	logger log.Logger,
+) (*DASNode, error) {
+	if cfg.P2P.UseLegacy {
+		return nil, fmt.Errorf("data availability sampling doesn't support the legacy p2p stack")
+	}
+
+	nodeInfo := types.NodeInfo{
+		ProtocolVersion: types.ProtocolVersion{
+			P2P:   version.P2PProtocol, // global
+			Block: blockVersion,
+		},
+		NodeID:  nodeKey.ID,
+		Network: chainID,
+		Version: version.TMVersion,
+		// the peers without the share channels are incompatible
+		Channels: []byte{byte(share.ShareChannel), byte(share.FraudChannel)},
+		Moniker:  cfg.Moniker,
+		Other: types.NodeInfoOther{
+			TxIndex: "off",
+		},
+		Features: []string{p2p.RekeyFeature},
+	}
+	nodeInfo.ListenAddr = cfg.P2P.ExternalAddress
+	if nodeInfo.ListenAddr == "" {
+		nodeInfo.ListenAddr = cfg.P2P.ListenAddress
+	}
+	if err := nodeInfo.Validate(); err != nil {
+		return nil, err
+	}
+
+	p2pMetrics := p2p.PrometheusMetrics(cfg.Instrumentation.Namespace, "chain_id", chainID).
+		LimitPeerLabels(cfg.Instrumentation.MaxPeerMetrics)
+	p2pLogger := logger.With("module", "p2p")
+	transport := createTransport(p2pLogger, cfg)
+
+	peerManager, err := createPeerManager(cfg, config.DefaultDBProvider, p2pLogger, nodeKey.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create peer manager: %w", err)
+	}
+
+	routerOpts, err := getRouterConfig(cfg, nil, p2pLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create router: %w", err)
+	}
+	router, err := createRouter(p2pLogger, p2pMetrics, nodeInfo, nodeKey.PrivKey,
+		peerManager, transport, routerOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create router: %w", err)
+	}
+
+	// nothing is served with a zero serve rate, so that no store is needed
+	p2pCfg := *cfg.P2P
+	p2pCfg.ShareServeRate = 0
+
+	channels := makeChannelsFromShims(router, share.ChannelShims)
+	shareReactor := share.NewReactor(
+		&p2pCfg,
+		logger.With("module", "share"),
+		nil,
+		channels[share.ShareChannel],
+		channels[share.FraudChannel],
+		peerManager.Subscribe(),
+	)
+	transport.AddChannelDescriptors([]*p2p.ChannelDescriptor{
+		share.ChannelShims[share.ShareChannel].Descriptor,
+		share.ChannelShims[share.FraudChannel].Descriptor,
+	})
+
+	n := &DASNode{
+		config:       cfg,
+		nodeKey:      nodeKey,
+		transport:    transport,
+		peerManager:  peerManager,
+		router:       router,
+		shareReactor: shareReactor,
+	}
+	n.BaseService = *service.NewBaseService(logger, "DASNode", n)
+	return n, nil
+}
+
+// Shares returns the fetcher of the shares sampled from the connected peers.
+func (n *DASNode) Shares() das.ShareFetcher {
+	return n.shareReactor
+}
+
+// SetBadEncodingProofHandler sets the handler verifying the bad encoding
+// proofs received from the peers, such as das.Sampler.HandleBadEncodingProof.
+// The proofs are dropped until it is set, the DASNode not having the blocks.
+func (n *DASNode) SetBadEncodingProofHandler(handler share.BadEncodingProofHandler) {
+	n.shareReactor.SetBadEncodingProofHandler(handler)
+}
+
+// OnStart implements service.Service by listening for the peers and starting
+// the router and the share reactor.
+func (n *DASNode) OnStart() error {
+	addr, err := types.NewNetAddressString(n.nodeKey.ID.AddressString(n.config.P2P.ListenAddress))
+	if err != nil {
+		return err
+	}
+	if err := n.transport.Listen(p2p.NewEndpoint(addr)); err != nil {
+		return err
+	}
+
+	if err := n.router.Start(); err != nil {
+		return err
+	}
+	return n.shareReactor.Start()
+}
+
+// OnStop implements service.Service.
+func (n *DASNode) OnStop() {
+	if err := n.shareReactor.Stop(); err != nil {
+		n.Logger.Error("failed to stop the share reactor", "err", err)
+	}
+	if err := n.router.Stop(); err != nil {
+		n.Logger.Error("failed to stop router", "err", err)
+	}
+	if err := n.transport.Close(); err != nil {
+		n.Logger.Error("Error closing transport", "err", err)
+	}
+}
@@ -0,0 +1,167 @@
+package node
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// debugBundleWALTail is the size of the end of the consensus WAL added to the
+// debug bundles.
+const debugBundleWALTail = 8 * 1024 * 1024 // 8 MiB
+
+// SaveDebugBundle collects debugging data for a support ticket into a tar.gz
+// file in the debug directory of the node home, as /unsafe_debug_bundle does,
+// and returns its path. The node saves a debug bundle on SIGUSR1.
+func (n *nodeImpl) SaveDebugBundle() (string, error) {
+	dir := filepath.Join(n.config.RootDir, "debug")
+	if err := tmos.EnsureDir(dir, config.DefaultDirPerm); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("bundle-%s.tar.gz", time.Now().UTC().Format("20060102-150405.000")))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+	if err := n.writeDebugBundle(f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	n.Logger.Info("saved debug bundle", "file", path)
+	return path, nil
+}
+
+// writeDebugBundle writes a tar.gz archive of the goroutine, heap and block
+// profiles, the status, consensus state and net info of the node, the end of
+// its consensus WAL and its config, without passwords. The data which can't
+// be collected is reported in errors.txt.
+func (n *nodeImpl) writeDebugBundle(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	var errs bytes.Buffer
+	add := func(name string, write func(io.Writer) error) error {
+		var buf bytes.Buffer
+		if err := write(&buf); err != nil {
+			n.Logger.Error("failed to collect debugging data", "file", name, "err", err)
+			fmt.Fprintf(&errs, "%s: %v\n", name, err)
+			return nil
+		}
+		return addTarFile(tw, name, buf.Bytes(), now)
+	}
+
+	for _, entry := range []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{"goroutine.txt", profileWriter("goroutine", 2)},
+		{"heap.pprof", profileWriter("heap", 0)},
+		{"block.pprof", profileWriter("block", 0)},
+		{"status.json", n.rpcResultWriter(func(ctx *rpctypes.Context) (interface{}, error) {
+			return n.rpcEnv.Status(ctx)
+		})},
+		{"consensus_state.json", n.rpcResultWriter(func(ctx *rpctypes.Context) (interface{}, error) {
+			return n.rpcEnv.DumpConsensusState(ctx)
+		})},
+		{"net_info.json", n.rpcResultWriter(func(ctx *rpctypes.Context) (interface{}, error) {
+			return n.rpcEnv.NetInfo(ctx)
+		})},
+		{"wal_tail", walTailWriter(n.config.Consensus.WalFile())},
+		{"config.toml", func(w io.Writer) error { return config.WriteConfig(w, n.config.Redacted()) }},
+	} {
+		if err := add(entry.name, entry.write); err != nil {
+			return err
+		}
+	}
+	if errs.Len() > 0 {
+		if err := addTarFile(tw, "errors.txt", errs.Bytes(), now); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func addTarFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+func profileWriter(profile string, debug int) func(io.Writer) error {
+	return func(w io.Writer) error {
+		p := pprof.Lookup(profile)
+		if p == nil {
+			return fmt.Errorf("no %s profile", profile)
+		}
+		return p.WriteTo(w, debug)
+	}
+}
+
+func (n *nodeImpl) rpcResultWriter(call func(*rpctypes.Context) (interface{}, error)) func(io.Writer) error {
+	return func(w io.Writer) error {
+		if n.rpcEnv == nil {
+			return fmt.Errorf("not available on %s nodes", n.config.Mode)
+		}
+		res, err := call(&rpctypes.Context{})
+		if err != nil {
+			return err
+		}
+		bz, err := tmjson.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(bz)
+		return err
+	}
+}
+
+// walTailWriter writes the last debugBundleWALTail bytes of the WAL. The
+// first message may be truncated.
+func walTailWriter(walFile string) func(io.Writer) error {
+	return func(w io.Writer) error {
+		f, err := os.Open(walFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		if offset := info.Size() - debugBundleWALTail; offset > 0 {
+			if _, err := f.Seek(offset, io.SeekStart); err != nil {
+				return err
+			}
+		}
+		_, err = io.Copy(w, f)
+		return err
+	}
+}
@@ -0,0 +1,299 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tendermint/tendermint/libs/pubsub"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Overflow is what a subscription does when its buffer is full.
+type Overflow int
+
+const (
+	// OverflowCancel cancels the subscription, with pubsub.ErrOutOfCapacity,
+	// like the RPC does for the websocket subscriptions.
+	OverflowCancel Overflow = iota
+	// OverflowDrop drops the events, counted by Subscription.Dropped.
+	OverflowDrop
+	// OverflowBlock blocks the node until the subscriber receives the event.
+	// A slow subscriber slows down, or halts, consensus.
+	OverflowBlock
+)
+
+// DefaultSubscriptionBufferSize is the number of events buffered by a
+// subscription, unless set with WithBufferSize.
+const DefaultSubscriptionBufferSize = 100
+
+// SubscribeOption sets an optional parameter of a subscription.
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	bufferSize int
+	overflow   Overflow
+	query      string
+}
+
+func newSubscribeOptions(options []SubscribeOption) *subscribeOptions {
+	opts := &subscribeOptions{bufferSize: DefaultSubscriptionBufferSize}
+	for _, option := range options {
+		option(opts)
+	}
+	if opts.bufferSize < 0 {
+		opts.bufferSize = 0
+	}
+	return opts
+}
+
+// WithBufferSize sets the number of events buffered by the subscription, 0 for
+// none.
+func WithBufferSize(size int) SubscribeOption {
+	return func(opts *subscribeOptions) { opts.bufferSize = size }
+}
+
+// WithOverflow sets what the subscription does when its buffer is full,
+// OverflowCancel by default.
+func WithOverflow(overflow Overflow) SubscribeOption {
+	return func(opts *subscribeOptions) { opts.overflow = overflow }
+}
+
+// WithQuery only subscribes to the events matching the query, e.g.
+// "transfer.sender = 'addr'" for the txs of the sender.
+func WithQuery(query string) SubscribeOption {
+	return func(opts *subscribeOptions) { opts.query = query }
+}
+
+// EventSubscriber subscribes in process to the events of a node, delivered on
+// typed channels.
+type EventSubscriber struct {
+	eventBus *types.EventBus
+}
+
+// subscriberID numbers the subscribers to the event buses, for the
+// subscriptions to a same query not to conflict.
+var subscriberID uint64 // atomic
+
+// NewEventSubscriber returns an EventSubscriber to the events of the node n,
+// created with New or NewDefault. Seed nodes have no events.
+func NewEventSubscriber(n service.Service) (*EventSubscriber, error) {
+	node, ok := n.(interface{ EventBus() *types.EventBus })
+	if !ok || node.EventBus() == nil {
+		return nil, errors.New("the node has no event bus")
+	}
+	return &EventSubscriber{eventBus: node.EventBus()}, nil
+}
+
+// SubscribeNewBlocks subscribes to the blocks committed by the node.
+func (s *EventSubscriber) SubscribeNewBlocks(
+	ctx context.Context,
+	options ...SubscribeOption,
+) (<-chan types.EventDataNewBlock, *Subscription, error) {
+	opts := newSubscribeOptions(options)
+	out := make(chan types.EventDataNewBlock, opts.bufferSize)
+	sub, err := s.subscribe(ctx, types.EventQueryNewBlock, opts,
+		func(ctx context.Context, data types.TMEventData, wait bool) bool {
+			event := data.(types.EventDataNewBlock)
+			if !wait {
+				select {
+				case out <- event:
+					return true
+				default:
+					return false
+				}
+			}
+			select {
+			case out <- event:
+			case <-ctx.Done():
+			}
+			return true
+		},
+		func() { close(out) })
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, sub, nil
+}
+
+// SubscribeTxs subscribes to the txs committed by the node.
+func (s *EventSubscriber) SubscribeTxs(
+	ctx context.Context,
+	options ...SubscribeOption,
+) (<-chan types.EventDataTx, *Subscription, error) {
+	opts := newSubscribeOptions(options)
+	out := make(chan types.EventDataTx, opts.bufferSize)
+	sub, err := s.subscribe(ctx, types.EventQueryTx, opts,
+		func(ctx context.Context, data types.TMEventData, wait bool) bool {
+			event := data.(types.EventDataTx)
+			if !wait {
+				select {
+				case out <- event:
+					return true
+				default:
+					return false
+				}
+			}
+			select {
+			case out <- event:
+			case <-ctx.Done():
+			}
+			return true
+		},
+		func() { close(out) })
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, sub, nil
+}
+
+// SubscribeValidatorSetUpdates subscribes to the updates of the validator set.
+func (s *EventSubscriber) SubscribeValidatorSetUpdates(
+	ctx context.Context,
+	options ...SubscribeOption,
+) (<-chan types.EventDataValidatorSetUpdates, *Subscription, error) {
+	opts := newSubscribeOptions(options)
+	out := make(chan types.EventDataValidatorSetUpdates, opts.bufferSize)
+	sub, err := s.subscribe(ctx, types.EventQueryValidatorSetUpdates, opts,
+		func(ctx context.Context, data types.TMEventData, wait bool) bool {
+			event := data.(types.EventDataValidatorSetUpdates)
+			if !wait {
+				select {
+				case out <- event:
+					return true
+				default:
+					return false
+				}
+			}
+			select {
+			case out <- event:
+			case <-ctx.Done():
+			}
+			return true
+		},
+		func() { close(out) })
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, sub, nil
+}
+
+// Subscription is a subscription of an EventSubscriber. Its channel is closed
+// once it ends, when the node stops, the context passed to subscribe is
+// canceled, Unsubscribe is called or, with OverflowCancel, its buffer is full.
+type Subscription struct {
+	cancel  context.CancelFunc
+	done    chan struct{}
+	dropped uint64 // atomic
+
+	mtx sync.Mutex
+	err error
+}
+
+// Unsubscribe ends the subscription, and waits for its channel to be closed.
+func (sub *Subscription) Unsubscribe() {
+	sub.cancel()
+	<-sub.done
+}
+
+// Done returns a channel closed once the subscription has ended.
+func (sub *Subscription) Done() <-chan struct{} {
+	return sub.done
+}
+
+// Err returns why the subscription ended, nil if it hasn't or the node
+// stopped.
+func (sub *Subscription) Err() error {
+	sub.mtx.Lock()
+	defer sub.mtx.Unlock()
+	return sub.err
+}
+
+// Dropped returns the number of events dropped with OverflowDrop.
+func (sub *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&sub.dropped)
+}
+
+func (sub *Subscription) setErr(err error) {
+	sub.mtx.Lock()
+	defer sub.mtx.Unlock()
+	sub.err = err
+}
+
+// subscribe subscribes to the events of the query, relaying them with send
+// until the subscription ends, and then calling closeOut. send must only
+// block if wait is set, and return whether it sent the event.
+func (s *EventSubscriber) subscribe(
+	ctx context.Context,
+	query pubsub.Query,
+	opts *subscribeOptions,
+	send func(ctx context.Context, data types.TMEventData, wait bool) bool,
+	closeOut func(),
+) (*Subscription, error) {
+	if opts.query != "" {
+		q, err := tmquery.New(fmt.Sprintf("%s AND %s", query, opts.query))
+		if err != nil {
+			return nil, fmt.Errorf("invalid query %q: %w", opts.query, err)
+		}
+		query = q
+	}
+
+	// The events are relayed from an unbuffered subscription, the buffer
+	// being the one of the typed channel, for the overflow to be ours.
+	subscriber := fmt.Sprintf("node-events-%d", atomic.AddUint64(&subscriberID, 1))
+	eventSub, err := s.eventBus.SubscribeUnbuffered(ctx, subscriber, query)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	sub := &Subscription{cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(sub.done)
+		defer closeOut()
+		defer s.unsubscribe(subscriber, eventSub)
+
+		for {
+			select {
+			case msg := <-eventSub.Out():
+				if send(ctx, msg.Data(), opts.overflow == OverflowBlock) {
+					continue
+				}
+				if opts.overflow == OverflowDrop {
+					atomic.AddUint64(&sub.dropped, 1)
+					continue
+				}
+				sub.setErr(pubsub.ErrOutOfCapacity)
+				return
+			case <-eventSub.Canceled():
+				sub.setErr(eventSub.Err())
+				return
+			case <-ctx.Done():
+				sub.setErr(ctx.Err())
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// unsubscribe unsubscribes from the event bus, draining the subscription
+// meanwhile for the event bus not to block on it.
+func (s *EventSubscriber) unsubscribe(subscriber string, eventSub types.Subscription) {
+	go func() {
+		_ = s.eventBus.UnsubscribeAll(context.Background(), subscriber)
+	}()
+	for {
+		select {
+		case <-eventSub.Out():
+		case <-eventSub.Canceled():
+			return
+		case <-s.eventBus.Quit():
+			return
+		}
+	}
+}
@@ -11,20 +11,22 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/rs/cors"
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/internal/consensus"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/p2p/pex"
 	"github.com/tendermint/tendermint/internal/proxy"
 	rpccore "github.com/tendermint/tendermint/internal/rpc/core"
+	"github.com/tendermint/tendermint/internal/share"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/statesync"
 	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/internal/telemetry"
 	"github.com/tendermint/tendermint/libs/log"
 	tmnet "github.com/tendermint/tendermint/libs/net"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
@@ -36,6 +38,7 @@ import (
 	grpccore "github.com/tendermint/tendermint/rpc/grpc"
 	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
 	"github.com/tendermint/tendermint/types"
+	"go.opentelemetry.io/otel/attribute"
 
 	_ "net/http/pprof" // nolint: gosec // securely exposed on separate, optional port
 
@@ -62,22 +65,41 @@ type nodeImpl struct {
 	nodeKey     types.NodeKey // our node privkey
 	isListening bool
 
+	// nodeInfoOther returns the data of the node info which changes over
+	// time, if set
+	nodeInfoOther func() types.NodeInfoOther
+
 	// services
 	eventBus         *types.EventBus // pub/sub for services
 	stateStore       sm.Store
 	blockStore       *store.BlockStore // store the blockchain to disk
+	pruner           *sm.Pruner        // prunes the stores in the background
+	blockExec        *sm.BlockExecutor // executes the blocks
 	bcReactor        service.Service   // for block-syncing
 	mempoolReactor   service.Service   // for gossipping transactions
 	mempool          mempool.Mempool
+	seenTxs          *mempool.SeenTxCache
 	stateSync        bool               // whether the node should state sync on startup
 	stateSyncReactor *statesync.Reactor // for hosting and restoring state sync snapshots
 	consensusReactor *consensus.Reactor // for participating in the consensus
 	pexReactor       service.Service    // for exchanging peer addresses
 	evidenceReactor  service.Service
+	shareReactor     *share.Reactor // for serving the shares to DAS clients and partial nodes
 	rpcListeners     []net.Listener // rpc servers
 	indexerService   service.Service
 	rpcEnv           *rpccore.Environment
+	rpcMetrics       *rpcserver.Metrics
+	rpcDrainTimeout  time.Duration // longest a request in flight can last
+	rpcRateLimiter   *rpcserver.RateLimiter
+	rpcCORSHandlers  []*corsHandler
 	prometheusSrv    *http.Server
+	otelExporter     *telemetry.Exporter
+
+	// set by the embedder
+	customReactors    []service.Service
+	startHooks        []func() error
+	stopHooks         []func()
+	metricsRegisterer prometheus.Registerer
 }
 
 // newDefaultNode returns a Tendermint node with default settings for the
@@ -107,7 +129,8 @@ func newDefaultNode(cfg *config.Config, logger log.Logger) (service.Service, err
 		pval = nil
 	}
 
-	appClient, _ := proxy.DefaultClientCreator(cfg.ProxyApp, cfg.ABCI, cfg.DBDir())
+	appClient, _ := proxy.DefaultClientCreator(cfg.ProxyApp, cfg.ABCI, cfg.DBDir(),
+		proxy.GRPCClientOptions(cfg.BaseConfig)...)
 	return makeNode(cfg,
 		pval,
 		nodeKey,
@@ -125,22 +148,30 @@ func makeNode(cfg *config.Config,
 	clientCreator abciclient.Creator,
 	genesisDocProvider genesisDocProvider,
 	dbProvider config.DBProvider,
-	logger log.Logger) (service.Service, error) {
+	logger log.Logger,
+	options ...Option) (service.Service, error) {
+
+	opts := newNodeOptions(options)
+	if opts.dbProvider != nil {
+		dbProvider = opts.dbProvider
+	}
+	if opts.privValidator != nil {
+		privValidator = opts.privValidator
+	}
 
-	blockStore, stateDB, err := initDBs(cfg, dbProvider)
+	blockStore, blockStoreDB, stateDB, err := initDBs(cfg, dbProvider)
 	if err != nil {
 		return nil, err
 	}
 	stateStore := sm.NewStore(stateDB)
+	genesisStore := sm.NewGenesisStore(stateDB)
 
-	genDoc, err := genesisDocProvider()
+	genDoc, genesisHash, err := loadGenesisDoc(genesisStore, genesisDocProvider, logger)
 	if err != nil {
 		return nil, err
 	}
-
-	err = genDoc.ValidateAndComplete()
-	if err != nil {
-		return nil, fmt.Errorf("error in genesis doc: %w", err)
+	if err := checkGenesisHash(cfg, genDoc.ChainID, genesisHash, logger); err != nil {
+		return nil, err
 	}
 
 	state, err := loadStateFromDBOrGenesisDocProvider(stateStore, genDoc)
@@ -149,16 +180,27 @@ func makeNode(cfg *config.Config,
 	}
 
 	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
-	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger)
+	proxyApp, err := createAndStartProxyAppConns(clientCreator, cfg, logger)
 	if err != nil {
 		return nil, err
 	}
 
+	metricsProvider := defaultMetricsProvider(cfg.Instrumentation)
+	if opts.registerer != nil {
+		metricsProvider = registeredMetricsProvider(cfg.Instrumentation, opts.registerer)
+	}
+	nodeMetrics := metricsProvider(genDoc.ChainID)
+
 	// EventBus and IndexerService must be started before the handshake because
 	// we might need to index the txs of the replayed block as this might not have happened
 	// when the node stopped last time (i.e. the node stopped after it saved the block
 	// but before it indexed the txs, or, endblocker panicked)
-	eventBus, err := createAndStartEventBus(logger)
+	eventBus := opts.eventBus
+	if eventBus == nil {
+		eventBus, err = createAndStartEventBus(logger, cfg.RPC.EventHistorySize, nodeMetrics.pubsub)
+	} else if !eventBus.IsRunning() {
+		err = eventBus.Start()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -168,9 +210,16 @@ func makeNode(cfg *config.Config,
 		return nil, err
 	}
 
-	// If an address is provided, listen on the socket for a connection from an
-	// external signing process.
-	if cfg.PrivValidator.ListenAddr != "" {
+	// If threshold addresses are provided, listen on the sockets for
+	// connections from, or dial the gRPC servers of, the external signing
+	// processes holding the shares of a threshold key. If an address is provided, listen on the socket for a
+	// connection from an external signing process.
+	if len(cfg.PrivValidator.ThresholdListenAddrs) > 0 {
+		privValidator, err = createAndStartPrivValidatorThresholdClient(cfg, genDoc.ChainID, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error with private validator threshold client: %w", err)
+		}
+	} else if cfg.PrivValidator.ListenAddr != "" {
 		protocol, _ := tmnet.ProtocolAndAddress(cfg.PrivValidator.ListenAddr)
 		// FIXME: we should start services inside OnStart
 		switch protocol {
@@ -180,6 +229,14 @@ func makeNode(cfg *config.Config,
 				return nil, fmt.Errorf("error with private validator grpc client: %w", err)
 			}
 		default:
+			if len(cfg.PrivValidator.FailoverListenAddrs) > 0 {
+				privValidator, err = createAndStartPrivValidatorFailoverClient(
+					cfg.PrivValidator, genDoc.ChainID, logger, nodeMetrics.privval)
+				if err != nil {
+					return nil, fmt.Errorf("error with private validator failover client: %w", err)
+				}
+				break
+			}
 			privValidator, err = createAndStartPrivValidatorSocketClient(cfg.PrivValidator.ListenAddr, genDoc.ChainID, logger)
 			if err != nil {
 				return nil, fmt.Errorf("error with private validator socket client: %w", err)
@@ -208,7 +265,10 @@ func makeNode(cfg *config.Config,
 	// and replays any blocks as necessary to sync tendermint with the app.
 	consensusLogger := logger.With("module", "consensus")
 	if !stateSync {
-		if err := doHandshake(stateStore, state, blockStore, genDoc, eventBus, proxyApp, consensusLogger); err != nil {
+		if err := doHandshake(
+			stateStore, state, blockStore, genDoc, genesisStore, eventBus, proxyApp, consensusLogger,
+			cfg.MismatchReportFile(),
+		); err != nil {
 			return nil, err
 		}
 
@@ -221,6 +281,14 @@ func makeNode(cfg *config.Config,
 		}
 	}
 
+	// app_state is only needed by InitChain, and is read from the genesis
+	// store when the genesis doc is served: don't hold it in memory
+	if len(genDoc.AppState) > 0 {
+		withoutAppState := *genDoc
+		withoutAppState.AppState = nil
+		genDoc = &withoutAppState
+	}
+
 	// Determine whether we should do block sync. This must happen after the handshake, since the
 	// app may modify the validator set, specifying ourself as the only validator.
 	blockSync := cfg.BlockSync.Enable && !onlyValidatorIsUs(state, pubKey)
@@ -242,17 +310,20 @@ func makeNode(cfg *config.Config,
 		return nil, fmt.Errorf("failed to create peer manager: %w", err)
 	}
 
-	nodeMetrics :=
-		defaultMetricsProvider(cfg.Instrumentation)(genDoc.ChainID)
-
+	routerOpts, err := getRouterConfig(cfg, proxyApp, p2pLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create router: %w", err)
+	}
 	router, err := createRouter(p2pLogger, nodeMetrics.p2p, nodeInfo, nodeKey.PrivKey,
-		peerManager, transport, getRouterConfig(cfg, proxyApp))
+		peerManager, transport, routerOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create router: %w", err)
 	}
+	router.SetEventBus(eventBus)
 
+	seenTxs := createSeenTxCache(cfg.Mempool, logger.With("module", "mempool"))
 	mpReactorShim, mpReactor, mp, err := createMempoolReactor(
-		cfg, proxyApp, state, nodeMetrics.mempool, peerManager, router, logger,
+		cfg, proxyApp, state, nodeMetrics.mempool, seenTxs, peerManager, router, logger,
 	)
 	if err != nil {
 		return nil, err
@@ -265,6 +336,23 @@ func makeNode(cfg *config.Config,
 		return nil, err
 	}
 
+	shareReactorShim, shareReactor := createShareReactor(cfg, blockStore, peerManager, router, logger)
+
+	// prune the heights the application doesn't retain in the background
+	prunerOptions := []sm.PrunerOption{
+		sm.PrunerWithMetrics(nodeMetrics.state),
+		sm.PrunerWithCompaction(blockStoreDB, stateDB),
+	}
+	if cfg.ArchiveRetainBlocks > 0 {
+		prunerOptions = append(prunerOptions, sm.PrunerWithArchive(blockStore, cfg.ArchiveRetainBlocks))
+	}
+	pruner := sm.NewPruner(stateStore, blockStore, logger.With("module", "pruner"), prunerOptions...)
+
+	info, err := appInfo(proxyApp)
+	if err != nil {
+		return nil, err
+	}
+
 	// make block executor for consensus and blockchain reactors to execute blocks
 	blockExec := sm.NewBlockExecutor(
 		stateStore,
@@ -274,6 +362,12 @@ func makeNode(cfg *config.Config,
 		evPool,
 		blockStore,
 		sm.BlockExecutorWithMetrics(nodeMetrics.state),
+		sm.BlockExecutorWithRetainResultsBlocks(cfg.RetainResultsBlocks),
+		sm.BlockExecutorWithPruner(pruner),
+		sm.BlockExecutorWithFinalizeBlock(info.FinalizeBlock),
+		sm.BlockExecutorWithProcessProposal(info.ProcessProposal),
+		sm.BlockExecutorWithPipelineCommit(cfg.PipelineCommit),
+		sm.BlockExecutorWithMismatchReportFile(cfg.MismatchReportFile()),
 	)
 
 	csReactorShim, csReactor, csState := createConsensusReactor(
@@ -348,6 +442,11 @@ func makeNode(cfg *config.Config,
 		nodeMetrics.statesync,
 	)
 
+	// advertise the earliest block of the node, as it prunes its blocks, and
+	// whether it serves snapshots
+	nodeInfoOther := makeNodeInfoOther(nodeInfo.Other, blockStore, stateSyncReactor)
+	router.SetNodeInfoOther(nodeInfoOther)
+
 	// add the channel descriptors to both the transports
 	// FIXME: This should be removed when the legacy p2p stack is removed and
 	// transports can either be agnostic to channel descriptors or can be
@@ -356,8 +455,14 @@ func makeNode(cfg *config.Config,
 	transport.AddChannelDescriptors(bcReactorForSwitch.GetChannels())
 	transport.AddChannelDescriptors(csReactorShim.GetChannels())
 	transport.AddChannelDescriptors(evReactorShim.GetChannels())
+	transport.AddChannelDescriptors(shareReactorShim.GetChannels())
 	transport.AddChannelDescriptors(stateSyncReactorShim.GetChannels())
 
+	customReactors, err := createCustomReactors(cfg, opts.reactors, router, transport)
+	if err != nil {
+		return nil, err
+	}
+
 	// Optionally, start the pex reactor
 	//
 	// TODO:
@@ -384,8 +489,10 @@ func makeNode(cfg *config.Config,
 		// setup Transport and Switch
 		sw = createSwitch(
 			cfg, transport, nodeMetrics.p2p, mpReactorShim, bcReactorForSwitch,
-			stateSyncReactorShim, csReactorShim, evReactorShim, proxyApp, nodeInfo, nodeKey, p2pLogger,
+			stateSyncReactorShim, csReactorShim, evReactorShim, shareReactorShim, proxyApp, nodeInfo, nodeKey,
+			p2pLogger,
 		)
+		sw.SetEventBus(eventBus)
 
 		err = sw.AddPersistentPeers(strings.SplitAndTrimEmpty(cfg.P2P.PersistentPeers, ",", " "))
 		if err != nil {
@@ -402,7 +509,9 @@ func makeNode(cfg *config.Config,
 			return nil, fmt.Errorf("could not create addrbook: %w", err)
 		}
 
-		pexReactor = createPEXReactorAndAddToSwitch(addrBook, cfg, sw, logger)
+		legacyPEXReactor := createPEXReactorAndAddToSwitch(addrBook, cfg, sw, logger)
+		legacyPEXReactor.SetEventBus(eventBus)
+		pexReactor = legacyPEXReactor
 	} else {
 		addrBook = nil
 		pexReactor, err = createPEXReactorV2(cfg, logger, peerManager, router)
@@ -431,18 +540,30 @@ func makeNode(cfg *config.Config,
 		nodeInfo:    nodeInfo,
 		nodeKey:     nodeKey,
 
+		nodeInfoOther: nodeInfoOther,
+
 		stateStore:       stateStore,
 		blockStore:       blockStore,
+		pruner:           pruner,
+		blockExec:        blockExec,
 		bcReactor:        bcReactor,
 		mempoolReactor:   mpReactor,
 		mempool:          mp,
+		seenTxs:          seenTxs,
 		consensusReactor: csReactor,
 		stateSyncReactor: stateSyncReactor,
 		stateSync:        stateSync,
 		pexReactor:       pexReactor,
 		evidenceReactor:  evReactor,
+		shareReactor:     shareReactor,
+		customReactors:   customReactors,
 		indexerService:   indexerService,
 		eventBus:         eventBus,
+		rpcMetrics:       nodeMetrics.rpc,
+		startHooks:       opts.startHooks,
+		stopHooks:        opts.stopHooks,
+
+		metricsRegisterer: opts.registerer,
 
 		rpcEnv: &rpccore.Environment{
 			ProxyAppQuery:   proxyApp.Query(),
@@ -452,6 +573,7 @@ func makeNode(cfg *config.Config,
 			BlockStore:     blockStore,
 			EvidencePool:   evPool,
 			ConsensusState: csState,
+			BlockExecutor:  blockExec,
 
 			ConsensusReactor: csReactor,
 			BlockSyncReactor: bcReactor.(consensus.BlockSyncReactor),
@@ -459,14 +581,23 @@ func makeNode(cfg *config.Config,
 			P2PPeers:    sw,
 			PeerManager: peerManager,
 
-			GenDoc:     genDoc,
-			EventSinks: eventSinks,
-			EventBus:   eventBus,
-			Mempool:    mp,
-			Logger:     logger.With("module", "rpc"),
-			Config:     *cfg.RPC,
+			GenDoc:         genDoc,
+			GenesisStore:   genesisStore,
+			EventSinks:     eventSinks,
+			IndexerService: indexerService,
+			EventBus:       eventBus,
+			Mempool:        mp,
+			Logger:         logger.With("module", "rpc"),
+			Config:         *cfg.RPC,
+			ConfigLoader:   configFileLoader(cfg.RootDir),
+
+			ConsensusConfig: cfg.Consensus,
+			ProofRuntime:    merkle.DefaultProofRuntime(),
 		},
 	}
+	if opts.proofRuntime != nil {
+		node.rpcEnv.ProofRuntime = opts.proofRuntime
+	}
 
 	// this is a terrible, because typed nil interfaces are not ==
 	// nil, so this is just cleanup to avoid having a non-nil
@@ -480,6 +611,8 @@ func makeNode(cfg *config.Config,
 	// end hack
 
 	node.rpcEnv.P2PTransport = node
+	node.rpcEnv.ConfigReloader = node.applyConfig
+	node.rpcEnv.DebugBundler = node.SaveDebugBundle
 
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
@@ -503,6 +636,13 @@ func makeSeedNode(cfg *config.Config,
 	if err != nil {
 		return nil, err
 	}
+	genesisHash, err := genDoc.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the genesis doc: %w", err)
+	}
+	if err := checkGenesisHash(cfg, genDoc.ChainID, genesisHash, logger); err != nil {
+		return nil, err
+	}
 
 	nodeInfo, err := makeSeedNodeInfo(cfg, nodeKey, genDoc, state)
 	if err != nil {
@@ -510,7 +650,8 @@ func makeSeedNode(cfg *config.Config,
 	}
 
 	// Setup Transport and Switch.
-	p2pMetrics := p2p.PrometheusMetrics(cfg.Instrumentation.Namespace, "chain_id", genDoc.ChainID)
+	p2pMetrics := p2p.PrometheusMetrics(cfg.Instrumentation.Namespace, "chain_id", genDoc.ChainID).
+		LimitPeerLabels(cfg.Instrumentation.MaxPeerMetrics)
 	p2pLogger := logger.With("module", "p2p")
 	transport := createTransport(p2pLogger, cfg)
 
@@ -519,8 +660,12 @@ func makeSeedNode(cfg *config.Config,
 		return nil, fmt.Errorf("failed to create peer manager: %w", err)
 	}
 
+	routerOpts, err := getRouterConfig(cfg, nil, p2pLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create router: %w", err)
+	}
 	router, err := createRouter(p2pLogger, p2pMetrics, nodeInfo, nodeKey.PrivKey,
-		peerManager, transport, getRouterConfig(cfg, nil))
+		peerManager, transport, routerOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create router: %w", err)
 	}
@@ -541,7 +686,7 @@ func makeSeedNode(cfg *config.Config,
 	if cfg.P2P.UseLegacy {
 		sw = createSwitch(
 			cfg, transport, p2pMetrics, nil, nil,
-			nil, nil, nil, nil, nodeInfo, nodeKey, p2pLogger,
+			nil, nil, nil, nil, nil, nodeInfo, nodeKey, p2pLogger,
 		)
 
 		err = sw.AddPersistentPeers(strings.SplitAndTrimEmpty(cfg.P2P.PersistentPeers, ",", " "))
@@ -612,11 +757,26 @@ func (n *nodeImpl) OnStart() error {
 		n.rpcListeners = listeners
 	}
 
-	if n.config.Instrumentation.Prometheus &&
+	if (n.config.Instrumentation.Prometheus || n.metricsRegisterer != nil) &&
 		n.config.Instrumentation.PrometheusListenAddr != "" {
 		n.prometheusSrv = n.startPrometheusServer(n.config.Instrumentation.PrometheusListenAddr)
 	}
 
+	if n.config.Instrumentation.OTel.Enabled() {
+		_, gatherer := n.prometheusRegistry()
+		n.otelExporter = telemetry.NewExporter(
+			n.config.Instrumentation.OTel,
+			gatherer,
+			n.Logger.With("module", "otel"),
+			attribute.String("service.instance.id", string(n.nodeKey.ID)),
+			attribute.String("chain_id", n.genesisDoc.ChainID),
+			attribute.String("moniker", n.config.Moniker),
+		)
+		if err := n.otelExporter.Start(); err != nil {
+			return fmt.Errorf("failed to start the OpenTelemetry exporter: %w", err)
+		}
+	}
+
 	// Start the transport.
 	addr, err := types.NewNetAddressString(n.nodeKey.ID.AddressString(n.config.P2P.ListenAddress))
 	if err != nil {
@@ -640,6 +800,10 @@ func (n *nodeImpl) OnStart() error {
 	}
 
 	if n.config.Mode != config.ModeSeed {
+		if err := n.pruner.Start(); err != nil {
+			return err
+		}
+
 		if n.config.BlockSync.Version == config.BlockSyncV0 {
 			if err := n.bcReactor.Start(); err != nil {
 				return err
@@ -665,6 +829,17 @@ func (n *nodeImpl) OnStart() error {
 		if err := n.evidenceReactor.Start(); err != nil {
 			return err
 		}
+
+		// Start the real share reactor separately since the switch uses the shim.
+		if err := n.shareReactor.Start(); err != nil {
+			return err
+		}
+
+		for _, reactor := range n.customReactors {
+			if err := reactor.Start(); err != nil {
+				return err
+			}
+		}
 	}
 
 	if n.config.P2P.UseLegacy {
@@ -744,6 +919,12 @@ func (n *nodeImpl) OnStart() error {
 		}()
 	}
 
+	for _, hook := range n.startHooks {
+		if err := hook(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -752,6 +933,20 @@ func (n *nodeImpl) OnStop() {
 
 	n.Logger.Info("Stopping Node")
 
+	for _, hook := range n.stopHooks {
+		hook()
+	}
+
+	// once the RPC server is drained, the load balancers send no new requests
+	// to the node: let those in flight finish
+	if n.rpcEnv != nil && n.rpcEnv.Drainer.Draining() {
+		ctx, cancel := context.WithTimeout(context.Background(), n.rpcDrainTimeout)
+		if err := n.rpcEnv.Drainer.Wait(ctx); err != nil {
+			n.Logger.Error("RPC requests still in flight", "err", err)
+		}
+		cancel()
+	}
+
 	// first stop the non-reactor services
 	if err := n.eventBus.Stop(); err != nil {
 		n.Logger.Error("Error closing eventBus", "err", err)
@@ -762,6 +957,12 @@ func (n *nodeImpl) OnStop() {
 
 	if n.config.Mode != config.ModeSeed {
 		// now stop the reactors
+		for _, reactor := range n.customReactors {
+			if err := reactor.Stop(); err != nil {
+				n.Logger.Error("failed to stop a custom reactor", "err", err)
+			}
+		}
+
 		if n.config.BlockSync.Version == config.BlockSyncV0 {
 			// Stop the real blockchain reactor separately since the switch uses the shim.
 			if err := n.bcReactor.Stop(); err != nil {
@@ -783,11 +984,32 @@ func (n *nodeImpl) OnStop() {
 		if err := n.mempoolReactor.Stop(); err != nil {
 			n.Logger.Error("failed to stop the mempool reactor", "err", err)
 		}
+		if n.seenTxs != nil {
+			if err := n.seenTxs.Save(); err != nil {
+				n.Logger.Error("failed to persist the seen txs", "err", err)
+			}
+		}
 
 		// Stop the real evidence reactor separately since the switch uses the shim.
 		if err := n.evidenceReactor.Stop(); err != nil {
 			n.Logger.Error("failed to stop the evidence reactor", "err", err)
 		}
+
+		// Stop the real share reactor separately since the switch uses the shim.
+		if err := n.shareReactor.Stop(); err != nil {
+			n.Logger.Error("failed to stop the share reactor", "err", err)
+		}
+
+		// Wait for the state of the last block to be saved, with the pipelined
+		// commits.
+		if err := n.blockExec.Wait(); err != nil {
+			n.Logger.Error("failed to save the state of the last block", "err", err)
+		}
+
+		// Stop the pruner once no more blocks are executed.
+		if err := n.pruner.Stop(); err != nil {
+			n.Logger.Error("failed to stop the pruner", "err", err)
+		}
 	}
 
 	if err := n.pexReactor.Stop(); err != nil {
@@ -830,6 +1052,12 @@ func (n *nodeImpl) OnStop() {
 			n.Logger.Error("Prometheus HTTP server Shutdown", "err", err)
 		}
 	}
+
+	if n.otelExporter != nil {
+		if err := n.otelExporter.Stop(); err != nil {
+			n.Logger.Error("Error stopping the OpenTelemetry exporter", "err", err)
+		}
+	}
 }
 
 func (n *nodeImpl) startRPC() ([]net.Listener, error) {
@@ -839,10 +1067,12 @@ func (n *nodeImpl) startRPC() ([]net.Listener, error) {
 			return nil, fmt.Errorf("can't get pubkey: %w", err)
 		}
 		n.rpcEnv.PubKey = pubKey
+		n.rpcEnv.PrivValidator = n.privValidator
 	}
 	if err := n.rpcEnv.InitGenesisChunks(); err != nil {
 		return nil, err
 	}
+	n.rpcEnv.InitResponseCache()
 
 	listenAddrs := strings.SplitAndTrimEmpty(n.config.RPC.ListenAddress, ",", " ")
 	routes := n.rpcEnv.GetRoutes()
@@ -862,6 +1092,30 @@ func (n *nodeImpl) startRPC() ([]net.Listener, error) {
 		cfg.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
+	n.rpcEnv.Drainer = rpcserver.NewDrainer()
+	n.rpcDrainTimeout = cfg.WriteTimeout
+
+	var clientMethods rpcserver.ClientMethods
+	if n.config.RPC.IsClientAuthEnabled() {
+		clientCAs, err := rpcserver.LoadCertPool(n.config.RPC.ClientCAFile())
+		if err != nil {
+			return nil, fmt.Errorf("loading the client CAs: %w", err)
+		}
+		cfg.ClientCAs = clientCAs
+		if clientMethods, err = n.config.RPC.ClientAllowedMethods(); err != nil {
+			return nil, err
+		}
+	}
+
+	// the limits are shared by all listeners
+	rateLimiter := rpcserver.NewRateLimiter(rateLimitConfig(n.config.RPC), n.rpcMetrics)
+	n.rpcRateLimiter = rateLimiter
+
+	socketMode, err := n.config.RPC.UnixSocketFileMode()
+	if err != nil {
+		return nil, err
+	}
+
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, listenAddr := range listenAddrs {
@@ -876,27 +1130,30 @@ func (n *nodeImpl) startRPC() ([]net.Listener, error) {
 				}
 			}),
 			rpcserver.ReadLimit(cfg.MaxBodyBytes),
+			rpcserver.RateLimit(rateLimiter),
 		)
 		wm.SetLogger(wmLogger)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
 		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
-		listener, err := rpcserver.Listen(
+		listener, err := rpcserver.ListenWithMode(
 			listenAddr,
 			cfg.MaxOpenConnections,
+			socketMode,
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		var rootHandler http.Handler = mux
-		if n.config.RPC.IsCorsEnabled() {
-			corsMiddleware := cors.New(cors.Options{
-				AllowedOrigins: n.config.RPC.CORSAllowedOrigins,
-				AllowedMethods: n.config.RPC.CORSAllowedMethods,
-				AllowedHeaders: n.config.RPC.CORSAllowedHeaders,
-			})
-			rootHandler = corsMiddleware.Handler(mux)
-		}
+		corsHandler := newCORSHandler(rpcserver.DrainHandler(
+			rpcserver.ClientAuthHandler(
+				rpcserver.RateLimitHandler(mux, rateLimiter, rpcLogger),
+				clientMethods,
+				rpcLogger,
+			),
+			n.rpcEnv.Drainer,
+		), n.config.RPC)
+		n.rpcCORSHandlers = append(n.rpcCORSHandlers, corsHandler)
+		var rootHandler http.Handler = corsHandler
 		if n.config.RPC.IsTLSEnabled() {
 			go func() {
 				if err := rpcserver.ServeTLS(
@@ -940,7 +1197,7 @@ func (n *nodeImpl) startRPC() ([]net.Listener, error) {
 		if cfg.WriteTimeout <= n.config.RPC.TimeoutBroadcastTxCommit {
 			cfg.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 		}
-		listener, err := rpcserver.Listen(grpcListenAddr, cfg.MaxOpenConnections)
+		listener, err := rpcserver.ListenWithMode(grpcListenAddr, cfg.MaxOpenConnections, socketMode)
 		if err != nil {
 			return nil, err
 		}
@@ -960,11 +1217,12 @@ func (n *nodeImpl) startRPC() ([]net.Listener, error) {
 // startPrometheusServer starts a Prometheus HTTP server, listening for metrics
 // collectors on addr.
 func (n *nodeImpl) startPrometheusServer(addr string) *http.Server {
+	registerer, gatherer := n.prometheusRegistry()
 	srv := &http.Server{
 		Addr: addr,
 		Handler: promhttp.InstrumentMetricHandler(
-			prometheus.DefaultRegisterer, promhttp.HandlerFor(
-				prometheus.DefaultGatherer,
+			registerer, promhttp.HandlerFor(
+				gatherer,
 				promhttp.HandlerOpts{MaxRequestsInFlight: n.config.Instrumentation.MaxOpenConnections},
 			),
 		),
@@ -978,6 +1236,19 @@ func (n *nodeImpl) startPrometheusServer(addr string) *http.Server {
 	return srv
 }
 
+// prometheusRegistry returns the registerer of the metrics of the node, set
+// by the embedder or the default one, and the gatherer to read them.
+func (n *nodeImpl) prometheusRegistry() (prometheus.Registerer, prometheus.Gatherer) {
+	registerer, gatherer := prometheus.DefaultRegisterer, prometheus.DefaultGatherer
+	if n.metricsRegisterer != nil {
+		registerer = n.metricsRegisterer
+		if g, ok := n.metricsRegisterer.(prometheus.Gatherer); ok {
+			gatherer = g
+		}
+	}
+	return registerer, gatherer
+}
+
 // ConsensusReactor returns the Node's ConsensusReactor.
 func (n *nodeImpl) ConsensusReactor() *consensus.Reactor {
 	return n.consensusReactor
@@ -1023,7 +1294,11 @@ func (n *nodeImpl) IsListening() bool {
 
 // NodeInfo returns the Node's Info from the Switch.
 func (n *nodeImpl) NodeInfo() types.NodeInfo {
-	return n.nodeInfo
+	nodeInfo := n.nodeInfo
+	if n.nodeInfoOther != nil {
+		nodeInfo.Other = n.nodeInfoOther()
+	}
+	return nodeInfo
 }
 
 // genesisDocProvider returns a GenesisDoc.
@@ -1045,23 +1320,21 @@ type nodeMetrics struct {
 	mempool   *mempool.Metrics
 	state     *sm.Metrics
 	statesync *statesync.Metrics
+	rpc       *rpcserver.Metrics
+	privval   *privval.Metrics
+	pubsub    *tmpubsub.Metrics
 }
 
-// metricsProvider returns consensus, p2p, mempool, state, statesync Metrics.
+// metricsProvider returns consensus, p2p, mempool, state, statesync, rpc,
+// privval, pubsub Metrics.
 type metricsProvider func(chainID string) *nodeMetrics
 
 // defaultMetricsProvider returns Metrics build using Prometheus client library
 // if Prometheus is enabled. Otherwise, it returns no-op Metrics.
 func defaultMetricsProvider(cfg *config.InstrumentationConfig) metricsProvider {
 	return func(chainID string) *nodeMetrics {
-		if cfg.Prometheus {
-			return &nodeMetrics{
-				consensus.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				p2p.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				mempool.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				sm.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				statesync.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-			}
+		if cfg.Prometheus || cfg.OTel.Enabled() && cfg.OTel.Metrics {
+			return prometheusMetrics(cfg, chainID)
 		}
 		return &nodeMetrics{
 			consensus.NopMetrics(),
@@ -1069,10 +1342,29 @@ func defaultMetricsProvider(cfg *config.InstrumentationConfig) metricsProvider {
 			mempool.NopMetrics(),
 			sm.NopMetrics(),
 			statesync.NopMetrics(),
+			rpcserver.NopMetrics(),
+			privval.NopMetrics(),
+			tmpubsub.NopMetrics(),
 		}
 	}
 }
 
+// prometheusMetrics returns the Prometheus metrics of the node, registered
+// with the default registerer.
+func prometheusMetrics(cfg *config.InstrumentationConfig, chainID string) *nodeMetrics {
+	namespace := cfg.Namespace
+	return &nodeMetrics{
+		consensus.PrometheusMetrics(namespace, "chain_id", chainID),
+		p2p.PrometheusMetrics(namespace, "chain_id", chainID).LimitPeerLabels(cfg.MaxPeerMetrics),
+		mempool.PrometheusMetrics(namespace, "chain_id", chainID),
+		sm.PrometheusMetrics(namespace, "chain_id", chainID),
+		statesync.PrometheusMetrics(namespace, "chain_id", chainID),
+		rpcserver.PrometheusMetrics(namespace, "chain_id", chainID),
+		privval.PrometheusMetrics(namespace, "chain_id", chainID),
+		tmpubsub.PrometheusMetrics(namespace, "chain_id", chainID),
+	}
+}
+
 //------------------------------------------------------------------------------
 
 // loadStateFromDBOrGenesisDocProvider attempts to load the state from the
@@ -1131,6 +1423,83 @@ func createAndStartPrivValidatorSocketClient(
 	return pvscWithRetries, nil
 }
 
+func createAndStartPrivValidatorFailoverClient(
+	cfg *config.PrivValidatorConfig,
+	chainID string,
+	logger log.Logger,
+	metrics *privval.Metrics,
+) (types.PrivValidator, error) {
+	listenAddrs := append([]string{cfg.ListenAddr}, cfg.FailoverListenAddrs...)
+	clients := make([]*privval.SignerClient, len(listenAddrs))
+	for i, listenAddr := range listenAddrs {
+		pve, err := privval.NewSignerListener(listenAddr, logger.With("endpoint", i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+
+		clients[i], err = privval.NewSignerClient(pve, chainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+	}
+
+	pvfc, err := privval.NewFailoverSignerClient(
+		clients,
+		privval.FailoverSignerClientWithLogger(logger.With("module", "privval")),
+		privval.FailoverSignerClientWithMetrics(metrics),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start private validator: %w", err)
+	}
+
+	// try to get a pubkey from private validate first time
+	_, err = pvfc.GetPubKey(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	}
+
+	return pvfc, nil
+}
+
+func createAndStartPrivValidatorThresholdClient(
+	cfg *config.Config,
+	chainID string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	pvCfg := cfg.PrivValidator
+	signers := make([]privval.ThresholdRemoteSigner, len(pvCfg.ThresholdListenAddrs))
+	for i, addr := range pvCfg.ThresholdListenAddrs {
+		signerLogger := logger.With("signer", i)
+		if protocol, _ := tmnet.ProtocolAndAddress(addr); protocol == "grpc" {
+			pvsc, err := tmgrpc.DialRemoteSignerAddr(pvCfg, addr, chainID, signerLogger,
+				cfg.Instrumentation.Prometheus)
+			if err != nil {
+				return nil, fmt.Errorf("failed to start private validator: %w", err)
+			}
+			signers[i] = pvsc
+			continue
+		}
+
+		pve, err := privval.NewSignerListener(addr, signerLogger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+
+		signers[i], err = privval.NewSignerClient(pve, chainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+	}
+
+	return privval.NewThresholdSignerClient(
+		context.TODO(),
+		signers,
+		pvCfg.Threshold,
+		pvCfg.ThresholdTimeout,
+		pvCfg.ThresholdRetries,
+	)
+}
+
 func createAndStartPrivValidatorGRPCClient(
 	cfg *config.Config,
 	chainID string,
@@ -1155,11 +1524,20 @@ func createAndStartPrivValidatorGRPCClient(
 	return pvsc, nil
 }
 
-func getRouterConfig(conf *config.Config, proxyApp proxy.AppConns) p2p.RouterOptions {
+func getRouterConfig(conf *config.Config, proxyApp proxy.AppConns, logger log.Logger) (p2p.RouterOptions, error) {
 	opts := p2p.RouterOptions{
 		QueueType: conf.P2P.QueueType,
 	}
 
+	if path := conf.P2P.AuditLogFile(); path != "" {
+		auditLog, err := p2p.NewAuditLog(logger, path,
+			conf.P2P.AuditLogMaxSize, conf.P2P.AuditLogMaxTotalSize)
+		if err != nil {
+			return opts, fmt.Errorf("failed to open the audit log: %w", err)
+		}
+		opts.AuditLog = auditLog
+	}
+
 	if conf.P2P.MaxNumInboundPeers > 0 {
 		opts.MaxIncomingConnectionAttempts = conf.P2P.MaxIncomingConnectionAttempts
 	}
@@ -1195,7 +1573,7 @@ func getRouterConfig(conf *config.Config, proxyApp proxy.AppConns) p2p.RouterOpt
 
 	}
 
-	return opts
+	return opts, nil
 }
 
 // FIXME: Temporary helper function, shims should be removed.
@@ -0,0 +1,237 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Option sets an optional parameter of the node, so that the projects
+// embedding it can replace its components or extend it.
+type Option func(*nodeOptions)
+
+type nodeOptions struct {
+	privValidator types.PrivValidator
+	dbProvider    config.DBProvider
+	registerer    prometheus.Registerer
+	eventBus      *types.EventBus
+	reactors      []customReactor
+	startHooks    []func() error
+	stopHooks     []func()
+	proofRuntime  *merkle.ProofRuntime
+}
+
+func newNodeOptions(options []Option) *nodeOptions {
+	opts := &nodeOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+	return opts
+}
+
+// WithPrivValidator makes the node sign with pv instead of the key file of
+// the config. It has no effect if the config makes the node listen to an
+// external signer.
+func WithPrivValidator(pv types.PrivValidator) Option {
+	return func(opts *nodeOptions) { opts.privValidator = pv }
+}
+
+// WithDBProvider makes the node open its databases with dbProvider instead of
+// the backend of the config, e.g. to keep them in memory.
+func WithDBProvider(dbProvider config.DBProvider) Option {
+	return func(opts *nodeOptions) { opts.dbProvider = dbProvider }
+}
+
+// WithMetricsRegisterer turns the Prometheus metrics of the node on, and
+// registers them with registerer instead of the default registerer, so that
+// several nodes can run in a process. If registerer is also a
+// prometheus.Gatherer, the Prometheus server of the node serves its metrics.
+func WithMetricsRegisterer(registerer prometheus.Registerer) Option {
+	return func(opts *nodeOptions) { opts.registerer = registerer }
+}
+
+// WithEventBus makes the node publish its events to eventBus, which the
+// embedder can subscribe to before the node starts. The node starts the
+// event bus if it isn't running, and stops it when it stops.
+func WithEventBus(eventBus *types.EventBus) Option {
+	return func(opts *nodeOptions) { opts.eventBus = eventBus }
+}
+
+// WithReactor adds a reactor to the node, which exchanges the messages of
+// the given channels with the peers. create is called with the opened
+// channels, by ID, once the node is set up, and the returned service is
+// started after the reactors of the node and stopped before them.
+//
+// Reactors can only be added with the router, not the legacy p2p stack.
+func WithReactor(channels []ChannelDescriptor, create func(map[byte]*Channel) (service.Service, error)) Option {
+	return func(opts *nodeOptions) {
+		opts.reactors = append(opts.reactors, customReactor{channels: channels, create: create})
+	}
+}
+
+// WithStartHook adds a function called once the node has started. The node
+// fails to start if hook returns an error.
+func WithStartHook(hook func() error) Option {
+	return func(opts *nodeOptions) { opts.startHooks = append(opts.startHooks, hook) }
+}
+
+// WithStopHook adds a function called when the node stops, before its
+// services are stopped.
+func WithStopHook(hook func()) Option {
+	return func(opts *nodeOptions) { opts.stopHooks = append(opts.stopHooks, hook) }
+}
+
+// WithProofRuntime makes the node verify the proofs of the queries at past
+// heights with prt, which knows the proof op types of the application,
+// instead of merkle.DefaultProofRuntime.
+func WithProofRuntime(prt *merkle.ProofRuntime) Option {
+	return func(opts *nodeOptions) { opts.proofRuntime = prt }
+}
+
+// ChannelDescriptor describes a p2p channel of a reactor added with
+// WithReactor.
+type ChannelDescriptor struct {
+	// ID must not be used by another reactor of the node.
+	ID byte
+	// MessageType is the type of the messages of the channel.
+	MessageType proto.Message
+	// Priority is the share of the bandwidth of the channel relative to the
+	// others.
+	Priority int
+	// RecvBufferCapacity is the number of received messages buffered, 0 for
+	// the default.
+	RecvBufferCapacity int
+	// RecvMessageCapacity is the largest size of a message, 0 for the default.
+	RecvMessageCapacity int
+}
+
+// Envelope is a message exchanged with peers over a Channel.
+type Envelope struct {
+	From      types.NodeID // sender, set on received messages
+	To        types.NodeID // receiver, ignored if Broadcast
+	Broadcast bool         // send to all the connected peers
+	Message   proto.Message
+}
+
+// ErrChannelClosed is returned by the methods of a Channel once the node has
+// stopped.
+var ErrChannelClosed = errors.New("channel closed")
+
+// Channel exchanges the messages of a reactor added with WithReactor with
+// the peers of the node.
+type Channel struct {
+	ch *p2p.Channel
+}
+
+// Receive waits for a message from a peer.
+func (c *Channel) Receive(ctx context.Context) (Envelope, error) {
+	select {
+	case envelope, ok := <-c.ch.In:
+		if !ok {
+			return Envelope{}, ErrChannelClosed
+		}
+		return Envelope{From: envelope.From, Message: envelope.Message}, nil
+	case <-c.ch.Done():
+		return Envelope{}, ErrChannelClosed
+	case <-ctx.Done():
+		return Envelope{}, ctx.Err()
+	}
+}
+
+// Send queues a message for a peer, or all of them.
+func (c *Channel) Send(ctx context.Context, envelope Envelope) error {
+	select {
+	case c.ch.Out <- p2p.Envelope{To: envelope.To, Broadcast: envelope.Broadcast, Message: envelope.Message}:
+		return nil
+	case <-c.ch.Done():
+		return ErrChannelClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ReportError reports a misbehaving peer, which is disconnected.
+func (c *Channel) ReportError(ctx context.Context, peer types.NodeID, err error) error {
+	select {
+	case c.ch.Error <- p2p.PeerError{NodeID: peer, Err: err}:
+		return nil
+	case <-c.ch.Done():
+		return ErrChannelClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type customReactor struct {
+	channels []ChannelDescriptor
+	create   func(map[byte]*Channel) (service.Service, error)
+}
+
+// createCustomReactors opens the channels of the reactors added with
+// WithReactor, and creates them.
+func createCustomReactors(
+	cfg *config.Config,
+	reactors []customReactor,
+	router *p2p.Router,
+	transport *p2p.MConnTransport,
+) ([]service.Service, error) {
+	if len(reactors) == 0 {
+		return nil, nil
+	}
+	if cfg.P2P.UseLegacy {
+		return nil, errors.New("reactors can't be added with the legacy p2p stack")
+	}
+
+	services := make([]service.Service, 0, len(reactors))
+	for _, reactor := range reactors {
+		channels := make(map[byte]*Channel, len(reactor.channels))
+		for _, desc := range reactor.channels {
+			chDesc := p2p.ChannelDescriptor{
+				ID:                  desc.ID,
+				Priority:            desc.Priority,
+				RecvBufferCapacity:  desc.RecvBufferCapacity,
+				RecvMessageCapacity: desc.RecvMessageCapacity,
+			}.FillDefaults()
+			transport.AddChannelDescriptors([]*p2p.ChannelDescriptor{&chDesc})
+			ch, err := router.OpenChannel(chDesc, desc.MessageType, chDesc.RecvBufferCapacity)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open channel %#x: %w", desc.ID, err)
+			}
+			channels[desc.ID] = &Channel{ch: ch}
+		}
+		srv, err := reactor.create(channels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create reactor: %w", err)
+		}
+		services = append(services, srv)
+	}
+	return services, nil
+}
+
+// registererMtx serializes the swaps of the default Prometheus registerer.
+var registererMtx sync.Mutex
+
+// registeredMetricsProvider returns the Prometheus metrics of the node,
+// registered with registerer.
+func registeredMetricsProvider(cfg *config.InstrumentationConfig, registerer prometheus.Registerer) metricsProvider {
+	return func(chainID string) *nodeMetrics {
+		// the metrics register themselves with the default registerer
+		registererMtx.Lock()
+		defer registererMtx.Unlock()
+		defaultRegisterer := prometheus.DefaultRegisterer
+		prometheus.DefaultRegisterer = registerer
+		defer func() { prometheus.DefaultRegisterer = defaultRegisterer }()
+
+		return prometheusMetrics(cfg, chainID)
+	}
+}
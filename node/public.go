@@ -25,11 +25,14 @@ func NewDefault(conf *config.Config, logger log.Logger) (service.Service, error)
 // process as the tendermint node.  The final option is a pointer to a
 // Genesis document: if the value is nil, the genesis document is read
 // from the file specified in the config, and otherwise the node uses
-// value of the final argument.
+// value of the final argument. The options make it possible to embed the
+// node in other projects: to replace its components, add reactors and hook
+// into its start and stop.
 func New(conf *config.Config,
 	logger log.Logger,
 	cf abciclient.Creator,
 	gen *types.GenesisDoc,
+	options ...Option,
 ) (service.Service, error) {
 	nodeKey, err := types.LoadOrGenNodeKey(conf.NodeKeyFile())
 	if err != nil {
@@ -44,11 +47,20 @@ func New(conf *config.Config,
 		genProvider = func() (*types.GenesisDoc, error) { return gen, nil }
 	}
 
+	opts := newNodeOptions(options)
+	dbProvider := config.DefaultDBProvider
+	if opts.dbProvider != nil {
+		dbProvider = opts.dbProvider
+	}
+
 	switch conf.Mode {
 	case config.ModeFull, config.ModeValidator:
-		pval, err := privval.LoadOrGenFilePV(conf.PrivValidator.KeyFile(), conf.PrivValidator.StateFile())
-		if err != nil {
-			return nil, err
+		pval := opts.privValidator
+		if pval == nil {
+			pval, err = privval.LoadOrGenFilePV(conf.PrivValidator.KeyFile(), conf.PrivValidator.StateFile())
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		return makeNode(conf,
@@ -56,10 +68,11 @@ func New(conf *config.Config,
 			nodeKey,
 			cf,
 			genProvider,
-			config.DefaultDBProvider,
-			logger)
+			dbProvider,
+			logger,
+			options...)
 	case config.ModeSeed:
-		return makeSeedNode(conf, config.DefaultDBProvider, nodeKey, genProvider, logger)
+		return makeSeedNode(conf, dbProvider, nodeKey, genProvider, logger)
 	default:
 		return nil, fmt.Errorf("%q is not a valid mode", conf.Mode)
 	}
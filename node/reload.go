@@ -0,0 +1,153 @@
+package node
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/rs/cors"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+)
+
+// ReloadConfig loads the config of the node again and applies the settings
+// which can change while the node runs, as /unsafe_reload_config does. The
+// node reloads its config on SIGHUP.
+func (n *nodeImpl) ReloadConfig() error {
+	_, err := n.rpcEnv.ReloadConfig()
+	return err
+}
+
+// SetConfigLoader makes the node reload its config with load, which should
+// read it the way the node was configured when it started: the tendermint
+// command binds its flags and environment variables. By default, the node
+// reads its config file again. It must be called before the node starts.
+func (n *nodeImpl) SetConfigLoader(load func() (*config.Config, error)) {
+	n.rpcEnv.ConfigLoader = load
+}
+
+// configFileLoader returns the default loader of the config of the node,
+// which reads the config file in root.
+func configFileLoader(root string) func() (*config.Config, error) {
+	return func() (*config.Config, error) {
+		cfg := config.DefaultConfig()
+		v := viper.New()
+		v.SetConfigFile(cfg.SetRoot(root).ConfigFile())
+		if err := v.ReadInConfig(); err != nil {
+			return nil, err
+		}
+		if err := v.Unmarshal(cfg); err != nil {
+			return nil, err
+		}
+		cfg.SetRoot(root)
+		return cfg, nil
+	}
+}
+
+// applyConfig applies the settings of cfg which differ from the config the
+// node started with and can be changed at runtime. The log level and the
+// limits of the mempool are applied by the RPC environment. It returns the
+// keys of the applied settings, and of those which require a restart.
+func (n *nodeImpl) applyConfig(cfg *config.Config) (applied, requiresRestart []string, err error) {
+	rateLimitChanged, maxConnectionsChanged := false, false
+	for _, key := range n.config.Diff(cfg) {
+		reloadable := false
+		switch key {
+		case "log-level", "log-format", "retain-results-blocks",
+			"rpc.cors-allowed-origins", "rpc.cors-allowed-methods", "rpc.cors-allowed-headers",
+			"rpc.timeout-broadcast-tx-commit", "mempool.size", "mempool.max-txs-bytes":
+			reloadable = true
+		case "archive-retain-blocks":
+			// archiving can't be turned on at runtime
+			reloadable = n.pruner != nil && n.config.ArchiveRetainBlocks > 0
+		case "rpc.rate-limit", "rpc.rate-limit-burst", "rpc.rate-limit-per-ip",
+			"rpc.rate-limit-per-ip-burst", "rpc.rate-limit-weights":
+			// rate limiting can't be turned on at runtime
+			reloadable = n.rpcRateLimiter != nil
+			rateLimitChanged = rateLimitChanged || reloadable
+		case "p2p.max-connections", "p2p.max-num-inbound-peers", "p2p.max-num-outbound-peers":
+			reloadable = n.peerManager != nil
+			maxConnectionsChanged = maxConnectionsChanged || reloadable
+		}
+		if reloadable {
+			applied = append(applied, key)
+		} else {
+			requiresRestart = append(requiresRestart, key)
+		}
+	}
+
+	var maxConnections uint16
+	if maxConnectionsChanged {
+		if maxConnections, err = maxPeerConnections(cfg.P2P); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := log.SetFormat(n.Logger, cfg.LogFormat); err != nil {
+		return nil, nil, err
+	}
+	if n.blockExec != nil {
+		n.blockExec.SetRetainResultsBlocks(cfg.RetainResultsBlocks)
+	}
+	if n.pruner != nil && n.config.ArchiveRetainBlocks > 0 {
+		n.pruner.SetArchiveRetainBlocks(cfg.ArchiveRetainBlocks)
+	}
+	for _, h := range n.rpcCORSHandlers {
+		h.setConfig(cfg.RPC)
+	}
+	if rateLimitChanged {
+		n.rpcRateLimiter.SetConfig(rateLimitConfig(cfg.RPC))
+	}
+	if maxConnectionsChanged {
+		if err := n.peerManager.SetMaxConnected(maxConnections); err != nil {
+			return applied, requiresRestart, err
+		}
+	}
+	return applied, requiresRestart, nil
+}
+
+// rateLimitConfig returns the limits of the RPC server.
+func rateLimitConfig(cfg *config.RPCConfig) rpcserver.RateLimitConfig {
+	return rpcserver.RateLimitConfig{
+		GlobalRate:  cfg.RateLimit,
+		GlobalBurst: cfg.RateLimitBurst,
+		PerIPRate:   cfg.RateLimitPerIP,
+		PerIPBurst:  cfg.RateLimitPerIPBurst,
+		Weights:     cfg.RateLimitWeights,
+	}
+}
+
+// corsHandler applies the CORS settings of the RPC server, which can be
+// changed while it serves requests.
+type corsHandler struct {
+	next    http.Handler
+	handler atomic.Value // handlerBox
+}
+
+// handlerBox wraps the handlers stored in corsHandler, as atomic.Value
+// requires values of a single concrete type.
+type handlerBox struct{ http.Handler }
+
+func newCORSHandler(next http.Handler, cfg *config.RPCConfig) *corsHandler {
+	h := &corsHandler{next: next}
+	h.setConfig(cfg)
+	return h
+}
+
+func (h *corsHandler) setConfig(cfg *config.RPCConfig) {
+	handler := h.next
+	if cfg.IsCorsEnabled() {
+		handler = cors.New(cors.Options{
+			AllowedOrigins: cfg.CORSAllowedOrigins,
+			AllowedMethods: cfg.CORSAllowedMethods,
+			AllowedHeaders: cfg.CORSAllowedHeaders,
+		}).Handler(h.next)
+	}
+	h.handler.Store(handlerBox{handler})
+}
+
+func (h *corsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.handler.Load().(handlerBox).ServeHTTP(w, r)
+}
@@ -3,6 +3,7 @@ package node
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
@@ -22,15 +23,19 @@ import (
 	"github.com/tendermint/tendermint/internal/mempool"
 	mempoolv0 "github.com/tendermint/tendermint/internal/mempool/v0"
 	mempoolv1 "github.com/tendermint/tendermint/internal/mempool/v1"
+	"github.com/tendermint/tendermint/internal/migrate"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/p2p/pex"
 	"github.com/tendermint/tendermint/internal/proxy"
+	"github.com/tendermint/tendermint/internal/share"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/internal/state/indexer/sink"
 	"github.com/tendermint/tendermint/internal/statesync"
 	"github.com/tendermint/tendermint/internal/store"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/libs/log"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/libs/service"
 	tmstrings "github.com/tendermint/tendermint/libs/strings"
 	protop2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
@@ -40,20 +45,41 @@ import (
 	_ "net/http/pprof" // nolint: gosec // securely exposed on separate, optional port
 )
 
-func initDBs(cfg *config.Config, dbProvider config.DBProvider) (blockStore *store.BlockStore, stateDB dbm.DB, err error) { //nolint:lll
-	var blockStoreDB dbm.DB
+func initDBs(
+	cfg *config.Config,
+	dbProvider config.DBProvider,
+) (blockStore *store.BlockStore, blockStoreDB, stateDB dbm.DB, err error) {
 	blockStoreDB, err = dbProvider(&config.DBContext{ID: "blockstore", Config: cfg})
 	if err != nil {
 		return
 	}
-	blockStore = store.NewBlockStore(blockStoreDB)
+	if err = migrate.BlockStore.Check(blockStoreDB); err != nil {
+		return
+	}
+	archive, err := store.ArchiveFromConfig(cfg.BaseConfig)
+	if err != nil {
+		return
+	}
+	blockStore = store.NewBlockStore(blockStoreDB, store.BlockStoreWithArchive(archive))
 
 	stateDB, err = dbProvider(&config.DBContext{ID: "state", Config: cfg})
+	if err != nil {
+		return
+	}
+	err = migrate.State.Check(stateDB)
 	return
 }
 
-func createAndStartProxyAppConns(clientCreator abciclient.Creator, logger log.Logger) (proxy.AppConns, error) {
-	proxyApp := proxy.NewAppConns(clientCreator)
+func createAndStartProxyAppConns(
+	clientCreator abciclient.Creator,
+	cfg *config.Config,
+	logger log.Logger,
+) (proxy.AppConns, error) {
+	proxyApp := proxy.NewAppConns(clientCreator,
+		proxy.AppConnsWithResponseValidation(cfg.ABCIResponseValidation),
+		proxy.AppConnsWithMempoolConnections(cfg.Mempool.ABCIConnections),
+		proxy.AppConnsWithHistoricalQueryConnections(cfg.RPC.HistoricalQueryConnections),
+	)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
 		return nil, fmt.Errorf("error starting proxy app connections: %v", err)
@@ -61,8 +87,8 @@ func createAndStartProxyAppConns(clientCreator abciclient.Creator, logger log.Lo
 	return proxyApp, nil
 }
 
-func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
-	eventBus := types.NewEventBus()
+func createAndStartEventBus(logger log.Logger, historySize int, metrics *tmpubsub.Metrics) (*types.EventBus, error) {
+	eventBus := types.NewEventBusWithOptions(tmpubsub.EventHistory(historySize), tmpubsub.WithMetrics(metrics))
 	eventBus.SetLogger(logger.With("module", "events"))
 	if err := eventBus.Start(); err != nil {
 		return nil, err
@@ -92,24 +118,90 @@ func createAndStartIndexerService(
 	return indexerService, eventSinks, nil
 }
 
+// loadGenesisDoc returns the genesis doc saved in the state DB, without its
+// app_state, or else loads it with the provider and saves it, so that a large
+// genesis file is only parsed on the first start. It also returns the
+// canonical hash of the genesis doc.
+func loadGenesisDoc(
+	genesisStore *sm.GenesisStore,
+	provider genesisDocProvider,
+	logger log.Logger,
+) (*types.GenesisDoc, []byte, error) {
+	genDoc, hash, err := genesisStore.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load the saved genesis doc: %w", err)
+	}
+	if genDoc != nil {
+		logger.Debug("loaded the genesis doc from the state DB")
+		return genDoc, hash, nil
+	}
+
+	genDoc, err = provider()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, nil, fmt.Errorf("error in genesis doc: %w", err)
+	}
+	if hash, err = genDoc.Hash(); err != nil {
+		return nil, nil, fmt.Errorf("failed to hash the genesis doc: %w", err)
+	}
+	if err := genesisStore.Save(genDoc, hash); err != nil {
+		return nil, nil, fmt.Errorf("failed to save the genesis doc: %w", err)
+	}
+	return genDoc, hash, nil
+}
+
+// checkGenesisHash logs the canonical hash of the genesis doc, and checks it
+// against the expected one, if configured.
+func checkGenesisHash(cfg *config.Config, chainID string, hash []byte, logger log.Logger) error {
+	logger.Info("loaded genesis", "chain_id", chainID, "hash", tmbytes.HexBytes(hash))
+
+	if cfg.ExpectedGenesisHash == "" {
+		return nil
+	}
+	expected, err := hex.DecodeString(cfg.ExpectedGenesisHash)
+	if err != nil {
+		return fmt.Errorf("invalid expected-genesis-hash: %w", err)
+	}
+	if !bytes.Equal(expected, hash) {
+		return fmt.Errorf("genesis hash %X doesn't match expected-genesis-hash %X", hash, expected)
+	}
+	return nil
+}
+
 func doHandshake(
 	stateStore sm.Store,
 	state sm.State,
 	blockStore sm.BlockStore,
 	genDoc *types.GenesisDoc,
+	genesisStore *sm.GenesisStore,
 	eventBus types.BlockEventPublisher,
 	proxyApp proxy.AppConns,
-	consensusLogger log.Logger) error {
+	consensusLogger log.Logger,
+	mismatchReportFile string) error {
 
 	handshaker := consensus.NewHandshaker(stateStore, state, blockStore, genDoc)
 	handshaker.SetLogger(consensusLogger)
 	handshaker.SetEventBus(eventBus)
+	handshaker.SetMismatchReportFile(mismatchReportFile)
+	handshaker.SetAppStateLoader(func() ([]byte, error) { return genesisStore.LoadAppState() })
 	if err := handshaker.Handshake(proxyApp); err != nil {
-		return fmt.Errorf("error during handshake: %v", err)
+		return fmt.Errorf("error during handshake: %w", err)
 	}
 	return nil
 }
 
+// appInfo returns the Info of the app, which says how it executes the blocks
+// and whether it checks the proposed ones.
+func appInfo(proxyApp proxy.AppConns) (*abci.ResponseInfo, error) {
+	res, err := proxyApp.Query().InfoSync(context.Background(), proxy.RequestInfo)
+	if err != nil {
+		return nil, fmt.Errorf("error calling Info: %w", err)
+	}
+	return res, nil
+}
+
 func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger, consensusLogger log.Logger, mode string) {
 	// Log the version info.
 	logger.Info("Version info",
@@ -149,11 +241,27 @@ func onlyValidatorIsUs(state sm.State, pubKey crypto.PubKey) bool {
 	return pubKey != nil && bytes.Equal(pubKey.Address(), addr)
 }
 
+// createSeenTxCache creates the cache of the txs seen by the mempool,
+// persisted across restarts, or returns nil if it isn't persisted.
+func createSeenTxCache(cfg *config.MempoolConfig, logger log.Logger) *mempool.SeenTxCache {
+	if cfg.SeenTxsFile == "" || cfg.CacheSize == 0 {
+		return nil
+	}
+	cache := mempool.NewSeenTxCache(cfg.CacheSize, cfg.SeenTxsFilePath(),
+		cfg.SeenTxsFalsePositiveRate, cfg.SeenTxsMaxAge)
+	if err := cache.Load(); err != nil {
+		// the txs are checked again, as without the file
+		logger.Error("failed to restore the seen txs", "err", err)
+	}
+	return cache
+}
+
 func createMempoolReactor(
 	cfg *config.Config,
 	proxyApp proxy.AppConns,
 	state sm.State,
 	memplMetrics *mempool.Metrics,
+	seenTxs *mempool.SeenTxCache,
 	peerManager *p2p.PeerManager,
 	router *p2p.Router,
 	logger log.Logger,
@@ -178,13 +286,19 @@ func createMempoolReactor(
 
 	switch cfg.Mempool.Version {
 	case config.MempoolV0:
+		options := []mempoolv0.CListMempoolOption{
+			mempoolv0.WithMetrics(memplMetrics),
+			mempoolv0.WithPreCheck(sm.TxPreCheck(state)),
+			mempoolv0.WithPostCheck(sm.TxPostCheck(state)),
+		}
+		if seenTxs != nil {
+			options = append(options, mempoolv0.WithCache(seenTxs))
+		}
 		mp := mempoolv0.NewCListMempool(
 			cfg.Mempool,
 			proxyApp.Mempool(),
 			state.LastBlockHeight,
-			mempoolv0.WithMetrics(memplMetrics),
-			mempoolv0.WithPreCheck(sm.TxPreCheck(state)),
-			mempoolv0.WithPostCheck(sm.TxPostCheck(state)),
+			options...,
 		)
 
 		mp.SetLogger(logger)
@@ -205,14 +319,20 @@ func createMempoolReactor(
 		return reactorShim, reactor, mp, nil
 
 	case config.MempoolV1:
+		options := []mempoolv1.TxMempoolOption{
+			mempoolv1.WithMetrics(memplMetrics),
+			mempoolv1.WithPreCheck(sm.TxPreCheck(state)),
+			mempoolv1.WithPostCheck(sm.TxPostCheck(state)),
+		}
+		if seenTxs != nil {
+			options = append(options, mempoolv1.WithCache(seenTxs))
+		}
 		mp := mempoolv1.NewTxMempool(
 			logger,
 			cfg.Mempool,
 			proxyApp.Mempool(),
 			state.LastBlockHeight,
-			mempoolv1.WithMetrics(memplMetrics),
-			mempoolv1.WithPreCheck(sm.TxPreCheck(state)),
-			mempoolv1.WithPostCheck(sm.TxPostCheck(state)),
+			options...,
 		)
 
 		reactor := mempoolv1.NewReactor(
@@ -280,6 +400,41 @@ func createEvidenceReactor(
 	return reactorShim, evidenceReactor, evidencePool, nil
 }
 
+func createShareReactor(
+	cfg *config.Config,
+	blockStore *store.BlockStore,
+	peerManager *p2p.PeerManager,
+	router *p2p.Router,
+	logger log.Logger,
+) (*p2p.ReactorShim, *share.Reactor) {
+	logger = logger.With("module", "share")
+	reactorShim := p2p.NewReactorShim(logger, "ShareShim", share.ChannelShims)
+
+	var (
+		channels    map[p2p.ChannelID]*p2p.Channel
+		peerUpdates *p2p.PeerUpdates
+	)
+
+	if cfg.P2P.UseLegacy {
+		channels = getChannelsFromShim(reactorShim)
+		peerUpdates = reactorShim.PeerUpdates
+	} else {
+		channels = makeChannelsFromShims(router, share.ChannelShims)
+		peerUpdates = peerManager.Subscribe()
+	}
+
+	shareReactor := share.NewReactor(
+		cfg.P2P,
+		logger,
+		blockStore,
+		channels[share.ShareChannel],
+		channels[share.FraudChannel],
+		peerUpdates,
+	)
+
+	return reactorShim, shareReactor
+}
+
 func createBlockchainReactor(
 	logger log.Logger,
 	cfg *config.Config,
@@ -315,7 +470,7 @@ func createBlockchainReactor(
 		reactor, err := bcv0.NewReactor(
 			logger, state.Copy(), blockExec, blockStore, csReactor,
 			channels[bcv0.BlockSyncChannel], peerUpdates, blockSync,
-			metrics,
+			cfg.BlockSync.ArchiveFile(), metrics,
 		)
 		if err != nil {
 			return nil, nil, err
@@ -376,6 +531,14 @@ func createConsensusReactor(
 		peerUpdates = peerManager.Subscribe()
 	}
 
+	options := []consensus.ReactorOption{
+		consensus.ReactorMetrics(csMetrics),
+		consensus.ReactorParityParts(cfg.Consensus.ErasureCodedParts),
+	}
+	if cfg.Consensus.CompactBlocks {
+		options = append(options, consensus.ReactorCompactBlocks(mp))
+	}
+
 	reactor := consensus.NewReactor(
 		logger,
 		consensusState,
@@ -385,7 +548,7 @@ func createConsensusReactor(
 		channels[consensus.VoteSetBitsChannel],
 		peerUpdates,
 		waitSync,
-		consensus.ReactorMetrics(csMetrics),
+		options...,
 	)
 
 	// Services which will be publishing and/or subscribing for messages (events)
@@ -406,34 +569,41 @@ func createTransport(logger log.Logger, cfg *config.Config) *p2p.MConnTransport
 	)
 }
 
-func createPeerManager(
-	cfg *config.Config,
-	dbProvider config.DBProvider,
-	p2pLogger log.Logger,
-	nodeID types.NodeID,
-) (*p2p.PeerManager, error) {
-
-	var maxConns uint16
-
+// maxPeerConnections returns the maximum number of connected peers of the
+// peer manager.
+func maxPeerConnections(cfg *config.P2PConfig) (uint16, error) {
 	switch {
-	case cfg.P2P.MaxConnections > 0:
-		maxConns = cfg.P2P.MaxConnections
+	case cfg.MaxConnections > 0:
+		return cfg.MaxConnections, nil
 
-	case cfg.P2P.MaxNumInboundPeers > 0 && cfg.P2P.MaxNumOutboundPeers > 0:
-		x := cfg.P2P.MaxNumInboundPeers + cfg.P2P.MaxNumOutboundPeers
+	case cfg.MaxNumInboundPeers > 0 && cfg.MaxNumOutboundPeers > 0:
+		x := cfg.MaxNumInboundPeers + cfg.MaxNumOutboundPeers
 		if x > math.MaxUint16 {
-			return nil, fmt.Errorf(
+			return 0, fmt.Errorf(
 				"max inbound peers (%d) + max outbound peers (%d) exceeds maximum (%d)",
-				cfg.P2P.MaxNumInboundPeers,
-				cfg.P2P.MaxNumOutboundPeers,
+				cfg.MaxNumInboundPeers,
+				cfg.MaxNumOutboundPeers,
 				math.MaxUint16,
 			)
 		}
 
-		maxConns = uint16(x)
+		return uint16(x), nil
 
 	default:
-		maxConns = 64
+		return 64, nil
+	}
+}
+
+func createPeerManager(
+	cfg *config.Config,
+	dbProvider config.DBProvider,
+	p2pLogger log.Logger,
+	nodeID types.NodeID,
+) (*p2p.PeerManager, error) {
+
+	maxConns, err := maxPeerConnections(cfg.P2P)
+	if err != nil {
+		return nil, err
 	}
 
 	privatePeerIDs := make(map[types.NodeID]struct{})
@@ -450,6 +620,17 @@ func createPeerManager(
 		MaxRetryTimePersistent: 5 * time.Minute,
 		RetryTimeJitter:        3 * time.Second,
 		PrivatePeers:           privatePeerIDs,
+		Anchors:                2,
+	}
+
+	// With strict address routability rules, i.e. on public networks, the
+	// peers are bucketed and the outbound connections spread over the address
+	// groups, to make eclipse attacks harder.
+	if cfg.P2P.AddrBookStrict {
+		options.NewBuckets = 32
+		options.TriedBuckets = 8
+		options.BucketSize = 32
+		options.MaxOutboundPerGroup = 2
 	}
 
 	peers := []p2p.NodeAddress{}
@@ -487,6 +668,16 @@ func createPeerManager(
 		}
 	}
 
+	if !cfg.P2P.UseLegacy {
+		migrated, err := pex.MigrateAddrBook(cfg.P2P.AddrBookFile(), peerManager)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate address book: %w", err)
+		}
+		if migrated > 0 {
+			p2pLogger.Info("migrated the legacy address book", "peers", migrated)
+		}
+	}
+
 	return peerManager, nil
 }
 
@@ -520,6 +711,7 @@ func createSwitch(
 	stateSyncReactor *p2p.ReactorShim,
 	consensusReactor *p2p.ReactorShim,
 	evidenceReactor *p2p.ReactorShim,
+	shareReactor *p2p.ReactorShim,
 	proxyApp proxy.AppConns,
 	nodeInfo types.NodeInfo,
 	nodeKey types.NodeKey,
@@ -589,6 +781,7 @@ func createSwitch(
 		sw.AddReactor("BLOCKCHAIN", bcReactor)
 		sw.AddReactor("CONSENSUS", consensusReactor)
 		sw.AddReactor("EVIDENCE", evidenceReactor)
+		sw.AddReactor("SHARE", shareReactor)
 		sw.AddReactor("STATESYNC", stateSyncReactor)
 	}
 
@@ -705,6 +898,8 @@ func makeNodeInfo(
 			byte(consensus.VoteSetBitsChannel),
 			byte(mempool.MempoolChannel),
 			byte(evidence.EvidenceChannel),
+			byte(share.ShareChannel),
+			byte(share.FraudChannel),
 			byte(statesync.SnapshotChannel),
 			byte(statesync.ChunkChannel),
 			byte(statesync.LightBlockChannel),
@@ -721,6 +916,14 @@ func makeNodeInfo(
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
 
+	nodeInfo.Features = []string{p2p.RekeyFeature}
+	if cfg.Consensus.ErasureCodedParts {
+		nodeInfo.Features = append(nodeInfo.Features, consensus.ParityPartsFeature)
+	}
+	if cfg.Consensus.CompactBlocks {
+		nodeInfo.Features = append(nodeInfo.Features, consensus.CompactBlocksFeature)
+	}
+
 	lAddr := cfg.P2P.ExternalAddress
 
 	if lAddr == "" {
@@ -733,6 +936,24 @@ func makeNodeInfo(
 	return nodeInfo, err
 }
 
+// makeNodeInfoOther returns the function returning other, the data of the
+// node info, along with those which change over time: the earliest block the
+// node stores, and whether it serves snapshots.
+func makeNodeInfoOther(
+	other types.NodeInfoOther,
+	blockStore *store.BlockStore,
+	stateSyncReactor *statesync.Reactor,
+) func() types.NodeInfoOther {
+	return func() types.NodeInfoOther {
+		if meta := blockStore.LoadBaseMeta(); meta != nil {
+			other.EarliestBlockHeight = meta.Header.Height
+			other.EarliestAppHash = meta.Header.AppHash
+		}
+		other.ServesSnapshots = stateSyncReactor.ServesSnapshots()
+		return other
+	}
+}
+
 func makeSeedNodeInfo(
 	cfg *config.Config,
 	nodeKey types.NodeKey,
@@ -754,6 +975,7 @@ func makeSeedNodeInfo(
 			TxIndex:    "off",
 			RPCAddress: cfg.RPC.ListenAddress,
 		},
+		Features: []string{p2p.RekeyFeature},
 	}
 
 	if cfg.P2P.PexReactor {
@@ -0,0 +1,74 @@
+package da
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/wrapper"
+)
+
+// VerifyRow checks that shares are all the shares of the row at index of the
+// extended data square, against its row root in dah.
+func VerifyRow(dah *DataAvailabilityHeader, index uint64, shares [][]byte) error {
+	if index >= uint64(len(dah.RowsRoots)) {
+		return fmt.Errorf("row %d out of the %d rows", index, len(dah.RowsRoots))
+	}
+	if err := verifyAxis(dah.RowsRoots[index], index, shares); err != nil {
+		return fmt.Errorf("invalid shares of row %d: %w", index, err)
+	}
+	return nil
+}
+
+// VerifyCol checks that shares are all the shares of the column at index of
+// the extended data square, against its column root in dah.
+func VerifyCol(dah *DataAvailabilityHeader, index uint64, shares [][]byte) error {
+	if index >= uint64(len(dah.ColumnRoots)) {
+		return fmt.Errorf("column %d out of the %d columns", index, len(dah.ColumnRoots))
+	}
+	if err := verifyAxis(dah.ColumnRoots[index], index, shares); err != nil {
+		return fmt.Errorf("invalid shares of column %d: %w", index, err)
+	}
+	return nil
+}
+
+// verifyAxis rebuilds the root of the row, or column, axis from its shares and
+// compares it with root.
+func verifyAxis(root []byte, axis uint64, shares [][]byte) error {
+	width := uint64(len(shares))
+	if width == 0 || width%2 != 0 {
+		return fmt.Errorf("invalid number of shares %d", width)
+	}
+	for i, share := range shares {
+		if len(share) != consts.ShareSize {
+			return fmt.Errorf("share %d must be %d bytes, got %d", i, consts.ShareSize, len(share))
+		}
+	}
+
+	axisRoot, err := computeAxisRoot(axis, shares)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(axisRoot, root) {
+		return fmt.Errorf("root %X does not match with %X", axisRoot, root)
+	}
+	return nil
+}
+
+// computeAxisRoot rebuilds the tree of the row, or column, axis from its
+// shares, the same way as its root, and returns its root.
+func computeAxisRoot(axis uint64, shares [][]byte) (root []byte, err error) {
+	// the tree panics on the shares out of the namespace order
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to rebuild the tree: %v", r)
+		}
+	}()
+	tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(len(shares)) / 2)
+	for i, share := range shares {
+		tree.Push(share, rsmt2d.SquareIndex{Axis: uint(axis), Cell: uint(i)})
+	}
+	return tree.Root(), nil
+}
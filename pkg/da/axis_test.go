@@ -0,0 +1,46 @@
+package da
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+func TestVerifyAxis(t *testing.T) {
+	squareSize := uint64(4)
+	shares := make([][]byte, squareSize*squareSize)
+	for i := range shares {
+		nID := []byte{0, 0, 0, 0, 0, 0, 1, byte(i)}
+		shares[i] = append(nID, bytes.Repeat([]byte{byte(i)}, consts.MsgShareSize)...)
+	}
+	eds, err := ExtendShares(squareSize, shares)
+	require.NoError(t, err)
+	dah := NewDataAvailabilityHeader(eds)
+
+	for i := uint64(0); i < 2*squareSize; i++ {
+		require.NoError(t, VerifyRow(&dah, i, eds.Row(uint(i))), "row %d", i)
+		require.NoError(t, VerifyCol(&dah, i, eds.Col(uint(i))), "column %d", i)
+	}
+
+	// the shares of another axis
+	require.Error(t, VerifyRow(&dah, 1, eds.Row(2)))
+	require.Error(t, VerifyRow(&dah, 1, eds.Col(1)))
+
+	// a tampered share
+	tampered := append([][]byte{}, eds.Row(1)...)
+	tampered[5] = append([]byte{}, tampered[5]...)
+	tampered[5][consts.ShareSize-1] ^= 0xFF
+	require.Error(t, VerifyRow(&dah, 1, tampered))
+
+	// shares out of the namespace order
+	swapped := append([][]byte{}, eds.Row(0)...)
+	swapped[0], swapped[1] = swapped[1], swapped[0]
+	require.Error(t, VerifyRow(&dah, 0, swapped))
+
+	// a share missing, or out of the square
+	require.Error(t, VerifyCol(&dah, 0, eds.Col(0)[1:]))
+	require.Error(t, VerifyCol(&dah, 2*squareSize, eds.Col(0)))
+}
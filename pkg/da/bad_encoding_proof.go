@@ -0,0 +1,233 @@
+package da
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/nmt"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+// BadEncodingProof proves that the row, or column, at Index of the extended
+// data square committed to by the data availability header of the block at
+// Height is not erasure coded: its shares, proven against the roots of their
+// orthogonal axes, are not the erasure coding of the same data, or rebuild an
+// axis whose root isn't the one committed to. The data of such a block can't
+// be recovered from the shares sampled, so light clients halt on them.
+type BadEncodingProof struct {
+	Height int64 `json:"height"`
+	// IsRow tells whether the axis is a row, or a column.
+	IsRow bool   `json:"is_row"`
+	Index uint64 `json:"index"`
+	// Shares are the shares of the axis, nil if missing. At least half of
+	// them are needed to rebuild the axis.
+	Shares []*ProvenShare `json:"shares"`
+}
+
+// ProvenShare is a share of the axis of a BadEncodingProof, with the nodes of
+// the proof of its inclusion in its orthogonal axis.
+type ProvenShare struct {
+	Share []byte   `json:"share"`
+	Nodes [][]byte `json:"nodes"`
+}
+
+// ProveBadEncoding checks the erasure coding of the extended data square of
+// the block at height, given by its shares in row-major order, nil if
+// missing, against dah. The shares are proven against the rows and columns of
+// the square which are complete and match their roots in dah. It returns the
+// proof of the first row, or column, which isn't erasure coded, or nil if
+// there is none among the ones having enough shares proven.
+func ProveBadEncoding(height int64, dah *DataAvailabilityHeader, square [][]byte) (*BadEncodingProof, error) {
+	width := uint64(len(dah.RowsRoots))
+	if uint64(len(dah.ColumnRoots)) != width || uint64(len(square)) != width*width {
+		return nil, fmt.Errorf("%d shares for a %dx%d square", len(square), width, width)
+	}
+
+	rows := make([][][]byte, width)
+	cols := make([][][]byte, width)
+	for i := range cols {
+		cols[i] = make([][]byte, width)
+	}
+	for i := range rows {
+		rows[i] = square[uint64(i)*width : uint64(i+1)*width]
+		for j, share := range rows[i] {
+			cols[j][i] = share
+		}
+	}
+	provenRows := provenAxes(dah.RowsRoots, rows)
+	provenCols := provenAxes(dah.ColumnRoots, cols)
+
+	for _, isRow := range []bool{true, false} {
+		axes, orthogonal, proven := rows, cols, provenCols
+		if !isRow {
+			axes, orthogonal, proven = cols, rows, provenRows
+		}
+		for index := range axes {
+			proof, err := proveAxis(height, isRow, uint64(index), orthogonal, proven)
+			if err != nil {
+				return nil, err
+			}
+			if proof != nil && proof.Verify(dah) == nil {
+				return proof, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// provenAxes returns which of the axes are complete and match their roots.
+func provenAxes(roots [][]byte, axes [][][]byte) []bool {
+	proven := make([]bool, len(axes))
+	for i, shares := range axes {
+		complete := true
+		for _, share := range shares {
+			if share == nil {
+				complete = false
+				break
+			}
+		}
+		proven[i] = complete && verifyAxis(roots[i], uint64(i), shares) == nil
+	}
+	return proven
+}
+
+// proveAxis returns the proof of the axis at index, with its shares in the
+// proven orthogonal axes, or nil if there are not enough of them to rebuild
+// it.
+func proveAxis(height int64, isRow bool, index uint64, orthogonal [][][]byte, proven []bool) (*BadEncodingProof, error) {
+	width := uint64(len(orthogonal))
+	proof := &BadEncodingProof{
+		Height: height,
+		IsRow:  isRow,
+		Index:  index,
+		Shares: make([]*ProvenShare, width),
+	}
+	count := uint64(0)
+	for cell, shares := range orthogonal {
+		if !proven[cell] {
+			continue
+		}
+		leaf, err := proveLeaf(shares, uint64(cell), index, width/2)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prove share %d of axis %d: %w", index, cell, err)
+		}
+		proof.Shares[cell] = &ProvenShare{Share: shares[index], Nodes: leaf.Nodes()}
+		count++
+	}
+	if count < width/2 {
+		return nil, nil
+	}
+	return proof, nil
+}
+
+// ValidateBasic performs basic validation.
+func (p *BadEncodingProof) ValidateBasic() error {
+	if p == nil {
+		return errors.New("nil bad encoding proof")
+	}
+	if p.Height <= 0 {
+		return fmt.Errorf("non-positive height %d", p.Height)
+	}
+	width := uint64(len(p.Shares))
+	if width < minExtendedSquareWidth || width > maxExtendedSquareWidth || width%2 != 0 {
+		return fmt.Errorf("invalid number of shares %d", width)
+	}
+	if p.Index >= width {
+		return fmt.Errorf("axis %d out of the %d axes", p.Index, width)
+	}
+	for cell, share := range p.Shares {
+		if share != nil && len(share.Share) != consts.ShareSize {
+			return fmt.Errorf("share %d must be %d bytes, got %d", cell, consts.ShareSize, len(share.Share))
+		}
+	}
+	return nil
+}
+
+// Verify checks that the proof proves that the axis isn't erasure coded,
+// against dah, the data availability header of the block at Height.
+func (p *BadEncodingProof) Verify(dah *DataAvailabilityHeader) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	width := uint64(len(dah.RowsRoots))
+	if uint64(len(p.Shares)) != width || uint64(len(dah.ColumnRoots)) != width {
+		return fmt.Errorf("%d shares for a %dx%d square", len(p.Shares), width, width)
+	}
+	roots, orthogonalRoots := dah.RowsRoots, dah.ColumnRoots
+	if !p.IsRow {
+		roots, orthogonalRoots = dah.ColumnRoots, dah.RowsRoots
+	}
+
+	shares := make([][]byte, width)
+	count := uint64(0)
+	for cell, share := range p.Shares {
+		if share == nil {
+			continue
+		}
+		row, col := p.Index, uint64(cell)
+		if !p.IsRow {
+			row, col = col, row
+		}
+		nID := leafNamespace(row, col, width, share.Share)
+		proof := nmt.NewInclusionProof(int(p.Index), int(p.Index)+1, share.Nodes, true)
+		if !proof.VerifyInclusion(consts.NewBaseHashFunc(), nID, share.Share, orthogonalRoots[cell]) {
+			return fmt.Errorf("invalid proof of share (%d, %d)", row, col)
+		}
+		shares[cell] = share.Share
+		count++
+	}
+	if count < width/2 {
+		return fmt.Errorf("%d shares proven, %d are needed to rebuild the axis", count, width/2)
+	}
+
+	rebuilt, err := rebuildAxis(shares)
+	if err != nil {
+		return err
+	}
+	for cell, share := range shares {
+		if share != nil && !bytes.Equal(share, rebuilt[cell]) {
+			// the shares are not the erasure coding of the same data
+			return nil
+		}
+	}
+	root, err := computeAxisRoot(p.Index, rebuilt)
+	if err != nil {
+		// the namespaces of the rebuilt axis are out of order, which no root
+		// can commit to
+		return nil
+	}
+	if bytes.Equal(root, roots[p.Index]) {
+		return fmt.Errorf("axis %d is erasure coded", p.Index)
+	}
+	return nil
+}
+
+// rebuildAxis rebuilds the shares of the axis, nil if missing, from the first
+// half of them present: it decodes its data and encodes it again.
+func rebuildAxis(shares [][]byte) ([][]byte, error) {
+	k := len(shares) / 2
+	subset := make([][]byte, len(shares))
+	n := 0
+	for i, share := range shares {
+		if share != nil && n < k {
+			subset[i] = append([]byte(nil), share...)
+			n++
+		}
+	}
+
+	codec := consts.DefaultCodec()
+	data, err := codec.Decode(subset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the axis: %w", err)
+	}
+	if len(data) < k {
+		return nil, fmt.Errorf("decoded %d shares of the %d of the data", len(data), k)
+	}
+	parity, err := codec.Encode(data[:k])
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the axis: %w", err)
+	}
+	return append(append(make([][]byte, 0, len(shares)), data[:k]...), parity...), nil
+}
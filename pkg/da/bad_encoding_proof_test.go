@@ -0,0 +1,110 @@
+package da
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+// testSquare returns the shares of an extended data square in row-major order,
+// and its data availability header.
+func testSquare(t *testing.T, squareSize uint64) ([][]byte, DataAvailabilityHeader) {
+	shares := make([][]byte, squareSize*squareSize)
+	for i := range shares {
+		nID := []byte{0, 0, 0, 0, 0, 0, 1, byte(i)}
+		shares[i] = append(nID, bytes.Repeat([]byte{byte(i)}, consts.MsgShareSize)...)
+	}
+	eds, err := ExtendShares(squareSize, shares)
+	require.NoError(t, err)
+
+	var square [][]byte
+	for i := uint(0); i < eds.Width(); i++ {
+		square = append(square, eds.Row(i)...)
+	}
+	return square, NewDataAvailabilityHeader(eds)
+}
+
+func TestProveBadEncoding(t *testing.T) {
+	square, dah := testSquare(t, 4)
+
+	// the roots of the square are the ones of its data availability header
+	squareDAH, err := SquareDataAvailabilityHeader(square)
+	require.NoError(t, err)
+	require.True(t, squareDAH.Equals(&dah))
+
+	// an erasure coded square has no bad encoding proof
+	proof, err := ProveBadEncoding(3, &dah, square)
+	require.NoError(t, err)
+	require.Nil(t, proof)
+
+	// nor has an axis of it
+	proof, err = proveAxis(3, true, 2, columns(square, 8), allProven(8))
+	require.NoError(t, err)
+	require.Error(t, proof.Verify(&dah))
+
+	// a parity share of the square is tampered with, and its roots committed
+	// to by a byzantine block producer
+	tampered := append([][]byte(nil), square...)
+	tampered[1*8+6] = bytes.Repeat([]byte{0xFF}, consts.ShareSize)
+	tamperedDAH, err := SquareDataAvailabilityHeader(tampered)
+	require.NoError(t, err)
+
+	proof, err = ProveBadEncoding(3, &tamperedDAH, tampered)
+	require.NoError(t, err)
+	require.NotNil(t, proof)
+	assert.EqualValues(t, 3, proof.Height)
+	assert.True(t, proof.IsRow)
+	assert.EqualValues(t, 1, proof.Index)
+	require.NoError(t, proof.Verify(&tamperedDAH))
+
+	// the proof doesn't hold against another header
+	require.Error(t, proof.Verify(&dah))
+
+	// the missing shares are proven by the other axes
+	missing := append([][]byte(nil), tampered...)
+	missing[0*8+2] = nil
+	missing[5*8+7] = nil
+	proof, err = ProveBadEncoding(3, &tamperedDAH, missing)
+	require.NoError(t, err)
+	require.NotNil(t, proof)
+	assert.Nil(t, proof.Shares[2])
+	require.NoError(t, proof.Verify(&tamperedDAH))
+
+	// half of the shares are needed to rebuild the axis
+	for cell := 0; cell < 5; cell++ {
+		proof.Shares[cell] = nil
+	}
+	require.Error(t, proof.Verify(&tamperedDAH))
+
+	// which must be proven
+	proof, err = ProveBadEncoding(3, &tamperedDAH, tampered)
+	require.NoError(t, err)
+	proof.Shares[3].Nodes = proof.Shares[4].Nodes
+	require.Error(t, proof.Verify(&tamperedDAH))
+
+	// the square must match the header
+	_, err = ProveBadEncoding(3, &tamperedDAH, tampered[:16])
+	require.Error(t, err)
+}
+
+func columns(square [][]byte, width int) [][][]byte {
+	cols := make([][][]byte, width)
+	for j := range cols {
+		for i := 0; i < width; i++ {
+			cols[j] = append(cols[j], square[i*width+j])
+		}
+	}
+	return cols
+}
+
+func allProven(width int) []bool {
+	proven := make([]bool, width)
+	for i := range proven {
+		proven[i] = true
+	}
+	return proven
+}
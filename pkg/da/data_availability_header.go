@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/celestiaorg/rsmt2d"
 	"github.com/tendermint/tendermint/crypto/merkle"
@@ -51,6 +52,40 @@ func NewDataAvailabilityHeader(eds *rsmt2d.ExtendedDataSquare) DataAvailabilityH
 	return dah
 }
 
+// SquareDataAvailabilityHeader returns the data availability header of the
+// extended data square given by its shares in row-major order, from the roots
+// of its rows and columns, whether the square is erasure coded or not.
+func SquareDataAvailabilityHeader(square [][]byte) (DataAvailabilityHeader, error) {
+	width := uint64(math.Sqrt(float64(len(square))))
+	if width*width != uint64(len(square)) || width%2 != 0 {
+		return DataAvailabilityHeader{}, fmt.Errorf("%d shares don't make an extended square", len(square))
+	}
+
+	dah := DataAvailabilityHeader{
+		RowsRoots:   make([][]byte, width),
+		ColumnRoots: make([][]byte, width),
+	}
+	for i := uint64(0); i < width; i++ {
+		row := square[i*width : (i+1)*width]
+		col := make([][]byte, width)
+		for j := range col {
+			col[j] = square[uint64(j)*width+i]
+		}
+
+		var err error
+		if dah.RowsRoots[i], err = computeAxisRoot(i, row); err != nil {
+			return DataAvailabilityHeader{}, fmt.Errorf("row %d: %w", i, err)
+		}
+		if dah.ColumnRoots[i], err = computeAxisRoot(i, col); err != nil {
+			return DataAvailabilityHeader{}, fmt.Errorf("column %d: %w", i, err)
+		}
+	}
+	dah.Hash()
+	return dah, nil
+}
+
+// ExtendShares erasure codes the shares of an original data square of width
+// squareSize, in row-major order, into its extended data square.
 func ExtendShares(squareSize uint64, shares [][]byte) (*rsmt2d.ExtendedDataSquare, error) {
 	// Check that square size is with range
 	if squareSize < consts.MinSquareSize || squareSize > consts.MaxSquareSize {
@@ -69,8 +104,12 @@ func ExtendShares(squareSize uint64, shares [][]byte) (*rsmt2d.ExtendedDataSquar
 			squareSize*squareSize,
 		)
 	}
+	square, err := extendSquare(squareSize, shares)
+	if err != nil {
+		return nil, err
+	}
 	tree := wrapper.NewErasuredNamespacedMerkleTree(squareSize)
-	return rsmt2d.ComputeExtendedDataSquare(shares, consts.DefaultCodec(), tree.Constructor)
+	return rsmt2d.ImportExtendedDataSquare(square, consts.DefaultCodec(), tree.Constructor)
 }
 
 // String returns hex representation of merkle hash of the DAHeader.
@@ -95,6 +134,15 @@ func (dah *DataAvailabilityHeader) Hash() []byte {
 		return dah.hash
 	}
 
+	// The single data root is computed using a simple binary merkle tree.
+	// Effectively being root(rowRoots || columnRoots):
+	dah.hash = merkle.HashFromByteSlices(dah.roots())
+	return dah.hash
+}
+
+// roots returns the row roots followed by the column roots, the leaves of the
+// merkle tree of the data root.
+func (dah *DataAvailabilityHeader) roots() [][]byte {
 	colsCount := len(dah.ColumnRoots)
 	rowsCount := len(dah.RowsRoots)
 	slices := make([][]byte, colsCount+rowsCount)
@@ -104,10 +152,7 @@ func (dah *DataAvailabilityHeader) Hash() []byte {
 	for i, colRoot := range dah.ColumnRoots {
 		slices[i+colsCount] = colRoot
 	}
-	// The single data root is computed using a simple binary merkle tree.
-	// Effectively being root(rowRoots || columnRoots):
-	dah.hash = merkle.HashFromByteSlices(slices)
-	return dah.hash
+	return slices
 }
 
 func (dah *DataAvailabilityHeader) ToProto() (*daproto.DataAvailabilityHeader, error) {
@@ -164,6 +209,12 @@ func (dah *DataAvailabilityHeader) ValidateBasic() error {
 	return nil
 }
 
+// SquareSize returns the width of the original data square the data
+// availability header commits to.
+func (dah *DataAvailabilityHeader) SquareSize() uint64 {
+	return uint64(len(dah.RowsRoots)) / 2
+}
+
 func (dah *DataAvailabilityHeader) IsZero() bool {
 	if dah == nil {
 		return true
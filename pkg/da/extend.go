@@ -0,0 +1,104 @@
+package da
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+// encoder erasure codes the axes of a square. Each worker has its own, as the
+// codec caches its encoders unsynchronized, and reuses it and its axis buffer
+// for all the axes it encodes.
+type encoder struct {
+	codec rsmt2d.Codec
+	axis  [][]byte
+}
+
+// extendSquare erasure codes the original data square of width k, given by its
+// shares in row-major order, into the shares of its extended data square. The
+// rows of the original data are extended first, then the columns of the top
+// half of the extended square, each step across a pool of GOMAXPROCS workers.
+// As the codec is linear, the bottom right quadrant is the same as the one of
+// the extension of the rows of the bottom left quadrant.
+func extendSquare(k uint64, shares [][]byte) ([][]byte, error) {
+	for i, share := range shares {
+		if len(share) != len(shares[0]) {
+			return nil, fmt.Errorf("share %d must be %d bytes, got %d", i, len(shares[0]), len(share))
+		}
+	}
+
+	width := 2 * k
+	square := make([][]byte, width*width)
+	for i := uint64(0); i < k; i++ {
+		copy(square[i*width:i*width+k], shares[i*k:(i+1)*k])
+	}
+
+	encoders := make([]*encoder, runtime.GOMAXPROCS(0))
+	for i := range encoders {
+		encoders[i] = &encoder{codec: consts.DefaultCodec(), axis: make([][]byte, k)}
+	}
+
+	err := encodeAxes(encoders, k, func(enc *encoder, row uint64) error {
+		parity, err := enc.codec.Encode(square[row*width : row*width+k])
+		if err != nil {
+			return fmt.Errorf("failed to extend row %d: %w", row, err)
+		}
+		copy(square[row*width+k:(row+1)*width], parity)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = encodeAxes(encoders, width, func(enc *encoder, col uint64) error {
+		for i := range enc.axis {
+			enc.axis[i] = square[uint64(i)*width+col]
+		}
+		parity, err := enc.codec.Encode(enc.axis)
+		if err != nil {
+			return fmt.Errorf("failed to extend column %d: %w", col, err)
+		}
+		for i, share := range parity {
+			square[(k+uint64(i))*width+col] = share
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return square, nil
+}
+
+// encodeAxes calls encode for the count axes, across the encoders, and
+// returns the first error.
+func encodeAxes(encoders []*encoder, count uint64, encode func(enc *encoder, axis uint64) error) error {
+	axes := make(chan uint64, count)
+	for i := uint64(0); i < count; i++ {
+		axes <- i
+	}
+	close(axes)
+
+	var (
+		wg      sync.WaitGroup
+		errOnce sync.Once
+		err     error
+	)
+	for _, enc := range encoders {
+		wg.Add(1)
+		go func(enc *encoder) {
+			defer wg.Done()
+			for axis := range axes {
+				if encErr := encode(enc, axis); encErr != nil {
+					errOnce.Do(func() { err = encErr })
+					return
+				}
+			}
+		}(enc)
+	}
+	wg.Wait()
+	return err
+}
@@ -0,0 +1,51 @@
+package da
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/celestiaorg/rsmt2d"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/wrapper"
+)
+
+func TestExtendSquare(t *testing.T) {
+	for _, squareSize := range []uint64{1, 2, 8, consts.MaxSquareSize} {
+		shares := make([][]byte, squareSize*squareSize)
+		for i := range shares {
+			shares[i] = generateShares(1, byte(i))[0]
+		}
+
+		// the extension across the workers is the one of rsmt2d
+		eds, err := ExtendShares(squareSize, shares)
+		require.NoError(t, err)
+		tree := wrapper.NewErasuredNamespacedMerkleTree(squareSize)
+		expected, err := rsmt2d.ComputeExtendedDataSquare(shares, consts.DefaultCodec(), tree.Constructor)
+		require.NoError(t, err)
+		for i := uint(0); i < expected.Width(); i++ {
+			require.Equal(t, expected.Row(i), eds.Row(i), "row %d of square size %d", i, squareSize)
+		}
+	}
+
+	// the shares must have the same size
+	shares := generateShares(4, 1)
+	shares[3] = shares[3][1:]
+	_, err := ExtendShares(2, shares)
+	require.Error(t, err)
+}
+
+func BenchmarkExtendShares(b *testing.B) {
+	for _, squareSize := range []uint64{8, 32, consts.MaxSquareSize} {
+		shares := generateShares(int(squareSize*squareSize), 1)
+		b.Run(fmt.Sprintf("%dx%d", squareSize, squareSize), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := ExtendShares(squareSize, shares); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package da
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/wrapper"
+)
+
+// NamespaceProof proves that Shares are all the shares of a namespace in a
+// row of the extended data square, or that the row has none of them, against
+// the row root of the DataAvailabilityHeader.
+type NamespaceProof struct {
+	// Row is the index of the row of the extended data square.
+	Row uint64 `json:"row"`
+	// Start and End delimit the leaves of the row proven (End is exclusive).
+	Start int      `json:"start"`
+	End   int      `json:"end"`
+	Nodes [][]byte `json:"nodes"`
+	// LeafHash is the hash of the leaf proving the absence of the namespace in
+	// the row. It is only set by the proofs of absence.
+	LeafHash []byte `json:"leaf_hash,omitempty"`
+	// Shares are the shares of the namespace in the row.
+	Shares [][]byte `json:"shares"`
+}
+
+// ProveNamespace returns the proofs of the shares of the namespace nID in
+// eds, one for each row of the original data whose namespace range includes
+// nID. The shares being ordered by namespace, these rows are contiguous. There
+// is no proof if no row may hold the namespace.
+func ProveNamespace(eds *rsmt2d.ExtendedDataSquare, nID namespace.ID) ([]NamespaceProof, error) {
+	if len(nID) != consts.NamespaceSize {
+		return nil, fmt.Errorf("namespace ID must be %d bytes, got %d", consts.NamespaceSize, len(nID))
+	}
+
+	squareSize := eds.Width() / 2
+	rowRoots := eds.RowRoots()
+	proofs := make([]NamespaceProof, 0)
+	for row := uint(0); row < squareSize; row++ {
+		if !rootIncludes(rowRoots[row], nID) {
+			continue
+		}
+
+		// rebuild the tree of the row, the same way as the row root
+		tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(squareSize))
+		shares := eds.Row(row)
+		for col, share := range shares {
+			tree.Push(share, rsmt2d.SquareIndex{Axis: row, Cell: uint(col)})
+		}
+		proof, err := tree.ProveNamespace(nID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prove namespace %X in row %d: %w", nID, row, err)
+		}
+
+		nsProof := NamespaceProof{
+			Row:      uint64(row),
+			Start:    proof.Start(),
+			End:      proof.End(),
+			Nodes:    proof.Nodes(),
+			LeafHash: proof.LeafHash(),
+			Shares:   [][]byte{},
+		}
+		if !proof.IsOfAbsence() {
+			nsProof.Shares = shares[proof.Start():proof.End()]
+		}
+		proofs = append(proofs, nsProof)
+	}
+	return proofs, nil
+}
+
+// Verify checks the proof of the shares of the namespace nID against the row
+// root of dah.
+func (p NamespaceProof) Verify(dah *DataAvailabilityHeader, nID namespace.ID) error {
+	if p.Row >= uint64(len(dah.RowsRoots)) {
+		return fmt.Errorf("row %d out of the %d rows", p.Row, len(dah.RowsRoots))
+	}
+
+	var proof nmt.Proof
+	if len(p.LeafHash) > 0 {
+		if len(p.Shares) > 0 {
+			return errors.New("proof of absence with shares")
+		}
+		proof = nmt.NewAbsenceProof(p.Start, p.End, p.Nodes, p.LeafHash, true)
+	} else {
+		proof = nmt.NewInclusionProof(p.Start, p.End, p.Nodes, true)
+	}
+
+	// the leaves are the shares prefixed with their namespace, see
+	// wrapper.ErasuredNamespacedMerkleTree
+	leaves := make([][]byte, len(p.Shares))
+	for i, share := range p.Shares {
+		leaves[i] = append(append(make([]byte, 0, len(nID)+len(share)), nID...), share...)
+	}
+	if !proof.VerifyNamespace(consts.NewBaseHashFunc(), nID, leaves, dah.RowsRoots[p.Row]) {
+		return fmt.Errorf("invalid proof of namespace %X in row %d", nID, p.Row)
+	}
+	return nil
+}
+
+// VerifyNamespaceProofs checks that proofs, as returned by ProveNamespace,
+// prove all the shares of the namespace nID in the data of dah: there must be
+// a valid proof for each row of the original data whose namespace range
+// includes nID, and none for the other rows.
+func VerifyNamespaceProofs(dah *DataAvailabilityHeader, nID namespace.ID, proofs []NamespaceProof) error {
+	if len(nID) != consts.NamespaceSize {
+		return fmt.Errorf("namespace ID must be %d bytes, got %d", consts.NamespaceSize, len(nID))
+	}
+
+	i := 0
+	for row := 0; row < len(dah.RowsRoots)/2; row++ {
+		if !rootIncludes(dah.RowsRoots[row], nID) {
+			continue
+		}
+		if i >= len(proofs) || proofs[i].Row != uint64(row) {
+			return fmt.Errorf("missing proof of namespace %X in row %d", nID, row)
+		}
+		if err := proofs[i].Verify(dah, nID); err != nil {
+			return err
+		}
+		i++
+	}
+	if i != len(proofs) {
+		return fmt.Errorf("unexpected proofs of namespace %X in rows not including it", nID)
+	}
+	return nil
+}
+
+// rootIncludes returns whether the namespace range of the NMT root includes
+// nID. The root is prefixed with its min and max namespaces.
+func rootIncludes(root []byte, nID namespace.ID) bool {
+	if len(root) < 2*consts.NamespaceSize {
+		return false
+	}
+	min, max := root[:consts.NamespaceSize], root[consts.NamespaceSize:2*consts.NamespaceSize]
+	return bytes.Compare(min, nID) <= 0 && bytes.Compare(nID, max) <= 0
+}
@@ -0,0 +1,99 @@
+package da
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+func TestProveNamespace(t *testing.T) {
+	// a 4x4 square with the namespaces 1, 3, 3, ..., 3, 5 (5 being the tail)
+	squareSize := uint64(4)
+	shares := make([][]byte, squareSize*squareSize)
+	for i := range shares {
+		nID := namespace.ID{0, 0, 0, 0, 0, 0, 1, 3}
+		switch i {
+		case 0:
+			nID = namespace.ID{0, 0, 0, 0, 0, 0, 1, 1}
+		case len(shares) - 1:
+			nID = namespace.ID{0, 0, 0, 0, 0, 0, 1, 5}
+		}
+		shares[i] = append(append([]byte{}, nID...), bytes.Repeat([]byte{byte(i)}, consts.MsgShareSize)...)
+	}
+	eds, err := ExtendShares(squareSize, shares)
+	require.NoError(t, err)
+	dah := NewDataAvailabilityHeader(eds)
+
+	testCases := []struct {
+		name   string
+		nID    namespace.ID
+		rows   []uint64
+		shares int
+	}{
+		{"first share", namespace.ID{0, 0, 0, 0, 0, 0, 1, 1}, []uint64{0}, 1},
+		{"all rows", namespace.ID{0, 0, 0, 0, 0, 0, 1, 3}, []uint64{0, 1, 2, 3}, 14},
+		{"absent in row range", namespace.ID{0, 0, 0, 0, 0, 0, 1, 2}, []uint64{0}, 0},
+		{"out of the rows range", namespace.ID{0, 0, 0, 0, 0, 0, 0, 9}, nil, 0},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			proofs, err := ProveNamespace(eds, tc.nID)
+			require.NoError(t, err)
+
+			rows := make([]uint64, 0, len(proofs))
+			count := 0
+			for _, proof := range proofs {
+				rows = append(rows, proof.Row)
+				for _, share := range proof.Shares {
+					require.Equal(t, []byte(tc.nID), share[:consts.NamespaceSize])
+				}
+				count += len(proof.Shares)
+			}
+			if tc.rows == nil {
+				assert.Empty(t, rows)
+			} else {
+				assert.Equal(t, tc.rows, rows)
+			}
+			assert.Equal(t, tc.shares, count)
+			require.NoError(t, VerifyNamespaceProofs(&dah, tc.nID, proofs))
+
+			// a proof missing, or of the shares of another namespace, doesn't
+			// verify
+			if len(proofs) > 0 {
+				require.Error(t, VerifyNamespaceProofs(&dah, tc.nID, proofs[1:]))
+			}
+			if tc.shares > 0 {
+				require.Error(t, proofs[0].Verify(&dah, namespace.ID{0, 0, 0, 0, 0, 0, 1, 4}))
+			}
+		})
+	}
+
+	_, err = ProveNamespace(eds, namespace.ID{1})
+	require.Error(t, err)
+}
+
+func TestNamespaceProofTampered(t *testing.T) {
+	nID := namespace.ID{0, 0, 0, 0, 0, 0, 1, 3}
+	shares := make([][]byte, 4)
+	for i := range shares {
+		shares[i] = append(append([]byte{}, nID...), bytes.Repeat([]byte{byte(i)}, consts.MsgShareSize)...)
+	}
+	eds, err := ExtendShares(2, shares)
+	require.NoError(t, err)
+	dah := NewDataAvailabilityHeader(eds)
+
+	proofs, err := ProveNamespace(eds, nID)
+	require.NoError(t, err)
+	require.Len(t, proofs, 2)
+	require.NoError(t, VerifyNamespaceProofs(&dah, nID, proofs))
+
+	// dropping a share
+	proofs[1].Shares = proofs[1].Shares[1:]
+	require.Error(t, VerifyNamespaceProofs(&dah, nID, proofs))
+}
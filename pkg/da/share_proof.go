@@ -0,0 +1,96 @@
+package da
+
+import (
+	"fmt"
+
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/wrapper"
+)
+
+// ShareProof proves that Share is the share at the coordinates (Row, Col) of
+// the extended data square, against both the row and the column roots of the
+// DataAvailabilityHeader.
+type ShareProof struct {
+	Row   uint64 `json:"row"`
+	Col   uint64 `json:"col"`
+	Share []byte `json:"share"`
+	// RowNodes and ColNodes are the nodes of the proofs of the inclusion of
+	// the share in its row and in its column.
+	RowNodes [][]byte `json:"row_nodes"`
+	ColNodes [][]byte `json:"col_nodes"`
+}
+
+// ProveShare returns the proof of the share at the coordinates (row, col) of
+// eds.
+func ProveShare(eds *rsmt2d.ExtendedDataSquare, row, col uint64) (ShareProof, error) {
+	width := uint64(eds.Width())
+	if row >= width || col >= width {
+		return ShareProof{}, fmt.Errorf("coordinates (%d, %d) out of the %dx%d square", row, col, width, width)
+	}
+
+	rowProof, err := proveLeaf(eds.Row(uint(row)), row, col, width/2)
+	if err != nil {
+		return ShareProof{}, fmt.Errorf("failed to prove share (%d, %d) in its row: %w", row, col, err)
+	}
+	colProof, err := proveLeaf(eds.Col(uint(col)), col, row, width/2)
+	if err != nil {
+		return ShareProof{}, fmt.Errorf("failed to prove share (%d, %d) in its column: %w", row, col, err)
+	}
+
+	return ShareProof{
+		Row:      row,
+		Col:      col,
+		Share:    eds.Row(uint(row))[col],
+		RowNodes: rowProof.Nodes(),
+		ColNodes: colProof.Nodes(),
+	}, nil
+}
+
+// Verify checks the proof of the share against the row and column roots of
+// dah.
+func (p ShareProof) Verify(dah *DataAvailabilityHeader) error {
+	width := uint64(len(dah.RowsRoots))
+	if p.Row >= width || p.Col >= width || uint64(len(dah.ColumnRoots)) != width {
+		return fmt.Errorf("coordinates (%d, %d) out of the %dx%d square", p.Row, p.Col, width, width)
+	}
+	if len(p.Share) != consts.ShareSize {
+		return fmt.Errorf("share must be %d bytes, got %d", consts.ShareSize, len(p.Share))
+	}
+
+	nID := leafNamespace(p.Row, p.Col, width, p.Share)
+	rowProof := nmt.NewInclusionProof(int(p.Col), int(p.Col)+1, p.RowNodes, true)
+	if !rowProof.VerifyInclusion(consts.NewBaseHashFunc(), nID, p.Share, dah.RowsRoots[p.Row]) {
+		return fmt.Errorf("invalid proof of share (%d, %d) in its row", p.Row, p.Col)
+	}
+	colProof := nmt.NewInclusionProof(int(p.Row), int(p.Row)+1, p.ColNodes, true)
+	if !colProof.VerifyInclusion(consts.NewBaseHashFunc(), nID, p.Share, dah.ColumnRoots[p.Col]) {
+		return fmt.Errorf("invalid proof of share (%d, %d) in its column", p.Row, p.Col)
+	}
+	return nil
+}
+
+// leafNamespace returns the namespace of the leaf of the share at the
+// coordinates (row, col) of the extended data square of the given width: the
+// leaves are the shares prefixed with their namespace, the parity one outside
+// of the original data, see wrapper.ErasuredNamespacedMerkleTree.
+func leafNamespace(row, col, width uint64, share []byte) namespace.ID {
+	if row >= width/2 || col >= width/2 {
+		return consts.ParitySharesNamespaceID
+	}
+	return namespace.ID(share[:consts.NamespaceSize])
+}
+
+// proveLeaf rebuilds the tree of the row, or column, axis of the extended data
+// square from its shares, the same way as its root, and proves the leaf at
+// index cell.
+func proveLeaf(shares [][]byte, axis, cell, squareSize uint64) (nmt.Proof, error) {
+	tree := wrapper.NewErasuredNamespacedMerkleTree(squareSize)
+	for i, share := range shares {
+		tree.Push(share, rsmt2d.SquareIndex{Axis: uint(axis), Cell: uint(i)})
+	}
+	return tree.Prove(int(cell))
+}
@@ -0,0 +1,57 @@
+package da
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+func TestProveShare(t *testing.T) {
+	squareSize := uint64(4)
+	shares := make([][]byte, squareSize*squareSize)
+	for i := range shares {
+		nID := []byte{0, 0, 0, 0, 0, 0, 1, byte(i)}
+		shares[i] = append(nID, bytes.Repeat([]byte{byte(i)}, consts.MsgShareSize)...)
+	}
+	eds, err := ExtendShares(squareSize, shares)
+	require.NoError(t, err)
+	dah := NewDataAvailabilityHeader(eds)
+
+	// every share, of the original data as well as of the parity data, is
+	// proven against its row and column roots
+	for row := uint64(0); row < 2*squareSize; row++ {
+		for col := uint64(0); col < 2*squareSize; col++ {
+			proof, err := ProveShare(eds, row, col)
+			require.NoError(t, err)
+			require.NoError(t, proof.Verify(&dah), "(%d, %d)", row, col)
+		}
+	}
+
+	proof, err := ProveShare(eds, 1, 6)
+	require.NoError(t, err)
+
+	// a share at other coordinates
+	moved := proof
+	moved.Row = 2
+	require.Error(t, moved.Verify(&dah))
+
+	// a tampered share
+	tampered := proof
+	tampered.Share = append([]byte{}, proof.Share...)
+	tampered.Share[consts.ShareSize-1] ^= 0xFF
+	require.Error(t, tampered.Verify(&dah))
+
+	// proven against the row root only
+	noCol := proof
+	noCol.ColNodes = nil
+	require.Error(t, noCol.Verify(&dah))
+
+	_, err = ProveShare(eds, 2*squareSize, 0)
+	require.Error(t, err)
+	out := proof
+	out.Col = 2 * squareSize
+	require.Error(t, out.Verify(&dah))
+}
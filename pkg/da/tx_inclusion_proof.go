@@ -0,0 +1,121 @@
+package da
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+// TxInclusionProof proves the inclusion of a tx in the data root of a block,
+// the hash of its DataAvailabilityHeader: the tx is laid out in a range of
+// the tx shares of the original data square, each share is proven against the
+// root of its row, and each of these row roots against the data root. Unlike
+// the TxProof over the flat tx list, it can be checked against the DataHash
+// of a header alone, e.g. by a rollup bridge.
+type TxInclusionProof struct {
+	// Start is the index of the first share of the tx in the original data
+	// square, in row-major order, and Offset the index of the first byte of
+	// the length-delimited tx in the data of that share.
+	Start  uint64 `json:"start"`
+	Offset uint64 `json:"offset"`
+	// Shares are the shares of the tx, and ShareNodes the nodes of the proofs
+	// of their inclusion in their row.
+	Shares     [][]byte   `json:"shares"`
+	ShareNodes [][][]byte `json:"share_nodes"`
+	// RowRoots are the roots of the rows of the shares, and RowProofs the
+	// proofs of their inclusion in the data root.
+	RowRoots  [][]byte       `json:"row_roots"`
+	RowProofs []merkle.Proof `json:"row_proofs"`
+}
+
+// ProveTxInclusion returns the proof of the inclusion of the tx laid out in
+// the shares [start, end) of eds, starting at offset in the data of the first
+// one, see types.Txs.ShareRange.
+func ProveTxInclusion(eds *rsmt2d.ExtendedDataSquare, start, end, offset uint64) (TxInclusionProof, error) {
+	squareSize := uint64(eds.Width()) / 2
+	if start >= end || end > squareSize*squareSize {
+		return TxInclusionProof{}, fmt.Errorf("shares [%d, %d) out of the %dx%d square",
+			start, end, squareSize, squareSize)
+	}
+
+	dah := NewDataAvailabilityHeader(eds)
+	_, rootProofs := merkle.ProofsFromByteSlices(dah.roots())
+
+	proof := TxInclusionProof{Start: start, Offset: offset}
+	firstRow, lastRow := start/squareSize, (end-1)/squareSize
+	for row := firstRow; row <= lastRow; row++ {
+		proof.RowRoots = append(proof.RowRoots, dah.RowsRoots[row])
+		proof.RowProofs = append(proof.RowProofs, *rootProofs[row])
+	}
+	for i := start; i < end; i++ {
+		row, col := i/squareSize, i%squareSize
+		leafProof, err := proveLeaf(eds.Row(uint(row)), row, col, squareSize)
+		if err != nil {
+			return TxInclusionProof{}, fmt.Errorf("failed to prove share (%d, %d) in its row: %w", row, col, err)
+		}
+		proof.Shares = append(proof.Shares, eds.Row(uint(row))[col])
+		proof.ShareNodes = append(proof.ShareNodes, leafProof.Nodes())
+	}
+	return proof, nil
+}
+
+// Verify checks that the proof proves the inclusion of tx in the data root.
+func (p TxInclusionProof) Verify(dataRoot []byte, tx []byte) error {
+	if len(p.Shares) == 0 || len(p.ShareNodes) != len(p.Shares) {
+		return errors.New("proof has no shares, or not as many share proofs")
+	}
+	if len(p.RowProofs) == 0 || len(p.RowProofs) != len(p.RowRoots) {
+		return errors.New("proof has no row roots, or not as many row proofs")
+	}
+
+	// the data root is the root of the row and column roots
+	squareSize := uint64(p.RowProofs[0].Total) / 4
+	end := p.Start + uint64(len(p.Shares))
+	if squareSize == 0 || end > squareSize*squareSize {
+		return fmt.Errorf("shares [%d, %d) out of the %dx%d square", p.Start, end, squareSize, squareSize)
+	}
+	firstRow := p.Start / squareSize
+	if rows := (end-1)/squareSize - firstRow + 1; uint64(len(p.RowRoots)) != rows {
+		return fmt.Errorf("the shares span %d rows, got %d row roots", rows, len(p.RowRoots))
+	}
+	for i := range p.RowProofs {
+		row := firstRow + uint64(i)
+		if p.RowProofs[i].Index != int64(row) || uint64(p.RowProofs[i].Total) != 4*squareSize {
+			return fmt.Errorf("proof of row root %d is not of row %d", i, row)
+		}
+		if err := p.RowProofs[i].Verify(dataRoot, p.RowRoots[i]); err != nil {
+			return fmt.Errorf("invalid proof of row %d: %w", row, err)
+		}
+	}
+
+	var data []byte
+	for i, share := range p.Shares {
+		if len(share) != consts.ShareSize || !bytes.HasPrefix(share, consts.TxNamespaceID) {
+			return fmt.Errorf("share %d is not a tx share", p.Start+uint64(i))
+		}
+		index := p.Start + uint64(i)
+		row, col := index/squareSize, index%squareSize
+		proof := nmt.NewInclusionProof(int(col), int(col)+1, p.ShareNodes[i], true)
+		if !proof.VerifyInclusion(consts.NewBaseHashFunc(), consts.TxNamespaceID, share, p.RowRoots[row-firstRow]) {
+			return fmt.Errorf("invalid proof of share (%d, %d) in its row", row, col)
+		}
+		data = append(data, share[consts.NamespaceSize+consts.ShareReservedBytes:]...)
+	}
+
+	// the tx is length-delimited in the data of the shares
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(lenBuf, uint64(len(tx)))
+	delimited := append(lenBuf[:n], tx...)
+	if p.Offset+uint64(len(delimited)) > uint64(len(data)) ||
+		!bytes.Equal(data[p.Offset:p.Offset+uint64(len(delimited))], delimited) {
+		return errors.New("the shares don't hold the tx at the offset")
+	}
+	return nil
+}
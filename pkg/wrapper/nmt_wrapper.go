@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/nmt/namespace"
 	"github.com/celestiaorg/rsmt2d"
 
 	"github.com/tendermint/tendermint/pkg/consts"
@@ -72,3 +73,15 @@ func (w *ErasuredNamespacedMerkleTree) Push(data []byte, idx rsmt2d.SquareIndex)
 func (w *ErasuredNamespacedMerkleTree) Root() []byte {
 	return w.tree.Root()
 }
+
+// ProveNamespace returns the proof of the leaves of the namespace nID pushed
+// to the underlying NamespaceMerkleTree, or of their absence.
+func (w *ErasuredNamespacedMerkleTree) ProveNamespace(nID namespace.ID) (nmt.Proof, error) {
+	return w.tree.ProveNamespace(nID)
+}
+
+// Prove returns the proof of the inclusion of the leaf at index in the
+// underlying NamespaceMerkleTree.
+func (w *ErasuredNamespacedMerkleTree) Prove(index int) (nmt.Proof, error) {
+	return w.tree.Prove(index)
+}
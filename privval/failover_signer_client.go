@@ -0,0 +1,169 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// FailoverSignerClient implements PrivValidator over several remote signer
+// endpoints, in order of priority.
+//
+// Every request goes to the connected endpoint of highest priority, and moves
+// on to the next one if it fails to respond, e.g. on a timeout. A remote
+// signer refusing to sign (RemoteSignerError) is not failed over, as the
+// endpoints are expected to share the key and the next one might not know
+// of the signature refused.
+type FailoverSignerClient struct {
+	clients []*SignerClient
+	logger  log.Logger
+	metrics *Metrics
+
+	mtx    tmsync.Mutex
+	active int
+}
+
+var _ types.PrivValidator = (*FailoverSignerClient)(nil)
+
+// FailoverSignerClientOption sets an optional parameter on the
+// FailoverSignerClient.
+type FailoverSignerClientOption func(*FailoverSignerClient)
+
+// FailoverSignerClientWithMetrics sets the metrics.
+func FailoverSignerClientWithMetrics(metrics *Metrics) FailoverSignerClientOption {
+	return func(sc *FailoverSignerClient) { sc.metrics = metrics }
+}
+
+// FailoverSignerClientWithLogger sets the logger.
+func FailoverSignerClientWithLogger(logger log.Logger) FailoverSignerClientOption {
+	return func(sc *FailoverSignerClient) { sc.logger = logger }
+}
+
+// NewFailoverSignerClient returns a FailoverSignerClient over the given
+// clients, in order of priority.
+func NewFailoverSignerClient(clients []*SignerClient, options ...FailoverSignerClientOption) (*FailoverSignerClient, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("no remote signer endpoints")
+	}
+	sc := &FailoverSignerClient{
+		clients: clients,
+		logger:  log.NewNopLogger(),
+		metrics: NopMetrics(),
+	}
+	for _, option := range options {
+		option(sc)
+	}
+	return sc, nil
+}
+
+// Close closes the connections to all the endpoints.
+func (sc *FailoverSignerClient) Close() error {
+	var err error
+	for _, client := range sc.clients {
+		if cerr := client.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// IsConnected indicates whether any endpoint is connected.
+func (sc *FailoverSignerClient) IsConnected() bool {
+	for _, client := range sc.clients {
+		if client.IsConnected() {
+			return true
+		}
+	}
+	return false
+}
+
+//--------------------------------------------------------
+// Implement PrivValidator
+
+// GetPubKey retrieves the public key from the first endpoint to respond.
+func (sc *FailoverSignerClient) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
+	var err error
+	for _, i := range sc.order() {
+		var pubKey crypto.PubKey
+		pubKey, err = sc.clients[i].GetPubKey(ctx)
+		if err == nil {
+			return pubKey, nil
+		}
+	}
+	return nil, fmt.Errorf("no remote signer endpoint returned the pubkey: %w", err)
+}
+
+// SignVote requests the first endpoint to respond to sign a vote.
+func (sc *FailoverSignerClient) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	return sc.do("vote", func(client *SignerClient) error {
+		return client.SignVote(ctx, chainID, vote)
+	})
+}
+
+// SignProposal requests the first endpoint to respond to sign a proposal.
+func (sc *FailoverSignerClient) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	return sc.do("proposal", func(client *SignerClient) error {
+		return client.SignProposal(ctx, chainID, proposal)
+	})
+}
+
+// do sends a request to the endpoints in order, until one responds.
+func (sc *FailoverSignerClient) do(msgType string, request func(*SignerClient) error) error {
+	var err error
+	for _, i := range sc.order() {
+		endpoint := strconv.Itoa(i)
+		start := time.Now()
+		err = request(sc.clients[i])
+		if err == nil {
+			sc.metrics.SignLatency.With("endpoint", endpoint, "type", msgType).Observe(time.Since(start).Seconds())
+			sc.setActive(i)
+			return nil
+		}
+
+		sc.metrics.SignFailures.With("endpoint", endpoint).Add(1)
+		var remoteErr *RemoteSignerError
+		if errors.As(err, &remoteErr) {
+			break
+		}
+		sc.logger.Error("remote signer endpoint failed to respond", "endpoint", i, "type", msgType, "err", err)
+	}
+
+	sc.metrics.MissedSigns.With("type", msgType).Add(1)
+	return fmt.Errorf("no remote signer endpoint signed the %s: %w", msgType, err)
+}
+
+// order returns the endpoints to try, the connected ones first, each in order
+// of priority.
+func (sc *FailoverSignerClient) order() []int {
+	connected := make([]int, 0, len(sc.clients))
+	disconnected := make([]int, 0, len(sc.clients))
+	for i, client := range sc.clients {
+		if client.IsConnected() {
+			sc.metrics.EndpointConnected.With("endpoint", strconv.Itoa(i)).Set(1)
+			connected = append(connected, i)
+		} else {
+			sc.metrics.EndpointConnected.With("endpoint", strconv.Itoa(i)).Set(0)
+			disconnected = append(disconnected, i)
+		}
+	}
+	return append(connected, disconnected...)
+}
+
+func (sc *FailoverSignerClient) setActive(i int) {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+
+	if sc.active != i {
+		sc.logger.Info("remote signer endpoint changed", "from", sc.active, "to", i)
+		sc.metrics.Failovers.Add(1)
+		sc.active = i
+	}
+}
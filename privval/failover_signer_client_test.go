@@ -0,0 +1,85 @@
+package privval
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func newFailoverTestEndpoints(t *testing.T, chainID string, pvs ...types.PrivValidator) ([]*SignerClient, []*SignerServer) {
+	clients := make([]*SignerClient, len(pvs))
+	servers := make([]*SignerServer, len(pvs))
+	for i, pv := range pvs {
+		dtc := getDialerTestCases(t)[0]
+		sl, sd := getMockEndpoints(t, dtc.addr, dtc.dialer)
+		sc, err := NewSignerClient(sl, chainID)
+		require.NoError(t, err)
+		ss := NewSignerServer(sd, chainID, pv)
+		require.NoError(t, ss.Start())
+		clients[i], servers[i] = sc, ss
+	}
+	t.Cleanup(func() {
+		for _, ss := range servers {
+			_ = ss.Stop()
+		}
+		for _, sc := range clients {
+			_ = sc.Close()
+		}
+	})
+	return clients, servers
+}
+
+func TestFailoverSignerClient(t *testing.T) {
+	ctx := context.Background()
+	chainID := tmrand.Str(12)
+	privKey := ed25519.GenPrivKey()
+	primary := types.NewMockPVWithParams(privKey, false, false)
+	backup := types.NewMockPVWithParams(privKey, false, false)
+	clients, servers := newFailoverTestEndpoints(t, chainID, primary, backup)
+
+	sc, err := NewFailoverSignerClient(clients)
+	require.NoError(t, err)
+	pubKey, err := sc.GetPubKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, privKey.PubKey(), pubKey)
+
+	blockID := types.BlockID{Hash: tmrand.Bytes(32)}
+	vote := newVote(pubKey.Address(), 0, 10, 0, tmproto.PrevoteType, blockID).ToProto()
+	require.NoError(t, sc.SignVote(ctx, chainID, vote))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+	assert.Equal(t, 0, sc.active)
+
+	// the backup signs once the primary is gone
+	require.NoError(t, servers[0].Stop())
+	proposal := newProposal(11, 0, blockID).ToProto()
+	require.NoError(t, sc.SignProposal(ctx, chainID, proposal))
+	assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainID, proposal), proposal.Signature))
+	assert.Equal(t, 1, sc.active)
+}
+
+func TestFailoverSignerClientRefusal(t *testing.T) {
+	ctx := context.Background()
+	chainID := tmrand.Str(12)
+	privKey := ed25519.GenPrivKey()
+	clients, _ := newFailoverTestEndpoints(t, chainID,
+		types.NewErroringMockPV(), types.NewMockPVWithParams(privKey, false, false))
+
+	sc, err := NewFailoverSignerClient(clients)
+	require.NoError(t, err)
+
+	// a remote signer refusing to sign is not failed over
+	blockID := types.BlockID{Hash: tmrand.Bytes(32)}
+	vote := newVote(privKey.PubKey().Address(), 0, 10, 0, tmproto.PrevoteType, blockID).ToProto()
+	err = sc.SignVote(ctx, chainID, vote)
+	var remoteErr *RemoteSignerError
+	assert.ErrorAs(t, err, &remoteErr)
+	assert.Empty(t, vote.Signature)
+	assert.Equal(t, 0, sc.active)
+}
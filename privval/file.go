@@ -11,6 +11,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/internal/libs/protoio"
@@ -52,6 +53,8 @@ type FilePVKey struct {
 	PrivKey crypto.PrivKey `json:"priv_key"`
 
 	filePath string
+	// passphrase encrypts the key file, if set.
+	passphrase []byte
 }
 
 // Save persists the FilePVKey to its filePath.
@@ -65,6 +68,12 @@ func (pvKey FilePVKey) Save() {
 	if err != nil {
 		panic(err)
 	}
+	if pvKey.passphrase != nil {
+		jsonBytes, err = EncryptKey(jsonBytes, pvKey.passphrase)
+		if err != nil {
+			panic(err)
+		}
+	}
 	err = tempfile.WriteFileAtomic(outFile, jsonBytes, 0600)
 	if err != nil {
 		panic(err)
@@ -72,6 +81,12 @@ func (pvKey FilePVKey) Save() {
 
 }
 
+// SetPassphrase sets the passphrase the key file is encrypted with on Save.
+// A nil passphrase saves the key file in plaintext.
+func (pvKey *FilePVKey) SetPassphrase(passphrase []byte) {
+	pvKey.passphrase = passphrase
+}
+
 //-------------------------------------------------------------------------------
 
 // FilePVLastSignState stores the mutable part of PrivValidator.
@@ -149,9 +164,15 @@ func (lss *FilePVLastSignState) Save() {
 // NOTE: the directories containing pv.Key.filePath and pv.LastSignState.filePath must already exist.
 // It includes the LastSignature and LastSignBytes so we don't lose the signature
 // if the process crashes after signing but before the resulting consensus message is processed.
+//
+// If a SignStateStore is set, the LastSignState is loaded from it before every
+// signature and swapped in it after, instead of being persisted to
+// pv.LastSignState.filePath.
 type FilePV struct {
 	Key           FilePVKey
 	LastSignState FilePVLastSignState
+
+	signStateStore SignStateStore
 }
 
 var _ types.PrivValidator = (*FilePV)(nil)
@@ -178,6 +199,8 @@ func GenFilePV(keyFilePath, stateFilePath, keyType string) (*FilePV, error) {
 	switch keyType {
 	case types.ABCIPubKeyTypeSecp256k1:
 		return NewFilePV(secp256k1.GenPrivKey(), keyFilePath, stateFilePath), nil
+	case types.ABCIPubKeyTypeBLS:
+		return NewFilePV(bls.GenPrivKey(), keyFilePath, stateFilePath), nil
 	case "", types.ABCIPubKeyTypeEd25519:
 		return NewFilePV(ed25519.GenPrivKey(), keyFilePath, stateFilePath), nil
 	default:
@@ -204,6 +227,17 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) (*FilePV, err
 	if err != nil {
 		return nil, err
 	}
+	var passphrase []byte
+	if IsEncryptedKey(keyJSONBytes) {
+		passphrase, err = KeyPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		keyJSONBytes, err = DecryptKey(keyJSONBytes, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting PrivValidator key from %v: %w", keyFilePath, err)
+		}
+	}
 	pvKey := FilePVKey{}
 	err = tmjson.Unmarshal(keyJSONBytes, &pvKey)
 	if err != nil {
@@ -214,6 +248,7 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) (*FilePV, err
 	pvKey.PubKey = pvKey.PrivKey.PubKey()
 	pvKey.Address = pvKey.PubKey.Address()
 	pvKey.filePath = keyFilePath
+	pvKey.passphrase = passphrase
 
 	pvState := FilePVLastSignState{}
 
@@ -252,6 +287,13 @@ func LoadOrGenFilePV(keyFilePath, stateFilePath string) (*FilePV, error) {
 	return pv, err
 }
 
+// SetSignStateStore sets the store of the LastSignState, and loads the
+// LastSignState from it.
+func (pv *FilePV) SetSignStateStore(store SignStateStore) error {
+	pv.signStateStore = store
+	return pv.loadSignState()
+}
+
 // GetAddress returns the address of the validator.
 // Implements PrivValidator.
 func (pv *FilePV) GetAddress() types.Address {
@@ -282,21 +324,30 @@ func (pv *FilePV) SignProposal(ctx context.Context, chainID string, proposal *tm
 	return nil
 }
 
-// Save persists the FilePV to disk.
+// Save persists the FilePV to disk. The LastSignState is left to the
+// SignStateStore, if any.
 func (pv *FilePV) Save() {
 	pv.Key.Save()
-	pv.LastSignState.Save()
+	if pv.signStateStore == nil {
+		pv.LastSignState.Save()
+	}
 }
 
 // Reset resets all fields in the FilePV.
 // NOTE: Unsafe!
 func (pv *FilePV) Reset() {
 	var sig []byte
+	prev := pv.LastSignState
 	pv.LastSignState.Height = 0
 	pv.LastSignState.Round = 0
 	pv.LastSignState.Step = 0
 	pv.LastSignState.Signature = sig
 	pv.LastSignState.SignBytes = nil
+	if pv.signStateStore != nil {
+		if err := pv.signStateStore.CompareAndSwap(prev, pv.LastSignState); err != nil {
+			panic(err)
+		}
+	}
 	pv.Save()
 }
 
@@ -319,6 +370,9 @@ func (pv *FilePV) String() string {
 func (pv *FilePV) signVote(chainID string, vote *tmproto.Vote) error {
 	height, round, step := vote.Height, vote.Round, voteToStep(vote)
 
+	if err := pv.loadSignState(); err != nil {
+		return err
+	}
 	lss := pv.LastSignState
 
 	sameHRS, err := lss.CheckHRS(height, round, step)
@@ -350,7 +404,9 @@ func (pv *FilePV) signVote(chainID string, vote *tmproto.Vote) error {
 	if err != nil {
 		return err
 	}
-	pv.saveSigned(height, round, step, signBytes, sig)
+	if err := pv.saveSigned(height, round, step, signBytes, sig); err != nil {
+		return err
+	}
 	vote.Signature = sig
 	return nil
 }
@@ -361,6 +417,9 @@ func (pv *FilePV) signVote(chainID string, vote *tmproto.Vote) error {
 func (pv *FilePV) signProposal(chainID string, proposal *tmproto.Proposal) error {
 	height, round, step := proposal.Height, proposal.Round, stepPropose
 
+	if err := pv.loadSignState(); err != nil {
+		return err
+	}
 	lss := pv.LastSignState
 
 	sameHRS, err := lss.CheckHRS(height, round, step)
@@ -392,21 +451,49 @@ func (pv *FilePV) signProposal(chainID string, proposal *tmproto.Proposal) error
 	if err != nil {
 		return err
 	}
-	pv.saveSigned(height, round, step, signBytes, sig)
+	if err := pv.saveSigned(height, round, step, signBytes, sig); err != nil {
+		return err
+	}
 	proposal.Signature = sig
 	return nil
 }
 
 // Persist height/round/step and signature
 func (pv *FilePV) saveSigned(height int64, round int32, step int8,
-	signBytes []byte, sig []byte) {
+	signBytes []byte, sig []byte) error {
 
-	pv.LastSignState.Height = height
-	pv.LastSignState.Round = round
-	pv.LastSignState.Step = step
-	pv.LastSignState.Signature = sig
-	pv.LastSignState.SignBytes = signBytes
+	lss := pv.LastSignState
+	lss.Height = height
+	lss.Round = round
+	lss.Step = step
+	lss.Signature = sig
+	lss.SignBytes = signBytes
+
+	if pv.signStateStore != nil {
+		if err := pv.signStateStore.CompareAndSwap(pv.LastSignState, lss); err != nil {
+			return fmt.Errorf("can't save sign state: %w", err)
+		}
+		pv.LastSignState = lss
+		return nil
+	}
+
+	pv.LastSignState = lss
 	pv.LastSignState.Save()
+	return nil
+}
+
+// loadSignState loads the LastSignState from the SignStateStore, if any.
+func (pv *FilePV) loadSignState() error {
+	if pv.signStateStore == nil {
+		return nil
+	}
+	lss, err := pv.signStateStore.Load()
+	if err != nil {
+		return fmt.Errorf("can't load sign state: %w", err)
+	}
+	lss.filePath = pv.LastSignState.filePath
+	pv.LastSignState = lss
+	return nil
 }
 
 //-----------------------------------------------------------------------------------------
@@ -350,3 +350,28 @@ func newProposal(height int64, round int32, blockID types.BlockID) *types.Propos
 		Timestamp: tmtime.Now(),
 	}
 }
+
+func TestSignVoteKeyTypes(t *testing.T) {
+	chainID := tmrand.Str(12)
+	for _, keyType := range []string{
+		types.ABCIPubKeyTypeEd25519, types.ABCIPubKeyTypeSecp256k1, types.ABCIPubKeyTypeBLS,
+	} {
+		t.Run(keyType, func(t *testing.T) {
+			tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+			require.NoError(t, err)
+			privVal, err := GenFilePV("", tempStateFile.Name(), keyType)
+			require.NoError(t, err)
+			pubKey, err := privVal.GetPubKey(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, keyType, pubKey.Type())
+
+			blockID := types.BlockID{Hash: tmrand.Bytes(32)}
+			vote := newVote(privVal.Key.Address, 0, 1, 0, tmproto.PrecommitType, blockID).ToProto()
+			require.NoError(t, privVal.SignVote(context.Background(), chainID, vote))
+			assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+		})
+	}
+
+	_, err := GenFilePV("", "", "bls12381")
+	assert.Error(t, err)
+}
@@ -2,13 +2,16 @@ package grpc
 
 import (
 	"context"
+	"time"
 
 	grpc "google.golang.org/grpc"
 	"google.golang.org/grpc/status"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/crypto/threshold"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/privval"
 	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
@@ -24,7 +27,10 @@ type SignerClient struct {
 	chainID string
 }
 
-var _ types.PrivValidator = (*SignerClient)(nil)
+var (
+	_ types.PrivValidator           = (*SignerClient)(nil)
+	_ privval.ThresholdRemoteSigner = (*SignerClient)(nil)
+)
 
 // NewSignerClient returns an instance of SignerClient.
 // it will start the endpoint (if not already started)
@@ -99,3 +105,57 @@ func (sc *SignerClient) SignProposal(ctx context.Context, chainID string, propos
 
 	return nil
 }
+
+// ThresholdKey requests the public part of the key share of a remote signer,
+// holding a share of a threshold key.
+func (sc *SignerClient) ThresholdKey(ctx context.Context) (threshold.KeyShare, error) {
+	resp, err := sc.client.ThresholdKey(ctx, &privvalproto.ThresholdKeyRequest{ChainId: sc.chainID})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("SignerClient::ThresholdKey", "err", errStatus.Message())
+		return threshold.KeyShare{}, errStatus.Err()
+	}
+
+	return privval.ThresholdKeyFromProto(resp)
+}
+
+// ThresholdCommit requests a remote signer, holding a share of a threshold
+// key, to commit to fresh nonces for the session.
+func (sc *SignerClient) ThresholdCommit(ctx context.Context, sessionID string) (threshold.Commitment, error) {
+	resp, err := sc.client.ThresholdCommit(
+		ctx, &privvalproto.ThresholdCommitRequest{SessionId: sessionID, ChainId: sc.chainID})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("SignerClient::ThresholdCommit", "err", errStatus.Message())
+		return threshold.Commitment{}, errStatus.Err()
+	}
+
+	return privval.ThresholdCommitmentsFromProto([]privvalproto.ThresholdCommitment{resp.Commitment})[0], nil
+}
+
+// ThresholdSign requests a remote signer, holding a share of a threshold key,
+// for its partial signature of either the vote or the proposal in the
+// session. It returns the index of the share of the signer, the partial
+// signature and the timestamp signed.
+func (sc *SignerClient) ThresholdSign(
+	ctx context.Context,
+	sessionID string,
+	vote *tmproto.Vote,
+	proposal *tmproto.Proposal,
+	commitments []threshold.Commitment,
+) (uint32, []byte, time.Time, error) {
+	resp, err := sc.client.ThresholdSign(ctx, &privvalproto.ThresholdSignRequest{
+		SessionId:   sessionID,
+		ChainId:     sc.chainID,
+		Vote:        vote,
+		Proposal:    proposal,
+		Commitments: privval.ThresholdCommitmentsToProto(commitments),
+	})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("SignerClient::ThresholdSign", "err", errStatus.Message())
+		return 0, nil, time.Time{}, errStatus.Err()
+	}
+
+	return resp.Index, resp.PartialSignature, resp.Timestamp, nil
+}
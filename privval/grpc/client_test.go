@@ -2,7 +2,9 @@ package grpc_test
 
 import (
 	"context"
+	"io/ioutil"
 	"net"
+	"os"
 	"testing"
 	"time"
 
@@ -12,9 +14,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/privval"
 	tmgrpc "github.com/tendermint/tendermint/privval/grpc"
 	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -166,3 +171,51 @@ func TestSignerClient_SignProposal(t *testing.T) {
 
 	assert.Equal(t, pbWant.Signature, pbHave.Signature)
 }
+
+func TestSignerClient_Threshold(t *testing.T) {
+	for _, keyType := range []string{ed25519.KeyType, bls.KeyType} {
+		keyType := keyType
+		t.Run(keyType, func(t *testing.T) {
+			ctx := context.Background()
+			logger := log.TestingLogger()
+			keys, err := privval.GenThresholdPVKeys(keyType, 2, 3)
+			require.NoError(t, err)
+
+			signers := make([]privval.ThresholdRemoteSigner, len(keys))
+			for i, key := range keys {
+				stateFile, err := ioutil.TempFile("", "priv_validator_state_")
+				require.NoError(t, err)
+				t.Cleanup(func() { os.Remove(stateFile.Name()) })
+				pv := privval.NewThresholdPV(key.KeyShare, "", stateFile.Name())
+
+				srv, dialer := dialer(pv, logger)
+				t.Cleanup(srv.Stop)
+				conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialer))
+				require.NoError(t, err)
+				t.Cleanup(func() { conn.Close() })
+
+				signers[i], err = tmgrpc.NewSignerClient(conn, chainID, logger)
+				require.NoError(t, err)
+			}
+
+			tsc, err := privval.NewThresholdSignerClient(ctx, signers, 2, time.Second, 3)
+			require.NoError(t, err)
+			pubKey, err := tsc.GetPubKey(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, keys[0].PubKey, pubKey)
+
+			hash := tmrand.Bytes(tmhash.Size)
+			vote := (&types.Vote{
+				Type:             tmproto.PrecommitType,
+				Height:           1,
+				Round:            2,
+				BlockID:          types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}},
+				Timestamp:        time.Now(),
+				ValidatorAddress: pubKey.Address(),
+				ValidatorIndex:   1,
+			}).ToProto()
+			require.NoError(t, tsc.SignVote(ctx, chainID, vote))
+			assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+		})
+	}
+}
@@ -2,6 +2,8 @@ package grpc
 
 import (
 	context "context"
+	"errors"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -9,6 +11,7 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/privval"
 	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
 	"github.com/tendermint/tendermint/types"
 )
@@ -85,3 +88,92 @@ func (ss *SignerServer) SignProposal(ctx context.Context, req *privvalproto.Sign
 
 	return &privvalproto.SignedProposalResponse{Proposal: *proposal}, nil
 }
+
+// ThresholdKey receives a request for the public part of the key share of the
+// validator, which must be a privval.ThresholdSigner
+// returns the key share on success and error on failure
+func (ss *SignerServer) ThresholdKey(ctx context.Context, req *privvalproto.ThresholdKeyRequest) (
+	*privvalproto.ThresholdKeyResponse, error) {
+	signer, err := ss.thresholdSigner(req.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := privval.ThresholdKeyToProto(signer.ThresholdKey())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error transitioning key share to proto: %v", err)
+	}
+
+	ss.logger.Info("SignerServer: ThresholdKey Success")
+
+	return resp, nil
+}
+
+// ThresholdCommit receives a request to commit to the nonces of a threshold
+// signing session
+// returns the commitment on success and error on failure
+func (ss *SignerServer) ThresholdCommit(ctx context.Context, req *privvalproto.ThresholdCommitRequest) (
+	*privvalproto.ThresholdCommitResponse, error) {
+	signer, err := ss.thresholdSigner(req.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	commitment, err := signer.ThresholdCommit(req.SessionId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error committing: %v", err)
+	}
+
+	ss.logger.Info("SignerServer: ThresholdCommit Success", "session", req.SessionId)
+
+	return &privvalproto.ThresholdCommitResponse{Commitment: privval.ThresholdCommitmentToProto(commitment)}, nil
+}
+
+// ThresholdSign receives a request for the partial signature of a vote or a
+// proposal in a threshold signing session
+// returns the partial signature on success and error on failure
+func (ss *SignerServer) ThresholdSign(ctx context.Context, req *privvalproto.ThresholdSignRequest) (
+	*privvalproto.ThresholdSignResponse, error) {
+	signer, err := ss.thresholdSigner(req.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		commitments = privval.ThresholdCommitmentsFromProto(req.Commitments)
+		partial     []byte
+		timestamp   time.Time
+	)
+	switch {
+	case req.Vote != nil && req.Proposal == nil:
+		partial, err = signer.ThresholdSignVote(req.ChainId, req.SessionId, req.Vote, commitments)
+		timestamp = req.Vote.Timestamp
+	case req.Proposal != nil && req.Vote == nil:
+		partial, err = signer.ThresholdSignProposal(req.ChainId, req.SessionId, req.Proposal, commitments)
+		timestamp = req.Proposal.Timestamp
+	default:
+		err = errors.New("expected either a vote or a proposal to sign")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error signing: %v", err)
+	}
+
+	ss.logger.Info("SignerServer: ThresholdSign Success", "session", req.SessionId)
+
+	return &privvalproto.ThresholdSignResponse{
+		Index:            signer.ThresholdKey().Index,
+		PartialSignature: partial,
+		Timestamp:        timestamp,
+	}, nil
+}
+
+func (ss *SignerServer) thresholdSigner(chainID string) (privval.ThresholdSigner, error) {
+	signer, ok := ss.privVal.(privval.ThresholdSigner)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "%T is not a threshold signer", ss.privVal)
+	}
+	if chainID != ss.chainID {
+		return nil, status.Errorf(codes.InvalidArgument, "want chainID: %s, got chainID: %s", ss.chainID, chainID)
+	}
+	return signer, nil
+}
@@ -92,6 +92,18 @@ func DialRemoteSigner(
 	chainID string,
 	logger log.Logger,
 	usePrometheus bool,
+) (*SignerClient, error) {
+	return DialRemoteSignerAddr(cfg, cfg.ListenAddr, chainID, logger, usePrometheus)
+}
+
+// DialRemoteSignerAddr dials the gRPC server at the given address, rather than
+// the one of the configuration, as the remote signers of a threshold key are.
+func DialRemoteSignerAddr(
+	cfg *config.PrivValidatorConfig,
+	addr string,
+	chainID string,
+	logger log.Logger,
+	usePrometheus bool,
 ) (*SignerClient, error) {
 	var transportSecurity grpc.DialOption
 	if cfg.AreSecurityOptionsPresent() {
@@ -111,7 +123,7 @@ func DialRemoteSigner(
 	dialOptions = append(dialOptions, transportSecurity)
 
 	ctx := context.Background()
-	_, address := tmnet.ProtocolAndAddress(cfg.ListenAddr)
+	_, address := tmnet.ProtocolAndAddress(addr)
 	conn, err := grpc.DialContext(ctx, address, dialOptions...)
 	if err != nil {
 		logger.Error("unable to connect to server", "target", address, "err", err)
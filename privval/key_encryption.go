@@ -0,0 +1,169 @@
+package privval
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/term"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+const (
+	// KeyEncryption is the scheme of the encrypted key files: the key is
+	// derived from the passphrase with argon2id, and encrypts the key file
+	// with AES-256-GCM.
+	KeyEncryption = "argon2id-aes256gcm"
+
+	// KeyPassphraseEnv is the environment variable holding the passphrase
+	// of an encrypted key file.
+	KeyPassphraseEnv = "TM_PRIV_VALIDATOR_KEY_PASSPHRASE"
+
+	// KeyPassphraseCredential is the name of the systemd credential holding
+	// the passphrase of an encrypted key file (see LoadCredential= in
+	// systemd.exec(5)).
+	KeyPassphraseCredential = "priv_validator_key_passphrase"
+
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	saltSize      = 16
+
+	// The bounds of the argon2id parameters read from a key file, so that a
+	// corrupted or malicious file can't exhaust the resources of the node.
+	maxArgon2Time    = 64
+	maxArgon2Memory  = 4 * 1024 * 1024 // 4GiB, in KiB
+	maxArgon2Threads = 64
+	maxSaltSize      = 64
+)
+
+// ErrWrongPassphrase is returned when an encrypted key file can't be
+// decrypted with the passphrase.
+var ErrWrongPassphrase = errors.New("wrong passphrase for the encrypted key file")
+
+// encryptedKeyFile is the content of an encrypted key file.
+type encryptedKeyFile struct {
+	Encryption string `json:"encryption"`
+	Salt       []byte `json:"salt"`
+	Time       uint32 `json:"time"`
+	Memory     uint32 `json:"memory"`
+	Threads    uint8  `json:"threads"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// IsEncryptedKey returns whether the content of a key file is encrypted.
+func IsEncryptedKey(bz []byte) bool {
+	var file encryptedKeyFile
+	return json.Unmarshal(bz, &file) == nil && file.Encryption != ""
+}
+
+// EncryptKey encrypts the content of a key file with the passphrase.
+func EncryptKey(bz, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("empty passphrase")
+	}
+	file := encryptedKeyFile{
+		Encryption: KeyEncryption,
+		Salt:       crypto.CRandBytes(saltSize),
+		Time:       argon2Time,
+		Memory:     argon2Memory,
+		Threads:    argon2Threads,
+	}
+	aead, err := file.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	file.Nonce = crypto.CRandBytes(aead.NonceSize())
+	file.Ciphertext = aead.Seal(nil, file.Nonce, bz, nil)
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// DecryptKey decrypts the content of a key file encrypted with the
+// passphrase.
+func DecryptKey(bz, passphrase []byte) ([]byte, error) {
+	var file encryptedKeyFile
+	if err := json.Unmarshal(bz, &file); err != nil {
+		return nil, err
+	}
+	if file.Encryption != KeyEncryption {
+		return nil, fmt.Errorf("unsupported key encryption %q", file.Encryption)
+	}
+	aead, err := file.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+	plaintext, err := aead.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+func (file encryptedKeyFile) aead(passphrase []byte) (cipher.AEAD, error) {
+	if file.Time == 0 || file.Memory == 0 || file.Threads == 0 || len(file.Salt) == 0 {
+		return nil, errors.New("invalid argon2id parameters")
+	}
+	if file.Time > maxArgon2Time || file.Memory > maxArgon2Memory || file.Threads > maxArgon2Threads ||
+		len(file.Salt) > maxSaltSize {
+		return nil, fmt.Errorf("argon2id parameters out of bounds: time %d (max %d), memory %dKiB (max %d), "+
+			"threads %d (max %d), salt %d bytes (max %d)", file.Time, maxArgon2Time, file.Memory, maxArgon2Memory,
+			file.Threads, maxArgon2Threads, len(file.Salt), maxSaltSize)
+	}
+	key := argon2.IDKey(passphrase, file.Salt, file.Time, file.Memory, file.Threads, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// KeyPassphrase returns the passphrase of an encrypted key file, from the
+// first of:
+//
+//   - the TM_PRIV_VALIDATOR_KEY_PASSPHRASE environment variable,
+//   - the priv_validator_key_passphrase systemd credential,
+//   - a prompt, if the standard input is a terminal.
+func KeyPassphrase() ([]byte, error) {
+	if passphrase, ok := os.LookupEnv(KeyPassphraseEnv); ok {
+		return []byte(passphrase), nil
+	}
+	if dir, ok := os.LookupEnv("CREDENTIALS_DIRECTORY"); ok {
+		bz, err := ioutil.ReadFile(filepath.Join(dir, KeyPassphraseCredential))
+		switch {
+		case err == nil:
+			return []byte(strings.TrimRight(string(bz), "\r\n")), nil
+		case !os.IsNotExist(err):
+			return nil, err
+		}
+	}
+	passphrase, err := PromptPassphrase("Passphrase of the validator key: ")
+	if err != nil {
+		return nil, fmt.Errorf("the validator key is encrypted, set %s or the %s systemd credential: %w",
+			KeyPassphraseEnv, KeyPassphraseCredential, err)
+	}
+	return passphrase, nil
+}
+
+// PromptPassphrase prompts for a passphrase on the terminal.
+func PromptPassphrase(prompt string) ([]byte, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, errors.New("can't prompt for a passphrase, the standard input is not a terminal")
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	return passphrase, err
+}
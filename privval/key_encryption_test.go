@@ -0,0 +1,110 @@
+package privval
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecryptKey(t *testing.T) {
+	plaintext := []byte(`{"priv_key":"secret"}`)
+
+	encrypted, err := EncryptKey(plaintext, []byte("passphrase"))
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedKey(encrypted))
+	assert.False(t, IsEncryptedKey(plaintext))
+	assert.NotContains(t, string(encrypted), "secret")
+
+	decrypted, err := DecryptKey(encrypted, []byte("passphrase"))
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	_, err = DecryptKey(encrypted, []byte("wrong"))
+	assert.Equal(t, ErrWrongPassphrase, err)
+
+	_, err = EncryptKey(plaintext, nil)
+	assert.Error(t, err)
+}
+
+func TestDecryptKeyParametersOutOfBounds(t *testing.T) {
+	encrypted, err := EncryptKey([]byte(`{"priv_key":"secret"}`), []byte("passphrase"))
+	require.NoError(t, err)
+
+	testCases := map[string]func(*encryptedKeyFile){
+		"time":    func(f *encryptedKeyFile) { f.Time = maxArgon2Time + 1 },
+		"memory":  func(f *encryptedKeyFile) { f.Memory = 1 << 31 },
+		"threads": func(f *encryptedKeyFile) { f.Threads = maxArgon2Threads + 1 },
+		"salt":    func(f *encryptedKeyFile) { f.Salt = make([]byte, maxSaltSize+1) },
+	}
+	for name, tamper := range testCases {
+		tamper := tamper
+		t.Run(name, func(t *testing.T) {
+			var file encryptedKeyFile
+			require.NoError(t, json.Unmarshal(encrypted, &file))
+			tamper(&file)
+			bz, err := json.Marshal(file)
+			require.NoError(t, err)
+
+			_, err = DecryptKey(bz, []byte("passphrase"))
+			require.Error(t, err)
+			assert.NotEqual(t, ErrWrongPassphrase, err)
+		})
+	}
+}
+
+func TestLoadEncryptedFilePV(t *testing.T) {
+	dir, err := ioutil.TempDir("", "privval")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "priv_validator_key.json")
+	stateFile := filepath.Join(dir, "priv_validator_state.json")
+
+	privVal, err := GenFilePV(keyFile, stateFile, "")
+	require.NoError(t, err)
+	privVal.Key.SetPassphrase([]byte("passphrase"))
+	privVal.Save()
+
+	bz, err := ioutil.ReadFile(keyFile)
+	require.NoError(t, err)
+	require.True(t, IsEncryptedKey(bz))
+
+	require.NoError(t, os.Setenv(KeyPassphraseEnv, "wrong"))
+	defer os.Unsetenv(KeyPassphraseEnv)
+	_, err = LoadFilePV(keyFile, stateFile)
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+
+	require.NoError(t, os.Setenv(KeyPassphraseEnv, "passphrase"))
+	loaded, err := LoadFilePV(keyFile, stateFile)
+	require.NoError(t, err)
+	assert.Equal(t, privVal.GetAddress(), loaded.GetAddress())
+
+	// saving a loaded key keeps it encrypted with the same passphrase
+	loaded.Save()
+	bz, err = ioutil.ReadFile(keyFile)
+	require.NoError(t, err)
+	require.True(t, IsEncryptedKey(bz))
+	loaded, err = LoadFilePV(keyFile, stateFile)
+	require.NoError(t, err)
+	assert.Equal(t, privVal.GetAddress(), loaded.GetAddress())
+
+	// the systemd credential is used in the absence of the variable
+	require.NoError(t, os.Unsetenv(KeyPassphraseEnv))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, KeyPassphraseCredential), []byte("passphrase\n"), 0600))
+	require.NoError(t, os.Setenv("CREDENTIALS_DIRECTORY", dir))
+	defer os.Unsetenv("CREDENTIALS_DIRECTORY")
+	loaded, err = LoadFilePV(keyFile, stateFile)
+	require.NoError(t, err)
+	assert.Equal(t, privVal.GetAddress(), loaded.GetAddress())
+
+	// decrypting saves the key in plaintext
+	loaded.Key.SetPassphrase(nil)
+	loaded.Key.Save()
+	bz, err = ioutil.ReadFile(keyFile)
+	require.NoError(t, err)
+	assert.False(t, IsEncryptedKey(bz))
+}
@@ -0,0 +1,153 @@
+package privval
+
+import (
+	stded25519 "crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/sr25519"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+const (
+	// pemTypePKCS8 is the type of the PEM blocks of the ed25519 keys, in
+	// PKCS #8 as written by openssl.
+	pemTypePKCS8 = "PRIVATE KEY"
+
+	// pemTypeTendermint is the type of the PEM blocks of the keys of the
+	// other types, which have no standard encoding: the block holds the
+	// bytes of the key, and its Type header the name of the key type in the
+	// JSON key files, e.g. tendermint/PrivKeySecp256k1.
+	pemTypeTendermint = "TENDERMINT PRIVATE KEY"
+	pemHeaderType     = "Type"
+
+	// bip39Iterations is the number of PBKDF2 iterations deriving the seed
+	// of a mnemonic, as in BIP-39.
+	bip39Iterations = 2048
+)
+
+// MarshalPrivKeyPEM encodes the key as a PEM block: a PKCS #8 block for the
+// ed25519 keys, as read by openssl and most HSM tooling, and a TENDERMINT
+// PRIVATE KEY block for the other types.
+func MarshalPrivKeyPEM(privKey crypto.PrivKey) ([]byte, error) {
+	if privKey, ok := privKey.(ed25519.PrivKey); ok {
+		der, err := x509.MarshalPKCS8PrivateKey(stded25519.PrivateKey(privKey))
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: pemTypePKCS8, Bytes: der}), nil
+	}
+
+	// the name of the type is the one registered with tmjson
+	bz, err := tmjson.Marshal(privKey)
+	if err != nil {
+		return nil, err
+	}
+	var typed struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(bz, &typed); err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:    pemTypeTendermint,
+		Headers: map[string]string{pemHeaderType: typed.Type},
+		Bytes:   privKey.Bytes(),
+	}), nil
+}
+
+// UnmarshalPrivKeyPEM decodes the key of a PEM block encoded by
+// MarshalPrivKeyPEM, or of any PKCS #8 block holding an ed25519 key.
+func UnmarshalPrivKeyPEM(bz []byte) (crypto.PrivKey, error) {
+	block, _ := pem.Decode(bz)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	switch block.Type {
+	case pemTypePKCS8:
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		privKey, ok := key.(stded25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("unsupported PKCS #8 key of type %T", key)
+		}
+		return ed25519.PrivKey(privKey), nil
+
+	case pemTypeTendermint:
+		keyType, ok := block.Headers[pemHeaderType]
+		if !ok {
+			return nil, fmt.Errorf("missing %s header", pemHeaderType)
+		}
+		typed, err := json.Marshal(struct {
+			Type  string `json:"type"`
+			Value []byte `json:"value"`
+		}{keyType, block.Bytes})
+		if err != nil {
+			return nil, err
+		}
+		var privKey crypto.PrivKey
+		if err := tmjson.Unmarshal(typed, &privKey); err != nil {
+			return nil, fmt.Errorf("invalid %s key: %w", keyType, err)
+		}
+		return privKey, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
+
+// GenPrivKeyFromSeed deterministically derives a key of the type from the
+// seed, e.g. for the keys of a test network to be the same on each run.
+func GenPrivKeyFromSeed(keyType string, seed []byte) (crypto.PrivKey, error) {
+	switch keyType {
+	case "", ed25519.KeyType:
+		return ed25519.GenPrivKeyFromSecret(seed), nil
+	case secp256k1.KeyType:
+		return secp256k1.GenPrivKeySecp256k1(seed), nil
+	case sr25519.KeyType:
+		return sr25519.GenPrivKeyFromSecret(seed), nil
+	case bls.KeyType:
+		return bls.GenPrivKeyFromSecret(seed), nil
+	default:
+		return nil, fmt.Errorf("key type: %s is not supported", keyType)
+	}
+}
+
+// PrivKeyFromMnemonic derives a key of the type from the mnemonic and its
+// passphrase. The seed of the mnemonic is derived as in BIP-39, and the
+// ed25519 keys are the master keys of the seed in SLIP-0010, as derived by
+// the wallets, the keys of the other types being derived from the seed by
+// GenPrivKeyFromSeed.
+//
+// The words of the mnemonic aren't checked against the BIP-39 word lists, nor
+// normalized but for their spacing.
+func PrivKeyFromMnemonic(keyType, mnemonic, passphrase string) (crypto.PrivKey, error) {
+	words := strings.Fields(mnemonic)
+	if len(words) == 0 {
+		return nil, errors.New("empty mnemonic")
+	}
+	seed := pbkdf2.Key([]byte(strings.Join(words, " ")), []byte("mnemonic"+passphrase),
+		bip39Iterations, 64, sha512.New)
+
+	if keyType != "" && keyType != ed25519.KeyType {
+		return GenPrivKeyFromSeed(keyType, seed)
+	}
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	master := mac.Sum(nil)
+	return ed25519.PrivKey(stded25519.NewKeyFromSeed(master[:32])), nil
+}
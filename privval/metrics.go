@@ -0,0 +1,85 @@
+package privval
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "privval"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Time taken by a remote signer endpoint to sign, by endpoint and type of
+	// message.
+	SignLatency metrics.Histogram
+	// Number of sign requests which failed, by endpoint.
+	SignFailures metrics.Counter
+	// Number of votes and proposals which no remote signer endpoint signed,
+	// by type of message.
+	MissedSigns metrics.Counter
+	// Number of times signing moved to another remote signer endpoint.
+	Failovers metrics.Counter
+	// Whether a remote signer endpoint is connected (1) or not (0), by
+	// endpoint.
+	EndpointConnected metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		SignLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sign_latency",
+			Help:      "Time taken by a remote signer endpoint to sign, in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 12),
+		}, append(labels, "endpoint", "type")).With(labelsAndValues...),
+		SignFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sign_failures",
+			Help:      "Number of sign requests to a remote signer endpoint which failed.",
+		}, append(labels, "endpoint")).With(labelsAndValues...),
+		MissedSigns: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "missed_signs",
+			Help:      "Number of votes and proposals which no remote signer endpoint signed.",
+		}, append(labels, "type")).With(labelsAndValues...),
+		Failovers: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "failovers",
+			Help:      "Number of times signing moved to another remote signer endpoint.",
+		}, labels).With(labelsAndValues...),
+		EndpointConnected: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "endpoint_connected",
+			Help:      "Whether a remote signer endpoint is connected (1) or not (0).",
+		}, append(labels, "endpoint")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		SignLatency:       discard.NewHistogram(),
+		SignFailures:      discard.NewCounter(),
+		MissedSigns:       discard.NewCounter(),
+		Failovers:         discard.NewCounter(),
+		EndpointConnected: discard.NewGauge(),
+	}
+}
@@ -32,6 +32,18 @@ func mustWrapMsg(pb proto.Message) privvalproto.Message {
 		msg.Sum = &privvalproto.Message_PingRequest{PingRequest: pb}
 	case *privvalproto.PingResponse:
 		msg.Sum = &privvalproto.Message_PingResponse{PingResponse: pb}
+	case *privvalproto.ThresholdCommitRequest:
+		msg.Sum = &privvalproto.Message_ThresholdCommitRequest{ThresholdCommitRequest: pb}
+	case *privvalproto.ThresholdCommitResponse:
+		msg.Sum = &privvalproto.Message_ThresholdCommitResponse{ThresholdCommitResponse: pb}
+	case *privvalproto.ThresholdSignRequest:
+		msg.Sum = &privvalproto.Message_ThresholdSignRequest{ThresholdSignRequest: pb}
+	case *privvalproto.ThresholdSignResponse:
+		msg.Sum = &privvalproto.Message_ThresholdSignResponse{ThresholdSignResponse: pb}
+	case *privvalproto.ThresholdKeyRequest:
+		msg.Sum = &privvalproto.Message_ThresholdKeyRequest{ThresholdKeyRequest: pb}
+	case *privvalproto.ThresholdKeyResponse:
+		msg.Sum = &privvalproto.Message_ThresholdKeyResponse{ThresholdKeyResponse: pb}
 	default:
 		panic(fmt.Errorf("unknown message type %T", pb))
 	}
@@ -90,6 +90,11 @@ func TestPrivvalVectors(t *testing.T) {
 		{"Proposal Request", &privproto.SignProposalRequest{Proposal: proposalpb}, "2a700a6e08011003180220022a4a0a208b01023386c371778ecb6368573e539afc3cc860ec3a2f614e54fe5652f4fc80122608c0843d122072db3d959635dff1bb567bedaa70573392c5159666a3f8caf11e413aac52207a320608f49a8ded053a10697427732061207369676e6174757265"},
 		{"Proposal Response", &privproto.SignedProposalResponse{Proposal: *proposalpb, Error: nil}, "32700a6e08011003180220022a4a0a208b01023386c371778ecb6368573e539afc3cc860ec3a2f614e54fe5652f4fc80122608c0843d122072db3d959635dff1bb567bedaa70573392c5159666a3f8caf11e413aac52207a320608f49a8ded053a10697427732061207369676e6174757265"},
 		{"Proposal Response with error", &privproto.SignedProposalResponse{Proposal: tmproto.Proposal{}, Error: remoteError}, "32250a112a021200320b088092b8c398feffffff0112100801120c697427732061206572726f72"},
+		{"Threshold Commit Request", &privproto.ThresholdCommitRequest{SessionId: "s", ChainId: "c"}, "4a060a0173120163"},
+		{"Threshold Commit Response", &privproto.ThresholdCommitResponse{Commitment: privproto.ThresholdCommitment{Index: 1, Hiding: []byte{1}, Binding: []byte{2}}}, "520a0a0808011201011a0102"},
+		{"Threshold Sign Response", &privproto.ThresholdSignResponse{Index: 1, PartialSignature: []byte{3}}, "62120801120103220b088092b8c398feffffff01"},
+		{"Threshold Key Request", &privproto.ThresholdKeyRequest{ChainId: "c"}, "6a030a0163"},
+		{"Threshold Key Response", &privproto.ThresholdKeyResponse{Index: 1, PubKey: ppk, PubShares: [][]byte{{1}}, Threshold: 1}, "722b080112220a20556a436f1218d30942efe798420f51dc9b6a311b929c578257457d05c5fcf2301a01012001"},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,131 @@
+package privval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+// ErrSignStateConflict is returned by a SignStateStore when the stored state
+// is no longer the one a validator last loaded, i.e. another instance of the
+// validator signed in between.
+var ErrSignStateConflict = errors.New("sign state was updated concurrently")
+
+// SignStateStore stores the last sign state, i.e. the high watermark, of a
+// validator.
+//
+// An implementation backed by a strongly consistent store (e.g. etcd, or any
+// raft-backed key/value store) may be shared between the active and the
+// standby instances of a validator: as every instance loads the state before
+// signing, and only releases a signature once it swapped the state, at most
+// one of them signs at each height, round and step.
+type SignStateStore interface {
+	// Load returns the stored sign state.
+	Load() (FilePVLastSignState, error)
+	// CompareAndSwap stores next, if the stored sign state is still prev.
+	// It returns ErrSignStateConflict otherwise.
+	CompareAndSwap(prev, next FilePVLastSignState) error
+}
+
+// Equal returns whether the two sign states are the same.
+func (lss FilePVLastSignState) Equal(other FilePVLastSignState) bool {
+	return lss.Height == other.Height &&
+		lss.Round == other.Round &&
+		lss.Step == other.Step &&
+		bytes.Equal(lss.Signature, other.Signature) &&
+		bytes.Equal(lss.SignBytes, other.SignBytes)
+}
+
+// FileSignStateStore is a SignStateStore backed by a file, which can only be
+// shared by the validators of a single process.
+type FileSignStateStore struct {
+	mtx      tmsync.Mutex
+	filePath string
+}
+
+var _ SignStateStore = (*FileSignStateStore)(nil)
+
+// NewFileSignStateStore returns a SignStateStore backed by the given file. A
+// missing file is an empty state.
+func NewFileSignStateStore(filePath string) *FileSignStateStore {
+	return &FileSignStateStore{filePath: filePath}
+}
+
+// Load implements SignStateStore.
+func (s *FileSignStateStore) Load() (FilePVLastSignState, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.load()
+}
+
+// CompareAndSwap implements SignStateStore.
+func (s *FileSignStateStore) CompareAndSwap(prev, next FilePVLastSignState) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	stored, err := s.load()
+	if err != nil {
+		return err
+	}
+	if !stored.Equal(prev) {
+		return ErrSignStateConflict
+	}
+	jsonBytes, err := tmjson.MarshalIndent(next, "", "  ")
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(s.filePath, jsonBytes, 0600)
+}
+
+func (s *FileSignStateStore) load() (FilePVLastSignState, error) {
+	state := FilePVLastSignState{}
+	jsonBytes, err := ioutil.ReadFile(s.filePath)
+	switch {
+	case os.IsNotExist(err):
+		return state, nil
+	case err != nil:
+		return state, err
+	}
+	if err := tmjson.Unmarshal(jsonBytes, &state); err != nil {
+		return state, fmt.Errorf("error reading sign state from %v: %w", s.filePath, err)
+	}
+	return state, nil
+}
+
+// MemSignStateStore is a SignStateStore in memory.
+type MemSignStateStore struct {
+	mtx   tmsync.Mutex
+	state FilePVLastSignState
+}
+
+var _ SignStateStore = (*MemSignStateStore)(nil)
+
+// NewMemSignStateStore returns an empty SignStateStore in memory.
+func NewMemSignStateStore() *MemSignStateStore {
+	return &MemSignStateStore{}
+}
+
+// Load implements SignStateStore.
+func (s *MemSignStateStore) Load() (FilePVLastSignState, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.state, nil
+}
+
+// CompareAndSwap implements SignStateStore.
+func (s *MemSignStateStore) CompareAndSwap(prev, next FilePVLastSignState) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if !s.state.Equal(prev) {
+		return ErrSignStateConflict
+	}
+	s.state = next
+	return nil
+}
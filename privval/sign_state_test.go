@@ -0,0 +1,70 @@
+package privval
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestSignStateStoreActiveStandby(t *testing.T) {
+	ctx := context.Background()
+	chainID := tmrand.Str(12)
+	privKey := ed25519.GenPrivKey()
+	store := NewMemSignStateStore()
+
+	active := NewFilePV(privKey, "", "")
+	require.NoError(t, active.SetSignStateStore(store))
+	standby := NewFilePV(privKey, "", "")
+	require.NoError(t, standby.SetSignStateStore(store))
+
+	blockID := types.BlockID{Hash: tmrand.Bytes(32)}
+	vote := newVote(active.Key.Address, 0, 10, 0, tmproto.PrevoteType, blockID).ToProto()
+	require.NoError(t, active.SignVote(ctx, chainID, vote))
+
+	// the standby sees the high watermark of the active instance
+	conflicting := newVote(active.Key.Address, 0, 10, 0, tmproto.PrevoteType,
+		types.BlockID{Hash: tmrand.Bytes(32)}).ToProto()
+	assert.Error(t, standby.SignVote(ctx, chainID, conflicting))
+	assert.Empty(t, conflicting.Signature)
+
+	// and takes over from it
+	proposal := newProposal(11, 0, blockID).ToProto()
+	require.NoError(t, standby.SignProposal(ctx, chainID, proposal))
+	assert.EqualValues(t, 11, store.state.Height)
+
+	// the active instance can't go back
+	proposal = newProposal(10, 1, blockID).ToProto()
+	assert.Error(t, active.SignProposal(ctx, chainID, proposal))
+}
+
+func TestFileSignStateStore(t *testing.T) {
+	stateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(stateFile.Name()))
+	t.Cleanup(func() { os.Remove(stateFile.Name()) })
+	store := NewFileSignStateStore(stateFile.Name())
+
+	// a missing file is an empty state
+	prev, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, FilePVLastSignState{}, prev)
+
+	next := FilePVLastSignState{Height: 1, Step: stepPrevote, Signature: []byte{1}, SignBytes: []byte{2}}
+	require.NoError(t, store.CompareAndSwap(prev, next))
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.True(t, next.Equal(loaded))
+
+	// the swap fails if the state changed in between
+	other := FilePVLastSignState{Height: 2, Step: stepPrevote, Signature: []byte{3}, SignBytes: []byte{4}}
+	assert.ErrorIs(t, store.CompareAndSwap(prev, other), ErrSignStateConflict)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/crypto/threshold"
 	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
@@ -132,3 +133,78 @@ func (sc *SignerClient) SignProposal(ctx context.Context, chainID string, propos
 
 	return nil
 }
+
+// ThresholdKey requests the public part of the key share of a remote signer,
+// holding a share of a threshold key.
+func (sc *SignerClient) ThresholdKey(ctx context.Context) (threshold.KeyShare, error) {
+	response, err := sc.endpoint.SendRequest(mustWrapMsg(
+		&privvalproto.ThresholdKeyRequest{ChainId: sc.chainID},
+	))
+	if err != nil {
+		return threshold.KeyShare{}, err
+	}
+
+	resp := response.GetThresholdKeyResponse()
+	if resp == nil {
+		return threshold.KeyShare{}, ErrUnexpectedResponse
+	}
+	if resp.Error != nil {
+		return threshold.KeyShare{}, &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	return ThresholdKeyFromProto(resp)
+}
+
+// ThresholdCommit requests a remote signer, holding a share of a threshold
+// key, to commit to fresh nonces for the session.
+func (sc *SignerClient) ThresholdCommit(ctx context.Context, sessionID string) (threshold.Commitment, error) {
+	response, err := sc.endpoint.SendRequest(mustWrapMsg(
+		&privvalproto.ThresholdCommitRequest{SessionId: sessionID, ChainId: sc.chainID},
+	))
+	if err != nil {
+		return threshold.Commitment{}, err
+	}
+
+	resp := response.GetThresholdCommitResponse()
+	if resp == nil {
+		return threshold.Commitment{}, ErrUnexpectedResponse
+	}
+	if resp.Error != nil {
+		return threshold.Commitment{}, &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	return ThresholdCommitmentsFromProto([]privvalproto.ThresholdCommitment{resp.Commitment})[0], nil
+}
+
+// ThresholdSign requests a remote signer, holding a share of a threshold key,
+// for its partial signature of either the vote or the proposal in the
+// session. It returns the index of the share of the signer, the partial
+// signature and the timestamp signed.
+func (sc *SignerClient) ThresholdSign(
+	ctx context.Context,
+	sessionID string,
+	vote *tmproto.Vote,
+	proposal *tmproto.Proposal,
+	commitments []threshold.Commitment,
+) (uint32, []byte, time.Time, error) {
+	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.ThresholdSignRequest{
+		SessionId:   sessionID,
+		ChainId:     sc.chainID,
+		Vote:        vote,
+		Proposal:    proposal,
+		Commitments: ThresholdCommitmentsToProto(commitments),
+	}))
+	if err != nil {
+		return 0, nil, time.Time{}, err
+	}
+
+	resp := response.GetThresholdSignResponse()
+	if resp == nil {
+		return 0, nil, time.Time{}, ErrUnexpectedResponse
+	}
+	if resp.Error != nil {
+		return 0, nil, time.Time{}, &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	return resp.Index, resp.PartialSignature, resp.Timestamp, nil
+}
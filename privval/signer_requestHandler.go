@@ -2,10 +2,13 @@ package privval
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/crypto/threshold"
 	cryptoproto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -88,6 +91,82 @@ func DefaultValidationRequestHandler(
 	case *privvalproto.Message_PingRequest:
 		err, res = nil, mustWrapMsg(&privvalproto.PingResponse{})
 
+	case *privvalproto.Message_ThresholdKeyRequest:
+		signer, ok := privVal.(ThresholdSigner)
+		if !ok || r.ThresholdKeyRequest.GetChainId() != chainID {
+			res = mustWrapMsg(&privvalproto.ThresholdKeyResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: "unable to provide threshold key"}})
+			if !ok {
+				return res, fmt.Errorf("%T is not a threshold signer", privVal)
+			}
+			return res, fmt.Errorf("want chainID: %s, got chainID: %s", r.ThresholdKeyRequest.GetChainId(), chainID)
+		}
+
+		var resp *privvalproto.ThresholdKeyResponse
+		resp, err = ThresholdKeyToProto(signer.ThresholdKey())
+		if err != nil {
+			res = mustWrapMsg(&privvalproto.ThresholdKeyResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
+		} else {
+			res = mustWrapMsg(resp)
+		}
+
+	case *privvalproto.Message_ThresholdCommitRequest:
+		signer, ok := privVal.(ThresholdSigner)
+		if !ok || r.ThresholdCommitRequest.GetChainId() != chainID {
+			res = mustWrapMsg(&privvalproto.ThresholdCommitResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: "unable to commit"}})
+			if !ok {
+				return res, fmt.Errorf("%T is not a threshold signer", privVal)
+			}
+			return res, fmt.Errorf("want chainID: %s, got chainID: %s", r.ThresholdCommitRequest.GetChainId(), chainID)
+		}
+
+		var commitment threshold.Commitment
+		commitment, err = signer.ThresholdCommit(r.ThresholdCommitRequest.SessionId)
+		if err != nil {
+			res = mustWrapMsg(&privvalproto.ThresholdCommitResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
+		} else {
+			res = mustWrapMsg(&privvalproto.ThresholdCommitResponse{
+				Commitment: ThresholdCommitmentToProto(commitment), Error: nil})
+		}
+
+	case *privvalproto.Message_ThresholdSignRequest:
+		signer, ok := privVal.(ThresholdSigner)
+		if !ok || r.ThresholdSignRequest.GetChainId() != chainID {
+			res = mustWrapMsg(&privvalproto.ThresholdSignResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: "unable to sign"}})
+			if !ok {
+				return res, fmt.Errorf("%T is not a threshold signer", privVal)
+			}
+			return res, fmt.Errorf("want chainID: %s, got chainID: %s", r.ThresholdSignRequest.GetChainId(), chainID)
+		}
+
+		var (
+			req         = r.ThresholdSignRequest
+			commitments = ThresholdCommitmentsFromProto(req.Commitments)
+			partial     []byte
+			timestamp   time.Time
+		)
+		switch {
+		case req.Vote != nil && req.Proposal == nil:
+			partial, err = signer.ThresholdSignVote(chainID, req.SessionId, req.Vote, commitments)
+			timestamp = req.Vote.Timestamp
+		case req.Proposal != nil && req.Vote == nil:
+			partial, err = signer.ThresholdSignProposal(chainID, req.SessionId, req.Proposal, commitments)
+			timestamp = req.Proposal.Timestamp
+		default:
+			err = errors.New("expected either a vote or a proposal to sign")
+		}
+		if err != nil {
+			res = mustWrapMsg(&privvalproto.ThresholdSignResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
+		} else {
+			res = mustWrapMsg(&privvalproto.ThresholdSignResponse{
+				Index: signer.ThresholdKey().Index, PartialSignature: partial, Timestamp: timestamp, Error: nil})
+		}
+
 	default:
 		err = fmt.Errorf("unknown msg: %v", r)
 	}
@@ -0,0 +1,376 @@
+package privval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/crypto/threshold"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// maxThresholdSessions is the number of sessions a ThresholdPV keeps the
+// nonces of, as the nonces of abandoned sessions are never used.
+const maxThresholdSessions = 64
+
+// ErrThresholdSignAlone is returned when a ThresholdPV is asked to sign on its
+// own.
+var ErrThresholdSignAlone = errors.New("a share of a threshold key can't sign alone")
+
+// ThresholdSigner is implemented by the validators which hold a share of a
+// threshold key, and sign jointly with the holders of the other shares.
+type ThresholdSigner interface {
+	// ThresholdKey returns the public part of the key share of the signer.
+	ThresholdKey() threshold.KeyShare
+	// ThresholdCommit commits to the nonces of the session. Only the keys
+	// for which threshold.Interactive is true take commitments.
+	ThresholdCommit(sessionID string) (threshold.Commitment, error)
+	// ThresholdSignVote returns the partial signature of the vote in the
+	// session, given the commitments of the signers of the session. Like
+	// SignVote, it may set the timestamp of the vote to the one signed before.
+	ThresholdSignVote(chainID, sessionID string, vote *tmproto.Vote,
+		commitments []threshold.Commitment) ([]byte, error)
+	// ThresholdSignProposal returns the partial signature of the proposal in
+	// the session, given the commitments of the signers of the session. Like
+	// SignProposal, it may set the timestamp of the proposal to the one signed
+	// before.
+	ThresholdSignProposal(chainID, sessionID string, proposal *tmproto.Proposal,
+		commitments []threshold.Commitment) ([]byte, error)
+}
+
+// ThresholdPVKey stores a share of a threshold validator key.
+type ThresholdPVKey struct {
+	threshold.KeyShare `json:"key_share"`
+
+	filePath string
+}
+
+// Save persists the ThresholdPVKey to its filePath.
+func (pvKey ThresholdPVKey) Save() {
+	outFile := pvKey.filePath
+	if outFile == "" {
+		panic("cannot save ThresholdPV key: filePath not set")
+	}
+
+	jsonBytes, err := tmjson.MarshalIndent(pvKey, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	err = tempfile.WriteFileAtomic(outFile, jsonBytes, 0600)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ThresholdPV holds a share of a threshold validator key, and serves the
+// threshold signing sessions of a node as a remote signer. Like FilePV, it
+// persists the last height, round and step it signed for, and refuses to sign
+// conflicting data.
+type ThresholdPV struct {
+	Key           ThresholdPVKey
+	LastSignState FilePVLastSignState
+
+	mtx tmsync.Mutex
+	// the nonces of the sessions committed to, by session ID
+	nonces   map[string]*threshold.Nonces
+	sessions []string
+}
+
+var (
+	_ types.PrivValidator = (*ThresholdPV)(nil)
+	_ ThresholdSigner     = (*ThresholdPV)(nil)
+)
+
+// NewThresholdPV returns a validator holding the given key share.
+func NewThresholdPV(share threshold.KeyShare, keyFilePath, stateFilePath string) *ThresholdPV {
+	return &ThresholdPV{
+		Key: ThresholdPVKey{
+			KeyShare: share,
+			filePath: keyFilePath,
+		},
+		LastSignState: FilePVLastSignState{
+			Step:     stepNone,
+			filePath: stateFilePath,
+		},
+		nonces: make(map[string]*threshold.Nonces),
+	}
+}
+
+// GenThresholdPVKeys generates a new threshold validator key of the given type,
+// split into n shares of which required are needed to sign.
+func GenThresholdPVKeys(keyType string, required, n int) ([]ThresholdPVKey, error) {
+	shares, err := threshold.Deal(keyType, required, n)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]ThresholdPVKey, len(shares))
+	for i, share := range shares {
+		keys[i] = ThresholdPVKey{KeyShare: share}
+	}
+	return keys, nil
+}
+
+// LoadThresholdPV loads a ThresholdPV from the filePaths. If the state file
+// does not exist, the validator starts with an empty state.
+func LoadThresholdPV(keyFilePath, stateFilePath string) (*ThresholdPV, error) {
+	keyJSONBytes, err := ioutil.ReadFile(keyFilePath)
+	if err != nil {
+		return nil, err
+	}
+	pvKey := ThresholdPVKey{}
+	if err := tmjson.Unmarshal(keyJSONBytes, &pvKey); err != nil {
+		return nil, fmt.Errorf("error reading ThresholdPV key from %v: %w", keyFilePath, err)
+	}
+
+	pv := NewThresholdPV(pvKey.KeyShare, keyFilePath, stateFilePath)
+	stateJSONBytes, err := ioutil.ReadFile(stateFilePath)
+	switch {
+	case err == nil:
+		if err := tmjson.Unmarshal(stateJSONBytes, &pv.LastSignState); err != nil {
+			return nil, fmt.Errorf("error reading ThresholdPV state from %v: %w", stateFilePath, err)
+		}
+		pv.LastSignState.filePath = stateFilePath
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+	return pv, nil
+}
+
+// GetPubKey returns the threshold public key of the validator.
+// Implements PrivValidator.
+func (pv *ThresholdPV) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
+	return pv.Key.PubKey, nil
+}
+
+// SignVote implements PrivValidator, and always fails.
+func (pv *ThresholdPV) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	return ErrThresholdSignAlone
+}
+
+// SignProposal implements PrivValidator, and always fails.
+func (pv *ThresholdPV) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	return ErrThresholdSignAlone
+}
+
+// ThresholdKey implements ThresholdSigner.
+func (pv *ThresholdPV) ThresholdKey() threshold.KeyShare {
+	return pv.Key.Public()
+}
+
+// ThresholdCommit implements ThresholdSigner.
+func (pv *ThresholdPV) ThresholdCommit(sessionID string) (threshold.Commitment, error) {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	if _, ok := pv.nonces[sessionID]; ok {
+		return threshold.Commitment{}, fmt.Errorf("session %q already committed to", sessionID)
+	}
+	nonces, commitment, err := pv.Key.Commit()
+	if err != nil {
+		return threshold.Commitment{}, err
+	}
+	if len(pv.sessions) == maxThresholdSessions {
+		delete(pv.nonces, pv.sessions[0])
+		pv.sessions = pv.sessions[1:]
+	}
+	pv.nonces[sessionID] = nonces
+	pv.sessions = append(pv.sessions, sessionID)
+	return commitment, nil
+}
+
+// ThresholdSignVote implements ThresholdSigner.
+func (pv *ThresholdPV) ThresholdSignVote(
+	chainID, sessionID string,
+	vote *tmproto.Vote,
+	commitments []threshold.Commitment,
+) ([]byte, error) {
+	partial, timestamp, err := pv.sign(sessionID, vote.Height, vote.Round, voteToStep(vote),
+		types.VoteSignBytes(chainID, vote), commitments, checkVotesOnlyDifferByTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	if !timestamp.IsZero() {
+		vote.Timestamp = timestamp
+	}
+	return partial, nil
+}
+
+// ThresholdSignProposal implements ThresholdSigner.
+func (pv *ThresholdPV) ThresholdSignProposal(
+	chainID, sessionID string,
+	proposal *tmproto.Proposal,
+	commitments []threshold.Commitment,
+) ([]byte, error) {
+	partial, timestamp, err := pv.sign(sessionID, proposal.Height, proposal.Round, stepPropose,
+		types.ProposalSignBytes(chainID, proposal), commitments, checkProposalsOnlyDifferByTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	if !timestamp.IsZero() {
+		proposal.Timestamp = timestamp
+	}
+	return partial, nil
+}
+
+// sign returns the partial signature of signBytes, once the nonces of the
+// session, if the key takes any, are used up.
+//
+// As FilePV, it signs the same data again at the same height, round and step,
+// and if the data only differs by timestamp, the data signed before, of which
+// it returns the timestamp. As the partial signatures of different sessions
+// can't be combined, the data is signed again in the session rather than the
+// last signature returned.
+func (pv *ThresholdPV) sign(
+	sessionID string,
+	height int64,
+	round int32,
+	step int8,
+	signBytes []byte,
+	commitments []threshold.Commitment,
+	onlyDifferByTimestamp func(lastSignBytes, newSignBytes []byte) (time.Time, bool),
+) ([]byte, time.Time, error) {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	var nonces *threshold.Nonces
+	if threshold.Interactive(pv.Key.PubKey) {
+		var ok bool
+		nonces, ok = pv.nonces[sessionID]
+		if !ok {
+			return nil, time.Time{}, fmt.Errorf("unknown session %q", sessionID)
+		}
+		delete(pv.nonces, sessionID)
+		for i, id := range pv.sessions {
+			if id == sessionID {
+				pv.sessions = append(pv.sessions[:i], pv.sessions[i+1:]...)
+				break
+			}
+		}
+	}
+
+	lss := pv.LastSignState
+	sameHRS, err := lss.CheckHRS(height, round, step)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	// We might crash before writing to the wal,
+	// causing us to try to re-sign for the same HRS.
+	// If signbytes are the same, sign them again.
+	// If they only differ by timestamp, sign the last signbytes, with the last
+	// timestamp. Otherwise, return error
+	if sameHRS {
+		var timestamp time.Time
+		if !bytes.Equal(signBytes, lss.SignBytes) {
+			var ok bool
+			if timestamp, ok = onlyDifferByTimestamp(lss.SignBytes, signBytes); !ok {
+				return nil, time.Time{}, errors.New("conflicting data")
+			}
+		}
+		partial, err := pv.Key.Sign(nonces, lss.SignBytes, commitments)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return partial, timestamp, nil
+	}
+
+	partial, err := pv.Key.Sign(nonces, signBytes, commitments)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	pv.LastSignState.Height = height
+	pv.LastSignState.Round = round
+	pv.LastSignState.Step = step
+	pv.LastSignState.Signature = partial
+	pv.LastSignState.SignBytes = signBytes
+	pv.LastSignState.Save()
+	return partial, time.Time{}, nil
+}
+
+// String returns a string representation of the ThresholdPV.
+func (pv *ThresholdPV) String() string {
+	return fmt.Sprintf(
+		"ThresholdPV{%v#%d LH:%v, LR:%v, LS:%v}",
+		pv.Key.PubKey.Address(),
+		pv.Key.Index,
+		pv.LastSignState.Height,
+		pv.LastSignState.Round,
+		pv.LastSignState.Step,
+	)
+}
+
+// ThresholdCommitmentToProto converts the commitment to its protobuf
+// representation.
+func ThresholdCommitmentToProto(c threshold.Commitment) privvalproto.ThresholdCommitment {
+	return privvalproto.ThresholdCommitment{
+		Index:   c.Index,
+		Hiding:  c.Hiding,
+		Binding: c.Binding,
+	}
+}
+
+// ThresholdCommitmentsToProto converts the commitments to their protobuf
+// representation.
+func ThresholdCommitmentsToProto(commitments []threshold.Commitment) []privvalproto.ThresholdCommitment {
+	pbs := make([]privvalproto.ThresholdCommitment, len(commitments))
+	for i, c := range commitments {
+		pbs[i] = ThresholdCommitmentToProto(c)
+	}
+	return pbs
+}
+
+// ThresholdCommitmentsFromProto converts the protobuf representation of
+// commitments.
+func ThresholdCommitmentsFromProto(pbs []privvalproto.ThresholdCommitment) []threshold.Commitment {
+	commitments := make([]threshold.Commitment, len(pbs))
+	for i, pb := range pbs {
+		commitments[i] = threshold.Commitment{
+			Index:   pb.Index,
+			Hiding:  pb.Hiding,
+			Binding: pb.Binding,
+		}
+	}
+	return commitments
+}
+
+// ThresholdKeyToProto converts the public part of the key share to the
+// protobuf response of a remote signer.
+func ThresholdKeyToProto(ks threshold.KeyShare) (*privvalproto.ThresholdKeyResponse, error) {
+	pk, err := encoding.PubKeyToProto(ks.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	return &privvalproto.ThresholdKeyResponse{
+		Index:     ks.Index,
+		PubKey:    pk,
+		PubShares: ks.PubShares,
+		Threshold: int32(ks.Threshold),
+	}, nil
+}
+
+// ThresholdKeyFromProto converts the protobuf response of a remote signer to
+// the public part of its key share.
+func ThresholdKeyFromProto(pb *privvalproto.ThresholdKeyResponse) (threshold.KeyShare, error) {
+	pubKey, err := encoding.PubKeyFromProto(pb.PubKey)
+	if err != nil {
+		return threshold.KeyShare{}, err
+	}
+	return threshold.KeyShare{
+		Index:     pb.Index,
+		PubKey:    pubKey,
+		PubShares: pb.PubShares,
+		Threshold: int(pb.Threshold),
+	}, nil
+}
@@ -0,0 +1,376 @@
+package privval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/threshold"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ThresholdRemoteSigner is a connection to a remote signer holding a share of
+// a threshold key, over a socket (SignerClient) or gRPC.
+type ThresholdRemoteSigner interface {
+	// ThresholdKey returns the public part of the key share of the signer.
+	ThresholdKey(ctx context.Context) (threshold.KeyShare, error)
+	// ThresholdCommit requests the signer to commit to fresh nonces for the
+	// session.
+	ThresholdCommit(ctx context.Context, sessionID string) (threshold.Commitment, error)
+	// ThresholdSign requests the partial signature of either the vote or the
+	// proposal in the session, and returns the index of the share of the
+	// signer, the partial signature and the timestamp signed.
+	ThresholdSign(ctx context.Context, sessionID string, vote *tmproto.Vote, proposal *tmproto.Proposal,
+		commitments []threshold.Commitment) (uint32, []byte, time.Time, error)
+	// Close closes the connection to the signer.
+	Close() error
+}
+
+var _ ThresholdRemoteSigner = (*SignerClient)(nil)
+
+// ThresholdSignerClient implements PrivValidator over the remote signers
+// holding the shares of a threshold key, of which threshold sign jointly.
+//
+// Every signature takes a session. With an Ed25519 key, the client asks all
+// the signers to commit to fresh nonces, asks the first threshold to commit
+// for their partial signatures, and aggregates them. With a BLS key, it asks
+// all the signers for their partial signatures, and aggregates the first
+// threshold. The client checks every partial signature against the public
+// share of its signer. The signers which don't respond in time, or return
+// invalid partial signatures, are left out of the next session, up to retries
+// sessions.
+type ThresholdSignerClient struct {
+	signers   []ThresholdRemoteSigner
+	threshold int
+	timeout   time.Duration
+	retries   int
+
+	// the public part of the key shares of the signers, without an index
+	key threshold.KeyShare
+}
+
+var _ types.PrivValidator = (*ThresholdSignerClient)(nil)
+
+// NewThresholdSignerClient returns a ThresholdSignerClient over the given
+// signers, after checking that they hold shares of the same key, of which
+// required are needed to sign.
+func NewThresholdSignerClient(
+	ctx context.Context,
+	signers []ThresholdRemoteSigner,
+	required int,
+	timeout time.Duration,
+	retries int,
+) (*ThresholdSignerClient, error) {
+	if required < 1 || required > len(signers) {
+		return nil, fmt.Errorf("invalid threshold %d of %d signers", required, len(signers))
+	}
+	if retries < 1 {
+		return nil, errors.New("retries must be at least 1")
+	}
+	sc := &ThresholdSignerClient{
+		signers:   signers,
+		threshold: required,
+		timeout:   timeout,
+		retries:   retries,
+	}
+
+	results := sc.gather(ctx, sc.allSigners(), len(signers), func(signer ThresholdRemoteSigner) (interface{}, error) {
+		return signer.ThresholdKey(ctx)
+	})
+	if len(results) < required {
+		return nil, fmt.Errorf("got the key share of %d signers, need %d", len(results), required)
+	}
+	for _, result := range results {
+		key := result.(threshold.KeyShare)
+		switch key.PubKey.(type) {
+		case ed25519.PubKey, bls.PubKey:
+		default:
+			return nil, fmt.Errorf("threshold signing doesn't support %T keys", key.PubKey)
+		}
+		if key.Threshold != required {
+			return nil, fmt.Errorf("signers hold the shares of a key with a threshold of %d, not %d",
+				key.Threshold, required)
+		}
+		if sc.key.PubKey != nil && (!sc.key.PubKey.Equals(key.PubKey) || !equalPubShares(sc.key.PubShares, key.PubShares)) {
+			return nil, errors.New("signers hold shares of different keys")
+		}
+		key.Index = 0
+		sc.key = key
+	}
+	return sc, nil
+}
+
+// Close closes the connections to all the signers.
+func (sc *ThresholdSignerClient) Close() error {
+	var err error
+	for _, signer := range sc.signers {
+		if cerr := signer.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+//--------------------------------------------------------
+// Implement PrivValidator
+
+// GetPubKey returns the threshold key of the signers.
+func (sc *ThresholdSignerClient) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
+	return sc.key.PubKey, nil
+}
+
+// SignVote requests the signers to sign a vote jointly. Like FilePV, the
+// signers may sign the vote with the timestamp they signed it with before,
+// which the vote is set to.
+func (sc *ThresholdSignerClient) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	sig, timestamp, err := sc.sign(ctx, vote.Timestamp,
+		func(timestamp time.Time) []byte {
+			v := *vote
+			v.Timestamp = timestamp
+			return types.VoteSignBytes(chainID, &v)
+		},
+		func(
+			signer ThresholdRemoteSigner,
+			sessionID string,
+			timestamp time.Time,
+			commitments []threshold.Commitment,
+		) (uint32, []byte, time.Time, error) {
+			v := *vote
+			v.Timestamp = timestamp
+			return signer.ThresholdSign(ctx, sessionID, &v, nil, commitments)
+		})
+	if err != nil {
+		return fmt.Errorf("failed to sign vote: %w", err)
+	}
+	vote.Timestamp = timestamp
+	vote.Signature = sig
+	return nil
+}
+
+// SignProposal requests the signers to sign a proposal jointly. Like FilePV,
+// the signers may sign the proposal with the timestamp they signed it with
+// before, which the proposal is set to.
+func (sc *ThresholdSignerClient) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	sig, timestamp, err := sc.sign(ctx, proposal.Timestamp,
+		func(timestamp time.Time) []byte {
+			p := *proposal
+			p.Timestamp = timestamp
+			return types.ProposalSignBytes(chainID, &p)
+		},
+		func(
+			signer ThresholdRemoteSigner,
+			sessionID string,
+			timestamp time.Time,
+			commitments []threshold.Commitment,
+		) (uint32, []byte, time.Time, error) {
+			p := *proposal
+			p.Timestamp = timestamp
+			return signer.ThresholdSign(ctx, sessionID, nil, &p, commitments)
+		})
+	if err != nil {
+		return fmt.Errorf("failed to sign proposal: %w", err)
+	}
+	proposal.Timestamp = timestamp
+	proposal.Signature = sig
+	return nil
+}
+
+type partialSignature struct {
+	index     uint32
+	partial   []byte
+	timestamp time.Time
+}
+
+// sign runs signing sessions until the partial signatures of one aggregate
+// into a signature of the data, with the given timestamp or the one the
+// signers signed the data with before. It returns the signature and the
+// timestamp signed.
+func (sc *ThresholdSignerClient) sign(
+	ctx context.Context,
+	timestamp time.Time,
+	signBytes func(time.Time) []byte,
+	signPartial func(ThresholdRemoteSigner, string, time.Time, []threshold.Commitment) (uint32, []byte, time.Time, error),
+) ([]byte, time.Time, error) {
+	interactive := threshold.Interactive(sc.key.PubKey)
+	failed := make(map[int]bool)
+	var err error
+	for attempt := 0; attempt < sc.retries; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, time.Time{}, err
+		}
+
+		// leave out the signers which failed, as long as enough are left
+		candidates := make([]int, 0, len(sc.signers))
+		for i := range sc.signers {
+			if !failed[i] {
+				candidates = append(candidates, i)
+			}
+		}
+		if len(candidates) < sc.threshold {
+			failed = make(map[int]bool)
+			candidates = sc.allSigners()
+		}
+
+		// only Ed25519 keys take a commitment round, after which all the
+		// signers which committed must sign
+		sessionID := tmrand.Str(16)
+		signers, want := candidates, sc.threshold
+		var commitments []threshold.Commitment
+		if interactive {
+			commitResults := sc.gather(ctx, candidates, sc.threshold, func(signer ThresholdRemoteSigner) (interface{}, error) {
+				return signer.ThresholdCommit(ctx, sessionID)
+			})
+			if len(commitResults) < sc.threshold {
+				for _, i := range candidates {
+					if _, ok := commitResults[i]; !ok {
+						failed[i] = true
+					}
+				}
+				err = fmt.Errorf("got %d commitments, need %d", len(commitResults), sc.threshold)
+				continue
+			}
+
+			signers = make([]int, 0, len(commitResults))
+			for i, result := range commitResults {
+				signers = append(signers, i)
+				commitments = append(commitments, result.(threshold.Commitment))
+			}
+			want = len(signers)
+		}
+
+		requested := timestamp
+		signResults := sc.gather(ctx, signers, want, func(signer ThresholdRemoteSigner) (interface{}, error) {
+			index, partial, signed, err := signPartial(signer, sessionID, requested, commitments)
+			return partialSignature{index: index, partial: partial, timestamp: signed}, err
+		})
+		if len(signResults) < want {
+			for _, i := range signers {
+				if _, ok := signResults[i]; !ok {
+					failed[i] = true
+				}
+			}
+			err = fmt.Errorf("got %d partial signatures, need %d", len(signResults), want)
+			continue
+		}
+
+		// the signers which signed the data before sign it again with the
+		// same timestamp, and can't sign it with another: the session signs
+		// with the timestamp most signers signed, preferring the one signed
+		// before, and the next sessions leave out the other signers
+		timestamp = sessionTimestamp(requested, signResults)
+		msg := signBytes(timestamp)
+		partials := make(map[uint32][]byte, len(signResults))
+		for i, result := range signResults {
+			ps := result.(partialSignature)
+			if !ps.timestamp.Equal(timestamp) {
+				failed[i] = true
+				continue
+			}
+			if verr := threshold.VerifyPartial(sc.key.PubKey, sc.key.PubShare(ps.index), msg,
+				commitments, ps.index, ps.partial); verr != nil {
+				failed[i] = true
+				continue
+			}
+			partials[ps.index] = ps.partial
+		}
+		if len(partials) < want {
+			err = fmt.Errorf("got %d valid partial signatures, need %d", len(partials), want)
+			continue
+		}
+
+		var sig []byte
+		sig, err = threshold.Aggregate(sc.key.PubKey, msg, commitments, partials)
+		if err == nil {
+			return sig, timestamp, nil
+		}
+	}
+	return nil, time.Time{}, fmt.Errorf("exhausted all %d signing sessions: %w", sc.retries, err)
+}
+
+// sessionTimestamp returns the timestamp most of the partial signatures were
+// signed with, preferring one other than the requested timestamp on a tie.
+func sessionTimestamp(requested time.Time, results map[int]interface{}) time.Time {
+	var (
+		timestamp = requested
+		most      int
+		counts    = make(map[int64]int, len(results))
+	)
+	for _, result := range results {
+		signed := result.(partialSignature).timestamp
+		counts[signed.UnixNano()]++
+		count := counts[signed.UnixNano()]
+		if count > most || (count == most && timestamp.Equal(requested)) {
+			timestamp, most = signed, count
+		}
+	}
+	return timestamp
+}
+
+// gather calls fn on the given signers concurrently, and returns the results
+// of the signers which succeed, by signer, once want of them succeed, all of
+// them returned or the timeout expires.
+func (sc *ThresholdSignerClient) gather(
+	ctx context.Context,
+	signers []int,
+	want int,
+	fn func(ThresholdRemoteSigner) (interface{}, error),
+) map[int]interface{} {
+	type result struct {
+		signer int
+		value  interface{}
+		err    error
+	}
+	// buffered, so that the calls of the signers left behind don't block
+	resultCh := make(chan result, len(signers))
+	for _, i := range signers {
+		go func(i int) {
+			value, err := fn(sc.signers[i])
+			resultCh <- result{signer: i, value: value, err: err}
+		}(i)
+	}
+
+	timer := time.NewTimer(sc.timeout)
+	defer timer.Stop()
+
+	results := make(map[int]interface{}, want)
+	for pending := len(signers); pending > 0 && len(results) < want; pending-- {
+		select {
+		case r := <-resultCh:
+			if r.err == nil {
+				results[r.signer] = r.value
+			}
+		case <-timer.C:
+			return results
+		case <-ctx.Done():
+			return results
+		}
+	}
+	return results
+}
+
+func (sc *ThresholdSignerClient) allSigners() []int {
+	all := make([]int, len(sc.signers))
+	for i := range all {
+		all[i] = i
+	}
+	return all
+}
+
+func equalPubShares(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
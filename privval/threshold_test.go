@@ -0,0 +1,254 @@
+package privval
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/bls"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/threshold"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func newTestThresholdPVs(t *testing.T, keyType string, thresh, n int) []*ThresholdPV {
+	keys, err := GenThresholdPVKeys(keyType, thresh, n)
+	require.NoError(t, err)
+	pvs := make([]*ThresholdPV, n)
+	for i, key := range keys {
+		stateFile, err := ioutil.TempFile("", "priv_validator_state_")
+		require.NoError(t, err)
+		t.Cleanup(func() { os.Remove(stateFile.Name()) })
+		pvs[i] = NewThresholdPV(key.KeyShare, "", stateFile.Name())
+	}
+	return pvs
+}
+
+// newTestThresholdSigners serves the validators over sockets, and returns the
+// clients of the remote signers.
+func newTestThresholdSigners(t *testing.T, chainID string, pvs []*ThresholdPV) ([]*SignerServer, []*SignerClient) {
+	servers := make([]*SignerServer, len(pvs))
+	clients := make([]*SignerClient, len(pvs))
+	for i, pv := range pvs {
+		dtc := getDialerTestCases(t)[0]
+		sl, sd := getMockEndpoints(t, dtc.addr, dtc.dialer)
+		sc, err := NewSignerClient(sl, chainID)
+		require.NoError(t, err)
+		ss := NewSignerServer(sd, chainID, pv)
+		require.NoError(t, ss.Start())
+		servers[i], clients[i] = ss, sc
+	}
+	t.Cleanup(func() {
+		for _, ss := range servers {
+			_ = ss.Stop()
+		}
+	})
+	return servers, clients
+}
+
+func TestThresholdPVConflictingData(t *testing.T) {
+	pvs := newTestThresholdPVs(t, ed25519.KeyType, 2, 2)
+	chainID := tmrand.Str(12)
+	blockID := types.BlockID{Hash: tmrand.Bytes(32)}
+	vote := newVote(pvs[0].Key.PubKey.Address(), 0, 10, 0, tmproto.PrevoteType, blockID).ToProto()
+
+	sessionSign := func(vote *tmproto.Vote) error {
+		sessionID := tmrand.Str(16)
+		commitments := make([]threshold.Commitment, len(pvs))
+		for i, pv := range pvs {
+			commitment, err := pv.ThresholdCommit(sessionID)
+			require.NoError(t, err)
+			commitments[i] = commitment
+		}
+		_, err := pvs[0].ThresholdSignVote(chainID, sessionID, vote, commitments)
+		return err
+	}
+
+	// the same vote may be signed again, in another session
+	require.NoError(t, sessionSign(vote))
+	require.NoError(t, sessionSign(vote))
+
+	// and a vote which only differs by timestamp, with the last timestamp
+	later := *vote
+	later.Timestamp = vote.Timestamp.Add(time.Second)
+	require.NoError(t, sessionSign(&later))
+	assert.Equal(t, vote.Timestamp, later.Timestamp)
+
+	// but not a conflicting one
+	conflicting := *vote
+	otherBlockID := types.BlockID{Hash: tmrand.Bytes(32)}
+	conflicting.BlockID = otherBlockID.ToProto()
+	assert.Error(t, sessionSign(&conflicting))
+
+	// nor can a session sign twice
+	sessionID := tmrand.Str(16)
+	commitment, err := pvs[0].ThresholdCommit(sessionID)
+	require.NoError(t, err)
+	_, err = pvs[0].ThresholdCommit(sessionID)
+	assert.Error(t, err)
+	_, err = pvs[0].ThresholdSignVote(chainID, sessionID, vote, []threshold.Commitment{commitment})
+	assert.Error(t, err)
+	_, err = pvs[0].ThresholdSignVote(chainID, sessionID, vote, []threshold.Commitment{commitment})
+	assert.Error(t, err)
+
+	// nor can a share sign alone
+	assert.ErrorIs(t, pvs[0].SignVote(context.Background(), chainID, vote), ErrThresholdSignAlone)
+}
+
+func TestThresholdPVBLS(t *testing.T) {
+	pvs := newTestThresholdPVs(t, bls.KeyType, 2, 3)
+	chainID := tmrand.Str(12)
+	blockID := types.BlockID{Hash: tmrand.Bytes(32)}
+	proposal := newProposal(10, 0, blockID).ToProto()
+
+	// BLS keys take no commitments, nor sessions
+	_, err := pvs[0].ThresholdCommit(tmrand.Str(16))
+	assert.Error(t, err)
+
+	partial, err := pvs[0].ThresholdSignProposal(chainID, "", proposal, nil)
+	require.NoError(t, err)
+	key := pvs[0].ThresholdKey()
+	assert.Nil(t, key.Secret)
+	assert.NoError(t, threshold.VerifyPartial(key.PubKey, key.PubShare(key.Index),
+		types.ProposalSignBytes(chainID, proposal), nil, key.Index, partial))
+
+	// a proposal which only differs by timestamp is signed with the last
+	// timestamp, to the same partial signature
+	later := *proposal
+	later.Timestamp = proposal.Timestamp.Add(time.Second)
+	again, err := pvs[0].ThresholdSignProposal(chainID, "", &later, nil)
+	require.NoError(t, err)
+	assert.Equal(t, proposal.Timestamp, later.Timestamp)
+	assert.Equal(t, partial, again)
+
+	conflicting := *proposal
+	conflicting.POLRound = 1
+	_, err = pvs[0].ThresholdSignProposal(chainID, "", &conflicting, nil)
+	assert.Error(t, err)
+}
+
+func TestThresholdSignerClient(t *testing.T) {
+	for _, keyType := range []string{ed25519.KeyType, bls.KeyType} {
+		keyType := keyType
+		t.Run(keyType, func(t *testing.T) {
+			ctx := context.Background()
+			chainID := tmrand.Str(12)
+			pvs := newTestThresholdPVs(t, keyType, 2, 3)
+			servers, clients := newTestThresholdSigners(t, chainID, pvs)
+			signers := make([]ThresholdRemoteSigner, len(clients))
+			for i, client := range clients {
+				signers[i] = client
+			}
+
+			// the threshold must be the one of the key
+			_, err := NewThresholdSignerClient(ctx, signers, 3, 500*time.Millisecond, 3)
+			require.Error(t, err)
+
+			tsc, err := NewThresholdSignerClient(ctx, signers, 2, 500*time.Millisecond, 3)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = tsc.Close() })
+			pubKey, err := tsc.GetPubKey(ctx)
+			require.NoError(t, err)
+			require.Equal(t, pvs[0].Key.PubKey, pubKey)
+
+			blockID := types.BlockID{Hash: tmrand.Bytes(32)}
+			vote := newVote(pubKey.Address(), 0, 10, 0, tmproto.PrecommitType, blockID).ToProto()
+			require.NoError(t, tsc.SignVote(ctx, chainID, vote))
+			assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+
+			// the vote signed again, at another time, is signed with the
+			// timestamp signed before
+			later := *vote
+			later.Timestamp = vote.Timestamp.Add(time.Second)
+			later.Signature = nil
+			require.NoError(t, tsc.SignVote(ctx, chainID, &later))
+			assert.Equal(t, vote.Timestamp, later.Timestamp)
+			assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, &later), later.Signature))
+
+			// the signers left can still sign
+			require.NoError(t, servers[0].Stop())
+			proposal := newProposal(11, 0, blockID).ToProto()
+			require.NoError(t, tsc.SignProposal(ctx, chainID, proposal))
+			assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainID, proposal), proposal.Signature))
+
+			// but not a single one
+			require.NoError(t, servers[1].Stop())
+			proposal = newProposal(12, 0, blockID).ToProto()
+			assert.Error(t, tsc.SignProposal(ctx, chainID, proposal))
+		})
+	}
+}
+
+// invalidPartialSigner returns invalid partial signatures.
+type invalidPartialSigner struct {
+	ThresholdRemoteSigner
+}
+
+func (s invalidPartialSigner) ThresholdSign(
+	ctx context.Context,
+	sessionID string,
+	vote *tmproto.Vote,
+	proposal *tmproto.Proposal,
+	commitments []threshold.Commitment,
+) (uint32, []byte, time.Time, error) {
+	index, partial, timestamp, err := s.ThresholdRemoteSigner.ThresholdSign(ctx, sessionID, vote, proposal, commitments)
+	if err != nil {
+		return 0, nil, time.Time{}, err
+	}
+	invalid := make([]byte, len(partial))
+	copy(invalid, partial)
+	invalid[0] ^= 0x01
+	return index, invalid, timestamp, nil
+}
+
+func TestThresholdSignerClientInvalidPartial(t *testing.T) {
+	for _, keyType := range []string{ed25519.KeyType, bls.KeyType} {
+		keyType := keyType
+		t.Run(keyType, func(t *testing.T) {
+			ctx := context.Background()
+			chainID := tmrand.Str(12)
+			pvs := newTestThresholdPVs(t, keyType, 2, 3)
+			_, clients := newTestThresholdSigners(t, chainID, pvs)
+			signers := []ThresholdRemoteSigner{invalidPartialSigner{clients[0]}, clients[1], clients[2]}
+
+			tsc, err := NewThresholdSignerClient(ctx, signers, 2, 500*time.Millisecond, 3)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = tsc.Close() })
+
+			// the signer returning invalid partial signatures is left out
+			blockID := types.BlockID{Hash: tmrand.Bytes(32)}
+			for height := int64(10); height < 13; height++ {
+				vote := newVote(tsc.key.PubKey.Address(), 0, height, 0, tmproto.PrecommitType, blockID).ToProto()
+				require.NoError(t, tsc.SignVote(ctx, chainID, vote))
+				assert.True(t, tsc.key.PubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+			}
+		})
+	}
+}
+
+func TestLoadThresholdPV(t *testing.T) {
+	keyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.Remove(keyFile.Name()) })
+	stateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(stateFile.Name()))
+
+	keys, err := GenThresholdPVKeys(bls.KeyType, 2, 3)
+	require.NoError(t, err)
+	pv := NewThresholdPV(keys[1].KeyShare, keyFile.Name(), stateFile.Name())
+	pv.Key.Save()
+
+	// the state file doesn't exist before the first signature
+	loaded, err := LoadThresholdPV(keyFile.Name(), stateFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, pv.Key, loaded.Key)
+	assert.Equal(t, pv.LastSignState, loaded.LastSignState)
+}
@@ -12,6 +12,12 @@ func (m *Message) Wrap(pb proto.Message) error {
 	case *Txs:
 		m.Sum = &Message_Txs{Txs: msg}
 
+	case *TxHashes:
+		m.Sum = &Message_TxHashes{TxHashes: msg}
+
+	case *WantTxs:
+		m.Sum = &Message_WantTxs{WantTxs: msg}
+
 	default:
 		return fmt.Errorf("unknown message: %T", msg)
 	}
@@ -26,6 +32,12 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_Txs:
 		return m.GetTxs(), nil
 
+	case *Message_TxHashes:
+		return m.GetTxHashes(), nil
+
+	case *Message_WantTxs:
+		return m.GetWantTxs(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -96,6 +96,7 @@ type NodeInfo struct {
 	Channels        []byte          `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
 	Moniker         string          `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
 	Other           NodeInfoOther   `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	Features        []string        `protobuf:"bytes,9,rep,name=features,proto3" json:"features,omitempty"`
 }
 
 func (m *NodeInfo) Reset()         { *m = NodeInfo{} }
@@ -187,9 +188,22 @@ func (m *NodeInfo) GetOther() NodeInfoOther {
 	return NodeInfoOther{}
 }
 
+func (m *NodeInfo) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
 type NodeInfoOther struct {
 	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
 	RPCAddress string `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
+	// earliest_block_height and earliest_app_hash are those of the earliest
+	// block the node serves, once it pruned the previous ones.
+	EarliestBlockHeight int64  `protobuf:"varint,3,opt,name=earliest_block_height,json=earliestBlockHeight,proto3" json:"earliest_block_height,omitempty"`
+	EarliestAppHash     []byte `protobuf:"bytes,4,opt,name=earliest_app_hash,json=earliestAppHash,proto3" json:"earliest_app_hash,omitempty"`
+	// serves_snapshots is set if the node has state sync snapshots to serve.
+	ServesSnapshots bool `protobuf:"varint,5,opt,name=serves_snapshots,json=servesSnapshots,proto3" json:"serves_snapshots,omitempty"`
 }
 
 func (m *NodeInfoOther) Reset()         { *m = NodeInfoOther{} }
@@ -239,10 +253,36 @@ func (m *NodeInfoOther) GetRPCAddress() string {
 	return ""
 }
 
+func (m *NodeInfoOther) GetEarliestBlockHeight() int64 {
+	if m != nil {
+		return m.EarliestBlockHeight
+	}
+	return 0
+}
+
+func (m *NodeInfoOther) GetEarliestAppHash() []byte {
+	if m != nil {
+		return m.EarliestAppHash
+	}
+	return nil
+}
+
+func (m *NodeInfoOther) GetServesSnapshots() bool {
+	if m != nil {
+		return m.ServesSnapshots
+	}
+	return false
+}
+
 type PeerInfo struct {
 	ID            string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	AddressInfo   []*PeerAddressInfo `protobuf:"bytes,2,rep,name=address_info,json=addressInfo,proto3" json:"address_info,omitempty"`
 	LastConnected *time.Time         `protobuf:"bytes,3,opt,name=last_connected,json=lastConnected,proto3,stdtime" json:"last_connected,omitempty"`
+	// source_group is the address group of the peer we learned about this
+	// peer from, if any.
+	SourceGroup string `protobuf:"bytes,4,opt,name=source_group,json=sourceGroup,proto3" json:"source_group,omitempty"`
+	// anchor is set for the outbound peers dialed first on restart.
+	Anchor bool `protobuf:"varint,5,opt,name=anchor,proto3" json:"anchor,omitempty"`
 }
 
 func (m *PeerInfo) Reset()         { *m = PeerInfo{} }
@@ -299,6 +339,20 @@ func (m *PeerInfo) GetLastConnected() *time.Time {
 	return nil
 }
 
+func (m *PeerInfo) GetSourceGroup() string {
+	if m != nil {
+		return m.SourceGroup
+	}
+	return ""
+}
+
+func (m *PeerInfo) GetAnchor() bool {
+	if m != nil {
+		return m.Anchor
+	}
+	return false
+}
+
 type PeerAddressInfo struct {
 	Address         string     `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	LastDialSuccess *time.Time `protobuf:"bytes,2,opt,name=last_dial_success,json=lastDialSuccess,proto3,stdtime" json:"last_dial_success,omitempty"`
@@ -378,46 +432,53 @@ func init() {
 func init() { proto.RegisterFile("tendermint/p2p/types.proto", fileDescriptor_c8a29e659aeca578) }
 
 var fileDescriptor_c8a29e659aeca578 = []byte{
-	// 610 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcd, 0x4e, 0x1b, 0x3d,
-	0x14, 0xcd, 0x24, 0x21, 0x09, 0x37, 0x84, 0xf0, 0x59, 0xe8, 0xd3, 0x10, 0xa9, 0x19, 0x14, 0x36,
-	0xac, 0x26, 0x52, 0xaa, 0x2e, 0xba, 0x64, 0x40, 0xad, 0x22, 0x55, 0x25, 0x9a, 0xa2, 0x2e, 0xda,
-	0xc5, 0x68, 0x32, 0x76, 0x82, 0xc5, 0xc4, 0xb6, 0x3c, 0x4e, 0x4b, 0xdf, 0x82, 0x37, 0xe9, 0x63,
-	0x94, 0x25, 0xcb, 0xae, 0xd2, 0x6a, 0xd8, 0xf6, 0x21, 0x2a, 0xdb, 0x33, 0x40, 0xa2, 0x2e, 0xd8,
-	0xf9, 0xdc, 0xe3, 0x73, 0xee, 0x8f, 0xad, 0x0b, 0x3d, 0x45, 0x18, 0x26, 0x72, 0x41, 0x99, 0x1a,
-	0x8a, 0x91, 0x18, 0xaa, 0x6f, 0x82, 0x64, 0xbe, 0x90, 0x5c, 0x71, 0xb4, 0xfb, 0xc8, 0xf9, 0x62,
-	0x24, 0x7a, 0xfb, 0x73, 0x3e, 0xe7, 0x86, 0x1a, 0xea, 0x93, 0xbd, 0xd5, 0xf3, 0xe6, 0x9c, 0xcf,
-	0x53, 0x32, 0x34, 0x68, 0xba, 0x9c, 0x0d, 0x15, 0x5d, 0x90, 0x4c, 0xc5, 0x0b, 0x61, 0x2f, 0x0c,
-	0x2e, 0xa0, 0x3b, 0xd1, 0x87, 0x84, 0xa7, 0x1f, 0x89, 0xcc, 0x28, 0x67, 0xe8, 0x00, 0x6a, 0x62,
-	0x24, 0x5c, 0xe7, 0xd0, 0x39, 0xae, 0x07, 0xcd, 0x7c, 0xe5, 0xd5, 0x26, 0xa3, 0x49, 0xa8, 0x63,
-	0x68, 0x1f, 0xb6, 0xa6, 0x29, 0x4f, 0xae, 0xdc, 0xaa, 0x26, 0x43, 0x0b, 0xd0, 0x1e, 0xd4, 0x62,
-	0x21, 0xdc, 0x9a, 0x89, 0xe9, 0xe3, 0xe0, 0x47, 0x15, 0x5a, 0xef, 0x39, 0x26, 0x63, 0x36, 0xe3,
-	0x68, 0x02, 0x7b, 0xa2, 0x48, 0x11, 0x7d, 0xb1, 0x39, 0x8c, 0x79, 0x7b, 0xe4, 0xf9, 0xeb, 0x4d,
-	0xf8, 0x1b, 0xa5, 0x04, 0xf5, 0xdb, 0x95, 0x57, 0x09, 0xbb, 0x62, 0xa3, 0xc2, 0x23, 0x68, 0x32,
-	0x8e, 0x49, 0x44, 0xb1, 0x29, 0x64, 0x3b, 0x80, 0x7c, 0xe5, 0x35, 0x4c, 0xc2, 0xb3, 0xb0, 0xa1,
-	0xa9, 0x31, 0x46, 0x1e, 0xb4, 0x53, 0x9a, 0x29, 0xc2, 0xa2, 0x18, 0x63, 0x69, 0xaa, 0xdb, 0x0e,
-	0xc1, 0x86, 0x4e, 0x30, 0x96, 0xc8, 0x85, 0x26, 0x23, 0xea, 0x2b, 0x97, 0x57, 0x6e, 0xdd, 0x90,
-	0x25, 0xd4, 0x4c, 0x59, 0xe8, 0x96, 0x65, 0x0a, 0x88, 0x7a, 0xd0, 0x4a, 0x2e, 0x63, 0xc6, 0x48,
-	0x9a, 0xb9, 0x8d, 0x43, 0xe7, 0x78, 0x27, 0x7c, 0xc0, 0x5a, 0xb5, 0xe0, 0x8c, 0x5e, 0x11, 0xe9,
-	0x36, 0xad, 0xaa, 0x80, 0xe8, 0x35, 0x6c, 0x71, 0x75, 0x49, 0xa4, 0xdb, 0x32, 0x6d, 0xbf, 0xd8,
-	0x6c, 0xbb, 0x1c, 0xd5, 0xb9, 0xbe, 0x54, 0x34, 0x6d, 0x15, 0x83, 0xcf, 0xd0, 0x59, 0x63, 0xd1,
-	0x01, 0xb4, 0xd4, 0x75, 0x44, 0x19, 0x26, 0xd7, 0x66, 0x8a, 0xdb, 0x61, 0x53, 0x5d, 0x8f, 0x35,
-	0x44, 0x43, 0x68, 0x4b, 0x91, 0x98, 0x76, 0x49, 0x96, 0x15, 0xa3, 0xd9, 0xcd, 0x57, 0x1e, 0x84,
-	0x93, 0xd3, 0x13, 0x1b, 0x0d, 0x41, 0x8a, 0xa4, 0x38, 0x0f, 0xbe, 0x3b, 0xd0, 0x9a, 0x10, 0x22,
-	0xcd, 0x33, 0xfd, 0x0f, 0x55, 0x8a, 0xad, 0x65, 0xd0, 0xc8, 0x57, 0x5e, 0x75, 0x7c, 0x16, 0x56,
-	0x29, 0x46, 0x01, 0xec, 0x14, 0x8e, 0x11, 0x65, 0x33, 0xee, 0x56, 0x0f, 0x6b, 0xff, 0x7c, 0x3a,
-	0x42, 0x64, 0xe1, 0xab, 0xed, 0xc2, 0x76, 0xfc, 0x08, 0xd0, 0x5b, 0xd8, 0x4d, 0xe3, 0x4c, 0x45,
-	0x09, 0x67, 0x8c, 0x24, 0x8a, 0x60, 0xf3, 0x1c, 0xed, 0x51, 0xcf, 0xb7, 0xff, 0xd3, 0x2f, 0xff,
-	0xa7, 0x7f, 0x51, 0xfe, 0xcf, 0xa0, 0x7e, 0xf3, 0xcb, 0x73, 0xc2, 0x8e, 0xd6, 0x9d, 0x96, 0xb2,
-	0xc1, 0x1f, 0x07, 0xba, 0x1b, 0x99, 0xf4, 0xdc, 0xcb, 0x96, 0x8b, 0x81, 0x14, 0x10, 0xbd, 0x83,
-	0xff, 0x4c, 0x5a, 0x4c, 0xe3, 0x34, 0xca, 0x96, 0x49, 0x52, 0x8e, 0xe5, 0x39, 0x99, 0xbb, 0x5a,
-	0x7a, 0x46, 0xe3, 0xf4, 0x83, 0x15, 0xae, 0xbb, 0xcd, 0x62, 0x9a, 0x2e, 0x25, 0x79, 0x76, 0x1f,
-	0x0f, 0x6e, 0x6f, 0xac, 0x10, 0x1d, 0x41, 0xe7, 0xa9, 0x51, 0x66, 0xfe, 0x60, 0x27, 0xdc, 0xc1,
-	0x8f, 0x77, 0xb2, 0xe0, 0xfc, 0x36, 0xef, 0x3b, 0x77, 0x79, 0xdf, 0xf9, 0x9d, 0xf7, 0x9d, 0x9b,
-	0xfb, 0x7e, 0xe5, 0xee, 0xbe, 0x5f, 0xf9, 0x79, 0xdf, 0xaf, 0x7c, 0x7a, 0x35, 0xa7, 0xea, 0x72,
-	0x39, 0xf5, 0x13, 0xbe, 0x18, 0x3e, 0xd9, 0x12, 0x4f, 0x17, 0x86, 0xd9, 0x05, 0xeb, 0x1b, 0x64,
-	0xda, 0x30, 0xd1, 0x97, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x0b, 0xe9, 0x56, 0xd3, 0x5a, 0x04,
-	0x00, 0x00,
+	// 730 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x4d, 0x8f, 0x23, 0x35,
+	0x10, 0x4d, 0xa7, 0x33, 0xf9, 0x70, 0x92, 0xc9, 0xac, 0x59, 0x56, 0xbd, 0x91, 0x48, 0x87, 0xec,
+	0x65, 0xe0, 0xd0, 0x91, 0x82, 0x38, 0x70, 0x9c, 0xde, 0x11, 0xbb, 0x91, 0x10, 0x1b, 0x79, 0x57,
+	0x1c, 0xb8, 0xb4, 0x9c, 0x6e, 0x27, 0x6d, 0x4d, 0xc7, 0xb6, 0x6c, 0x67, 0x19, 0xfe, 0xc5, 0xfe,
+	0xac, 0x39, 0xce, 0x91, 0x53, 0x40, 0x89, 0xc4, 0x01, 0xf1, 0x23, 0x90, 0xed, 0xee, 0x99, 0x49,
+	0xc4, 0x01, 0x6e, 0xf5, 0xea, 0xf9, 0x95, 0xeb, 0x95, 0x3f, 0xc0, 0x50, 0x13, 0x96, 0x11, 0xb9,
+	0xa1, 0x4c, 0x4f, 0xc5, 0x4c, 0x4c, 0xf5, 0xaf, 0x82, 0xa8, 0x48, 0x48, 0xae, 0x39, 0x3c, 0x7f,
+	0xe4, 0x22, 0x31, 0x13, 0xc3, 0xe7, 0x6b, 0xbe, 0xe6, 0x96, 0x9a, 0x9a, 0xc8, 0xad, 0x1a, 0x86,
+	0x6b, 0xce, 0xd7, 0x05, 0x99, 0x5a, 0xb4, 0xdc, 0xae, 0xa6, 0x9a, 0x6e, 0x88, 0xd2, 0x78, 0x23,
+	0xdc, 0x82, 0xc9, 0x07, 0x30, 0x58, 0x98, 0x20, 0xe5, 0xc5, 0x4f, 0x44, 0x2a, 0xca, 0x19, 0x7c,
+	0x09, 0x7c, 0x31, 0x13, 0x81, 0x37, 0xf6, 0x2e, 0x1b, 0x71, 0x6b, 0xbf, 0x0b, 0xfd, 0xc5, 0x6c,
+	0x81, 0x4c, 0x0e, 0x3e, 0x07, 0x67, 0xcb, 0x82, 0xa7, 0x37, 0x41, 0xdd, 0x90, 0xc8, 0x01, 0x78,
+	0x01, 0x7c, 0x2c, 0x44, 0xe0, 0xdb, 0x9c, 0x09, 0x27, 0x87, 0x3a, 0x68, 0xff, 0xc8, 0x33, 0x32,
+	0x67, 0x2b, 0x0e, 0x17, 0xe0, 0x42, 0x94, 0x5b, 0x24, 0x1f, 0xdd, 0x1e, 0xb6, 0x78, 0x77, 0x16,
+	0x46, 0xc7, 0x26, 0xa2, 0x93, 0x56, 0xe2, 0xc6, 0xdd, 0x2e, 0xac, 0xa1, 0x81, 0x38, 0xe9, 0xf0,
+	0x15, 0x68, 0x31, 0x9e, 0x91, 0x84, 0x66, 0xb6, 0x91, 0x4e, 0x0c, 0xf6, 0xbb, 0xb0, 0x69, 0x37,
+	0xbc, 0x46, 0x4d, 0x43, 0xcd, 0x33, 0x18, 0x82, 0x6e, 0x41, 0x95, 0x26, 0x2c, 0xc1, 0x59, 0x26,
+	0x6d, 0x77, 0x1d, 0x04, 0x5c, 0xea, 0x2a, 0xcb, 0x24, 0x0c, 0x40, 0x8b, 0x11, 0xfd, 0x0b, 0x97,
+	0x37, 0x41, 0xc3, 0x92, 0x15, 0x34, 0x4c, 0xd5, 0xe8, 0x99, 0x63, 0x4a, 0x08, 0x87, 0xa0, 0x9d,
+	0xe6, 0x98, 0x31, 0x52, 0xa8, 0xa0, 0x39, 0xf6, 0x2e, 0x7b, 0xe8, 0x01, 0x1b, 0xd5, 0x86, 0x33,
+	0x7a, 0x43, 0x64, 0xd0, 0x72, 0xaa, 0x12, 0xc2, 0xef, 0xc0, 0x19, 0xd7, 0x39, 0x91, 0x41, 0xdb,
+	0xda, 0xfe, 0xe2, 0xd4, 0x76, 0x35, 0xaa, 0x77, 0x66, 0x51, 0x69, 0xda, 0x29, 0xcc, 0x86, 0x2b,
+	0x82, 0xf5, 0x56, 0x12, 0x15, 0x74, 0xc6, 0xfe, 0x65, 0x07, 0x3d, 0xe0, 0xc9, 0x9f, 0x1e, 0xe8,
+	0x1f, 0x49, 0xe1, 0x4b, 0xd0, 0xd6, 0xb7, 0x09, 0x65, 0x19, 0xb9, 0xb5, 0x23, 0xee, 0xa0, 0x96,
+	0xbe, 0x9d, 0x1b, 0x08, 0xa7, 0xa0, 0x2b, 0x45, 0x6a, 0x67, 0x41, 0x94, 0x2a, 0xe7, 0x76, 0xbe,
+	0xdf, 0x85, 0x00, 0x2d, 0x5e, 0x5f, 0xb9, 0x2c, 0x02, 0x52, 0xa4, 0x65, 0x0c, 0x67, 0xe0, 0x73,
+	0x82, 0x65, 0x41, 0x89, 0xd2, 0x89, 0x3d, 0xe7, 0x24, 0x27, 0x74, 0x9d, 0x6b, 0x3b, 0x49, 0x1f,
+	0x7d, 0x56, 0x91, 0xb1, 0xe1, 0xde, 0x5a, 0x0a, 0x7e, 0x0d, 0x9e, 0x3d, 0x68, 0xb0, 0x10, 0x49,
+	0x8e, 0x55, 0x6e, 0x87, 0xdb, 0x43, 0x83, 0x8a, 0xb8, 0x12, 0xe2, 0x2d, 0x56, 0x39, 0xfc, 0x0a,
+	0x5c, 0x28, 0x22, 0x3f, 0x12, 0x95, 0x28, 0x86, 0x85, 0xca, 0xb9, 0x56, 0x76, 0xda, 0x6d, 0x34,
+	0x70, 0xf9, 0xf7, 0x55, 0x7a, 0xf2, 0x97, 0x07, 0xda, 0x0b, 0x42, 0xa4, 0xbd, 0x4e, 0x2f, 0x40,
+	0x9d, 0x66, 0xce, 0x5d, 0xdc, 0xdc, 0xef, 0xc2, 0xfa, 0xfc, 0x1a, 0xd5, 0x69, 0x06, 0x63, 0xd0,
+	0x2b, 0xcd, 0x25, 0x94, 0xad, 0x78, 0x50, 0x1f, 0xfb, 0xff, 0x7a, 0xc5, 0x08, 0x91, 0xa5, 0x45,
+	0x53, 0x0e, 0x75, 0xf1, 0x23, 0x80, 0x6f, 0xc0, 0x79, 0x81, 0x95, 0x4e, 0x52, 0xce, 0x18, 0x49,
+	0x35, 0xc9, 0xac, 0xd9, 0xee, 0x6c, 0x18, 0xb9, 0x77, 0x14, 0x55, 0xef, 0x28, 0xfa, 0x50, 0xbd,
+	0xa3, 0xb8, 0xf1, 0xe9, 0xf7, 0xd0, 0x43, 0x7d, 0xa3, 0x7b, 0x5d, 0xc9, 0xe0, 0x97, 0xa0, 0xa7,
+	0xf8, 0x56, 0xa6, 0x24, 0x59, 0x4b, 0xbe, 0x15, 0xe5, 0x05, 0xeb, 0xba, 0xdc, 0x1b, 0x93, 0x82,
+	0x2f, 0x40, 0x13, 0xb3, 0x34, 0xe7, 0xb2, 0x74, 0x5d, 0xa2, 0xc9, 0xdf, 0x1e, 0x18, 0x9c, 0x34,
+	0x69, 0xae, 0x56, 0x75, 0x70, 0xe5, 0xb1, 0x96, 0x10, 0xfe, 0x00, 0x9e, 0xd9, 0x8e, 0x33, 0x8a,
+	0x8b, 0x44, 0x6d, 0xd3, 0xb4, 0x3a, 0xdc, 0xff, 0xd2, 0xf4, 0xc0, 0x48, 0xaf, 0x29, 0x2e, 0xde,
+	0x3b, 0xe1, 0x71, 0xb5, 0x15, 0xa6, 0xc5, 0x56, 0x92, 0xc0, 0xff, 0xbf, 0xd5, 0xbe, 0x77, 0x42,
+	0xf8, 0x0a, 0xf4, 0x9f, 0x16, 0x52, 0x76, 0x0a, 0x7d, 0xd4, 0xcb, 0x1e, 0xd7, 0xa8, 0xf8, 0xdd,
+	0xdd, 0x7e, 0xe4, 0xdd, 0xef, 0x47, 0xde, 0x1f, 0xfb, 0x91, 0xf7, 0xe9, 0x30, 0xaa, 0xdd, 0x1f,
+	0x46, 0xb5, 0xdf, 0x0e, 0xa3, 0xda, 0xcf, 0xdf, 0xae, 0xa9, 0xce, 0xb7, 0xcb, 0x28, 0xe5, 0x9b,
+	0xe9, 0x93, 0x8f, 0xf0, 0x49, 0xe8, 0xbe, 0xbb, 0xe3, 0x4f, 0x72, 0xd9, 0xb4, 0xd9, 0x6f, 0xfe,
+	0x19, 0x00, 0xef, 0xee, 0x0e, 0x28, 0x3d, 0x05, 0x00, 0x00,
 }
 
 func (m *ProtocolVersion) Marshal() (dAtA []byte, err error) {
@@ -478,6 +539,15 @@ func (m *NodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Features) > 0 {
+		for iNdEx := len(m.Features) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Features[iNdEx])
+			copy(dAtA[i:], m.Features[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Features[iNdEx])))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
 	{
 		size, err := m.Other.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -563,6 +633,28 @@ func (m *NodeInfoOther) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ServesSnapshots {
+		i--
+		if m.ServesSnapshots {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.EarliestAppHash) > 0 {
+		i -= len(m.EarliestAppHash)
+		copy(dAtA[i:], m.EarliestAppHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.EarliestAppHash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.EarliestBlockHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.EarliestBlockHeight))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.RPCAddress) > 0 {
 		i -= len(m.RPCAddress)
 		copy(dAtA[i:], m.RPCAddress)
@@ -600,6 +692,23 @@ func (m *PeerInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Anchor {
+		i--
+		if m.Anchor {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.SourceGroup) > 0 {
+		i -= len(m.SourceGroup)
+		copy(dAtA[i:], m.SourceGroup)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.SourceGroup)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.LastConnected != nil {
 		n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastConnected, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastConnected):])
 		if err3 != nil {
@@ -752,6 +861,12 @@ func (m *NodeInfo) Size() (n int) {
 	}
 	l = m.Other.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -769,6 +884,16 @@ func (m *NodeInfoOther) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.EarliestBlockHeight != 0 {
+		n += 1 + sovTypes(uint64(m.EarliestBlockHeight))
+	}
+	l = len(m.EarliestAppHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.ServesSnapshots {
+		n += 2
+	}
 	return n
 }
 
@@ -792,6 +917,13 @@ func (m *PeerInfo) Size() (n int) {
 		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastConnected)
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.SourceGroup)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Anchor {
+		n += 2
+	}
 	return n
 }
 
@@ -1221,6 +1353,38 @@ func (m *NodeInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1335,6 +1499,79 @@ func (m *NodeInfoOther) Unmarshal(dAtA []byte) error {
 			}
 			m.RPCAddress = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EarliestBlockHeight", wireType)
+			}
+			m.EarliestBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EarliestBlockHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EarliestAppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EarliestAppHash = append(m.EarliestAppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.EarliestAppHash == nil {
+				m.EarliestAppHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServesSnapshots", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ServesSnapshots = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1487,6 +1724,58 @@ func (m *PeerInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceGroup", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceGroup = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Anchor", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Anchor = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -27,23 +27,27 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 func init() { proto.RegisterFile("tendermint/privval/service.proto", fileDescriptor_7afe74f9f46d3dc9) }
 
 var fileDescriptor_7afe74f9f46d3dc9 = []byte{
-	// 251 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x28, 0x49, 0xcd, 0x4b,
-	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x28, 0xca, 0x2c, 0x2b, 0x4b, 0xcc, 0xd1, 0x2f,
-	0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x42, 0xa8,
-	0xd0, 0x83, 0xaa, 0x90, 0x92, 0xc3, 0xa2, 0xab, 0xa4, 0xb2, 0x20, 0xb5, 0x18, 0xa2, 0xc7, 0x68,
-	0x09, 0x13, 0x97, 0x40, 0x40, 0x51, 0x66, 0x59, 0x58, 0x62, 0x4e, 0x66, 0x4a, 0x62, 0x49, 0x7e,
-	0x91, 0x63, 0x80, 0xa7, 0x50, 0x10, 0x17, 0xa7, 0x7b, 0x6a, 0x49, 0x40, 0x69, 0x92, 0x77, 0x6a,
-	0xa5, 0x90, 0xa2, 0x1e, 0xa6, 0xb1, 0x7a, 0x10, 0xb9, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12,
-	0x29, 0x25, 0x7c, 0x4a, 0x8a, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x85, 0xc2, 0xb9, 0x38, 0x82, 0x33,
-	0xd3, 0xf3, 0xc2, 0xf2, 0x4b, 0x52, 0x85, 0x94, 0xb1, 0xa9, 0x87, 0xc9, 0xc2, 0x0c, 0x55, 0xc3,
-	0xa5, 0x28, 0x35, 0x05, 0xa2, 0x0c, 0x6a, 0x70, 0x32, 0x17, 0x0f, 0x48, 0x34, 0xa0, 0x28, 0xbf,
-	0x20, 0xbf, 0x38, 0x31, 0x47, 0x48, 0x1d, 0x97, 0x3e, 0x98, 0x0a, 0x98, 0x05, 0x5a, 0xb8, 0x2d,
-	0x40, 0x28, 0x85, 0x58, 0xe2, 0x14, 0x7c, 0xe2, 0x91, 0x1c, 0xe3, 0x85, 0x47, 0x72, 0x8c, 0x0f,
-	0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0x70, 0xe1, 0xb1, 0x1c, 0xc3, 0x8d, 0xc7, 0x72, 0x0c,
-	0x51, 0x96, 0xe9, 0x99, 0x25, 0x19, 0xa5, 0x49, 0x7a, 0xc9, 0xf9, 0xb9, 0xfa, 0x48, 0x61, 0x8d,
-	0x12, 0xec, 0xf9, 0x25, 0xf9, 0xfa, 0x98, 0xf1, 0x90, 0xc4, 0x06, 0x96, 0x31, 0x06, 0x04, 0x00,
-	0x00, 0xff, 0xff, 0x42, 0x60, 0x24, 0x48, 0xda, 0x01, 0x00, 0x00,
+	// 315 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xcd, 0x4a, 0xf3, 0x40,
+	0x14, 0x86, 0x1b, 0xf8, 0xf8, 0xd0, 0xa1, 0xa2, 0xcc, 0xb2, 0x8b, 0xc1, 0x1f, 0xf0, 0xa7, 0x42,
+	0x02, 0xba, 0x72, 0xa9, 0x2e, 0x44, 0xdc, 0x84, 0x56, 0x2a, 0xb8, 0xcb, 0xcf, 0xa1, 0x19, 0x49,
+	0x72, 0xe2, 0xcc, 0x24, 0xd0, 0xbb, 0xf0, 0xb2, 0x5c, 0x76, 0xa9, 0x3b, 0x49, 0x6e, 0x44, 0x62,
+	0x32, 0x6d, 0xa5, 0x49, 0xec, 0xf6, 0xbc, 0xcf, 0x7b, 0x9e, 0x64, 0x38, 0x64, 0x5f, 0x41, 0xec,
+	0x83, 0x88, 0x78, 0xac, 0xac, 0x44, 0xf0, 0x2c, 0x73, 0x42, 0x4b, 0x82, 0xc8, 0xb8, 0x07, 0x66,
+	0x22, 0x50, 0x21, 0xa5, 0x4b, 0xc2, 0xac, 0x89, 0x01, 0x6b, 0x68, 0xa9, 0x59, 0x02, 0xb2, 0xea,
+	0x5c, 0x7c, 0xfe, 0x23, 0x7b, 0xb6, 0xe0, 0xd9, 0xc4, 0x09, 0xb9, 0xef, 0x28, 0x14, 0xd7, 0xf6,
+	0x3d, 0x1d, 0x91, 0xed, 0x3b, 0x50, 0x76, 0xea, 0x3e, 0xc0, 0x8c, 0x1e, 0x98, 0xeb, 0x6b, 0xcd,
+	0x2a, 0x1b, 0xc1, 0x6b, 0x0a, 0x52, 0x0d, 0x0e, 0xbb, 0x10, 0x99, 0x60, 0x2c, 0x81, 0x3e, 0x91,
+	0xad, 0x31, 0x9f, 0xc6, 0x13, 0x54, 0x40, 0x8f, 0x9a, 0x78, 0x9d, 0xea, 0xa5, 0xc7, 0x6d, 0x10,
+	0xf8, 0x15, 0x56, 0x2f, 0xf6, 0x48, 0xbf, 0x9c, 0xda, 0x02, 0x13, 0x94, 0x4e, 0x48, 0x4f, 0xda,
+	0x7a, 0x9a, 0xd0, 0x82, 0x61, 0xbb, 0x60, 0x89, 0xd6, 0x12, 0x87, 0xf4, 0x1f, 0x03, 0x01, 0x32,
+	0xc0, 0xd0, 0x2f, 0x1f, 0xa5, 0x51, 0xb2, 0x4a, 0x68, 0xc9, 0xe9, 0xdf, 0x60, 0xad, 0x78, 0x21,
+	0xbb, 0x8b, 0xf9, 0x2d, 0x46, 0x11, 0x57, 0x74, 0xd8, 0x59, 0xae, 0x20, 0x2d, 0x3a, 0xdf, 0x88,
+	0xad, 0x5d, 0x3e, 0xd9, 0x59, 0x44, 0xe5, 0x1f, 0xd3, 0xee, 0xcf, 0x2c, 0x11, 0xed, 0x39, 0xdb,
+	0x80, 0xac, 0x2c, 0x37, 0xe3, 0xf7, 0x9c, 0x19, 0xf3, 0x9c, 0x19, 0x5f, 0x39, 0x33, 0xde, 0x0a,
+	0xd6, 0x9b, 0x17, 0xac, 0xf7, 0x51, 0xb0, 0xde, 0xf3, 0xd5, 0x94, 0xab, 0x20, 0x75, 0x4d, 0x0f,
+	0x23, 0x6b, 0xe5, 0x40, 0x7f, 0xdd, 0x2a, 0x2a, 0xb4, 0xd6, 0x8f, 0xd7, 0xfd, 0xff, 0x93, 0x5c,
+	0x7e, 0x0f, 0x00, 0x46, 0xc1, 0x72, 0xc1, 0x0f, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -61,6 +65,9 @@ type PrivValidatorAPIClient interface {
 	GetPubKey(ctx context.Context, in *PubKeyRequest, opts ...grpc.CallOption) (*PubKeyResponse, error)
 	SignVote(ctx context.Context, in *SignVoteRequest, opts ...grpc.CallOption) (*SignedVoteResponse, error)
 	SignProposal(ctx context.Context, in *SignProposalRequest, opts ...grpc.CallOption) (*SignedProposalResponse, error)
+	ThresholdKey(ctx context.Context, in *ThresholdKeyRequest, opts ...grpc.CallOption) (*ThresholdKeyResponse, error)
+	ThresholdCommit(ctx context.Context, in *ThresholdCommitRequest, opts ...grpc.CallOption) (*ThresholdCommitResponse, error)
+	ThresholdSign(ctx context.Context, in *ThresholdSignRequest, opts ...grpc.CallOption) (*ThresholdSignResponse, error)
 }
 
 type privValidatorAPIClient struct {
@@ -98,11 +105,41 @@ func (c *privValidatorAPIClient) SignProposal(ctx context.Context, in *SignPropo
 	return out, nil
 }
 
+func (c *privValidatorAPIClient) ThresholdKey(ctx context.Context, in *ThresholdKeyRequest, opts ...grpc.CallOption) (*ThresholdKeyResponse, error) {
+	out := new(ThresholdKeyResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/ThresholdKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) ThresholdCommit(ctx context.Context, in *ThresholdCommitRequest, opts ...grpc.CallOption) (*ThresholdCommitResponse, error) {
+	out := new(ThresholdCommitResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/ThresholdCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) ThresholdSign(ctx context.Context, in *ThresholdSignRequest, opts ...grpc.CallOption) (*ThresholdSignResponse, error) {
+	out := new(ThresholdSignResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/ThresholdSign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PrivValidatorAPIServer is the server API for PrivValidatorAPI service.
 type PrivValidatorAPIServer interface {
 	GetPubKey(context.Context, *PubKeyRequest) (*PubKeyResponse, error)
 	SignVote(context.Context, *SignVoteRequest) (*SignedVoteResponse, error)
 	SignProposal(context.Context, *SignProposalRequest) (*SignedProposalResponse, error)
+	ThresholdKey(context.Context, *ThresholdKeyRequest) (*ThresholdKeyResponse, error)
+	ThresholdCommit(context.Context, *ThresholdCommitRequest) (*ThresholdCommitResponse, error)
+	ThresholdSign(context.Context, *ThresholdSignRequest) (*ThresholdSignResponse, error)
 }
 
 // UnimplementedPrivValidatorAPIServer can be embedded to have forward compatible implementations.
@@ -118,6 +155,15 @@ func (*UnimplementedPrivValidatorAPIServer) SignVote(ctx context.Context, req *S
 func (*UnimplementedPrivValidatorAPIServer) SignProposal(ctx context.Context, req *SignProposalRequest) (*SignedProposalResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignProposal not implemented")
 }
+func (*UnimplementedPrivValidatorAPIServer) ThresholdKey(ctx context.Context, req *ThresholdKeyRequest) (*ThresholdKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ThresholdKey not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) ThresholdCommit(ctx context.Context, req *ThresholdCommitRequest) (*ThresholdCommitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ThresholdCommit not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) ThresholdSign(ctx context.Context, req *ThresholdSignRequest) (*ThresholdSignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ThresholdSign not implemented")
+}
 
 func RegisterPrivValidatorAPIServer(s *grpc.Server, srv PrivValidatorAPIServer) {
 	s.RegisterService(&_PrivValidatorAPI_serviceDesc, srv)
@@ -177,6 +223,60 @@ func _PrivValidatorAPI_SignProposal_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PrivValidatorAPI_ThresholdKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThresholdKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).ThresholdKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/ThresholdKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).ThresholdKey(ctx, req.(*ThresholdKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_ThresholdCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThresholdCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).ThresholdCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/ThresholdCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).ThresholdCommit(ctx, req.(*ThresholdCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_ThresholdSign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThresholdSignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).ThresholdSign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/ThresholdSign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).ThresholdSign(ctx, req.(*ThresholdSignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PrivValidatorAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tendermint.privval.PrivValidatorAPI",
 	HandlerType: (*PrivValidatorAPIServer)(nil),
@@ -193,6 +293,18 @@ var _PrivValidatorAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SignProposal",
 			Handler:    _PrivValidatorAPI_SignProposal_Handler,
 		},
+		{
+			MethodName: "ThresholdKey",
+			Handler:    _PrivValidatorAPI_ThresholdKey_Handler,
+		},
+		{
+			MethodName: "ThresholdCommit",
+			Handler:    _PrivValidatorAPI_ThresholdCommit_Handler,
+		},
+		{
+			MethodName: "ThresholdSign",
+			Handler:    _PrivValidatorAPI_ThresholdSign_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tendermint/privval/service.proto",
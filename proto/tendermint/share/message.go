@@ -0,0 +1,86 @@
+package share
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// Wrap implements the p2p Wrapper interface and wraps a share message.
+func (m *Message) Wrap(pb proto.Message) error {
+	switch msg := pb.(type) {
+	case *SharesRequest:
+		m.Sum = &Message_SharesRequest{SharesRequest: msg}
+
+	case *SharesResponse:
+		m.Sum = &Message_SharesResponse{SharesResponse: msg}
+
+	case *BadEncodingProof:
+		m.Sum = &Message_BadEncodingProof{BadEncodingProof: msg}
+
+	default:
+		return fmt.Errorf("unknown message: %T", msg)
+	}
+
+	return nil
+}
+
+// Unwrap implements the p2p Wrapper interface and unwraps a wrapped share
+// message.
+func (m *Message) Unwrap() (proto.Message, error) {
+	switch msg := m.Sum.(type) {
+	case *Message_SharesRequest:
+		return m.GetSharesRequest(), nil
+
+	case *Message_SharesResponse:
+		return m.GetSharesResponse(), nil
+
+	case *Message_BadEncodingProof:
+		return m.GetBadEncodingProof(), nil
+
+	default:
+		return nil, fmt.Errorf("unknown message: %T", msg)
+	}
+}
+
+// Validate validates the message returning an error upon failure.
+func (m *Message) Validate() error {
+	if m == nil {
+		return errors.New("message cannot be nil")
+	}
+
+	switch msg := m.Sum.(type) {
+	case *Message_SharesRequest:
+		req := m.GetSharesRequest()
+		if req.Height < 0 {
+			return errors.New("negative Height")
+		}
+		if len(req.Rows)+len(req.Cols)+len(req.Coordinates) == 0 {
+			return errors.New("empty request")
+		}
+
+	case *Message_SharesResponse:
+		// the shares are verified against the data availability header by
+		// the requester
+		if m.GetSharesResponse().Height < 0 {
+			return errors.New("negative Height")
+		}
+
+	case *Message_BadEncodingProof:
+		// the proof is verified against the data availability header by the
+		// receiver
+		proof := m.GetBadEncodingProof()
+		if proof.Height <= 0 {
+			return errors.New("non-positive Height")
+		}
+		if len(proof.Shares) == 0 {
+			return errors.New("no shares")
+		}
+
+	default:
+		return fmt.Errorf("unknown message type: %T", msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package share_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	shproto "github.com/tendermint/tendermint/proto/tendermint/share"
+)
+
+func TestSharesRequest_Validate(t *testing.T) {
+	testCases := []struct {
+		testName  string
+		request   *shproto.SharesRequest
+		expectErr bool
+	}{
+		{"Valid Request Message", &shproto.SharesRequest{Height: 1, Rows: []uint64{0}}, false},
+		{"Valid Coordinates Request Message",
+			&shproto.SharesRequest{Height: 1, Coordinates: []shproto.Coordinate{{Row: 1, Col: 2}}}, false},
+		{"Invalid Request Message", &shproto.SharesRequest{Height: -1, Cols: []uint64{0}}, true},
+		{"Empty Request Message", &shproto.SharesRequest{Height: 1}, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			msg := &shproto.Message{}
+			require.NoError(t, msg.Wrap(tc.request))
+
+			require.Equal(t, tc.expectErr, msg.Validate() != nil)
+		})
+	}
+}
+
+func TestSharesResponse_Validate(t *testing.T) {
+	msg := &shproto.Message{}
+	require.NoError(t, msg.Wrap(&shproto.SharesResponse{Height: 1}))
+	require.NoError(t, msg.Validate())
+
+	require.NoError(t, msg.Wrap(&shproto.SharesResponse{Height: -1}))
+	require.Error(t, msg.Validate())
+}
+
+func TestBadEncodingProof_Validate(t *testing.T) {
+	msg := &shproto.Message{}
+	shares := []shproto.ProvenShare{{Data: []byte{1}}, {}}
+	require.NoError(t, msg.Wrap(&shproto.BadEncodingProof{Height: 1, IsRow: true, Shares: shares}))
+	require.NoError(t, msg.Validate())
+
+	require.NoError(t, msg.Wrap(&shproto.BadEncodingProof{Height: 0, Shares: shares}))
+	require.Error(t, msg.Validate())
+
+	require.NoError(t, msg.Wrap(&shproto.BadEncodingProof{Height: 1}))
+	require.Error(t, msg.Validate())
+}
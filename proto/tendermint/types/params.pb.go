@@ -105,6 +105,15 @@ type BlockParams struct {
 	// Max gas per block.
 	// Note: must be greater or equal to -1
 	MaxGas int64 `protobuf:"varint,2,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
+	// Max width of the original data square, a power of two.
+	// Note: 0 stands for the maximum square size of the protocol
+	MaxSquareSize uint64 `protobuf:"varint,3,opt,name=max_square_size,json=maxSquareSize,proto3" json:"max_square_size,omitempty"`
+	// Size of the parts the blocks are split in to be gossiped, in bytes.
+	// Note: 0 stands for the default part size of 64kB
+	PartSizeBytes uint32 `protobuf:"varint,4,opt,name=part_size_bytes,json=partSizeBytes,proto3" json:"part_size_bytes,omitempty"`
+	// Max size the parts of large blocks grow to, in bytes.
+	// Note: 0 stands for the part size, the parts not growing
+	MaxPartSizeBytes uint32 `protobuf:"varint,5,opt,name=max_part_size_bytes,json=maxPartSizeBytes,proto3" json:"max_part_size_bytes,omitempty"`
 }
 
 func (m *BlockParams) Reset()         { *m = BlockParams{} }
@@ -154,6 +163,27 @@ func (m *BlockParams) GetMaxGas() int64 {
 	return 0
 }
 
+func (m *BlockParams) GetMaxSquareSize() uint64 {
+	if m != nil {
+		return m.MaxSquareSize
+	}
+	return 0
+}
+
+func (m *BlockParams) GetPartSizeBytes() uint32 {
+	if m != nil {
+		return m.PartSizeBytes
+	}
+	return 0
+}
+
+func (m *BlockParams) GetMaxPartSizeBytes() uint32 {
+	if m != nil {
+		return m.MaxPartSizeBytes
+	}
+	return 0
+}
+
 // EvidenceParams determine how we handle evidence of malfeasance.
 type EvidenceParams struct {
 	// Max age of evidence, in blocks.
@@ -385,39 +415,42 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/params.proto", fileDescriptor_e12598271a686f57) }
 
 var fileDescriptor_e12598271a686f57 = []byte{
-	// 498 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0xc1, 0x6a, 0xd4, 0x40,
-	0x1c, 0xc6, 0x77, 0x9a, 0xda, 0xee, 0xfe, 0xe3, 0x76, 0xcb, 0x20, 0x18, 0x2b, 0xcd, 0xae, 0x39,
-	0x48, 0x41, 0x48, 0xc4, 0x22, 0x22, 0x08, 0xe2, 0x56, 0xa9, 0x20, 0x15, 0x09, 0xea, 0xa1, 0x97,
-	0x30, 0xd9, 0x8c, 0x69, 0xe8, 0x4e, 0x66, 0xc8, 0x24, 0xcb, 0xee, 0xcd, 0x47, 0xf0, 0xe8, 0x23,
-	0xe8, 0x9b, 0xf4, 0xd8, 0xa3, 0x27, 0x95, 0xdd, 0x17, 0x91, 0x4c, 0x32, 0xa6, 0x9b, 0xf6, 0x36,
-	0x33, 0xdf, 0xef, 0x9b, 0xe1, 0xfb, 0x86, 0x3f, 0xec, 0xe7, 0x34, 0x8d, 0x68, 0xc6, 0x92, 0x34,
-	0xf7, 0xf2, 0x85, 0xa0, 0xd2, 0x13, 0x24, 0x23, 0x4c, 0xba, 0x22, 0xe3, 0x39, 0xc7, 0xbb, 0x8d,
-	0xec, 0x2a, 0x79, 0xef, 0x4e, 0xcc, 0x63, 0xae, 0x44, 0xaf, 0x5c, 0x55, 0xdc, 0x9e, 0x1d, 0x73,
-	0x1e, 0x4f, 0xa9, 0xa7, 0x76, 0x61, 0xf1, 0xc5, 0x8b, 0x8a, 0x8c, 0xe4, 0x09, 0x4f, 0x2b, 0xdd,
-	0xf9, 0xba, 0x01, 0x83, 0x23, 0x9e, 0x4a, 0x9a, 0xca, 0x42, 0x7e, 0x50, 0x2f, 0xe0, 0x43, 0xb8,
-	0x15, 0x4e, 0xf9, 0xe4, 0xdc, 0x42, 0x23, 0x74, 0x60, 0x3e, 0xd9, 0x77, 0xdb, 0x6f, 0xb9, 0xe3,
-	0x52, 0xae, 0x68, 0xbf, 0x62, 0xf1, 0x0b, 0xe8, 0xd2, 0x59, 0x12, 0xd1, 0x74, 0x42, 0xad, 0x0d,
-	0xe5, 0x1b, 0x5d, 0xf7, 0xbd, 0xa9, 0x89, 0xda, 0xfa, 0xdf, 0x81, 0x5f, 0x42, 0x6f, 0x46, 0xa6,
-	0x49, 0x44, 0x72, 0x9e, 0x59, 0x86, 0xb2, 0x3f, 0xb8, 0x6e, 0xff, 0xac, 0x91, 0xda, 0xdf, 0x78,
-	0xf0, 0x73, 0xd8, 0x9e, 0xd1, 0x4c, 0x26, 0x3c, 0xb5, 0x36, 0x95, 0x7d, 0x78, 0x83, 0xbd, 0x02,
-	0x6a, 0xb3, 0xe6, 0x9d, 0x23, 0x30, 0xaf, 0xe4, 0xc1, 0xf7, 0xa1, 0xc7, 0xc8, 0x3c, 0x08, 0x17,
-	0x39, 0x95, 0xaa, 0x01, 0xc3, 0xef, 0x32, 0x32, 0x1f, 0x97, 0x7b, 0x7c, 0x17, 0xb6, 0x4b, 0x31,
-	0x26, 0x52, 0x85, 0x34, 0xfc, 0x2d, 0x46, 0xe6, 0xc7, 0x44, 0x3a, 0x3f, 0x11, 0xec, 0xac, 0xa7,
-	0xc3, 0x8f, 0x00, 0x97, 0x2c, 0x89, 0x69, 0x90, 0x16, 0x2c, 0x50, 0x35, 0xe9, 0x1b, 0x07, 0x8c,
-	0xcc, 0x5f, 0xc5, 0xf4, 0x7d, 0xc1, 0xd4, 0xd3, 0x12, 0x9f, 0xc0, 0xae, 0x86, 0xf5, 0x0f, 0xd5,
-	0x35, 0xde, 0x73, 0xab, 0x2f, 0x74, 0xf5, 0x17, 0xba, 0xaf, 0x6b, 0x60, 0xdc, 0xbd, 0xf8, 0x3d,
-	0xec, 0x7c, 0xff, 0x33, 0x44, 0xfe, 0x4e, 0x75, 0x9f, 0x56, 0xd6, 0x43, 0x18, 0xeb, 0x21, 0x9c,
-	0xa7, 0x30, 0x68, 0x35, 0x89, 0x1d, 0xe8, 0x8b, 0x22, 0x0c, 0xce, 0xe9, 0x22, 0x50, 0x5d, 0x59,
-	0x68, 0x64, 0x1c, 0xf4, 0x7c, 0x53, 0x14, 0xe1, 0x3b, 0xba, 0xf8, 0x58, 0x1e, 0x39, 0x8f, 0xa1,
-	0xbf, 0xd6, 0x20, 0x1e, 0x82, 0x49, 0x84, 0x08, 0x74, 0xef, 0x65, 0xb2, 0x4d, 0x1f, 0x88, 0x10,
-	0x35, 0xe6, 0x9c, 0xc2, 0xed, 0xb7, 0x44, 0x9e, 0xd1, 0xa8, 0x36, 0x3c, 0x84, 0x81, 0x6a, 0x21,
-	0x68, 0x17, 0xdc, 0x57, 0xc7, 0x27, 0xba, 0x65, 0x07, 0xfa, 0x0d, 0xd7, 0x74, 0x6d, 0x6a, 0xea,
-	0x98, 0xc8, 0xf1, 0xa7, 0x1f, 0x4b, 0x1b, 0x5d, 0x2c, 0x6d, 0x74, 0xb9, 0xb4, 0xd1, 0xdf, 0xa5,
-	0x8d, 0xbe, 0xad, 0xec, 0xce, 0xe5, 0xca, 0xee, 0xfc, 0x5a, 0xd9, 0x9d, 0xd3, 0x67, 0x71, 0x92,
-	0x9f, 0x15, 0xa1, 0x3b, 0xe1, 0xcc, 0xbb, 0x3a, 0x48, 0xcd, 0xb2, 0x9a, 0x94, 0xf6, 0x90, 0x85,
-	0x5b, 0xea, 0xfc, 0xf0, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x18, 0x54, 0x4f, 0xe1, 0x7f, 0x03,
-	0x00, 0x00,
+	// 558 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0xcd, 0x6e, 0xd3, 0x4e,
+	0x14, 0xc5, 0x33, 0x4d, 0x3f, 0x92, 0x9b, 0xbf, 0x9b, 0x68, 0xfe, 0x48, 0x84, 0xa2, 0x3a, 0xc1,
+	0x8b, 0xaa, 0x12, 0xc2, 0x46, 0x54, 0x08, 0x21, 0x21, 0x21, 0x02, 0xa8, 0x48, 0xa8, 0xa8, 0x72,
+	0x81, 0x45, 0x37, 0xd6, 0x38, 0x19, 0x5c, 0xab, 0xb1, 0xc7, 0x78, 0xec, 0x28, 0xe9, 0x8a, 0x47,
+	0x60, 0xc9, 0x23, 0xc0, 0x6b, 0xb0, 0xea, 0xb2, 0x4b, 0x56, 0x80, 0x92, 0x17, 0x41, 0x73, 0xed,
+	0x21, 0x1f, 0x65, 0x67, 0xdf, 0xf3, 0x3b, 0x33, 0x73, 0xef, 0x99, 0x81, 0xdd, 0x8c, 0xc7, 0x03,
+	0x9e, 0x46, 0x61, 0x9c, 0x39, 0xd9, 0x24, 0xe1, 0xd2, 0x49, 0x58, 0xca, 0x22, 0x69, 0x27, 0xa9,
+	0xc8, 0x04, 0x6d, 0xcd, 0x65, 0x1b, 0xe5, 0x9d, 0x1b, 0x81, 0x08, 0x04, 0x8a, 0x8e, 0xfa, 0x2a,
+	0xb8, 0x1d, 0x33, 0x10, 0x22, 0x18, 0x72, 0x07, 0xff, 0xfc, 0xfc, 0x83, 0x33, 0xc8, 0x53, 0x96,
+	0x85, 0x22, 0x2e, 0x74, 0xeb, 0xd3, 0x1a, 0x34, 0x9f, 0x8b, 0x58, 0xf2, 0x58, 0xe6, 0xf2, 0x18,
+	0x77, 0xa0, 0x07, 0xb0, 0xe1, 0x0f, 0x45, 0xff, 0xbc, 0x4d, 0xba, 0x64, 0xbf, 0xf1, 0x60, 0xd7,
+	0x5e, 0xdd, 0xcb, 0xee, 0x29, 0xb9, 0xa0, 0xdd, 0x82, 0xa5, 0x4f, 0xa0, 0xc6, 0x47, 0xe1, 0x80,
+	0xc7, 0x7d, 0xde, 0x5e, 0x43, 0x5f, 0xf7, 0xba, 0xef, 0x65, 0x49, 0x94, 0xd6, 0xbf, 0x0e, 0xfa,
+	0x14, 0xea, 0x23, 0x36, 0x0c, 0x07, 0x2c, 0x13, 0x69, 0xbb, 0x8a, 0xf6, 0x3b, 0xd7, 0xed, 0xef,
+	0x35, 0x52, 0xfa, 0xe7, 0x1e, 0xfa, 0x18, 0xb6, 0x46, 0x3c, 0x95, 0xa1, 0x88, 0xdb, 0xeb, 0x68,
+	0xef, 0xfc, 0xc3, 0x5e, 0x00, 0xa5, 0x59, 0xf3, 0xd6, 0x77, 0x02, 0x8d, 0x85, 0x86, 0xe8, 0x6d,
+	0xa8, 0x47, 0x6c, 0xec, 0xf9, 0x93, 0x8c, 0x4b, 0x1c, 0x41, 0xd5, 0xad, 0x45, 0x6c, 0xdc, 0x53,
+	0xff, 0xf4, 0x26, 0x6c, 0x29, 0x31, 0x60, 0x12, 0xbb, 0xac, 0xba, 0x9b, 0x11, 0x1b, 0x1f, 0x32,
+	0x49, 0xf7, 0xa0, 0xa9, 0x04, 0xf9, 0x31, 0x67, 0x29, 0xf7, 0x64, 0x78, 0xc1, 0xb1, 0x8f, 0x75,
+	0xd7, 0x88, 0xd8, 0xf8, 0x04, 0xab, 0x27, 0xe1, 0x05, 0x57, 0x5c, 0xc2, 0xd2, 0x0c, 0x89, 0x72,
+	0x0f, 0x75, 0x60, 0xc3, 0x35, 0x54, 0x59, 0x21, 0xc5, 0x46, 0xf7, 0xe0, 0x7f, 0xb5, 0xde, 0x2a,
+	0xbb, 0x81, 0x6c, 0x2b, 0x62, 0xe3, 0xe3, 0x45, 0xdc, 0xfa, 0x46, 0x60, 0x7b, 0x79, 0xba, 0xf4,
+	0x2e, 0x50, 0xb5, 0x02, 0x0b, 0xb8, 0x17, 0xe7, 0x91, 0x87, 0x31, 0xe9, 0x86, 0xd4, 0x59, 0x9f,
+	0x05, 0xfc, 0x4d, 0x1e, 0x61, 0xe7, 0x92, 0x1e, 0x41, 0x4b, 0xc3, 0xfa, 0x86, 0x94, 0x31, 0xde,
+	0xb2, 0x8b, 0x2b, 0x64, 0xeb, 0x2b, 0x64, 0xbf, 0x28, 0x81, 0x5e, 0xed, 0xf2, 0x67, 0xa7, 0xf2,
+	0xe5, 0x57, 0x87, 0xb8, 0xdb, 0xc5, 0x7a, 0x5a, 0x59, 0x9e, 0x61, 0x75, 0x79, 0x86, 0xd6, 0x43,
+	0x68, 0xae, 0x24, 0x49, 0x2d, 0x30, 0x92, 0xdc, 0xf7, 0xce, 0xf9, 0xc4, 0xc3, 0xac, 0xda, 0xa4,
+	0x5b, 0xdd, 0xaf, 0xbb, 0x8d, 0x24, 0xf7, 0x5f, 0xf3, 0xc9, 0x5b, 0x55, 0xb2, 0xee, 0x83, 0xb1,
+	0x94, 0x20, 0xed, 0x40, 0x83, 0x25, 0x89, 0xa7, 0x73, 0x27, 0x38, 0x6e, 0x60, 0x49, 0x52, 0x62,
+	0xd6, 0x29, 0xfc, 0xf7, 0x8a, 0xc9, 0x33, 0x3e, 0x28, 0x0d, 0x7b, 0xd0, 0xc4, 0x29, 0x78, 0xab,
+	0xf9, 0x1a, 0x58, 0x3e, 0xd2, 0x21, 0x5b, 0x60, 0xcc, 0xb9, 0x79, 0xd4, 0x0d, 0x4d, 0x1d, 0x32,
+	0xd9, 0x7b, 0xf7, 0x75, 0x6a, 0x92, 0xcb, 0xa9, 0x49, 0xae, 0xa6, 0x26, 0xf9, 0x3d, 0x35, 0xc9,
+	0xe7, 0x99, 0x59, 0xb9, 0x9a, 0x99, 0x95, 0x1f, 0x33, 0xb3, 0x72, 0xfa, 0x28, 0x08, 0xb3, 0xb3,
+	0xdc, 0xb7, 0xfb, 0x22, 0x72, 0x16, 0x1f, 0xf2, 0xfc, 0xb3, 0x78, 0xa9, 0xab, 0x8f, 0xdc, 0xdf,
+	0xc4, 0xfa, 0xc1, 0x9f, 0x01, 0x00, 0xbd, 0xfb, 0x11, 0x85, 0xff, 0x03, 0x00, 0x00,
 }
 
 func (this *ConsensusParams) Equal(that interface{}) bool {
@@ -478,6 +511,15 @@ func (this *BlockParams) Equal(that interface{}) bool {
 	if this.MaxGas != that1.MaxGas {
 		return false
 	}
+	if this.MaxSquareSize != that1.MaxSquareSize {
+		return false
+	}
+	if this.PartSizeBytes != that1.PartSizeBytes {
+		return false
+	}
+	if this.MaxPartSizeBytes != that1.MaxPartSizeBytes {
+		return false
+	}
 	return true
 }
 func (this *EvidenceParams) Equal(that interface{}) bool {
@@ -681,6 +723,21 @@ func (m *BlockParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MaxPartSizeBytes != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxPartSizeBytes))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.PartSizeBytes != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.PartSizeBytes))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MaxSquareSize != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxSquareSize))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.MaxGas != 0 {
 		i = encodeVarintParams(dAtA, i, uint64(m.MaxGas))
 		i--
@@ -876,6 +933,15 @@ func (m *BlockParams) Size() (n int) {
 	if m.MaxGas != 0 {
 		n += 1 + sovParams(uint64(m.MaxGas))
 	}
+	if m.MaxSquareSize != 0 {
+		n += 1 + sovParams(uint64(m.MaxSquareSize))
+	}
+	if m.PartSizeBytes != 0 {
+		n += 1 + sovParams(uint64(m.PartSizeBytes))
+	}
+	if m.MaxPartSizeBytes != 0 {
+		n += 1 + sovParams(uint64(m.MaxPartSizeBytes))
+	}
 	return n
 }
 
@@ -1205,6 +1271,63 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSquareSize", wireType)
+			}
+			m.MaxSquareSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSquareSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartSizeBytes", wireType)
+			}
+			m.PartSizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PartSizeBytes |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPartSizeBytes", wireType)
+			}
+			m.MaxPartSizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPartSizeBytes |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -911,6 +911,77 @@ func (m *Message) GetData() []byte {
 	return nil
 }
 
+// BlobTx is the envelope of a tx carrying blobs, which are laid out in the
+// messages of the block data, and the share commitments to them. The tx is
+// included in the block without its blobs.
+type BlobTx struct {
+	Tx               []byte     `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Blobs            []*Message `protobuf:"bytes,2,rep,name=blobs,proto3" json:"blobs,omitempty"`
+	ShareCommitments [][]byte   `protobuf:"bytes,3,rep,name=share_commitments,json=shareCommitments,proto3" json:"share_commitments,omitempty"`
+	TypeId           string     `protobuf:"bytes,4,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+}
+
+func (m *BlobTx) Reset()         { *m = BlobTx{} }
+func (m *BlobTx) String() string { return proto.CompactTextString(m) }
+func (*BlobTx) ProtoMessage()    {}
+func (*BlobTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3a6e55e2345de56, []int{12}
+}
+func (m *BlobTx) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlobTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlobTx.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlobTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlobTx.Merge(m, src)
+}
+func (m *BlobTx) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlobTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlobTx.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlobTx proto.InternalMessageInfo
+
+func (m *BlobTx) GetTx() []byte {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+func (m *BlobTx) GetBlobs() []*Message {
+	if m != nil {
+		return m.Blobs
+	}
+	return nil
+}
+
+func (m *BlobTx) GetShareCommitments() [][]byte {
+	if m != nil {
+		return m.ShareCommitments
+	}
+	return nil
+}
+
+func (m *BlobTx) GetTypeId() string {
+	if m != nil {
+		return m.TypeId
+	}
+	return ""
+}
+
 // DataAvailabilityHeader contains the row and column roots of the erasure
 // coded version of the data in Block.Data.
 // Therefor the original Block.Data is arranged in a
@@ -932,7 +1003,7 @@ func (m *DataAvailabilityHeader) Reset()         { *m = DataAvailabilityHeader{}
 func (m *DataAvailabilityHeader) String() string { return proto.CompactTextString(m) }
 func (*DataAvailabilityHeader) ProtoMessage()    {}
 func (*DataAvailabilityHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{12}
+	return fileDescriptor_d3a6e55e2345de56, []int{13}
 }
 func (m *DataAvailabilityHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -992,7 +1063,7 @@ func (m *Vote) Reset()         { *m = Vote{} }
 func (m *Vote) String() string { return proto.CompactTextString(m) }
 func (*Vote) ProtoMessage()    {}
 func (*Vote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{13}
+	return fileDescriptor_d3a6e55e2345de56, []int{14}
 }
 func (m *Vote) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1083,13 +1154,16 @@ type Commit struct {
 	Round      int32       `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
 	BlockID    BlockID     `protobuf:"bytes,3,opt,name=block_id,json=blockId,proto3" json:"block_id"`
 	Signatures []CommitSig `protobuf:"bytes,4,rep,name=signatures,proto3" json:"signatures"`
+	// aggregated_signature is the BLS aggregate of the signatures left out of
+	// the signatures of the commit, from the aggregated commit block version on.
+	AggregatedSignature []byte `protobuf:"bytes,5,opt,name=aggregated_signature,json=aggregatedSignature,proto3" json:"aggregated_signature,omitempty"`
 }
 
 func (m *Commit) Reset()         { *m = Commit{} }
 func (m *Commit) String() string { return proto.CompactTextString(m) }
 func (*Commit) ProtoMessage()    {}
 func (*Commit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{14}
+	return fileDescriptor_d3a6e55e2345de56, []int{15}
 }
 func (m *Commit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1146,6 +1220,13 @@ func (m *Commit) GetSignatures() []CommitSig {
 	return nil
 }
 
+func (m *Commit) GetAggregatedSignature() []byte {
+	if m != nil {
+		return m.AggregatedSignature
+	}
+	return nil
+}
+
 // CommitSig is a part of the Vote included in a Commit.
 type CommitSig struct {
 	BlockIdFlag      BlockIDFlag `protobuf:"varint,1,opt,name=block_id_flag,json=blockIdFlag,proto3,enum=tendermint.types.BlockIDFlag" json:"block_id_flag,omitempty"`
@@ -1158,7 +1239,7 @@ func (m *CommitSig) Reset()         { *m = CommitSig{} }
 func (m *CommitSig) String() string { return proto.CompactTextString(m) }
 func (*CommitSig) ProtoMessage()    {}
 func (*CommitSig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{15}
+	return fileDescriptor_d3a6e55e2345de56, []int{16}
 }
 func (m *CommitSig) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1229,7 +1310,7 @@ func (m *Proposal) Reset()         { *m = Proposal{} }
 func (m *Proposal) String() string { return proto.CompactTextString(m) }
 func (*Proposal) ProtoMessage()    {}
 func (*Proposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{16}
+	return fileDescriptor_d3a6e55e2345de56, []int{17}
 }
 func (m *Proposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1316,7 +1397,7 @@ func (m *SignedHeader) Reset()         { *m = SignedHeader{} }
 func (m *SignedHeader) String() string { return proto.CompactTextString(m) }
 func (*SignedHeader) ProtoMessage()    {}
 func (*SignedHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{17}
+	return fileDescriptor_d3a6e55e2345de56, []int{18}
 }
 func (m *SignedHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1368,7 +1449,7 @@ func (m *LightBlock) Reset()         { *m = LightBlock{} }
 func (m *LightBlock) String() string { return proto.CompactTextString(m) }
 func (*LightBlock) ProtoMessage()    {}
 func (*LightBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{18}
+	return fileDescriptor_d3a6e55e2345de56, []int{19}
 }
 func (m *LightBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1422,7 +1503,7 @@ func (m *BlockMeta) Reset()         { *m = BlockMeta{} }
 func (m *BlockMeta) String() string { return proto.CompactTextString(m) }
 func (*BlockMeta) ProtoMessage()    {}
 func (*BlockMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{19}
+	return fileDescriptor_d3a6e55e2345de56, []int{20}
 }
 func (m *BlockMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1490,7 +1571,7 @@ func (m *TxProof) Reset()         { *m = TxProof{} }
 func (m *TxProof) String() string { return proto.CompactTextString(m) }
 func (*TxProof) ProtoMessage()    {}
 func (*TxProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{20}
+	return fileDescriptor_d3a6e55e2345de56, []int{21}
 }
 func (m *TxProof) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1555,6 +1636,7 @@ func init() {
 	proto.RegisterType((*IntermediateStateRoots)(nil), "tendermint.types.IntermediateStateRoots")
 	proto.RegisterType((*Messages)(nil), "tendermint.types.Messages")
 	proto.RegisterType((*Message)(nil), "tendermint.types.Message")
+	proto.RegisterType((*BlobTx)(nil), "tendermint.types.BlobTx")
 	proto.RegisterType((*DataAvailabilityHeader)(nil), "tendermint.types.DataAvailabilityHeader")
 	proto.RegisterType((*Vote)(nil), "tendermint.types.Vote")
 	proto.RegisterType((*Commit)(nil), "tendermint.types.Commit")
@@ -1569,119 +1651,124 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/types.proto", fileDescriptor_d3a6e55e2345de56) }
 
 var fileDescriptor_d3a6e55e2345de56 = []byte{
-	// 1783 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x4b, 0x6f, 0xdb, 0xd8,
-	0x15, 0x36, 0x25, 0xd9, 0x92, 0x8e, 0x24, 0x5b, 0xbe, 0x75, 0x1c, 0x59, 0x49, 0x64, 0x95, 0x7d,
-	0x8c, 0xe7, 0x51, 0x39, 0xcd, 0x14, 0x7d, 0x00, 0xed, 0x60, 0x24, 0xdb, 0x13, 0xab, 0xe3, 0x87,
-	0x4a, 0x79, 0xd2, 0xc7, 0x86, 0xb8, 0x12, 0x6f, 0x24, 0x36, 0x14, 0x2f, 0xc1, 0x7b, 0xe5, 0xd8,
-	0x59, 0x76, 0x55, 0x78, 0x95, 0x55, 0x77, 0x5e, 0xb5, 0x8b, 0xee, 0xfb, 0x07, 0x8a, 0xae, 0x66,
-	0x53, 0x60, 0x76, 0xed, 0xa6, 0xd3, 0x22, 0x29, 0x8a, 0xfe, 0x8c, 0xe2, 0x3e, 0x48, 0x51, 0x96,
-	0x94, 0x06, 0x41, 0x30, 0x1b, 0x81, 0x3c, 0xe7, 0x3b, 0xf7, 0x9e, 0xf7, 0x39, 0x14, 0xdc, 0xe5,
-	0xc4, 0x77, 0x48, 0x38, 0x72, 0x7d, 0xbe, 0xcb, 0x2f, 0x03, 0xc2, 0xd4, 0x6f, 0x23, 0x08, 0x29,
-	0xa7, 0xa8, 0x3c, 0xe1, 0x36, 0x24, 0xbd, 0xba, 0x31, 0xa0, 0x03, 0x2a, 0x99, 0xbb, 0xe2, 0x49,
-	0xe1, 0xaa, 0xdb, 0x03, 0x4a, 0x07, 0x1e, 0xd9, 0x95, 0x6f, 0xbd, 0xf1, 0xe3, 0x5d, 0xee, 0x8e,
-	0x08, 0xe3, 0x78, 0x14, 0x68, 0xc0, 0xbd, 0xc4, 0x35, 0xfd, 0xf0, 0x32, 0xe0, 0x54, 0x60, 0xe9,
-	0x63, 0xcd, 0xae, 0x25, 0xd8, 0xe7, 0x24, 0x64, 0x2e, 0xf5, 0x93, 0x7a, 0x54, 0xeb, 0x33, 0x5a,
-	0x9e, 0x63, 0xcf, 0x75, 0x30, 0xa7, 0xa1, 0x42, 0x98, 0x3f, 0x82, 0x52, 0x07, 0x87, 0xbc, 0x4b,
-	0xf8, 0x21, 0xc1, 0x0e, 0x09, 0xd1, 0x06, 0x2c, 0x73, 0xca, 0xb1, 0x57, 0x31, 0xea, 0xc6, 0x4e,
-	0xc9, 0x52, 0x2f, 0x08, 0x41, 0x66, 0x88, 0xd9, 0xb0, 0x92, 0xaa, 0x1b, 0x3b, 0x45, 0x4b, 0x3e,
-	0x9b, 0x43, 0xc8, 0x08, 0x51, 0x21, 0xe1, 0xfa, 0x0e, 0xb9, 0x88, 0x24, 0xe4, 0x8b, 0xa0, 0xf6,
-	0x2e, 0x39, 0x61, 0x5a, 0x44, 0xbd, 0xa0, 0xef, 0xc1, 0xb2, 0xd4, 0xbf, 0x92, 0xae, 0x1b, 0x3b,
-	0x85, 0x07, 0x95, 0x46, 0xc2, 0x51, 0xca, 0xbe, 0x46, 0x47, 0xf0, 0x5b, 0x99, 0xcf, 0xbf, 0xdc,
-	0x5e, 0xb2, 0x14, 0xd8, 0xf4, 0x20, 0xdb, 0xf2, 0x68, 0xff, 0x49, 0x7b, 0x3f, 0x56, 0xc4, 0x98,
-	0x28, 0x82, 0x8e, 0x61, 0x2d, 0xc0, 0x21, 0xb7, 0x19, 0xe1, 0xf6, 0x50, 0x5a, 0x21, 0x2f, 0x2d,
-	0x3c, 0xd8, 0x6e, 0xdc, 0x8c, 0x43, 0x63, 0xca, 0x58, 0x7d, 0x4b, 0x29, 0x48, 0x12, 0xcd, 0xff,
-	0x64, 0x60, 0x45, 0x3b, 0xe3, 0x27, 0x90, 0xd5, 0x6e, 0x95, 0x17, 0x16, 0x1e, 0xdc, 0x4b, 0x9e,
-	0xa8, 0x59, 0x8d, 0x3d, 0xea, 0x33, 0xe2, 0xb3, 0x31, 0xd3, 0xe7, 0x45, 0x32, 0xe8, 0xdb, 0x90,
-	0xeb, 0x0f, 0xb1, 0xeb, 0xdb, 0xae, 0x23, 0x35, 0xca, 0xb7, 0x0a, 0x2f, 0xbe, 0xdc, 0xce, 0xee,
-	0x09, 0x5a, 0x7b, 0xdf, 0xca, 0x4a, 0x66, 0xdb, 0x41, 0x9b, 0xb0, 0x32, 0x24, 0xee, 0x60, 0xc8,
-	0xa5, 0x5b, 0xd2, 0x96, 0x7e, 0x43, 0x3f, 0x84, 0x8c, 0x48, 0x88, 0x4a, 0x46, 0xde, 0x5d, 0x6d,
-	0xa8, 0x6c, 0x69, 0x44, 0xd9, 0xd2, 0x38, 0x8b, 0xb2, 0xa5, 0x95, 0x13, 0x17, 0x3f, 0xff, 0xe7,
-	0xb6, 0x61, 0x49, 0x09, 0xb4, 0x07, 0x25, 0x0f, 0x33, 0x6e, 0xf7, 0x84, 0xdb, 0xc4, 0xf5, 0xcb,
-	0xf2, 0x88, 0xad, 0x59, 0x87, 0x68, 0xc7, 0x6a, 0xd5, 0x0b, 0x42, 0x4a, 0x91, 0x1c, 0xb4, 0x03,
-	0x65, 0x79, 0x48, 0x9f, 0x8e, 0x46, 0x2e, 0xb7, 0xa5, 0xdf, 0x57, 0xa4, 0xdf, 0x57, 0x05, 0x7d,
-	0x4f, 0x92, 0x0f, 0x45, 0x04, 0xee, 0x40, 0xde, 0xc1, 0x1c, 0x2b, 0x48, 0x56, 0x42, 0x72, 0x82,
-	0x20, 0x99, 0xef, 0xc0, 0x5a, 0x9c, 0x75, 0x4c, 0x41, 0x72, 0xea, 0x94, 0x09, 0x59, 0x02, 0xef,
-	0xc3, 0x86, 0x4f, 0x2e, 0xb8, 0x7d, 0x13, 0x9d, 0x97, 0x68, 0x24, 0x78, 0x8f, 0xa6, 0x25, 0xbe,
-	0x05, 0xab, 0xfd, 0xc8, 0xf9, 0x0a, 0x0b, 0x12, 0x5b, 0x8a, 0xa9, 0x12, 0xb6, 0x05, 0x39, 0x1c,
-	0x04, 0x0a, 0x50, 0x90, 0x80, 0x2c, 0x0e, 0x02, 0xc9, 0x7a, 0x0f, 0xd6, 0xa5, 0x8d, 0x21, 0x61,
-	0x63, 0x8f, 0xeb, 0x43, 0x8a, 0x12, 0xb3, 0x26, 0x18, 0x96, 0xa2, 0x4b, 0xec, 0x37, 0xa0, 0x44,
-	0xce, 0x5d, 0x87, 0xf8, 0x7d, 0xa2, 0x70, 0x25, 0x89, 0x2b, 0x46, 0x44, 0x09, 0x7a, 0x17, 0xca,
-	0x41, 0x48, 0x03, 0xca, 0x48, 0x68, 0x63, 0xc7, 0x09, 0x09, 0x63, 0x95, 0x55, 0x75, 0x5e, 0x44,
-	0x6f, 0x2a, 0xb2, 0xf9, 0x9b, 0x14, 0x64, 0xf6, 0x31, 0xc7, 0xa8, 0x0c, 0x69, 0x7e, 0xc1, 0x2a,
-	0x46, 0x3d, 0xbd, 0x53, 0xb4, 0xc4, 0x23, 0x1a, 0x42, 0xc5, 0xf5, 0x39, 0x09, 0x47, 0xc4, 0x71,
-	0x31, 0x27, 0x36, 0xe3, 0xe2, 0x37, 0xa4, 0x94, 0x33, 0x9d, 0xdb, 0x3b, 0xb3, 0xa1, 0x6c, 0x27,
-	0x24, 0xba, 0x42, 0xc0, 0x12, 0x78, 0x1d, 0xd9, 0x4d, 0x77, 0x2e, 0x17, 0x7d, 0x0c, 0xb9, 0x48,
-	0x7f, 0x5d, 0x94, 0xb5, 0xd9, 0x93, 0x0f, 0x34, 0xe2, 0xc8, 0x65, 0x5c, 0x9f, 0x17, 0x4b, 0xa1,
-	0x1f, 0x43, 0x6e, 0x44, 0x18, 0xc3, 0x03, 0xc2, 0xe2, 0x4c, 0x9d, 0x39, 0xe1, 0x58, 0x23, 0x22,
-	0xe9, 0x48, 0xc2, 0x7c, 0x9e, 0x82, 0x5b, 0xfb, 0xe3, 0xc0, 0x73, 0xfb, 0x98, 0x93, 0x47, 0x94,
-	0x93, 0xe8, 0x2e, 0xf4, 0x1d, 0x58, 0x39, 0xa7, 0x9c, 0xd8, 0x58, 0xd7, 0xde, 0xe6, 0xec, 0xa9,
-	0x02, 0x6f, 0x2d, 0x0b, 0x54, 0x33, 0x86, 0xf7, 0xb4, 0x83, 0x5e, 0x09, 0x6f, 0xa1, 0x0f, 0x00,
-	0xc9, 0xd6, 0x66, 0x9f, 0x53, 0xee, 0xfa, 0x03, 0x3b, 0xa0, 0x4f, 0x49, 0xa8, 0xeb, 0xaf, 0x2c,
-	0x39, 0x8f, 0x24, 0xa3, 0x23, 0xe8, 0x53, 0x39, 0xac, 0xa1, 0x19, 0x09, 0x9d, 0xe4, 0xb0, 0x02,
-	0xb6, 0x20, 0x1f, 0xf7, 0x70, 0x5d, 0x74, 0xaf, 0x57, 0xb7, 0x13, 0x31, 0xf3, 0xaf, 0x29, 0xd8,
-	0x3a, 0x12, 0x0d, 0x60, 0xcf, 0x73, 0x89, 0xcf, 0x9b, 0x9c, 0xe3, 0xfe, 0x93, 0xd8, 0x2d, 0x6d,
-	0x58, 0xef, 0x53, 0xff, 0xb1, 0xe7, 0xf6, 0xa5, 0xde, 0xb2, 0xc2, 0xb5, 0x87, 0xee, 0xce, 0x9a,
-	0x2c, 0xcf, 0x91, 0x05, 0x6d, 0x95, 0x13, 0x62, 0x92, 0x22, 0x12, 0x5a, 0xd4, 0x36, 0xf5, 0x6d,
-	0xdd, 0x7e, 0x52, 0xd2, 0xa6, 0xa2, 0x22, 0x1e, 0xaa, 0x26, 0x74, 0x02, 0x1b, 0xbd, 0xcb, 0x67,
-	0xd8, 0xe7, 0xae, 0x4f, 0x12, 0xa5, 0x59, 0x49, 0xd7, 0xd3, 0x3b, 0x85, 0x07, 0x77, 0xe6, 0x78,
-	0x39, 0xc2, 0x58, 0x5f, 0x8b, 0x05, 0x27, 0x75, 0xbb, 0xc0, 0xf1, 0x99, 0x05, 0x8e, 0x7f, 0x1b,
-	0xfe, 0xfc, 0xb7, 0x01, 0xb9, 0xd8, 0x7d, 0x18, 0x6e, 0x3b, 0x51, 0xba, 0xd9, 0x32, 0x61, 0xe2,
-	0xf4, 0x57, 0x4e, 0x7c, 0x67, 0xd6, 0xa2, 0xb9, 0xf9, 0x79, 0xb8, 0x64, 0xdd, 0x72, 0xe6, 0x26,
-	0xae, 0x0f, 0x77, 0x3d, 0xe1, 0x3a, 0xbb, 0x2f, 0xe3, 0x67, 0x63, 0x19, 0xc0, 0xc9, 0x3d, 0x2a,
-	0x3f, 0xdf, 0x5f, 0x10, 0xac, 0x79, 0x41, 0x3f, 0x5c, 0xb2, 0xb6, 0xbc, 0x45, 0xcc, 0xd6, 0x32,
-	0xa4, 0xd9, 0x78, 0x64, 0x1e, 0x41, 0x31, 0x59, 0xa7, 0xa2, 0x2e, 0x13, 0xa6, 0xa5, 0xe7, 0xd7,
-	0x65, 0x7c, 0xc8, 0x8d, 0xaa, 0x36, 0x3f, 0x82, 0xcd, 0xf9, 0xfd, 0x04, 0x7d, 0x13, 0x56, 0x43,
-	0xfc, 0x54, 0x35, 0x23, 0xdb, 0x73, 0x19, 0xd7, 0x8d, 0xab, 0x18, 0xe2, 0xa7, 0x12, 0x21, 0x6e,
-	0x37, 0x7f, 0x0a, 0xb9, 0xa8, 0xe6, 0xd1, 0x47, 0x50, 0x8a, 0xea, 0x7d, 0x22, 0x30, 0x77, 0x1a,
-	0x69, 0x11, 0xab, 0x18, 0xe1, 0xe5, 0x59, 0x1f, 0x43, 0x56, 0x33, 0xd0, 0xd7, 0xa1, 0xe8, 0xe3,
-	0x11, 0x61, 0x01, 0xee, 0x13, 0x31, 0xd7, 0xd4, 0x1e, 0x50, 0x88, 0x69, 0x6d, 0x47, 0xac, 0x08,
-	0x62, 0xf6, 0x44, 0xbb, 0x8a, 0x78, 0x36, 0x7f, 0x01, 0x9b, 0xa2, 0xd3, 0x36, 0xcf, 0xb1, 0xeb,
-	0xe1, 0x9e, 0xeb, 0xb9, 0xfc, 0x52, 0x8f, 0xf8, 0x3b, 0x90, 0x0f, 0xa9, 0xb6, 0x46, 0x1b, 0x92,
-	0x0b, 0xa9, 0x32, 0x44, 0xdc, 0xd6, 0xa7, 0xde, 0x78, 0xe4, 0xc7, 0xad, 0x57, 0xf0, 0x0b, 0x8a,
-	0x26, 0x21, 0xe6, 0x7f, 0x53, 0x90, 0x11, 0xd1, 0x47, 0x1f, 0x42, 0x46, 0xd8, 0x20, 0x35, 0x5a,
-	0x9d, 0xb7, 0x7a, 0x74, 0xdd, 0x81, 0x4f, 0x9c, 0x63, 0x36, 0x38, 0xbb, 0x0c, 0x88, 0x25, 0xc1,
-	0x89, 0xc9, 0x9f, 0x9a, 0x9a, 0xfc, 0x1b, 0xb0, 0x1c, 0xd2, 0xb1, 0xef, 0xc8, 0x86, 0xb4, 0x6c,
-	0xa9, 0x17, 0x74, 0x00, 0xb9, 0x78, 0xa0, 0x67, 0xfe, 0xdf, 0x40, 0x5f, 0x13, 0x01, 0x15, 0xeb,
-	0x86, 0x26, 0x58, 0xd9, 0x9e, 0x9e, 0xeb, 0x6f, 0xa1, 0xa6, 0xd0, 0xfb, 0xb0, 0x3e, 0x69, 0x88,
-	0xd1, 0x9c, 0x53, 0xcb, 0x41, 0x39, 0x66, 0xe8, 0x41, 0x37, 0xdd, 0x3d, 0xd5, 0xae, 0x98, 0x95,
-	0x76, 0x4d, 0xba, 0x67, 0x5b, 0x2e, 0x8d, 0x77, 0x21, 0xcf, 0xdc, 0x81, 0x8f, 0xf9, 0x38, 0x24,
-	0x7a, 0x49, 0x98, 0x10, 0xcc, 0x3f, 0x1b, 0xb0, 0xa2, 0x96, 0x8e, 0x84, 0xdf, 0x8c, 0xf9, 0x7e,
-	0x4b, 0x2d, 0xf2, 0x5b, 0xfa, 0xcd, 0xfd, 0xd6, 0x04, 0x88, 0x95, 0x11, 0xa3, 0x6e, 0x41, 0xff,
-	0x53, 0x2a, 0x76, 0xdd, 0x81, 0xae, 0xa9, 0x84, 0x90, 0xf9, 0x0f, 0x03, 0xf2, 0x31, 0x1f, 0x35,
-	0xa1, 0x14, 0xe9, 0x65, 0x3f, 0xf6, 0xf0, 0x40, 0xe7, 0xce, 0xbd, 0x85, 0xca, 0x7d, 0xe2, 0xe1,
-	0x81, 0x55, 0xd0, 0xfa, 0x88, 0x97, 0xf9, 0x71, 0x48, 0x2d, 0x88, 0xc3, 0x54, 0xe0, 0xd3, 0x6f,
-	0x16, 0xf8, 0xa9, 0x10, 0x65, 0x6e, 0x86, 0xe8, 0x4f, 0x29, 0xc8, 0x75, 0xe4, 0x9a, 0x83, 0xbd,
-	0xaf, 0xa2, 0x22, 0xee, 0x40, 0x3e, 0xa0, 0x9e, 0xad, 0x38, 0x19, 0xc9, 0xc9, 0x05, 0xd4, 0xb3,
-	0x66, 0xc2, 0xbe, 0xfc, 0x96, 0xca, 0x65, 0xe5, 0x2d, 0x78, 0x2d, 0x7b, 0xd3, 0x6b, 0x21, 0x14,
-	0x95, 0x2b, 0x74, 0x4f, 0xba, 0x2f, 0x7c, 0x20, 0xbf, 0x63, 0x8c, 0xd9, 0xcf, 0x24, 0xa5, 0xb6,
-	0x42, 0x5a, 0x1a, 0x27, 0x24, 0xd4, 0x96, 0xae, 0x87, 0x4b, 0x65, 0x51, 0x5a, 0x5a, 0x1a, 0x67,
-	0xfe, 0xce, 0x00, 0x98, 0x2c, 0x07, 0xe2, 0x83, 0x81, 0x49, 0x15, 0xec, 0xa9, 0x9b, 0x6b, 0x8b,
-	0x82, 0xa6, 0xef, 0x2f, 0xb2, 0xa4, 0xde, 0x7b, 0x50, 0x9a, 0x24, 0x23, 0x23, 0x91, 0x32, 0xb5,
-	0x57, 0xec, 0x08, 0x5d, 0xc2, 0xad, 0xe2, 0x79, 0xe2, 0xcd, 0xfc, 0x8b, 0x01, 0x79, 0xa9, 0xd3,
-	0x31, 0xe1, 0x78, 0x2a, 0x86, 0xc6, 0x9b, 0xc7, 0xf0, 0x1e, 0x80, 0x3a, 0x86, 0xb9, 0xcf, 0x88,
-	0xce, 0xac, 0xbc, 0xa4, 0x74, 0xdd, 0x67, 0x04, 0x7d, 0x3f, 0x76, 0x78, 0xfa, 0xd5, 0x0e, 0xd7,
-	0x25, 0x1d, 0xb9, 0xfd, 0x36, 0x64, 0xfd, 0xf1, 0xc8, 0x16, 0xcb, 0xbb, 0x5a, 0x60, 0x56, 0xfc,
-	0xf1, 0xe8, 0xec, 0x82, 0x99, 0xbf, 0x86, 0xec, 0xd9, 0x85, 0xfc, 0x92, 0x55, 0x03, 0x86, 0xea,
-	0xcf, 0x27, 0x35, 0xae, 0x72, 0x82, 0x20, 0xbf, 0x16, 0xe6, 0xcc, 0x2a, 0xd4, 0x78, 0xcd, 0x6f,
-	0x64, 0xfd, 0x75, 0xfc, 0xde, 0xdf, 0x0c, 0x28, 0x24, 0xfa, 0x03, 0xfa, 0x2e, 0xdc, 0x6a, 0x1d,
-	0x9d, 0xee, 0x7d, 0x6a, 0xb7, 0xf7, 0xed, 0x4f, 0x8e, 0x9a, 0x0f, 0xed, 0xcf, 0x4e, 0x3e, 0x3d,
-	0x39, 0xfd, 0xf9, 0x49, 0x79, 0xa9, 0xba, 0x79, 0x75, 0x5d, 0x47, 0x09, 0xec, 0x67, 0xfe, 0x13,
-	0x9f, 0x3e, 0xf5, 0xd1, 0x2e, 0x6c, 0x4c, 0x8b, 0x34, 0x5b, 0xdd, 0x83, 0x93, 0xb3, 0xb2, 0x51,
-	0xbd, 0x75, 0x75, 0x5d, 0x5f, 0x4f, 0x48, 0x34, 0x7b, 0x8c, 0xf8, 0x7c, 0x56, 0x60, 0xef, 0xf4,
-	0xf8, 0xb8, 0x7d, 0x56, 0x4e, 0xcd, 0x08, 0xe8, 0x86, 0xfd, 0x2e, 0xac, 0x4f, 0x0b, 0x9c, 0xb4,
-	0x8f, 0xca, 0xe9, 0x2a, 0xba, 0xba, 0xae, 0xaf, 0x26, 0xd0, 0x27, 0xae, 0x57, 0xcd, 0xfd, 0xf6,
-	0xf7, 0xb5, 0xa5, 0x3f, 0xfe, 0xa1, 0x66, 0x08, 0xcb, 0x4a, 0x53, 0x3d, 0x02, 0x7d, 0x00, 0xb7,
-	0xbb, 0xed, 0x87, 0x27, 0x07, 0xfb, 0xf6, 0x71, 0xf7, 0xa1, 0x7d, 0xf6, 0xcb, 0xce, 0x41, 0xc2,
-	0xba, 0xb5, 0xab, 0xeb, 0x7a, 0x41, 0x9b, 0xb4, 0x08, 0xdd, 0xb1, 0x0e, 0x1e, 0x9d, 0x9e, 0x1d,
-	0x94, 0x0d, 0x85, 0xee, 0x84, 0x44, 0xec, 0x80, 0x12, 0x7d, 0x1f, 0xb6, 0xe6, 0xa0, 0x63, 0xc3,
-	0xd6, 0xaf, 0xae, 0xeb, 0xa5, 0x4e, 0x48, 0x54, 0xfd, 0x48, 0x89, 0x06, 0x54, 0x66, 0x25, 0x4e,
-	0x3b, 0xa7, 0xdd, 0xe6, 0x51, 0xb9, 0x5e, 0x2d, 0x5f, 0x5d, 0xd7, 0x8b, 0x51, 0x33, 0x14, 0xf8,
-	0x89, 0x65, 0xad, 0x9f, 0x7d, 0xfe, 0xa2, 0x66, 0x7c, 0xf1, 0xa2, 0x66, 0xfc, 0xeb, 0x45, 0xcd,
-	0x78, 0xfe, 0xb2, 0xb6, 0xf4, 0xc5, 0xcb, 0xda, 0xd2, 0xdf, 0x5f, 0xd6, 0x96, 0x7e, 0xf5, 0x83,
-	0x81, 0xcb, 0x87, 0xe3, 0x5e, 0xa3, 0x4f, 0x47, 0xbb, 0xc9, 0x7f, 0x6f, 0x26, 0x8f, 0xea, 0x5f,
-	0xa4, 0x9b, 0xff, 0xec, 0xf4, 0x56, 0x24, 0xfd, 0xc3, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0x76,
-	0x17, 0xbb, 0x3e, 0x9a, 0x12, 0x00, 0x00,
+	// 1868 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x4b, 0x73, 0x1b, 0x59,
+	0xf5, 0x77, 0xeb, 0xad, 0x23, 0xc9, 0x96, 0xef, 0x38, 0x8e, 0xec, 0x24, 0xb2, 0xfe, 0xfa, 0x03,
+	0xe3, 0x79, 0x20, 0x67, 0x32, 0x14, 0x8f, 0x2a, 0x98, 0x1a, 0xc9, 0xf6, 0xc4, 0x62, 0xfc, 0x10,
+	0x2d, 0x4d, 0x78, 0x6c, 0xba, 0xae, 0xd4, 0x37, 0x52, 0x93, 0x56, 0xdf, 0xae, 0xbe, 0x57, 0x8e,
+	0x9d, 0x25, 0x2b, 0xca, 0x1b, 0xb2, 0x62, 0xe7, 0x15, 0x2c, 0xd8, 0xf3, 0x0d, 0x58, 0xcd, 0x86,
+	0xaa, 0xd9, 0xc1, 0x86, 0x81, 0x4a, 0x28, 0x8a, 0x3d, 0x5f, 0x80, 0xba, 0x8f, 0x6e, 0xb5, 0x2c,
+	0x69, 0x02, 0xa9, 0x14, 0x1b, 0x55, 0xf7, 0x39, 0xbf, 0x73, 0xee, 0x79, 0xdf, 0xd3, 0x82, 0xbb,
+	0x9c, 0x78, 0x36, 0x09, 0xc6, 0x8e, 0xc7, 0xf7, 0xf8, 0xa5, 0x4f, 0x98, 0xfa, 0x6d, 0xf8, 0x01,
+	0xe5, 0x14, 0x95, 0xa7, 0xdc, 0x86, 0xa4, 0x6f, 0x6f, 0x0c, 0xe9, 0x90, 0x4a, 0xe6, 0x9e, 0x78,
+	0x52, 0xb8, 0xed, 0x9d, 0x21, 0xa5, 0x43, 0x97, 0xec, 0xc9, 0xb7, 0xfe, 0xe4, 0xf1, 0x1e, 0x77,
+	0xc6, 0x84, 0x71, 0x3c, 0xf6, 0x35, 0xe0, 0x5e, 0xec, 0x98, 0x41, 0x70, 0xe9, 0x73, 0x2a, 0xb0,
+	0xf4, 0xb1, 0x66, 0x57, 0x63, 0xec, 0x73, 0x12, 0x30, 0x87, 0x7a, 0x71, 0x3b, 0xb6, 0x6b, 0x73,
+	0x56, 0x9e, 0x63, 0xd7, 0xb1, 0x31, 0xa7, 0x81, 0x42, 0xd4, 0xbf, 0x07, 0xa5, 0x0e, 0x0e, 0x78,
+	0x97, 0xf0, 0x23, 0x82, 0x6d, 0x12, 0xa0, 0x0d, 0x48, 0x73, 0xca, 0xb1, 0x5b, 0x31, 0x6a, 0xc6,
+	0x6e, 0xc9, 0x54, 0x2f, 0x08, 0x41, 0x6a, 0x84, 0xd9, 0xa8, 0x92, 0xa8, 0x19, 0xbb, 0x45, 0x53,
+	0x3e, 0xd7, 0x47, 0x90, 0x12, 0xa2, 0x42, 0xc2, 0xf1, 0x6c, 0x72, 0x11, 0x4a, 0xc8, 0x17, 0x41,
+	0xed, 0x5f, 0x72, 0xc2, 0xb4, 0x88, 0x7a, 0x41, 0xdf, 0x82, 0xb4, 0xb4, 0xbf, 0x92, 0xac, 0x19,
+	0xbb, 0x85, 0x07, 0x95, 0x46, 0x2c, 0x50, 0xca, 0xbf, 0x46, 0x47, 0xf0, 0x5b, 0xa9, 0xcf, 0xbf,
+	0xdc, 0x59, 0x31, 0x15, 0xb8, 0xee, 0x42, 0xb6, 0xe5, 0xd2, 0xc1, 0x93, 0xf6, 0x41, 0x64, 0x88,
+	0x31, 0x35, 0x04, 0x9d, 0xc0, 0x9a, 0x8f, 0x03, 0x6e, 0x31, 0xc2, 0xad, 0x91, 0xf4, 0x42, 0x1e,
+	0x5a, 0x78, 0xb0, 0xd3, 0xb8, 0x99, 0x87, 0xc6, 0x8c, 0xb3, 0xfa, 0x94, 0x92, 0x1f, 0x27, 0xd6,
+	0xff, 0x91, 0x82, 0x8c, 0x0e, 0xc6, 0x0f, 0x20, 0xab, 0xc3, 0x2a, 0x0f, 0x2c, 0x3c, 0xb8, 0x17,
+	0xd7, 0xa8, 0x59, 0x8d, 0x7d, 0xea, 0x31, 0xe2, 0xb1, 0x09, 0xd3, 0xfa, 0x42, 0x19, 0xf4, 0x0d,
+	0xc8, 0x0d, 0x46, 0xd8, 0xf1, 0x2c, 0xc7, 0x96, 0x16, 0xe5, 0x5b, 0x85, 0x17, 0x5f, 0xee, 0x64,
+	0xf7, 0x05, 0xad, 0x7d, 0x60, 0x66, 0x25, 0xb3, 0x6d, 0xa3, 0x4d, 0xc8, 0x8c, 0x88, 0x33, 0x1c,
+	0x71, 0x19, 0x96, 0xa4, 0xa9, 0xdf, 0xd0, 0x77, 0x21, 0x25, 0x0a, 0xa2, 0x92, 0x92, 0x67, 0x6f,
+	0x37, 0x54, 0xb5, 0x34, 0xc2, 0x6a, 0x69, 0xf4, 0xc2, 0x6a, 0x69, 0xe5, 0xc4, 0xc1, 0xcf, 0xff,
+	0xba, 0x63, 0x98, 0x52, 0x02, 0xed, 0x43, 0xc9, 0xc5, 0x8c, 0x5b, 0x7d, 0x11, 0x36, 0x71, 0x7c,
+	0x5a, 0xaa, 0xd8, 0x9a, 0x0f, 0x88, 0x0e, 0xac, 0x36, 0xbd, 0x20, 0xa4, 0x14, 0xc9, 0x46, 0xbb,
+	0x50, 0x96, 0x4a, 0x06, 0x74, 0x3c, 0x76, 0xb8, 0x25, 0xe3, 0x9e, 0x91, 0x71, 0x5f, 0x15, 0xf4,
+	0x7d, 0x49, 0x3e, 0x12, 0x19, 0xb8, 0x03, 0x79, 0x1b, 0x73, 0xac, 0x20, 0x59, 0x09, 0xc9, 0x09,
+	0x82, 0x64, 0xbe, 0x0d, 0x6b, 0x51, 0xd5, 0x31, 0x05, 0xc9, 0x29, 0x2d, 0x53, 0xb2, 0x04, 0xde,
+	0x87, 0x0d, 0x8f, 0x5c, 0x70, 0xeb, 0x26, 0x3a, 0x2f, 0xd1, 0x48, 0xf0, 0x1e, 0xcd, 0x4a, 0x7c,
+	0x1d, 0x56, 0x07, 0x61, 0xf0, 0x15, 0x16, 0x24, 0xb6, 0x14, 0x51, 0x25, 0x6c, 0x0b, 0x72, 0xd8,
+	0xf7, 0x15, 0xa0, 0x20, 0x01, 0x59, 0xec, 0xfb, 0x92, 0xf5, 0x2e, 0xac, 0x4b, 0x1f, 0x03, 0xc2,
+	0x26, 0x2e, 0xd7, 0x4a, 0x8a, 0x12, 0xb3, 0x26, 0x18, 0xa6, 0xa2, 0x4b, 0xec, 0xff, 0x43, 0x89,
+	0x9c, 0x3b, 0x36, 0xf1, 0x06, 0x44, 0xe1, 0x4a, 0x12, 0x57, 0x0c, 0x89, 0x12, 0xf4, 0x0e, 0x94,
+	0xfd, 0x80, 0xfa, 0x94, 0x91, 0xc0, 0xc2, 0xb6, 0x1d, 0x10, 0xc6, 0x2a, 0xab, 0x4a, 0x5f, 0x48,
+	0x6f, 0x2a, 0x72, 0xfd, 0x17, 0x09, 0x48, 0x1d, 0x60, 0x8e, 0x51, 0x19, 0x92, 0xfc, 0x82, 0x55,
+	0x8c, 0x5a, 0x72, 0xb7, 0x68, 0x8a, 0x47, 0x34, 0x82, 0x8a, 0xe3, 0x71, 0x12, 0x8c, 0x89, 0xed,
+	0x60, 0x4e, 0x2c, 0xc6, 0xc5, 0x6f, 0x40, 0x29, 0x67, 0xba, 0xb6, 0x77, 0xe7, 0x53, 0xd9, 0x8e,
+	0x49, 0x74, 0x85, 0x80, 0x29, 0xf0, 0x3a, 0xb3, 0x9b, 0xce, 0x42, 0x2e, 0xfa, 0x18, 0x72, 0xa1,
+	0xfd, 0xba, 0x29, 0xab, 0xf3, 0x9a, 0x0f, 0x35, 0xe2, 0xd8, 0x61, 0x5c, 0xeb, 0x8b, 0xa4, 0xd0,
+	0xf7, 0x21, 0x37, 0x26, 0x8c, 0xe1, 0x21, 0x61, 0x51, 0xa5, 0xce, 0x69, 0x38, 0xd1, 0x88, 0x50,
+	0x3a, 0x94, 0xa8, 0x3f, 0x4f, 0xc0, 0xad, 0x83, 0x89, 0xef, 0x3a, 0x03, 0xcc, 0xc9, 0x23, 0xca,
+	0x49, 0x78, 0x16, 0xfa, 0x26, 0x64, 0xce, 0x29, 0x27, 0x16, 0xd6, 0xbd, 0xb7, 0x39, 0xaf, 0x55,
+	0xe0, 0xcd, 0xb4, 0x40, 0x35, 0x23, 0x78, 0xbf, 0x92, 0x78, 0x35, 0xbc, 0x85, 0xde, 0x07, 0x24,
+	0x47, 0x9b, 0x75, 0x4e, 0xb9, 0xe3, 0x0d, 0x2d, 0x9f, 0x3e, 0x25, 0x81, 0xee, 0xbf, 0xb2, 0xe4,
+	0x3c, 0x92, 0x8c, 0x8e, 0xa0, 0xcf, 0xd4, 0xb0, 0x86, 0xa6, 0x24, 0x74, 0x5a, 0xc3, 0x0a, 0xd8,
+	0x82, 0x7c, 0x34, 0xc3, 0x2b, 0xe9, 0xff, 0xa2, 0x6f, 0xa7, 0x62, 0xf5, 0x3f, 0x26, 0x60, 0xeb,
+	0x58, 0x0c, 0x80, 0x7d, 0xd7, 0x21, 0x1e, 0x6f, 0x72, 0x8e, 0x07, 0x4f, 0xa2, 0xb0, 0xb4, 0x61,
+	0x7d, 0x40, 0xbd, 0xc7, 0xae, 0x33, 0x90, 0x76, 0xcb, 0x0e, 0xd7, 0x11, 0xba, 0x3b, 0xef, 0xb2,
+	0xd4, 0x23, 0x1b, 0xda, 0x2c, 0xc7, 0xc4, 0x24, 0x45, 0x14, 0xb4, 0xe8, 0x6d, 0xea, 0x59, 0x7a,
+	0xfc, 0x24, 0xa4, 0x4f, 0x45, 0x45, 0x3c, 0x92, 0x34, 0x74, 0x0a, 0x1b, 0xfd, 0xcb, 0x67, 0xd8,
+	0xe3, 0x8e, 0x47, 0x62, 0xad, 0x59, 0x49, 0xd6, 0x92, 0xbb, 0x85, 0x07, 0x77, 0x16, 0x44, 0x39,
+	0xc4, 0x98, 0x6f, 0x45, 0x82, 0x11, 0x8d, 0x2d, 0x09, 0x7c, 0x6a, 0x49, 0xe0, 0xdf, 0x44, 0x3c,
+	0xff, 0x6e, 0x40, 0x2e, 0x0a, 0x1f, 0x86, 0xdb, 0x76, 0x58, 0x6e, 0x96, 0x2c, 0x98, 0xa8, 0xfc,
+	0x55, 0x10, 0xdf, 0x9e, 0xf7, 0x68, 0x61, 0x7d, 0x1e, 0xad, 0x98, 0xb7, 0xec, 0x85, 0x85, 0xeb,
+	0xc1, 0x5d, 0x57, 0x84, 0xce, 0x1a, 0xc8, 0xfc, 0x59, 0x58, 0x26, 0x70, 0x7a, 0x8e, 0xaa, 0xcf,
+	0xf7, 0x96, 0x24, 0x6b, 0x51, 0xd2, 0x8f, 0x56, 0xcc, 0x2d, 0x77, 0x19, 0xb3, 0x95, 0x86, 0x24,
+	0x9b, 0x8c, 0xeb, 0xc7, 0x50, 0x8c, 0xf7, 0xa9, 0xe8, 0xcb, 0x98, 0x6b, 0xc9, 0xc5, 0x7d, 0x19,
+	0x29, 0xb9, 0xd1, 0xd5, 0xf5, 0x8f, 0x60, 0x73, 0xf1, 0x3c, 0x41, 0x5f, 0x83, 0xd5, 0x00, 0x3f,
+	0x55, 0xc3, 0xc8, 0x72, 0x1d, 0xc6, 0xf5, 0xe0, 0x2a, 0x06, 0xf8, 0xa9, 0x44, 0x88, 0xd3, 0xeb,
+	0x3f, 0x84, 0x5c, 0xd8, 0xf3, 0xe8, 0x23, 0x28, 0x85, 0xfd, 0x3e, 0x15, 0x58, 0x78, 0x1b, 0x69,
+	0x11, 0xb3, 0x18, 0xe2, 0xa5, 0xae, 0x8f, 0x21, 0xab, 0x19, 0xe8, 0xff, 0xa0, 0xe8, 0xe1, 0x31,
+	0x61, 0x3e, 0x1e, 0x10, 0x71, 0xaf, 0xa9, 0x3d, 0xa0, 0x10, 0xd1, 0xda, 0xb6, 0x58, 0x11, 0xc4,
+	0xdd, 0x13, 0xee, 0x2a, 0xe2, 0xb9, 0xfe, 0x2b, 0x03, 0x32, 0x2d, 0x97, 0xf6, 0x7b, 0x17, 0x68,
+	0x15, 0x12, 0xfc, 0x42, 0xcb, 0x25, 0xf8, 0x05, 0xda, 0x83, 0x74, 0xdf, 0xa5, 0x7d, 0x31, 0x57,
+	0x5f, 0x61, 0x94, 0xc2, 0xa1, 0xf7, 0x60, 0x9d, 0x8d, 0x70, 0x40, 0xf4, 0xbd, 0x38, 0x26, 0x1e,
+	0x57, 0xdd, 0x50, 0x34, 0xcb, 0x92, 0xb1, 0x3f, 0xa5, 0xa3, 0xdb, 0x90, 0x15, 0x4a, 0x84, 0xa9,
+	0xa2, 0xc4, 0xf3, 0x66, 0x46, 0xbc, 0xb6, 0xed, 0xfa, 0x4f, 0x60, 0x53, 0xcc, 0xfe, 0xe6, 0x39,
+	0x76, 0x5c, 0xdc, 0x77, 0x5c, 0x87, 0x5f, 0xea, 0xa5, 0xe3, 0x0e, 0xe4, 0x03, 0xaa, 0xe3, 0xab,
+	0x43, 0x9b, 0x0b, 0xa8, 0x0a, 0xad, 0xf0, 0x7f, 0x40, 0xdd, 0xc9, 0xd8, 0x8b, 0x2e, 0x03, 0xc1,
+	0x2f, 0x28, 0x9a, 0x84, 0xd4, 0xff, 0x99, 0x80, 0x94, 0xa8, 0x47, 0xf4, 0x21, 0xa4, 0xc4, 0x61,
+	0xd2, 0xd7, 0xd5, 0x45, 0xcb, 0x50, 0xd7, 0x19, 0x7a, 0xc4, 0x3e, 0x61, 0xc3, 0xde, 0xa5, 0x4f,
+	0x4c, 0x09, 0x8e, 0xed, 0x22, 0x89, 0x99, 0x5d, 0x64, 0x03, 0xd2, 0x01, 0x9d, 0x78, 0xb6, 0x1c,
+	0x91, 0x69, 0x53, 0xbd, 0xa0, 0x43, 0xc8, 0x45, 0x2b, 0x46, 0xea, 0x55, 0x2b, 0xc6, 0x9a, 0x28,
+	0x31, 0xb1, 0x00, 0x69, 0x82, 0x99, 0xed, 0xeb, 0x4d, 0xe3, 0x0d, 0x74, 0xb9, 0x48, 0xcb, 0x74,
+	0x44, 0x87, 0x37, 0xaf, 0x5a, 0x57, 0xca, 0x11, 0x43, 0x5f, 0xbd, 0xb3, 0xf3, 0x5c, 0x6d, 0xaf,
+	0x59, 0xe9, 0xd7, 0x74, 0x9e, 0xb7, 0x05, 0x15, 0xdd, 0x85, 0x3c, 0x73, 0x86, 0x1e, 0xe6, 0x93,
+	0x80, 0xe8, 0xb5, 0x65, 0x4a, 0xa8, 0xff, 0xcb, 0x80, 0x8c, 0xca, 0x76, 0x2c, 0x6e, 0xc6, 0xe2,
+	0xb8, 0x25, 0x96, 0xc5, 0x2d, 0xf9, 0xfa, 0x71, 0x6b, 0x02, 0x44, 0xc6, 0x88, 0xcb, 0x77, 0xc9,
+	0x44, 0x56, 0x26, 0x76, 0x9d, 0xa1, 0xee, 0xf2, 0x98, 0x10, 0xfa, 0x00, 0x36, 0xf0, 0x70, 0x18,
+	0x90, 0x21, 0xe6, 0xc4, 0xb6, 0xa6, 0xbe, 0xa6, 0xa5, 0xaf, 0x6f, 0x4d, 0x79, 0xdd, 0xc8, 0xeb,
+	0xbf, 0x18, 0x90, 0x8f, 0x54, 0xa2, 0x26, 0x94, 0x42, 0x57, 0xac, 0xc7, 0x2e, 0x1e, 0xea, 0x72,
+	0xbb, 0xb7, 0xd4, 0x9f, 0x4f, 0x5c, 0x3c, 0x34, 0x0b, 0xda, 0x05, 0xf1, 0xb2, 0x38, 0x75, 0x89,
+	0x25, 0xa9, 0x9b, 0xa9, 0x95, 0xe4, 0xeb, 0xd5, 0xca, 0x4c, 0x56, 0x53, 0x37, 0xb3, 0xfa, 0xfb,
+	0x04, 0xe4, 0x3a, 0x72, 0x57, 0xc3, 0xee, 0xff, 0xa2, 0x89, 0xee, 0x40, 0xde, 0xa7, 0xae, 0xa5,
+	0x38, 0x29, 0xc9, 0xc9, 0xf9, 0xd4, 0x35, 0xe7, 0x2a, 0x25, 0xfd, 0x86, 0x3a, 0x2c, 0xf3, 0x06,
+	0xa2, 0x96, 0xbd, 0x19, 0xb5, 0x00, 0x8a, 0x2a, 0x14, 0x7a, 0x8c, 0xdd, 0x17, 0x31, 0x10, 0x4f,
+	0x15, 0x63, 0xfe, 0x5b, 0x4f, 0x99, 0xad, 0x90, 0x66, 0x66, 0x14, 0x49, 0xa8, 0x91, 0x5a, 0x49,
+	0x2c, 0x93, 0x50, 0x65, 0x67, 0x6a, 0x5c, 0xfd, 0xd7, 0x06, 0xc0, 0x74, 0xc3, 0x11, 0x5f, 0x3d,
+	0x4c, 0x9a, 0x60, 0xcd, 0x9c, 0x5c, 0x5d, 0x96, 0x34, 0x7d, 0x7e, 0x91, 0xc5, 0xed, 0xde, 0x87,
+	0xd2, 0xb4, 0x18, 0x19, 0x09, 0x8d, 0xa9, 0x7e, 0xc5, 0xa2, 0xd3, 0x25, 0xdc, 0x2c, 0x9e, 0xc7,
+	0xde, 0xea, 0x7f, 0x30, 0x20, 0x2f, 0x6d, 0x3a, 0x21, 0x1c, 0xcf, 0xe4, 0xd0, 0x78, 0xfd, 0x1c,
+	0xde, 0x03, 0x50, 0x6a, 0x98, 0xf3, 0x8c, 0xe8, 0xca, 0xca, 0x4b, 0x4a, 0xd7, 0x79, 0x46, 0xd0,
+	0xb7, 0xa3, 0x80, 0x27, 0xbf, 0x3a, 0xe0, 0x7a, 0x0a, 0x84, 0x61, 0xbf, 0x0d, 0x59, 0x6f, 0x32,
+	0xb6, 0xc4, 0x17, 0x88, 0xda, 0xc2, 0x32, 0xde, 0x64, 0xdc, 0xbb, 0x60, 0xf5, 0x9f, 0x43, 0xb6,
+	0x77, 0x21, 0x3f, 0xc7, 0xd5, 0x9d, 0x44, 0xf5, 0x37, 0xa0, 0xba, 0x3b, 0x73, 0x82, 0x20, 0x3f,
+	0x79, 0x16, 0x5c, 0xb8, 0xa8, 0xf1, 0x1f, 0x7e, 0xe8, 0xeb, 0x4f, 0xfc, 0x77, 0xff, 0x64, 0x40,
+	0x21, 0x36, 0x1f, 0xd0, 0x07, 0x70, 0xab, 0x75, 0x7c, 0xb6, 0xff, 0xa9, 0xd5, 0x3e, 0xb0, 0x3e,
+	0x39, 0x6e, 0x3e, 0xb4, 0x3e, 0x3b, 0xfd, 0xf4, 0xf4, 0xec, 0xc7, 0xa7, 0xe5, 0x95, 0xed, 0xcd,
+	0xab, 0xeb, 0x1a, 0x8a, 0x61, 0x3f, 0xf3, 0x9e, 0x78, 0xf4, 0xa9, 0x87, 0xf6, 0x60, 0x63, 0x56,
+	0xa4, 0xd9, 0xea, 0x1e, 0x9e, 0xf6, 0xca, 0xc6, 0xf6, 0xad, 0xab, 0xeb, 0xda, 0x7a, 0x4c, 0xa2,
+	0xd9, 0x67, 0xc4, 0xe3, 0xf3, 0x02, 0xfb, 0x67, 0x27, 0x27, 0xed, 0x5e, 0x39, 0x31, 0x27, 0xa0,
+	0x67, 0xfc, 0x3b, 0xb0, 0x3e, 0x2b, 0x70, 0xda, 0x3e, 0x2e, 0x27, 0xb7, 0xd1, 0xd5, 0x75, 0x6d,
+	0x35, 0x86, 0x3e, 0x75, 0xdc, 0xed, 0xdc, 0x2f, 0x7f, 0x53, 0x5d, 0xf9, 0xdd, 0x6f, 0xab, 0x86,
+	0xf0, 0xac, 0x34, 0x33, 0x23, 0xd0, 0xfb, 0x70, 0xbb, 0xdb, 0x7e, 0x78, 0x7a, 0x78, 0x60, 0x9d,
+	0x74, 0x1f, 0x5a, 0xbd, 0x9f, 0x76, 0x0e, 0x63, 0xde, 0xad, 0x5d, 0x5d, 0xd7, 0x0a, 0xda, 0xa5,
+	0x65, 0xe8, 0x8e, 0x79, 0xf8, 0xe8, 0xac, 0x77, 0x58, 0x36, 0x14, 0xba, 0x13, 0x10, 0xb1, 0xc8,
+	0x4a, 0xf4, 0x7d, 0xd8, 0x5a, 0x80, 0x8e, 0x1c, 0x5b, 0xbf, 0xba, 0xae, 0x95, 0x3a, 0x01, 0x51,
+	0xfd, 0x23, 0x25, 0x1a, 0x50, 0x99, 0x97, 0x38, 0xeb, 0x9c, 0x75, 0x9b, 0xc7, 0xe5, 0xda, 0x76,
+	0xf9, 0xea, 0xba, 0x56, 0x0c, 0x87, 0xa1, 0xc0, 0x4f, 0x3d, 0x6b, 0xfd, 0xe8, 0xf3, 0x17, 0x55,
+	0xe3, 0x8b, 0x17, 0x55, 0xe3, 0x6f, 0x2f, 0xaa, 0xc6, 0xf3, 0x97, 0xd5, 0x95, 0x2f, 0x5e, 0x56,
+	0x57, 0xfe, 0xfc, 0xb2, 0xba, 0xf2, 0xb3, 0xef, 0x0c, 0x1d, 0x3e, 0x9a, 0xf4, 0x1b, 0x03, 0x3a,
+	0xde, 0x8b, 0xff, 0x05, 0x35, 0x7d, 0x54, 0x7f, 0x85, 0xdd, 0xfc, 0x7b, 0xaa, 0x9f, 0x91, 0xf4,
+	0x0f, 0xff, 0x3d, 0x00, 0x72, 0x67, 0x49, 0x83, 0x5f, 0x13, 0x00, 0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -2341,6 +2428,66 @@ func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *BlobTx) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlobTx) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlobTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TypeId) > 0 {
+		i -= len(m.TypeId)
+		copy(dAtA[i:], m.TypeId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.TypeId)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ShareCommitments) > 0 {
+		for iNdEx := len(m.ShareCommitments) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ShareCommitments[iNdEx])
+			copy(dAtA[i:], m.ShareCommitments[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.ShareCommitments[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Blobs) > 0 {
+		for iNdEx := len(m.Blobs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Blobs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Tx) > 0 {
+		i -= len(m.Tx)
+		copy(dAtA[i:], m.Tx)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Tx)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *DataAvailabilityHeader) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2477,6 +2624,13 @@ func (m *Commit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.AggregatedSignature) > 0 {
+		i -= len(m.AggregatedSignature)
+		copy(dAtA[i:], m.AggregatedSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.AggregatedSignature)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Signatures) > 0 {
 		for iNdEx := len(m.Signatures) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -3117,6 +3271,35 @@ func (m *Message) Size() (n int) {
 	return n
 }
 
+func (m *BlobTx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Tx)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Blobs) > 0 {
+		for _, e := range m.Blobs {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.ShareCommitments) > 0 {
+		for _, b := range m.ShareCommitments {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	l = len(m.TypeId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *DataAvailabilityHeader) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3191,6 +3374,10 @@ func (m *Commit) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	l = len(m.AggregatedSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -5240,6 +5427,188 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *BlobTx) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlobTx: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlobTx: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tx = append(m.Tx[:0], dAtA[iNdEx:postIndex]...)
+			if m.Tx == nil {
+				m.Tx = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Blobs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Blobs = append(m.Blobs, &Message{})
+			if err := m.Blobs[len(m.Blobs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShareCommitments", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ShareCommitments = append(m.ShareCommitments, make([]byte, postIndex-iNdEx))
+			copy(m.ShareCommitments[len(m.ShareCommitments)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TypeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TypeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *DataAvailabilityHeader) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -5748,6 +6117,40 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregatedSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AggregatedSignature = append(m.AggregatedSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.AggregatedSignature == nil {
+				m.AggregatedSignature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -358,6 +358,27 @@ func (c *baseRPCClient) ConsensusParams(
 	return result, nil
 }
 
+func (c *baseRPCClient) ConsensusParamsHistory(
+	ctx context.Context,
+	fromHeight, toHeight int64,
+	page,
+	perPage *int,
+) (*coretypes.ResultConsensusParamsHistory, error) {
+	result := new(coretypes.ResultConsensusParamsHistory)
+	params := map[string]interface{}{"from_height": fromHeight, "to_height": toHeight}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	_, err := c.caller.Call(ctx, "consensus_params_history", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Health(ctx context.Context) (*coretypes.ResultHealth, error) {
 	result := new(coretypes.ResultHealth)
 	_, err := c.caller.Call(ctx, "health", map[string]interface{}{}, result)
@@ -367,6 +388,15 @@ func (c *baseRPCClient) Health(ctx context.Context) (*coretypes.ResultHealth, er
 	return result, nil
 }
 
+func (c *baseRPCClient) MismatchReport(ctx context.Context) (*coretypes.ResultMismatchReport, error) {
+	result := new(coretypes.ResultMismatchReport)
+	_, err := c.caller.Call(ctx, "mismatch_report", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BlockchainInfo(
 	ctx context.Context,
 	minHeight,
@@ -382,6 +412,22 @@ func (c *baseRPCClient) BlockchainInfo(
 	return result, nil
 }
 
+func (c *baseRPCClient) Blocks(
+	ctx context.Context,
+	minHeight,
+	maxHeight int64,
+	includeTxs bool,
+) (*coretypes.ResultBlocks, error) {
+	result := new(coretypes.ResultBlocks)
+	_, err := c.caller.Call(ctx, "blocks",
+		map[string]interface{}{"min_height": minHeight, "max_height": maxHeight, "include_txs": includeTxs},
+		result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
 	result := new(coretypes.ResultGenesis)
 	_, err := c.caller.Call(ctx, "genesis", map[string]interface{}{}, result)
@@ -441,6 +487,90 @@ func (c *baseRPCClient) BlockResults(
 	return result, nil
 }
 
+func (c *baseRPCClient) NamespacedData(
+	ctx context.Context,
+	height *int64,
+	namespaceID bytes.HexBytes,
+) (*coretypes.ResultNamespacedData, error) {
+	result := new(coretypes.ResultNamespacedData)
+	params := map[string]interface{}{"namespace_id": namespaceID}
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "namespaced_data", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) DataAvailabilityHeader(
+	ctx context.Context,
+	height *int64,
+) (*coretypes.ResultDataAvailabilityHeader, error) {
+	result := new(coretypes.ResultDataAvailabilityHeader)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "data_availability_header", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) Share(
+	ctx context.Context,
+	height *int64,
+	row, col uint64,
+) (*coretypes.ResultShare, error) {
+	result := new(coretypes.ResultShare)
+	params := map[string]interface{}{"row": row, "col": col}
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "share", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) Blob(
+	ctx context.Context,
+	height *int64,
+	namespaceID, commitment bytes.HexBytes,
+) (*coretypes.ResultBlob, error) {
+	result := new(coretypes.ResultBlob)
+	params := map[string]interface{}{"namespace": namespaceID, "commitment": commitment}
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "blob", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) ProveTxInclusion(
+	ctx context.Context,
+	height *int64,
+	txHash bytes.HexBytes,
+) (*coretypes.ResultTxInclusionProof, error) {
+	result := new(coretypes.ResultTxInclusionProof)
+	params := map[string]interface{}{"tx_hash": txHash}
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "prove_tx_inclusion", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Commit(ctx context.Context, height *int64) (*coretypes.ResultCommit, error) {
 	result := new(coretypes.ResultCommit)
 	params := make(map[string]interface{})
@@ -467,6 +597,19 @@ func (c *baseRPCClient) Tx(ctx context.Context, hash bytes.HexBytes, prove bool)
 	return result, nil
 }
 
+func (c *baseRPCClient) Txs(ctx context.Context, hashes []bytes.HexBytes, prove bool) (*coretypes.ResultTxs, error) {
+	result := new(coretypes.ResultTxs)
+	params := map[string]interface{}{
+		"hashes": hashes,
+		"prove":  prove,
+	}
+	_, err := c.caller.Call(ctx, "txs", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) TxSearch(
 	ctx context.Context,
 	query string,
@@ -550,6 +693,27 @@ func (c *baseRPCClient) Validators(
 	return result, nil
 }
 
+func (c *baseRPCClient) ValidatorSets(
+	ctx context.Context,
+	fromHeight, toHeight int64,
+	page,
+	perPage *int,
+) (*coretypes.ResultValidatorSets, error) {
+	result := new(coretypes.ResultValidatorSets)
+	params := map[string]interface{}{"from_height": fromHeight, "to_height": toHeight}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	_, err := c.caller.Call(ctx, "validator_sets", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BroadcastEvidence(
 	ctx context.Context,
 	ev types.Evidence,
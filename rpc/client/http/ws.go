@@ -56,6 +56,10 @@ type wsSubscription struct {
 	res   chan coretypes.ResultEvent
 	id    string
 	query string
+
+	// ID of the last event received, to resume the subscription from after
+	// a reconnection
+	lastEventID string
 }
 
 var _ rpcclient.EventsClient = (*wsEvents)(nil)
@@ -204,7 +208,15 @@ func (w *wsEvents) redoSubscriptionsAfter(d time.Duration) {
 		if q != "" && q == info.id {
 			continue
 		}
-		err := w.ws.Subscribe(ctx, q)
+		var err error
+		if info.lastEventID != "" {
+			err = w.ws.SubscribeFrom(ctx, q, info.lastEventID)
+			// if the events were not buffered anymore, the next attempt
+			// subscribes without resuming
+			info.lastEventID = ""
+		} else {
+			err = w.ws.Subscribe(ctx, q)
+		}
 		if err != nil {
 			w.Logger.Error("failed to resubscribe", "query", q, "err", err)
 			delete(w.subscriptions, q)
@@ -252,6 +264,9 @@ func (w *wsEvents) eventListener() {
 					out.id = result.SubscriptionID
 					w.subscriptions[result.SubscriptionID] = out
 				}
+				if result.EventID != "" {
+					out.lastEventID = result.EventID
+				}
 			}
 
 			w.mtx.RUnlock()
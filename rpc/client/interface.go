@@ -78,9 +78,16 @@ type SignClient interface {
 	Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error)
 	BlockByHash(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultBlock, error)
 	BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error)
+	NamespacedData(ctx context.Context, height *int64, namespaceID bytes.HexBytes) (*coretypes.ResultNamespacedData, error)
+	DataAvailabilityHeader(ctx context.Context, height *int64) (*coretypes.ResultDataAvailabilityHeader, error)
+	Share(ctx context.Context, height *int64, row, col uint64) (*coretypes.ResultShare, error)
+	Blob(ctx context.Context, height *int64, namespaceID, commitment bytes.HexBytes) (*coretypes.ResultBlob, error)
+	ProveTxInclusion(ctx context.Context, height *int64, txHash bytes.HexBytes) (*coretypes.ResultTxInclusionProof, error)
 	Commit(ctx context.Context, height *int64) (*coretypes.ResultCommit, error)
 	Validators(ctx context.Context, height *int64, page, perPage *int) (*coretypes.ResultValidators, error)
+	ValidatorSets(ctx context.Context, fromHeight, toHeight int64, page, perPage *int) (*coretypes.ResultValidatorSets, error)
 	Tx(ctx context.Context, hash bytes.HexBytes, prove bool) (*coretypes.ResultTx, error)
+	Txs(ctx context.Context, hashes []bytes.HexBytes, prove bool) (*coretypes.ResultTxs, error)
 
 	// TxSearch defines a method to search for a paginated set of transactions by
 	// DeliverTx event search criteria.
@@ -107,6 +114,7 @@ type HistoryClient interface {
 	Genesis(context.Context) (*coretypes.ResultGenesis, error)
 	GenesisChunked(context.Context, uint) (*coretypes.ResultGenesisChunk, error)
 	BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*coretypes.ResultBlockchainInfo, error)
+	Blocks(ctx context.Context, minHeight, maxHeight int64, includeTxs bool) (*coretypes.ResultBlocks, error)
 }
 
 // StatusClient provides access to general chain info.
@@ -121,7 +129,9 @@ type NetworkClient interface {
 	DumpConsensusState(context.Context) (*coretypes.ResultDumpConsensusState, error)
 	ConsensusState(context.Context) (*coretypes.ResultConsensusState, error)
 	ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error)
+	ConsensusParamsHistory(ctx context.Context, fromHeight, toHeight int64, page, perPage *int) (*coretypes.ResultConsensusParamsHistory, error)
 	Health(context.Context) (*coretypes.ResultHealth, error)
+	MismatchReport(context.Context) (*coretypes.ResultMismatchReport, error)
 }
 
 // EventsClient is reactive, you can subscribe to any message, given the proper
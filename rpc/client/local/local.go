@@ -132,8 +132,20 @@ func (c *Local) ConsensusParams(ctx context.Context, height *int64) (*coretypes.
 	return c.env.ConsensusParams(c.ctx, height)
 }
 
+func (c *Local) ConsensusParamsHistory(
+	ctx context.Context,
+	fromHeight, toHeight int64,
+	page, perPage *int,
+) (*coretypes.ResultConsensusParamsHistory, error) {
+	return c.env.ConsensusParamsHistory(c.ctx, fromHeight, toHeight, page, perPage)
+}
+
 func (c *Local) Health(ctx context.Context) (*coretypes.ResultHealth, error) {
-	return c.env.Health(c.ctx)
+	return c.env.Health(c.ctx, "")
+}
+
+func (c *Local) MismatchReport(ctx context.Context) (*coretypes.ResultMismatchReport, error) {
+	return c.env.MismatchReport(c.ctx)
 }
 
 func (c *Local) DialSeeds(ctx context.Context, seeds []string) (*coretypes.ResultDialSeeds, error) {
@@ -154,6 +166,10 @@ func (c *Local) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 	return c.env.BlockchainInfo(c.ctx, minHeight, maxHeight)
 }
 
+func (c *Local) Blocks(ctx context.Context, minHeight, maxHeight int64, includeTxs bool) (*coretypes.ResultBlocks, error) { //nolint:lll
+	return c.env.Blocks(c.ctx, minHeight, maxHeight, includeTxs)
+}
+
 func (c *Local) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
 	return c.env.Genesis(c.ctx)
 }
@@ -174,6 +190,41 @@ func (c *Local) BlockResults(ctx context.Context, height *int64) (*coretypes.Res
 	return c.env.BlockResults(c.ctx, height)
 }
 
+func (c *Local) NamespacedData(
+	ctx context.Context,
+	height *int64,
+	namespaceID bytes.HexBytes,
+) (*coretypes.ResultNamespacedData, error) {
+	return c.env.NamespacedData(c.ctx, height, namespaceID)
+}
+
+func (c *Local) DataAvailabilityHeader(
+	ctx context.Context,
+	height *int64,
+) (*coretypes.ResultDataAvailabilityHeader, error) {
+	return c.env.DataAvailabilityHeader(c.ctx, height)
+}
+
+func (c *Local) Share(ctx context.Context, height *int64, row, col uint64) (*coretypes.ResultShare, error) {
+	return c.env.Share(c.ctx, height, row, col)
+}
+
+func (c *Local) Blob(
+	ctx context.Context,
+	height *int64,
+	namespaceID, commitment bytes.HexBytes,
+) (*coretypes.ResultBlob, error) {
+	return c.env.Blob(c.ctx, height, namespaceID, commitment)
+}
+
+func (c *Local) ProveTxInclusion(
+	ctx context.Context,
+	height *int64,
+	txHash bytes.HexBytes,
+) (*coretypes.ResultTxInclusionProof, error) {
+	return c.env.ProveTxInclusion(c.ctx, height, txHash)
+}
+
 func (c *Local) Commit(ctx context.Context, height *int64) (*coretypes.ResultCommit, error) {
 	return c.env.Commit(c.ctx, height)
 }
@@ -182,10 +233,22 @@ func (c *Local) Validators(ctx context.Context, height *int64, page, perPage *in
 	return c.env.Validators(c.ctx, height, page, perPage)
 }
 
+func (c *Local) ValidatorSets(
+	ctx context.Context,
+	fromHeight, toHeight int64,
+	page, perPage *int,
+) (*coretypes.ResultValidatorSets, error) {
+	return c.env.ValidatorSets(c.ctx, fromHeight, toHeight, page, perPage)
+}
+
 func (c *Local) Tx(ctx context.Context, hash bytes.HexBytes, prove bool) (*coretypes.ResultTx, error) {
 	return c.env.Tx(c.ctx, hash, prove)
 }
 
+func (c *Local) Txs(ctx context.Context, hashes []bytes.HexBytes, prove bool) (*coretypes.ResultTxs, error) {
+	return c.env.Txs(c.ctx, hashes, prove)
+}
+
 func (c *Local) TxSearch(
 	_ context.Context,
 	queryString string,
@@ -194,7 +257,7 @@ func (c *Local) TxSearch(
 	perPage *int,
 	orderBy string,
 ) (*coretypes.ResultTxSearch, error) {
-	return c.env.TxSearch(c.ctx, queryString, prove, page, perPage, orderBy)
+	return c.env.TxSearch(c.ctx, queryString, prove, page, perPage, orderBy, "", nil, false)
 }
 
 func (c *Local) BlockSearch(
@@ -203,7 +266,7 @@ func (c *Local) BlockSearch(
 	page, perPage *int,
 	orderBy string,
 ) (*coretypes.ResultBlockSearch, error) {
-	return c.env.BlockSearch(c.ctx, queryString, page, perPage, orderBy)
+	return c.env.BlockSearch(c.ctx, queryString, page, perPage, orderBy, "", nil, false)
 }
 
 func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
@@ -127,8 +127,20 @@ func (c Client) ConsensusParams(ctx context.Context, height *int64) (*coretypes.
 	return c.env.ConsensusParams(&rpctypes.Context{}, height)
 }
 
+func (c Client) ConsensusParamsHistory(
+	ctx context.Context,
+	fromHeight, toHeight int64,
+	page, perPage *int,
+) (*coretypes.ResultConsensusParamsHistory, error) {
+	return c.env.ConsensusParamsHistory(&rpctypes.Context{}, fromHeight, toHeight, page, perPage)
+}
+
 func (c Client) Health(ctx context.Context) (*coretypes.ResultHealth, error) {
-	return c.env.Health(&rpctypes.Context{})
+	return c.env.Health(&rpctypes.Context{}, "")
+}
+
+func (c Client) MismatchReport(ctx context.Context) (*coretypes.ResultMismatchReport, error) {
+	return c.env.MismatchReport(&rpctypes.Context{})
 }
 
 func (c Client) DialSeeds(ctx context.Context, seeds []string) (*coretypes.ResultDialSeeds, error) {
@@ -149,6 +161,10 @@ func (c Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 	return c.env.BlockchainInfo(&rpctypes.Context{}, minHeight, maxHeight)
 }
 
+func (c Client) Blocks(ctx context.Context, minHeight, maxHeight int64, includeTxs bool) (*coretypes.ResultBlocks, error) { //nolint:lll
+	return c.env.Blocks(&rpctypes.Context{}, minHeight, maxHeight, includeTxs)
+}
+
 func (c Client) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
 	return c.env.Genesis(&rpctypes.Context{})
 }
@@ -161,6 +177,41 @@ func (c Client) BlockByHash(ctx context.Context, hash bytes.HexBytes) (*coretype
 	return c.env.BlockByHash(&rpctypes.Context{}, hash)
 }
 
+func (c Client) NamespacedData(
+	ctx context.Context,
+	height *int64,
+	namespaceID bytes.HexBytes,
+) (*coretypes.ResultNamespacedData, error) {
+	return c.env.NamespacedData(&rpctypes.Context{}, height, namespaceID)
+}
+
+func (c Client) DataAvailabilityHeader(
+	ctx context.Context,
+	height *int64,
+) (*coretypes.ResultDataAvailabilityHeader, error) {
+	return c.env.DataAvailabilityHeader(&rpctypes.Context{}, height)
+}
+
+func (c Client) Share(ctx context.Context, height *int64, row, col uint64) (*coretypes.ResultShare, error) {
+	return c.env.Share(&rpctypes.Context{}, height, row, col)
+}
+
+func (c Client) Blob(
+	ctx context.Context,
+	height *int64,
+	namespaceID, commitment bytes.HexBytes,
+) (*coretypes.ResultBlob, error) {
+	return c.env.Blob(&rpctypes.Context{}, height, namespaceID, commitment)
+}
+
+func (c Client) ProveTxInclusion(
+	ctx context.Context,
+	height *int64,
+	txHash bytes.HexBytes,
+) (*coretypes.ResultTxInclusionProof, error) {
+	return c.env.ProveTxInclusion(&rpctypes.Context{}, height, txHash)
+}
+
 func (c Client) Commit(ctx context.Context, height *int64) (*coretypes.ResultCommit, error) {
 	return c.env.Commit(&rpctypes.Context{}, height)
 }
@@ -169,6 +220,14 @@ func (c Client) Validators(ctx context.Context, height *int64, page, perPage *in
 	return c.env.Validators(&rpctypes.Context{}, height, page, perPage)
 }
 
+func (c Client) ValidatorSets(
+	ctx context.Context,
+	fromHeight, toHeight int64,
+	page, perPage *int,
+) (*coretypes.ResultValidatorSets, error) {
+	return c.env.ValidatorSets(&rpctypes.Context{}, fromHeight, toHeight, page, perPage)
+}
+
 func (c Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	return c.env.BroadcastEvidence(&rpctypes.Context{}, ev)
 }
@@ -89,6 +89,29 @@ func (_m *Client) ABCIQueryWithOptions(ctx context.Context, path string, data by
 	return r0, r1
 }
 
+// Blob provides a mock function with given fields: ctx, height, namespaceID, commitment
+func (_m *Client) Blob(ctx context.Context, height *int64, namespaceID bytes.HexBytes, commitment bytes.HexBytes) (*coretypes.ResultBlob, error) {
+	ret := _m.Called(ctx, height, namespaceID, commitment)
+
+	var r0 *coretypes.ResultBlob
+	if rf, ok := ret.Get(0).(func(context.Context, *int64, bytes.HexBytes, bytes.HexBytes) *coretypes.ResultBlob); ok {
+		r0 = rf(ctx, height, namespaceID, commitment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBlob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64, bytes.HexBytes, bytes.HexBytes) error); ok {
+		r1 = rf(ctx, height, namespaceID, commitment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Block provides a mock function with given fields: ctx, height
 func (_m *Client) Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error) {
 	ret := _m.Called(ctx, height)
@@ -204,6 +227,29 @@ func (_m *Client) BlockchainInfo(ctx context.Context, minHeight int64, maxHeight
 	return r0, r1
 }
 
+// Blocks provides a mock function with given fields: ctx, minHeight, maxHeight, includeTxs
+func (_m *Client) Blocks(ctx context.Context, minHeight int64, maxHeight int64, includeTxs bool) (*coretypes.ResultBlocks, error) {
+	ret := _m.Called(ctx, minHeight, maxHeight, includeTxs)
+
+	var r0 *coretypes.ResultBlocks
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, bool) *coretypes.ResultBlocks); ok {
+		r0 = rf(ctx, minHeight, maxHeight, includeTxs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBlocks)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, bool) error); ok {
+		r1 = rf(ctx, minHeight, maxHeight, includeTxs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BroadcastEvidence provides a mock function with given fields: _a0, _a1
 func (_m *Client) BroadcastEvidence(_a0 context.Context, _a1 types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	ret := _m.Called(_a0, _a1)
@@ -365,6 +411,29 @@ func (_m *Client) ConsensusParams(ctx context.Context, height *int64) (*coretype
 	return r0, r1
 }
 
+// ConsensusParamsHistory provides a mock function with given fields: ctx, fromHeight, toHeight, page, perPage
+func (_m *Client) ConsensusParamsHistory(ctx context.Context, fromHeight int64, toHeight int64, page *int, perPage *int) (*coretypes.ResultConsensusParamsHistory, error) {
+	ret := _m.Called(ctx, fromHeight, toHeight, page, perPage)
+
+	var r0 *coretypes.ResultConsensusParamsHistory
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *int, *int) *coretypes.ResultConsensusParamsHistory); ok {
+		r0 = rf(ctx, fromHeight, toHeight, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultConsensusParamsHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, *int, *int) error); ok {
+		r1 = rf(ctx, fromHeight, toHeight, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ConsensusState provides a mock function with given fields: _a0
 func (_m *Client) ConsensusState(_a0 context.Context) (*coretypes.ResultConsensusState, error) {
 	ret := _m.Called(_a0)
@@ -388,6 +457,29 @@ func (_m *Client) ConsensusState(_a0 context.Context) (*coretypes.ResultConsensu
 	return r0, r1
 }
 
+// DataAvailabilityHeader provides a mock function with given fields: ctx, height
+func (_m *Client) DataAvailabilityHeader(ctx context.Context, height *int64) (*coretypes.ResultDataAvailabilityHeader, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *coretypes.ResultDataAvailabilityHeader
+	if rf, ok := ret.Get(0).(func(context.Context, *int64) *coretypes.ResultDataAvailabilityHeader); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultDataAvailabilityHeader)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DumpConsensusState provides a mock function with given fields: _a0
 func (_m *Client) DumpConsensusState(_a0 context.Context) (*coretypes.ResultDumpConsensusState, error) {
 	ret := _m.Called(_a0)
@@ -494,6 +586,52 @@ func (_m *Client) IsRunning() bool {
 	return r0
 }
 
+// MismatchReport provides a mock function with given fields: _a0
+func (_m *Client) MismatchReport(_a0 context.Context) (*coretypes.ResultMismatchReport, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultMismatchReport
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultMismatchReport); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultMismatchReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NamespacedData provides a mock function with given fields: ctx, height, namespaceID
+func (_m *Client) NamespacedData(ctx context.Context, height *int64, namespaceID bytes.HexBytes) (*coretypes.ResultNamespacedData, error) {
+	ret := _m.Called(ctx, height, namespaceID)
+
+	var r0 *coretypes.ResultNamespacedData
+	if rf, ok := ret.Get(0).(func(context.Context, *int64, bytes.HexBytes) *coretypes.ResultNamespacedData); ok {
+		r0 = rf(ctx, height, namespaceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultNamespacedData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64, bytes.HexBytes) error); ok {
+		r1 = rf(ctx, height, namespaceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NetInfo provides a mock function with given fields: _a0
 func (_m *Client) NetInfo(_a0 context.Context) (*coretypes.ResultNetInfo, error) {
 	ret := _m.Called(_a0)
@@ -540,6 +678,52 @@ func (_m *Client) NumUnconfirmedTxs(_a0 context.Context) (*coretypes.ResultUncon
 	return r0, r1
 }
 
+// ProveTxInclusion provides a mock function with given fields: ctx, height, txHash
+func (_m *Client) ProveTxInclusion(ctx context.Context, height *int64, txHash bytes.HexBytes) (*coretypes.ResultTxInclusionProof, error) {
+	ret := _m.Called(ctx, height, txHash)
+
+	var r0 *coretypes.ResultTxInclusionProof
+	if rf, ok := ret.Get(0).(func(context.Context, *int64, bytes.HexBytes) *coretypes.ResultTxInclusionProof); ok {
+		r0 = rf(ctx, height, txHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxInclusionProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64, bytes.HexBytes) error); ok {
+		r1 = rf(ctx, height, txHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Share provides a mock function with given fields: ctx, height, row, col
+func (_m *Client) Share(ctx context.Context, height *int64, row uint64, col uint64) (*coretypes.ResultShare, error) {
+	ret := _m.Called(ctx, height, row, col)
+
+	var r0 *coretypes.ResultShare
+	if rf, ok := ret.Get(0).(func(context.Context, *int64, uint64, uint64) *coretypes.ResultShare); ok {
+		r0 = rf(ctx, height, row, col)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultShare)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64, uint64, uint64) error); ok {
+		r1 = rf(ctx, height, row, col)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Start provides a mock function with given fields:
 func (_m *Client) Start() error {
 	ret := _m.Called()
@@ -644,6 +828,29 @@ func (_m *Client) Tx(ctx context.Context, hash bytes.HexBytes, prove bool) (*cor
 	return r0, r1
 }
 
+// Txs provides a mock function with given fields: ctx, hashes, prove
+func (_m *Client) Txs(ctx context.Context, hashes []bytes.HexBytes, prove bool) (*coretypes.ResultTxs, error) {
+	ret := _m.Called(ctx, hashes, prove)
+
+	var r0 *coretypes.ResultTxs
+	if rf, ok := ret.Get(0).(func(context.Context, []bytes.HexBytes, bool) *coretypes.ResultTxs); ok {
+		r0 = rf(ctx, hashes, prove)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxs)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []bytes.HexBytes, bool) error); ok {
+		r1 = rf(ctx, hashes, prove)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TxSearch provides a mock function with given fields: ctx, query, prove, page, perPage, orderBy
 func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, page *int, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
 	ret := _m.Called(ctx, query, prove, page, perPage, orderBy)
@@ -740,3 +947,26 @@ func (_m *Client) Validators(ctx context.Context, height *int64, page *int, perP
 
 	return r0, r1
 }
+
+// ValidatorSets provides a mock function with given fields: ctx, fromHeight, toHeight, page, perPage
+func (_m *Client) ValidatorSets(ctx context.Context, fromHeight int64, toHeight int64, page *int, perPage *int) (*coretypes.ResultValidatorSets, error) {
+	ret := _m.Called(ctx, fromHeight, toHeight, page, perPage)
+
+	var r0 *coretypes.ResultValidatorSets
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *int, *int) *coretypes.ResultValidatorSets); ok {
+		r0 = rf(ctx, fromHeight, toHeight, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultValidatorSets)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, *int, *int) error); ok {
+		r1 = rf(ctx, fromHeight, toHeight, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -17,10 +17,13 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/mempool"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/da"
 	"github.com/tendermint/tendermint/rpc/client"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	rpclocal "github.com/tendermint/tendermint/rpc/client/local"
@@ -122,6 +125,11 @@ func TestStatus(t *testing.T) {
 		status, err := c.Status(ctx)
 		require.Nil(t, err, "%d: %+v", i, err)
 		assert.Equal(t, moniker, status.NodeInfo.Moniker)
+
+		// the node is the single validator, proposing every block
+		assert.Equal(t, status.ValidatorInfo.Address, status.ConsensusInfo.ProposerAddress)
+		assert.Equal(t, conf.Consensus.TimeoutCommit, status.ConsensusInfo.TimeoutCommit)
+		assert.Equal(t, conf.Consensus.Propose(status.ConsensusInfo.Round), status.ConsensusInfo.TimeoutPropose)
 	}
 }
 
@@ -419,6 +427,112 @@ func TestBlockchainInfo(t *testing.T) {
 	}
 }
 
+func TestBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n, conf := NodeSuite(t)
+
+	for i, c := range GetClients(t, n, conf) {
+		err := client.WaitForHeight(c, 3, nil)
+		require.NoError(t, err)
+
+		res, err := c.Blocks(ctx, 1, 3, true)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.True(t, res.LastHeight >= 3)
+		if assert.Len(t, res.Blocks, 3) {
+			for j, b := range res.Blocks {
+				assert.EqualValues(t, j+1, b.Block.Height)
+				assert.Equal(t, b.BlockID.Hash, b.Block.Hash())
+			}
+		}
+
+		block, err := c.Block(ctx, &res.Blocks[1].Block.Height)
+		require.NoError(t, err)
+		assert.Equal(t, block.Block.Txs, res.Blocks[1].Block.Txs)
+
+		res, err = c.Blocks(ctx, 2, 2, false)
+		require.Nil(t, err, "%d: %+v", i, err)
+		if assert.Len(t, res.Blocks, 1) {
+			assert.Empty(t, res.Blocks[0].Block.Txs)
+			assert.Equal(t, block.BlockID, res.Blocks[0].BlockID)
+		}
+
+		res, err = c.Blocks(ctx, 1, 10000, false)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.True(t, len(res.Blocks) <= 100)
+
+		res, err = c.Blocks(ctx, 10000, 1, false)
+		require.NotNil(t, err)
+		assert.Nil(t, res)
+	}
+}
+
+func TestNamespacedData(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n, conf := NodeSuite(t)
+
+	for i, c := range GetClients(t, n, conf) {
+		_, _, tx := MakeTxKV()
+		bres, err := c.BroadcastTxCommit(ctx, tx)
+		require.NoError(t, err, "%d", i)
+		require.True(t, bres.DeliverTx.IsOK())
+
+		res, err := c.NamespacedData(ctx, &bres.Height, []byte(consts.TxNamespaceID))
+		require.NoError(t, err, "%d: %+v", i, err)
+		assert.Equal(t, bres.Height, res.Height)
+		require.NoError(t, da.VerifyNamespaceProofs(&res.DataAvailabilityHeader, consts.TxNamespaceID, res.Proofs))
+		if assert.NotEmpty(t, res.Proofs) {
+			assert.NotEmpty(t, res.Proofs[0].Shares)
+		}
+
+		block, err := c.Block(ctx, &bres.Height)
+		require.NoError(t, err)
+		assert.EqualValues(t, block.Block.DataHash, res.DataAvailabilityHeader.Hash())
+
+		// the namespace ID must be 8 bytes
+		_, err = c.NamespacedData(ctx, &bres.Height, []byte{1})
+		require.Error(t, err)
+	}
+}
+
+func TestShare(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n, conf := NodeSuite(t)
+
+	for i, c := range GetClients(t, n, conf) {
+		_, _, tx := MakeTxKV()
+		bres, err := c.BroadcastTxCommit(ctx, tx)
+		require.NoError(t, err, "%d", i)
+		require.True(t, bres.DeliverTx.IsOK())
+
+		dres, err := c.DataAvailabilityHeader(ctx, &bres.Height)
+		require.NoError(t, err, "%d: %+v", i, err)
+		assert.Equal(t, bres.Height, dres.Height)
+		block, err := c.Block(ctx, &bres.Height)
+		require.NoError(t, err)
+		assert.EqualValues(t, block.Block.DataHash, dres.DataAvailabilityHeader.Hash())
+
+		// a share of the original data and one of the parity data
+		width := uint64(len(dres.DataAvailabilityHeader.RowsRoots))
+		for _, coords := range [][2]uint64{{0, 0}, {width - 1, 1}} {
+			res, err := c.Share(ctx, &bres.Height, coords[0], coords[1])
+			require.NoError(t, err, "%d: %+v", i, err)
+			assert.Equal(t, bres.Height, res.Height)
+			assert.Equal(t, coords[0], res.Proof.Row)
+			assert.Equal(t, coords[1], res.Proof.Col)
+			require.NoError(t, res.Proof.Verify(&dres.DataAvailabilityHeader))
+		}
+
+		_, err = c.Share(ctx, &bres.Height, width, 0)
+		require.Error(t, err)
+	}
+}
+
 func TestBroadcastTxSync(t *testing.T) {
 	n, conf := NodeSuite(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -469,6 +583,54 @@ func TestBroadcastTxCommit(t *testing.T) {
 	}
 }
 
+func TestBroadcastTxSubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, conf := NodeSuite(t)
+
+	ws, err := rpcclient.NewWS(conf.RPC.ListenAddress, "/websocket")
+	require.NoError(t, err)
+	require.NoError(t, ws.Start())
+	t.Cleanup(func() { _ = ws.Stop() })
+
+	_, _, tx := MakeTxKV()
+	require.NoError(t, ws.Call(ctx, "broadcast_tx_subscribe", map[string]interface{}{"tx": tx}))
+
+	// the CheckTx result and the commit event may come in any order
+	var (
+		bres  *coretypes.ResultBroadcastTxSubscribe
+		event *coretypes.ResultEvent
+	)
+	for bres == nil || event == nil {
+		select {
+		case resp := <-ws.ResponsesCh:
+			require.Nil(t, resp.Error)
+			result := new(coretypes.ResultEvent)
+			require.NoError(t, tmjson.Unmarshal(resp.Result, result))
+			if result.Query != "" {
+				event = result
+				continue
+			}
+			bres = new(coretypes.ResultBroadcastTxSubscribe)
+			require.NoError(t, tmjson.Unmarshal(resp.Result, bres))
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the tx to be committed")
+		}
+	}
+
+	require.True(t, bres.CheckTx.IsOK())
+	require.EqualValues(t, tx.Hash(), bres.Hash)
+	require.NotEmpty(t, bres.SubscriptionID)
+	require.Equal(t, bres.SubscriptionID, event.SubscriptionID)
+
+	data, ok := event.Data.(types.EventDataTx)
+	require.True(t, ok, "unexpected event %T", event.Data)
+	require.True(t, data.Result.IsOK())
+	require.EqualValues(t, tx, data.Tx)
+	require.Greater(t, data.Height, int64(0))
+}
+
 func TestUnconfirmedTxs(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -616,6 +778,48 @@ func TestTx(t *testing.T) {
 	}
 }
 
+func TestTxs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n, conf := NodeSuite(t)
+
+	c := getHTTPClient(t, conf)
+
+	// first we broadcast a tx
+	_, _, tx := MakeTxKV()
+	bres, err := c.BroadcastTxCommit(ctx, tx)
+	require.Nil(t, err, "%+v", err)
+
+	txHash := bres.Hash
+	anotherTxHash := types.Tx("a different tx").Hash()
+
+	for i, c := range GetClients(t, n, conf) {
+		t.Logf("client %d", i)
+
+		res, err := c.Txs(ctx, []tmbytes.HexBytes{txHash}, true)
+		require.NoError(t, err)
+		require.Len(t, res.Txs, 1)
+		assert.EqualValues(t, bres.Height, res.Txs[0].Height)
+		assert.EqualValues(t, tx, res.Txs[0].Tx)
+
+		// time to verify the multiproof
+		require.Len(t, res.Proofs, 1)
+		proof := res.Proofs[0].Proof
+		if assert.EqualValues(t, types.Txs{tx}, proof.Data) {
+			assert.NoError(t, proof.Proof.Verify(proof.RootHash, proof.Leaves()))
+		}
+
+		res, err = c.Txs(ctx, []tmbytes.HexBytes{txHash}, false)
+		require.NoError(t, err)
+		assert.Empty(t, res.Proofs)
+
+		_, err = c.Txs(ctx, []tmbytes.HexBytes{txHash, anotherTxHash}, true)
+		assert.Error(t, err)
+		_, err = c.Txs(ctx, nil, true)
+		assert.Error(t, err)
+	}
+}
+
 func TestTxSearchWithTimeout(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
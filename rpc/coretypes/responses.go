@@ -3,11 +3,13 @@ package coretypes
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/pkg/da"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
@@ -22,14 +24,125 @@ var (
 	// ErrInvalidRequest is used as a wrapper to cover more specific cases where the user has
 	// made an invalid request
 	ErrInvalidRequest = errors.New("invalid request")
+	// ErrDraining is returned once the RPC server is drained, see /unsafe_drain
+	ErrDraining = errors.New("draining")
+	// ErrUnhealthy is returned by the liveness and readiness probes of
+	// /health when the node is unhealthy
+	ErrUnhealthy = errors.New("unhealthy")
+	// ErrNotReady is returned by the readiness probe of /health when the
+	// node can't serve up to date data, e.g. while it catches up
+	ErrNotReady = errors.New("not ready")
+	// ErrTxNotFound is returned when a transaction is not in the tx index
+	ErrTxNotFound = errors.New("tx not found")
+	// ErrTimedOut is returned when a request gave up waiting, e.g. for a
+	// transaction to be committed
+	ErrTimedOut = errors.New("timed out")
 )
 
+// AppError is returned when the application rejects a request, with the code
+// and the codespace of its answer.
+type AppError struct {
+	Code      uint32
+	Codespace string
+	Log       string
+}
+
+func (e AppError) Error() string {
+	return fmt.Sprintf("application error (codespace: %q, code: %d): %s", e.Codespace, e.Code, e.Log)
+}
+
 // List of blocks
 type ResultBlockchainInfo struct {
 	LastHeight int64              `json:"last_height"`
 	BlockMetas []*types.BlockMeta `json:"block_metas"`
 }
 
+// Range of blocks
+type ResultBlocks struct {
+	LastHeight int64          `json:"last_height"`
+	Blocks     []*ResultBlock `json:"blocks"`
+}
+
+// Shares of a namespace in a block, with the proofs of their inclusion, or
+// absence, against the data availability header of the block
+type ResultNamespacedData struct {
+	Height                 int64                     `json:"height"`
+	NamespaceID            bytes.HexBytes            `json:"namespace_id"`
+	DataAvailabilityHeader da.DataAvailabilityHeader `json:"data_availability_header"`
+	Proofs                 []da.NamespaceProof       `json:"proofs"`
+
+	Verification *ResultVerification `json:"verification,omitempty"`
+}
+
+// Blob of a namespace in a block, with the proofs of the shares of the
+// namespace, among which it is laid out, against the data availability header
+// of the block
+type ResultBlob struct {
+	Height                 int64                     `json:"height"`
+	NamespaceID            bytes.HexBytes            `json:"namespace_id"`
+	Commitment             bytes.HexBytes            `json:"commitment"`
+	Data                   []byte                    `json:"data"`
+	DataAvailabilityHeader da.DataAvailabilityHeader `json:"data_availability_header"`
+	Proofs                 []da.NamespaceProof       `json:"proofs"`
+
+	Verification *ResultVerification `json:"verification,omitempty"`
+}
+
+// Data availability header of a block
+type ResultDataAvailabilityHeader struct {
+	Height                 int64                     `json:"height"`
+	DataAvailabilityHeader da.DataAvailabilityHeader `json:"data_availability_header"`
+
+	Verification *ResultVerification `json:"verification,omitempty"`
+}
+
+// Share of the extended data square of a block, with the proofs of its
+// inclusion against the data availability header of the block
+type ResultShare struct {
+	Height int64         `json:"height"`
+	Proof  da.ShareProof `json:"proof"`
+
+	Verification *ResultVerification `json:"verification,omitempty"`
+}
+
+// Proof of the inclusion of a tx in the data root of its block, through the
+// shares it is laid out in and the roots of their rows
+type ResultTxInclusionProof struct {
+	Height   int64               `json:"height"`
+	Hash     bytes.HexBytes      `json:"hash"`
+	Tx       types.Tx            `json:"tx"`
+	DataRoot bytes.HexBytes      `json:"data_root"`
+	Proof    da.TxInclusionProof `json:"proof"`
+
+	Verification *ResultVerification `json:"verification,omitempty"`
+}
+
+// Availability of the data of the blocks sampled by a light client running
+// data availability sampling
+type ResultDASStatus struct {
+	SamplesPerHeight int               `json:"samples_per_height"`
+	Heights          []DASHeightStatus `json:"heights"`
+	// BadEncodingProof is set if the sampling halted on it.
+	BadEncodingProof *da.BadEncodingProof `json:"bad_encoding_proof,omitempty"`
+}
+
+// Outcome of the sampling of the shares of a block
+type DASHeightStatus struct {
+	Height     int64  `json:"height"`
+	SquareSize uint64 `json:"square_size"`
+	// Samples is the number of shares sampled and verified.
+	Samples int `json:"samples"`
+	// Confidence is the probability that the data is available, given the
+	// samples verified: it is 0 as soon as a share can't be sampled.
+	Confidence float64 `json:"confidence"`
+	// Failed is set if the height couldn't be sampled, because of Error. The
+	// height is sampled again until it succeeds.
+	Failed bool   `json:"failed"`
+	Error  string `json:"error,omitempty"`
+	// Attempts is the number of times the height was sampled.
+	Attempts int `json:"attempts"`
+}
+
 // Genesis file
 type ResultGenesis struct {
 	Genesis *types.GenesisDoc `json:"genesis"`
@@ -49,6 +162,8 @@ type ResultGenesisChunk struct {
 type ResultBlock struct {
 	BlockID types.BlockID `json:"block_id"`
 	Block   *types.Block  `json:"block"`
+	// SquareSize is the width of the original data square of the block.
+	SquareSize uint64 `json:"square_size,omitempty"`
 }
 
 // Commit and Header
@@ -66,6 +181,17 @@ type ResultBlockResults struct {
 	EndBlockEvents        []abci.Event              `json:"end_block_events"`
 	ValidatorUpdates      []abci.ValidatorUpdate    `json:"validator_updates"`
 	ConsensusParamUpdates *tmproto.ConsensusParams  `json:"consensus_param_updates"`
+
+	Verification *ResultVerification `json:"verification,omitempty"`
+}
+
+// ResultVerification is set by the light client proxy on the results it has
+// verified against a trusted header. Nodes never set it.
+type ResultVerification struct {
+	Verified bool `json:"verified"`
+	// Height and hash of the trusted header the result was verified against.
+	TrustedHeight int64          `json:"trusted_height"`
+	TrustedHash   bytes.HexBytes `json:"trusted_hash"`
 }
 
 // NewResultCommit is a helper to initialize the ResultCommit with
@@ -94,6 +220,9 @@ type SyncInfo struct {
 	EarliestBlockHeight int64          `json:"earliest_block_height"`
 	EarliestBlockTime   time.Time      `json:"earliest_block_time"`
 
+	// ServesSnapshots is whether the node serves state sync snapshots.
+	ServesSnapshots bool `json:"serves_snapshots"`
+
 	MaxPeerBlockHeight int64 `json:"max_peer_block_height"`
 
 	CatchingUp bool `json:"catching_up"`
@@ -101,13 +230,14 @@ type SyncInfo struct {
 	TotalSyncedTime time.Duration `json:"total_synced_time"`
 	RemainingTime   time.Duration `json:"remaining_time"`
 
-	TotalSnapshots      int64         `json:"total_snapshots"`
-	ChunkProcessAvgTime time.Duration `json:"chunk_process_avg_time"`
-	SnapshotHeight      int64         `json:"snapshot_height"`
-	SnapshotChunksCount int64         `json:"snapshot_chunks_count"`
-	SnapshotChunksTotal int64         `json:"snapshot_chunks_total"`
-	BackFilledBlocks    int64         `json:"backfilled_blocks"`
-	BackFillBlocksTotal int64         `json:"backfill_blocks_total"`
+	TotalSnapshots        int64         `json:"total_snapshots"`
+	ChunkProcessAvgTime   time.Duration `json:"chunk_process_avg_time"`
+	SnapshotHeight        int64         `json:"snapshot_height"`
+	SnapshotChunksCount   int64         `json:"snapshot_chunks_count"`
+	SnapshotChunksTotal   int64         `json:"snapshot_chunks_total"`
+	SnapshotRemainingTime time.Duration `json:"snapshot_remaining_time"`
+	BackFilledBlocks      int64         `json:"backfilled_blocks"`
+	BackFillBlocksTotal   int64         `json:"backfill_blocks_total"`
 }
 
 // Info about the node's validator
@@ -117,11 +247,31 @@ type ValidatorInfo struct {
 	VotingPower int64          `json:"voting_power"`
 }
 
+// Info about the consensus of the node
+type ConsensusInfo struct {
+	Height int64  `json:"height"`
+	Round  int32  `json:"round"`
+	Step   string `json:"step"`
+
+	// the proposer of the round in progress, i.e. of the next block
+	ProposerAddress bytes.HexBytes `json:"proposer_address"`
+
+	// the timeouts of the round in progress
+	TimeoutPropose    time.Duration `json:"timeout_propose"`
+	TimeoutPrevote    time.Duration `json:"timeout_prevote"`
+	TimeoutPrecommit  time.Duration `json:"timeout_precommit"`
+	TimeoutCommit     time.Duration `json:"timeout_commit"`
+	SkipTimeoutCommit bool          `json:"skip_timeout_commit"`
+
+	TimeSinceLastBlock time.Duration `json:"time_since_last_block"`
+}
+
 // Node Status
 type ResultStatus struct {
 	NodeInfo      types.NodeInfo `json:"node_info"`
 	SyncInfo      SyncInfo       `json:"sync_info"`
 	ValidatorInfo ValidatorInfo  `json:"validator_info"`
+	ConsensusInfo ConsensusInfo  `json:"consensus_info"`
 }
 
 // Is TxIndexing enabled
@@ -172,6 +322,42 @@ type ResultConsensusParams struct {
 	ConsensusParams types.ConsensusParams `json:"consensus_params"`
 }
 
+// Validator sets of a page of heights
+type ResultValidatorSets struct {
+	ValidatorSets []ValidatorSetRange `json:"validator_sets"`
+	// Count of heights in this result
+	Count int `json:"count"`
+	// Total number of heights
+	Total int `json:"total"`
+
+	Verification *ResultVerification `json:"verification,omitempty"`
+}
+
+// Validator set of the consecutive heights FromHeight <= height <= ToHeight
+type ValidatorSetRange struct {
+	FromHeight int64              `json:"from_height"`
+	ToHeight   int64              `json:"to_height"`
+	Validators []*types.Validator `json:"validators"`
+}
+
+// Consensus params of a page of heights
+type ResultConsensusParamsHistory struct {
+	ConsensusParams []ConsensusParamsRange `json:"consensus_params"`
+	// Count of heights in this result
+	Count int `json:"count"`
+	// Total number of heights
+	Total int `json:"total"`
+
+	Verification *ResultVerification `json:"verification,omitempty"`
+}
+
+// Consensus params of the consecutive heights FromHeight <= height <= ToHeight
+type ConsensusParamsRange struct {
+	FromHeight      int64                 `json:"from_height"`
+	ToHeight        int64                 `json:"to_height"`
+	ConsensusParams types.ConsensusParams `json:"consensus_params"`
+}
+
 // Info about the consensus state.
 // UNSTABLE
 type ResultDumpConsensusState struct {
@@ -201,6 +387,14 @@ type ResultBroadcastTx struct {
 	Hash bytes.HexBytes `json:"hash"`
 }
 
+// CheckTx result, and the ID of the subscription delivering the DeliverTx
+// result once the tx is committed
+type ResultBroadcastTxSubscribe struct {
+	CheckTx        abci.ResponseCheckTx `json:"check_tx"`
+	Hash           bytes.HexBytes       `json:"hash"`
+	SubscriptionID string               `json:"subscription_id,omitempty"`
+}
+
 // CheckTx and DeliverTx results
 type ResultBroadcastTxCommit struct {
 	CheckTx   abci.ResponseCheckTx   `json:"check_tx"`
@@ -222,18 +416,34 @@ type ResultTx struct {
 	TxResult abci.ResponseDeliverTx `json:"tx_result"`
 	Tx       types.Tx               `json:"tx"`
 	Proof    types.TxProof          `json:"proof,omitempty"`
+
+	Verification *ResultVerification `json:"verification,omitempty"`
+}
+
+// Results of several txs, along with the multiproofs of the txs of each block
+type ResultTxs struct {
+	Txs    []*ResultTx     `json:"txs"`
+	Proofs []BlockTxsProof `json:"proofs,omitempty"`
+}
+
+// Multiproof of the txs of a block
+type BlockTxsProof struct {
+	Height int64              `json:"height"`
+	Proof  types.TxMultiProof `json:"proof"`
 }
 
 // Result of searching for txs
 type ResultTxSearch struct {
 	Txs        []*ResultTx `json:"txs"`
-	TotalCount int         `json:"total_count"`
+	TotalCount int         `json:"total_count"` // -1 if not counted
+	NextCursor string      `json:"next_cursor,omitempty"`
 }
 
 // ResultBlockSearch defines the RPC response type for a block search by events.
 type ResultBlockSearch struct {
 	Blocks     []*ResultBlock `json:"blocks"`
-	TotalCount int            `json:"total_count"`
+	TotalCount int            `json:"total_count"` // -1 if not counted
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 // List of mempool txs
@@ -252,6 +462,8 @@ type ResultABCIInfo struct {
 // Query abci msg
 type ResultABCIQuery struct {
 	Response abci.ResponseQuery `json:"response"`
+
+	Verification *ResultVerification `json:"verification,omitempty"`
 }
 
 // Result of broadcasting evidence
@@ -259,19 +471,91 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// Log level of the node
+type ResultUnsafeSetLogLevel struct {
+	LogLevel string `json:"log_level"`
+}
+
+// Debug bundle saved on the node host
+type ResultUnsafeDebugBundle struct {
+	File string `json:"file"`
+}
+
+// Settings applied by reloading the config file
+type ResultUnsafeReloadConfig struct {
+	LogLevel                 string        `json:"log_level"`
+	MempoolSize              int           `json:"mempool_size"`
+	MempoolMaxTxsBytes       int64         `json:"mempool_max_txs_bytes"`
+	TimeoutBroadcastTxCommit time.Duration `json:"timeout_broadcast_tx_commit"`
+	// the changed settings which were applied, and those which require a
+	// restart
+	Applied         []string `json:"applied"`
+	RequiresRestart []string `json:"requires_restart"`
+}
+
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
+	ResultUnsafeDrain        struct{}
 	ResultUnsafeProfile      struct{}
 	ResultSubscribe          struct{}
 	ResultUnsubscribe        struct{}
-	ResultHealth             struct{}
 )
 
+// HealthStatus is the state of the node, or of one of its subsystems.
+type HealthStatus string
+
+const (
+	// HealthStatusHealthy: working as expected.
+	HealthStatusHealthy HealthStatus = "healthy"
+	// HealthStatusDegraded: working, but needs attention.
+	HealthStatusDegraded HealthStatus = "degraded"
+	// HealthStatusUnhealthy: not working.
+	HealthStatusUnhealthy HealthStatus = "unhealthy"
+)
+
+// HealthCheck is the state of a subsystem of the node.
+type HealthCheck struct {
+	Name    string       `json:"name"`
+	Status  HealthStatus `json:"status"`
+	Message string       `json:"message,omitempty"`
+}
+
+// ResultHealth is the state of the node: the worst state of its subsystems,
+// whether it is live (not unhealthy), and whether it is ready to serve up to
+// date data (live, caught up and not draining).
+type ResultHealth struct {
+	Status HealthStatus  `json:"status"`
+	Live   bool          `json:"live"`
+	Ready  bool          `json:"ready"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+// ResultMismatchReport is the report of the last app hash mismatch of a
+// committed block, if Mismatch: the hash of the block Height, Expected, isn't
+// the one the application computed, Got, executing the block Height-1, whose
+// txs and results are reported.
+type ResultMismatchReport struct {
+	Mismatch bool           `json:"mismatch"`
+	Time     time.Time      `json:"time"`
+	Field    string         `json:"field"`
+	Height   int64          `json:"height"`
+	Expected bytes.HexBytes `json:"expected"`
+	Got      bytes.HexBytes `json:"got"`
+
+	TxHashes              []bytes.HexBytes          `json:"tx_hashes"`
+	TxsResults            []*abci.ResponseDeliverTx `json:"txs_results"`
+	BeginBlockEvents      []abci.Event              `json:"begin_block_events"`
+	EndBlockEvents        []abci.Event              `json:"end_block_events"`
+	ValidatorUpdates      []abci.ValidatorUpdate    `json:"validator_updates"`
+	ConsensusParamUpdates *tmproto.ConsensusParams  `json:"consensus_param_updates"`
+}
+
 // Event data from a subscription
 type ResultEvent struct {
 	SubscriptionID string            `json:"subscription_id"`
 	Query          string            `json:"query"`
+	EventID        string            `json:"event_id,omitempty"`
 	Data           types.TMEventData `json:"data"`
 	Events         []abci.Event      `json:"events"`
 }
@@ -15,13 +15,15 @@ type Config struct {
 	MaxOpenConnections int
 }
 
-// StartGRPCServer starts a new gRPC BroadcastAPIServer using the given
-// net.Listener.
+// StartGRPCServer starts a new gRPC server, serving the BroadcastAPI, the
+// LightAPI and the QueryAPI, using the given net.Listener.
 // NOTE: This function blocks - you may want to call it in a go-routine.
 // Deprecated: gRPC  in the RPC layer of Tendermint will be removed in 0.36
 func StartGRPCServer(env *core.Environment, ln net.Listener) error {
 	grpcServer := grpc.NewServer()
 	RegisterBroadcastAPIServer(grpcServer, &broadcastAPI{env: env})
+	RegisterLightAPIServer(grpcServer, &lightAPI{env: env})
+	RegisterQueryAPIServer(grpcServer, &queryAPI{env: env})
 	return grpcServer.Serve(ln)
 }
 
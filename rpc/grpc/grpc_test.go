@@ -2,12 +2,19 @@ package coregrpc_test
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	"github.com/tendermint/tendermint/config"
+	tmnet "github.com/tendermint/tendermint/libs/net"
 	"github.com/tendermint/tendermint/libs/service"
 	coregrpc "github.com/tendermint/tendermint/rpc/grpc"
 	rpctest "github.com/tendermint/tendermint/rpc/test"
@@ -43,3 +50,56 @@ func TestBroadcastTx(t *testing.T) {
 	require.EqualValues(t, 0, res.CheckTx.Code)
 	require.EqualValues(t, 0, res.DeliverTx.Code)
 }
+
+func TestQueryAPI(t *testing.T) {
+	_, conf := NodeSuite(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	conn, err := grpc.Dial(conf.RPC.GRPCListenAddress, grpc.WithInsecure(),
+		grpc.WithContextDialer(func(_ context.Context, addr string) (net.Conn, error) {
+			return tmnet.Connect(addr)
+		}))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := coregrpc.NewQueryAPIClient(conn)
+
+	sub, err := client.Subscribe(ctx, &coregrpc.RequestSubscribe{Query: "tm.event = 'Tx'"})
+	require.NoError(t, err)
+
+	tx := []byte("grpc=query")
+	bres, err := client.BroadcastTxSync(ctx, &coregrpc.RequestBroadcastTx{Tx: tx})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, bres.Code)
+
+	ev, err := sub.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "tm.event = 'Tx'", ev.Query)
+	require.NotEmpty(t, ev.Events)
+
+	status, err := client.Status(ctx, &coregrpc.RequestStatus{})
+	require.NoError(t, err)
+	require.Equal(t, conf.Moniker, status.NodeInfo.Moniker)
+	require.Positive(t, status.SyncInfo.LatestBlockHeight)
+
+	txRes, err := client.Tx(ctx, &coregrpc.RequestTx{Hash: bres.Hash, Prove: true})
+	require.NoError(t, err)
+	require.Equal(t, tx, txRes.Tx)
+	require.NotNil(t, txRes.Proof)
+
+	block, err := client.Block(ctx, &coregrpc.RequestBlock{Height: txRes.Height})
+	require.NoError(t, err)
+	require.Equal(t, txRes.Height, block.Block.Header.Height)
+	require.Len(t, block.Block.Data.Txs, 1)
+
+	results, err := client.BlockResults(ctx, &coregrpc.RequestBlockResults{Height: txRes.Height})
+	require.NoError(t, err)
+	require.Len(t, results.TxsResults, 1)
+
+	search, err := client.TxSearch(ctx, &coregrpc.RequestTxSearch{Query: fmt.Sprintf("tx.height = %d", txRes.Height)})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, search.TotalCount)
+
+	_, err = client.Block(ctx, &coregrpc.RequestBlock{Height: status.SyncInfo.LatestBlockHeight + 1000})
+	require.Equal(t, codes.OutOfRange, grpcstatus.Code(err))
+}
@@ -0,0 +1,188 @@
+package coregrpc
+
+import (
+	"context"
+	"errors"
+
+	gogotypes "github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tendermint/tendermint/internal/rpc/core"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// LightAPIServer is the server API for the LightAPI service, which serves
+// light blocks to light clients that can only reach a node over gRPC. Its
+// messages are the ones used by state sync to exchange light blocks with peers.
+//
+// A LightBlockRequest with a zero height asks for the latest light block.
+// Errors are reported with the following status codes:
+//
+//	OutOfRange        the height is above the latest height of the node
+//	NotFound          the light block has been pruned or is not available
+//	InvalidArgument   the request is malformed
+type LightAPIServer interface {
+	LightBlock(context.Context, *ssproto.LightBlockRequest) (*ssproto.LightBlockResponse, error)
+	ReportEvidence(context.Context, *tmproto.Evidence) (*gogotypes.Empty, error)
+}
+
+// LightAPIClient is the client API for the LightAPI service.
+type LightAPIClient interface {
+	LightBlock(ctx context.Context, in *ssproto.LightBlockRequest, opts ...grpc.CallOption) (*ssproto.LightBlockResponse, error)
+	ReportEvidence(ctx context.Context, in *tmproto.Evidence, opts ...grpc.CallOption) (*gogotypes.Empty, error)
+}
+
+type lightAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewLightAPIClient returns a LightAPI client using the given connection.
+func NewLightAPIClient(cc grpc.ClientConnInterface) LightAPIClient {
+	return &lightAPIClient{cc}
+}
+
+func (c *lightAPIClient) LightBlock(
+	ctx context.Context,
+	in *ssproto.LightBlockRequest,
+	opts ...grpc.CallOption,
+) (*ssproto.LightBlockResponse, error) {
+	out := new(ssproto.LightBlockResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.rpc.grpc.LightAPI/LightBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightAPIClient) ReportEvidence(
+	ctx context.Context,
+	in *tmproto.Evidence,
+	opts ...grpc.CallOption,
+) (*gogotypes.Empty, error) {
+	out := new(gogotypes.Empty)
+	err := c.cc.Invoke(ctx, "/tendermint.rpc.grpc.LightAPI/ReportEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RegisterLightAPIServer registers srv as the LightAPI service of s.
+func RegisterLightAPIServer(s *grpc.Server, srv LightAPIServer) {
+	s.RegisterService(&lightAPIServiceDesc, srv)
+}
+
+func lightAPILightBlockHandler(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	in := new(ssproto.LightBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightAPIServer).LightBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.rpc.grpc.LightAPI/LightBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightAPIServer).LightBlock(ctx, req.(*ssproto.LightBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func lightAPIReportEvidenceHandler(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	in := new(tmproto.Evidence)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightAPIServer).ReportEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.rpc.grpc.LightAPI/ReportEvidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightAPIServer).ReportEvidence(ctx, req.(*tmproto.Evidence))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var lightAPIServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.rpc.grpc.LightAPI",
+	HandlerType: (*LightAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "LightBlock",
+			Handler:    lightAPILightBlockHandler,
+		},
+		{
+			MethodName: "ReportEvidence",
+			Handler:    lightAPIReportEvidenceHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+type lightAPI struct {
+	env *core.Environment
+}
+
+func (lapi *lightAPI) LightBlock(ctx context.Context, req *ssproto.LightBlockRequest) (*ssproto.LightBlockResponse, error) {
+	var heightPtr *int64
+	if req.Height != 0 {
+		height := int64(req.Height)
+		heightPtr = &height
+	}
+
+	commit, err := lapi.env.Commit(&rpctypes.Context{}, heightPtr)
+	switch {
+	case errors.Is(err, coretypes.ErrHeightExceedsChainHead):
+		return nil, status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, coretypes.ErrHeightNotAvailable):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case commit == nil:
+		return nil, status.Errorf(codes.NotFound, "no commit at height %d", req.Height)
+	}
+
+	vals, err := lapi.env.StateStore.LoadValidators(commit.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	lb := &types.LightBlock{SignedHeader: &commit.SignedHeader, ValidatorSet: vals}
+	pb, err := lb.ToProto()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &ssproto.LightBlockResponse{LightBlock: pb}, nil
+}
+
+func (lapi *lightAPI) ReportEvidence(ctx context.Context, req *tmproto.Evidence) (*gogotypes.Empty, error) {
+	ev, err := types.EvidenceFromProto(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := lapi.env.BroadcastEvidence(&rpctypes.Context{}, ev); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &gogotypes.Empty{}, nil
+}
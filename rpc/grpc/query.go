@@ -0,0 +1,293 @@
+package coregrpc
+
+import (
+	"context"
+	"errors"
+
+	gogotypes "github.com/gogo/protobuf/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/internal/rpc/core"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// subscriptionBufferSize is the number of events buffered for a subscriber
+// before it is considered too slow and its subscription is canceled.
+const subscriptionBufferSize = 100
+
+// queryAPI implements QueryAPIServer, mirroring the query, broadcast and
+// subscription endpoints of the JSON-RPC API.
+//
+// Errors are reported with the following status codes:
+//
+//	OutOfRange        the height is above the latest height of the node
+//	NotFound          the block, results or transaction are not available
+//	InvalidArgument   the request is malformed or rejected
+//	ResourceExhausted the subscription limits are reached
+type queryAPI struct {
+	env *core.Environment
+}
+
+var _ QueryAPIServer = (*queryAPI)(nil)
+
+func (qapi *queryAPI) Status(ctx context.Context, req *RequestStatus) (*ResponseStatus, error) {
+	res, err := qapi.env.Status(&rpctypes.Context{})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &ResponseStatus{
+		NodeInfo: res.NodeInfo.ToProto(),
+		SyncInfo: &SyncInfo{
+			LatestBlockHash:     res.SyncInfo.LatestBlockHash,
+			LatestAppHash:       res.SyncInfo.LatestAppHash,
+			LatestBlockHeight:   res.SyncInfo.LatestBlockHeight,
+			EarliestBlockHash:   res.SyncInfo.EarliestBlockHash,
+			EarliestAppHash:     res.SyncInfo.EarliestAppHash,
+			EarliestBlockHeight: res.SyncInfo.EarliestBlockHeight,
+			MaxPeerBlockHeight:  res.SyncInfo.MaxPeerBlockHeight,
+			CatchingUp:          res.SyncInfo.CatchingUp,
+		},
+		ValidatorInfo: &ValidatorInfo{
+			Address:     res.ValidatorInfo.Address,
+			VotingPower: res.ValidatorInfo.VotingPower,
+		},
+	}
+	if resp.SyncInfo.LatestBlockTime, err = gogotypes.TimestampProto(res.SyncInfo.LatestBlockTime); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if resp.SyncInfo.EarliestBlockTime, err = gogotypes.TimestampProto(res.SyncInfo.EarliestBlockTime); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if res.ValidatorInfo.PubKey != nil {
+		pk, err := cryptoenc.PubKeyToProto(res.ValidatorInfo.PubKey)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.ValidatorInfo.PubKey = &pk
+	}
+	return resp, nil
+}
+
+func (qapi *queryAPI) Block(ctx context.Context, req *RequestBlock) (*ResponseBlock, error) {
+	res, err := qapi.env.Block(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, queryError(err)
+	}
+	if res.Block == nil {
+		return nil, status.Errorf(codes.NotFound, "no block at height %d", req.Height)
+	}
+
+	block, err := res.Block.ToProto()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	blockID := res.BlockID.ToProto()
+	return &ResponseBlock{BlockID: &blockID, Block: block}, nil
+}
+
+func (qapi *queryAPI) BlockResults(ctx context.Context, req *RequestBlockResults) (*ResponseBlockResults, error) {
+	res, err := qapi.env.BlockResults(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	updates := make([]*abci.ValidatorUpdate, len(res.ValidatorUpdates))
+	for i := range res.ValidatorUpdates {
+		updates[i] = &res.ValidatorUpdates[i]
+	}
+	return &ResponseBlockResults{
+		Height:                res.Height,
+		TxsResults:            res.TxsResults,
+		TotalGasUsed:          res.TotalGasUsed,
+		BeginBlockEvents:      eventPtrs(res.BeginBlockEvents),
+		EndBlockEvents:        eventPtrs(res.EndBlockEvents),
+		ValidatorUpdates:      updates,
+		ConsensusParamUpdates: res.ConsensusParamUpdates,
+	}, nil
+}
+
+func (qapi *queryAPI) Tx(ctx context.Context, req *RequestTx) (*ResponseTx, error) {
+	res, err := qapi.env.Tx(&rpctypes.Context{}, req.Hash, req.Prove)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return txResponse(res, req.Prove), nil
+}
+
+func (qapi *queryAPI) TxSearch(ctx context.Context, req *RequestTxSearch) (*ResponseTxSearch, error) {
+	var page, perPage, limit *int
+	if req.Page != 0 {
+		p := int(req.Page)
+		page = &p
+	}
+	if req.PerPage != 0 {
+		pp := int(req.PerPage)
+		perPage = &pp
+	}
+	if req.Limit != 0 {
+		l := int(req.Limit)
+		limit = &l
+	}
+
+	res, err := qapi.env.TxSearch(&rpctypes.Context{}, req.Query, req.Prove, page, perPage, req.OrderBy,
+		req.Cursor, limit, req.CountTotal)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	txs := make([]*ResponseTx, len(res.Txs))
+	for i, tx := range res.Txs {
+		txs[i] = txResponse(tx, req.Prove)
+	}
+	return &ResponseTxSearch{Txs: txs, TotalCount: int32(res.TotalCount), NextCursor: res.NextCursor}, nil
+}
+
+func (qapi *queryAPI) BroadcastTxSync(ctx context.Context, req *RequestBroadcastTx) (*ResponseBroadcastTxSync, error) {
+	res, err := qapi.env.BroadcastTxSync(&rpctypes.Context{}, req.Tx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return broadcastResponse(res), nil
+}
+
+func (qapi *queryAPI) BroadcastTxAsync(ctx context.Context, req *RequestBroadcastTx) (*ResponseBroadcastTxSync, error) {
+	res, err := qapi.env.BroadcastTxAsync(&rpctypes.Context{}, req.Tx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return broadcastResponse(res), nil
+}
+
+// Subscribe streams the events matching the query until the client cancels
+// the call or the subscription is canceled, e.g. because the client is too
+// slow to keep up with the events.
+func (qapi *queryAPI) Subscribe(req *RequestSubscribe, stream QueryAPI_SubscribeServer) error {
+	env := qapi.env
+	ctx := stream.Context()
+
+	// Subscribers are identified by their remote address, like websocket
+	// subscribers of the JSON-RPC API.
+	subscriber := "grpc"
+	if p, ok := peer.FromContext(ctx); ok {
+		subscriber = p.Addr.String()
+	}
+
+	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
+		return status.Errorf(codes.ResourceExhausted, "max_subscription_clients %d reached",
+			env.Config.MaxSubscriptionClients)
+	} else if env.EventBus.NumClientSubscriptions(subscriber) >= env.Config.MaxSubscriptionsPerClient {
+		return status.Errorf(codes.ResourceExhausted, "max_subscriptions_per_client %d reached",
+			env.Config.MaxSubscriptionsPerClient)
+	}
+
+	q, err := tmquery.New(req.Query)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to parse query: %v", err)
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, core.SubscribeTimeout)
+	defer cancel()
+	sub, err := env.EventBus.Subscribe(subCtx, subscriber, q, subscriptionBufferSize)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	defer func() {
+		args := tmpubsub.UnsubscribeArgs{Subscriber: subscriber, Query: q}
+		if err := env.EventBus.Unsubscribe(context.Background(), args); err != nil &&
+			!errors.Is(err, tmpubsub.ErrSubscriptionNotFound) {
+			env.Logger.Error("failed to unsubscribe", "subscriber", subscriber, "query", req.Query, "err", err)
+		}
+	}()
+
+	env.Logger.Info("Subscribe to query", "remote", subscriber, "query", req.Query)
+
+	for {
+		select {
+		case msg := <-sub.Out():
+			data, err := tmjson.Marshal(msg.Data())
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			if err := stream.Send(&ResponseEvent{
+				Query:  req.Query,
+				Data:   data,
+				Events: eventPtrs(msg.Events()),
+			}); err != nil {
+				return err
+			}
+
+		case <-sub.Canceled():
+			reason := "Tendermint exited"
+			if sub.Err() != nil {
+				reason = sub.Err().Error()
+			}
+			return status.Errorf(codes.Aborted, "subscription was canceled (reason: %s)", reason)
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// queryError converts an error of the block queries into a gRPC error.
+func queryError(err error) error {
+	switch {
+	case errors.Is(err, coretypes.ErrHeightExceedsChainHead):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, coretypes.ErrHeightNotAvailable):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+// heightPtr returns nil for a zero height, which means the latest height.
+func heightPtr(height int64) *int64 {
+	if height == 0 {
+		return nil
+	}
+	return &height
+}
+
+func eventPtrs(events []abci.Event) []*abci.Event {
+	ptrs := make([]*abci.Event, len(events))
+	for i := range events {
+		ptrs[i] = &events[i]
+	}
+	return ptrs
+}
+
+func txResponse(res *coretypes.ResultTx, prove bool) *ResponseTx {
+	resp := &ResponseTx{
+		Hash:     res.Hash,
+		Height:   res.Height,
+		Index:    res.Index,
+		TxResult: &res.TxResult,
+		Tx:       res.Tx,
+	}
+	if prove {
+		proof := res.Proof.ToProto()
+		resp.Proof = &proof
+	}
+	return resp
+}
+
+func broadcastResponse(res *coretypes.ResultBroadcastTx) *ResponseBroadcastTxSync {
+	return &ResponseBroadcastTxSync{
+		Code:         res.Code,
+		Data:         res.Data,
+		Log:          res.Log,
+		Codespace:    res.Codespace,
+		MempoolError: res.MempoolError,
+		Hash:         res.Hash,
+	}
+}
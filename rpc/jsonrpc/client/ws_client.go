@@ -509,6 +509,14 @@ func (c *WSClient) Subscribe(ctx context.Context, query string) error {
 	return c.Call(ctx, "subscribe", params)
 }
 
+// SubscribeFrom subscribes to a query, first receiving the events which
+// followed the one of ID lastEventID. Note the server must have a "subscribe"
+// route defined, accepting a "last_event_id" param.
+func (c *WSClient) SubscribeFrom(ctx context.Context, query, lastEventID string) error {
+	params := map[string]interface{}{"query": query, "last_event_id": lastEventID}
+	return c.Call(ctx, "subscribe", params)
+}
+
 // Unsubscribe from a query. Note the server must have a "unsubscribe" route
 // defined.
 func (c *WSClient) Unsubscribe(ctx context.Context, query string) error {
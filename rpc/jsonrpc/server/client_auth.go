@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/tendermint/tendermint/libs/log"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// AnyClientCert is the key of the ClientMethods of the clients whose
+// certificate is not listed, and of the clients without certificate.
+const AnyClientCert = "*"
+
+// ClientMethods restricts the methods the clients can call by the common name
+// of the TLS certificate they authenticated with. The clients whose common
+// name is not listed are restricted by the AnyClientCert entry, if any.
+type ClientMethods map[string][]string
+
+// allowed returns the methods the client authenticated with the given common
+// name can call, nil if it is not restricted.
+func (cm ClientMethods) allowed(commonName string) map[string]bool {
+	methods, ok := cm[commonName]
+	if !ok || commonName == "" {
+		if methods, ok = cm[AnyClientCert]; !ok {
+			return nil
+		}
+	}
+	allowed := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		allowed[method] = true
+	}
+	return allowed
+}
+
+// clientAuth is the restriction of the client of a websocket connection,
+// passed to the WebsocketHandler in the context of its request.
+type clientAuth struct {
+	commonName string
+	allowed    map[string]bool
+}
+
+type clientAuthKey struct{}
+
+// ClientAuthHandler wraps handler, rejecting the requests calling a method
+// their client is not allowed to with the status 403 Forbidden. The client is
+// identified by the common name of the certificate it authenticated with over
+// TLS. The websocket connections are restricted to the allowed methods.
+func ClientAuthHandler(handler http.Handler, cm ClientMethods, logger log.Logger) http.Handler {
+	if len(cm) == 0 {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		commonName := clientCommonName(r)
+		allowed := cm.allowed(commonName)
+		if allowed == nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		if r.URL.Path == "/websocket" {
+			auth := clientAuth{commonName: commonName, allowed: allowed}
+			handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientAuthKey{}, auth)))
+			return
+		}
+
+		methods, err := requestMethods(r)
+		if err != nil {
+			// let the handler report the error
+			handler.ServeHTTP(w, r)
+			return
+		}
+		for _, method := range methods {
+			if !allowed[method] {
+				writeForbidden(w, methodForbiddenError(method, commonName), logger)
+				return
+			}
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// restrictMethods restricts the requests sent over the websocket connection
+// to the methods its client is allowed to call. All the methods are allowed by
+// default.
+func restrictMethods(auth clientAuth) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.clientAuth = &auth
+	}
+}
+
+// LoadCertPool reads the PEM encoded certificates of file, e.g. the
+// certificate authorities of the clients.
+func LoadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in %s", file)
+	}
+	return pool, nil
+}
+
+// clientCommonName returns the common name of the verified certificate of the
+// client of r, empty if it has none.
+func clientCommonName(r *http.Request) string {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return r.TLS.VerifiedChains[0][0].Subject.CommonName
+}
+
+// methodForbiddenError returns the data of the error response to a request
+// calling a method its client is not allowed to.
+func methodForbiddenError(method, commonName string) string {
+	if commonName == "" {
+		return fmt.Sprintf("method %s is not allowed without a client certificate", method)
+	}
+	return fmt.Sprintf("method %s is not allowed for the client certificate %q", method, commonName)
+}
+
+func writeForbidden(w http.ResponseWriter, data string, logger log.Logger) {
+	res := rpctypes.NewRPCErrorResponse(rpctypes.JSONRPCIntID(-1), rpctypes.CodeServerError, "Server error", data)
+	jsonBytes, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		logger.Error("failed to marshal response", "res", res, "err", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	if _, err := w.Write(jsonBytes); err != nil {
+		logger.Error("failed to write response", "res", res, "err", err)
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestClientAuthHandler(t *testing.T) {
+	handler := ClientAuthHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), ClientMethods{
+		"relayer":     {"broadcast_tx_sync", "broadcast_tx_async"},
+		"operator":    nil,
+		AnyClientCert: {"health"},
+	}, log.TestingLogger())
+
+	request := func(commonName string, req *http.Request) int {
+		if commonName != "" {
+			cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		resp := w.Result()
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, request("relayer", httptest.NewRequest("GET", "/broadcast_tx_sync?tx=0x01", nil)))
+	assert.Equal(t, http.StatusForbidden, request("relayer", httptest.NewRequest("GET", "/status", nil)))
+	// all the requests of a batch must be allowed
+	assert.Equal(t, http.StatusOK, request("relayer", httptest.NewRequest("POST", "/",
+		strings.NewReader(`[{"jsonrpc":"2.0","id":1,"method":"broadcast_tx_sync"},`+
+			`{"jsonrpc":"2.0","id":2,"method":"broadcast_tx_async"}]`))))
+	assert.Equal(t, http.StatusForbidden, request("relayer", httptest.NewRequest("POST", "/",
+		strings.NewReader(`[{"jsonrpc":"2.0","id":1,"method":"broadcast_tx_sync"},`+
+			`{"jsonrpc":"2.0","id":2,"method":"unsafe_flush_mempool"}]`))))
+	// operator is listed without methods, and can't call any
+	assert.Equal(t, http.StatusForbidden, request("operator", httptest.NewRequest("GET", "/health", nil)))
+
+	// the other clients are restricted by the wildcard entry
+	assert.Equal(t, http.StatusOK, request("other", httptest.NewRequest("GET", "/health", nil)))
+	assert.Equal(t, http.StatusForbidden, request("other", httptest.NewRequest("GET", "/status", nil)))
+	assert.Equal(t, http.StatusOK, request("", httptest.NewRequest("GET", "/health", nil)))
+	assert.Equal(t, http.StatusForbidden, request("", httptest.NewRequest("POST", "/",
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"status"}`))))
+
+	// the clients not listed are served without a wildcard entry
+	unrestricted := ClientAuthHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), ClientMethods{"relayer": {"health"}}, log.TestingLogger())
+	w := httptest.NewRecorder()
+	unrestricted.ServeHTTP(w, httptest.NewRequest("GET", "/status", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
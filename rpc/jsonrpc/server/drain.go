@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Drainer lets an RPC server be drained before it is stopped, e.g. during a
+// rolling restart: once Drain is called, the server reports it is draining so
+// that load balancers stop sending it requests, and Wait returns once the
+// requests in flight are done.
+//
+// A nil Drainer is valid, and never drains.
+type Drainer struct {
+	draining int32 // atomic
+
+	mtx      sync.Mutex
+	inFlight int
+	idle     chan struct{} // closed when inFlight is 0
+}
+
+// NewDrainer returns a Drainer which is not draining.
+func NewDrainer() *Drainer {
+	idle := make(chan struct{})
+	close(idle)
+	return &Drainer{idle: idle}
+}
+
+// Drain puts the server in drain mode. It can't be undone.
+func (d *Drainer) Drain() {
+	if d == nil {
+		return
+	}
+	atomic.StoreInt32(&d.draining, 1)
+}
+
+// Draining reports whether Drain was called.
+func (d *Drainer) Draining() bool {
+	return d != nil && atomic.LoadInt32(&d.draining) == 1
+}
+
+// Wait blocks until no request is in flight, or ctx is done.
+func (d *Drainer) Wait(ctx context.Context) error {
+	if d == nil {
+		return nil
+	}
+	d.mtx.Lock()
+	idle := d.idle
+	d.mtx.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Drainer) start() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.inFlight == 0 {
+		d.idle = make(chan struct{})
+	}
+	d.inFlight++
+}
+
+func (d *Drainer) done() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.inFlight--
+	if d.inFlight == 0 {
+		close(d.idle)
+	}
+}
+
+// DrainHandler wraps handler, tracking its requests in flight with d.
+// WebSocket connections are not tracked, as they last until the client
+// closes them.
+func DrainHandler(handler http.Handler, d *Drainer) http.Handler {
+	if d == nil {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		d.start()
+		defer d.done()
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainer(t *testing.T) {
+	d := NewDrainer()
+	assert.False(t, d.Draining())
+	require.NoError(t, d.Wait(context.Background()))
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	handler := DrainHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}), d)
+	go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/status", nil))
+	<-started
+
+	d.Drain()
+	assert.True(t, d.Draining())
+
+	// the request in flight is waited for
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, d.Wait(ctx))
+
+	close(release)
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, d.Wait(ctx))
+
+	// a nil Drainer never drains
+	var nilDrainer *Drainer
+	nilDrainer.Drain()
+	assert.False(t, nilDrainer.Draining())
+}
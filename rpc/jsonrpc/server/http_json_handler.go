@@ -3,7 +3,6 @@ package server
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -12,7 +11,6 @@ import (
 
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
-	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
@@ -111,27 +109,11 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 			returns := rpcFunc.f.Call(args)
 			logger.Debug("HTTPJSONRPC", "method", request.Method, "args", args, "returns", returns)
 			result, err := unreflectResult(returns)
-			switch e := err.(type) {
-			// if no error then return a success response
-			case nil:
+			if err == nil {
 				responses = append(responses, rpctypes.NewRPCSuccessResponse(request.ID, result))
-
-			// if this already of type RPC error then forward that error
-			case *rpctypes.RPCError:
-				responses = append(responses, rpctypes.NewRPCErrorResponse(request.ID, e.Code, e.Message, e.Data))
+			} else {
+				responses = append(responses, rpctypes.RPCResponse{JSONRPC: "2.0", ID: request.ID, Error: rpcError(err)})
 				c = false
-			default: // we need to unwrap the error and parse it accordingly
-				switch errors.Unwrap(err) {
-				// check if the error was due to an invald request
-				case coretypes.ErrZeroOrNegativeHeight, coretypes.ErrZeroOrNegativePerPage,
-					coretypes.ErrPageOutOfRange, coretypes.ErrInvalidRequest:
-					responses = append(responses, rpctypes.RPCInvalidRequestError(request.ID, err))
-					c = false
-				// lastly default all remaining errors as internal errors
-				default: // includes ctypes.ErrHeightNotAvailable and ctypes.ErrHeightExceedsChainHead
-					responses = append(responses, rpctypes.RPCInternalError(request.ID, err))
-					c = false
-				}
 			}
 
 			if c && !rpcFunc.cache {
@@ -279,7 +261,8 @@ func writeListOfEndpoints(w http.ResponseWriter, r *http.Request, funcMap map[st
 
 func hasDefaultHeight(r rpctypes.RPCRequest, h []reflect.Value) bool {
 	switch r.Method {
-	case "block", "block_results", "commit", "consensus_params", "validators":
+	case "block", "block_results", "commit", "consensus_params", "validators", "namespaced_data",
+		"data_availability_header", "share":
 		return len(h) < 2 || h[1].IsZero()
 	default:
 		return false
@@ -2,6 +2,8 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -12,7 +14,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 func testMux() *http.ServeMux {
@@ -258,3 +262,62 @@ func TestRPCResponseCache(t *testing.T) {
 	res.Body.Close()
 	require.Nil(t, err, "reading from the body should not give back an error")
 }
+
+func TestRPCErrorCodes(t *testing.T) {
+	errs := map[string]error{
+		"invalid":  fmt.Errorf("%w: bad", coretypes.ErrInvalidRequest),
+		"notfound": fmt.Errorf("%w: tx (AB)", coretypes.ErrTxNotFound),
+		"pruned":   fmt.Errorf("%w (requested height: 1)", coretypes.ErrHeightNotAvailable),
+		"head":     coretypes.ErrHeightExceedsChainHead,
+		"full":     types.ErrMempoolIsFull{},
+		"timeout":  fmt.Errorf("%w waiting for commit", coretypes.ErrTimedOut),
+		"app":      coretypes.AppError{Code: 5, Codespace: "bank", Log: "insufficient funds"},
+		"other":    errors.New("boom"),
+	}
+	funcMap := map[string]*RPCFunc{
+		"fail": NewRPCFunc(func(ctx *rpctypes.Context, kind string) (string, error) {
+			return "", errs[kind]
+		}, "kind", false),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewNopLogger())
+
+	testCases := []struct {
+		kind string
+		code int
+	}{
+		{"invalid", rpctypes.CodeInvalidRequest},
+		{"notfound", rpctypes.CodeTxNotFound},
+		{"pruned", rpctypes.CodeHeightNotAvailable},
+		{"head", rpctypes.CodeHeightExceedsChainHead},
+		{"full", rpctypes.CodeMempoolFull},
+		{"timeout", rpctypes.CodeTimedOut},
+		{"app", rpctypes.CodeAppError},
+		{"other", rpctypes.CodeInternalError},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.kind, func(t *testing.T) {
+			for _, req := range []*http.Request{
+				httptest.NewRequest("POST", "http://localhost/",
+					strings.NewReader(`{"jsonrpc":"2.0","method":"fail","id":0,"params":{"kind":"`+tc.kind+`"}}`)),
+				httptest.NewRequest("GET", "http://localhost/fail?kind=\""+tc.kind+"\"", nil),
+			} {
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, req)
+
+				var res rpctypes.RPCResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.code, res.Error.Code)
+				if tc.kind == "app" {
+					assert.Equal(t, "bank", res.Error.Codespace)
+					assert.EqualValues(t, 5, res.Error.AppCode)
+					assert.Equal(t, "insufficient funds", res.Error.Data)
+				} else {
+					assert.Equal(t, errs[tc.kind].Error(), res.Error.Data)
+				}
+			}
+		})
+	}
+}
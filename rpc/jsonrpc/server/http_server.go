@@ -3,6 +3,9 @@ package server
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -32,6 +35,9 @@ type Config struct {
 	MaxBodyBytes int64
 	// mirrors http.Server#MaxHeaderBytes
 	MaxHeaderBytes int
+	// ClientCAs, if set, are the certificate authorities one of which must
+	// sign the certificate the clients authenticate with over TLS.
+	ClientCAs *x509.CertPool
 }
 
 // DefaultConfig returns a default configuration.
@@ -65,7 +71,9 @@ func Serve(listener net.Listener, handler http.Handler, logger log.Logger, confi
 
 // Serve creates a http.Server and calls ServeTLS with the given listener,
 // certFile and keyFile. It wraps handler with RecoverAndLogHandler and a
-// handler, which limits the max body size to config.MaxBodyBytes.
+// handler, which limits the max body size to config.MaxBodyBytes. If
+// config.ClientCAs is set, the clients must authenticate with a certificate
+// signed by one of them.
 //
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func ServeTLS(
@@ -83,6 +91,13 @@ func ServeTLS(
 		WriteTimeout:   config.WriteTimeout,
 		MaxHeaderBytes: config.MaxHeaderBytes,
 	}
+	if config.ClientCAs != nil {
+		s.TLSConfig = &tls.Config{
+			ClientCAs:  config.ClientCAs,
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
 	err := s.ServeTLS(listener, certFile, keyFile)
 
 	logger.Error("RPC HTTPS server stopped", "err", err)
@@ -118,9 +133,9 @@ func WriteRPCResponseHTTPError(
 
 	var httpCode int
 	switch res.Error.Code {
-	case -32600:
+	case rpctypes.CodeInvalidRequest:
 		httpCode = http.StatusBadRequest
-	case -32601:
+	case rpctypes.CodeMethodNotFound:
 		httpCode = http.StatusNotFound
 	default:
 		httpCode = http.StatusInternalServerError
@@ -155,6 +170,43 @@ func WriteRPCResponseHTTP(w http.ResponseWriter, c bool, res ...rpctypes.RPCResp
 	return err
 }
 
+// WriteCacheableRPCResponseHTTP marshals res as JSON (with indent) and writes
+// it to w like WriteRPCResponseHTTP with caching allowed. The response also
+// carries an ETag derived from its content: if it matches the If-None-Match
+// header of the request r, only the status 304 Not Modified is written.
+func WriteCacheableRPCResponseHTTP(w http.ResponseWriter, r *http.Request, res rpctypes.RPCResponse) error {
+	jsonBytes, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+	hash := sha256.Sum256(jsonBytes)
+	etag := fmt.Sprintf(`"%x"`, hash[:16])
+
+	w.Header().Set("Cache-Control", "max-age=31536000") // expired after one year
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err = w.Write(jsonBytes)
+	return err
+}
+
+// etagMatches reports whether the value of an If-None-Match header matches
+// etag, using the weak comparison of RFC 7232.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
 //-----------------------------------------------------------------------------
 
 // RecoverAndLogHandler wraps an HTTP handler, adding error logging.
@@ -261,7 +313,14 @@ func (h maxBytesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 // Listen starts a new net.Listener on the given address.
 // It returns an error if the address is invalid or the call to Listen() fails.
+// A unix socket left over by a stopped process is replaced.
 func Listen(addr string, maxOpenConnections int) (listener net.Listener, err error) {
+	return ListenWithMode(addr, maxOpenConnections, 0)
+}
+
+// ListenWithMode is Listen, setting the permissions of a unix socket to
+// socketMode, unless 0. The socket is removed when the listener is closed.
+func ListenWithMode(addr string, maxOpenConnections int, socketMode os.FileMode) (listener net.Listener, err error) {
 	parts := strings.SplitN(addr, "://", 2)
 	if len(parts) != 2 {
 		return nil, fmt.Errorf(
@@ -270,13 +329,44 @@ func Listen(addr string, maxOpenConnections int) (listener net.Listener, err err
 		)
 	}
 	proto, addr := parts[0], parts[1]
+	if proto == "unix" {
+		if err := removeStaleSocket(addr); err != nil {
+			return nil, fmt.Errorf("failed to listen on %v: %w", addr, err)
+		}
+	}
 	listener, err = net.Listen(proto, addr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen on %v: %v", addr, err)
 	}
+	if proto == "unix" && socketMode != 0 {
+		if err := os.Chmod(addr, socketMode); err != nil {
+			_ = listener.Close()
+			return nil, fmt.Errorf("failed to set the permissions of %v: %w", addr, err)
+		}
+	}
 	if maxOpenConnections > 0 {
 		listener = netutil.LimitListener(listener, maxOpenConnections)
 	}
 
 	return listener, nil
 }
+
+// removeStaleSocket removes the unix socket at path if no process listens on
+// it anymore, e.g. after a crash. Anything else at path is left untouched.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return err
+	case info.Mode()&os.ModeSocket == 0:
+		return errors.New("the file exists and is not a unix socket")
+	}
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err == nil {
+		_ = conn.Close()
+		return errors.New("the socket is in use by another process")
+	}
+	return os.Remove(path)
+}
@@ -8,6 +8,8 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -106,6 +108,77 @@ func TestServeTLS(t *testing.T) {
 	assert.Equal(t, []byte("some body"), body)
 }
 
+func TestListenUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rpc.sock")
+
+	ln, err := ListenWithMode("unix://"+path, 0, 0600)
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// the socket is in use
+	_, err = ListenWithMode("unix://"+path, 0, 0600)
+	require.Error(t, err)
+
+	// a socket left over by a stopped process is replaced
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, ln.Close())
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+	ln, err = Listen("unix://"+path, 0)
+	require.NoError(t, err)
+	require.NoError(t, ln.Close())
+	// and removed when the listener is closed
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	// other files are left untouched
+	require.NoError(t, ioutil.WriteFile(path, []byte("data"), 0600))
+	_, err = Listen("unix://"+path, 0)
+	require.Error(t, err)
+}
+
+func TestWriteCacheableRPCResponseHTTP(t *testing.T) {
+	res := rpctypes.NewRPCSuccessResponse(rpctypes.JSONRPCIntID(-1), &sampleResult{"hello"})
+
+	w := httptest.NewRecorder()
+	err := WriteCacheableRPCResponseHTTP(w, httptest.NewRequest("GET", "/block?height=1", nil), res)
+	require.NoError(t, err)
+	resp := w.Result()
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "max-age=31536000", resp.Header.Get("Cache-control"))
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	testCases := []struct {
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{etag, http.StatusNotModified},
+		{"W/" + etag, http.StatusNotModified},
+		{`"other", ` + etag, http.StatusNotModified},
+		{"*", http.StatusNotModified},
+		{`"other"`, http.StatusOK},
+	}
+	for _, tc := range testCases {
+		req := httptest.NewRequest("GET", "/block?height=1", nil)
+		req.Header.Set("If-None-Match", tc.ifNoneMatch)
+		w := httptest.NewRecorder()
+		require.NoError(t, WriteCacheableRPCResponseHTTP(w, req, res))
+		resp := w.Result()
+		body, err := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, tc.wantStatus, resp.StatusCode, tc.ifNoneMatch)
+		assert.Equal(t, etag, resp.Header.Get("ETag"))
+		if tc.wantStatus == http.StatusNotModified {
+			assert.Empty(t, body)
+		}
+	}
+}
+
 func TestWriteRPCResponseHTTP(t *testing.T) {
 	id := rpctypes.JSONRPCIntID(-1)
 
@@ -2,7 +2,6 @@ package server
 
 import (
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -11,7 +10,6 @@ import (
 
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
-	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
@@ -57,37 +55,23 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 
 		logger.Debug("HTTPRestRPC", "method", r.URL.Path, "args", args, "returns", returns)
 		result, err := unreflectResult(returns)
-		switch e := err.(type) {
-		// if no error then return a success response
-		case nil:
-			res := rpctypes.NewRPCSuccessResponse(dummyID, result)
-			if wErr := WriteRPCResponseHTTP(w, rpcFunc.cache, res); wErr != nil {
-				logger.Error("failed to write response", "res", res, "err", wErr)
-			}
-
-		// if this already of type RPC error then forward that error.
-		case *rpctypes.RPCError:
-			res := rpctypes.NewRPCErrorResponse(dummyID, e.Code, e.Message, e.Data)
+		if err != nil {
+			res := rpctypes.RPCResponse{JSONRPC: "2.0", ID: dummyID, Error: rpcError(err)}
 			if wErr := WriteRPCResponseHTTPError(w, res); wErr != nil {
 				logger.Error("failed to write response", "res", res, "err", wErr)
 			}
+			return
+		}
 
-		default: // we need to unwrap the error and parse it accordingly
-			var res rpctypes.RPCResponse
-
-			switch errors.Unwrap(err) {
-			case coretypes.ErrZeroOrNegativeHeight,
-				coretypes.ErrZeroOrNegativePerPage,
-				coretypes.ErrPageOutOfRange,
-				coretypes.ErrInvalidRequest:
-				res = rpctypes.RPCInvalidRequestError(dummyID, err)
-			default: // ctypes.ErrHeightNotAvailable, ctypes.ErrHeightExceedsChainHead:
-				res = rpctypes.RPCInternalError(dummyID, err)
-			}
-
-			if wErr := WriteRPCResponseHTTPError(w, res); wErr != nil {
-				logger.Error("failed to write response", "res", res, "err", wErr)
-			}
+		res := rpctypes.NewRPCSuccessResponse(dummyID, result)
+		var wErr error
+		if rpcFunc.cache {
+			wErr = WriteCacheableRPCResponseHTTP(w, r, res)
+		} else {
+			wErr = WriteRPCResponseHTTP(w, false, res)
+		}
+		if wErr != nil {
+			logger.Error("failed to write response", "res", res, "err", wErr)
 		}
 
 	}
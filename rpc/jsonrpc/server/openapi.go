@@ -0,0 +1,322 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/version"
+)
+
+// OpenAPI document of the routes of an RPC server, generated from the
+// arguments and the results of their functions, so that it can't drift from
+// the routes actually served. Schemas follow the encoding of libs/json: 64-bit
+// integers are strings, and interfaces are wrapped in a type/value object.
+//
+// Only the URI (GET) form of the routes is described, the websocket-only
+// routes are left out.
+type openAPIDoc struct {
+	OpenAPI    string                 `json:"openapi"`
+	Info       openAPIInfo            `json:"info"`
+	Paths      map[string]openAPIPath `json:"paths"`
+	Components openAPIComponents      `json:"components"`
+
+	schemas map[reflect.Type]string // names of the struct schemas
+	names   map[string]reflect.Type // to detect name collisions
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIPath struct {
+	Get openAPIOperation `json:"get"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name   string         `json:"name"`
+	In     string         `json:"in"`
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*openAPISchema `json:"schemas"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+}
+
+var (
+	hexBytesType       = reflect.TypeOf(bytes.HexBytes{})
+	timeType           = reflect.TypeOf(time.Time{})
+	jsonMarshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	errorResponseRef   = &openAPISchema{Ref: "#/components/schemas/RPCErrorResponse"}
+	errorResponseShape = &openAPISchema{
+		Type: "object",
+		Properties: map[string]*openAPISchema{
+			"jsonrpc": {Type: "string"},
+			"id":      {Type: "integer"},
+			"error": {
+				Type: "object",
+				Properties: map[string]*openAPISchema{
+					"code":    {Type: "integer"},
+					"message": {Type: "string"},
+					"data":    {}, // a string, or an object for application errors
+				},
+				Required: []string{"code", "message"},
+			},
+		},
+		Required: []string{"jsonrpc", "id", "error"},
+	}
+)
+
+// OpenAPIHandler returns a handler serving the OpenAPI document of the routes
+// in funcMap, as JSON.
+func OpenAPIHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.HandlerFunc {
+	var (
+		once sync.Once
+		doc  []byte
+		err  error
+	)
+	return func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() {
+			doc, err = json.MarshalIndent(newOpenAPIDoc(funcMap), "", "  ")
+		})
+		if err != nil {
+			logger.Error("failed to marshal OpenAPI document", "err", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(doc); err != nil {
+			logger.Error("failed to write OpenAPI document", "err", err)
+		}
+	}
+}
+
+func newOpenAPIDoc(funcMap map[string]*RPCFunc) *openAPIDoc {
+	doc := &openAPIDoc{
+		OpenAPI: "3.0.0",
+		Info: openAPIInfo{
+			Title:   "Tendermint RPC",
+			Version: version.TMVersion,
+		},
+		Paths: make(map[string]openAPIPath),
+		Components: openAPIComponents{
+			Schemas: map[string]*openAPISchema{"RPCErrorResponse": errorResponseShape},
+		},
+		schemas: make(map[reflect.Type]string),
+		names:   make(map[string]reflect.Type),
+	}
+
+	// in a stable order, for the names of the schemas to be stable too
+	names := make([]string, 0, len(funcMap))
+	for name := range funcMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		rpcFunc := funcMap[name]
+		if rpcFunc.ws {
+			continue
+		}
+		doc.Paths["/"+name] = openAPIPath{Get: doc.operation(name, rpcFunc)}
+	}
+	return doc
+}
+
+func (doc *openAPIDoc) operation(name string, rpcFunc *RPCFunc) openAPIOperation {
+	// skip types.Context
+	const argsOffset = 1
+
+	op := openAPIOperation{OperationID: name}
+	for i, argName := range rpcFunc.argNames {
+		op.Parameters = append(op.Parameters, openAPIParameter{
+			Name:   argName,
+			In:     "query",
+			Schema: doc.paramSchema(rpcFunc.args[i+argsOffset]),
+		})
+	}
+
+	result := &openAPISchema{}
+	if len(rpcFunc.returns) > 0 {
+		result = doc.schema(rpcFunc.returns[0])
+	}
+	op.Responses = map[string]openAPIResponse{
+		"200": {
+			Description: "OK",
+			Content: map[string]openAPIMediaType{"application/json": {Schema: &openAPISchema{
+				Type: "object",
+				Properties: map[string]*openAPISchema{
+					"jsonrpc": {Type: "string"},
+					"id":      {Type: "integer"},
+					"result":  result,
+				},
+				Required: []string{"jsonrpc", "id", "result"},
+			}}},
+		},
+		"default": {
+			Description: "Error",
+			Content: map[string]openAPIMediaType{
+				"application/json": {Schema: errorResponseRef},
+			},
+		},
+	}
+	return op
+}
+
+// paramSchema returns the schema of a query parameter of type rt, where
+// integers are not quoted.
+func (doc *openAPIDoc) paramSchema(rt reflect.Type) *openAPISchema {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	switch rt.Kind() {
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	default:
+		return doc.schema(rt)
+	}
+}
+
+// schema returns the schema of the libs/json encoding of rt.
+func (doc *openAPIDoc) schema(rt reflect.Type) *openAPISchema {
+	nullable := false
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+		nullable = true
+	}
+
+	switch {
+	case rt == timeType:
+		return &openAPISchema{Type: "string", Format: "date-time", Nullable: nullable}
+	case rt == hexBytesType:
+		return &openAPISchema{Type: "string", Format: "hex"}
+	case rt.Implements(jsonMarshalerType) || reflect.PtrTo(rt).Implements(jsonMarshalerType):
+		// custom encoding, which can't be described
+		return &openAPISchema{}
+	}
+
+	switch rt.Kind() {
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean", Nullable: nullable}
+	case reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &openAPISchema{Type: "integer", Format: "int32", Nullable: nullable}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &openAPISchema{Type: "string", Format: "int64", Nullable: nullable}
+	case reflect.Float32, reflect.Float64:
+		return &openAPISchema{Type: "number", Nullable: nullable}
+	case reflect.String:
+		return &openAPISchema{Type: "string", Nullable: nullable}
+	case reflect.Slice, reflect.Array:
+		if rt.Elem().Kind() == reflect.Uint8 {
+			return &openAPISchema{Type: "string", Format: "byte", Nullable: true}
+		}
+		return &openAPISchema{Type: "array", Items: doc.schema(rt.Elem()), Nullable: rt.Kind() == reflect.Slice}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: doc.schema(rt.Elem())}
+	case reflect.Interface:
+		return &openAPISchema{
+			Type: "object",
+			Properties: map[string]*openAPISchema{
+				"type":  {Type: "string"},
+				"value": {},
+			},
+			Required: []string{"type", "value"},
+			Nullable: true,
+		}
+	case reflect.Struct:
+		if rt.Name() == "" {
+			s := &openAPISchema{Type: "object", Nullable: nullable}
+			doc.fields(s, rt)
+			return s
+		}
+		return &openAPISchema{Ref: "#/components/schemas/" + doc.structSchema(rt)}
+	default:
+		return &openAPISchema{}
+	}
+}
+
+// structSchema adds the schema of the named struct rt to the components, if
+// it is not there yet, and returns its name.
+func (doc *openAPIDoc) structSchema(rt reflect.Type) string {
+	if name, ok := doc.schemas[rt]; ok {
+		return name
+	}
+
+	// the short name, unless a type of another package has it already
+	name := rt.String()
+	if _, taken := doc.names[name]; taken {
+		name = strings.ReplaceAll(rt.PkgPath(), "/", ".") + "." + rt.Name()
+	}
+	doc.schemas[rt] = name
+	doc.names[name] = rt
+
+	// registered before the fields are generated, for recursive types
+	s := &openAPISchema{Type: "object"}
+	doc.Components.Schemas[name] = s
+	doc.fields(s, rt)
+	return name
+}
+
+// fields sets the properties of s to the fields of the struct rt.
+func (doc *openAPIDoc) fields(s *openAPISchema, rt reflect.Type) {
+	s.Properties = make(map[string]*openAPISchema)
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.Name == "" || !unicode.IsUpper(rune(field.Name[0])) {
+			continue
+		}
+		jsonName, omitEmpty := field.Name, false
+		if tag := field.Tag.Get("json"); tag == "-" {
+			continue
+		} else if tag != "" {
+			opts := strings.Split(tag, ",")
+			if opts[0] != "" {
+				jsonName = opts[0]
+			}
+			for _, o := range opts[1:] {
+				if o == "omitempty" {
+					omitEmpty = true
+				}
+			}
+		}
+		s.Properties[jsonName] = doc.schema(field.Type)
+		if !omitEmpty {
+			s.Required = append(s.Required, jsonName)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+type openAPITestResult struct {
+	Height int64          `json:"height"`
+	Hash   bytes.HexBytes `json:"hash"`
+	Time   time.Time      `json:"time"`
+	Data   []byte         `json:"data,omitempty"`
+	Parent *openAPITestResult
+	hidden bool
+}
+
+func TestOpenAPIDoc(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"c": NewRPCFunc(func(ctx *rpctypes.Context, height *int64, prove bool) (*openAPITestResult, error) {
+			return nil, nil
+		}, "height,prove", false),
+		"subscribe": NewWSRPCFunc(func(ctx *rpctypes.Context, query string) (*openAPITestResult, error) {
+			return nil, nil
+		}, "query"),
+	}
+	doc := newOpenAPIDoc(funcMap)
+
+	// websocket only routes are left out
+	require.Len(t, doc.Paths, 1)
+	op := doc.Paths["/c"].Get
+	assert.Equal(t, "c", op.OperationID)
+	assert.Equal(t, []openAPIParameter{
+		{Name: "height", In: "query", Schema: &openAPISchema{Type: "integer", Format: "int64"}},
+		{Name: "prove", In: "query", Schema: &openAPISchema{Type: "boolean"}},
+	}, op.Parameters)
+
+	ref := "#/components/schemas/server.openAPITestResult"
+	result := op.Responses["200"].Content["application/json"].Schema.Properties["result"]
+	assert.Equal(t, &openAPISchema{Ref: ref}, result)
+
+	s := doc.Components.Schemas["server.openAPITestResult"]
+	require.NotNil(t, s)
+	assert.Equal(t, map[string]*openAPISchema{
+		"height": {Type: "string", Format: "int64"},
+		"hash":   {Type: "string", Format: "hex"},
+		"time":   {Type: "string", Format: "date-time"},
+		"data":   {Type: "string", Format: "byte", Nullable: true},
+		"Parent": {Ref: ref},
+	}, s.Properties)
+	assert.Equal(t, []string{"height", "hash", "time", "Parent"}, s.Required)
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"c": NewRPCFunc(func(ctx *rpctypes.Context) (*openAPITestResult, error) {
+			return nil, nil
+		}, "", false),
+	}
+	w := httptest.NewRecorder()
+	OpenAPIHandler(funcMap, log.TestingLogger())(w, httptest.NewRequest("GET", "/openapi.json", nil))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.0", doc["openapi"])
+	assert.Contains(t, doc["paths"], "/c")
+}
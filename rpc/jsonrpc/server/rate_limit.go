@@ -0,0 +1,279 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// idle per-IP buckets are dropped at most this often
+const rateLimitCleanupInterval = time.Minute
+
+// RateLimitConfig configures a RateLimiter. Rates are in requests per second,
+// a rate of 0 meaning no limit. A request costs the weight of its endpoint,
+// which defaults to 1.
+type RateLimitConfig struct {
+	// limit of the requests of all clients
+	GlobalRate  float64
+	GlobalBurst int
+
+	// limit of the requests of each client IP
+	PerIPRate  float64
+	PerIPBurst int
+
+	Weights map[string]float64
+}
+
+// RateLimiter throttles requests with token buckets: a global one, and one
+// per client IP.
+type RateLimiter struct {
+	config  RateLimitConfig
+	metrics *Metrics
+
+	mtx         sync.Mutex
+	global      *tokenBucket
+	perIP       map[string]*tokenBucket
+	lastCleanup time.Time
+	now         func() time.Time
+}
+
+// NewRateLimiter returns a RateLimiter enforcing the given limits. It returns
+// nil, which throttles nothing, if neither limit is set.
+func NewRateLimiter(config RateLimitConfig, metrics *Metrics) *RateLimiter {
+	if config.GlobalRate <= 0 && config.PerIPRate <= 0 {
+		return nil
+	}
+	if metrics == nil {
+		metrics = NopMetrics()
+	}
+	rl := &RateLimiter{
+		config:  config,
+		metrics: metrics,
+		perIP:   make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+	rl.lastCleanup = rl.now()
+	if config.GlobalRate > 0 {
+		rl.global = newTokenBucket(config.GlobalRate, config.GlobalBurst, rl.now())
+	}
+	return rl
+}
+
+// SetConfig changes the limits. The clients start over with full buckets.
+// Unlike NewRateLimiter, it keeps the RateLimiter, which throttles nothing if
+// neither limit is set.
+func (rl *RateLimiter) SetConfig(config RateLimitConfig) {
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+
+	rl.config = config
+	rl.perIP = make(map[string]*tokenBucket)
+	rl.global = nil
+	if config.GlobalRate > 0 {
+		rl.global = newTokenBucket(config.GlobalRate, config.GlobalBurst, rl.now())
+	}
+}
+
+// Allow reports whether a request of the client ip, calling the given
+// methods, can be served. If not, it returns how long to wait before retrying.
+func (rl *RateLimiter) Allow(ip string, methods ...string) (time.Duration, bool) {
+	if rl == nil {
+		return 0, true
+	}
+
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+
+	var cost float64
+	for _, method := range methods {
+		if w, ok := rl.config.Weights[method]; ok {
+			cost += w
+		} else {
+			cost++
+		}
+	}
+
+	now := rl.now()
+	if now.Sub(rl.lastCleanup) > rateLimitCleanupInterval {
+		for key, b := range rl.perIP {
+			if b.full(now) {
+				delete(rl.perIP, key)
+			}
+		}
+		rl.lastCleanup = now
+	}
+
+	var ipBucket *tokenBucket
+	if rl.config.PerIPRate > 0 {
+		ipBucket = rl.perIP[ip]
+		if ipBucket == nil {
+			ipBucket = newTokenBucket(rl.config.PerIPRate, rl.config.PerIPBurst, now)
+			rl.perIP[ip] = ipBucket
+		}
+		if wait := ipBucket.wait(cost, now); wait > 0 {
+			rl.throttled(methods, "ip")
+			return wait, false
+		}
+	}
+	if rl.global != nil {
+		if wait := rl.global.wait(cost, now); wait > 0 {
+			rl.throttled(methods, "global")
+			return wait, false
+		}
+		rl.global.take(cost)
+	}
+	if ipBucket != nil {
+		ipBucket.take(cost)
+	}
+	return 0, true
+}
+
+func (rl *RateLimiter) throttled(methods []string, limit string) {
+	for _, method := range methods {
+		rl.metrics.ThrottledRequests.With("endpoint", method, "limit", limit).Add(1)
+	}
+}
+
+// RateLimitHandler wraps handler, rejecting the requests throttled by rl with
+// the status 429 Too Many Requests and a Retry-After header. The endpoint of a
+// request is taken from its path, or from the body of JSON-RPC requests.
+func RateLimitHandler(handler http.Handler, rl *RateLimiter, logger log.Logger) http.Handler {
+	if rl == nil {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods, err := requestMethods(r)
+		if err != nil {
+			// let the handler report the error
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		wait, ok := rl.Allow(remoteIP(r.RemoteAddr), methods...)
+		if ok {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		res := rpctypes.NewRPCErrorResponse(rpctypes.JSONRPCIntID(-1), rpctypes.CodeServerError, "Server error", rateLimitedError(wait))
+		jsonBytes, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			logger.Error("failed to marshal response", "res", res, "err", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		w.WriteHeader(http.StatusTooManyRequests)
+		if _, err := w.Write(jsonBytes); err != nil {
+			logger.Error("failed to write response", "res", res, "err", err)
+		}
+	})
+}
+
+// RateLimit sets the rate limiter of the requests sent over the websocket
+// connection. Nil by default, which throttles nothing.
+func RateLimit(rl *RateLimiter) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.rateLimiter = rl
+	}
+}
+
+// requestMethods returns the endpoints called by r. The body of r is
+// restored after it is read.
+func requestMethods(r *http.Request) ([]string, error) {
+	if r.URL.Path != "/" {
+		return []string{strings.TrimPrefix(r.URL.Path, "/")}, nil
+	}
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+	var requests []struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(b, &requests); err != nil {
+		var request struct {
+			Method string `json:"method"`
+		}
+		if err := json.Unmarshal(b, &request); err != nil {
+			return nil, err
+		}
+		return []string{request.Method}, nil
+	}
+
+	methods := make([]string, len(requests))
+	for i, req := range requests {
+		methods[i] = req.Method
+	}
+	return methods, nil
+}
+
+// rateLimitedError returns the data of the error response to a throttled
+// request.
+func rateLimitedError(wait time.Duration) string {
+	return fmt.Sprintf("rate limit exceeded, retry after %v", wait.Round(time.Millisecond))
+}
+
+func remoteIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+// tokenBucket holds up to burst tokens, refilled at rate tokens per second.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: now}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// wait returns how long it takes for cost tokens to be available, 0 if they
+// are. A cost above the burst only requires a full bucket.
+func (b *tokenBucket) wait(cost float64, now time.Time) time.Duration {
+	b.refill(now)
+	cost = math.Min(cost, b.burst)
+	if b.tokens >= cost {
+		return 0
+	}
+	return time.Duration((cost - b.tokens) / b.rate * float64(time.Second))
+}
+
+// take removes cost tokens from the bucket, which must have been checked
+// with wait.
+func (b *tokenBucket) take(cost float64) {
+	b.tokens -= math.Min(cost, b.burst)
+}
+
+func (b *tokenBucket) full(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= b.burst
+}
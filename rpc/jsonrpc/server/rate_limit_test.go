@@ -0,0 +1,106 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestRateLimiter(t *testing.T) {
+	assert.Nil(t, NewRateLimiter(RateLimitConfig{}, nil))
+
+	rl := NewRateLimiter(RateLimitConfig{
+		GlobalRate:  10,
+		GlobalBurst: 10,
+		PerIPRate:   1,
+		PerIPBurst:  2,
+		Weights:     map[string]float64{"tx_search": 5},
+	}, nil)
+	now := time.Now()
+	rl.now = func() time.Time { return now }
+
+	// the burst of each ip
+	for i := 0; i < 2; i++ {
+		_, ok := rl.Allow("1.1.1.1", "status")
+		require.True(t, ok)
+	}
+	wait, ok := rl.Allow("1.1.1.1", "status")
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, wait)
+
+	// other ips are not affected
+	_, ok = rl.Allow("2.2.2.2", "status")
+	assert.True(t, ok)
+
+	// a heavy request only needs a full bucket
+	_, ok = rl.Allow("3.3.3.3", "tx_search")
+	assert.True(t, ok)
+
+	// the global burst is spent: 2 + 1 + 5 so far
+	_, ok = rl.Allow("4.4.4.4", "status", "status")
+	assert.True(t, ok)
+	wait, ok = rl.Allow("5.5.5.5", "status")
+	assert.False(t, ok)
+	assert.Equal(t, 100*time.Millisecond, wait)
+
+	now = now.Add(time.Second)
+	_, ok = rl.Allow("1.1.1.1", "status")
+	assert.True(t, ok)
+}
+
+func TestRateLimiterSetConfig(t *testing.T) {
+	rl := NewRateLimiter(RateLimitConfig{PerIPRate: 1, PerIPBurst: 1}, nil)
+	now := time.Now()
+	rl.now = func() time.Time { return now }
+
+	_, ok := rl.Allow("1.1.1.1", "status")
+	require.True(t, ok)
+	_, ok = rl.Allow("1.1.1.1", "status")
+	require.False(t, ok)
+
+	// the new limits apply, with full buckets
+	rl.SetConfig(RateLimitConfig{GlobalRate: 1, GlobalBurst: 3})
+	for i := 0; i < 3; i++ {
+		_, ok = rl.Allow("1.1.1.1", "status")
+		require.True(t, ok)
+	}
+	_, ok = rl.Allow("2.2.2.2", "status")
+	assert.False(t, ok)
+
+	// no limits
+	rl.SetConfig(RateLimitConfig{})
+	_, ok = rl.Allow("2.2.2.2", "status")
+	assert.True(t, ok)
+}
+
+func TestRateLimitHandler(t *testing.T) {
+	rl := NewRateLimiter(RateLimitConfig{PerIPRate: 1, PerIPBurst: 2}, nil)
+	handler := RateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), rl, log.TestingLogger())
+
+	request := func(req *http.Request) *http.Response {
+		req.RemoteAddr = "1.1.1.1:1234"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	// a batch of two JSON-RPC requests spends the burst
+	resp := request(httptest.NewRequest("POST", "/",
+		strings.NewReader(`[{"jsonrpc":"2.0","id":1,"method":"status"},{"jsonrpc":"2.0","id":2,"method":"health"}]`)))
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = request(httptest.NewRequest("GET", "/status", nil))
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+}
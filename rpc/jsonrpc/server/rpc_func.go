@@ -1,11 +1,16 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"reflect"
 	"strings"
 
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 // RegisterRPCFuncs adds a route for each function in the funcMap, as well as
@@ -18,6 +23,9 @@ func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger lo
 		mux.HandleFunc("/"+funcName, makeHTTPHandler(rpcFunc, logger))
 	}
 
+	// OpenAPI document of the HTTP endpoints
+	mux.HandleFunc("/openapi.json", OpenAPIHandler(funcMap, logger))
+
 	// JSONRPC endpoints
 	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger)))
 }
@@ -98,3 +106,47 @@ func unreflectResult(returns []reflect.Value) (interface{}, error) {
 	rvp.Elem().Set(rv)
 	return rvp.Interface(), nil
 }
+
+// rpcError returns the RPC error for the error returned by an RPC function,
+// with the code of its kind of error.
+func rpcError(err error) *rpctypes.RPCError {
+	var (
+		rpcErr *rpctypes.RPCError
+		appErr coretypes.AppError
+	)
+	switch {
+	// if this already of type RPC error then forward that error
+	case errors.As(err, &rpcErr):
+		return rpcErr
+	case errors.As(err, &appErr):
+		return &rpctypes.RPCError{
+			Code:      rpctypes.CodeAppError,
+			Message:   "Application error",
+			Data:      appErr.Log,
+			Codespace: appErr.Codespace,
+			AppCode:   appErr.Code,
+		}
+	case errors.Is(err, coretypes.ErrZeroOrNegativeHeight),
+		errors.Is(err, coretypes.ErrZeroOrNegativePerPage),
+		errors.Is(err, coretypes.ErrPageOutOfRange),
+		errors.Is(err, coretypes.ErrInvalidRequest):
+		return newRPCError(rpctypes.CodeInvalidRequest, "Invalid Request", err)
+	case errors.Is(err, coretypes.ErrTxNotFound):
+		return newRPCError(rpctypes.CodeTxNotFound, "Transaction not found", err)
+	case errors.Is(err, coretypes.ErrHeightNotAvailable):
+		return newRPCError(rpctypes.CodeHeightNotAvailable, "Height not available", err)
+	case errors.Is(err, coretypes.ErrHeightExceedsChainHead):
+		return newRPCError(rpctypes.CodeHeightExceedsChainHead, "Height exceeds chain head", err)
+	case errors.As(err, &types.ErrMempoolIsFull{}):
+		return newRPCError(rpctypes.CodeMempoolFull, "Mempool is full", err)
+	case errors.Is(err, coretypes.ErrTimedOut), errors.Is(err, context.DeadlineExceeded):
+		return newRPCError(rpctypes.CodeTimedOut, "Timed out", err)
+	// lastly default all remaining errors as internal errors
+	default:
+		return newRPCError(rpctypes.CodeInternalError, "Internal error", err)
+	}
+}
+
+func newRPCError(code int, msg string, err error) *rpctypes.RPCError {
+	return &rpctypes.RPCError{Code: code, Message: msg, Data: err.Error()}
+}
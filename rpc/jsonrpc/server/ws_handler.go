@@ -8,13 +8,13 @@ import (
 	"net/http"
 	"reflect"
 	"runtime/debug"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
 
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/rpc/client"
-	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
@@ -87,7 +87,11 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 
 	// register connection
 	logger := wm.logger.With("remote", wsConn.RemoteAddr())
-	con := newWSConnection(wsConn, wm.funcMap, logger, wm.wsConnOptions...)
+	options := wm.wsConnOptions
+	if auth, ok := r.Context().Value(clientAuthKey{}).(clientAuth); ok {
+		options = append(options[:len(options):len(options)], restrictMethods(auth))
+	}
+	con := newWSConnection(wsConn, wm.funcMap, logger, options...)
 	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
 	err = con.Start() // BLOCKING
 	if err != nil {
@@ -137,6 +141,12 @@ type wsConnection struct {
 	// callback which is called upon disconnect
 	onDisconnect func(remoteAddr string)
 
+	// throttles the requests, if set
+	rateLimiter *RateLimiter
+
+	// restricts the methods of the requests, if set
+	clientAuth *clientAuth
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -155,7 +165,7 @@ func newWSConnection(
 ) *wsConnection {
 	wsc := &wsConnection{
 		RunState:          client.NewRunState("wsConnection", logger),
-		remoteAddr:        baseConn.RemoteAddr().String(),
+		remoteAddr:        wsRemoteAddr(baseConn),
 		baseConn:          baseConn,
 		funcMap:           funcMap,
 		writeWait:         defaultWSWriteWait,
@@ -171,6 +181,20 @@ func newWSConnection(
 	return wsc
 }
 
+// unixConnCount numbers the websocket connections over unix sockets.
+var unixConnCount uint64
+
+// wsRemoteAddr returns the address of the client of conn, which identifies its
+// subscriptions. The clients of a unix socket have no address, so they are
+// told apart by the order they connected in.
+func wsRemoteAddr(conn *websocket.Conn) string {
+	addr := conn.RemoteAddr()
+	if addr.Network() != "unix" {
+		return addr.String()
+	}
+	return fmt.Sprintf("unix#%d", atomic.AddUint64(&unixConnCount, 1))
+}
+
 // OnDisconnect sets a callback which is used upon disconnect - not
 // Goroutine-safe. Nop by default.
 func OnDisconnect(onDisconnect func(remoteAddr string)) func(*wsConnection) {
@@ -369,6 +393,23 @@ func (wsc *wsConnection) readRoutine() {
 				continue
 			}
 
+			if wsc.clientAuth != nil && !wsc.clientAuth.allowed[request.Method] {
+				resp := rpctypes.NewRPCErrorResponse(request.ID, rpctypes.CodeServerError, "Server error",
+					methodForbiddenError(request.Method, wsc.clientAuth.commonName))
+				if err := wsc.WriteRPCResponse(writeCtx, resp); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
+
+			if wait, ok := wsc.rateLimiter.Allow(remoteIP(wsc.remoteAddr), request.Method); !ok {
+				resp := rpctypes.NewRPCErrorResponse(request.ID, rpctypes.CodeServerError, "Server error", rateLimitedError(wait))
+				if err := wsc.WriteRPCResponse(writeCtx, resp); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
+
 			ctx := &rpctypes.Context{JSONReq: &request, WSConn: wsc}
 			args := []reflect.Value{reflect.ValueOf(ctx)}
 			if len(request.Params) > 0 {
@@ -391,26 +432,10 @@ func (wsc *wsConnection) readRoutine() {
 
 			var resp rpctypes.RPCResponse
 			result, err := unreflectResult(returns)
-			switch e := err.(type) {
-			// if no error then return a success response
-			case nil:
+			if err == nil {
 				resp = rpctypes.NewRPCSuccessResponse(request.ID, result)
-
-			// if this already of type RPC error then forward that error
-			case *rpctypes.RPCError:
-				resp = rpctypes.NewRPCErrorResponse(request.ID, e.Code, e.Message, e.Data)
-
-			default: // we need to unwrap the error and parse it accordingly
-				switch errors.Unwrap(err) {
-				// check if the error was due to an invald request
-				case coretypes.ErrZeroOrNegativeHeight, coretypes.ErrZeroOrNegativePerPage,
-					coretypes.ErrPageOutOfRange, coretypes.ErrInvalidRequest:
-					resp = rpctypes.RPCInvalidRequestError(request.ID, err)
-
-				// lastly default all remaining errors as internal errors
-				default: // includes ctypes.ErrHeightNotAvailable and ctypes.ErrHeightExceedsChainHead
-					resp = rpctypes.RPCInternalError(request.ID, err)
-				}
+			} else {
+				resp = rpctypes.RPCResponse{JSONRPC: "2.0", ID: request.ID, Error: rpcError(err)}
 			}
 
 			if err := wsc.WriteRPCResponse(writeCtx, resp); err != nil {
@@ -137,10 +137,85 @@ func ArrayToRequest(id jsonrpcid, method string, params []interface{}) (RPCReque
 //----------------------------------------
 // RESPONSE
 
+// Error codes of the responses. Besides the codes defined by JSON-RPC 2.0,
+// codes in the [-32099, -32000] range, reserved for the implementation, let
+// clients tell apart the errors they can act on without parsing the messages.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	CodeServerError    = -32000
+
+	// CodeTxNotFound: the transaction is not indexed by the node.
+	CodeTxNotFound = -32001
+	// CodeHeightNotAvailable: the height was pruned, or is not stored yet.
+	CodeHeightNotAvailable = -32002
+	// CodeHeightExceedsChainHead: the height is above the node's last block.
+	CodeHeightExceedsChainHead = -32003
+	// CodeMempoolFull: the transaction can't be added to the full mempool.
+	CodeMempoolFull = -32004
+	// CodeTimedOut: the request timed out, it may still succeed later.
+	CodeTimedOut = -32005
+	// CodeAppError: the application rejected the request, its codespace and
+	// code are in the data of the error.
+	CodeAppError = -32006
+)
+
 type RPCError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Data    string `json:"data,omitempty"`
+
+	// Codespace and AppCode of the application's answer, for CodeAppError.
+	// They are sent in data as {"codespace", "code", "log"}, with the log in
+	// Data.
+	Codespace string `json:"-"`
+	AppCode   uint32 `json:"-"`
+}
+
+// rpcAppErrorData is the data of a CodeAppError error.
+type rpcAppErrorData struct {
+	Codespace string `json:"codespace,omitempty"`
+	Code      uint32 `json:"code"`
+	Log       string `json:"log,omitempty"`
+}
+
+func (err RPCError) MarshalJSON() ([]byte, error) {
+	type rpcError RPCError // without the methods
+	if err.Code != CodeAppError {
+		return json.Marshal(rpcError(err))
+	}
+	return json.Marshal(struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Data    rpcAppErrorData `json:"data"`
+	}{err.Code, err.Message, rpcAppErrorData{err.Codespace, err.AppCode, err.Data}})
+}
+
+func (err *RPCError) UnmarshalJSON(data []byte) error {
+	var unsafeErr struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data,omitempty"`
+	}
+	if err := json.Unmarshal(data, &unsafeErr); err != nil {
+		return err
+	}
+	*err = RPCError{Code: unsafeErr.Code, Message: unsafeErr.Message}
+	if len(unsafeErr.Data) == 0 || string(unsafeErr.Data) == "null" {
+		return nil
+	}
+	if unsafeErr.Data[0] != '{' {
+		return json.Unmarshal(unsafeErr.Data, &err.Data)
+	}
+	var appData rpcAppErrorData
+	if err := json.Unmarshal(unsafeErr.Data, &appData); err != nil {
+		return err
+	}
+	err.Data, err.Codespace, err.AppCode = appData.Log, appData.Codespace, appData.Code
+	return nil
 }
 
 func (err RPCError) Error() string {
@@ -218,30 +293,30 @@ func (resp RPCResponse) String() string {
 //	If there was an error in detecting the id in the Request object (e.g. Parse
 // 	error/Invalid Request), it MUST be Null.
 func RPCParseError(err error) RPCResponse {
-	return NewRPCErrorResponse(nil, -32700, "Parse error", err.Error())
+	return NewRPCErrorResponse(nil, CodeParseError, "Parse error", err.Error())
 }
 
 // From the JSON-RPC 2.0 spec:
 //	If there was an error in detecting the id in the Request object (e.g. Parse
 // 	error/Invalid Request), it MUST be Null.
 func RPCInvalidRequestError(id jsonrpcid, err error) RPCResponse {
-	return NewRPCErrorResponse(id, -32600, "Invalid Request", err.Error())
+	return NewRPCErrorResponse(id, CodeInvalidRequest, "Invalid Request", err.Error())
 }
 
 func RPCMethodNotFoundError(id jsonrpcid) RPCResponse {
-	return NewRPCErrorResponse(id, -32601, "Method not found", "")
+	return NewRPCErrorResponse(id, CodeMethodNotFound, "Method not found", "")
 }
 
 func RPCInvalidParamsError(id jsonrpcid, err error) RPCResponse {
-	return NewRPCErrorResponse(id, -32602, "Invalid params", err.Error())
+	return NewRPCErrorResponse(id, CodeInvalidParams, "Invalid params", err.Error())
 }
 
 func RPCInternalError(id jsonrpcid, err error) RPCResponse {
-	return NewRPCErrorResponse(id, -32603, "Internal error", err.Error())
+	return NewRPCErrorResponse(id, CodeInternalError, "Internal error", err.Error())
 }
 
 func RPCServerError(id jsonrpcid, err error) RPCResponse {
-	return NewRPCErrorResponse(id, -32000, "Server error", err.Error())
+	return NewRPCErrorResponse(id, CodeServerError, "Server error", err.Error())
 }
 
 //----------------------------------------
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type SampleResult struct {
@@ -81,3 +82,25 @@ func TestRPCError(t *testing.T) {
 			Message: "Badness",
 		}))
 }
+
+func TestRPCErrorJSON(t *testing.T) {
+	errs := []*RPCError{
+		{Code: CodeInternalError, Message: "Internal error", Data: "boom"},
+		{Code: CodeMethodNotFound, Message: "Method not found"},
+		{Code: CodeAppError, Message: "Application error", Data: "insufficient funds", Codespace: "bank", AppCode: 5},
+	}
+	for _, rpcErr := range errs {
+		bz, err := json.Marshal(rpcErr)
+		require.NoError(t, err)
+
+		var decoded RPCError
+		require.NoError(t, json.Unmarshal(bz, &decoded))
+		assert.Equal(t, *rpcErr, decoded)
+	}
+
+	bz, err := json.Marshal(errs[2])
+	require.NoError(t, err)
+	assert.JSONEq(t,
+		`{"code":-32006,"message":"Application error","data":{"codespace":"bank","code":5,"log":"insufficient funds"}}`,
+		string(bz))
+}
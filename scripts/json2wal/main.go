@@ -40,7 +40,7 @@ func main() {
 	// the length of tendermint/wal/MsgInfo in the wal.json may exceed the defaultBufSize(4096) of bufio
 	// because of the byte array in BlockPart
 	// leading to unmarshal error: unexpected end of JSON input
-	br := bufio.NewReaderSize(f, int(2*types.BlockPartSizeBytes))
+	br := bufio.NewReaderSize(f, int(2*types.MaxBlockPartSizeBytes))
 	dec := consensus.NewWALEncoder(walFile)
 
 	for {
@@ -5,12 +5,19 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"math/rand"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/tendermint/tendermint/abci/example/code"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
 	"github.com/tendermint/tendermint/version"
 )
 
@@ -66,8 +73,29 @@ type Config struct {
 	//
 	// height <-> pubkey <-> voting power
 	ValidatorUpdates map[string]map[string]uint8 `toml:"validator_update"`
+
+	// LargeValidatorUpdates is the number of filler validators, with power 1,
+	// added to every validator update after InitChain and removed again at the
+	// next height. See FillerValidatorKey for their keys.
+	LargeValidatorUpdates int `toml:"large_validator_updates"`
+
+	// DeliverTxDelay is an artificial latency added to the execution of every
+	// tx. Defaults to 0 (none).
+	DeliverTxDelay time.Duration `toml:"deliver_tx_delay"`
+
+	// CheckTxFailureRate is the probability with which a new tx is rejected in
+	// CheckTx. Defaults to 0 (never).
+	CheckTxFailureRate float64 `toml:"check_tx_failure_rate"`
+
+	// ProcessProposalRejectRate is the probability with which a proposed block
+	// is rejected in ProcessProposal. Defaults to 0 (never).
+	ProcessProposalRejectRate float64 `toml:"process_proposal_reject_rate"`
 }
 
+// CodeTypeInjectedFailure is the CheckTx code of the txs rejected at random,
+// see Config.CheckTxFailureRate.
+const CodeTypeInjectedFailure uint32 = 100
+
 func DefaultConfig(dir string) *Config {
 	return &Config{
 		PersistInterval:  1,
@@ -101,6 +129,7 @@ func (app *Application) Info(req abci.RequestInfo) abci.ResponseInfo {
 		AppVersion:       1,
 		LastBlockHeight:  int64(app.state.Height),
 		LastBlockAppHash: app.state.Hash,
+		ProcessProposal:  app.cfg.ProcessProposalRejectRate > 0,
 	}
 }
 
@@ -132,6 +161,13 @@ func (app *Application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 			Log:  err.Error(),
 		}
 	}
+	// only new txs are rejected, as the result of a recheck must not change
+	if req.Type == abci.CheckTxType_New && rand.Float64() < app.cfg.CheckTxFailureRate { //nolint:gosec
+		return abci.ResponseCheckTx{
+			Code: CodeTypeInjectedFailure,
+			Log:  "tx rejected by failure injection",
+		}
+	}
 	return abci.ResponseCheckTx{Code: code.CodeTypeOK, GasWanted: 1}
 }
 
@@ -141,6 +177,9 @@ func (app *Application) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDelive
 	if err != nil {
 		panic(err) // shouldn't happen since we verified it in CheckTx
 	}
+	if app.cfg.DeliverTxDelay > 0 {
+		time.Sleep(app.cfg.DeliverTxDelay)
+	}
 	app.state.Set(key, value)
 	return abci.ResponseDeliverTx{Code: code.CodeTypeOK}
 }
@@ -259,12 +298,20 @@ func (app *Application) PreprocessTxs(
 	return abci.ResponsePreprocessTxs{Txs: req.Txs}
 }
 
-// validatorUpdates generates a validator set update.
+// ProcessProposal implements ABCI.
+func (app *Application) ProcessProposal(req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+	if rand.Float64() < app.cfg.ProcessProposalRejectRate { //nolint:gosec
+		app.logger.Info("rejecting proposal by failure injection", "height", req.Header.Height)
+		return abci.ResponseProcessProposal{Accept: false}
+	}
+	return abci.ResponseProcessProposal{Accept: true}
+}
+
+// validatorUpdates generates a validator set update. After InitChain, the
+// filler validators are added along with the configured updates, and removed
+// at the next height.
 func (app *Application) validatorUpdates(height uint64) (abci.ValidatorUpdates, error) {
 	updates := app.cfg.ValidatorUpdates[fmt.Sprintf("%v", height)]
-	if len(updates) == 0 {
-		return nil, nil
-	}
 
 	valUpdates := abci.ValidatorUpdates{}
 	for keyString, power := range updates {
@@ -275,9 +322,44 @@ func (app *Application) validatorUpdates(height uint64) (abci.ValidatorUpdates,
 		}
 		valUpdates = append(valUpdates, abci.UpdateValidator(keyBytes, int64(power), app.cfg.KeyType))
 	}
+	if height > 0 && len(updates) > 0 {
+		valUpdates = append(valUpdates, app.fillerValidatorUpdates(height, 1)...)
+	}
+	if height > 1 && len(app.cfg.ValidatorUpdates[fmt.Sprintf("%v", height-1)]) > 0 {
+		valUpdates = append(valUpdates, app.fillerValidatorUpdates(height-1, 0)...)
+	}
+	if len(valUpdates) == 0 {
+		return nil, nil
+	}
 	return valUpdates, nil
 }
 
+// fillerValidatorUpdates sets the power of the filler validators added at
+// height.
+func (app *Application) fillerValidatorUpdates(height uint64, power int64) abci.ValidatorUpdates {
+	valUpdates := make(abci.ValidatorUpdates, 0, app.cfg.LargeValidatorUpdates)
+	for i := 0; i < app.cfg.LargeValidatorUpdates; i++ {
+		key := FillerValidatorKey(app.cfg.KeyType, int64(height), i)
+		valUpdates = append(valUpdates, abci.UpdateValidator(key.Bytes(), power, app.cfg.KeyType))
+	}
+	return valUpdates
+}
+
+// FillerValidatorKey returns the pubkey of the index'th filler validator
+// added at height, of the given key type. The keys are derived from the
+// height and the index, so that the tests can predict the validator sets.
+func FillerValidatorKey(keyType string, height int64, index int) crypto.PubKey {
+	secret := []byte(fmt.Sprintf("filler validator %d at height %d", index, height))
+	switch keyType {
+	case types.ABCIPubKeyTypeSecp256k1:
+		return secp256k1.GenPrivKeySecp256k1(secret).PubKey()
+	case types.ABCIPubKeyTypeBLS:
+		return bls.GenPrivKeyFromSecret(secret).PubKey()
+	default:
+		return ed25519.GenPrivKeyFromSecret(secret).PubKey()
+	}
+}
+
 // parseTx parses a tx in 'key=value' format into a key and value.
 func parseTx(tx []byte) (string, string, error) {
 	parts := bytes.Split(tx, []byte("="))
@@ -31,6 +31,7 @@ var (
 		"badgerdb":  35,
 		"boltdb":    15,
 		"rocksdb":   10,
+		"pebble":    10,
 		"cleveldb":  5,
 	}
 	nodeABCIProtocols = weightedChoice{
@@ -56,16 +57,31 @@ var (
 	nodePersistIntervals  = uniformChoice{0, 1, 5}
 	nodeSnapshotIntervals = uniformChoice{0, 3}
 	nodeRetainBlocks      = uniformChoice{0, 2 * int(e2e.EvidenceAgeHeight), 4 * int(e2e.EvidenceAgeHeight)}
+	nodeDeliverTxDelays   = weightedChoice{"": 80, "5ms": 20}
+	nodeCheckTxFailures   = uniformChoice{0.0, 0.0, 0.1}
+	nodeProposalRejects   = uniformChoice{0.0, 0.0, 0.1}
 	nodePerturbations     = probSetChoice{
 		"disconnect": 0.1,
 		"pause":      0.1,
 		"kill":       0.1,
 		"restart":    0.1,
 	}
-	evidence = uniformChoice{0, 1, 10}
-	txSize   = uniformChoice{1024, 4096} // either 1kb or 4kb
-	ipv6     = uniformChoice{false, true}
-	keyType  = uniformChoice{types.ABCIPubKeyTypeEd25519, types.ABCIPubKeyTypeSecp256k1}
+	evidence   = uniformChoice{0, 1, 10}
+	txSize     = uniformChoice{1024, 4096} // either 1kb or 4kb
+	ipv6       = uniformChoice{false, true}
+	keyType    = uniformChoice{types.ABCIPubKeyTypeEd25519, types.ABCIPubKeyTypeSecp256k1}
+	loadShapes = weightedChoice{
+		string(e2e.LoadShapeConstant): 70,
+		string(e2e.LoadShapeStep):     10,
+		string(e2e.LoadShapeSpike):    10,
+		string(e2e.LoadShapeSine):     10,
+	}
+	loadBroadcasts = weightedChoice{
+		string(e2e.BroadcastSync):   50,
+		string(e2e.BroadcastAsync):  15,
+		string(e2e.BroadcastCommit): 10,
+		string(e2e.BroadcastMixed):  25,
+	}
 )
 
 // Generate generates random testnets using the given RNG.
@@ -145,6 +161,12 @@ func generateTestnet(r *rand.Rand, opt map[string]interface{}) (e2e.Manifest, er
 		QueueType:        opt["queueType"].(string),
 		TxSize:           int64(txSize.Choose(r).(int)),
 	}
+	if shape := loadShapes.Choose(r); shape != string(e2e.LoadShapeConstant) {
+		manifest.LoadShape = shape
+	}
+	if broadcast := loadBroadcasts.Choose(r); broadcast != string(e2e.BroadcastSync) {
+		manifest.LoadBroadcast = broadcast
+	}
 
 	p2pMode := opt["p2p"].(P2PMode)
 	switch p2pMode {
@@ -366,6 +388,14 @@ func generateNode(
 		Perturb:          nodePerturbations.Choose(r),
 	}
 
+	if mode != e2e.ModeSeed {
+		node.DeliverTxDelay = nodeDeliverTxDelays.Choose(r)
+		node.CheckTxFailureRate = nodeCheckTxFailures.Choose(r).(float64)
+	}
+	if mode == e2e.ModeValidator {
+		node.ProcessProposalRejectRate = nodeProposalRejects.Choose(r).(float64)
+	}
+
 	if startAt > 0 {
 		node.StateSync = nodeStateSyncs.Choose(r)
 		if manifest.InitialHeight-startAt <= 5 && node.StateSync == e2e.StateSyncDisabled {
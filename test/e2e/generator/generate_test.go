@@ -11,7 +11,7 @@ import (
 )
 
 func TestGenerator(t *testing.T) {
-	manifests, err := Generate(rand.New(rand.NewSource(randomSeed)), Options{P2P: MixedP2PMode})
+	manifests, err := Generate(rand.New(rand.NewSource(defaultSeed)), Options{P2P: MixedP2PMode})
 	require.NoError(t, err)
 	require.True(t, len(manifests) >= 64, "insufficient combinations")
 
@@ -43,7 +43,7 @@ func TestGenerator(t *testing.T) {
 	}
 
 	t.Run("Hybrid", func(t *testing.T) {
-		manifests, err := Generate(rand.New(rand.NewSource(randomSeed)), Options{P2P: HybridP2PMode})
+		manifests, err := Generate(rand.New(rand.NewSource(defaultSeed)), Options{P2P: HybridP2PMode})
 		require.NoError(t, err)
 		require.True(t, len(manifests) >= 16, "insufficient combinations: %d", len(manifests))
 
@@ -2,10 +2,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -14,7 +17,9 @@ import (
 )
 
 const (
-	randomSeed int64 = 4827085738
+	// defaultSeed is the seed of the testnets generated unless set with
+	// --seed, for the nightly runs to be reproducible.
+	defaultSeed int64 = 4827085738
 )
 
 var logger = log.MustNewDefaultLogger(log.LogFormatPlain, log.LogLevelInfo, false)
@@ -27,6 +32,11 @@ func main() {
 type CLI struct {
 	root *cobra.Command
 	opts Options
+	seed int64
+
+	minimize string // the failing manifest to minimize, if any
+	runner   string
+	runs     int
 }
 
 // NewCLI sets up the CLI.
@@ -38,8 +48,9 @@ func NewCLI() *CLI {
 		SilenceUsage:  true,
 		SilenceErrors: true, // we'll output them ourselves in Run()
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var opts Options
-			var err error
+			if cli.minimize != "" {
+				return cli.minimizeManifest()
+			}
 
 			p2pMode, err := cmd.Flags().GetString("p2p")
 			if err != nil {
@@ -47,7 +58,7 @@ func NewCLI() *CLI {
 			}
 			switch mode := P2PMode(p2pMode); mode {
 			case NewP2PMode, LegacyP2PMode, HybridP2PMode, MixedP2PMode:
-				opts.P2P = mode
+				cli.opts.P2P = mode
 			default:
 				return fmt.Errorf("p2p mode must be either new, legacy, hybrid or mixed got %s", p2pMode)
 			}
@@ -66,6 +77,15 @@ func NewCLI() *CLI {
 		"Minimum network size (nodes)")
 	cli.root.PersistentFlags().IntVarP(&cli.opts.MaxNetworkSize, "max-size", "", 0,
 		"Maxmum network size (nodes), 0 is unlimited")
+	cli.root.PersistentFlags().Int64VarP(&cli.seed, "seed", "s", defaultSeed,
+		"Seed of the random generation of the testnets")
+	cli.root.Flags().StringVarP(&cli.minimize, "minimize", "m", "",
+		"Failing manifest to minimize, by re-running the runner on simplified manifests, "+
+			"instead of generating testnets")
+	cli.root.Flags().StringVar(&cli.runner, "runner", "./build/runner",
+		"Runner to run the manifests with, with --minimize")
+	cli.root.Flags().IntVar(&cli.runs, "runs", 1,
+		"Runs of each manifest with --minimize, a manifest reproducing the failure if any of them fails")
 
 	return cli
 }
@@ -77,7 +97,8 @@ func (cli *CLI) generate() error {
 		return err
 	}
 
-	manifests, err := Generate(rand.New(rand.NewSource(randomSeed)), cli.opts)
+	logger.Info("Generating testnets", "seed", cli.seed)
+	manifests, err := Generate(rand.New(rand.NewSource(cli.seed)), cli.opts)
 	if err != nil {
 		return err
 	}
@@ -105,6 +126,84 @@ func (cli *CLI) generate() error {
 	return nil
 }
 
+// minimizeManifest minimizes a failing manifest, writing the minimal manifest
+// still failing to <name>-min.toml in the directory.
+func (cli *CLI) minimizeManifest() error {
+	if cli.runs < 1 {
+		return fmt.Errorf("runs must be at least 1, got %v", cli.runs)
+	}
+	manifest, err := e2e.LoadManifest(cli.minimize)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(cli.opts.Directory, 0755); err != nil {
+		return err
+	}
+
+	name := strings.TrimSuffix(filepath.Base(cli.minimize), filepath.Ext(cli.minimize))
+	file := filepath.Join(cli.opts.Directory, name+"-min.toml")
+	attempt := 0
+	minimal, features, err := Minimize(manifest, func(m e2e.Manifest) (bool, error) {
+		attempt++
+		if err := m.Save(file); err != nil {
+			return false, err
+		}
+		if _, err := e2e.LoadTestnet(file); err != nil {
+			logger.Info("Skipping invalid manifest", "attempt", attempt, "err", err)
+			return false, nil
+		}
+		for run := 1; run <= cli.runs; run++ {
+			logPath := filepath.Join(cli.opts.Directory, fmt.Sprintf("%v-min-%03d-%d.log", name, attempt, run))
+			logger.Info("Running manifest", "attempt", attempt, "run", run, "log", logPath)
+			failed, err := cli.run(file, logPath)
+			if err != nil {
+				return false, err
+			}
+			if failed {
+				logger.Info("Manifest reproduces the failure", "attempt", attempt)
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := minimal.Save(file); err != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf("Minimal manifest written to %q", file),
+		"attempts", attempt, "features", formatFeatures(features))
+	return nil
+}
+
+// run runs the runner on the manifest, logging its output to logPath and
+// cleaning up the testnet, and returns whether the run failed.
+func (cli *CLI) run(file, logPath string) (bool, error) {
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return false, err
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(cli.runner, "-f", file)
+	cmd.Stdout, cmd.Stderr = logFile, logFile
+	runErr := cmd.Run()
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return false, fmt.Errorf("failed to run %v: %w", cli.runner, runErr)
+	}
+
+	// the runner preserves the testnets which failed
+	cmd = exec.Command(cli.runner, "-f", file, "cleanup")
+	cmd.Stdout, cmd.Stderr = logFile, logFile
+	if err := cmd.Run(); err != nil {
+		return false, fmt.Errorf("failed to clean up the testnet of %q: %w", file, err)
+	}
+	return runErr != nil, nil
+}
+
 // Run runs the CLI.
 func (cli *CLI) Run() {
 	if err := cli.root.Execute(); err != nil {
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
+)
+
+// simplification simplifies a feature of a manifest, e.g. removes a node or
+// the perturbations of a node.
+type simplification struct {
+	name     string
+	simplify func(*e2e.Manifest)
+}
+
+// simplifications returns the simplifications applicable to the manifest, in
+// the order they must be applied: the nodes are removed last, the other
+// simplifications of a removed node being no-ops.
+func simplifications(manifest e2e.Manifest) []simplification {
+	var simple, removals []simplification
+	add := func(name string, simplify func(*e2e.Manifest)) {
+		simple = append(simple, simplification{name, simplify})
+	}
+
+	if manifest.Evidence > 0 {
+		add("evidence", func(m *e2e.Manifest) { m.Evidence = 0 })
+	}
+	if manifest.LargeValidatorUpdates > 0 {
+		add("large validator updates", func(m *e2e.Manifest) { m.LargeValidatorUpdates = 0 })
+	}
+	if manifest.IPv6 {
+		add("ipv6", func(m *e2e.Manifest) { m.IPv6 = false })
+	}
+	if manifest.KeyType != "" {
+		add("key type", func(m *e2e.Manifest) { m.KeyType = "" })
+	}
+	if manifest.LoadShape != "" && manifest.LoadShape != string(e2e.LoadShapeConstant) {
+		add("load shape", func(m *e2e.Manifest) { m.LoadShape = "" })
+	}
+	if manifest.LoadBroadcast != "" && manifest.LoadBroadcast != string(e2e.BroadcastSync) {
+		add("load broadcast", func(m *e2e.Manifest) { m.LoadBroadcast = "" })
+	}
+
+	names := make([]string, 0, len(manifest.Nodes))
+	for name := range manifest.Nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		name, node := name, manifest.Nodes[name]
+		// simplify the node, if it hasn't been removed
+		addNode := func(feature string, simplify func(*e2e.ManifestNode)) {
+			add(fmt.Sprintf("%v %v", name, feature), func(m *e2e.Manifest) {
+				if node, ok := m.Nodes[name]; ok {
+					simplify(node)
+				}
+			})
+		}
+
+		if len(node.Perturb) > 0 {
+			addNode("perturbations", func(n *e2e.ManifestNode) { n.Perturb = nil })
+		}
+		if node.UseLegacyP2P {
+			addNode("legacy p2p", func(n *e2e.ManifestNode) { n.UseLegacyP2P = false })
+		}
+		if node.ABCIProtocol != "" && node.ABCIProtocol != string(e2e.ProtocolBuiltin) {
+			addNode("abci protocol", func(n *e2e.ManifestNode) { n.ABCIProtocol = string(e2e.ProtocolBuiltin) })
+		}
+		if node.PrivvalProtocol != "" && node.PrivvalProtocol != string(e2e.ProtocolFile) {
+			addNode("privval protocol", func(n *e2e.ManifestNode) { n.PrivvalProtocol = string(e2e.ProtocolFile) })
+		}
+		if node.Database != "" && node.Database != "goleveldb" {
+			addNode("database", func(n *e2e.ManifestNode) { n.Database = "goleveldb" })
+		}
+		if node.DeliverTxDelay != "" || node.CheckTxFailureRate > 0 || node.ProcessProposalRejectRate > 0 {
+			addNode("abci faults", func(n *e2e.ManifestNode) {
+				n.DeliverTxDelay = ""
+				n.CheckTxFailureRate = 0
+				n.ProcessProposalRejectRate = 0
+			})
+		}
+
+		removals = append(removals, simplification{name, func(m *e2e.Manifest) { removeNode(m, name) }})
+	}
+
+	return append(simple, removals...)
+}
+
+// removeNode removes a node from the manifest, and the references to it.
+func removeNode(m *e2e.Manifest, name string) {
+	delete(m.Nodes, name)
+	for _, node := range m.Nodes {
+		node.Seeds = without(node.Seeds, name)
+		node.PersistentPeers = without(node.PersistentPeers, name)
+	}
+	if m.Validators != nil {
+		delete(*m.Validators, name)
+	}
+	for height, updates := range m.ValidatorUpdates {
+		delete(updates, name)
+		if len(updates) == 0 {
+			delete(m.ValidatorUpdates, height)
+		}
+	}
+}
+
+func without(names []string, name string) []string {
+	var rest []string
+	for _, n := range names {
+		if n != name {
+			rest = append(rest, n)
+		}
+	}
+	return rest
+}
+
+// Minimize finds a minimal manifest still reproducing the failure of a
+// manifest, bisecting its features with delta debugging: it looks for the
+// smallest set of features of the manifest to keep, the others being
+// simplified, for which reproduces still returns true. The manifest must
+// reproduce the failure.
+func Minimize(
+	manifest e2e.Manifest,
+	reproduces func(e2e.Manifest) (bool, error),
+) (e2e.Manifest, []string, error) {
+	simplifications := simplifications(manifest)
+
+	// with returns the manifest keeping the given features, the indexes of
+	// their simplifications, and simplifying the others
+	with := func(kept []int) (e2e.Manifest, error) {
+		m, err := copyManifest(manifest)
+		if err != nil {
+			return m, err
+		}
+		isKept := map[int]bool{}
+		for _, i := range kept {
+			isKept[i] = true
+		}
+		for i, s := range simplifications {
+			if !isKept[i] {
+				s.simplify(&m)
+			}
+		}
+		return m, nil
+	}
+	results := map[string]bool{}
+	test := func(kept []int) (bool, error) {
+		key := fmt.Sprint(kept)
+		if result, ok := results[key]; ok {
+			return result, nil
+		}
+		m, err := with(kept)
+		if err != nil {
+			return false, err
+		}
+		result, err := reproduces(m)
+		if err != nil {
+			return false, err
+		}
+		results[key] = result
+		return result, nil
+	}
+
+	all := make([]int, len(simplifications))
+	for i := range all {
+		all[i] = i
+	}
+	ok, err := test(all)
+	switch {
+	case err != nil:
+		return manifest, nil, err
+	case !ok:
+		return manifest, nil, fmt.Errorf("the manifest does not reproduce the failure")
+	}
+
+	kept, err := ddmin(all, test)
+	if err != nil {
+		return manifest, nil, err
+	}
+	minimal, err := with(kept)
+	if err != nil {
+		return manifest, nil, err
+	}
+	features := make([]string, 0, len(kept))
+	for _, i := range kept {
+		features = append(features, simplifications[i].name)
+	}
+	return minimal, features, nil
+}
+
+// ddmin returns a 1-minimal subset of the failing set c for which test still
+// fails, i.e. returns true, using the delta debugging algorithm of Zeller and
+// Hildebrandt.
+func ddmin(c []int, test func([]int) (bool, error)) ([]int, error) {
+	if len(c) == 0 {
+		return c, nil
+	}
+	if ok, err := test(nil); err != nil || ok {
+		return nil, err
+	}
+
+	n := 2
+	for len(c) >= 2 {
+		chunks := split(c, n)
+		reduced := false
+
+		// try each chunk, and then the complement of each chunk
+		for _, chunk := range chunks {
+			ok, err := test(chunk)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				c, n, reduced = chunk, 2, true
+				break
+			}
+		}
+		if !reduced && n > 2 {
+			for i := range chunks {
+				complement := complementOf(chunks, i)
+				ok, err := test(complement)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					c, n, reduced = complement, n-1, true
+					break
+				}
+			}
+		}
+
+		if !reduced {
+			if n >= len(c) {
+				break
+			}
+			n *= 2
+			if n > len(c) {
+				n = len(c)
+			}
+		}
+	}
+	return c, nil
+}
+
+// split splits c into n chunks of nearly equal sizes.
+func split(c []int, n int) [][]int {
+	chunks := make([][]int, 0, n)
+	start := 0
+	for i := 0; i < n; i++ {
+		end := start + (len(c)-start)/(n-i)
+		chunks = append(chunks, c[start:end])
+		start = end
+	}
+	return chunks
+}
+
+// complementOf returns the union of the chunks but the i-th.
+func complementOf(chunks [][]int, i int) []int {
+	var complement []int
+	for j, chunk := range chunks {
+		if j != i {
+			complement = append(complement, chunk...)
+		}
+	}
+	return complement
+}
+
+// copyManifest returns a deep copy of the manifest.
+func copyManifest(manifest e2e.Manifest) (e2e.Manifest, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(manifest); err != nil {
+		return e2e.Manifest{}, err
+	}
+	var m e2e.Manifest
+	if _, err := toml.Decode(buf.String(), &m); err != nil {
+		return e2e.Manifest{}, err
+	}
+	return m, nil
+}
+
+// formatFeatures formats the features kept by Minimize.
+func formatFeatures(features []string) string {
+	if len(features) == 0 {
+		return "none"
+	}
+	return strings.Join(features, ", ")
+}
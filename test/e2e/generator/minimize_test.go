@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
+)
+
+func TestDDMin(t *testing.T) {
+	c := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	tests := 0
+	kept, err := ddmin(c, func(kept []int) (bool, error) {
+		tests++
+		has := map[int]bool{}
+		for _, i := range kept {
+			has[i] = true
+		}
+		return has[2] && has[7], nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 7}, kept)
+	assert.Less(t, tests, 30)
+}
+
+func TestMinimize(t *testing.T) {
+	manifest := e2e.Manifest{
+		Evidence: 10,
+		IPv6:     true,
+		Nodes: map[string]*e2e.ManifestNode{
+			"validator01": {ABCIProtocol: "grpc", Perturb: []string{"kill"}},
+			"validator02": {ABCIProtocol: "tcp", Perturb: []string{"restart"}, UseLegacyP2P: true},
+			"validator03": {Database: "rocksdb", PersistentPeers: []string{"validator02"}},
+			"full01":      {Mode: "full", PersistentPeers: []string{"validator01", "validator02"}},
+		},
+	}
+
+	// the failure needs validator02 to restart with the legacy p2p, and a
+	// full node
+	minimal, features, err := Minimize(manifest, func(m e2e.Manifest) (bool, error) {
+		node, ok := m.Nodes["validator02"]
+		return ok && len(node.Perturb) > 0 && node.UseLegacyP2P && m.Nodes["full01"] != nil, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"validator02 perturbations", "validator02 legacy p2p", "full01", "validator02"},
+		features)
+	assert.Zero(t, minimal.Evidence)
+	assert.False(t, minimal.IPv6)
+	require.Len(t, minimal.Nodes, 2)
+	assert.Equal(t, "builtin", minimal.Nodes["validator02"].ABCIProtocol)
+	assert.Equal(t, []string{"validator02"}, minimal.Nodes["full01"].PersistentPeers)
+
+	// the original manifest is left untouched
+	assert.Len(t, manifest.Nodes, 4)
+	assert.Equal(t, "tcp", manifest.Nodes["validator02"].ABCIProtocol)
+
+	_, _, err = Minimize(manifest, func(m e2e.Manifest) (bool, error) { return false, nil })
+	require.Error(t, err)
+}
@@ -4,6 +4,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/BurntSushi/toml"
 
@@ -12,30 +13,40 @@ import (
 
 // Config is the application configuration.
 type Config struct {
-	ChainID          string `toml:"chain_id"`
-	Listen           string
-	Protocol         string
-	Dir              string
-	Mode             string                      `toml:"mode"`
-	PersistInterval  uint64                      `toml:"persist_interval"`
-	SnapshotInterval uint64                      `toml:"snapshot_interval"`
-	RetainBlocks     uint64                      `toml:"retain_blocks"`
-	ValidatorUpdates map[string]map[string]uint8 `toml:"validator_update"`
-	PrivValServer    string                      `toml:"privval_server"`
-	PrivValKey       string                      `toml:"privval_key"`
-	PrivValState     string                      `toml:"privval_state"`
-	KeyType          string                      `toml:"key_type"`
+	ChainID                   string `toml:"chain_id"`
+	Listen                    string
+	Protocol                  string
+	Dir                       string
+	Mode                      string                      `toml:"mode"`
+	PersistInterval           uint64                      `toml:"persist_interval"`
+	SnapshotInterval          uint64                      `toml:"snapshot_interval"`
+	RetainBlocks              uint64                      `toml:"retain_blocks"`
+	ValidatorUpdates          map[string]map[string]uint8 `toml:"validator_update"`
+	LargeValidatorUpdates     int                         `toml:"large_validator_updates"`
+	DeliverTxDelay            string                      `toml:"deliver_tx_delay"`
+	CheckTxFailureRate        float64                     `toml:"check_tx_failure_rate"`
+	ProcessProposalRejectRate float64                     `toml:"process_proposal_reject_rate"`
+	PrivValServer             string                      `toml:"privval_server"`
+	PrivValKey                string                      `toml:"privval_key"`
+	PrivValState              string                      `toml:"privval_state"`
+	KeyType                   string                      `toml:"key_type"`
 }
 
 // App extracts out the application specific configuration parameters
 func (cfg *Config) App() *app.Config {
+	// the delay has been checked by Validate
+	deliverTxDelay, _ := time.ParseDuration(cfg.DeliverTxDelay)
 	return &app.Config{
-		Dir:              cfg.Dir,
-		SnapshotInterval: cfg.SnapshotInterval,
-		RetainBlocks:     cfg.RetainBlocks,
-		KeyType:          cfg.KeyType,
-		ValidatorUpdates: cfg.ValidatorUpdates,
-		PersistInterval:  cfg.PersistInterval,
+		Dir:                       cfg.Dir,
+		SnapshotInterval:          cfg.SnapshotInterval,
+		RetainBlocks:              cfg.RetainBlocks,
+		KeyType:                   cfg.KeyType,
+		ValidatorUpdates:          cfg.ValidatorUpdates,
+		LargeValidatorUpdates:     cfg.LargeValidatorUpdates,
+		PersistInterval:           cfg.PersistInterval,
+		DeliverTxDelay:            deliverTxDelay,
+		CheckTxFailureRate:        cfg.CheckTxFailureRate,
+		ProcessProposalRejectRate: cfg.ProcessProposalRejectRate,
 	}
 }
 
@@ -61,6 +72,11 @@ func (cfg Config) Validate() error {
 		return errors.New("chain_id parameter is required")
 	case cfg.Listen == "" && cfg.Protocol != "builtin":
 		return errors.New("listen parameter is required")
+	case cfg.DeliverTxDelay != "":
+		if _, err := time.ParseDuration(cfg.DeliverTxDelay); err != nil {
+			return fmt.Errorf("invalid deliver_tx_delay %q: %w", cfg.DeliverTxDelay, err)
+		}
+		return nil
 	default:
 		return nil
 	}
@@ -203,6 +203,9 @@ func startLightNode(cfg *Config) error {
 		return err
 	}
 
+	// the headers are verified as they are produced, and not only when queried
+	go verifyHeaders(c, nodeLogger)
+
 	logger.Info("Starting proxy...", "laddr", tmcfg.RPC.ListenAddress)
 	if err := p.ListenAndServe(); err != http.ErrServerClosed {
 		// Error starting or closing listener:
@@ -212,6 +215,20 @@ func startLightNode(cfg *Config) error {
 	return nil
 }
 
+// verifyHeaders verifies the latest header of the network every second, with
+// the light client.
+func verifyHeaders(c *light.Client, logger log.Logger) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if _, err := c.Update(ctx, time.Now()); err != nil {
+			logger.Error("failed to verify the latest header", "err", err)
+		}
+		cancel()
+	}
+}
+
 // startSigner starts a signer server connecting to the given endpoint.
 func startSigner(cfg *Config) error {
 	filePV, err := privval.LoadFilePV(cfg.PrivValKey, cfg.PrivValState)
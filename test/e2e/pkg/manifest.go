@@ -45,11 +45,18 @@ type Manifest struct {
 	// not specified are not changed.
 	ValidatorUpdates map[string]map[string]int64 `toml:"validator_update"`
 
+	// LargeValidatorUpdates adds the given number of filler validators, with
+	// power 1 and keys derived from the height, to every validator update
+	// after InitChain, and removes them again at the next height. It is used
+	// to exercise large validator set changes. Their total power must be less
+	// than a tenth of the power of the validators. Defaults to 0 (disabled).
+	LargeValidatorUpdates int `toml:"large_validator_updates"`
+
 	// Nodes specifies the network nodes. At least one node must be given.
 	Nodes map[string]*ManifestNode `toml:"node"`
 
 	// KeyType sets the curve that will be used by validators.
-	// Options are ed25519 & secp256k1
+	// Options are ed25519, secp256k1 & bls
 	KeyType string `toml:"key_type"`
 
 	// Evidence indicates the amount of evidence that will be injected into the
@@ -65,6 +72,28 @@ type Manifest struct {
 
 	// Number of bytes per tx. Default is 1kb (1024)
 	TxSize int64
+
+	// LoadShape shapes the rate of the transaction load over time: "constant"
+	// (default), "step", "spike" or "sine". With "step", the rate is raised by
+	// its base rate every LoadPeriod, until it reaches LoadAmplitude times the
+	// base rate. With "spike", the rate is LoadAmplitude times the base rate
+	// during the last tenth of every LoadPeriod. With "sine", the rate swings
+	// between the base rate and LoadAmplitude times it, over each LoadPeriod.
+	LoadShape string `toml:"load_shape"`
+
+	// LoadPeriod is the period of the load shape, as a duration, e.g. "30s".
+	// Defaults to 30s.
+	LoadPeriod string `toml:"load_period"`
+
+	// LoadAmplitude is the peak rate of the load shape, as a multiple of the
+	// base rate. Defaults to 10.
+	LoadAmplitude float64 `toml:"load_amplitude"`
+
+	// LoadBroadcast is the RPC method the load submits the transactions with:
+	// "sync" (default) for broadcast_tx_sync, "async" for broadcast_tx_async,
+	// "commit" for broadcast_tx_commit, or "mixed" for a third of the load
+	// workers using each of them.
+	LoadBroadcast string `toml:"load_broadcast"`
 }
 
 // ManifestNode represents a node in a testnet manifest.
@@ -84,7 +113,7 @@ type ManifestNode struct {
 	PersistentPeers []string `toml:"persistent_peers"`
 
 	// Database specifies the database backend: "goleveldb", "cleveldb",
-	// "rocksdb", "boltdb", or "badgerdb". Defaults to goleveldb.
+	// "rocksdb", "boltdb", "badgerdb" or "pebble". Defaults to goleveldb.
 	Database string `toml:"database"`
 
 	// ABCIProtocol specifies the protocol used to communicate with the ABCI
@@ -148,6 +177,20 @@ type ManifestNode struct {
 
 	// UseLegacyP2P enables use of the legacy p2p layer for this node.
 	UseLegacyP2P bool `toml:"use_legacy_p2p"`
+
+	// DeliverTxDelay is an artificial latency added by the application to the
+	// execution of every tx, as a duration, e.g. "10ms". Defaults to none.
+	DeliverTxDelay string `toml:"deliver_tx_delay"`
+
+	// CheckTxFailureRate is the probability, in [0, 1), with which the
+	// application rejects a new tx in CheckTx. Rechecked txs are never
+	// rejected. Defaults to 0.
+	CheckTxFailureRate float64 `toml:"check_tx_failure_rate"`
+
+	// ProcessProposalRejectRate is the probability, in [0, 1), with which the
+	// application of a validator rejects a proposed block, prevoting nil.
+	// Defaults to 0, where ProcessProposal isn't called.
+	ProcessProposalRejectRate float64 `toml:"process_proposal_reject_rate"`
 }
 
 // Stateless reports whether m is a node that does not own state, including light and seed nodes.
@@ -161,6 +204,7 @@ func (m Manifest) Save(file string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create manifest file %q: %w", file, err)
 	}
+	defer f.Close()
 	return toml.NewEncoder(f).Encode(m)
 }
 
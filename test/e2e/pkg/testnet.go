@@ -15,7 +15,7 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
-	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/privval"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	"github.com/tendermint/tendermint/types"
 )
@@ -30,6 +30,8 @@ const (
 type Mode string
 type Protocol string
 type Perturbation string
+type LoadShape string
+type BroadcastMode string
 
 const (
 	ModeValidator Mode = "validator"
@@ -48,6 +50,16 @@ const (
 	PerturbationPause      Perturbation = "pause"
 	PerturbationRestart    Perturbation = "restart"
 
+	LoadShapeConstant LoadShape = "constant"
+	LoadShapeStep     LoadShape = "step"
+	LoadShapeSpike    LoadShape = "spike"
+	LoadShapeSine     LoadShape = "sine"
+
+	BroadcastSync   BroadcastMode = "sync"
+	BroadcastAsync  BroadcastMode = "async"
+	BroadcastCommit BroadcastMode = "commit"
+	BroadcastMixed  BroadcastMode = "mixed"
+
 	EvidenceAgeHeight int64         = 7
 	EvidenceAgeTime   time.Duration = 500 * time.Millisecond
 
@@ -58,47 +70,60 @@ const (
 
 // Testnet represents a single testnet.
 type Testnet struct {
-	Name             string
-	File             string
-	Dir              string
-	IP               *net.IPNet
-	InitialHeight    int64
-	InitialState     map[string]string
-	Validators       map[*Node]int64
-	ValidatorUpdates map[int64]map[*Node]int64
-	Nodes            []*Node
-	KeyType          string
-	Evidence         int
-	LogLevel         string
-	TxSize           int64
+	Name                  string
+	File                  string
+	Dir                   string
+	IP                    *net.IPNet
+	InitialHeight         int64
+	InitialState          map[string]string
+	Validators            map[*Node]int64
+	ValidatorUpdates      map[int64]map[*Node]int64
+	LargeValidatorUpdates int
+	Nodes                 []*Node
+	KeyType               string
+	Evidence              int
+	LogLevel              string
+	TxSize                int64
+	LoadShape             LoadShape
+	LoadPeriod            time.Duration
+	LoadAmplitude         float64
+	LoadBroadcast         BroadcastMode
+
+	// Hostnames addresses the nodes by their name rather than their IP, for
+	// infrastructures where the IPs of the nodes aren't known beforehand,
+	// e.g. Kubernetes.
+	Hostnames bool
 }
 
 // Node represents a Tendermint node in a testnet.
 type Node struct {
-	Name             string
-	Testnet          *Testnet
-	Mode             Mode
-	PrivvalKey       crypto.PrivKey
-	NodeKey          crypto.PrivKey
-	IP               net.IP
-	ProxyPort        uint32
-	StartAt          int64
-	BlockSync        string
-	Mempool          string
-	StateSync        string
-	Database         string
-	ABCIProtocol     Protocol
-	PrivvalProtocol  Protocol
-	PersistInterval  uint64
-	SnapshotInterval uint64
-	RetainBlocks     uint64
-	Seeds            []*Node
-	PersistentPeers  []*Node
-	Perturbations    []Perturbation
-	LogLevel         string
-	UseLegacyP2P     bool
-	QueueType        string
-	HasStarted       bool
+	Name                      string
+	Testnet                   *Testnet
+	Mode                      Mode
+	PrivvalKey                crypto.PrivKey
+	NodeKey                   crypto.PrivKey
+	IP                        net.IP
+	ProxyPort                 uint32
+	StartAt                   int64
+	BlockSync                 string
+	Mempool                   string
+	StateSync                 string
+	Database                  string
+	ABCIProtocol              Protocol
+	PrivvalProtocol           Protocol
+	PersistInterval           uint64
+	SnapshotInterval          uint64
+	RetainBlocks              uint64
+	DeliverTxDelay            time.Duration
+	CheckTxFailureRate        float64
+	ProcessProposalRejectRate float64
+	Seeds                     []*Node
+	PersistentPeers           []*Node
+	Perturbations             []Perturbation
+	LogLevel                  string
+	UseLegacyP2P              bool
+	QueueType                 string
+	HasStarted                bool
 }
 
 // LoadTestnet loads a testnet from a manifest file, using the filename to
@@ -128,19 +153,24 @@ func LoadTestnet(file string) (*Testnet, error) {
 	proxyPortGen := newPortGenerator(proxyPortFirst)
 
 	testnet := &Testnet{
-		Name:             filepath.Base(dir),
-		File:             file,
-		Dir:              dir,
-		IP:               ipGen.Network(),
-		InitialHeight:    1,
-		InitialState:     manifest.InitialState,
-		Validators:       map[*Node]int64{},
-		ValidatorUpdates: map[int64]map[*Node]int64{},
-		Nodes:            []*Node{},
-		Evidence:         manifest.Evidence,
-		KeyType:          "ed25519",
-		LogLevel:         manifest.LogLevel,
-		TxSize:           manifest.TxSize,
+		Name:                  filepath.Base(dir),
+		File:                  file,
+		Dir:                   dir,
+		IP:                    ipGen.Network(),
+		InitialHeight:         1,
+		InitialState:          manifest.InitialState,
+		Validators:            map[*Node]int64{},
+		ValidatorUpdates:      map[int64]map[*Node]int64{},
+		LargeValidatorUpdates: manifest.LargeValidatorUpdates,
+		Nodes:                 []*Node{},
+		Evidence:              manifest.Evidence,
+		KeyType:               "ed25519",
+		LogLevel:              manifest.LogLevel,
+		TxSize:                manifest.TxSize,
+		LoadShape:             LoadShapeConstant,
+		LoadPeriod:            30 * time.Second,
+		LoadAmplitude:         10,
+		LoadBroadcast:         BroadcastSync,
 	}
 	if len(manifest.KeyType) != 0 {
 		testnet.KeyType = manifest.KeyType
@@ -148,6 +178,21 @@ func LoadTestnet(file string) (*Testnet, error) {
 	if testnet.TxSize <= 0 {
 		testnet.TxSize = 1024
 	}
+	if manifest.LoadShape != "" {
+		testnet.LoadShape = LoadShape(manifest.LoadShape)
+	}
+	if manifest.LoadPeriod != "" {
+		testnet.LoadPeriod, err = time.ParseDuration(manifest.LoadPeriod)
+		if err != nil {
+			return nil, fmt.Errorf("invalid load_period %q: %w", manifest.LoadPeriod, err)
+		}
+	}
+	if manifest.LoadAmplitude != 0 {
+		testnet.LoadAmplitude = manifest.LoadAmplitude
+	}
+	if manifest.LoadBroadcast != "" {
+		testnet.LoadBroadcast = BroadcastMode(manifest.LoadBroadcast)
+	}
 	if manifest.InitialHeight > 0 {
 		testnet.InitialHeight = manifest.InitialHeight
 	}
@@ -162,27 +207,29 @@ func LoadTestnet(file string) (*Testnet, error) {
 	for _, name := range nodeNames {
 		nodeManifest := manifest.Nodes[name]
 		node := &Node{
-			Name:             name,
-			Testnet:          testnet,
-			PrivvalKey:       keyGen.Generate(manifest.KeyType),
-			NodeKey:          keyGen.Generate("ed25519"),
-			IP:               ipGen.Next(),
-			ProxyPort:        proxyPortGen.Next(),
-			Mode:             ModeValidator,
-			Database:         "goleveldb",
-			ABCIProtocol:     ProtocolBuiltin,
-			PrivvalProtocol:  ProtocolFile,
-			StartAt:          nodeManifest.StartAt,
-			BlockSync:        nodeManifest.BlockSync,
-			Mempool:          nodeManifest.Mempool,
-			StateSync:        nodeManifest.StateSync,
-			PersistInterval:  1,
-			SnapshotInterval: nodeManifest.SnapshotInterval,
-			RetainBlocks:     nodeManifest.RetainBlocks,
-			Perturbations:    []Perturbation{},
-			LogLevel:         manifest.LogLevel,
-			QueueType:        manifest.QueueType,
-			UseLegacyP2P:     nodeManifest.UseLegacyP2P,
+			Name:                      name,
+			Testnet:                   testnet,
+			PrivvalKey:                keyGen.Generate(manifest.KeyType),
+			NodeKey:                   keyGen.Generate("ed25519"),
+			IP:                        ipGen.Next(),
+			ProxyPort:                 proxyPortGen.Next(),
+			Mode:                      ModeValidator,
+			Database:                  "goleveldb",
+			ABCIProtocol:              ProtocolBuiltin,
+			PrivvalProtocol:           ProtocolFile,
+			StartAt:                   nodeManifest.StartAt,
+			BlockSync:                 nodeManifest.BlockSync,
+			Mempool:                   nodeManifest.Mempool,
+			StateSync:                 nodeManifest.StateSync,
+			PersistInterval:           1,
+			SnapshotInterval:          nodeManifest.SnapshotInterval,
+			RetainBlocks:              nodeManifest.RetainBlocks,
+			CheckTxFailureRate:        nodeManifest.CheckTxFailureRate,
+			ProcessProposalRejectRate: nodeManifest.ProcessProposalRejectRate,
+			Perturbations:             []Perturbation{},
+			LogLevel:                  manifest.LogLevel,
+			QueueType:                 manifest.QueueType,
+			UseLegacyP2P:              nodeManifest.UseLegacyP2P,
 		}
 
 		if node.StartAt == testnet.InitialHeight {
@@ -209,6 +256,13 @@ func LoadTestnet(file string) (*Testnet, error) {
 		if nodeManifest.LogLevel != "" {
 			node.LogLevel = nodeManifest.LogLevel
 		}
+		if nodeManifest.DeliverTxDelay != "" {
+			node.DeliverTxDelay, err = time.ParseDuration(nodeManifest.DeliverTxDelay)
+			if err != nil {
+				return nil, fmt.Errorf("invalid deliver_tx_delay %q for node %q: %w",
+					nodeManifest.DeliverTxDelay, name, err)
+			}
+		}
 		testnet.Nodes = append(testnet.Nodes, node)
 	}
 
@@ -300,10 +354,45 @@ func (t Testnet) Validate() error {
 		return errors.New("network has no nodes")
 	}
 	switch t.KeyType {
-	case "", types.ABCIPubKeyTypeEd25519, types.ABCIPubKeyTypeSecp256k1:
+	case "", types.ABCIPubKeyTypeEd25519, types.ABCIPubKeyTypeSecp256k1, types.ABCIPubKeyTypeBLS:
 	default:
 		return errors.New("unsupported KeyType")
 	}
+	switch t.LoadShape {
+	case LoadShapeConstant, LoadShapeStep, LoadShapeSpike, LoadShapeSine:
+	default:
+		return fmt.Errorf("unknown load_shape %q", t.LoadShape)
+	}
+	if t.LoadPeriod <= 0 {
+		return errors.New("load_period must be positive")
+	}
+	if t.LoadAmplitude < 1 {
+		return errors.New("load_amplitude must be at least 1")
+	}
+	switch t.LoadBroadcast {
+	case BroadcastSync, BroadcastAsync, BroadcastCommit, BroadcastMixed:
+	default:
+		return fmt.Errorf("unknown load_broadcast %q", t.LoadBroadcast)
+	}
+	if t.LargeValidatorUpdates < 0 {
+		return errors.New("large_validator_updates can't be negative")
+	}
+	if t.LargeValidatorUpdates > 0 {
+		// the fillers never sign, so their power must be kept well under the
+		// third which would halt the network
+		validators := t.Validators
+		if v, ok := t.ValidatorUpdates[0]; ok {
+			validators = v
+		}
+		var power int64
+		for _, p := range validators {
+			power += p
+		}
+		if 10*int64(t.LargeValidatorUpdates) >= power {
+			return fmt.Errorf("large_validator_updates=%d must be less than a tenth of the validator power %d",
+				t.LargeValidatorUpdates, power)
+		}
+	}
 	for _, node := range t.Nodes {
 		if err := node.Validate(t); err != nil {
 			return fmt.Errorf("invalid node %q: %w", node.Name, err)
@@ -354,7 +443,7 @@ func (n Node) Validate(testnet Testnet) error {
 		return fmt.Errorf("unsupported p2p queue type: %s", n.QueueType)
 	}
 	switch n.Database {
-	case "goleveldb", "cleveldb", "boltdb", "rocksdb", "badgerdb":
+	case "goleveldb", "cleveldb", "boltdb", "rocksdb", "badgerdb", "pebble":
 	default:
 		return fmt.Errorf("invalid database setting %q", n.Database)
 	}
@@ -392,6 +481,18 @@ func (n Node) Validate(testnet Testnet) error {
 	if n.SnapshotInterval > 0 && n.RetainBlocks > 0 && n.RetainBlocks < n.SnapshotInterval {
 		return errors.New("snapshot_interval must be less than er equal to retain_blocks")
 	}
+	if n.DeliverTxDelay < 0 {
+		return errors.New("deliver_tx_delay can't be negative")
+	}
+	if n.CheckTxFailureRate < 0 || n.CheckTxFailureRate >= 1 {
+		return fmt.Errorf("check_tx_failure_rate %v must be in [0, 1)", n.CheckTxFailureRate)
+	}
+	if n.ProcessProposalRejectRate < 0 || n.ProcessProposalRejectRate >= 1 {
+		return fmt.Errorf("process_proposal_reject_rate %v must be in [0, 1)", n.ProcessProposalRejectRate)
+	}
+	if n.ProcessProposalRejectRate > 0 && n.Mode != ModeValidator {
+		return errors.New("process_proposal_reject_rate is only supported for validators")
+	}
 
 	for _, perturbation := range n.Perturbations {
 		switch perturbation {
@@ -444,12 +545,7 @@ func (t Testnet) HasPerturbations() bool {
 
 // Address returns a P2P endpoint address for the node.
 func (n Node) AddressP2P(withID bool) string {
-	ip := n.IP.String()
-	if n.IP.To4() == nil {
-		// IPv6 addresses must be wrapped in [] to avoid conflict with : port separator
-		ip = fmt.Sprintf("[%v]", ip)
-	}
-	addr := fmt.Sprintf("%v:26656", ip)
+	addr := fmt.Sprintf("%v:26656", n.host())
 	if withID {
 		addr = fmt.Sprintf("%x@%v", n.NodeKey.PubKey().Address().Bytes(), addr)
 	}
@@ -458,12 +554,21 @@ func (n Node) AddressP2P(withID bool) string {
 
 // Address returns an RPC endpoint address for the node.
 func (n Node) AddressRPC() string {
+	return fmt.Sprintf("%v:26657", n.host())
+}
+
+// host returns the host of the node endpoints, its name if the testnet uses
+// hostnames or else its IP.
+func (n Node) host() string {
+	if n.Testnet != nil && n.Testnet.Hostnames {
+		return n.Name
+	}
 	ip := n.IP.String()
 	if n.IP.To4() == nil {
 		// IPv6 addresses must be wrapped in [] to avoid conflict with : port separator
 		ip = fmt.Sprintf("[%v]", ip)
 	}
-	return fmt.Sprintf("%v:26657", ip)
+	return ip
 }
 
 // Client returns an RPC client for a node.
@@ -476,7 +581,7 @@ func (n Node) Stateless() bool {
 	return n.Mode == ModeLight || n.Mode == ModeSeed
 }
 
-// keyGenerator generates pseudorandom Ed25519 keys based on a seed.
+// keyGenerator generates pseudorandom keys based on a seed.
 type keyGenerator struct {
 	random *rand.Rand
 }
@@ -494,14 +599,12 @@ func (g *keyGenerator) Generate(keyType string) crypto.PrivKey {
 	if err != nil {
 		panic(err) // this shouldn't happen
 	}
-	switch keyType {
-	case "secp256k1":
-		return secp256k1.GenPrivKeySecp256k1(seed)
-	case "", "ed25519":
-		return ed25519.GenPrivKeyFromSecret(seed)
-	default:
-		panic("KeyType not supported") // should not make it this far
+	// the keys are derived as by tendermint key import --format=seed
+	privKey, err := privval.GenPrivKeyFromSeed(keyType, seed)
+	if err != nil {
+		panic(err) // should not make it this far
 	}
+	return privKey
 }
 
 // portGenerator generates local Docker proxy ports for each node.
@@ -5,19 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-
-	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
 )
 
-// Cleanup removes the Docker Compose containers and testnet directory.
-func Cleanup(testnet *e2e.Testnet) error {
-	err := cleanupDocker()
-	if err != nil {
-		return err
-	}
-	return cleanupDir(testnet.Dir)
-}
-
 // cleanupDocker removes all E2E resources (with label e2e=True), regardless
 // of testnet.
 func cleanupDocker() error {
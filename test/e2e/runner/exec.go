@@ -3,6 +3,7 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	osexec "os/exec"
 	"path/filepath"
@@ -22,6 +23,20 @@ func exec(args ...string) error {
 	}
 }
 
+// execOutput executes a shell command, returning its output.
+func execOutput(args ...string) ([]byte, error) {
+	cmd := osexec.Command(args[0], args[1:]...)
+	out, err := cmd.Output()
+	switch err := err.(type) {
+	case nil:
+		return out, nil
+	case *osexec.ExitError:
+		return nil, fmt.Errorf("failed to run %q:\n%v", args, string(err.Stderr))
+	default:
+		return nil, err
+	}
+}
+
 // execVerbose executes a shell command while displaying its output.
 func execVerbose(args ...string) error {
 	cmd := osexec.Command(args[0], args[1:]...)
@@ -30,6 +45,15 @@ func execVerbose(args ...string) error {
 	return cmd.Run()
 }
 
+// execTee executes a shell command while displaying its output, and copying
+// it to out.
+func execTee(out io.Writer, args ...string) error {
+	cmd := osexec.Command(args[0], args[1:]...)
+	cmd.Stdout = io.MultiWriter(os.Stdout, out)
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
 // execCompose runs a Docker Compose command for a testnet.
 func execCompose(dir string, args ...string) error {
 	return exec(append(
@@ -48,3 +72,14 @@ func execComposeVerbose(dir string, args ...string) error {
 func execDocker(args ...string) error {
 	return exec(append([]string{"docker"}, args...)...)
 }
+
+// execKubectl runs a kubectl command in a namespace.
+func execKubectl(namespace string, args ...string) error {
+	return exec(append([]string{"kubectl", "--namespace", namespace}, args...)...)
+}
+
+// execKubectlVerbose runs a kubectl command in a namespace and displays its
+// output.
+func execKubectlVerbose(namespace string, args ...string) error {
+	return execVerbose(append([]string{"kubectl", "--namespace", namespace}, args...)...)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
+)
+
+const (
+	InfraDocker     = "docker"
+	InfraKubernetes = "kubernetes"
+)
+
+// Infra is an infrastructure provider, running the nodes of a testnet. The
+// RPC of a started node must be reachable on 127.0.0.1:<ProxyPort>.
+type Infra interface {
+	// Setup generates the infrastructure configuration in the testnet
+	// directory.
+	Setup() error
+
+	// StartNode starts a node for the first time.
+	StartNode(ctx context.Context, node *e2e.Node) error
+
+	// KillNode kills a node with the signal, SIGKILL or SIGTERM.
+	KillNode(ctx context.Context, node *e2e.Node, signal string) error
+
+	// RestartNode starts a killed node again, with the data it had.
+	RestartNode(ctx context.Context, node *e2e.Node) error
+
+	// PauseNode suspends the processes of a node, and UnpauseNode resumes
+	// them.
+	PauseNode(ctx context.Context, node *e2e.Node) error
+	UnpauseNode(ctx context.Context, node *e2e.Node) error
+
+	// DisconnectNode cuts a node from the network, and ConnectNode joins it
+	// back.
+	DisconnectNode(ctx context.Context, node *e2e.Node) error
+	ConnectNode(ctx context.Context, node *e2e.Node) error
+
+	// Stop stops the testnet, and Pause and Resume suspend and resume all
+	// its nodes.
+	Stop() error
+	Pause() error
+	Resume() error
+
+	// Logs displays the logs of the node, or of all the nodes if empty,
+	// following them if set.
+	Logs(node string, follow bool) error
+
+	// Cleanup removes the testnet from the infrastructure, and its
+	// directory.
+	Cleanup() error
+
+	// Close releases the resources held by the runner, leaving the testnet
+	// running.
+	Close() error
+}
+
+// NewInfra returns the infrastructure provider of the given name for the
+// testnet.
+func NewInfra(name string, testnet *e2e.Testnet, opts KubernetesOptions) (Infra, error) {
+	switch name {
+	case InfraDocker:
+		return &dockerInfra{testnet: testnet}, nil
+	case InfraKubernetes:
+		return newKubernetesInfra(testnet, opts), nil
+	default:
+		return nil, fmt.Errorf("unknown infrastructure %q, must be %q or %q",
+			name, InfraDocker, InfraKubernetes)
+	}
+}
+
+// dockerInfra runs the testnet on the local machine with Docker Compose, a
+// container per node.
+type dockerInfra struct {
+	testnet *e2e.Testnet
+}
+
+func (d *dockerInfra) Setup() error {
+	compose, err := MakeDockerCompose(d.testnet)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(d.testnet.Dir, "docker-compose.yml"), compose, 0644)
+}
+
+func (d *dockerInfra) StartNode(ctx context.Context, node *e2e.Node) error {
+	return execCompose(d.testnet.Dir, "up", "-d", node.Name)
+}
+
+func (d *dockerInfra) KillNode(ctx context.Context, node *e2e.Node, signal string) error {
+	return execCompose(d.testnet.Dir, "kill", "-s", signal, node.Name)
+}
+
+func (d *dockerInfra) RestartNode(ctx context.Context, node *e2e.Node) error {
+	return execCompose(d.testnet.Dir, "start", node.Name)
+}
+
+func (d *dockerInfra) PauseNode(ctx context.Context, node *e2e.Node) error {
+	return execCompose(d.testnet.Dir, "pause", node.Name)
+}
+
+func (d *dockerInfra) UnpauseNode(ctx context.Context, node *e2e.Node) error {
+	return execCompose(d.testnet.Dir, "unpause", node.Name)
+}
+
+func (d *dockerInfra) DisconnectNode(ctx context.Context, node *e2e.Node) error {
+	return execDocker("network", "disconnect", d.network(), node.Name)
+}
+
+func (d *dockerInfra) ConnectNode(ctx context.Context, node *e2e.Node) error {
+	return execDocker("network", "connect", d.network(), node.Name)
+}
+
+func (d *dockerInfra) Stop() error {
+	return execCompose(d.testnet.Dir, "down")
+}
+
+func (d *dockerInfra) Pause() error {
+	return execCompose(d.testnet.Dir, "pause")
+}
+
+func (d *dockerInfra) Resume() error {
+	return execCompose(d.testnet.Dir, "unpause")
+}
+
+func (d *dockerInfra) Logs(node string, follow bool) error {
+	args := []string{"logs", "--no-color"}
+	if follow {
+		args = append(args, "--follow")
+	}
+	if node != "" {
+		args = append(args, node)
+	}
+	return execComposeVerbose(d.testnet.Dir, args...)
+}
+
+func (d *dockerInfra) Cleanup() error {
+	err := cleanupDocker()
+	if err != nil {
+		return err
+	}
+	return cleanupDir(d.testnet.Dir)
+}
+
+func (d *dockerInfra) Close() error {
+	return nil
+}
+
+// network returns the name of the Docker network of the testnet, prefixed by
+// Docker Compose with the project name.
+func (d *dockerInfra) network() string {
+	return d.testnet.Name + "_" + d.testnet.Name
+}
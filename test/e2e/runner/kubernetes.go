@@ -0,0 +1,424 @@
+// nolint: gosec
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
+)
+
+// KubernetesOptions are the options of the Kubernetes infrastructure.
+type KubernetesOptions struct {
+	// Image is the image of the nodes, which must be pullable by the cluster.
+	Image string
+	// StorageClass is the storage class of the node volumes, the default one
+	// of the cluster if empty.
+	StorageClass string
+	// StorageSize is the size of each node volume, e.g. 1Gi.
+	StorageSize string
+}
+
+// kubernetesInfra runs the testnet on a Kubernetes cluster, with kubectl, for
+// testnets too large for a single machine. Each node is a pod, with a
+// persistent volume claim for its files and a headless service resolving its
+// name, the nodes being addressed by name. A node is disconnected by
+// isolating it with a network policy, which requires a network plugin
+// enforcing them, and its RPC is reached through a port forward.
+type kubernetesInfra struct {
+	testnet   *e2e.Testnet
+	opts      KubernetesOptions
+	namespace string
+
+	mtx      sync.Mutex
+	forwards map[string]*osexec.Cmd // by node name
+}
+
+func newKubernetesInfra(testnet *e2e.Testnet, opts KubernetesOptions) *kubernetesInfra {
+	// the pods have no fixed IPs, but services resolving their names
+	testnet.Hostnames = true
+	return &kubernetesInfra{
+		testnet:   testnet,
+		opts:      opts,
+		namespace: "e2e-" + testnet.Name,
+		forwards:  map[string]*osexec.Cmd{},
+	}
+}
+
+func (k *kubernetesInfra) Setup() error {
+	dir := filepath.Join(k.testnet.Dir, "kubernetes")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	manifests, err := MakeKubernetesManifests(k.testnet, k.namespace, k.opts)
+	if err != nil {
+		return err
+	}
+	for name, manifest := range manifests {
+		if err := ioutil.WriteFile(filepath.Join(dir, name+".yaml"), manifest, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *kubernetesInfra) StartNode(ctx context.Context, node *e2e.Node) error {
+	if err := execKubectl(k.namespace, "apply", "-f", k.manifest("namespace")); err != nil {
+		return err
+	}
+	if err := execKubectl(k.namespace, "apply", "-f", k.manifest(node.Name)); err != nil {
+		return err
+	}
+
+	// The setup container of the pod waits for the node files to be copied
+	// to the volume, the node starting once they are.
+	if err := k.waitForPod(ctx, node, "{.status.initContainerStatuses[0].state.running}"); err != nil {
+		return err
+	}
+	err := exec("bash", "-c", fmt.Sprintf(
+		"set -o pipefail; tar -C %q -cf - . | kubectl --namespace %v exec -i %v -c setup -- tar -C /tendermint -xf - "+
+			"&& kubectl --namespace %v exec %v -c setup -- touch /tendermint/.ready",
+		filepath.Join(k.testnet.Dir, node.Name), k.namespace, node.Name, k.namespace, node.Name))
+	if err != nil {
+		return err
+	}
+
+	return k.waitAndForward(node)
+}
+
+func (k *kubernetesInfra) KillNode(ctx context.Context, node *e2e.Node, signal string) error {
+	k.stopForward(node.Name)
+	if signal == "SIGKILL" {
+		return execKubectl(k.namespace, "delete", "pod", node.Name, "--grace-period=0", "--force")
+	}
+	// The entrypoint runs as PID 1, which ignores the signal, so it is sent
+	// to the node processes, the pod being deleted once they have exited.
+	err := execKubectl(k.namespace, "exec", node.Name, "-c", "node", "--",
+		"sh", "-c", fmt.Sprintf("kill -%v -1", strings.TrimPrefix(signal, "SIG")))
+	if err != nil {
+		return err
+	}
+	return execKubectl(k.namespace, "delete", "pod", node.Name)
+}
+
+func (k *kubernetesInfra) RestartNode(ctx context.Context, node *e2e.Node) error {
+	// the volume already has the node files, so the setup container exits
+	// right away
+	if err := execKubectl(k.namespace, "apply", "-f", k.manifest(node.Name)); err != nil {
+		return err
+	}
+	return k.waitAndForward(node)
+}
+
+func (k *kubernetesInfra) PauseNode(ctx context.Context, node *e2e.Node) error {
+	// there are no paused pods, so the node processes are stopped instead
+	return execKubectl(k.namespace, "exec", node.Name, "-c", "node", "--", "sh", "-c", "kill -STOP -1")
+}
+
+func (k *kubernetesInfra) UnpauseNode(ctx context.Context, node *e2e.Node) error {
+	return execKubectl(k.namespace, "exec", node.Name, "-c", "node", "--", "sh", "-c", "kill -CONT -1")
+}
+
+func (k *kubernetesInfra) DisconnectNode(ctx context.Context, node *e2e.Node) error {
+	return execKubectl(k.namespace, "apply", "-f", k.manifest(node.Name+"-partition"))
+}
+
+func (k *kubernetesInfra) ConnectNode(ctx context.Context, node *e2e.Node) error {
+	return execKubectl(k.namespace, "delete", "networkpolicy", node.Name+"-partition")
+}
+
+func (k *kubernetesInfra) Stop() error {
+	if err := k.Close(); err != nil {
+		return err
+	}
+	// the volumes are kept, as the directories of the Docker nodes are
+	return execKubectl(k.namespace, "delete", "pods,services,networkpolicies", "--all", "--ignore-not-found")
+}
+
+func (k *kubernetesInfra) Pause() error {
+	for _, node := range k.testnet.Nodes {
+		if err := k.PauseNode(context.Background(), node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *kubernetesInfra) Resume() error {
+	for _, node := range k.testnet.Nodes {
+		if err := k.UnpauseNode(context.Background(), node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *kubernetesInfra) Logs(node string, follow bool) error {
+	args := []string{"logs", "-c", "node"}
+	if node != "" {
+		args = append(args, node)
+	} else {
+		args = append(args, "-l", "e2e=true", "--prefix",
+			"--max-log-requests", strconv.Itoa(len(k.testnet.Nodes)))
+	}
+	if follow {
+		args = append(args, "--follow")
+	}
+	return execKubectlVerbose(k.namespace, args...)
+}
+
+func (k *kubernetesInfra) Cleanup() error {
+	if err := k.Close(); err != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf("Removing Kubernetes namespace %q", k.namespace))
+	err := exec("kubectl", "delete", "namespace", k.namespace, "--ignore-not-found")
+	if err != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf("Removing testnet directory %q", k.testnet.Dir))
+	return os.RemoveAll(k.testnet.Dir)
+}
+
+func (k *kubernetesInfra) Close() error {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	for name := range k.forwards {
+		k.stopForwardLocked(name)
+	}
+	return nil
+}
+
+// Forward forwards the ports of the started nodes until the context is
+// canceled, for the RPCs of a testnet started by another runner to be
+// reachable.
+func (k *kubernetesInfra) Forward(ctx context.Context) error {
+	for _, node := range k.testnet.Nodes {
+		if err := k.forward(node); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return k.Close()
+}
+
+// manifest returns the path of a manifest generated by Setup.
+func (k *kubernetesInfra) manifest(name string) string {
+	return filepath.Join(k.testnet.Dir, "kubernetes", name+".yaml")
+}
+
+// waitForPod waits for the JSONPath expression to be non-empty for the pod of
+// the node.
+func (k *kubernetesInfra) waitForPod(ctx context.Context, node *e2e.Node, jsonPath string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+	for {
+		out, err := execOutput("kubectl", "--namespace", k.namespace, "get", "pod", node.Name,
+			"-o", "jsonpath="+jsonPath)
+		if err == nil && len(bytes.TrimSpace(out)) > 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for pod %v: %w", node.Name, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// waitAndForward waits for the pod of the node to be ready, and forwards its
+// ports.
+func (k *kubernetesInfra) waitAndForward(node *e2e.Node) error {
+	err := execKubectl(k.namespace, "wait", "--for=condition=Ready", "--timeout=5m", "pod/"+node.Name)
+	if err != nil {
+		return err
+	}
+	return k.forward(node)
+}
+
+// forward forwards the RPC and Prometheus ports of the node to the local
+// ports the Docker nodes publish them on.
+func (k *kubernetesInfra) forward(node *e2e.Node) error {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	k.stopForwardLocked(node.Name)
+
+	cmd := osexec.Command("kubectl", "--namespace", k.namespace, "port-forward", "pod/"+node.Name,
+		fmt.Sprintf("%v:26657", node.ProxyPort), fmt.Sprintf("%v:26660", node.ProxyPort+1000))
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to forward the ports of %v: %w", node.Name, err)
+	}
+	go func() { _ = cmd.Wait() }()
+	k.forwards[node.Name] = cmd
+	return nil
+}
+
+func (k *kubernetesInfra) stopForward(name string) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	k.stopForwardLocked(name)
+}
+
+func (k *kubernetesInfra) stopForwardLocked(name string) {
+	if cmd, ok := k.forwards[name]; ok {
+		_ = cmd.Process.Kill()
+		delete(k.forwards, name)
+	}
+}
+
+// MakeKubernetesManifests generates the Kubernetes manifests of a testnet, by
+// name: the namespace, and for each node its resources and the network policy
+// disconnecting it.
+func MakeKubernetesManifests(
+	testnet *e2e.Testnet,
+	namespace string,
+	opts KubernetesOptions,
+) (map[string][]byte, error) {
+	tmpl, err := template.New("kubernetes").Parse(kubernetesTemplate)
+	if err != nil {
+		return nil, err
+	}
+	manifests := map[string][]byte{}
+	execute := func(name, tmplName string, data interface{}) error {
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, tmplName, data); err != nil {
+			return err
+		}
+		manifests[name] = buf.Bytes()
+		return nil
+	}
+
+	if err := execute("namespace", "namespace", namespace); err != nil {
+		return nil, err
+	}
+	for _, node := range testnet.Nodes {
+		data := struct {
+			*e2e.Node
+			Namespace string
+			Options   KubernetesOptions
+		}{node, namespace, opts}
+		if err := execute(node.Name, "node", data); err != nil {
+			return nil, err
+		}
+		if err := execute(node.Name+"-partition", "partition", data); err != nil {
+			return nil, err
+		}
+	}
+	return manifests, nil
+}
+
+const kubernetesTemplate = `
+{{- define "namespace" -}}
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: {{ . }}
+  labels:
+    e2e: "true"
+{{ end }}
+
+{{- define "node" -}}
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: {{ .Name }}
+  namespace: {{ .Namespace }}
+  labels:
+    e2e: "true"
+spec:
+  accessModes:
+  - ReadWriteOnce
+{{- if .Options.StorageClass }}
+  storageClassName: {{ .Options.StorageClass }}
+{{- end }}
+  resources:
+    requests:
+      storage: {{ .Options.StorageSize }}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ .Name }}
+  namespace: {{ .Namespace }}
+  labels:
+    e2e: "true"
+spec:
+  clusterIP: None
+  publishNotReadyAddresses: true
+  selector:
+    e2e-node: {{ .Name }}
+  ports:
+  - name: p2p
+    port: 26656
+  - name: rpc
+    port: 26657
+  - name: prometheus
+    port: 26660
+  - name: pprof
+    port: 6060
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ .Name }}
+  namespace: {{ .Namespace }}
+  labels:
+    e2e: "true"
+    e2e-node: {{ .Name }}
+spec:
+  restartPolicy: Never
+  initContainers:
+  - name: setup
+    image: {{ .Options.Image }}
+    command: ["sh", "-c", "until [ -f /tendermint/.ready ]; do sleep 1; done"]
+    volumeMounts:
+    - name: tendermint
+      mountPath: /tendermint
+  containers:
+  - name: node
+    image: {{ .Options.Image }}
+{{- if eq .ABCIProtocol "builtin" }}
+    command: ["/usr/bin/entrypoint-builtin"]
+{{- else if .LogLevel }}
+    args: ["start", "--log-level", "{{ .LogLevel }}"]
+{{- end }}
+    ports:
+    - containerPort: 26656
+    - containerPort: 26657
+    - containerPort: 26660
+    - containerPort: 6060
+    volumeMounts:
+    - name: tendermint
+      mountPath: /tendermint
+  volumes:
+  - name: tendermint
+    persistentVolumeClaim:
+      claimName: {{ .Name }}
+{{ end }}
+
+{{- define "partition" -}}
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: {{ .Name }}-partition
+  namespace: {{ .Namespace }}
+  labels:
+    e2e: "true"
+spec:
+  podSelector:
+    matchLabels:
+      e2e-node: {{ .Name }}
+  policyTypes:
+  - Ingress
+  - Egress
+{{ end }}`
@@ -3,18 +3,33 @@ package main
 import (
 	"container/ring"
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
 	"github.com/tendermint/tendermint/types"
 )
 
+// loadTx is a transaction submitted by the load.
+type loadTx struct {
+	hash      tmbytes.HexBytes
+	node      string
+	broadcast e2e.BroadcastMode
+	latency   time.Duration // of the broadcast call
+	rejected  bool          // by CheckTx
+	unchecked bool          // broadcast async, its CheckTx result is unknown
+}
+
 // Load generates transactions against the network until the given context is
-// canceled.
-func Load(ctx context.Context, testnet *e2e.Testnet) error {
+// canceled, and returns the number and rate of the transactions submitted.
+// The transactions accepted by the nodes are confirmed by ConfirmLoad.
+func Load(ctx context.Context, testnet *e2e.Testnet) (*LoadResult, error) {
 	// Since transactions are executed across all nodes in the network, we need
 	// to reduce transaction load for larger networks to avoid using too much
 	// CPU. This gives high-throughput small networks and low-throughput large ones.
@@ -26,7 +41,7 @@ func Load(ctx context.Context, testnet *e2e.Testnet) error {
 	}
 
 	chTx := make(chan types.Tx)
-	chSuccess := make(chan int) // success counts per iteration
+	chSubmitted := make(chan loadTx)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -34,14 +49,15 @@ func Load(ctx context.Context, testnet *e2e.Testnet) error {
 	logger.Info("starting transaction load",
 		"workers", concurrency,
 		"nodes", len(testnet.Nodes),
-		"tx", testnet.TxSize)
+		"tx", testnet.TxSize,
+		"shape", testnet.LoadShape)
 
 	started := time.Now()
 
-	go loadGenerate(ctx, chTx, testnet.TxSize)
+	go loadGenerate(ctx, chTx, testnet)
 
 	for w := 0; w < concurrency; w++ {
-		go loadProcess(ctx, testnet, chTx, chSuccess)
+		go loadProcess(ctx, testnet, loadBroadcastMode(testnet.LoadBroadcast, w), chTx, chSubmitted)
 	}
 
 	// Montior transaction to ensure load propagates to the network
@@ -51,14 +67,33 @@ func Load(ctx context.Context, testnet *e2e.Testnet) error {
 	// from the test harness, and there are other checks for
 	// stalls in the framework. Ideally we should monitor latency as a guide
 	// for when to give up, but we don't have a good way to track that yet.
-	success := 0
+	var (
+		success    = 0
+		rejected   = 0
+		accepted   []loadTx
+		broadcasts = map[e2e.BroadcastMode]*BroadcastResult{}
+		latencies  = map[e2e.BroadcastMode]time.Duration{}
+	)
 	for {
 		select {
-		case numSeen := <-chSuccess:
-			success += numSeen
+		case tx := <-chSubmitted:
+			success++
+			b, ok := broadcasts[tx.broadcast]
+			if !ok {
+				b = &BroadcastResult{}
+				broadcasts[tx.broadcast] = b
+			}
+			b.Txs++
+			latencies[tx.broadcast] += tx.latency
+			if tx.rejected {
+				rejected++
+				b.Rejected++
+			} else {
+				accepted = append(accepted, tx)
+			}
 		case <-ctx.Done():
 			if success == 0 {
-				return fmt.Errorf("failed to submit transactions in %s by %d workers",
+				return nil, fmt.Errorf("failed to submit transactions in %s by %d workers",
 					time.Since(started), concurrency)
 			}
 
@@ -71,10 +106,23 @@ func Load(ctx context.Context, testnet *e2e.Testnet) error {
 			logger.Info("ending transaction load",
 				"dur_secs", time.Since(started).Seconds(),
 				"txns", success,
+				"rejected", rejected,
 				"workers", concurrency,
 				"rate", rate)
 
-			return nil
+			result := &LoadResult{
+				Txs:          success,
+				Rejected:     rejected,
+				Broadcasts:   map[string]*BroadcastResult{},
+				DurationSecs: time.Since(started).Seconds(),
+				Rate:         rate,
+				accepted:     accepted,
+			}
+			for mode, b := range broadcasts {
+				b.LatencySecs = latencies[mode].Seconds() / float64(b.Txs)
+				result.Broadcasts[string(mode)] = b
+			}
+			return result, nil
 		}
 	}
 }
@@ -84,8 +132,10 @@ func Load(ctx context.Context, testnet *e2e.Testnet) error {
 // The chTx has multiple consumers, thus the rate limiting of the load
 // generation is primarily the result of backpressure from the
 // broadcast transaction, though there is still some timer-based
-// limiting.
-func loadGenerate(ctx context.Context, chTx chan<- types.Tx, size int64) {
+// limiting, which follows the load shape of the testnet.
+func loadGenerate(ctx context.Context, chTx chan<- types.Tx, testnet *e2e.Testnet) {
+	size := testnet.TxSize
+	started := time.Now()
 	timer := time.NewTimer(0)
 	defer timer.Stop()
 	defer close(chTx)
@@ -114,7 +164,9 @@ func loadGenerate(ctx context.Context, chTx chan<- types.Tx, size int64) {
 		case chTx <- tx:
 			// sleep for a bit before sending the
 			// next transaction.
-			timer.Reset(loadGenerateWaitTime(size))
+			rate := loadShapeRate(testnet.LoadShape, testnet.LoadPeriod, testnet.LoadAmplitude,
+				time.Since(started))
+			timer.Reset(time.Duration(float64(loadGenerateWaitTime(size)) / rate))
 		}
 
 	}
@@ -140,11 +192,53 @@ func loadGenerateWaitTime(size int64) time.Duration {
 	return waitTime
 }
 
-// loadProcess processes transactions
-func loadProcess(ctx context.Context, testnet *e2e.Testnet, chTx <-chan types.Tx, chSuccess chan<- int) {
+// loadShapeRate returns the rate of the load at the given time since its
+// start, as a multiple of its base rate.
+func loadShapeRate(shape e2e.LoadShape, period time.Duration, amplitude float64, elapsed time.Duration) float64 {
+	switch shape {
+	case e2e.LoadShapeStep:
+		return math.Min(1+math.Floor(float64(elapsed)/float64(period)), amplitude)
+	case e2e.LoadShapeSpike:
+		if elapsed%period >= period-period/10 {
+			return amplitude
+		}
+		return 1
+	case e2e.LoadShapeSine:
+		phase := 2 * math.Pi * float64(elapsed%period) / float64(period)
+		return 1 + (amplitude-1)*(1-math.Cos(phase))/2
+	default:
+		return 1
+	}
+}
+
+// loadBroadcastModes are the broadcast modes of the workers of a mixed load.
+var loadBroadcastModes = []e2e.BroadcastMode{e2e.BroadcastSync, e2e.BroadcastAsync, e2e.BroadcastCommit}
+
+// loadBroadcastMode returns the broadcast mode of the w-th load worker.
+func loadBroadcastMode(mode e2e.BroadcastMode, w int) e2e.BroadcastMode {
+	if mode == e2e.BroadcastMixed {
+		return loadBroadcastModes[w%len(loadBroadcastModes)]
+	}
+	return mode
+}
+
+// loadClient is the client of a node used by the load.
+type loadClient struct {
+	node   *e2e.Node
+	client *rpchttp.HTTP
+}
+
+// loadProcess processes transactions, broadcasting them with the given mode.
+func loadProcess(
+	ctx context.Context,
+	testnet *e2e.Testnet,
+	mode e2e.BroadcastMode,
+	chTx <-chan types.Tx,
+	chSubmitted chan<- loadTx,
+) {
 	// Each worker gets its own client to each usable node, which
 	// allows for some concurrency while still bounding it.
-	clients := make([]*rpchttp.HTTP, 0, len(testnet.Nodes))
+	clients := make([]loadClient, 0, len(testnet.Nodes))
 
 	for idx := range testnet.Nodes {
 		// Construct a list of usable nodes for the creating
@@ -160,7 +254,7 @@ func loadProcess(ctx context.Context, testnet *e2e.Testnet, chTx <-chan types.Tx
 			continue
 		}
 
-		clients = append(clients, client)
+		clients = append(clients, loadClient{testnet.Nodes[idx], client})
 	}
 
 	if len(clients) == 0 {
@@ -175,35 +269,136 @@ func loadProcess(ctx context.Context, testnet *e2e.Testnet, chTx <-chan types.Tx
 		clientRing = clientRing.Next()
 	}
 
-	successes := 0
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case tx := <-chTx:
 			clientRing = clientRing.Next()
-			client := clientRing.Value.(*rpchttp.HTTP)
+			lc := clientRing.Value.(loadClient)
 
-			if status, err := client.Status(ctx); err != nil {
+			if status, err := lc.client.Status(ctx); err != nil {
 				continue
 			} else if status.SyncInfo.CatchingUp {
 				continue
 			}
 
-			if _, err := client.BroadcastTxSync(ctx, tx); err != nil {
+			submitted, err := loadBroadcast(ctx, lc.client, mode, tx)
+			if err != nil {
 				continue
 			}
-			successes++
-
+			submitted.node = lc.node.Name
 			select {
-			case chSuccess <- successes:
-				successes = 0 // reset counter for the next iteration
-				continue
+			case chSubmitted <- submitted:
 			case <-ctx.Done():
 				return
-			default:
 			}
+		}
+	}
+}
+
+// loadBroadcast broadcasts a transaction with the given mode.
+func loadBroadcast(ctx context.Context, client *rpchttp.HTTP, mode e2e.BroadcastMode, tx types.Tx) (loadTx, error) {
+	submitted := loadTx{hash: tx.Hash(), broadcast: mode}
+	started := time.Now()
+	switch mode {
+	case e2e.BroadcastAsync:
+		if _, err := client.BroadcastTxAsync(ctx, tx); err != nil {
+			return submitted, err
+		}
+		submitted.unchecked = true
+	case e2e.BroadcastCommit:
+		res, err := client.BroadcastTxCommit(ctx, tx)
+		if err != nil {
+			return submitted, err
+		}
+		submitted.rejected = res.CheckTx.Code != abci.CodeTypeOK || res.CheckTx.MempoolError != ""
+	default:
+		res, err := client.BroadcastTxSync(ctx, tx)
+		if err != nil {
+			return submitted, err
+		}
+		submitted.rejected = res.Code != abci.CodeTypeOK || res.MempoolError != ""
+	}
+	submitted.latency = time.Since(started)
+	return submitted, nil
+}
+
+// confirmBlocks is the number of blocks ConfirmLoad waits for the accepted
+// transactions to be committed.
+const confirmBlocks = 5
+
+// ConfirmLoad checks that the transactions of the load accepted by the nodes
+// were committed, waiting a few blocks for the pending ones, and records
+// those which weren't as lost. It fails if transactions were lost by nodes
+// which weren't killed or restarted, the others losing their mempool, except
+// those broadcast async which may have been rejected by CheckTx.
+func ConfirmLoad(ctx context.Context, testnet *e2e.Testnet, result *LoadResult) error {
+	var client *rpchttp.HTTP
+	for _, node := range testnet.ArchiveNodes() {
+		if c, err := node.Client(); err == nil {
+			client = c
+			break
+		}
+	}
+	if client == nil {
+		return errors.New("no archive node to confirm the transactions with")
+	}
+
+	pending := result.accepted
+	for i := 0; len(pending) > 0; i++ {
+		var notFound []loadTx
+		for _, tx := range pending {
+			if _, err := client.Tx(ctx, tx.hash, false); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				notFound = append(notFound, tx)
+				continue
+			}
+			result.Confirmed++
+		}
+		pending = notFound
+		if len(pending) == 0 || i == confirmBlocks {
+			break
+		}
+
+		// wait for the next block before looking for the pending txs again
+		status, err := client.Status(ctx)
+		if err != nil {
+			return err
+		}
+		if _, _, err := waitForHeight(ctx, testnet, status.SyncInfo.LatestBlockHeight+1); err != nil {
+			return err
+		}
+	}
+
+	unexpected := 0
+	for _, tx := range pending {
+		result.Lost = append(result.Lost, LostTx{Hash: tx.hash.String(), Node: tx.node})
+		if node := testnet.LookupNode(tx.node); node != nil && !losesMempool(node) && !tx.unchecked {
+			unexpected++
+		}
+	}
+	logger.Info("Confirmed transaction load",
+		"accepted", len(result.accepted),
+		"confirmed", result.Confirmed,
+		"lost", len(result.Lost))
+	if unexpected > 0 {
+		return fmt.Errorf("%v of the %v transactions accepted by the nodes were never committed, e.g. %v sent to %v",
+			unexpected, len(result.accepted), result.Lost[0].Hash, result.Lost[0].Node)
+	}
+	return nil
+}
 
+// losesMempool returns whether the node loses the transactions of its mempool
+// when perturbed.
+func losesMempool(node *e2e.Node) bool {
+	for _, perturbation := range node.Perturbations {
+		switch perturbation {
+		case e2e.PerturbationKill, e2e.PerturbationRestart:
+			return true
 		}
 	}
+	return false
 }
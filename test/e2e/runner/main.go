@@ -2,9 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -23,9 +26,11 @@ func main() {
 
 // CLI is the Cobra-based command-line interface.
 type CLI struct {
-	root     *cobra.Command
-	testnet  *e2e.Testnet
-	preserve bool
+	root      *cobra.Command
+	testnet   *e2e.Testnet
+	infra     Infra
+	preserve  bool
+	reportDir string
 }
 
 // NewCLI sets up the CLI.
@@ -45,12 +50,41 @@ func NewCLI() *CLI {
 			if err != nil {
 				return err
 			}
+			infraName, err := cmd.Flags().GetString("infra")
+			if err != nil {
+				return err
+			}
+			var k8sOpts KubernetesOptions
+			if k8sOpts.Image, err = cmd.Flags().GetString("k8s-image"); err != nil {
+				return err
+			}
+			if k8sOpts.StorageClass, err = cmd.Flags().GetString("k8s-storage-class"); err != nil {
+				return err
+			}
+			if k8sOpts.StorageSize, err = cmd.Flags().GetString("k8s-storage-size"); err != nil {
+				return err
+			}
+			infra, err := NewInfra(infraName, testnet, k8sOpts)
+			if err != nil {
+				return err
+			}
 
 			cli.testnet = testnet
+			cli.infra = infra
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
-			if err = Cleanup(cli.testnet); err != nil {
+			report := NewReport(cli.testnet)
+			if cli.reportDir != "" {
+				defer func() {
+					report.Finish(err)
+					if err := report.Write(cli.reportDir); err != nil {
+						logger.Error("Error writing the report", "err", err)
+					}
+				}()
+			}
+
+			if err = cli.infra.Cleanup(); err != nil {
 				return err
 			}
 			defer func() {
@@ -59,46 +93,62 @@ func NewCLI() *CLI {
 				} else if err != nil {
 					logger.Info("Preserving testnet that encountered error",
 						"err", err)
-				} else if err := Cleanup(cli.testnet); err != nil {
+				} else if err := cli.infra.Cleanup(); err != nil {
 					logger.Error("Error cleaning up testnet contents", "err", err)
 				}
 			}()
-			if err = Setup(cli.testnet); err != nil {
+			if err = report.Stage("setup", func() error { return Setup(cli.testnet, cli.infra) }); err != nil {
 				return err
 			}
 
-			chLoadResult := make(chan error)
+			type loadResult struct {
+				result *LoadResult
+				err    error
+			}
+			chLoadResult := make(chan loadResult)
 			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
 			lctx, loadCancel := context.WithCancel(ctx)
 			defer loadCancel()
 			go func() {
-				chLoadResult <- Load(lctx, cli.testnet)
+				result, err := Load(lctx, cli.testnet)
+				chLoadResult <- loadResult{result, err}
 			}()
 			startAt := time.Now()
-			if err = Start(ctx, cli.testnet); err != nil {
+			if err = report.Stage("start", func() error { return Start(ctx, cli.testnet, cli.infra) }); err != nil {
 				return err
 			}
 
-			if err = Wait(ctx, cli.testnet, 5); err != nil { // allow some txs to go through
+			if err = report.Stage("wait", func() error {
+				return Wait(ctx, cli.testnet, 5) // allow some txs to go through
+			}); err != nil {
+				return err
+			}
+			startBlock, err := getLatestBlock(ctx, cli.testnet)
+			if err != nil {
 				return err
 			}
 
 			if cli.testnet.HasPerturbations() {
-				if err = Perturb(ctx, cli.testnet); err != nil {
-					return err
-				}
-				if err = Wait(ctx, cli.testnet, 5); err != nil { // allow some txs to go through
+				if err = report.Stage("perturb", func() error {
+					report.Perturbations, err = Perturb(ctx, cli.testnet, cli.infra)
+					if err != nil {
+						return err
+					}
+					return Wait(ctx, cli.testnet, 5) // allow some txs to go through
+				}); err != nil {
 					return err
 				}
 			}
 
 			if cli.testnet.Evidence > 0 {
-				if err = InjectEvidence(ctx, cli.testnet, cli.testnet.Evidence); err != nil {
-					return err
-				}
-				if err = Wait(ctx, cli.testnet, 5); err != nil { // ensure chain progress
+				if err = report.Stage("evidence", func() error {
+					if err := InjectEvidence(ctx, cli.testnet, cli.testnet.Evidence); err != nil {
+						return err
+					}
+					return Wait(ctx, cli.testnet, 5) // ensure chain progress
+				}); err != nil {
 					return err
 				}
 			}
@@ -118,24 +168,49 @@ func NewCLI() *CLI {
 
 			loadCancel()
 
-			if err = <-chLoadResult; err != nil {
+			load := <-chLoadResult
+			report.Load = load.result
+			if err = load.err; err != nil {
 				return fmt.Errorf("transaction load failed: %w", err)
 			}
-			if err = Wait(ctx, cli.testnet, 5); err != nil { // wait for network to settle before tests
+			if err = report.Stage("settle", func() error {
+				return Wait(ctx, cli.testnet, 5) // wait for network to settle before tests
+			}); err != nil {
 				return err
 			}
-			if err := Test(cli.testnet); err != nil {
+			if err = report.Stage("confirm", func() error {
+				return ConfirmLoad(ctx, cli.testnet, report.Load)
+			}); err != nil {
 				return err
 			}
-			return nil
+			endBlock, err := getLatestBlock(ctx, cli.testnet)
+			if err != nil {
+				return err
+			}
+			report.SetBlocks(startBlock.Height, startBlock.Time, endBlock.Height, endBlock.Time)
+
+			return report.Stage("test", func() error {
+				report.Tests, err = Test(cli.testnet)
+				return err
+			})
 		},
 	}
 
 	cli.root.PersistentFlags().StringP("file", "f", "", "Testnet TOML manifest")
 	_ = cli.root.MarkPersistentFlagRequired("file")
+	cli.root.PersistentFlags().String("infra", InfraDocker,
+		"Infrastructure running the testnet, docker (Docker Compose) or kubernetes (with kubectl)")
+	cli.root.PersistentFlags().String("k8s-image", "tendermint/e2e-node",
+		"Image of the nodes with --infra kubernetes, which must be pullable by the cluster")
+	cli.root.PersistentFlags().String("k8s-storage-class", "",
+		"Storage class of the node volumes with --infra kubernetes, the cluster default if empty")
+	cli.root.PersistentFlags().String("k8s-storage-size", "1Gi",
+		"Size of each node volume with --infra kubernetes")
 
 	cli.root.Flags().BoolVarP(&cli.preserve, "preserve", "p", false,
 		"Preserves the running of the test net after tests are completed")
+	cli.root.Flags().StringVarP(&cli.reportDir, "report-dir", "r", "",
+		"Writes the results of the run to <testnet>.json and <testnet>.xml (JUnit) in this directory")
 
 	cli.root.SetHelpCommand(&cobra.Command{
 		Use:    "no-help",
@@ -146,30 +221,31 @@ func NewCLI() *CLI {
 		Use:   "setup",
 		Short: "Generates the testnet directory and configuration",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return Setup(cli.testnet)
+			return Setup(cli.testnet, cli.infra)
 		},
 	})
 
 	cli.root.AddCommand(&cobra.Command{
 		Use:   "start",
-		Short: "Starts the Docker testnet, waiting for nodes to become available",
+		Short: "Starts the testnet, waiting for nodes to become available",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			_, err := os.Stat(cli.testnet.Dir)
 			if os.IsNotExist(err) {
-				err = Setup(cli.testnet)
+				err = Setup(cli.testnet, cli.infra)
 			}
 			if err != nil {
 				return err
 			}
-			return Start(cmd.Context(), cli.testnet)
+			return Start(cmd.Context(), cli.testnet, cli.infra)
 		},
 	})
 
 	cli.root.AddCommand(&cobra.Command{
 		Use:   "perturb",
-		Short: "Perturbs the Docker testnet, e.g. by restarting or disconnecting nodes",
+		Short: "Perturbs the testnet, e.g. by restarting or disconnecting nodes",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return Perturb(cmd.Context(), cli.testnet)
+			_, err := Perturb(cmd.Context(), cli.testnet, cli.infra)
+			return err
 		},
 	})
 
@@ -183,28 +259,28 @@ func NewCLI() *CLI {
 
 	cli.root.AddCommand(&cobra.Command{
 		Use:   "stop",
-		Short: "Stops the Docker testnet",
+		Short: "Stops the testnet",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger.Info("Stopping testnet")
-			return execCompose(cli.testnet.Dir, "down")
+			return cli.infra.Stop()
 		},
 	})
 
 	cli.root.AddCommand(&cobra.Command{
 		Use:   "pause",
-		Short: "Pauses the Docker testnet",
+		Short: "Pauses the testnet",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger.Info("Pausing testnet")
-			return execCompose(cli.testnet.Dir, "pause")
+			return cli.infra.Pause()
 		},
 	})
 
 	cli.root.AddCommand(&cobra.Command{
 		Use:   "resume",
-		Short: "Resumes the Docker testnet",
+		Short: "Resumes the testnet",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger.Info("Resuming testnet")
-			return execCompose(cli.testnet.Dir, "unpause")
+			return cli.infra.Resume()
 		},
 	})
 
@@ -212,7 +288,8 @@ func NewCLI() *CLI {
 		Use:   "load",
 		Short: "Generates transaction load until the command is canceled",
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
-			return Load(context.Background(), cli.testnet)
+			_, err = Load(context.Background(), cli.testnet)
+			return err
 		},
 	})
 
@@ -238,7 +315,8 @@ func NewCLI() *CLI {
 		Use:   "test",
 		Short: "Runs test cases against a running testnet",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return Test(cli.testnet)
+			_, err := Test(cli.testnet)
+			return err
 		},
 	})
 
@@ -246,7 +324,7 @@ func NewCLI() *CLI {
 		Use:   "cleanup",
 		Short: "Removes the testnet directory",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return Cleanup(cli.testnet)
+			return cli.infra.Cleanup()
 		},
 	})
 
@@ -256,7 +334,10 @@ func NewCLI() *CLI {
 		Example: "runner logs validator03",
 		Args:    cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return execComposeVerbose(cli.testnet.Dir, append([]string{"logs", "--no-color"}, args...)...)
+			if len(args) == 1 {
+				return cli.infra.Logs(args[0], false)
+			}
+			return cli.infra.Logs("", false)
 		},
 	})
 
@@ -266,9 +347,26 @@ func NewCLI() *CLI {
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 1 {
-				return execComposeVerbose(cli.testnet.Dir, "logs", "--follow", args[0])
+				return cli.infra.Logs(args[0], true)
 			}
-			return execComposeVerbose(cli.testnet.Dir, "logs", "--follow")
+			return cli.infra.Logs("", true)
+		},
+	})
+
+	cli.root.AddCommand(&cobra.Command{
+		Use:   "forward",
+		Short: "Forwards the RPC ports of a Kubernetes testnet until canceled",
+		Long: `Forwards the RPC ports of the nodes of a Kubernetes testnet to the local
+ports they are published on with Docker, until canceled, for the other
+commands to reach a testnet started by another runner.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k8s, ok := cli.infra.(*kubernetesInfra)
+			if !ok {
+				return errors.New("the ports are only forwarded with --infra kubernetes")
+			}
+			ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			return k8s.Forward(ctx)
 		},
 	})
 
@@ -285,16 +383,16 @@ over a 100 block sampling period.
 Does not run any perbutations.
 		`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := Cleanup(cli.testnet); err != nil {
+			if err := cli.infra.Cleanup(); err != nil {
 				return err
 			}
 			defer func() {
-				if err := Cleanup(cli.testnet); err != nil {
+				if err := cli.infra.Cleanup(); err != nil {
 					logger.Error("Error cleaning up testnet contents", "err", err)
 				}
 			}()
 
-			if err := Setup(cli.testnet); err != nil {
+			if err := Setup(cli.testnet, cli.infra); err != nil {
 				return err
 			}
 
@@ -305,11 +403,11 @@ Does not run any perbutations.
 			lctx, loadCancel := context.WithCancel(ctx)
 			defer loadCancel()
 			go func() {
-				err := Load(lctx, cli.testnet)
+				_, err := Load(lctx, cli.testnet)
 				chLoadResult <- err
 			}()
 
-			if err := Start(ctx, cli.testnet); err != nil {
+			if err := Start(ctx, cli.testnet, cli.infra); err != nil {
 				return err
 			}
 
@@ -336,7 +434,13 @@ Does not run any perbutations.
 
 // Run runs the CLI.
 func (cli *CLI) Run() {
-	if err := cli.root.Execute(); err != nil {
+	err := cli.root.Execute()
+	if cli.infra != nil {
+		if err := cli.infra.Close(); err != nil {
+			logger.Error("Error closing the infrastructure", "err", err)
+		}
+	}
+	if err != nil {
 		logger.Error(err.Error())
 		os.Exit(1)
 	}
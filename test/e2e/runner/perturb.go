@@ -9,20 +9,35 @@ import (
 	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
 )
 
-// Perturbs a running testnet.
-func Perturb(ctx context.Context, testnet *e2e.Testnet) error {
+// Perturbs a running testnet, returning the results of the perturbations
+// applied.
+func Perturb(ctx context.Context, testnet *e2e.Testnet, infra Infra) ([]PerturbationResult, error) {
 	timer := time.NewTimer(0) // first tick fires immediately; reset below
 	defer timer.Stop()
 
+	var results []PerturbationResult
 	for _, node := range testnet.Nodes {
 		for _, perturbation := range node.Perturbations {
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return results, ctx.Err()
 			case <-timer.C:
-				_, err := PerturbNode(ctx, node, perturbation)
+				started := time.Now()
+				status, err := PerturbNode(ctx, infra, node, perturbation)
+				result := PerturbationResult{
+					Node:         node.Name,
+					Perturbation: string(perturbation),
+					DurationSecs: time.Since(started).Seconds(),
+				}
+				if status != nil {
+					result.RecoveryHeight = status.SyncInfo.LatestBlockHeight
+				}
+				if err != nil {
+					result.Error = err.Error()
+				}
+				results = append(results, result)
 				if err != nil {
-					return err
+					return results, err
 				}
 
 				// give network some time to recover between each
@@ -30,51 +45,55 @@ func Perturb(ctx context.Context, testnet *e2e.Testnet) error {
 			}
 		}
 	}
-	return nil
+	return results, nil
 }
 
 // PerturbNode perturbs a node with a given perturbation, returning its status
 // after recovering.
-func PerturbNode(ctx context.Context, node *e2e.Node, perturbation e2e.Perturbation) (*rpctypes.ResultStatus, error) {
-	testnet := node.Testnet
+func PerturbNode(
+	ctx context.Context,
+	infra Infra,
+	node *e2e.Node,
+	perturbation e2e.Perturbation,
+) (*rpctypes.ResultStatus, error) {
 	switch perturbation {
 	case e2e.PerturbationDisconnect:
 		logger.Info(fmt.Sprintf("Disconnecting node %v...", node.Name))
-		if err := execDocker("network", "disconnect", testnet.Name+"_"+testnet.Name, node.Name); err != nil {
+		if err := infra.DisconnectNode(ctx, node); err != nil {
 			return nil, err
 		}
 		time.Sleep(10 * time.Second)
-		if err := execDocker("network", "connect", testnet.Name+"_"+testnet.Name, node.Name); err != nil {
+		if err := infra.ConnectNode(ctx, node); err != nil {
 			return nil, err
 		}
 
 	case e2e.PerturbationKill:
 		logger.Info(fmt.Sprintf("Killing node %v...", node.Name))
-		if err := execCompose(testnet.Dir, "kill", "-s", "SIGKILL", node.Name); err != nil {
+		if err := infra.KillNode(ctx, node, "SIGKILL"); err != nil {
 			return nil, err
 		}
 		time.Sleep(10 * time.Second)
-		if err := execCompose(testnet.Dir, "start", node.Name); err != nil {
+		if err := infra.RestartNode(ctx, node); err != nil {
 			return nil, err
 		}
 
 	case e2e.PerturbationPause:
 		logger.Info(fmt.Sprintf("Pausing node %v...", node.Name))
-		if err := execCompose(testnet.Dir, "pause", node.Name); err != nil {
+		if err := infra.PauseNode(ctx, node); err != nil {
 			return nil, err
 		}
 		time.Sleep(10 * time.Second)
-		if err := execCompose(testnet.Dir, "unpause", node.Name); err != nil {
+		if err := infra.UnpauseNode(ctx, node); err != nil {
 			return nil, err
 		}
 
 	case e2e.PerturbationRestart:
 		logger.Info(fmt.Sprintf("Restarting node %v...", node.Name))
-		if err := execCompose(testnet.Dir, "kill", "-s", "SIGTERM", node.Name); err != nil {
+		if err := infra.KillNode(ctx, node, "SIGTERM"); err != nil {
 			return nil, err
 		}
 		time.Sleep(10 * time.Second)
-		if err := execCompose(testnet.Dir, "start", node.Name); err != nil {
+		if err := infra.RestartNode(ctx, node); err != nil {
 			return nil, err
 		}
 
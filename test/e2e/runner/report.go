@@ -0,0 +1,302 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
+)
+
+// Report is the machine-readable result of a run of the runner, written in
+// JSON and JUnit XML for CI systems to display failures and trend the
+// performance of the testnets.
+type Report struct {
+	Testnet       string               `json:"testnet"`
+	Manifest      string               `json:"manifest"`
+	Nodes         int                  `json:"nodes"`
+	Started       time.Time            `json:"started"`
+	DurationSecs  float64              `json:"duration_secs"`
+	Passed        bool                 `json:"passed"`
+	Error         string               `json:"error,omitempty"`
+	Stages        []StageResult        `json:"stages"`
+	Perturbations []PerturbationResult `json:"perturbations,omitempty"`
+	Load          *LoadResult          `json:"load,omitempty"`
+	Blocks        *BlockResult         `json:"blocks,omitempty"`
+	Tests         []TestResult         `json:"tests,omitempty"`
+}
+
+// StageResult is the result of a stage of the run, e.g. setup or perturb.
+type StageResult struct {
+	Name         string  `json:"name"`
+	DurationSecs float64 `json:"duration_secs"`
+	Error        string  `json:"error,omitempty"`
+}
+
+// PerturbationResult is the result of a perturbation of a node.
+type PerturbationResult struct {
+	Node           string  `json:"node"`
+	Perturbation   string  `json:"perturbation"`
+	RecoveryHeight int64   `json:"recovery_height,omitempty"`
+	DurationSecs   float64 `json:"duration_secs"`
+	Error          string  `json:"error,omitempty"`
+}
+
+// LoadResult is the result of the transaction load.
+type LoadResult struct {
+	Txs          int      `json:"txs"`       // submitted
+	Rejected     int      `json:"rejected"`  // by CheckTx
+	Confirmed    int      `json:"confirmed"` // committed
+	Lost         []LostTx `json:"lost,omitempty"`
+	DurationSecs float64  `json:"duration_secs"`
+	Rate         float64  `json:"rate"` // txs submitted per second
+
+	// Broadcasts are the results per broadcast mode, e.g. sync.
+	Broadcasts map[string]*BroadcastResult `json:"broadcasts,omitempty"`
+
+	accepted []loadTx // by CheckTx, to be confirmed
+}
+
+// BroadcastResult is the result of the transactions of the load broadcast
+// with a mode, e.g. async.
+type BroadcastResult struct {
+	Txs         int     `json:"txs"`
+	Rejected    int     `json:"rejected"`     // by CheckTx, unknown for async
+	LatencySecs float64 `json:"latency_secs"` // mean, of the broadcast calls
+}
+
+// LostTx is a transaction accepted by a node which was never committed.
+type LostTx struct {
+	Hash string `json:"hash"`
+	Node string `json:"node"`
+}
+
+// BlockResult is the block production of the testnet during the run.
+type BlockResult struct {
+	StartHeight int64     `json:"start_height"`
+	EndHeight   int64     `json:"end_height"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	Rate        float64   `json:"rate"` // blocks per second
+}
+
+// TestResult is the result of a test case under tests/, the subtests being
+// run per node.
+type TestResult struct {
+	Name         string  `json:"name"`
+	Node         string  `json:"node,omitempty"`
+	Status       string  `json:"status"` // pass, fail or skip
+	DurationSecs float64 `json:"duration_secs"`
+	Output       string  `json:"output,omitempty"`
+}
+
+// NewReport returns the report of a run of the testnet.
+func NewReport(testnet *e2e.Testnet) *Report {
+	return &Report{
+		Testnet:  testnet.Name,
+		Manifest: testnet.File,
+		Nodes:    len(testnet.Nodes),
+		Started:  time.Now(),
+	}
+}
+
+// Stage runs a stage of the run, and records its result.
+func (r *Report) Stage(name string, fn func() error) error {
+	started := time.Now()
+	err := fn()
+	stage := StageResult{Name: name, DurationSecs: time.Since(started).Seconds()}
+	if err != nil {
+		stage.Error = err.Error()
+	}
+	r.Stages = append(r.Stages, stage)
+	return err
+}
+
+// SetBlocks records the block production between the two blocks.
+func (r *Report) SetBlocks(startHeight int64, startTime time.Time, endHeight int64, endTime time.Time) {
+	r.Blocks = &BlockResult{
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+		StartTime:   startTime,
+		EndTime:     endTime,
+	}
+	if d := endTime.Sub(startTime).Seconds(); d > 0 {
+		r.Blocks.Rate = float64(endHeight-startHeight) / d
+	}
+}
+
+// Finish records the outcome of the run.
+func (r *Report) Finish(err error) {
+	r.DurationSecs = time.Since(r.Started).Seconds()
+	r.Passed = err == nil
+	if err != nil {
+		r.Error = err.Error()
+	}
+}
+
+// Write writes the report to <testnet>.json and <testnet>.xml in dir.
+func (r *Report) Write(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	bz, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, r.Testnet+".json"), bz, 0644); err != nil {
+		return err
+	}
+	bz, err = xml.MarshalIndent(r.junit(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, r.Testnet+".xml"), append([]byte(xml.Header), bz...), 0644)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     float64          `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     float64         `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// junit returns the report in JUnit XML: a suite of the stages, one of the
+// perturbations and one of the tests, with a case per node.
+func (r *Report) junit() junitTestSuites {
+	suites := junitTestSuites{Name: r.Testnet, Time: r.DurationSecs}
+
+	stages := junitTestSuite{Name: r.Testnet + ".stages"}
+	for _, stage := range r.Stages {
+		stages.add(junitTestCase{Name: stage.Name, ClassName: stages.Name, Time: stage.DurationSecs},
+			stage.Error, "")
+	}
+	suites.add(stages)
+
+	if len(r.Perturbations) > 0 {
+		perturbations := junitTestSuite{Name: r.Testnet + ".perturbations"}
+		for _, p := range r.Perturbations {
+			perturbations.add(junitTestCase{
+				Name:      p.Node + "/" + p.Perturbation,
+				ClassName: perturbations.Name,
+				Time:      p.DurationSecs,
+			}, p.Error, "")
+		}
+		suites.add(perturbations)
+	}
+
+	if len(r.Tests) > 0 {
+		tests := junitTestSuite{Name: r.Testnet + ".tests"}
+		for _, test := range r.Tests {
+			tc := junitTestCase{Name: test.Name, ClassName: tests.Name, Time: test.DurationSecs}
+			if test.Node != "" {
+				tc.Name, tc.ClassName = test.Node, tests.Name+"."+test.Name
+			}
+			var failure string
+			switch test.Status {
+			case "fail":
+				failure = "failed"
+			case "skip":
+				tc.Skipped = &struct{}{}
+			}
+			tests.add(tc, failure, test.Output)
+		}
+		suites.add(tests)
+	}
+	return suites
+}
+
+func (s *junitTestSuites) add(suite junitTestSuite) {
+	s.Suites = append(s.Suites, suite)
+	s.Tests += suite.Tests
+	s.Failures += suite.Failures
+}
+
+func (s *junitTestSuite) add(tc junitTestCase, failure, output string) {
+	if failure != "" {
+		tc.Failure = &junitFailure{Message: failure, Contents: output}
+		s.Failures++
+	}
+	if tc.Skipped != nil {
+		s.Skipped++
+	}
+	s.Tests++
+	s.Time += tc.Time
+	s.Cases = append(s.Cases, tc)
+}
+
+// testResultRegexp matches the result lines of the verbose output of go
+// tests, e.g. "    --- FAIL: TestApp_Hash/validator01 (0.02s)".
+var testResultRegexp = regexp.MustCompile(`^(\s*)--- (PASS|FAIL|SKIP): (\S+) \(([0-9.]+)s\)$`)
+
+// parseTestResults parses the results of the verbose output of go tests, the
+// output logged by the failed tests being kept.
+func parseTestResults(r io.Reader) ([]TestResult, error) {
+	var (
+		results []TestResult
+		failed  = -1 // the failed test whose output follows, if any
+		indent  string
+	)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := testResultRegexp.FindStringSubmatch(line); m != nil {
+			secs, err := strconv.ParseFloat(m[4], 64)
+			if err != nil {
+				return nil, err
+			}
+			result := TestResult{Name: m[3], Status: strings.ToLower(m[2]), DurationSecs: secs}
+			if i := strings.Index(result.Name, "/"); i >= 0 {
+				result.Name, result.Node = result.Name[:i], result.Name[i+1:]
+			}
+			results = append(results, result)
+			failed, indent = -1, m[1]
+			if result.Status == "fail" {
+				failed = len(results) - 1
+			}
+			continue
+		}
+		// the output of a test is indented below its result
+		if failed >= 0 && strings.HasPrefix(line, indent+"    ") {
+			results[failed].Output += strings.TrimPrefix(line, indent+"    ") + "\n"
+			continue
+		}
+		failed = -1
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the test output: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTestResults(t *testing.T) {
+	output := `=== RUN   TestApp_Hash
+=== RUN   TestApp_Hash/validator01
+=== RUN   TestApp_Hash/full01
+--- FAIL: TestApp_Hash (0.05s)
+    --- PASS: TestApp_Hash/validator01 (0.02s)
+    --- FAIL: TestApp_Hash/full01 (0.03s)
+        app_test.go:42: 
+            	Error:      	Not equal
+=== RUN   TestBlock_Header
+--- SKIP: TestBlock_Header (0.00s)
+FAIL
+`
+	results, err := parseTestResults(strings.NewReader(output))
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Equal(t, TestResult{Name: "TestApp_Hash", Status: "fail", DurationSecs: 0.05}, results[0])
+	assert.Equal(t, TestResult{Name: "TestApp_Hash", Node: "validator01", Status: "pass", DurationSecs: 0.02}, results[1])
+	assert.Equal(t, TestResult{
+		Name:         "TestApp_Hash",
+		Node:         "full01",
+		Status:       "fail",
+		DurationSecs: 0.03,
+		Output:       "app_test.go:42: \n    \tError:      \tNot equal\n",
+	}, results[2])
+	assert.Equal(t, "skip", results[3].Status)
+
+	report := &Report{Testnet: "ci", Tests: results, Stages: []StageResult{{Name: "setup"}}}
+	bz, err := xml.Marshal(report.junit())
+	require.NoError(t, err)
+	assert.True(t, bytes.Contains(bz, []byte(`<testsuites name="ci" tests="5" failures="2"`)), string(bz))
+	assert.True(t, bytes.Contains(bz, []byte(`<testcase name="full01" classname="ci.tests.TestApp_Hash"`)), string(bz))
+}
@@ -38,8 +38,9 @@ const (
 	PrivvalDummyStateFile = "data/dummy_validator_state.json"
 )
 
-// Setup sets up the testnet configuration.
-func Setup(testnet *e2e.Testnet) error {
+// Setup sets up the testnet configuration, and the one of the infrastructure
+// running it.
+func Setup(testnet *e2e.Testnet, infra Infra) error {
 	logger.Info(fmt.Sprintf("Generating testnet files in %q", testnet.Dir))
 
 	err := os.MkdirAll(testnet.Dir, os.ModePerm)
@@ -47,11 +48,7 @@ func Setup(testnet *e2e.Testnet) error {
 		return err
 	}
 
-	compose, err := MakeDockerCompose(testnet)
-	if err != nil {
-		return err
-	}
-	err = ioutil.WriteFile(filepath.Join(testnet.Dir, "docker-compose.yml"), compose, 0644)
+	err = infra.Setup()
 	if err != nil {
 		return err
 	}
@@ -196,6 +193,9 @@ func MakeGenesis(testnet *e2e.Testnet) (types.GenesisDoc, error) {
 	case "", types.ABCIPubKeyTypeEd25519, types.ABCIPubKeyTypeSecp256k1:
 		genesis.ConsensusParams.Validator.PubKeyTypes =
 			append(genesis.ConsensusParams.Validator.PubKeyTypes, types.ABCIPubKeyTypeSecp256k1)
+	case types.ABCIPubKeyTypeBLS:
+		genesis.ConsensusParams.Validator.PubKeyTypes =
+			append(genesis.ConsensusParams.Validator.PubKeyTypes, types.ABCIPubKeyTypeBLS)
 	default:
 		return genesis, errors.New("unsupported KeyType")
 	}
@@ -242,6 +242,7 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 	cfg.P2P.QueueType = node.QueueType
 	cfg.DBBackend = node.Database
 	cfg.StateSync.DiscoveryTime = 5 * time.Second
+	cfg.Consensus.ParanoidMode = true
 	if node.Mode != e2e.ModeLight {
 		cfg.Mode = string(node.Mode)
 	}
@@ -345,17 +346,21 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 // MakeAppConfig generates an ABCI application config for a node.
 func MakeAppConfig(node *e2e.Node) ([]byte, error) {
 	cfg := map[string]interface{}{
-		"chain_id":          node.Testnet.Name,
-		"dir":               "data/app",
-		"listen":            AppAddressUNIX,
-		"mode":              node.Mode,
-		"proxy_port":        node.ProxyPort,
-		"protocol":          "socket",
-		"persist_interval":  node.PersistInterval,
-		"snapshot_interval": node.SnapshotInterval,
-		"retain_blocks":     node.RetainBlocks,
-		"key_type":          node.PrivvalKey.Type(),
-		"use_legacy_p2p":    node.UseLegacyP2P,
+		"chain_id":                     node.Testnet.Name,
+		"dir":                          "data/app",
+		"listen":                       AppAddressUNIX,
+		"mode":                         node.Mode,
+		"proxy_port":                   node.ProxyPort,
+		"protocol":                     "socket",
+		"persist_interval":             node.PersistInterval,
+		"snapshot_interval":            node.SnapshotInterval,
+		"retain_blocks":                node.RetainBlocks,
+		"key_type":                     node.PrivvalKey.Type(),
+		"use_legacy_p2p":               node.UseLegacyP2P,
+		"deliver_tx_delay":             node.DeliverTxDelay.String(),
+		"check_tx_failure_rate":        node.CheckTxFailureRate,
+		"process_proposal_reject_rate": node.ProcessProposalRejectRate,
+		"large_validator_updates":      node.Testnet.LargeValidatorUpdates,
 	}
 	switch node.ABCIProtocol {
 	case e2e.ProtocolUNIX:
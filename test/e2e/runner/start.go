@@ -9,7 +9,7 @@ import (
 	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
 )
 
-func Start(ctx context.Context, testnet *e2e.Testnet) error {
+func Start(ctx context.Context, testnet *e2e.Testnet, infra Infra) error {
 	if len(testnet.Nodes) == 0 {
 		return fmt.Errorf("no nodes in testnet")
 	}
@@ -43,7 +43,7 @@ func Start(ctx context.Context, testnet *e2e.Testnet) error {
 	for len(nodeQueue) > 0 && nodeQueue[0].StartAt == 0 {
 		node := nodeQueue[0]
 		nodeQueue = nodeQueue[1:]
-		if err := execCompose(testnet.Dir, "up", "-d", node.Name); err != nil {
+		if err := infra.StartNode(ctx, node); err != nil {
 			return err
 		}
 
@@ -105,7 +105,7 @@ func Start(ctx context.Context, testnet *e2e.Testnet) error {
 			}
 		}
 
-		if err := execCompose(testnet.Dir, "up", "-d", node.Name); err != nil {
+		if err := infra.StartNode(ctx, node); err != nil {
 			return err
 		}
 
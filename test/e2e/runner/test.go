@@ -1,19 +1,31 @@
 package main
 
 import (
+	"bytes"
 	"os"
 
 	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
 )
 
-// Test runs test cases under tests/
-func Test(testnet *e2e.Testnet) error {
+// Test runs test cases under tests/, returning their results.
+func Test(testnet *e2e.Testnet) ([]TestResult, error) {
 	logger.Info("Running tests in ./tests/...")
 
 	err := os.Setenv("E2E_MANIFEST", testnet.File)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if testnet.Hostnames {
+		if err := os.Setenv("E2E_HOSTNAMES", "1"); err != nil {
+			return nil, err
+		}
 	}
 
-	return execVerbose("./build/tests", "-test.count", "1")
+	var out bytes.Buffer
+	err = execTee(&out, "./build/tests", "-test.count", "1", "-test.v")
+	results, parseErr := parseTestResults(&out)
+	if err != nil {
+		return results, err
+	}
+	return results, parseErr
 }
@@ -11,7 +11,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/rpc/client/http"
+	"github.com/tendermint/tendermint/test/e2e/app"
 	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
 	"github.com/tendermint/tendermint/types"
 )
@@ -71,8 +73,22 @@ func TestApp_Tx(t *testing.T) {
 			WaitTime: time.Minute,
 			BroadcastTx: func(client *http.HTTP) broadcastFunc {
 				return func(ctx context.Context, tx types.Tx) error {
-					_, err := client.BroadcastTxSync(ctx, tx)
-					return err
+					for {
+						res, err := client.BroadcastTxSync(ctx, tx)
+						if err != nil {
+							return err
+						}
+						// the app rejects new txs at random with a
+						// check_tx_failure_rate below 1, only those
+						// rejections are resubmitted
+						if res.Code == app.CodeTypeInjectedFailure {
+							continue
+						}
+						if res.Code != abci.CodeTypeOK {
+							return fmt.Errorf("tx rejected by CheckTx with code %d: %s", res.Code, res.Log)
+						}
+						return nil
+					}
 				}
 			},
 		},
@@ -0,0 +1,56 @@
+package e2e_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/da"
+	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
+)
+
+// Tests that the extended data squares of the blocks are erasure coded, and
+// that an invalid extended square injected in place of the one of a block is
+// caught by a bad encoding proof, which doesn't hold against the data
+// availability header committed to.
+func TestDA_BadEncodingProof(t *testing.T) {
+	testNode(t, func(t *testing.T, node e2e.Node) {
+		client, err := node.Client()
+		require.NoError(t, err)
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+
+		height := status.SyncInfo.LatestBlockHeight
+		block, err := client.Block(ctx, &height)
+		require.NoError(t, err)
+		res, err := client.DataAvailabilityHeader(ctx, &height)
+		require.NoError(t, err)
+		dah := &res.DataAvailabilityHeader
+		require.Equal(t, []byte(block.Block.DataHash), dah.Hash())
+
+		shares, _ := block.Block.Data.ComputeShares()
+		eds, err := da.ExtendShares(uint64(math.Sqrt(float64(len(shares)))), shares.RawShares())
+		require.NoError(t, err)
+		var square [][]byte
+		for i := uint(0); i < eds.Width(); i++ {
+			square = append(square, eds.Row(i)...)
+		}
+		proof, err := da.ProveBadEncoding(height, dah, square)
+		require.NoError(t, err)
+		require.Nil(t, proof, "the square of height %d is not erasure coded", height)
+
+		// a parity share is tampered with, and the square committed to by a
+		// byzantine block producer
+		square[len(square)-1] = bytes.Repeat([]byte{0xFF}, consts.ShareSize)
+		tamperedDAH, err := da.SquareDataAvailabilityHeader(square)
+		require.NoError(t, err)
+		proof, err = da.ProveBadEncoding(height, &tamperedDAH, square)
+		require.NoError(t, err)
+		require.NotNil(t, proof)
+		require.NoError(t, proof.Verify(&tamperedDAH))
+		require.Error(t, proof.Verify(dah))
+	})
+}
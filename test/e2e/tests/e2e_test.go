@@ -63,6 +63,34 @@ func testNode(t *testing.T, testFunc func(*testing.T, e2e.Node)) {
 	}
 }
 
+// testLightNode runs tests for the light nodes of the testnet, like testNode
+// does for the stateful nodes.
+func testLightNode(t *testing.T, testFunc func(*testing.T, e2e.Node)) {
+	t.Helper()
+
+	testnet := loadTestnet(t)
+	nodes := testnet.Nodes
+
+	if name := os.Getenv("E2E_NODE"); name != "" {
+		node := testnet.LookupNode(name)
+		require.NotNil(t, node, "node %q not found in testnet %q", name, testnet.Name)
+		nodes = []*e2e.Node{node}
+	}
+
+	for _, node := range nodes {
+		node := *node
+
+		if node.Mode != e2e.ModeLight {
+			continue
+		}
+
+		t.Run(node.Name, func(t *testing.T) {
+			t.Parallel()
+			testFunc(t, node)
+		})
+	}
+}
+
 // loadTestnet loads the testnet based on the E2E_MANIFEST envvar.
 func loadTestnet(t *testing.T) e2e.Testnet {
 	t.Helper()
@@ -80,6 +108,8 @@ func loadTestnet(t *testing.T) e2e.Testnet {
 
 	testnet, err := e2e.LoadTestnet(manifest)
 	require.NoError(t, err)
+	// the runner sets E2E_HOSTNAMES when the nodes are addressed by name
+	testnet.Hostnames = os.Getenv("E2E_HOSTNAMES") != ""
 	testnetCache[manifest] = *testnet
 	return *testnet
 }
@@ -0,0 +1,54 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
+)
+
+// maxLightLag is the number of blocks the light nodes may lag behind the full
+// nodes, their primary being a full node itself.
+const maxLightLag = 2
+
+// Tests that the light nodes verify the headers up to the height of the full
+// nodes, and that these are the headers of the full nodes.
+func TestLight_VerifiedHeaders(t *testing.T) {
+	testLightNode(t, func(t *testing.T, node e2e.Node) {
+		archiveNodes := node.Testnet.ArchiveNodes()
+		require.NotEmpty(t, archiveNodes, "no archive node to compare the headers with")
+		full, err := archiveNodes[0].Client()
+		require.NoError(t, err)
+		status, err := full.Status(ctx)
+		require.NoError(t, err)
+		fullHeight := status.SyncInfo.LatestBlockHeight
+
+		client, err := node.Client()
+		require.NoError(t, err)
+
+		// the latest commit of a light node is the latest header it verified
+		latest, err := client.Commit(ctx, nil)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, latest.Height, fullHeight-maxLightLag,
+			"light node verified height %v, full node is at %v", latest.Height, fullHeight)
+
+		// the headers before and after the trusted one, which is at least
+		// at the start height, are verified too
+		first, last := node.StartAt, latest.Height
+		if first == 0 {
+			first = node.Testnet.InitialHeight
+		}
+		if last > fullHeight {
+			last = fullHeight
+		}
+		for _, height := range []int64{first, last} {
+			commit, err := client.Commit(ctx, &height)
+			require.NoError(t, err, "light node failed to verify height %v", height)
+			block, err := full.Block(ctx, &height)
+			require.NoError(t, err)
+			require.Equal(t, block.BlockID.Hash, commit.Header.Hash(),
+				"light node verified a different header at height %v", height)
+		}
+	})
+}
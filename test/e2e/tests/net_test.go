@@ -30,8 +30,11 @@ func TestNet_Peers(t *testing.T) {
 			id := peerInfo.ID
 			peer := node.Testnet.LookupNode(string(id))
 			require.NotNil(t, peer, "unknown node %v", id)
-			require.Contains(t, peerInfo.URL, peer.IP.String(),
-				"unexpected IP address for peer %v", id)
+			// the IPs of the nodes addressed by name aren't known
+			if !node.Testnet.Hostnames {
+				require.Contains(t, peerInfo.URL, peer.IP.String(),
+					"unexpected IP address for peer %v", id)
+			}
 			seen[string(id)] = true
 		}
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/test/e2e/app"
 	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
 	"github.com/tendermint/tendermint/types"
 )
@@ -133,6 +134,8 @@ type validatorSchedule struct {
 	Set     *types.ValidatorSet
 	height  int64
 	updates map[int64]map[*e2e.Node]int64
+	keyType string
+	fillers int
 }
 
 func newValidatorSchedule(testnet e2e.Testnet) *validatorSchedule {
@@ -144,6 +147,8 @@ func newValidatorSchedule(testnet e2e.Testnet) *validatorSchedule {
 		height:  testnet.InitialHeight,
 		Set:     types.NewValidatorSet(makeVals(valMap)),
 		updates: testnet.ValidatorUpdates,
+		keyType: testnet.KeyType,
+		fillers: testnet.LargeValidatorUpdates,
 	}
 }
 
@@ -153,8 +158,18 @@ func (s *validatorSchedule) Increment(heights int64) {
 		if s.height > 2 {
 			// validator set updates are offset by 2, since they only take effect
 			// two blocks after they're returned.
+			// the app adds filler validators with every update, and removes
+			// them at the next height.
+			changes := []*types.Validator{}
 			if update, ok := s.updates[s.height-2]; ok {
-				if err := s.Set.UpdateWithChangeSet(makeVals(update)); err != nil {
+				changes = append(changes, makeVals(update)...)
+				changes = append(changes, s.makeFillers(s.height-2, 1)...)
+			}
+			if _, ok := s.updates[s.height-3]; ok && s.height > 3 {
+				changes = append(changes, s.makeFillers(s.height-3, 0)...)
+			}
+			if len(changes) > 0 {
+				if err := s.Set.UpdateWithChangeSet(changes); err != nil {
 					panic(err)
 				}
 			}
@@ -163,6 +178,14 @@ func (s *validatorSchedule) Increment(heights int64) {
 	}
 }
 
+func (s *validatorSchedule) makeFillers(height, power int64) []*types.Validator {
+	vals := make([]*types.Validator, 0, s.fillers)
+	for i := 0; i < s.fillers; i++ {
+		vals = append(vals, types.NewValidator(app.FillerValidatorKey(s.keyType, height, i), power))
+	}
+	return vals
+}
+
 func makeVals(valMap map[*e2e.Node]int64) []*types.Validator {
 	vals := make([]*types.Validator, 0, len(valMap))
 	for node, power := range valMap {
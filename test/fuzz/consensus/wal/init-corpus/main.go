@@ -0,0 +1,66 @@
+// nolint: gosec
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func main() {
+	baseDir := flag.String("base", ".", `where the "corpus" directory will live`)
+	flag.Parse()
+
+	initCorpus(*baseDir)
+}
+
+func initCorpus(baseDir string) {
+	log.SetFlags(0)
+
+	corpusDir := filepath.Join(baseDir, "corpus")
+	if err := os.MkdirAll(corpusDir, 0755); err != nil {
+		log.Fatal(err)
+	}
+
+	// a record of each type of WAL message, in the framed mode of the fuzzer
+	hasVote := tmcons.Message{}
+	if err := hasVote.Wrap(&tmcons.HasVote{Height: 10, Round: 1, Type: tmproto.PrecommitType, Index: 2}); err != nil {
+		log.Fatal(err)
+	}
+	msgs := []*tmcons.WALMessage{
+		{Sum: &tmcons.WALMessage_EventDataRoundState{
+			EventDataRoundState: &tmproto.EventDataRoundState{Height: 10, Round: 1, Step: "RoundStepPropose"},
+		}},
+		{Sum: &tmcons.WALMessage_MsgInfo{
+			MsgInfo: &tmcons.MsgInfo{Msg: hasVote, PeerID: "0123456789abcdef0123456789abcdef01234567"},
+		}},
+		{Sum: &tmcons.WALMessage_TimeoutInfo{
+			TimeoutInfo: &tmcons.TimeoutInfo{Duration: time.Second, Height: 10, Round: 1, Step: 3},
+		}},
+		{Sum: &tmcons.WALMessage_EndHeight{
+			EndHeight: &tmcons.EndHeight{Height: 10},
+		}},
+	}
+
+	for i, msg := range msgs {
+		bz, err := proto.Marshal(&tmcons.TimedWALMessage{Time: time.Unix(1600000000, 0).UTC(), Msg: msg})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		filename := filepath.Join(corpusDir, fmt.Sprintf("%d", i))
+		if err := ioutil.WriteFile(filename, append([]byte{0}, bz...), 0644); err != nil {
+			log.Fatalf("can't write %v to %q: %v", msg, filename, err)
+		}
+		log.Printf("wrote %q", filename)
+	}
+}
@@ -0,0 +1,67 @@
+package wal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+
+	"github.com/tendermint/tendermint/internal/consensus"
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// Fuzz decodes a WAL. If the first byte of the input is even, the rest is the
+// payload of a single WAL record, framed with its valid checksum and length
+// for the fuzzer to reach the decoding of the messages, and otherwise the raw
+// bytes of a WAL. The decoded messages must survive their encoding.
+func Fuzz(data []byte) int {
+	if len(data) == 0 {
+		return -1
+	}
+	wal := data[1:]
+	if data[0]%2 == 0 {
+		wal = Frame(data[1:])
+	}
+
+	dec := consensus.NewWALDecoder(bytes.NewReader(wal))
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if msg != nil {
+				panic("msg != nil on error")
+			}
+			return 0
+		}
+
+		var w bytes.Buffer
+		if err := consensus.NewWALEncoder(&w).Encode(msg); err != nil {
+			panic(err)
+		}
+		encoded := w.Bytes()
+		decoded, err := consensus.NewWALDecoder(bytes.NewReader(encoded)).Decode()
+		if err != nil {
+			panic(err)
+		}
+		w.Reset()
+		if err := consensus.NewWALEncoder(&w).Encode(decoded); err != nil {
+			panic(err)
+		}
+		if !bytes.Equal(encoded, w.Bytes()) {
+			panic("the WAL message changed once decoded")
+		}
+	}
+	return 1
+}
+
+// Frame returns the WAL record of the payload, with its checksum and length.
+func Frame(payload []byte) []byte {
+	record := make([]byte, 8+len(payload))
+	binary.BigEndian.PutUint32(record[0:4], crc32.Checksum(payload, crc32c))
+	binary.BigEndian.PutUint32(record[4:8], uint32(len(payload)))
+	copy(record[8:], payload)
+	return record
+}
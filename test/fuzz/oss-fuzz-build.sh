@@ -6,9 +6,16 @@ export FUZZ_ROOT="github.com/tendermint/tendermint"
 compile_go_fuzzer "$FUZZ_ROOT"/test/fuzz/p2p/addrbook Fuzz fuzz_p2p_addrbook fuzz
 (cd test/fuzz/p2p/pex; go run ./init-corpus/main.go)
 compile_go_fuzzer "$FUZZ_ROOT"/test/fuzz/p2p/pex Fuzz fuzz_p2p_pex fuzz
-(cd test/fuzz/p2p/secret_connection; go run ./init-corpus/main.go)
-compile_go_fuzzer "$FUZZ_ROOT"/test/fuzz/p2p/secret_connection Fuzz fuzz_p2p_secret_connection fuzz
+(cd test/fuzz/p2p/secretconnection; go run ./init-corpus/main.go)
+compile_go_fuzzer "$FUZZ_ROOT"/test/fuzz/p2p/secretconnection Fuzz fuzz_p2p_secret_connection fuzz
+compile_go_fuzzer "$FUZZ_ROOT"/test/fuzz/p2p/handshake Fuzz fuzz_p2p_handshake fuzz
+(cd test/fuzz/p2p/receive; go run ./init-corpus/main.go)
+compile_go_fuzzer "$FUZZ_ROOT"/test/fuzz/p2p/receive Fuzz fuzz_p2p_receive fuzz
 
-compile_go_fuzzer "$FUZZ_ROOT"/test/fuzz/mempool Fuzz fuzz_mempool fuzz
+compile_go_fuzzer "$FUZZ_ROOT"/test/fuzz/mempool/v0 Fuzz fuzz_mempool_v0 fuzz
+compile_go_fuzzer "$FUZZ_ROOT"/test/fuzz/mempool/v1 Fuzz fuzz_mempool_v1 fuzz
+
+(cd test/fuzz/consensus/wal; go run ./init-corpus/main.go)
+compile_go_fuzzer "$FUZZ_ROOT"/test/fuzz/consensus/wal Fuzz fuzz_consensus_wal fuzz
 
 compile_go_fuzzer "$FUZZ_ROOT"/test/fuzz/rpc/jsonrpc/server Fuzz fuzz_rpc_jsonrpc_server fuzz
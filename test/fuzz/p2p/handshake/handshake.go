@@ -0,0 +1,107 @@
+package handshake
+
+import (
+	"encoding/binary"
+	"io"
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	sc "github.com/tendermint/tendermint/internal/p2p/conn"
+)
+
+var (
+	fooPrvKey = ed25519.GenPrivKey()
+	barPrvKey = ed25519.GenPrivKey()
+)
+
+// Fuzz runs the secret connection handshake of two peers, the bytes foo sends
+// bar being tampered with in transit: the input is a list of mutations of 3
+// bytes, the big-endian offset of a byte of the stream and the mask it is
+// xored with. The handshake must fail, without panicking or hanging, unless
+// no byte was changed.
+func Fuzz(data []byte) int {
+	mutations := make(map[int]byte)
+	for ; len(data) >= 3; data = data[3:] {
+		mutations[int(binary.BigEndian.Uint16(data))] ^= data[2]
+	}
+
+	barReader, fooWriter := io.Pipe()
+	fooReader, barWriter := io.Pipe()
+	tampering := &tamperingWriter{w: fooWriter, mutations: mutations}
+	fooConn := &conn{Reader: fooReader, Writer: tampering, closers: []io.Closer{fooReader, fooWriter}}
+	barConn := &conn{Reader: barReader, Writer: barWriter, closers: []io.Closer{barReader, barWriter}}
+
+	var (
+		wg             sync.WaitGroup
+		fooErr, barErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if _, fooErr = sc.MakeSecretConnection(fooConn, fooPrvKey); fooErr != nil {
+			// unblock bar
+			fooConn.Close()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if _, barErr = sc.MakeSecretConnection(barConn, barPrvKey); barErr != nil {
+			barConn.Close()
+		}
+	}()
+	wg.Wait()
+	fooConn.Close()
+	barConn.Close()
+
+	if fooErr == nil && barErr == nil {
+		if tampering.tampered() {
+			panic("handshake succeeded with tampered bytes")
+		}
+		return 0
+	}
+	return 1
+}
+
+// tamperingWriter xors the bytes written at the offsets of the mutations.
+type tamperingWriter struct {
+	w         io.Writer
+	mutations map[int]byte
+
+	mtx     sync.Mutex
+	offset  int
+	applied bool
+}
+
+func (t *tamperingWriter) Write(p []byte) (int, error) {
+	t.mtx.Lock()
+	buf := make([]byte, len(p))
+	copy(buf, p)
+	for i := range buf {
+		if mask := t.mutations[t.offset+i]; mask != 0 {
+			buf[i] ^= mask
+			t.applied = true
+		}
+	}
+	t.offset += len(buf)
+	t.mtx.Unlock()
+	return t.w.Write(buf)
+}
+
+func (t *tamperingWriter) tampered() bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.applied
+}
+
+type conn struct {
+	io.Reader
+	io.Writer
+	closers []io.Closer
+}
+
+func (c *conn) Close() error {
+	for _, closer := range c.closers {
+		closer.Close()
+	}
+	return nil
+}
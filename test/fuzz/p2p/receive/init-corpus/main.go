@@ -0,0 +1,94 @@
+// nolint: gosec
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/gogo/protobuf/proto"
+
+	bcproto "github.com/tendermint/tendermint/proto/tendermint/blocksync"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	protomem "github.com/tendermint/tendermint/proto/tendermint/mempool"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/test/fuzz/p2p/receive"
+)
+
+func main() {
+	baseDir := flag.String("base", ".", `where the "corpus" directory will live`)
+	flag.Parse()
+
+	initCorpus(*baseDir)
+}
+
+// wrapper is implemented by the messages wrapping the messages of a channel.
+type wrapper interface {
+	proto.Message
+	Wrap(proto.Message) error
+}
+
+func initCorpus(baseDir string) {
+	log.SetFlags(0)
+
+	corpusDir := filepath.Join(baseDir, "corpus")
+	if err := os.MkdirAll(corpusDir, 0755); err != nil {
+		log.Fatal(err)
+	}
+
+	// a valid message of each channel, for the fuzzer to mutate
+	msgs := map[int][]proto.Message{
+		0: {
+			&tmcons.NewRoundStep{Height: 10, Round: 1, Step: 3, LastCommitRound: 0},
+			&tmcons.HasVote{Height: 10, Round: 1, Type: tmproto.PrevoteType, Index: 2},
+		},
+		1: {
+			&bcproto.BlockRequest{Height: 10},
+			&bcproto.StatusResponse{Height: 10, Base: 1},
+		},
+		2: {
+			&protomem.Txs{Txs: [][]byte{[]byte("key=value")}},
+		},
+		3: {
+			&ssproto.SnapshotsRequest{},
+			&ssproto.ChunkRequest{Height: 10, Format: 1, Index: 2},
+			&ssproto.LightBlockRequest{Height: 10},
+		},
+		4: {
+			&tmproto.EvidenceList{},
+		},
+		5: {
+			&tmp2p.PexRequest{},
+		},
+	}
+
+	n := 0
+	for channel := range receive.Channels {
+		for _, msg := range msgs[channel] {
+			wrapped := proto.Clone(receive.Channels[channel])
+			if w, ok := wrapped.(wrapper); ok {
+				if err := w.Wrap(msg); err != nil {
+					log.Fatal(err)
+				}
+			} else {
+				wrapped = msg
+			}
+			bz, err := proto.Marshal(wrapped)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			filename := filepath.Join(corpusDir, fmt.Sprintf("%d", n))
+			if err := ioutil.WriteFile(filename, append([]byte{byte(channel)}, bz...), 0644); err != nil {
+				log.Fatalf("can't write %v to %q: %v", msg, filename, err)
+			}
+			log.Printf("wrote %q", filename)
+			n++
+		}
+	}
+}
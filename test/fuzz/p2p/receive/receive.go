@@ -0,0 +1,122 @@
+package receive
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/internal/consensus"
+	bcproto "github.com/tendermint/tendermint/proto/tendermint/blocksync"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	protomem "github.com/tendermint/tendermint/proto/tendermint/mempool"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Channels are the message types of the reactor channels the fuzzer decodes
+// the payloads as, the first byte of the input selecting the channel.
+var Channels = []proto.Message{
+	new(tmcons.Message),
+	new(bcproto.Message),
+	new(protomem.Message),
+	new(ssproto.Message),
+	new(tmproto.EvidenceList),
+	new(tmp2p.PexMessage),
+}
+
+// wrapper is implemented by the messages wrapping the messages of a channel,
+// as p2p.Wrapper.
+type wrapper interface {
+	Unwrap() (proto.Message, error)
+}
+
+// Fuzz decodes the payload as the router does for the reactors, then
+// validates the message as the reactor of the channel does before handling
+// it. The valid messages must survive their encoding.
+func Fuzz(data []byte) int {
+	if len(data) == 0 {
+		return -1
+	}
+	channel, payload := Channels[int(data[0])%len(Channels)], data[1:]
+
+	msg := proto.Clone(channel)
+	msg.Reset()
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return 0
+	}
+	if w, ok := msg.(wrapper); ok {
+		var err error
+		if msg, err = w.Unwrap(); err != nil {
+			return 0
+		}
+	}
+
+	if err := validate(channel, msg); err != nil {
+		return 0
+	}
+	return 1
+}
+
+// validate converts the message to its domain type and validates it, as the
+// reactors do.
+func validate(channel, msg proto.Message) error {
+	switch msg := msg.(type) {
+	case *bcproto.BlockResponse:
+		block, err := types.BlockFromProto(msg.Block)
+		if err != nil {
+			return err
+		}
+		return block.ValidateBasic()
+
+	case *ssproto.LightBlockResponse:
+		if msg.LightBlock == nil {
+			return nil
+		}
+		lb, err := types.LightBlockFromProto(msg.LightBlock)
+		if err != nil {
+			return err
+		}
+		// validated against the chain ID of the block, not to stop at the
+		// check of the chain ID of the reactor
+		chainID := ""
+		if lb.SignedHeader != nil && lb.Header != nil {
+			chainID = lb.ChainID
+		}
+		return lb.ValidateBasic(chainID)
+
+	case *ssproto.ParamsResponse:
+		return types.ConsensusParamsFromProto(msg.ConsensusParams).ValidateConsensusParams()
+
+	case *tmproto.EvidenceList:
+		for i := range msg.Evidence {
+			ev, err := types.EvidenceFromProto(&msg.Evidence[i])
+			if err != nil {
+				return err
+			}
+			if err := ev.ValidateBasic(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if _, ok := channel.(*tmcons.Message); ok {
+		wrapped := new(tmcons.Message)
+		if err := wrapped.Wrap(msg); err != nil {
+			return err
+		}
+		consMsg, err := consensus.MsgFromProto(wrapped)
+		if err != nil {
+			return err
+		}
+		if err := consMsg.ValidateBasic(); err != nil {
+			return err
+		}
+		if _, err := consensus.MsgToProto(consMsg); err != nil {
+			panic(fmt.Sprintf("valid message %v can't be encoded: %v", consMsg, err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto/bls"
+)
+
+// AggregatedCommitBlockVersion is the first block protocol version whose
+// blocks may carry their last commit in aggregated form: the signatures of
+// the validators with BLS keys are left out of the CommitSigs, and aggregated
+// into the single AggregatedSignature of the commit. It shrinks the commit of
+// a chain of hundreds of validators from 64 bytes per signature to 64 bytes.
+const AggregatedCommitBlockVersion uint64 = 12
+
+// CanAggregateCommit returns true if the signatures of the commits of the
+// validator set can be aggregated, that is if all the validators have BLS
+// keys.
+func CanAggregateCommit(vals *ValidatorSet) bool {
+	if vals == nil || len(vals.Validators) == 0 {
+		return false
+	}
+	for _, val := range vals.Validators {
+		if val.PubKey.Type() != bls.KeyType {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAggregated returns true if the signatures of some of the CommitSigs, those
+// without a signature of their own, are aggregated into the
+// AggregatedSignature of the commit.
+func (commit *Commit) IsAggregated() bool {
+	return commit != nil && len(commit.AggregatedSignature) != 0
+}
+
+// Aggregate returns a copy of the commit in which the signatures of the
+// CommitSigs are aggregated into the AggregatedSignature, along with the
+// signatures it already aggregates. The signatures must be BLS signatures.
+func (commit *Commit) Aggregate() (*Commit, error) {
+	sigs := make([][]byte, 0, len(commit.Signatures)+1)
+	if commit.IsAggregated() {
+		sigs = append(sigs, commit.AggregatedSignature)
+	}
+	commitSigs := make([]CommitSig, len(commit.Signatures))
+	for i, commitSig := range commit.Signatures {
+		if len(commitSig.Signature) != 0 {
+			sigs = append(sigs, commitSig.Signature)
+			commitSig.Signature = nil
+		}
+		commitSigs[i] = commitSig
+	}
+	if len(sigs) == 0 {
+		return nil, errors.New("no signatures to aggregate")
+	}
+	aggregated, err := bls.AggregateSignatures(sigs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate the signatures of the commit: %w", err)
+	}
+
+	return &Commit{
+		Height:              commit.Height,
+		Round:               commit.Round,
+		BlockID:             commit.BlockID,
+		Signatures:          commitSigs,
+		AggregatedSignature: aggregated,
+	}, nil
+}
+
+// verifyCommitAggregated verifies an aggregated commit: the signatures left in
+// the CommitSigs one by one, as verifyCommitSingle, and the aggregated
+// signature at once against all the signatures it aggregates, whether they
+// are ignored or not.
+//
+// When looking up the validators by address, the signers of the aggregated
+// signature may not all be in the validator set, in which case it can't be
+// verified and the signatures are only tallied: the caller must then verify
+// the commit against its own validator set with VerifyCommitLight, as the
+// light client and the verification of light client attacks do.
+func verifyCommitAggregated(
+	chainID string,
+	vals *ValidatorSet,
+	commit *Commit,
+	votingPowerNeeded int64,
+	ignoreSig func(CommitSig) bool,
+	countSig func(CommitSig) bool,
+	lookUpByIndex bool,
+) error {
+	var (
+		val                *Validator
+		valIdx             int32
+		seenVals                 = make(map[int32]int, len(commit.Signatures))
+		talliedVotingPower int64 = 0
+		pubKeys            []bls.PubKey
+		msgs               [][]byte
+		unknownSigners     bool
+	)
+	for idx, commitSig := range commit.Signatures {
+		aggregated := len(commitSig.Signature) == 0
+		if commitSig.Absent() || (!aggregated && ignoreSig(commitSig)) {
+			continue
+		}
+
+		// If the vals and commit have a 1-to-1 correspondance we can retrieve
+		// them by index else we need to retrieve them by address
+		if lookUpByIndex {
+			val = vals.Validators[idx]
+		} else {
+			valIdx, val = vals.GetByAddress(commitSig.ValidatorAddress)
+
+			// if the signature doesn't belong to anyone in the validator set
+			// then we just skip over it, but can't verify the aggregate
+			if val == nil {
+				unknownSigners = unknownSigners || aggregated
+				continue
+			}
+
+			// because we are getting validators by address we need to make sure
+			// that the same validator doesn't commit twice
+			if firstIndex, ok := seenVals[valIdx]; ok {
+				secondIndex := idx
+				return fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, secondIndex)
+			}
+			seenVals[valIdx] = idx
+		}
+
+		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
+		if aggregated {
+			pubKey, ok := val.PubKey.(bls.PubKey)
+			if !ok {
+				return fmt.Errorf("signature #%d is aggregated, but of a %s key", idx, val.PubKey.Type())
+			}
+			pubKeys = append(pubKeys, pubKey)
+			msgs = append(msgs, voteSignBytes)
+		} else if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
+			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
+		}
+
+		// If this signature counts then add the voting power of the validator
+		// to the tally
+		if !ignoreSig(commitSig) && countSig(commitSig) {
+			talliedVotingPower += val.VotingPower
+		}
+	}
+
+	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
+		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
+	}
+
+	if unknownSigners {
+		return nil
+	}
+	if len(pubKeys) == 0 {
+		return errors.New("aggregated signature of no signature")
+	}
+	if !bls.VerifyAggregateSignature(pubKeys, msgs, commit.AggregatedSignature) {
+		return fmt.Errorf("wrong aggregated signature: %X", commit.AggregatedSignature)
+	}
+	return nil
+}
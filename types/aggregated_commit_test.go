@@ -0,0 +1,100 @@
+package types
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/bls"
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func blsValidatorPrivValSet(numValidators int, votingPower int64) (*ValidatorSet, []PrivValidator) {
+	var (
+		valz           = make([]*Validator, numValidators)
+		privValidators = make([]PrivValidator, numValidators)
+	)
+	for i := 0; i < numValidators; i++ {
+		privVal := NewMockPVWithParams(bls.GenPrivKey(), false, false)
+		valz[i] = privVal.ExtractIntoValidator(votingPower)
+		privValidators[i] = privVal
+	}
+	sort.Sort(PrivValidatorsByAddress(privValidators))
+	return NewValidatorSet(valz), privValidators
+}
+
+func TestAggregatedCommit(t *testing.T) {
+	const chainID = "test_chain_id"
+	vals, privVals := blsValidatorPrivValSet(4, 10)
+	require.True(t, CanAggregateCommit(vals))
+	edVals, _ := randValidatorPrivValSet(4, 10)
+	require.False(t, CanAggregateCommit(edVals))
+
+	blockID := makeBlockIDRandom()
+	voteSet := NewVoteSet(chainID, 3, 1, tmproto.PrecommitType, vals)
+	commit, err := makeCommit(blockID, 3, 1, voteSet, privVals[:3], time.Now())
+	require.NoError(t, err)
+	require.False(t, commit.IsAggregated())
+
+	aggregated, err := commit.Aggregate()
+	require.NoError(t, err)
+	require.True(t, aggregated.IsAggregated())
+	for _, commitSig := range aggregated.Signatures {
+		require.Empty(t, commitSig.Signature)
+	}
+	require.NoError(t, aggregated.ValidateBasic())
+	assert.NotEqual(t, commit.Hash(), aggregated.Hash())
+	assert.Less(t, aggregated.ToProto().Size(), commit.ToProto().Size())
+
+	require.NoError(t, VerifyCommit(chainID, vals, blockID, 3, aggregated))
+	require.NoError(t, VerifyCommitLight(chainID, vals, blockID, 3, aggregated))
+	require.NoError(t, VerifyCommitLightTrusting(chainID, vals, aggregated, tmmath.Fraction{Numerator: 1, Denominator: 3}))
+
+	// the aggregated commit survives its encoding
+	decoded, err := CommitFromProto(aggregated.ToProto())
+	require.NoError(t, err)
+	assert.Equal(t, aggregated.Hash(), decoded.Hash())
+
+	// a vote set reconstructed from it makes the same commit
+	lastPrecommits := CommitToVoteSet(chainID, aggregated, vals)
+	require.True(t, lastPrecommits.HasTwoThirdsMajority())
+	remade := lastPrecommits.MakeCommit()
+	assert.Equal(t, aggregated.Hash(), remade.Hash())
+
+	// a late precommit is aggregated along with the others
+	pubKey, err := privVals[3].GetPubKey(context.Background())
+	require.NoError(t, err)
+	added, err := signAddVote(privVals[3], &Vote{
+		ValidatorAddress: pubKey.Address(),
+		ValidatorIndex:   3,
+		Height:           3,
+		Round:            1,
+		Type:             tmproto.PrecommitType,
+		BlockID:          blockID,
+		Timestamp:        time.Now(),
+	}, lastPrecommits)
+	require.NoError(t, err)
+	require.True(t, added)
+	mixed := lastPrecommits.MakeCommit()
+	require.NoError(t, VerifyCommit(chainID, vals, blockID, 3, mixed))
+	all, err := mixed.Aggregate()
+	require.NoError(t, err)
+	require.NoError(t, VerifyCommit(chainID, vals, blockID, 3, all))
+
+	// a signature left out of the aggregate
+	partial, err := commit.Aggregate()
+	require.NoError(t, err)
+	partial.Signatures[0].Signature = commit.Signatures[0].Signature
+	require.Error(t, VerifyCommit(chainID, vals, blockID, 3, partial))
+
+	// an aggregate of other signatures
+	tampered, err := commit.Aggregate()
+	require.NoError(t, err)
+	tampered.AggregatedSignature = commit.Signatures[0].Signature
+	require.Error(t, VerifyCommit(chainID, vals, blockID, 3, tampered))
+}
@@ -0,0 +1,215 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/nmt"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// BlobTxTypeID tells the blob txs apart from the other txs.
+const BlobTxTypeID = "BLOB"
+
+// BlobTx is a tx carrying blobs. The blobs are laid out in the messages of
+// the block data, and the tx is included in the block without them, along
+// with the share commitments to them, which the validators verify against the
+// messages, see Data.VerifyBlobCommitments.
+type BlobTx struct {
+	Tx    Tx
+	Blobs []Message
+	// ShareCommitments are the share commitments to the blobs, in order, see
+	// ShareCommitment.
+	ShareCommitments [][]byte
+}
+
+// NewBlobTx returns the envelope of tx carrying the blobs, committed to.
+func NewBlobTx(tx Tx, blobs ...Message) (Tx, error) {
+	btx := BlobTx{Tx: tx, Blobs: blobs, ShareCommitments: make([][]byte, len(blobs))}
+	for i, blob := range blobs {
+		commitment, err := ShareCommitment(blob)
+		if err != nil {
+			return nil, fmt.Errorf("blob %d: %w", i, err)
+		}
+		btx.ShareCommitments[i] = commitment
+	}
+	return btx.Marshal()
+}
+
+// UnmarshalBlobTx returns the blob tx of tx, and whether tx is one.
+func UnmarshalBlobTx(tx Tx) (*BlobTx, bool) {
+	var pbtx tmproto.BlobTx
+	if err := pbtx.Unmarshal(tx); err != nil || pbtx.TypeId != BlobTxTypeID {
+		return nil, false
+	}
+	btx := &BlobTx{
+		Tx:               pbtx.Tx,
+		Blobs:            make([]Message, len(pbtx.Blobs)),
+		ShareCommitments: pbtx.ShareCommitments,
+	}
+	for i, blob := range pbtx.Blobs {
+		btx.Blobs[i] = MessageFromProto(blob)
+	}
+	return btx, true
+}
+
+// Marshal returns the tx of the blob tx.
+func (btx *BlobTx) Marshal() (Tx, error) {
+	pbtx := tmproto.BlobTx{
+		Tx:               btx.Tx,
+		Blobs:            make([]*tmproto.Message, len(btx.Blobs)),
+		ShareCommitments: btx.ShareCommitments,
+		TypeId:           BlobTxTypeID,
+	}
+	for i, blob := range btx.Blobs {
+		pbtx.Blobs[i] = &tmproto.Message{NamespaceId: blob.NamespaceID, Data: blob.Data}
+	}
+	return pbtx.Marshal()
+}
+
+// ValidateBasic checks that the blob tx commits to each of its blobs, if it
+// carries them.
+func (btx *BlobTx) ValidateBasic() error {
+	if len(btx.ShareCommitments) == 0 {
+		return errors.New("blob tx without share commitments")
+	}
+	if len(btx.Blobs) == 0 {
+		return nil
+	}
+	if len(btx.Blobs) != len(btx.ShareCommitments) {
+		return fmt.Errorf("%d share commitments to %d blobs", len(btx.ShareCommitments), len(btx.Blobs))
+	}
+	for i, blob := range btx.Blobs {
+		commitment, err := ShareCommitment(blob)
+		if err != nil {
+			return fmt.Errorf("blob %d: %w", i, err)
+		}
+		if !bytes.Equal(commitment, btx.ShareCommitments[i]) {
+			return fmt.Errorf("wrong share commitment to blob %d. Expected %X, got %X",
+				i, commitment, btx.ShareCommitments[i])
+		}
+	}
+	return nil
+}
+
+// WithoutBlobs returns tx as it is included in the blocks: the blob txs
+// without their blobs, the other txs as they are.
+func (tx Tx) WithoutBlobs() Tx {
+	btx, ok := UnmarshalBlobTx(tx)
+	if !ok || len(btx.Blobs) == 0 {
+		return tx
+	}
+	btx.Blobs = nil
+	stripped, err := btx.Marshal()
+	if err != nil {
+		return tx
+	}
+	return stripped
+}
+
+// SplitBlobs returns the txs as they are included in the blocks, and the blobs
+// of the blob txs among them, to lay out in the messages of the block data.
+func (txs Txs) SplitBlobs() (Txs, []Message) {
+	var blobs []Message
+	stripped := make(Txs, len(txs))
+	for i, tx := range txs {
+		if btx, ok := UnmarshalBlobTx(tx); ok {
+			blobs = append(blobs, btx.Blobs...)
+		}
+		stripped[i] = tx.WithoutBlobs()
+	}
+	return stripped, blobs
+}
+
+// ShareCommitment returns the share commitment to the blob: the root of the
+// namespaced Merkle tree of its message shares, as laid out in the data
+// square.
+func ShareCommitment(blob Message) ([]byte, error) {
+	if len(blob.NamespaceID) != consts.NamespaceSize {
+		return nil, fmt.Errorf("namespace ID must be %d bytes, got %d", consts.NamespaceSize, len(blob.NamespaceID))
+	}
+	if bytes.Compare(blob.NamespaceID, consts.MaxReservedNamespace) <= 0 ||
+		bytes.Compare(blob.NamespaceID, consts.TailPaddingNamespaceID) >= 0 {
+		return nil, fmt.Errorf("namespace ID %X is reserved", blob.NamespaceID)
+	}
+	if len(blob.Data) == 0 {
+		return nil, errors.New("empty blob")
+	}
+
+	rawData, err := blob.MarshalDelimited()
+	if err != nil {
+		return nil, err
+	}
+	tree := nmt.New(consts.NewBaseHashFunc())
+	for _, share := range appendToShares(nil, blob.NamespaceID, rawData) {
+		if err := tree.Push(append(append(make([]byte, 0, len(share.ID)+len(share.Share)), share.ID...),
+			share.Share...)); err != nil {
+			return nil, err
+		}
+	}
+	return tree.Root(), nil
+}
+
+// VerifyBlobCommitments checks that the share commitments of the blob txs of
+// the data are to the blobs laid out in its messages: each of them must be the
+// share commitment to a distinct message. The blob txs must not carry their
+// blobs.
+func (data *Data) VerifyBlobCommitments() error {
+	var commitments [][]byte
+	for i, tx := range data.Txs {
+		btx, ok := UnmarshalBlobTx(tx)
+		if !ok {
+			continue
+		}
+		if len(btx.Blobs) != 0 {
+			return fmt.Errorf("blob tx %d carries its blobs", i)
+		}
+		if err := btx.ValidateBasic(); err != nil {
+			return fmt.Errorf("blob tx %d: %w", i, err)
+		}
+		commitments = append(commitments, btx.ShareCommitments...)
+	}
+	if len(commitments) == 0 {
+		return nil
+	}
+
+	// the messages are committed to by the shares they are split into
+	committed := make(map[string]int, len(data.Messages.MessagesList))
+	for _, msg := range data.Messages.MessagesList {
+		commitment, err := ShareCommitment(msg)
+		if err != nil {
+			continue
+		}
+		committed[string(commitment)]++
+	}
+	for _, commitment := range commitments {
+		if committed[string(commitment)] == 0 {
+			return fmt.Errorf("no message for the share commitment %X", commitment)
+		}
+		committed[string(commitment)]--
+	}
+	return nil
+}
+
+// FindBlob returns the message of the data in the namespace nID whose share
+// commitment is commitment, if any.
+func (data *Data) FindBlob(nID []byte, commitment []byte) (Message, bool) {
+	for _, msg := range data.Messages.MessagesList {
+		if !bytes.Equal(msg.NamespaceID, nID) {
+			continue
+		}
+		if c, err := ShareCommitment(msg); err == nil && bytes.Equal(c, commitment) {
+			return msg, true
+		}
+	}
+	return Message{}, false
+}
+
+// MessagesFromShares parses the messages out of the consecutive message
+// shares, as laid out in the data square.
+func MessagesFromShares(shares [][]byte) ([]Message, error) {
+	return parseMsgShares(shares)
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+func TestBlobTx(t *testing.T) {
+	blob := Message{NamespaceID: []byte{1, 1, 1, 1, 1, 1, 1, 1}, Data: bytes.Repeat([]byte{7}, 1000)}
+	tx, err := NewBlobTx(Tx("pay for blob"), blob)
+	require.NoError(t, err)
+
+	btx, ok := UnmarshalBlobTx(tx)
+	require.True(t, ok)
+	require.NoError(t, btx.ValidateBasic())
+	assert.Equal(t, Tx("pay for blob"), btx.Tx)
+	assert.Equal(t, []Message{blob}, btx.Blobs)
+
+	_, ok = UnmarshalBlobTx(Tx("not a blob tx"))
+	assert.False(t, ok)
+
+	// the blob tx is included without its blob
+	txs, blobs := Txs{Tx("tx"), tx}.SplitBlobs()
+	assert.Equal(t, []Message{blob}, blobs)
+	assert.Equal(t, Tx("tx"), txs[0])
+	assert.Equal(t, tx.WithoutBlobs(), txs[1])
+	stripped, ok := UnmarshalBlobTx(txs[1])
+	require.True(t, ok)
+	assert.Empty(t, stripped.Blobs)
+	assert.Equal(t, btx.ShareCommitments, stripped.ShareCommitments)
+	assert.Equal(t, txs[1], txs[1].WithoutBlobs())
+
+	// the commitment must be to the blob
+	btx.Blobs[0].Data = []byte("another blob")
+	assert.Error(t, btx.ValidateBasic())
+
+	_, err = NewBlobTx(Tx("pay for blob"), Message{NamespaceID: consts.TxNamespaceID, Data: []byte{1}})
+	assert.Error(t, err)
+	_, err = NewBlobTx(Tx("pay for blob"), Message{NamespaceID: []byte{1, 1, 1, 1, 1, 1, 1, 1}})
+	assert.Error(t, err)
+}
+
+func TestDataVerifyBlobCommitments(t *testing.T) {
+	nID := []byte{1, 1, 1, 1, 1, 1, 1, 1}
+	blob := Message{NamespaceID: nID, Data: bytes.Repeat([]byte{7}, 1000)}
+	tx, err := NewBlobTx(Tx("pay for blob"), blob)
+	require.NoError(t, err)
+	txs, blobs := Txs{tx}.SplitBlobs()
+
+	data := Data{Txs: txs, Messages: Messages{MessagesList: blobs}}
+	require.NoError(t, data.VerifyBlobCommitments())
+
+	commitment, err := ShareCommitment(blob)
+	require.NoError(t, err)
+	found, ok := data.FindBlob(nID, commitment)
+	require.True(t, ok)
+	assert.Equal(t, blob, found)
+	_, ok = data.FindBlob([]byte{2, 2, 2, 2, 2, 2, 2, 2}, commitment)
+	assert.False(t, ok)
+
+	// the blob must be laid out in the messages
+	data = Data{Txs: txs}
+	assert.Error(t, data.VerifyBlobCommitments())
+	data = Data{Txs: txs, Messages: Messages{MessagesList: []Message{{NamespaceID: nID, Data: []byte("another blob")}}}}
+	assert.Error(t, data.VerifyBlobCommitments())
+
+	// the blob tx must be included without its blob
+	data = Data{Txs: Txs{tx}, Messages: Messages{MessagesList: blobs}}
+	assert.Error(t, data.VerifyBlobCommitments())
+
+	// each commitment must be to a distinct message
+	data = Data{Txs: Txs{txs[0], txs[0]}, Messages: Messages{MessagesList: blobs}}
+	assert.Error(t, data.VerifyBlobCommitments())
+}
+
+func TestMessagesFromShares(t *testing.T) {
+	blob := Message{NamespaceID: []byte{1, 1, 1, 1, 1, 1, 1, 1}, Data: bytes.Repeat([]byte{7}, 1000)}
+	shares := Messages{MessagesList: []Message{blob}}.SplitIntoShares()
+
+	msgs, err := MessagesFromShares(shares.RawShares())
+	require.NoError(t, err)
+	assert.Equal(t, []Message{blob}, msgs)
+}
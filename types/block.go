@@ -22,7 +22,6 @@ import (
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	"github.com/tendermint/tendermint/pkg/consts"
-	"github.com/tendermint/tendermint/pkg/da"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/version"
 )
@@ -75,13 +74,16 @@ func (b *Block) ValidateBasic() error {
 	if err := b.LastCommit.ValidateBasic(); err != nil {
 		return fmt.Errorf("wrong LastCommit: %v", err)
 	}
+	if b.LastCommit.IsAggregated() && b.Version.Block < AggregatedCommitBlockVersion {
+		return fmt.Errorf("aggregated LastCommit in a block of protocol version %d", b.Version.Block)
+	}
 
 	if w, g := b.LastCommit.Hash(), b.LastCommitHash; !bytes.Equal(w, g) {
 		return fmt.Errorf("wrong Header.LastCommitHash. Expected %X, got %X", w, g)
 	}
 
-	// NOTE: b.Data.Txs may be nil, but b.Data.Hash() still works fine.
-	if w, g := b.Data.Hash(), b.DataHash; !bytes.Equal(w, g) {
+	// NOTE: b.Data.Txs may be nil, but b.dataHash() still works fine.
+	if w, g := b.dataHash(), b.DataHash; !bytes.Equal(w, g) {
 		return fmt.Errorf("wrong Header.DataHash. Expected %X, got %X", w, g)
 	}
 
@@ -105,13 +107,23 @@ func (b *Block) fillHeader() {
 		b.LastCommitHash = b.LastCommit.Hash()
 	}
 	if b.DataHash == nil {
-		b.DataHash = b.Data.Hash()
+		b.DataHash = b.dataHash()
 	}
 	if b.EvidenceHash == nil {
 		b.EvidenceHash = b.Evidence.Hash()
 	}
 }
 
+// dataHash returns the hash of the block data the header commits to: the hash
+// of its data availability header from DataAvailabilityHeaderBlockVersion on,
+// the merkle root of its txs before.
+func (b *Block) dataHash() tmbytes.HexBytes {
+	if b.Version.Block < DataAvailabilityHeaderBlockVersion {
+		return b.Data.Txs.Hash()
+	}
+	return b.Data.Hash()
+}
+
 // Hash computes and returns the block hash.
 // If the block is incomplete, block hash is nil for safety.
 func (b *Block) Hash() tmbytes.HexBytes {
@@ -135,6 +147,23 @@ func (b *Block) MakePartSet(partSize uint32) *PartSet {
 	if b == nil {
 		return nil
 	}
+	return NewPartSetFromData(b.marshal(), partSize)
+}
+
+// MakePartSetForParams returns a PartSet containing parts of the serialized
+// block, of the size the block params give to the blocks of its size, see
+// BlockParams.PartSize. The nodes must all split the blocks as the params say
+// to derive the same block IDs from them.
+func (b *Block) MakePartSetForParams(params BlockParams) *PartSet {
+	if b == nil {
+		return nil
+	}
+	bz := b.marshal()
+	return NewPartSetFromData(bz, params.PartSize(len(bz)))
+}
+
+// marshal returns the serialized block.
+func (b *Block) marshal() []byte {
 	b.mtx.Lock()
 	defer b.mtx.Unlock()
 
@@ -146,7 +175,7 @@ func (b *Block) MakePartSet(partSize uint32) *PartSet {
 	if err != nil {
 		panic(err)
 	}
-	return NewPartSetFromData(bz, partSize)
+	return bz
 }
 
 // HashesTo is a convenience function that checks if a block hashes to the given argument.
@@ -685,6 +714,13 @@ func (cs CommitSig) BlockID(commitBlockID BlockID) BlockID {
 
 // ValidateBasic performs basic validation.
 func (cs CommitSig) ValidateBasic() error {
+	return cs.validateBasic(false)
+}
+
+// validateBasic performs basic validation of the CommitSig of a commit,
+// aggregated or not: the signature of the CommitSig of an aggregated commit
+// may be left out, being aggregated into the signature of the commit.
+func (cs CommitSig) validateBasic(aggregated bool) error {
 	switch cs.BlockIDFlag {
 	case BlockIDFlagAbsent:
 	case BlockIDFlagCommit:
@@ -712,7 +748,7 @@ func (cs CommitSig) ValidateBasic() error {
 			)
 		}
 		// NOTE: Timestamp validation is subtle and handled elsewhere.
-		if len(cs.Signature) == 0 {
+		if len(cs.Signature) == 0 && !aggregated {
 			return errors.New("signature is missing")
 		}
 		if len(cs.Signature) > MaxSignatureSize {
@@ -740,13 +776,15 @@ func (cs *CommitSig) ToProto() *tmproto.CommitSig {
 // FromProto sets a protobuf CommitSig to the given pointer.
 // It returns an error if the CommitSig is invalid.
 func (cs *CommitSig) FromProto(csp tmproto.CommitSig) error {
+	cs.fromProto(csp)
+	return cs.ValidateBasic()
+}
 
+func (cs *CommitSig) fromProto(csp tmproto.CommitSig) {
 	cs.BlockIDFlag = BlockIDFlag(csp.BlockIdFlag)
 	cs.ValidatorAddress = csp.ValidatorAddress
 	cs.Timestamp = csp.Timestamp
 	cs.Signature = csp.Signature
-
-	return cs.ValidateBasic()
 }
 
 //-------------------------------------
@@ -762,6 +800,9 @@ type Commit struct {
 	Round      int32       `json:"round"`
 	BlockID    BlockID     `json:"block_id"`
 	Signatures []CommitSig `json:"signatures"`
+	// AggregatedSignature aggregates the BLS signatures of the CommitSigs
+	// without a signature of their own, see Aggregate.
+	AggregatedSignature []byte `json:"aggregated_signature,omitempty"`
 
 	// Memoized in first call to corresponding method.
 	// NOTE: can't memoize in constructor because constructor isn't used for
@@ -783,12 +824,26 @@ func NewCommit(height int64, round int32, blockID BlockID, commitSigs []CommitSi
 // CommitToVoteSet constructs a VoteSet from the Commit and validator set.
 // Panics if signatures from the commit can't be added to the voteset.
 // Inverse of VoteSet.MakeCommit().
+//
+// The votes of an aggregated commit without a signature of their own are
+// added once the aggregated signature is verified, and the VoteSet keeps the
+// aggregated signature for its commit.
 func CommitToVoteSet(chainID string, commit *Commit, vals *ValidatorSet) *VoteSet {
 	voteSet := NewVoteSet(chainID, commit.Height, commit.Round, tmproto.PrecommitType, vals)
+	if commit.IsAggregated() {
+		if err := VerifyCommit(chainID, vals, commit.BlockID, commit.Height, commit); err != nil {
+			panic(fmt.Sprintf("Failed to reconstruct LastCommit: %v", err))
+		}
+		voteSet.aggregatedSignature = commit.AggregatedSignature
+	}
 	for idx, commitSig := range commit.Signatures {
 		if commitSig.Absent() {
 			continue // OK, some precommits can be missing.
 		}
+		if len(commitSig.Signature) == 0 {
+			voteSet.addAggregatedVote(commit.GetVote(int32(idx)))
+			continue
+		}
 		added, err := voteSet.AddVote(commit.GetVote(int32(idx)))
 		if !added || err != nil {
 			panic(fmt.Sprintf("Failed to reconstruct LastCommit: %v", err))
@@ -901,10 +956,13 @@ func (commit *Commit) ValidateBasic() error {
 			return errors.New("no signatures in commit")
 		}
 		for i, commitSig := range commit.Signatures {
-			if err := commitSig.ValidateBasic(); err != nil {
+			if err := commitSig.validateBasic(commit.IsAggregated()); err != nil {
 				return fmt.Errorf("wrong CommitSig #%d: %v", i, err)
 			}
 		}
+		if len(commit.AggregatedSignature) > MaxSignatureSize {
+			return fmt.Errorf("aggregated signature is too big (max: %d)", MaxSignatureSize)
+		}
 	}
 	return nil
 }
@@ -925,6 +983,10 @@ func (commit *Commit) Hash() tmbytes.HexBytes {
 
 			bs[i] = bz
 		}
+		// the aggregated signature is the last leaf of an aggregated commit
+		if commit.IsAggregated() {
+			bs = append(bs, commit.AggregatedSignature)
+		}
 		commit.hash = merkle.HashFromByteSlices(bs)
 	}
 	return commit.hash
@@ -970,6 +1032,7 @@ func (commit *Commit) ToProto() *tmproto.Commit {
 	c.Height = commit.Height
 	c.Round = commit.Round
 	c.BlockID = commit.BlockID.ToProto()
+	c.AggregatedSignature = commit.AggregatedSignature
 
 	return c
 }
@@ -990,9 +1053,11 @@ func CommitFromProto(cp *tmproto.Commit) (*Commit, error) {
 		return nil, err
 	}
 
+	// the CommitSigs of an aggregated commit may have no signature
 	sigs := make([]CommitSig, len(cp.Signatures))
 	for i := range cp.Signatures {
-		if err := sigs[i].FromProto(cp.Signatures[i]); err != nil {
+		sigs[i].fromProto(cp.Signatures[i])
+		if err := sigs[i].validateBasic(len(cp.AggregatedSignature) != 0); err != nil {
 			return nil, err
 		}
 	}
@@ -1001,6 +1066,7 @@ func CommitFromProto(cp *tmproto.Commit) (*Commit, error) {
 	commit.Height = cp.Height
 	commit.Round = cp.Round
 	commit.BlockID = *bi
+	commit.AggregatedSignature = cp.AggregatedSignature
 
 	return commit, commit.ValidateBasic()
 }
@@ -1043,20 +1109,12 @@ func (data *Data) Hash() tmbytes.HexBytes {
 		return data.hash
 	}
 
-	// compute the data availability header
 	// todo(evan): add the non redundant shares back into the header
-	shares, _ := data.ComputeShares()
-	rawShares := shares.RawShares()
-
-	squareSize := uint64(math.Sqrt(float64(len(shares))))
-
-	eds, err := da.ExtendShares(squareSize, rawShares)
+	dah, err := NewDataAvailabilityHeader(data)
 	if err != nil {
 		panic(err)
 	}
 
-	dah := da.NewDataAvailabilityHeader(eds)
-
 	data.hash = dah.Hash()
 
 	return data.hash
@@ -1101,6 +1159,25 @@ func (data *Data) ComputeShares() (NamespacedShares, int) {
 		tailShares...), curLen
 }
 
+// ShareCount returns the number of shares the data is split into, before it
+// is padded into a square.
+func (data *Data) ShareCount() int {
+	return len(data.Txs.SplitIntoShares()) +
+		len(data.IntermediateStateRoots.SplitIntoShares()) +
+		len(data.Evidence.SplitIntoShares()) +
+		len(data.Messages.SplitIntoShares())
+}
+
+// SquareSize returns the width of the original data square the data is laid
+// out in: the smallest power of two fitting its shares.
+func (data *Data) SquareSize() uint64 {
+	width := uint64(math.Sqrt(float64(paddedLen(data.ShareCount()))))
+	if width < consts.MinSquareSize {
+		return consts.MinSquareSize
+	}
+	return width
+}
+
 // paddedLen calculates the number of shares needed to make a power of 2 square
 // given the current number of shares
 func paddedLen(length int) int {
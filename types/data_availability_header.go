@@ -0,0 +1,53 @@
+package types
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tendermint/tendermint/pkg/da"
+	daproto "github.com/tendermint/tendermint/proto/tendermint/da"
+)
+
+// DataAvailabilityHeaderBlockVersion is the first block protocol version whose
+// header commits to the data availability header of the block data in its
+// DataHash, instead of the merkle root of the txs.
+const DataAvailabilityHeaderBlockVersion uint64 = 11
+
+// DataAvailabilityHeader holds the row and column roots of the extended data
+// square of the block data, see da.DataAvailabilityHeader.
+type DataAvailabilityHeader = da.DataAvailabilityHeader
+
+// NewDataAvailabilityHeader erasure codes the data and returns the data
+// availability header of its extended data square.
+func NewDataAvailabilityHeader(data *Data) (DataAvailabilityHeader, error) {
+	shares, _ := data.ComputeShares()
+	squareSize := uint64(math.Sqrt(float64(len(shares))))
+
+	eds, err := da.ExtendShares(squareSize, shares.RawShares())
+	if err != nil {
+		return DataAvailabilityHeader{}, err
+	}
+	return da.NewDataAvailabilityHeader(eds), nil
+}
+
+// DataAvailabilityHeaderFromProto returns the data availability header of
+// its protobuf form, once validated.
+func DataAvailabilityHeaderFromProto(pb *daproto.DataAvailabilityHeader) (*DataAvailabilityHeader, error) {
+	return da.DataAvailabilityHeaderFromProto(pb)
+}
+
+// ValidateDataAvailabilityHeader checks that the data availability header is
+// the one of a square the blocks can be laid out in, under the block params.
+func ValidateDataAvailabilityHeader(dah *DataAvailabilityHeader, params BlockParams) error {
+	if err := dah.ValidateBasic(); err != nil {
+		return err
+	}
+	squareSize := dah.SquareSize()
+	if squareSize&(squareSize-1) != 0 {
+		return fmt.Errorf("square size %d is not a power of 2", squareSize)
+	}
+	if max := params.SquareSizeUpperBound(); squareSize > max {
+		return fmt.Errorf("square size %d is greater than max square size %d", squareSize, max)
+	}
+	return nil
+}
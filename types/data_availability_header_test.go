@@ -0,0 +1,50 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/version"
+)
+
+func TestDataAvailabilityHeader(t *testing.T) {
+	data := Data{Txs: makeTxs(100, 300)}
+	dah, err := NewDataAvailabilityHeader(&data)
+	require.NoError(t, err)
+	assert.Equal(t, data.SquareSize(), dah.SquareSize())
+	assert.EqualValues(t, data.Hash(), dah.Hash())
+
+	pb, err := dah.ToProto()
+	require.NoError(t, err)
+	got, err := DataAvailabilityHeaderFromProto(pb)
+	require.NoError(t, err)
+	assert.True(t, dah.Equals(got))
+
+	params := DefaultBlockParams()
+	require.NoError(t, ValidateDataAvailabilityHeader(&dah, params))
+	params.MaxSquareSize = dah.SquareSize() / 2
+	assert.Error(t, ValidateDataAvailabilityHeader(&dah, params))
+
+	odd := DataAvailabilityHeader{
+		RowsRoots:   make([][]byte, 6),
+		ColumnRoots: make([][]byte, 6),
+	}
+	assert.Error(t, ValidateDataAvailabilityHeader(&odd, DefaultBlockParams()))
+	assert.Error(t, ValidateDataAvailabilityHeader(nil, DefaultBlockParams()))
+}
+
+func TestBlockDataHashVersion(t *testing.T) {
+	txs := makeTxs(5, 10)
+	block := MakeBlock(1, txs, nil, nil, nil, &Commit{})
+	assert.EqualValues(t, block.Data.Hash(), block.DataHash)
+
+	legacy := &Block{
+		Header:     Header{Version: version.Consensus{Block: DataAvailabilityHeaderBlockVersion - 1}},
+		Data:       Data{Txs: txs},
+		LastCommit: &Commit{},
+	}
+	legacy.fillHeader()
+	assert.EqualValues(t, txs.Hash(), legacy.DataHash)
+}
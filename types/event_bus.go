@@ -45,7 +45,23 @@ func NewEventBus() *EventBus {
 // NewEventBusWithBufferCapacity returns a new event bus with the given buffer capacity.
 func NewEventBusWithBufferCapacity(cap int) *EventBus {
 	// capacity could be exposed later if needed
-	pubsub := tmpubsub.NewServer(tmpubsub.BufferCapacity(cap))
+	return newEventBus(tmpubsub.BufferCapacity(cap))
+}
+
+// NewEventBusWithHistory returns a new event bus which keeps the last
+// historySize events, so that subscriptions can be resumed with SubscribeFrom.
+func NewEventBusWithHistory(historySize int) *EventBus {
+	return newEventBus(tmpubsub.BufferCapacity(defaultCapacity), tmpubsub.EventHistory(historySize))
+}
+
+// NewEventBusWithOptions returns a new event bus, whose pubsub server is
+// created with the given options.
+func NewEventBusWithOptions(options ...tmpubsub.Option) *EventBus {
+	return newEventBus(append([]tmpubsub.Option{tmpubsub.BufferCapacity(defaultCapacity)}, options...)...)
+}
+
+func newEventBus(options ...tmpubsub.Option) *EventBus {
+	pubsub := tmpubsub.NewServer(options...)
 	b := &EventBus{pubsub: pubsub}
 	b.BaseService = *service.NewBaseService(nil, "EventBus", b)
 	return b
@@ -83,6 +99,24 @@ func (b *EventBus) Subscribe(
 	return b.pubsub.Subscribe(ctx, subscriber, query, outCapacity...)
 }
 
+// SubscribeFrom subscribes like Subscribe, first sending the events of the
+// history published after the one with the ID lastEventID.
+func (b *EventBus) SubscribeFrom(
+	ctx context.Context,
+	subscriber string,
+	query tmpubsub.Query,
+	lastEventID string,
+	outCapacity ...int,
+) (Subscription, error) {
+	return b.pubsub.SubscribeFrom(ctx, subscriber, query, lastEventID, outCapacity...)
+}
+
+// SubscribeWithArgs subscribes with the given parameters, e.g. to choose the
+// overflow policy of the subscription.
+func (b *EventBus) SubscribeWithArgs(ctx context.Context, args tmpubsub.SubscribeArgs) (Subscription, error) {
+	return b.pubsub.SubscribeWithArgs(ctx, args)
+}
+
 // This method can be used for a local consensus explorer and synchronous
 // testing. Do not use for for public facing / untrusted subscriptions!
 func (b *EventBus) SubscribeUnbuffered(
@@ -237,6 +271,41 @@ func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpd
 	return b.Publish(EventValidatorSetUpdatesValue, data)
 }
 
+// PublishEventPeerConnected publishes the connection of a peer. Like the
+// other peer lifecycle events, it adds the predefined key PeerIDKey, to
+// subscribe to the events of a given peer.
+func (b *EventBus) PublishEventPeerConnected(data EventDataPeer) error {
+	return b.publishPeerEvent(EventPeerConnectedValue, data)
+}
+
+func (b *EventBus) PublishEventPeerDisconnected(data EventDataPeer) error {
+	return b.publishPeerEvent(EventPeerDisconnectedValue, data)
+}
+
+func (b *EventBus) PublishEventPeerBanned(data EventDataPeer) error {
+	return b.publishPeerEvent(EventPeerBannedValue, data)
+}
+
+func (b *EventBus) publishPeerEvent(eventValue string, data EventDataPeer) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	typeTokens := strings.Split(EventTypeKey, ".")
+	peerTokens := strings.Split(PeerIDKey, ".")
+	events := []types.Event{
+		{
+			Type:       typeTokens[0],
+			Attributes: []types.EventAttribute{{Key: typeTokens[1], Value: eventValue}},
+		},
+		{
+			Type:       peerTokens[0],
+			Attributes: []types.EventAttribute{{Key: peerTokens[1], Value: string(data.NodeID)}},
+		},
+	}
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
 //-----------------------------------------------------------------------------
 type NopEventBus struct{}
 
@@ -324,3 +393,15 @@ func (NopEventBus) PublishEventBlockSyncStatus(data EventDataBlockSyncStatus) er
 func (NopEventBus) PublishEventStateSyncStatus(data EventDataStateSyncStatus) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventPeerConnected(data EventDataPeer) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventPeerDisconnected(data EventDataPeer) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventPeerBanned(data EventDataPeer) error {
+	return nil
+}
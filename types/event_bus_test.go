@@ -315,6 +315,50 @@ func TestEventBusPublishEventNewEvidence(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventPeer(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	peer := EventDataPeer{
+		NodeID:   NodeID("00112233445566778899aabbccddeeff00112233"),
+		Address:  "mconn://00112233445566778899aabbccddeeff00112233@127.0.0.1:26656",
+		Reason:   "peer misbehaved",
+		Duration: time.Hour,
+	}
+
+	// the peer lifecycle events add the peer ID, so the query below should
+	// only match the events of the peer
+	query := fmt.Sprintf("tm.event='PeerDisconnected' AND peer.id='%s'", peer.NodeID)
+	sub, err := eventBus.Subscribe(context.Background(), "test", tmquery.MustParse(query))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		msg := <-sub.Out()
+		assert.Equal(t, peer, msg.Data().(EventDataPeer))
+		close(done)
+	}()
+
+	other := peer
+	other.NodeID = NodeID("ffeeddccbbaa99887766554433221100ffeeddcc")
+	require.NoError(t, eventBus.PublishEventPeerDisconnected(other))
+	require.NoError(t, eventBus.PublishEventPeerConnected(peer))
+	require.NoError(t, eventBus.PublishEventPeerBanned(peer))
+	require.NoError(t, eventBus.PublishEventPeerDisconnected(peer))
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive a peer disconnected event after 1 sec.")
+	}
+}
+
 func TestEventBusPublish(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
@@ -325,7 +369,7 @@ func TestEventBusPublish(t *testing.T) {
 		}
 	})
 
-	const numEventsExpected = 14
+	const numEventsExpected = 17
 
 	sub, err := eventBus.Subscribe(context.Background(), "test", tmquery.Empty{}, numEventsExpected)
 	require.NoError(t, err)
@@ -374,6 +418,12 @@ func TestEventBusPublish(t *testing.T) {
 	require.NoError(t, err)
 	err = eventBus.PublishEventStateSyncStatus(EventDataStateSyncStatus{})
 	require.NoError(t, err)
+	err = eventBus.PublishEventPeerConnected(EventDataPeer{})
+	require.NoError(t, err)
+	err = eventBus.PublishEventPeerDisconnected(EventDataPeer{})
+	require.NoError(t, err)
+	err = eventBus.PublishEventPeerBanned(EventDataPeer{})
+	require.NoError(t, err)
 
 	select {
 	case <-done:
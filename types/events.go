@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmjson "github.com/tendermint/tendermint/libs/json"
@@ -41,6 +42,12 @@ const (
 	EventUnlockValue          = "Unlock"
 	EventValidBlockValue      = "ValidBlock"
 	EventVoteValue            = "Vote"
+
+	// Peer lifecycle events, for monitoring the churn of the peers of the
+	// node.
+	EventPeerBannedValue       = "PeerBanned"
+	EventPeerConnectedValue    = "PeerConnected"
+	EventPeerDisconnectedValue = "PeerDisconnected"
 )
 
 // Pre-populated ABCI Tendermint-reserved events
@@ -106,6 +113,7 @@ func init() {
 	tmjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
 	tmjson.RegisterType(EventDataBlockSyncStatus{}, "tendermint/event/FastSyncStatus")
 	tmjson.RegisterType(EventDataStateSyncStatus{}, "tendermint/event/StateSyncStatus")
+	tmjson.RegisterType(EventDataPeer{}, "tendermint/event/Peer")
 }
 
 // Most event messages are basic types (a block, a transaction)
@@ -164,6 +172,29 @@ type EventDataCompleteProposal struct {
 	Step   string `json:"step"`
 
 	BlockID BlockID `json:"block_id"`
+
+	// When the proposal and the parts of the block were first received, and
+	// from which peers.
+	Propagation *BlockPropagation `json:"propagation,omitempty"`
+}
+
+// BlockPropagation records when a node first received a proposal and each
+// part of its block, and from which peers, to follow the propagation of the
+// blocks from the proposers through the network.
+type BlockPropagation struct {
+	// Proposal is nil if the block was received without its proposal, e.g.
+	// when catching up.
+	Proposal      *FirstSeen  `json:"proposal,omitempty"`
+	ProposalRound int32       `json:"proposal_round"`
+	Parts         []FirstSeen `json:"parts"` // by part index
+	Complete      time.Time   `json:"complete"`
+}
+
+// FirstSeen is when a message was first received and the peer which sent
+// it, empty for the messages of the node itself.
+type FirstSeen struct {
+	Time   time.Time `json:"time"`
+	PeerID NodeID    `json:"peer_id"`
 }
 
 type EventDataVote struct {
@@ -190,6 +221,20 @@ type EventDataStateSyncStatus struct {
 	Height   int64 `json:"height"`
 }
 
+// EventDataPeer is the data of the PeerConnected, PeerDisconnected and
+// PeerBanned events.
+type EventDataPeer struct {
+	NodeID  NodeID `json:"node_id"`
+	Address string `json:"address"`
+
+	// Reason is the error the peer is disconnected or banned for, if any.
+	Reason string `json:"reason,omitempty"`
+
+	// Duration is how long the peer was connected for, when disconnected, or
+	// is banned for, when banned.
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
 // PUBSUB
 
 const (
@@ -206,6 +251,10 @@ const (
 	// events.
 	BlockHeightKey = "block.height"
 
+	// PeerIDKey is a reserved key, used to specify the node ID of the peer of
+	// the peer lifecycle events, see EventBus#PublishEventPeerConnected.
+	PeerIDKey = "peer.id"
+
 	EventTypeBeginBlock = "begin_block"
 	EventTypeEndBlock   = "end_block"
 )
@@ -229,6 +278,9 @@ var (
 	EventQueryVote                = QueryForEvent(EventVoteValue)
 	EventQueryBlockSyncStatus     = QueryForEvent(EventBlockSyncStatusValue)
 	EventQueryStateSyncStatus     = QueryForEvent(EventStateSyncStatusValue)
+	EventQueryPeerConnected       = QueryForEvent(EventPeerConnectedValue)
+	EventQueryPeerDisconnected    = QueryForEvent(EventPeerDisconnectedValue)
+	EventQueryPeerBanned          = QueryForEvent(EventPeerBannedValue)
 )
 
 func EventQueryTxFor(tx Tx) tmpubsub.Query {
@@ -251,3 +303,10 @@ type BlockEventPublisher interface {
 type TxEventPublisher interface {
 	PublishEventTx(EventDataTx) error
 }
+
+// PeerEventPublisher publishes the peer lifecycle events.
+type PeerEventPublisher interface {
+	PublishEventPeerConnected(EventDataPeer) error
+	PublishEventPeerDisconnected(EventDataPeer) error
+	PublishEventPeerBanned(EventDataPeer) error
+}
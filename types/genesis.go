@@ -1,14 +1,18 @@
 package types
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
 	"time"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmtime "github.com/tendermint/tendermint/libs/time"
@@ -86,10 +90,27 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		return err
 	}
 
+	var totalPower int64
+	pubKeys := make(map[string]struct{}, len(genDoc.Validators))
 	for i, v := range genDoc.Validators {
-		if v.Power == 0 {
+		if v.PubKey == nil {
+			return fmt.Errorf("the genesis file cannot contain validators without a public key: %v", v)
+		}
+		if v.Power <= 0 {
 			return fmt.Errorf("the genesis file cannot contain validators with no voting power: %v", v)
 		}
+		if totalPower += v.Power; v.Power > MaxTotalVotingPower || totalPower > MaxTotalVotingPower {
+			return fmt.Errorf("the total voting power of the genesis validators exceeds the maximum %d",
+				MaxTotalVotingPower)
+		}
+		if !genDoc.ConsensusParams.Validator.IsValidPubkeyType(v.PubKey.Type()) {
+			return fmt.Errorf("validator %v in the genesis file uses a %s key, which the consensus params don't allow",
+				v, v.PubKey.Type())
+		}
+		if _, ok := pubKeys[string(v.PubKey.Bytes())]; ok {
+			return fmt.Errorf("duplicate validator %v in the genesis file", v)
+		}
+		pubKeys[string(v.PubKey.Bytes())] = struct{}{}
 		if len(v.Address) > 0 && !bytes.Equal(v.PubKey.Address(), v.Address) {
 			return fmt.Errorf("incorrect address for validator %v in the genesis file, should be %v", v, v.PubKey.Address())
 		}
@@ -98,6 +119,10 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		}
 	}
 
+	if len(genDoc.AppState) > 0 && !json.Valid(genDoc.AppState) {
+		return errors.New("app_state in genesis doc is not valid JSON")
+	}
+
 	if genDoc.GenesisTime.IsZero() {
 		genDoc.GenesisTime = tmtime.Now()
 	}
@@ -105,6 +130,114 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 	return nil
 }
 
+// Hash returns the canonical hash of the genesis doc: the SHA-256 hash of
+// its JSON encoding, with sorted keys and without whitespace. It doesn't
+// depend on the formatting of the genesis file, or on the defaults left out
+// of it, as long as the genesis doc is completed (see ValidateAndComplete).
+func (genDoc *GenesisDoc) Hash() ([]byte, error) {
+	bz, err := tmjson.Marshal(genDoc)
+	if err != nil {
+		return nil, err
+	}
+	bz, err = canonicalJSON(bz)
+	if err != nil {
+		return nil, err
+	}
+	return tmhash.Sum(bz), nil
+}
+
+// canonicalJSON re-encodes bz with sorted object keys and without
+// whitespace. Numbers are kept as they are written.
+func canonicalJSON(bz []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+//------------------------------------------------------------
+// Build a genesis doc
+
+// GenesisBuilder builds a genesis doc deterministically: the same calls give
+// the same genesis doc, and so the same genesis hash. The first error
+// encountered is returned by Build.
+type GenesisBuilder struct {
+	doc GenesisDoc
+	err error
+}
+
+// NewGenesisBuilder starts a genesis doc. The genesis time is required, as
+// it would otherwise default to the current time.
+func NewGenesisBuilder(chainID string, genesisTime time.Time) *GenesisBuilder {
+	b := &GenesisBuilder{doc: GenesisDoc{ChainID: chainID, GenesisTime: genesisTime}}
+	if genesisTime.IsZero() {
+		b.err = errors.New("genesis time must be set")
+	}
+	return b
+}
+
+// InitialHeight sets the height of the first block, 1 by default.
+func (b *GenesisBuilder) InitialHeight(height int64) *GenesisBuilder {
+	b.doc.InitialHeight = height
+	return b
+}
+
+// ConsensusParams sets the consensus params, DefaultConsensusParams by
+// default.
+func (b *GenesisBuilder) ConsensusParams(params *ConsensusParams) *GenesisBuilder {
+	b.doc.ConsensusParams = params
+	return b
+}
+
+// AddValidator adds a validator to the initial validator set.
+func (b *GenesisBuilder) AddValidator(pubKey crypto.PubKey, power int64, name string) *GenesisBuilder {
+	b.doc.Validators = append(b.doc.Validators, GenesisValidator{PubKey: pubKey, Power: power, Name: name})
+	return b
+}
+
+// AppHash sets the initial hash of the application state.
+func (b *GenesisBuilder) AppHash(hash []byte) *GenesisBuilder {
+	b.doc.AppHash = hash
+	return b
+}
+
+// AppState sets the initial application state, which must be JSON. It is
+// canonicalized, with sorted keys and without whitespace.
+func (b *GenesisBuilder) AppState(state []byte) *GenesisBuilder {
+	bz, err := canonicalJSON(state)
+	if err != nil && b.err == nil {
+		b.err = fmt.Errorf("invalid app_state: %w", err)
+	}
+	b.doc.AppState = bz
+	return b
+}
+
+// Build validates and completes the genesis doc.
+func (b *GenesisBuilder) Build() (*GenesisDoc, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	genDoc := b.doc
+	genDoc.Validators = append([]GenesisValidator(nil), b.doc.Validators...)
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+	return &genDoc, nil
+}
+
 //------------------------------------------------------------
 // Make genesis state from file
 
@@ -123,13 +256,72 @@ func GenesisDocFromJSON(jsonBlob []byte) (*GenesisDoc, error) {
 	return &genDoc, err
 }
 
-// GenesisDocFromFile reads JSON data from a file and unmarshalls it into a GenesisDoc.
+// GenesisDocFromReader parses a GenesisDoc in JSON as it is read. Unlike
+// GenesisDocFromJSON, only app_state, which can be very large, is held in
+// memory as a whole, not the JSON data.
+func GenesisDocFromReader(r io.Reader) (*GenesisDoc, error) {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('{') {
+		return nil, errors.New("genesis doc must be a JSON object")
+	}
+
+	// app_state is kept as it is read, the other fields are decoded at once
+	var appState json.RawMessage
+	fields := make(map[string]json.RawMessage)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected %v in genesis doc", tok)
+		}
+		if key == "app_state" {
+			err = dec.Decode(&appState)
+		} else {
+			var field json.RawMessage
+			err = dec.Decode(&field)
+			fields[key] = field
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	bz, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	genDoc := GenesisDoc{}
+	if err := tmjson.Unmarshal(bz, &genDoc); err != nil {
+		return nil, err
+	}
+	if len(appState) > 0 && !bytes.Equal(appState, []byte("null")) {
+		genDoc.AppState = appState
+	}
+
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+
+	return &genDoc, nil
+}
+
+// GenesisDocFromFile reads JSON data from a file and unmarshalls it into a
+// GenesisDoc, as it is read.
 func GenesisDocFromFile(genDocFile string) (*GenesisDoc, error) {
-	jsonBlob, err := ioutil.ReadFile(genDocFile)
+	f, err := os.Open(genDocFile)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't read GenesisDoc file: %w", err)
 	}
-	genDoc, err := GenesisDocFromJSON(jsonBlob)
+	defer f.Close()
+	genDoc, err := GenesisDocFromReader(bufio.NewReader(f))
 	if err != nil {
 		return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", genDocFile, err)
 	}
@@ -1,9 +1,12 @@
 package types
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,6 +52,37 @@ func TestGenesisBad(t *testing.T) {
 				`},"power":"10","name":""}` +
 				`]}`,
 		),
+		// duplicate validator
+		[]byte(
+			`{"chain_id":"mychain", "validators":[` +
+				`{"pub_key":{` +
+				`"type":"tendermint/PubKeyEd25519","value":"AT/+aaL1eB0477Mud9JMm8Sh8BIvOYlPGC9KkIUmFaE="` +
+				`},"power":"10","name":""},` +
+				`{"pub_key":{` +
+				`"type":"tendermint/PubKeyEd25519","value":"AT/+aaL1eB0477Mud9JMm8Sh8BIvOYlPGC9KkIUmFaE="` +
+				`},"power":"5","name":""}` +
+				`]}`,
+		),
+		// negative voting power
+		[]byte(
+			`{"chain_id":"mychain", "validators":[` +
+				`{"pub_key":{` +
+				`"type":"tendermint/PubKeyEd25519","value":"AT/+aaL1eB0477Mud9JMm8Sh8BIvOYlPGC9KkIUmFaE="` +
+				`},"power":"-10","name":""}` +
+				`]}`,
+		),
+		// key type not allowed by the consensus params
+		[]byte(
+			`{"chain_id":"mychain", "consensus_params":{` +
+				`"block":{"max_bytes":"22020096","max_gas":"-1"},` +
+				`"evidence":{"max_age_num_blocks":"100000","max_age_duration":"172800000000000","max_bytes":"1048576"},` +
+				`"validator":{"pub_key_types":["secp256k1"]},"version":{}` +
+				`}, "validators":[` +
+				`{"pub_key":{` +
+				`"type":"tendermint/PubKeyEd25519","value":"AT/+aaL1eB0477Mud9JMm8Sh8BIvOYlPGC9KkIUmFaE="` +
+				`},"power":"10","name":""}` +
+				`]}`,
+		),
 	}
 
 	for _, testCase := range testCases {
@@ -121,6 +155,45 @@ func TestGenesisGood(t *testing.T) {
 	}
 }
 
+func TestGenesisDocFromReader(t *testing.T) {
+	genDocBytes := []byte(
+		`{
+			"genesis_time": "2021-01-01T00:00:00Z",
+			"chain_id": "test-chain-QDKdJr",
+			"initial_height": "1000",
+			"app_state": {"account_owner": "Bob", "accounts": [1, 2, 3]},
+			"validators": [{
+				"pub_key":{"type":"tendermint/PubKeyEd25519","value":"AT/+aaL1eB0477Mud9JMm8Sh8BIvOYlPGC9KkIUmFaE="},
+				"power":"10",
+				"name":""
+			}],
+			"app_hash":""
+		}`,
+	)
+	expected, err := GenesisDocFromJSON(genDocBytes)
+	require.NoError(t, err)
+	genDoc, err := GenesisDocFromReader(bytes.NewReader(genDocBytes))
+	require.NoError(t, err)
+	assert.Equal(t, expected, genDoc)
+	assert.JSONEq(t, `{"account_owner": "Bob", "accounts": [1, 2, 3]}`, string(genDoc.AppState))
+
+	// app_state is optional
+	genDoc, err = GenesisDocFromReader(strings.NewReader(`{"chain_id":"mychain","app_state":null}`))
+	require.NoError(t, err)
+	assert.Empty(t, genDoc.AppState)
+
+	for _, tc := range []string{
+		``,
+		`[]`,
+		`{"chain_id":"mychain"`,
+		`{"chain_id":"mychain","app_state":{"a":}}`,
+		`{"chain_id":""}`,
+	} {
+		_, err := GenesisDocFromReader(strings.NewReader(tc))
+		assert.Error(t, err, tc)
+	}
+}
+
 func TestGenesisSaveAs(t *testing.T) {
 	tmpfile, err := ioutil.TempFile("", "genesis")
 	require.NoError(t, err)
@@ -163,3 +236,77 @@ func randomGenesisDoc() *GenesisDoc {
 		AppHash:         []byte{1, 2, 3},
 	}
 }
+
+func TestGenesisHash(t *testing.T) {
+	genDocBytes := []byte(`{
+		"genesis_time": "2021-10-10T08:20:13.695936996Z",
+		"chain_id": "test-chain",
+		"validators": [{
+			"pub_key":{"type":"tendermint/PubKeyEd25519","value":"AT/+aaL1eB0477Mud9JMm8Sh8BIvOYlPGC9KkIUmFaE="},
+			"power":"10",
+			"name":""
+		}],
+		"app_hash":"",
+		"app_state":{"b": [1, 2], "a": "<&>"}
+	}`)
+	genDoc, err := GenesisDocFromJSON(genDocBytes)
+	require.NoError(t, err)
+	hash, err := genDoc.Hash()
+	require.NoError(t, err)
+	assert.Len(t, hash, 32)
+
+	// the formatting and the defaults left out don't change the hash
+	params, err := tmjson.Marshal(DefaultConsensusParams())
+	require.NoError(t, err)
+	reformatted, err := GenesisDocFromJSON([]byte(`{"app_hash":"","app_state":{"a":"<&>","b":[1,2]},` +
+		`"chain_id":"test-chain","consensus_params":` + string(params) + `,` +
+		`"genesis_time":"2021-10-10T08:20:13.695936996Z","initial_height":"1","validators":[{` +
+		`"pub_key":{"type":"tendermint/PubKeyEd25519","value":"AT/+aaL1eB0477Mud9JMm8Sh8BIvOYlPGC9KkIUmFaE="},` +
+		`"power":"10","name":""}]}`))
+	require.NoError(t, err)
+	reformattedHash, err := reformatted.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, reformattedHash)
+
+	genDoc.ChainID = "other-chain"
+	otherHash, err := genDoc.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+}
+
+func TestGenesisBuilder(t *testing.T) {
+	genesisTime := time.Date(2021, 10, 10, 8, 20, 13, 0, time.UTC)
+	pubKey := ed25519.GenPrivKey().PubKey()
+	build := func() *GenesisBuilder {
+		return NewGenesisBuilder("test-chain", genesisTime).
+			AddValidator(pubKey, 10, "val").
+			AppState([]byte(`{ "b": 1, "a": {"d": null, "c": "x"} }`))
+	}
+
+	genDoc, err := build().Build()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, genDoc.InitialHeight)
+	assert.Equal(t, DefaultConsensusParams(), genDoc.ConsensusParams)
+	assert.Equal(t, pubKey.Address(), genDoc.Validators[0].Address)
+	assert.Equal(t, `{"a":{"c":"x","d":null},"b":1}`, string(genDoc.AppState))
+
+	// building is deterministic
+	other, err := build().Build()
+	require.NoError(t, err)
+	hash, err := genDoc.Hash()
+	require.NoError(t, err)
+	otherHash, err := other.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, otherHash)
+
+	_, err = NewGenesisBuilder("test-chain", time.Time{}).Build()
+	assert.Error(t, err)
+	_, err = build().AppState([]byte(`{"a":`)).Build()
+	assert.Error(t, err)
+	_, err = build().AddValidator(pubKey, 5, "dup").Build()
+	assert.Error(t, err)
+	params := DefaultConsensusParams()
+	params.Block.MaxBytes = MaxBlockSizeBytes + 1
+	_, err = build().ConsensusParams(params).Build()
+	assert.Error(t, err)
+}
@@ -12,6 +12,8 @@ import (
 const (
 	maxNodeInfoSize = 10240 // 10KB
 	maxNumChannels  = 16    // plenty of room for upgrades, for now
+	maxNumFeatures  = 16
+	maxFeatureLen   = 64
 )
 
 // Max size of the NodeInfo struct
@@ -47,12 +49,29 @@ type NodeInfo struct {
 	// ASCIIText fields
 	Moniker string        `json:"moniker"` // arbitrary moniker
 	Other   NodeInfoOther `json:"other"`   // other application specific data
+
+	// Features are the optional protocol features the node supports. Its
+	// peers only use a feature with it if advertised here, so that it can be
+	// rolled out without a new P2P protocol version.
+	Features []string `json:"features"`
 }
 
 // NodeInfoOther is the misc. applcation specific data
 type NodeInfoOther struct {
 	TxIndex    string `json:"tx_index"`
 	RPCAddress string `json:"rpc_address"`
+
+	// EarliestBlockHeight and EarliestAppHash are those of the earliest block
+	// the node serves, once it pruned the previous ones, as of the handshake.
+	EarliestBlockHeight int64          `json:"earliest_block_height"`
+	EarliestAppHash     bytes.HexBytes `json:"earliest_app_hash"`
+	// ServesSnapshots is set if the node has state sync snapshots to serve.
+	ServesSnapshots bool `json:"serves_snapshots"`
+}
+
+// HasFeature returns whether the node advertises the given feature.
+func (info NodeInfo) HasFeature(feature string) bool {
+	return tmstrings.StringInSlice(feature, info.Features)
 }
 
 // ID returns the node's peer ID.
@@ -105,6 +124,22 @@ func (info NodeInfo) Validate() error {
 		channels[ch] = struct{}{}
 	}
 
+	// Validate Features - ensure max and check for duplicates.
+	if len(info.Features) > maxNumFeatures {
+		return fmt.Errorf("info.Features is too long (%v). Max is %v", len(info.Features), maxNumFeatures)
+	}
+	features := make(map[string]struct{})
+	for _, feature := range info.Features {
+		if len(feature) > maxFeatureLen || !tmstrings.IsASCIIText(feature) || tmstrings.ASCIITrim(feature) == "" {
+			return fmt.Errorf("info.Features must be valid non-empty ASCII text of at most %v bytes, but got %v",
+				maxFeatureLen, feature)
+		}
+		if _, ok := features[feature]; ok {
+			return fmt.Errorf("info.Features contains duplicate feature %v", feature)
+		}
+		features[feature] = struct{}{}
+	}
+
 	// Validate Moniker.
 	if !tmstrings.IsASCIIText(info.Moniker) || tmstrings.ASCIITrim(info.Moniker) == "" {
 		return fmt.Errorf("info.Moniker must be valid non-empty ASCII text without tabs, but got %v", info.Moniker)
@@ -123,6 +158,9 @@ func (info NodeInfo) Validate() error {
 	if len(rpcAddr) > 0 && (!tmstrings.IsASCIIText(rpcAddr) || tmstrings.ASCIITrim(rpcAddr) == "") {
 		return fmt.Errorf("info.Other.RPCAddress=%v must be valid ASCII text without tabs", rpcAddr)
 	}
+	if other.EarliestBlockHeight < 0 {
+		return fmt.Errorf("info.Other.EarliestBlockHeight=%v can't be negative", other.EarliestBlockHeight)
+	}
 
 	return nil
 }
@@ -194,6 +232,7 @@ func (info NodeInfo) Copy() NodeInfo {
 		Channels:        info.Channels,
 		Moniker:         info.Moniker,
 		Other:           info.Other,
+		Features:        info.Features,
 	}
 }
 
@@ -213,9 +252,13 @@ func (info NodeInfo) ToProto() *tmp2p.NodeInfo {
 	dni.Channels = info.Channels
 	dni.Moniker = info.Moniker
 	dni.Other = tmp2p.NodeInfoOther{
-		TxIndex:    info.Other.TxIndex,
-		RPCAddress: info.Other.RPCAddress,
+		TxIndex:             info.Other.TxIndex,
+		RPCAddress:          info.Other.RPCAddress,
+		EarliestBlockHeight: info.Other.EarliestBlockHeight,
+		EarliestAppHash:     info.Other.EarliestAppHash,
+		ServesSnapshots:     info.Other.ServesSnapshots,
 	}
+	dni.Features = info.Features
 
 	return dni
 }
@@ -237,9 +280,13 @@ func NodeInfoFromProto(pb *tmp2p.NodeInfo) (NodeInfo, error) {
 		Channels:   pb.Channels,
 		Moniker:    pb.Moniker,
 		Other: NodeInfoOther{
-			TxIndex:    pb.Other.TxIndex,
-			RPCAddress: pb.Other.RPCAddress,
+			TxIndex:             pb.Other.TxIndex,
+			RPCAddress:          pb.Other.RPCAddress,
+			EarliestBlockHeight: pb.Other.EarliestBlockHeight,
+			EarliestAppHash:     pb.Other.EarliestAppHash,
+			ServesSnapshots:     pb.Other.ServesSnapshots,
 		},
+		Features: pb.Features,
 	}
 
 	return dni, nil
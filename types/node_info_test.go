@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	tmnet "github.com/tendermint/tendermint/libs/net"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
 	"github.com/tendermint/tendermint/version"
 )
 
@@ -69,6 +70,15 @@ func TestNodeInfoValidate(t *testing.T) {
 		{"Empty space RPCAddress", func(ni *NodeInfo) { ni.Other.RPCAddress = emptySpace }, true},
 		{"Empty RPCAddress", func(ni *NodeInfo) { ni.Other.RPCAddress = "" }, false},
 		{"Good RPCAddress", func(ni *NodeInfo) { ni.Other.RPCAddress = "0.0.0.0:26657" }, false},
+
+		{"Negative EarliestBlockHeight", func(ni *NodeInfo) { ni.Other.EarliestBlockHeight = -1 }, true},
+		{"Good EarliestBlockHeight", func(ni *NodeInfo) { ni.Other.EarliestBlockHeight = 100 }, false},
+
+		{"Too Many Features", func(ni *NodeInfo) { ni.Features = make([]string, maxNumFeatures+1) }, true},
+		{"Duplicate Feature", func(ni *NodeInfo) { ni.Features = []string{"rekey", "rekey"} }, true},
+		{"Non-ASCII Feature", func(ni *NodeInfo) { ni.Features = []string{nonASCII} }, true},
+		{"Empty Feature", func(ni *NodeInfo) { ni.Features = []string{""} }, true},
+		{"Good Features", func(ni *NodeInfo) { ni.Features = []string{"rekey", "parity-parts"} }, false},
 	}
 
 	nodeKeyID := testNodeID()
@@ -129,6 +139,22 @@ func getFreePort() int {
 	return port
 }
 
+func TestNodeInfoProto(t *testing.T) {
+	ni := testNodeInfo(testNodeID(), "testing")
+	ni.Features = []string{"rekey"}
+	ni.Other.EarliestBlockHeight = 100
+	ni.Other.EarliestAppHash = []byte("app_hash")
+	ni.Other.ServesSnapshots = true
+
+	bz, err := ni.ToProto().Marshal()
+	require.NoError(t, err)
+	var pb tmp2p.NodeInfo
+	require.NoError(t, pb.Unmarshal(bz))
+	decoded, err := NodeInfoFromProto(&pb)
+	require.NoError(t, err)
+	assert.Equal(t, ni, decoded)
+}
+
 func TestNodeInfoCompatible(t *testing.T) {
 	nodeKey1ID := testNodeID()
 	nodeKey2ID := testNodeID()
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/tendermint/tendermint/crypto/bls"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/crypto/sr25519"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmstrings "github.com/tendermint/tendermint/libs/strings"
+	"github.com/tendermint/tendermint/pkg/consts"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
@@ -17,21 +19,32 @@ const (
 	// MaxBlockSizeBytes is the maximum permitted size of the blocks.
 	MaxBlockSizeBytes = 104857600 // 100MB
 
-	// BlockPartSizeBytes is the size of one block part.
+	// BlockPartSizeBytes is the default size of one block part, and the
+	// smallest one.
 	BlockPartSizeBytes uint32 = 65536 // 64kB
 
+	// MaxBlockPartSizeBytes is the largest size of one block part, for the
+	// parts to fit the messages of the consensus reactor.
+	MaxBlockPartSizeBytes uint32 = 524288 // 512kB
+
 	// MaxBlockPartsCount is the maximum number of block parts.
 	MaxBlockPartsCount = (MaxBlockSizeBytes / BlockPartSizeBytes) + 1
 
+	// adaptiveBlockPartsCount is the number of parts above which the parts of
+	// the blocks grow, if the block params let them.
+	adaptiveBlockPartsCount = 64
+
 	ABCIPubKeyTypeEd25519   = ed25519.KeyType
 	ABCIPubKeyTypeSecp256k1 = secp256k1.KeyType
 	ABCIPubKeyTypeSr25519   = sr25519.KeyType
+	ABCIPubKeyTypeBLS       = bls.KeyType
 )
 
 var ABCIPubKeyTypesToNames = map[string]string{
 	ABCIPubKeyTypeEd25519:   ed25519.PubKeyName,
 	ABCIPubKeyTypeSecp256k1: secp256k1.PubKeyName,
 	ABCIPubKeyTypeSr25519:   sr25519.PubKeyName,
+	ABCIPubKeyTypeBLS:       bls.PubKeyName,
 }
 
 // ConsensusParams contains consensus critical parameters that determine the
@@ -56,6 +69,15 @@ type HashedParams struct {
 type BlockParams struct {
 	MaxBytes int64 `json:"max_bytes"`
 	MaxGas   int64 `json:"max_gas"`
+	// MaxSquareSize is the max width of the original data square of the
+	// blocks. 0 stands for consts.MaxSquareSize.
+	MaxSquareSize uint64 `json:"max_square_size"`
+	// PartSizeBytes is the size of the parts the blocks are split in to be
+	// gossiped. 0 stands for BlockPartSizeBytes.
+	PartSizeBytes uint32 `json:"part_size_bytes"`
+	// MaxPartSizeBytes is the size the parts of large blocks grow to, see
+	// PartSize. 0 stands for PartSizeBytes, the parts not growing.
+	MaxPartSizeBytes uint32 `json:"max_part_size_bytes"`
 }
 
 // EvidenceParams determine how we handle evidence of malfeasance.
@@ -88,9 +110,37 @@ func DefaultConsensusParams() *ConsensusParams {
 // DefaultBlockParams returns a default BlockParams.
 func DefaultBlockParams() BlockParams {
 	return BlockParams{
-		MaxBytes: 22020096, // 21MB
-		MaxGas:   -1,
+		MaxBytes:      22020096, // 21MB
+		MaxGas:        -1,
+		MaxSquareSize: consts.MaxSquareSize,
+	}
+}
+
+// SquareSizeUpperBound returns the max width of the original data square of
+// the blocks.
+func (params BlockParams) SquareSizeUpperBound() uint64 {
+	if params.MaxSquareSize == 0 {
+		return consts.MaxSquareSize
+	}
+	return params.MaxSquareSize
+}
+
+// PartSize returns the size of the parts a block of blockSize bytes is split
+// in: PartSizeBytes, doubled while the block has more than 64 parts, up to
+// MaxPartSizeBytes. The larger parts of the large blocks cut the overhead of
+// their proofs, and the round trips to gossip them.
+func (params BlockParams) PartSize(blockSize int) uint32 {
+	size := params.PartSizeBytes
+	if size == 0 {
+		size = BlockPartSizeBytes
+	}
+	for size < params.MaxPartSizeBytes && int64(blockSize) > int64(size)*adaptiveBlockPartsCount {
+		size *= 2
+		if size > params.MaxPartSizeBytes {
+			size = params.MaxPartSizeBytes
+		}
 	}
+	return size
 }
 
 // DefaultEvidenceParams returns a default EvidenceParams.
@@ -142,6 +192,25 @@ func (params ConsensusParams) ValidateConsensusParams() error {
 			params.Block.MaxGas)
 	}
 
+	if size := params.Block.MaxSquareSize; size != 0 &&
+		(size < consts.MinSquareSize || size > consts.MaxSquareSize || size&(size-1) != 0) {
+		return fmt.Errorf("block.MaxSquareSize must be a power of 2 between %d and %d. Got %d",
+			consts.MinSquareSize, consts.MaxSquareSize, size)
+	}
+
+	if size := params.Block.PartSizeBytes; size != 0 &&
+		(size < BlockPartSizeBytes || size > MaxBlockPartSizeBytes) {
+		return fmt.Errorf("block.PartSizeBytes must be between %d and %d. Got %d",
+			BlockPartSizeBytes, MaxBlockPartSizeBytes, size)
+	}
+
+	// the part size of an empty block is the smallest one
+	if size, partSize := params.Block.MaxPartSizeBytes, params.Block.PartSize(0); size != 0 &&
+		(size < partSize || size > MaxBlockPartSizeBytes) {
+		return fmt.Errorf("block.MaxPartSizeBytes must be between the part size %d and %d. Got %d",
+			partSize, MaxBlockPartSizeBytes, size)
+	}
+
 	if params.Evidence.MaxAgeNumBlocks <= 0 {
 		return fmt.Errorf("evidence.MaxAgeNumBlocks must be greater than 0. Got %d",
 			params.Evidence.MaxAgeNumBlocks)
@@ -221,6 +290,17 @@ func (params ConsensusParams) UpdateConsensusParams(params2 *tmproto.ConsensusPa
 	if params2.Block != nil {
 		res.Block.MaxBytes = params2.Block.MaxBytes
 		res.Block.MaxGas = params2.Block.MaxGas
+		// apps unaware of the square size leave it unset
+		if params2.Block.MaxSquareSize != 0 {
+			res.Block.MaxSquareSize = params2.Block.MaxSquareSize
+		}
+		// and of the part sizes
+		if params2.Block.PartSizeBytes != 0 {
+			res.Block.PartSizeBytes = params2.Block.PartSizeBytes
+		}
+		if params2.Block.MaxPartSizeBytes != 0 {
+			res.Block.MaxPartSizeBytes = params2.Block.MaxPartSizeBytes
+		}
 	}
 	if params2.Evidence != nil {
 		res.Evidence.MaxAgeNumBlocks = params2.Evidence.MaxAgeNumBlocks
@@ -241,8 +321,11 @@ func (params ConsensusParams) UpdateConsensusParams(params2 *tmproto.ConsensusPa
 func (params *ConsensusParams) ToProto() tmproto.ConsensusParams {
 	return tmproto.ConsensusParams{
 		Block: &tmproto.BlockParams{
-			MaxBytes: params.Block.MaxBytes,
-			MaxGas:   params.Block.MaxGas,
+			MaxBytes:         params.Block.MaxBytes,
+			MaxGas:           params.Block.MaxGas,
+			MaxSquareSize:    params.Block.MaxSquareSize,
+			PartSizeBytes:    params.Block.PartSizeBytes,
+			MaxPartSizeBytes: params.Block.MaxPartSizeBytes,
 		},
 		Evidence: &tmproto.EvidenceParams{
 			MaxAgeNumBlocks: params.Evidence.MaxAgeNumBlocks,
@@ -261,8 +344,11 @@ func (params *ConsensusParams) ToProto() tmproto.ConsensusParams {
 func ConsensusParamsFromProto(pbParams tmproto.ConsensusParams) ConsensusParams {
 	return ConsensusParams{
 		Block: BlockParams{
-			MaxBytes: pbParams.Block.MaxBytes,
-			MaxGas:   pbParams.Block.MaxGas,
+			MaxBytes:         pbParams.Block.MaxBytes,
+			MaxGas:           pbParams.Block.MaxGas,
+			MaxSquareSize:    pbParams.Block.MaxSquareSize,
+			PartSizeBytes:    pbParams.Block.PartSizeBytes,
+			MaxPartSizeBytes: pbParams.Block.MaxPartSizeBytes,
 		},
 		Evidence: EvidenceParams{
 			MaxAgeNumBlocks: pbParams.Evidence.MaxAgeNumBlocks,
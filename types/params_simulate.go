@@ -0,0 +1,159 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// ParamsEnvironment describes the network the consensus params apply to, for
+// SimulateUpdate to check them against. The checks needing a zero value are
+// skipped.
+type ParamsEnvironment struct {
+	// Validators is the number of validators, signing the commits of the
+	// blocks. Defaults to 1.
+	Validators int
+
+	// BlockTime is the expected time between blocks.
+	BlockTime time.Duration
+
+	// UnbondingPeriod is the unbonding period of the application, within
+	// which the evidence of misbehavior must be committed to be punished.
+	UnbondingPeriod time.Duration
+
+	// TimeoutPropose is the timeout of the nodes waiting for a proposal.
+	TimeoutPropose time.Duration
+
+	// RecvRate is the rate at which the nodes receive the blocks gossiped by
+	// their peers, in bytes per second.
+	RecvRate int64
+
+	// MempoolMaxTxBytes is the size of the largest transaction the mempool
+	// of the nodes accepts.
+	MempoolMaxTxBytes int
+}
+
+// ParamsConflict is a conflict of the consensus params with each other, with
+// the network, or with the params they are updated from.
+type ParamsConflict struct {
+	Param  string `json:"param"` // e.g. block.max_bytes
+	Reason string `json:"reason"`
+}
+
+func (c ParamsConflict) String() string {
+	return fmt.Sprintf("%s: %s", c.Param, c.Reason)
+}
+
+// ParamChange is a change of a consensus param.
+type ParamChange struct {
+	Param string `json:"param"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// ParamsSimulation is the result of the simulation of an update of the
+// consensus params.
+type ParamsSimulation struct {
+	Params    ConsensusParams  `json:"params"` // once updated
+	Changes   []ParamChange    `json:"changes"`
+	Conflicts []ParamsConflict `json:"conflicts"`
+}
+
+// SimulateUpdate applies the update to the params as the application would at
+// the end of a block, and reports the changes and the conflicts of the
+// updated params, before the update is proposed on chain. It returns an error
+// if the updated params are invalid, the nodes halting on such an update.
+func (params ConsensusParams) SimulateUpdate(
+	update *tmproto.ConsensusParams,
+	env ParamsEnvironment,
+) (*ParamsSimulation, error) {
+	updated := params.UpdateConsensusParams(update)
+	if err := updated.ValidateConsensusParams(); err != nil {
+		return nil, fmt.Errorf("invalid consensus params once updated: %w", err)
+	}
+	sim := &ParamsSimulation{
+		Params:  updated,
+		Changes: paramsChanges(params, updated),
+	}
+	conflict := func(param, format string, args ...interface{}) {
+		sim.Conflicts = append(sim.Conflicts, ParamsConflict{Param: param, Reason: fmt.Sprintf(format, args...)})
+	}
+
+	// the blocks must fit the header, the commit and the txs of the mempool,
+	// and be gossiped before the nodes stop waiting for the proposal
+	validators := env.Validators
+	if validators <= 0 {
+		validators = 1
+	}
+	overhead := MaxOverheadForBlock + MaxHeaderBytes + MaxCommitBytes(validators)
+	maxDataBytes := updated.Block.MaxBytes - overhead - updated.Evidence.MaxBytes
+	switch {
+	case updated.Block.MaxBytes < overhead:
+		conflict("block.max_bytes", "%d bytes can't fit the header and the commit of %d validators, of %d bytes",
+			updated.Block.MaxBytes, validators, overhead)
+	case maxDataBytes <= 0:
+		conflict("evidence.max_bytes", "%d bytes of evidence leave no room for the txs in blocks of %d bytes",
+			updated.Evidence.MaxBytes, updated.Block.MaxBytes)
+	case env.MempoolMaxTxBytes > 0 && int64(env.MempoolMaxTxBytes) > maxDataBytes:
+		conflict("block.max_bytes", "the mempool accepts txs of up to %d bytes, which may not fit the %d bytes of txs of a block",
+			env.MempoolMaxTxBytes, maxDataBytes)
+	}
+	if env.RecvRate > 0 && env.TimeoutPropose > 0 {
+		gossip := time.Duration(float64(updated.Block.MaxBytes) / float64(env.RecvRate) * float64(time.Second))
+		if gossip > env.TimeoutPropose {
+			conflict("block.max_bytes", "a full block takes %v to gossip at %d bytes/s, more than the timeout-propose of %v",
+				gossip, env.RecvRate, env.TimeoutPropose)
+		}
+	}
+
+	// the evidence must be committed within the unbonding period to be
+	// punished, and expires once both of its max ages are reached
+	if env.UnbondingPeriod > 0 {
+		if updated.Evidence.MaxAgeDuration > env.UnbondingPeriod {
+			conflict("evidence.max_age_duration", "%v is longer than the unbonding period of %v",
+				updated.Evidence.MaxAgeDuration, env.UnbondingPeriod)
+		}
+		if env.BlockTime > 0 {
+			age := time.Duration(updated.Evidence.MaxAgeNumBlocks) * env.BlockTime
+			if age > env.UnbondingPeriod {
+				conflict("evidence.max_age_num_blocks", "%d blocks last %v, longer than the unbonding period of %v",
+					updated.Evidence.MaxAgeNumBlocks, age, env.UnbondingPeriod)
+			}
+		}
+	}
+
+	// the update can't be undone for the validators and the application
+	// already relying on the params
+	for _, keyType := range params.Validator.PubKeyTypes {
+		if !updated.Validator.IsValidPubkeyType(keyType) {
+			conflict("validator.pub_key_types", "the validators with %s keys can't be updated anymore", keyType)
+		}
+	}
+	if updated.Version.AppVersion < params.Version.AppVersion {
+		conflict("version.app_version", "decreases from %d to %d",
+			params.Version.AppVersion, updated.Version.AppVersion)
+	}
+	return sim, nil
+}
+
+// paramsChanges returns the changes of the params from old to updated.
+func paramsChanges(old, updated ConsensusParams) []ParamChange {
+	var changes []ParamChange
+	add := func(param string, o, n interface{}) {
+		if o, n := fmt.Sprint(o), fmt.Sprint(n); o != n {
+			changes = append(changes, ParamChange{Param: param, Old: o, New: n})
+		}
+	}
+	add("block.max_bytes", old.Block.MaxBytes, updated.Block.MaxBytes)
+	add("block.max_gas", old.Block.MaxGas, updated.Block.MaxGas)
+	add("block.max_square_size", old.Block.MaxSquareSize, updated.Block.MaxSquareSize)
+	add("block.part_size_bytes", old.Block.PartSizeBytes, updated.Block.PartSizeBytes)
+	add("block.max_part_size_bytes", old.Block.MaxPartSizeBytes, updated.Block.MaxPartSizeBytes)
+	add("evidence.max_age_num_blocks", old.Evidence.MaxAgeNumBlocks, updated.Evidence.MaxAgeNumBlocks)
+	add("evidence.max_age_duration", old.Evidence.MaxAgeDuration, updated.Evidence.MaxAgeDuration)
+	add("evidence.max_bytes", old.Evidence.MaxBytes, updated.Evidence.MaxBytes)
+	add("validator.pub_key_types", old.Validator.PubKeyTypes, updated.Validator.PubKeyTypes)
+	add("version.app_version", old.Version.AppVersion, updated.Version.AppVersion)
+	return changes
+}
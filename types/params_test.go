@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/pkg/consts"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
@@ -165,6 +167,87 @@ func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 	assert.EqualValues(t, 1, updated.Version.AppVersion)
 }
 
+func TestConsensusParamsMaxSquareSize(t *testing.T) {
+	params := makeParams(1, 2, 3, 0, valEd25519)
+	assert.EqualValues(t, consts.MaxSquareSize, params.Block.SquareSizeUpperBound())
+
+	for size, valid := range map[uint64]bool{
+		1:                        true,
+		16:                       true,
+		consts.MaxSquareSize:     true,
+		3:                        false,
+		consts.MaxSquareSize * 2: false,
+	} {
+		params.Block.MaxSquareSize = size
+		if valid {
+			assert.NoError(t, params.ValidateConsensusParams(), size)
+			assert.Equal(t, size, params.Block.SquareSizeUpperBound())
+		} else {
+			assert.Error(t, params.ValidateConsensusParams(), size)
+		}
+	}
+
+	// updates which don't set the square size leave it unchanged
+	params.Block.MaxSquareSize = 16
+	updated := params.UpdateConsensusParams(
+		&tmproto.ConsensusParams{Block: &tmproto.BlockParams{MaxBytes: 100, MaxGas: 200}})
+	assert.EqualValues(t, 16, updated.Block.MaxSquareSize)
+	updated = params.UpdateConsensusParams(
+		&tmproto.ConsensusParams{Block: &tmproto.BlockParams{MaxBytes: 100, MaxGas: 200, MaxSquareSize: 32}})
+	assert.EqualValues(t, 32, updated.Block.MaxSquareSize)
+}
+
+func TestConsensusParamsPartSize(t *testing.T) {
+	params := makeParams(1, 2, 3, 0, valEd25519)
+	assert.Equal(t, BlockPartSizeBytes, params.Block.PartSize(0))
+	assert.Equal(t, BlockPartSizeBytes, params.Block.PartSize(MaxBlockSizeBytes))
+
+	for size, valid := range map[uint32]bool{
+		BlockPartSizeBytes:        true,
+		2 * BlockPartSizeBytes:    true,
+		MaxBlockPartSizeBytes:     true,
+		BlockPartSizeBytes - 1:    false,
+		MaxBlockPartSizeBytes + 1: false,
+	} {
+		params.Block.PartSizeBytes = size
+		if valid {
+			assert.NoError(t, params.ValidateConsensusParams(), size)
+			assert.Equal(t, size, params.Block.PartSize(MaxBlockSizeBytes))
+		} else {
+			assert.Error(t, params.ValidateConsensusParams(), size)
+		}
+	}
+
+	// the max part size can't be smaller than the part size
+	params.Block.PartSizeBytes = 2 * BlockPartSizeBytes
+	params.Block.MaxPartSizeBytes = BlockPartSizeBytes
+	assert.Error(t, params.ValidateConsensusParams())
+
+	// the parts double while the blocks have more than 64 parts, up to the
+	// max part size
+	params.Block.PartSizeBytes = 0
+	params.Block.MaxPartSizeBytes = 3 * BlockPartSizeBytes
+	assert.NoError(t, params.ValidateConsensusParams())
+	for blockSize, partSize := range map[int]uint32{
+		100:                             BlockPartSizeBytes,
+		64 * int(BlockPartSizeBytes):    BlockPartSizeBytes,
+		64*int(BlockPartSizeBytes) + 1:  2 * BlockPartSizeBytes,
+		128 * int(BlockPartSizeBytes):   2 * BlockPartSizeBytes,
+		128*int(BlockPartSizeBytes) + 1: 3 * BlockPartSizeBytes,
+		MaxBlockSizeBytes:               3 * BlockPartSizeBytes,
+	} {
+		assert.Equal(t, partSize, params.Block.PartSize(blockSize), blockSize)
+	}
+
+	// updates which don't set the part sizes leave them unchanged
+	updated := params.UpdateConsensusParams(
+		&tmproto.ConsensusParams{Block: &tmproto.BlockParams{MaxBytes: 100, MaxGas: 200}})
+	assert.EqualValues(t, 3*BlockPartSizeBytes, updated.Block.MaxPartSizeBytes)
+	updated = params.UpdateConsensusParams(
+		&tmproto.ConsensusParams{Block: &tmproto.BlockParams{MaxBytes: 100, MaxGas: 200, PartSizeBytes: 2 * BlockPartSizeBytes}})
+	assert.EqualValues(t, 2*BlockPartSizeBytes, updated.Block.PartSizeBytes)
+}
+
 func TestProto(t *testing.T) {
 	params := []ConsensusParams{
 		makeParams(4, 2, 3, 1, valEd25519),
@@ -186,3 +269,57 @@ func TestProto(t *testing.T) {
 
 	}
 }
+
+func TestConsensusParamsSimulateUpdate(t *testing.T) {
+	params := *DefaultConsensusParams()
+	env := ParamsEnvironment{
+		Validators:        100,
+		BlockTime:         time.Second,
+		UnbondingPeriod:   21 * 24 * time.Hour,
+		TimeoutPropose:    10 * time.Second,
+		RecvRate:          5120000,
+		MempoolMaxTxBytes: 1048576,
+	}
+
+	sim, err := params.SimulateUpdate(nil, env)
+	require.NoError(t, err)
+	assert.Equal(t, params, sim.Params)
+	assert.Empty(t, sim.Changes)
+	assert.Empty(t, sim.Conflicts)
+
+	_, err = params.SimulateUpdate(&tmproto.ConsensusParams{Block: &tmproto.BlockParams{MaxBytes: 0}}, env)
+	assert.Error(t, err)
+
+	sim, err = params.SimulateUpdate(&tmproto.ConsensusParams{
+		Block: &tmproto.BlockParams{MaxBytes: 50 * 1024 * 1024, MaxGas: -1},
+		Evidence: &tmproto.EvidenceParams{
+			MaxAgeNumBlocks: 100000,
+			MaxAgeDuration:  30 * 24 * time.Hour,
+			MaxBytes:        1048576,
+		},
+		Validator: &tmproto.ValidatorParams{PubKeyTypes: valSecp256k1},
+	}, env)
+	require.NoError(t, err)
+	assert.EqualValues(t, 50*1024*1024, sim.Params.Block.MaxBytes)
+	changes := make([]string, 0, len(sim.Changes))
+	for _, c := range sim.Changes {
+		changes = append(changes, c.Param)
+	}
+	assert.Equal(t, []string{"block.max_bytes", "evidence.max_age_duration", "validator.pub_key_types"}, changes)
+	conflicts := make([]string, 0, len(sim.Conflicts))
+	for _, c := range sim.Conflicts {
+		conflicts = append(conflicts, c.Param)
+	}
+	// a full block takes 10s to gossip, the evidence outlives the unbonding
+	// period, and the ed25519 validators can't be updated anymore
+	assert.Equal(t, []string{"block.max_bytes", "evidence.max_age_duration", "validator.pub_key_types"}, conflicts)
+
+	// the evidence leaves no room for the txs
+	sim, err = params.SimulateUpdate(&tmproto.ConsensusParams{
+		Block:    &tmproto.BlockParams{MaxBytes: 1048576, MaxGas: -1},
+		Evidence: &tmproto.EvidenceParams{MaxAgeNumBlocks: 1, MaxAgeDuration: time.Hour, MaxBytes: 1048576},
+	}, env)
+	require.NoError(t, err)
+	require.Len(t, sim.Conflicts, 1)
+	assert.Equal(t, "evidence.max_bytes", sim.Conflicts[0].Param)
+}
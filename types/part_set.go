@@ -28,8 +28,8 @@ type Part struct {
 
 // ValidateBasic performs basic validation.
 func (part *Part) ValidateBasic() error {
-	if len(part.Bytes) > int(BlockPartSizeBytes) {
-		return fmt.Errorf("too big: %d bytes, max: %d", len(part.Bytes), BlockPartSizeBytes)
+	if len(part.Bytes) > int(MaxBlockPartSizeBytes) {
+		return fmt.Errorf("too big: %d bytes, max: %d", len(part.Bytes), MaxBlockPartSizeBytes)
 	}
 	if err := part.Proof.ValidateBasic(); err != nil {
 		return fmt.Errorf("wrong Proof: %w", err)
@@ -303,6 +303,25 @@ func (ps *PartSet) IsComplete() bool {
 	return ps.count == ps.total
 }
 
+// HasPartSize returns whether the part set is complete, and split in parts of
+// partSize bytes as NewPartSetFromData splits its data: the last part holding
+// the remaining bytes.
+func (ps *PartSet) HasPartSize(partSize uint32) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.count != ps.total {
+		return false
+	}
+	for i, part := range ps.parts {
+		last := i == len(ps.parts)-1
+		if (!last && len(part.Bytes) != int(partSize)) || (last && len(part.Bytes) > int(partSize)) {
+			return false
+		}
+	}
+	return true
+}
+
 func (ps *PartSet) GetReader() io.Reader {
 	if !ps.IsComplete() {
 		panic("Cannot GetReader() on incomplete PartSet")
@@ -29,8 +29,12 @@ func TestBasicPartSet(t *testing.T) {
 	assert.EqualValues(t, nParts, partSet.Count())
 	assert.EqualValues(t, testPartSize*nParts, partSet.ByteSize())
 
+	assert.True(t, partSet.HasPartSize(testPartSize))
+	assert.False(t, partSet.HasPartSize(2*testPartSize))
+
 	// Test adding parts to a new partSet.
 	partSet2 := NewPartSetFromHeader(partSet.Header())
+	assert.False(t, partSet2.HasPartSize(testPartSize))
 
 	assert.True(t, partSet2.HasHeader(partSet.Header()))
 	for i := 0; i < int(partSet.Total()); i++ {
@@ -116,7 +120,8 @@ func TestPartValidateBasic(t *testing.T) {
 		expectErr    bool
 	}{
 		{"Good Part", func(pt *Part) {}, false},
-		{"Too big part", func(pt *Part) { pt.Bytes = make([]byte, BlockPartSizeBytes+1) }, true},
+		{"Big part", func(pt *Part) { pt.Bytes = make([]byte, BlockPartSizeBytes+1) }, false},
+		{"Too big part", func(pt *Part) { pt.Bytes = make([]byte, MaxBlockPartSizeBytes+1) }, true},
 		{"Too big proof", func(pt *Part) {
 			pt.Proof = merkle.Proof{
 				Total:    1,
@@ -250,10 +250,12 @@ func TestDataFromSquare(t *testing.T) {
 				tc.maxSize,
 			)
 
-			shares, _ := data.ComputeShares()
+			shares, shareCount := data.ComputeShares()
 			rawShares := shares.RawShares()
 
 			squareSize := uint64(math.Sqrt(float64(len(shares))))
+			assert.Equal(t, shareCount, data.ShareCount())
+			assert.Equal(t, squareSize, data.SquareSize())
 			eds, err := da.ExtendShares(squareSize, rawShares)
 			require.NoError(t, err)
 
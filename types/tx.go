@@ -80,6 +80,29 @@ func (txs Txs) Proof(i int) TxProof {
 	}
 }
 
+// MultiProof returns the merkle multiproof of the txs at the indices, in
+// increasing order.
+func (txs Txs) MultiProof(indices []int64) (TxMultiProof, error) {
+	bzs := make([][]byte, len(txs))
+	for i := range txs {
+		bzs[i] = txs[i].Hash()
+	}
+	root, proof, err := merkle.MultiProofFromByteSlices(bzs, indices)
+	if err != nil {
+		return TxMultiProof{}, err
+	}
+
+	data := make(Txs, len(indices))
+	for i, index := range indices {
+		data[i] = txs[index]
+	}
+	return TxMultiProof{
+		RootHash: root,
+		Data:     data,
+		Proof:    *proof,
+	}, nil
+}
+
 func (txs Txs) SplitIntoShares() NamespacedShares {
 	rawDatas := make([][]byte, len(txs))
 	for i, tx := range txs {
@@ -93,6 +116,23 @@ func (txs Txs) SplitIntoShares() NamespacedShares {
 	return shares
 }
 
+// ShareRange returns the range [start, end) of the shares of SplitIntoShares
+// the tx i is laid out in, and the offset of its length-delimited bytes in the
+// data of the first one. The tx shares being the first shares of the data
+// square, start and end are also its indexes in the square.
+// Panics if i < 0 or i >= len(txs).
+func (txs Txs) ShareRange(i int) (start, end, offset uint64) {
+	var pos uint64
+	for _, tx := range txs[:i] {
+		rawData, _ := tx.MarshalDelimited()
+		pos += uint64(len(rawData))
+	}
+	rawData, _ := txs[i].MarshalDelimited()
+	start, offset = pos/consts.TxShareSize, pos%consts.TxShareSize
+	end = (pos+uint64(len(rawData))-1)/consts.TxShareSize + 1
+	return start, end, offset
+}
+
 // TxProof represents a Merkle proof of the presence of a transaction in the Merkle tree.
 type TxProof struct {
 	RootHash tmbytes.HexBytes `json:"root_hash"`
@@ -124,6 +164,38 @@ func (tp TxProof) Validate(dataHash []byte) error {
 	return nil
 }
 
+// TxMultiProof represents a Merkle multiproof of the presence of several
+// transactions of a block in the Merkle tree, which is smaller than their
+// proofs taken one by one as they share their inner nodes.
+type TxMultiProof struct {
+	RootHash tmbytes.HexBytes  `json:"root_hash"`
+	Data     Txs               `json:"data"`
+	Proof    merkle.MultiProof `json:"proof"`
+}
+
+// Leaves returns the hashes of the txs, which are the leaves in the merkle
+// tree this multiproof refers to.
+func (tp TxMultiProof) Leaves() [][]byte {
+	leaves := make([][]byte, len(tp.Data))
+	for i, tx := range tp.Data {
+		leaves[i] = tx.Hash()
+	}
+	return leaves
+}
+
+// Validate verifies the multiproof. It returns nil if the RootHash matches the
+// dataHash argument, and if the multiproof is internally consistent.
+// Otherwise, it returns a sensible error.
+func (tp TxMultiProof) Validate(dataHash []byte) error {
+	if !bytes.Equal(dataHash, tp.RootHash) {
+		return errors.New("proof matches different data hash")
+	}
+	if err := tp.Proof.Verify(tp.RootHash, tp.Leaves()); err != nil {
+		return fmt.Errorf("proof is not internally consistent: %w", err)
+	}
+	return nil
+}
+
 func (tp TxProof) ToProto() tmproto.TxProof {
 
 	pbProof := tp.Proof.ToProto()
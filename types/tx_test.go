@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"math"
 	mrand "math/rand"
 	"testing"
 
@@ -10,6 +11,8 @@ import (
 
 	ctest "github.com/tendermint/tendermint/internal/libs/test"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/da"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
@@ -52,6 +55,54 @@ func TestTxIndexByHash(t *testing.T) {
 	}
 }
 
+func TestTxInclusionProof(t *testing.T) {
+	// txs of various sizes, some spanning several shares and rows
+	data := &Data{Txs: Txs{
+		tmrand.Bytes(10), tmrand.Bytes(500), tmrand.Bytes(60),
+		tmrand.Bytes(2000), tmrand.Bytes(240), tmrand.Bytes(1),
+	}}
+	shares, _ := data.ComputeShares()
+	squareSize := uint64(math.Sqrt(float64(len(shares))))
+	eds, err := da.ExtendShares(squareSize, shares.RawShares())
+	require.NoError(t, err)
+	dah := da.NewDataAvailabilityHeader(eds)
+	dataRoot := dah.Hash()
+
+	for i, tx := range data.Txs {
+		start, end, offset := data.Txs.ShareRange(i)
+		require.Less(t, offset, uint64(consts.TxShareSize))
+		proof, err := da.ProveTxInclusion(eds, start, end, offset)
+		require.NoError(t, err)
+		require.NoError(t, proof.Verify(dataRoot, tx), "tx %d", i)
+
+		// another tx, or another data root
+		require.Error(t, proof.Verify(dataRoot, data.Txs[(i+1)%len(data.Txs)]))
+		require.Error(t, proof.Verify(tmrand.Bytes(32), tx))
+	}
+
+	start, end, offset := data.Txs.ShareRange(3)
+	proof, err := da.ProveTxInclusion(eds, start, end, offset)
+	require.NoError(t, err)
+
+	// a tampered share
+	tampered := proof
+	tampered.Shares = append([][]byte{}, proof.Shares...)
+	tampered.Shares[0] = append([]byte{}, proof.Shares[0]...)
+	tampered.Shares[0][consts.ShareSize-1] ^= 0xFF
+	require.Error(t, tampered.Verify(dataRoot, data.Txs[3]))
+
+	// the shares moved
+	moved := proof
+	moved.Start++
+	require.Error(t, moved.Verify(dataRoot, data.Txs[3]))
+
+	// a share left out
+	truncated := proof
+	truncated.Shares = proof.Shares[:len(proof.Shares)-1]
+	truncated.ShareNodes = proof.ShareNodes[:len(proof.ShareNodes)-1]
+	require.Error(t, truncated.Verify(dataRoot, data.Txs[3]))
+}
+
 func TestValidTxProof(t *testing.T) {
 	cases := []struct {
 		txs Txs
@@ -99,6 +150,24 @@ func TestValidTxProof(t *testing.T) {
 	}
 }
 
+func TestValidTxMultiProof(t *testing.T) {
+	txs := makeTxs(61, 15)
+	root := txs.Hash()
+
+	proof, err := txs.MultiProof([]int64{3, 4, 40})
+	require.NoError(t, err)
+	assert.EqualValues(t, root, proof.RootHash)
+	assert.Equal(t, Txs{txs[3], txs[4], txs[40]}, proof.Data)
+	assert.NoError(t, proof.Validate(root))
+	assert.Error(t, proof.Validate([]byte("foobar")))
+
+	proof.Data[1] = txs[5]
+	assert.Error(t, proof.Validate(root))
+
+	_, err = txs.MultiProof([]int64{61})
+	assert.Error(t, err)
+}
+
 func TestTxProofUnchangable(t *testing.T) {
 	// run the other test a bunch...
 	for i := 0; i < 40; i++ {
@@ -12,7 +12,17 @@ import (
 const batchVerifyThreshold = 2
 
 func shouldBatchVerify(vals *ValidatorSet, commit *Commit) bool {
-	return len(commit.Signatures) >= batchVerifyThreshold && batch.SupportsBatchVerifier(vals.GetProposer().PubKey)
+	if len(commit.Signatures) < batchVerifyThreshold || !batch.SupportsBatchVerifier(vals.GetProposer().PubKey) {
+		return false
+	}
+	// a batch verifier only takes keys of its own type
+	keyType := vals.GetProposer().PubKey.Type()
+	for _, val := range vals.Validators {
+		if val.PubKey.Type() != keyType {
+			return false
+		}
+	}
+	return true
 }
 
 // VerifyCommit verifies +2/3 of the set had signed the given commit.
@@ -39,13 +49,20 @@ func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
 	// only count the signatures that are for the block
 	count := func(c CommitSig) bool { return c.ForBlock() }
 
-	// attempt to batch verify
+	// the aggregated signature is verified at once
+	if commit.IsAggregated() {
+		return verifyCommitAggregated(chainID, vals, commit, votingPowerNeeded,
+			ignore, count, true)
+	}
+
+	// attempt to batch verify, which falls back to single verification if it
+	// fails
 	if shouldBatchVerify(vals, commit) {
 		return verifyCommitBatch(chainID, vals, commit,
 			votingPowerNeeded, ignore, count, true, true)
 	}
 
-	// if verification failed or is not supported then fallback to single verification
+	// if batch verification is not supported then use single verification
 	return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
 		ignore, count, true, true)
 }
@@ -72,13 +89,20 @@ func VerifyCommitLight(chainID string, vals *ValidatorSet, blockID BlockID,
 	// count all the remaining signatures
 	count := func(c CommitSig) bool { return true }
 
-	// attempt to batch verify
+	// the aggregated signature is verified at once
+	if commit.IsAggregated() {
+		return verifyCommitAggregated(chainID, vals, commit, votingPowerNeeded,
+			ignore, count, true)
+	}
+
+	// attempt to batch verify, which falls back to single verification if it
+	// fails
 	if shouldBatchVerify(vals, commit) {
 		return verifyCommitBatch(chainID, vals, commit,
 			votingPowerNeeded, ignore, count, false, true)
 	}
 
-	// if verification failed or is not supported then fallback to single verification
+	// if batch verification is not supported then use single verification
 	return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
 		ignore, count, false, true)
 }
@@ -87,7 +111,9 @@ func VerifyCommitLight(chainID string, vals *ValidatorSet, blockID BlockID,
 // this commit.
 //
 // NOTE the given validators do not necessarily correspond to the validator set
-// for this commit, but there may be some intersection.
+// for this commit, but there may be some intersection. The aggregated
+// signature of an aggregated commit is only verified if all its signers are
+// in the given validators, see verifyCommitAggregated.
 //
 // This method is primarily used by the light client and does not check all the
 // signatures.
@@ -116,6 +142,13 @@ func VerifyCommitLightTrusting(chainID string, vals *ValidatorSet, commit *Commi
 	// count all the remaining signatures
 	count := func(c CommitSig) bool { return true }
 
+	// the aggregated signature is verified at once, if all its signers are
+	// in the validator set
+	if commit.IsAggregated() {
+		return verifyCommitAggregated(chainID, vals, commit, votingPowerNeeded,
+			ignore, count, false)
+	}
+
 	// attempt to batch verify commit. As the validator set doesn't necessarily
 	// correspond with the validator set that signed the block we need to look
 	// up by address rather than index.
@@ -145,7 +178,8 @@ func ValidateHash(h []byte) error {
 
 // verifyCommitBatch batch verifies commits.  This routine is equivalent
 // to verifyCommitSingle in behavior, just faster iff every signature in the
-// batch is valid.
+// batch is valid. If the batch fails, it falls back to verifyCommitSingle to
+// identify the invalid signature.
 //
 // Note: The caller is responsible for checking to see if this routine is
 // usable via `shouldVerifyBatch(vals, commit)`.
@@ -163,7 +197,6 @@ func verifyCommitBatch(
 		val                *Validator
 		valIdx             int32
 		seenVals                 = make(map[int32]int, len(commit.Signatures))
-		batchSigIdxs             = make([]int, 0, len(commit.Signatures))
 		talliedVotingPower int64 = 0
 	)
 	// attempt to create a batch verifier
@@ -205,11 +238,12 @@ func verifyCommitBatch(
 		// Validate signature.
 		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
 
-		// add the key, sig and message to the verifier
+		// add the key, sig and message to the verifier, a malformed signature
+		// is reported by single verification
 		if err := bv.Add(val.PubKey, voteSignBytes, commitSig.Signature); err != nil {
-			return err
+			return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
+				ignoreSig, countSig, countAllSignatures, lookUpByIndex)
 		}
-		batchSigIdxs = append(batchSigIdxs, idx)
 
 		// If this signature counts then add the voting power of the validator
 		// to the tally
@@ -231,28 +265,15 @@ func verifyCommitBatch(
 	}
 
 	// attempt to verify the batch.
-	ok, validSigs := bv.Verify()
-	if ok {
+	if ok, _ := bv.Verify(); ok {
 		// success
 		return nil
 	}
 
-	// one or more of the signatures is invalid, find and return the first
-	// invalid signature.
-	for i, ok := range validSigs {
-		if !ok {
-			// go back from the batch index to the commit.Signatures index
-			idx := batchSigIdxs[i]
-			sig := commit.Signatures[idx]
-			return fmt.Errorf("wrong signature (#%d): %X", idx, sig)
-		}
-	}
-
-	// execution reaching here is a bug, and one of the following has
-	// happened:
-	//  * non-zero tallied voting power, empty batch (impossible?)
-	//  * bv.Verify() returned `false, []bool{true, ..., true}` (BUG)
-	return fmt.Errorf("BUG: batch verification failed with no invalid signatures")
+	// one or more of the signatures is invalid, fall back to single
+	// verification to find and return the first invalid signature.
+	return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
+		ignoreSig, countSig, countAllSignatures, lookUpByIndex)
 }
 
 // Single Verification
@@ -8,6 +8,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
@@ -260,3 +264,83 @@ func TestValidatorSet_VerifyCommitLightTrustingErrorsOnOverflow(t *testing.T) {
 		assert.Contains(t, err.Error(), "int64 overflow")
 	}
 }
+
+func TestValidatorSet_VerifyCommit_KeyTypes(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	// a validator set mixing all the key types
+	privVals := make(map[string]PrivValidator)
+	vals := make([]*Validator, 0)
+	for _, privKey := range []crypto.PrivKey{
+		ed25519.GenPrivKey(), secp256k1.GenPrivKey(), bls.GenPrivKey(),
+		ed25519.GenPrivKey(), secp256k1.GenPrivKey(), bls.GenPrivKey(),
+	} {
+		privVals[privKey.PubKey().Address().String()] = NewMockPVWithParams(privKey, false, false)
+		vals = append(vals, NewValidator(privKey.PubKey(), 10))
+	}
+	valSet := NewValidatorSet(vals)
+	ordered := make([]PrivValidator, len(valSet.Validators))
+	for i, val := range valSet.Validators {
+		ordered[i] = privVals[val.Address.String()]
+	}
+
+	// the set hashes the same once through protobuf
+	pb, err := valSet.ToProto()
+	require.NoError(t, err)
+	fromProto, err := ValidatorSetFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, valSet.Hash(), fromProto.Hash())
+
+	voteSet := NewVoteSet(chainID, h, 0, tmproto.PrecommitType, valSet)
+	commit, err := makeCommit(blockID, h, 0, voteSet, ordered, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+	require.NoError(t, valSet.VerifyCommitLight(chainID, blockID, h, commit))
+}
+
+func TestValidatorSet_VerifyCommit_BatchFallback(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	for _, genPrivKey := range []func() crypto.PrivKey{
+		func() crypto.PrivKey { return ed25519.GenPrivKey() },
+		func() crypto.PrivKey { return secp256k1.GenPrivKey() },
+	} {
+		privVals := make(map[string]PrivValidator)
+		vals := make([]*Validator, 0)
+		for i := 0; i < 4; i++ {
+			privKey := genPrivKey()
+			privVals[privKey.PubKey().Address().String()] = NewMockPVWithParams(privKey, false, false)
+			vals = append(vals, NewValidator(privKey.PubKey(), 10))
+		}
+		valSet := NewValidatorSet(vals)
+		ordered := make([]PrivValidator, len(valSet.Validators))
+		for i, val := range valSet.Validators {
+			ordered[i] = privVals[val.Address.String()]
+		}
+		require.True(t, shouldBatchVerify(valSet, &Commit{Signatures: make([]CommitSig, 4)}))
+
+		voteSet := NewVoteSet(chainID, h, 0, tmproto.PrecommitType, valSet)
+		commit, err := makeCommit(blockID, h, 0, voteSet, ordered, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+
+		// the invalid signature is identified when the batch fails
+		commit.Signatures[1].Signature = commit.Signatures[0].Signature
+		err = valSet.VerifyCommit(chainID, blockID, h, commit)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "wrong signature (#1)")
+		}
+		err = valSet.VerifyCommitLight(chainID, blockID, h, commit)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "wrong signature (#1)")
+		}
+	}
+}
@@ -72,6 +72,10 @@ type VoteSet struct {
 	maj23         *BlockID               // First 2/3 majority seen
 	votesByBlock  map[string]*blockVotes // string(blockHash|blockParts) -> blockVotes
 	peerMaj23s    map[P2PID]BlockID      // Maj23 for each peer
+
+	// aggregatedSignature aggregates the signatures of the votes without a
+	// signature of their own, added from an aggregated commit.
+	aggregatedSignature []byte
 }
 
 // Constructs a new VoteSet struct used to accumulate votes for given height/round.
@@ -215,6 +219,21 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 	return added, nil
 }
 
+// addAggregatedVote adds a vote of an aggregated commit without a signature of
+// its own, the aggregated signature of the commit being verified.
+func (voteSet *VoteSet) addAggregatedVote(vote *Vote) {
+	voteSet.mtx.Lock()
+	defer voteSet.mtx.Unlock()
+
+	_, val := voteSet.valSet.GetByIndex(vote.ValidatorIndex)
+	if val == nil {
+		panic(fmt.Sprintf("Failed to reconstruct LastCommit: no validator %d", vote.ValidatorIndex))
+	}
+	if added, _ := voteSet.addVerifiedVote(vote, vote.BlockID.Key(), val.VotingPower); !added {
+		panic(fmt.Sprintf("Failed to reconstruct LastCommit: vote %v not added", vote))
+	}
+}
+
 // Returns (vote, true) if vote exists for valIndex and blockKey.
 func (voteSet *VoteSet) getVote(valIndex int32, blockKey string) (vote *Vote, ok bool) {
 	if existing := voteSet.votes[valIndex]; existing != nil && existing.BlockID.Key() == blockKey {
@@ -613,7 +632,9 @@ func (voteSet *VoteSet) MakeCommit() *Commit {
 		commitSigs[i] = commitSig
 	}
 
-	return NewCommit(voteSet.GetHeight(), voteSet.GetRound(), *voteSet.maj23, commitSigs)
+	commit := NewCommit(voteSet.GetHeight(), voteSet.GetRound(), *voteSet.maj23, commitSigs)
+	commit.AggregatedSignature = voteSet.aggregatedSignature
+	return commit
 }
 
 //--------------------------------------------------------------------------------